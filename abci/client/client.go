@@ -42,6 +42,9 @@ type Client interface {
 	OfferSnapshotAsync(types.RequestOfferSnapshot) *ReqRes
 	LoadSnapshotChunkAsync(types.RequestLoadSnapshotChunk) *ReqRes
 	ApplySnapshotChunkAsync(types.RequestApplySnapshotChunk) *ReqRes
+	CreateSnapshotAsync(types.RequestCreateSnapshot) *ReqRes
+	PruneSnapshotsAsync(types.RequestPruneSnapshots) *ReqRes
+	VerifyEvidenceAsync(types.RequestVerifyEvidence) *ReqRes
 
 	FlushSync() error
 	EchoSync(msg string) (*types.ResponseEcho, error)
@@ -58,6 +61,9 @@ type Client interface {
 	OfferSnapshotSync(types.RequestOfferSnapshot) (*types.ResponseOfferSnapshot, error)
 	LoadSnapshotChunkSync(types.RequestLoadSnapshotChunk) (*types.ResponseLoadSnapshotChunk, error)
 	ApplySnapshotChunkSync(types.RequestApplySnapshotChunk) (*types.ResponseApplySnapshotChunk, error)
+	CreateSnapshotSync(types.RequestCreateSnapshot) (*types.ResponseCreateSnapshot, error)
+	PruneSnapshotsSync(types.RequestPruneSnapshots) (*types.ResponsePruneSnapshots, error)
+	VerifyEvidenceSync(types.RequestVerifyEvidence) (*types.ResponseVerifyEvidence, error)
 	PrepareProposalSync(types.RequestPrepareProposal) (*types.ResponsePrepareProposal, error)
 	ProcessProposalSync(types.RequestProcessProposal) (*types.ResponseProcessProposal, error)
 }
@@ -109,7 +115,8 @@ func NewReqRes(req *types.Request) *ReqRes {
 
 // Sets sets the callback. If reqRes is already done, it will call the cb
 // immediately. Note, reqRes.cb should not change if reqRes.done and only one
-// callback is supported.
+// callback is supported. The cb is called with a nil response if the request
+// failed, e.g. because the connection to the application was lost.
 func (r *ReqRes) SetCallback(cb func(res *types.Response)) {
 	r.mtx.Lock()
 
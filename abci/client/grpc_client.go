@@ -301,6 +301,33 @@ func (cli *grpcClient) ApplySnapshotChunkAsync(params types.RequestApplySnapshot
 	return cli.finishAsyncCall(req, &types.Response{Value: &types.Response_ApplySnapshotChunk{ApplySnapshotChunk: res}})
 }
 
+func (cli *grpcClient) CreateSnapshotAsync(params types.RequestCreateSnapshot) *ReqRes {
+	req := types.ToRequestCreateSnapshot(params)
+	res, err := cli.client.CreateSnapshot(context.Background(), req.GetCreateSnapshot(), grpc.WaitForReady(true))
+	if err != nil {
+		cli.StopForError(err)
+	}
+	return cli.finishAsyncCall(req, &types.Response{Value: &types.Response_CreateSnapshot{CreateSnapshot: res}})
+}
+
+func (cli *grpcClient) PruneSnapshotsAsync(params types.RequestPruneSnapshots) *ReqRes {
+	req := types.ToRequestPruneSnapshots(params)
+	res, err := cli.client.PruneSnapshots(context.Background(), req.GetPruneSnapshots(), grpc.WaitForReady(true))
+	if err != nil {
+		cli.StopForError(err)
+	}
+	return cli.finishAsyncCall(req, &types.Response{Value: &types.Response_PruneSnapshots{PruneSnapshots: res}})
+}
+
+func (cli *grpcClient) VerifyEvidenceAsync(params types.RequestVerifyEvidence) *ReqRes {
+	req := types.ToRequestVerifyEvidence(params)
+	res, err := cli.client.VerifyEvidence(context.Background(), req.GetVerifyEvidence(), grpc.WaitForReady(true))
+	if err != nil {
+		cli.StopForError(err)
+	}
+	return cli.finishAsyncCall(req, &types.Response{Value: &types.Response_VerifyEvidence{VerifyEvidence: res}})
+}
+
 func (cli *grpcClient) PrepareProposalAsync(
 	params types.RequestPrepareProposal,
 ) *ReqRes {
@@ -458,6 +485,24 @@ func (cli *grpcClient) ApplySnapshotChunkSync(
 	return cli.finishSyncCall(reqres).GetApplySnapshotChunk(), cli.Error()
 }
 
+func (cli *grpcClient) CreateSnapshotSync(
+	params types.RequestCreateSnapshot) (*types.ResponseCreateSnapshot, error) {
+	reqres := cli.CreateSnapshotAsync(params)
+	return cli.finishSyncCall(reqres).GetCreateSnapshot(), cli.Error()
+}
+
+func (cli *grpcClient) PruneSnapshotsSync(
+	params types.RequestPruneSnapshots) (*types.ResponsePruneSnapshots, error) {
+	reqres := cli.PruneSnapshotsAsync(params)
+	return cli.finishSyncCall(reqres).GetPruneSnapshots(), cli.Error()
+}
+
+func (cli *grpcClient) VerifyEvidenceSync(
+	params types.RequestVerifyEvidence) (*types.ResponseVerifyEvidence, error) {
+	reqres := cli.VerifyEvidenceAsync(params)
+	return cli.finishSyncCall(reqres).GetVerifyEvidence(), cli.Error()
+}
+
 func (cli *grpcClient) PrepareProposalSync(
 	params types.RequestPrepareProposal,
 ) (*types.ResponsePrepareProposal, error) {
@@ -207,6 +207,39 @@ func (app *localClient) ApplySnapshotChunkAsync(req types.RequestApplySnapshotCh
 	)
 }
 
+func (app *localClient) CreateSnapshotAsync(req types.RequestCreateSnapshot) *ReqRes {
+	app.mtx.Lock()
+	defer app.mtx.Unlock()
+
+	res := app.Application.CreateSnapshot(req)
+	return app.callback(
+		types.ToRequestCreateSnapshot(req),
+		types.ToResponseCreateSnapshot(res),
+	)
+}
+
+func (app *localClient) PruneSnapshotsAsync(req types.RequestPruneSnapshots) *ReqRes {
+	app.mtx.Lock()
+	defer app.mtx.Unlock()
+
+	res := app.Application.PruneSnapshots(req)
+	return app.callback(
+		types.ToRequestPruneSnapshots(req),
+		types.ToResponsePruneSnapshots(res),
+	)
+}
+
+func (app *localClient) VerifyEvidenceAsync(req types.RequestVerifyEvidence) *ReqRes {
+	app.mtx.Lock()
+	defer app.mtx.Unlock()
+
+	res := app.Application.VerifyEvidence(req)
+	return app.callback(
+		types.ToRequestVerifyEvidence(req),
+		types.ToResponseVerifyEvidence(res),
+	)
+}
+
 func (app *localClient) PrepareProposalAsync(
 	req types.RequestPrepareProposal,
 ) *ReqRes {
@@ -349,6 +382,33 @@ func (app *localClient) ApplySnapshotChunkSync(
 	return &res, nil
 }
 
+func (app *localClient) CreateSnapshotSync(
+	req types.RequestCreateSnapshot) (*types.ResponseCreateSnapshot, error) {
+	app.mtx.Lock()
+	defer app.mtx.Unlock()
+
+	res := app.Application.CreateSnapshot(req)
+	return &res, nil
+}
+
+func (app *localClient) PruneSnapshotsSync(
+	req types.RequestPruneSnapshots) (*types.ResponsePruneSnapshots, error) {
+	app.mtx.Lock()
+	defer app.mtx.Unlock()
+
+	res := app.Application.PruneSnapshots(req)
+	return &res, nil
+}
+
+func (app *localClient) VerifyEvidenceSync(
+	req types.RequestVerifyEvidence) (*types.ResponseVerifyEvidence, error) {
+	app.mtx.Lock()
+	defer app.mtx.Unlock()
+
+	res := app.Application.VerifyEvidence(req)
+	return &res, nil
+}
+
 func (app *localClient) PrepareProposalSync(
 	req types.RequestPrepareProposal,
 ) (*types.ResponsePrepareProposal, error) {
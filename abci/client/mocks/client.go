@@ -172,6 +172,45 @@ func (_m *Client) CommitSync() (*types.ResponseCommit, error) {
 	return r0, r1
 }
 
+// CreateSnapshotAsync provides a mock function with given fields: _a0
+func (_m *Client) CreateSnapshotAsync(_a0 types.RequestCreateSnapshot) *abcicli.ReqRes {
+	ret := _m.Called(_a0)
+
+	var r0 *abcicli.ReqRes
+	if rf, ok := ret.Get(0).(func(types.RequestCreateSnapshot) *abcicli.ReqRes); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*abcicli.ReqRes)
+		}
+	}
+
+	return r0
+}
+
+// CreateSnapshotSync provides a mock function with given fields: _a0
+func (_m *Client) CreateSnapshotSync(_a0 types.RequestCreateSnapshot) (*types.ResponseCreateSnapshot, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *types.ResponseCreateSnapshot
+	if rf, ok := ret.Get(0).(func(types.RequestCreateSnapshot) *types.ResponseCreateSnapshot); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.ResponseCreateSnapshot)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(types.RequestCreateSnapshot) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DeliverTxAsync provides a mock function with given fields: _a0
 func (_m *Client) DeliverTxAsync(_a0 types.RequestDeliverTx) *abcicli.ReqRes {
 	ret := _m.Called(_a0)
@@ -621,6 +660,45 @@ func (_m *Client) ProcessProposalSync(_a0 types.RequestProcessProposal) (*types.
 	return r0, r1
 }
 
+// PruneSnapshotsAsync provides a mock function with given fields: _a0
+func (_m *Client) PruneSnapshotsAsync(_a0 types.RequestPruneSnapshots) *abcicli.ReqRes {
+	ret := _m.Called(_a0)
+
+	var r0 *abcicli.ReqRes
+	if rf, ok := ret.Get(0).(func(types.RequestPruneSnapshots) *abcicli.ReqRes); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*abcicli.ReqRes)
+		}
+	}
+
+	return r0
+}
+
+// PruneSnapshotsSync provides a mock function with given fields: _a0
+func (_m *Client) PruneSnapshotsSync(_a0 types.RequestPruneSnapshots) (*types.ResponsePruneSnapshots, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *types.ResponsePruneSnapshots
+	if rf, ok := ret.Get(0).(func(types.RequestPruneSnapshots) *types.ResponsePruneSnapshots); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.ResponsePruneSnapshots)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(types.RequestPruneSnapshots) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // QueryAsync provides a mock function with given fields: _a0
 func (_m *Client) QueryAsync(_a0 types.RequestQuery) *abcicli.ReqRes {
 	ret := _m.Called(_a0)
@@ -781,6 +859,45 @@ func (_m *Client) String() string {
 	return r0
 }
 
+// VerifyEvidenceAsync provides a mock function with given fields: _a0
+func (_m *Client) VerifyEvidenceAsync(_a0 types.RequestVerifyEvidence) *abcicli.ReqRes {
+	ret := _m.Called(_a0)
+
+	var r0 *abcicli.ReqRes
+	if rf, ok := ret.Get(0).(func(types.RequestVerifyEvidence) *abcicli.ReqRes); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*abcicli.ReqRes)
+		}
+	}
+
+	return r0
+}
+
+// VerifyEvidenceSync provides a mock function with given fields: _a0
+func (_m *Client) VerifyEvidenceSync(_a0 types.RequestVerifyEvidence) (*types.ResponseVerifyEvidence, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *types.ResponseVerifyEvidence
+	if rf, ok := ret.Get(0).(func(types.RequestVerifyEvidence) *types.ResponseVerifyEvidence); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.ResponseVerifyEvidence)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(types.RequestVerifyEvidence) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type mockConstructorTestingTNewClient interface {
 	mock.TestingT
 	Cleanup(func())
@@ -279,6 +279,18 @@ func (cli *socketClient) ApplySnapshotChunkAsync(req types.RequestApplySnapshotC
 	return cli.queueRequest(types.ToRequestApplySnapshotChunk(req))
 }
 
+func (cli *socketClient) CreateSnapshotAsync(req types.RequestCreateSnapshot) *ReqRes {
+	return cli.queueRequest(types.ToRequestCreateSnapshot(req))
+}
+
+func (cli *socketClient) PruneSnapshotsAsync(req types.RequestPruneSnapshots) *ReqRes {
+	return cli.queueRequest(types.ToRequestPruneSnapshots(req))
+}
+
+func (cli *socketClient) VerifyEvidenceAsync(req types.RequestVerifyEvidence) *ReqRes {
+	return cli.queueRequest(types.ToRequestVerifyEvidence(req))
+}
+
 func (cli *socketClient) PrepareProposalAsync(
 	req types.RequestPrepareProposal,
 ) *ReqRes {
@@ -427,6 +439,33 @@ func (cli *socketClient) ApplySnapshotChunkSync(
 	return reqres.Response.GetApplySnapshotChunk(), cli.Error()
 }
 
+func (cli *socketClient) CreateSnapshotSync(
+	req types.RequestCreateSnapshot) (*types.ResponseCreateSnapshot, error) {
+	reqres := cli.queueRequest(types.ToRequestCreateSnapshot(req))
+	if err := cli.FlushSync(); err != nil {
+		return nil, err
+	}
+	return reqres.Response.GetCreateSnapshot(), cli.Error()
+}
+
+func (cli *socketClient) PruneSnapshotsSync(
+	req types.RequestPruneSnapshots) (*types.ResponsePruneSnapshots, error) {
+	reqres := cli.queueRequest(types.ToRequestPruneSnapshots(req))
+	if err := cli.FlushSync(); err != nil {
+		return nil, err
+	}
+	return reqres.Response.GetPruneSnapshots(), cli.Error()
+}
+
+func (cli *socketClient) VerifyEvidenceSync(
+	req types.RequestVerifyEvidence) (*types.ResponseVerifyEvidence, error) {
+	reqres := cli.queueRequest(types.ToRequestVerifyEvidence(req))
+	if err := cli.FlushSync(); err != nil {
+		return nil, err
+	}
+	return reqres.Response.GetVerifyEvidence(), cli.Error()
+}
+
 func (cli *socketClient) PrepareProposalSync(
 	req types.RequestPrepareProposal,
 ) (*types.ResponsePrepareProposal, error) {
@@ -470,12 +509,13 @@ func (cli *socketClient) queueRequest(req *types.Request) *ReqRes {
 
 func (cli *socketClient) flushQueue() {
 	cli.mtx.Lock()
-	defer cli.mtx.Unlock()
+	var flushed []*ReqRes
 
 	// mark all in-flight messages as resolved (they will get cli.Error())
 	for req := cli.reqSent.Front(); req != nil; req = req.Next() {
 		reqres := req.Value.(*ReqRes)
 		reqres.Done()
+		flushed = append(flushed, reqres)
 	}
 
 	// mark all queued messages as resolved
@@ -484,10 +524,19 @@ LOOP:
 		select {
 		case reqres := <-cli.reqQueue:
 			reqres.Done()
+			flushed = append(flushed, reqres)
 		default:
 			break LOOP
 		}
 	}
+	cli.mtx.Unlock()
+
+	// invoke their callbacks, with no response, so that the callers waiting on
+	// them are released too. The lock is not held, as the callbacks may query
+	// the client.
+	for _, reqres := range flushed {
+		reqres.InvokeCallback()
+	}
 }
 
 //----------------------------------------
@@ -528,6 +577,12 @@ func resMatchesReq(req *types.Request, res *types.Response) (ok bool) {
 		_, ok = res.Value.(*types.Response_PrepareProposal)
 	case *types.Request_ProcessProposal:
 		_, ok = res.Value.(*types.Response_ProcessProposal)
+	case *types.Request_CreateSnapshot:
+		_, ok = res.Value.(*types.Response_CreateSnapshot)
+	case *types.Request_PruneSnapshots:
+		_, ok = res.Value.(*types.Response_PruneSnapshots)
+	case *types.Request_VerifyEvidence:
+		_, ok = res.Value.(*types.Response_VerifyEvidence)
 	}
 	return ok
 }
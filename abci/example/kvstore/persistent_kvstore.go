@@ -173,6 +173,21 @@ func (app *PersistentKVStoreApplication) ApplySnapshotChunk(
 	return types.ResponseApplySnapshotChunk{Result: types.ResponseApplySnapshotChunk_ABORT}
 }
 
+func (app *PersistentKVStoreApplication) CreateSnapshot(
+	req types.RequestCreateSnapshot) types.ResponseCreateSnapshot {
+	return types.ResponseCreateSnapshot{}
+}
+
+func (app *PersistentKVStoreApplication) PruneSnapshots(
+	req types.RequestPruneSnapshots) types.ResponsePruneSnapshots {
+	return types.ResponsePruneSnapshots{}
+}
+
+func (app *PersistentKVStoreApplication) VerifyEvidence(
+	req types.RequestVerifyEvidence) types.ResponseVerifyEvidence {
+	return types.ResponseVerifyEvidence{Result: types.ResponseVerifyEvidence_ACCEPT}
+}
+
 func (app *PersistentKVStoreApplication) PrepareProposal(
 	req types.RequestPrepareProposal) types.ResponsePrepareProposal {
 	return app.app.PrepareProposal(req)
@@ -242,6 +242,15 @@ func (s *SocketServer) handleRequest(req *types.Request, responses chan<- *types
 	case *types.Request_ApplySnapshotChunk:
 		res := s.app.ApplySnapshotChunk(*r.ApplySnapshotChunk)
 		responses <- types.ToResponseApplySnapshotChunk(res)
+	case *types.Request_CreateSnapshot:
+		res := s.app.CreateSnapshot(*r.CreateSnapshot)
+		responses <- types.ToResponseCreateSnapshot(res)
+	case *types.Request_PruneSnapshots:
+		res := s.app.PruneSnapshots(*r.PruneSnapshots)
+		responses <- types.ToResponsePruneSnapshots(res)
+	case *types.Request_VerifyEvidence:
+		res := s.app.VerifyEvidence(*r.VerifyEvidence)
+		responses <- types.ToResponseVerifyEvidence(res)
 	default:
 		responses <- types.ToResponseException("Unknown request")
 	}
@@ -31,6 +31,36 @@ type Application interface {
 	OfferSnapshot(RequestOfferSnapshot) ResponseOfferSnapshot                // Offer a snapshot to the application
 	LoadSnapshotChunk(RequestLoadSnapshotChunk) ResponseLoadSnapshotChunk    // Load a snapshot chunk
 	ApplySnapshotChunk(RequestApplySnapshotChunk) ResponseApplySnapshotChunk // Apply a shapshot chunk
+
+	// Snapshot Connection, only used if the application advertises
+	// CapabilitySnapshotOrchestration
+	CreateSnapshot(RequestCreateSnapshot) ResponseCreateSnapshot // Create the snapshots of a committed height
+	PruneSnapshots(RequestPruneSnapshots) ResponsePruneSnapshots // Delete the snapshots taken at some heights
+
+	// Evidence veto on the Info/Query Connection, only used if the application
+	// advertises CapabilityVerifyEvidence
+	VerifyEvidence(RequestVerifyEvidence) ResponseVerifyEvidence // Whether to propose and gossip evidence
+}
+
+// CapabilitySnapshotOrchestration is advertised in ResponseInfo.Capabilities
+// by the applications implementing CreateSnapshot and PruneSnapshots, so that
+// the node decides when to create snapshots and which ones to keep.
+const CapabilitySnapshotOrchestration = "snapshot_orchestration"
+
+// CapabilityVerifyEvidence is advertised in ResponseInfo.Capabilities by the
+// applications implementing VerifyEvidence, so that the evidence pool asks
+// them before proposing or gossiping a piece of evidence. It does not affect
+// the validation of the evidence of blocks.
+const CapabilityVerifyEvidence = "verify_evidence"
+
+// HasCapability returns true if the application advertises capability.
+func (r ResponseInfo) HasCapability(capability string) bool {
+	for _, c := range r.Capabilities {
+		if c == capability {
+			return true
+		}
+	}
+	return false
 }
 
 //-------------------------------------------------------
@@ -97,6 +127,18 @@ func (BaseApplication) ApplySnapshotChunk(req RequestApplySnapshotChunk) Respons
 	return ResponseApplySnapshotChunk{}
 }
 
+func (BaseApplication) CreateSnapshot(req RequestCreateSnapshot) ResponseCreateSnapshot {
+	return ResponseCreateSnapshot{}
+}
+
+func (BaseApplication) PruneSnapshots(req RequestPruneSnapshots) ResponsePruneSnapshots {
+	return ResponsePruneSnapshots{}
+}
+
+func (BaseApplication) VerifyEvidence(req RequestVerifyEvidence) ResponseVerifyEvidence {
+	return ResponseVerifyEvidence{Result: ResponseVerifyEvidence_ACCEPT}
+}
+
 func (BaseApplication) PrepareProposal(req RequestPrepareProposal) ResponsePrepareProposal {
 	return ResponsePrepareProposal{BlockData: req.BlockData}
 }
@@ -193,6 +235,24 @@ func (app *GRPCApplication) ApplySnapshotChunk(
 	return &res, nil
 }
 
+func (app *GRPCApplication) CreateSnapshot(
+	ctx context.Context, req *RequestCreateSnapshot) (*ResponseCreateSnapshot, error) {
+	res := app.app.CreateSnapshot(*req)
+	return &res, nil
+}
+
+func (app *GRPCApplication) PruneSnapshots(
+	ctx context.Context, req *RequestPruneSnapshots) (*ResponsePruneSnapshots, error) {
+	res := app.app.PruneSnapshots(*req)
+	return &res, nil
+}
+
+func (app *GRPCApplication) VerifyEvidence(
+	ctx context.Context, req *RequestVerifyEvidence) (*ResponseVerifyEvidence, error) {
+	res := app.app.VerifyEvidence(*req)
+	return &res, nil
+}
+
 func (app *GRPCApplication) PrepareProposal(
 	ctx context.Context, req *RequestPrepareProposal) (*ResponsePrepareProposal, error) {
 	res := app.app.PrepareProposal(*req)
@@ -159,6 +159,24 @@ func ToRequestApplySnapshotChunk(req RequestApplySnapshotChunk) *Request {
 	}
 }
 
+func ToRequestCreateSnapshot(req RequestCreateSnapshot) *Request {
+	return &Request{
+		Value: &Request_CreateSnapshot{&req},
+	}
+}
+
+func ToRequestPruneSnapshots(req RequestPruneSnapshots) *Request {
+	return &Request{
+		Value: &Request_PruneSnapshots{&req},
+	}
+}
+
+func ToRequestVerifyEvidence(req RequestVerifyEvidence) *Request {
+	return &Request{
+		Value: &Request_VerifyEvidence{&req},
+	}
+}
+
 func ToRequestPrepareProposal(req RequestPrepareProposal) *Request {
 	return &Request{
 		Value: &Request_PrepareProposal{&req},
@@ -269,6 +287,24 @@ func ToResponseApplySnapshotChunk(res ResponseApplySnapshotChunk) *Response {
 	}
 }
 
+func ToResponseCreateSnapshot(res ResponseCreateSnapshot) *Response {
+	return &Response{
+		Value: &Response_CreateSnapshot{&res},
+	}
+}
+
+func ToResponsePruneSnapshots(res ResponsePruneSnapshots) *Response {
+	return &Response{
+		Value: &Response_PruneSnapshots{&res},
+	}
+}
+
+func ToResponseVerifyEvidence(res ResponseVerifyEvidence) *Response {
+	return &Response{
+		Value: &Response_VerifyEvidence{&res},
+	}
+}
+
 func ToResponsePrepareProposal(res ResponsePrepareProposal) *Response {
 	return &Response{
 		Value: &Response_PrepareProposal{&res},
@@ -10,6 +10,7 @@ import (
 	proto "github.com/gogo/protobuf/proto"
 	_ "github.com/gogo/protobuf/types"
 	github_com_gogo_protobuf_types "github.com/gogo/protobuf/types"
+	_ "github.com/golang/protobuf/ptypes/duration"
 	crypto "github.com/tendermint/tendermint/proto/tendermint/crypto"
 	types1 "github.com/tendermint/tendermint/proto/tendermint/types"
 	grpc "google.golang.org/grpc"
@@ -120,7 +121,7 @@ func (x ResponseOfferSnapshot_Result) String() string {
 }
 
 func (ResponseOfferSnapshot_Result) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{32, 0}
+	return fileDescriptor_252557cfdd89a31a, []int{33, 0}
 }
 
 type ResponseApplySnapshotChunk_Result int32
@@ -157,7 +158,7 @@ func (x ResponseApplySnapshotChunk_Result) String() string {
 }
 
 func (ResponseApplySnapshotChunk_Result) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{34, 0}
+	return fileDescriptor_252557cfdd89a31a, []int{35, 0}
 }
 
 type ResponseProcessProposal_Result int32
@@ -185,7 +186,38 @@ func (x ResponseProcessProposal_Result) String() string {
 }
 
 func (ResponseProcessProposal_Result) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{36, 0}
+	return fileDescriptor_252557cfdd89a31a, []int{37, 0}
+}
+
+type ResponseVerifyEvidence_Result int32
+
+const (
+	ResponseVerifyEvidence_UNKNOWN ResponseVerifyEvidence_Result = 0
+	ResponseVerifyEvidence_ACCEPT  ResponseVerifyEvidence_Result = 1
+	ResponseVerifyEvidence_SKIP    ResponseVerifyEvidence_Result = 2
+	ResponseVerifyEvidence_REJECT  ResponseVerifyEvidence_Result = 3
+)
+
+var ResponseVerifyEvidence_Result_name = map[int32]string{
+	0: "UNKNOWN",
+	1: "ACCEPT",
+	2: "SKIP",
+	3: "REJECT",
+}
+
+var ResponseVerifyEvidence_Result_value = map[string]int32{
+	"UNKNOWN": 0,
+	"ACCEPT":  1,
+	"SKIP":    2,
+	"REJECT":  3,
+}
+
+func (x ResponseVerifyEvidence_Result) String() string {
+	return proto.EnumName(ResponseVerifyEvidence_Result_name, int32(x))
+}
+
+func (ResponseVerifyEvidence_Result) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_252557cfdd89a31a, []int{54, 0}
 }
 
 type Request struct {
@@ -207,6 +239,9 @@ type Request struct {
 	//	*Request_ApplySnapshotChunk
 	//	*Request_PrepareProposal
 	//	*Request_ProcessProposal
+	//	*Request_CreateSnapshot
+	//	*Request_PruneSnapshots
+	//	*Request_VerifyEvidence
 	Value isRequest_Value `protobuf_oneof:"value"`
 }
 
@@ -300,6 +335,15 @@ type Request_PrepareProposal struct {
 type Request_ProcessProposal struct {
 	ProcessProposal *RequestProcessProposal `protobuf:"bytes,17,opt,name=process_proposal,json=processProposal,proto3,oneof" json:"process_proposal,omitempty"`
 }
+type Request_CreateSnapshot struct {
+	CreateSnapshot *RequestCreateSnapshot `protobuf:"bytes,18,opt,name=create_snapshot,json=createSnapshot,proto3,oneof" json:"create_snapshot,omitempty"`
+}
+type Request_PruneSnapshots struct {
+	PruneSnapshots *RequestPruneSnapshots `protobuf:"bytes,19,opt,name=prune_snapshots,json=pruneSnapshots,proto3,oneof" json:"prune_snapshots,omitempty"`
+}
+type Request_VerifyEvidence struct {
+	VerifyEvidence *RequestVerifyEvidence `protobuf:"bytes,20,opt,name=verify_evidence,json=verifyEvidence,proto3,oneof" json:"verify_evidence,omitempty"`
+}
 
 func (*Request_Echo) isRequest_Value()               {}
 func (*Request_Flush) isRequest_Value()              {}
@@ -318,6 +362,9 @@ func (*Request_LoadSnapshotChunk) isRequest_Value()  {}
 func (*Request_ApplySnapshotChunk) isRequest_Value() {}
 func (*Request_PrepareProposal) isRequest_Value()    {}
 func (*Request_ProcessProposal) isRequest_Value()    {}
+func (*Request_CreateSnapshot) isRequest_Value()     {}
+func (*Request_PruneSnapshots) isRequest_Value()     {}
+func (*Request_VerifyEvidence) isRequest_Value()     {}
 
 func (m *Request) GetValue() isRequest_Value {
 	if m != nil {
@@ -445,6 +492,27 @@ func (m *Request) GetProcessProposal() *RequestProcessProposal {
 	return nil
 }
 
+func (m *Request) GetCreateSnapshot() *RequestCreateSnapshot {
+	if x, ok := m.GetValue().(*Request_CreateSnapshot); ok {
+		return x.CreateSnapshot
+	}
+	return nil
+}
+
+func (m *Request) GetPruneSnapshots() *RequestPruneSnapshots {
+	if x, ok := m.GetValue().(*Request_PruneSnapshots); ok {
+		return x.PruneSnapshots
+	}
+	return nil
+}
+
+func (m *Request) GetVerifyEvidence() *RequestVerifyEvidence {
+	if x, ok := m.GetValue().(*Request_VerifyEvidence); ok {
+		return x.VerifyEvidence
+	}
+	return nil
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*Request) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
@@ -465,6 +533,9 @@ func (*Request) XXX_OneofWrappers() []interface{} {
 		(*Request_ApplySnapshotChunk)(nil),
 		(*Request_PrepareProposal)(nil),
 		(*Request_ProcessProposal)(nil),
+		(*Request_CreateSnapshot)(nil),
+		(*Request_PruneSnapshots)(nil),
+		(*Request_VerifyEvidence)(nil),
 	}
 }
 
@@ -1408,7 +1479,6 @@ func (m *RequestProcessProposal) GetBlockData() *types1.Data {
 
 type Response struct {
 	// Types that are valid to be assigned to Value:
-	//
 	//	*Response_Exception
 	//	*Response_Echo
 	//	*Response_Flush
@@ -1427,6 +1497,9 @@ type Response struct {
 	//	*Response_ApplySnapshotChunk
 	//	*Response_PrepareProposal
 	//	*Response_ProcessProposal
+	//	*Response_CreateSnapshot
+	//	*Response_PruneSnapshots
+	//	*Response_VerifyEvidence
 	Value isResponse_Value `protobuf_oneof:"value"`
 }
 
@@ -1523,6 +1596,15 @@ type Response_PrepareProposal struct {
 type Response_ProcessProposal struct {
 	ProcessProposal *ResponseProcessProposal `protobuf:"bytes,18,opt,name=process_proposal,json=processProposal,proto3,oneof" json:"process_proposal,omitempty"`
 }
+type Response_CreateSnapshot struct {
+	CreateSnapshot *ResponseCreateSnapshot `protobuf:"bytes,19,opt,name=create_snapshot,json=createSnapshot,proto3,oneof" json:"create_snapshot,omitempty"`
+}
+type Response_PruneSnapshots struct {
+	PruneSnapshots *ResponsePruneSnapshots `protobuf:"bytes,20,opt,name=prune_snapshots,json=pruneSnapshots,proto3,oneof" json:"prune_snapshots,omitempty"`
+}
+type Response_VerifyEvidence struct {
+	VerifyEvidence *ResponseVerifyEvidence `protobuf:"bytes,21,opt,name=verify_evidence,json=verifyEvidence,proto3,oneof" json:"verify_evidence,omitempty"`
+}
 
 func (*Response_Exception) isResponse_Value()          {}
 func (*Response_Echo) isResponse_Value()               {}
@@ -1542,6 +1624,9 @@ func (*Response_LoadSnapshotChunk) isResponse_Value()  {}
 func (*Response_ApplySnapshotChunk) isResponse_Value() {}
 func (*Response_PrepareProposal) isResponse_Value()    {}
 func (*Response_ProcessProposal) isResponse_Value()    {}
+func (*Response_CreateSnapshot) isResponse_Value()     {}
+func (*Response_PruneSnapshots) isResponse_Value()     {}
+func (*Response_VerifyEvidence) isResponse_Value()     {}
 
 func (m *Response) GetValue() isResponse_Value {
 	if m != nil {
@@ -1676,6 +1761,27 @@ func (m *Response) GetProcessProposal() *ResponseProcessProposal {
 	return nil
 }
 
+func (m *Response) GetCreateSnapshot() *ResponseCreateSnapshot {
+	if x, ok := m.GetValue().(*Response_CreateSnapshot); ok {
+		return x.CreateSnapshot
+	}
+	return nil
+}
+
+func (m *Response) GetPruneSnapshots() *ResponsePruneSnapshots {
+	if x, ok := m.GetValue().(*Response_PruneSnapshots); ok {
+		return x.PruneSnapshots
+	}
+	return nil
+}
+
+func (m *Response) GetVerifyEvidence() *ResponseVerifyEvidence {
+	if x, ok := m.GetValue().(*Response_VerifyEvidence); ok {
+		return x.VerifyEvidence
+	}
+	return nil
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*Response) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
@@ -1697,6 +1803,9 @@ func (*Response) XXX_OneofWrappers() []interface{} {
 		(*Response_ApplySnapshotChunk)(nil),
 		(*Response_PrepareProposal)(nil),
 		(*Response_ProcessProposal)(nil),
+		(*Response_CreateSnapshot)(nil),
+		(*Response_PruneSnapshots)(nil),
+		(*Response_VerifyEvidence)(nil),
 	}
 }
 
@@ -1831,6 +1940,15 @@ type ResponseInfo struct {
 	AppVersion       uint64 `protobuf:"varint,3,opt,name=app_version,json=appVersion,proto3" json:"app_version,omitempty"`
 	LastBlockHeight  int64  `protobuf:"varint,4,opt,name=last_block_height,json=lastBlockHeight,proto3" json:"last_block_height,omitempty"`
 	LastBlockAppHash []byte `protobuf:"bytes,5,opt,name=last_block_app_hash,json=lastBlockAppHash,proto3" json:"last_block_app_hash,omitempty"`
+	// The optional ABCI methods implemented by the application, see
+	// the Capability constants.
+	Capabilities []string `protobuf:"bytes,6,rep,name=capabilities,proto3" json:"capabilities,omitempty"`
+	// The requirements CheckTx currently enforces to admit a transaction in
+	// the mempool, if the application reports them.
+	MempoolRequirements *MempoolRequirements `protobuf:"bytes,7,opt,name=mempool_requirements,json=mempoolRequirements,proto3" json:"mempool_requirements,omitempty"`
+	// The evidence constraints the application declared in InitChain, for the
+	// nodes joining the chain by state sync, which do not run InitChain.
+	EvidenceConstraints *EvidenceConstraints `protobuf:"bytes,8,opt,name=evidence_constraints,json=evidenceConstraints,proto3" json:"evidence_constraints,omitempty"`
 }
 
 func (m *ResponseInfo) Reset()         { *m = ResponseInfo{} }
@@ -1901,6 +2019,85 @@ func (m *ResponseInfo) GetLastBlockAppHash() []byte {
 	return nil
 }
 
+func (m *ResponseInfo) GetCapabilities() []string {
+	if m != nil {
+		return m.Capabilities
+	}
+	return nil
+}
+
+func (m *ResponseInfo) GetMempoolRequirements() *MempoolRequirements {
+	if m != nil {
+		return m.MempoolRequirements
+	}
+	return nil
+}
+
+func (m *ResponseInfo) GetEvidenceConstraints() *EvidenceConstraints {
+	if m != nil {
+		return m.EvidenceConstraints
+	}
+	return nil
+}
+
+// MempoolRequirements are the requirements CheckTx enforces to admit a
+// transaction in the mempool, advertised to the clients so that they can
+// submit transactions the node accepts.
+type MempoolRequirements struct {
+	// The minimum price of a unit of gas, a decimal number followed by its
+	// denomination, e.g. "0.002utia". Empty if there is none.
+	MinGasPrice string `protobuf:"bytes,1,opt,name=min_gas_price,json=minGasPrice,proto3" json:"min_gas_price,omitempty"`
+	// The maximum size of a transaction in bytes, 0 if there is none.
+	MaxTxBytes int64 `protobuf:"varint,2,opt,name=max_tx_bytes,json=maxTxBytes,proto3" json:"max_tx_bytes,omitempty"`
+}
+
+func (m *MempoolRequirements) Reset()         { *m = MempoolRequirements{} }
+func (m *MempoolRequirements) String() string { return proto.CompactTextString(m) }
+func (*MempoolRequirements) ProtoMessage()    {}
+func (*MempoolRequirements) Descriptor() ([]byte, []int) {
+	return fileDescriptor_252557cfdd89a31a, []int{23}
+}
+func (m *MempoolRequirements) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *MempoolRequirements) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_MempoolRequirements.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *MempoolRequirements) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MempoolRequirements.Merge(m, src)
+}
+func (m *MempoolRequirements) XXX_Size() int {
+	return m.Size()
+}
+func (m *MempoolRequirements) XXX_DiscardUnknown() {
+	xxx_messageInfo_MempoolRequirements.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MempoolRequirements proto.InternalMessageInfo
+
+func (m *MempoolRequirements) GetMinGasPrice() string {
+	if m != nil {
+		return m.MinGasPrice
+	}
+	return ""
+}
+
+func (m *MempoolRequirements) GetMaxTxBytes() int64 {
+	if m != nil {
+		return m.MaxTxBytes
+	}
+	return 0
+}
+
 // nondeterministic
 type ResponseSetOption struct {
 	Code uint32 `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
@@ -1913,7 +2110,7 @@ func (m *ResponseSetOption) Reset()         { *m = ResponseSetOption{} }
 func (m *ResponseSetOption) String() string { return proto.CompactTextString(m) }
 func (*ResponseSetOption) ProtoMessage()    {}
 func (*ResponseSetOption) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{23}
+	return fileDescriptor_252557cfdd89a31a, []int{24}
 }
 func (m *ResponseSetOption) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1964,16 +2161,17 @@ func (m *ResponseSetOption) GetInfo() string {
 }
 
 type ResponseInitChain struct {
-	ConsensusParams *ConsensusParams  `protobuf:"bytes,1,opt,name=consensus_params,json=consensusParams,proto3" json:"consensus_params,omitempty"`
-	Validators      []ValidatorUpdate `protobuf:"bytes,2,rep,name=validators,proto3" json:"validators"`
-	AppHash         []byte            `protobuf:"bytes,3,opt,name=app_hash,json=appHash,proto3" json:"app_hash,omitempty"`
+	ConsensusParams     *ConsensusParams     `protobuf:"bytes,1,opt,name=consensus_params,json=consensusParams,proto3" json:"consensus_params,omitempty"`
+	Validators          []ValidatorUpdate    `protobuf:"bytes,2,rep,name=validators,proto3" json:"validators"`
+	AppHash             []byte               `protobuf:"bytes,3,opt,name=app_hash,json=appHash,proto3" json:"app_hash,omitempty"`
+	EvidenceConstraints *EvidenceConstraints `protobuf:"bytes,4,opt,name=evidence_constraints,json=evidenceConstraints,proto3" json:"evidence_constraints,omitempty"`
 }
 
 func (m *ResponseInitChain) Reset()         { *m = ResponseInitChain{} }
 func (m *ResponseInitChain) String() string { return proto.CompactTextString(m) }
 func (*ResponseInitChain) ProtoMessage()    {}
 func (*ResponseInitChain) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{24}
+	return fileDescriptor_252557cfdd89a31a, []int{25}
 }
 func (m *ResponseInitChain) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2023,6 +2221,13 @@ func (m *ResponseInitChain) GetAppHash() []byte {
 	return nil
 }
 
+func (m *ResponseInitChain) GetEvidenceConstraints() *EvidenceConstraints {
+	if m != nil {
+		return m.EvidenceConstraints
+	}
+	return nil
+}
+
 type ResponseQuery struct {
 	Code uint32 `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
 	// bytes data = 2; // use "value" instead.
@@ -2040,7 +2245,7 @@ func (m *ResponseQuery) Reset()         { *m = ResponseQuery{} }
 func (m *ResponseQuery) String() string { return proto.CompactTextString(m) }
 func (*ResponseQuery) ProtoMessage()    {}
 func (*ResponseQuery) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{25}
+	return fileDescriptor_252557cfdd89a31a, []int{26}
 }
 func (m *ResponseQuery) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2140,7 +2345,7 @@ func (m *ResponseBeginBlock) Reset()         { *m = ResponseBeginBlock{} }
 func (m *ResponseBeginBlock) String() string { return proto.CompactTextString(m) }
 func (*ResponseBeginBlock) ProtoMessage()    {}
 func (*ResponseBeginBlock) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{26}
+	return fileDescriptor_252557cfdd89a31a, []int{27}
 }
 func (m *ResponseBeginBlock) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2196,7 +2401,7 @@ func (m *ResponseCheckTx) Reset()         { *m = ResponseCheckTx{} }
 func (m *ResponseCheckTx) String() string { return proto.CompactTextString(m) }
 func (*ResponseCheckTx) ProtoMessage()    {}
 func (*ResponseCheckTx) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{27}
+	return fileDescriptor_252557cfdd89a31a, []int{28}
 }
 func (m *ResponseCheckTx) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2317,7 +2522,7 @@ func (m *ResponseDeliverTx) Reset()         { *m = ResponseDeliverTx{} }
 func (m *ResponseDeliverTx) String() string { return proto.CompactTextString(m) }
 func (*ResponseDeliverTx) ProtoMessage()    {}
 func (*ResponseDeliverTx) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{28}
+	return fileDescriptor_252557cfdd89a31a, []int{29}
 }
 func (m *ResponseDeliverTx) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2412,7 +2617,7 @@ func (m *ResponseEndBlock) Reset()         { *m = ResponseEndBlock{} }
 func (m *ResponseEndBlock) String() string { return proto.CompactTextString(m) }
 func (*ResponseEndBlock) ProtoMessage()    {}
 func (*ResponseEndBlock) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{29}
+	return fileDescriptor_252557cfdd89a31a, []int{30}
 }
 func (m *ResponseEndBlock) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2472,7 +2677,7 @@ func (m *ResponseCommit) Reset()         { *m = ResponseCommit{} }
 func (m *ResponseCommit) String() string { return proto.CompactTextString(m) }
 func (*ResponseCommit) ProtoMessage()    {}
 func (*ResponseCommit) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{30}
+	return fileDescriptor_252557cfdd89a31a, []int{31}
 }
 func (m *ResponseCommit) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2523,7 +2728,7 @@ func (m *ResponseListSnapshots) Reset()         { *m = ResponseListSnapshots{} }
 func (m *ResponseListSnapshots) String() string { return proto.CompactTextString(m) }
 func (*ResponseListSnapshots) ProtoMessage()    {}
 func (*ResponseListSnapshots) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{31}
+	return fileDescriptor_252557cfdd89a31a, []int{32}
 }
 func (m *ResponseListSnapshots) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2567,7 +2772,7 @@ func (m *ResponseOfferSnapshot) Reset()         { *m = ResponseOfferSnapshot{} }
 func (m *ResponseOfferSnapshot) String() string { return proto.CompactTextString(m) }
 func (*ResponseOfferSnapshot) ProtoMessage()    {}
 func (*ResponseOfferSnapshot) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{32}
+	return fileDescriptor_252557cfdd89a31a, []int{33}
 }
 func (m *ResponseOfferSnapshot) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2611,7 +2816,7 @@ func (m *ResponseLoadSnapshotChunk) Reset()         { *m = ResponseLoadSnapshotC
 func (m *ResponseLoadSnapshotChunk) String() string { return proto.CompactTextString(m) }
 func (*ResponseLoadSnapshotChunk) ProtoMessage()    {}
 func (*ResponseLoadSnapshotChunk) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{33}
+	return fileDescriptor_252557cfdd89a31a, []int{34}
 }
 func (m *ResponseLoadSnapshotChunk) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2657,7 +2862,7 @@ func (m *ResponseApplySnapshotChunk) Reset()         { *m = ResponseApplySnapsho
 func (m *ResponseApplySnapshotChunk) String() string { return proto.CompactTextString(m) }
 func (*ResponseApplySnapshotChunk) ProtoMessage()    {}
 func (*ResponseApplySnapshotChunk) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{34}
+	return fileDescriptor_252557cfdd89a31a, []int{35}
 }
 func (m *ResponseApplySnapshotChunk) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2715,7 +2920,7 @@ func (m *ResponsePrepareProposal) Reset()         { *m = ResponsePrepareProposal
 func (m *ResponsePrepareProposal) String() string { return proto.CompactTextString(m) }
 func (*ResponsePrepareProposal) ProtoMessage()    {}
 func (*ResponsePrepareProposal) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{35}
+	return fileDescriptor_252557cfdd89a31a, []int{36}
 }
 func (m *ResponsePrepareProposal) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2760,7 +2965,7 @@ func (m *ResponseProcessProposal) Reset()         { *m = ResponseProcessProposal
 func (m *ResponseProcessProposal) String() string { return proto.CompactTextString(m) }
 func (*ResponseProcessProposal) ProtoMessage()    {}
 func (*ResponseProcessProposal) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{36}
+	return fileDescriptor_252557cfdd89a31a, []int{37}
 }
 func (m *ResponseProcessProposal) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2816,7 +3021,7 @@ func (m *ConsensusParams) Reset()         { *m = ConsensusParams{} }
 func (m *ConsensusParams) String() string { return proto.CompactTextString(m) }
 func (*ConsensusParams) ProtoMessage()    {}
 func (*ConsensusParams) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{37}
+	return fileDescriptor_252557cfdd89a31a, []int{38}
 }
 func (m *ConsensusParams) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2879,13 +3084,15 @@ type BlockParams struct {
 	MaxBytes int64 `protobuf:"varint,1,opt,name=max_bytes,json=maxBytes,proto3" json:"max_bytes,omitempty"`
 	// Note: must be greater or equal to -1
 	MaxGas int64 `protobuf:"varint,2,opt,name=max_gas,json=maxGas,proto3" json:"max_gas,omitempty"`
+	// Note: rejects the consensus messages holding unknown fields
+	StrictDecoding bool `protobuf:"varint,3,opt,name=strict_decoding,json=strictDecoding,proto3" json:"strict_decoding,omitempty"`
 }
 
 func (m *BlockParams) Reset()         { *m = BlockParams{} }
 func (m *BlockParams) String() string { return proto.CompactTextString(m) }
 func (*BlockParams) ProtoMessage()    {}
 func (*BlockParams) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{38}
+	return fileDescriptor_252557cfdd89a31a, []int{39}
 }
 func (m *BlockParams) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2928,6 +3135,13 @@ func (m *BlockParams) GetMaxGas() int64 {
 	return 0
 }
 
+func (m *BlockParams) GetStrictDecoding() bool {
+	if m != nil {
+		return m.StrictDecoding
+	}
+	return false
+}
+
 type LastCommitInfo struct {
 	Round int32      `protobuf:"varint,1,opt,name=round,proto3" json:"round,omitempty"`
 	Votes []VoteInfo `protobuf:"bytes,2,rep,name=votes,proto3" json:"votes"`
@@ -2937,7 +3151,7 @@ func (m *LastCommitInfo) Reset()         { *m = LastCommitInfo{} }
 func (m *LastCommitInfo) String() string { return proto.CompactTextString(m) }
 func (*LastCommitInfo) ProtoMessage()    {}
 func (*LastCommitInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{39}
+	return fileDescriptor_252557cfdd89a31a, []int{40}
 }
 func (m *LastCommitInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2992,7 +3206,7 @@ func (m *Event) Reset()         { *m = Event{} }
 func (m *Event) String() string { return proto.CompactTextString(m) }
 func (*Event) ProtoMessage()    {}
 func (*Event) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{40}
+	return fileDescriptor_252557cfdd89a31a, []int{41}
 }
 func (m *Event) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3046,7 +3260,7 @@ func (m *EventAttribute) Reset()         { *m = EventAttribute{} }
 func (m *EventAttribute) String() string { return proto.CompactTextString(m) }
 func (*EventAttribute) ProtoMessage()    {}
 func (*EventAttribute) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{41}
+	return fileDescriptor_252557cfdd89a31a, []int{42}
 }
 func (m *EventAttribute) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3110,7 +3324,7 @@ func (m *TxResult) Reset()         { *m = TxResult{} }
 func (m *TxResult) String() string { return proto.CompactTextString(m) }
 func (*TxResult) ProtoMessage()    {}
 func (*TxResult) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{42}
+	return fileDescriptor_252557cfdd89a31a, []int{43}
 }
 func (m *TxResult) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3178,7 +3392,7 @@ func (m *Validator) Reset()         { *m = Validator{} }
 func (m *Validator) String() string { return proto.CompactTextString(m) }
 func (*Validator) ProtoMessage()    {}
 func (*Validator) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{43}
+	return fileDescriptor_252557cfdd89a31a, []int{44}
 }
 func (m *Validator) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3231,7 +3445,7 @@ func (m *ValidatorUpdate) Reset()         { *m = ValidatorUpdate{} }
 func (m *ValidatorUpdate) String() string { return proto.CompactTextString(m) }
 func (*ValidatorUpdate) ProtoMessage()    {}
 func (*ValidatorUpdate) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{44}
+	return fileDescriptor_252557cfdd89a31a, []int{45}
 }
 func (m *ValidatorUpdate) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3284,7 +3498,7 @@ func (m *VoteInfo) Reset()         { *m = VoteInfo{} }
 func (m *VoteInfo) String() string { return proto.CompactTextString(m) }
 func (*VoteInfo) ProtoMessage()    {}
 func (*VoteInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{45}
+	return fileDescriptor_252557cfdd89a31a, []int{46}
 }
 func (m *VoteInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3345,7 +3559,7 @@ func (m *Evidence) Reset()         { *m = Evidence{} }
 func (m *Evidence) String() string { return proto.CompactTextString(m) }
 func (*Evidence) ProtoMessage()    {}
 func (*Evidence) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{46}
+	return fileDescriptor_252557cfdd89a31a, []int{47}
 }
 func (m *Evidence) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3421,7 +3635,7 @@ func (m *Snapshot) Reset()         { *m = Snapshot{} }
 func (m *Snapshot) String() string { return proto.CompactTextString(m) }
 func (*Snapshot) ProtoMessage()    {}
 func (*Snapshot) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{47}
+	return fileDescriptor_252557cfdd89a31a, []int{48}
 }
 func (m *Snapshot) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3485,286 +3699,651 @@ func (m *Snapshot) GetMetadata() []byte {
 	return nil
 }
 
-func init() {
-	proto.RegisterEnum("tendermint.abci.CheckTxType", CheckTxType_name, CheckTxType_value)
-	proto.RegisterEnum("tendermint.abci.EvidenceType", EvidenceType_name, EvidenceType_value)
-	proto.RegisterEnum("tendermint.abci.ResponseOfferSnapshot_Result", ResponseOfferSnapshot_Result_name, ResponseOfferSnapshot_Result_value)
-	proto.RegisterEnum("tendermint.abci.ResponseApplySnapshotChunk_Result", ResponseApplySnapshotChunk_Result_name, ResponseApplySnapshotChunk_Result_value)
-	proto.RegisterEnum("tendermint.abci.ResponseProcessProposal_Result", ResponseProcessProposal_Result_name, ResponseProcessProposal_Result_value)
-	proto.RegisterType((*Request)(nil), "tendermint.abci.Request")
-	proto.RegisterType((*RequestEcho)(nil), "tendermint.abci.RequestEcho")
-	proto.RegisterType((*RequestFlush)(nil), "tendermint.abci.RequestFlush")
-	proto.RegisterType((*RequestInfo)(nil), "tendermint.abci.RequestInfo")
-	proto.RegisterType((*RequestSetOption)(nil), "tendermint.abci.RequestSetOption")
-	proto.RegisterType((*RequestInitChain)(nil), "tendermint.abci.RequestInitChain")
-	proto.RegisterType((*RequestQuery)(nil), "tendermint.abci.RequestQuery")
-	proto.RegisterType((*RequestBeginBlock)(nil), "tendermint.abci.RequestBeginBlock")
-	proto.RegisterType((*RequestCheckTx)(nil), "tendermint.abci.RequestCheckTx")
-	proto.RegisterType((*RequestDeliverTx)(nil), "tendermint.abci.RequestDeliverTx")
-	proto.RegisterType((*RequestEndBlock)(nil), "tendermint.abci.RequestEndBlock")
-	proto.RegisterType((*RequestCommit)(nil), "tendermint.abci.RequestCommit")
-	proto.RegisterType((*RequestListSnapshots)(nil), "tendermint.abci.RequestListSnapshots")
-	proto.RegisterType((*RequestOfferSnapshot)(nil), "tendermint.abci.RequestOfferSnapshot")
-	proto.RegisterType((*RequestLoadSnapshotChunk)(nil), "tendermint.abci.RequestLoadSnapshotChunk")
-	proto.RegisterType((*RequestApplySnapshotChunk)(nil), "tendermint.abci.RequestApplySnapshotChunk")
-	proto.RegisterType((*RequestPrepareProposal)(nil), "tendermint.abci.RequestPrepareProposal")
-	proto.RegisterType((*RequestProcessProposal)(nil), "tendermint.abci.RequestProcessProposal")
-	proto.RegisterType((*Response)(nil), "tendermint.abci.Response")
-	proto.RegisterType((*ResponseException)(nil), "tendermint.abci.ResponseException")
-	proto.RegisterType((*ResponseEcho)(nil), "tendermint.abci.ResponseEcho")
-	proto.RegisterType((*ResponseFlush)(nil), "tendermint.abci.ResponseFlush")
-	proto.RegisterType((*ResponseInfo)(nil), "tendermint.abci.ResponseInfo")
-	proto.RegisterType((*ResponseSetOption)(nil), "tendermint.abci.ResponseSetOption")
-	proto.RegisterType((*ResponseInitChain)(nil), "tendermint.abci.ResponseInitChain")
-	proto.RegisterType((*ResponseQuery)(nil), "tendermint.abci.ResponseQuery")
-	proto.RegisterType((*ResponseBeginBlock)(nil), "tendermint.abci.ResponseBeginBlock")
-	proto.RegisterType((*ResponseCheckTx)(nil), "tendermint.abci.ResponseCheckTx")
-	proto.RegisterType((*ResponseDeliverTx)(nil), "tendermint.abci.ResponseDeliverTx")
-	proto.RegisterType((*ResponseEndBlock)(nil), "tendermint.abci.ResponseEndBlock")
-	proto.RegisterType((*ResponseCommit)(nil), "tendermint.abci.ResponseCommit")
-	proto.RegisterType((*ResponseListSnapshots)(nil), "tendermint.abci.ResponseListSnapshots")
-	proto.RegisterType((*ResponseOfferSnapshot)(nil), "tendermint.abci.ResponseOfferSnapshot")
-	proto.RegisterType((*ResponseLoadSnapshotChunk)(nil), "tendermint.abci.ResponseLoadSnapshotChunk")
-	proto.RegisterType((*ResponseApplySnapshotChunk)(nil), "tendermint.abci.ResponseApplySnapshotChunk")
-	proto.RegisterType((*ResponsePrepareProposal)(nil), "tendermint.abci.ResponsePrepareProposal")
-	proto.RegisterType((*ResponseProcessProposal)(nil), "tendermint.abci.ResponseProcessProposal")
-	proto.RegisterType((*ConsensusParams)(nil), "tendermint.abci.ConsensusParams")
-	proto.RegisterType((*BlockParams)(nil), "tendermint.abci.BlockParams")
-	proto.RegisterType((*LastCommitInfo)(nil), "tendermint.abci.LastCommitInfo")
-	proto.RegisterType((*Event)(nil), "tendermint.abci.Event")
-	proto.RegisterType((*EventAttribute)(nil), "tendermint.abci.EventAttribute")
-	proto.RegisterType((*TxResult)(nil), "tendermint.abci.TxResult")
-	proto.RegisterType((*Validator)(nil), "tendermint.abci.Validator")
-	proto.RegisterType((*ValidatorUpdate)(nil), "tendermint.abci.ValidatorUpdate")
-	proto.RegisterType((*VoteInfo)(nil), "tendermint.abci.VoteInfo")
-	proto.RegisterType((*Evidence)(nil), "tendermint.abci.Evidence")
-	proto.RegisterType((*Snapshot)(nil), "tendermint.abci.Snapshot")
+// Creates the snapshots of the state at a height, after it was committed
+type RequestCreateSnapshot struct {
+	Height uint64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
 }
 
-func init() { proto.RegisterFile("tendermint/abci/types.proto", fileDescriptor_252557cfdd89a31a) }
-
-var fileDescriptor_252557cfdd89a31a = []byte{
-	// 3014 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe4, 0x5a, 0x4b, 0x73, 0xe3, 0xc6,
-	0xf1, 0xe7, 0x9b, 0x44, 0x53, 0x7c, 0x68, 0x76, 0xbd, 0x4b, 0xc3, 0x6b, 0x69, 0x8d, 0x2d, 0xbf,
-	0xd6, 0xb6, 0xf4, 0xb7, 0x5c, 0xeb, 0xbf, 0x1d, 0x3b, 0xb1, 0x45, 0x2e, 0xd7, 0x94, 0x57, 0x96,
-	0x14, 0x88, 0x5a, 0xe7, 0xe5, 0x85, 0x41, 0x72, 0x44, 0xc2, 0x4b, 0x02, 0x30, 0x30, 0x94, 0xa5,
-	0x3d, 0xa6, 0x92, 0x4a, 0x95, 0x73, 0x71, 0x55, 0x2e, 0xb9, 0xf8, 0x23, 0xe4, 0x9e, 0x4b, 0x72,
-	0xc9, 0xc5, 0x55, 0x39, 0xc4, 0xc7, 0x1c, 0x52, 0x4e, 0xca, 0xce, 0x29, 0x5f, 0x20, 0xa7, 0x54,
-	0x52, 0xf3, 0xc0, 0x8b, 0x24, 0x44, 0x68, 0x9d, 0x5b, 0x6e, 0x98, 0x46, 0x77, 0x03, 0xd3, 0x98,
-	0xf9, 0x75, 0xff, 0x1a, 0x03, 0x4f, 0x10, 0x6c, 0x0e, 0xb0, 0x33, 0x31, 0x4c, 0xb2, 0xa9, 0xf7,
-	0xfa, 0xc6, 0x26, 0x39, 0xb3, 0xb1, 0xbb, 0x61, 0x3b, 0x16, 0xb1, 0x50, 0x2d, 0xb8, 0xb9, 0x41,
-	0x6f, 0xca, 0x4f, 0x86, 0xb4, 0xfb, 0xce, 0x99, 0x4d, 0xac, 0x4d, 0xdb, 0xb1, 0xac, 0x63, 0xae,
-	0x2f, 0x5f, 0x0b, 0xdd, 0x66, 0x7e, 0xc2, 0xde, 0x22, 0x77, 0x85, 0xf1, 0x03, 0x7c, 0xe6, 0xdd,
-	0x7d, 0x72, 0xce, 0xd6, 0xd6, 0x1d, 0x7d, 0xe2, 0xdd, 0x5e, 0x1f, 0x5a, 0xd6, 0x70, 0x8c, 0x37,
-	0xd9, 0xa8, 0x37, 0x3d, 0xde, 0x24, 0xc6, 0x04, 0xbb, 0x44, 0x9f, 0xd8, 0x42, 0xe1, 0xf2, 0xd0,
-	0x1a, 0x5a, 0xec, 0x72, 0x93, 0x5e, 0x71, 0xa9, 0xf2, 0x3b, 0x09, 0x8a, 0x2a, 0xfe, 0x78, 0x8a,
-	0x5d, 0x82, 0xb6, 0x20, 0x87, 0xfb, 0x23, 0xab, 0x91, 0xbe, 0x9e, 0x7e, 0xae, 0xbc, 0x75, 0x6d,
-	0x63, 0x66, 0x72, 0x1b, 0x42, 0xaf, 0xdd, 0x1f, 0x59, 0x9d, 0x94, 0xca, 0x74, 0xd1, 0x2d, 0xc8,
-	0x1f, 0x8f, 0xa7, 0xee, 0xa8, 0x91, 0x61, 0x46, 0x4f, 0xc6, 0x19, 0xdd, 0xa1, 0x4a, 0x9d, 0x94,
-	0xca, 0xb5, 0xe9, 0xa3, 0x0c, 0xf3, 0xd8, 0x6a, 0x64, 0xcf, 0x7f, 0xd4, 0x8e, 0x79, 0xcc, 0x1e,
-	0x45, 0x75, 0x51, 0x13, 0xc0, 0xc5, 0x44, 0xb3, 0x6c, 0x62, 0x58, 0x66, 0x23, 0xc7, 0x2c, 0x9f,
-	0x8a, 0xb3, 0x3c, 0xc4, 0x64, 0x9f, 0x29, 0x76, 0x52, 0xaa, 0xe4, 0x7a, 0x03, 0xea, 0xc3, 0x30,
-	0x0d, 0xa2, 0xf5, 0x47, 0xba, 0x61, 0x36, 0xf2, 0xe7, 0xfb, 0xd8, 0x31, 0x0d, 0xd2, 0xa2, 0x8a,
-	0xd4, 0x87, 0xe1, 0x0d, 0xe8, 0x94, 0x3f, 0x9e, 0x62, 0xe7, 0xac, 0x51, 0x38, 0x7f, 0xca, 0xdf,
-	0xa7, 0x4a, 0x74, 0xca, 0x4c, 0x1b, 0xb5, 0xa1, 0xdc, 0xc3, 0x43, 0xc3, 0xd4, 0x7a, 0x63, 0xab,
-	0xff, 0xa0, 0x51, 0x64, 0xc6, 0x4a, 0x9c, 0x71, 0x93, 0xaa, 0x36, 0xa9, 0x66, 0x27, 0xa5, 0x42,
-	0xcf, 0x1f, 0xa1, 0x37, 0xa1, 0xd4, 0x1f, 0xe1, 0xfe, 0x03, 0x8d, 0x9c, 0x36, 0x4a, 0xcc, 0xc7,
-	0x7a, 0x9c, 0x8f, 0x16, 0xd5, 0xeb, 0x9e, 0x76, 0x52, 0x6a, 0xb1, 0xcf, 0x2f, 0xe9, 0xfc, 0x07,
-	0x78, 0x6c, 0x9c, 0x60, 0x87, 0xda, 0x4b, 0xe7, 0xcf, 0xff, 0x36, 0xd7, 0x64, 0x1e, 0xa4, 0x81,
-	0x37, 0x40, 0x6f, 0x81, 0x84, 0xcd, 0x81, 0x98, 0x06, 0x30, 0x17, 0xd7, 0x63, 0xd7, 0x8a, 0x39,
-	0xf0, 0x26, 0x51, 0xc2, 0xe2, 0x1a, 0xbd, 0x06, 0x85, 0xbe, 0x35, 0x99, 0x18, 0xa4, 0x51, 0x66,
-	0xd6, 0x6b, 0xb1, 0x13, 0x60, 0x5a, 0x9d, 0x94, 0x2a, 0xf4, 0xd1, 0x1e, 0x54, 0xc7, 0x86, 0x4b,
-	0x34, 0xd7, 0xd4, 0x6d, 0x77, 0x64, 0x11, 0xb7, 0xb1, 0xc2, 0x3c, 0x3c, 0x1d, 0xe7, 0x61, 0xd7,
-	0x70, 0xc9, 0xa1, 0xa7, 0xdc, 0x49, 0xa9, 0x95, 0x71, 0x58, 0x40, 0xfd, 0x59, 0xc7, 0xc7, 0xd8,
-	0xf1, 0x1d, 0x36, 0x2a, 0xe7, 0xfb, 0xdb, 0xa7, 0xda, 0x9e, 0x3d, 0xf5, 0x67, 0x85, 0x05, 0xe8,
-	0xc7, 0x70, 0x69, 0x6c, 0xe9, 0x03, 0xdf, 0x9d, 0xd6, 0x1f, 0x4d, 0xcd, 0x07, 0x8d, 0x2a, 0x73,
-	0xfa, 0x7c, 0xec, 0x4b, 0x5a, 0xfa, 0xc0, 0x73, 0xd1, 0xa2, 0x06, 0x9d, 0x94, 0xba, 0x3a, 0x9e,
-	0x15, 0xa2, 0xfb, 0x70, 0x59, 0xb7, 0xed, 0xf1, 0xd9, 0xac, 0xf7, 0x1a, 0xf3, 0x7e, 0x33, 0xce,
-	0xfb, 0x36, 0xb5, 0x99, 0x75, 0x8f, 0xf4, 0x39, 0x29, 0xea, 0x42, 0xdd, 0x76, 0xb0, 0xad, 0x3b,
-	0x58, 0xb3, 0x1d, 0xcb, 0xb6, 0x5c, 0x7d, 0xdc, 0xa8, 0x33, 0xdf, 0xcf, 0xc6, 0xf9, 0x3e, 0xe0,
-	0xfa, 0x07, 0x42, 0xbd, 0x93, 0x52, 0x6b, 0x76, 0x54, 0xc4, 0xbd, 0x5a, 0x7d, 0xec, 0xba, 0x81,
-	0xd7, 0xd5, 0x65, 0x5e, 0x99, 0x7e, 0xd4, 0x6b, 0x44, 0xd4, 0x2c, 0x42, 0xfe, 0x44, 0x1f, 0x4f,
-	0xb1, 0xf2, 0x2c, 0x94, 0x43, 0xb0, 0x84, 0x1a, 0x50, 0x9c, 0x60, 0xd7, 0xd5, 0x87, 0x98, 0xa1,
-	0x98, 0xa4, 0x7a, 0x43, 0xa5, 0x0a, 0x2b, 0x61, 0x28, 0x52, 0x26, 0xbe, 0x21, 0x05, 0x19, 0x6a,
-	0x78, 0x82, 0x1d, 0x97, 0x22, 0x8b, 0x30, 0x14, 0x43, 0x74, 0x03, 0x2a, 0x6c, 0xa9, 0x6b, 0xde,
-	0x7d, 0x8a, 0x74, 0x39, 0x75, 0x85, 0x09, 0xef, 0x09, 0xa5, 0x75, 0x28, 0xdb, 0x5b, 0xb6, 0xaf,
-	0x92, 0x65, 0x2a, 0x60, 0x6f, 0xd9, 0x42, 0x41, 0xf9, 0x0e, 0xd4, 0x67, 0x91, 0x09, 0xd5, 0x21,
-	0xfb, 0x00, 0x9f, 0x89, 0xe7, 0xd1, 0x4b, 0x74, 0x59, 0x4c, 0x8b, 0x3d, 0x43, 0x52, 0xc5, 0x1c,
-	0xff, 0x98, 0xf1, 0x8d, 0x7d, 0x48, 0x42, 0xaf, 0x41, 0x8e, 0x22, 0xbc, 0x00, 0x6b, 0x79, 0x83,
-	0xc3, 0xff, 0x86, 0x07, 0xff, 0x1b, 0x5d, 0x0f, 0xfe, 0x9b, 0xa5, 0x2f, 0xbe, 0x5a, 0x4f, 0x7d,
-	0xf6, 0xd7, 0xf5, 0xb4, 0xca, 0x2c, 0xd0, 0xe3, 0x14, 0x41, 0x74, 0xc3, 0xd4, 0x8c, 0x81, 0x78,
-	0x4e, 0x91, 0x8d, 0x77, 0x06, 0xe8, 0x2e, 0xd4, 0xfb, 0x96, 0xe9, 0x62, 0xd3, 0x9d, 0xba, 0x1a,
-	0x4f, 0x2f, 0x02, 0xa2, 0xe7, 0x77, 0x78, 0xcb, 0x53, 0x3c, 0x60, 0x7a, 0x6a, 0xad, 0x1f, 0x15,
-	0xa0, 0x3b, 0x00, 0x27, 0xfa, 0xd8, 0x18, 0xe8, 0xc4, 0x72, 0xdc, 0x46, 0xee, 0x7a, 0x76, 0xa1,
-	0x9b, 0x7b, 0x9e, 0xca, 0x91, 0x3d, 0xd0, 0x09, 0x6e, 0xe6, 0xe8, 0xdb, 0xaa, 0x21, 0x4b, 0xf4,
-	0x0c, 0xd4, 0x74, 0xdb, 0xd6, 0x5c, 0xa2, 0x13, 0xac, 0xf5, 0xce, 0x08, 0x76, 0x19, 0x70, 0xaf,
-	0xa8, 0x15, 0xdd, 0xb6, 0x0f, 0xa9, 0xb4, 0x49, 0x85, 0xe8, 0x69, 0xa8, 0x52, 0x90, 0x36, 0xf4,
-	0xb1, 0x36, 0xc2, 0xc6, 0x70, 0x44, 0x18, 0x40, 0x67, 0xd5, 0x8a, 0x90, 0x76, 0x98, 0x50, 0x19,
-	0xf8, 0x0b, 0x81, 0x01, 0x34, 0x42, 0x90, 0x1b, 0xe8, 0x44, 0x67, 0x81, 0x5c, 0x51, 0xd9, 0x35,
-	0x95, 0xd9, 0x3a, 0x19, 0x89, 0xf0, 0xb0, 0x6b, 0x74, 0x05, 0x0a, 0xc2, 0x6d, 0x96, 0xb9, 0x15,
-	0x23, 0xfa, 0xcd, 0x6c, 0xc7, 0x3a, 0xc1, 0x2c, 0x23, 0x95, 0x54, 0x3e, 0x50, 0x7e, 0x96, 0x81,
-	0xd5, 0x39, 0x28, 0xa7, 0x7e, 0x47, 0xba, 0x3b, 0xf2, 0x9e, 0x45, 0xaf, 0xd1, 0xab, 0xd4, 0xaf,
-	0x3e, 0xc0, 0x8e, 0x48, 0xa1, 0x8d, 0x70, 0x88, 0x78, 0x79, 0xd0, 0x61, 0xf7, 0x45, 0x68, 0x84,
-	0x36, 0xda, 0x87, 0xfa, 0x58, 0x77, 0x89, 0xc6, 0xa1, 0x51, 0x0b, 0xa5, 0xd3, 0xf9, 0x84, 0xb0,
-	0xab, 0x7b, 0x60, 0x4a, 0x17, 0xbb, 0x70, 0x54, 0x1d, 0x47, 0xa4, 0x48, 0x85, 0xcb, 0xbd, 0xb3,
-	0x87, 0xba, 0x49, 0x0c, 0x13, 0x6b, 0x73, 0x5f, 0xee, 0xf1, 0x39, 0xa7, 0xed, 0x13, 0x63, 0x80,
-	0xcd, 0xbe, 0xf7, 0xc9, 0x2e, 0xf9, 0xc6, 0xfe, 0x27, 0x75, 0x15, 0x15, 0xaa, 0xd1, 0x64, 0x84,
-	0xaa, 0x90, 0x21, 0xa7, 0x22, 0x00, 0x19, 0x72, 0x8a, 0xfe, 0x0f, 0x72, 0x74, 0x92, 0x6c, 0xf2,
-	0xd5, 0x05, 0x95, 0x80, 0xb0, 0xeb, 0x9e, 0xd9, 0x58, 0x65, 0x9a, 0x8a, 0xe2, 0xef, 0x06, 0x3f,
-	0x41, 0xcd, 0x7a, 0x55, 0x9e, 0x87, 0xda, 0x4c, 0x06, 0x0a, 0x7d, 0xbf, 0x74, 0xf8, 0xfb, 0x29,
-	0x35, 0xa8, 0x44, 0xd2, 0x8d, 0x72, 0x05, 0x2e, 0x2f, 0xca, 0x1e, 0xca, 0xc8, 0x97, 0x47, 0xb2,
-	0x00, 0xba, 0x05, 0x25, 0x3f, 0x7d, 0xf0, 0xdd, 0x38, 0x1f, 0x2b, 0x4f, 0x59, 0xf5, 0x55, 0xe9,
-	0x36, 0xa4, 0xcb, 0x9a, 0xad, 0x87, 0x0c, 0x7b, 0xf1, 0xa2, 0x6e, 0xdb, 0x1d, 0xdd, 0x1d, 0x29,
-	0x1f, 0x42, 0x23, 0x2e, 0x35, 0xcc, 0x4c, 0x23, 0xe7, 0x2f, 0xc3, 0x2b, 0x50, 0x38, 0xb6, 0x9c,
-	0x89, 0x4e, 0x98, 0xb3, 0x8a, 0x2a, 0x46, 0x74, 0x79, 0xf2, 0x34, 0x91, 0x65, 0x62, 0x3e, 0x50,
-	0x34, 0x78, 0x3c, 0x36, 0x3d, 0x50, 0x13, 0xc3, 0x1c, 0x60, 0x1e, 0xcf, 0x8a, 0xca, 0x07, 0x81,
-	0x23, 0xfe, 0xb2, 0x7c, 0x40, 0x1f, 0xeb, 0xb2, 0xb9, 0x32, 0xff, 0x92, 0x2a, 0x46, 0xca, 0xdf,
-	0xd3, 0x70, 0x65, 0x71, 0x92, 0x40, 0xb7, 0x00, 0x38, 0xa0, 0xfa, 0xdb, 0xae, 0xbc, 0x75, 0x65,
-	0x7e, 0xd1, 0xdf, 0xd6, 0x89, 0xae, 0x4a, 0x4c, 0x93, 0x5e, 0x52, 0x18, 0x08, 0xcc, 0x34, 0xd7,
-	0x78, 0xc8, 0xd7, 0x4c, 0x56, 0xad, 0xf8, 0x3a, 0x87, 0xc6, 0xc3, 0x28, 0xbc, 0x65, 0xa3, 0xf0,
-	0x16, 0xc4, 0x2e, 0x17, 0xd9, 0xc2, 0x1e, 0x96, 0xe6, 0x2f, 0x8a, 0xa5, 0xca, 0x2f, 0xc2, 0xd3,
-	0x8c, 0xa4, 0xa8, 0xd0, 0xbe, 0x4e, 0x5f, 0x68, 0x5f, 0x47, 0xc3, 0x93, 0x49, 0x18, 0x1e, 0xe5,
-	0x57, 0x00, 0x25, 0x15, 0xbb, 0x36, 0x05, 0x61, 0xd4, 0x04, 0x09, 0x9f, 0xf6, 0x31, 0xaf, 0x94,
-	0xd3, 0xb1, 0x95, 0x26, 0xd7, 0x6e, 0x7b, 0x9a, 0xb4, 0xcc, 0xf3, 0xcd, 0xd0, 0x2b, 0x82, 0x0d,
-	0xc4, 0x17, 0xf6, 0xc2, 0x3c, 0x4c, 0x07, 0x5e, 0xf5, 0xe8, 0x40, 0x36, 0xb6, 0xb2, 0xe3, 0x56,
-	0x33, 0x7c, 0xe0, 0x15, 0xc1, 0x07, 0x72, 0x4b, 0x1e, 0x16, 0x21, 0x04, 0xad, 0x08, 0x21, 0xc8,
-	0x2f, 0x99, 0x66, 0x0c, 0x23, 0x68, 0x45, 0x18, 0x41, 0x61, 0x89, 0x93, 0x18, 0x4a, 0xf0, 0xaa,
-	0x47, 0x09, 0x8a, 0x4b, 0xa6, 0x3d, 0xc3, 0x09, 0xee, 0x44, 0x39, 0x01, 0xaf, 0xe7, 0x6f, 0xc4,
-	0x5a, 0xc7, 0x92, 0x82, 0xef, 0x86, 0x48, 0x81, 0x14, 0x5b, 0x91, 0x73, 0x27, 0x0b, 0x58, 0x41,
-	0x2b, 0xc2, 0x0a, 0x60, 0x49, 0x0c, 0x62, 0x68, 0xc1, 0xdb, 0x61, 0x5a, 0x50, 0x8e, 0x65, 0x16,
-	0x62, 0xd1, 0x2c, 0xe2, 0x05, 0xaf, 0xfb, 0xbc, 0x60, 0x25, 0x96, 0xd8, 0x88, 0x39, 0xcc, 0x12,
-	0x83, 0xfd, 0x39, 0x62, 0xc0, 0x0b, 0xf9, 0x67, 0x62, 0x5d, 0x2c, 0x61, 0x06, 0xfb, 0x73, 0xcc,
-	0xa0, 0xba, 0xc4, 0xe1, 0x12, 0x6a, 0xf0, 0x93, 0xc5, 0xd4, 0x20, 0xbe, 0x78, 0x17, 0xaf, 0x99,
-	0x8c, 0x1b, 0x68, 0x31, 0xdc, 0x80, 0xd7, 0xef, 0x2f, 0xc4, 0xba, 0x4f, 0x4c, 0x0e, 0x8e, 0x16,
-	0x90, 0x03, 0x5e, 0xc6, 0x3f, 0x17, 0xeb, 0x3c, 0x01, 0x3b, 0x38, 0x5a, 0xc0, 0x0e, 0xd0, 0x52,
-	0xb7, 0xc9, 0xe9, 0xc1, 0xf3, 0xb4, 0x0a, 0x9b, 0x81, 0x39, 0x9a, 0xc9, 0xb0, 0xe3, 0x58, 0x8e,
-	0xa8, 0xbc, 0xf9, 0x40, 0x79, 0x8e, 0xd6, 0x85, 0x01, 0xa4, 0x9d, 0x43, 0x25, 0x58, 0xc5, 0x10,
-	0x82, 0x31, 0xe5, 0xb7, 0xe9, 0xc0, 0x96, 0x95, 0x52, 0xe1, 0x9a, 0x52, 0x12, 0x35, 0x65, 0x88,
-	0x61, 0x64, 0xa2, 0x0c, 0x63, 0x1d, 0xca, 0xb4, 0x12, 0x98, 0x21, 0x0f, 0xba, 0xed, 0x91, 0x07,
-	0x74, 0x13, 0x56, 0x59, 0xa9, 0xc7, 0xf3, 0x42, 0x24, 0x85, 0xd5, 0xe8, 0x0d, 0xbe, 0x95, 0x78,
-	0x2e, 0x7b, 0x09, 0x2e, 0x85, 0x74, 0xfd, 0x0a, 0x83, 0x57, 0xcc, 0x75, 0x5f, 0x7b, 0x5b, 0x94,
-	0x1a, 0xef, 0x05, 0x01, 0x0a, 0x88, 0x09, 0x82, 0x5c, 0xdf, 0x1a, 0x60, 0x91, 0xff, 0xd9, 0x35,
-	0x25, 0x2b, 0x63, 0x6b, 0x28, 0x32, 0x2a, 0xbd, 0xa4, 0x5a, 0x3e, 0x66, 0x4b, 0x1c, 0x92, 0x95,
-	0x3f, 0xa4, 0x03, 0x7f, 0x01, 0x57, 0x59, 0x44, 0x2b, 0xd2, 0xff, 0x1d, 0x5a, 0x91, 0x79, 0x64,
-	0x5a, 0x11, 0xae, 0xbf, 0xb2, 0xd1, 0xfa, 0xeb, 0x9f, 0xe9, 0xe0, 0x0b, 0xfb, 0x24, 0xe1, 0xd1,
-	0x22, 0x12, 0x14, 0x53, 0x79, 0xf6, 0xbd, 0x44, 0x31, 0x25, 0xa8, 0x5f, 0x81, 0x3d, 0x37, 0x4a,
-	0xfd, 0x8a, 0xbc, 0xbc, 0x62, 0x03, 0xf4, 0x1a, 0x48, 0xac, 0x7f, 0xa8, 0x59, 0xb6, 0x2b, 0xd2,
-	0xc3, 0x13, 0xe1, 0xb9, 0xf2, 0x36, 0xe1, 0xc6, 0x01, 0xd5, 0xd9, 0xb7, 0x5d, 0xb5, 0x64, 0x8b,
-	0xab, 0x50, 0xad, 0x23, 0x45, 0x6a, 0x9d, 0x6b, 0x20, 0xd1, 0xb7, 0x77, 0x6d, 0xbd, 0x8f, 0x19,
-	0xd4, 0x4b, 0x6a, 0x20, 0x50, 0xee, 0x03, 0x9a, 0x4f, 0x36, 0xa8, 0x03, 0x05, 0x7c, 0x82, 0x4d,
-	0x42, 0xbf, 0x5a, 0x76, 0xb6, 0x1c, 0x11, 0x5c, 0x00, 0x9b, 0xa4, 0xd9, 0xa0, 0x41, 0xfe, 0xc7,
-	0x57, 0xeb, 0x75, 0xae, 0xfd, 0xa2, 0x35, 0x31, 0x08, 0x9e, 0xd8, 0xe4, 0x4c, 0x15, 0xf6, 0xca,
-	0x5f, 0x32, 0xb4, 0x30, 0x8f, 0x24, 0xa2, 0x85, 0xb1, 0xf5, 0x36, 0x50, 0x26, 0x44, 0xca, 0x92,
-	0xc5, 0x7b, 0x0d, 0x60, 0xa8, 0xbb, 0xda, 0x27, 0xba, 0x49, 0xf0, 0x40, 0x04, 0x3d, 0x24, 0x41,
-	0x32, 0x94, 0xe8, 0x68, 0xea, 0xe2, 0x81, 0xe0, 0x87, 0xfe, 0x38, 0x34, 0xcf, 0xe2, 0xb7, 0x9b,
-	0x67, 0x34, 0xca, 0xa5, 0x99, 0x28, 0x87, 0x8a, 0x66, 0x29, 0x5c, 0x34, 0xd3, 0x77, 0xb3, 0x1d,
-	0xc3, 0x72, 0x0c, 0x72, 0xc6, 0x3e, 0x4d, 0x56, 0xf5, 0xc7, 0xe8, 0x06, 0x54, 0x26, 0x78, 0x62,
-	0x5b, 0xd6, 0x58, 0xe3, 0xe0, 0x55, 0x66, 0xa6, 0x2b, 0x42, 0xd8, 0x66, 0x18, 0xf6, 0xf3, 0x4c,
-	0xb0, 0xfd, 0x02, 0x72, 0xf4, 0x3f, 0x17, 0x60, 0xe5, 0x97, 0xac, 0x63, 0x12, 0x2d, 0x35, 0xd0,
-	0x21, 0xac, 0xfa, 0xdb, 0x5f, 0x9b, 0x32, 0x58, 0xf0, 0x16, 0x74, 0x52, 0xfc, 0xa8, 0x9f, 0x44,
-	0xc5, 0x2e, 0xfa, 0x01, 0x5c, 0x9d, 0x81, 0x36, 0xdf, 0x75, 0x26, 0x21, 0xc2, 0x3d, 0x16, 0x45,
-	0x38, 0xcf, 0x73, 0x10, 0xab, 0xec, 0xb7, 0xdc, 0x74, 0x3b, 0x94, 0x84, 0x87, 0x0b, 0xa7, 0x85,
-	0x5f, 0xff, 0x06, 0x54, 0x1c, 0x4c, 0x28, 0x71, 0x8a, 0xb4, 0x39, 0x56, 0xb8, 0x50, 0x34, 0x4f,
-	0x0e, 0xe0, 0xb1, 0x85, 0x05, 0x14, 0xfa, 0x7f, 0x90, 0x82, 0xda, 0x2b, 0x1d, 0xd3, 0x31, 0xf0,
-	0x59, 0x70, 0xa0, 0xab, 0xfc, 0x3e, 0x1d, 0xb8, 0x8c, 0xf2, 0xea, 0x36, 0x14, 0x1c, 0xec, 0x4e,
-	0xc7, 0x9c, 0xe9, 0x56, 0xb7, 0x5e, 0x4a, 0x56, 0x7a, 0x51, 0xe9, 0x74, 0x4c, 0x54, 0x61, 0xac,
-	0xdc, 0x87, 0x02, 0x97, 0xa0, 0x32, 0x14, 0x8f, 0xf6, 0xee, 0xee, 0xed, 0xbf, 0xbf, 0x57, 0x4f,
-	0x21, 0x80, 0xc2, 0x76, 0xab, 0xd5, 0x3e, 0xe8, 0xd6, 0xd3, 0x48, 0x82, 0xfc, 0x76, 0x73, 0x5f,
-	0xed, 0xd6, 0x33, 0x54, 0xac, 0xb6, 0xdf, 0x6d, 0xb7, 0xba, 0xf5, 0x2c, 0x5a, 0x85, 0x0a, 0xbf,
-	0xd6, 0xee, 0xec, 0xab, 0xef, 0x6d, 0x77, 0xeb, 0xb9, 0x90, 0xe8, 0xb0, 0xbd, 0x77, 0xbb, 0xad,
-	0xd6, 0xf3, 0xca, 0xcb, 0x94, 0x4a, 0xc7, 0x14, 0x6b, 0x01, 0x69, 0x4e, 0x87, 0x48, 0xb3, 0xf2,
-	0xeb, 0x0c, 0xc8, 0xf1, 0x15, 0x18, 0x7a, 0x77, 0x66, 0xe2, 0x5b, 0x17, 0x28, 0xdf, 0x66, 0x66,
-	0x8f, 0x9e, 0x86, 0xaa, 0x83, 0x8f, 0x31, 0xe9, 0x8f, 0x78, 0x45, 0xc8, 0x33, 0x66, 0x45, 0xad,
-	0x08, 0x29, 0x33, 0x72, 0xb9, 0xda, 0x47, 0xb8, 0x4f, 0x34, 0x0e, 0x45, 0x7c, 0xd1, 0x49, 0x54,
-	0x8d, 0x4a, 0x0f, 0xb9, 0x50, 0xf9, 0xf0, 0x42, 0xb1, 0x94, 0x20, 0xaf, 0xb6, 0xbb, 0xea, 0x0f,
-	0xeb, 0x59, 0x84, 0xa0, 0xca, 0x2e, 0xb5, 0xc3, 0xbd, 0xed, 0x83, 0xc3, 0xce, 0x3e, 0x8d, 0xe5,
-	0x25, 0xa8, 0x79, 0xb1, 0xf4, 0x84, 0x79, 0xe5, 0x00, 0xae, 0xc6, 0x94, 0x8f, 0x8f, 0xd8, 0x37,
-	0x50, 0x7e, 0x93, 0x0e, 0xbb, 0x8c, 0x72, 0xf4, 0x77, 0x66, 0x22, 0xbd, 0x99, 0xb4, 0xe8, 0x9c,
-	0x0d, 0xb3, 0x0c, 0x25, 0x2c, 0xda, 0x61, 0x2c, 0xc0, 0x2b, 0xaa, 0x3f, 0x56, 0x5e, 0x5a, 0x1e,
-	0xb4, 0x60, 0xd5, 0x65, 0x94, 0x7f, 0xa7, 0xa1, 0x36, 0x03, 0x11, 0x68, 0x0b, 0xf2, 0x9c, 0x57,
-	0xc5, 0xfd, 0x9a, 0x63, 0x08, 0x27, 0xf0, 0x84, 0xab, 0xa2, 0x37, 0x23, 0xaf, 0x34, 0x07, 0x45,
-	0x3c, 0x58, 0x5e, 0x0f, 0x4f, 0x98, 0xfa, 0x16, 0xe8, 0x2d, 0x90, 0x7c, 0xac, 0x13, 0x64, 0xfe,
-	0xa9, 0x79, 0x73, 0x1f, 0x25, 0x85, 0x7d, 0x60, 0x83, 0x5e, 0x0f, 0xca, 0xdd, 0xdc, 0x3c, 0x9b,
-	0x13, 0xe6, 0x5c, 0x41, 0x18, 0x7b, 0xfa, 0x4a, 0x0b, 0xca, 0xa1, 0xf9, 0xa0, 0x27, 0x40, 0x9a,
-	0xe8, 0xa7, 0xa2, 0xf3, 0xcb, 0x7b, 0x77, 0xa5, 0x89, 0x7e, 0xca, 0x9b, 0xbe, 0x57, 0xa1, 0x48,
-	0x6f, 0x0e, 0x75, 0x57, 0x74, 0x83, 0x0a, 0x13, 0xfd, 0xf4, 0x1d, 0xdd, 0x55, 0x3e, 0x80, 0x6a,
-	0xb4, 0xeb, 0x49, 0xf7, 0xa2, 0x63, 0x4d, 0xcd, 0x01, 0xf3, 0x91, 0x57, 0xf9, 0x00, 0xdd, 0x82,
-	0xfc, 0x89, 0xc5, 0xe1, 0x7a, 0x31, 0x68, 0xdd, 0xb3, 0x08, 0x0e, 0x75, 0x4d, 0xb9, 0xb6, 0xf2,
-	0x10, 0xf2, 0x0c, 0x7e, 0x29, 0x94, 0xb2, 0xfe, 0xa5, 0x28, 0xf5, 0xe9, 0x35, 0xfa, 0x00, 0x40,
-	0x27, 0xc4, 0x31, 0x7a, 0xd3, 0xc0, 0xf1, 0xfa, 0x62, 0xf8, 0xde, 0xf6, 0xf4, 0x9a, 0xd7, 0x04,
-	0x8e, 0x5f, 0x0e, 0x4c, 0x43, 0x58, 0x1e, 0x72, 0xa8, 0xec, 0x41, 0x35, 0x6a, 0x1b, 0xfe, 0x93,
-	0xb0, 0xb2, 0xe0, 0x4f, 0x82, 0x5f, 0x4e, 0xfa, 0xc5, 0x68, 0x96, 0xf7, 0xaa, 0xd9, 0x40, 0xf9,
-	0x34, 0x0d, 0xa5, 0xee, 0xa9, 0x58, 0xa3, 0x31, 0x6d, 0xd2, 0xc0, 0x34, 0x13, 0x6e, 0x0a, 0xf2,
-	0xbe, 0x6b, 0xd6, 0xef, 0xe6, 0xbe, 0xed, 0x6f, 0xa8, 0x5c, 0xd2, 0x2e, 0x82, 0xd7, 0xfe, 0x12,
-	0x70, 0xfd, 0x06, 0x48, 0xfe, 0xaa, 0xa2, 0x9c, 0x49, 0x1f, 0x0c, 0x1c, 0xec, 0xba, 0x62, 0x6e,
-	0xde, 0x90, 0x75, 0xdd, 0xad, 0x4f, 0x44, 0xdb, 0x31, 0xab, 0xf2, 0x81, 0x32, 0x80, 0xda, 0x4c,
-	0xe2, 0x46, 0x6f, 0x40, 0xd1, 0x9e, 0xf6, 0x34, 0x2f, 0x3c, 0x33, 0x9b, 0xc7, 0xab, 0x9f, 0xa7,
-	0xbd, 0xb1, 0xd1, 0xbf, 0x8b, 0xcf, 0xbc, 0x97, 0xb1, 0xa7, 0xbd, 0xbb, 0x3c, 0x8a, 0xfc, 0x29,
-	0x99, 0xf0, 0x53, 0x4e, 0xa0, 0xe4, 0x2d, 0x0a, 0xf4, 0xbd, 0xf0, 0x3e, 0xf1, 0xfe, 0xc5, 0xc4,
-	0x16, 0x13, 0xc2, 0x7d, 0x68, 0x9b, 0xdc, 0x84, 0x55, 0xd7, 0x18, 0x9a, 0x78, 0xa0, 0x05, 0xac,
-	0x8d, 0x3d, 0xad, 0xa4, 0xd6, 0xf8, 0x8d, 0x5d, 0x8f, 0xb2, 0x29, 0xff, 0x4a, 0x43, 0xc9, 0xdb,
-	0xb0, 0xe8, 0xe5, 0xd0, 0xba, 0xab, 0x2e, 0xe8, 0x98, 0x79, 0x8a, 0x41, 0xe3, 0x3c, 0xfa, 0xae,
-	0x99, 0x8b, 0xbf, 0x6b, 0xdc, 0x1f, 0x10, 0xaf, 0x7d, 0x9a, 0xbb, 0xf0, 0xaf, 0xa8, 0x17, 0x01,
-	0x11, 0x8b, 0xe8, 0x63, 0xed, 0xc4, 0x22, 0x86, 0x39, 0xd4, 0x78, 0xb0, 0x79, 0x4d, 0x59, 0x67,
-	0x77, 0xee, 0xb1, 0x1b, 0x07, 0x2c, 0xee, 0x3f, 0x4d, 0x43, 0xc9, 0xaf, 0x0e, 0x2e, 0xda, 0x07,
-	0xbf, 0x02, 0x05, 0x91, 0x00, 0x79, 0x23, 0x5c, 0x8c, 0xfc, 0x5f, 0x32, 0xb9, 0xd0, 0x2f, 0x19,
-	0x19, 0x4a, 0x13, 0x4c, 0x74, 0x96, 0x67, 0x38, 0x71, 0xf6, 0xc7, 0x37, 0x5f, 0x87, 0x72, 0xe8,
-	0x97, 0x04, 0xdd, 0x79, 0x7b, 0xed, 0xf7, 0xeb, 0x29, 0xb9, 0xf8, 0xe9, 0xe7, 0xd7, 0xb3, 0x7b,
-	0xf8, 0x13, 0xba, 0x66, 0xd5, 0x76, 0xab, 0xd3, 0x6e, 0xdd, 0xad, 0xa7, 0xe5, 0xf2, 0xa7, 0x9f,
-	0x5f, 0x2f, 0xaa, 0x98, 0x35, 0xda, 0x6e, 0x76, 0x60, 0x25, 0xfc, 0x55, 0xa2, 0xe9, 0x00, 0x41,
-	0xf5, 0xf6, 0xd1, 0xc1, 0xee, 0x4e, 0x6b, 0xbb, 0xdb, 0xd6, 0xee, 0xed, 0x77, 0xdb, 0xf5, 0x34,
-	0xba, 0x0a, 0x97, 0x76, 0x77, 0xde, 0xe9, 0x74, 0xb5, 0xd6, 0xee, 0x4e, 0x7b, 0xaf, 0xab, 0x6d,
-	0x77, 0xbb, 0xdb, 0xad, 0xbb, 0xf5, 0xcc, 0xd6, 0x9f, 0xca, 0x50, 0xdb, 0x6e, 0xb6, 0x76, 0x68,
-	0xfe, 0x37, 0xfa, 0xba, 0x68, 0x64, 0xe6, 0x58, 0xdf, 0xe2, 0xdc, 0x73, 0x1b, 0xf2, 0xf9, 0x7d,
-	0x5c, 0x74, 0x07, 0xf2, 0xac, 0xa5, 0x81, 0xce, 0x3f, 0xc8, 0x21, 0x2f, 0x69, 0xec, 0xd2, 0x97,
-	0x61, 0xdb, 0xe3, 0xdc, 0x93, 0x1d, 0xf2, 0xf9, 0x7d, 0x5e, 0xa4, 0x82, 0x14, 0xf4, 0x24, 0x96,
-	0x9f, 0xf4, 0x90, 0x13, 0xf4, 0x7e, 0xa9, 0xcf, 0x80, 0x18, 0x2d, 0x3f, 0xf9, 0x20, 0x27, 0x00,
-	0x30, 0xb4, 0x0b, 0x45, 0x8f, 0xcb, 0x2e, 0x3b, 0x8b, 0x21, 0x2f, 0xed, 0xcb, 0xd2, 0x4f, 0xc0,
-	0x7b, 0x0e, 0xe7, 0x1f, 0x2c, 0x91, 0x97, 0x34, 0x99, 0xd1, 0x0e, 0x14, 0x44, 0xb5, 0xbf, 0xe4,
-	0x7c, 0x85, 0xbc, 0xac, 0xcf, 0x4a, 0x83, 0x16, 0x34, 0x73, 0x96, 0x1f, 0x97, 0x91, 0x13, 0xf4,
-	0xcf, 0xd1, 0x11, 0x40, 0xa8, 0xc3, 0x90, 0xe0, 0x1c, 0x8c, 0x9c, 0xa4, 0x2f, 0x8e, 0xf6, 0xa1,
-	0xe4, 0x13, 0xbe, 0xa5, 0xa7, 0x52, 0xe4, 0xe5, 0x0d, 0x6a, 0x74, 0x1f, 0x2a, 0x51, 0xa6, 0x93,
-	0xec, 0xac, 0x89, 0x9c, 0xb0, 0xf3, 0x4c, 0xfd, 0x47, 0x69, 0x4f, 0xb2, 0xb3, 0x27, 0x72, 0xc2,
-	0x46, 0x34, 0xfa, 0x08, 0x56, 0xe7, 0x69, 0x49, 0xf2, 0xa3, 0x28, 0xf2, 0x05, 0x5a, 0xd3, 0x68,
-	0x02, 0x68, 0x01, 0x9d, 0xb9, 0xc0, 0xc9, 0x14, 0xf9, 0x22, 0x9d, 0x6a, 0x34, 0x80, 0xda, 0x2c,
-	0x47, 0x48, 0x7a, 0x52, 0x45, 0x4e, 0xdc, 0xb5, 0xe6, 0x4f, 0x89, 0xd2, 0x86, 0xa4, 0x27, 0x57,
-	0xe4, 0xc4, 0x4d, 0xec, 0x66, 0xfb, 0x8b, 0xaf, 0xd7, 0xd2, 0x5f, 0x7e, 0xbd, 0x96, 0xfe, 0xdb,
-	0xd7, 0x6b, 0xe9, 0xcf, 0xbe, 0x59, 0x4b, 0x7d, 0xf9, 0xcd, 0x5a, 0xea, 0xcf, 0xdf, 0xac, 0xa5,
-	0x7e, 0xf4, 0xc2, 0xd0, 0x20, 0xa3, 0x69, 0x6f, 0xa3, 0x6f, 0x4d, 0x36, 0xc3, 0x47, 0x00, 0x17,
-	0x1d, 0x4b, 0xec, 0x15, 0x58, 0xd2, 0x7d, 0xe5, 0x3f, 0x01, 0x00, 0x00, 0xff, 0xff, 0x48, 0xb2,
-	0x05, 0xf3, 0xb6, 0x28, 0x00, 0x00,
+func (m *RequestCreateSnapshot) Reset()         { *m = RequestCreateSnapshot{} }
+func (m *RequestCreateSnapshot) String() string { return proto.CompactTextString(m) }
+func (*RequestCreateSnapshot) ProtoMessage()    {}
+func (*RequestCreateSnapshot) Descriptor() ([]byte, []int) {
+	return fileDescriptor_252557cfdd89a31a, []int{49}
+}
+func (m *RequestCreateSnapshot) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RequestCreateSnapshot) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RequestCreateSnapshot.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RequestCreateSnapshot) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RequestCreateSnapshot.Merge(m, src)
+}
+func (m *RequestCreateSnapshot) XXX_Size() int {
+	return m.Size()
+}
+func (m *RequestCreateSnapshot) XXX_DiscardUnknown() {
+	xxx_messageInfo_RequestCreateSnapshot.DiscardUnknown(m)
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
+var xxx_messageInfo_RequestCreateSnapshot proto.InternalMessageInfo
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+func (m *RequestCreateSnapshot) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
 
-// ABCIApplicationClient is the client API for ABCIApplication service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type ABCIApplicationClient interface {
-	Echo(ctx context.Context, in *RequestEcho, opts ...grpc.CallOption) (*ResponseEcho, error)
-	Flush(ctx context.Context, in *RequestFlush, opts ...grpc.CallOption) (*ResponseFlush, error)
-	Info(ctx context.Context, in *RequestInfo, opts ...grpc.CallOption) (*ResponseInfo, error)
-	SetOption(ctx context.Context, in *RequestSetOption, opts ...grpc.CallOption) (*ResponseSetOption, error)
-	DeliverTx(ctx context.Context, in *RequestDeliverTx, opts ...grpc.CallOption) (*ResponseDeliverTx, error)
-	CheckTx(ctx context.Context, in *RequestCheckTx, opts ...grpc.CallOption) (*ResponseCheckTx, error)
-	Query(ctx context.Context, in *RequestQuery, opts ...grpc.CallOption) (*ResponseQuery, error)
-	Commit(ctx context.Context, in *RequestCommit, opts ...grpc.CallOption) (*ResponseCommit, error)
-	InitChain(ctx context.Context, in *RequestInitChain, opts ...grpc.CallOption) (*ResponseInitChain, error)
-	BeginBlock(ctx context.Context, in *RequestBeginBlock, opts ...grpc.CallOption) (*ResponseBeginBlock, error)
-	EndBlock(ctx context.Context, in *RequestEndBlock, opts ...grpc.CallOption) (*ResponseEndBlock, error)
-	ListSnapshots(ctx context.Context, in *RequestListSnapshots, opts ...grpc.CallOption) (*ResponseListSnapshots, error)
-	OfferSnapshot(ctx context.Context, in *RequestOfferSnapshot, opts ...grpc.CallOption) (*ResponseOfferSnapshot, error)
-	LoadSnapshotChunk(ctx context.Context, in *RequestLoadSnapshotChunk, opts ...grpc.CallOption) (*ResponseLoadSnapshotChunk, error)
+type ResponseCreateSnapshot struct {
+	Snapshots []*Snapshot `protobuf:"bytes,1,rep,name=snapshots,proto3" json:"snapshots,omitempty"`
+}
+
+func (m *ResponseCreateSnapshot) Reset()         { *m = ResponseCreateSnapshot{} }
+func (m *ResponseCreateSnapshot) String() string { return proto.CompactTextString(m) }
+func (*ResponseCreateSnapshot) ProtoMessage()    {}
+func (*ResponseCreateSnapshot) Descriptor() ([]byte, []int) {
+	return fileDescriptor_252557cfdd89a31a, []int{50}
+}
+func (m *ResponseCreateSnapshot) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ResponseCreateSnapshot) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ResponseCreateSnapshot.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ResponseCreateSnapshot) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResponseCreateSnapshot.Merge(m, src)
+}
+func (m *ResponseCreateSnapshot) XXX_Size() int {
+	return m.Size()
+}
+func (m *ResponseCreateSnapshot) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResponseCreateSnapshot.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResponseCreateSnapshot proto.InternalMessageInfo
+
+func (m *ResponseCreateSnapshot) GetSnapshots() []*Snapshot {
+	if m != nil {
+		return m.Snapshots
+	}
+	return nil
+}
+
+// Deletes the snapshots taken at the heights
+type RequestPruneSnapshots struct {
+	Heights []uint64 `protobuf:"varint,1,rep,packed,name=heights,proto3" json:"heights,omitempty"`
+}
+
+func (m *RequestPruneSnapshots) Reset()         { *m = RequestPruneSnapshots{} }
+func (m *RequestPruneSnapshots) String() string { return proto.CompactTextString(m) }
+func (*RequestPruneSnapshots) ProtoMessage()    {}
+func (*RequestPruneSnapshots) Descriptor() ([]byte, []int) {
+	return fileDescriptor_252557cfdd89a31a, []int{51}
+}
+func (m *RequestPruneSnapshots) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RequestPruneSnapshots) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RequestPruneSnapshots.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RequestPruneSnapshots) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RequestPruneSnapshots.Merge(m, src)
+}
+func (m *RequestPruneSnapshots) XXX_Size() int {
+	return m.Size()
+}
+func (m *RequestPruneSnapshots) XXX_DiscardUnknown() {
+	xxx_messageInfo_RequestPruneSnapshots.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RequestPruneSnapshots proto.InternalMessageInfo
+
+func (m *RequestPruneSnapshots) GetHeights() []uint64 {
+	if m != nil {
+		return m.Heights
+	}
+	return nil
+}
+
+type ResponsePruneSnapshots struct {
+}
+
+func (m *ResponsePruneSnapshots) Reset()         { *m = ResponsePruneSnapshots{} }
+func (m *ResponsePruneSnapshots) String() string { return proto.CompactTextString(m) }
+func (*ResponsePruneSnapshots) ProtoMessage()    {}
+func (*ResponsePruneSnapshots) Descriptor() ([]byte, []int) {
+	return fileDescriptor_252557cfdd89a31a, []int{52}
+}
+func (m *ResponsePruneSnapshots) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ResponsePruneSnapshots) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ResponsePruneSnapshots.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ResponsePruneSnapshots) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResponsePruneSnapshots.Merge(m, src)
+}
+func (m *ResponsePruneSnapshots) XXX_Size() int {
+	return m.Size()
+}
+func (m *ResponsePruneSnapshots) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResponsePruneSnapshots.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResponsePruneSnapshots proto.InternalMessageInfo
+
+// Asks whether to propose and gossip a piece of evidence, already verified by
+// the node
+type RequestVerifyEvidence struct {
+	// The hash of the evidence
+	Hash []byte `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	// The misbehavior of the evidence, one per faulty validator
+	ByzantineValidators []Evidence `protobuf:"bytes,2,rep,name=byzantine_validators,json=byzantineValidators,proto3" json:"byzantine_validators"`
+}
+
+func (m *RequestVerifyEvidence) Reset()         { *m = RequestVerifyEvidence{} }
+func (m *RequestVerifyEvidence) String() string { return proto.CompactTextString(m) }
+func (*RequestVerifyEvidence) ProtoMessage()    {}
+func (*RequestVerifyEvidence) Descriptor() ([]byte, []int) {
+	return fileDescriptor_252557cfdd89a31a, []int{53}
+}
+func (m *RequestVerifyEvidence) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RequestVerifyEvidence) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RequestVerifyEvidence.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RequestVerifyEvidence) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RequestVerifyEvidence.Merge(m, src)
+}
+func (m *RequestVerifyEvidence) XXX_Size() int {
+	return m.Size()
+}
+func (m *RequestVerifyEvidence) XXX_DiscardUnknown() {
+	xxx_messageInfo_RequestVerifyEvidence.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RequestVerifyEvidence proto.InternalMessageInfo
+
+func (m *RequestVerifyEvidence) GetHash() []byte {
+	if m != nil {
+		return m.Hash
+	}
+	return nil
+}
+
+func (m *RequestVerifyEvidence) GetByzantineValidators() []Evidence {
+	if m != nil {
+		return m.ByzantineValidators
+	}
+	return nil
+}
+
+type ResponseVerifyEvidence struct {
+	Result ResponseVerifyEvidence_Result `protobuf:"varint,1,opt,name=result,proto3,enum=tendermint.abci.ResponseVerifyEvidence_Result" json:"result,omitempty"`
+}
+
+func (m *ResponseVerifyEvidence) Reset()         { *m = ResponseVerifyEvidence{} }
+func (m *ResponseVerifyEvidence) String() string { return proto.CompactTextString(m) }
+func (*ResponseVerifyEvidence) ProtoMessage()    {}
+func (*ResponseVerifyEvidence) Descriptor() ([]byte, []int) {
+	return fileDescriptor_252557cfdd89a31a, []int{54}
+}
+func (m *ResponseVerifyEvidence) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ResponseVerifyEvidence) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ResponseVerifyEvidence.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ResponseVerifyEvidence) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResponseVerifyEvidence.Merge(m, src)
+}
+func (m *ResponseVerifyEvidence) XXX_Size() int {
+	return m.Size()
+}
+func (m *ResponseVerifyEvidence) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResponseVerifyEvidence.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResponseVerifyEvidence proto.InternalMessageInfo
+
+func (m *ResponseVerifyEvidence) GetResult() ResponseVerifyEvidence_Result {
+	if m != nil {
+		return m.Result
+	}
+	return ResponseVerifyEvidence_UNKNOWN
+}
+
+// The minimum age up to which the application requires evidence to be
+// accepted, e.g. to slash validators until they can unbond. The evidence
+// params of the consensus params must not allow evidence to expire earlier.
+type EvidenceConstraints struct {
+	MinMaxAgeNumBlocks int64         `protobuf:"varint,1,opt,name=min_max_age_num_blocks,json=minMaxAgeNumBlocks,proto3" json:"min_max_age_num_blocks,omitempty"`
+	MinMaxAgeDuration  time.Duration `protobuf:"bytes,2,opt,name=min_max_age_duration,json=minMaxAgeDuration,proto3,stdduration" json:"min_max_age_duration"`
+}
+
+func (m *EvidenceConstraints) Reset()         { *m = EvidenceConstraints{} }
+func (m *EvidenceConstraints) String() string { return proto.CompactTextString(m) }
+func (*EvidenceConstraints) ProtoMessage()    {}
+func (*EvidenceConstraints) Descriptor() ([]byte, []int) {
+	return fileDescriptor_252557cfdd89a31a, []int{55}
+}
+func (m *EvidenceConstraints) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *EvidenceConstraints) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_EvidenceConstraints.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *EvidenceConstraints) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EvidenceConstraints.Merge(m, src)
+}
+func (m *EvidenceConstraints) XXX_Size() int {
+	return m.Size()
+}
+func (m *EvidenceConstraints) XXX_DiscardUnknown() {
+	xxx_messageInfo_EvidenceConstraints.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EvidenceConstraints proto.InternalMessageInfo
+
+func (m *EvidenceConstraints) GetMinMaxAgeNumBlocks() int64 {
+	if m != nil {
+		return m.MinMaxAgeNumBlocks
+	}
+	return 0
+}
+
+func (m *EvidenceConstraints) GetMinMaxAgeDuration() time.Duration {
+	if m != nil {
+		return m.MinMaxAgeDuration
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterEnum("tendermint.abci.CheckTxType", CheckTxType_name, CheckTxType_value)
+	proto.RegisterEnum("tendermint.abci.EvidenceType", EvidenceType_name, EvidenceType_value)
+	proto.RegisterEnum("tendermint.abci.ResponseOfferSnapshot_Result", ResponseOfferSnapshot_Result_name, ResponseOfferSnapshot_Result_value)
+	proto.RegisterEnum("tendermint.abci.ResponseApplySnapshotChunk_Result", ResponseApplySnapshotChunk_Result_name, ResponseApplySnapshotChunk_Result_value)
+	proto.RegisterEnum("tendermint.abci.ResponseProcessProposal_Result", ResponseProcessProposal_Result_name, ResponseProcessProposal_Result_value)
+	proto.RegisterEnum("tendermint.abci.ResponseVerifyEvidence_Result", ResponseVerifyEvidence_Result_name, ResponseVerifyEvidence_Result_value)
+	proto.RegisterType((*Request)(nil), "tendermint.abci.Request")
+	proto.RegisterType((*RequestEcho)(nil), "tendermint.abci.RequestEcho")
+	proto.RegisterType((*RequestFlush)(nil), "tendermint.abci.RequestFlush")
+	proto.RegisterType((*RequestInfo)(nil), "tendermint.abci.RequestInfo")
+	proto.RegisterType((*RequestSetOption)(nil), "tendermint.abci.RequestSetOption")
+	proto.RegisterType((*RequestInitChain)(nil), "tendermint.abci.RequestInitChain")
+	proto.RegisterType((*RequestQuery)(nil), "tendermint.abci.RequestQuery")
+	proto.RegisterType((*RequestBeginBlock)(nil), "tendermint.abci.RequestBeginBlock")
+	proto.RegisterType((*RequestCheckTx)(nil), "tendermint.abci.RequestCheckTx")
+	proto.RegisterType((*RequestDeliverTx)(nil), "tendermint.abci.RequestDeliverTx")
+	proto.RegisterType((*RequestEndBlock)(nil), "tendermint.abci.RequestEndBlock")
+	proto.RegisterType((*RequestCommit)(nil), "tendermint.abci.RequestCommit")
+	proto.RegisterType((*RequestListSnapshots)(nil), "tendermint.abci.RequestListSnapshots")
+	proto.RegisterType((*RequestOfferSnapshot)(nil), "tendermint.abci.RequestOfferSnapshot")
+	proto.RegisterType((*RequestLoadSnapshotChunk)(nil), "tendermint.abci.RequestLoadSnapshotChunk")
+	proto.RegisterType((*RequestApplySnapshotChunk)(nil), "tendermint.abci.RequestApplySnapshotChunk")
+	proto.RegisterType((*RequestPrepareProposal)(nil), "tendermint.abci.RequestPrepareProposal")
+	proto.RegisterType((*RequestProcessProposal)(nil), "tendermint.abci.RequestProcessProposal")
+	proto.RegisterType((*Response)(nil), "tendermint.abci.Response")
+	proto.RegisterType((*ResponseException)(nil), "tendermint.abci.ResponseException")
+	proto.RegisterType((*ResponseEcho)(nil), "tendermint.abci.ResponseEcho")
+	proto.RegisterType((*ResponseFlush)(nil), "tendermint.abci.ResponseFlush")
+	proto.RegisterType((*ResponseInfo)(nil), "tendermint.abci.ResponseInfo")
+	proto.RegisterType((*MempoolRequirements)(nil), "tendermint.abci.MempoolRequirements")
+	proto.RegisterType((*ResponseSetOption)(nil), "tendermint.abci.ResponseSetOption")
+	proto.RegisterType((*ResponseInitChain)(nil), "tendermint.abci.ResponseInitChain")
+	proto.RegisterType((*ResponseQuery)(nil), "tendermint.abci.ResponseQuery")
+	proto.RegisterType((*ResponseBeginBlock)(nil), "tendermint.abci.ResponseBeginBlock")
+	proto.RegisterType((*ResponseCheckTx)(nil), "tendermint.abci.ResponseCheckTx")
+	proto.RegisterType((*ResponseDeliverTx)(nil), "tendermint.abci.ResponseDeliverTx")
+	proto.RegisterType((*ResponseEndBlock)(nil), "tendermint.abci.ResponseEndBlock")
+	proto.RegisterType((*ResponseCommit)(nil), "tendermint.abci.ResponseCommit")
+	proto.RegisterType((*ResponseListSnapshots)(nil), "tendermint.abci.ResponseListSnapshots")
+	proto.RegisterType((*ResponseOfferSnapshot)(nil), "tendermint.abci.ResponseOfferSnapshot")
+	proto.RegisterType((*ResponseLoadSnapshotChunk)(nil), "tendermint.abci.ResponseLoadSnapshotChunk")
+	proto.RegisterType((*ResponseApplySnapshotChunk)(nil), "tendermint.abci.ResponseApplySnapshotChunk")
+	proto.RegisterType((*ResponsePrepareProposal)(nil), "tendermint.abci.ResponsePrepareProposal")
+	proto.RegisterType((*ResponseProcessProposal)(nil), "tendermint.abci.ResponseProcessProposal")
+	proto.RegisterType((*ConsensusParams)(nil), "tendermint.abci.ConsensusParams")
+	proto.RegisterType((*BlockParams)(nil), "tendermint.abci.BlockParams")
+	proto.RegisterType((*LastCommitInfo)(nil), "tendermint.abci.LastCommitInfo")
+	proto.RegisterType((*Event)(nil), "tendermint.abci.Event")
+	proto.RegisterType((*EventAttribute)(nil), "tendermint.abci.EventAttribute")
+	proto.RegisterType((*TxResult)(nil), "tendermint.abci.TxResult")
+	proto.RegisterType((*Validator)(nil), "tendermint.abci.Validator")
+	proto.RegisterType((*ValidatorUpdate)(nil), "tendermint.abci.ValidatorUpdate")
+	proto.RegisterType((*VoteInfo)(nil), "tendermint.abci.VoteInfo")
+	proto.RegisterType((*Evidence)(nil), "tendermint.abci.Evidence")
+	proto.RegisterType((*Snapshot)(nil), "tendermint.abci.Snapshot")
+	proto.RegisterType((*RequestCreateSnapshot)(nil), "tendermint.abci.RequestCreateSnapshot")
+	proto.RegisterType((*ResponseCreateSnapshot)(nil), "tendermint.abci.ResponseCreateSnapshot")
+	proto.RegisterType((*RequestPruneSnapshots)(nil), "tendermint.abci.RequestPruneSnapshots")
+	proto.RegisterType((*ResponsePruneSnapshots)(nil), "tendermint.abci.ResponsePruneSnapshots")
+	proto.RegisterType((*RequestVerifyEvidence)(nil), "tendermint.abci.RequestVerifyEvidence")
+	proto.RegisterType((*ResponseVerifyEvidence)(nil), "tendermint.abci.ResponseVerifyEvidence")
+	proto.RegisterType((*EvidenceConstraints)(nil), "tendermint.abci.EvidenceConstraints")
+}
+
+func init() { proto.RegisterFile("tendermint/abci/types.proto", fileDescriptor_252557cfdd89a31a) }
+
+var fileDescriptor_252557cfdd89a31a = []byte{
+	// 3466 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe4, 0x5b, 0xcd, 0x73, 0x1b, 0xd7,
+	0x91, 0x27, 0x3e, 0x48, 0x00, 0x8d, 0x4f, 0x3e, 0x52, 0x14, 0x34, 0x96, 0x49, 0xee, 0x78, 0x6d,
+	0xcb, 0xb2, 0x4d, 0xae, 0xe9, 0xf2, 0xd7, 0x7a, 0x3f, 0x4c, 0x42, 0x90, 0x40, 0x4b, 0x22, 0xe9,
+	0x47, 0x48, 0xde, 0x5d, 0xaf, 0x35, 0x1e, 0x02, 0x8f, 0xc0, 0x58, 0xc0, 0xcc, 0x78, 0x66, 0x40,
+	0x93, 0xba, 0xa4, 0x2a, 0x95, 0x54, 0xaa, 0x9c, 0xaa, 0xc4, 0x47, 0xa7, 0x2a, 0xae, 0xfc, 0x05,
+	0xb9, 0xe6, 0x98, 0xb3, 0xab, 0x72, 0xf1, 0x31, 0x87, 0x94, 0x93, 0xb2, 0x73, 0xca, 0x3f, 0x90,
+	0x5c, 0x52, 0x49, 0xbd, 0xaf, 0xc1, 0xcc, 0x00, 0x43, 0x0c, 0xa5, 0xdc, 0x72, 0x9b, 0xd7, 0xe8,
+	0xee, 0xf7, 0xdd, 0xdd, 0xbf, 0xee, 0x07, 0x78, 0xca, 0x23, 0x66, 0x97, 0x38, 0x43, 0xc3, 0xf4,
+	0x36, 0xf5, 0xa3, 0x8e, 0xb1, 0xe9, 0x9d, 0xd9, 0xc4, 0xdd, 0xb0, 0x1d, 0xcb, 0xb3, 0x50, 0x75,
+	0xfc, 0xe3, 0x06, 0xfd, 0x51, 0x79, 0x3a, 0xc0, 0xdd, 0x71, 0xce, 0x6c, 0xcf, 0xda, 0xb4, 0x1d,
+	0xcb, 0x3a, 0xe6, 0xfc, 0xca, 0xd5, 0xc0, 0xcf, 0x4c, 0x4f, 0x50, 0x9b, 0x72, 0x75, 0x52, 0xf8,
+	0x21, 0x39, 0x93, 0xbf, 0x3e, 0x3d, 0x21, 0x6b, 0xeb, 0x8e, 0x3e, 0x94, 0x3f, 0xaf, 0xf5, 0x2c,
+	0xab, 0x37, 0x20, 0x9b, 0xac, 0x75, 0x34, 0x3a, 0xde, 0xf4, 0x8c, 0x21, 0x71, 0x3d, 0x7d, 0x68,
+	0x0b, 0x86, 0xd5, 0x28, 0x43, 0x77, 0xe4, 0xe8, 0x9e, 0x61, 0x99, 0xe2, 0xf7, 0xe5, 0x9e, 0xd5,
+	0xb3, 0xd8, 0xe7, 0x26, 0xfd, 0xe2, 0x54, 0xf5, 0x57, 0x45, 0xc8, 0x61, 0xf2, 0xc9, 0x88, 0xb8,
+	0x1e, 0xda, 0x82, 0x2c, 0xe9, 0xf4, 0xad, 0x7a, 0x6a, 0x3d, 0x75, 0xad, 0xb8, 0x75, 0x75, 0x23,
+	0x32, 0xf9, 0x0d, 0xc1, 0xd7, 0xec, 0xf4, 0xad, 0xd6, 0x1c, 0x66, 0xbc, 0xe8, 0x35, 0x98, 0x3f,
+	0x1e, 0x8c, 0xdc, 0x7e, 0x3d, 0xcd, 0x84, 0x9e, 0x8e, 0x13, 0xba, 0x49, 0x99, 0x5a, 0x73, 0x98,
+	0x73, 0xd3, 0xae, 0x0c, 0xf3, 0xd8, 0xaa, 0x67, 0xce, 0xef, 0x6a, 0xd7, 0x3c, 0x66, 0x5d, 0x51,
+	0x5e, 0xb4, 0x03, 0xe0, 0x12, 0x4f, 0xb3, 0x6c, 0x3a, 0xa9, 0x7a, 0x96, 0x49, 0xfe, 0x4b, 0x9c,
+	0xe4, 0x21, 0xf1, 0xf6, 0x19, 0x63, 0x6b, 0x0e, 0x17, 0x5c, 0xd9, 0xa0, 0x3a, 0x0c, 0xd3, 0xf0,
+	0xb4, 0x4e, 0x5f, 0x37, 0xcc, 0xfa, 0xfc, 0xf9, 0x3a, 0x76, 0x4d, 0xc3, 0x6b, 0x50, 0x46, 0xaa,
+	0xc3, 0x90, 0x0d, 0x3a, 0xe5, 0x4f, 0x46, 0xc4, 0x39, 0xab, 0x2f, 0x9c, 0x3f, 0xe5, 0xf7, 0x28,
+	0x13, 0x9d, 0x32, 0xe3, 0x46, 0x4d, 0x28, 0x1e, 0x91, 0x9e, 0x61, 0x6a, 0x47, 0x03, 0xab, 0xf3,
+	0xb0, 0x9e, 0x63, 0xc2, 0x6a, 0x9c, 0xf0, 0x0e, 0x65, 0xdd, 0xa1, 0x9c, 0xad, 0x39, 0x0c, 0x47,
+	0x7e, 0x0b, 0xfd, 0x07, 0xe4, 0x3b, 0x7d, 0xd2, 0x79, 0xa8, 0x79, 0xa7, 0xf5, 0x3c, 0xd3, 0xb1,
+	0x16, 0xa7, 0xa3, 0x41, 0xf9, 0xda, 0xa7, 0xad, 0x39, 0x9c, 0xeb, 0xf0, 0x4f, 0x3a, 0xff, 0x2e,
+	0x19, 0x18, 0x27, 0xc4, 0xa1, 0xf2, 0x85, 0xf3, 0xe7, 0x7f, 0x83, 0x73, 0x32, 0x0d, 0x85, 0xae,
+	0x6c, 0xa0, 0xff, 0x86, 0x02, 0x31, 0xbb, 0x62, 0x1a, 0xc0, 0x54, 0xac, 0xc7, 0x9e, 0x15, 0xb3,
+	0x2b, 0x27, 0x91, 0x27, 0xe2, 0x1b, 0xbd, 0x09, 0x0b, 0x1d, 0x6b, 0x38, 0x34, 0xbc, 0x7a, 0x91,
+	0x49, 0xaf, 0xc6, 0x4e, 0x80, 0x71, 0xb5, 0xe6, 0xb0, 0xe0, 0x47, 0x7b, 0x50, 0x19, 0x18, 0xae,
+	0xa7, 0xb9, 0xa6, 0x6e, 0xbb, 0x7d, 0xcb, 0x73, 0xeb, 0x25, 0xa6, 0xe1, 0xd9, 0x38, 0x0d, 0x77,
+	0x0c, 0xd7, 0x3b, 0x94, 0xcc, 0xad, 0x39, 0x5c, 0x1e, 0x04, 0x09, 0x54, 0x9f, 0x75, 0x7c, 0x4c,
+	0x1c, 0x5f, 0x61, 0xbd, 0x7c, 0xbe, 0xbe, 0x7d, 0xca, 0x2d, 0xe5, 0xa9, 0x3e, 0x2b, 0x48, 0x40,
+	0x1f, 0xc0, 0xd2, 0xc0, 0xd2, 0xbb, 0xbe, 0x3a, 0xad, 0xd3, 0x1f, 0x99, 0x0f, 0xeb, 0x15, 0xa6,
+	0xf4, 0x85, 0xd8, 0x41, 0x5a, 0x7a, 0x57, 0xaa, 0x68, 0x50, 0x81, 0xd6, 0x1c, 0x5e, 0x1c, 0x44,
+	0x89, 0xe8, 0x01, 0x2c, 0xeb, 0xb6, 0x3d, 0x38, 0x8b, 0x6a, 0xaf, 0x32, 0xed, 0xd7, 0xe3, 0xb4,
+	0x6f, 0x53, 0x99, 0xa8, 0x7a, 0xa4, 0x4f, 0x50, 0x51, 0x1b, 0x6a, 0xb6, 0x43, 0x6c, 0xdd, 0x21,
+	0x9a, 0xed, 0x58, 0xb6, 0xe5, 0xea, 0x83, 0x7a, 0x8d, 0xe9, 0x7e, 0x3e, 0x4e, 0xf7, 0x01, 0xe7,
+	0x3f, 0x10, 0xec, 0xad, 0x39, 0x5c, 0xb5, 0xc3, 0x24, 0xae, 0xd5, 0xea, 0x10, 0xd7, 0x1d, 0x6b,
+	0x5d, 0x9c, 0xa5, 0x95, 0xf1, 0x87, 0xb5, 0x86, 0x48, 0xe8, 0x3d, 0xa8, 0x76, 0x1c, 0xa2, 0x7b,
+	0x64, 0xbc, 0x73, 0x88, 0x29, 0x7d, 0x2e, 0xf6, 0x2c, 0x31, 0xf6, 0xc0, 0xd6, 0x55, 0x3a, 0x21,
+	0x0a, 0x55, 0x69, 0x3b, 0x23, 0x93, 0x04, 0x0e, 0xd7, 0xd2, 0xf9, 0x2a, 0x0f, 0x28, 0x7b, 0xf0,
+	0x74, 0x55, 0xec, 0x10, 0x85, 0xaa, 0x3c, 0x21, 0x8e, 0x71, 0x7c, 0xa6, 0x91, 0x13, 0xa3, 0x4b,
+	0xcc, 0x0e, 0xa9, 0x2f, 0x9f, 0xaf, 0xf2, 0x3e, 0x63, 0x6f, 0x0a, 0x6e, 0xaa, 0xf2, 0x24, 0x44,
+	0xd9, 0xc9, 0xc1, 0xfc, 0x89, 0x3e, 0x18, 0x11, 0xf5, 0x79, 0x28, 0x06, 0xec, 0x31, 0xaa, 0x43,
+	0x6e, 0x48, 0x5c, 0x57, 0xef, 0x11, 0x66, 0xbe, 0x0b, 0x58, 0x36, 0xd5, 0x0a, 0x94, 0x82, 0x36,
+	0x58, 0x1d, 0x42, 0x31, 0x60, 0x5d, 0xa9, 0xe0, 0x09, 0x71, 0x5c, 0x6a, 0x52, 0x85, 0xa0, 0x68,
+	0xa2, 0x67, 0xa0, 0xcc, 0xee, 0xb8, 0x26, 0x7f, 0xa7, 0x26, 0x3e, 0x8b, 0x4b, 0x8c, 0x78, 0x5f,
+	0x30, 0xad, 0x41, 0xd1, 0xde, 0xb2, 0x7d, 0x96, 0x0c, 0x63, 0x01, 0x7b, 0xcb, 0x16, 0x0c, 0xea,
+	0xbf, 0x43, 0x2d, 0x6a, 0x92, 0x51, 0x0d, 0x32, 0x0f, 0xc9, 0x99, 0xe8, 0x8f, 0x7e, 0xa2, 0x65,
+	0x31, 0x2d, 0xd6, 0x47, 0x01, 0x8b, 0x39, 0xfe, 0x26, 0x0d, 0xb5, 0xa8, 0x2d, 0x46, 0x6f, 0x42,
+	0x96, 0xba, 0x3e, 0xe1, 0xa5, 0x94, 0x0d, 0xee, 0xf6, 0x36, 0xa4, 0xdb, 0xdb, 0x68, 0x4b, 0xbf,
+	0xb8, 0x93, 0xff, 0xea, 0x9b, 0xb5, 0xb9, 0xcf, 0x7f, 0xbf, 0x96, 0xc2, 0x4c, 0x02, 0x5d, 0xa1,
+	0xa6, 0x53, 0x37, 0x4c, 0xcd, 0xe8, 0x8a, 0x7e, 0x72, 0xac, 0xbd, 0xdb, 0x45, 0xb7, 0xa1, 0xd6,
+	0xb1, 0x4c, 0x97, 0x98, 0xee, 0xc8, 0xd5, 0xb8, 0xdf, 0xad, 0x67, 0x62, 0x4c, 0x5b, 0x43, 0x32,
+	0x1e, 0x30, 0x3e, 0x5c, 0xed, 0x84, 0x09, 0xe8, 0x26, 0xc0, 0x89, 0x3e, 0x30, 0xba, 0xba, 0x67,
+	0x39, 0x6e, 0x3d, 0xbb, 0x9e, 0x99, 0xaa, 0xe6, 0xbe, 0x64, 0xb9, 0x67, 0x77, 0x75, 0x8f, 0xec,
+	0x64, 0xe9, 0x68, 0x71, 0x40, 0x12, 0x3d, 0x07, 0x55, 0xdd, 0xb6, 0x35, 0xd7, 0xa3, 0xe7, 0xfc,
+	0xe8, 0xcc, 0x23, 0x2e, 0xf3, 0x58, 0x25, 0x5c, 0xd6, 0x6d, 0xfb, 0x90, 0x52, 0x77, 0x28, 0x11,
+	0x3d, 0x0b, 0x15, 0xc3, 0x34, 0x3c, 0x43, 0x1f, 0x68, 0x7d, 0x62, 0xf4, 0xfa, 0x1e, 0xf3, 0x4c,
+	0x19, 0x5c, 0x16, 0xd4, 0x16, 0x23, 0xaa, 0x5d, 0x28, 0x05, 0x3d, 0x13, 0x42, 0x90, 0xed, 0xea,
+	0x9e, 0xce, 0x16, 0xb2, 0x84, 0xd9, 0x37, 0xa5, 0xd9, 0xba, 0xd7, 0x17, 0xcb, 0xc3, 0xbe, 0xd1,
+	0x0a, 0x2c, 0x08, 0xb5, 0x19, 0xa6, 0x56, 0xb4, 0xe8, 0x9e, 0xd9, 0x8e, 0x75, 0x42, 0x98, 0x2b,
+	0xce, 0x63, 0xde, 0x50, 0x7f, 0x90, 0x86, 0xc5, 0x09, 0x1f, 0x46, 0xf5, 0xf6, 0x75, 0xb7, 0x2f,
+	0xfb, 0xa2, 0xdf, 0xe8, 0x75, 0xaa, 0x57, 0xef, 0x12, 0x47, 0xc4, 0x0e, 0xf5, 0xe0, 0x12, 0xf1,
+	0xb8, 0xa9, 0xc5, 0x7e, 0x17, 0x4b, 0x23, 0xb8, 0xd1, 0x3e, 0xd4, 0x06, 0xba, 0xeb, 0x69, 0xdc,
+	0x27, 0x68, 0x81, 0x38, 0x62, 0xd2, 0x13, 0xde, 0xd1, 0xa5, 0x17, 0xa1, 0x87, 0x5d, 0x28, 0xaa,
+	0x0c, 0x42, 0x54, 0x84, 0x61, 0xf9, 0xe8, 0xec, 0x91, 0x6e, 0x7a, 0x86, 0x49, 0xb4, 0x89, 0x9d,
+	0xbb, 0x32, 0xa1, 0xd4, 0xbf, 0x8c, 0x5c, 0xdd, 0x92, 0x2f, 0xec, 0x6f, 0xa9, 0xab, 0x62, 0xa8,
+	0x84, 0xbd, 0x30, 0xaa, 0x40, 0xda, 0x3b, 0x15, 0x0b, 0x90, 0xf6, 0x4e, 0xd1, 0xbf, 0x41, 0x96,
+	0x4e, 0x92, 0x4d, 0xbe, 0x32, 0x25, 0x04, 0x12, 0x72, 0xed, 0x33, 0x9b, 0x60, 0xc6, 0xa9, 0xaa,
+	0x50, 0x8b, 0x7a, 0xe6, 0xa8, 0x56, 0xf5, 0x05, 0xa8, 0x46, 0x5c, 0x6f, 0x60, 0xff, 0x52, 0xc1,
+	0xfd, 0x53, 0xab, 0x50, 0x0e, 0xf9, 0x59, 0x75, 0x05, 0x96, 0xa7, 0xb9, 0x4d, 0xb5, 0x0f, 0xcb,
+	0xd3, 0xdc, 0x1f, 0x7a, 0x0d, 0xf2, 0xbe, 0xf5, 0xe5, 0xb7, 0x71, 0x72, 0xad, 0x24, 0x33, 0xf6,
+	0x59, 0xe9, 0x35, 0xa4, 0xc7, 0x9a, 0x9d, 0x87, 0x34, 0x1b, 0x78, 0x4e, 0xb7, 0xed, 0x96, 0xee,
+	0xf6, 0xd5, 0x8f, 0xa0, 0x1e, 0xe7, 0x13, 0x23, 0xd3, 0xc8, 0xfa, 0xc7, 0x70, 0x05, 0x16, 0x8e,
+	0x2d, 0x67, 0xa8, 0x7b, 0x4c, 0x59, 0x19, 0x8b, 0x16, 0x3d, 0x9e, 0xdc, 0x3f, 0x66, 0x18, 0x99,
+	0x37, 0x54, 0x0d, 0xae, 0xc4, 0xfa, 0x45, 0x2a, 0x62, 0x98, 0x5d, 0xc2, 0xd7, 0xb3, 0x8c, 0x79,
+	0x63, 0xac, 0x88, 0x0f, 0x96, 0x37, 0x68, 0xb7, 0x2e, 0x9b, 0x2b, 0xd3, 0x5f, 0xc0, 0xa2, 0xa5,
+	0xfe, 0x31, 0x05, 0x2b, 0xd3, 0xbd, 0x23, 0x7a, 0x0d, 0x80, 0x1b, 0x54, 0xff, 0xda, 0x15, 0xb7,
+	0x56, 0x26, 0x0f, 0xfd, 0x0d, 0xdd, 0xd3, 0x71, 0x81, 0x71, 0xd2, 0x4f, 0x6a, 0x06, 0xc6, 0x62,
+	0x9a, 0x6b, 0x3c, 0xe2, 0x67, 0x26, 0x83, 0xcb, 0x3e, 0xcf, 0xa1, 0xf1, 0x28, 0x6c, 0xde, 0x32,
+	0x61, 0xf3, 0x36, 0x5e, 0xbb, 0x6c, 0xe8, 0x0a, 0x4b, 0x5b, 0x3a, 0x7f, 0x51, 0x5b, 0xaa, 0xfe,
+	0x28, 0x38, 0xcd, 0xb0, 0x6f, 0x1e, 0xdf, 0xeb, 0xd4, 0x85, 0xee, 0x75, 0x78, 0x79, 0xd2, 0x09,
+	0x97, 0x47, 0xfd, 0x69, 0x09, 0xf2, 0x98, 0xb8, 0x36, 0x35, 0xc2, 0x68, 0x07, 0x0a, 0xe4, 0xb4,
+	0x43, 0x38, 0x44, 0x48, 0xc5, 0x86, 0xd8, 0x9c, 0xbb, 0x29, 0x39, 0x69, 0x7c, 0xeb, 0x8b, 0xa1,
+	0x57, 0x05, 0x0c, 0x8a, 0x47, 0x34, 0x42, 0x3c, 0x88, 0x83, 0x5e, 0x97, 0x38, 0x28, 0x13, 0x1b,
+	0xd2, 0x72, 0xa9, 0x08, 0x10, 0x7a, 0x55, 0x00, 0xa1, 0xec, 0x8c, 0xce, 0x42, 0x48, 0xa8, 0x11,
+	0x42, 0x42, 0xf3, 0x33, 0xa6, 0x19, 0x03, 0x85, 0x1a, 0x21, 0x28, 0xb4, 0x30, 0x43, 0x49, 0x0c,
+	0x16, 0x7a, 0x5d, 0x62, 0xa1, 0xdc, 0x8c, 0x69, 0x47, 0xc0, 0xd0, 0xcd, 0x30, 0x18, 0xe2, 0x40,
+	0xe6, 0x99, 0x58, 0xe9, 0x58, 0x34, 0xf4, 0x9f, 0x01, 0x34, 0x54, 0x88, 0x85, 0x22, 0x5c, 0xc9,
+	0x14, 0x38, 0xd4, 0x08, 0xc1, 0x21, 0x98, 0xb1, 0x06, 0x31, 0x78, 0xe8, 0x9d, 0x20, 0x1e, 0x2a,
+	0xc6, 0x42, 0x2a, 0x71, 0x68, 0xa6, 0x01, 0xa2, 0xb7, 0x7c, 0x40, 0x54, 0x8a, 0x45, 0x74, 0x62,
+	0x0e, 0x51, 0x44, 0xb4, 0x3f, 0x81, 0x88, 0xca, 0xb1, 0x11, 0x26, 0x57, 0x31, 0x03, 0x12, 0xed,
+	0x4f, 0x40, 0xa2, 0xca, 0x0c, 0x85, 0x33, 0x30, 0xd1, 0xff, 0x4f, 0xc7, 0x44, 0xf1, 0xa8, 0x45,
+	0x0c, 0x33, 0x19, 0x28, 0xd2, 0x62, 0x40, 0x11, 0x07, 0x2e, 0x2f, 0xc6, 0xaa, 0x4f, 0x8c, 0x8a,
+	0xee, 0x4d, 0x41, 0x45, 0x1c, 0xbf, 0x5c, 0x8b, 0x55, 0x9e, 0x00, 0x16, 0xdd, 0x9b, 0x02, 0x8b,
+	0xd0, 0x4c, 0xb5, 0x33, 0x71, 0x11, 0x9e, 0xc4, 0x45, 0x4b, 0xb1, 0x60, 0x4b, 0x1c, 0xa9, 0x59,
+	0xc0, 0x08, 0x4f, 0x02, 0xa3, 0xe5, 0x19, 0x3a, 0x67, 0x22, 0x23, 0x3c, 0x89, 0x8c, 0x2e, 0xcd,
+	0xd0, 0x99, 0x1c, 0x1a, 0xbd, 0x00, 0x8b, 0x52, 0xc8, 0x37, 0xf1, 0xd4, 0x8b, 0x13, 0xc7, 0xb1,
+	0x1c, 0x81, 0x3a, 0x78, 0x43, 0xbd, 0x06, 0x25, 0x9f, 0xf5, 0x7c, 0x18, 0xc5, 0xa2, 0xa5, 0x80,
+	0x09, 0x57, 0x7f, 0x92, 0x81, 0x52, 0xd0, 0x3a, 0x87, 0xe2, 0xe9, 0x82, 0x88, 0xa7, 0x03, 0xe8,
+	0x2a, 0x1d, 0x46, 0x57, 0x6b, 0x50, 0xa4, 0x51, 0x50, 0x04, 0x38, 0xe9, 0xb6, 0x04, 0x4e, 0xe8,
+	0x3a, 0x2c, 0xb2, 0x30, 0x97, 0xfb, 0xc4, 0x90, 0xfb, 0xae, 0xd2, 0x1f, 0xb8, 0x19, 0x61, 0x64,
+	0xf4, 0x32, 0x2c, 0x05, 0x78, 0xfd, 0xe8, 0x8a, 0xa3, 0x85, 0x9a, 0xcf, 0xbd, 0xcd, 0xc3, 0x2c,
+	0xa4, 0x42, 0xa9, 0xa3, 0xdb, 0xfa, 0x91, 0x31, 0x30, 0x3c, 0x83, 0xb8, 0xf5, 0x85, 0xf5, 0xcc,
+	0xb5, 0x02, 0x0e, 0xd1, 0xd0, 0xfb, 0xb0, 0x3c, 0x24, 0x43, 0xdb, 0xb2, 0x06, 0x9a, 0x43, 0x3e,
+	0x19, 0x19, 0x0e, 0x19, 0x12, 0xd3, 0x73, 0x85, 0xa1, 0xff, 0xd7, 0x89, 0x6d, 0xba, 0xcb, 0x99,
+	0x71, 0x80, 0x17, 0x2f, 0x0d, 0x27, 0x89, 0x54, 0xb1, 0xdc, 0x73, 0x8d, 0x22, 0x27, 0xcf, 0xd1,
+	0x0d, 0xaa, 0x38, 0x1f, 0xa3, 0x58, 0xee, 0x6f, 0x63, 0xcc, 0x8b, 0x97, 0xc8, 0x24, 0x51, 0xfd,
+	0x00, 0x96, 0xa6, 0x0c, 0x02, 0xa9, 0x50, 0x1e, 0x1a, 0xa6, 0xd6, 0xd3, 0xe9, 0x4d, 0x33, 0x3a,
+	0x72, 0x63, 0x8b, 0x43, 0xc3, 0xbc, 0xa5, 0xbb, 0x07, 0x94, 0x84, 0xd6, 0xa1, 0x34, 0xd4, 0x4f,
+	0x35, 0xef, 0x54, 0xc0, 0x2c, 0x1e, 0x5f, 0xc1, 0x50, 0x3f, 0x6d, 0x9f, 0x32, 0x8c, 0xa5, 0xde,
+	0x85, 0xc5, 0x09, 0x7f, 0x4a, 0x77, 0xbc, 0x63, 0x75, 0x89, 0x08, 0x17, 0xd9, 0x37, 0xc5, 0xb6,
+	0x03, 0xab, 0x27, 0x02, 0x30, 0xfa, 0x49, 0xb9, 0x7c, 0x17, 0x5f, 0xe0, 0x1e, 0x5c, 0xfd, 0x79,
+	0x1a, 0x16, 0x27, 0x5c, 0xeb, 0x54, 0x14, 0x9a, 0xfa, 0xc7, 0xa0, 0xd0, 0xf4, 0x63, 0xa3, 0xd0,
+	0x60, 0xb8, 0x9e, 0x09, 0x85, 0xeb, 0xb1, 0x5b, 0x99, 0x7d, 0xd2, 0xad, 0xfc, 0x73, 0x0a, 0xca,
+	0xa1, 0xc8, 0xe1, 0xf1, 0x97, 0x7a, 0x1c, 0xd4, 0xcf, 0xb3, 0x4d, 0xe5, 0x0d, 0x99, 0x82, 0x58,
+	0x60, 0x13, 0x0a, 0xa7, 0x20, 0x72, 0x3c, 0xcc, 0x67, 0x0d, 0xf4, 0x26, 0x14, 0x58, 0x82, 0x5f,
+	0xb3, 0x6c, 0x79, 0x44, 0x9f, 0x0a, 0xce, 0x8b, 0xe7, 0xf1, 0x37, 0x0e, 0x28, 0xcf, 0xbe, 0xed,
+	0xe2, 0xbc, 0x2d, 0xbe, 0x02, 0x31, 0x77, 0x21, 0x14, 0x73, 0x5f, 0x85, 0x02, 0x1d, 0xbd, 0x6b,
+	0xeb, 0x1d, 0xc2, 0x42, 0x8e, 0x02, 0x1e, 0x13, 0xd4, 0x07, 0x80, 0x26, 0x83, 0x1e, 0xd4, 0x82,
+	0x05, 0x72, 0xc2, 0xae, 0x5f, 0x6a, 0x3d, 0x13, 0x0d, 0x8b, 0xc5, 0xd2, 0x12, 0xd3, 0xdb, 0xa9,
+	0xd3, 0xdd, 0xfb, 0xd3, 0x37, 0x6b, 0x35, 0xce, 0xfd, 0x92, 0x35, 0x34, 0x3c, 0x32, 0xb4, 0xbd,
+	0x33, 0x2c, 0xe4, 0xd5, 0xdf, 0xa5, 0xa1, 0x2a, 0x3b, 0x90, 0xc8, 0x74, 0xda, 0xda, 0x4a, 0x63,
+	0x96, 0x0e, 0x24, 0x07, 0x92, 0xad, 0xf7, 0x2a, 0x00, 0xbd, 0x6b, 0x9f, 0xea, 0xa6, 0x47, 0xba,
+	0x62, 0xd1, 0x03, 0x14, 0xa4, 0x40, 0x9e, 0xb6, 0x46, 0x2e, 0xe9, 0x8a, 0x3c, 0x85, 0xdf, 0x0e,
+	0xcc, 0x33, 0xf7, 0x64, 0xf3, 0x0c, 0xaf, 0x72, 0x3e, 0xb2, 0xca, 0x01, 0xf0, 0x56, 0x08, 0x82,
+	0x37, 0x3a, 0x36, 0xdb, 0x31, 0x2c, 0xc7, 0xf0, 0xce, 0xd8, 0xd6, 0x64, 0xb0, 0xdf, 0xa6, 0xe9,
+	0x30, 0x69, 0x10, 0xb9, 0x23, 0x29, 0x32, 0xd1, 0x92, 0x20, 0x36, 0x99, 0x3f, 0xf9, 0x61, 0xe0,
+	0x5e, 0x8f, 0x41, 0xfa, 0x3f, 0xdd, 0x02, 0xab, 0x3f, 0x66, 0x99, 0xbb, 0x70, 0xc8, 0x8b, 0x0e,
+	0x61, 0xd1, 0xb7, 0x2b, 0xda, 0x88, 0xd9, 0x1b, 0x79, 0xa0, 0x93, 0x1a, 0xa6, 0xda, 0x49, 0x98,
+	0xec, 0xa2, 0xff, 0x81, 0xcb, 0x11, 0x9b, 0xe9, 0xab, 0x4e, 0x27, 0x34, 0x9d, 0x97, 0xc2, 0xa6,
+	0x53, 0x6a, 0x1e, 0xaf, 0x55, 0xe6, 0x09, 0x2f, 0xdd, 0x2e, 0x54, 0xe4, 0x62, 0xf0, 0x00, 0x7e,
+	0xea, 0xee, 0x3f, 0x03, 0x65, 0x87, 0x78, 0x14, 0xc0, 0x87, 0xd2, 0x6d, 0x25, 0x4e, 0x14, 0x49,
+	0xbc, 0x03, 0xb8, 0x34, 0x35, 0x90, 0x47, 0x6f, 0x40, 0x61, 0x1c, 0x9f, 0xa5, 0x62, 0x32, 0x57,
+	0x92, 0x1d, 0x8f, 0x79, 0xd5, 0x5f, 0xa7, 0xe0, 0xd2, 0xd4, 0x50, 0x1e, 0x35, 0x61, 0xc1, 0x21,
+	0xee, 0x68, 0xc0, 0x33, 0x2e, 0x95, 0xad, 0x97, 0x93, 0x41, 0x00, 0x4a, 0x1d, 0x0d, 0x3c, 0x2c,
+	0x84, 0xd5, 0x07, 0xb0, 0xc0, 0x29, 0xa8, 0x08, 0xb9, 0x7b, 0x7b, 0xb7, 0xf7, 0xf6, 0xdf, 0xdf,
+	0xab, 0xcd, 0x21, 0x80, 0x85, 0xed, 0x46, 0xa3, 0x79, 0xd0, 0xae, 0xa5, 0x50, 0x01, 0xe6, 0xb7,
+	0x77, 0xf6, 0x71, 0xbb, 0x96, 0xa6, 0x64, 0xdc, 0x7c, 0xb7, 0xd9, 0x68, 0xd7, 0x32, 0x68, 0x11,
+	0xca, 0xfc, 0x5b, 0xbb, 0xb9, 0x8f, 0xef, 0x6e, 0xb7, 0x6b, 0xd9, 0x00, 0xe9, 0xb0, 0xb9, 0x77,
+	0xa3, 0x89, 0x6b, 0xf3, 0xea, 0x2b, 0x70, 0x45, 0x8e, 0x63, 0x32, 0x6b, 0xe4, 0x27, 0x6f, 0x52,
+	0x81, 0xe4, 0x8d, 0xfa, 0x45, 0x1a, 0x94, 0x78, 0x24, 0x80, 0xde, 0x8d, 0x4c, 0x7c, 0xeb, 0x02,
+	0x30, 0x22, 0x32, 0x7b, 0x9a, 0x9c, 0x75, 0xc8, 0x31, 0xf1, 0x3a, 0x7d, 0x8e, 0x4c, 0xb8, 0x2b,
+	0x2e, 0xe3, 0xb2, 0xa0, 0x32, 0x21, 0x97, 0xb3, 0x7d, 0x4c, 0x3a, 0x9e, 0xc6, 0x4d, 0x11, 0x3f,
+	0x74, 0x05, 0x5c, 0xe6, 0xd4, 0x43, 0x4e, 0x54, 0x3f, 0xba, 0xd0, 0x5a, 0x16, 0x60, 0x1e, 0x37,
+	0xdb, 0xf8, 0x7f, 0x6b, 0x19, 0x84, 0xa0, 0xc2, 0x3e, 0xb5, 0xc3, 0xbd, 0xed, 0x83, 0xc3, 0xd6,
+	0x3e, 0x5d, 0xcb, 0x25, 0xa8, 0xca, 0xb5, 0x94, 0xc4, 0x79, 0xf5, 0x00, 0x2e, 0xc7, 0xc0, 0x98,
+	0xc7, 0xcc, 0x5f, 0xa9, 0xbf, 0x4c, 0x05, 0x55, 0x86, 0xf1, 0xca, 0xad, 0xc8, 0x4a, 0x6f, 0x26,
+	0x05, 0x3f, 0xd1, 0x65, 0x56, 0x20, 0xef, 0x23, 0x09, 0xba, 0xc0, 0x25, 0xec, 0xb7, 0xd5, 0x97,
+	0x67, 0x2f, 0xda, 0xf8, 0xd4, 0xa5, 0xd5, 0xbf, 0xa5, 0xa0, 0x1a, 0x31, 0x11, 0x68, 0x0b, 0xe6,
+	0x39, 0xbe, 0x8f, 0xab, 0x8d, 0x33, 0x0b, 0xc7, 0x99, 0xf1, 0xfc, 0x91, 0xac, 0xd4, 0x06, 0x86,
+	0x34, 0x61, 0x8a, 0xf8, 0x62, 0xc9, 0x90, 0x48, 0x88, 0xfa, 0x12, 0xb4, 0xca, 0xea, 0xdb, 0xba,
+	0x7a, 0x66, 0x32, 0xab, 0xc0, 0xc5, 0x7d, 0x2b, 0x29, 0xe4, 0xc7, 0x32, 0xe8, 0xad, 0x31, 0xf4,
+	0xc8, 0x4e, 0x66, 0x15, 0x84, 0x38, 0x67, 0x10, 0xc2, 0x92, 0x5f, 0x1d, 0x40, 0x31, 0x30, 0x1f,
+	0xf4, 0x14, 0x14, 0x86, 0xba, 0x0c, 0x8d, 0x79, 0x0e, 0x39, 0x3f, 0xd4, 0x79, 0x60, 0x8c, 0x2e,
+	0x43, 0x8e, 0xfe, 0xd8, 0xd3, 0x65, 0xd4, 0xbc, 0x30, 0xd4, 0x4f, 0x6f, 0xe9, 0x2e, 0x7a, 0x1e,
+	0xaa, 0xae, 0xe7, 0x18, 0x1d, 0x4f, 0xeb, 0x92, 0x8e, 0xd5, 0x35, 0x4c, 0xee, 0xd8, 0xf2, 0xb8,
+	0xc2, 0xc9, 0x37, 0x04, 0x55, 0xfd, 0x10, 0x2a, 0xe1, 0x34, 0x3d, 0xbd, 0xb4, 0x8e, 0x35, 0x32,
+	0xbb, 0xac, 0xb3, 0x79, 0xcc, 0x1b, 0xb4, 0xee, 0x7e, 0x62, 0x71, 0xbb, 0x3e, 0xdd, 0xba, 0xdd,
+	0xb7, 0x3c, 0x12, 0x48, 0xf3, 0x73, 0x6e, 0xf5, 0x11, 0xcc, 0x33, 0x3b, 0x4d, 0x6d, 0x2e, 0x4b,
+	0xb8, 0x0b, 0x7c, 0x46, 0xbf, 0xd1, 0x87, 0x00, 0xba, 0xe7, 0x39, 0xc6, 0xd1, 0x68, 0xac, 0x78,
+	0x6d, 0xba, 0x9d, 0xdf, 0x96, 0x7c, 0x3b, 0x57, 0x85, 0xc1, 0x5f, 0x1e, 0x8b, 0x06, 0x8c, 0x7e,
+	0x40, 0xa1, 0xba, 0x07, 0x95, 0xb0, 0x6c, 0xb0, 0xf4, 0x55, 0x9a, 0x52, 0xfa, 0xf2, 0xe3, 0x4e,
+	0x3f, 0x6a, 0xe5, 0x6b, 0xc6, 0x1b, 0xea, 0x67, 0x29, 0xc8, 0xb7, 0x4f, 0xc5, 0x61, 0x8e, 0xc9,
+	0xeb, 0x8f, 0x45, 0xd3, 0xc1, 0x2c, 0x36, 0x2f, 0x14, 0x64, 0xfc, 0xf2, 0xc3, 0x3b, 0xfe, 0xcd,
+	0xcb, 0x26, 0x4d, 0x7b, 0xc9, 0x7c, 0xad, 0xb0, 0xeb, 0x6f, 0x43, 0xc1, 0x3f, 0x7e, 0x14, 0xe8,
+	0xea, 0xdd, 0xae, 0x43, 0x5c, 0x57, 0xcc, 0x4d, 0x36, 0xe9, 0x70, 0x6c, 0xeb, 0x53, 0x91, 0x27,
+	0xcf, 0x60, 0xde, 0x50, 0xbb, 0x50, 0x8d, 0x78, 0x78, 0xf4, 0x36, 0xe4, 0xec, 0xd1, 0x91, 0x26,
+	0x97, 0x27, 0x72, 0xcb, 0x64, 0xa0, 0x3d, 0x3a, 0x1a, 0x18, 0x9d, 0xdb, 0xe4, 0x4c, 0x0e, 0xc6,
+	0x1e, 0x1d, 0xdd, 0xe6, 0xab, 0xc8, 0x7b, 0x49, 0x07, 0x7b, 0x39, 0x81, 0xbc, 0x3c, 0x14, 0xe8,
+	0xbf, 0x82, 0x17, 0x4a, 0x16, 0x0f, 0x63, 0xa3, 0x0e, 0xa1, 0x3e, 0x70, 0x9f, 0xae, 0xc3, 0xa2,
+	0x6b, 0xf4, 0x4c, 0xd2, 0xd5, 0xc6, 0x50, 0x9b, 0xf5, 0x96, 0xc7, 0x55, 0xfe, 0xc3, 0x1d, 0x89,
+	0xb3, 0xd5, 0xbf, 0xa6, 0x20, 0x2f, 0x6f, 0x36, 0x7a, 0x25, 0x70, 0xee, 0x2a, 0x53, 0x52, 0xbc,
+	0x92, 0x71, 0x5c, 0xe9, 0x09, 0x8f, 0x35, 0x7d, 0xf1, 0xb1, 0xc6, 0x95, 0xec, 0x64, 0xbe, 0x3f,
+	0x7b, 0xe1, 0xda, 0xe9, 0x4b, 0x80, 0x3c, 0xcb, 0xd3, 0x07, 0xda, 0x89, 0xe5, 0x19, 0x66, 0x4f,
+	0xe3, 0x8b, 0xcd, 0x83, 0xcf, 0x1a, 0xfb, 0xe5, 0x3e, 0xfb, 0xe1, 0x80, 0xad, 0xfb, 0xf7, 0x53,
+	0x90, 0xf7, 0xc3, 0x88, 0x8b, 0x16, 0x6e, 0x56, 0x60, 0x41, 0x78, 0x4a, 0x5e, 0xb9, 0x11, 0x2d,
+	0xbf, 0x86, 0x98, 0x0d, 0xd4, 0x10, 0x15, 0xc8, 0x0f, 0x89, 0xa7, 0x33, 0x87, 0xc4, 0xb3, 0x1d,
+	0x7e, 0x5b, 0xdd, 0x84, 0x4b, 0xa2, 0x42, 0x11, 0x4e, 0x71, 0xc5, 0x0d, 0x48, 0x7d, 0x0f, 0x56,
+	0xe4, 0x99, 0x8f, 0x48, 0x3c, 0x76, 0x70, 0xf5, 0x8a, 0x3f, 0x86, 0x70, 0x4a, 0x8c, 0xde, 0x17,
+	0xde, 0x2b, 0xd7, 0x97, 0xc5, 0xb2, 0xa9, 0xd6, 0xc7, 0xa3, 0x08, 0xcb, 0xa8, 0xdf, 0xf3, 0x95,
+	0x85, 0x73, 0x61, 0x53, 0xab, 0xab, 0x71, 0x45, 0xcd, 0xf4, 0x13, 0x14, 0x35, 0x7f, 0x96, 0x1a,
+	0x8f, 0x2d, 0x32, 0x84, 0x9b, 0x11, 0x47, 0xbe, 0x91, 0x30, 0x8f, 0x17, 0x0d, 0x16, 0xdf, 0x98,
+	0xed, 0xab, 0xf3, 0x90, 0x3d, 0xbc, 0xbd, 0x7b, 0x10, 0x8e, 0x15, 0xd5, 0x5f, 0xa4, 0x60, 0x69,
+	0x4a, 0x7e, 0x01, 0x6d, 0xc1, 0x0a, 0x4d, 0xff, 0x50, 0x1f, 0xa5, 0xf7, 0x88, 0x66, 0x8e, 0x86,
+	0xfc, 0xea, 0x4a, 0x4f, 0x86, 0x86, 0x86, 0x79, 0x57, 0x3f, 0xdd, 0xee, 0x91, 0xbd, 0xd1, 0x90,
+	0xdd, 0x5e, 0x17, 0xb5, 0x61, 0x39, 0x28, 0x23, 0x1f, 0xd2, 0x89, 0x9b, 0x78, 0x65, 0xe2, 0xda,
+	0xdc, 0x10, 0x0c, 0xfc, 0xd6, 0x7c, 0x41, 0x6f, 0xcd, 0xa2, 0xaf, 0x56, 0xfe, 0x78, 0xfd, 0x2d,
+	0x28, 0x06, 0x6a, 0xba, 0xd4, 0x13, 0xec, 0x35, 0xdf, 0xaf, 0xcd, 0x29, 0xb9, 0xcf, 0xbe, 0x5c,
+	0xcf, 0xec, 0x91, 0x4f, 0xe9, 0x99, 0xc0, 0xcd, 0x46, 0xab, 0xd9, 0xb8, 0x5d, 0x4b, 0x29, 0xc5,
+	0xcf, 0xbe, 0x5c, 0xcf, 0x61, 0xc2, 0x2a, 0x15, 0xd7, 0x5b, 0x50, 0x0a, 0x5a, 0x89, 0xf0, 0xda,
+	0x20, 0xa8, 0xdc, 0xb8, 0x77, 0x70, 0x67, 0xb7, 0xb1, 0xdd, 0x6e, 0x6a, 0xf7, 0xf7, 0xdb, 0xcd,
+	0x5a, 0x0a, 0x5d, 0x86, 0xa5, 0x3b, 0xbb, 0xb7, 0x5a, 0x6d, 0xad, 0x71, 0x67, 0xb7, 0xb9, 0xd7,
+	0xd6, 0xb6, 0xdb, 0xed, 0xed, 0xc6, 0xed, 0x5a, 0x7a, 0xeb, 0x2f, 0x65, 0xa8, 0x6e, 0xef, 0x34,
+	0x76, 0x69, 0xe0, 0x6a, 0x74, 0x74, 0x51, 0x09, 0xca, 0xb2, 0xe4, 0xe7, 0xb9, 0x2f, 0xfe, 0x94,
+	0xf3, 0x0b, 0x61, 0xe8, 0x26, 0xcc, 0xb3, 0xbc, 0x28, 0x3a, 0xff, 0x09, 0xa0, 0x32, 0xa3, 0x32,
+	0x46, 0x07, 0xc3, 0xcc, 0xf5, 0xb9, 0x6f, 0x02, 0x95, 0xf3, 0x0b, 0x65, 0x08, 0x43, 0x61, 0x9c,
+	0xa5, 0x9b, 0xfd, 0x46, 0x50, 0x49, 0x50, 0x3c, 0xa3, 0x3a, 0xc7, 0x88, 0x7e, 0xf6, 0x9b, 0x39,
+	0x25, 0x81, 0x43, 0x45, 0x77, 0x20, 0x27, 0x93, 0x30, 0xb3, 0x5e, 0xf1, 0x29, 0x33, 0x0b, 0x5b,
+	0x74, 0x0b, 0x78, 0xb2, 0xec, 0xfc, 0x27, 0x89, 0xca, 0x8c, 0x2a, 0x1d, 0xda, 0x85, 0x05, 0x01,
+	0x53, 0x67, 0xbc, 0xcc, 0x53, 0x66, 0x15, 0xaa, 0xe8, 0xa2, 0x8d, 0xd3, 0x9b, 0xb3, 0x1f, 0x5a,
+	0x2a, 0x09, 0x0a, 0x90, 0xe8, 0x1e, 0x40, 0x20, 0x35, 0x96, 0xe0, 0x05, 0xa5, 0x92, 0xa4, 0xb0,
+	0x88, 0xf6, 0x21, 0xef, 0x67, 0x2a, 0x66, 0xbe, 0x67, 0x54, 0x66, 0x57, 0xf8, 0xd0, 0x03, 0x28,
+	0x87, 0x21, 0x7a, 0xb2, 0x57, 0x8a, 0x4a, 0xc2, 0xd2, 0x1d, 0xd5, 0x1f, 0xc6, 0xeb, 0xc9, 0x5e,
+	0x2d, 0x2a, 0x09, 0x2b, 0x79, 0xe8, 0x63, 0x58, 0x9c, 0xc4, 0xd3, 0xc9, 0x1f, 0x31, 0x2a, 0x17,
+	0xa8, 0xed, 0xa1, 0x21, 0xa0, 0x29, 0x38, 0xfc, 0x02, 0x6f, 0x1a, 0x95, 0x8b, 0x94, 0xfa, 0x50,
+	0x17, 0xaa, 0x51, 0x70, 0x9b, 0xf4, 0x8d, 0xa3, 0x92, 0xb8, 0xec, 0xc7, 0x7b, 0x09, 0xe3, 0xdd,
+	0xa4, 0x6f, 0x1e, 0x95, 0xc4, 0x55, 0x40, 0xa4, 0x43, 0x25, 0x12, 0xad, 0x24, 0x7c, 0x03, 0xa9,
+	0x24, 0xad, 0x09, 0xd2, 0x2e, 0x22, 0xe1, 0x4b, 0xc2, 0x37, 0x91, 0x4a, 0xd2, 0x12, 0x21, 0xed,
+	0x22, 0x12, 0x51, 0x24, 0x7c, 0x23, 0xa9, 0x24, 0xad, 0x18, 0xee, 0x34, 0xbf, 0xfa, 0x76, 0x35,
+	0xf5, 0xf5, 0xb7, 0xab, 0xa9, 0x3f, 0x7c, 0xbb, 0x9a, 0xfa, 0xfc, 0xbb, 0xd5, 0xb9, 0xaf, 0xbf,
+	0x5b, 0x9d, 0xfb, 0xed, 0x77, 0xab, 0x73, 0xff, 0xf7, 0x62, 0xcf, 0xf0, 0xfa, 0xa3, 0xa3, 0x8d,
+	0x8e, 0x35, 0xdc, 0x0c, 0xbe, 0xc2, 0x9f, 0xf6, 0xcf, 0x80, 0xa3, 0x05, 0xe6, 0xf6, 0x5f, 0xfd,
+	0xfb, 0x00, 0x17, 0x85, 0x5c, 0xfe, 0x39, 0x30, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// ABCIApplicationClient is the client API for ABCIApplication service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ABCIApplicationClient interface {
+	Echo(ctx context.Context, in *RequestEcho, opts ...grpc.CallOption) (*ResponseEcho, error)
+	Flush(ctx context.Context, in *RequestFlush, opts ...grpc.CallOption) (*ResponseFlush, error)
+	Info(ctx context.Context, in *RequestInfo, opts ...grpc.CallOption) (*ResponseInfo, error)
+	SetOption(ctx context.Context, in *RequestSetOption, opts ...grpc.CallOption) (*ResponseSetOption, error)
+	DeliverTx(ctx context.Context, in *RequestDeliverTx, opts ...grpc.CallOption) (*ResponseDeliverTx, error)
+	CheckTx(ctx context.Context, in *RequestCheckTx, opts ...grpc.CallOption) (*ResponseCheckTx, error)
+	Query(ctx context.Context, in *RequestQuery, opts ...grpc.CallOption) (*ResponseQuery, error)
+	Commit(ctx context.Context, in *RequestCommit, opts ...grpc.CallOption) (*ResponseCommit, error)
+	InitChain(ctx context.Context, in *RequestInitChain, opts ...grpc.CallOption) (*ResponseInitChain, error)
+	BeginBlock(ctx context.Context, in *RequestBeginBlock, opts ...grpc.CallOption) (*ResponseBeginBlock, error)
+	EndBlock(ctx context.Context, in *RequestEndBlock, opts ...grpc.CallOption) (*ResponseEndBlock, error)
+	ListSnapshots(ctx context.Context, in *RequestListSnapshots, opts ...grpc.CallOption) (*ResponseListSnapshots, error)
+	OfferSnapshot(ctx context.Context, in *RequestOfferSnapshot, opts ...grpc.CallOption) (*ResponseOfferSnapshot, error)
+	LoadSnapshotChunk(ctx context.Context, in *RequestLoadSnapshotChunk, opts ...grpc.CallOption) (*ResponseLoadSnapshotChunk, error)
 	ApplySnapshotChunk(ctx context.Context, in *RequestApplySnapshotChunk, opts ...grpc.CallOption) (*ResponseApplySnapshotChunk, error)
 	PrepareProposal(ctx context.Context, in *RequestPrepareProposal, opts ...grpc.CallOption) (*ResponsePrepareProposal, error)
 	ProcessProposal(ctx context.Context, in *RequestProcessProposal, opts ...grpc.CallOption) (*ResponseProcessProposal, error)
+	CreateSnapshot(ctx context.Context, in *RequestCreateSnapshot, opts ...grpc.CallOption) (*ResponseCreateSnapshot, error)
+	PruneSnapshots(ctx context.Context, in *RequestPruneSnapshots, opts ...grpc.CallOption) (*ResponsePruneSnapshots, error)
+	VerifyEvidence(ctx context.Context, in *RequestVerifyEvidence, opts ...grpc.CallOption) (*ResponseVerifyEvidence, error)
 }
 
 type aBCIApplicationClient struct {
@@ -3928,10 +4507,37 @@ func (c *aBCIApplicationClient) ProcessProposal(ctx context.Context, in *Request
 	return out, nil
 }
 
-// ABCIApplicationServer is the server API for ABCIApplication service.
-type ABCIApplicationServer interface {
-	Echo(context.Context, *RequestEcho) (*ResponseEcho, error)
-	Flush(context.Context, *RequestFlush) (*ResponseFlush, error)
+func (c *aBCIApplicationClient) CreateSnapshot(ctx context.Context, in *RequestCreateSnapshot, opts ...grpc.CallOption) (*ResponseCreateSnapshot, error) {
+	out := new(ResponseCreateSnapshot)
+	err := c.cc.Invoke(ctx, "/tendermint.abci.ABCIApplication/CreateSnapshot", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aBCIApplicationClient) PruneSnapshots(ctx context.Context, in *RequestPruneSnapshots, opts ...grpc.CallOption) (*ResponsePruneSnapshots, error) {
+	out := new(ResponsePruneSnapshots)
+	err := c.cc.Invoke(ctx, "/tendermint.abci.ABCIApplication/PruneSnapshots", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aBCIApplicationClient) VerifyEvidence(ctx context.Context, in *RequestVerifyEvidence, opts ...grpc.CallOption) (*ResponseVerifyEvidence, error) {
+	out := new(ResponseVerifyEvidence)
+	err := c.cc.Invoke(ctx, "/tendermint.abci.ABCIApplication/VerifyEvidence", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ABCIApplicationServer is the server API for ABCIApplication service.
+type ABCIApplicationServer interface {
+	Echo(context.Context, *RequestEcho) (*ResponseEcho, error)
+	Flush(context.Context, *RequestFlush) (*ResponseFlush, error)
 	Info(context.Context, *RequestInfo) (*ResponseInfo, error)
 	SetOption(context.Context, *RequestSetOption) (*ResponseSetOption, error)
 	DeliverTx(context.Context, *RequestDeliverTx) (*ResponseDeliverTx, error)
@@ -3947,6 +4553,9 @@ type ABCIApplicationServer interface {
 	ApplySnapshotChunk(context.Context, *RequestApplySnapshotChunk) (*ResponseApplySnapshotChunk, error)
 	PrepareProposal(context.Context, *RequestPrepareProposal) (*ResponsePrepareProposal, error)
 	ProcessProposal(context.Context, *RequestProcessProposal) (*ResponseProcessProposal, error)
+	CreateSnapshot(context.Context, *RequestCreateSnapshot) (*ResponseCreateSnapshot, error)
+	PruneSnapshots(context.Context, *RequestPruneSnapshots) (*ResponsePruneSnapshots, error)
+	VerifyEvidence(context.Context, *RequestVerifyEvidence) (*ResponseVerifyEvidence, error)
 }
 
 // UnimplementedABCIApplicationServer can be embedded to have forward compatible implementations.
@@ -4004,6 +4613,15 @@ func (*UnimplementedABCIApplicationServer) PrepareProposal(ctx context.Context,
 func (*UnimplementedABCIApplicationServer) ProcessProposal(ctx context.Context, req *RequestProcessProposal) (*ResponseProcessProposal, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ProcessProposal not implemented")
 }
+func (*UnimplementedABCIApplicationServer) CreateSnapshot(ctx context.Context, req *RequestCreateSnapshot) (*ResponseCreateSnapshot, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSnapshot not implemented")
+}
+func (*UnimplementedABCIApplicationServer) PruneSnapshots(ctx context.Context, req *RequestPruneSnapshots) (*ResponsePruneSnapshots, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PruneSnapshots not implemented")
+}
+func (*UnimplementedABCIApplicationServer) VerifyEvidence(ctx context.Context, req *RequestVerifyEvidence) (*ResponseVerifyEvidence, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyEvidence not implemented")
+}
 
 func RegisterABCIApplicationServer(s *grpc.Server, srv ABCIApplicationServer) {
 	s.RegisterService(&_ABCIApplication_serviceDesc, srv)
@@ -4315,6 +4933,60 @@ func _ABCIApplication_ProcessProposal_Handler(srv interface{}, ctx context.Conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ABCIApplication_CreateSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestCreateSnapshot)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ABCIApplicationServer).CreateSnapshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tendermint.abci.ABCIApplication/CreateSnapshot",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ABCIApplicationServer).CreateSnapshot(ctx, req.(*RequestCreateSnapshot))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ABCIApplication_PruneSnapshots_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestPruneSnapshots)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ABCIApplicationServer).PruneSnapshots(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tendermint.abci.ABCIApplication/PruneSnapshots",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ABCIApplicationServer).PruneSnapshots(ctx, req.(*RequestPruneSnapshots))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ABCIApplication_VerifyEvidence_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestVerifyEvidence)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ABCIApplicationServer).VerifyEvidence(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tendermint.abci.ABCIApplication/VerifyEvidence",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ABCIApplicationServer).VerifyEvidence(ctx, req.(*RequestVerifyEvidence))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _ABCIApplication_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "tendermint.abci.ABCIApplication",
 	HandlerType: (*ABCIApplicationServer)(nil),
@@ -4387,6 +5059,18 @@ var _ABCIApplication_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ProcessProposal",
 			Handler:    _ABCIApplication_ProcessProposal_Handler,
 		},
+		{
+			MethodName: "CreateSnapshot",
+			Handler:    _ABCIApplication_CreateSnapshot_Handler,
+		},
+		{
+			MethodName: "PruneSnapshots",
+			Handler:    _ABCIApplication_PruneSnapshots_Handler,
+		},
+		{
+			MethodName: "VerifyEvidence",
+			Handler:    _ABCIApplication_VerifyEvidence_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "tendermint/abci/types.proto",
@@ -4785,6 +5469,75 @@ func (m *Request_ProcessProposal) MarshalToSizedBuffer(dAtA []byte) (int, error)
 	}
 	return len(dAtA) - i, nil
 }
+func (m *Request_CreateSnapshot) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Request_CreateSnapshot) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.CreateSnapshot != nil {
+		{
+			size, err := m.CreateSnapshot.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x92
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Request_PruneSnapshots) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Request_PruneSnapshots) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.PruneSnapshots != nil {
+		{
+			size, err := m.PruneSnapshots.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x9a
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Request_VerifyEvidence) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Request_VerifyEvidence) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.VerifyEvidence != nil {
+		{
+			size, err := m.VerifyEvidence.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xa2
+	}
+	return len(dAtA) - i, nil
+}
 func (m *RequestEcho) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -4980,12 +5733,12 @@ func (m *RequestInitChain) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i--
 		dAtA[i] = 0x12
 	}
-	n19, err19 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Time, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Time):])
-	if err19 != nil {
-		return 0, err19
+	n22, err22 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Time, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Time):])
+	if err22 != nil {
+		return 0, err22
 	}
-	i -= n19
-	i = encodeVarintTypes(dAtA, i, uint64(n19))
+	i -= n22
+	i = encodeVarintTypes(dAtA, i, uint64(n22))
 	i--
 	dAtA[i] = 0xa
 	return len(dAtA) - i, nil
@@ -5388,12 +6141,12 @@ func (m *RequestPrepareProposal) MarshalToSizedBuffer(dAtA []byte) (int, error)
 	_ = i
 	var l int
 	_ = l
-	n23, err23 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Time, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Time):])
-	if err23 != nil {
-		return 0, err23
+	n26, err26 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Time, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Time):])
+	if err26 != nil {
+		return 0, err26
 	}
-	i -= n23
-	i = encodeVarintTypes(dAtA, i, uint64(n23))
+	i -= n26
+	i = encodeVarintTypes(dAtA, i, uint64(n26))
 	i--
 	dAtA[i] = 0x2a
 	if m.Height != 0 {
@@ -5889,6 +6642,75 @@ func (m *Response_ProcessProposal) MarshalToSizedBuffer(dAtA []byte) (int, error
 	}
 	return len(dAtA) - i, nil
 }
+func (m *Response_CreateSnapshot) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Response_CreateSnapshot) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.CreateSnapshot != nil {
+		{
+			size, err := m.CreateSnapshot.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x9a
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Response_PruneSnapshots) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Response_PruneSnapshots) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.PruneSnapshots != nil {
+		{
+			size, err := m.PruneSnapshots.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xa2
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Response_VerifyEvidence) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Response_VerifyEvidence) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.VerifyEvidence != nil {
+		{
+			size, err := m.VerifyEvidence.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xaa
+	}
+	return len(dAtA) - i, nil
+}
 func (m *ResponseException) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -5992,6 +6814,39 @@ func (m *ResponseInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.EvidenceConstraints != nil {
+		{
+			size, err := m.EvidenceConstraints.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x42
+	}
+	if m.MempoolRequirements != nil {
+		{
+			size, err := m.MempoolRequirements.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x3a
+	}
+	if len(m.Capabilities) > 0 {
+		for iNdEx := len(m.Capabilities) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Capabilities[iNdEx])
+			copy(dAtA[i:], m.Capabilities[iNdEx])
+			i = encodeVarintTypes(dAtA, i, uint64(len(m.Capabilities[iNdEx])))
+			i--
+			dAtA[i] = 0x32
+		}
+	}
 	if len(m.LastBlockAppHash) > 0 {
 		i -= len(m.LastBlockAppHash)
 		copy(dAtA[i:], m.LastBlockAppHash)
@@ -6026,6 +6881,41 @@ func (m *ResponseInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *MempoolRequirements) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *MempoolRequirements) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *MempoolRequirements) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.MaxTxBytes != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.MaxTxBytes))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.MinGasPrice) > 0 {
+		i -= len(m.MinGasPrice)
+		copy(dAtA[i:], m.MinGasPrice)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.MinGasPrice)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *ResponseSetOption) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -6088,6 +6978,18 @@ func (m *ResponseInitChain) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.EvidenceConstraints != nil {
+		{
+			size, err := m.EvidenceConstraints.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
+	}
 	if len(m.AppHash) > 0 {
 		i -= len(m.AppHash)
 		copy(dAtA[i:], m.AppHash)
@@ -6648,20 +7550,20 @@ func (m *ResponseApplySnapshotChunk) MarshalToSizedBuffer(dAtA []byte) (int, err
 		}
 	}
 	if len(m.RefetchChunks) > 0 {
-		dAtA49 := make([]byte, len(m.RefetchChunks)*10)
-		var j48 int
+		dAtA58 := make([]byte, len(m.RefetchChunks)*10)
+		var j57 int
 		for _, num := range m.RefetchChunks {
 			for num >= 1<<7 {
-				dAtA49[j48] = uint8(uint64(num)&0x7f | 0x80)
+				dAtA58[j57] = uint8(uint64(num)&0x7f | 0x80)
 				num >>= 7
-				j48++
+				j57++
 			}
-			dAtA49[j48] = uint8(num)
-			j48++
+			dAtA58[j57] = uint8(num)
+			j57++
 		}
-		i -= j48
-		copy(dAtA[i:], dAtA49[:j48])
-		i = encodeVarintTypes(dAtA, i, uint64(j48))
+		i -= j57
+		copy(dAtA[i:], dAtA58[:j57])
+		i = encodeVarintTypes(dAtA, i, uint64(j57))
 		i--
 		dAtA[i] = 0x12
 	}
@@ -6836,6 +7738,16 @@ func (m *BlockParams) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.StrictDecoding {
+		i--
+		if m.StrictDecoding {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x18
+	}
 	if m.MaxGas != 0 {
 		i = encodeVarintTypes(dAtA, i, uint64(m.MaxGas))
 		i--
@@ -7173,12 +8085,12 @@ func (m *Evidence) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i--
 		dAtA[i] = 0x28
 	}
-	n58, err58 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Time, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Time):])
-	if err58 != nil {
-		return 0, err58
+	n67, err67 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Time, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Time):])
+	if err67 != nil {
+		return 0, err67
 	}
-	i -= n58
-	i = encodeVarintTypes(dAtA, i, uint64(n58))
+	i -= n67
+	i = encodeVarintTypes(dAtA, i, uint64(n67))
 	i--
 	dAtA[i] = 0x22
 	if m.Height != 0 {
@@ -7256,16 +8168,253 @@ func (m *Snapshot) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func encodeVarintTypes(dAtA []byte, offset int, v uint64) int {
-	offset -= sovTypes(v)
-	base := offset
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
-	}
-	dAtA[offset] = uint8(v)
-	return base
+func (m *RequestCreateSnapshot) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RequestCreateSnapshot) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RequestCreateSnapshot) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Height != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ResponseCreateSnapshot) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ResponseCreateSnapshot) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ResponseCreateSnapshot) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Snapshots) > 0 {
+		for iNdEx := len(m.Snapshots) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Snapshots[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintTypes(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RequestPruneSnapshots) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RequestPruneSnapshots) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RequestPruneSnapshots) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Heights) > 0 {
+		dAtA70 := make([]byte, len(m.Heights)*10)
+		var j69 int
+		for _, num := range m.Heights {
+			for num >= 1<<7 {
+				dAtA70[j69] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j69++
+			}
+			dAtA70[j69] = uint8(num)
+			j69++
+		}
+		i -= j69
+		copy(dAtA[i:], dAtA70[:j69])
+		i = encodeVarintTypes(dAtA, i, uint64(j69))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ResponsePruneSnapshots) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ResponsePruneSnapshots) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ResponsePruneSnapshots) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	return len(dAtA) - i, nil
+}
+
+func (m *RequestVerifyEvidence) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RequestVerifyEvidence) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RequestVerifyEvidence) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.ByzantineValidators) > 0 {
+		for iNdEx := len(m.ByzantineValidators) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.ByzantineValidators[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintTypes(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.Hash) > 0 {
+		i -= len(m.Hash)
+		copy(dAtA[i:], m.Hash)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Hash)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ResponseVerifyEvidence) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ResponseVerifyEvidence) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ResponseVerifyEvidence) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Result != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Result))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *EvidenceConstraints) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *EvidenceConstraints) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *EvidenceConstraints) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	n71, err71 := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.MinMaxAgeDuration, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(m.MinMaxAgeDuration):])
+	if err71 != nil {
+		return 0, err71
+	}
+	i -= n71
+	i = encodeVarintTypes(dAtA, i, uint64(n71))
+	i--
+	dAtA[i] = 0x12
+	if m.MinMaxAgeNumBlocks != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.MinMaxAgeNumBlocks))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintTypes(dAtA []byte, offset int, v uint64) int {
+	offset -= sovTypes(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
 }
 func (m *Request) Size() (n int) {
 	if m == nil {
@@ -7483,6 +8632,42 @@ func (m *Request_ProcessProposal) Size() (n int) {
 	}
 	return n
 }
+func (m *Request_CreateSnapshot) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.CreateSnapshot != nil {
+		l = m.CreateSnapshot.Size()
+		n += 2 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+func (m *Request_PruneSnapshots) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.PruneSnapshots != nil {
+		l = m.PruneSnapshots.Size()
+		n += 2 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+func (m *Request_VerifyEvidence) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.VerifyEvidence != nil {
+		l = m.VerifyEvidence.Size()
+		n += 2 + l + sovTypes(uint64(l))
+	}
+	return n
+}
 func (m *RequestEcho) Size() (n int) {
 	if m == nil {
 		return 0
@@ -8001,52 +9186,88 @@ func (m *Response_ProcessProposal) Size() (n int) {
 	}
 	return n
 }
-func (m *ResponseException) Size() (n int) {
+func (m *Response_CreateSnapshot) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	l = len(m.Error)
-	if l > 0 {
-		n += 1 + l + sovTypes(uint64(l))
+	if m.CreateSnapshot != nil {
+		l = m.CreateSnapshot.Size()
+		n += 2 + l + sovTypes(uint64(l))
 	}
 	return n
 }
-
-func (m *ResponseEcho) Size() (n int) {
+func (m *Response_PruneSnapshots) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	l = len(m.Message)
-	if l > 0 {
-		n += 1 + l + sovTypes(uint64(l))
+	if m.PruneSnapshots != nil {
+		l = m.PruneSnapshots.Size()
+		n += 2 + l + sovTypes(uint64(l))
 	}
 	return n
 }
-
-func (m *ResponseFlush) Size() (n int) {
+func (m *Response_VerifyEvidence) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
+	if m.VerifyEvidence != nil {
+		l = m.VerifyEvidence.Size()
+		n += 2 + l + sovTypes(uint64(l))
+	}
 	return n
 }
-
-func (m *ResponseInfo) Size() (n int) {
+func (m *ResponseException) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	l = len(m.Data)
+	l = len(m.Error)
 	if l > 0 {
 		n += 1 + l + sovTypes(uint64(l))
 	}
-	l = len(m.Version)
+	return n
+}
+
+func (m *ResponseEcho) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Message)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+
+func (m *ResponseFlush) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *ResponseInfo) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Data)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.Version)
 	if l > 0 {
 		n += 1 + l + sovTypes(uint64(l))
 	}
@@ -8060,6 +9281,36 @@ func (m *ResponseInfo) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovTypes(uint64(l))
 	}
+	if len(m.Capabilities) > 0 {
+		for _, s := range m.Capabilities {
+			l = len(s)
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	if m.MempoolRequirements != nil {
+		l = m.MempoolRequirements.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.EvidenceConstraints != nil {
+		l = m.EvidenceConstraints.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+
+func (m *MempoolRequirements) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.MinGasPrice)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.MaxTxBytes != 0 {
+		n += 1 + sovTypes(uint64(m.MaxTxBytes))
+	}
 	return n
 }
 
@@ -8103,6 +9354,10 @@ func (m *ResponseInitChain) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovTypes(uint64(l))
 	}
+	if m.EvidenceConstraints != nil {
+		l = m.EvidenceConstraints.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
 	return n
 }
 
@@ -8428,6 +9683,9 @@ func (m *BlockParams) Size() (n int) {
 	if m.MaxGas != 0 {
 		n += 1 + sovTypes(uint64(m.MaxGas))
 	}
+	if m.StrictDecoding {
+		n += 2
+	}
 	return n
 }
 
@@ -8601,6 +9859,103 @@ func (m *Snapshot) Size() (n int) {
 	return n
 }
 
+func (m *RequestCreateSnapshot) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Height != 0 {
+		n += 1 + sovTypes(uint64(m.Height))
+	}
+	return n
+}
+
+func (m *ResponseCreateSnapshot) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Snapshots) > 0 {
+		for _, e := range m.Snapshots {
+			l = e.Size()
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *RequestPruneSnapshots) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Heights) > 0 {
+		l = 0
+		for _, e := range m.Heights {
+			l += sovTypes(uint64(e))
+		}
+		n += 1 + sovTypes(uint64(l)) + l
+	}
+	return n
+}
+
+func (m *ResponsePruneSnapshots) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *RequestVerifyEvidence) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Hash)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if len(m.ByzantineValidators) > 0 {
+		for _, e := range m.ByzantineValidators {
+			l = e.Size()
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *ResponseVerifyEvidence) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Result != 0 {
+		n += 1 + sovTypes(uint64(m.Result))
+	}
+	return n
+}
+
+func (m *EvidenceConstraints) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.MinMaxAgeNumBlocks != 0 {
+		n += 1 + sovTypes(uint64(m.MinMaxAgeNumBlocks))
+	}
+	l = github_com_gogo_protobuf_types.SizeOfStdDuration(m.MinMaxAgeDuration)
+	n += 1 + l + sovTypes(uint64(l))
+	return n
+}
+
 func sovTypes(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
 }
@@ -9231,6 +10586,111 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 			}
 			m.Value = &Request_ProcessProposal{v}
 			iNdEx = postIndex
+		case 18:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CreateSnapshot", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &RequestCreateSnapshot{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &Request_CreateSnapshot{v}
+			iNdEx = postIndex
+		case 19:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PruneSnapshots", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &RequestPruneSnapshots{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &Request_PruneSnapshots{v}
+			iNdEx = postIndex
+		case 20:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field VerifyEvidence", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &RequestVerifyEvidence{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &Request_VerifyEvidence{v}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -11880,23 +13340,128 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 			}
 			m.Value = &Response_ProcessProposal{v}
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipTypes(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthTypes
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
+		case 19:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CreateSnapshot", wireType)
 			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &ResponseCreateSnapshot{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &Response_CreateSnapshot{v}
+			iNdEx = postIndex
+		case 20:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PruneSnapshots", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &ResponsePruneSnapshots{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &Response_PruneSnapshots{v}
+			iNdEx = postIndex
+		case 21:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field VerifyEvidence", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &ResponseVerifyEvidence{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &Response_VerifyEvidence{v}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
 		return io.ErrUnexpectedEOF
 	}
 	return nil
@@ -12280,61 +13845,11 @@ func (m *ResponseInfo) Unmarshal(dAtA []byte) error {
 				m.LastBlockAppHash = []byte{}
 			}
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipTypes(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthTypes
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *ResponseSetOption) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowTypes
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: ResponseSetOption: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ResponseSetOption: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Code", wireType)
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Capabilities", wireType)
 			}
-			m.Code = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -12344,16 +13859,29 @@ func (m *ResponseSetOption) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Code |= uint32(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 3:
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Capabilities = append(m.Capabilities, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 7:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Log", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field MempoolRequirements", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -12363,29 +13891,33 @@ func (m *ResponseSetOption) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Log = string(dAtA[iNdEx:postIndex])
+			if m.MempoolRequirements == nil {
+				m.MempoolRequirements = &MempoolRequirements{}
+			}
+			if err := m.MempoolRequirements.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
-		case 4:
+		case 8:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Info", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field EvidenceConstraints", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -12395,23 +13927,27 @@ func (m *ResponseSetOption) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Info = string(dAtA[iNdEx:postIndex])
+			if m.EvidenceConstraints == nil {
+				m.EvidenceConstraints = &EvidenceConstraints{}
+			}
+			if err := m.EvidenceConstraints.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -12434,7 +13970,7 @@ func (m *ResponseSetOption) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ResponseInitChain) Unmarshal(dAtA []byte) error {
+func (m *MempoolRequirements) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -12457,17 +13993,17 @@ func (m *ResponseInitChain) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ResponseInitChain: wiretype end group for non-group")
+			return fmt.Errorf("proto: MempoolRequirements: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ResponseInitChain: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: MempoolRequirements: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ConsensusParams", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field MinGasPrice", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -12477,33 +14013,29 @@ func (m *ResponseInitChain) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.ConsensusParams == nil {
-				m.ConsensusParams = &ConsensusParams{}
-			}
-			if err := m.ConsensusParams.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.MinGasPrice = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Validators", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxTxBytes", wireType)
 			}
-			var msglen int
+			m.MaxTxBytes = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -12513,70 +14045,21 @@ func (m *ResponseInitChain) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.MaxTxBytes |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthTypes
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthTypes
 			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Validators = append(m.Validators, ValidatorUpdate{})
-			if err := m.Validators[len(m.Validators)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AppHash", wireType)
-			}
-			var byteLen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowTypes
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				byteLen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if byteLen < 0 {
-				return ErrInvalidLengthTypes
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthTypes
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.AppHash = append(m.AppHash[:0], dAtA[iNdEx:postIndex]...)
-			if m.AppHash == nil {
-				m.AppHash = []byte{}
-			}
-			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipTypes(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthTypes
-			}
-			if (iNdEx + skippy) > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
 			iNdEx += skippy
@@ -12588,7 +14071,7 @@ func (m *ResponseInitChain) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ResponseQuery) Unmarshal(dAtA []byte) error {
+func (m *ResponseSetOption) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -12611,10 +14094,10 @@ func (m *ResponseQuery) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ResponseQuery: wiretype end group for non-group")
+			return fmt.Errorf("proto: ResponseSetOption: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ResponseQuery: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ResponseSetOption: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -12700,30 +14183,61 @@ func (m *ResponseQuery) Unmarshal(dAtA []byte) error {
 			}
 			m.Info = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 5:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			m.Index = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowTypes
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Index |= int64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
 			}
-		case 6:
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ResponseInitChain) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ResponseInitChain: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ResponseInitChain: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ConsensusParams", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -12733,31 +14247,33 @@ func (m *ResponseQuery) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Key = append(m.Key[:0], dAtA[iNdEx:postIndex]...)
-			if m.Key == nil {
-				m.Key = []byte{}
+			if m.ConsensusParams == nil {
+				m.ConsensusParams = &ConsensusParams{}
+			}
+			if err := m.ConsensusParams.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
 			iNdEx = postIndex
-		case 7:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Validators", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -12767,31 +14283,31 @@ func (m *ResponseQuery) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Value = append(m.Value[:0], dAtA[iNdEx:postIndex]...)
-			if m.Value == nil {
-				m.Value = []byte{}
+			m.Validators = append(m.Validators, ValidatorUpdate{})
+			if err := m.Validators[len(m.Validators)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
 			iNdEx = postIndex
-		case 8:
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ProofOps", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field AppHash", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -12801,52 +14317,31 @@ func (m *ResponseQuery) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.ProofOps == nil {
-				m.ProofOps = &crypto.ProofOps{}
-			}
-			if err := m.ProofOps.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.AppHash = append(m.AppHash[:0], dAtA[iNdEx:postIndex]...)
+			if m.AppHash == nil {
+				m.AppHash = []byte{}
 			}
 			iNdEx = postIndex
-		case 9:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
-			}
-			m.Height = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowTypes
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Height |= int64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 10:
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Codespace", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field EvidenceConstraints", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -12856,23 +14351,27 @@ func (m *ResponseQuery) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Codespace = string(dAtA[iNdEx:postIndex])
+			if m.EvidenceConstraints == nil {
+				m.EvidenceConstraints = &EvidenceConstraints{}
+			}
+			if err := m.EvidenceConstraints.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -12895,7 +14394,7 @@ func (m *ResponseQuery) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ResponseBeginBlock) Unmarshal(dAtA []byte) error {
+func (m *ResponseQuery) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -12918,17 +14417,36 @@ func (m *ResponseBeginBlock) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ResponseBeginBlock: wiretype end group for non-group")
+			return fmt.Errorf("proto: ResponseQuery: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ResponseBeginBlock: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ResponseQuery: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Code", wireType)
+			}
+			m.Code = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Code |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Events", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Log", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -12938,164 +14456,27 @@ func (m *ResponseBeginBlock) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Events = append(m.Events, Event{})
-			if err := m.Events[len(m.Events)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Log = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipTypes(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthTypes
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *ResponseCheckTx) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowTypes
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: ResponseCheckTx: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ResponseCheckTx: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Code", wireType)
-			}
-			m.Code = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowTypes
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Code |= uint32(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
-			}
-			var byteLen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowTypes
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				byteLen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if byteLen < 0 {
-				return ErrInvalidLengthTypes
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthTypes
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
-			if m.Data == nil {
-				m.Data = []byte{}
-			}
-			iNdEx = postIndex
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Log", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowTypes
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthTypes
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthTypes
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Log = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Info", wireType)
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Info", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -13127,9 +14508,9 @@ func (m *ResponseCheckTx) Unmarshal(dAtA []byte) error {
 			iNdEx = postIndex
 		case 5:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field GasWanted", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
 			}
-			m.GasWanted = 0
+			m.Index = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -13139,35 +14520,16 @@ func (m *ResponseCheckTx) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.GasWanted |= int64(b&0x7F) << shift
+				m.Index |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 6:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field GasUsed", wireType)
-			}
-			m.GasUsed = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowTypes
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.GasUsed |= int64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 7:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Events", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -13177,31 +14539,31 @@ func (m *ResponseCheckTx) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Events = append(m.Events, Event{})
-			if err := m.Events[len(m.Events)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Key = append(m.Key[:0], dAtA[iNdEx:postIndex]...)
+			if m.Key == nil {
+				m.Key = []byte{}
 			}
 			iNdEx = postIndex
-		case 8:
+		case 7:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Codespace", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
 			}
-			var stringLen uint64
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -13211,29 +14573,31 @@ func (m *ResponseCheckTx) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Codespace = string(dAtA[iNdEx:postIndex])
+			m.Value = append(m.Value[:0], dAtA[iNdEx:postIndex]...)
+			if m.Value == nil {
+				m.Value = []byte{}
+			}
 			iNdEx = postIndex
-		case 9:
+		case 8:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Sender", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ProofOps", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -13243,29 +14607,33 @@ func (m *ResponseCheckTx) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Sender = string(dAtA[iNdEx:postIndex])
+			if m.ProofOps == nil {
+				m.ProofOps = &crypto.ProofOps{}
+			}
+			if err := m.ProofOps.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
-		case 10:
+		case 9:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Priority", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
 			}
-			m.Priority = 0
+			m.Height = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -13275,14 +14643,14 @@ func (m *ResponseCheckTx) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Priority |= int64(b&0x7F) << shift
+				m.Height |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 11:
+		case 10:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field MempoolError", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Codespace", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -13310,7 +14678,7 @@ func (m *ResponseCheckTx) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.MempoolError = string(dAtA[iNdEx:postIndex])
+			m.Codespace = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -13333,7 +14701,7 @@ func (m *ResponseCheckTx) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ResponseDeliverTx) Unmarshal(dAtA []byte) error {
+func (m *ResponseBeginBlock) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -13356,36 +14724,17 @@ func (m *ResponseDeliverTx) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ResponseDeliverTx: wiretype end group for non-group")
+			return fmt.Errorf("proto: ResponseBeginBlock: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ResponseDeliverTx: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ResponseBeginBlock: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Code", wireType)
-			}
-			m.Code = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowTypes
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Code |= uint32(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Events", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -13395,7 +14744,110 @@ func (m *ResponseDeliverTx) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Events = append(m.Events, Event{})
+			if err := m.Events[len(m.Events)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ResponseCheckTx) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ResponseCheckTx: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ResponseCheckTx: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Code", wireType)
+			}
+			m.Code = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Code |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -13583,6 +15035,89 @@ func (m *ResponseDeliverTx) Unmarshal(dAtA []byte) error {
 			}
 			m.Codespace = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sender", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Sender = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 10:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Priority", wireType)
+			}
+			m.Priority = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Priority |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MempoolError", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.MempoolError = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -13604,7 +15139,7 @@ func (m *ResponseDeliverTx) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ResponseEndBlock) Unmarshal(dAtA []byte) error {
+func (m *ResponseDeliverTx) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -13627,17 +15162,36 @@ func (m *ResponseEndBlock) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ResponseEndBlock: wiretype end group for non-group")
+			return fmt.Errorf("proto: ResponseDeliverTx: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ResponseEndBlock: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ResponseDeliverTx: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Code", wireType)
+			}
+			m.Code = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Code |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ValidatorUpdates", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -13647,31 +15201,31 @@ func (m *ResponseEndBlock) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ValidatorUpdates = append(m.ValidatorUpdates, ValidatorUpdate{})
-			if err := m.ValidatorUpdates[len(m.ValidatorUpdates)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
+			if m.Data == nil {
+				m.Data = []byte{}
 			}
 			iNdEx = postIndex
-		case 2:
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ConsensusParamUpdates", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Log", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -13681,33 +15235,991 @@ func (m *ResponseEndBlock) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.ConsensusParamUpdates == nil {
-				m.ConsensusParamUpdates = &ConsensusParams{}
-			}
-			if err := m.ConsensusParamUpdates.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Log = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 3:
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Events", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Info", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Info = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GasWanted", wireType)
+			}
+			m.GasWanted = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.GasWanted |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GasUsed", wireType)
+			}
+			m.GasUsed = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.GasUsed |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Events", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Events = append(m.Events, Event{})
+			if err := m.Events[len(m.Events)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Codespace", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Codespace = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ResponseEndBlock) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ResponseEndBlock: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ResponseEndBlock: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ValidatorUpdates", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ValidatorUpdates = append(m.ValidatorUpdates, ValidatorUpdate{})
+			if err := m.ValidatorUpdates[len(m.ValidatorUpdates)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConsensusParamUpdates", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ConsensusParamUpdates == nil {
+				m.ConsensusParamUpdates = &ConsensusParams{}
+			}
+			if err := m.ConsensusParamUpdates.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Events", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Events = append(m.Events, Event{})
+			if err := m.Events[len(m.Events)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ResponseCommit) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ResponseCommit: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ResponseCommit: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
+			if m.Data == nil {
+				m.Data = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RetainHeight", wireType)
+			}
+			m.RetainHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RetainHeight |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ResponseListSnapshots) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ResponseListSnapshots: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ResponseListSnapshots: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Snapshots", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Snapshots = append(m.Snapshots, &Snapshot{})
+			if err := m.Snapshots[len(m.Snapshots)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ResponseOfferSnapshot) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ResponseOfferSnapshot: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ResponseOfferSnapshot: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Result", wireType)
+			}
+			m.Result = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Result |= ResponseOfferSnapshot_Result(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ResponseLoadSnapshotChunk) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ResponseLoadSnapshotChunk: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ResponseLoadSnapshotChunk: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Chunk", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Chunk = append(m.Chunk[:0], dAtA[iNdEx:postIndex]...)
+			if m.Chunk == nil {
+				m.Chunk = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ResponseApplySnapshotChunk) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ResponseApplySnapshotChunk: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ResponseApplySnapshotChunk: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Result", wireType)
+			}
+			m.Result = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Result |= ResponseApplySnapshotChunk_Result(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType == 0 {
+				var v uint32
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowTypes
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= uint32(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.RefetchChunks = append(m.RefetchChunks, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowTypes
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthTypes
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthTypes
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.RefetchChunks) == 0 {
+					m.RefetchChunks = make([]uint32, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v uint32
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowTypes
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= uint32(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.RefetchChunks = append(m.RefetchChunks, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field RefetchChunks", wireType)
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RejectSenders", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RejectSenders = append(m.RejectSenders, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ResponsePrepareProposal) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ResponsePrepareProposal: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ResponsePrepareProposal: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockData", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.BlockData == nil {
+				m.BlockData = &types1.Data{}
+			}
+			if err := m.BlockData.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ResponseProcessProposal) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ResponseProcessProposal: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ResponseProcessProposal: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Result", wireType)
+			}
+			m.Result = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Result |= ResponseProcessProposal_Result(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Evidence", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -13717,25 +16229,23 @@ func (m *ResponseEndBlock) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Events = append(m.Events, Event{})
-			if err := m.Events[len(m.Events)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Evidence = append(m.Evidence, make([]byte, postIndex-iNdEx))
+			copy(m.Evidence[len(m.Evidence)-1], dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -13758,7 +16268,7 @@ func (m *ResponseEndBlock) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ResponseCommit) Unmarshal(dAtA []byte) error {
+func (m *ConsensusParams) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -13781,17 +16291,17 @@ func (m *ResponseCommit) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ResponseCommit: wiretype end group for non-group")
+			return fmt.Errorf("proto: ConsensusParams: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ResponseCommit: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ConsensusParams: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
-		case 2:
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Block", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -13801,31 +16311,33 @@ func (m *ResponseCommit) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
-			if m.Data == nil {
-				m.Data = []byte{}
+			if m.Block == nil {
+				m.Block = &BlockParams{}
+			}
+			if err := m.Block.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
 			iNdEx = postIndex
-		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RetainHeight", wireType)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Evidence", wireType)
 			}
-			m.RetainHeight = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -13835,64 +16347,67 @@ func (m *ResponseCommit) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.RetainHeight |= int64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		default:
-			iNdEx = preIndex
-			skippy, err := skipTypes(dAtA[iNdEx:])
-			if err != nil {
-				return err
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
 			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
-			if (iNdEx + skippy) > l {
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *ResponseListSnapshots) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowTypes
+			if m.Evidence == nil {
+				m.Evidence = &types1.EvidenceParams{}
 			}
-			if iNdEx >= l {
+			if err := m.Evidence.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Validator", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			if m.Validator == nil {
+				m.Validator = &types1.ValidatorParams{}
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: ResponseListSnapshots: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ResponseListSnapshots: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			if err := m.Validator.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Snapshots", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -13919,8 +16434,10 @@ func (m *ResponseListSnapshots) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Snapshots = append(m.Snapshots, &Snapshot{})
-			if err := m.Snapshots[len(m.Snapshots)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.Version == nil {
+				m.Version = &types1.VersionParams{}
+			}
+			if err := m.Version.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -13945,7 +16462,7 @@ func (m *ResponseListSnapshots) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ResponseOfferSnapshot) Unmarshal(dAtA []byte) error {
+func (m *BlockParams) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -13968,17 +16485,17 @@ func (m *ResponseOfferSnapshot) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ResponseOfferSnapshot: wiretype end group for non-group")
+			return fmt.Errorf("proto: BlockParams: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ResponseOfferSnapshot: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: BlockParams: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Result", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxBytes", wireType)
 			}
-			m.Result = 0
+			m.MaxBytes = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -13988,66 +16505,16 @@ func (m *ResponseOfferSnapshot) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Result |= ResponseOfferSnapshot_Result(b&0x7F) << shift
+				m.MaxBytes |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		default:
-			iNdEx = preIndex
-			skippy, err := skipTypes(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthTypes
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *ResponseLoadSnapshotChunk) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowTypes
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: ResponseLoadSnapshotChunk: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ResponseLoadSnapshotChunk: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Chunk", wireType)
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxGas", wireType)
 			}
-			var byteLen int
+			m.MaxGas = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -14057,26 +16524,31 @@ func (m *ResponseLoadSnapshotChunk) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				m.MaxGas |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
-				return ErrInvalidLengthTypes
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthTypes
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StrictDecoding", wireType)
 			}
-			m.Chunk = append(m.Chunk[:0], dAtA[iNdEx:postIndex]...)
-			if m.Chunk == nil {
-				m.Chunk = []byte{}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			iNdEx = postIndex
+			m.StrictDecoding = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -14098,7 +16570,7 @@ func (m *ResponseLoadSnapshotChunk) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ResponseApplySnapshotChunk) Unmarshal(dAtA []byte) error {
+func (m *LastCommitInfo) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -14121,17 +16593,17 @@ func (m *ResponseApplySnapshotChunk) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ResponseApplySnapshotChunk: wiretype end group for non-group")
+			return fmt.Errorf("proto: LastCommitInfo: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ResponseApplySnapshotChunk: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: LastCommitInfo: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Result", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Round", wireType)
 			}
-			m.Result = 0
+			m.Round = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -14141,92 +16613,16 @@ func (m *ResponseApplySnapshotChunk) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Result |= ResponseApplySnapshotChunk_Result(b&0x7F) << shift
+				m.Round |= int32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 2:
-			if wireType == 0 {
-				var v uint32
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowTypes
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					v |= uint32(b&0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				m.RefetchChunks = append(m.RefetchChunks, v)
-			} else if wireType == 2 {
-				var packedLen int
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowTypes
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					packedLen |= int(b&0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				if packedLen < 0 {
-					return ErrInvalidLengthTypes
-				}
-				postIndex := iNdEx + packedLen
-				if postIndex < 0 {
-					return ErrInvalidLengthTypes
-				}
-				if postIndex > l {
-					return io.ErrUnexpectedEOF
-				}
-				var elementCount int
-				var count int
-				for _, integer := range dAtA[iNdEx:postIndex] {
-					if integer < 128 {
-						count++
-					}
-				}
-				elementCount = count
-				if elementCount != 0 && len(m.RefetchChunks) == 0 {
-					m.RefetchChunks = make([]uint32, 0, elementCount)
-				}
-				for iNdEx < postIndex {
-					var v uint32
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowTypes
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						v |= uint32(b&0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					m.RefetchChunks = append(m.RefetchChunks, v)
-				}
-			} else {
-				return fmt.Errorf("proto: wrong wireType = %d for field RefetchChunks", wireType)
-			}
-		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RejectSenders", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Votes", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -14236,23 +16632,25 @@ func (m *ResponseApplySnapshotChunk) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.RejectSenders = append(m.RejectSenders, string(dAtA[iNdEx:postIndex]))
+			m.Votes = append(m.Votes, VoteInfo{})
+			if err := m.Votes[len(m.Votes)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -14275,7 +16673,7 @@ func (m *ResponseApplySnapshotChunk) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ResponsePrepareProposal) Unmarshal(dAtA []byte) error {
+func (m *Event) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -14298,15 +16696,47 @@ func (m *ResponsePrepareProposal) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ResponsePrepareProposal: wiretype end group for non-group")
+			return fmt.Errorf("proto: Event: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ResponsePrepareProposal: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Event: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field BlockData", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Type = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Attributes", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -14333,10 +16763,8 @@ func (m *ResponsePrepareProposal) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.BlockData == nil {
-				m.BlockData = &types1.Data{}
-			}
-			if err := m.BlockData.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Attributes = append(m.Attributes, EventAttribute{})
+			if err := m.Attributes[len(m.Attributes)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -14361,7 +16789,7 @@ func (m *ResponsePrepareProposal) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ResponseProcessProposal) Unmarshal(dAtA []byte) error {
+func (m *EventAttribute) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -14384,17 +16812,17 @@ func (m *ResponseProcessProposal) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ResponseProcessProposal: wiretype end group for non-group")
+			return fmt.Errorf("proto: EventAttribute: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ResponseProcessProposal: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: EventAttribute: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Result", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
 			}
-			m.Result = 0
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -14404,14 +16832,29 @@ func (m *ResponseProcessProposal) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Result |= ResponseProcessProposal_Result(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Key = append(m.Key[:0], dAtA[iNdEx:postIndex]...)
+			if m.Key == nil {
+				m.Key = []byte{}
+			}
+			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Evidence", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -14438,9 +16881,31 @@ func (m *ResponseProcessProposal) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Evidence = append(m.Evidence, make([]byte, postIndex-iNdEx))
-			copy(m.Evidence[len(m.Evidence)-1], dAtA[iNdEx:postIndex])
+			m.Value = append(m.Value[:0], dAtA[iNdEx:postIndex]...)
+			if m.Value == nil {
+				m.Value = []byte{}
+			}
 			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Index = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -14462,7 +16927,7 @@ func (m *ResponseProcessProposal) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ConsensusParams) Unmarshal(dAtA []byte) error {
+func (m *TxResult) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -14485,17 +16950,17 @@ func (m *ConsensusParams) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ConsensusParams: wiretype end group for non-group")
+			return fmt.Errorf("proto: TxResult: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ConsensusParams: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: TxResult: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Block", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
 			}
-			var msglen int
+			m.Height = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -14505,33 +16970,16 @@ func (m *ConsensusParams) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.Height |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthTypes
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthTypes
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Block == nil {
-				m.Block = &BlockParams{}
-			}
-			if err := m.Block.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Evidence", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
 			}
-			var msglen int
+			m.Index = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -14541,33 +16989,16 @@ func (m *ConsensusParams) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.Index |= uint32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthTypes
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthTypes
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Evidence == nil {
-				m.Evidence = &types1.EvidenceParams{}
-			}
-			if err := m.Evidence.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Validator", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Tx", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -14577,31 +17008,29 @@ func (m *ConsensusParams) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Validator == nil {
-				m.Validator = &types1.ValidatorParams{}
-			}
-			if err := m.Validator.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Tx = append(m.Tx[:0], dAtA[iNdEx:postIndex]...)
+			if m.Tx == nil {
+				m.Tx = []byte{}
 			}
 			iNdEx = postIndex
 		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Result", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -14628,10 +17057,7 @@ func (m *ConsensusParams) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Version == nil {
-				m.Version = &types1.VersionParams{}
-			}
-			if err := m.Version.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Result.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -14656,7 +17082,7 @@ func (m *ConsensusParams) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *BlockParams) Unmarshal(dAtA []byte) error {
+func (m *Validator) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -14679,17 +17105,17 @@ func (m *BlockParams) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: BlockParams: wiretype end group for non-group")
+			return fmt.Errorf("proto: Validator: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: BlockParams: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Validator: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field MaxBytes", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Address", wireType)
 			}
-			m.MaxBytes = 0
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -14699,16 +17125,31 @@ func (m *BlockParams) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.MaxBytes |= int64(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 2:
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Address = append(m.Address[:0], dAtA[iNdEx:postIndex]...)
+			if m.Address == nil {
+				m.Address = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field MaxGas", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Power", wireType)
 			}
-			m.MaxGas = 0
+			m.Power = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -14718,7 +17159,7 @@ func (m *BlockParams) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.MaxGas |= int64(b&0x7F) << shift
+				m.Power |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -14744,7 +17185,7 @@ func (m *BlockParams) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *LastCommitInfo) Unmarshal(dAtA []byte) error {
+func (m *ValidatorUpdate) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -14767,34 +17208,15 @@ func (m *LastCommitInfo) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: LastCommitInfo: wiretype end group for non-group")
+			return fmt.Errorf("proto: ValidatorUpdate: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: LastCommitInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ValidatorUpdate: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Round", wireType)
-			}
-			m.Round = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowTypes
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Round |= int32(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Votes", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field PubKey", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -14821,11 +17243,29 @@ func (m *LastCommitInfo) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Votes = append(m.Votes, VoteInfo{})
-			if err := m.Votes[len(m.Votes)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.PubKey.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Power", wireType)
+			}
+			m.Power = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Power |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -14847,7 +17287,7 @@ func (m *LastCommitInfo) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Event) Unmarshal(dAtA []byte) error {
+func (m *VoteInfo) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -14870,17 +17310,17 @@ func (m *Event) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Event: wiretype end group for non-group")
+			return fmt.Errorf("proto: VoteInfo: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Event: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: VoteInfo: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Validator", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -14890,29 +17330,30 @@ func (m *Event) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Type = string(dAtA[iNdEx:postIndex])
+			if err := m.Validator.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Attributes", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SignedLastBlock", wireType)
 			}
-			var msglen int
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -14922,26 +17363,12 @@ func (m *Event) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthTypes
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthTypes
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Attributes = append(m.Attributes, EventAttribute{})
-			if err := m.Attributes[len(m.Attributes)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
+			m.SignedLastBlock = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -14963,7 +17390,7 @@ func (m *Event) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *EventAttribute) Unmarshal(dAtA []byte) error {
+func (m *Evidence) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -14986,17 +17413,36 @@ func (m *EventAttribute) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: EventAttribute: wiretype end group for non-group")
+			return fmt.Errorf("proto: Evidence: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: EventAttribute: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Evidence: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			m.Type = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Type |= EvidenceType(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Validator", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -15006,31 +17452,49 @@ func (m *EventAttribute) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Key = append(m.Key[:0], dAtA[iNdEx:postIndex]...)
-			if m.Key == nil {
-				m.Key = []byte{}
+			if err := m.Validator.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
 			iNdEx = postIndex
-		case 2:
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Time", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -15040,31 +17504,30 @@ func (m *EventAttribute) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Value = append(m.Value[:0], dAtA[iNdEx:postIndex]...)
-			if m.Value == nil {
-				m.Value = []byte{}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.Time, dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
 			iNdEx = postIndex
-		case 3:
+		case 5:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field TotalVotingPower", wireType)
 			}
-			var v int
+			m.TotalVotingPower = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -15074,12 +17537,11 @@ func (m *EventAttribute) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= int(b&0x7F) << shift
+				m.TotalVotingPower |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.Index = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -15101,7 +17563,7 @@ func (m *EventAttribute) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *TxResult) Unmarshal(dAtA []byte) error {
+func (m *Snapshot) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -15124,17 +17586,36 @@ func (m *TxResult) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: TxResult: wiretype end group for non-group")
+			return fmt.Errorf("proto: Snapshot: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: TxResult: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Snapshot: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Format", wireType)
 			}
-			m.Height = 0
+			m.Format = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -15144,16 +17625,16 @@ func (m *TxResult) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Height |= int64(b&0x7F) << shift
+				m.Format |= uint32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 2:
+		case 3:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Chunks", wireType)
 			}
-			m.Index = 0
+			m.Chunks = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -15163,14 +17644,14 @@ func (m *TxResult) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Index |= uint32(b&0x7F) << shift
+				m.Chunks |= uint32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 3:
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Tx", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Hash", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -15197,16 +17678,16 @@ func (m *TxResult) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Tx = append(m.Tx[:0], dAtA[iNdEx:postIndex]...)
-			if m.Tx == nil {
-				m.Tx = []byte{}
+			m.Hash = append(m.Hash[:0], dAtA[iNdEx:postIndex]...)
+			if m.Hash == nil {
+				m.Hash = []byte{}
 			}
 			iNdEx = postIndex
-		case 4:
+		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Result", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Metadata", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -15216,23 +17697,24 @@ func (m *TxResult) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.Result.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Metadata = append(m.Metadata[:0], dAtA[iNdEx:postIndex]...)
+			if m.Metadata == nil {
+				m.Metadata = []byte{}
 			}
 			iNdEx = postIndex
 		default:
@@ -15256,7 +17738,7 @@ func (m *TxResult) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Validator) Unmarshal(dAtA []byte) error {
+func (m *RequestCreateSnapshot) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -15279,51 +17761,17 @@ func (m *Validator) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Validator: wiretype end group for non-group")
+			return fmt.Errorf("proto: RequestCreateSnapshot: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Validator: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: RequestCreateSnapshot: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Address", wireType)
-			}
-			var byteLen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowTypes
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				byteLen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if byteLen < 0 {
-				return ErrInvalidLengthTypes
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthTypes
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Address = append(m.Address[:0], dAtA[iNdEx:postIndex]...)
-			if m.Address == nil {
-				m.Address = []byte{}
-			}
-			iNdEx = postIndex
-		case 3:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Power", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
 			}
-			m.Power = 0
+			m.Height = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -15333,7 +17781,7 @@ func (m *Validator) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Power |= int64(b&0x7F) << shift
+				m.Height |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -15359,7 +17807,7 @@ func (m *Validator) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ValidatorUpdate) Unmarshal(dAtA []byte) error {
+func (m *ResponseCreateSnapshot) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -15382,15 +17830,15 @@ func (m *ValidatorUpdate) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ValidatorUpdate: wiretype end group for non-group")
+			return fmt.Errorf("proto: ResponseCreateSnapshot: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ValidatorUpdate: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ResponseCreateSnapshot: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PubKey", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Snapshots", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -15417,29 +17865,187 @@ func (m *ValidatorUpdate) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.PubKey.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Snapshots = append(m.Snapshots, &Snapshot{})
+			if err := m.Snapshots[len(m.Snapshots)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Power", wireType)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			m.Power = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowTypes
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RequestPruneSnapshots) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RequestPruneSnapshots: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RequestPruneSnapshots: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType == 0 {
+				var v uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowTypes
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
 				}
-				if iNdEx >= l {
+				m.Heights = append(m.Heights, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowTypes
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthTypes
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthTypes
+				}
+				if postIndex > l {
 					return io.ErrUnexpectedEOF
 				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Power |= int64(b&0x7F) << shift
-				if b < 0x80 {
-					break
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.Heights) == 0 {
+					m.Heights = make([]uint64, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowTypes
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.Heights = append(m.Heights, v)
 				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field Heights", wireType)
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ResponsePruneSnapshots) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
 			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ResponsePruneSnapshots: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ResponsePruneSnapshots: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -15461,7 +18067,7 @@ func (m *ValidatorUpdate) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *VoteInfo) Unmarshal(dAtA []byte) error {
+func (m *RequestVerifyEvidence) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -15484,17 +18090,17 @@ func (m *VoteInfo) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: VoteInfo: wiretype end group for non-group")
+			return fmt.Errorf("proto: RequestVerifyEvidence: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: VoteInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: RequestVerifyEvidence: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Validator", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Hash", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -15504,30 +18110,31 @@ func (m *VoteInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.Validator.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Hash = append(m.Hash[:0], dAtA[iNdEx:postIndex]...)
+			if m.Hash == nil {
+				m.Hash = []byte{}
 			}
 			iNdEx = postIndex
 		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SignedLastBlock", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ByzantineValidators", wireType)
 			}
-			var v int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -15537,12 +18144,26 @@ func (m *VoteInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.SignedLastBlock = bool(v != 0)
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ByzantineValidators = append(m.ByzantineValidators, Evidence{})
+			if err := m.ByzantineValidators[len(m.ByzantineValidators)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -15564,7 +18185,7 @@ func (m *VoteInfo) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Evidence) Unmarshal(dAtA []byte) error {
+func (m *ResponseVerifyEvidence) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -15587,121 +18208,17 @@ func (m *Evidence) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Evidence: wiretype end group for non-group")
+			return fmt.Errorf("proto: ResponseVerifyEvidence: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Evidence: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ResponseVerifyEvidence: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
-			}
-			m.Type = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowTypes
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Type |= EvidenceType(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Validator", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowTypes
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthTypes
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthTypes
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if err := m.Validator.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
-			}
-			m.Height = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowTypes
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Height |= int64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Time", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowTypes
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthTypes
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthTypes
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.Time, dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 5:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TotalVotingPower", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Result", wireType)
 			}
-			m.TotalVotingPower = 0
+			m.Result = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -15711,7 +18228,7 @@ func (m *Evidence) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.TotalVotingPower |= int64(b&0x7F) << shift
+				m.Result |= ResponseVerifyEvidence_Result(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -15737,7 +18254,7 @@ func (m *Evidence) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Snapshot) Unmarshal(dAtA []byte) error {
+func (m *EvidenceConstraints) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -15760,17 +18277,17 @@ func (m *Snapshot) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Snapshot: wiretype end group for non-group")
+			return fmt.Errorf("proto: EvidenceConstraints: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Snapshot: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: EvidenceConstraints: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field MinMaxAgeNumBlocks", wireType)
 			}
-			m.Height = 0
+			m.MinMaxAgeNumBlocks = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -15780,88 +18297,16 @@ func (m *Snapshot) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Height |= uint64(b&0x7F) << shift
+				m.MinMaxAgeNumBlocks |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Format", wireType)
-			}
-			m.Format = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowTypes
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Format |= uint32(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Chunks", wireType)
-			}
-			m.Chunks = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowTypes
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Chunks |= uint32(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Hash", wireType)
-			}
-			var byteLen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowTypes
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				byteLen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if byteLen < 0 {
-				return ErrInvalidLengthTypes
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthTypes
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Hash = append(m.Hash[:0], dAtA[iNdEx:postIndex]...)
-			if m.Hash == nil {
-				m.Hash = []byte{}
-			}
-			iNdEx = postIndex
-		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Metadata", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field MinMaxAgeDuration", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -15871,24 +18316,23 @@ func (m *Snapshot) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Metadata = append(m.Metadata[:0], dAtA[iNdEx:postIndex]...)
-			if m.Metadata == nil {
-				m.Metadata = []byte{}
+			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(&m.MinMaxAgeDuration, dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
 			iNdEx = postIndex
 		default:
@@ -3,6 +3,7 @@ package v0
 import (
 	"fmt"
 	"reflect"
+	"sync/atomic"
 	"time"
 
 	"github.com/gogo/protobuf/proto"
@@ -61,6 +62,10 @@ type BlockchainReactor struct {
 
 	requestsCh <-chan BlockRequest
 	errorsCh   <-chan peerError
+
+	// strictDecoding mirrors the StrictDecoding consensus param of the latest
+	// synced state.
+	strictDecoding atomic.Bool
 }
 
 // NewBlockchainReactor returns new reactor instance.
@@ -92,6 +97,7 @@ func NewBlockchainReactor(state sm.State, blockExec *sm.BlockExecutor, store *st
 		requestsCh:   requestsCh,
 		errorsCh:     errorsCh,
 	}
+	bcR.strictDecoding.Store(state.ConsensusParams.Block.StrictDecoding)
 	bcR.BaseReactor = *p2p.NewBaseReactor("BlockchainReactor", bcR)
 	return bcR
 }
@@ -118,6 +124,7 @@ func (bcR *BlockchainReactor) OnStart() error {
 func (bcR *BlockchainReactor) SwitchToFastSync(state sm.State) error {
 	bcR.fastSync = true
 	bcR.initialState = state
+	bcR.strictDecoding.Store(state.ConsensusParams.Block.StrictDecoding)
 
 	bcR.pool.height = state.LastBlockHeight + 1
 	err := bcR.pool.Start()
@@ -128,6 +135,13 @@ func (bcR *BlockchainReactor) SwitchToFastSync(state sm.State) error {
 	return nil
 }
 
+// PoolStatus returns the height the block pool is syncing, the number of
+// blocks pending and of requesters, and the highest height of the peers.
+func (bcR *BlockchainReactor) PoolStatus() (height int64, numPending int32, numRequesters int, maxPeerHeight int64) {
+	height, numPending, numRequesters = bcR.pool.GetStatus()
+	return height, numPending, numRequesters, bcR.pool.MaxPeerHeight()
+}
+
 // OnStop implements service.Service.
 func (bcR *BlockchainReactor) OnStop() {
 	if bcR.fastSync {
@@ -208,6 +222,13 @@ func (bcR *BlockchainReactor) ReceiveEnvelope(e p2p.Envelope) {
 	case *bcproto.BlockRequest:
 		bcR.respondToPeer(msg, e.Src)
 	case *bcproto.BlockResponse:
+		if e.Bytes != nil && bcR.strictDecoding.Load() {
+			if err := types.CheckCanonicalEncoding(msg.Wrap(), e.Bytes); err != nil {
+				bcR.Logger.Error("Peer sent us non-canonical block", "peer", e.Src, "height", msg.Block.GetHeader().Height, "err", err)
+				bcR.Switch.StopPeerForError(e.Src, err)
+				return
+			}
+		}
 		bi, err := types.BlockFromProto(msg.Block)
 		if err != nil {
 			bcR.Logger.Error("Block content is invalid", "err", err)
@@ -417,6 +438,7 @@ FOR_LOOP:
 				// TODO This is bad, are we zombie?
 				panic(fmt.Sprintf("Failed to process committed block (%d:%X): %v", first.Height, first.Hash(), err))
 			}
+			bcR.strictDecoding.Store(state.ConsensusParams.Block.StrictDecoding)
 			blocksSynced++
 
 			if blocksSynced%100 == 0 {
@@ -10,6 +10,7 @@ import (
 	"github.com/gogo/protobuf/proto"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
 
 	dbm "github.com/cometbft/cometbft-db"
 
@@ -18,6 +19,7 @@ import (
 	"github.com/tendermint/tendermint/libs/log"
 	"github.com/tendermint/tendermint/mempool/mock"
 	"github.com/tendermint/tendermint/p2p"
+	p2pmock "github.com/tendermint/tendermint/p2p/mock"
 	bcproto "github.com/tendermint/tendermint/proto/tendermint/blockchain"
 	"github.com/tendermint/tendermint/proxy"
 	sm "github.com/tendermint/tendermint/state"
@@ -214,6 +216,56 @@ func TestLegacyReactorReceiveBasic(t *testing.T) {
 	})
 }
 
+func TestReactorStrictDecoding(t *testing.T) {
+	config = cfg.ResetTestRoot("blockchain_reactor_test")
+	defer os.RemoveAll(config.RootDir)
+	genDoc, privVals := randGenesisDoc(1, false, 30)
+	pair := newBlockchainReactor(log.TestingLogger(), genDoc, privVals, 3)
+	defer pair.app.Stop() //nolint:errcheck // ignore for tests
+	reactor := pair.reactor
+	p2p.MakeSwitch(config.P2P, 0, "127.0.0.1", "123.123.123", func(i int, sw *p2p.Switch) *p2p.Switch {
+		sw.AddReactor("BLOCKCHAIN", reactor)
+		return sw
+	})
+
+	pbb, err := reactor.store.LoadBlock(1).ToProto()
+	require.NoError(t, err)
+	msg := &bcproto.BlockResponse{Block: pbb}
+	// encode returns the encoding of msg as sent by a peer, with an unknown
+	// field appended to the block if unknownField is set
+	encode := func(unknownField bool) []byte {
+		blockBz, err := proto.Marshal(pbb)
+		require.NoError(t, err)
+		if unknownField {
+			blockBz = protowire.AppendTag(blockBz, 1000, protowire.VarintType)
+			blockBz = protowire.AppendVarint(blockBz, 7)
+		}
+		// the message holds the block response in its field 3, which holds
+		// the block in its field 1
+		resBz := protowire.AppendTag(nil, 1, protowire.BytesType)
+		resBz = protowire.AppendBytes(resBz, blockBz)
+		bz := protowire.AppendTag(nil, 3, protowire.BytesType)
+		return protowire.AppendBytes(bz, resBz)
+	}
+	newPeer := func() p2p.Peer {
+		peer := p2pmock.NewPeer(nil)
+		reactor.InitPeer(peer)
+		return peer
+	}
+
+	// tolerant by default
+	peer := newPeer()
+	reactor.ReceiveEnvelope(p2p.Envelope{ChannelID: BlockchainChannel, Src: peer, Message: msg, Bytes: encode(true)})
+	assert.True(t, peer.IsRunning())
+
+	reactor.strictDecoding.Store(true)
+	reactor.ReceiveEnvelope(p2p.Envelope{ChannelID: BlockchainChannel, Src: peer, Message: msg, Bytes: encode(false)})
+	assert.True(t, peer.IsRunning())
+
+	reactor.ReceiveEnvelope(p2p.Envelope{ChannelID: BlockchainChannel, Src: peer, Message: msg, Bytes: encode(true)})
+	assert.False(t, peer.IsRunning())
+}
+
 // NOTE: This is too hard to test without
 // an easy way to add test peer to switch
 // or without significant refactoring of the module.
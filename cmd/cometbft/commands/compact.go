@@ -2,7 +2,6 @@ package commands
 
 import (
 	"errors"
-	"path/filepath"
 	"sync"
 
 	"github.com/spf13/cobra"
@@ -10,6 +9,7 @@ import (
 	"github.com/syndtr/goleveldb/leveldb/opt"
 	"github.com/syndtr/goleveldb/leveldb/util"
 
+	cfg "github.com/tendermint/tendermint/config"
 	"github.com/tendermint/tendermint/libs/log"
 )
 
@@ -30,12 +30,22 @@ Currently, only GoLevelDB is supported.
 			return errors.New("compaction is currently only supported with goleveldb")
 		}
 
-		compactGoLevelDBs(config.RootDir, logger)
+		lock, err := lockDataDir(config)
+		if err != nil {
+			return err
+		}
+		defer releaseDataDirLock(lock)
+
+		compactGoLevelDBs(config, logger)
 		return nil
 	},
 }
 
-func compactGoLevelDBs(rootDir string, logger log.Logger) {
+func init() {
+	addForceLockFlag(CompactGoLevelDBCmd)
+}
+
+func compactGoLevelDBs(conf *cfg.Config, logger log.Logger) {
 	dbNames := []string{"state", "blockstore"}
 	o := &opt.Options{
 		DisableSeeksCompaction: true,
@@ -47,7 +57,7 @@ func compactGoLevelDBs(rootDir string, logger log.Logger) {
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			dbPath := filepath.Join(rootDir, "data", dbName+".db")
+			dbPath := conf.StorePath(dbName)
 			store, err := leveldb.OpenFile(dbPath, o)
 			if err != nil {
 				logger.Error("failed to initialize cometbft db", "path", dbPath, "err", err)
@@ -20,8 +20,9 @@ var dumpCmd = &cobra.Command{
 	Short: "Continuously poll a CometBFT process and dump debugging data into a single location",
 	Long: `Continuously poll a CometBFT process and dump debugging data into a single
 location at a specified frequency. At each frequency interval, an archived and compressed
-file will contain node debugging information including the goroutine and heap profiles
-if enabled.`,
+file will contain node debugging information, including the debug bundle of the node
+(its status, network info and consensus state captured at the same height) and the
+goroutine and heap profiles if enabled.`,
 	Args: cobra.ExactArgs(1),
 	RunE: dumpCmdHandler,
 }
@@ -106,6 +107,12 @@ func dumpDebugData(outDir string, conf *cfg.Config, rpc *rpchttp.HTTP) {
 		return
 	}
 
+	// nodes predating the debug_bundle endpoint do not serve it
+	logger.Info("getting node debug bundle...")
+	if err := dumpDebugBundle(rpc, tmpDir, "debug_bundle.json"); err != nil {
+		logger.Error("failed to dump node debug bundle", "error", err)
+	}
+
 	logger.Info("copying node WAL...")
 	if err := copyWAL(conf, tmpDir); err != nil {
 		logger.Error("failed to copy node WAL", "error", err)
@@ -76,6 +76,12 @@ func killCmdHandler(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	// nodes predating the debug_bundle endpoint do not serve it
+	logger.Info("getting node debug bundle...")
+	if err := dumpDebugBundle(rpc, tmpDir, "debug_bundle.json"); err != nil {
+		logger.Error("failed to dump node debug bundle", "error", err)
+	}
+
 	logger.Info("copying node WAL...")
 	if err := copyWAL(conf, tmpDir); err != nil {
 		return err
@@ -46,6 +46,18 @@ func dumpConsensusState(rpc *rpchttp.HTTP, dir, filename string) error {
 	return writeStateJSONToFile(consDump, dir, filename)
 }
 
+// dumpDebugBundle gets the debug bundle, a snapshot of the node status,
+// network info and consensus state captured at the same height, from the
+// CometBFT RPC and writes it to file. It returns an error upon failure.
+func dumpDebugBundle(rpc *rpchttp.HTTP, dir, filename string) error {
+	bundle, err := rpc.DebugBundle(context.Background())
+	if err != nil {
+		return fmt.Errorf("failed to get node debug bundle: %w", err)
+	}
+
+	return writeStateJSONToFile(bundle, dir, filename)
+}
+
 // copyWAL copies the CometBFT node's WAL file. It returns an error if the
 // WAL file cannot be read or copied.
 func copyWAL(conf *cfg.Config, dir string) error {
@@ -0,0 +1,137 @@
+package commands
+
+import (
+	"fmt"
+
+	dbm "github.com/cometbft/cometbft-db"
+	"github.com/spf13/cobra"
+
+	"github.com/tendermint/tendermint/libs/os"
+	"github.com/tendermint/tendermint/store"
+)
+
+var (
+	exportFromHeight int64
+	exportToHeight   int64
+	exportOutput     string
+	exportGzip       bool
+	importInput      string
+)
+
+// ExportBlocksCmd writes a range of stored blocks, along with their commits, to
+// a block archive.
+var ExportBlocksCmd = &cobra.Command{
+	Use:     "export-blocks",
+	Aliases: []string{"export_blocks"},
+	Short:   "Export stored blocks and their commits to an archive file",
+	Long: `
+export-blocks writes the blocks from --from to --to of the blockstore, each along with
+the commit for it, to the archive at --output. The archive holds length prefixed proto
+records followed by an index, which allows reading any block without scanning the file.
+
+An interrupted export can be resumed by running the same command again: the blocks
+already written are kept, and the export continues after the last valid one.
+
+The node should be stopped while exporting.
+`,
+	Example: `
+	cometbft export-blocks --from 1 --to 1000 --output blocks.dat
+	cometbft export-blocks --from 1 --to 1000 --output blocks.dat.gz --gzip
+	`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		lock, err := lockDataDir(config)
+		if err != nil {
+			return err
+		}
+		defer releaseDataDirLock(lock)
+
+		blockStore, err := loadBlockStore(false)
+		if err != nil {
+			return err
+		}
+		defer blockStore.Close()
+
+		from, to := exportFromHeight, exportToHeight
+		if from == 0 {
+			from = blockStore.Base()
+		}
+		if to == 0 {
+			to = blockStore.Height()
+		}
+		if err := store.ExportBlocks(blockStore, exportOutput, from, to, exportGzip); err != nil {
+			return fmt.Errorf("failed to export blocks: %w", err)
+		}
+
+		fmt.Printf("exported the blocks from height %d to %d to %s\n", from, to, exportOutput)
+		return nil
+	},
+}
+
+// ImportBlocksCmd saves the blocks of a block archive to the blockstore.
+var ImportBlocksCmd = &cobra.Command{
+	Use:     "import-blocks",
+	Aliases: []string{"import_blocks"},
+	Short:   "Import the blocks of an archive file into the blockstore",
+	Long: `
+import-blocks saves the blocks of the archive at --input, written by export-blocks, to the
+blockstore, which is created if it does not exist. Each block is checked against its commit
+and the previous block before being saved. The import starts after the last stored block, so
+an interrupted import can be resumed by running the same command again.
+
+Note: the commit signatures are not verified, as the archive does not hold the validator
+sets. Only import archives from a trusted source.
+
+The node should be stopped while importing.
+`,
+	Example: `
+	cometbft import-blocks --input blocks.dat
+	`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		lock, err := lockDataDir(config)
+		if err != nil {
+			return err
+		}
+		defer releaseDataDirLock(lock)
+
+		blockStore, err := loadBlockStore(true)
+		if err != nil {
+			return err
+		}
+		defer blockStore.Close()
+
+		imported, err := store.ImportBlocks(blockStore, importInput)
+		if err != nil {
+			return fmt.Errorf("failed to import blocks after importing %d: %w", imported, err)
+		}
+
+		fmt.Printf("imported %d blocks, the blockstore holds the blocks from height %d to %d\n",
+			imported, blockStore.Base(), blockStore.Height())
+		return nil
+	},
+}
+
+func init() {
+	ExportBlocksCmd.Flags().Int64Var(&exportFromHeight, "from", 0,
+		"the first height to export, defaults to the base of the blockstore")
+	ExportBlocksCmd.Flags().Int64Var(&exportToHeight, "to", 0,
+		"the last height to export, defaults to the latest stored height")
+	ExportBlocksCmd.Flags().StringVar(&exportOutput, "output", "blocks.dat", "the archive file to write")
+	ExportBlocksCmd.Flags().BoolVar(&exportGzip, "gzip", false, "compress the blocks of the archive with gzip")
+	addForceLockFlag(ExportBlocksCmd)
+
+	ImportBlocksCmd.Flags().StringVar(&importInput, "input", "blocks.dat", "the archive file to import")
+	addForceLockFlag(ImportBlocksCmd)
+}
+
+// loadBlockStore opens the blockstore of the node. Unless create is set, the
+// blockstore must exist.
+func loadBlockStore(create bool) (*store.BlockStore, error) {
+	if !create && !os.FileExists(config.StorePath("blockstore")) {
+		return nil, fmt.Errorf("no blockstore found in %v", config.StoreDir("blockstore"))
+	}
+	db, err := dbm.NewDB("blockstore", dbm.BackendType(config.DBBackend), config.StoreDir("blockstore"))
+	if err != nil {
+		return nil, err
+	}
+	return store.NewBlockStore(db), nil
+}
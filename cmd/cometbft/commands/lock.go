@@ -0,0 +1,71 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	cfg "github.com/tendermint/tendermint/config"
+	cmtos "github.com/tendermint/tendermint/libs/os"
+)
+
+// forceLock breaks the lock of the data directory before acquiring it, if the
+// process holding it is not running anymore.
+var forceLock bool
+
+// addForceLockFlag adds the --force flag to a command opening the databases
+// of the data directory.
+func addForceLockFlag(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&forceLock, "force", false,
+		"break the lock of the data directory if the process holding it is not running anymore")
+}
+
+// lockDataDir acquires the lock of the data directory of config, so that the
+// databases are not opened by two processes, e.g. by a command while the node
+// runs. With --force, the lock is broken first if its holder is not running
+// anymore. The directories of the databases are created first.
+func lockDataDir(config *cfg.Config) (*cmtos.FileLock, error) {
+	if err := cfg.EnsureStoreDirs(config.BaseConfig); err != nil {
+		return nil, err
+	}
+	if err := breakStaleLock(config); err != nil {
+		return nil, err
+	}
+	lock, err := cmtos.LockFile(config.DBLockFile())
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock the data directory: %w", lockHint(err))
+	}
+	if lock.StalePID != 0 {
+		logger.Info("The previous process using the data directory did not release its lock", "pid", lock.StalePID)
+	}
+	return lock, nil
+}
+
+func releaseDataDirLock(lock *cmtos.FileLock) {
+	if err := lock.Release(); err != nil {
+		logger.Error("Error releasing the data directory lock", "err", err)
+	}
+}
+
+// breakStaleLock breaks the lock of the data directory of config if --force is
+// set and the process holding it is not running anymore.
+func breakStaleLock(config *cfg.Config) error {
+	if !forceLock {
+		return nil
+	}
+	if err := cmtos.BreakLock(config.DBLockFile()); err != nil {
+		return fmt.Errorf("failed to break the lock of the data directory: %w", err)
+	}
+	return nil
+}
+
+// lockHint adds to an error of a lock held by a process that is not running
+// anymore how to break it.
+func lockHint(err error) error {
+	var lerr *cmtos.LockedError
+	if errors.As(err, &lerr) && lerr.Stale {
+		return fmt.Errorf("%w, use --force to break the lock", err)
+	}
+	return err
+}
@@ -52,6 +52,13 @@ want to use this command.
 	cometbft reindex-event --start-height 2 --end-height 10
 	`,
 	Run: func(cmd *cobra.Command, args []string) {
+		lock, err := lockDataDir(config)
+		if err != nil {
+			fmt.Println(reindexFailed, err)
+			return
+		}
+		defer releaseDataDirLock(lock)
+
 		bs, ss, err := loadStateAndBlockStore(config)
 		if err != nil {
 			fmt.Println(reindexFailed, err)
@@ -93,6 +100,7 @@ var (
 func init() {
 	ReIndexEventCmd.Flags().Int64Var(&startHeight, "start-height", 0, "the block height would like to start for re-index")
 	ReIndexEventCmd.Flags().Int64Var(&endHeight, "end-height", 0, "the block height would like to finish for re-index")
+	addForceLockFlag(ReIndexEventCmd)
 }
 
 func loadEventSinks(cfg *cmtcfg.Config) (indexer.BlockIndexer, txindex.TxIndexer, error) {
@@ -110,7 +118,7 @@ func loadEventSinks(cfg *cmtcfg.Config) (indexer.BlockIndexer, txindex.TxIndexer
 		}
 		return es.BlockIndexer(), es.TxIndexer(), nil
 	case "kv":
-		store, err := dbm.NewDB("tx_index", dbm.BackendType(cfg.DBBackend), cfg.DBDir())
+		store, err := dbm.NewDB("tx_index", dbm.BackendType(cfg.DBBackend), cfg.StoreDir("tx_index"))
 		if err != nil {
 			return nil, nil, err
 		}
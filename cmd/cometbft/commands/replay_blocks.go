@@ -0,0 +1,216 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/proxy"
+	"github.com/tendermint/tendermint/state"
+	"github.com/tendermint/tendermint/types"
+)
+
+var (
+	replayFromHeight    int64
+	replayToHeight      int64
+	replayProxyApp      string
+	replayFromGenesis   bool
+	replayStateSnapshot string
+)
+
+// ReplayBlocksCmd re-executes stored blocks against an application and reports
+// the first height at which the results diverge from the stored chain.
+var ReplayBlocksCmd = &cobra.Command{
+	Use:     "replay-blocks",
+	Aliases: []string{"replay_blocks"},
+	Short:   "Replay stored blocks against an application and compare the results",
+	Long: `
+replay-blocks is an offline tool that loads blocks from the blockstore and executes
+them against the application at --proxy-app, i.e. BeginBlock, DeliverTx, EndBlock and
+Commit. The resulting app hashes and tx results are compared with the stored headers
+and ABCI responses, and the first divergent height and field are reported.
+
+The application must have committed the height preceding --from. Alternatively, a fresh
+application can be initialized from the genesis file with --genesis, or restored from a
+state sync snapshot with --state-snapshot. A snapshot directory contains the snapshot
+metadata in snapshot.json and its chunks in files named by chunk index.
+
+Neither the blockstore nor the state is modified by this command.
+
+Note: Comparing tx results requires ABCIResponses. If DiscardABCIResponses is set to true,
+only the results hash committed to in the headers is compared.
+`,
+	Example: `
+	cometbft replay-blocks --proxy-app tcp://127.0.0.1:26658 --from 10 --to 20
+	cometbft replay-blocks --proxy-app tcp://127.0.0.1:26658 --genesis
+	cometbft replay-blocks --proxy-app tcp://127.0.0.1:26658 --state-snapshot ./snapshot
+	`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		divergence, err := ReplayBlocks(cmd.Context())
+		if err != nil {
+			return fmt.Errorf("failed to replay blocks: %w", err)
+		}
+		if divergence != nil {
+			return fmt.Errorf("replayed blocks diverged at %v", divergence)
+		}
+
+		fmt.Println("replayed blocks match the stored chain")
+		return nil
+	},
+}
+
+func init() {
+	ReplayBlocksCmd.Flags().Int64Var(&replayFromHeight, "from", 0,
+		"the first height to replay, defaults to the height after the one the app is restored to")
+	ReplayBlocksCmd.Flags().Int64Var(&replayToHeight, "to", 0,
+		"the last height to replay, defaults to the latest stored height")
+	ReplayBlocksCmd.Flags().StringVar(&replayProxyApp, "proxy-app", "",
+		"proxy app address, defaults to the proxy_app of the config")
+	ReplayBlocksCmd.Flags().BoolVar(&replayFromGenesis, "genesis", false,
+		"initialize the app with InitChain from the genesis file before replaying")
+	ReplayBlocksCmd.Flags().StringVar(&replayStateSnapshot, "state-snapshot", "",
+		"directory of a state sync snapshot to restore the app from before replaying")
+	addForceLockFlag(ReplayBlocksCmd)
+}
+
+// ReplayBlocks replays the blocks in the configured height range against the
+// application and returns the first divergence from the stored chain, if any.
+func ReplayBlocks(ctx context.Context) (*state.ReplayDivergence, error) {
+	if replayFromGenesis && replayStateSnapshot != "" {
+		return nil, errors.New("--genesis and --state-snapshot are mutually exclusive")
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	lock, err := lockDataDir(config)
+	if err != nil {
+		return nil, err
+	}
+	defer releaseDataDirLock(lock)
+
+	blockStore, stateStore, err := loadStateAndBlockStore(config)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = blockStore.Close()
+		_ = stateStore.Close()
+	}()
+
+	addr := replayProxyApp
+	if addr == "" {
+		addr = config.ProxyApp
+	}
+	proxyApp := proxy.NewAppConns(proxy.DefaultClientCreator(addr, config.ABCI, config.DBDir()))
+	proxyApp.SetLogger(logger.With("module", "proxy"))
+	if err := proxyApp.Start(); err != nil {
+		return nil, fmt.Errorf("error starting proxy app connections: %w", err)
+	}
+	defer func() {
+		_ = proxyApp.Stop()
+	}()
+
+	var genDoc *types.GenesisDoc
+	from, to := replayFromHeight, replayToHeight
+	switch {
+	case replayFromGenesis:
+		genDoc, err = types.GenesisDocFromFile(config.GenesisFile())
+		if err != nil {
+			return nil, err
+		}
+		if from == 0 {
+			from = genDoc.InitialHeight
+		}
+	case replayStateSnapshot != "":
+		height, err := restoreSnapshot(proxyApp, blockStore, replayStateSnapshot)
+		if err != nil {
+			return nil, fmt.Errorf("failed to restore snapshot: %w", err)
+		}
+		if from == 0 {
+			from = height + 1
+		}
+	case from == 0:
+		res, err := proxyApp.Query().InfoSync(proxy.RequestInfo)
+		if err != nil {
+			return nil, fmt.Errorf("error calling Info: %w", err)
+		}
+		from = res.LastBlockHeight + 1
+	}
+	if to == 0 {
+		to = blockStore.Height()
+	}
+
+	return state.ReplayBlocks(ctx, proxyApp, blockStore, stateStore, genDoc, from, to, logger)
+}
+
+// restoreSnapshot offers the snapshot in dir to the application, applies all of
+// its chunks and verifies the restored app against the stored headers. It
+// returns the height of the snapshot.
+func restoreSnapshot(proxyApp proxy.AppConns, blockStore state.BlockStore, dir string) (int64, error) {
+	bz, err := os.ReadFile(filepath.Join(dir, "snapshot.json"))
+	if err != nil {
+		return 0, err
+	}
+	var snapshot abci.Snapshot
+	if err := json.Unmarshal(bz, &snapshot); err != nil {
+		return 0, fmt.Errorf("error reading snapshot metadata: %w", err)
+	}
+	height := int64(snapshot.Height)
+
+	// The app hash resulting from executing the snapshot height is committed to
+	// in the header of the next height.
+	meta := blockStore.LoadBlockMeta(height + 1)
+	if meta == nil {
+		return 0, fmt.Errorf("no block at height %d to verify the snapshot at height %d", height+1, height)
+	}
+	appHash := meta.Header.AppHash
+
+	offerRes, err := proxyApp.Snapshot().OfferSnapshotSync(abci.RequestOfferSnapshot{
+		Snapshot: &snapshot,
+		AppHash:  appHash,
+	})
+	if err != nil {
+		return 0, err
+	}
+	if offerRes.Result != abci.ResponseOfferSnapshot_ACCEPT {
+		return 0, fmt.Errorf("snapshot was not accepted: %v", offerRes.Result)
+	}
+
+	for index := uint32(0); index < snapshot.Chunks; index++ {
+		chunk, err := os.ReadFile(filepath.Join(dir, strconv.FormatUint(uint64(index), 10)))
+		if err != nil {
+			return 0, err
+		}
+		res, err := proxyApp.Snapshot().ApplySnapshotChunkSync(abci.RequestApplySnapshotChunk{
+			Index: index,
+			Chunk: chunk,
+		})
+		if err != nil {
+			return 0, err
+		}
+		if res.Result != abci.ResponseApplySnapshotChunk_ACCEPT {
+			return 0, fmt.Errorf("chunk %d was not accepted: %v", index, res.Result)
+		}
+	}
+
+	res, err := proxyApp.Query().InfoSync(proxy.RequestInfo)
+	if err != nil {
+		return 0, fmt.Errorf("error calling Info: %w", err)
+	}
+	if res.LastBlockHeight != height {
+		return 0, fmt.Errorf("app restored to height %d, expected height %d", res.LastBlockHeight, height)
+	}
+	if !bytes.Equal(res.LastBlockAppHash, appHash) {
+		return 0, fmt.Errorf("app restored to app hash %X, expected app hash %X", res.LastBlockAppHash, appHash)
+	}
+	return height, nil
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	cfg "github.com/tendermint/tendermint/config"
 	"github.com/tendermint/tendermint/libs/log"
 	cmtos "github.com/tendermint/tendermint/libs/os"
 	"github.com/tendermint/tendermint/privval"
@@ -33,12 +34,20 @@ var ResetStateCmd = &cobra.Command{
 			return err
 		}
 
-		return resetState(config.DBDir(), logger)
+		lock, err := lockDataDir(config)
+		if err != nil {
+			return err
+		}
+		defer releaseDataDirLock(lock)
+
+		return resetState(config, logger)
 	},
 }
 
 func init() {
 	ResetAllCmd.Flags().BoolVar(&keepAddrBook, "keep-addr-book", false, "keep the address book intact")
+	addForceLockFlag(ResetAllCmd)
+	addForceLockFlag(ResetStateCmd)
 }
 
 // ResetPrivValidatorCmd resets the private validator files.
@@ -56,14 +65,13 @@ func resetAllCmd(cmd *cobra.Command, args []string) (err error) {
 	if err != nil {
 		return err
 	}
+	lock, err := lockDataDir(config)
+	if err != nil {
+		return err
+	}
+	defer releaseDataDirLock(lock)
 
-	return resetAll(
-		config.DBDir(),
-		config.P2P.AddrBookFile(),
-		config.PrivValidatorKeyFile(),
-		config.PrivValidatorStateFile(),
-		logger,
-	)
+	return resetAll(config, logger)
 }
 
 // XXX: this is totally unsafe.
@@ -79,13 +87,18 @@ func resetPrivValidator(cmd *cobra.Command, args []string) (err error) {
 }
 
 // resetAll removes address book files plus all data, and resets the privValdiator data.
-func resetAll(dbDir, addrBookFile, privValKeyFile, privValStateFile string, logger log.Logger) error {
+func resetAll(conf *cfg.Config, logger log.Logger) error {
 	if keepAddrBook {
 		logger.Info("The address book remains intact")
 	} else {
-		removeAddrBook(addrBookFile, logger)
+		removeAddrBook(conf.P2P.AddrBookFile(), logger)
 	}
 
+	// the databases and the WAL may be outside of the database directory
+	if err := resetState(conf, logger); err != nil {
+		return err
+	}
+	dbDir := conf.DBDir()
 	if err := os.RemoveAll(dbDir); err == nil {
 		logger.Info("Removed all blockchain history", "dir", dbDir)
 	} else {
@@ -97,59 +110,36 @@ func resetAll(dbDir, addrBookFile, privValKeyFile, privValStateFile string, logg
 	}
 
 	// recreate the dbDir since the privVal state needs to live there
-	resetFilePV(privValKeyFile, privValStateFile, logger)
+	resetFilePV(conf.PrivValidatorKeyFile(), conf.PrivValidatorStateFile(), logger)
 	return nil
 }
 
-// resetState removes address book files plus all databases.
-func resetState(dbDir string, logger log.Logger) error {
-	blockdb := filepath.Join(dbDir, "blockstore.db")
-	state := filepath.Join(dbDir, "state.db")
-	wal := filepath.Join(dbDir, "cs.wal")
-	evidence := filepath.Join(dbDir, "evidence.db")
-	txIndex := filepath.Join(dbDir, "tx_index.db")
-
-	if cmtos.FileExists(blockdb) {
-		if err := os.RemoveAll(blockdb); err == nil {
-			logger.Info("Removed all blockstore.db", "dir", blockdb)
-		} else {
-			logger.Error("error removing all blockstore.db", "dir", blockdb, "err", err)
-		}
-	}
-
-	if cmtos.FileExists(state) {
-		if err := os.RemoveAll(state); err == nil {
-			logger.Info("Removed all state.db", "dir", state)
-		} else {
-			logger.Error("error removing all state.db", "dir", state, "err", err)
-		}
+// resetState removes all the databases, in their configured directories, and
+// the consensus WAL.
+func resetState(conf *cfg.Config, logger log.Logger) error {
+	paths := make([]string, 0, len(cfg.StoreIDs))
+	for _, id := range cfg.StoreIDs {
+		paths = append(paths, conf.StorePath(id))
 	}
-
-	if cmtos.FileExists(wal) {
-		if err := os.RemoveAll(wal); err == nil {
-			logger.Info("Removed all cs.wal", "dir", wal)
-		} else {
-			logger.Error("error removing all cs.wal", "dir", wal, "err", err)
-		}
+	// the WAL and the files it was rotated to, <wal_file>.000 and so on
+	walFiles, err := filepath.Glob(conf.Consensus.WalFile() + "*")
+	if err != nil {
+		return err
 	}
+	paths = append(paths, walFiles...)
 
-	if cmtos.FileExists(evidence) {
-		if err := os.RemoveAll(evidence); err == nil {
-			logger.Info("Removed all evidence.db", "dir", evidence)
-		} else {
-			logger.Error("error removing all evidence.db", "dir", evidence, "err", err)
+	for _, path := range paths {
+		if !cmtos.FileExists(path) {
+			continue
 		}
-	}
-
-	if cmtos.FileExists(txIndex) {
-		if err := os.RemoveAll(txIndex); err == nil {
-			logger.Info("Removed tx_index.db", "dir", txIndex)
+		if err := os.RemoveAll(path); err == nil {
+			logger.Info("Removed all "+filepath.Base(path), "dir", path)
 		} else {
-			logger.Error("error removing tx_index.db", "dir", txIndex, "err", err)
+			logger.Error("error removing all "+filepath.Base(path), "dir", path, "err", err)
 		}
 	}
 
-	if err := cmtos.EnsureDir(dbDir, 0700); err != nil {
+	if err := cmtos.EnsureDir(conf.DBDir(), 0700); err != nil {
 		logger.Error("unable to recreate dbDir", "err", err)
 	}
 	return nil
@@ -1,12 +1,14 @@
 package commands
 
 import (
+	"os"
 	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
 	cfg "github.com/tendermint/tendermint/config"
+	cmtos "github.com/tendermint/tendermint/libs/os"
 	"github.com/tendermint/tendermint/privval"
 )
 
@@ -19,8 +21,7 @@ func Test_ResetAll(t *testing.T) {
 	pv := privval.LoadFilePV(config.PrivValidatorKeyFile(), config.PrivValidatorStateFile())
 	pv.LastSignState.Height = 10
 	pv.Save()
-	require.NoError(t, resetAll(config.DBDir(), config.P2P.AddrBookFile(), config.PrivValidatorKeyFile(),
-		config.PrivValidatorStateFile(), logger))
+	require.NoError(t, resetAll(config, logger))
 	require.DirExists(t, config.DBDir())
 	require.NoFileExists(t, filepath.Join(config.DBDir(), "block.db"))
 	require.NoFileExists(t, filepath.Join(config.DBDir(), "state.db"))
@@ -40,7 +41,7 @@ func Test_ResetState(t *testing.T) {
 	pv := privval.LoadFilePV(config.PrivValidatorKeyFile(), config.PrivValidatorStateFile())
 	pv.LastSignState.Height = 10
 	pv.Save()
-	require.NoError(t, resetState(config.DBDir(), logger))
+	require.NoError(t, resetState(config, logger))
 	require.DirExists(t, config.DBDir())
 	require.NoFileExists(t, filepath.Join(config.DBDir(), "block.db"))
 	require.NoFileExists(t, filepath.Join(config.DBDir(), "state.db"))
@@ -51,3 +52,32 @@ func Test_ResetState(t *testing.T) {
 	// private validator state should still be in tact.
 	require.Equal(t, int64(10), pv.LastSignState.Height)
 }
+
+func Test_ResetStateSplitDirs(t *testing.T) {
+	config := cfg.TestConfig()
+	dir := t.TempDir()
+	config.SetRoot(dir)
+	cfg.EnsureRoot(dir)
+	config.BlockStorePath = "cold"
+	config.StatePath = "fast"
+	config.Consensus.WalPath = "fast/cs.wal/wal"
+	require.NoError(t, initFilesWithConfig(config))
+	require.NoError(t, cfg.EnsureStoreDirs(config.BaseConfig))
+	require.NoError(t, cmtos.EnsureDir(filepath.Dir(config.Consensus.WalFile()), 0o700))
+	files := []string{config.Consensus.WalFile(), config.Consensus.WalFile() + ".000"}
+	for _, id := range cfg.StoreIDs {
+		require.NoError(t, cmtos.EnsureDir(config.StorePath(id), 0o700))
+		files = append(files, config.StorePath(id))
+	}
+	for _, file := range files[:2] {
+		require.NoError(t, os.WriteFile(file, []byte("wal"), 0o600))
+	}
+
+	require.NoError(t, resetState(config, logger))
+	for _, file := range files {
+		require.NoFileExists(t, file)
+		require.NoDirExists(t, file)
+	}
+	require.DirExists(t, filepath.Join(dir, "cold"))
+	require.FileExists(t, config.PrivValidatorStateFile())
+}
@@ -2,7 +2,6 @@ package commands
 
 import (
 	"fmt"
-	"path/filepath"
 
 	"github.com/spf13/cobra"
 
@@ -36,10 +35,20 @@ application.
 	},
 }
 
+func init() {
+	addForceLockFlag(RollbackStateCmd)
+}
+
 // RollbackState takes the state at the current height n and overwrites it with the state
 // at height n - 1. Note state here refers to CometBFT state not application state.
 // Returns the latest state height and app hash alongside an error if there was one.
 func RollbackState(config *cfg.Config) (int64, []byte, error) {
+	lock, err := lockDataDir(config)
+	if err != nil {
+		return -1, nil, err
+	}
+	defer releaseDataDirLock(lock)
+
 	// use the parsed config to load the block and state store
 	blockStore, stateStore, err := loadStateAndBlockStore(config)
 	if err != nil {
@@ -57,23 +66,23 @@ func RollbackState(config *cfg.Config) (int64, []byte, error) {
 func loadStateAndBlockStore(config *cfg.Config) (*store.BlockStore, state.Store, error) {
 	dbType := dbm.BackendType(config.DBBackend)
 
-	if !os.FileExists(filepath.Join(config.DBDir(), "blockstore.db")) {
-		return nil, nil, fmt.Errorf("no blockstore found in %v", config.DBDir())
+	if !os.FileExists(config.StorePath("blockstore")) {
+		return nil, nil, fmt.Errorf("no blockstore found in %v", config.StoreDir("blockstore"))
 	}
 
 	// Get BlockStore
-	blockStoreDB, err := dbm.NewDB("blockstore", dbType, config.DBDir())
+	blockStoreDB, err := dbm.NewDB("blockstore", dbType, config.StoreDir("blockstore"))
 	if err != nil {
 		return nil, nil, err
 	}
 	blockStore := store.NewBlockStore(blockStoreDB)
 
-	if !os.FileExists(filepath.Join(config.DBDir(), "state.db")) {
-		return nil, nil, fmt.Errorf("no statestore found in %v", config.DBDir())
+	if !os.FileExists(config.StorePath("state")) {
+		return nil, nil, fmt.Errorf("no statestore found in %v", config.StoreDir("state"))
 	}
 
 	// Get StateStore
-	stateDB, err := dbm.NewDB("state", dbType, config.DBDir())
+	stateDB, err := dbm.NewDB("state", dbType, config.StoreDir("state"))
 	if err != nil {
 		return nil, nil, err
 	}
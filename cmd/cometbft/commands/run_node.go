@@ -132,9 +132,12 @@ func NewRunNodeCmd(nodeProvider nm.Provider) *cobra.Command {
 				return err
 			}
 
+			if err := breakStaleLock(config); err != nil {
+				return err
+			}
 			n, err := nodeProvider(config, logger)
 			if err != nil {
-				return fmt.Errorf("failed to create node: %w", err)
+				return fmt.Errorf("failed to create node: %w", lockHint(err))
 			}
 
 			if err := n.Start(); err != nil {
@@ -158,6 +161,7 @@ func NewRunNodeCmd(nodeProvider nm.Provider) *cobra.Command {
 	}
 
 	AddNodeFlags(cmd)
+	addForceLockFlag(cmd)
 	return cmd
 }
 
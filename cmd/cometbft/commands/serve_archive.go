@@ -0,0 +1,81 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	cmtos "github.com/tendermint/tendermint/libs/os"
+	nm "github.com/tendermint/tendermint/node"
+	"github.com/tendermint/tendermint/proxy"
+)
+
+var serveArchiveQueryApp bool
+
+// ServeArchiveCmd serves the RPC of a node from its databases, without running
+// p2p or consensus.
+var ServeArchiveCmd = &cobra.Command{
+	Use:     "serve-archive",
+	Aliases: []string{"serve_archive"},
+	Short:   "Serve the RPC of a node from its databases, without p2p or consensus",
+	Long: `
+serve-archive serves the RPC, on the configured rpc.laddr, from the blockstore, state and
+tx index databases of the home directory, e.g. copied from another node. No p2p, consensus
+or mempool is run, so the databases are never written to.
+
+The read-only routes are served, e.g. /block, /tx, /block_results and /status, which
+reports archive_mode. The routes needing a running node, e.g. /net_info, /broadcast_tx_sync
+or /subscribe, return an error as not available in archive mode.
+
+The proofs, e.g. of /tx?prove=true, and /abci_query need the application: with --query_app,
+it is queried at proxy_app.
+`,
+	Example: `
+	cometbft serve-archive --home /mnt/archive
+	cometbft serve-archive --home /mnt/archive --query_app --proxy_app tcp://127.0.0.1:26658
+	`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var clientCreator proxy.ClientCreator
+		if serveArchiveQueryApp {
+			clientCreator = proxy.DefaultClientCreator(config.ProxyApp, config.ABCI, config.DBDir())
+		}
+
+		if err := breakStaleLock(config); err != nil {
+			return err
+		}
+		s, err := nm.NewArchiveServer(config, nm.DefaultDBProvider, nm.DefaultGenesisDocProviderFunc(config),
+			clientCreator, logger)
+		if err != nil {
+			return fmt.Errorf("failed to create archive server: %w", lockHint(err))
+		}
+		if err := s.Start(); err != nil {
+			return fmt.Errorf("failed to start archive server: %w", err)
+		}
+		logger.Info("Started archive server", "listeners", s.Listeners())
+
+		// Stop upon receiving SIGTERM or CTRL-C.
+		cmtos.TrapSignal(logger, func() {
+			if s.IsRunning() {
+				if err := s.Stop(); err != nil {
+					logger.Error("unable to stop the archive server", "error", err)
+				}
+			}
+		})
+
+		// Run forever.
+		select {}
+	},
+}
+
+func init() {
+	ServeArchiveCmd.Flags().BoolVar(&serveArchiveQueryApp, "query_app", false,
+		"query the application at proxy_app for the proofs and /abci_query")
+	ServeArchiveCmd.Flags().String(
+		"proxy_app",
+		config.ProxyApp,
+		"proxy app address, or one of: 'kvstore',"+
+			" 'persistent_kvstore', 'counter', 'e2e' or 'noop' for local testing.")
+	ServeArchiveCmd.Flags().String("abci", config.ABCI, "specify abci transport (socket | grpc)")
+	ServeArchiveCmd.Flags().String("rpc.laddr", config.RPC.ListenAddress, "RPC listen address. Port required")
+	addForceLockFlag(ServeArchiveCmd)
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/tendermint/tendermint/p2p"
+	"github.com/tendermint/tendermint/p2p/pkcs11"
 )
 
 // ShowNodeIDCmd dumps node's ID to the standard output.
@@ -17,6 +18,17 @@ var ShowNodeIDCmd = &cobra.Command{
 }
 
 func showNodeID(cmd *cobra.Command, args []string) error {
+	if config.P2P.NodeKeyPKCS11.Enabled() {
+		signer, err := pkcs11.NewSigner(config.P2P.NodeKeyPKCS11)
+		if err != nil {
+			return err
+		}
+		defer signer.Close()
+
+		fmt.Println(p2p.PubKeyToID(signer.PubKey()))
+		return nil
+	}
+
 	nodeKey, err := p2p.LoadNodeKey(config.NodeKeyFile())
 	if err != nil {
 		return err
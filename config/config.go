@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 )
 
@@ -152,12 +153,47 @@ func (cfg *Config) ValidateBasic() error {
 	if err := cfg.Consensus.ValidateBasic(); err != nil {
 		return fmt.Errorf("error in [consensus] section: %w", err)
 	}
+	if err := cfg.TxIndex.ValidateBasic(); err != nil {
+		return fmt.Errorf("error in [tx_index] section: %w", err)
+	}
 	if err := cfg.Instrumentation.ValidateBasic(); err != nil {
 		return fmt.Errorf("error in [instrumentation] section: %w", err)
 	}
+	return cfg.validateStorePaths()
+}
+
+// validateStorePaths checks that no database directory, nor the consensus WAL,
+// is in the files of a database, as they would corrupt it. The databases can
+// share a directory, each being stored in <dir>/<name>.db.
+func (cfg *Config) validateStorePaths() error {
+	type path struct{ key, dir string }
+	paths := []path{
+		{"db_dir", cfg.DBDir()},
+		{"consensus.wal_file", filepath.Dir(cfg.Consensus.WalFile())},
+	}
+	for _, id := range StoreIDs {
+		paths = append(paths, path{id + "_dir", cfg.StoreDir(id)})
+	}
+	for _, id := range StoreIDs {
+		db := filepath.Clean(cfg.StorePath(id))
+		for _, p := range paths {
+			if isWithin(filepath.Clean(p.dir), db) {
+				return fmt.Errorf("%s %q is in the %s database %q", p.key, p.dir, id, db)
+			}
+		}
+	}
 	return nil
 }
 
+// isWithin returns whether path is dir or a path in dir.
+func isWithin(path, dir string) bool {
+	rel, err := filepath.Rel(dir, path)
+	if err != nil {
+		return false
+	}
+	return rel == "." || (rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)))
+}
+
 //-----------------------------------------------------------------------------
 // BaseConfig
 
@@ -174,6 +210,15 @@ type BaseConfig struct { //nolint: maligned
 	// or the name of an ABCI application compiled in with the CometBFT binary
 	ProxyApp string `mapstructure:"proxy_app"`
 
+	// TCP or UNIX socket addresses of the ABCI applications serving the
+	// consensus, mempool, query and snapshot connections, or the names of ABCI
+	// applications compiled in with the CometBFT binary. If empty, the
+	// connection is served by ProxyApp
+	ProxyAppConsensus string `mapstructure:"proxy_app_consensus"`
+	ProxyAppMempool   string `mapstructure:"proxy_app_mempool"`
+	ProxyAppQuery     string `mapstructure:"proxy_app_query"`
+	ProxyAppSnapshot  string `mapstructure:"proxy_app_snapshot"`
+
 	// A custom human readable name for this node
 	Moniker string `mapstructure:"moniker"`
 
@@ -206,12 +251,24 @@ type BaseConfig struct { //nolint: maligned
 	// Database directory
 	DBPath string `mapstructure:"db_dir"`
 
+	// Directories of the block store, state, tx index and evidence databases,
+	// e.g. to keep them on distinct disks. They default to the database
+	// directory when empty.
+	BlockStorePath string `mapstructure:"blockstore_dir"`
+	StatePath      string `mapstructure:"state_dir"`
+	TxIndexPath    string `mapstructure:"tx_index_dir"`
+	EvidencePath   string `mapstructure:"evidence_dir"`
+
 	// Output level for logging
 	LogLevel string `mapstructure:"log_level"`
 
 	// Output format: 'plain' (colored text) or 'json'
 	LogFormat string `mapstructure:"log_format"`
 
+	// Number of the most recent log lines kept in memory and reported by the
+	// /debug_bundle RPC endpoint. 0 disables the buffer.
+	LogRingBufferSize int `mapstructure:"log_ring_buffer_size"`
+
 	// Path to the JSON file containing the initial validator set and other meta data
 	Genesis string `mapstructure:"genesis_file"`
 
@@ -294,6 +351,42 @@ func (cfg BaseConfig) DBDir() string {
 	return rootify(cfg.DBPath, cfg.RootDir)
 }
 
+// DBLockFile returns the full path to the lock file held by the process using
+// the database directory
+func (cfg BaseConfig) DBLockFile() string {
+	return filepath.Join(cfg.DBDir(), "node.lock")
+}
+
+// StoreIDs are the names of the databases of the node whose directory can be
+// configured, see StoreDir.
+var StoreIDs = []string{"blockstore", "state", "tx_index", "evidence"}
+
+// StoreDir returns the full path to the directory of the database with the
+// given name, e.g. "blockstore", in which it is stored as <name>.db. The
+// databases without a directory of their own are in the database directory.
+func (cfg BaseConfig) StoreDir(id string) string {
+	var path string
+	switch id {
+	case "blockstore":
+		path = cfg.BlockStorePath
+	case "state":
+		path = cfg.StatePath
+	case "tx_index":
+		path = cfg.TxIndexPath
+	case "evidence":
+		path = cfg.EvidencePath
+	}
+	if path == "" {
+		return cfg.DBDir()
+	}
+	return rootify(path, cfg.RootDir)
+}
+
+// StorePath returns the full path to the database with the given name.
+func (cfg BaseConfig) StorePath(id string) string {
+	return filepath.Join(cfg.StoreDir(id), id+".db")
+}
+
 // ValidateBasic performs basic validation (checking param bounds, etc.) and
 // returns an error if any check fails.
 func (cfg BaseConfig) ValidateBasic() error {
@@ -302,6 +395,9 @@ func (cfg BaseConfig) ValidateBasic() error {
 	default:
 		return errors.New("unknown log_format (must be 'plain' or 'json')")
 	}
+	if cfg.LogRingBufferSize < 0 {
+		return errors.New("log_ring_buffer_size can't be negative")
+	}
 	return nil
 }
 
@@ -328,7 +424,9 @@ type RPCConfig struct {
 	CORSAllowedHeaders []string `mapstructure:"cors_allowed_headers"`
 
 	// TCP or UNIX socket address for the gRPC server to listen on
-	// NOTE: This server only supports /broadcast_tx_commit
+	// NOTE: This server only supports /broadcast_tx_commit, along with the
+	// standard gRPC health and server reflection services. It uses TLS if
+	// TLSCertFile and TLSKeyFile are set.
 	GRPCListenAddress string `mapstructure:"grpc_laddr"`
 
 	// Maximum number of simultaneous connections.
@@ -341,6 +439,26 @@ type RPCConfig struct {
 	// Activate unsafe RPC commands like /dial_persistent_peers and /unsafe_flush_mempool
 	Unsafe bool `mapstructure:"unsafe"`
 
+	// Reject the RPC commands which mutate the state of the node or of the
+	// network, like /broadcast_tx_sync, with a read-only error, as well as the
+	// unsafe ones. It can't be combined with unsafe, nor with a gRPC server, as
+	// the latter broadcasts transactions.
+	ReadOnly bool `mapstructure:"read_only"`
+
+	// The path to a JSON file of API keys, each granting the calls of a set of
+	// method classes: "read", "broadcast", "subscribe" and "unsafe". Might be
+	// either absolute path or path related to CometBFT's config directory.
+	// The file is reloaded once modified. If set, the calls of the classes
+	// which are not public need a key granting them, presented as a bearer
+	// token in the Authorization header, or in the first message of a
+	// WebSocket connection. It can't be combined with a gRPC server, which is
+	// not authenticated.
+	AuthKeysFile string `mapstructure:"auth_keys_file"`
+
+	// The method classes whose calls need no API key when auth_keys_file is
+	// set.
+	AuthPublicClasses []string `mapstructure:"auth_public_classes"`
+
 	// Maximum number of simultaneous connections (including WebSocket).
 	// Does not include gRPC connections. See grpc_max_open_connections
 	// If you want to accept a larger number than the default, make sure
@@ -360,6 +478,14 @@ type RPCConfig struct {
 	// to the estimated maximum number of broadcast_tx_commit calls per block.
 	MaxSubscriptionsPerClient int `mapstructure:"max_subscriptions_per_client"`
 
+	// Maximum number of subscriptions of all the clients together
+	MaxSubscriptions int `mapstructure:"max_subscriptions"`
+
+	// If true, a client subscribing again to a query it is already subscribed
+	// to gets the ID of the existing subscription instead of an error. Queries
+	// only differing by the spaces around operators are the same query.
+	ReuseDuplicateSubscriptions bool `mapstructure:"reuse_duplicate_subscriptions"`
+
 	// The number of events that can be buffered per subscription before
 	// returning `ErrOutOfCapacity`.
 	SubscriptionBufferSize int `mapstructure:"experimental_subscription_buffer_size"`
@@ -383,15 +509,43 @@ type RPCConfig struct {
 	// predictability in subscription behaviour.
 	CloseOnSlowClient bool `mapstructure:"experimental_close_on_slow_client"`
 
+	// How often the WebSocket clients are pinged, to tell the ones which
+	// vanished without closing their connection.
+	WebSocketPingInterval time.Duration `mapstructure:"websocket_ping_interval"`
+
+	// The number of pings in a row a WebSocket client may leave unanswered,
+	// without sending anything else either, before its connection is closed
+	// and its subscriptions are removed.
+	WebSocketPongMissThreshold int `mapstructure:"websocket_pong_miss_threshold"`
+
 	// How long to wait for a tx to be committed during /broadcast_tx_commit
 	// WARNING: Using a value larger than 10s will result in increasing the
 	// global HTTP write timeout, which applies to all connections and endpoints.
 	// See https://github.com/tendermint/tendermint/issues/3435
 	TimeoutBroadcastTxCommit time.Duration `mapstructure:"timeout_broadcast_tx_commit"`
 
+	// Maximum number of transactions submitted by the broadcast endpoints
+	// whose CheckTx is pending. Once reached, /broadcast_tx_async is rejected
+	// with a server overloaded error, to be retried later, while
+	// /broadcast_tx_sync and /broadcast_tx_commit wait up to
+	// BroadcastTxAdmissionWait for a transaction to be checked before being
+	// rejected the same way. 0 - unlimited.
+	MaxPendingBroadcastTxs int `mapstructure:"max_pending_broadcast_txs"`
+
+	// How long /broadcast_tx_sync and /broadcast_tx_commit wait for room among
+	// the MaxPendingBroadcastTxs pending transactions.
+	BroadcastTxAdmissionWait time.Duration `mapstructure:"broadcast_tx_admission_wait"`
+
 	// Maximum size of request body, in bytes
 	MaxBodyBytes int64 `mapstructure:"max_body_bytes"`
 
+	// Maximum size of a single WebSocket message, in bytes
+	MaxWebSocketMessageBytes int64 `mapstructure:"max_websocket_message_bytes"`
+
+	// Maximum encoded size of a single byte-carrying request parameter (e.g. a
+	// tx or evidence), in bytes. 0 - unlimited.
+	MaxParamBytes int64 `mapstructure:"max_param_bytes"`
+
 	// Maximum size of request header, in bytes
 	MaxHeaderBytes int `mapstructure:"max_header_bytes"`
 
@@ -402,21 +556,24 @@ type RPCConfig struct {
 	// the certFile should be the concatenation of the server's certificate, any intermediates,
 	// and the CA's certificate.
 	//
-	// NOTE: both tls_cert_file and tls_key_file must be present for CometBFT to create HTTPS server.
-	// Otherwise, HTTP server is run.
+	// NOTE: both tls_cert_file and tls_key_file must be present for CometBFT to create HTTPS server,
+	// and to use TLS on the gRPC server. Otherwise, HTTP server is run.
 	TLSCertFile string `mapstructure:"tls_cert_file"`
 
 	// The path to a file containing matching private key that is used to create the HTTPS server.
 	// Might be either absolute path or path related to CometBFT's config directory.
 	//
-	// NOTE: both tls_cert_file and tls_key_file must be present for CometBFT to create HTTPS server.
-	// Otherwise, HTTP server is run.
+	// NOTE: both tls_cert_file and tls_key_file must be present for CometBFT to create HTTPS server,
+	// and to use TLS on the gRPC server. Otherwise, HTTP server is run.
 	TLSKeyFile string `mapstructure:"tls_key_file"`
 
 	// pprof listen address (https://golang.org/pkg/net/http/pprof)
 	PprofListenAddress string `mapstructure:"pprof_laddr"`
 }
 
+// rpcMethodClasses are the method classes API keys grant the calls of.
+var rpcMethodClasses = map[string]bool{"read": true, "broadcast": true, "subscribe": true, "unsafe": true}
+
 // DefaultRPCConfig returns a default configuration for the RPC server
 func DefaultRPCConfig() *RPCConfig {
 	return &RPCConfig{
@@ -428,16 +585,27 @@ func DefaultRPCConfig() *RPCConfig {
 		GRPCMaxOpenConnections: 900,
 
 		Unsafe:             false,
+		ReadOnly:           false,
+		AuthKeysFile:       "",
+		AuthPublicClasses:  []string{"read"},
 		MaxOpenConnections: 900,
 
-		MaxSubscriptionClients:    100,
-		MaxSubscriptionsPerClient: 5,
-		SubscriptionBufferSize:    defaultSubscriptionBufferSize,
-		TimeoutBroadcastTxCommit:  10 * time.Second,
-		WebSocketWriteBufferSize:  defaultSubscriptionBufferSize,
-
-		MaxBodyBytes:   int64(1000000), // 1MB
-		MaxHeaderBytes: 1 << 20,        // same as the net/http default
+		MaxSubscriptionClients:      100,
+		MaxSubscriptionsPerClient:   5,
+		MaxSubscriptions:            500,
+		ReuseDuplicateSubscriptions: false,
+		SubscriptionBufferSize:      defaultSubscriptionBufferSize,
+		TimeoutBroadcastTxCommit:    10 * time.Second,
+		MaxPendingBroadcastTxs:      5000,
+		BroadcastTxAdmissionWait:    100 * time.Millisecond,
+		WebSocketWriteBufferSize:    defaultSubscriptionBufferSize,
+		WebSocketPingInterval:       5 * time.Second,
+		WebSocketPongMissThreshold:  3,
+
+		MaxBodyBytes:             int64(1000000), // 1MB
+		MaxWebSocketMessageBytes: int64(1000000), // 1MB
+		MaxParamBytes:            int64(1000000), // 1MB
+		MaxHeaderBytes:           1 << 20,        // same as the net/http default
 
 		TLSCertFile: "",
 		TLSKeyFile:  "",
@@ -468,6 +636,9 @@ func (cfg *RPCConfig) ValidateBasic() error {
 	if cfg.MaxSubscriptionsPerClient < 0 {
 		return errors.New("max_subscriptions_per_client can't be negative")
 	}
+	if cfg.MaxSubscriptions < 0 {
+		return errors.New("max_subscriptions can't be negative")
+	}
 	if cfg.SubscriptionBufferSize < minSubscriptionBufferSize {
 		return fmt.Errorf(
 			"experimental_subscription_buffer_size must be >= %d",
@@ -480,18 +651,57 @@ func (cfg *RPCConfig) ValidateBasic() error {
 			cfg.SubscriptionBufferSize,
 		)
 	}
+	if cfg.WebSocketPingInterval <= 0 {
+		return errors.New("websocket_ping_interval must be positive")
+	}
+	if cfg.WebSocketPongMissThreshold < 1 {
+		return errors.New("websocket_pong_miss_threshold must be at least 1")
+	}
 	if cfg.TimeoutBroadcastTxCommit < 0 {
 		return errors.New("timeout_broadcast_tx_commit can't be negative")
 	}
+	if cfg.MaxPendingBroadcastTxs < 0 {
+		return errors.New("max_pending_broadcast_txs can't be negative")
+	}
+	if cfg.BroadcastTxAdmissionWait < 0 {
+		return errors.New("broadcast_tx_admission_wait can't be negative")
+	}
 	if cfg.MaxBodyBytes < 0 {
 		return errors.New("max_body_bytes can't be negative")
 	}
+	if cfg.MaxWebSocketMessageBytes < 0 {
+		return errors.New("max_websocket_message_bytes can't be negative")
+	}
+	if cfg.MaxParamBytes < 0 {
+		return errors.New("max_param_bytes can't be negative")
+	}
 	if cfg.MaxHeaderBytes < 0 {
 		return errors.New("max_header_bytes can't be negative")
 	}
+	if cfg.ReadOnly && cfg.Unsafe {
+		return errors.New("read_only and unsafe can't both be enabled")
+	}
+	if cfg.ReadOnly && cfg.GRPCListenAddress != "" {
+		return errors.New("grpc_laddr must be empty when read_only is enabled, as the gRPC server broadcasts transactions")
+	}
+	for _, class := range cfg.AuthPublicClasses {
+		if !rpcMethodClasses[class] {
+			return fmt.Errorf("unknown method class %q in auth_public_classes, expected read, broadcast, subscribe or unsafe", class)
+		}
+	}
+	if cfg.AuthKeysFile != "" && cfg.GRPCListenAddress != "" {
+		return errors.New("grpc_laddr must be empty when auth_keys_file is set, as the gRPC server is not authenticated")
+	}
 	return nil
 }
 
+// WebSocketReadWait returns how long a WebSocket connection may stay silent
+// before it is closed: the client is dropped between the last ping it missed
+// and the next one.
+func (cfg *RPCConfig) WebSocketReadWait() time.Duration {
+	return time.Duration(cfg.WebSocketPongMissThreshold)*cfg.WebSocketPingInterval + cfg.WebSocketPingInterval/2
+}
+
 // IsCorsEnabled returns true if cross-origin resource sharing is enabled.
 func (cfg *RPCConfig) IsCorsEnabled() bool {
 	return len(cfg.CORSAllowedOrigins) != 0
@@ -513,6 +723,15 @@ func (cfg RPCConfig) CertFile() string {
 	return rootify(filepath.Join(defaultConfigDir, path), cfg.RootDir)
 }
 
+// AuthKeysFilePath returns the path to the API keys file.
+func (cfg RPCConfig) AuthKeysFilePath() string {
+	path := cfg.AuthKeysFile
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return rootify(filepath.Join(defaultConfigDir, path), cfg.RootDir)
+}
+
 func (cfg RPCConfig) IsTLSEnabled() bool {
 	return cfg.TLSCertFile != "" && cfg.TLSKeyFile != ""
 }
@@ -553,6 +772,15 @@ type P2PConfig struct { //nolint: maligned
 	// Maximum number of outbound peers to connect to, excluding persistent peers
 	MaxNumOutboundPeers int `mapstructure:"max_num_outbound_peers"`
 
+	// Make the number of outbound peers follow the size of the network
+	// observed through PEX, between MinNumOutboundPeers and
+	// MaxNumOutboundPeers. Surplus outbound peers are evicted gradually.
+	DynamicPeerTargets bool `mapstructure:"dynamic_peer_targets"`
+
+	// Minimum number of outbound peers with DynamicPeerTargets. 0 uses the
+	// default of the role of the node, see p2p.DefaultMinOutboundPeers.
+	MinNumOutboundPeers int `mapstructure:"min_num_outbound_peers"`
+
 	// List of node IDs, to which a connection will be (re)established ignoring any existing limits
 	UnconditionalPeerIDs string `mapstructure:"unconditional_peer_ids"`
 
@@ -587,10 +815,37 @@ type P2PConfig struct { //nolint: maligned
 	// Toggle to disable guard against peers connecting from the same ip.
 	AllowDuplicateIP bool `mapstructure:"allow_duplicate_ip"`
 
+	// Maximum number of incoming connections accepted from a single IP subnet
+	// within SubnetConnRateWindow. Connections beyond the limit are refused.
+	// 0 disables the limit.
+	MaxSubnetConnRate int `mapstructure:"max_subnet_conn_rate"`
+	// Window of time over which incoming connections from a subnet are counted.
+	SubnetConnRateWindow time.Duration `mapstructure:"subnet_conn_rate_window"`
+	// Length of the prefix grouping IPv4 and IPv6 addresses into subnets.
+	SubnetIPv4PrefixLen int `mapstructure:"subnet_ipv4_prefix_len"`
+	SubnetIPv6PrefixLen int `mapstructure:"subnet_ipv6_prefix_len"`
+
+	// Maximum number of peers within a single IP subnet, as grouped by the
+	// prefix lengths above. Applies to the addresses dialed and, once half of
+	// the inbound peer slots are taken, to inbound peers. Persistent and
+	// unconditional peers are exempt. 0 disables the limit.
+	MaxPeersPerSubnet int `mapstructure:"max_peers_per_subnet"`
+	// Path to an IP to ASN database, in the tab-separated format of the ip2asn
+	// databases, optionally gzipped. Required by MaxPeersPerASN.
+	ASNDBFile string `mapstructure:"asn_db_file"`
+	// Maximum number of peers within a single autonomous system, i.e. hosting
+	// provider, as found in ASNDBFile. Applies like MaxPeersPerSubnet. 0
+	// disables the limit.
+	MaxPeersPerASN int `mapstructure:"max_peers_per_asn"`
+
 	// Peer connection configuration.
 	HandshakeTimeout time.Duration `mapstructure:"handshake_timeout"`
 	DialTimeout      time.Duration `mapstructure:"dial_timeout"`
 
+	// Key in a PKCS#11 token, e.g. an HSM, authenticating the node in place
+	// of the key of node_key_file, if its module is set.
+	NodeKeyPKCS11 PKCS11Config `mapstructure:"node_key_pkcs11"`
+
 	// Testing params.
 	// Force dial to fail
 	TestDialFail bool `mapstructure:"test_dial_fail"`
@@ -609,6 +864,8 @@ func DefaultP2PConfig() *P2PConfig {
 		AddrBookStrict:               true,
 		MaxNumInboundPeers:           40,
 		MaxNumOutboundPeers:          10,
+		DynamicPeerTargets:           false,
+		MinNumOutboundPeers:          0,
 		PersistentPeersMaxDialPeriod: 0 * time.Second,
 		FlushThrottleTimeout:         100 * time.Millisecond,
 		MaxPacketMsgPayloadSize:      1024,    // 1 kB
@@ -617,6 +874,13 @@ func DefaultP2PConfig() *P2PConfig {
 		PexReactor:                   true,
 		SeedMode:                     false,
 		AllowDuplicateIP:             false,
+		MaxSubnetConnRate:            0,
+		SubnetConnRateWindow:         time.Minute,
+		SubnetIPv4PrefixLen:          24,
+		SubnetIPv6PrefixLen:          48,
+		MaxPeersPerSubnet:            0,
+		ASNDBFile:                    "",
+		MaxPeersPerASN:               0,
 		HandshakeTimeout:             20 * time.Second,
 		DialTimeout:                  3 * time.Second,
 		TestDialFail:                 false,
@@ -639,6 +903,11 @@ func (cfg *P2PConfig) AddrBookFile() string {
 	return rootify(cfg.AddrBook, cfg.RootDir)
 }
 
+// ASNDBFilePath returns the full path to the IP to ASN database
+func (cfg *P2PConfig) ASNDBFilePath() string {
+	return rootify(cfg.ASNDBFile, cfg.RootDir)
+}
+
 // ValidateBasic performs basic validation (checking param bounds, etc.) and
 // returns an error if any check fails.
 func (cfg *P2PConfig) ValidateBasic() error {
@@ -648,6 +917,15 @@ func (cfg *P2PConfig) ValidateBasic() error {
 	if cfg.MaxNumOutboundPeers < 0 {
 		return errors.New("max_num_outbound_peers can't be negative")
 	}
+	if cfg.MinNumOutboundPeers < 0 {
+		return errors.New("min_num_outbound_peers can't be negative")
+	}
+	if cfg.MinNumOutboundPeers > cfg.MaxNumOutboundPeers {
+		return errors.New("min_num_outbound_peers can't be greater than max_num_outbound_peers")
+	}
+	if cfg.DynamicPeerTargets && !cfg.PexReactor {
+		return errors.New("dynamic_peer_targets requires pex")
+	}
 	if cfg.FlushThrottleTimeout < 0 {
 		return errors.New("flush_throttle_timeout can't be negative")
 	}
@@ -663,6 +941,61 @@ func (cfg *P2PConfig) ValidateBasic() error {
 	if cfg.RecvRate < 0 {
 		return errors.New("recv_rate can't be negative")
 	}
+	if cfg.MaxSubnetConnRate < 0 {
+		return errors.New("max_subnet_conn_rate can't be negative")
+	}
+	if cfg.MaxSubnetConnRate > 0 && cfg.SubnetConnRateWindow <= 0 {
+		return errors.New("subnet_conn_rate_window must be positive")
+	}
+	if cfg.SubnetIPv4PrefixLen < 0 || cfg.SubnetIPv4PrefixLen > 32 {
+		return errors.New("subnet_ipv4_prefix_len must be between 0 and 32")
+	}
+	if cfg.SubnetIPv6PrefixLen < 0 || cfg.SubnetIPv6PrefixLen > 128 {
+		return errors.New("subnet_ipv6_prefix_len must be between 0 and 128")
+	}
+	if cfg.MaxPeersPerSubnet < 0 {
+		return errors.New("max_peers_per_subnet can't be negative")
+	}
+	if cfg.MaxPeersPerASN < 0 {
+		return errors.New("max_peers_per_asn can't be negative")
+	}
+	if cfg.MaxPeersPerASN > 0 && cfg.ASNDBFile == "" {
+		return errors.New("max_peers_per_asn requires asn_db_file")
+	}
+	if err := cfg.NodeKeyPKCS11.ValidateBasic(); err != nil {
+		return fmt.Errorf("node_key_pkcs11: %w", err)
+	}
+	return nil
+}
+
+// PKCS11Config locates an ed25519 key in a PKCS#11 token, e.g. an HSM.
+type PKCS11Config struct {
+	// Path to the PKCS#11 module of the token, e.g.
+	// /usr/lib/softhsm/libsofthsm2.so. Empty to not use a token.
+	Module string `mapstructure:"module"`
+	// Slot of the token.
+	Slot uint `mapstructure:"slot"`
+	// Label of the key, shared by its private and public key objects.
+	KeyLabel string `mapstructure:"key_label"`
+	// Name of the environment variable holding the user PIN of the token, not
+	// to write it in the config file. Empty to not log in.
+	PINEnv string `mapstructure:"pin_env"`
+}
+
+// Enabled returns true if the key is in a token.
+func (cfg PKCS11Config) Enabled() bool {
+	return cfg.Module != ""
+}
+
+// ValidateBasic performs basic validation and returns an error if any check
+// fails.
+func (cfg PKCS11Config) ValidateBasic() error {
+	if !cfg.Enabled() {
+		return nil
+	}
+	if cfg.KeyLabel == "" {
+		return errors.New("key_label is required with module")
+	}
 	return nil
 }
 
@@ -758,6 +1091,19 @@ type MempoolConfig struct {
 	// Only applicable to the v2 / CAT mempool
 	// Default is 200ms
 	MaxGossipDelay time.Duration `mapstructure:"max-gossip-delay"`
+
+	// MaxGossipTxAge, if non-zero, defines the maximum number of blocks since
+	// a transaction was first seen for it to be gossiped: the received
+	// transactions the sender first saw more blocks ago are dropped without
+	// CheckTx, and the transactions the node first saw more blocks ago are not
+	// sent to its peers anymore. The transactions received from peers which do
+	// not report when they first saw them are not filtered.
+	MaxGossipTxAge int64 `mapstructure:"max-gossip-tx-age"`
+
+	// EnforceAppRequirements makes the mempool reject, before CheckTx, the
+	// transactions larger than the maximum size the application reports in
+	// ResponseInfo.MempoolRequirements, which the RPC advertises.
+	EnforceAppRequirements bool `mapstructure:"enforce-app-requirements"`
 }
 
 // DefaultMempoolConfig returns a default configuration for the CometBFT mempool
@@ -810,6 +1156,9 @@ func (cfg *MempoolConfig) ValidateBasic() error {
 	if cfg.MaxTxBytes < 0 {
 		return errors.New("max_tx_bytes can't be negative")
 	}
+	if cfg.MaxGossipTxAge < 0 {
+		return errors.New("max-gossip-tx-age can't be negative")
+	}
 	return nil
 }
 
@@ -827,6 +1176,31 @@ type StateSyncConfig struct {
 	DiscoveryTime       time.Duration `mapstructure:"discovery_time"`
 	ChunkRequestTimeout time.Duration `mapstructure:"chunk_request_timeout"`
 	ChunkFetchers       int32         `mapstructure:"chunk_fetchers"`
+
+	// SnapshotInterval is the height interval at which the node asks the
+	// application to take a state machine snapshot. 0 disables it.
+	SnapshotInterval uint64 `mapstructure:"snapshot_interval"`
+	// SnapshotKeepRecent is the number of most recent snapshots the node keeps
+	// when pruning. 0 keeps all snapshots.
+	SnapshotKeepRecent uint32 `mapstructure:"snapshot_keep_recent"`
+
+	// Timeout bounds the whole state sync, snapshot discovery included. When
+	// it expires, the node gives up on state sync and falls back to fast sync
+	// from its initial state. 0 disables it.
+	Timeout time.Duration `mapstructure:"timeout"`
+	// MaxAttempts is the number of snapshot restorations the node attempts
+	// before giving up on state sync and falling back to fast sync. 0 means no
+	// limit.
+	MaxAttempts uint32 `mapstructure:"max_attempts"`
+	// FallbackHeight is the height from which the node fast syncs when it
+	// falls back from state sync. The app must already hold the state of that
+	// height, whose app hash is verified with the light client. 0 falls back
+	// to fast sync from genesis.
+	//
+	// The node does not clean up the state of a snapshot the app partially
+	// restored before the fallback: the app must discard it itself, e.g. when
+	// InitChain is called on the fallback from genesis.
+	FallbackHeight int64 `mapstructure:"fallback_height"`
 }
 
 func (cfg *StateSyncConfig) TrustHashBytes() []byte {
@@ -845,6 +1219,7 @@ func DefaultStateSyncConfig() *StateSyncConfig {
 		DiscoveryTime:       15 * time.Second,
 		ChunkRequestTimeout: 10 * time.Second,
 		ChunkFetchers:       4,
+		SnapshotKeepRecent:  2,
 	}
 }
 
@@ -900,6 +1275,14 @@ func (cfg *StateSyncConfig) ValidateBasic() error {
 		}
 	}
 
+	if cfg.Timeout < 0 {
+		return errors.New("timeout can't be negative")
+	}
+
+	if cfg.FallbackHeight < 0 {
+		return errors.New("fallback_height can't be negative")
+	}
+
 	return nil
 }
 
@@ -974,11 +1357,58 @@ type ConsensusConfig struct {
 	CreateEmptyBlocks         bool          `mapstructure:"create_empty_blocks"`
 	CreateEmptyBlocksInterval time.Duration `mapstructure:"create_empty_blocks_interval"`
 
+	// With create_empty_blocks = false, the proposer waits for the mempool to
+	// hold at least MinTxsInBlock txs, or for MaxEmptyBlockInterval to elapse
+	// since the last block was committed, whichever comes first. 0 means a
+	// single tx and no interval respectively. A block is created regardless when the
+	// app hash or the validator set changed, or when evidence is pending.
+	MinTxsInBlock         int           `mapstructure:"min_txs_in_block"`
+	MaxEmptyBlockInterval time.Duration `mapstructure:"max_empty_block_interval"`
+
 	// Reactor sleep duration parameters
 	PeerGossipSleepDuration     time.Duration `mapstructure:"peer_gossip_sleep_duration"`
 	PeerQueryMaj23SleepDuration time.Duration `mapstructure:"peer_query_maj23_sleep_duration"`
 
 	DoubleSignCheckHeight int64 `mapstructure:"double_sign_check_height"`
+
+	// Record why the txs of the mempool were left out of the blocks proposed
+	// by this node, for the last heights. They are served by the
+	// unsafe_proposal_debug RPC endpoint.
+	RecordReapDecisions bool `mapstructure:"record_reap_decisions"`
+
+	// The drift of the local clock, estimated from the timestamps of the votes
+	// of the peers and from the times of the recent blocks, above which a
+	// warning is logged and a ClockDrift event is published. 0 disables the
+	// warning, the drift estimate is still exposed as a metric.
+	ClockDriftThreshold time.Duration `mapstructure:"clock_drift_threshold"`
+
+	// Announce the validator run by this node to its peers, and learn the
+	// validators run by the peers from their announcements, to gossip the
+	// votes and block parts first to the peers of the validators which have
+	// not voted yet.
+	ValidatorAnnouncements bool `mapstructure:"validator_announcements"`
+
+	// The number of heights around the current height within which the
+	// consensus messages of the peers must fall. Peers repeatedly sending
+	// messages out of that window, or with absurd rounds, part counts or
+	// validator indexes, are disconnected and banned. 0 disables the height
+	// check.
+	PeerMsgHeightSlack int64 `mapstructure:"peer_msg_height_slack"`
+
+	// How long the node holds its participation in consensus, once paused for
+	// an upgrade of the application, before giving up waiting for the upgraded
+	// application and resuming with an alert.
+	AppUpgradeTimeout time.Duration `mapstructure:"app_upgrade_timeout"`
+
+	// The number of rounds after the round the node locked on a block in which
+	// +2/3 of the validators prevoted without a polka for the block, above
+	// which the node is deemed stuck on a stale lock: the round state and the
+	// states of the peers are logged and the stale_locks metric is
+	// incremented. 0 disables the detection.
+	StaleLockRounds int `mapstructure:"stale_lock_rounds"`
+	// On a stale lock, also forget the votes and the proposal known by each
+	// peer, for them to be gossiped again.
+	StaleLockResync bool `mapstructure:"stale_lock_resync"`
 }
 
 // DefaultConsensusConfig returns a default configuration for the consensus service
@@ -996,9 +1426,18 @@ func DefaultConsensusConfig() *ConsensusConfig {
 		SkipTimeoutCommit:           false,
 		CreateEmptyBlocks:           true,
 		CreateEmptyBlocksInterval:   0 * time.Second,
+		MinTxsInBlock:               0,
+		MaxEmptyBlockInterval:       0 * time.Second,
 		PeerGossipSleepDuration:     100 * time.Millisecond,
 		PeerQueryMaj23SleepDuration: 2000 * time.Millisecond,
 		DoubleSignCheckHeight:       int64(0),
+		RecordReapDecisions:         false,
+		ClockDriftThreshold:         2 * time.Second,
+		ValidatorAnnouncements:      false,
+		PeerMsgHeightSlack:          10000,
+		AppUpgradeTimeout:           10 * time.Minute,
+		StaleLockRounds:             10,
+		StaleLockResync:             false,
 	}
 }
 
@@ -1092,6 +1531,18 @@ func (cfg *ConsensusConfig) ValidateBasic() error {
 	if cfg.CreateEmptyBlocksInterval < 0 {
 		return errors.New("create_empty_blocks_interval can't be negative")
 	}
+	if cfg.MinTxsInBlock < 0 {
+		return errors.New("min_txs_in_block can't be negative")
+	}
+	if cfg.MaxEmptyBlockInterval < 0 {
+		return errors.New("max_empty_block_interval can't be negative")
+	}
+	if cfg.CreateEmptyBlocks && (cfg.MinTxsInBlock > 0 || cfg.MaxEmptyBlockInterval > 0) {
+		return errors.New("min_txs_in_block and max_empty_block_interval require create_empty_blocks = false")
+	}
+	if cfg.MaxEmptyBlockInterval > 0 && cfg.CreateEmptyBlocksInterval > 0 {
+		return errors.New("max_empty_block_interval and create_empty_blocks_interval can't both be set")
+	}
 	if cfg.PeerGossipSleepDuration < 0 {
 		return errors.New("peer_gossip_sleep_duration can't be negative")
 	}
@@ -1101,6 +1552,18 @@ func (cfg *ConsensusConfig) ValidateBasic() error {
 	if cfg.DoubleSignCheckHeight < 0 {
 		return errors.New("double_sign_check_height can't be negative")
 	}
+	if cfg.ClockDriftThreshold < 0 {
+		return errors.New("clock_drift_threshold can't be negative")
+	}
+	if cfg.PeerMsgHeightSlack < 0 {
+		return errors.New("peer_msg_height_slack can't be negative")
+	}
+	if cfg.AppUpgradeTimeout <= 0 {
+		return errors.New("app_upgrade_timeout must be positive")
+	}
+	if cfg.StaleLockRounds < 0 {
+		return errors.New("stale_lock_rounds can't be negative")
+	}
 	return nil
 }
 
@@ -1114,13 +1577,26 @@ type StorageConfig struct {
 	// required for `/block_results` RPC queries, and to reindex events in the
 	// command-line tool.
 	DiscardABCIResponses bool `mapstructure:"discard_abci_responses"`
+	// Set to true to save the proof of the row roots of the data square of
+	// every new block, computed by the application, in the block store, so
+	// that it is not recomputed to prove shares. Proving every share of every
+	// block holds the application, and consensus with it if the application
+	// is local. The proofs that are not saved are still computed, and saved,
+	// when first requested.
+	PersistRowProofs bool `mapstructure:"persist_row_proofs"`
+	// The largest data square whose row proof is saved with the new block,
+	// with PersistRowProofs. The proofs of the larger squares are only
+	// computed when first requested.
+	MaxRowProofSquareSize uint64 `mapstructure:"max_row_proof_square_size"`
 }
 
 // DefaultStorageConfig returns the default configuration options relating to
 // CometBFT storage optimization.
 func DefaultStorageConfig() *StorageConfig {
 	return &StorageConfig{
-		DiscardABCIResponses: false,
+		DiscardABCIResponses:  false,
+		PersistRowProofs:      false,
+		MaxRowProofSquareSize: 64,
 	}
 }
 
@@ -1128,7 +1604,9 @@ func DefaultStorageConfig() *StorageConfig {
 // testing.
 func TestStorageConfig() *StorageConfig {
 	return &StorageConfig{
-		DiscardABCIResponses: false,
+		DiscardABCIResponses:  false,
+		PersistRowProofs:      false,
+		MaxRowProofSquareSize: 64,
 	}
 }
 
@@ -1158,6 +1636,32 @@ type TxIndexConfig struct {
 	// The PostgreSQL connection configuration, the connection format:
 	// postgresql://<user>:<password>@<host>:<port>/<db>?<opts>
 	PsqlConn string `mapstructure:"psql-conn"`
+
+	// If true, events are indexed asynchronously by a background routine in
+	// batches, so that indexing blocks with many transactions does not delay
+	// committing the next block. The /tx and /tx_search endpoints wait briefly
+	// for the latest height to be indexed. Heights that were committed but not
+	// indexed before a crash are indexed on restart, which requires ABCI
+	// responses to be stored (see storage.discard_abci_responses). Only
+	// supported by the "kv" indexer.
+	Async bool `mapstructure:"async"`
+
+	// If positive, the queries of /tx_search must have an equality condition,
+	// e.g. on tx.hash, tx.height or an event attribute, or bound tx.height on
+	// both sides to a range of at most QueryMaxHeightSpan heights. The other
+	// queries, e.g. "tx.height > 1", would scan the whole index and are
+	// rejected. Only supported by the "kv" indexer.
+	QueryMaxHeightSpan int64 `mapstructure:"query-max-height-span"`
+
+	// The maximum number of index entries read by a /tx_search, after which
+	// the results found so far are returned with truncated set. 0 means
+	// unlimited. Only supported by the "kv" indexer.
+	QueryMaxRows int `mapstructure:"query-max-rows"`
+
+	// The maximum duration of a /tx_search, after which the results found so
+	// far are returned with truncated set. 0 means unlimited. Only supported
+	// by the "kv" indexer.
+	QueryTimeout time.Duration `mapstructure:"query-timeout"`
 }
 
 // DefaultTxIndexConfig returns a default configuration for the transaction indexer.
@@ -1172,6 +1676,21 @@ func TestTxIndexConfig() *TxIndexConfig {
 	return DefaultTxIndexConfig()
 }
 
+// ValidateBasic performs basic validation (checking param bounds, etc.) and
+// returns an error if any check fails.
+func (cfg *TxIndexConfig) ValidateBasic() error {
+	if cfg.QueryMaxHeightSpan < 0 {
+		return errors.New("query-max-height-span can't be negative")
+	}
+	if cfg.QueryMaxRows < 0 {
+		return errors.New("query-max-rows can't be negative")
+	}
+	if cfg.QueryTimeout < 0 {
+		return errors.New("query-timeout can't be negative")
+	}
+	return nil
+}
+
 //-----------------------------------------------------------------------------
 // InstrumentationConfig
 
@@ -1194,6 +1713,18 @@ type InstrumentationConfig struct {
 	// Instrumentation namespace.
 	Namespace string `mapstructure:"namespace"`
 
+	// When true, the connection statistics of every peer are reported with a
+	// peer_id label and persisted in the peerstore database, so that they are
+	// not reset on restart. Every peer that ever connected adds series to the
+	// metrics, so this is disabled by default.
+	PeerConnectionMetrics bool `mapstructure:"peer_connection_metrics"`
+
+	// When true, the latency and the bytes read and written of the operations
+	// on the databases (block store, state store, tx index, ...) are reported,
+	// labeled by database, operation and kind of key. Only applies when
+	// Prometheus is true.
+	DBMetrics bool `mapstructure:"db_metrics"`
+
 	// TracePushConfig is the relative path of the push config. This second
 	// config contains credentials for where and how often to.
 	TracePushConfig string `mapstructure:"trace_push_config"`
@@ -1231,17 +1762,19 @@ type InstrumentationConfig struct {
 // reporting.
 func DefaultInstrumentationConfig() *InstrumentationConfig {
 	return &InstrumentationConfig{
-		Prometheus:           false,
-		PrometheusListenAddr: ":26660",
-		MaxOpenConnections:   3,
-		Namespace:            "cometbft",
-		TracePushConfig:      "",
-		TracePullAddress:     "",
-		TraceType:            "noop",
-		TraceBufferSize:      1000,
-		TracingTables:        DefaultTracingTables,
-		PyroscopeURL:         "",
-		PyroscopeTrace:       false,
+		Prometheus:            false,
+		PrometheusListenAddr:  ":26660",
+		MaxOpenConnections:    3,
+		Namespace:             "cometbft",
+		PeerConnectionMetrics: false,
+		DBMetrics:             true,
+		TracePushConfig:       "",
+		TracePullAddress:      "",
+		TraceType:             "noop",
+		TraceBufferSize:       1000,
+		TracingTables:         DefaultTracingTables,
+		PyroscopeURL:          "",
+		PyroscopeTrace:        false,
 		PyroscopeProfileTypes: []string{
 			"cpu",
 			"alloc_objects",
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"path/filepath"
 	"reflect"
 	"testing"
 	"time"
@@ -38,6 +39,39 @@ func TestConfigValidateBasic(t *testing.T) {
 	assert.Error(t, cfg.ValidateBasic())
 }
 
+func TestStoreDirs(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.SetRoot("/home")
+	for _, id := range StoreIDs {
+		assert.Equal(t, "/home/data", cfg.StoreDir(id))
+		assert.Equal(t, filepath.Join("/home/data", id+".db"), cfg.StorePath(id))
+	}
+	assert.Equal(t, "/home/data", cfg.StoreDir("peerstore"))
+
+	cfg.BlockStorePath = "/cold/blocks"
+	cfg.StatePath = "fast"
+	cfg.TxIndexPath = "fast"
+	assert.Equal(t, "/cold/blocks/blockstore.db", cfg.StorePath("blockstore"))
+	assert.Equal(t, "/home/fast/state.db", cfg.StorePath("state"))
+	assert.Equal(t, "/home/fast/tx_index.db", cfg.StorePath("tx_index"))
+	assert.Equal(t, "/home/data/evidence.db", cfg.StorePath("evidence"))
+	assert.NoError(t, cfg.ValidateBasic())
+
+	// nothing can be in the files of a database
+	cfg.EvidencePath = "/cold/blocks/blockstore.db"
+	assert.ErrorContains(t, cfg.ValidateBasic(), "evidence_dir")
+	cfg.EvidencePath = "fast/state.db/evidence"
+	assert.ErrorContains(t, cfg.ValidateBasic(), "evidence_dir")
+	cfg.EvidencePath = ""
+	cfg.DBPath = "fast/tx_index.db"
+	assert.ErrorContains(t, cfg.ValidateBasic(), "db_dir")
+	cfg.DBPath = "data"
+	cfg.Consensus.WalPath = "data/evidence.db/cs.wal/wal"
+	assert.ErrorContains(t, cfg.ValidateBasic(), "consensus.wal_file")
+	cfg.Consensus.WalPath = "fast/state.db.wal/wal"
+	assert.NoError(t, cfg.ValidateBasic())
+}
+
 func TestTLSConfiguration(t *testing.T) {
 	assert := assert.New(t)
 	cfg := DefaultConfig()
@@ -61,6 +95,10 @@ func TestBaseConfigValidateBasic(t *testing.T) {
 	// tamper with log format
 	cfg.LogFormat = "invalid"
 	assert.Error(t, cfg.ValidateBasic())
+
+	cfg = TestBaseConfig()
+	cfg.LogRingBufferSize = -1
+	assert.Error(t, cfg.ValidateBasic())
 }
 
 func TestRPCConfigValidateBasic(t *testing.T) {
@@ -72,6 +110,7 @@ func TestRPCConfigValidateBasic(t *testing.T) {
 		"MaxOpenConnections",
 		"MaxSubscriptionClients",
 		"MaxSubscriptionsPerClient",
+		"MaxSubscriptions",
 		"TimeoutBroadcastTxCommit",
 		"MaxBodyBytes",
 		"MaxHeaderBytes",
@@ -82,6 +121,34 @@ func TestRPCConfigValidateBasic(t *testing.T) {
 		assert.Error(t, cfg.ValidateBasic())
 		reflect.ValueOf(cfg).Elem().FieldByName(fieldName).SetInt(0)
 	}
+
+	cfg.WebSocketPingInterval = 0
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.WebSocketPingInterval = time.Second
+	cfg.WebSocketPongMissThreshold = 0
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.WebSocketPongMissThreshold = 3
+	assert.NoError(t, cfg.ValidateBasic())
+	assert.Equal(t, 3500*time.Millisecond, cfg.WebSocketReadWait())
+
+	// read-only mode excludes the unsafe commands and the gRPC server
+	cfg.ReadOnly = true
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.Unsafe = false
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.GRPCListenAddress = ""
+	assert.NoError(t, cfg.ValidateBasic())
+
+	// API keys exclude the gRPC server and only grant the known classes
+	cfg = TestRPCConfig()
+	cfg.AuthKeysFile = "api_keys.json"
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.GRPCListenAddress = ""
+	assert.NoError(t, cfg.ValidateBasic())
+	cfg.AuthPublicClasses = []string{"read", "write"}
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.AuthPublicClasses = []string{"read", "subscribe"}
+	assert.NoError(t, cfg.ValidateBasic())
 }
 
 func TestP2PConfigValidateBasic(t *testing.T) {
@@ -95,6 +162,11 @@ func TestP2PConfigValidateBasic(t *testing.T) {
 		"MaxPacketMsgPayloadSize",
 		"SendRate",
 		"RecvRate",
+		"MaxSubnetConnRate",
+		"SubnetIPv4PrefixLen",
+		"SubnetIPv6PrefixLen",
+		"MaxPeersPerSubnet",
+		"MaxPeersPerASN",
 	}
 
 	for _, fieldName := range fieldsToTest {
@@ -102,6 +174,18 @@ func TestP2PConfigValidateBasic(t *testing.T) {
 		assert.Error(t, cfg.ValidateBasic())
 		reflect.ValueOf(cfg).Elem().FieldByName(fieldName).SetInt(0)
 	}
+
+	// the ASN cap requires an ASN database
+	cfg.MaxPeersPerASN = 2
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.ASNDBFile = "asn.tsv"
+	assert.NoError(t, cfg.ValidateBasic())
+
+	// a key in a PKCS#11 token needs its label
+	cfg.NodeKeyPKCS11.Module = "/usr/lib/softhsm/libsofthsm2.so"
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.NodeKeyPKCS11.KeyLabel = "node_key"
+	assert.NoError(t, cfg.ValidateBasic())
 }
 
 func TestMempoolConfigValidateBasic(t *testing.T) {
@@ -113,6 +197,7 @@ func TestMempoolConfigValidateBasic(t *testing.T) {
 		"MaxTxsBytes",
 		"CacheSize",
 		"MaxTxBytes",
+		"MaxGossipTxAge",
 	}
 
 	for _, fieldName := range fieldsToTest {
@@ -125,6 +210,13 @@ func TestMempoolConfigValidateBasic(t *testing.T) {
 func TestStateSyncConfigValidateBasic(t *testing.T) {
 	cfg := TestStateSyncConfig()
 	require.NoError(t, cfg.ValidateBasic())
+
+	cfg.Timeout = -1
+	assert.Error(t, cfg.ValidateBasic())
+
+	cfg.Timeout = 0
+	cfg.FallbackHeight = -1
+	assert.Error(t, cfg.ValidateBasic())
 }
 
 func TestFastSyncConfigValidateBasic(t *testing.T) {
@@ -139,6 +231,17 @@ func TestFastSyncConfigValidateBasic(t *testing.T) {
 	assert.Error(t, cfg.ValidateBasic())
 }
 
+func TestTxIndexConfigValidateBasic(t *testing.T) {
+	cfg := TestTxIndexConfig()
+	require.NoError(t, cfg.ValidateBasic())
+
+	for _, fieldName := range []string{"QueryMaxHeightSpan", "QueryMaxRows", "QueryTimeout"} {
+		reflect.ValueOf(cfg).Elem().FieldByName(fieldName).SetInt(-1)
+		assert.Error(t, cfg.ValidateBasic(), fieldName)
+		reflect.ValueOf(cfg).Elem().FieldByName(fieldName).SetInt(0)
+	}
+}
+
 //nolint:lll
 func TestConsensusConfig_ValidateBasic(t *testing.T) {
 	testcases := map[string]struct {
@@ -164,6 +267,35 @@ func TestConsensusConfig_ValidateBasic(t *testing.T) {
 		"PeerQueryMaj23SleepDuration":          {func(c *ConsensusConfig) { c.PeerQueryMaj23SleepDuration = time.Second }, false},
 		"PeerQueryMaj23SleepDuration negative": {func(c *ConsensusConfig) { c.PeerQueryMaj23SleepDuration = -1 }, true},
 		"DoubleSignCheckHeight negative":       {func(c *ConsensusConfig) { c.DoubleSignCheckHeight = -1 }, true},
+		"ClockDriftThreshold disabled":         {func(c *ConsensusConfig) { c.ClockDriftThreshold = 0 }, false},
+		"ClockDriftThreshold negative":         {func(c *ConsensusConfig) { c.ClockDriftThreshold = -1 }, true},
+		"PeerMsgHeightSlack disabled":          {func(c *ConsensusConfig) { c.PeerMsgHeightSlack = 0 }, false},
+		"PeerMsgHeightSlack negative":          {func(c *ConsensusConfig) { c.PeerMsgHeightSlack = -1 }, true},
+		"AppUpgradeTimeout zero":               {func(c *ConsensusConfig) { c.AppUpgradeTimeout = 0 }, true},
+		"StaleLockRounds disabled":             {func(c *ConsensusConfig) { c.StaleLockRounds = 0 }, false},
+		"StaleLockRounds negative":             {func(c *ConsensusConfig) { c.StaleLockRounds = -1 }, true},
+		"MinTxsInBlock": {func(c *ConsensusConfig) {
+			c.CreateEmptyBlocks = false
+			c.MinTxsInBlock = 10
+		}, false},
+		"MinTxsInBlock negative": {func(c *ConsensusConfig) {
+			c.CreateEmptyBlocks = false
+			c.MinTxsInBlock = -1
+		}, true},
+		"MinTxsInBlock with empty blocks": {func(c *ConsensusConfig) { c.MinTxsInBlock = 10 }, true},
+		"MaxEmptyBlockInterval": {func(c *ConsensusConfig) {
+			c.CreateEmptyBlocks = false
+			c.MaxEmptyBlockInterval = time.Second
+		}, false},
+		"MaxEmptyBlockInterval negative": {func(c *ConsensusConfig) {
+			c.CreateEmptyBlocks = false
+			c.MaxEmptyBlockInterval = -1
+		}, true},
+		"MaxEmptyBlockInterval with interval": {func(c *ConsensusConfig) {
+			c.CreateEmptyBlocks = false
+			c.CreateEmptyBlocksInterval = time.Second
+			c.MaxEmptyBlockInterval = time.Second
+		}, true},
 	}
 
 	for desc, tc := range testcases {
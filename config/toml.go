@@ -28,6 +28,20 @@ func init() {
 
 /****** these are for production settings ***********/
 
+// EnsureStoreDirs creates the database directory and the directories of the
+// databases if they don't exist, and returns an error if it fails.
+func EnsureStoreDirs(config BaseConfig) error {
+	if err := cmtos.EnsureDir(config.DBDir(), 0o700); err != nil {
+		return fmt.Errorf("failed to create db_dir: %w", err)
+	}
+	for _, id := range StoreIDs {
+		if err := cmtos.EnsureDir(config.StoreDir(id), 0o700); err != nil {
+			return fmt.Errorf("failed to create %s_dir: %w", id, err)
+		}
+	}
+	return nil
+}
+
 // EnsureRoot creates the root, config, and data directories if they don't exist,
 // and panics if it fails.
 func EnsureRoot(rootDir string) {
@@ -84,6 +98,16 @@ const defaultConfigTemplate = `# This is a TOML config file.
 # or the name of an ABCI application compiled in with the CometBFT binary
 proxy_app = "{{ .BaseConfig.ProxyApp }}"
 
+# TCP or UNIX socket addresses of the ABCI applications serving each of the
+# consensus, mempool, query and snapshot connections, to split them between
+# distinct application processes. An empty address uses proxy_app.
+# If the mempool, query or snapshot application crashes, CometBFT reconnects
+# to it instead of stopping.
+proxy_app_consensus = "{{ .BaseConfig.ProxyAppConsensus }}"
+proxy_app_mempool = "{{ .BaseConfig.ProxyAppMempool }}"
+proxy_app_query = "{{ .BaseConfig.ProxyAppQuery }}"
+proxy_app_snapshot = "{{ .BaseConfig.ProxyAppSnapshot }}"
+
 # A custom human readable name for this node
 moniker = "{{ .BaseConfig.Moniker }}"
 
@@ -116,12 +140,25 @@ db_backend = "{{ .BaseConfig.DBBackend }}"
 # Database directory
 db_dir = "{{ js .BaseConfig.DBPath }}"
 
+# Directories of the block store, state, tx index and evidence databases, to
+# place them on distinct disks. Each defaults to db_dir when empty. A directory
+# can be shared by several databases, but cannot be in the files of one, e.g.
+# "data/blockstore.db". The lock of the data directory is held in db_dir only.
+blockstore_dir = "{{ js .BaseConfig.BlockStorePath }}"
+state_dir = "{{ js .BaseConfig.StatePath }}"
+tx_index_dir = "{{ js .BaseConfig.TxIndexPath }}"
+evidence_dir = "{{ js .BaseConfig.EvidencePath }}"
+
 # Output level for logging, including package level options
 log_level = "{{ .BaseConfig.LogLevel }}"
 
 # Output format: 'plain' (colored text) or 'json'
 log_format = "{{ .BaseConfig.LogFormat }}"
 
+# Number of the most recent log lines kept in memory and reported by the
+# /debug_bundle RPC endpoint. 0 disables the buffer.
+log_ring_buffer_size = {{ .BaseConfig.LogRingBufferSize }}
+
 ##### additional base config options #####
 
 # Path to the JSON file containing the initial validator set and other meta data
@@ -172,7 +209,9 @@ cors_allowed_methods = [{{ range .RPC.CORSAllowedMethods }}{{ printf "%q, " . }}
 cors_allowed_headers = [{{ range .RPC.CORSAllowedHeaders }}{{ printf "%q, " . }}{{end}}]
 
 # TCP or UNIX socket address for the gRPC server to listen on
-# NOTE: This server only supports /broadcast_tx_commit
+# NOTE: This server only supports /broadcast_tx_commit, along with the standard
+# gRPC health and server reflection services. It uses TLS if tls_cert_file and
+# tls_key_file are set.
 grpc_laddr = "{{ .RPC.GRPCListenAddress }}"
 
 # Maximum number of simultaneous connections.
@@ -187,6 +226,28 @@ grpc_max_open_connections = {{ .RPC.GRPCMaxOpenConnections }}
 # Activate unsafe RPC commands like /dial_seeds and /unsafe_flush_mempool
 unsafe = {{ .RPC.Unsafe }}
 
+# Reject the RPC commands which mutate the state of the node or of the network,
+# like /broadcast_tx_sync, as well as the unsafe ones, e.g. for public gateways.
+# It can't be combined with unsafe, nor with grpc_laddr, as the gRPC server
+# broadcasts transactions.
+read_only = {{ .RPC.ReadOnly }}
+
+# The path to a JSON file of API keys, each granting the calls of a set of
+# method classes: "read", "broadcast", "subscribe" and "unsafe", e.g.
+# {"keys": [{"key": "<key>", "classes": ["read", "broadcast"]}]}
+# Might be either absolute path or path related to CometBFT's config directory.
+# The file is reloaded once modified. If set, the calls of the classes which are
+# not public need a key granting them, presented as a bearer token in the
+# Authorization header ("Authorization: Bearer <key>"), or in the first message
+# of a WebSocket connection:
+# {"jsonrpc": "2.0", "id": 0, "method": "auth", "params": {"key": "<key>"}}
+# It can't be combined with grpc_laddr, as the gRPC server is not authenticated.
+# Add "Authorization" to cors_allowed_headers for browsers to present keys.
+auth_keys_file = "{{ .RPC.AuthKeysFile }}"
+
+# The method classes whose calls need no API key when auth_keys_file is set.
+auth_public_classes = [{{ range .RPC.AuthPublicClasses }}{{ printf "%q, " . }}{{end}}]
+
 # Maximum number of simultaneous connections (including WebSocket).
 # Does not include gRPC connections. See grpc_max_open_connections
 # If you want to accept a larger number than the default, make sure
@@ -206,6 +267,17 @@ max_subscription_clients = {{ .RPC.MaxSubscriptionClients }}
 # the estimated # maximum number of broadcast_tx_commit calls per block.
 max_subscriptions_per_client = {{ .RPC.MaxSubscriptionsPerClient }}
 
+# Maximum number of subscriptions of all the clients together, including the
+# ones of /broadcast_tx_commit. Set to at least the estimated number of
+# subscription clients times their average number of subscriptions.
+max_subscriptions = {{ .RPC.MaxSubscriptions }}
+
+# If true, a client subscribing again to a query it is already subscribed to
+# (ignoring the spaces around operators, e.g. "tm.event = 'NewBlock'" is the
+# same query as "tm.event='NewBlock'") gets the ID of the existing
+# subscription. Otherwise, it gets an error.
+reuse_duplicate_subscriptions = {{ .RPC.ReuseDuplicateSubscriptions }}
+
 # Experimental parameter to specify the maximum number of events a node will
 # buffer, per subscription, before returning an error and closing the
 # subscription. Must be set to at least 100, but higher values will accommodate
@@ -233,15 +305,42 @@ experimental_websocket_write_buffer_size = {{ .RPC.WebSocketWriteBufferSize }}
 # predictability in subscription behaviour.
 experimental_close_on_slow_client = {{ .RPC.CloseOnSlowClient }}
 
+# How often the WebSocket clients are pinged, to tell the ones which vanished
+# without closing their connection.
+websocket_ping_interval = "{{ .RPC.WebSocketPingInterval }}"
+
+# The number of pings in a row a WebSocket client may leave unanswered, without
+# sending anything else either, before its connection is closed and its
+# subscriptions are removed.
+websocket_pong_miss_threshold = {{ .RPC.WebSocketPongMissThreshold }}
+
 # How long to wait for a tx to be committed during /broadcast_tx_commit.
 # WARNING: Using a value larger than 10s will result in increasing the
 # global HTTP write timeout, which applies to all connections and endpoints.
 # See https://github.com/tendermint/tendermint/issues/3435
 timeout_broadcast_tx_commit = "{{ .RPC.TimeoutBroadcastTxCommit }}"
 
+# Maximum number of transactions submitted by the broadcast endpoints whose
+# CheckTx is pending. Once reached, /broadcast_tx_async is rejected with a
+# server overloaded error, to be retried later, while /broadcast_tx_sync and
+# /broadcast_tx_commit wait up to broadcast_tx_admission_wait for a transaction
+# to be checked before being rejected the same way. 0 - unlimited.
+max_pending_broadcast_txs = {{ .RPC.MaxPendingBroadcastTxs }}
+
+# How long /broadcast_tx_sync and /broadcast_tx_commit wait for room among the
+# max_pending_broadcast_txs pending transactions.
+broadcast_tx_admission_wait = "{{ .RPC.BroadcastTxAdmissionWait }}"
+
 # Maximum size of request body, in bytes
 max_body_bytes = {{ .RPC.MaxBodyBytes }}
 
+# Maximum size of a single WebSocket message, in bytes
+max_websocket_message_bytes = {{ .RPC.MaxWebSocketMessageBytes }}
+
+# Maximum encoded size of a single byte-carrying request parameter (e.g. a tx
+# or evidence), in bytes. 0 - unlimited.
+max_param_bytes = {{ .RPC.MaxParamBytes }}
+
 # Maximum size of request header, in bytes
 max_header_bytes = {{ .RPC.MaxHeaderBytes }}
 
@@ -250,14 +349,14 @@ max_header_bytes = {{ .RPC.MaxHeaderBytes }}
 # If the certificate is signed by a certificate authority,
 # the certFile should be the concatenation of the server's certificate, any intermediates,
 # and the CA's certificate.
-# NOTE: both tls_cert_file and tls_key_file must be present for CometBFT to create HTTPS server.
-# Otherwise, HTTP server is run.
+# NOTE: both tls_cert_file and tls_key_file must be present for CometBFT to create HTTPS server,
+# and to use TLS on the gRPC server. Otherwise, HTTP server is run.
 tls_cert_file = "{{ .RPC.TLSCertFile }}"
 
 # The path to a file containing matching private key that is used to create the HTTPS server.
 # Might be either absolute path or path related to CometBFT's config directory.
-# NOTE: both tls-cert-file and tls-key-file must be present for CometBFT to create HTTPS server.
-# Otherwise, HTTP server is run.
+# NOTE: both tls-cert-file and tls-key-file must be present for CometBFT to create HTTPS server,
+# and to use TLS on the gRPC server. Otherwise, HTTP server is run.
 tls_key_file = "{{ .RPC.TLSKeyFile }}"
 
 # pprof listen address (https://golang.org/pkg/net/http/pprof)
@@ -275,6 +374,7 @@ laddr = "{{ .P2P.ListenAddress }}"
 # If empty, will use the same port as the laddr,
 # and will introspect on the listener or use UPnP
 # to figure out the address. ip and port are required
+# It must be set for a node behind a NAT, as peers can't dial its laddr.
 # example: 159.89.10.97:26656
 external_address = "{{ .P2P.ExternalAddress }}"
 
@@ -300,6 +400,17 @@ max_num_inbound_peers = {{ .P2P.MaxNumInboundPeers }}
 # Maximum number of outbound peers to connect to, excluding persistent peers
 max_num_outbound_peers = {{ .P2P.MaxNumOutboundPeers }}
 
+# Set true to make the number of outbound peers follow the size of the network
+# observed through the peer exchange, between min_num_outbound_peers and
+# max_num_outbound_peers. Surplus outbound peers are evicted one at a time, the
+# ones with the lowest reputation first. Requires pex.
+dynamic_peer_targets = {{ .P2P.DynamicPeerTargets }}
+
+# Minimum number of outbound peers to connect to when dynamic_peer_targets is
+# set. 0 uses the default of the role of the node: 3 for validators,
+# max_num_outbound_peers for seeds and half of it for other nodes.
+min_num_outbound_peers = {{ .P2P.MinNumOutboundPeers }}
+
 # List of node IDs, to which a connection will be (re)established ignoring any existing limits
 unconditional_peer_ids = "{{ .P2P.UnconditionalPeerIDs }}"
 
@@ -333,10 +444,58 @@ private_peer_ids = "{{ .P2P.PrivatePeerIDs }}"
 # Toggle to disable guard against peers connecting from the same ip.
 allow_duplicate_ip = {{ .P2P.AllowDuplicateIP }}
 
+# Maximum number of incoming connections accepted from a single IP subnet
+# within subnet_conn_rate_window. Further connections from the subnet are
+# refused, which makes it harder to fill the inbound peer slots with peers
+# controlled by a single party. 0 disables the limit.
+max_subnet_conn_rate = {{ .P2P.MaxSubnetConnRate }}
+
+# Window of time over which incoming connections from a subnet are counted.
+subnet_conn_rate_window = "{{ .P2P.SubnetConnRateWindow }}"
+
+# Length of the prefix grouping IPv4 and IPv6 addresses into subnets.
+subnet_ipv4_prefix_len = {{ .P2P.SubnetIPv4PrefixLen }}
+subnet_ipv6_prefix_len = {{ .P2P.SubnetIPv6PrefixLen }}
+
+# Maximum number of peers within a single IP subnet, as grouped by the prefix
+# lengths above, so that a party controlling a few subnets cannot take most of
+# the peer slots. Applies to the addresses dialed and, once half of the inbound
+# peer slots are taken, to inbound peers. Persistent and unconditional peers
+# are exempt. 0 disables the limit.
+max_peers_per_subnet = {{ .P2P.MaxPeersPerSubnet }}
+
+# Path to an IP to ASN database, in the tab-separated format of the ip2asn
+# databases (range_start, range_end, AS_number, country_code, AS_description),
+# optionally gzipped. Required by max_peers_per_asn.
+asn_db_file = "{{ js .P2P.ASNDBFile }}"
+
+# Maximum number of peers within a single autonomous system, i.e. hosting
+# provider, as found in asn_db_file. Applies like max_peers_per_subnet.
+# 0 disables the limit.
+max_peers_per_asn = {{ .P2P.MaxPeersPerASN }}
+
 # Peer connection configuration.
 handshake_timeout = "{{ .P2P.HandshakeTimeout }}"
 dial_timeout = "{{ .P2P.DialTimeout }}"
 
+# Key in a PKCS#11 token, e.g. an HSM, authenticating the node to its peers in
+# place of the key of node_key_file, if module is set. Only ed25519 keys are
+# supported, and the binary must be built with COMETBFT_BUILD_OPTIONS=pkcs11.
+[p2p.node_key_pkcs11]
+
+# Path to the PKCS#11 module of the token, e.g. /usr/lib/softhsm/libsofthsm2.so
+module = "{{ js .P2P.NodeKeyPKCS11.Module }}"
+
+# Slot of the token
+slot = {{ .P2P.NodeKeyPKCS11.Slot }}
+
+# Label of the key, shared by its private and public key objects
+key_label = "{{ js .P2P.NodeKeyPKCS11.KeyLabel }}"
+
+# Name of the environment variable holding the user PIN of the token, so that
+# it is not written in this file. Leave empty to not log in to the token.
+pin_env = "{{ js .P2P.NodeKeyPKCS11.PINEnv }}"
+
 #######################################################
 ###          Mempool Configuration Option          ###
 #######################################################
@@ -404,6 +563,20 @@ ttl-num-blocks = {{ .Mempool.TTLNumBlocks }}
 # Default is 200ms
 max-gossip-delay = "{{ .Mempool.MaxGossipDelay }}"
 
+# max-gossip-tx-age, if non-zero, defines the maximum number of blocks since a
+# transaction was first seen for it to be gossiped: the received transactions
+# the sender first saw more blocks ago are dropped without CheckTx, and the
+# transactions the node first saw more blocks ago are not sent to its peers
+# anymore. The transactions received from peers which do not report when they
+# first saw them are not filtered.
+max-gossip-tx-age = {{ .Mempool.MaxGossipTxAge }}
+
+# enforce-app-requirements makes the mempool reject, before CheckTx, the
+# transactions larger than the maximum size the application reports in
+# ResponseInfo.mempool_requirements, which the RPC advertises on the
+# /mempool_requirements route.
+enforce-app-requirements = {{ .Mempool.EnforceAppRequirements }}
+
 #######################################################
 ###         State Sync Configuration Options        ###
 #######################################################
@@ -440,6 +613,36 @@ chunk_request_timeout = "{{ .StateSync.ChunkRequestTimeout }}"
 # The number of concurrent chunk fetchers to run (default: 1).
 chunk_fetchers = "{{ .StateSync.ChunkFetchers }}"
 
+# Height interval at which the node asks the application to create a state
+# machine snapshot (via the CreateSnapshot ABCI call) so that it can serve it
+# to state syncing peers. Only applications that advertise the
+# "snapshot_orchestration" capability in their Info response are asked.
+# 0 disables node-driven snapshots.
+snapshot_interval = {{ .StateSync.SnapshotInterval }}
+
+# Number of most recent node-driven snapshots to keep. Older snapshots are
+# pruned via the PruneSnapshots ABCI call. 0 keeps all snapshots.
+snapshot_keep_recent = {{ .StateSync.SnapshotKeepRecent }}
+
+# Maximum duration of the whole state sync, snapshot discovery included. When
+# it expires, or after max_attempts failed snapshot restorations, the node
+# gives up on state sync and falls back to fast sync, see fallback_height. 0
+# disables the timeout.
+timeout = "{{ .StateSync.Timeout }}"
+
+# Number of snapshot restorations to attempt before falling back to fast sync.
+# 0 means no limit.
+max_attempts = {{ .StateSync.MaxAttempts }}
+
+# Height from which to fast sync when falling back from state sync. The
+# application must already hold the state of that height, e.g. restored from a
+# backup, and its app hash is verified with the light client. 0 falls back to
+# fast sync from genesis.
+# The node does not clean up the state of a snapshot the application partially
+# restored before the fallback: the application must discard it itself, e.g.
+# when InitChain is called on the fallback from genesis.
+fallback_height = {{ .StateSync.FallbackHeight }}
+
 #######################################################
 ###       Fast Sync Configuration Connections       ###
 #######################################################
@@ -493,10 +696,66 @@ skip_timeout_commit = {{ .Consensus.SkipTimeoutCommit }}
 create_empty_blocks = {{ .Consensus.CreateEmptyBlocks }}
 create_empty_blocks_interval = "{{ .Consensus.CreateEmptyBlocksInterval }}"
 
+# With create_empty_blocks = false, propose a block once the mempool holds at
+# least min_txs_in_block transactions, or once max_empty_block_interval has
+# elapsed since the last block was committed, whichever comes first.
+# 0 means a single transaction and no interval respectively.
+# A block is proposed regardless when the app hash or the validator set
+# changed, or when evidence is pending.
+min_txs_in_block = {{ .Consensus.MinTxsInBlock }}
+max_empty_block_interval = "{{ .Consensus.MaxEmptyBlockInterval }}"
+
 # Reactor sleep duration parameters
 peer_gossip_sleep_duration = "{{ .Consensus.PeerGossipSleepDuration }}"
 peer_query_maj23_sleep_duration = "{{ .Consensus.PeerQueryMaj23SleepDuration }}"
 
+# Record why the transactions of the mempool were left out of the blocks
+# proposed by this node (over the max gas or bytes of the block, or removed
+# by the application in PrepareProposal), for the last 100 heights.
+# They are served by the unsafe_proposal_debug RPC endpoint.
+record_reap_decisions = {{ .Consensus.RecordReapDecisions }}
+
+# The drift of the local clock above which a warning is logged and a ClockDrift
+# event is published. The drift is estimated from the timestamps of the votes
+# received from peers and from the times of the recent blocks, and is exposed
+# as the clock_drift_seconds metric. A broken NTP setup is a common cause.
+# Set to 0 to disable the warning.
+clock_drift_threshold = "{{ .Consensus.ClockDriftThreshold }}"
+
+# Announce the validator run by this node to its peers, signed by its private
+# validator, and learn the validators run by the peers from their announcements.
+# The votes and block parts are then gossiped first to the peers running the
+# validators which have not voted yet in the round. Peers without the feature
+# ignore the announcements.
+validator_announcements = {{ .Consensus.ValidatorAnnouncements }}
+
+# The number of heights around the current height within which the consensus
+# messages of the peers must fall. Peers repeatedly sending messages out of
+# that window, or with absurd rounds, block part counts or validator indexes,
+# are disconnected and banned. The check is skipped while fast or state
+# syncing. It must exceed the number of heights the node may lag behind its
+# peers while in consensus, e.g. with fast_sync disabled. Set to 0 to disable
+# the height check.
+peer_msg_height_slack = {{ .Consensus.PeerMsgHeightSlack }}
+
+# How long the node holds its participation in consensus, once paused via the
+# unsafe_pause_app_upgrade RPC, while waiting for the application to restart
+# with a higher app version. On timeout the node gives up, logs an error and
+# takes part in consensus again with the running application.
+app_upgrade_timeout = "{{ .Consensus.AppUpgradeTimeout }}"
+
+# The number of rounds after the round the node locked on a block in which +2/3
+# of the validators prevoted without a polka for the block, above which the
+# node is deemed stuck on a stale lock, e.g. for lack of the newer proposals.
+# The round state and the states of the peers are then logged as an error, and
+# the stale_locks metric is incremented. The locking rules are unchanged.
+# Set to 0 to disable the detection.
+stale_lock_rounds = {{ .Consensus.StaleLockRounds }}
+
+# On a stale lock, also forget the votes and the proposal known by each peer,
+# for them to be gossiped again.
+stale_lock_resync = {{ .Consensus.StaleLockResync }}
+
 #######################################################
 ###         Storage Configuration Options           ###
 #######################################################
@@ -508,6 +767,19 @@ peer_query_maj23_sleep_duration = "{{ .Consensus.PeerQueryMaj23SleepDuration }}"
 # reindex events in the command-line tool.
 discard_abci_responses = {{ .Storage.DiscardABCIResponses}}
 
+# Set to true to save the proof of the row roots of the data square of every
+# new block, computed by the application, in the block store, so that it is not
+# recomputed every time shares are proven by the RPC. The application proves
+# every share of every block then, which holds consensus if it is local. The
+# proofs that are not saved, e.g. for the blocks saved before, are saved when
+# first requested.
+persist_row_proofs = {{ .Storage.PersistRowProofs }}
+
+# The largest data square whose row proof is saved with the new block, with
+# persist_row_proofs. The proofs of the larger squares are saved when first
+# requested.
+max_row_proof_square_size = {{ .Storage.MaxRowProofSquareSize }}
+
 #######################################################
 ###   Transaction Indexer Configuration Options     ###
 #######################################################
@@ -530,6 +802,30 @@ indexer = "{{ .TxIndex.Indexer }}"
 #   postgresql://<user>:<password>@<host>:<port>/<db>?<opts>
 psql-conn = "{{ .TxIndex.PsqlConn }}"
 
+# If true, events are indexed asynchronously by a background routine in batches,
+# so that indexing blocks with many transactions does not delay committing the
+# next block. The /tx and /tx_search endpoints wait briefly for the latest height
+# to be indexed. Heights that were committed but not indexed before a crash are
+# indexed on restart, which requires ABCI responses to be stored
+# (see discard_abci_responses). Only supported by the "kv" indexer.
+async = {{ .TxIndex.Async }}
+
+# If positive, the queries of /tx_search must have an equality condition, e.g. on
+# tx.hash, tx.height or an event attribute, or bound tx.height on both sides to a
+# range of at most this number of heights. The other queries, e.g.
+# "tx.height > 1", would scan the whole index and are rejected.
+# Only supported by the "kv" indexer.
+query-max-height-span = {{ .TxIndex.QueryMaxHeightSpan }}
+
+# The maximum number of index entries read by a /tx_search, after which the
+# results found so far are returned with truncated set. 0 means unlimited.
+# Only supported by the "kv" indexer.
+query-max-rows = {{ .TxIndex.QueryMaxRows }}
+
+# The maximum duration of a /tx_search, after which the results found so far are
+# returned with truncated set. 0 means unlimited. Only supported by the "kv" indexer.
+query-timeout = "{{ .TxIndex.QueryTimeout }}"
+
 #######################################################
 ###       Instrumentation Configuration Options     ###
 #######################################################
@@ -552,6 +848,17 @@ max_open_connections = {{ .Instrumentation.MaxOpenConnections }}
 # Instrumentation namespace
 namespace = "{{ .Instrumentation.Namespace }}"
 
+# When true, the connection statistics of every peer are reported with a
+# peer_id label and persisted in the peerstore database, so that they are not
+# reset on restart. Every peer that ever connected adds series to the metrics,
+# so this is disabled by default.
+peer_connection_metrics = {{ .Instrumentation.PeerConnectionMetrics }}
+
+# When true, the latency and the bytes read and written of the operations on
+# the databases (block store, state store, tx index, ...) are reported, labeled
+# by database, operation and kind of key. Only applies when prometheus is true.
+db_metrics = {{ .Instrumentation.DBMetrics }}
+
 # TracePushConfig is the relative path of the push config.
 # This second config contains credentials for where and how often to
 # push trace data to. For example, if the config is next to this config,
@@ -0,0 +1,234 @@
+package consensus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/tendermint/tendermint/libs/log"
+	cmtsync "github.com/tendermint/tendermint/libs/sync"
+	"github.com/tendermint/tendermint/proxy"
+	sm "github.com/tendermint/tendermint/state"
+	"github.com/tendermint/tendermint/types"
+)
+
+const appUpgraderSubscriber = "AppUpgrader"
+
+// AppUpgrader pauses the participation of the node in consensus while the
+// application is upgraded, so that its binary can be swapped without
+// restarting CometBFT:
+//
+//  1. Pause waits for the current height to be committed, then holds the
+//     participation of the node: it stops proposing, votes nil and commits no
+//     block. The termination of the connections to the application is
+//     expected from then on.
+//  2. The operator restarts the application with a higher app version.
+//  3. Once the connections are recreated, the upgrader checks the app version
+//     reported by Info, handshakes with the application, replaying the blocks
+//     it may lack, and resumes participation.
+//
+// If the application does not come back with a higher app version within the
+// timeout, the upgrade is aborted: participation resumes with an error logged.
+// If the application is down by then, CometBFT is killed, as it is when the
+// application crashes.
+//
+// The progress of the upgrade is published as AppUpgrade events.
+type AppUpgrader struct {
+	cs         *State
+	proxyApp   proxy.AppConns
+	stateStore sm.Store
+	blockStore sm.BlockStore
+	genDoc     *types.GenesisDoc
+	eventBus   *types.EventBus
+	timeout    time.Duration
+	logger     log.Logger
+
+	mtx        cmtsync.Mutex
+	inProgress bool
+}
+
+// NewAppUpgrader returns a new AppUpgrader. timeout is how long the node
+// waits for the upgraded application once paused.
+func NewAppUpgrader(
+	cs *State,
+	proxyApp proxy.AppConns,
+	stateStore sm.Store,
+	blockStore sm.BlockStore,
+	genDoc *types.GenesisDoc,
+	eventBus *types.EventBus,
+	timeout time.Duration,
+) *AppUpgrader {
+	return &AppUpgrader{
+		cs:         cs,
+		proxyApp:   proxyApp,
+		stateStore: stateStore,
+		blockStore: blockStore,
+		genDoc:     genDoc,
+		eventBus:   eventBus,
+		timeout:    timeout,
+		logger:     log.NewNopLogger(),
+	}
+}
+
+// SetLogger sets the logger.
+func (u *AppUpgrader) SetLogger(l log.Logger) {
+	u.logger = l
+}
+
+// Pause finishes the current height, then holds the participation of the
+// node until the application is restarted with a higher app version. It
+// returns the last height committed before the pause and the app version of
+// the running application.
+func (u *AppUpgrader) Pause(ctx context.Context) (int64, uint64, error) {
+	u.mtx.Lock()
+	defer u.mtx.Unlock()
+	if u.inProgress {
+		return 0, 0, errors.New("an upgrade of the application is already in progress")
+	}
+	restarter, ok := u.proxyApp.(proxy.AppRestarter)
+	if !ok {
+		return 0, 0, errors.New("the connections to the application cannot be recreated")
+	}
+	if !u.cs.IsRunning() {
+		return 0, 0, errors.New("consensus is not running")
+	}
+
+	info, err := u.proxyApp.Consensus().InfoSync(proxy.RequestInfo)
+	if err != nil {
+		return 0, 0, fmt.Errorf("error calling Info: %w", err)
+	}
+
+	if err := u.waitForCommit(ctx); err != nil {
+		return 0, 0, err
+	}
+	restarted, err := restarter.ExpectAppRestart()
+	if err != nil {
+		return 0, 0, fmt.Errorf("the application cannot be restarted: %w", err)
+	}
+	height := u.cs.HoldParticipation()
+	u.inProgress = true
+
+	u.logger.Info("Paused for an upgrade of the application",
+		"height", height, "appVersion", info.AppVersion, "timeout", u.timeout)
+	u.publish(types.EventDataAppUpgrade{
+		Status:     types.AppUpgradePaused,
+		Height:     height,
+		AppVersion: info.AppVersion,
+	})
+
+	go u.awaitRestart(restarter, restarted, height, info.AppVersion)
+	return height, info.AppVersion, nil
+}
+
+// waitForCommit waits for the height in progress to be committed.
+func (u *AppUpgrader) waitForCommit(ctx context.Context) error {
+	sub, err := u.eventBus.Subscribe(ctx, appUpgraderSubscriber, types.EventQueryNewBlockHeader)
+	if err != nil {
+		return fmt.Errorf("failed to subscribe to new blocks: %w", err)
+	}
+	defer func() {
+		if err := u.eventBus.Unsubscribe(context.Background(), appUpgraderSubscriber,
+			types.EventQueryNewBlockHeader); err != nil {
+			u.logger.Error("Failed to unsubscribe from event bus", "err", err)
+		}
+	}()
+
+	height := u.cs.GetLastHeight() + 1
+	for {
+		select {
+		case msg := <-sub.Out():
+			if msg.Data().(types.EventDataNewBlockHeader).Header.Height >= height {
+				return nil
+			}
+		case <-sub.Cancelled():
+			return fmt.Errorf("subscription to new blocks was cancelled: %w", sub.Err())
+		case <-ctx.Done():
+			return fmt.Errorf("height %d was not committed: %w", height, ctx.Err())
+		case <-u.cs.Quit():
+			return errors.New("consensus stopped")
+		}
+	}
+}
+
+// awaitRestart resumes participation once the application is back, or after
+// the timeout.
+func (u *AppUpgrader) awaitRestart(
+	restarter proxy.AppRestarter,
+	restarted <-chan struct{},
+	height int64,
+	oldAppVersion uint64,
+) {
+	timer := time.NewTimer(u.timeout)
+	defer timer.Stop()
+
+	var (
+		appVersion = oldAppVersion
+		err        error
+	)
+	select {
+	case <-restarted:
+		appVersion, err = u.handshake(oldAppVersion)
+	case <-timer.C:
+		restarter.CancelAppRestart()
+		err = fmt.Errorf("the application did not restart within %v", u.timeout)
+	case <-u.cs.Quit():
+		restarter.CancelAppRestart()
+		return
+	}
+
+	u.cs.ResumeParticipation()
+	u.mtx.Lock()
+	u.inProgress = false
+	u.mtx.Unlock()
+
+	if err != nil {
+		u.logger.Error("Aborted the upgrade of the application, resuming consensus",
+			"height", height, "appVersion", appVersion, "err", err)
+		u.publish(types.EventDataAppUpgrade{
+			Status:     types.AppUpgradeAborted,
+			Height:     height,
+			AppVersion: appVersion,
+			Reason:     err.Error(),
+		})
+		return
+	}
+	u.logger.Info("Completed the upgrade of the application, resuming consensus",
+		"height", height, "oldAppVersion", oldAppVersion, "appVersion", appVersion)
+	u.publish(types.EventDataAppUpgrade{
+		Status:     types.AppUpgradeCompleted,
+		Height:     height,
+		AppVersion: appVersion,
+	})
+}
+
+// handshake checks that the restarted application has a higher app version,
+// and syncs it with the block store. It returns its app version.
+func (u *AppUpgrader) handshake(oldAppVersion uint64) (uint64, error) {
+	info, err := u.proxyApp.Consensus().InfoSync(proxy.RequestInfo)
+	if err != nil {
+		return oldAppVersion, fmt.Errorf("error calling Info: %w", err)
+	}
+	if info.AppVersion <= oldAppVersion {
+		return info.AppVersion, fmt.Errorf("the application restarted with app version %d, expected above %d",
+			info.AppVersion, oldAppVersion)
+	}
+
+	state, err := u.stateStore.Load()
+	if err != nil {
+		return info.AppVersion, fmt.Errorf("failed to load state: %w", err)
+	}
+	handshaker := NewHandshaker(u.stateStore, state, u.blockStore, u.genDoc)
+	handshaker.SetLogger(u.logger)
+	handshaker.SetEventBus(u.eventBus)
+	if _, err := handshaker.Handshake(u.proxyApp); err != nil {
+		return info.AppVersion, fmt.Errorf("error during handshake: %w", err)
+	}
+	return info.AppVersion, nil
+}
+
+func (u *AppUpgrader) publish(data types.EventDataAppUpgrade) {
+	if err := u.eventBus.PublishEventAppUpgrade(data); err != nil {
+		u.logger.Error("Failed to publish AppUpgrade event", "err", err)
+	}
+}
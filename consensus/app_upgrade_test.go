@@ -0,0 +1,75 @@
+package consensus
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/abci/example/kvstore"
+	abciserver "github.com/tendermint/tendermint/abci/server"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/proxy"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestAppUpgraderAbortsOnTimeout(t *testing.T) {
+	cs1, _ := randState(1)
+	_, _, err := NewAppUpgrader(cs1, proxy.NewAppConns(proxy.NewLocalClientCreator(kvstore.NewApplication())),
+		nil, nil, nil, cs1.eventBus, time.Second).Pause(context.Background())
+	require.Error(t, err, "consensus is not running")
+
+	newBlockCh := subscribe(cs1.eventBus, types.EventQueryNewBlock)
+	appUpgradeCh := subscribe(cs1.eventBus, types.EventQueryAppUpgrade)
+
+	// the application is never restarted
+	addr := fmt.Sprintf("unix://%s", filepath.Join(t.TempDir(), "app.sock"))
+	server := abciserver.NewSocketServer(addr, kvstore.NewApplication())
+	server.SetLogger(log.TestingLogger())
+	require.NoError(t, server.Start())
+	t.Cleanup(func() { _ = server.Stop() })
+
+	proxyApp := proxy.NewAppConns(proxy.NewRemoteClientCreator(addr, "socket", true))
+	require.NoError(t, proxyApp.Start())
+	t.Cleanup(func() { _ = proxyApp.Stop() })
+
+	require.NoError(t, cs1.Start())
+	t.Cleanup(func() { _ = cs1.Stop() })
+
+	upgrader := NewAppUpgrader(cs1, proxyApp, nil, nil, nil, cs1.eventBus, 500*time.Millisecond)
+	upgrader.SetLogger(log.TestingLogger())
+
+	height, appVersion, err := upgrader.Pause(context.Background())
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, height, int64(1))
+	assert.Equal(t, kvstore.ProtocolVersion, appVersion)
+
+	_, _, err = upgrader.Pause(context.Background())
+	require.Error(t, err, "an upgrade is already in progress")
+
+	msg := <-appUpgradeCh
+	assert.Equal(t, types.EventDataAppUpgrade{Status: types.AppUpgradePaused, Height: height, AppVersion: appVersion},
+		msg.Data().(types.EventDataAppUpgrade))
+
+	// no block is committed while paused
+	for len(newBlockCh) > 0 {
+		<-newBlockCh
+	}
+	ensureNoNewEventOnChannel(newBlockCh)
+
+	select {
+	case msg = <-appUpgradeCh:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the upgrade was not aborted")
+	}
+	data := msg.Data().(types.EventDataAppUpgrade)
+	assert.Equal(t, types.AppUpgradeAborted, data.Status)
+	assert.Equal(t, height, data.Height)
+	assert.NotEmpty(t, data.Reason)
+
+	ensureNewBlock(newBlockCh, height+1)
+}
@@ -0,0 +1,126 @@
+package consensus
+
+import (
+	"sort"
+	"time"
+
+	cmtsync "github.com/tendermint/tendermint/libs/sync"
+	"github.com/tendermint/tendermint/types"
+)
+
+const (
+	// The sources the drift of the local clock is estimated from.
+	clockDriftSourceVotes  = "votes"
+	clockDriftSourceBlocks = "blocks"
+
+	// clockDriftWindow is the number of recent samples of a source the drift
+	// is estimated from.
+	clockDriftWindow = 100
+	// minClockDriftSamples is the number of samples of a source needed to
+	// estimate the drift from it, so that a few delayed messages right after
+	// starting do not make for an estimate.
+	minClockDriftSamples = 10
+)
+
+// clockDriftMonitor estimates the drift of the local clock from the clocks of
+// the other validators, from two sources:
+//   - the timestamps of the votes of the peers for the current round, which are
+//     received shortly after being signed. The estimate includes the gossip
+//     latency, so it is slightly biased towards the local clock being ahead.
+//   - the times of the committed blocks. The time of a block is the median of
+//     the precommit times of the previous block, so a block is committed about
+//     a block interval after its time. The block interval is the median of the
+//     recent intervals between block times.
+//
+// The estimates are the medians of the recent samples, so that they are not
+// skewed by a few validators with broken clocks or by delayed messages. The
+// drift is positive when the local clock is ahead. The monitor only observes,
+// it has no effect on consensus.
+type clockDriftMonitor struct {
+	mtx           cmtsync.Mutex
+	votes         driftSamples
+	blocks        driftSamples
+	intervals     driftSamples
+	lastBlockTime time.Time
+	// exceeded holds the sources whose last estimate exceeded the threshold.
+	exceeded map[string]bool
+}
+
+func newClockDriftMonitor() *clockDriftMonitor {
+	return &clockDriftMonitor{exceeded: make(map[string]bool)}
+}
+
+// addVote records the timestamp of the vote received at the local time now. It
+// returns the drift estimated from the votes, or false if there are too few
+// samples.
+func (m *clockDriftMonitor) addVote(vote *types.Vote, now time.Time) (time.Duration, bool) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	m.votes.add(now.Sub(vote.Timestamp))
+	return m.votes.median()
+}
+
+// addBlock records the time of the block committed at the local time now. It
+// returns the drift estimated from the blocks, or false if there are too few
+// samples.
+func (m *clockDriftMonitor) addBlock(blockTime, now time.Time) (time.Duration, bool) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	lastBlockTime := m.lastBlockTime
+	m.lastBlockTime = blockTime
+	if lastBlockTime.IsZero() || !blockTime.After(lastBlockTime) {
+		return 0, false
+	}
+	m.intervals.add(blockTime.Sub(lastBlockTime))
+	interval, ok := m.intervals.median()
+	if !ok {
+		return 0, false
+	}
+	m.blocks.add(now.Sub(blockTime.Add(interval)))
+	return m.blocks.median()
+}
+
+// exceeds returns true if the drift estimated from the source exceeds the
+// threshold while the previous estimate did not, so that the drift is only
+// reported once until it is back within the threshold. A threshold of 0 is
+// never exceeded.
+func (m *clockDriftMonitor) exceeds(source string, drift, threshold time.Duration) bool {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	if drift < 0 {
+		drift = -drift
+	}
+	exceeded := threshold > 0 && drift > threshold
+	previously := m.exceeded[source]
+	m.exceeded[source] = exceeded
+	return exceeded && !previously
+}
+
+// driftSamples holds the last clockDriftWindow samples of a source.
+type driftSamples struct {
+	samples []time.Duration
+	next    int
+}
+
+func (s *driftSamples) add(d time.Duration) {
+	if len(s.samples) < clockDriftWindow {
+		s.samples = append(s.samples, d)
+		return
+	}
+	s.samples[s.next] = d
+	s.next = (s.next + 1) % clockDriftWindow
+}
+
+// median returns the median of the samples, or false if there are fewer than
+// minClockDriftSamples.
+func (s *driftSamples) median() (time.Duration, bool) {
+	if len(s.samples) < minClockDriftSamples {
+		return 0, false
+	}
+	sorted := append([]time.Duration(nil), s.samples...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	return sorted[len(sorted)/2], true
+}
@@ -0,0 +1,137 @@
+package consensus
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/types"
+	cmttime "github.com/tendermint/tendermint/types/time"
+)
+
+func TestClockDriftMonitorVotes(t *testing.T) {
+	m := newClockDriftMonitor()
+	now := cmttime.Now()
+
+	// the peers sign their votes 3s before they are received, give or take the
+	// gossip latency, and one of them has a broken clock
+	vote := func(i int) *types.Vote {
+		offset := 3*time.Second + time.Duration(i%5)*10*time.Millisecond
+		if i%7 == 0 {
+			offset = -time.Hour
+		}
+		return &types.Vote{Timestamp: now.Add(-offset)}
+	}
+	for i := 1; i < minClockDriftSamples; i++ {
+		_, ok := m.addVote(vote(i), now)
+		assert.False(t, ok, "estimated from %d votes", i)
+	}
+	for i := minClockDriftSamples; i < 2*clockDriftWindow; i++ {
+		drift, ok := m.addVote(vote(i), now)
+		require.True(t, ok)
+		assert.InDelta(t, 3*time.Second, drift, float64(50*time.Millisecond))
+	}
+
+	// the drift follows the recent votes
+	for i := 0; i < clockDriftWindow; i++ {
+		drift, _ := m.addVote(&types.Vote{Timestamp: now.Add(time.Second)}, now)
+		if i > clockDriftWindow/2 {
+			assert.Equal(t, -time.Second, drift)
+		}
+	}
+}
+
+func TestClockDriftMonitorBlocks(t *testing.T) {
+	const (
+		interval = 5 * time.Second
+		skew     = -2 * time.Second
+	)
+	m := newClockDriftMonitor()
+
+	// each block is committed a block interval after its time, as seen by a
+	// clock 2s behind
+	blockTime := cmttime.Now()
+	for i := 0; i < 3*minClockDriftSamples; i++ {
+		blockTime = blockTime.Add(interval + time.Duration(i%3)*100*time.Millisecond)
+		drift, ok := m.addBlock(blockTime, blockTime.Add(interval+skew))
+		if i < 2*minClockDriftSamples-1 {
+			assert.False(t, ok, "estimated from %d blocks", i+1)
+			continue
+		}
+		require.True(t, ok)
+		assert.InDelta(t, skew, drift, float64(200*time.Millisecond))
+	}
+
+	// a block time out of order is not an interval
+	_, ok := m.addBlock(blockTime, blockTime)
+	assert.False(t, ok)
+}
+
+func TestClockDriftMonitorExceeds(t *testing.T) {
+	m := newClockDriftMonitor()
+	const threshold = time.Second
+
+	assert.False(t, m.exceeds(clockDriftSourceVotes, 500*time.Millisecond, threshold))
+	assert.True(t, m.exceeds(clockDriftSourceVotes, -2*time.Second, threshold))
+	// the drift is only reported again once back within the threshold
+	assert.False(t, m.exceeds(clockDriftSourceVotes, 2*time.Second, threshold))
+	assert.True(t, m.exceeds(clockDriftSourceBlocks, 2*time.Second, threshold))
+	assert.False(t, m.exceeds(clockDriftSourceVotes, 0, threshold))
+	assert.True(t, m.exceeds(clockDriftSourceVotes, 2*time.Second, threshold))
+
+	// a threshold of 0 disables the reports
+	assert.False(t, m.exceeds("other", time.Hour, 0))
+}
+
+func TestReactorReportsClockDrift(t *testing.T) {
+	cs, _ := randState(1)
+	cs.config.ClockDriftThreshold = time.Second
+	conR := NewReactor(cs, false)
+	conR.SetLogger(log.TestingLogger())
+
+	eventBus := types.NewEventBus()
+	require.NoError(t, eventBus.Start())
+	t.Cleanup(func() {
+		if err := eventBus.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+	conR.SetEventBus(eventBus)
+	sub, err := eventBus.Subscribe(context.Background(), "test", types.EventQueryClockDrift, 10)
+	require.NoError(t, err)
+
+	// the votes of the peers are timestamped 1.5s after they are received
+	now := cmttime.Now()
+	for i := 0; i < clockDriftWindow; i++ {
+		conR.observeVoteTime(&types.Vote{Timestamp: now.Add(1500 * time.Millisecond)}, now)
+	}
+	select {
+	case msg := <-sub.Out():
+		data := msg.Data().(types.EventDataClockDrift)
+		assert.Equal(t, clockDriftSourceVotes, data.Source)
+		assert.Equal(t, -1500*time.Millisecond, data.Drift)
+		assert.Equal(t, time.Second, data.Threshold)
+	case <-time.After(time.Second):
+		t.Fatal("no clock drift event")
+	}
+	// it is only reported once
+	select {
+	case msg := <-sub.Out():
+		t.Fatalf("unexpected event %v", msg.Data())
+	default:
+	}
+
+	// nor when it is within the threshold
+	for i := 0; i < clockDriftWindow; i++ {
+		conR.observeVoteTime(&types.Vote{Timestamp: now.Add(-500 * time.Millisecond)}, now)
+	}
+	select {
+	case msg := <-sub.Out():
+		t.Fatalf("unexpected event %v", msg.Data())
+	default:
+	}
+}
@@ -408,7 +408,8 @@ func newStateWithConfigAndBlockStore(
 			state.LastBlockHeight,
 			mempoolv0.WithMetrics(memplMetrics),
 			mempoolv0.WithPreCheck(sm.TxPreCheck(state)),
-			mempoolv0.WithPostCheck(sm.TxPostCheck(state)))
+			mempoolv0.WithPostCheck(sm.TxPostCheck(state)),
+			mempoolv0.WithTxsAvailableThreshold(thisConfig.Consensus.MinTxsInBlock))
 	case cfg.MempoolV1:
 		logger := consensusLogger()
 		mempool = mempoolv1.NewTxMempool(logger,
@@ -418,6 +419,7 @@ func newStateWithConfigAndBlockStore(
 			mempoolv1.WithMetrics(memplMetrics),
 			mempoolv1.WithPreCheck(sm.TxPreCheck(state)),
 			mempoolv1.WithPostCheck(sm.TxPostCheck(state)),
+			mempoolv1.WithTxsAvailableThreshold(thisConfig.Consensus.MinTxsInBlock),
 		)
 	case cfg.MempoolV2:
 		logger := consensusLogger()
@@ -429,6 +431,7 @@ func newStateWithConfigAndBlockStore(
 			mempoolv2.WithMetrics(memplMetrics),
 			mempoolv2.WithPreCheck(sm.TxPreCheck(state)),
 			mempoolv2.WithPostCheck(sm.TxPostCheck(state)),
+			mempoolv2.WithTxsAvailableThreshold(thisConfig.Consensus.MinTxsInBlock),
 		)
 	}
 	if thisConfig.Consensus.WaitForTxs() {
@@ -574,7 +577,8 @@ func ensureNewTimeout(timeoutCh <-chan cmtpubsub.Message, height int64, round in
 		"Timeout expired while waiting for NewTimeout event")
 }
 
-func ensureNewProposal(proposalCh <-chan cmtpubsub.Message, height int64, round int32) {
+// ensureNewProposal returns the block ID of the proposal.
+func ensureNewProposal(proposalCh <-chan cmtpubsub.Message, height int64, round int32) types.BlockID {
 	select {
 	case <-time.After(ensureTimeout):
 		panic("Timeout expired while waiting for NewProposal event")
@@ -590,6 +594,7 @@ func ensureNewProposal(proposalCh <-chan cmtpubsub.Message, height int64, round
 		if proposalEvent.Round != round {
 			panic(fmt.Sprintf("expected round %v, got %v", round, proposalEvent.Round))
 		}
+		return proposalEvent.BlockID
 	}
 }
 
@@ -1,6 +1,7 @@
 package consensus
 
 import (
+	"context"
 	"encoding/binary"
 	"fmt"
 	"os"
@@ -62,6 +63,112 @@ func TestMempoolProgressAfterCreateEmptyBlocksInterval(t *testing.T) {
 	ensureNewEventOnChannel(newBlockCh)   // until the CreateEmptyBlocksInterval has passed
 }
 
+func TestMempoolProgressAfterMinTxsInBlock(t *testing.T) {
+	config := ResetConfig("consensus_mempool_txs_available_test")
+	defer os.RemoveAll(config.RootDir)
+	config.Consensus.CreateEmptyBlocks = false
+	config.Consensus.MinTxsInBlock = 3
+	state, privVals := randGenesisState(1, false, 10)
+	cs := newStateWithConfig(config, state, privVals[0], NewCounterApplication())
+	newBlockCh := subscribe(cs.eventBus, types.EventQueryNewBlock)
+	startTestRound(cs, cs.Height, cs.Round)
+
+	ensureNewEventOnChannel(newBlockCh) // first block gets committed
+	deliverTxsRange(cs, 0, 2)
+	ensureNoNewEventOnChannel(newBlockCh) // not enough txs yet
+	deliverTxsRange(cs, 2, 3)
+	ensureNewEventOnChannel(newBlockCh) // commit txs
+	ensureNewEventOnChannel(newBlockCh) // commit updated app hash
+	ensureNoNewEventOnChannel(newBlockCh)
+}
+
+func TestMempoolProgressAfterMaxEmptyBlockInterval(t *testing.T) {
+	config := ResetConfig("consensus_mempool_txs_available_test")
+	defer os.RemoveAll(config.RootDir)
+	config.Consensus.CreateEmptyBlocks = false
+	config.Consensus.MinTxsInBlock = 1
+	config.Consensus.MaxEmptyBlockInterval = 2 * ensureTimeout
+	state, privVals := randGenesisState(1, false, 10)
+	// keep the block times, hence the commit times, close to the local clock
+	state.ConsensusParams.Block.TimeIotaMs = 1
+	cs := newStateWithConfig(config, state, privVals[0], NewCounterApplication())
+	newBlockCh := subscribe(cs.eventBus, types.EventQueryNewBlock)
+	startTestRound(cs, cs.Height, cs.Round)
+
+	ensureNewEventOnChannel(newBlockCh)   // first block gets committed
+	ensureNoNewEventOnChannel(newBlockCh) // then we dont make a block ...
+
+	// ... until the interval has passed since the first block was committed
+	var blocks []*types.Block
+	for i := 0; i < 2; i++ {
+		select {
+		case msg := <-newBlockCh:
+			blocks = append(blocks, msg.Data().(types.EventDataNewBlock).Block)
+		case <-time.After(3 * ensureTimeout):
+			t.Fatalf("expected an empty block %d after max_empty_block_interval", i)
+		}
+	}
+	// the time of a block is the time the previous block was committed
+	assert.Empty(t, blocks[0].Txs)
+	assert.GreaterOrEqual(t, blocks[1].Time.Sub(blocks[0].Time), config.Consensus.MaxEmptyBlockInterval)
+}
+
+func TestMempoolProgressWithPendingEvidence(t *testing.T) {
+	config := ResetConfig("consensus_mempool_txs_available_test")
+	defer os.RemoveAll(config.RootDir)
+	config.Consensus.CreateEmptyBlocks = false
+	config.Consensus.MinTxsInBlock = 10
+	state, privVals := randGenesisState(1, false, 10)
+	cs := newStateWithConfig(config, state, privVals[0], NewCounterApplication())
+	cs.evpool = pendingEvidencePool{}
+	// the blocks are committed back to back, faster than they may be read
+	sub, err := cs.eventBus.Subscribe(context.Background(), testSubscriber, types.EventQueryNewBlock, 3)
+	require.NoError(t, err)
+	startTestRound(cs, cs.Height, cs.Round)
+
+	// blocks are created without txs while evidence is pending
+	ensureNewEventOnChannel(sub.Out())
+	ensureNewEventOnChannel(sub.Out())
+	ensureNewEventOnChannel(sub.Out())
+}
+
+func TestStateNeedBlock(t *testing.T) {
+	config := ResetConfig("consensus_mempool_txs_available_test")
+	defer os.RemoveAll(config.RootDir)
+	config.Consensus.CreateEmptyBlocks = false
+	state, privVals := randGenesisState(1, false, 10)
+	cs := newStateWithConfig(config, state, privVals[0], NewCounterApplication())
+	newBlockCh := subscribe(cs.eventBus, types.EventQueryNewBlock)
+	startTestRound(cs, cs.Height, cs.Round)
+	ensureNewEventOnChannel(newBlockCh) // first block gets committed
+	ensureNoNewEventOnChannel(newBlockCh)
+
+	cs.mtx.Lock()
+	defer cs.mtx.Unlock()
+	height := cs.Height
+	require.False(t, cs.needBlock(height))
+
+	// a change of the validator set made by the last block
+	cs.state.LastHeightValidatorsChanged = cs.state.LastBlockHeight + 2
+	assert.True(t, cs.needBlock(height))
+	// the next block, which carries the validator set, is still needed
+	cs.state.LastHeightValidatorsChanged = cs.state.LastBlockHeight + 1
+	assert.True(t, cs.needBlock(height))
+	cs.state.LastHeightValidatorsChanged = cs.state.LastBlockHeight
+	assert.False(t, cs.needBlock(height))
+
+	// pending evidence
+	cs.evpool = pendingEvidencePool{}
+	assert.True(t, cs.needBlock(height))
+}
+
+// pendingEvidencePool reports a pending evidence which is never committed.
+type pendingEvidencePool struct {
+	sm.EmptyEvidencePool
+}
+
+func (pendingEvidencePool) Size() uint32 { return 1 }
+
 func TestMempoolProgressInHigherRound(t *testing.T) {
 	config := ResetConfig("consensus_mempool_txs_available_test")
 	defer os.RemoveAll(config.RootDir)
@@ -118,7 +225,10 @@ func TestMempoolTxConcurrentWithCommit(t *testing.T) {
 	cs := newStateWithConfigAndBlockStore(config, state, privVals[0], NewCounterApplication(), blockDB)
 	err := stateStore.Save(state)
 	require.NoError(t, err)
-	newBlockHeaderCh := subscribe(cs.eventBus, types.EventQueryNewBlockHeader)
+	// the blocks are committed back to back, faster than they may be read
+	sub, err := cs.eventBus.Subscribe(context.Background(), testSubscriber, types.EventQueryNewBlockHeader, 100)
+	require.NoError(t, err)
+	newBlockHeaderCh := sub.Out()
 
 	const numTxs int64 = 3000
 	go deliverTxsRange(cs, 0, int(numTxs))
@@ -98,6 +98,26 @@ type Metrics struct {
 
 	// The amount of proposals that failed to be received in time
 	TimedOutProposals metrics.Counter
+
+	// ClockDriftSeconds is the estimated drift in seconds of the local clock
+	// from the clocks of the other validators, labeled by what it was
+	// estimated from. It is positive when the local clock is ahead.
+	ClockDriftSeconds metrics.Gauge
+
+	// Number of consensus messages dropped for being out of bounds, e.g. for
+	// an absurd height or round.
+	MessageBoundsViolations metrics.Counter
+
+	// Number of votes received from the peers that the node already had, i.e.
+	// sent more than once to the node.
+	DuplicateVotesReceived metrics.Counter
+	// Number of times the votes known by a peer that made no progress were
+	// forgotten, for them to be gossiped again.
+	VoteResyncs metrics.Counter
+
+	// Number of times consensus was found stuck on a stale lock, see
+	// ConsensusConfig.StaleLockRounds.
+	StaleLocks metrics.Counter
 }
 
 // PrometheusMetrics returns Metrics build using Prometheus client library.
@@ -275,6 +295,37 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 			Name:      "timed_out_proposals",
 			Help:      "Number of proposals that failed to be received in time",
 		}, labels).With(labelsAndValues...),
+		ClockDriftSeconds: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "clock_drift_seconds",
+			Help: "Estimated drift in seconds of the local clock from the clocks of the other " +
+				"validators, labeled by whether it was estimated from the votes or the blocks.",
+		}, append(labels, "source")).With(labelsAndValues...),
+		MessageBoundsViolations: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "message_bounds_violations",
+			Help:      "Number of consensus messages of the peers dropped for being out of bounds.",
+		}, append(labels, "peer_id")).With(labelsAndValues...),
+		DuplicateVotesReceived: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "duplicate_votes_received",
+			Help:      "Number of votes received from the peers that the node already had.",
+		}, labels).With(labelsAndValues...),
+		VoteResyncs: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "vote_resyncs",
+			Help:      "Number of times the votes known by a stalled peer were forgotten to gossip them again.",
+		}, labels).With(labelsAndValues...),
+		StaleLocks: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "stale_locks",
+			Help:      "Number of times consensus was found locked on a block the other validators moved on from.",
+		}, labels).With(labelsAndValues...),
 	}
 }
 
@@ -313,6 +364,11 @@ func NopMetrics() *Metrics {
 		FullPrevoteMessageDelay:      discard.NewGauge(),
 		ApplicationRejectedProposals: discard.NewCounter(),
 		TimedOutProposals:            discard.NewCounter(),
+		ClockDriftSeconds:            discard.NewGauge(),
+		MessageBoundsViolations:      discard.NewCounter(),
+		DuplicateVotesReceived:       discard.NewCounter(),
+		VoteResyncs:                  discard.NewCounter(),
+		StaleLocks:                   discard.NewCounter(),
 	}
 }
 
@@ -0,0 +1,200 @@
+package consensus
+
+import (
+	"fmt"
+
+	"github.com/tendermint/tendermint/types"
+)
+
+const (
+	// maxMsgRound is the highest round accepted in the messages of the peers.
+	// Even with no timeouts at all, reaching it takes days of failed rounds.
+	maxMsgRound = 1 << 20
+
+	// maxMsgBoundsViolations is the number of messages out of bounds after
+	// which a peer is stopped, and banned by the switch. The messages are
+	// dropped until then.
+	maxMsgBoundsViolations = 3
+)
+
+// msgBounds holds what the messages of the peers are checked against before
+// they affect the peer state or the consensus state. The bounds only reject
+// messages no honest peer could send, so they are loose: a message within the
+// bounds may still be ignored later on.
+type msgBounds struct {
+	// height is the height of the consensus state, or 0 while syncing, in which
+	// case the heights are not checked.
+	height      int64
+	heightSlack int64
+
+	// the sizes of the validator sets at height and height-1, 0 if unknown.
+	valSize        int
+	lastCommitSize int
+}
+
+// msgBounds returns the bounds of the messages of the peers at the current
+// height.
+func (conR *Reactor) msgBounds() msgBounds {
+	bounds := msgBounds{heightSlack: conR.conS.config.PeerMsgHeightSlack}
+	if conR.WaitSync() {
+		return bounds
+	}
+	cs := conR.conS
+	cs.mtx.RLock()
+	bounds.height, bounds.valSize, bounds.lastCommitSize = cs.Height, cs.Validators.Size(), cs.LastCommit.Size()
+	cs.mtx.RUnlock()
+	return bounds
+}
+
+// validate returns an error if the message is out of bounds.
+func (b msgBounds) validate(msg Message) error {
+	switch msg := msg.(type) {
+	case *NewRoundStepMessage:
+		return firstError(
+			b.validatePeerHeight(msg.Height),
+			validateRound(msg.Round),
+			validateRound(msg.LastCommitRound),
+		)
+	case *NewValidBlockMessage:
+		return firstError(
+			b.validatePeerHeight(msg.Height),
+			validateRound(msg.Round),
+			validatePartsTotal(msg.BlockPartSetHeader.Total),
+		)
+	case *HasVoteMessage:
+		return firstError(
+			b.validatePeerHeight(msg.Height),
+			validateRound(msg.Round),
+			b.validateValidatorIndex(msg.Height, msg.Index),
+		)
+	case *VoteSetMaj23Message:
+		return firstError(
+			b.validateHeight(msg.Height),
+			validateRound(msg.Round),
+			validatePartsTotal(msg.BlockID.PartSetHeader.Total),
+		)
+	case *ProposalMessage:
+		return firstError(
+			b.validateHeight(msg.Proposal.Height),
+			validateRound(msg.Proposal.Round),
+			validateRound(msg.Proposal.POLRound),
+			validatePartsTotal(msg.Proposal.BlockID.PartSetHeader.Total),
+		)
+	case *ProposalPOLMessage:
+		return firstError(
+			b.validateHeight(msg.Height),
+			validateRound(msg.ProposalPOLRound),
+			b.validateValidatorSetSize(msg.Height, msg.ProposalPOL.Size()),
+		)
+	case *BlockPartMessage:
+		return firstError(
+			b.validateHeight(msg.Height),
+			validateRound(msg.Round),
+			validatePartIndex(msg.Part),
+		)
+	case *VoteMessage:
+		return firstError(
+			b.validateHeight(msg.Vote.Height),
+			validateRound(msg.Vote.Round),
+			validatePartsTotal(msg.Vote.BlockID.PartSetHeader.Total),
+			b.validateValidatorIndex(msg.Vote.Height, msg.Vote.ValidatorIndex),
+		)
+	case *VoteSetBitsMessage:
+		return firstError(
+			b.validateHeight(msg.Height),
+			validateRound(msg.Round),
+			validatePartsTotal(msg.BlockID.PartSetHeader.Total),
+			b.validateValidatorSetSize(msg.Height, msg.Votes.Size()),
+		)
+	}
+	return nil
+}
+
+// validateHeight checks the height of a message about the height of the node,
+// e.g. a vote or a block part, which must be within heightSlack of it.
+func (b msgBounds) validateHeight(height int64) error {
+	if b.height == 0 || b.heightSlack == 0 {
+		return nil
+	}
+	if height < b.height-b.heightSlack || height > b.height+b.heightSlack {
+		return fmt.Errorf("height %d is too far from our height %d", height, b.height)
+	}
+	return nil
+}
+
+// validatePeerHeight checks the height of a message about the height of the
+// peer, e.g. a new round step. Honest peers may lag arbitrarily behind, so only
+// heights too far ahead are rejected.
+func (b msgBounds) validatePeerHeight(height int64) error {
+	if b.height == 0 || b.heightSlack == 0 {
+		return nil
+	}
+	if height > b.height+b.heightSlack {
+		return fmt.Errorf("height %d is too far ahead of our height %d", height, b.height)
+	}
+	return nil
+}
+
+// validatorSetSize returns the size of the validator set at the given height,
+// or types.MaxVotesCount if unknown.
+func (b msgBounds) validatorSetSize(height int64) int {
+	var size int
+	switch {
+	case b.height == 0:
+	case height == b.height:
+		size = b.valSize
+	case height == b.height-1:
+		size = b.lastCommitSize
+	}
+	if size == 0 {
+		return types.MaxVotesCount
+	}
+	return size
+}
+
+func (b msgBounds) validateValidatorIndex(height int64, index int32) error {
+	if size := b.validatorSetSize(height); int(index) >= size {
+		return fmt.Errorf("validator index %d is out of the validator set of size %d at height %d",
+			index, size, height)
+	}
+	return nil
+}
+
+func (b msgBounds) validateValidatorSetSize(height int64, bitArraySize int) error {
+	if size := b.validatorSetSize(height); bitArraySize > size {
+		return fmt.Errorf("bit array of size %d exceeds the validator set of size %d at height %d",
+			bitArraySize, size, height)
+	}
+	return nil
+}
+
+func validateRound(round int32) error {
+	if round > maxMsgRound {
+		return fmt.Errorf("round %d exceeds the maximum %d", round, maxMsgRound)
+	}
+	return nil
+}
+
+func validatePartsTotal(total uint32) error {
+	if total > types.MaxBlockPartsCount {
+		return fmt.Errorf("part set total %d exceeds the maximum %d", total, types.MaxBlockPartsCount)
+	}
+	return nil
+}
+
+func validatePartIndex(part *types.Part) error {
+	if part.Index >= types.MaxBlockPartsCount || part.Proof.Total > int64(types.MaxBlockPartsCount) {
+		return fmt.Errorf("part %d of %d exceeds the maximum %d parts",
+			part.Index, part.Proof.Total, types.MaxBlockPartsCount)
+	}
+	return nil
+}
+
+func firstError(errs ...error) error {
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
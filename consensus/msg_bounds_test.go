@@ -0,0 +1,225 @@
+package consensus
+
+import (
+	"math"
+	"runtime"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/crypto/merkle"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	"github.com/tendermint/tendermint/libs/bits"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/p2p"
+	p2pmock "github.com/tendermint/tendermint/p2p/mock"
+	cmtcons "github.com/tendermint/tendermint/proto/tendermint/consensus"
+	cmtprotobits "github.com/tendermint/tendermint/proto/tendermint/libs/bits"
+	cmtproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestMsgBoundsValidate(t *testing.T) {
+	bounds := msgBounds{height: 100, heightSlack: 10, valSize: 4, lastCommitSize: 3}
+	blockID := func(total uint32) types.BlockID {
+		return types.BlockID{
+			Hash:          tmhash.Sum([]byte("block")),
+			PartSetHeader: types.PartSetHeader{Total: total, Hash: tmhash.Sum([]byte("parts"))},
+		}
+	}
+	vote := func(height int64, round int32, index int32) *VoteMessage {
+		return &VoteMessage{Vote: &types.Vote{
+			Type:           cmtproto.PrevoteType,
+			Height:         height,
+			Round:          round,
+			BlockID:        blockID(1),
+			ValidatorIndex: index,
+		}}
+	}
+	part := func(index uint32, total int64) *BlockPartMessage {
+		return &BlockPartMessage{Height: 100, Part: &types.Part{
+			Index: index,
+			Proof: merkle.Proof{Total: total, Index: int64(index)},
+		}}
+	}
+
+	tests := []struct {
+		name   string
+		bounds msgBounds
+		msg    Message
+		valid  bool
+	}{
+		{"vote", bounds, vote(100, 0, 3), true},
+		{"vote within slack", bounds, vote(110, 0, 0), true},
+		{"vote too far ahead", bounds, vote(111, 0, 0), false},
+		{"vote too far behind", bounds, vote(89, 0, 0), false},
+		{"vote absurd height", bounds, vote(math.MaxInt64, 0, 0), false},
+		{"vote absurd height while syncing", msgBounds{heightSlack: 10}, vote(math.MaxInt64, 0, 0), true},
+		{"vote absurd height, no slack", msgBounds{height: 100}, vote(math.MaxInt64, 0, 0), true},
+		{"vote max round", bounds, vote(100, maxMsgRound, 0), true},
+		{"vote absurd round", bounds, vote(100, maxMsgRound+1, 0), false},
+		{"vote index out of the validator set", bounds, vote(100, 0, 4), false},
+		{"vote index out of the last validator set", bounds, vote(99, 0, 3), false},
+		{"vote index at another height", bounds, vote(105, 0, types.MaxVotesCount-1), true},
+		{"vote absurd index", bounds, vote(105, 0, types.MaxVotesCount), false},
+
+		{"new round step of a lagging peer", bounds, &NewRoundStepMessage{Height: 1, LastCommitRound: -1}, true},
+		{"new round step too far ahead", bounds, &NewRoundStepMessage{Height: 111}, false},
+		{"new round step absurd round", bounds, &NewRoundStepMessage{Height: 100, Round: math.MaxInt32}, false},
+		{"new round step absurd last commit round", bounds,
+			&NewRoundStepMessage{Height: 100, LastCommitRound: maxMsgRound + 1}, false},
+
+		{"new valid block", bounds, &NewValidBlockMessage{Height: 100,
+			BlockPartSetHeader: types.PartSetHeader{Total: types.MaxBlockPartsCount}}, true},
+		{"new valid block absurd parts", bounds, &NewValidBlockMessage{Height: 100,
+			BlockPartSetHeader: types.PartSetHeader{Total: types.MaxBlockPartsCount + 1}}, false},
+
+		{"has vote", bounds, &HasVoteMessage{Height: 100, Index: 3}, true},
+		{"has vote index out of the validator set", bounds, &HasVoteMessage{Height: 100, Index: 4}, false},
+
+		{"maj23", bounds, &VoteSetMaj23Message{Height: 100, BlockID: blockID(2)}, true},
+		{"maj23 absurd parts", bounds, &VoteSetMaj23Message{Height: 100, BlockID: blockID(math.MaxUint32)}, false},
+		{"maj23 too far behind", bounds, &VoteSetMaj23Message{Height: 1, BlockID: blockID(2)}, false},
+
+		{"proposal", bounds, &ProposalMessage{Proposal: &types.Proposal{
+			Height: 100, POLRound: -1, BlockID: blockID(2)}}, true},
+		{"proposal absurd POL round", bounds, &ProposalMessage{Proposal: &types.Proposal{
+			Height: 100, POLRound: math.MaxInt32, BlockID: blockID(2)}}, false},
+		{"proposal absurd parts", bounds, &ProposalMessage{Proposal: &types.Proposal{
+			Height: 100, POLRound: -1, BlockID: blockID(math.MaxUint32)}}, false},
+
+		{"proposal POL", bounds, &ProposalPOLMessage{Height: 100, ProposalPOL: bits.NewBitArray(4)}, true},
+		{"proposal POL larger than the validator set", bounds,
+			&ProposalPOLMessage{Height: 100, ProposalPOL: bits.NewBitArray(5)}, false},
+
+		{"block part", bounds, part(0, 1), true},
+		{"block part absurd index", bounds, part(types.MaxBlockPartsCount, 1), false},
+		{"block part absurd total", bounds, part(0, math.MaxInt64), false},
+
+		{"vote set bits", bounds, &VoteSetBitsMessage{Height: 99, BlockID: blockID(1), Votes: bits.NewBitArray(3)}, true},
+		{"vote set bits larger than the validator set", bounds,
+			&VoteSetBitsMessage{Height: 99, BlockID: blockID(1), Votes: bits.NewBitArray(4)}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.bounds.validate(tt.msg)
+			if tt.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestReactorStopsPeerSendingMessagesOutOfBounds(t *testing.T) {
+	N := 1
+	css, cleanup := randConsensusNet(N, "consensus_reactor_test", newMockTickerFunc(true), newCounter)
+	defer cleanup()
+	reactors, _, eventBuses := startConsensusNet(t, css, N)
+	defer stopConsensusNet(log.TestingLogger(), reactors, eventBuses)
+
+	var (
+		reactor = reactors[0]
+		peer    = p2pmock.NewPeer(nil)
+	)
+	reactor.InitPeer(peer)
+	ps := peer.Get(types.PeerStateKey).(*PeerState)
+
+	absurd := &cmtcons.NewRoundStep{Height: math.MaxInt64 - 1, Round: 0, Step: 1, LastCommitRound: 0}
+	for i := 1; i < maxMsgBoundsViolations; i++ {
+		reactor.ReceiveEnvelope(p2p.Envelope{ChannelID: StateChannel, Src: peer, Message: absurd})
+		require.True(t, peer.IsRunning(), "stopped after %d messages out of bounds", i)
+	}
+	// the messages never reach the peer state
+	assert.Zero(t, ps.GetHeight())
+
+	reactor.ReceiveEnvelope(p2p.Envelope{ChannelID: StateChannel, Src: peer, Message: absurd})
+	assert.False(t, peer.IsRunning())
+	assert.Zero(t, ps.GetHeight())
+}
+
+// FuzzReactorReceive feeds random consensus messages to the reactor, decoded
+// like the switch does, and checks that whatever a peer sends, receiving it
+// neither panics nor allocates more than the largest message.
+func FuzzReactorReceive(f *testing.F) {
+	hash := tmhash.Sum([]byte("hash"))
+	blockID := func(total uint32) cmtproto.BlockID {
+		return cmtproto.BlockID{Hash: hash, PartSetHeader: cmtproto.PartSetHeader{Total: total, Hash: hash}}
+	}
+	bitArray := &cmtprotobits.BitArray{Bits: 1, Elems: []uint64{1}}
+	seeds := []struct {
+		chID byte
+		msg  proto.Message
+	}{
+		{StateChannel, &cmtcons.NewRoundStep{Height: math.MaxInt64, Round: math.MaxInt32, Step: 1}},
+		{StateChannel, &cmtcons.NewValidBlock{Height: 1 << 40, BlockPartSetHeader: blockID(1).PartSetHeader,
+			BlockParts: bitArray}},
+		{StateChannel, &cmtcons.HasVote{Height: 1, Type: cmtproto.PrevoteType, Index: math.MaxInt32}},
+		{StateChannel, &cmtcons.VoteSetMaj23{Height: 1, Round: math.MaxInt32, Type: cmtproto.PrecommitType,
+			BlockID: blockID(1)}},
+		{DataChannel, &cmtcons.Proposal{Proposal: cmtproto.Proposal{
+			Type: cmtproto.ProposalType, Height: 1, PolRound: -1, BlockID: blockID(math.MaxUint32),
+			Signature: []byte("signature"),
+		}}},
+		{DataChannel, &cmtcons.ProposalPOL{Height: 1, ProposalPolRound: math.MaxInt32, ProposalPol: *bitArray}},
+		{DataChannel, &cmtcons.BlockPart{Height: 1, Part: cmtproto.Part{
+			Index: math.MaxUint32,
+			Bytes: []byte("part"),
+			Proof: *(&merkle.Proof{Total: math.MaxInt64, LeafHash: hash}).ToProto(),
+		}}},
+		{VoteChannel, &cmtcons.Vote{Vote: &cmtproto.Vote{
+			Type: cmtproto.PrevoteType, Height: 1, ValidatorIndex: math.MaxInt32,
+			ValidatorAddress: make([]byte, 20), Signature: []byte("signature"),
+		}}},
+		{VoteSetBitsChannel, &cmtcons.VoteSetBits{Height: math.MaxInt64, Type: cmtproto.PrevoteType,
+			Votes: *bitArray}},
+	}
+	for _, seed := range seeds {
+		bz, err := proto.Marshal(seed.msg.(p2p.Wrapper).Wrap())
+		require.NoError(f, err)
+		f.Add(seed.chID-StateChannel, bz)
+	}
+
+	css, cleanup := randConsensusNet(1, "consensus_reactor_test", newMockTickerFunc(true), newCounter)
+	defer cleanup()
+	reactors, _, eventBuses := startConsensusNet(f, css, 1)
+	defer stopConsensusNet(log.NewNopLogger(), reactors, eventBuses)
+	reactor := reactors[0]
+
+	f.Fuzz(func(t *testing.T, ch byte, bz []byte) {
+		if len(bz) > maxMsgSize {
+			return
+		}
+		msg := &cmtcons.Message{}
+		if err := proto.Unmarshal(bz, msg); err != nil {
+			return
+		}
+		uw, err := msg.Unwrap()
+		if err != nil {
+			return
+		}
+
+		// the peer is at our height, so that the messages affect its state
+		peer := p2pmock.NewPeer(nil)
+		reactor.InitPeer(peer)
+		reactor.ReceiveEnvelope(p2p.Envelope{
+			ChannelID: StateChannel,
+			Src:       peer,
+			Message:   &cmtcons.NewRoundStep{Height: 1, Step: 3, LastCommitRound: -1},
+		})
+
+		var before, after runtime.MemStats
+		runtime.ReadMemStats(&before)
+		reactor.ReceiveEnvelope(p2p.Envelope{
+			ChannelID: StateChannel + ch%4,
+			Src:       peer,
+			Message:   uw,
+		})
+		runtime.ReadMemStats(&after)
+
+		assert.LessOrEqual(t, after.TotalAlloc-before.TotalAlloc, uint64(maxMsgSize))
+	})
+}
@@ -0,0 +1,60 @@
+package consensus
+
+import (
+	"github.com/tendermint/tendermint/crypto"
+	cmtstate "github.com/tendermint/tendermint/proto/tendermint/state"
+)
+
+// proposerRecord tracks, for the height in progress, the proposer of every
+// round the node entered and whether it observed a valid proposal from it.
+// It is persisted in the state store once the height is committed, see
+// saveProposerRecord, for the RPC to report the proposers that missed their
+// rounds. It is best effort: a proposal the node did not receive, e.g. because
+// it caught up on the height, is not observed rather than missed.
+type proposerRecord struct {
+	rounds []cmtstate.RoundProposer
+	// the rounds a valid proposal was observed for, which for round 0 may be
+	// before the node entered it
+	observed map[int32]bool
+}
+
+func (r *proposerRecord) reset() {
+	r.rounds = nil
+	r.observed = make(map[int32]bool)
+}
+
+// enterRound records the proposer of a round the node entered.
+func (r *proposerRecord) enterRound(round int32, proposer crypto.Address) {
+	r.rounds = append(r.rounds, cmtstate.RoundProposer{
+		Round:           round,
+		ProposerAddress: proposer,
+	})
+}
+
+// observeProposal records that a valid proposal was received for a round.
+func (r *proposerRecord) observeProposal(round int32) {
+	if r.observed == nil {
+		r.observed = make(map[int32]bool)
+	}
+	r.observed[round] = true
+}
+
+func (r *proposerRecord) toProto() *cmtstate.ProposerRecord {
+	pb := &cmtstate.ProposerRecord{Rounds: make([]cmtstate.RoundProposer, len(r.rounds))}
+	for i, round := range r.rounds {
+		round.ProposalObserved = r.observed[round.Round]
+		pb.Rounds[i] = round
+	}
+	return pb
+}
+
+// saveProposerRecord persists the proposers of the rounds of the committed
+// height. Failing to do so is only logged, as the record is informational.
+func (cs *State) saveProposerRecord(height int64) {
+	if len(cs.proposerRecord.rounds) == 0 {
+		return
+	}
+	if err := cs.blockExec.Store().SaveProposerRecord(height, cs.proposerRecord.toProto()); err != nil {
+		cs.Logger.Error("failed to save the proposer record", "height", height, "err", err)
+	}
+}
@@ -1,10 +1,12 @@
 package consensus
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"reflect"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/gogo/protobuf/proto"
@@ -15,6 +17,7 @@ import (
 	"github.com/tendermint/tendermint/libs/log"
 	cmtsync "github.com/tendermint/tendermint/libs/sync"
 	"github.com/tendermint/tendermint/p2p"
+	"github.com/tendermint/tendermint/p2p/conn"
 	"github.com/tendermint/tendermint/pkg/trace"
 	"github.com/tendermint/tendermint/pkg/trace/schema"
 	cmtcons "github.com/tendermint/tendermint/proto/tendermint/consensus"
@@ -29,11 +32,18 @@ const (
 	DataChannel        = byte(0x21)
 	VoteChannel        = byte(0x22)
 	VoteSetBitsChannel = byte(0x23)
+	// ValidatorAnnouncementChannel carries the announcements of the validators
+	// run by the peers. It is only opened with consensus.validator_announcements.
+	ValidatorAnnouncementChannel = byte(0x24)
 
 	maxMsgSize = 1048576 // 1MB; NOTE/TODO: keep in sync with types.PartSet sizes.
 
 	blocksToContributeToBecomeGoodPeer = 10000
 	votesToContributeToBecomeGoodPeer  = 10000
+
+	// the default interval of the checks of the progress of the peers, whose
+	// known votes are forgotten if they made none, see PeerState.ResyncVotes
+	defaultVoteResyncInterval = 30 * time.Second
 )
 
 //-----------------------------------------------------------------------------
@@ -49,8 +59,13 @@ type Reactor struct {
 	eventBus *types.EventBus
 	rs       *cstypes.RoundState
 
+	clockDrift     *clockDriftMonitor
+	validatorPeers *validatorPeers
+
 	Metrics     *Metrics
 	traceClient trace.Tracer
+
+	voteResyncInterval time.Duration
 }
 
 type ReactorOption func(*Reactor)
@@ -59,11 +74,15 @@ type ReactorOption func(*Reactor)
 // consensusState.
 func NewReactor(consensusState *State, waitSync bool, options ...ReactorOption) *Reactor {
 	conR := &Reactor{
-		conS:        consensusState,
-		waitSync:    waitSync,
-		rs:          consensusState.GetRoundState(),
-		Metrics:     NopMetrics(),
-		traceClient: trace.NoOpTracer(),
+		conS:           consensusState,
+		waitSync:       waitSync,
+		rs:             consensusState.GetRoundState(),
+		clockDrift:     newClockDriftMonitor(),
+		validatorPeers: newValidatorPeers(),
+		Metrics:        NopMetrics(),
+		traceClient:    trace.NoOpTracer(),
+
+		voteResyncInterval: defaultVoteResyncInterval,
 	}
 	conR.BaseReactor = *p2p.NewBaseReactor("Consensus", conR)
 
@@ -85,6 +104,10 @@ func (conR *Reactor) OnStart() error {
 	conR.subscribeToBroadcastEvents()
 	go conR.updateRoundStateRoutine()
 
+	if conR.conS.config.ValidatorAnnouncements {
+		go conR.announceValidatorRoutine()
+	}
+
 	if !conR.WaitSync() {
 		err := conR.conS.Start()
 		if err != nil {
@@ -150,7 +173,7 @@ conR:
 // GetChannels implements Reactor
 func (conR *Reactor) GetChannels() []*p2p.ChannelDescriptor {
 	// TODO optimize
-	return []*p2p.ChannelDescriptor{
+	channels := []*p2p.ChannelDescriptor{
 		{
 			ID:                  StateChannel,
 			Priority:            6,
@@ -166,6 +189,7 @@ func (conR *Reactor) GetChannels() []*p2p.ChannelDescriptor {
 			RecvBufferCapacity:  50 * 4096,
 			RecvMessageCapacity: maxMsgSize,
 			MessageType:         &cmtcons.Message{},
+			SendQueuePolicy:     conn.SendQueueEvictStale,
 		},
 		{
 			ID:                  VoteChannel,
@@ -174,6 +198,7 @@ func (conR *Reactor) GetChannels() []*p2p.ChannelDescriptor {
 			RecvBufferCapacity:  100 * 100,
 			RecvMessageCapacity: maxMsgSize,
 			MessageType:         &cmtcons.Message{},
+			SendQueuePolicy:     conn.SendQueueEvictStale,
 		},
 		{
 			ID:                  VoteSetBitsChannel,
@@ -184,6 +209,18 @@ func (conR *Reactor) GetChannels() []*p2p.ChannelDescriptor {
 			MessageType:         &cmtcons.Message{},
 		},
 	}
+	if conR.conS.config.ValidatorAnnouncements {
+		// the peers without the channel are never sent announcements
+		channels = append(channels, &p2p.ChannelDescriptor{
+			ID:                  ValidatorAnnouncementChannel,
+			Priority:            1,
+			SendQueueCapacity:   2,
+			RecvBufferCapacity:  1024,
+			RecvMessageCapacity: 1024,
+			MessageType:         &cmtproto.ValidatorAnnouncement{},
+		})
+	}
+	return channels
 }
 
 // InitPeer implements Reactor by creating a state for the peer.
@@ -214,13 +251,18 @@ func (conR *Reactor) AddPeer(peer p2p.Peer) {
 	if !conR.WaitSync() {
 		conR.sendNewRoundStepMessage(peer)
 	}
+
+	if conR.conS.config.ValidatorAnnouncements {
+		conR.sendValidatorAnnouncement(peer)
+	}
 }
 
-// RemovePeer is a noop.
+// RemovePeer forgets the validator run by the peer.
 func (conR *Reactor) RemovePeer(peer p2p.Peer, reason interface{}) {
 	if !conR.IsRunning() {
 		return
 	}
+	conR.validatorPeers.remove(peer.ID())
 	// TODO
 	// ps, ok := peer.Get(PeerStateKey).(*PeerState)
 	// if !ok {
@@ -240,6 +282,10 @@ func (conR *Reactor) ReceiveEnvelope(e p2p.Envelope) {
 		conR.Logger.Debug("Receive", "src", e.Src, "chId", e.ChannelID)
 		return
 	}
+	if e.ChannelID == ValidatorAnnouncementChannel {
+		conR.receiveValidatorAnnouncement(e)
+		return
+	}
 	m := e.Message
 	if wm, ok := m.(p2p.Wrapper); ok {
 		m = wm.Wrap()
@@ -257,6 +303,14 @@ func (conR *Reactor) ReceiveEnvelope(e p2p.Envelope) {
 		return
 	}
 
+	if e.Bytes != nil && isStrictlyDecoded(msg) && conR.conS.strictDecoding.Load() {
+		if err = types.CheckCanonicalEncoding(m, e.Bytes); err != nil {
+			conR.Logger.Error("Peer sent us non-canonical msg", "peer", e.Src, "msg", msg, "err", err)
+			conR.Switch.StopPeerForError(e.Src, err)
+			return
+		}
+	}
+
 	conR.Logger.Debug("Receive", "src", e.Src, "chId", e.ChannelID, "msg", msg)
 
 	// Get peer states
@@ -265,6 +319,17 @@ func (conR *Reactor) ReceiveEnvelope(e p2p.Envelope) {
 		panic(fmt.Sprintf("Peer %v has no state", e.Src))
 	}
 
+	// Drop the messages no honest peer could send before they reach the peer
+	// state or the consensus state, and ban the peers sending too many.
+	if err = conR.msgBounds().validate(msg); err != nil {
+		conR.Logger.Debug("Peer sent us msg out of bounds", "peer", e.Src, "msg", msg, "err", err)
+		conR.Metrics.MessageBoundsViolations.With("peer_id", string(e.Src.ID())).Add(1)
+		if violations := atomic.AddUint32(&ps.boundsViolations, 1); violations >= maxMsgBoundsViolations {
+			conR.Switch.StopPeerForError(e.Src, p2p.ErrPeerMessageLimits{Violations: violations, Err: err})
+		}
+		return
+	}
+
 	switch e.ChannelID {
 	case StateChannel:
 		switch msg := msg.(type) {
@@ -414,13 +479,21 @@ func (conR *Reactor) ReceiveEnvelope(e p2p.Envelope) {
 			cs.mtx.RLock()
 			height, round, valSize, lastCommitSize := cs.Height, cs.Round,
 				cs.Validators.Size(), cs.LastCommit.Size()
+			votes, lastCommit := cs.Votes, cs.LastCommit
 			cs.mtx.RUnlock()
 
+			if hasVote(msg.Vote, height, votes, lastCommit) {
+				conR.Metrics.DuplicateVotesReceived.Add(1)
+			}
+
 			schema.WriteVote(conR.traceClient, height, round, msg.Vote, string(e.Src.ID()), schema.Download)
 
 			ps.EnsureVoteBitArrays(height, valSize)
 			ps.EnsureVoteBitArrays(height-1, lastCommitSize)
 			ps.SetHasVote(msg.Vote)
+			if msg.Vote.Height == height && msg.Vote.Round == round {
+				conR.observeVoteTime(msg.Vote, cmttime.Now())
+			}
 
 			cs.peerMsgQueue <- msgInfo{msg, e.Src.ID()}
 
@@ -466,6 +539,18 @@ func (conR *Reactor) ReceiveEnvelope(e p2p.Envelope) {
 }
 
 func (conR *Reactor) Receive(chID byte, peer p2p.Peer, msgBytes []byte) {
+	if chID == ValidatorAnnouncementChannel {
+		announcement := &cmtproto.ValidatorAnnouncement{}
+		if err := proto.Unmarshal(msgBytes, announcement); err != nil {
+			panic(err)
+		}
+		conR.ReceiveEnvelope(p2p.Envelope{
+			ChannelID: chID,
+			Src:       peer,
+			Message:   announcement,
+		})
+		return
+	}
 	msg := &cmtcons.Message{}
 	err := proto.Unmarshal(msgBytes, msg)
 	if err != nil {
@@ -495,6 +580,163 @@ func (conR *Reactor) WaitSync() bool {
 	return conR.waitSync
 }
 
+// isStrictlyDecoded returns whether msg carries one of the consensus critical
+// types subject to the StrictDecoding consensus param.
+func isStrictlyDecoded(msg Message) bool {
+	switch msg.(type) {
+	case *ProposalMessage, *BlockPartMessage, *VoteMessage:
+		return true
+	default:
+		return false
+	}
+}
+
+// observeVoteTime estimates the drift of the local clock from the timestamp of a
+// vote for the current round, received at the local time now.
+func (conR *Reactor) observeVoteTime(vote *types.Vote, now time.Time) {
+	if drift, ok := conR.clockDrift.addVote(vote, now); ok {
+		conR.reportClockDrift(clockDriftSourceVotes, drift)
+	}
+}
+
+// observeBlockTime estimates the drift of the local clock from the time of a
+// block, committed at the local time now.
+func (conR *Reactor) observeBlockTime(blockTime, now time.Time) {
+	if drift, ok := conR.clockDrift.addBlock(blockTime, now); ok {
+		conR.reportClockDrift(clockDriftSourceBlocks, drift)
+	}
+}
+
+// reportClockDrift updates the drift gauge of the source, and warns when the
+// drift exceeds the configured threshold. It does not affect consensus, which
+// relies on the local clock regardless.
+func (conR *Reactor) reportClockDrift(source string, drift time.Duration) {
+	conR.Metrics.ClockDriftSeconds.With("source", source).Set(drift.Seconds())
+
+	threshold := conR.conS.config.ClockDriftThreshold
+	if !conR.clockDrift.exceeds(source, drift, threshold) {
+		return
+	}
+	conR.Logger.Error("Local clock drifts from the network beyond the threshold, check its synchronization",
+		"source", source, "drift", drift, "threshold", threshold)
+	if conR.eventBus == nil {
+		return
+	}
+	if err := conR.eventBus.PublishEventClockDrift(types.EventDataClockDrift{
+		Source:    source,
+		Drift:     drift,
+		Threshold: threshold,
+	}); err != nil {
+		conR.Logger.Error("Error publishing clock drift event", "err", err)
+	}
+}
+
+// validatorAnnouncement returns the signed announcement of the validator run by
+// this node, or nil if the node runs no validator of the current set or its
+// PrivValidator does not sign announcements.
+func (conR *Reactor) validatorAnnouncement() *cmtproto.ValidatorAnnouncement {
+	cs := conR.conS
+	cs.mtx.RLock()
+	privValidator, pubKey, chainID, vals := cs.privValidator, cs.privValidatorPubKey, cs.state.ChainID, cs.Validators
+	cs.mtx.RUnlock()
+
+	signer, ok := privValidator.(types.ValidatorAnnouncementSigner)
+	if !ok || pubKey == nil || vals == nil || !vals.HasAddress(pubKey.Address()) {
+		return nil
+	}
+	announcement := &cmtproto.ValidatorAnnouncement{
+		ValidatorAddress: pubKey.Address(),
+		NodeId:           string(conR.Switch.NodeInfo().ID()),
+		ChainID:          chainID,
+	}
+	if err := signer.SignValidatorAnnouncement(chainID, announcement); err != nil {
+		conR.Logger.Error("Error signing validator announcement", "err", err)
+		return nil
+	}
+	return announcement
+}
+
+// sendValidatorAnnouncement announces the validator run by this node to the
+// peer, if any.
+func (conR *Reactor) sendValidatorAnnouncement(peer p2p.Peer) {
+	if announcement := conR.validatorAnnouncement(); announcement != nil {
+		p2p.SendEnvelopeShim(peer, p2p.Envelope{ //nolint: staticcheck
+			ChannelID: ValidatorAnnouncementChannel,
+			Message:   announcement,
+		}, conR.Logger)
+	}
+}
+
+// announceValidatorRoutine periodically announces the validator run by this
+// node to all the peers.
+func (conR *Reactor) announceValidatorRoutine() {
+	ticker := time.NewTicker(validatorAnnouncementInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if announcement := conR.validatorAnnouncement(); announcement != nil {
+				conR.Switch.BroadcastEnvelope(p2p.Envelope{
+					ChannelID: ValidatorAnnouncementChannel,
+					Message:   announcement,
+				})
+			}
+		case <-conR.Quit():
+			return
+		}
+	}
+}
+
+// receiveValidatorAnnouncement records the validator run by the peer, once its
+// announcement is verified against the current validator set. Announcements
+// are not relayed, so a peer can only announce itself.
+func (conR *Reactor) receiveValidatorAnnouncement(e p2p.Envelope) {
+	announcement, ok := e.Message.(*cmtproto.ValidatorAnnouncement)
+	if !ok {
+		conR.Logger.Error(fmt.Sprintf("Unknown message type %v", reflect.TypeOf(e.Message)))
+		return
+	}
+	if p2p.ID(announcement.NodeId) != e.Src.ID() {
+		err := fmt.Errorf("validator announcement of node %s", announcement.NodeId)
+		conR.Logger.Error("Peer sent us invalid msg", "peer", e.Src, "msg", announcement, "err", err)
+		conR.Switch.StopPeerForError(e.Src, err)
+		return
+	}
+
+	cs := conR.conS
+	cs.mtx.RLock()
+	chainID, vals := cs.state.ChainID, cs.Validators
+	cs.mtx.RUnlock()
+	if vals == nil {
+		return
+	}
+
+	err := types.VerifyValidatorAnnouncement(chainID, vals, announcement)
+	switch {
+	case errors.Is(err, types.ErrAnnouncementUnknownValidator):
+		// the peer may be ahead of us, with another validator set
+		conR.Logger.Debug("Ignoring announcement of unknown validator", "peer", e.Src, "err", err)
+	case err != nil:
+		conR.Logger.Error("Peer sent us invalid msg", "peer", e.Src, "msg", announcement, "err", err)
+		conR.Switch.StopPeerForError(e.Src, err)
+	default:
+		conR.Logger.Debug("Peer runs validator", "peer", e.Src, "validator", types.Address(announcement.ValidatorAddress))
+		conR.validatorPeers.set(e.Src.ID(), announcement.ValidatorAddress)
+	}
+}
+
+// gossipSleepDuration returns how long the gossip routines of the peer sleep
+// when they have nothing to send. It is shorter for the peers running a
+// validator which has not precommitted yet in the round, so that they are sent
+// the votes and block parts they miss as soon as this node has them.
+func (conR *Reactor) gossipSleepDuration(peer p2p.Peer, rs *cstypes.RoundState) time.Duration {
+	sleep := conR.conS.config.PeerGossipSleepDuration
+	if conR.validatorPeers.awaitsPrecommit(peer.ID(), rs) {
+		return sleep / prioritizedGossipSleepDivisor
+	}
+	return sleep
+}
+
 //--------------------------------------
 
 // subscribeToBroadcastEvents subscribes for new round steps and votes
@@ -504,7 +746,13 @@ func (conR *Reactor) subscribeToBroadcastEvents() {
 	const subscriber = "consensus-reactor"
 	if err := conR.conS.evsw.AddListenerForEvent(subscriber, types.EventNewRoundStep,
 		func(data cmtevents.EventData) {
-			conR.broadcastNewRoundStepMessage(data.(*cstypes.RoundState))
+			rs := data.(*cstypes.RoundState)
+			conR.broadcastNewRoundStepMessage(rs)
+			if rs.Step == cstypes.RoundStepNewHeight && !rs.CommitTime.IsZero() {
+				// the listeners are called with the consensus state locked, and
+				// the state holds the time of the block just committed
+				conR.observeBlockTime(conR.conS.state.LastBlockTime, rs.CommitTime)
+			}
 		}); err != nil {
 		conR.Logger.Error("Error adding listener for events", "err", err)
 	}
@@ -523,6 +771,13 @@ func (conR *Reactor) subscribeToBroadcastEvents() {
 		conR.Logger.Error("Error adding listener for events", "err", err)
 	}
 
+	if err := conR.conS.evsw.AddListenerForEvent(subscriber, eventStaleLock,
+		func(data cmtevents.EventData) {
+			conR.handleStaleLock(data.(*staleLock))
+		}); err != nil {
+		conR.Logger.Error("Error adding listener for events", "err", err)
+	}
+
 }
 
 func (conR *Reactor) unsubscribeFromBroadcastEvents() {
@@ -530,6 +785,34 @@ func (conR *Reactor) unsubscribeFromBroadcastEvents() {
 	conR.conS.evsw.RemoveListener(subscriber)
 }
 
+// handleStaleLock logs the states of the peers when consensus is stuck on a
+// stale lock and, with StaleLockResync, forgets the votes and the proposal
+// known by each of them, for them to be gossiped again.
+func (conR *Reactor) handleStaleLock(sl *staleLock) {
+	resync := conR.conS.config.StaleLockResync
+	peers := conR.Switch.Peers().List()
+	states := make([]string, 0, len(peers))
+	for _, peer := range peers {
+		ps, ok := peer.Get(types.PeerStateKey).(*PeerState)
+		if !ok {
+			continue
+		}
+		states = append(states, ps.StringIndented(""))
+		if resync {
+			ps.Resync()
+			conR.Metrics.VoteResyncs.Add(1)
+		}
+	}
+	conR.Logger.Error("peer states on a stale lock",
+		"height", sl.Height,
+		"round", sl.Round,
+		"locked_round", sl.LockedRound,
+		"locked_block", sl.LockedBlock,
+		"peers", states,
+		"resync", resync,
+	)
+}
+
 func (conR *Reactor) broadcastNewRoundStepMessage(rs *cstypes.RoundState) {
 	nrsMsg := makeRoundStepMessage(rs)
 	conR.Switch.BroadcastEnvelope(p2p.Envelope{
@@ -665,6 +948,20 @@ func (conR *Reactor) getRoundState() *cstypes.RoundState {
 	return conR.rs
 }
 
+// relevanceTag tags a message about (height, round) sent to a peer in the
+// round state prs. The Data and Vote channels evict the queued messages tagged
+// older than the latest tag under backpressure: a message stays relevant
+// until the peer moves past both the round it is about and the round the peer
+// was in when the message was picked for it, at which point the peer state
+// bookkeeping the message was recorded in is reset anyway.
+func relevanceTag(prs *cstypes.PeerRoundState, height int64, round int32) p2p.RelevanceTag {
+	tag := p2p.RelevanceTag{Height: height, Round: round}
+	if peerTag := (p2p.RelevanceTag{Height: prs.Height, Round: prs.Round}); tag.Before(peerTag) {
+		return peerTag
+	}
+	return tag
+}
+
 func (conR *Reactor) gossipDataRoutine(peer p2p.Peer, ps *PeerState) {
 	logger := conR.Logger.With("peer", peer)
 
@@ -686,14 +983,14 @@ OUTER_LOOP:
 					panic(err)
 				}
 				logger.Debug("Sending block part", "height", prs.Height, "round", prs.Round)
-				if p2p.SendEnvelopeShim(peer, p2p.Envelope{ //nolint: staticcheck
+				if p2p.SendEnvelopeTaggedShim(peer, p2p.Envelope{
 					ChannelID: DataChannel,
 					Message: &cmtcons.BlockPart{
 						Height: rs.Height, // This tells peer that this part applies to us.
 						Round:  rs.Round,  // This tells peer that this part applies to us.
 						Part:   *parts,
 					},
-				}, logger) {
+				}, relevanceTag(prs, rs.Height, rs.Round), logger) {
 					schema.WriteBlockPart(conR.traceClient, rs.Height, rs.Round, part.Index, false, string(peer.ID()), schema.Upload)
 					ps.SetHasProposalBlockPart(prs.Height, prs.Round, index)
 				}
@@ -727,7 +1024,7 @@ OUTER_LOOP:
 		if (rs.Height != prs.Height) || (rs.Round != prs.Round) {
 			// logger.Info("Peer Height|Round mismatch, sleeping",
 			// "peerHeight", prs.Height, "peerRound", prs.Round, "peer", peer)
-			time.Sleep(conR.conS.config.PeerGossipSleepDuration)
+			time.Sleep(conR.gossipSleepDuration(peer, rs))
 			continue OUTER_LOOP
 		}
 
@@ -741,10 +1038,10 @@ OUTER_LOOP:
 			// Proposal: share the proposal metadata with peer.
 			{
 				logger.Debug("Sending proposal", "height", prs.Height, "round", prs.Round)
-				if p2p.SendEnvelopeShim(peer, p2p.Envelope{ //nolint: staticcheck
+				if p2p.SendEnvelopeTaggedShim(peer, p2p.Envelope{
 					ChannelID: DataChannel,
 					Message:   &cmtcons.Proposal{Proposal: *rs.Proposal.ToProto()},
-				}, logger) {
+				}, relevanceTag(prs, rs.Height, rs.Proposal.Round), logger) {
 					// NOTE[ZM]: A peer might have received different proposal msg so this Proposal msg will be rejected!
 					ps.SetHasProposal(rs.Proposal)
 					schema.WriteProposal(
@@ -762,14 +1059,14 @@ OUTER_LOOP:
 			// so we definitely have rs.Votes.Prevotes(rs.Proposal.POLRound).
 			if 0 <= rs.Proposal.POLRound {
 				logger.Debug("Sending POL", "height", prs.Height, "round", prs.Round)
-				if p2p.SendEnvelopeShim(peer, p2p.Envelope{ //nolint: staticcheck
+				if p2p.SendEnvelopeTaggedShim(peer, p2p.Envelope{
 					ChannelID: DataChannel,
 					Message: &cmtcons.ProposalPOL{
 						Height:           rs.Height,
 						ProposalPolRound: rs.Proposal.POLRound,
 						ProposalPol:      *rs.Votes.Prevotes(rs.Proposal.POLRound).BitArray().ToProto(),
 					},
-				}, logger) {
+				}, relevanceTag(prs, rs.Height, rs.Proposal.POLRound), logger) {
 					schema.WriteConsensusState(
 						conR.traceClient,
 						rs.Height,
@@ -784,7 +1081,7 @@ OUTER_LOOP:
 		}
 
 		// Nothing to do. Sleep.
-		time.Sleep(conR.conS.config.PeerGossipSleepDuration)
+		time.Sleep(conR.gossipSleepDuration(peer, rs))
 		continue OUTER_LOOP
 	}
 }
@@ -821,14 +1118,14 @@ func (conR *Reactor) gossipDataForCatchup(logger log.Logger, rs *cstypes.RoundSt
 			logger.Error("Could not convert part to proto", "index", index, "error", err)
 			return
 		}
-		if p2p.SendEnvelopeShim(peer, p2p.Envelope{ //nolint: staticcheck
+		if p2p.SendEnvelopeTaggedShim(peer, p2p.Envelope{
 			ChannelID: DataChannel,
 			Message: &cmtcons.BlockPart{
 				Height: prs.Height, // Not our height, so it doesn't matter.
 				Round:  prs.Round,  // Not our height, so it doesn't matter.
 				Part:   *pp,
 			},
-		}, logger) {
+		}, relevanceTag(prs, prs.Height, prs.Round), logger) {
 			ps.SetHasProposalBlockPart(prs.Height, prs.Round, index)
 			schema.WriteBlockPart(
 				conR.traceClient,
@@ -856,6 +1153,9 @@ func (conR *Reactor) gossipVotesRoutine(peer p2p.Peer, ps *PeerState) {
 	// Simple hack to throttle logs upon sleep.
 	var sleeping = 0
 
+	// the progress of the peer at the last check, see resyncStalledPeer
+	lastCheck, checkedPRS := time.Now(), ps.GetRoundState()
+
 OUTER_LOOP:
 	for {
 		// Manage disconnects from self or peer.
@@ -865,6 +1165,11 @@ OUTER_LOOP:
 		rs := conR.getRoundState()
 		prs := ps.GetRoundState()
 
+		if now := time.Now(); now.Sub(lastCheck) >= conR.voteResyncInterval {
+			conR.resyncStalledPeer(logger, ps, checkedPRS, prs)
+			lastCheck, checkedPRS = now, prs
+		}
+
 		switch sleeping {
 		case 1: // First sleep
 			sleeping = 2
@@ -920,11 +1225,45 @@ OUTER_LOOP:
 			sleeping = 1
 		}
 
-		time.Sleep(conR.conS.config.PeerGossipSleepDuration)
+		time.Sleep(conR.gossipSleepDuration(peer, rs))
 		continue OUTER_LOOP
 	}
 }
 
+// resyncStalledPeer forgets the votes known by the peer if it stayed at the
+// same height, round and step since the last check, as it may be stuck for
+// lack of votes it lost.
+func (conR *Reactor) resyncStalledPeer(logger log.Logger, ps *PeerState, checked, prs *cstypes.PeerRoundState) {
+	if prs.Height != checked.Height || prs.Round != checked.Round || prs.Step != checked.Step {
+		return
+	}
+	logger.Debug("Peer made no progress, gossiping its votes again",
+		"height", prs.Height, "round", prs.Round, "step", prs.Step)
+	ps.ResyncVotes()
+	conR.Metrics.VoteResyncs.Add(1)
+}
+
+// hasVote returns true if the vote is in the votes of the height, or in the
+// last commit.
+func hasVote(vote *types.Vote, height int64, votes *cstypes.HeightVoteSet, lastCommit *types.VoteSet) bool {
+	var voteSet *types.VoteSet
+	switch {
+	case vote.Height == height && votes != nil:
+		if vote.Type == cmtproto.PrevoteType {
+			voteSet = votes.Prevotes(vote.Round)
+		} else {
+			voteSet = votes.Precommits(vote.Round)
+		}
+	case vote.Height+1 == height && vote.Type == cmtproto.PrecommitType && lastCommit.GetRound() == vote.Round:
+		voteSet = lastCommit
+	}
+	if vote.ValidatorIndex >= int32(voteSet.Size()) {
+		return false
+	}
+	known := voteSet.GetByIndex(vote.ValidatorIndex)
+	return known != nil && bytes.Equal(known.Signature, vote.Signature)
+}
+
 // pickSendVoteAndTrace picks a vote to send and traces it.
 // It returns true if a vote is sent.
 // Note that it is a wrapper around PickSendVote with the addition of tracing the vote.
@@ -1206,6 +1545,14 @@ func ReactorTracing(traceClient trace.Tracer) ReactorOption {
 	return func(conR *Reactor) { conR.traceClient = traceClient }
 }
 
+// ReactorVoteResyncInterval sets the interval of the checks of the progress of
+// the peers: the votes known by a peer that stayed at the same height, round
+// and step since the last check are forgotten, for the votes it may have lost
+// to be gossiped again.
+func ReactorVoteResyncInterval(interval time.Duration) ReactorOption {
+	return func(conR *Reactor) { conR.voteResyncInterval = interval }
+}
+
 //-----------------------------------------------------------------------------
 
 var (
@@ -1224,8 +1571,27 @@ type PeerState struct {
 	mtx   sync.Mutex             // NOTE: Modify below using setters, never directly.
 	PRS   cstypes.PeerRoundState `json:"round_state"` // Exposed.
 	Stats *peerStateStats        `json:"stats"`       // Exposed.
+
+	// number of messages out of bounds received from the peer, see msgBounds.
+	boundsViolations uint32
+
+	// the votes known by the peer at its height and the precommits at the
+	// previous height, by round, including the bit-arrays of PRS. Unlike PRS,
+	// they survive the changes of round, see pruneVoteBits.
+	voteBits map[voteBitsKey]*bits.BitArray
 }
 
+// voteBitsKey identifies the votes of a type at a height and round.
+type voteBitsKey struct {
+	height   int64
+	round    int32
+	voteType cmtproto.SignedMsgType
+}
+
+// the number of rounds before the one of a peer whose votes known by the peer
+// are kept track of
+const recentVoteRounds = 3
+
 // peerStateStats holds internal statistics for a peer.
 type peerStateStats struct {
 	Votes      int `json:"votes"`
@@ -1248,7 +1614,8 @@ func NewPeerState(peer p2p.Peer) *PeerState {
 			LastCommitRound:    -1,
 			CatchupCommitRound: -1,
 		},
-		Stats: &peerStateStats{},
+		Stats:    &peerStateStats{},
+		voteBits: make(map[voteBitsKey]*bits.BitArray),
 	}
 }
 
@@ -1342,12 +1709,13 @@ func (ps *PeerState) SetHasProposalBlockPart(height int64, round int32, index in
 func (ps *PeerState) PickSendVote(votes types.VoteSetReader) *types.Vote {
 	if vote, ok := ps.PickVoteToSend(votes); ok {
 		ps.logger.Debug("Sending vote message", "ps", ps, "vote", vote)
-		if p2p.SendEnvelopeShim(ps.peer, p2p.Envelope{ //nolint: staticcheck
+		prs := ps.GetRoundState()
+		if p2p.SendEnvelopeTaggedShim(ps.peer, p2p.Envelope{
 			ChannelID: VoteChannel,
 			Message: &cmtcons.Vote{
 				Vote: vote.ToProto(),
 			},
-		}, ps.logger) {
+		}, relevanceTag(prs, vote.Height, vote.Round), ps.logger) {
 			ps.SetHasVote(vote)
 			return vote
 		}
@@ -1454,11 +1822,8 @@ func (ps *PeerState) ensureCatchupCommitRound(height int64, round int32, numVali
 		return // Nothing to do!
 	}
 	ps.PRS.CatchupCommitRound = round
-	if round == ps.PRS.Round {
-		ps.PRS.CatchupCommit = ps.PRS.Precommits
-	} else {
-		ps.PRS.CatchupCommit = bits.NewBitArray(numValidators)
-	}
+	// the precommits of the current round if round is the current round
+	ps.PRS.CatchupCommit = ps.ensureVoteBits(height, round, cmtproto.PrecommitType, numValidators)
 }
 
 // EnsureVoteBitArrays ensures the bit-arrays have been allocated for tracking
@@ -1474,24 +1839,109 @@ func (ps *PeerState) EnsureVoteBitArrays(height int64, numValidators int) {
 func (ps *PeerState) ensureVoteBitArrays(height int64, numValidators int) {
 	if ps.PRS.Height == height {
 		if ps.PRS.Prevotes == nil {
-			ps.PRS.Prevotes = bits.NewBitArray(numValidators)
+			ps.PRS.Prevotes = ps.ensureVoteBits(height, ps.PRS.Round, cmtproto.PrevoteType, numValidators)
 		}
 		if ps.PRS.Precommits == nil {
-			ps.PRS.Precommits = bits.NewBitArray(numValidators)
+			ps.PRS.Precommits = ps.ensureVoteBits(height, ps.PRS.Round, cmtproto.PrecommitType, numValidators)
 		}
 		if ps.PRS.CatchupCommit == nil {
-			ps.PRS.CatchupCommit = bits.NewBitArray(numValidators)
+			ps.PRS.CatchupCommit = ps.ensureVoteBits(height, ps.PRS.CatchupCommitRound,
+				cmtproto.PrecommitType, numValidators)
 		}
 		if ps.PRS.ProposalPOL == nil {
-			ps.PRS.ProposalPOL = bits.NewBitArray(numValidators)
+			ps.PRS.ProposalPOL = ps.ensureVoteBits(height, ps.PRS.ProposalPOLRound,
+				cmtproto.PrevoteType, numValidators)
 		}
 	} else if ps.PRS.Height == height+1 {
 		if ps.PRS.LastCommit == nil {
-			ps.PRS.LastCommit = bits.NewBitArray(numValidators)
+			ps.PRS.LastCommit = ps.ensureVoteBits(height, ps.PRS.LastCommitRound,
+				cmtproto.PrecommitType, numValidators)
 		}
 	}
 }
 
+// ensureVoteBits returns the votes of a type at a height and round known by
+// the peer, allocated if needed. The votes of round -1 are not kept track of.
+func (ps *PeerState) ensureVoteBits(height int64, round int32, voteType cmtproto.SignedMsgType,
+	numValidators int,
+) *bits.BitArray {
+	if round < 0 {
+		return bits.NewBitArray(numValidators)
+	}
+	key := voteBitsKey{height, round, voteType}
+	votes, ok := ps.voteBits[key]
+	if !ok {
+		votes = bits.NewBitArray(numValidators)
+		ps.voteBits[key] = votes
+	}
+	return votes
+}
+
+// knownVoteBits returns the votes of a type at a height and round known by the
+// peer, or nil if they are not kept track of. It also returns the votes of the
+// recent rounds, unlike getVoteBitArray, which only returns the ones worth
+// sending.
+func (ps *PeerState) knownVoteBits(height int64, round int32, voteType cmtproto.SignedMsgType) *bits.BitArray {
+	if votes := ps.getVoteBitArray(height, round, voteType); votes != nil {
+		return votes
+	}
+	if height != ps.PRS.Height && height+1 != ps.PRS.Height {
+		return nil
+	}
+	return ps.voteBits[voteBitsKey{height, round, voteType}]
+}
+
+// pruneVoteBits forgets the votes known by the peer that are not kept track
+// of anymore: the ones below the previous height, the prevotes of the previous
+// height, and the votes of the rounds older than recentVoteRounds but for the
+// ones in use.
+func (ps *PeerState) pruneVoteBits() {
+	prs := &ps.PRS
+	for key := range ps.voteBits {
+		switch {
+		case key.height == prs.Height:
+			if key.round >= prs.Round-recentVoteRounds || key.round == prs.CatchupCommitRound ||
+				(key.round == prs.ProposalPOLRound && key.voteType == cmtproto.PrevoteType) {
+				continue
+			}
+		case key.height+1 == prs.Height && key.voteType == cmtproto.PrecommitType:
+			if key.round >= prs.LastCommitRound-recentVoteRounds {
+				continue
+			}
+		}
+		delete(ps.voteBits, key)
+	}
+}
+
+// ResyncVotes forgets which votes the peer has, for all of them to be gossiped
+// again. It is the way out for a peer that lost some of the votes sent to it.
+func (ps *PeerState) ResyncVotes() {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+
+	prs := &ps.PRS
+	for _, votes := range ps.voteBits {
+		votes.Update(bits.NewBitArray(votes.Size()))
+	}
+	// the ones of round -1 are not kept track of
+	for _, votes := range []*bits.BitArray{prs.Prevotes, prs.Precommits, prs.ProposalPOL,
+		prs.CatchupCommit, prs.LastCommit} {
+		votes.Update(bits.NewBitArray(votes.Size()))
+	}
+}
+
+// Resync forgets the votes and the proposal known by the peer, for them to be
+// gossiped again, see ResyncVotes.
+func (ps *PeerState) Resync() {
+	ps.ResyncVotes()
+
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+	ps.PRS.Proposal = false
+	ps.PRS.ProposalBlockPartSetHeader = types.PartSetHeader{}
+	ps.PRS.ProposalBlockParts = nil
+}
+
 // RecordVote increments internal votes related statistics for this peer.
 // It returns the total number of added votes.
 func (ps *PeerState) RecordVote() int {
@@ -1547,7 +1997,7 @@ func (ps *PeerState) setHasVote(height int64, round int32, voteType cmtproto.Sig
 		"type", voteType, "index", index)
 
 	// NOTE: some may be nil BitArrays -> no side effects.
-	psVotes := ps.getVoteBitArray(height, round, voteType)
+	psVotes := ps.knownVoteBits(height, round, voteType)
 	if psVotes != nil {
 		psVotes.SetIndex(int(index), true)
 	}
@@ -1581,9 +2031,10 @@ func (ps *PeerState) ApplyNewRoundStepMessage(msg *NewRoundStepMessage) {
 		ps.PRS.ProposalBlockParts = nil
 		ps.PRS.ProposalPOLRound = -1
 		ps.PRS.ProposalPOL = nil
-		// We'll update the BitArray capacity later.
-		ps.PRS.Prevotes = nil
-		ps.PRS.Precommits = nil
+		// The votes of a round the peer was at are kept, else we'll update
+		// the BitArray capacity later.
+		ps.PRS.Prevotes = ps.voteBits[voteBitsKey{msg.Height, msg.Round, cmtproto.PrevoteType}]
+		ps.PRS.Precommits = ps.voteBits[voteBitsKey{msg.Height, msg.Round, cmtproto.PrecommitType}]
 	}
 	if psHeight == msg.Height && psRound != msg.Round && msg.Round == psCatchupCommitRound {
 		// Peer caught up to CatchupCommitRound.
@@ -1598,13 +2049,16 @@ func (ps *PeerState) ApplyNewRoundStepMessage(msg *NewRoundStepMessage) {
 			ps.PRS.LastCommitRound = msg.LastCommitRound
 			ps.PRS.LastCommit = lastPrecommits
 		} else {
+			// the precommits of the round of the commit, e.g. the catchup
+			// commit, if known
 			ps.PRS.LastCommitRound = msg.LastCommitRound
-			ps.PRS.LastCommit = nil
+			ps.PRS.LastCommit = ps.voteBits[voteBitsKey{msg.Height - 1, msg.LastCommitRound, cmtproto.PrecommitType}]
 		}
 		// We'll update the BitArray capacity later.
 		ps.PRS.CatchupCommitRound = -1
 		ps.PRS.CatchupCommit = nil
 	}
+	ps.pruneVoteBits()
 }
 
 // ApplyNewValidBlockMessage updates the peer state for the new valid block.
@@ -1636,9 +2090,16 @@ func (ps *PeerState) ApplyProposalPOLMessage(msg *ProposalPOLMessage) {
 		return
 	}
 
-	// TODO: Merge onto existing ps.PRS.ProposalPOL?
-	// We might have sent some prevotes in the meantime.
+	// Merge onto the prevotes we know the peer has for the round, as we might
+	// have sent some in the meantime.
+	key := voteBitsKey{msg.Height, msg.ProposalPOLRound, cmtproto.PrevoteType}
+	if votes, ok := ps.voteBits[key]; ok {
+		votes.Update(votes.Or(msg.ProposalPOL))
+		ps.PRS.ProposalPOL = votes
+		return
+	}
 	ps.PRS.ProposalPOL = msg.ProposalPOL
+	ps.voteBits[key] = msg.ProposalPOL
 }
 
 // ApplyHasVoteMessage updates the peer state for the new vote.
@@ -1646,7 +2107,9 @@ func (ps *PeerState) ApplyHasVoteMessage(msg *HasVoteMessage) {
 	ps.mtx.Lock()
 	defer ps.mtx.Unlock()
 
-	if ps.PRS.Height != msg.Height {
+	// the precommits of the previous height are still added to the last commit
+	// of the peer
+	if ps.PRS.Height != msg.Height && ps.PRS.Height != msg.Height+1 {
 		return
 	}
 
@@ -1662,7 +2125,7 @@ func (ps *PeerState) ApplyVoteSetBitsMessage(msg *VoteSetBitsMessage, ourVotes *
 	ps.mtx.Lock()
 	defer ps.mtx.Unlock()
 
-	votes := ps.getVoteBitArray(msg.Height, msg.Round, msg.Type)
+	votes := ps.knownVoteBits(msg.Height, msg.Round, msg.Type)
 	if votes != nil {
 		if ourVotes == nil {
 			votes.Update(msg.Votes)
@@ -12,10 +12,12 @@ import (
 	"testing"
 	"time"
 
+	"github.com/go-kit/kit/metrics/generic"
 	"github.com/gogo/protobuf/proto"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
 
 	dbm "github.com/cometbft/cometbft-db"
 
@@ -42,6 +44,7 @@ import (
 	statemocks "github.com/tendermint/tendermint/state/mocks"
 	"github.com/tendermint/tendermint/store"
 	"github.com/tendermint/tendermint/types"
+	cmttime "github.com/tendermint/tendermint/types/time"
 )
 
 //----------------------------------------------
@@ -49,7 +52,7 @@ import (
 
 var defaultTestTime = time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
 
-func startConsensusNet(t *testing.T, css []*State, n int) (
+func startConsensusNet(t testing.TB, css []*State, n int, options ...ReactorOption) (
 	[]*Reactor,
 	[]types.Subscription,
 	[]*types.EventBus,
@@ -60,7 +63,7 @@ func startConsensusNet(t *testing.T, css []*State, n int) (
 	for i := 0; i < n; i++ {
 		/*logger, err := cmtflags.ParseLogLevel("consensus:info,*:error", logger, "info")
 		if err != nil {	t.Fatal(err)}*/
-		reactors[i] = NewReactor(css[i], true) // so we dont start the consensus states
+		reactors[i] = NewReactor(css[i], true, options...) // so we dont start the consensus states
 		reactors[i].SetLogger(css[i].Logger)
 
 		// eventBus is already started with the cs
@@ -370,6 +373,231 @@ func TestReactorRecordsVotesAndBlockParts(t *testing.T) {
 	assert.Equal(t, true, ps.BlockPartsSent() > 0, "number of votes sent should have increased")
 }
 
+// Ensure the votes are seldom sent more than once to a peer, on a network of
+// 10 validators.
+func TestReactorVoteGossipDedup(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+	const (
+		N       = 10
+		heights = 5
+	)
+	css, cleanup := randConsensusNet(N, "consensus_reactor_test", newMockTickerFunc(true), newCounter)
+	defer cleanup()
+	metrics := NopMetrics()
+	duplicates := generic.NewCounter("duplicate_votes_received")
+	metrics.DuplicateVotesReceived = duplicates
+	resyncs := generic.NewCounter("vote_resyncs")
+	metrics.VoteResyncs = resyncs
+	reactors, blocksSubs, eventBuses := startConsensusNet(t, css, N, ReactorMetrics(metrics),
+		ReactorVoteResyncInterval(time.Minute))
+	defer stopConsensusNet(log.TestingLogger(), reactors, eventBuses)
+
+	for h := 0; h < heights; h++ {
+		timeoutWaitGroup(t, N, func(j int) {
+			<-blocksSubs[j].Out()
+		}, css)
+	}
+
+	// the votes received from the peers that the nodes did not have
+	useful := 0
+	for _, r := range reactors {
+		for _, peer := range r.Switch.Peers().List() {
+			useful += peer.Get(types.PeerStateKey).(*PeerState).VotesSent()
+		}
+	}
+	// a vote is still received from several peers before they learn from each
+	// other that they have it
+	t.Logf("received %d useful votes and %v duplicates", useful, duplicates.Value())
+	require.Positive(t, useful)
+	assert.Positive(t, duplicates.Value())
+	// the nodes made progress, so the votes were never resynced
+	assert.Zero(t, resyncs.Value())
+}
+
+func TestPeerStateKeepsVotesOfRecentRounds(t *testing.T) {
+	const numValidators = 4
+	newRoundStep := func(ps *PeerState, height int64, round, lastCommitRound int32) {
+		ps.ApplyNewRoundStepMessage(&NewRoundStepMessage{
+			Height:          height,
+			Round:           round,
+			Step:            cstypes.RoundStepPropose,
+			LastCommitRound: lastCommitRound,
+		})
+		ps.EnsureVoteBitArrays(height, numValidators)
+		ps.EnsureVoteBitArrays(height-1, numValidators)
+	}
+	hasVote := func(ps *PeerState, height int64, round int32, voteType cmtproto.SignedMsgType, index int32) bool {
+		ps.mtx.Lock()
+		defer ps.mtx.Unlock()
+		return ps.knownVoteBits(height, round, voteType).GetIndex(int(index))
+	}
+
+	t.Run("catchup commit of a previous round", func(t *testing.T) {
+		ps := NewPeerState(nil)
+		newRoundStep(ps, 1, 0, -1)
+		ps.ApplyHasVoteMessage(&HasVoteMessage{Height: 1, Round: 0, Type: cmtproto.PrecommitType, Index: 2})
+		newRoundStep(ps, 1, 1, -1)
+
+		ps.mtx.Lock()
+		ps.ensureCatchupCommitRound(1, 0, numValidators)
+		ps.mtx.Unlock()
+		assert.True(t, ps.GetRoundState().CatchupCommit.GetIndex(2))
+	})
+
+	t.Run("last commit of the round of the catchup commit", func(t *testing.T) {
+		ps := NewPeerState(nil)
+		newRoundStep(ps, 1, 0, -1)
+		ps.mtx.Lock()
+		ps.ensureCatchupCommitRound(1, 1, numValidators)
+		ps.mtx.Unlock()
+		ps.ApplyHasVoteMessage(&HasVoteMessage{Height: 1, Round: 1, Type: cmtproto.PrecommitType, Index: 3})
+		newRoundStep(ps, 2, 0, 1)
+
+		assert.True(t, ps.GetRoundState().LastCommit.GetIndex(3))
+		// the precommits of the previous height are still learned of
+		ps.ApplyHasVoteMessage(&HasVoteMessage{Height: 1, Round: 1, Type: cmtproto.PrecommitType, Index: 0})
+		assert.True(t, ps.GetRoundState().LastCommit.GetIndex(0))
+	})
+
+	t.Run("votes of a previous round", func(t *testing.T) {
+		ps := NewPeerState(nil)
+		newRoundStep(ps, 1, 0, -1)
+		newRoundStep(ps, 1, 2, -1)
+		votes := bits.NewBitArray(numValidators)
+		votes.SetIndex(1, true)
+		ps.ApplyVoteSetBitsMessage(&VoteSetBitsMessage{
+			Height: 1, Round: 0, Type: cmtproto.PrevoteType, Votes: votes,
+		}, nil)
+		assert.True(t, hasVote(ps, 1, 0, cmtproto.PrevoteType, 1))
+
+		// the proof of lock of the proposal is merged onto them
+		ps.SetHasProposal(&types.Proposal{Height: 1, Round: 2, POLRound: 0})
+		pol := bits.NewBitArray(numValidators)
+		pol.SetIndex(3, true)
+		ps.ApplyProposalPOLMessage(&ProposalPOLMessage{Height: 1, ProposalPOLRound: 0, ProposalPOL: pol})
+		prs := ps.GetRoundState()
+		assert.True(t, prs.ProposalPOL.GetIndex(1))
+		assert.True(t, prs.ProposalPOL.GetIndex(3))
+
+		// the older rounds are forgotten
+		newRoundStep(ps, 1, 4, -1)
+		assert.False(t, hasVote(ps, 1, 0, cmtproto.PrevoteType, 1))
+		ps.ApplyHasVoteMessage(&HasVoteMessage{Height: 1, Round: 2, Type: cmtproto.PrevoteType, Index: 0})
+		newRoundStep(ps, 1, 6, -1)
+		assert.False(t, hasVote(ps, 1, 2, cmtproto.PrevoteType, 0))
+		newRoundStep(ps, 3, 0, 0)
+		ps.mtx.Lock()
+		assert.Len(t, ps.voteBits, 3)
+		ps.mtx.Unlock()
+	})
+
+	t.Run("resync", func(t *testing.T) {
+		ps := NewPeerState(nil)
+		newRoundStep(ps, 1, 0, -1)
+		ps.ApplyHasVoteMessage(&HasVoteMessage{Height: 1, Round: 0, Type: cmtproto.PrevoteType, Index: 0})
+		newRoundStep(ps, 1, 1, -1)
+		ps.ApplyHasVoteMessage(&HasVoteMessage{Height: 1, Round: 1, Type: cmtproto.PrecommitType, Index: 1})
+
+		ps.ResyncVotes()
+		assert.False(t, hasVote(ps, 1, 0, cmtproto.PrevoteType, 0))
+		assert.False(t, hasVote(ps, 1, 1, cmtproto.PrecommitType, 1))
+		assert.False(t, ps.GetRoundState().Precommits.GetIndex(1))
+	})
+}
+
+// voteRecorder is a reactor opening the consensus channels, which records the
+// votes it receives.
+type voteRecorder struct {
+	p2p.BaseReactor
+	channels []*p2p.ChannelDescriptor
+	votes    chan *cmtcons.Vote
+}
+
+func (r *voteRecorder) GetChannels() []*p2p.ChannelDescriptor { return r.channels }
+
+func (r *voteRecorder) ReceiveEnvelope(e p2p.Envelope) {
+	if vote, ok := e.Message.(*cmtcons.Vote); ok {
+		r.votes <- vote
+	}
+}
+
+func (r *voteRecorder) Receive(chID byte, peer p2p.Peer, msgBytes []byte) {}
+
+// Ensure the votes of a later round make it to a peer ahead of the backlog of
+// the previous round, when the sends are throttled by a constrained send rate.
+func TestReactorSendQueueEvictsStaleRounds(t *testing.T) {
+	css, cleanup := randConsensusNet(1, "consensus_reactor_test", newMockTickerFunc(true), newCounter)
+	defer cleanup()
+	channels := NewReactor(css[0], true).GetChannels()
+
+	config := cfg.TestP2PConfig()
+	config.SendRate = 5000
+	recorders := make([]*voteRecorder, 2)
+	switches := p2p.MakeConnectedSwitches(config, 2, func(i int, sw *p2p.Switch) *p2p.Switch {
+		recorders[i] = &voteRecorder{channels: channels, votes: make(chan *cmtcons.Vote, 1000)}
+		recorders[i].BaseReactor = *p2p.NewBaseReactor("voteRecorder", recorders[i])
+		sw.AddReactor("CONSENSUS", recorders[i])
+		return sw
+	}, p2p.Connect2Switches)
+	defer func() {
+		for _, sw := range switches {
+			if err := sw.Stop(); err != nil {
+				t.Error(err)
+			}
+		}
+	}()
+
+	peer := switches[0].Peers().List()[0]
+	prs := &cstypes.PeerRoundState{Height: 1, Round: 0}
+	send := func(round int32, index int32) bool {
+		return p2p.TrySendEnvelopeTaggedShim(peer, p2p.Envelope{
+			ChannelID: VoteChannel,
+			Message: &cmtcons.Vote{Vote: &cmtproto.Vote{
+				Type:             cmtproto.PrevoteType,
+				Height:           1,
+				Round:            round,
+				ValidatorIndex:   index,
+				ValidatorAddress: tmhash.SumTruncated([]byte{byte(index)}),
+			}},
+		}, relevanceTag(prs, 1, round), log.TestingLogger())
+	}
+
+	// saturate the queue with the votes of round 0
+	sentStale := 0
+	for i := int32(0); i < 1000; i++ {
+		if send(0, i) {
+			sentStale++
+		}
+	}
+	require.Less(t, sentStale, 1000, "the send queue never filled up")
+
+	// the peer moves to round 1, whose votes take the place of round 0's
+	prs.Round = 1
+	const fresh = 50
+	for i := int32(0); i < fresh; i++ {
+		require.True(t, send(1, i), "vote %d of round 1 was dropped", i)
+	}
+
+	receivedStale, receivedFresh := 0, 0
+	timeout := time.After(20 * time.Second)
+	for receivedFresh < fresh {
+		select {
+		case vote := <-recorders[1].votes:
+			if vote.Vote.Round == 1 {
+				receivedFresh++
+			} else {
+				assert.Zero(t, receivedFresh, "a vote of round 0 arrived after the votes of round 1")
+				receivedStale++
+			}
+		case <-timeout:
+			t.Fatalf("received %d of the %d votes of round 1", receivedFresh, fresh)
+		}
+	}
+	assert.Less(t, receivedStale, sentStale, "no vote of round 0 was evicted")
+}
+
 //-------------------------------------------------------------
 // ensure we can make blocks despite cycling a validator set
 
@@ -588,6 +816,85 @@ func TestReactorWithTimeoutCommit(t *testing.T) {
 	}, css)
 }
 
+// Ensure the validators learn the validators run by their peers from their
+// announcements, and interoperate with a validator without the feature.
+func TestReactorValidatorAnnouncements(t *testing.T) {
+	N := 4
+	css, cleanup := randConsensusNet(N, "consensus_reactor_test", newMockTickerFunc(true), newCounter,
+		func(c *cfg.Config) { c.Consensus.ValidatorAnnouncements = true })
+	defer cleanup()
+	// the last validator does not announce, nor open the channel
+	css[N-1].config.ValidatorAnnouncements = false
+
+	reactors, blocksSubs, eventBuses := startConsensusNet(t, css, N)
+	defer stopConsensusNet(log.TestingLogger(), reactors, eventBuses)
+
+	for i, r := range reactors {
+		for j, peer := range reactors {
+			if i == j {
+				continue
+			}
+			id := peer.Switch.NodeInfo().ID()
+			if i == N-1 || j == N-1 {
+				_, ok := r.validatorPeers.validator(id)
+				assert.False(t, ok, "%d maps %d", i, j)
+				continue
+			}
+			address := css[j].privValidatorPubKey.Address()
+			assert.Eventually(t, func() bool {
+				validator, ok := r.validatorPeers.validator(id)
+				return ok && string(validator) == string(address)
+			}, 5*time.Second, 10*time.Millisecond, "%d does not map %d", i, j)
+		}
+	}
+
+	// wait till everyone makes the first new block
+	timeoutWaitGroup(t, N, func(j int) {
+		<-blocksSubs[j].Out()
+	}, css)
+}
+
+// Ensure the votes are completed faster when gossiped first to the validators
+// which have not precommitted, with the gossip throttled by a long sleep, as
+// over links of constrained bandwidth.
+func TestReactorValidatorAnnouncementsSpeedUpVotes(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+	const (
+		N       = 4
+		heights = 3
+	)
+	commitTime := func(t *testing.T, announcements bool) time.Duration {
+		css, cleanup := randConsensusNet(N, "consensus_reactor_test", newMockTickerFunc(true), newCounter,
+			func(c *cfg.Config) {
+				c.Consensus.PeerGossipSleepDuration = 200 * time.Millisecond
+				c.Consensus.ValidatorAnnouncements = announcements
+			})
+		defer cleanup()
+		reactors, blocksSubs, eventBuses := startConsensusNet(t, css, N)
+		defer stopConsensusNet(log.TestingLogger(), reactors, eventBuses)
+
+		// the announcements are exchanged when the peers connect, before the
+		// first block
+		timeoutWaitGroup(t, N, func(j int) {
+			<-blocksSubs[j].Out()
+		}, css)
+		start := time.Now()
+		for h := 0; h < heights; h++ {
+			timeoutWaitGroup(t, N, func(j int) {
+				<-blocksSubs[j].Out()
+			}, css)
+		}
+		return time.Since(start)
+	}
+
+	untargeted := commitTime(t, false)
+	targeted := commitTime(t, true)
+	t.Logf("committed %d heights in %v untargeted, %v targeted", heights, untargeted, targeted)
+	assert.Less(t, targeted, untargeted)
+}
+
 func waitForAndValidateBlock(
 	t *testing.T,
 	n int,
@@ -1073,3 +1380,61 @@ func TestMarshalJSONPeerState(t *testing.T) {
 			"block_parts":"0"}
 		}`, string(data))
 }
+
+func TestReactorStrictDecoding(t *testing.T) {
+	N := 1
+	css, cleanup := randConsensusNet(N, "consensus_reactor_test", newMockTickerFunc(true), newCounter)
+	defer cleanup()
+	reactors, _, eventBuses := startConsensusNet(t, css, N)
+	defer stopConsensusNet(log.TestingLogger(), reactors, eventBuses)
+
+	reactor := reactors[0]
+	hash := tmhash.Sum([]byte("block"))
+	vote := &cmtcons.Vote{Vote: &cmtproto.Vote{
+		Type:             cmtproto.PrevoteType,
+		Height:           1,
+		BlockID:          cmtproto.BlockID{Hash: hash, PartSetHeader: cmtproto.PartSetHeader{Total: 1, Hash: hash}},
+		Timestamp:        cmttime.Now(),
+		ValidatorAddress: make([]byte, 20),
+		Signature:        make([]byte, 64),
+	}}
+	step := &cmtcons.NewRoundStep{Height: 1, Step: 1, LastCommitRound: -1}
+
+	newPeer := func() p2p.Peer {
+		peer := p2pmock.NewPeer(nil)
+		reactor.InitPeer(peer)
+		return peer
+	}
+	// encode returns the encoding of msg as sent by a peer, with an unknown
+	// field appended if unknownField is set
+	encode := func(msg p2p.Wrapper, unknownField bool) []byte {
+		bz, err := proto.Marshal(msg.Wrap())
+		require.NoError(t, err)
+		if unknownField {
+			bz = protowire.AppendTag(bz, 1000, protowire.VarintType)
+			bz = protowire.AppendVarint(bz, 7)
+		}
+		return bz
+	}
+
+	// tolerant by default
+	peer := newPeer()
+	reactor.ReceiveEnvelope(p2p.Envelope{ChannelID: VoteChannel, Src: peer, Message: vote, Bytes: encode(vote, true)})
+	assert.True(t, peer.IsRunning())
+
+	css[0].mtx.Lock()
+	css[0].state.ConsensusParams.Block.StrictDecoding = true
+	css[0].strictDecoding.Store(true)
+	css[0].mtx.Unlock()
+
+	// non consensus critical messages are still decoded tolerantly
+	peer = newPeer()
+	reactor.ReceiveEnvelope(p2p.Envelope{ChannelID: StateChannel, Src: peer, Message: step, Bytes: encode(step, true)})
+	assert.True(t, peer.IsRunning())
+
+	reactor.ReceiveEnvelope(p2p.Envelope{ChannelID: VoteChannel, Src: peer, Message: vote, Bytes: encode(vote, false)})
+	assert.True(t, peer.IsRunning())
+
+	reactor.ReceiveEnvelope(p2p.Envelope{ChannelID: VoteChannel, Src: peer, Message: vote, Bytes: encode(vote, true)})
+	assert.False(t, peer.IsRunning())
+}
@@ -246,8 +246,9 @@ func (h *Handshaker) Handshake(proxyApp proxy.AppConns) (string, error) {
 // HandshakeWithContext is cancellable version of Handshake
 func (h *Handshaker) HandshakeWithContext(ctx context.Context, proxyApp proxy.AppConns) (string, error) {
 
-	// Handshake is done via ABCI Info on the query conn.
-	res, err := proxyApp.Query().InfoSync(proxy.RequestInfo)
+	// Handshake is done via ABCI Info on the consensus conn, since the query
+	// conn may be served by a distinct app.
+	res, err := proxyApp.Consensus().InfoSync(proxy.RequestInfo)
 	if err != nil {
 		return "", fmt.Errorf("error calling Info: %v", err)
 	}
@@ -362,6 +363,15 @@ func (h *Handshaker) ReplayBlocksWithContext(
 				state.ConsensusParams = types.UpdateConsensusParams(state.ConsensusParams, res.ConsensusParams)
 				state.Version.Consensus.App = state.ConsensusParams.Version.AppVersion
 			}
+			// If the app declared a minimum evidence age, refuse to start a chain
+			// letting evidence expire before it, and check the updates against it.
+			if res.EvidenceConstraints != nil {
+				err := types.ValidateEvidenceConstraints(state.ConsensusParams.Evidence, res.EvidenceConstraints)
+				if err != nil {
+					return nil, fmt.Errorf("genesis consensus params violate the evidence constraints of the app: %w", err)
+				}
+				state.EvidenceConstraints = res.EvidenceConstraints
+			}
 			// We update the last results hash with the empty hash, to conform with RFC-6962.
 			state.LastResultsHash = merkle.HashFromByteSlices(nil)
 			if err := h.stateStore.Save(state); err != nil {
@@ -1222,12 +1222,19 @@ func (bs *mockBlockStore) LoadBlockMeta(height int64) *types.BlockMeta {
 	}
 }
 func (bs *mockBlockStore) LoadBlockPart(height int64, index int) *types.Part { return nil }
+func (bs *mockBlockStore) HasBlockData(height int64) bool                    { return true }
 func (bs *mockBlockStore) SaveBlock(block *types.Block, blockParts *types.PartSet, seenCommit *types.Commit) {
 }
 func (bs *mockBlockStore) SaveTxInfo(block *types.Block, txResponseCode []uint32) error {
 	return nil
 }
 func (bs *mockBlockStore) LoadTxInfo(hash []byte) *cmtstore.TxInfo { return &cmtstore.TxInfo{} }
+func (bs *mockBlockStore) RetainHeight() int64                     { return 0 }
+func (bs *mockBlockStore) SaveRetainHeight(height int64)           {}
+func (bs *mockBlockStore) SaveRowProof(height int64, rowProof types.RowProof) error {
+	return nil
+}
+func (bs *mockBlockStore) LoadRowProof(height int64) (*types.RowProof, error) { return nil, nil }
 
 func (bs *mockBlockStore) LoadBlockCommit(height int64) *types.Commit {
 	return bs.commits[height-1]
@@ -1297,17 +1304,79 @@ func TestHandshakeUpdatesValidators(t *testing.T) {
 	assert.Equal(t, newValAddr, expectValAddr)
 }
 
+func TestHandshakeEvidenceConstraints(t *testing.T) {
+	genParams := types.DefaultConsensusParams().Evidence
+	testCases := map[string]struct {
+		constraints *abci.EvidenceConstraints
+		valid       bool
+	}{
+		"none": {nil, true},
+		"met": {&abci.EvidenceConstraints{
+			MinMaxAgeNumBlocks: genParams.MaxAgeNumBlocks,
+			MinMaxAgeDuration:  genParams.MaxAgeDuration,
+		}, true},
+		"num blocks violated": {&abci.EvidenceConstraints{
+			MinMaxAgeNumBlocks: genParams.MaxAgeNumBlocks + 1,
+		}, false},
+		"duration violated": {&abci.EvidenceConstraints{
+			MinMaxAgeDuration: genParams.MaxAgeDuration + time.Second,
+		}, false},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			app := &initChainApp{evidenceConstraints: tc.constraints}
+			clientCreator := proxy.NewLocalClientCreator(app)
+
+			config := ResetConfig("handshake_test_")
+			defer os.RemoveAll(config.RootDir)
+			privVal := privval.LoadFilePV(config.PrivValidatorKeyFile(), config.PrivValidatorStateFile())
+			pubKey, err := privVal.GetPubKey()
+			require.NoError(t, err)
+			stateDB, state, store := stateAndStore(config, pubKey, 0x0)
+			stateStore := sm.NewStore(stateDB, sm.StoreOptions{
+				DiscardABCIResponses: false,
+			})
+
+			genDoc, _ := sm.MakeGenesisDocFromFile(config.GenesisFile())
+			handshaker := NewHandshaker(stateStore, state, store, genDoc)
+			proxyApp := proxy.NewAppConns(clientCreator)
+			require.NoError(t, proxyApp.Start())
+			t.Cleanup(func() {
+				if err := proxyApp.Stop(); err != nil {
+					t.Error(err)
+				}
+			})
+
+			_, err = handshaker.Handshake(proxyApp)
+			savedState, loadErr := stateStore.Load()
+			require.NoError(t, loadErr)
+			constraints := savedState.EvidenceConstraints
+			if !tc.valid {
+				// the node refuses to start the chain
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "evidence constraints")
+				assert.Nil(t, constraints)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.constraints, constraints)
+		})
+	}
+}
+
 const customVersion = "v1.0.0"
 
-// returns the vals on InitChain
+// returns the vals and evidence constraints on InitChain
 type initChainApp struct {
 	abci.BaseApplication
-	vals []abci.ValidatorUpdate
+	vals                []abci.ValidatorUpdate
+	evidenceConstraints *abci.EvidenceConstraints
 }
 
 func (ica *initChainApp) InitChain(req abci.RequestInitChain) abci.ResponseInitChain {
 	return abci.ResponseInitChain{
-		Validators: ica.vals,
+		Validators:          ica.vals,
+		EvidenceConstraints: ica.evidenceConstraints,
 	}
 }
 
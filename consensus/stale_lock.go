@@ -0,0 +1,88 @@
+package consensus
+
+import (
+	cstypes "github.com/tendermint/tendermint/consensus/types"
+	"github.com/tendermint/tendermint/libs/bytes"
+)
+
+// eventStaleLock is fired on the event switch of the state, with a *staleLock,
+// when consensus is found stuck on a stale lock. It is not published on the
+// event bus.
+const eventStaleLock = "StaleLock"
+
+// staleLock describes consensus stuck on a lock: the node kept its lock on a
+// block while, in the later rounds, +2/3 of the validators prevoted without a
+// polka for the block. It happens when the node misses the proposals and the
+// votes the others moved on with, e.g. because they are not gossiped to it, as
+// it would unlock on a polka of a later round otherwise.
+type staleLock struct {
+	Height      int64
+	Round       int32
+	LockedRound int32
+	LockedBlock bytes.HexBytes
+	// Rounds is the number of rounds after LockedRound in which +2/3 of the
+	// validators prevoted without a polka for the locked block.
+	Rounds int
+	// RoundState is the round state of the node, which is locked while the
+	// listeners are called.
+	RoundState *cstypes.RoundState
+}
+
+// staleLockRounds returns the number of rounds after the locked round, up to
+// round excluded, in which +2/3 of the validators prevoted without a polka for
+// the locked block.
+func staleLockRounds(rs *cstypes.RoundState, round int32) int {
+	if rs.LockedBlock == nil {
+		return 0
+	}
+	n := 0
+	for r := rs.LockedRound + 1; r < round; r++ {
+		prevotes := rs.Votes.Prevotes(r)
+		if !prevotes.HasTwoThirdsAny() {
+			continue
+		}
+		if blockID, ok := prevotes.TwoThirdsMajority(); ok && rs.LockedBlock.HashesTo(blockID.Hash) {
+			continue
+		}
+		n++
+	}
+	return n
+}
+
+// checkStaleLock reports consensus stuck on a stale lock as of the given new
+// round, once every StaleLockRounds rounds at most: the round state is logged,
+// the stale_locks metric is incremented and eventStaleLock is fired for the
+// reactor to log the states of the peers and resync them if configured. The
+// locking rules are unchanged.
+func (cs *State) checkStaleLock(height int64, round int32) {
+	threshold := cs.config.StaleLockRounds
+	if threshold == 0 || cs.LockedBlock == nil {
+		return
+	}
+	if cs.staleLockReportRound >= 0 && round-cs.staleLockReportRound < int32(threshold) {
+		return
+	}
+	n := staleLockRounds(&cs.RoundState, round)
+	if n < threshold {
+		return
+	}
+	cs.staleLockReportRound = round
+
+	cs.Logger.Error("consensus is stuck on a stale lock: the validators moved on from the locked block",
+		"height", height,
+		"round", round,
+		"locked_round", cs.LockedRound,
+		"locked_block", cs.LockedBlock.Hash(),
+		"rounds", n,
+		"round_state", cs.RoundState.StringIndented(""),
+	)
+	cs.metrics.StaleLocks.Add(1)
+	cs.evsw.FireEvent(eventStaleLock, &staleLock{
+		Height:      height,
+		Round:       round,
+		LockedRound: cs.LockedRound,
+		LockedBlock: cs.LockedBlock.Hash(),
+		Rounds:      n,
+		RoundState:  &cs.RoundState,
+	})
+}
@@ -0,0 +1,169 @@
+package consensus
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-kit/kit/metrics/generic"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cmtevents "github.com/tendermint/tendermint/libs/events"
+	"github.com/tendermint/tendermint/libs/log"
+	cmtproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+// Reproduce a validator locked on a block while the others, whose proposals
+// it does not get, prevote nil round after round.
+func TestStateReportsStaleLock(t *testing.T) {
+	cs1, vss := randState(4)
+	vs2, vs3, vs4 := vss[1], vss[2], vss[3]
+	height, round := cs1.Height, cs1.Round
+	cs1.config.StaleLockRounds = 2
+	staleLocks := generic.NewCounter("stale_locks")
+	cs1.metrics.StaleLocks = staleLocks
+
+	reports := make(chan *staleLock, 10)
+	require.NoError(t, cs1.evsw.AddListenerForEvent("test", eventStaleLock, func(data cmtevents.EventData) {
+		reports <- data.(*staleLock)
+	}))
+
+	voteCh := subscribeUnBuffered(cs1.eventBus, types.EventQueryVote)
+	proposalCh := subscribe(cs1.eventBus, types.EventQueryCompleteProposal)
+	newRoundCh := subscribe(cs1.eventBus, types.EventQueryNewRound)
+
+	// addVotes adds the votes of the validators and waits for them
+	addVotes := func(voteType cmtproto.SignedMsgType, round int32, hash []byte, header types.PartSetHeader,
+		vss ...*validatorStub,
+	) {
+		signAddVotes(cs1, voteType, hash, header, vss...)
+		for range vss {
+			ensureVote(voteCh, height, round, voteType)
+		}
+	}
+
+	cs1.enterNewRound(height, round)
+	cs1.startRoutines(0)
+
+	// round 0: the node locks on its proposal, the others precommit nil
+	ensureNewRound(newRoundCh, height, round)
+	ensureNewProposal(proposalCh, height, round)
+	rs := cs1.GetRoundState()
+	lockedHash, partSetHeader := rs.ProposalBlock.Hash(), rs.ProposalBlockParts.Header()
+	ensurePrevote(voteCh, height, round)
+	addVotes(cmtproto.PrevoteType, round, lockedHash, partSetHeader, vs2, vs3)
+	ensurePrecommit(voteCh, height, round)
+	validatePrecommit(t, cs1, round, round, vss[0], lockedHash, lockedHash)
+	addVotes(cmtproto.PrecommitType, round, nil, types.PartSetHeader{}, vs2, vs3, vs4)
+
+	// the next rounds: the node prevotes its locked block, the others nil
+	for r := int32(1); r <= 4; r++ {
+		incrementRound(vs2, vs3, vs4)
+		ensureNewRound(newRoundCh, height, r)
+		if r == 3 {
+			select {
+			case sl := <-reports:
+				assert.Equal(t, height, sl.Height)
+				assert.EqualValues(t, 3, sl.Round)
+				assert.EqualValues(t, 0, sl.LockedRound)
+				assert.EqualValues(t, lockedHash, sl.LockedBlock)
+				assert.Equal(t, 2, sl.Rounds)
+			case <-time.After(time.Second):
+				t.Fatal("stale lock not reported")
+			}
+		}
+		ensurePrevote(voteCh, height, r)
+		validatePrevote(t, cs1, r, vss[0], lockedHash)
+		addVotes(cmtproto.PrevoteType, r, nil, types.PartSetHeader{}, vs2, vs3)
+		ensurePrecommit(voteCh, height, r)
+		// the locking rules are unchanged
+		validatePrecommit(t, cs1, r, 0, vss[0], nil, lockedHash)
+		addVotes(cmtproto.PrecommitType, r, nil, types.PartSetHeader{}, vs2, vs3)
+	}
+	ensureNewRound(newRoundCh, height, 5)
+
+	// it is reported again StaleLockRounds rounds later
+	select {
+	case sl := <-reports:
+		assert.EqualValues(t, 5, sl.Round)
+		assert.Equal(t, 4, sl.Rounds)
+	case <-time.After(time.Second):
+		t.Fatal("stale lock not reported again")
+	}
+	assert.Empty(t, reports)
+	assert.Equal(t, 2.0, staleLocks.Value())
+}
+
+func TestStaleLockRounds(t *testing.T) {
+	cs1, vss := randState(4)
+	vs2, vs3, vs4 := vss[1], vss[2], vss[3]
+	height := cs1.Height
+	_, block := decideProposal(cs1, vss[0], height, 0)
+	blockParts := block.MakePartSet(types.BlockPartSizeBytes)
+	otherHash := make([]byte, len(block.Hash()))
+	copy(otherHash, block.Hash())
+	otherHash[0]++
+
+	cs1.Votes.SetRound(5)
+	cs1.LockedRound = 0
+	cs1.LockedBlock = block
+	assert.Zero(t, staleLockRounds(&cs1.RoundState, 5))
+
+	prevote := func(round int32, hash []byte, vss ...*validatorStub) {
+		for _, vs := range vss {
+			vs.Height, vs.Round = height, round
+			vote := signVote(vs, cmtproto.PrevoteType, hash, blockParts.Header())
+			added, err := cs1.Votes.AddVote(vote, "")
+			require.NoError(t, err)
+			require.True(t, added)
+		}
+	}
+	// round 1: nil and another block, without a polka
+	prevote(1, nil, vs2, vs3)
+	prevote(1, otherHash, vs4)
+	// round 2: a polka for the locked block
+	prevote(2, block.Hash(), vs2, vs3, vs4)
+	// round 3: too few prevotes
+	prevote(3, nil, vs2, vs3)
+	// round 4: a polka for nil
+	prevote(4, nil, vs2, vs3, vs4)
+
+	assert.Equal(t, 2, staleLockRounds(&cs1.RoundState, 5))
+	assert.Equal(t, 1, staleLockRounds(&cs1.RoundState, 4))
+
+	// nor without a lock
+	cs1.LockedRound, cs1.LockedBlock = -1, nil
+	assert.Zero(t, staleLockRounds(&cs1.RoundState, 5))
+}
+
+func TestReactorResyncsPeersOnStaleLock(t *testing.T) {
+	const N = 2
+	css, cleanup := randConsensusNet(N, "consensus_reactor_test", newMockTickerFunc(true), newCounter)
+	defer cleanup()
+	metrics := NopMetrics()
+	resyncs := generic.NewCounter("vote_resyncs")
+	metrics.VoteResyncs = resyncs
+	reactors, blocksSubs, eventBuses := startConsensusNet(t, css, N, ReactorMetrics(metrics))
+	defer stopConsensusNet(log.TestingLogger(), reactors, eventBuses)
+	<-blocksSubs[0].Out()
+
+	fire := func() {
+		cs := css[0]
+		cs.mtx.Lock()
+		defer cs.mtx.Unlock()
+		cs.evsw.FireEvent(eventStaleLock, &staleLock{
+			Height:     cs.Height,
+			Round:      cs.Round,
+			RoundState: &cs.RoundState,
+		})
+	}
+
+	// the states of the peers are only logged by default
+	fire()
+	assert.Zero(t, resyncs.Value())
+
+	css[0].config.StaleLockResync = true
+	fire()
+	assert.Equal(t, 1.0, resyncs.Value())
+}
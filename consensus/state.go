@@ -8,6 +8,7 @@ import (
 	"os"
 	"runtime/debug"
 	"sort"
+	"sync/atomic"
 	"time"
 
 	"github.com/gogo/protobuf/proto"
@@ -73,6 +74,12 @@ type evidencePool interface {
 	ReportConflictingVotes(voteA, voteB *types.Vote)
 }
 
+// pendingEvidenceCounter is implemented by the evidence pools reporting the
+// number of evidence waiting to be committed.
+type pendingEvidenceCounter interface {
+	Size() uint32
+}
+
 // State handles execution of the consensus algorithm.
 // It processes votes and proposals, and upon reaching agreement,
 // commits blocks to the chain and executes them against the application.
@@ -101,6 +108,9 @@ type State struct {
 	mtx cmtsync.RWMutex
 	cstypes.RoundState
 	state sm.State // State until height-1.
+	// strictDecoding mirrors the StrictDecoding consensus param of state, so
+	// that the reactor reads it without taking mtx for every message.
+	strictDecoding atomic.Bool
 	// privValidator pubkey, memoized for the duration of one block
 	// to avoid extra requests to HSM
 	privValidatorPubKey crypto.PubKey
@@ -111,6 +121,11 @@ type State struct {
 	internalMsgQueue chan msgInfo
 	timeoutTicker    TimeoutTicker
 
+	// whether participation is held, see HoldParticipation. It has its own
+	// mutex so that it can be set while a step holds mtx.
+	holdMtx cmtsync.Mutex
+	held    bool
+
 	// information about about added votes and block parts are written on this channel
 	// so statistics can be computed by reactor
 	statsMsgQueue chan msgInfo
@@ -140,6 +155,13 @@ type State struct {
 	// state only emits EventNewRoundStep and EventVote
 	evsw cmtevents.EventSwitch
 
+	// the round consensus was last reported stuck on a stale lock at in the
+	// current height, or -1, see checkStaleLock
+	staleLockReportRound int32
+
+	// the proposers of the rounds of the current height
+	proposerRecord proposerRecord
+
 	// for reporting metrics
 	metrics *Metrics
 
@@ -175,6 +197,8 @@ func NewState(
 		evsw:             cmtevents.NewEventSwitch(),
 		metrics:          NopMetrics(),
 		traceClient:      trace.NoOpTracer(),
+
+		staleLockReportRound: -1,
 	}
 
 	// set function defaults (may be overwritten before calling Start)
@@ -264,6 +288,19 @@ func (cs *State) GetRoundStateSimpleJSON() ([]byte, error) {
 	return cmtjson.Marshal(cs.RoundState.RoundStateSimple())
 }
 
+// WithRoundStateSimpleJSON calls fn with a json of RoundStateSimple, holding
+// the consensus lock until fn returns so that no block is committed meanwhile.
+// fn must not call back into the consensus state.
+func (cs *State) WithRoundStateSimpleJSON(fn func(roundState []byte) error) error {
+	cs.mtx.RLock()
+	defer cs.mtx.RUnlock()
+	bz, err := cmtjson.Marshal(cs.RoundState.RoundStateSimple())
+	if err != nil {
+		return err
+	}
+	return fn(bz)
+}
+
 // GetValidators returns a copy of the current validators.
 func (cs *State) GetValidators() (int64, []*types.Validator) {
 	cs.mtx.RLock()
@@ -684,6 +721,8 @@ func (cs *State) updateToState(state sm.State) {
 	cs.LockedRound = -1
 	cs.LockedBlock = nil
 	cs.LockedBlockParts = nil
+	cs.staleLockReportRound = -1
+	cs.proposerRecord.reset()
 	cs.TwoThirdPrevoteRound = -1
 	cs.TwoThirdPrevoteBlock = nil
 	cs.TwoThirdPrevoteBlockParts = nil
@@ -693,6 +732,7 @@ func (cs *State) updateToState(state sm.State) {
 	cs.TriggeredTimeoutPrecommit = false
 
 	cs.state = state
+	cs.strictDecoding.Store(state.ConsensusParams.Block.StrictDecoding)
 
 	// Finally, broadcast RoundState
 	cs.newStep()
@@ -973,7 +1013,7 @@ func (cs *State) handleTxsAvailable() {
 
 	switch cs.Step {
 	case cstypes.RoundStepNewHeight: // timeoutCommit phase
-		if cs.needProofBlock(cs.Height) {
+		if cs.needBlock(cs.Height) {
 			// enterPropose will be called by enterNewRound
 			return
 		}
@@ -1016,7 +1056,8 @@ func (cs *State) enterNewRound(height int64, round int32) {
 
 	prevHeight, prevRound, prevStep := cs.Height, cs.Round, cs.Step
 
-	// increment validators if necessary
+	// increment validators if necessary, on a copy as the round states handed
+	// out by GetRoundState hold the current one
 	validators := cs.Validators
 	if cs.Round < round {
 		validators = validators.Copy()
@@ -1029,6 +1070,7 @@ func (cs *State) enterNewRound(height int64, round int32) {
 	cs.updateRoundStep(round, cstypes.RoundStepNewRound)
 	cs.Validators = validators
 	propAddress := validators.GetProposer().PubKey.Address()
+	cs.proposerRecord.enterRound(round, propAddress)
 	if round == 0 {
 		// We've already reset these upon new height,
 		// and meanwhile we might have received a proposal
@@ -1048,21 +1090,59 @@ func (cs *State) enterNewRound(height int64, round int32) {
 	cs.Votes.SetRound(cmtmath.SafeAddInt32(round, 1)) // also track next round (round+1) to allow round-skipping
 	cs.TriggeredTimeoutPrecommit = false
 
+	cs.checkStaleLock(height, round)
+
 	if err := cs.eventBus.PublishEventNewRound(cs.NewRoundEvent()); err != nil {
 		cs.Logger.Error("failed publishing new round", "err", err)
 	}
 	// Wait for txs to be available in the mempool
 	// before we enterPropose in round 0. If the last block changed the app hash,
 	// we may need an empty "proof" block, and enterPropose immediately.
-	waitForTxs := cs.config.WaitForTxs() && round == 0 && !cs.needProofBlock(height)
-	if waitForTxs {
-		if cs.config.CreateEmptyBlocksInterval > 0 {
-			cs.scheduleTimeout(cs.config.CreateEmptyBlocksInterval, height, round,
-				cstypes.RoundStepNewRound)
-		}
-	} else {
+	waitForTxs := cs.config.WaitForTxs() && round == 0 && !cs.needBlock(height)
+	if !waitForTxs {
 		cs.enterPropose(height, round)
+		return
+	}
+
+	switch {
+	case cs.config.MaxEmptyBlockInterval > 0:
+		timeout := cs.lastBlockCommitTime().Add(cs.config.MaxEmptyBlockInterval).Sub(cmttime.Now())
+		if timeout <= 0 {
+			cs.enterPropose(height, round)
+			return
+		}
+		cs.scheduleTimeout(timeout, height, round, cstypes.RoundStepNewRound)
+
+	case cs.config.CreateEmptyBlocksInterval > 0:
+		cs.scheduleTimeout(cs.config.CreateEmptyBlocksInterval, height, round,
+			cstypes.RoundStepNewRound)
+	}
+}
+
+// lastBlockCommitTime returns the median time of the precommits for the last
+// block, which is the time of the block proposed at this height.
+func (cs *State) lastBlockCommitTime() time.Time {
+	if cs.LastCommit == nil || !cs.LastCommit.HasTwoThirdsMajority() {
+		return cs.state.LastBlockTime
+	}
+	return sm.MedianTime(cs.LastCommit.MakeCommit(), cs.LastValidators)
+}
+
+// needBlock returns true if a block must be created at height even though
+// there are no txs in the mempool: for a proof block (see needProofBlock),
+// until a change of the validator set made by the last block takes effect,
+// or when evidence is pending.
+func (cs *State) needBlock(height int64) bool {
+	if cs.needProofBlock(height) {
+		return true
+	}
+	if cs.state.LastHeightValidatorsChanged > cs.state.LastBlockHeight {
+		return true
+	}
+	if evpool, ok := cs.evpool.(pendingEvidenceCounter); ok && evpool.Size() > 0 {
+		return true
 	}
+	return false
 }
 
 // needProofBlock returns true on the first height (so the genesis app hash is signed right away)
@@ -1088,6 +1168,10 @@ func (cs *State) needProofBlock(height int64) bool {
 //	after enterNewRound(height,round), after timeout of CreateEmptyBlocksInterval
 //
 // Enter (!CreateEmptyBlocks) : after enterNewRound(height,round), once txs are in the mempool
+// Enter (!CreateEmptyBlocks, MaxEmptyBlockInterval > 0):
+//
+//	after enterNewRound(height,round), once MinTxsInBlock txs are in the mempool
+//	or MaxEmptyBlockInterval after the last block was committed
 func (cs *State) enterPropose(height int64, round int32) {
 	logger := cs.Logger.With("height", height, "round", round)
 
@@ -1140,6 +1224,11 @@ func (cs *State) enterPropose(height int64, round int32) {
 		return
 	}
 
+	if cs.participationHeld() {
+		logger.Debug("propose step; participation held, not proposing")
+		return
+	}
+
 	if cs.isProposer(address) {
 		logger.Debug("propose step; our turn to propose", "proposer", address)
 		cs.decideProposal(height, round)
@@ -1148,6 +1237,47 @@ func (cs *State) enterPropose(height int64, round int32) {
 	}
 }
 
+// HoldParticipation makes the node stop taking part in consensus, e.g. while
+// the application is being upgraded: it no longer proposes, votes nil and does
+// not commit blocks until ResumeParticipation is called. A block decided by
+// the other validators meanwhile is committed once participation resumes.
+//
+// It returns the last committed height, which stays the same until then.
+func (cs *State) HoldParticipation() int64 {
+	cs.holdMtx.Lock()
+	cs.held = true
+	cs.holdMtx.Unlock()
+
+	// wait for the step in progress, which may still commit a block
+	cs.mtx.RLock()
+	defer cs.mtx.RUnlock()
+	return cs.state.LastBlockHeight
+}
+
+// ResumeParticipation resumes the participation held by HoldParticipation.
+func (cs *State) ResumeParticipation() {
+	cs.holdMtx.Lock()
+	held := cs.held
+	cs.held = false
+	cs.holdMtx.Unlock()
+	if !held {
+		return
+	}
+
+	// commit the block decided while participation was held, if any
+	cs.mtx.Lock()
+	defer cs.mtx.Unlock()
+	if cs.Step == cstypes.RoundStepCommit {
+		cs.tryFinalizeCommit(cs.Height)
+	}
+}
+
+func (cs *State) participationHeld() bool {
+	cs.holdMtx.Lock()
+	defer cs.holdMtx.Unlock()
+	return cs.held
+}
+
 func (cs *State) isProposer(address []byte) bool {
 	return bytes.Equal(cs.Validators.GetProposer().Address, address)
 }
@@ -1285,6 +1415,14 @@ func (cs *State) enterPrevote(height int64, round int32) {
 func (cs *State) defaultDoPrevote(height int64, round int32) {
 	logger := cs.Logger.With("height", height, "round", round)
 
+	// If participation is held, prevote nil without asking the application,
+	// which may be down.
+	if cs.participationHeld() {
+		logger.Debug("prevote step; participation held; prevoting nil")
+		cs.signAddVote(cmtproto.PrevoteType, nil, types.PartSetHeader{})
+		return
+	}
+
 	// If a block is locked, prevote that.
 	if cs.LockedBlock != nil {
 		logger.Debug("prevote step; already locked on a block; prevoting locked block")
@@ -1391,6 +1529,13 @@ func (cs *State) enterPrecommit(height int64, round int32) {
 		cs.newStep()
 	}()
 
+	// If participation is held, precommit nil.
+	if cs.participationHeld() {
+		logger.Debug("precommit step; participation held; precommitting nil")
+		cs.signAddVote(cmtproto.PrecommitType, nil, types.PartSetHeader{})
+		return
+	}
+
 	// check for a polka
 	blockID, ok := cs.Votes.Prevotes(round).TwoThirdsMajority()
 
@@ -1628,6 +1773,13 @@ func (cs *State) finalizeCommit(height int64) {
 		return
 	}
 
+	// The block is committed once participation resumes, as the application
+	// may be down.
+	if cs.participationHeld() {
+		logger.Info("participation held; waiting to finalize commit")
+		return
+	}
+
 	cs.calculatePrevoteMessageDelayMetrics()
 
 	blockID, ok := cs.Votes.Precommits(cs.CommitRound).TwoThirdsMajority()
@@ -1726,6 +1878,14 @@ func (cs *State) finalizeCommit(height int64) {
 
 	// Prune old heights, if requested by ABCI app.
 	if retainHeight > 0 {
+		// record the retain height of the app, reported by the RPC
+		cs.blockStore.SaveRetainHeight(retainHeight)
+		// keep the data needed to verify evidence that has not expired yet
+		if evidenceRetainHeight := sm.EvidenceRetainHeight(stateCopy, cs.blockStore, retainHeight); evidenceRetainHeight < retainHeight {
+			logger.Info("clamped retain height to keep the data needed to verify evidence",
+				"retain_height", retainHeight, "evidence_retain_height", evidenceRetainHeight)
+			retainHeight = evidenceRetainHeight
+		}
 		pruned, err := cs.pruneBlocks(retainHeight)
 		if err != nil {
 			logger.Error("failed to prune blocks", "retain_height", retainHeight, "err", err)
@@ -1736,6 +1896,7 @@ func (cs *State) finalizeCommit(height int64) {
 
 	// must be called before we update state
 	cs.recordMetrics(height, block)
+	cs.saveProposerRecord(height)
 
 	// NewHeightStep!
 	cs.updateToState(stateCopy)
@@ -1898,6 +2059,7 @@ func (cs *State) defaultSetProposal(proposal *types.Proposal) error {
 
 	proposal.Signature = p.Signature
 	cs.Proposal = proposal
+	cs.proposerRecord.observeProposal(proposal.Round)
 	// We don't update cs.ProposalBlockParts if it is already set.
 	// This happens if we're already in cstypes.RoundStepCommit or if there is a valid block in the current round.
 	// TODO: We can check if Proposal is for a different block as this is a sign of misbehavior!
@@ -1959,7 +2121,11 @@ func (cs *State) addProposalBlockPart(msg *BlockPartMessage, peerID p2p.ID) (add
 		}
 
 		pbb := new(cmtproto.Block)
-		err = proto.Unmarshal(bz, pbb)
+		if cs.state.ConsensusParams.Block.StrictDecoding {
+			err = types.UnmarshalStrict(bz, pbb)
+		} else {
+			err = proto.Unmarshal(bz, pbb)
+		}
 		if err != nil {
 			return added, err
 		}
@@ -132,6 +132,97 @@ func TestStateProposerSelection2(t *testing.T) {
 
 }
 
+// the proposers of the rounds of a height are recorded once it is committed,
+// along with whether their proposal was observed
+func TestStateProposerRecord(t *testing.T) {
+	cs1, vss := randState(4)
+	height, round := cs1.Height, cs1.Round
+	newRoundCh := subscribe(cs1.eventBus, types.EventQueryNewRound)
+	proposalCh := subscribe(cs1.eventBus, types.EventQueryCompleteProposal)
+
+	startTestRound(cs1, height, round)
+	ensureNewRound(newRoundCh, height, round)
+	ensureNewProposal(proposalCh, height, round)
+	rs := cs1.GetRoundState()
+	signAddVotes(cs1, cmtproto.PrecommitType, rs.ProposalBlock.Hash(), rs.ProposalBlockParts.Header(), vss[1:]...)
+
+	// the other validators propose the next rounds, but their proposals never
+	// reach cs1, and everyone votes nil until cs1 proposes again
+	incrementHeight(vss[1:]...)
+	ensureNewRound(newRoundCh, height+1, 0)
+	for i := int32(0); i < 3; i++ {
+		signAddVotes(cs1, cmtproto.PrecommitType, nil, types.PartSetHeader{}, vss[1:]...)
+		ensureNewRound(newRoundCh, height+1, i+1)
+		incrementRound(vss[1:]...)
+	}
+	ensureNewProposal(proposalCh, height+1, 3)
+	rs = cs1.GetRoundState()
+	signAddVotes(cs1, cmtproto.PrecommitType, rs.ProposalBlock.Hash(), rs.ProposalBlockParts.Header(), vss[1:]...)
+	ensureNewRound(newRoundCh, height+2, 0)
+
+	record, err := cs1.blockExec.Store().LoadProposerRecord(height)
+	require.NoError(t, err)
+	require.Len(t, record.Rounds, 1)
+	assert.True(t, record.Rounds[0].ProposalObserved)
+
+	record, err = cs1.blockExec.Store().LoadProposerRecord(height + 1)
+	require.NoError(t, err)
+	require.Len(t, record.Rounds, 4)
+	for i, r := range record.Rounds {
+		pubKey, err := vss[(i+1)%len(vss)].GetPubKey()
+		require.NoError(t, err)
+		assert.EqualValues(t, i, r.Round)
+		assert.Equal(t, pubKey.Address().Bytes(), r.ProposerAddress)
+		// only cs1's proposal, in round 3, was observed
+		assert.Equal(t, i == 3, r.ProposalObserved)
+	}
+}
+
+// TestStateRoundStateValidatorsCopyOnWrite checks that the validator sets of
+// the round states handed out by GetRoundState are never mutated, e.g. by the
+// increments of the proposer priorities of the next rounds, which readers in
+// other goroutines would observe, run with -race.
+func TestStateRoundStateValidatorsCopyOnWrite(t *testing.T) {
+	cs1, vss := randState(4)
+	height, round := cs1.Height, cs1.Round
+	newRoundCh := subscribe(cs1.eventBus, types.EventQueryNewRound)
+
+	// startTestRound enters the round outside of the lock of the state, so
+	// the reader starts after it
+	startTestRound(cs1, height, round)
+	ensureNewRound(newRoundCh, height, round)
+	rs := cs1.GetRoundState()
+	snapshot := rs.Validators.DeepCopy()
+
+	done := make(chan struct{})
+	readerDone := make(chan struct{})
+	go func() {
+		defer close(readerDone)
+		for {
+			select {
+			case <-done:
+				return
+			default:
+			}
+			rs := cs1.GetRoundState()
+			_ = rs.Validators.GetProposer()
+			_ = rs.Validators.Hash()
+			_, _ = cs1.GetValidators()
+		}
+	}()
+
+	for i := int32(0); i < 2; i++ {
+		signAddVotes(cs1, cmtproto.PrecommitType, nil, types.PartSetHeader{}, vss[1:]...)
+		ensureNewRound(newRoundCh, height, i+1)
+		incrementRound(vss[1:]...)
+	}
+	close(done)
+	<-readerDone
+
+	assert.True(t, rs.Validators.Equal(snapshot))
+	assert.False(t, cs1.GetRoundState().Validators.Equal(snapshot))
+}
+
 // a non-validator should timeout into the prevote round
 func TestStateEnterProposeNoPrivValidator(t *testing.T) {
 	cs, _ := randState(1)
@@ -349,8 +440,9 @@ func TestStateFullRound1(t *testing.T) {
 
 	ensureNewRound(newRoundCh, height, round)
 
-	ensureNewProposal(propCh, height, round)
-	propBlockHash := cs.GetRoundState().ProposalBlock.Hash()
+	// the round state may already be locked, publishing the prevote to the
+	// unbuffered voteCh, so the proposal block is read from its event
+	propBlockHash := ensureNewProposal(propCh, height, round).Hash
 
 	ensurePrevote(voteCh, height, round) // wait for prevote
 	validatePrevote(t, cs, round, vss[0], propBlockHash)
@@ -1824,6 +1916,69 @@ func TestStateHalt1(t *testing.T) {
 	ensureNewRound(newRoundCh, height+1, 0)
 }
 
+// 1 validator, participation held: no proposal, nil votes, and a block once
+// participation resumes.
+func TestStateHoldParticipation(t *testing.T) {
+	cs1, vss := randState(1)
+	height, round := cs1.Height, cs1.Round
+
+	proposalCh := subscribe(cs1.eventBus, types.EventQueryCompleteProposal)
+	newBlockCh := subscribe(cs1.eventBus, types.EventQueryNewBlock)
+	pv1, err := cs1.privValidator.GetPubKey()
+	require.NoError(t, err)
+	addr := pv1.Address()
+	voteCh := subscribeToVoter(cs1, addr)
+
+	assert.Equal(t, height-1, cs1.HoldParticipation())
+	startTestRound(cs1, height, round)
+
+	ensurePrevote(voteCh, height, round)
+	validatePrevote(t, cs1, round, vss[0], nil)
+	ensurePrecommit(voteCh, height, round)
+	validatePrecommit(t, cs1, round, -1, vss[0], nil, nil)
+	ensureNoNewEventOnChannel(proposalCh)
+	ensureNoNewEventOnChannel(newBlockCh)
+
+	// the nil votes of the next rounds must not hold up consensus
+	go func() {
+		for range voteCh {
+		}
+	}()
+	cs1.ResumeParticipation()
+	ensureNewBlock(newBlockCh, height)
+}
+
+// 2 validators, participation held once precommitted: the block committed by
+// both is finalized once participation resumes.
+func TestStateHoldParticipationDefersCommit(t *testing.T) {
+	cs1, vss := randState(2)
+	vs2 := vss[1]
+	height, round := cs1.Height, cs1.Round
+
+	voteCh := subscribeUnBuffered(cs1.eventBus, types.EventQueryVote)
+	newBlockCh := subscribe(cs1.eventBus, types.EventQueryNewBlock)
+
+	startTestRound(cs1, height, round)
+	ensurePrevote(voteCh, height, round)
+
+	rs := cs1.GetRoundState()
+	propBlockHash, propPartSetHeader := rs.ProposalBlock.Hash(), rs.ProposalBlockParts.Header()
+
+	signAddVotes(cs1, cmtproto.PrevoteType, propBlockHash, propPartSetHeader, vs2)
+	ensurePrevote(voteCh, height, round)
+	ensurePrecommit(voteCh, height, round)
+
+	assert.Equal(t, height-1, cs1.HoldParticipation())
+
+	signAddVotes(cs1, cmtproto.PrecommitType, propBlockHash, propPartSetHeader, vs2)
+	ensurePrecommit(voteCh, height, round)
+	ensureNoNewEventOnChannel(newBlockCh)
+	assert.Equal(t, cstypes.RoundStepCommit, cs1.GetRoundState().Step)
+
+	cs1.ResumeParticipation()
+	ensureNewBlock(newBlockCh, height)
+}
+
 func TestStateOutputsBlockPartsStats(t *testing.T) {
 	// create dummy peer
 	cs, _ := randState(1)
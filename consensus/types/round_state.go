@@ -64,6 +64,9 @@ func (rs RoundStepType) String() string {
 // RoundState defines the internal consensus state.
 // NOTE: Not thread safe. Should only be manipulated by functions downstream
 // of the cs.receiveRoutine
+// The validator sets of a RoundState are never mutated, but replaced with
+// updated copies, so that the shallow copies of the round state handed out to
+// other goroutines can read them.
 type RoundState struct {
 	Height    int64         `json:"height"` // Height we are working on
 	Round     int32         `json:"round"`
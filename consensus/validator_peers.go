@@ -0,0 +1,72 @@
+package consensus
+
+import (
+	"time"
+
+	cstypes "github.com/tendermint/tendermint/consensus/types"
+	cmtsync "github.com/tendermint/tendermint/libs/sync"
+	"github.com/tendermint/tendermint/p2p"
+	"github.com/tendermint/tendermint/types"
+)
+
+const (
+	// validatorAnnouncementInterval is the interval at which a validator
+	// announces the node it runs to its peers, besides announcing it to every
+	// new peer, so that the peers which did not know the validator yet learn
+	// it after a validator set change.
+	validatorAnnouncementInterval = time.Minute
+
+	// prioritizedGossipSleepDivisor divides the gossip sleep duration of the
+	// peers running a validator which has not voted yet in the round.
+	prioritizedGossipSleepDivisor = 4
+)
+
+// validatorPeers maps the IDs of the peers to the addresses of the validators
+// they run, learned from the announcements of the validators. The mapping is
+// only used to prioritize the gossip to some peers, so an outdated entry, for
+// a validator which left the set, is harmless.
+type validatorPeers struct {
+	mtx        cmtsync.RWMutex
+	validators map[p2p.ID]types.Address
+}
+
+func newValidatorPeers() *validatorPeers {
+	return &validatorPeers{validators: make(map[p2p.ID]types.Address)}
+}
+
+// set records that the peer runs the validator.
+func (vp *validatorPeers) set(id p2p.ID, address types.Address) {
+	vp.mtx.Lock()
+	defer vp.mtx.Unlock()
+	vp.validators[id] = address
+}
+
+// remove forgets the validator run by the peer.
+func (vp *validatorPeers) remove(id p2p.ID) {
+	vp.mtx.Lock()
+	defer vp.mtx.Unlock()
+	delete(vp.validators, id)
+}
+
+// validator returns the address of the validator run by the peer, if any.
+func (vp *validatorPeers) validator(id p2p.ID) (types.Address, bool) {
+	vp.mtx.RLock()
+	defer vp.mtx.RUnlock()
+	address, ok := vp.validators[id]
+	return address, ok
+}
+
+// awaitsPrecommit returns true if the peer runs a validator of the round state
+// which has not precommitted yet in its round.
+func (vp *validatorPeers) awaitsPrecommit(id p2p.ID, rs *cstypes.RoundState) bool {
+	address, ok := vp.validator(id)
+	if !ok || rs.Validators == nil || rs.Votes == nil {
+		return false
+	}
+	index, _ := rs.Validators.GetByAddress(address)
+	if index < 0 {
+		return false
+	}
+	precommits := rs.Votes.Precommits(rs.Round)
+	return precommits != nil && precommits.GetByIndex(index) == nil
+}
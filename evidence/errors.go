@@ -0,0 +1,59 @@
+package evidence
+
+import (
+	"fmt"
+	"time"
+)
+
+// ErrEvidenceDataPruned is returned when evidence can not be verified because
+// the headers, commits or validator sets at its height have been pruned. The
+// evidence is not necessarily invalid, so the peer that sent it should not be
+// punished.
+type ErrEvidenceDataPruned struct {
+	// Height of the evidence.
+	Height int64
+	// Lowest height still present in the block store.
+	Base int64
+}
+
+func (e *ErrEvidenceDataPruned) Error() string {
+	return fmt.Sprintf("can not verify evidence at height %d: the data needed to verify it has been pruned (base height %d)",
+		e.Height, e.Base)
+}
+
+// ErrEvidenceExpired is returned when evidence is older than both the max age
+// in blocks and the max age duration of the evidence params. It can no longer
+// be committed, but it is not invalid, so the peer that sent it should not be
+// punished.
+type ErrEvidenceExpired struct {
+	// Height and time of the evidence.
+	Height int64
+	Time   time.Time
+	// The lowest height and earliest time of the evidence still accepted.
+	MinHeight int64
+	MinTime   time.Time
+}
+
+func (e *ErrEvidenceExpired) Error() string {
+	return fmt.Sprintf("evidence from height %d (created at: %v) is too old; min height is %d and evidence can not be older than %v",
+		e.Height, e.Time, e.MinHeight, e.MinTime)
+}
+
+// ErrEvidenceUnverifiable is returned when evidence can not be verified
+// because the node lacks the headers, commits or validator sets needed, for
+// instance because the evidence refers to a height the node has not reached.
+// The evidence is not necessarily invalid, so the peer that sent it should not
+// be punished.
+type ErrEvidenceUnverifiable struct {
+	// Height of the evidence.
+	Height int64
+	Err    error
+}
+
+func (e *ErrEvidenceUnverifiable) Error() string {
+	return fmt.Sprintf("can not verify evidence at height %d: %v", e.Height, e.Err)
+}
+
+func (e *ErrEvidenceUnverifiable) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,56 @@
+package evidence
+
+import (
+	"github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/discard"
+	"github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// MetricsSubsystem is a subsystem shared by all metrics exposed by this
+	// package.
+	MetricsSubsystem = "evidence"
+)
+
+// Metrics contains metrics exposed by this package.
+type Metrics struct {
+	// Number of times evidence could not be verified because the blocks or
+	// validator sets needed to verify it have been pruned.
+	VerificationDataPruned metrics.Counter
+	// Number of pieces of evidence rejected, by reason: expired, unverifiable,
+	// skipped by the application or invalid.
+	EvidenceRejected metrics.Counter
+}
+
+// PrometheusMetrics returns Metrics build using Prometheus client library.
+// Optionally, labels can be provided along with their values ("foo",
+// "fooValue").
+func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
+	labels := []string{}
+	for i := 0; i < len(labelsAndValues); i += 2 {
+		labels = append(labels, labelsAndValues[i])
+	}
+	return &Metrics{
+		VerificationDataPruned: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "verification_data_pruned",
+			Help:      "Number of times evidence could not be verified because the data needed to verify it was pruned.",
+		}, labels).With(labelsAndValues...),
+		EvidenceRejected: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "rejected_total",
+			Help:      "Number of pieces of evidence rejected, by reason: expired, unverifiable, skipped or invalid.",
+		}, append(labels, "reason")).With(labelsAndValues...),
+	}
+}
+
+// NopMetrics returns no-op Metrics.
+func NopMetrics() *Metrics {
+	return &Metrics{
+		VerificationDataPruned: discard.NewCounter(),
+		EvidenceRejected:       discard.NewCounter(),
+	}
+}
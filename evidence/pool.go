@@ -12,6 +12,7 @@ import (
 	"github.com/gogo/protobuf/proto"
 	gogotypes "github.com/gogo/protobuf/types"
 
+	abci "github.com/tendermint/tendermint/abci/types"
 	clist "github.com/tendermint/tendermint/libs/clist"
 	"github.com/tendermint/tendermint/libs/log"
 	cmtproto "github.com/tendermint/tendermint/proto/tendermint/types"
@@ -26,7 +27,8 @@ const (
 
 // Pool maintains a pool of valid evidence to be broadcasted and committed
 type Pool struct {
-	logger log.Logger
+	logger  log.Logger
+	metrics *Metrics
 
 	evidenceStore dbm.DB
 	evidenceList  *clist.CList // concurrent linked-list of evidence
@@ -36,6 +38,13 @@ type Pool struct {
 	stateDB sm.Store
 	// needed to load headers and commits to verify evidence
 	blockStore BlockStore
+	// if set, asked whether to propose and gossip verified evidence
+	app AppConn
+
+	verdictsMtx sync.Mutex
+	// verdicts of the application on evidence since the last update of the
+	// pool, which it is not asked about again before the next height
+	verdicts map[string]abci.ResponseVerifyEvidence_Result
 
 	mtx sync.Mutex
 	// latest state
@@ -63,9 +72,11 @@ func NewPool(evidenceDB dbm.DB, stateDB sm.Store, blockStore BlockStore) (*Pool,
 		blockStore:      blockStore,
 		state:           state,
 		logger:          log.NewNopLogger(),
+		metrics:         NopMetrics(),
 		evidenceStore:   evidenceDB,
 		evidenceList:    clist.New(),
 		consensusBuffer: make([]duplicateVoteSet, 0),
+		verdicts:        make(map[string]abci.ResponseVerifyEvidence_Result),
 	}
 
 	// if pending evidence already in db, in event of prior failure, then check for expiration,
@@ -92,6 +103,15 @@ func (evpool *Pool) PendingEvidence(maxBytes int64) ([]types.Evidence, int64) {
 	if err != nil {
 		evpool.logger.Error("Unable to retrieve pending evidence", "err", err)
 	}
+	// the application may have changed its mind about evidence pending for a
+	// while, so it is asked again, once per height. Evidence it no longer
+	// wants is removed and leaves room for other pending evidence.
+	for err == nil && evpool.removeVetoedEvidence(evidence) {
+		evidence, size, err = evpool.listEvidence(baseKeyPending, maxBytes)
+		if err != nil {
+			evpool.logger.Error("Unable to retrieve pending evidence", "err", err)
+		}
+	}
 	return evidence, size
 }
 
@@ -114,6 +134,11 @@ func (evpool *Pool) Update(state sm.State, ev types.EvidenceList) {
 	evpool.logger.Debug("Updating evidence pool", "last_block_height", state.LastBlockHeight,
 		"last_block_time", state.LastBlockTime)
 
+	// the application is asked again about pending evidence at the new height
+	evpool.verdictsMtx.Lock()
+	evpool.verdicts = make(map[string]abci.ResponseVerifyEvidence_Result)
+	evpool.verdictsMtx.Unlock()
+
 	// flush conflicting vote pairs from the buffer, producing DuplicateVoteEvidence and
 	// adding it to the pool
 	evpool.processConsensusBuffer(state)
@@ -151,15 +176,31 @@ func (evpool *Pool) AddEvidence(ev types.Evidence) error {
 	// 1) Verify against state.
 	err := evpool.verify(ev)
 	if err != nil {
-		return types.NewErrInvalidEvidence(ev, err)
+		// evidence that expired or can't be verified is not invalid
+		if evpool.rejectEvidence(ev, err) {
+			return types.NewErrInvalidEvidence(ev, err)
+		}
+		return err
 	}
 
-	// 2) Save to store.
+	// 2) Ask the application whether to propose and gossip it.
+	switch evpool.appVerdict(ev) {
+	case abci.ResponseVerifyEvidence_SKIP:
+		// the sender is not punished for evidence the application is not
+		// interested in
+		evpool.metrics.EvidenceRejected.With("reason", "skipped").Add(1)
+		return nil
+	case abci.ResponseVerifyEvidence_REJECT:
+		evpool.metrics.EvidenceRejected.With("reason", "invalid").Add(1)
+		return types.NewErrInvalidEvidence(ev, errors.New("rejected by the application"))
+	}
+
+	// 3) Save to store.
 	if err := evpool.addPendingEvidence(ev); err != nil {
 		return fmt.Errorf("can't add evidence to pending list: %w", err)
 	}
 
-	// 3) Add evidence to clist.
+	// 4) Add evidence to clist.
 	evpool.evidenceList.PushBack(ev)
 
 	evpool.logger.Info("Verified new evidence of byzantine behavior", "evidence", ev)
@@ -167,6 +208,30 @@ func (evpool *Pool) AddEvidence(ev types.Evidence) error {
 	return nil
 }
 
+// rejectEvidence logs and counts the rejection of the evidence, which failed
+// verification with err, by reason, and returns whether the evidence is
+// invalid rather than expired or unverifiable.
+func (evpool *Pool) rejectEvidence(ev types.Evidence, err error) (invalid bool) {
+	var (
+		errExpired      *ErrEvidenceExpired
+		errUnverifiable *ErrEvidenceUnverifiable
+		errPruned       *ErrEvidenceDataPruned
+	)
+	switch {
+	case errors.As(err, &errExpired):
+		evpool.logger.Info("Rejected expired evidence", "evidence", ev, "err", err)
+		evpool.metrics.EvidenceRejected.With("reason", "expired").Add(1)
+	case errors.As(err, &errUnverifiable), errors.As(err, &errPruned):
+		evpool.logger.Error("Could not verify evidence", "evidence", ev, "err", err)
+		evpool.metrics.EvidenceRejected.With("reason", "unverifiable").Add(1)
+	default:
+		evpool.logger.Error("Rejected invalid evidence", "evidence", ev, "err", err)
+		evpool.metrics.EvidenceRejected.With("reason", "invalid").Add(1)
+		return true
+	}
+	return false
+}
+
 // ReportConflictingVotes takes two conflicting votes and forms duplicate vote evidence,
 // adding it eventually to the evidence pool.
 //
@@ -189,6 +254,8 @@ func (evpool *Pool) ReportConflictingVotes(voteA, voteB *types.Vote) {
 // If it has already verified the evidence then it jumps to the next one. It ensures that no
 // evidence has already been committed or is being proposed twice. It also adds any
 // evidence that it doesn't currently have so that it can quickly form ABCI Evidence later.
+// The application is not asked about the evidence, so that the validity of a block only
+// depends on the protocol.
 func (evpool *Pool) CheckEvidence(evList types.EvidenceList) error {
 	hashes := make([][]byte, len(evList))
 	for idx, ev := range evList {
@@ -205,6 +272,7 @@ func (evpool *Pool) CheckEvidence(evList types.EvidenceList) error {
 
 			err := evpool.verify(ev)
 			if err != nil {
+				evpool.rejectEvidence(ev, err)
 				return err
 			}
 
@@ -244,6 +312,18 @@ func (evpool *Pool) SetLogger(l log.Logger) {
 	evpool.logger = l
 }
 
+// SetAppConn sets the connection the pool asks the application whether to
+// propose and gossip verified evidence. It must only be set if the application
+// advertises abci.CapabilityVerifyEvidence, and before the pool is used.
+func (evpool *Pool) SetAppConn(app AppConn) {
+	evpool.app = app
+}
+
+// SetMetrics sets the metrics reported by the pool.
+func (evpool *Pool) SetMetrics(metrics *Metrics) {
+	evpool.metrics = metrics
+}
+
 // Size returns the number of evidence in the pool.
 func (evpool *Pool) Size() uint32 {
 	return atomic.LoadUint32(&evpool.evidenceSize)
@@ -323,6 +403,66 @@ func (evpool *Pool) removePendingEvidence(evidence types.Evidence) {
 	}
 }
 
+// appVerdict asks the application whether to propose and gossip the evidence.
+// Evidence is accepted if no application connection is set or the application
+// fails to answer. The application is asked about each evidence at most once
+// per height, its verdict being kept until the next update of the pool.
+func (evpool *Pool) appVerdict(ev types.Evidence) abci.ResponseVerifyEvidence_Result {
+	if evpool.app == nil {
+		return abci.ResponseVerifyEvidence_ACCEPT
+	}
+	key := evMapKey(ev)
+	evpool.verdictsMtx.Lock()
+	verdict, ok := evpool.verdicts[key]
+	evpool.verdictsMtx.Unlock()
+	if ok {
+		return verdict
+	}
+
+	res, err := evpool.app.VerifyEvidenceSync(abci.RequestVerifyEvidence{
+		Hash:                ev.Hash(),
+		ByzantineValidators: ev.ABCI(),
+	})
+	if err != nil {
+		evpool.logger.Error("Application failed to verify evidence, accepting it", "evidence", ev, "err", err)
+		verdict = abci.ResponseVerifyEvidence_ACCEPT
+	} else {
+		verdict = res.Result
+	}
+	switch verdict {
+	case abci.ResponseVerifyEvidence_SKIP:
+		evpool.logger.Info("Application skipped evidence", "evidence", ev)
+	case abci.ResponseVerifyEvidence_REJECT:
+		evpool.logger.Error("Application rejected evidence", "evidence", ev)
+	}
+
+	evpool.verdictsMtx.Lock()
+	evpool.verdicts[key] = verdict
+	evpool.verdictsMtx.Unlock()
+	return verdict
+}
+
+// removeVetoedEvidence removes the pending evidence the application skips or
+// rejects from the pool, and returns whether any was removed.
+func (evpool *Pool) removeVetoedEvidence(evidence []types.Evidence) bool {
+	if evpool.app == nil {
+		return false
+	}
+	vetoed := make(map[string]struct{})
+	for _, ev := range evidence {
+		switch evpool.appVerdict(ev) {
+		case abci.ResponseVerifyEvidence_SKIP, abci.ResponseVerifyEvidence_REJECT:
+			evpool.removePendingEvidence(ev)
+			vetoed[evMapKey(ev)] = struct{}{}
+		}
+	}
+	if len(vetoed) == 0 {
+		return false
+	}
+	evpool.removeEvidenceFromList(vetoed)
+	return true
+}
+
 // markEvidenceAsCommitted processes all the evidence in the block, marking it as
 // committed and removing it from the pending database.
 func (evpool *Pool) markEvidenceAsCommitted(evidence types.EvidenceList) {
@@ -515,6 +655,11 @@ func (evpool *Pool) processConsensusBuffer(state sm.State) {
 			continue
 		}
 
+		switch evpool.appVerdict(dve) {
+		case abci.ResponseVerifyEvidence_SKIP, abci.ResponseVerifyEvidence_REJECT:
+			continue
+		}
+
 		if err := evpool.addPendingEvidence(dve); err != nil {
 			evpool.logger.Error("failed to flush evidence from consensus buffer to pending list: %w", err)
 			continue
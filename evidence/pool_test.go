@@ -1,6 +1,7 @@
 package evidence_test
 
 import (
+	"errors"
 	"os"
 	"testing"
 	"time"
@@ -11,6 +12,8 @@ import (
 
 	dbm "github.com/cometbft/cometbft-db"
 
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/tmhash"
 	"github.com/tendermint/tendermint/evidence"
 	"github.com/tendermint/tendermint/evidence/mocks"
 	"github.com/tendermint/tendermint/libs/log"
@@ -47,6 +50,7 @@ func TestEvidencePoolBasic(t *testing.T) {
 
 	valSet, privVals := types.RandValidatorSet(1, 10)
 
+	blockStore.On("Base").Return(int64(1))
 	blockStore.On("LoadBlockMeta", mock.AnythingOfType("int64")).Return(
 		&types.BlockMeta{Header: types.Header{Time: defaultEvidenceTime}},
 	)
@@ -107,6 +111,7 @@ func TestAddExpiredEvidence(t *testing.T) {
 		expiredHeight       = int64(2)
 	)
 
+	blockStore.On("Base").Return(int64(1))
 	blockStore.On("LoadBlockMeta", mock.AnythingOfType("int64")).Return(func(h int64) *types.BlockMeta {
 		if h == height || h == expiredHeight {
 			return &types.BlockMeta{Header: types.Header{Time: defaultEvidenceTime}}
@@ -237,6 +242,109 @@ func TestVerifyDuplicatedEvidenceFails(t *testing.T) {
 	}
 }
 
+// vetoApp answers VerifyEvidence with the verdict set for the hash of the
+// evidence, ACCEPT if none, and counts the calls.
+type vetoApp struct {
+	verdicts map[string]abci.ResponseVerifyEvidence_Result
+	calls    int
+}
+
+func (app *vetoApp) VerifyEvidenceSync(req abci.RequestVerifyEvidence) (*abci.ResponseVerifyEvidence, error) {
+	app.calls++
+	return &abci.ResponseVerifyEvidence{Result: app.verdicts[string(req.Hash)]}, nil
+}
+
+func TestApplicationVetoesEvidence(t *testing.T) {
+	var height int64 = 10
+	pool, val := defaultTestPool(height)
+	app := &vetoApp{verdicts: make(map[string]abci.ResponseVerifyEvidence_Result)}
+	pool.SetAppConn(app)
+
+	evidence := make([]types.Evidence, 3)
+	for i := range evidence {
+		evidence[i] = types.NewMockDuplicateVoteEvidenceWithValidator(int64(i+1),
+			defaultEvidenceTime.Add(time.Duration(i+1)*time.Minute), val, evidenceChainID)
+	}
+	accepted, skipped, rejected := evidence[0], evidence[1], evidence[2]
+	app.verdicts[string(accepted.Hash())] = abci.ResponseVerifyEvidence_ACCEPT
+	app.verdicts[string(skipped.Hash())] = abci.ResponseVerifyEvidence_SKIP
+	app.verdicts[string(rejected.Hash())] = abci.ResponseVerifyEvidence_REJECT
+
+	require.NoError(t, pool.AddEvidence(accepted))
+	// the sender of skipped evidence is not punished
+	require.NoError(t, pool.AddEvidence(skipped))
+	err := pool.AddEvidence(rejected)
+	require.Error(t, err)
+	assert.IsType(t, &types.ErrInvalidEvidence{}, err)
+	assert.Equal(t, 3, app.calls)
+
+	evList, _ := pool.PendingEvidence(defaultEvidenceMaxBytes)
+	assert.Equal(t, []types.Evidence{accepted}, evList)
+	assert.Equal(t, accepted, pool.EvidenceFront().Value)
+	assert.Nil(t, pool.EvidenceFront().Next())
+
+	// the evidence of a block is valid regardless of the application
+	require.NoError(t, pool.CheckEvidence(types.EvidenceList{skipped, rejected}))
+	// but it is not proposed
+	evList, _ = pool.PendingEvidence(defaultEvidenceMaxBytes)
+	assert.Equal(t, []types.Evidence{accepted}, evList)
+	assert.EqualValues(t, 1, pool.Size())
+	// the application is not asked again about evidence at the same height
+	assert.Equal(t, 3, app.calls)
+	evList, _ = pool.PendingEvidence(defaultEvidenceMaxBytes)
+	assert.Equal(t, []types.Evidence{accepted}, evList)
+	assert.Equal(t, 3, app.calls)
+
+	// the application changes its mind about pending evidence, which is only
+	// taken into account at the next height
+	app.verdicts[string(accepted.Hash())] = abci.ResponseVerifyEvidence_SKIP
+	evList, _ = pool.PendingEvidence(defaultEvidenceMaxBytes)
+	assert.Equal(t, []types.Evidence{accepted}, evList)
+
+	state := pool.State()
+	state.LastBlockHeight = height + 1
+	state.LastBlockTime = defaultEvidenceTime.Add(time.Duration(height+1) * time.Minute)
+	pool.Update(state, types.EvidenceList{})
+	evList, size := pool.PendingEvidence(defaultEvidenceMaxBytes)
+	assert.Empty(t, evList)
+	assert.Zero(t, size)
+	assert.Nil(t, pool.EvidenceFront())
+	assert.Zero(t, pool.Size())
+	assert.Equal(t, 4, app.calls)
+}
+
+func TestApplicationAskedOnceAboutGossipedEvidence(t *testing.T) {
+	var height int64 = 10
+	pool, val := defaultTestPool(height)
+	app := &vetoApp{verdicts: make(map[string]abci.ResponseVerifyEvidence_Result)}
+	pool.SetAppConn(app)
+
+	skipped := types.NewMockDuplicateVoteEvidenceWithValidator(1, defaultEvidenceTime.Add(time.Minute),
+		val, evidenceChainID)
+	rejected := types.NewMockDuplicateVoteEvidenceWithValidator(2, defaultEvidenceTime.Add(2*time.Minute),
+		val, evidenceChainID)
+	app.verdicts[string(skipped.Hash())] = abci.ResponseVerifyEvidence_SKIP
+	app.verdicts[string(rejected.Hash())] = abci.ResponseVerifyEvidence_REJECT
+
+	// the same evidence is gossiped by two peers
+	for i := 0; i < 2; i++ {
+		require.NoError(t, pool.AddEvidence(skipped))
+		err := pool.AddEvidence(rejected)
+		require.Error(t, err)
+		assert.IsType(t, &types.ErrInvalidEvidence{}, err)
+	}
+	assert.Equal(t, 2, app.calls)
+	assert.Zero(t, pool.Size())
+
+	// until the next height
+	state := pool.State()
+	state.LastBlockHeight = height + 1
+	state.LastBlockTime = defaultEvidenceTime.Add(time.Duration(height+1) * time.Minute)
+	pool.Update(state, types.EvidenceList{})
+	require.NoError(t, pool.AddEvidence(skipped))
+	assert.Equal(t, 3, app.calls)
+}
+
 // check that valid light client evidence is correctly validated and stored in
 // evidence pool
 func TestLightClientAttackEvidenceLifecycle(t *testing.T) {
@@ -258,6 +366,7 @@ func TestLightClientAttackEvidenceLifecycle(t *testing.T) {
 	stateStore.On("LoadValidators", commonHeight).Return(common.ValidatorSet, nil)
 	stateStore.On("Load").Return(state, nil)
 	blockStore := &mocks.BlockStore{}
+	blockStore.On("Base").Return(int64(1))
 	blockStore.On("LoadBlockMeta", height).Return(&types.BlockMeta{Header: *trusted.Header})
 	blockStore.On("LoadBlockMeta", commonHeight).Return(&types.BlockMeta{Header: *common.Header})
 	blockStore.On("LoadBlockCommit", height).Return(trusted.Commit)
@@ -428,7 +537,12 @@ func makeCommit(height int64, valAddr []byte) *types.Commit {
 		Timestamp:        defaultEvidenceTime,
 		Signature:        []byte("Signature"),
 	}}
-	return types.NewCommit(height, 0, types.BlockID{}, commitSigs)
+	// the block ID is not checked but must be set for the blocks to be loadable
+	blockID := types.BlockID{
+		Hash:          tmhash.Sum([]byte("block")),
+		PartSetHeader: types.PartSetHeader{Total: 1, Hash: tmhash.Sum([]byte("parts"))},
+	}
+	return types.NewCommit(height, 0, blockID, commitSigs)
 }
 
 func defaultTestPool(height int64) (*evidence.Pool, types.MockPV) {
@@ -455,3 +569,43 @@ func createState(height int64, valSet *types.ValidatorSet) sm.State {
 		ConsensusParams: *types.DefaultConsensusParams(),
 	}
 }
+
+func TestAddEvidenceAfterPruning(t *testing.T) {
+	var (
+		height = int64(30)
+		val    = types.NewMockPV()
+	)
+	stateStore := initializeValidatorState(val, height)
+	state, err := stateStore.Load()
+	require.NoError(t, err)
+	blockStore := initializeBlockStore(dbm.NewMemDB(), state, val.PrivKey.PubKey().Address())
+	pool, err := evidence.NewPool(dbm.NewMemDB(), stateStore, blockStore)
+	require.NoError(t, err)
+	pool.SetLogger(log.TestingLogger())
+
+	// the application asks to prune all but the last block, which is clamped to
+	// keep the blocks within the max evidence age
+	state.LastBlockTime = defaultEvidenceTime.Add(time.Duration(height) * time.Minute)
+	retainHeight := sm.EvidenceRetainHeight(state, blockStore, height)
+	require.Equal(t, height-state.ConsensusParams.Evidence.MaxAgeNumBlocks, retainHeight)
+	_, err = blockStore.PruneBlocks(retainHeight)
+	require.NoError(t, err)
+	require.NoError(t, stateStore.PruneStates(1, retainHeight))
+
+	// late evidence at the lowest retained height can still be verified
+	ev := types.NewMockDuplicateVoteEvidenceWithValidator(retainHeight,
+		defaultEvidenceTime.Add(time.Duration(retainHeight)*time.Minute), val, evidenceChainID)
+	require.NoError(t, pool.AddEvidence(ev))
+
+	// evidence at a pruned height can't be verified, which doesn't make it invalid
+	ev = types.NewMockDuplicateVoteEvidenceWithValidator(retainHeight-1,
+		defaultEvidenceTime.Add(time.Duration(retainHeight-1)*time.Minute), val, evidenceChainID)
+	err = pool.AddEvidence(ev)
+	var errPruned *evidence.ErrEvidenceDataPruned
+	require.ErrorAs(t, err, &errPruned)
+	assert.Equal(t, retainHeight-1, errPruned.Height)
+	assert.Equal(t, retainHeight, errPruned.Base)
+	var errInvalid *types.ErrInvalidEvidence
+	assert.False(t, errors.As(err, &errInvalid))
+	assert.Error(t, pool.CheckEvidence(types.EvidenceList{ev}))
+}
@@ -192,6 +192,7 @@ func TestReactorBroadcastEvidenceMemoryLeak(t *testing.T) {
 	evidenceTime := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
 	evidenceDB := dbm.NewMemDB()
 	blockStore := &mocks.BlockStore{}
+	blockStore.On("Base").Return(int64(1))
 	blockStore.On("LoadBlockMeta", mock.AnythingOfType("int64")).Return(
 		&types.BlockMeta{Header: types.Header{Time: evidenceTime}},
 	)
@@ -252,6 +253,7 @@ func makeAndConnectReactorsAndPools(config *cfg.Config, stateStores []sm.Store)
 	for i := 0; i < N; i++ {
 		evidenceDB := dbm.NewMemDB()
 		blockStore := &mocks.BlockStore{}
+		blockStore.On("Base").Return(int64(1))
 		blockStore.On("LoadBlockMeta", mock.AnythingOfType("int64")).Return(
 			&types.BlockMeta{Header: types.Header{Time: evidenceTime}},
 		)
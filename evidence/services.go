@@ -1,6 +1,7 @@
 package evidence
 
 import (
+	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/types"
 )
 
@@ -10,4 +11,12 @@ type BlockStore interface {
 	LoadBlockMeta(height int64) *types.BlockMeta
 	LoadBlockCommit(height int64) *types.Commit
 	Height() int64
+	Base() int64
+}
+
+// AppConn is the connection the pool asks the application whether to propose
+// and gossip a piece of evidence, if the application advertises
+// abci.CapabilityVerifyEvidence.
+type AppConn interface {
+	VerifyEvidenceSync(abci.RequestVerifyEvidence) (*abci.ResponseVerifyEvidence, error)
 }
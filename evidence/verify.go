@@ -16,6 +16,10 @@ import (
 // - it is from a key who was a validator at the given height
 // - it is internally consistent with state
 // - it was properly signed by the alleged equivocator and meets the individual evidence verification requirements
+//
+// It returns ErrEvidenceExpired if the evidence is too old, and
+// ErrEvidenceDataPruned or ErrEvidenceUnverifiable if the node lacks the data
+// needed to verify it. Any other error means the evidence is invalid.
 func (evpool *Pool) verify(evidence types.Evidence) error {
 	var (
 		state          = evpool.State()
@@ -24,10 +28,19 @@ func (evpool *Pool) verify(evidence types.Evidence) error {
 		ageNumBlocks   = height - evidence.Height()
 	)
 
+	// the headers and validator sets needed to verify the evidence are gone
+	if base := evpool.blockStore.Base(); evidence.Height() < base {
+		evpool.metrics.VerificationDataPruned.Add(1)
+		return &ErrEvidenceDataPruned{Height: evidence.Height(), Base: base}
+	}
+
 	// verify the time of the evidence
 	blockMeta := evpool.blockStore.LoadBlockMeta(evidence.Height())
 	if blockMeta == nil {
-		return fmt.Errorf("don't have header #%d", evidence.Height())
+		return &ErrEvidenceUnverifiable{
+			Height: evidence.Height(),
+			Err:    fmt.Errorf("don't have header #%d", evidence.Height()),
+		}
 	}
 	evTime := blockMeta.Header.Time
 	if evidence.Time() != evTime {
@@ -38,13 +51,12 @@ func (evpool *Pool) verify(evidence types.Evidence) error {
 
 	// check that the evidence hasn't expired
 	if ageDuration > evidenceParams.MaxAgeDuration && ageNumBlocks > evidenceParams.MaxAgeNumBlocks {
-		return fmt.Errorf(
-			"evidence from height %d (created at: %v) is too old; min height is %d and evidence can not be older than %v",
-			evidence.Height(),
-			evTime,
-			height-evidenceParams.MaxAgeNumBlocks,
-			state.LastBlockTime.Add(evidenceParams.MaxAgeDuration),
-		)
+		return &ErrEvidenceExpired{
+			Height:    evidence.Height(),
+			Time:      evTime,
+			MinHeight: height - evidenceParams.MaxAgeNumBlocks,
+			MinTime:   state.LastBlockTime.Add(-evidenceParams.MaxAgeDuration),
+		}
 	}
 
 	// apply the evidence-specific verification logic
@@ -52,18 +64,18 @@ func (evpool *Pool) verify(evidence types.Evidence) error {
 	case *types.DuplicateVoteEvidence:
 		valSet, err := evpool.stateDB.LoadValidators(evidence.Height())
 		if err != nil {
-			return err
+			return &ErrEvidenceUnverifiable{Height: evidence.Height(), Err: err}
 		}
 		return VerifyDuplicateVote(ev, state.ChainID, valSet)
 
 	case *types.LightClientAttackEvidence:
 		commonHeader, err := getSignedHeader(evpool.blockStore, evidence.Height())
 		if err != nil {
-			return err
+			return &ErrEvidenceUnverifiable{Height: evidence.Height(), Err: err}
 		}
 		commonVals, err := evpool.stateDB.LoadValidators(evidence.Height())
 		if err != nil {
-			return err
+			return &ErrEvidenceUnverifiable{Height: evidence.Height(), Err: err}
 		}
 		trustedHeader := commonHeader
 		// in the case of lunatic the trusted header is different to the common header
@@ -78,12 +90,15 @@ func (evpool *Pool) verify(evidence types.Evidence) error {
 				latestHeight := evpool.blockStore.Height()
 				trustedHeader, err = getSignedHeader(evpool.blockStore, latestHeight)
 				if err != nil {
-					return err
+					return &ErrEvidenceUnverifiable{Height: evidence.Height(), Err: err}
 				}
+				// the node has not reached the time of the conflicting block yet
 				if trustedHeader.Time.Before(ev.ConflictingBlock.Time) {
-					return fmt.Errorf("latest block time (%v) is before conflicting block time (%v)",
-						trustedHeader.Time, ev.ConflictingBlock.Time,
-					)
+					return &ErrEvidenceUnverifiable{
+						Height: evidence.Height(),
+						Err: fmt.Errorf("latest block time (%v) is before conflicting block time (%v)",
+							trustedHeader.Time, ev.ConflictingBlock.Time),
+					}
 				}
 			}
 		}
@@ -2,6 +2,7 @@ package evidence_test
 
 import (
 	"bytes"
+	"errors"
 	"testing"
 	"time"
 
@@ -86,6 +87,7 @@ func TestVerify_LunaticAttackAgainstState(t *testing.T) {
 	stateStore.On("LoadValidators", commonHeight).Return(common.ValidatorSet, nil)
 	stateStore.On("Load").Return(state, nil)
 	blockStore := &mocks.BlockStore{}
+	blockStore.On("Base").Return(int64(1))
 	blockStore.On("LoadBlockMeta", commonHeight).Return(&types.BlockMeta{Header: *common.Header})
 	blockStore.On("LoadBlockMeta", height).Return(&types.BlockMeta{Header: *trusted.Header})
 	blockStore.On("LoadBlockCommit", commonHeight).Return(common.Commit)
@@ -132,6 +134,129 @@ func TestVerify_LunaticAttackAgainstState(t *testing.T) {
 	ev.TotalVotingPower = common.ValidatorSet.TotalVotingPower()
 }
 
+func TestVerify_LunaticAttackAge(t *testing.T) {
+	const (
+		height       int64 = 10
+		commonHeight int64 = 4
+		totalVals          = 10
+		byzVals            = 4
+		maxAgeBlocks int64 = 20
+		maxAge             = 3 * time.Hour
+	)
+	attackTime := defaultEvidenceTime.Add(1 * time.Hour)
+	ev, trusted, common := makeLunaticEvidence(
+		t, height, commonHeight, totalVals, byzVals, totalVals-byzVals, defaultEvidenceTime, attackTime)
+
+	testCases := []struct {
+		name        string
+		extraBlocks int64
+		extraTime   time.Duration
+		expired     bool
+	}{
+		{"just inside both limits", 0, 0, false},
+		{"just outside the max age in blocks", 1, 0, false},
+		{"just outside the max age duration", 0, 1, false},
+		{"just outside both limits", 1, 1, true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			params := *types.DefaultConsensusParams()
+			params.Evidence.MaxAgeNumBlocks = maxAgeBlocks
+			params.Evidence.MaxAgeDuration = maxAge
+			state := sm.State{
+				LastBlockTime:   defaultEvidenceTime.Add(maxAge + tc.extraTime),
+				LastBlockHeight: commonHeight + maxAgeBlocks + tc.extraBlocks,
+				ConsensusParams: params,
+			}
+			stateStore := &smmocks.Store{}
+			stateStore.On("LoadValidators", commonHeight).Return(common.ValidatorSet, nil)
+			stateStore.On("Load").Return(state, nil)
+			blockStore := &mocks.BlockStore{}
+			blockStore.On("Base").Return(int64(1))
+			blockStore.On("LoadBlockMeta", commonHeight).Return(&types.BlockMeta{Header: *common.Header})
+			blockStore.On("LoadBlockMeta", height).Return(&types.BlockMeta{Header: *trusted.Header})
+			blockStore.On("LoadBlockCommit", commonHeight).Return(common.Commit)
+			blockStore.On("LoadBlockCommit", height).Return(trusted.Commit)
+			pool, err := evidence.NewPool(dbm.NewMemDB(), stateStore, blockStore)
+			require.NoError(t, err)
+			pool.SetLogger(log.TestingLogger())
+
+			err = pool.AddEvidence(ev)
+			if !tc.expired {
+				assert.NoError(t, err)
+				return
+			}
+			var errExpired *evidence.ErrEvidenceExpired
+			require.ErrorAs(t, err, &errExpired)
+			assert.Equal(t, commonHeight+1, errExpired.MinHeight)
+			assert.Equal(t, defaultEvidenceTime.Add(tc.extraTime), errExpired.MinTime)
+			var errInvalid *types.ErrInvalidEvidence
+			assert.False(t, errors.As(err, &errInvalid))
+		})
+	}
+}
+
+func TestVerify_LunaticAttackRejections(t *testing.T) {
+	const (
+		height       int64 = 10
+		commonHeight int64 = 4
+		totalVals          = 10
+		byzVals            = 4
+	)
+	attackTime := defaultEvidenceTime.Add(1 * time.Hour)
+	ev, trusted, common := makeLunaticEvidence(
+		t, height, commonHeight, totalVals, byzVals, totalVals-byzVals, defaultEvidenceTime, attackTime)
+
+	state := sm.State{
+		LastBlockTime:   defaultEvidenceTime.Add(2 * time.Hour),
+		LastBlockHeight: height + 1,
+		ConsensusParams: *types.DefaultConsensusParams(),
+	}
+	stateStore := &smmocks.Store{}
+	stateStore.On("LoadValidators", commonHeight).Return(common.ValidatorSet, nil)
+	stateStore.On("Load").Return(state, nil)
+	blockStore := &mocks.BlockStore{}
+	blockStore.On("Base").Return(int64(1))
+	blockStore.On("LoadBlockMeta", commonHeight).Return(&types.BlockMeta{Header: *common.Header})
+	blockStore.On("LoadBlockMeta", height).Return(nil)
+	blockStore.On("Height").Return(height)
+	blockStore.On("LoadBlockCommit", commonHeight).Return(common.Commit)
+	blockStore.On("LoadBlockCommit", height).Return(trusted.Commit)
+	pool, err := evidence.NewPool(dbm.NewMemDB(), stateStore, blockStore)
+	require.NoError(t, err)
+	pool.SetLogger(log.TestingLogger())
+
+	// without the trusted header the evidence can not be verified, but it is
+	// not invalid
+	err = pool.CheckEvidence(types.EvidenceList{ev})
+	var errUnverifiable *evidence.ErrEvidenceUnverifiable
+	assert.ErrorAs(t, err, &errUnverifiable)
+	err = pool.AddEvidence(ev)
+	assert.ErrorAs(t, err, &errUnverifiable)
+	var errInvalid *types.ErrInvalidEvidence
+	assert.False(t, errors.As(err, &errInvalid))
+
+	// a conflicting validator set disjoint from the common one is invalid
+	ev, trusted, common = makeLunaticEvidence(
+		t, height, commonHeight, totalVals, 0, totalVals, defaultEvidenceTime, attackTime)
+	stateStore = &smmocks.Store{}
+	stateStore.On("LoadValidators", commonHeight).Return(common.ValidatorSet, nil)
+	stateStore.On("Load").Return(state, nil)
+	blockStore = &mocks.BlockStore{}
+	blockStore.On("Base").Return(int64(1))
+	blockStore.On("LoadBlockMeta", commonHeight).Return(&types.BlockMeta{Header: *common.Header})
+	blockStore.On("LoadBlockMeta", height).Return(&types.BlockMeta{Header: *trusted.Header})
+	blockStore.On("LoadBlockCommit", commonHeight).Return(common.Commit)
+	blockStore.On("LoadBlockCommit", height).Return(trusted.Commit)
+	pool, err = evidence.NewPool(dbm.NewMemDB(), stateStore, blockStore)
+	require.NoError(t, err)
+	pool.SetLogger(log.TestingLogger())
+
+	assert.Empty(t, ev.GetByzantineValidators(common.ValidatorSet, trusted.SignedHeader))
+	err = pool.AddEvidence(ev)
+	assert.ErrorAs(t, err, &errInvalid)
+}
+
 func TestVerify_ForwardLunaticAttack(t *testing.T) {
 	const (
 		nodeHeight   int64 = 8
@@ -161,6 +286,7 @@ func TestVerify_ForwardLunaticAttack(t *testing.T) {
 	stateStore.On("LoadValidators", commonHeight).Return(common.ValidatorSet, nil)
 	stateStore.On("Load").Return(state, nil)
 	blockStore := &mocks.BlockStore{}
+	blockStore.On("Base").Return(int64(1))
 	blockStore.On("LoadBlockMeta", commonHeight).Return(&types.BlockMeta{Header: *common.Header})
 	blockStore.On("LoadBlockMeta", nodeHeight).Return(&types.BlockMeta{Header: *trusted.Header})
 	blockStore.On("LoadBlockMeta", attackHeight).Return(nil)
@@ -177,6 +303,7 @@ func TestVerify_ForwardLunaticAttack(t *testing.T) {
 	oldBlockStore := &mocks.BlockStore{}
 	oldHeader := trusted.Header
 	oldHeader.Time = defaultEvidenceTime
+	oldBlockStore.On("Base").Return(int64(1))
 	oldBlockStore.On("LoadBlockMeta", commonHeight).Return(&types.BlockMeta{Header: *common.Header})
 	oldBlockStore.On("LoadBlockMeta", nodeHeight).Return(&types.BlockMeta{Header: *oldHeader})
 	oldBlockStore.On("LoadBlockMeta", attackHeight).Return(nil)
@@ -260,6 +387,7 @@ func TestVerifyLightClientAttack_Equivocation(t *testing.T) {
 	stateStore.On("LoadValidators", int64(10)).Return(conflictingVals, nil)
 	stateStore.On("Load").Return(state, nil)
 	blockStore := &mocks.BlockStore{}
+	blockStore.On("Base").Return(int64(1))
 	blockStore.On("LoadBlockMeta", int64(10)).Return(&types.BlockMeta{Header: *trustedHeader})
 	blockStore.On("LoadBlockCommit", int64(10)).Return(trustedCommit)
 
@@ -335,6 +463,7 @@ func TestVerifyLightClientAttack_Amnesia(t *testing.T) {
 	stateStore.On("LoadValidators", int64(10)).Return(conflictingVals, nil)
 	stateStore.On("Load").Return(state, nil)
 	blockStore := &mocks.BlockStore{}
+	blockStore.On("Base").Return(int64(1))
 	blockStore.On("LoadBlockMeta", int64(10)).Return(&types.BlockMeta{Header: *trustedHeader})
 	blockStore.On("LoadBlockCommit", int64(10)).Return(trustedCommit)
 
@@ -429,6 +558,7 @@ func TestVerifyDuplicateVoteEvidence(t *testing.T) {
 	stateStore.On("LoadValidators", int64(10)).Return(valSet, nil)
 	stateStore.On("Load").Return(state, nil)
 	blockStore := &mocks.BlockStore{}
+	blockStore.On("Base").Return(int64(1))
 	blockStore.On("LoadBlockMeta", int64(10)).Return(&types.BlockMeta{Header: types.Header{Time: defaultEvidenceTime}})
 
 	pool, err := evidence.NewPool(dbm.NewMemDB(), stateStore, blockStore)
@@ -52,6 +52,7 @@ require (
 	go.opentelemetry.io/otel/sdk v1.21.0
 	golang.org/x/crypto v0.21.0
 	golang.org/x/net v0.23.0
+	golang.org/x/sys v0.18.0
 	gonum.org/v1/gonum v0.12.0
 	google.golang.org/grpc v1.60.0
 	google.golang.org/protobuf v1.31.0
@@ -284,7 +285,6 @@ require (
 	golang.org/x/exp/typeparams v0.0.0-20230307190834-24139beb5833 // indirect
 	golang.org/x/mod v0.12.0 // indirect
 	golang.org/x/sync v0.5.0 // indirect
-	golang.org/x/sys v0.18.0 // indirect
 	golang.org/x/term v0.18.0 // indirect
 	golang.org/x/text v0.14.0 // indirect
 	golang.org/x/tools v0.13.0 // indirect
@@ -0,0 +1,49 @@
+package log
+
+import (
+	"strings"
+	"sync"
+)
+
+// RingWriter is an io.Writer keeping the last lines written to it, so that a
+// node can report its recent logs, e.g. in a debug bundle. Each Write is
+// expected to hold one log record, as written by the loggers of this package.
+// It is safe for concurrent use.
+type RingWriter struct {
+	mtx   sync.Mutex
+	lines []string
+	next  int // index of the oldest line once the ring is full
+}
+
+// NewRingWriter returns a RingWriter keeping the last size lines. It panics if
+// size is not positive.
+func NewRingWriter(size int) *RingWriter {
+	if size <= 0 {
+		panic("ring writer size must be positive")
+	}
+	return &RingWriter{lines: make([]string, 0, size)}
+}
+
+// Write implements io.Writer.
+func (w *RingWriter) Write(p []byte) (int, error) {
+	line := strings.TrimRight(string(p), "\n")
+
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+	if len(w.lines) < cap(w.lines) {
+		w.lines = append(w.lines, line)
+	} else {
+		w.lines[w.next] = line
+		w.next = (w.next + 1) % len(w.lines)
+	}
+	return len(p), nil
+}
+
+// Lines returns the lines kept, from the oldest to the newest.
+func (w *RingWriter) Lines() []string {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+	lines := make([]string, 0, len(w.lines))
+	lines = append(lines, w.lines[w.next:]...)
+	return append(lines, w.lines[:w.next]...)
+}
@@ -0,0 +1,46 @@
+package log_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+func TestRingWriter(t *testing.T) {
+	ring := log.NewRingWriter(3)
+	assert.Empty(t, ring.Lines())
+
+	logger := log.NewTMLogger(ring)
+	for i := 0; i < 5; i++ {
+		logger.Info(fmt.Sprintf("line %d", i))
+	}
+	lines := ring.Lines()
+	require.Len(t, lines, 3)
+	for i, line := range lines {
+		assert.Contains(t, line, fmt.Sprintf("line %d", i+2))
+		assert.False(t, strings.HasSuffix(line, "\n"))
+	}
+
+	assert.Panics(t, func() { log.NewRingWriter(0) })
+}
+
+func TestTeeLogger(t *testing.T) {
+	first, second := log.NewRingWriter(10), log.NewRingWriter(10)
+	logger := log.NewTeeLogger(
+		log.NewTMLogger(first),
+		log.NewFilter(log.NewTMLogger(second), log.AllowError()),
+	).With("module", "test")
+
+	logger.Info("info")
+	logger.Error("error")
+
+	require.Len(t, first.Lines(), 2)
+	assert.Contains(t, first.Lines()[0], "module=test")
+	require.Len(t, second.Lines(), 1)
+	assert.Contains(t, second.Lines()[0], "error")
+}
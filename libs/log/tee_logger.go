@@ -0,0 +1,35 @@
+package log
+
+// NewTeeLogger returns a logger passing every record on to all the given
+// loggers.
+func NewTeeLogger(loggers ...Logger) Logger {
+	return teeLogger(loggers)
+}
+
+type teeLogger []Logger
+
+func (l teeLogger) Debug(msg string, keyvals ...interface{}) {
+	for _, logger := range l {
+		logger.Debug(msg, keyvals...)
+	}
+}
+
+func (l teeLogger) Info(msg string, keyvals ...interface{}) {
+	for _, logger := range l {
+		logger.Info(msg, keyvals...)
+	}
+}
+
+func (l teeLogger) Error(msg string, keyvals ...interface{}) {
+	for _, logger := range l {
+		logger.Error(msg, keyvals...)
+	}
+}
+
+func (l teeLogger) With(keyvals ...interface{}) Logger {
+	loggers := make(teeLogger, len(l))
+	for i, logger := range l {
+		loggers[i] = logger.With(keyvals...)
+	}
+	return loggers
+}
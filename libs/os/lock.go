@@ -0,0 +1,140 @@
+package os
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// errLockHeld is returned by lockFile when the lock is held by another open
+// file.
+var errLockHeld = errors.New("lock held")
+
+// LockedError is returned by LockFile when the lock is held by another
+// process.
+type LockedError struct {
+	Path string
+	// PID is the pid of the process holding the lock, or 0 if unknown.
+	PID int
+	// Stale is true if the process holding the lock is not running anymore,
+	// see BreakLock.
+	Stale bool
+}
+
+func (e *LockedError) Error() string {
+	switch {
+	case e.PID == 0:
+		return fmt.Sprintf("another process holds the lock %s", e.Path)
+	case e.PID == os.Getpid():
+		return fmt.Sprintf("this process already holds the lock %s", e.Path)
+	case e.Stale:
+		return fmt.Sprintf("the lock %s is held by pid %d, which is not running anymore", e.Path, e.PID)
+	default:
+		return fmt.Sprintf("another process holds the lock %s (pid %d)", e.Path, e.PID)
+	}
+}
+
+// FileLock is an exclusive advisory lock on a file, which records the pid of
+// its holder. It is released by the OS when the holder exits, even if it
+// crashed.
+type FileLock struct {
+	file *os.File
+	// StalePID is the pid of the previous holder of the lock if it exited
+	// without releasing it, e.g. because it crashed, or 0.
+	StalePID int
+}
+
+// LockFile acquires an exclusive advisory lock on the file at path, which is
+// created if it does not exist, and records the pid of this process in it. It
+// does not wait: a *LockedError is returned if the lock is held by another
+// process, or by another FileLock of this process.
+//
+// The lock is a flock on unix and a LockFileEx on windows, and is not
+// supported on the other platforms, where it always succeeds.
+func LockFile(path string) (*FileLock, error) {
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	if err := lockFile(f); err != nil {
+		f.Close()
+		if !errors.Is(err, errLockHeld) {
+			return nil, fmt.Errorf("failed to lock %s: %w", path, err)
+		}
+		pid := readLockPID(path)
+		return nil, &LockedError{
+			Path:  path,
+			PID:   pid,
+			Stale: pid != 0 && pid != os.Getpid() && !ProcessAlive(pid),
+		}
+	}
+
+	// the pid is cleared on release, so the one of a crashed holder remains
+	l := &FileLock{file: f, StalePID: readLockPID(path)}
+	if err := l.writePID(os.Getpid()); err != nil {
+		_ = unlockFile(f)
+		f.Close()
+		return nil, fmt.Errorf("failed to write the pid to %s: %w", path, err)
+	}
+	return l, nil
+}
+
+func (l *FileLock) writePID(pid int) error {
+	if err := l.file.Truncate(0); err != nil {
+		return err
+	}
+	if pid != 0 {
+		if _, err := l.file.WriteAt([]byte(strconv.Itoa(pid)+"\n"), 0); err != nil {
+			return err
+		}
+	}
+	return l.file.Sync()
+}
+
+// Release clears the pid recorded in the lock file and releases the lock. The
+// file is not removed, as another process may be waiting on it.
+func (l *FileLock) Release() error {
+	err := l.writePID(0)
+	if uerr := unlockFile(l.file); uerr != nil && err == nil {
+		err = uerr
+	}
+	if cerr := l.file.Close(); cerr != nil && err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// BreakLock removes the lock file at path if the process holding the lock is
+// not running anymore, so that the next LockFile succeeds. This only happens
+// when the lock outlives its holder, e.g. on a network filesystem. It returns
+// a *LockedError if the holder is running or unknown, and does nothing if the
+// lock is not held.
+//
+// The liveness of the holder is checked by pid, so the lock must not be shared
+// across pid namespaces, e.g. by containers mounting the same directory.
+func BreakLock(path string) error {
+	l, err := LockFile(path)
+	if err == nil {
+		return l.Release()
+	}
+	var lerr *LockedError
+	if !errors.As(err, &lerr) || !lerr.Stale {
+		return err
+	}
+	return os.Remove(path)
+}
+
+// readLockPID returns the pid recorded in the lock file at path, or 0 if none.
+func readLockPID(path string) int {
+	bz, err := os.ReadFile(path)
+	if err != nil {
+		return 0
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(bz)))
+	if err != nil || pid < 0 {
+		return 0
+	}
+	return pid
+}
@@ -0,0 +1,140 @@
+package os
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const lockPathEnv = "CMT_TEST_LOCK_PATH"
+
+// TestLockerProcess is run by the competing locker processes spawned by the
+// other tests: it tries to acquire the lock, reports the outcome, and holds the
+// lock until its stdin is closed.
+func TestLockerProcess(t *testing.T) {
+	path := os.Getenv(lockPathEnv)
+	if path == "" {
+		t.Skip("only run as a locker process")
+	}
+	l, err := LockFile(path)
+	var lerr *LockedError
+	switch {
+	case errors.As(err, &lerr):
+		fmt.Printf("busy %d\n", lerr.PID)
+		return
+	case err != nil:
+		fmt.Printf("error %v\n", err)
+		return
+	}
+	fmt.Println("locked")
+	_, _ = io.Copy(io.Discard, os.Stdin)
+	require.NoError(t, l.Release())
+}
+
+type lockerProcess struct {
+	cmd    *exec.Cmd
+	stdin  io.WriteCloser
+	result string
+}
+
+func startLocker(t *testing.T, path string) *lockerProcess {
+	cmd := exec.Command(os.Args[0], "-test.run=^TestLockerProcess$")
+	cmd.Env = append(os.Environ(), lockPathEnv+"="+path)
+	stdin, err := cmd.StdinPipe()
+	require.NoError(t, err)
+	stdout, err := cmd.StdoutPipe()
+	require.NoError(t, err)
+	require.NoError(t, cmd.Start())
+	t.Cleanup(func() {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+	})
+	p := &lockerProcess{cmd: cmd, stdin: stdin}
+	line, err := bufio.NewReader(stdout).ReadString('\n')
+	require.NoError(t, err)
+	p.result = strings.TrimSpace(line)
+	return p
+}
+
+func TestLockFileCompetingProcesses(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.lock")
+
+	holder := startLocker(t, path)
+	require.Equal(t, "locked", holder.result)
+	pid := holder.cmd.Process.Pid
+
+	// the other lockers are turned away, with the pid of the holder
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, fmt.Sprintf("busy %d", pid), startLocker(t, path).result)
+	}
+	_, err := LockFile(path)
+	var lerr *LockedError
+	require.ErrorAs(t, err, &lerr)
+	assert.Equal(t, LockedError{Path: path, PID: pid}, *lerr)
+	assert.Contains(t, err.Error(), fmt.Sprintf("(pid %d)", pid))
+
+	// the lock of a running holder cannot be broken
+	require.ErrorAs(t, BreakLock(path), &lerr)
+
+	// a holder releasing the lock clears its pid
+	require.NoError(t, holder.stdin.Close())
+	require.NoError(t, holder.cmd.Wait())
+	l, err := LockFile(path)
+	require.NoError(t, err)
+	assert.Zero(t, l.StalePID)
+	require.NoError(t, l.Release())
+
+	// the lock of a crashed holder is released, and its pid reported
+	holder = startLocker(t, path)
+	require.Equal(t, "locked", holder.result)
+	require.NoError(t, holder.cmd.Process.Kill())
+	_ = holder.cmd.Wait()
+	l, err = LockFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, holder.cmd.Process.Pid, l.StalePID)
+
+	_, err = LockFile(path)
+	require.ErrorAs(t, err, &lerr)
+	assert.Contains(t, err.Error(), "this process already holds")
+	require.NoError(t, l.Release())
+}
+
+func TestBreakStaleLock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.lock")
+
+	// a process that is not running anymore
+	cmd := exec.Command(os.Args[0], "-test.run=^$")
+	require.NoError(t, cmd.Run())
+	deadPID := cmd.Process.Pid
+	require.False(t, ProcessAlive(deadPID))
+	require.True(t, ProcessAlive(os.Getpid()))
+
+	// a lock outliving its holder
+	stale, err := LockFile(path)
+	require.NoError(t, err)
+	require.NoError(t, stale.writePID(deadPID))
+	defer stale.Release()
+
+	_, err = LockFile(path)
+	var lerr *LockedError
+	require.ErrorAs(t, err, &lerr)
+	assert.Equal(t, LockedError{Path: path, PID: deadPID, Stale: true}, *lerr)
+
+	require.NoError(t, BreakLock(path))
+	l, err := LockFile(path)
+	require.NoError(t, err)
+	require.NoError(t, l.Release())
+
+	// breaking a lock that is not held does nothing
+	require.NoError(t, BreakLock(path))
+	assert.FileExists(t, path)
+}
@@ -93,10 +93,15 @@ type Server struct {
 	cmds    chan cmd
 	cmdsCap int
 
+	// cmdsMtx serializes the subscribe and unsubscribe commands with the
+	// updates of the subscriptions below, so that the loop receives them in
+	// the order the subscriptions were updated.
+	cmdsMtx cmtsync.Mutex
+
 	// check if we have subscription before
 	// subscribing or unsubscribing
 	mtx           cmtsync.RWMutex
-	subscriptions map[string]map[string]struct{} // subscriber -> query (string) -> empty struct
+	subscriptions map[string]map[string]*Subscription // subscriber -> query (string) -> subscription
 }
 
 // Option sets a parameter for the server.
@@ -107,7 +112,7 @@ type Option func(*Server)
 // provided, the resulting server's queue is unbuffered.
 func NewServer(options ...Option) *Server {
 	s := &Server{
-		subscriptions: make(map[string]map[string]struct{}),
+		subscriptions: make(map[string]map[string]*Subscription),
 	}
 	s.BaseService = *service.NewBaseService(nil, "PubSub", s)
 
@@ -170,24 +175,22 @@ func (s *Server) SubscribeUnbuffered(ctx context.Context, clientID string, query
 }
 
 func (s *Server) subscribe(ctx context.Context, clientID string, query Query, outCapacity int) (*Subscription, error) {
-	s.mtx.RLock()
-	clientSubscriptions, ok := s.subscriptions[clientID]
-	if ok {
-		_, ok = clientSubscriptions[query.String()]
-	}
-	s.mtx.RUnlock()
-	if ok {
+	s.cmdsMtx.Lock()
+	defer s.cmdsMtx.Unlock()
+
+	if s.lookup(clientID, query.String()) != nil {
 		return nil, ErrAlreadySubscribed
 	}
 
 	subscription := NewSubscription(outCapacity)
+	subscription.query = query.String()
 	select {
 	case s.cmds <- cmd{op: sub, clientID: clientID, query: query, subscription: subscription}:
 		s.mtx.Lock()
-		if _, ok = s.subscriptions[clientID]; !ok {
-			s.subscriptions[clientID] = make(map[string]struct{})
+		if _, ok := s.subscriptions[clientID]; !ok {
+			s.subscriptions[clientID] = make(map[string]*Subscription)
 		}
-		s.subscriptions[clientID][query.String()] = struct{}{}
+		s.subscriptions[clientID][query.String()] = subscription
 		s.mtx.Unlock()
 		return subscription, nil
 	case <-ctx.Done():
@@ -201,24 +204,38 @@ func (s *Server) subscribe(ctx context.Context, clientID string, query Query, ou
 // returned to the caller if the context is canceled or if subscription does
 // not exist.
 func (s *Server) Unsubscribe(ctx context.Context, clientID string, query Query) error {
-	s.mtx.RLock()
-	clientSubscriptions, ok := s.subscriptions[clientID]
-	if ok {
-		_, ok = clientSubscriptions[query.String()]
+	s.cmdsMtx.Lock()
+	defer s.cmdsMtx.Unlock()
+
+	subscription := s.lookup(clientID, query.String())
+	if subscription == nil {
+		return ErrSubscriptionNotFound
 	}
-	s.mtx.RUnlock()
-	if !ok {
+	return s.unsubscribe(ctx, clientID, subscription)
+}
+
+// UnsubscribeOwned removes the subscription of the client, provided it is
+// still the subscription of the client to its query. Unlike Unsubscribe, it
+// never removes a newer subscription of the client to the same query, so it
+// can be used by the owner of a subscription to release it however late. An
+// error will be returned to the caller if the context is canceled or if the
+// subscription is not active anymore.
+func (s *Server) UnsubscribeOwned(ctx context.Context, clientID string, subscription *Subscription) error {
+	s.cmdsMtx.Lock()
+	defer s.cmdsMtx.Unlock()
+
+	if s.lookup(clientID, subscription.query) != subscription {
 		return ErrSubscriptionNotFound
 	}
+	return s.unsubscribe(ctx, clientID, subscription)
+}
 
+// unsubscribe removes the subscription of the client. The caller must hold
+// cmdsMtx.
+func (s *Server) unsubscribe(ctx context.Context, clientID string, subscription *Subscription) error {
 	select {
-	case s.cmds <- cmd{op: unsub, clientID: clientID, query: query}:
-		s.mtx.Lock()
-		delete(clientSubscriptions, query.String())
-		if len(clientSubscriptions) == 0 {
-			delete(s.subscriptions, clientID)
-		}
-		s.mtx.Unlock()
+	case s.cmds <- cmd{op: unsub, clientID: clientID, subscription: subscription}:
+		s.forget(clientID, subscription)
 		return nil
 	case <-ctx.Done():
 		return ctx.Err()
@@ -230,6 +247,9 @@ func (s *Server) Unsubscribe(ctx context.Context, clientID string, query Query)
 // UnsubscribeAll removes all client subscriptions. An error will be returned
 // to the caller if the context is canceled or if subscription does not exist.
 func (s *Server) UnsubscribeAll(ctx context.Context, clientID string) error {
+	s.cmdsMtx.Lock()
+	defer s.cmdsMtx.Unlock()
+
 	s.mtx.RLock()
 	_, ok := s.subscriptions[clientID]
 	s.mtx.RUnlock()
@@ -250,6 +270,35 @@ func (s *Server) UnsubscribeAll(ctx context.Context, clientID string) error {
 	}
 }
 
+// lookup returns the active subscription of the client to the query, or nil
+// if there is none.
+func (s *Server) lookup(clientID, qStr string) *Subscription {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	subscription := s.subscriptions[clientID][qStr]
+	if subscription == nil || subscription.isCancelled() {
+		return nil
+	}
+	return subscription
+}
+
+// forget removes the subscription of the client, provided it is still the
+// subscription of the client to its query.
+func (s *Server) forget(clientID string, subscription *Subscription) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	clientSubscriptions := s.subscriptions[clientID]
+	if clientSubscriptions[subscription.query] != subscription {
+		return
+	}
+	delete(clientSubscriptions, subscription.query)
+	if len(clientSubscriptions) == 0 {
+		delete(s.subscriptions, clientID)
+	}
+}
+
 // NumClients returns the number of clients.
 func (s *Server) NumClients() int {
 	s.mtx.RLock()
@@ -264,6 +313,17 @@ func (s *Server) NumClientSubscriptions(clientID string) int {
 	return len(s.subscriptions[clientID])
 }
 
+// NumSubscriptions returns the number of subscriptions of all the clients.
+func (s *Server) NumSubscriptions() int {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	n := 0
+	for _, clientSubscriptions := range s.subscriptions {
+		n += len(clientSubscriptions)
+	}
+	return n
+}
+
 // Publish publishes the given message. An error will be returned to the caller
 // if the context is canceled.
 func (s *Server) Publish(ctx context.Context, msg interface{}) error {
@@ -323,8 +383,8 @@ loop:
 	for cmd := range s.cmds {
 		switch cmd.op {
 		case unsub:
-			if cmd.query != nil {
-				state.remove(cmd.clientID, cmd.query.String(), ErrUnsubscribed)
+			if cmd.subscription != nil {
+				state.removeSubscription(cmd.clientID, cmd.subscription, ErrUnsubscribed)
 			} else {
 				state.removeClient(cmd.clientID, ErrUnsubscribed)
 			}
@@ -334,7 +394,9 @@ loop:
 		case sub:
 			state.add(cmd.clientID, cmd.query, cmd.subscription)
 		case pub:
-			if err := state.send(cmd.msg, cmd.events); err != nil {
+			// the subscriptions removed for being out of capacity are
+			// forgotten, so that their clients may subscribe again
+			if err := state.send(cmd.msg, cmd.events, s.forget); err != nil {
 				s.Logger.Error("Error querying for events", "err", err)
 			}
 		}
@@ -387,6 +449,15 @@ func (state *state) remove(clientID string, qStr string, reason error) {
 	}
 }
 
+// removeSubscription removes the subscription of the client, provided it is
+// still the subscription of the client to its query.
+func (state *state) removeSubscription(clientID string, subscription *Subscription, reason error) {
+	if state.subscriptions[subscription.query][clientID] != subscription {
+		return
+	}
+	state.remove(clientID, subscription.query, reason)
+}
+
 func (state *state) removeClient(clientID string, reason error) {
 	for qStr, clientSubscriptions := range state.subscriptions {
 		if _, ok := clientSubscriptions[clientID]; ok {
@@ -403,7 +474,14 @@ func (state *state) removeAll(reason error) {
 	}
 }
 
-func (state *state) send(msg interface{}, events map[string][]string) error {
+// send pushes the message to the subscriptions whose query matches the events.
+// The buffered subscriptions which are out of capacity are passed to
+// onOutOfCapacity, then removed.
+func (state *state) send(
+	msg interface{},
+	events map[string][]string,
+	onOutOfCapacity func(clientID string, subscription *Subscription),
+) error {
 	for qStr, clientSubscriptions := range state.subscriptions {
 		q := state.queries[qStr].q
 
@@ -422,6 +500,7 @@ func (state *state) send(msg interface{}, events map[string][]string) error {
 					select {
 					case subscription.out <- NewMessage(msg, events):
 					default:
+						onOutOfCapacity(clientID, subscription)
 						state.remove(clientID, qStr, ErrOutOfCapacity)
 					}
 				}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"runtime/debug"
+	"sync"
 	"testing"
 	"time"
 
@@ -147,6 +148,11 @@ func TestSlowClientIsRemovedWithErrOutOfCapacity(t *testing.T) {
 	require.NoError(t, err)
 
 	assertCancelled(t, subscription, pubsub.ErrOutOfCapacity)
+
+	// the client may subscribe again
+	assert.Zero(t, s.NumClients())
+	_, err = s.Subscribe(ctx, clientID, query.Empty{})
+	require.NoError(t, err)
 }
 
 func TestDifferentClients(t *testing.T) {
@@ -191,6 +197,12 @@ func TestDifferentClients(t *testing.T) {
 	err = s.PublishWithEvents(ctx, "Valeria Richards", map[string][]string{"tm.events.type": {"NewRoundStep"}})
 	require.NoError(t, err)
 	assert.Zero(t, len(subscription3.Out()))
+
+	assert.Equal(t, 3, s.NumClients())
+	assert.Equal(t, 3, s.NumSubscriptions())
+	_, err = s.Subscribe(ctx, "client-1", query.MustParse("tm.events.type='NewRoundStep'"))
+	require.NoError(t, err)
+	assert.Equal(t, 4, s.NumSubscriptions())
 }
 
 func TestSubscribeDuplicateKeys(t *testing.T) {
@@ -377,6 +389,97 @@ func TestUnsubscribeAll(t *testing.T) {
 	assertCancelled(t, subscription2, pubsub.ErrUnsubscribed)
 }
 
+func TestUnsubscribeOwned(t *testing.T) {
+	s := pubsub.NewServer()
+	s.SetLogger(log.TestingLogger())
+	err := s.Start()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := s.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	ctx := context.Background()
+	q := query.MustParse("tm.events.type='NewBlock'")
+	subscription1, err := s.Subscribe(ctx, clientID, q)
+	require.NoError(t, err)
+	require.NoError(t, s.Unsubscribe(ctx, clientID, q))
+	subscription2, err := s.Subscribe(ctx, clientID, q)
+	require.NoError(t, err)
+
+	// the stale subscription does not remove the newer one
+	err = s.UnsubscribeOwned(ctx, clientID, subscription1)
+	assert.Equal(t, pubsub.ErrSubscriptionNotFound, err)
+	assert.Equal(t, 1, s.NumClientSubscriptions(clientID))
+	err = s.UnsubscribeOwned(ctx, "other-client", subscription2)
+	assert.Equal(t, pubsub.ErrSubscriptionNotFound, err)
+
+	require.NoError(t, s.UnsubscribeOwned(ctx, clientID, subscription2))
+	assertCancelled(t, subscription2, pubsub.ErrUnsubscribed)
+	assert.Zero(t, s.NumClients())
+}
+
+func TestSubscriptionChurn(t *testing.T) {
+	s := pubsub.NewServer(pubsub.BufferCapacity(10))
+	s.SetLogger(log.TestingLogger())
+	err := s.Start()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := s.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	ctx := context.Background()
+	stop := make(chan struct{})
+	published := make(chan struct{})
+	go func() {
+		defer close(published)
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+				assert.NoError(t, s.Publish(ctx, "Hulk"))
+			}
+		}
+	}()
+
+	// clients subscribe and unsubscribe concurrently, so that the commands of
+	// a client interleave
+	const clients, cycles = 4, 500
+	subscriptions := make(chan *pubsub.Subscription, 2*clients*cycles)
+	var wg sync.WaitGroup
+	for i := 0; i < 2*clients; i++ {
+		wg.Add(1)
+		go func(client string) {
+			defer wg.Done()
+			for j := 0; j < cycles; j++ {
+				subscription, err := s.Subscribe(ctx, client, query.Empty{}, 1000)
+				if err == nil {
+					subscriptions <- subscription
+				}
+				_ = s.UnsubscribeAll(ctx, client)
+			}
+		}(fmt.Sprintf("client-%d", i%clients))
+	}
+	wg.Wait()
+	close(stop)
+	<-published
+	close(subscriptions)
+
+	// every subscription ever made has been removed
+	assert.Zero(t, s.NumClients())
+	for subscription := range subscriptions {
+		select {
+		case <-subscription.Cancelled():
+		case <-time.After(time.Second):
+			t.Fatal("a subscription is still active after its client unsubscribed")
+		}
+	}
+}
+
 func TestBufferCapacity(t *testing.T) {
 	s := pubsub.NewServer(pubsub.BufferCapacity(2))
 	s.SetLogger(log.TestingLogger())
@@ -7,26 +7,26 @@
 // More: https://github.com/PhilippeSigaud/Pegged/wiki/PEG-Basics
 //
 // It has a support for numbers (integer and floating point), dates and times.
+//
+// Numbers are compared to the values without a denomination. The values with a
+// denomination, like "1000000utia", are compared by naming the denomination
+// after the tag, e.g. transfer.amount[utia] >= 1000000, so that amounts of
+// distinct denominations are never compared to each other.
 package query
 
 import (
 	"fmt"
 	"math/big"
-	"reflect"
-	"regexp"
 	"strconv"
 	"strings"
 	"time"
 )
 
-var (
-	numRegex = regexp.MustCompile(`([0-9\.]+)`)
-)
-
-// Query holds the query string and the query parser.
+// Query holds the query string and its conditions, parsed once so that
+// matching events does not parse the query again.
 type Query struct {
-	str    string
-	parser *QueryParser
+	str        string
+	conditions []Condition
 }
 
 // Condition represents a single condition within a query and consists of composite key
@@ -35,6 +35,10 @@ type Condition struct {
 	CompositeKey string
 	Op           Operator
 	Operand      interface{}
+	// Denom is the denomination of the values a numeric operand is compared
+	// to, e.g. "utia" for "transfer.amount[utia] > 5", empty for the values
+	// without one.
+	Denom string
 }
 
 // New parses the given string and returns a query or error if the string is
@@ -45,7 +49,77 @@ func New(s string) (*Query, error) {
 	if err := p.Parse(); err != nil {
 		return nil, err
 	}
-	return &Query{str: s, parser: p}, nil
+	// this also rejects the denominations the conditions can't compare
+	conditions, err := parseConditions(p)
+	if err != nil {
+		return nil, err
+	}
+	return &Query{str: s, conditions: conditions}, nil
+}
+
+// ParseNumericValue splits an event attribute value that looks numeric into
+// its number and its denomination, e.g. "1000000utia" into "1000000" and
+// "utia". The denomination is empty if the value has none. It returns false if
+// the value does not look numeric.
+func ParseNumericValue(value string) (number, denom string, ok bool) {
+	i := 0
+	if i < len(value) && value[i] == '-' {
+		i++
+	}
+	digits := i
+	for i < len(value) && isDigit(value[i]) {
+		i++
+	}
+	if i == digits {
+		return "", "", false
+	}
+	if i < len(value) && value[i] == '.' {
+		i++
+		for i < len(value) && isDigit(value[i]) {
+			i++
+		}
+	}
+	number, denom = value[:i], value[i:]
+	if denom != "" && !isDenomination(denom) {
+		return "", "", false
+	}
+	return number, denom, true
+}
+
+// splitDenomination splits a tag into the composite key and the denomination
+// it names, if any, e.g. "transfer.amount[utia]" into "transfer.amount" and
+// "utia".
+func splitDenomination(tag string) (compositeKey, denom string) {
+	if !strings.HasSuffix(tag, "]") {
+		return tag, ""
+	}
+	i := strings.LastIndexByte(tag, '[')
+	if i < 1 || !isDenomination(tag[i+1:len(tag)-1]) {
+		return tag, ""
+	}
+	return tag[:i], tag[i+1 : len(tag)-1]
+}
+
+// isDenomination returns true if s is a denomination: a letter followed by
+// letters, digits, and any of "/:._-".
+func isDenomination(s string) bool {
+	if s == "" || !isLetter(s[0]) {
+		return false
+	}
+	for i := 1; i < len(s); i++ {
+		if c := s[i]; !isLetter(c) && !isDigit(c) && !strings.ContainsRune("/:._-", rune(c)) {
+			return false
+		}
+	}
+	return true
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+func isLetter(c byte) bool {
+	return ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')
 }
 
 // MustParse turns the given string into a query or panics; for tests or others
@@ -63,6 +137,37 @@ func (q *Query) String() string {
 	return q.str
 }
 
+// Normalize returns the query string with the spaces outside of quoted values
+// canonicalized: they are removed at both ends and around comparison
+// operators, and every other run of spaces is replaced with a single space.
+// Valid queries that only differ by such spaces, like "tm.event = 'NewBlock'"
+// and "tm.event='NewBlock'", have the same normalized string.
+func Normalize(s string) string {
+	isOpChar := func(c byte) bool { return c == '=' || c == '<' || c == '>' }
+
+	var (
+		out     = make([]byte, 0, len(s))
+		quoted  bool
+		inSpace bool
+	)
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if !quoted && c == ' ' {
+			inSpace = true
+			continue
+		}
+		if inSpace && len(out) > 0 && !isOpChar(c) && !isOpChar(out[len(out)-1]) {
+			out = append(out, ' ')
+		}
+		inSpace = false
+		if c == '\'' {
+			quoted = !quoted
+		}
+		out = append(out, c)
+	}
+	return string(out)
+}
+
 // Operator is an operator that defines some kind of relation between composite key and
 // operand (equality, etc.).
 type Operator uint8
@@ -94,22 +199,46 @@ const (
 // Conditions returns a list of conditions. It returns an error if there is any
 // error with the provided grammar in the Query.
 func (q *Query) Conditions() ([]Condition, error) {
+	conditions := make([]Condition, len(q.conditions))
+	copy(conditions, q.conditions)
+	return conditions, nil
+}
+
+// parseConditions returns the conditions of the query parsed by p.
+func parseConditions(p *QueryParser) ([]Condition, error) {
 	var (
 		eventAttr string
+		denom     string
 		op        Operator
 	)
 
 	conditions := make([]Condition, 0)
-	buffer, begin, end := q.parser.Buffer, 0, 0
+	buffer, begin, end := p.Buffer, 0, 0
+	// the denominations of the numeric conditions by composite key, which
+	// must agree, see checkDenom
+	denoms := make(map[string]string)
+	checkDenom := func(numeric bool) error {
+		if !numeric {
+			if denom != "" {
+				return fmt.Errorf("%s[%s]: only numbers can be compared to denominated values", eventAttr, denom)
+			}
+			return nil
+		}
+		if other, ok := denoms[eventAttr]; ok && other != denom {
+			return fmt.Errorf("%s: conditions on distinct denominations %q and %q", eventAttr, other, denom)
+		}
+		denoms[eventAttr] = denom
+		return nil
+	}
 
 	// tokens must be in the following order: tag ("tx.gas") -> operator ("=") -> operand ("7")
-	for token := range q.parser.Tokens() {
+	for token := range p.Tokens() {
 		switch token.pegRule {
 		case rulePegText:
 			begin, end = int(token.begin), int(token.end)
 
 		case ruletag:
-			eventAttr = buffer[begin:end]
+			eventAttr, denom = splitDenomination(buffer[begin:end])
 
 		case rulele:
 			op = OpLessEqual
@@ -131,14 +260,23 @@ func (q *Query) Conditions() ([]Condition, error) {
 
 		case ruleexists:
 			op = OpExists
-			conditions = append(conditions, Condition{eventAttr, op, nil})
+			if err := checkDenom(false); err != nil {
+				return nil, err
+			}
+			conditions = append(conditions, Condition{CompositeKey: eventAttr, Op: op})
 
 		case rulevalue:
+			if err := checkDenom(false); err != nil {
+				return nil, err
+			}
 			// strip single quotes from value (i.e. "'NewBlock'" -> "NewBlock")
 			valueWithoutSingleQuotes := buffer[begin+1 : end-1]
-			conditions = append(conditions, Condition{eventAttr, op, valueWithoutSingleQuotes})
+			conditions = append(conditions, Condition{CompositeKey: eventAttr, Op: op, Operand: valueWithoutSingleQuotes})
 
 		case rulenumber:
+			if err := checkDenom(true); err != nil {
+				return nil, err
+			}
 			number := buffer[begin:end]
 			if strings.ContainsAny(number, ".") { // if it looks like a floating-point number
 				value, err := strconv.ParseFloat(number, 64)
@@ -150,7 +288,7 @@ func (q *Query) Conditions() ([]Condition, error) {
 					return nil, err
 				}
 
-				conditions = append(conditions, Condition{eventAttr, op, value})
+				conditions = append(conditions, Condition{CompositeKey: eventAttr, Op: op, Operand: value, Denom: denom})
 			} else {
 				valueBig := new(big.Int)
 				_, ok := valueBig.SetString(number, 10)
@@ -161,11 +299,14 @@ func (q *Query) Conditions() ([]Condition, error) {
 					)
 					return nil, err
 				}
-				conditions = append(conditions, Condition{eventAttr, op, valueBig})
+				conditions = append(conditions, Condition{CompositeKey: eventAttr, Op: op, Operand: valueBig, Denom: denom})
 
 			}
 
 		case ruletime:
+			if err := checkDenom(false); err != nil {
+				return nil, err
+			}
 			value, err := time.Parse(TimeLayout, buffer[begin:end])
 			if err != nil {
 				err = fmt.Errorf(
@@ -175,9 +316,12 @@ func (q *Query) Conditions() ([]Condition, error) {
 				return nil, err
 			}
 
-			conditions = append(conditions, Condition{eventAttr, op, value})
+			conditions = append(conditions, Condition{CompositeKey: eventAttr, Op: op, Operand: value})
 
 		case ruledate:
+			if err := checkDenom(false); err != nil {
+				return nil, err
+			}
 			value, err := time.Parse("2006-01-02", buffer[begin:end])
 			if err != nil {
 				err = fmt.Errorf(
@@ -187,7 +331,7 @@ func (q *Query) Conditions() ([]Condition, error) {
 				return nil, err
 			}
 
-			conditions = append(conditions, Condition{eventAttr, op, value})
+			conditions = append(conditions, Condition{CompositeKey: eventAttr, Op: op, Operand: value})
 		}
 	}
 
@@ -206,181 +350,51 @@ func (q *Query) Matches(events map[string][]string) (bool, error) {
 		return false, nil
 	}
 
-	var (
-		eventAttr string
-		op        Operator
-	)
-
-	buffer, begin, end := q.parser.Buffer, 0, 0
-
-	// tokens must be in the following order:
-
-	// tag ("tx.gas") -> operator ("=") -> operand ("7")
-	for token := range q.parser.Tokens() {
-		switch token.pegRule {
-		case rulePegText:
-			begin, end = int(token.begin), int(token.end)
-
-		case ruletag:
-			eventAttr = buffer[begin:end]
-
-		case rulele:
-			op = OpLessEqual
-
-		case rulege:
-			op = OpGreaterEqual
-
-		case rulel:
-			op = OpLess
-
-		case ruleg:
-			op = OpGreater
-
-		case ruleequal:
-			op = OpEqual
-
-		case rulecontains:
-			op = OpContains
-		case ruleexists:
-			op = OpExists
-			if strings.Contains(eventAttr, ".") {
-				// Searching for a full "type.attribute" event.
-				_, ok := events[eventAttr]
-				if !ok {
-					return false, nil
-				}
-			} else {
-				foundEvent := false
-
-			loop:
-				for compositeKey := range events {
-					if strings.Index(compositeKey, eventAttr) == 0 {
-						foundEvent = true
-						break loop
-					}
-				}
-				if !foundEvent {
-					return false, nil
-				}
-			}
-
-		case rulevalue:
-			// strip single quotes from value (i.e. "'NewBlock'" -> "NewBlock")
-			valueWithoutSingleQuotes := buffer[begin+1 : end-1]
-
-			// see if the triplet (event attribute, operator, operand) matches any event
-			// "tx.gas", "=", "7", { "tx.gas": 7, "tx.ID": "4AE393495334" }
-			match, err := match(eventAttr, op, reflect.ValueOf(valueWithoutSingleQuotes), events)
-			if err != nil {
-				return false, err
-			}
-
-			if !match {
-				return false, nil
-			}
-
-		case rulenumber:
-			number := buffer[begin:end]
-			if strings.ContainsAny(number, ".") { // if it looks like a floating-point number
-				value, err := strconv.ParseFloat(number, 64)
-				if err != nil {
-					err = fmt.Errorf(
-						"got %v while trying to parse %s as float64 (should never happen if the grammar is correct)",
-						err, number,
-					)
-					return false, err
-				}
-
-				match, err := match(eventAttr, op, reflect.ValueOf(value), events)
-				if err != nil {
-					return false, err
-				}
-
-				if !match {
-					return false, nil
-				}
-			} else {
-				value := new(big.Int)
-				_, ok := value.SetString(number, 10)
-				if !ok {
-					err := fmt.Errorf(
-						"problem parsing %s as bigInt (should never happen if the grammar is correct)",
-						number,
-					)
-					return false, err
-				}
-
-				match, err := match(eventAttr, op, reflect.ValueOf(value), events)
-				if err != nil {
-					return false, err
-				}
-
-				if !match {
-					return false, nil
-				}
-			}
-
-		case ruletime:
-			value, err := time.Parse(TimeLayout, buffer[begin:end])
-			if err != nil {
-				err = fmt.Errorf(
-					"got %v while trying to parse %s as time.Time / RFC3339 (should never happen if the grammar is correct)",
-					err, buffer[begin:end],
-				)
-				return false, err
-			}
-
-			match, err := match(eventAttr, op, reflect.ValueOf(value), events)
-			if err != nil {
-				return false, err
-			}
-
-			if !match {
-				return false, nil
-			}
-
-		case ruledate:
-			value, err := time.Parse("2006-01-02", buffer[begin:end])
-			if err != nil {
-				err = fmt.Errorf(
-					"got %v while trying to parse %s as time.Time / '2006-01-02' (should never happen if the grammar is correct)",
-					err, buffer[begin:end],
-				)
-				return false, err
-			}
-
-			match, err := match(eventAttr, op, reflect.ValueOf(value), events)
-			if err != nil {
-				return false, err
-			}
+	for _, c := range q.conditions {
+		// see if the triplet (event attribute, operator, operand) matches any event
+		// "tx.gas", "=", "7", { "tx.gas": 7, "tx.ID": "4AE393495334" }
+		match, err := c.matches(events)
+		if err != nil {
+			return false, err
+		}
 
-			if !match {
-				return false, nil
-			}
+		if !match {
+			return false, nil
 		}
 	}
 
 	return true, nil
 }
 
-// match returns true if the given triplet (attribute, operator, operand) matches
-// any value in an event for that attribute. If any match fails with an error,
-// that error is returned.
-//
-// First, it looks up the key in the events and if it finds one, tries to compare
-// all the values from it to the operand using the operator.
+// matches returns true if the condition matches any value in an event for its
+// attribute, or, for OpExists, if the attribute is in the events. If any match
+// fails with an error, that error is returned.
 //
 // "tx.gas", "=", "7", {"tx": [{"gas": 7, "ID": "4AE393495334"}]}
-func match(attr string, op Operator, operand reflect.Value, events map[string][]string) (bool, error) {
+func (c Condition) matches(events map[string][]string) (bool, error) {
+	if c.Op == OpExists {
+		if strings.Contains(c.CompositeKey, ".") {
+			// Searching for a full "type.attribute" event.
+			_, ok := events[c.CompositeKey]
+			return ok, nil
+		}
+		for compositeKey := range events {
+			if strings.HasPrefix(compositeKey, c.CompositeKey) {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+
 	// look up the tag from the query in tags
-	values, ok := events[attr]
+	values, ok := events[c.CompositeKey]
 	if !ok {
 		return false, nil
 	}
 
 	for _, value := range values {
 		// return true if any value in the set of the event's values matches
-		match, err := matchValue(value, op, operand)
+		match, err := matchValue(value, c.Op, c.Operand, c.Denom)
 		if err != nil {
 			return false, err
 		}
@@ -396,11 +410,10 @@ func match(attr string, op Operator, operand reflect.Value, events map[string][]
 // matchValue will attempt to match a string value against an operator an
 // operand. A boolean is returned representing the match result. It will return
 // an error if the value cannot be parsed and matched against the operand type.
-func matchValue(value string, op Operator, operand reflect.Value) (bool, error) {
-	switch operand.Kind() {
-	case reflect.Struct: // time
-		operandAsTime := operand.Interface().(time.Time)
-
+// A numeric operand only matches the values of the denomination denom.
+func matchValue(value string, op Operator, operand interface{}, denom string) (bool, error) {
+	switch operand := operand.(type) {
+	case time.Time:
 		// try our best to convert value from events to time.Time
 		var (
 			v   time.Time
@@ -418,22 +431,25 @@ func matchValue(value string, op Operator, operand reflect.Value) (bool, error)
 
 		switch op {
 		case OpLessEqual:
-			return (v.Before(operandAsTime) || v.Equal(operandAsTime)), nil
+			return (v.Before(operand) || v.Equal(operand)), nil
 		case OpGreaterEqual:
-			return (v.Equal(operandAsTime) || v.After(operandAsTime)), nil
+			return (v.Equal(operand) || v.After(operand)), nil
 		case OpLess:
-			return v.Before(operandAsTime), nil
+			return v.Before(operand), nil
 		case OpGreater:
-			return v.After(operandAsTime), nil
+			return v.After(operand), nil
 		case OpEqual:
-			return v.Equal(operandAsTime), nil
+			return v.Equal(operand), nil
 		}
 
-	case reflect.Float64:
-		var v float64
-
-		operandFloat64 := operand.Interface().(float64)
-		filteredValue := numRegex.FindString(value)
+	case float64:
+		filteredValue, valueDenom, ok := ParseNumericValue(value)
+		if !ok {
+			return false, fmt.Errorf("failed to convert value %v from event attribute to float64", value)
+		}
+		if valueDenom != denom {
+			return false, nil
+		}
 
 		// try our best to convert value from tags to float64
 		v, err := strconv.ParseFloat(filteredValue, 64)
@@ -443,79 +459,80 @@ func matchValue(value string, op Operator, operand reflect.Value) (bool, error)
 
 		switch op {
 		case OpLessEqual:
-			return v <= operandFloat64, nil
+			return v <= operand, nil
 		case OpGreaterEqual:
-			return v >= operandFloat64, nil
+			return v >= operand, nil
 		case OpLess:
-			return v < operandFloat64, nil
+			return v < operand, nil
 		case OpGreater:
-			return v > operandFloat64, nil
+			return v > operand, nil
 		case OpEqual:
-			return v == operandFloat64, nil
+			return v == operand, nil
 		}
 
-	case reflect.Pointer:
-
-		switch operand.Interface().(type) {
-		case *big.Int:
-			filteredValue := numRegex.FindString(value)
-			operandVal := operand.Interface().(*big.Int)
-			v := new(big.Int)
-			if strings.ContainsAny(filteredValue, ".") {
-				// We do this just to check whether the string can be parsed as a float
-				_, err := strconv.ParseFloat(filteredValue, 64)
-				if err != nil {
-					err = fmt.Errorf(
-						"got %v while trying to parse %s as float64 (should never happen if the grammar is correct)",
-						err, filteredValue,
-					)
-					return false, err
-				}
-
-				// If yes, we get the int part of the  string.
-				// We could simply cast the float to an int and use that to create a big int but
-				// if it is a number bigger than int64, it will not be parsed properly.
-				// If we use bigFloat and convert that to a string, the values will be rounded which
-				// is not what we want either.
-				// Here we are simulating the behavior that int64(floatValue). This was the default behavior
-				// before introducing BigInts and we do not want to break the logic in minor releases.
-				_, ok := v.SetString(strings.Split(filteredValue, ".")[0], 10)
-				if !ok {
-					return false, fmt.Errorf("failed to convert value %s from float to big int", filteredValue)
-				}
-			} else {
-				// try our best to convert value from tags to big int
-				_, ok := v.SetString(filteredValue, 10)
-				if !ok {
-					return false, fmt.Errorf("failed to convert value %v from event attribute to big int", filteredValue)
-				}
+	case *big.Int:
+		filteredValue, valueDenom, ok := ParseNumericValue(value)
+		if !ok {
+			return false, fmt.Errorf("failed to convert value %v from event attribute to big int", value)
+		}
+		if valueDenom != denom {
+			return false, nil
+		}
+		v := new(big.Int)
+		if strings.ContainsAny(filteredValue, ".") {
+			// We do this just to check whether the string can be parsed as a float
+			_, err := strconv.ParseFloat(filteredValue, 64)
+			if err != nil {
+				err = fmt.Errorf(
+					"got %v while trying to parse %s as float64 (should never happen if the grammar is correct)",
+					err, filteredValue,
+				)
+				return false, err
+			}
 
+			// If yes, we get the int part of the  string.
+			// We could simply cast the float to an int and use that to create a big int but
+			// if it is a number bigger than int64, it will not be parsed properly.
+			// If we use bigFloat and convert that to a string, the values will be rounded which
+			// is not what we want either.
+			// Here we are simulating the behavior that int64(floatValue). This was the default behavior
+			// before introducing BigInts and we do not want to break the logic in minor releases.
+			_, ok := v.SetString(strings.Split(filteredValue, ".")[0], 10)
+			if !ok {
+				return false, fmt.Errorf("failed to convert value %s from float to big int", filteredValue)
 			}
-			cmpRes := operandVal.Cmp(v)
-			switch op {
-			case OpLessEqual:
-				return cmpRes == 0 || cmpRes == 1, nil
-			case OpGreaterEqual:
-				return cmpRes == 0 || cmpRes == -1, nil
-			case OpLess:
-				return cmpRes == 1, nil
-			case OpGreater:
-				return cmpRes == -1, nil
-			case OpEqual:
-				return cmpRes == 0, nil
+		} else {
+			// try our best to convert value from tags to big int
+			_, ok := v.SetString(filteredValue, 10)
+			if !ok {
+				return false, fmt.Errorf("failed to convert value %v from event attribute to big int", filteredValue)
 			}
 
 		}
-	case reflect.String:
+		cmpRes := operand.Cmp(v)
+		switch op {
+		case OpLessEqual:
+			return cmpRes == 0 || cmpRes == 1, nil
+		case OpGreaterEqual:
+			return cmpRes == 0 || cmpRes == -1, nil
+		case OpLess:
+			return cmpRes == 1, nil
+		case OpGreater:
+			return cmpRes == -1, nil
+		case OpEqual:
+			return cmpRes == 0, nil
+		}
+
+	case string:
 		switch op {
 		case OpEqual:
-			return value == operand.String(), nil
+			return value == operand, nil
 		case OpContains:
-			return strings.Contains(value, operand.String()), nil
+			return strings.Contains(value, operand), nil
 		}
 
 	default:
-		return false, fmt.Errorf("unknown kind of operand %v", operand.Kind())
+		return false, fmt.Errorf("unknown kind of operand %T", operand)
 	}
 
 	return false, nil
@@ -78,10 +78,15 @@ func TestMatches(t *testing.T) {
 	}{
 		{"tm.events.type='NewBlock'", map[string][]string{"tm.events.type": {"NewBlock"}}, false, true, false},
 		{"tx.gas > 7", map[string][]string{"tx.gas": {"8"}}, false, true, false},
-		{"transfer.amount > 7", map[string][]string{"transfer.amount": {"8stake"}}, false, true, false},
-		{"transfer.amount > 7", map[string][]string{"transfer.amount": {"8.045stake"}}, false, true, false},
-		{"transfer.amount > 7.043", map[string][]string{"transfer.amount": {"8.045stake"}}, false, true, false},
-		{"transfer.amount > 8.045", map[string][]string{"transfer.amount": {"8.045stake"}}, false, false, false},
+		{"transfer.amount[stake] > 7", map[string][]string{"transfer.amount": {"8stake"}}, false, true, false},
+		{"transfer.amount[stake] > 7", map[string][]string{"transfer.amount": {"8.045stake"}}, false, true, false},
+		{"transfer.amount[stake] > 7.043", map[string][]string{"transfer.amount": {"8.045stake"}}, false, true, false},
+		{"transfer.amount[stake] > 8.045", map[string][]string{"transfer.amount": {"8.045stake"}}, false, false, false},
+		// amounts are only compared to amounts of the same denomination
+		{"transfer.amount > 7", map[string][]string{"transfer.amount": {"8stake"}}, false, false, false},
+		{"transfer.amount[utia] > 7", map[string][]string{"transfer.amount": {"8stake"}}, false, false, false},
+		{"transfer.amount[utia] > 7", map[string][]string{"transfer.amount": {"8", "8stake", "8utia"}}, false, true, false},
+		{"transfer.amount[utia] = 8", map[string][]string{"transfer.amount": {"8stake"}}, false, false, false},
 		{"tx.gas > 7 AND tx.gas < 9", map[string][]string{"tx.gas": {"8"}}, false, true, false},
 		{"body.weight >= 3.5", map[string][]string{"body.weight": {"3.5"}}, false, true, false},
 		{"account.balance < 1000.0", map[string][]string{"account.balance": {"900"}}, false, true, false},
@@ -223,11 +228,87 @@ func TestMatches(t *testing.T) {
 	}
 }
 
+func TestDenominations(t *testing.T) {
+	q, err := query.New("transfer.amount[utia] >= 5 AND transfer.amount[utia] < 10 AND tx.gas > 1")
+	require.NoError(t, err)
+	c, err := q.Conditions()
+	require.NoError(t, err)
+	assert.Equal(t, []query.Condition{
+		{CompositeKey: "transfer.amount", Op: query.OpGreaterEqual, Operand: big.NewInt(5), Denom: "utia"},
+		{CompositeKey: "transfer.amount", Op: query.OpLess, Operand: big.NewInt(10), Denom: "utia"},
+		{CompositeKey: "tx.gas", Op: query.OpGreater, Operand: big.NewInt(1)},
+	}, c)
+
+	for _, s := range []string{
+		// distinct denominations can't be compared
+		"transfer.amount[utia] >= 5 AND transfer.amount[stake] < 10",
+		"transfer.amount >= 5 AND transfer.amount[utia] < 10",
+		// only numbers are denominated
+		"transfer.amount[utia] = '5'",
+		"transfer.amount[utia] CONTAINS '5'",
+		"transfer.amount[utia] EXISTS",
+		"tx.time[utia] >= TIME 2013-05-03T14:45:00Z",
+	} {
+		_, err := query.New(s)
+		assert.Error(t, err, s)
+	}
+
+	number, denom, ok := query.ParseNumericValue("20000000000000000000000ibc/27394FB092D2ECCD56123C74F36E4C1F926001CEADA9CA97EA622B25F41E5EB2")
+	require.True(t, ok)
+	assert.Equal(t, "20000000000000000000000", number)
+	assert.Equal(t, "ibc/27394FB092D2ECCD56123C74F36E4C1F926001CEADA9CA97EA622B25F41E5EB2", denom)
+	_, _, ok = query.ParseNumericValue("utia")
+	assert.False(t, ok)
+	for value, want := range map[string][2]string{
+		"-5":       {"-5", ""},
+		"1.5utia":  {"1.5", "utia"},
+		"2.":       {"2.", ""},
+		"7u-t.i:a": {"7", "u-t.i:a"},
+	} {
+		number, denom, ok := query.ParseNumericValue(value)
+		require.True(t, ok, value)
+		assert.Equal(t, want, [2]string{number, denom}, value)
+	}
+	for _, value := range []string{"", "-", ".5", "5 utia", "5utia!", "5/utia"} {
+		_, _, ok := query.ParseNumericValue(value)
+		assert.False(t, ok, value)
+	}
+}
+
 func TestMustParse(t *testing.T) {
 	assert.Panics(t, func() { query.MustParse("=") })
 	assert.NotPanics(t, func() { query.MustParse("tm.events.type='NewBlock'") })
 }
 
+func TestNormalize(t *testing.T) {
+	testCases := []struct {
+		s, normalized string
+	}{
+		{"tm.event='NewBlock'", "tm.event='NewBlock'"},
+		{"tm.event = 'NewBlock'", "tm.event='NewBlock'"},
+		{"tm.event  =   'NewBlock'", "tm.event='NewBlock'"},
+		{"tx.height >= 5  AND  tx.height<10", "tx.height>=5 AND tx.height<10"},
+		{"abci.owner.name CONTAINS  'Igor  '", "abci.owner.name CONTAINS 'Igor  '"},
+		{"abci.owner.name EXISTS", "abci.owner.name EXISTS"},
+		{"tx.date > DATE 2017-01-01", "tx.date>DATE 2017-01-01"},
+	}
+	for _, tc := range testCases {
+		normalized := query.Normalize(tc.s)
+		assert.Equal(t, tc.normalized, normalized, tc.s)
+		// the normalized query is equivalent to the original one
+		q, err := query.New(normalized)
+		require.NoError(t, err, tc.s)
+		expected, err := query.MustParse(tc.s).Conditions()
+		require.NoError(t, err)
+		conditions, err := q.Conditions()
+		require.NoError(t, err)
+		assert.Equal(t, expected, conditions, tc.s)
+	}
+
+	// whitespace in quoted values is significant
+	assert.NotEqual(t, query.Normalize("tm.event='New Block'"), query.Normalize("tm.event='NewBlock'"))
+}
+
 func TestConditions(t *testing.T) {
 	txTime, err := time.Parse(time.RFC3339, "2013-05-03T14:45:00Z")
 	require.NoError(t, err)
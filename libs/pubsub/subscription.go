@@ -23,6 +23,9 @@ var (
 type Subscription struct {
 	out chan Message
 
+	// query is the string of the query subscribed to, set by the server.
+	query string
+
 	canceled chan struct{}
 	mtx      cmtsync.RWMutex
 	err      error
@@ -63,6 +66,15 @@ func (s *Subscription) Err() error {
 	return s.err
 }
 
+func (s *Subscription) isCancelled() bool {
+	select {
+	case <-s.canceled:
+		return true
+	default:
+		return false
+	}
+}
+
 func (s *Subscription) cancel(err error) {
 	s.mtx.Lock()
 	s.err = err
@@ -410,15 +410,18 @@ func newLightClientAttackEvidence(conflicted, trusted, common *types.LightBlock)
 	// if this is an equivocation or amnesia attack, i.e. the validator sets are the same, then we
 	// return the height of the conflicting block else if it is a lunatic attack and the validator sets
 	// are not the same then we send the height of the common header.
+	commonVals := common.ValidatorSet
 	if ev.ConflictingHeaderIsInvalid(trusted.Header) {
 		ev.CommonHeight = common.Height
 		ev.Timestamp = common.Time
-		ev.TotalVotingPower = common.ValidatorSet.TotalVotingPower()
 	} else {
 		ev.CommonHeight = trusted.Height
 		ev.Timestamp = trusted.Time
-		ev.TotalVotingPower = trusted.ValidatorSet.TotalVotingPower()
+		commonVals = trusted.ValidatorSet
 	}
-	ev.ByzantineValidators = ev.GetByzantineValidators(common.ValidatorSet, trusted.SignedHeader)
+	// the full nodes verify the evidence against the validator set at its
+	// common height, which may differ from the one of the common block
+	ev.TotalVotingPower = commonVals.TotalVotingPower()
+	ev.ByzantineValidators = ev.GetByzantineValidators(commonVals, trusted.SignedHeader)
 	return ev
 }
@@ -13,7 +13,7 @@ import (
 func RPCRoutes(c *lrpc.Client) map[string]*rpcserver.RPCFunc {
 	return map[string]*rpcserver.RPCFunc{
 		// Subscribe/unsubscribe are reserved for websocket events.
-		"subscribe":       rpcserver.NewWSRPCFunc(c.SubscribeWS, "query"),
+		"subscribe":       rpcserver.NewWSRPCFunc(c.SubscribeWS, "query,payload"),
 		"unsubscribe":     rpcserver.NewWSRPCFunc(c.UnsubscribeWS, "query"),
 		"unsubscribe_all": rpcserver.NewWSRPCFunc(c.UnsubscribeAllWS, ""),
 
@@ -31,7 +31,7 @@ func RPCRoutes(c *lrpc.Client) map[string]*rpcserver.RPCFunc {
 		"header":               rpcserver.NewRPCFunc(makeHeaderFunc(c), "height", rpcserver.Cacheable("height")),
 		"header_by_hash":       rpcserver.NewRPCFunc(makeHeaderByHashFunc(c), "hash"),
 		"tx":                   rpcserver.NewRPCFunc(makeTxFunc(c), "hash,prove", rpcserver.Cacheable()),
-		"tx_search":            rpcserver.NewRPCFunc(makeTxSearchFuncMatchEvents(c), "query,prove,page,per_page,order_by,match_events"),
+		"tx_search":            rpcserver.NewRPCFunc(makeTxSearchFuncMatchEvents(c), "query,prove,page,per_page,order_by,match_events,group_by_height"),
 		"block_search":         rpcserver.NewRPCFunc(makeBlockSearchFuncMatchEvents(c), "query,page,per_page,order_by,match_events"),
 		"validators":           rpcserver.NewRPCFunc(makeValidatorsFunc(c), "height,page,per_page", rpcserver.Cacheable("height")),
 		"dump_consensus_state": rpcserver.NewRPCFunc(makeDumpConsensusStateFunc(c), ""),
@@ -175,6 +175,7 @@ type rpcTxSearchFuncMatchEvents func(
 	page, perPage *int,
 	orderBy string,
 	matchEvents bool,
+	groupByHeight bool,
 ) (*ctypes.ResultTxSearch, error)
 
 func makeTxSearchFuncMatchEvents(c *lrpc.Client) rpcTxSearchFuncMatchEvents {
@@ -185,13 +186,22 @@ func makeTxSearchFuncMatchEvents(c *lrpc.Client) rpcTxSearchFuncMatchEvents {
 		page, perPage *int,
 		orderBy string,
 		matchEvents bool,
+		groupByHeight bool,
 	) (*ctypes.ResultTxSearch, error) {
 		if matchEvents {
 			query = "match.events = 1 AND " + query
 		} else {
 			query = "match.events = 0 AND " + query
 		}
-		return c.TxSearch(ctx.Context(), query, prove, page, perPage, orderBy)
+		res, err := c.TxSearch(ctx.Context(), query, prove, page, perPage, orderBy)
+		if err != nil {
+			return nil, err
+		}
+		if groupByHeight {
+			res.Groups = ctypes.GroupTxsByHeight(res.Txs)
+			res.Txs = []*ctypes.ResultTx{}
+		}
+		return res, nil
 	}
 }
 
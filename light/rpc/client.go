@@ -15,6 +15,7 @@ import (
 	cmtbytes "github.com/tendermint/tendermint/libs/bytes"
 	cmtmath "github.com/tendermint/tendermint/libs/math"
 	service "github.com/tendermint/tendermint/libs/service"
+	cmtproto "github.com/tendermint/tendermint/proto/tendermint/types"
 	rpcclient "github.com/tendermint/tendermint/rpc/client"
 	ctypes "github.com/tendermint/tendermint/rpc/core/types"
 	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
@@ -211,6 +212,14 @@ func (c *Client) NumUnconfirmedTxs(ctx context.Context) (*ctypes.ResultUnconfirm
 	return c.next.NumUnconfirmedTxs(ctx)
 }
 
+func (c *Client) MempoolSnapshot(ctx context.Context) (*ctypes.ResultMempoolSnapshot, error) {
+	return c.next.MempoolSnapshot(ctx)
+}
+
+func (c *Client) MempoolRequirements(ctx context.Context) (*ctypes.ResultMempoolRequirements, error) {
+	return c.next.MempoolRequirements(ctx)
+}
+
 func (c *Client) CheckTx(ctx context.Context, tx types.Tx) (*ctypes.ResultCheckTx, error) {
 	return c.next.CheckTx(ctx, tx)
 }
@@ -227,6 +236,18 @@ func (c *Client) ConsensusState(ctx context.Context) (*ctypes.ResultConsensusSta
 	return c.next.ConsensusState(ctx)
 }
 
+func (c *Client) DebugBundle(ctx context.Context) (*ctypes.ResultDebugBundle, error) {
+	return c.next.DebugBundle(ctx)
+}
+
+func (c *Client) ProposerMisses(ctx context.Context, address []byte, from, to int64) (*ctypes.ResultProposerMisses, error) {
+	return c.next.ProposerMisses(ctx, address, from, to)
+}
+
+func (c *Client) Snapshots(ctx context.Context) (*ctypes.ResultSnapshots, error) {
+	return c.next.Snapshots(ctx)
+}
+
 func (c *Client) ConsensusParams(ctx context.Context, height *int64) (*ctypes.ResultConsensusParams, error) {
 	res, err := c.next.ConsensusParams(ctx, height)
 	if err != nil {
@@ -301,6 +322,34 @@ func (c *Client) BlockchainInfo(ctx context.Context, minHeight, maxHeight int64)
 	return res, nil
 }
 
+// BlockMeta calls rpcclient#BlockMeta and then verifies the header returned.
+func (c *Client) BlockMeta(ctx context.Context, height *int64) (*ctypes.ResultBlockMeta, error) {
+	res, err := c.next.BlockMeta(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate res.
+	if res.BlockMeta == nil {
+		return nil, errors.New("nil block meta")
+	}
+	if err := res.BlockMeta.ValidateBasic(); err != nil {
+		return nil, fmt.Errorf("invalid block meta: %w", err)
+	}
+
+	// Update the light client if we're behind and verify the header.
+	l, err := c.updateLightClientIfNeededTo(ctx, &res.BlockMeta.Header.Height)
+	if err != nil {
+		return nil, err
+	}
+	if bmH, tH := res.BlockMeta.Header.Hash(), l.Hash(); !bytes.Equal(bmH, tH) {
+		return nil, fmt.Errorf("block meta header %X does not match with trusted header %X",
+			bmH, tH)
+	}
+
+	return res, nil
+}
+
 func (c *Client) Genesis(ctx context.Context) (*ctypes.ResultGenesis, error) {
 	return c.next.Genesis(ctx)
 }
@@ -492,6 +541,10 @@ func (c *Client) TxStatus(ctx context.Context, hash []byte) (*ctypes.ResultTxSta
 	return c.next.TxStatus(ctx, hash)
 }
 
+func (c *Client) TxIndexStatus(ctx context.Context) (*ctypes.ResultTxIndexStatus, error) {
+	return c.next.TxIndexStatus(ctx)
+}
+
 // Header fetches and verifies the header directly via the light client
 func (c *Client) Header(ctx context.Context, height *int64) (*ctypes.ResultHeader, error) {
 	lb, err := c.updateLightClientIfNeededTo(ctx, height)
@@ -611,6 +664,167 @@ func (c *Client) ProveSharesV2(
 	return res, err
 }
 
+// ProveSharesBatch returns proofs of inclusion for multiple share ranges to
+// the data root of the given height, in the order of the ranges.
+func (c *Client) ProveSharesBatch(
+	ctx context.Context,
+	height int64,
+	ranges []ctypes.ShareRange,
+) (*ctypes.ResultShareProofBatch, error) {
+	return c.next.ProveSharesBatch(ctx, height, ranges)
+}
+
+// ProveSharesPaged returns proofs of inclusion for a page of the chunks of a
+// share range to the data root of the given height, one chunk per row.
+func (c *Client) ProveSharesPaged(
+	ctx context.Context,
+	height int64,
+	startShare uint64,
+	endShare uint64,
+	page,
+	perPage *int,
+) (*ctypes.ResultShareProofPage, error) {
+	return c.next.ProveSharesPaged(ctx, height, startShare, endShare, page, perPage)
+}
+
+// RowNamespaceRanges returns the namespace range of every row of the original
+// data square of the block at the given height.
+func (c *Client) RowNamespaceRanges(
+	ctx context.Context,
+	height *int64,
+) (*ctypes.ResultRowNamespaceRanges, error) {
+	return c.next.RowNamespaceRanges(ctx, height)
+}
+
+// EstimateNamespaceProofSize returns an estimate of the size of the proof of
+// the shares of the namespace in the block at the given height. The estimate
+// is not verified.
+func (c *Client) EstimateNamespaceProofSize(
+	ctx context.Context,
+	height int64,
+	namespace []byte,
+) (*ctypes.ResultProofSizeEstimate, error) {
+	return c.next.EstimateNamespaceProofSize(ctx, height, namespace)
+}
+
+// VerifyNamespaceCompleteness checks that the share proofs together cover all
+// the shares of the namespace in the block at the given height. The share
+// range of the namespace is computed by the full node, so the result is not
+// verified.
+func (c *Client) VerifyNamespaceCompleteness(
+	ctx context.Context,
+	height int64,
+	namespaceID []byte,
+	proofs []cmtproto.ShareProof,
+) (*ctypes.ResultNamespaceCompleteness, error) {
+	return c.next.VerifyNamespaceCompleteness(ctx, height, namespaceID, proofs)
+}
+
+// ProveTxAbsence calls rpcclient#ProveTxAbsence and then verifies the proof
+// against the data hash of the light block at the given height.
+func (c *Client) ProveTxAbsence(
+	ctx context.Context,
+	height int64,
+	hash []byte,
+) (*ctypes.ResultTxAbsenceProof, error) {
+	res, err := c.next.ProveTxAbsence(ctx, height, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate res.
+	if res.Height <= 0 {
+		return nil, errNegOrZeroHeight
+	}
+	if res.Height != height {
+		return nil, fmt.Errorf("expected proof at height %d, got %d", height, res.Height)
+	}
+	if !bytes.Equal(res.Proof.TxHash, hash) {
+		return nil, fmt.Errorf("expected proof of tx %X, got %X", hash, res.Proof.TxHash)
+	}
+
+	// Update the light client if we're behind.
+	l, err := c.updateLightClientIfNeededTo(ctx, &res.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate the proof.
+	if err := res.Proof.Validate(l.DataHash); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// ProveTxShares calls rpcclient#ProveTxShares and then verifies the proof of
+// the shares of the tx, and each proof of the shares of its blobs, against the
+// data hash of the light block at the given height.
+func (c *Client) ProveTxShares(
+	ctx context.Context,
+	height int64,
+	hash []byte,
+	includeBlob bool,
+) (*ctypes.ResultTxSharesProof, error) {
+	res, err := c.next.ProveTxShares(ctx, height, hash, includeBlob)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate res.
+	if res.Height <= 0 {
+		return nil, errNegOrZeroHeight
+	}
+	if res.Height != height {
+		return nil, fmt.Errorf("expected proof at height %d, got %d", height, res.Height)
+	}
+
+	// Update the light client if we're behind.
+	l, err := c.updateLightClientIfNeededTo(ctx, &res.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate the proofs.
+	if err := res.TxProof.Validate(l.DataHash); err != nil {
+		return nil, fmt.Errorf("invalid tx proof: %w", err)
+	}
+	for i, blobProof := range res.BlobProofs {
+		if err := blobProof.ShareProof.Validate(l.DataHash); err != nil {
+			return nil, fmt.Errorf("invalid proof of blob %d: %w", i, err)
+		}
+	}
+	return res, nil
+}
+
+// TxBundle calls rpcclient#TxBundle and then verifies the bundle, and that its
+// header is the trusted header at its height.
+func (c *Client) TxBundle(ctx context.Context, hash []byte) (*ctypes.ResultTxBundle, error) {
+	res, err := c.next.TxBundle(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate res.
+	if res.Height <= 0 {
+		return nil, errNegOrZeroHeight
+	}
+	if err := res.Verify(c.lc.ChainID()); err != nil {
+		return nil, fmt.Errorf("invalid bundle: %w", err)
+	}
+
+	// Update the light client if we're behind.
+	l, err := c.updateLightClientIfNeededTo(ctx, &res.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	// Verify the header against the trusted one.
+	if bH, tH := res.LightBlock.Hash(), l.Hash(); !bytes.Equal(bH, tH) {
+		return nil, fmt.Errorf("bundle header %X does not match with trusted header %X", bH, tH)
+	}
+	return res, nil
+}
+
 func (c *Client) TxSearch(
 	ctx context.Context,
 	query string,
@@ -701,7 +915,10 @@ func (c *Client) RegisterOpDecoder(typ string, dec merkle.OpDecoder) {
 // SubscribeWS subscribes for events using the given query and remote address as
 // a subscriber, but does not verify responses (UNSAFE)!
 // TODO: verify data
-func (c *Client) SubscribeWS(ctx *rpctypes.Context, query string) (*ctypes.ResultSubscribe, error) {
+func (c *Client) SubscribeWS(ctx *rpctypes.Context, query, payload string) (*ctypes.ResultSubscribe, error) {
+	if err := ctypes.ValidateEventPayload(payload); err != nil {
+		return nil, err
+	}
 	out, err := c.next.Subscribe(context.Background(), ctx.RemoteAddr(), query)
 	if err != nil {
 		return nil, err
@@ -713,6 +930,7 @@ func (c *Client) SubscribeWS(ctx *rpctypes.Context, query string) (*ctypes.Resul
 			case resultEvent := <-out:
 				// We should have a switch here that performs a validation
 				// depending on the event's type.
+				resultEvent.Data = ctypes.EventPayloadData(payload, resultEvent.Data)
 				ctx.WSConn.TryWriteRPCResponse(
 					rpctypes.NewRPCSuccessResponse(
 						rpctypes.JSONRPCStringID(fmt.Sprintf("%v#event", ctx.JSONReq.ID)),
@@ -12,6 +12,7 @@ import (
 
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/config"
+	cmtbytes "github.com/tendermint/tendermint/libs/bytes"
 	"github.com/tendermint/tendermint/libs/log"
 	"github.com/tendermint/tendermint/mempool"
 	"github.com/tendermint/tendermint/proxy"
@@ -19,7 +20,10 @@ import (
 )
 
 // enforce compile-time satisfaction of the Mempool interface
-var _ mempool.Mempool = (*TxPool)(nil)
+var (
+	_ mempool.Mempool     = (*TxPool)(nil)
+	_ mempool.Snapshotter = (*TxPool)(nil)
+)
 
 var (
 	ErrTxInMempool       = errors.New("tx already exists in mempool")
@@ -53,6 +57,10 @@ type TxPool struct {
 	proxyAppConn proxy.AppConnMempool
 	metrics      *mempool.Metrics
 
+	// minimum number of transactions for the mempool to notify that
+	// transactions are available
+	txsAvailableThreshold int
+
 	// these values are modified once per height
 	updateMtx            sync.Mutex
 	notifiedTxsAvailable bool
@@ -77,6 +85,14 @@ type TxPool struct {
 	broadcastCh      chan *wrappedTx
 	broadcastMtx     sync.Mutex
 	txsToBeBroadcast []types.TxKey
+
+	// eventBus is notified of every transaction added to or removed from the
+	// store, and eventSeq numbers these events. eventMtx is held while the
+	// store is changed and the event published, so that a snapshot matches
+	// its sequence.
+	eventBus types.MempoolEventPublisher
+	eventMtx sync.Mutex
+	eventSeq uint64
 }
 
 // NewTxPool constructs a new, empty content addressable txpool at the specified
@@ -125,11 +141,22 @@ func WithPostCheck(f mempool.PostCheckFunc) TxPoolOption {
 	return func(txmp *TxPool) { txmp.postCheckFn = f }
 }
 
+// WithTxsAvailableThreshold sets the number of transactions the mempool must
+// hold before TxsAvailable fires. Values below 1 mean a single transaction.
+func WithTxsAvailableThreshold(n int) TxPoolOption {
+	return func(txmp *TxPool) { txmp.txsAvailableThreshold = n }
+}
+
 // WithMetrics sets the mempool's metrics collector.
 func WithMetrics(metrics *mempool.Metrics) TxPoolOption {
 	return func(txmp *TxPool) { txmp.metrics = metrics }
 }
 
+// WithEventBus sets the publisher of the MempoolTx events.
+func WithEventBus(eventBus types.MempoolEventPublisher) TxPoolOption {
+	return func(txmp *TxPool) { txmp.eventBus = eventBus }
+}
+
 // Lock is a noop as ABCI calls are serialized
 func (txmp *TxPool) Lock() {}
 
@@ -212,7 +239,7 @@ func (txmp *TxPool) CheckToPurgeExpiredTxs() {
 		expirationAge := time.Now().Add(-txmp.config.TTLDuration)
 		// A height of 0 means no transactions will be removed because of height
 		// (in other words, no transaction has a height less than 0)
-		purgedTxs, numExpired := txmp.store.purgeExpiredTxs(0, expirationAge)
+		purgedTxs, numExpired := txmp.purgeStoreTxs(0, expirationAge)
 		// Add the purged transactions to the evicted cache
 		for _, tx := range purgedTxs {
 			txmp.evictedTxCache.Push(tx.key)
@@ -372,14 +399,14 @@ func (txmp *TxPool) TryAddNewTx(tx types.Tx, key types.TxKey, txInfo mempool.TxI
 // RemoveTxByKey removes the transaction with the specified key from the
 // mempool. It adds it to the rejectedTxCache so it will not be added again
 func (txmp *TxPool) RemoveTxByKey(txKey types.TxKey) error {
-	txmp.removeTxByKey(txKey)
+	txmp.removeTxByKey(txKey, types.MempoolTxEvicted)
 	txmp.metrics.EvictedTxs.Add(1)
 	return nil
 }
 
-func (txmp *TxPool) removeTxByKey(txKey types.TxKey) {
+func (txmp *TxPool) removeTxByKey(txKey types.TxKey, reason string) {
 	txmp.rejectedTxCache.Push(txKey)
-	_ = txmp.store.remove(txKey)
+	txmp.removeStoreTx(txKey, reason)
 	txmp.seenByPeersSet.RemoveKey(txKey)
 }
 
@@ -389,7 +416,7 @@ func (txmp *TxPool) Flush() {
 	// Remove all the transactions in the list explicitly, so that the sizes
 	// and indexes get updated properly.
 	size := txmp.Size()
-	txmp.store.reset()
+	txmp.resetStore()
 	txmp.seenByPeersSet.Reset()
 	txmp.rejectedTxCache.Reset()
 	txmp.evictedTxCache.Reset()
@@ -430,15 +457,35 @@ func (txmp *TxPool) allEntriesSorted() []*wrappedTx {
 // If the mempool is empty or has no transactions fitting within the given
 // constraints, the result will also be empty.
 func (txmp *TxPool) ReapMaxBytesMaxGas(maxBytes, maxGas int64) types.Txs {
+	return txmp.reapMaxBytesMaxGas(maxBytes, maxGas, nil)
+}
+
+// ReapMaxBytesMaxGasWithExclusions implements mempool.ExclusionReaper. A
+// transaction that does not fit is left out, but the following ones can still
+// be reaped.
+func (txmp *TxPool) ReapMaxBytesMaxGasWithExclusions(maxBytes, maxGas int64) (types.Txs, mempool.Exclusions) {
+	exclusions := make(mempool.Exclusions)
+	return txmp.reapMaxBytesMaxGas(maxBytes, maxGas, exclusions), exclusions
+}
+
+// reapMaxBytesMaxGas reaps the transactions, adding the ones left out to
+// exclusions if it is not nil.
+func (txmp *TxPool) reapMaxBytesMaxGas(maxBytes, maxGas int64, exclusions mempool.Exclusions) types.Txs {
 	var totalGas, totalBytes int64
 
-	var keep []types.Tx //nolint:prealloc
-	for _, w := range txmp.allEntriesSorted() {
+	entries := txmp.allEntriesSorted()
+	keep := make([]types.Tx, 0, len(entries))
+	for _, w := range entries {
 		// N.B. When computing byte size, we need to include the overhead for
 		// encoding as protobuf to send to the application. This actually overestimates it
 		// as we add the proto overhead to each transaction
-		txBytes := types.ComputeProtoSizeForTxs([]types.Tx{w.tx})
-		if (maxGas >= 0 && totalGas+w.gasWanted > maxGas) || (maxBytes >= 0 && totalBytes+txBytes > maxBytes) {
+		txBytes := types.ComputeProtoSizeForTx(w.tx)
+		if maxGas >= 0 && totalGas+w.gasWanted > maxGas {
+			exclusions.Add(mempool.ExclusionOverGas, w.tx)
+			continue
+		}
+		if maxBytes >= 0 && totalBytes+txBytes > maxBytes {
+			exclusions.Add(mempool.ExclusionOverBytes, w.tx)
 			continue
 		}
 		totalBytes += txBytes
@@ -509,7 +556,7 @@ func (txmp *TxPool) Update(
 	txmp.metrics.SuccessfulTxs.Add(float64(len(blockTxs)))
 	for _, tx := range blockTxs {
 		// Regardless of success, remove the transaction from the mempool.
-		txmp.removeTxByKey(tx.Key())
+		txmp.removeTxByKey(tx.Key(), types.MempoolTxCommitted)
 	}
 
 	txmp.purgeExpiredTxs(blockHeight)
@@ -594,7 +641,7 @@ func (txmp *TxPool) addNewTransaction(wtx *wrappedTx, checkTxRes *abci.ResponseC
 		}
 	}
 
-	txmp.store.set(wtx)
+	txmp.setStoreTx(wtx)
 
 	txmp.metrics.TxSizeBytes.Observe(float64(wtx.size()))
 	txmp.metrics.Size.Set(float64(txmp.Size()))
@@ -611,7 +658,7 @@ func (txmp *TxPool) addNewTransaction(wtx *wrappedTx, checkTxRes *abci.ResponseC
 }
 
 func (txmp *TxPool) evictTx(wtx *wrappedTx) {
-	txmp.store.remove(wtx.key)
+	txmp.removeStoreTx(wtx.key, types.MempoolTxEvicted)
 	txmp.evictedTxCache.Push(wtx.key)
 	txmp.metrics.EvictedTxs.Add(1)
 	txmp.logger.Debug(
@@ -646,7 +693,7 @@ func (txmp *TxPool) handleRecheckResult(wtx *wrappedTx, checkTxRes *abci.Respons
 		"err", err,
 		"code", checkTxRes.Code,
 	)
-	txmp.store.remove(wtx.key)
+	txmp.removeStoreTx(wtx.key, types.MempoolTxRecheckFailed)
 	if txmp.config.KeepInvalidTxsInCache {
 		txmp.rejectedTxCache.Push(wtx.key)
 	}
@@ -704,6 +751,96 @@ func (txmp *TxPool) recheckTransactions() {
 	}()
 }
 
+// setStoreTx adds wtx to the store and publishes its addition.
+func (txmp *TxPool) setStoreTx(wtx *wrappedTx) {
+	txmp.eventMtx.Lock()
+	defer txmp.eventMtx.Unlock()
+	if !txmp.store.set(wtx) {
+		return
+	}
+	txmp.eventSeq++
+	txmp.publishEvent(types.EventDataMempoolTx{
+		Action:    types.MempoolTxAdded,
+		Sequence:  txmp.eventSeq,
+		Hash:      wtx.key[:],
+		Size:      int(wtx.size()),
+		GasWanted: wtx.gasWanted,
+		Priority:  wtx.priority,
+		Sender:    wtx.sender,
+	})
+}
+
+// removeStoreTx removes the transaction from the store, if it holds it, and
+// publishes its removal for the reason given.
+func (txmp *TxPool) removeStoreTx(txKey types.TxKey, reason string) {
+	txmp.eventMtx.Lock()
+	defer txmp.eventMtx.Unlock()
+	if txmp.store.remove(txKey) {
+		txmp.publishRemoved(txKey, reason)
+	}
+}
+
+// purgeStoreTxs purges the expired transactions from the store and publishes
+// their eviction.
+func (txmp *TxPool) purgeStoreTxs(expirationHeight int64, expirationAge time.Time) ([]*wrappedTx, int) {
+	txmp.eventMtx.Lock()
+	defer txmp.eventMtx.Unlock()
+	purgedTxs, numExpired := txmp.store.purgeExpiredTxs(expirationHeight, expirationAge)
+	for _, wtx := range purgedTxs {
+		txmp.publishRemoved(wtx.key, types.MempoolTxEvicted)
+	}
+	return purgedTxs, numExpired
+}
+
+// resetStore empties the store and publishes the eviction of its
+// transactions.
+func (txmp *TxPool) resetStore() {
+	txmp.eventMtx.Lock()
+	defer txmp.eventMtx.Unlock()
+	keys := txmp.store.getAllKeys()
+	txmp.store.reset()
+	for _, key := range keys {
+		txmp.publishRemoved(key, types.MempoolTxEvicted)
+	}
+}
+
+// publishRemoved publishes the removal of a transaction. eventMtx must be
+// held.
+func (txmp *TxPool) publishRemoved(txKey types.TxKey, reason string) {
+	txmp.eventSeq++
+	txmp.publishEvent(types.EventDataMempoolTx{
+		Action:   types.MempoolTxRemoved,
+		Sequence: txmp.eventSeq,
+		Hash:     txKey[:],
+		Reason:   reason,
+	})
+}
+
+func (txmp *TxPool) publishEvent(data types.EventDataMempoolTx) {
+	if txmp.eventBus == nil {
+		return
+	}
+	if err := txmp.eventBus.PublishEventMempoolTx(data); err != nil {
+		txmp.logger.Error("failed publishing mempool tx event", "err", err)
+	}
+}
+
+// Snapshot implements mempool.Snapshotter.
+func (txmp *TxPool) Snapshot() mempool.Snapshot {
+	txmp.eventMtx.Lock()
+	defer txmp.eventMtx.Unlock()
+
+	keys := txmp.store.getAllKeys()
+	hashes := make([]cmtbytes.HexBytes, len(keys))
+	for i := range keys {
+		hashes[i] = keys[i][:]
+	}
+	return mempool.Snapshot{
+		Sequence: txmp.eventSeq,
+		Hashes:   hashes,
+	}
+}
+
 // availableBytes returns the number of bytes available in the mempool.
 func (txmp *TxPool) availableBytes() int64 {
 	return txmp.config.MaxTxsBytes - txmp.SizeBytes()
@@ -742,7 +879,7 @@ func (txmp *TxPool) purgeExpiredTxs(blockHeight int64) {
 		expirationAge = time.Time{}
 	}
 
-	purgedTxs, numExpired := txmp.store.purgeExpiredTxs(expirationHeight, expirationAge)
+	purgedTxs, numExpired := txmp.purgeStoreTxs(expirationHeight, expirationAge)
 	// Add the purged transactions to the evicted cache
 	for _, tx := range purgedTxs {
 		txmp.evictedTxCache.Push(tx.key)
@@ -758,7 +895,7 @@ func (txmp *TxPool) purgeExpiredTxs(blockHeight int64) {
 }
 
 func (txmp *TxPool) notifyTxsAvailable() {
-	if txmp.Size() == 0 {
+	if txmp.Size() == 0 || txmp.Size() < txmp.txsAvailableThreshold {
 		return // nothing to do
 	}
 
@@ -238,7 +238,7 @@ func (memR *Reactor) ReceiveEnvelope(e p2p.Envelope) {
 		txInfo.SenderP2PID = e.Src.ID()
 
 		var err error
-		for _, tx := range protoTxs {
+		for i, tx := range protoTxs {
 			ntx := types.Tx(tx)
 			key := ntx.Key()
 			schema.WriteMempoolTx(memR.traceClient, string(e.Src.ID()), key[:], schema.Download)
@@ -252,6 +252,11 @@ func (memR *Reactor) ReceiveEnvelope(e p2p.Envelope) {
 				memR.mempool.PeerHasTx(peerID, key)
 				memR.Logger.Debug("received new trasaction", "peerID", peerID, "txKey", key)
 			}
+			if originHeight := mempool.OriginHeight(msg, i); memR.isStale(originHeight) {
+				memR.Logger.Debug("dropping stale tx", "txKey", key, "origin_height", originHeight)
+				memR.mempool.metrics.DroppedStaleTxs.Add(1)
+				continue
+			}
 			_, err = memR.mempool.TryAddNewTx(ntx, key, txInfo)
 			if err != nil && err != ErrTxInMempool {
 				memR.Logger.Info("Could not add tx", "txKey", key, "err", err)
@@ -318,13 +323,19 @@ func (memR *Reactor) ReceiveEnvelope(e p2p.Envelope) {
 			txKey[:],
 			schema.Download,
 		)
-		tx, has := memR.mempool.GetTxByKey(txKey)
-		if has && !memR.opts.ListenOnly {
+		wtx := memR.mempool.store.get(txKey)
+		if wtx != nil && memR.isStale(wtx.height) {
+			// the tx is held for too long to be forwarded
+			memR.Logger.Debug("not sending a stale tx in response to a want msg", "txKey", txKey)
+			memR.mempool.metrics.UnsentStaleTxs.Add(1)
+			return
+		}
+		if wtx != nil && !memR.opts.ListenOnly {
 			peerID := memR.ids.GetIDForPeer(e.Src.ID())
 			memR.Logger.Debug("sending a tx in response to a want msg", "peer", peerID)
 			if p2p.SendEnvelopeShim(e.Src, p2p.Envelope{ //nolint:staticcheck
 				ChannelID: mempool.MempoolChannel,
-				Message:   &protomem.Txs{Txs: [][]byte{tx}},
+				Message:   mempool.NewTxsMessage(wtx.tx, wtx.height, memR.opts.MaxTxSize),
 			}, memR.Logger) {
 				memR.mempool.PeerHasTx(peerID, txKey)
 				schema.WriteMempoolTx(
@@ -392,9 +403,7 @@ func (memR *Reactor) broadcastSeenTx(txKey types.TxKey) {
 func (memR *Reactor) broadcastNewTx(wtx *wrappedTx) {
 	msg := &protomem.Message{
 		Sum: &protomem.Message_Txs{
-			Txs: &protomem.Txs{
-				Txs: [][]byte{wtx.tx},
-			},
+			Txs: mempool.NewTxsMessage(wtx.tx, wtx.height, memR.opts.MaxTxSize),
 		},
 	}
 	bz, err := msg.Marshal()
@@ -423,6 +432,13 @@ func (memR *Reactor) broadcastNewTx(wtx *wrappedTx) {
 	}
 }
 
+// isStale returns true if a tx first seen at originHeight is too old to be
+// gossiped, as of the height of the mempool.
+func (memR *Reactor) isStale(originHeight int64) bool {
+	maxAge := memR.mempool.config.MaxGossipTxAge
+	return maxAge > 0 && mempool.IsStaleTx(originHeight, memR.mempool.Height(), maxAge)
+}
+
 // requestTx requests a transaction from a peer and tracks it,
 // requesting it from another peer if the first peer does not respond.
 func (memR *Reactor) requestTx(txKey types.TxKey, peer p2p.Peer) {
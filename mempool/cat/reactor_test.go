@@ -8,9 +8,11 @@ import (
 	"testing"
 	"time"
 
+	"github.com/go-kit/kit/metrics/generic"
 	"github.com/go-kit/log/term"
 	"github.com/gogo/protobuf/proto"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	"github.com/tendermint/tendermint/abci/example/kvstore"
@@ -92,7 +94,10 @@ func TestReactorSendsTxAfterReceivingWantTx(t *testing.T) {
 	tx := newDefaultTx("hello")
 	key := tx.Key()
 	txEnvelope := p2p.Envelope{
-		Message:   &protomem.Txs{Txs: [][]byte{tx}},
+		Message: &protomem.Txs{
+			Txs:      [][]byte{tx},
+			Metadata: []*protomem.TxMetadata{{OriginHeight: pool.Height()}},
+		},
 		ChannelID: mempool.MempoolChannel,
 	}
 
@@ -152,6 +157,54 @@ func TestReactorBroadcastsSeenTxAfterReceivingTx(t *testing.T) {
 	peers[1].AssertExpectations(t)
 }
 
+func TestReactorFiltersStaleTxs(t *testing.T) {
+	reactor, pool := setupReactor(t)
+	pool.config.MaxGossipTxAge = 5
+	dropped, unsent := generic.NewCounter("dropped"), generic.NewCounter("unsent")
+	pool.metrics.DroppedStaleTxs, pool.metrics.UnsentStaleTxs = dropped, unsent
+	advance := func(height int64) {
+		pool.Lock()
+		defer pool.Unlock()
+		require.NoError(t, pool.Update(height, nil, nil, nil, nil))
+	}
+	advance(10)
+
+	peer := genPeer()
+	reactor.InitPeer(peer)
+	receive := func(tx types.Tx, metadata ...*protomem.TxMetadata) {
+		reactor.ReceiveEnvelope(p2p.Envelope{
+			Src:       peer,
+			Message:   &protomem.Txs{Txs: [][]byte{tx}, Metadata: metadata},
+			ChannelID: mempool.MempoolChannel,
+		})
+	}
+
+	// the peer first saw the tx more than 5 blocks ago
+	tx := newDefaultTx("hello")
+	key := tx.Key()
+	receive(tx, &protomem.TxMetadata{OriginHeight: 4})
+	assert.False(t, pool.Has(key))
+	assert.Equal(t, 1.0, dropped.Value())
+
+	// a peer not reporting when it first saw the tx
+	receive(tx)
+	assert.True(t, pool.Has(key))
+	assert.Equal(t, 1.0, dropped.Value())
+
+	// the node does not send the txs it has held for too long
+	advance(16)
+	msgWant := &protomem.Message{
+		Sum: &protomem.Message_WantTx{WantTx: &protomem.WantTx{TxKey: key[:]}},
+	}
+	msgWantB, err := msgWant.Marshal()
+	require.NoError(t, err)
+	otherPeer := genPeer()
+	reactor.InitPeer(otherPeer)
+	reactor.Receive(MempoolStateChannel, otherPeer, msgWantB)
+	otherPeer.AssertNotCalled(t, "SendEnvelope", mock.Anything)
+	assert.Equal(t, 1.0, unsent.Value())
+}
+
 func TestRemovePeerRequestFromOtherPeer(t *testing.T) {
 	reactor, _ := setupReactor(t)
 
@@ -57,6 +57,7 @@ func (s *store) remove(txKey types.TxKey) bool {
 	if !exists {
 		return false
 	}
+	tx.guard.Check(tx.tx)
 	s.bytes -= tx.size()
 	delete(s.txs, txKey)
 	return true
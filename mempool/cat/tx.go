@@ -11,18 +11,20 @@ import (
 // seen this transaction, this struct should never be modified
 type wrappedTx struct {
 	// these fields are immutable
-	tx        types.Tx    // the original transaction data
-	key       types.TxKey // the transaction hash
-	height    int64       // height when this transaction was initially checked (for expiry)
-	timestamp time.Time   // time when transaction was entered (for TTL)
-	gasWanted int64       // app: gas required to execute this transaction
-	priority  int64       // app: priority value for this transaction
-	sender    string      // app: assigned sender label
+	tx        types.Tx      // the original transaction data
+	guard     types.TxGuard // checks that tx, shared by reference when reaped, is never mutated
+	key       types.TxKey   // the transaction hash
+	height    int64         // height when this transaction was initially checked (for expiry)
+	timestamp time.Time     // time when transaction was entered (for TTL)
+	gasWanted int64         // app: gas required to execute this transaction
+	priority  int64         // app: priority value for this transaction
+	sender    string        // app: assigned sender label
 }
 
 func newWrappedTx(tx types.Tx, key types.TxKey, height, gasWanted, priority int64, sender string) *wrappedTx {
 	return &wrappedTx{
 		tx:        tx,
+		guard:     types.GuardTx(tx),
 		key:       key,
 		height:    height,
 		timestamp: time.Now().UTC(),
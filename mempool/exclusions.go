@@ -0,0 +1,69 @@
+package mempool
+
+import (
+	cmtbytes "github.com/tendermint/tendermint/libs/bytes"
+	"github.com/tendermint/tendermint/types"
+)
+
+// Reasons for which transactions are left out of a proposal.
+const (
+	// ExclusionOverGas is the reason of the transactions whose gas would
+	// exceed the max gas of the block.
+	ExclusionOverGas = "over_gas"
+	// ExclusionOverBytes is the reason of the transactions whose size would
+	// exceed the max data bytes of the block.
+	ExclusionOverBytes = "over_bytes"
+	// ExclusionPrepareProposal is the reason of the transactions removed by
+	// the application in PrepareProposal, e.g. because they overflow the data
+	// square or fail the checks of the application.
+	ExclusionPrepareProposal = "prepare_proposal"
+)
+
+// MaxExclusionSampleHashes is the maximum number of transaction hashes kept
+// per exclusion reason.
+const MaxExclusionSampleHashes = 10
+
+// ExclusionReaper is implemented by the mempools that can report the
+// transactions they leave out when reaping.
+type ExclusionReaper interface {
+	// ReapMaxBytesMaxGasWithExclusions reaps the same transactions as
+	// ReapMaxBytesMaxGas, and returns the transactions of the mempool that
+	// were left out by reason.
+	ReapMaxBytesMaxGasWithExclusions(maxBytes, maxGas int64) (types.Txs, Exclusions)
+}
+
+// TxExclusions counts the transactions left out of a proposal for a reason,
+// and holds the hashes of the first MaxExclusionSampleHashes of them.
+type TxExclusions struct {
+	Count        int                 `json:"count"`
+	SampleHashes []cmtbytes.HexBytes `json:"sample_hashes"`
+}
+
+// Exclusions holds the transactions left out of a proposal, by reason.
+type Exclusions map[string]*TxExclusions
+
+// Add records that the transaction was left out for the reason. It does
+// nothing if e is nil, so reaping functions can skip the accounting by
+// passing a nil Exclusions.
+func (e Exclusions) Add(reason string, tx types.Tx) {
+	if e == nil {
+		return
+	}
+	txExclusions, ok := e[reason]
+	if !ok {
+		txExclusions = &TxExclusions{}
+		e[reason] = txExclusions
+	}
+	txExclusions.Count++
+	if len(txExclusions.SampleHashes) < MaxExclusionSampleHashes {
+		txExclusions.SampleHashes = append(txExclusions.SampleHashes, tx.Hash())
+	}
+}
+
+// Count returns the number of transactions left out for the reason.
+func (e Exclusions) Count(reason string) int {
+	if txExclusions, ok := e[reason]; ok {
+		return txExclusions.Count
+	}
+	return 0
+}
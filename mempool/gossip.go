@@ -0,0 +1,39 @@
+package mempool
+
+import (
+	protomem "github.com/tendermint/tendermint/proto/tendermint/mempool"
+	"github.com/tendermint/tendermint/types"
+)
+
+// maxTxMetadataOverhead bounds the number of bytes the metadata of a tx adds
+// to the Txs message gossiping it.
+const maxTxMetadataOverhead = 16
+
+// NewTxsMessage returns the message gossiping tx, which the node first saw at
+// originHeight. The metadata is left out when the origin height is unknown,
+// and for the txs too close to maxTxBytes, as the peers which ignore it only
+// accept messages up to the size of the largest tx without metadata.
+func NewTxsMessage(tx types.Tx, originHeight int64, maxTxBytes int) *protomem.Txs {
+	msg := &protomem.Txs{Txs: [][]byte{tx}}
+	if originHeight > 0 && len(tx)+maxTxMetadataOverhead <= maxTxBytes {
+		msg.Metadata = []*protomem.TxMetadata{{OriginHeight: originHeight}}
+	}
+	return msg
+}
+
+// OriginHeight returns the height at which the sender of msg first saw its
+// i-th tx, or 0 if unknown, e.g. because the sender does not gossip the
+// metadata of the txs.
+func OriginHeight(msg *protomem.Txs, i int) int64 {
+	if len(msg.Metadata) != len(msg.Txs) || msg.Metadata[i] == nil {
+		return 0
+	}
+	return msg.Metadata[i].OriginHeight
+}
+
+// IsStaleTx returns true if a tx first seen at originHeight is more than
+// maxAge blocks old at height. A tx of unknown origin height is never stale,
+// nor is any tx if maxAge is 0.
+func IsStaleTx(originHeight, height, maxAge int64) bool {
+	return maxAge > 0 && originHeight > 0 && height-originHeight > maxAge
+}
@@ -0,0 +1,59 @@
+package mempool
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	protomem "github.com/tendermint/tendermint/proto/tendermint/mempool"
+)
+
+func TestNewTxsMessage(t *testing.T) {
+	const maxTxBytes = 1024
+	// the capacity of the peers ignoring the metadata
+	legacyMsg := protomem.Message{Sum: &protomem.Message_Txs{
+		Txs: &protomem.Txs{Txs: [][]byte{make([]byte, maxTxBytes)}},
+	}}
+	capacity := legacyMsg.Size()
+
+	for _, tc := range []struct {
+		name         string
+		txBytes      int
+		originHeight int64
+		expMetadata  bool
+	}{
+		{"small tx", 10, 5, true},
+		{"unknown origin height", 10, 0, false},
+		{"largest tx with metadata", maxTxBytes - maxTxMetadataOverhead, math.MaxInt64, true},
+		{"tx too large for metadata", maxTxBytes - maxTxMetadataOverhead + 1, math.MaxInt64, false},
+		{"largest tx", maxTxBytes, 5, false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			txs := NewTxsMessage(make([]byte, tc.txBytes), tc.originHeight, maxTxBytes)
+			msg := protomem.Message{Sum: &protomem.Message_Txs{Txs: txs}}
+			assert.LessOrEqual(t, msg.Size(), capacity)
+
+			bz, err := msg.Marshal()
+			require.NoError(t, err)
+			var decoded protomem.Message
+			require.NoError(t, decoded.Unmarshal(bz))
+			if tc.expMetadata {
+				assert.Equal(t, tc.originHeight, OriginHeight(decoded.GetTxs(), 0))
+			} else {
+				assert.Empty(t, decoded.GetTxs().Metadata)
+				assert.Zero(t, OriginHeight(decoded.GetTxs(), 0))
+			}
+		})
+	}
+}
+
+func TestIsStaleTx(t *testing.T) {
+	assert.False(t, IsStaleTx(10, 15, 5))
+	assert.True(t, IsStaleTx(10, 16, 5))
+	// unknown origin height
+	assert.False(t, IsStaleTx(0, 16, 5))
+	// disabled
+	assert.False(t, IsStaleTx(10, 100, 0))
+}
@@ -29,7 +29,9 @@ const (
 // applications can reset their transient state on Commit.
 type Mempool interface {
 	// CheckTx executes a new transaction against the application to determine
-	// its validity and whether it should be added to the mempool.
+	// its validity and whether it should be added to the mempool. Unless it
+	// returns an error, it calls callback with the CheckTx response, or with
+	// nil if the request to the application failed.
 	CheckTx(tx types.Tx, callback func(*abci.Response), txInfo TxInfo) error
 
 	// RemoveTxByKey removes a transaction, identified by its key,
@@ -121,7 +123,7 @@ type PostCheckFunc func(types.Tx, *abci.ResponseCheckTx) error
 // to the expected maxBytes.
 func PreCheckMaxBytes(maxBytes int64) PreCheckFunc {
 	return func(tx types.Tx) error {
-		txSize := types.ComputeProtoSizeForTxs([]types.Tx{tx})
+		txSize := types.ComputeProtoSizeForTx(tx)
 
 		if txSize > maxBytes {
 			return fmt.Errorf("tx size is too big: %d, max: %d", txSize, maxBytes)
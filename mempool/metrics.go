@@ -57,6 +57,16 @@ type Metrics struct {
 	// RerequestedTxs defines the number of times that a requested tx
 	// never received a response in time and a new request was made.
 	RerequestedTxs metrics.Counter
+
+	// DroppedStaleTxs defines the number of gossiped transactions dropped,
+	// without CheckTx, because the sender first saw them more than
+	// max-gossip-tx-age blocks ago.
+	DroppedStaleTxs metrics.Counter
+
+	// UnsentStaleTxs defines the number of times a transaction was not sent to
+	// a peer because the node first saw it more than max-gossip-tx-age blocks
+	// ago.
+	UnsentStaleTxs metrics.Counter
 }
 
 // PrometheusMetrics returns Metrics build using Prometheus client library.
@@ -145,22 +155,38 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 			Name:      "rerequested_txs",
 			Help:      "Number of times a transaction was requested again after a previous request timed out",
 		}, labels).With(labelsAndValues...),
+
+		DroppedStaleTxs: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "dropped_stale_txs",
+			Help:      "Number of gossiped transactions dropped for being first seen by the sender too many blocks ago",
+		}, labels).With(labelsAndValues...),
+
+		UnsentStaleTxs: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "unsent_stale_txs",
+			Help:      "Number of times a transaction was not sent to a peer for being first seen too many blocks ago",
+		}, labels).With(labelsAndValues...),
 	}
 }
 
 // NopMetrics returns no-op Metrics.
 func NopMetrics() *Metrics {
 	return &Metrics{
-		Size:           discard.NewGauge(),
-		SizeBytes:      discard.NewGauge(),
-		TxSizeBytes:    discard.NewHistogram(),
-		FailedTxs:      discard.NewCounter(),
-		EvictedTxs:     discard.NewCounter(),
-		ExpiredTxs:     discard.NewCounter(),
-		SuccessfulTxs:  discard.NewCounter(),
-		RecheckTimes:   discard.NewCounter(),
-		AlreadySeenTxs: discard.NewCounter(),
-		RequestedTxs:   discard.NewCounter(),
-		RerequestedTxs: discard.NewCounter(),
+		Size:            discard.NewGauge(),
+		SizeBytes:       discard.NewGauge(),
+		TxSizeBytes:     discard.NewHistogram(),
+		FailedTxs:       discard.NewCounter(),
+		EvictedTxs:      discard.NewCounter(),
+		ExpiredTxs:      discard.NewCounter(),
+		SuccessfulTxs:   discard.NewCounter(),
+		RecheckTimes:    discard.NewCounter(),
+		AlreadySeenTxs:  discard.NewCounter(),
+		RequestedTxs:    discard.NewCounter(),
+		RerequestedTxs:  discard.NewCounter(),
+		DroppedStaleTxs: discard.NewCounter(),
+		UnsentStaleTxs:  discard.NewCounter(),
 	}
 }
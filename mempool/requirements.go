@@ -0,0 +1,97 @@
+package mempool
+
+import (
+	"fmt"
+	"sync"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/proxy"
+	"github.com/tendermint/tendermint/types"
+)
+
+// AppRequirements caches the requirements the application enforces in CheckTx
+// to admit a transaction, as reported in ResponseInfo.MempoolRequirements, so
+// that the RPC can advertise them to the clients. If enforced, PreCheck also
+// rejects the transactions that do not meet them, so that what the node
+// advertises and what its mempool admits come from the same values.
+//
+// The requirements are asked again with Refresh, which the block executor
+// calls after each block.
+type AppRequirements struct {
+	query   proxy.AppConnQuery
+	enforce bool
+
+	mtx          sync.RWMutex
+	requirements *abci.MempoolRequirements
+	height       int64
+}
+
+// NewAppRequirements returns an AppRequirements asking the application for
+// its requirements on query, enforcing them in PreCheck if enforce is set.
+// Refresh must be called to fetch them the first time.
+func NewAppRequirements(query proxy.AppConnQuery, enforce bool) *AppRequirements {
+	return &AppRequirements{
+		query:   query,
+		enforce: enforce,
+	}
+}
+
+// Refresh asks the application for its current requirements. An application
+// not reporting any has none. On error, the requirements fetched last are
+// kept.
+func (r *AppRequirements) Refresh() error {
+	info, err := r.query.InfoSync(proxy.RequestInfo)
+	if err != nil {
+		return fmt.Errorf("requesting the mempool requirements of the application: %w", err)
+	}
+
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.requirements = info.MempoolRequirements
+	r.height = info.LastBlockHeight
+	return nil
+}
+
+// Requirements returns the requirements fetched last, nil if the application
+// reports none, and the height of the last block the application had committed
+// when reporting them.
+func (r *AppRequirements) Requirements() (*abci.MempoolRequirements, int64) {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	if r.requirements == nil {
+		return nil, r.height
+	}
+	requirements := *r.requirements
+	return &requirements, r.height
+}
+
+// Enforced returns true if PreCheck enforces the requirements.
+func (r *AppRequirements) Enforced() bool {
+	return r.enforce
+}
+
+// PreCheck returns a PreCheckFunc running preCheck, if not nil, and, if the
+// requirements are enforced, rejecting the transactions larger than the
+// maximum size reported last by the application.
+func (r *AppRequirements) PreCheck(preCheck PreCheckFunc) PreCheckFunc {
+	if !r.enforce {
+		return preCheck
+	}
+	return func(tx types.Tx) error {
+		if preCheck != nil {
+			if err := preCheck(tx); err != nil {
+				return err
+			}
+		}
+		if maxTxBytes := r.maxTxBytes(); maxTxBytes > 0 && int64(len(tx)) > maxTxBytes {
+			return fmt.Errorf("tx size %d exceeds the max of the application %d", len(tx), maxTxBytes)
+		}
+		return nil
+	}
+}
+
+func (r *AppRequirements) maxTxBytes() int64 {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	return r.requirements.GetMaxTxBytes()
+}
@@ -0,0 +1,96 @@
+package mempool
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/proxy"
+	"github.com/tendermint/tendermint/proxy/mocks"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestAppRequirements(t *testing.T) {
+	query := &mocks.AppConnQuery{}
+	info := func(height, maxTxBytes int64) {
+		query.On("InfoSync", proxy.RequestInfo).Return(&abci.ResponseInfo{
+			LastBlockHeight: height,
+			MempoolRequirements: &abci.MempoolRequirements{
+				MinGasPrice: "0.002utia",
+				MaxTxBytes:  maxTxBytes,
+			},
+		}, nil).Once()
+	}
+	r := NewAppRequirements(query, true)
+	assert.True(t, r.Enforced())
+
+	// no requirements before the first refresh
+	requirements, height := r.Requirements()
+	assert.Nil(t, requirements)
+	assert.Zero(t, height)
+	var checked []types.Tx
+	preCheck := r.PreCheck(func(tx types.Tx) error {
+		checked = append(checked, tx)
+		return nil
+	})
+	require.NoError(t, preCheck(make(types.Tx, 1000)))
+
+	info(1, 100)
+	require.NoError(t, r.Refresh())
+	requirements, height = r.Requirements()
+	assert.Equal(t, &abci.MempoolRequirements{MinGasPrice: "0.002utia", MaxTxBytes: 100}, requirements)
+	assert.EqualValues(t, 1, height)
+	assert.NoError(t, preCheck(make(types.Tx, 100)))
+	assert.Error(t, preCheck(make(types.Tx, 101)))
+
+	// the app lowers its max mid-run, which the precheck created before
+	// applies once refreshed
+	info(2, 50)
+	assert.NoError(t, preCheck(make(types.Tx, 60)))
+	require.NoError(t, r.Refresh())
+	assert.Error(t, preCheck(make(types.Tx, 60)))
+	_, height = r.Requirements()
+	assert.EqualValues(t, 2, height)
+
+	// the returned requirements are a copy
+	requirements, _ = r.Requirements()
+	requirements.MaxTxBytes = 1000
+	assert.Error(t, preCheck(make(types.Tx, 60)))
+
+	// on error, the last requirements are kept
+	query.On("InfoSync", proxy.RequestInfo).Return(nil, errors.New("app down")).Once()
+	assert.Error(t, r.Refresh())
+	requirements, _ = r.Requirements()
+	assert.EqualValues(t, 50, requirements.MaxTxBytes)
+
+	// an app reporting no requirements has none
+	query.On("InfoSync", proxy.RequestInfo).Return(&abci.ResponseInfo{LastBlockHeight: 3}, nil).Once()
+	require.NoError(t, r.Refresh())
+	requirements, height = r.Requirements()
+	assert.Nil(t, requirements)
+	assert.EqualValues(t, 3, height)
+	assert.NoError(t, preCheck(make(types.Tx, 1000)))
+
+	// the given precheck runs first, for all the txs
+	assert.Len(t, checked, 7)
+	query.AssertExpectations(t)
+}
+
+func TestAppRequirementsNotEnforced(t *testing.T) {
+	query := &mocks.AppConnQuery{}
+	query.On("InfoSync", proxy.RequestInfo).Return(&abci.ResponseInfo{
+		MempoolRequirements: &abci.MempoolRequirements{MaxTxBytes: 10},
+	}, nil)
+	r := NewAppRequirements(query, false)
+	require.NoError(t, r.Refresh())
+	assert.False(t, r.Enforced())
+
+	// the precheck is left as is
+	assert.Nil(t, r.PreCheck(nil))
+	preCheckErr := errors.New("precheck")
+	preCheck := r.PreCheck(func(tx types.Tx) error { return preCheckErr })
+	assert.Equal(t, preCheckErr, preCheck(make(types.Tx, 100)))
+}
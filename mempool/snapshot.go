@@ -0,0 +1,21 @@
+package mempool
+
+import (
+	cmtbytes "github.com/tendermint/tendermint/libs/bytes"
+)
+
+// Snapshotter is implemented by the mempools that publish a MempoolTx event
+// for every transaction they add or remove.
+type Snapshotter interface {
+	// Snapshot returns the hashes of the transactions of the mempool, with the
+	// sequence of the last MempoolTx event published.
+	Snapshot() Snapshot
+}
+
+// Snapshot holds the transactions of a mempool at a point of its sequence of
+// MempoolTx events. Applying the events with a greater sequence to the
+// hashes of the snapshot yields the current transactions of the mempool.
+type Snapshot struct {
+	Sequence uint64
+	Hashes   []cmtbytes.HexBytes
+}
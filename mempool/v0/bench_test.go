@@ -26,6 +26,7 @@ func BenchmarkReap(b *testing.B) {
 			b.Fatal(err)
 		}
 	}
+	b.ReportAllocs()
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		mp.ReapMaxBytesMaxGas(100000000, 10000000)
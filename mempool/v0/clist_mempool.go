@@ -8,6 +8,7 @@ import (
 
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/config"
+	cmtbytes "github.com/tendermint/tendermint/libs/bytes"
 	"github.com/tendermint/tendermint/libs/clist"
 	"github.com/tendermint/tendermint/libs/log"
 	cmtmath "github.com/tendermint/tendermint/libs/math"
@@ -31,6 +32,8 @@ type CListMempool struct {
 	// notify listeners (ie. consensus) when txs are available
 	notifiedTxsAvailable bool
 	txsAvailable         chan struct{} // fires once for each height, when the mempool is not empty
+	// minimum number of txs for the mempool to notify that txs are available
+	txsAvailableThreshold int
 
 	config *config.MempoolConfig
 
@@ -57,11 +60,21 @@ type CListMempool struct {
 	// This reduces the pressure on the proxyApp.
 	cache mempool.TxCache
 
+	// eventBus is notified of every tx added or removed, and eventSeq numbers
+	// these events. eventMtx is held while a tx is added or removed and its
+	// event published, so that a snapshot matches its sequence.
+	eventBus types.MempoolEventPublisher
+	eventMtx cmtsync.Mutex
+	eventSeq uint64
+
 	logger  log.Logger
 	metrics *mempool.Metrics
 }
 
-var _ mempool.Mempool = &CListMempool{}
+var (
+	_ mempool.Mempool     = &CListMempool{}
+	_ mempool.Snapshotter = &CListMempool{}
+)
 
 // CListMempoolOption sets an optional parameter on the mempool.
 type CListMempoolOption func(*CListMempool)
@@ -125,11 +138,22 @@ func WithPostCheck(f mempool.PostCheckFunc) CListMempoolOption {
 	return func(mem *CListMempool) { mem.postCheck = f }
 }
 
+// WithTxsAvailableThreshold sets the number of txs the mempool must hold
+// before TxsAvailable fires. Values below 1 mean a single tx.
+func WithTxsAvailableThreshold(n int) CListMempoolOption {
+	return func(mem *CListMempool) { mem.txsAvailableThreshold = n }
+}
+
 // WithMetrics sets the metrics.
 func WithMetrics(metrics *mempool.Metrics) CListMempoolOption {
 	return func(mem *CListMempool) { mem.metrics = metrics }
 }
 
+// WithEventBus sets the publisher of the MempoolTx events.
+func WithEventBus(eventBus types.MempoolEventPublisher) CListMempoolOption {
+	return func(mem *CListMempool) { mem.eventBus = eventBus }
+}
+
 // Safe for concurrent use by multiple goroutines.
 func (mem *CListMempool) Lock() {
 	mem.updateMtx.Lock()
@@ -140,6 +164,14 @@ func (mem *CListMempool) Unlock() {
 	mem.updateMtx.Unlock()
 }
 
+// Height returns the height of the last block the mempool was updated to.
+// Safe for concurrent use by multiple goroutines.
+func (mem *CListMempool) Height() int64 {
+	mem.updateMtx.RLock()
+	defer mem.updateMtx.RUnlock()
+	return mem.height
+}
+
 // Safe for concurrent use by multiple goroutines.
 func (mem *CListMempool) Size() int {
 	return mem.txs.Len()
@@ -163,10 +195,13 @@ func (mem *CListMempool) Flush() {
 	_ = atomic.SwapInt64(&mem.txsBytes, 0)
 	mem.cache.Reset()
 
+	mem.eventMtx.Lock()
 	for e := mem.txs.Front(); e != nil; e = e.Next() {
 		mem.txs.Remove(e)
 		e.DetachPrev()
+		mem.publishRemoved(e.Value.(*mempoolTx).tx, types.MempoolTxEvicted)
 	}
+	mem.eventMtx.Unlock()
 
 	mem.txsMap.Range(func(key, _ interface{}) bool {
 		mem.txsMap.Delete(key)
@@ -210,7 +245,8 @@ func (mem *CListMempool) TxsWaitChan() <-chan struct{} {
 // It blocks if we're waiting on Update() or Reap().
 // cb: A callback from the CheckTx command.
 //
-//	It gets called from another goroutine.
+//	It gets called from another goroutine, with a nil response if the
+//	request to the application failed.
 //
 // CONTRACT: Either cb will get called, or err returned.
 //
@@ -311,6 +347,15 @@ func (mem *CListMempool) reqResCb(
 	externalCb func(*abci.Response),
 ) func(res *abci.Response) {
 	return func(res *abci.Response) {
+		if res == nil {
+			// the request failed without response, e.g. the connection to the
+			// application was lost: the tx may be submitted again
+			mem.cache.Remove(tx)
+			if externalCb != nil {
+				externalCb(nil)
+			}
+			return
+		}
 		if mem.recheckCursor != nil {
 			// this should never happen
 			panic("recheck cursor is not nil in reqResCb")
@@ -331,28 +376,87 @@ func (mem *CListMempool) reqResCb(
 
 // Called from:
 //   - resCbFirstTime (lock not held) if tx is valid
-func (mem *CListMempool) addTx(memTx *mempoolTx) {
+func (mem *CListMempool) addTx(memTx *mempoolTx, res *abci.ResponseCheckTx) {
+	mem.eventMtx.Lock()
+	defer mem.eventMtx.Unlock()
+
 	e := mem.txs.PushBack(memTx)
 	mem.txsMap.Store(memTx.tx.Key(), e)
 	atomic.AddInt64(&mem.txsBytes, int64(len(memTx.tx)))
 	mem.metrics.TxSizeBytes.Observe(float64(len(memTx.tx)))
+
+	mem.eventSeq++
+	mem.publishEvent(types.EventDataMempoolTx{
+		Action:    types.MempoolTxAdded,
+		Sequence:  mem.eventSeq,
+		Hash:      memTx.tx.Hash(),
+		Size:      len(memTx.tx),
+		GasWanted: memTx.gasWanted,
+		Priority:  res.Priority,
+		Sender:    res.Sender,
+	})
 }
 
 // Called from:
 //   - Update (lock held) if tx was committed
 //   - resCbRecheck (lock not held) if tx was invalidated
-func (mem *CListMempool) removeTx(tx types.Tx, elem *clist.CElement, removeFromCache bool) {
+//   - RemoveTxByKey if tx was evicted
+func (mem *CListMempool) removeTx(tx types.Tx, elem *clist.CElement, removeFromCache bool, reason string) {
+	mem.eventMtx.Lock()
+	defer mem.eventMtx.Unlock()
+
 	mem.txs.Remove(elem)
 	elem.DetachPrev()
 	mem.txsMap.Delete(tx.Key())
 	if memtx, ok := elem.Value.(*mempoolTx); ok {
 		tx = memtx.tx
+		// the tx may still be referenced by a proposed block
+		memtx.guard.Check(tx)
 	}
 	atomic.AddInt64(&mem.txsBytes, int64(-len(tx)))
 
 	if removeFromCache {
 		mem.cache.Remove(tx)
 	}
+
+	mem.publishRemoved(tx, reason)
+}
+
+// publishRemoved publishes the removal of tx. eventMtx must be held.
+func (mem *CListMempool) publishRemoved(tx types.Tx, reason string) {
+	mem.eventSeq++
+	mem.publishEvent(types.EventDataMempoolTx{
+		Action:   types.MempoolTxRemoved,
+		Sequence: mem.eventSeq,
+		Hash:     tx.Hash(),
+		Reason:   reason,
+	})
+}
+
+func (mem *CListMempool) publishEvent(data types.EventDataMempoolTx) {
+	if mem.eventBus == nil {
+		return
+	}
+	if err := mem.eventBus.PublishEventMempoolTx(data); err != nil {
+		mem.logger.Error("failed publishing mempool tx event", "err", err)
+	}
+}
+
+// Snapshot implements mempool.Snapshotter.
+//
+// Safe for concurrent use by multiple goroutines.
+func (mem *CListMempool) Snapshot() mempool.Snapshot {
+	mem.eventMtx.Lock()
+	defer mem.eventMtx.Unlock()
+
+	hashes := make([]cmtbytes.HexBytes, 0, mem.txs.Len())
+	for e := mem.txs.Front(); e != nil; e = e.Next() {
+		hashes = append(hashes, e.Value.(*mempoolTx).tx.Hash())
+	}
+	return mempool.Snapshot{
+		Sequence: mem.eventSeq,
+		Hashes:   hashes,
+	}
 }
 
 // RemoveTxByKey removes a transaction from the mempool by its TxKey index.
@@ -360,7 +464,7 @@ func (mem *CListMempool) RemoveTxByKey(txKey types.TxKey) error {
 	if e, ok := mem.txsMap.Load(txKey); ok {
 		memTx := e.(*clist.CElement).Value.(*mempoolTx)
 		if memTx != nil {
-			mem.removeTx(memTx.tx, e.(*clist.CElement), false)
+			mem.removeTx(memTx.tx, e.(*clist.CElement), false, types.MempoolTxEvicted)
 			return nil
 		}
 		return errors.New("transaction not found")
@@ -430,9 +534,10 @@ func (mem *CListMempool) resCbFirstTime(
 				height:    mem.height,
 				gasWanted: r.CheckTx.GasWanted,
 				tx:        tx,
+				guard:     types.GuardTx(tx),
 			}
 			memTx.senders.Store(peerID, true)
-			mem.addTx(memTx)
+			mem.addTx(memTx, r.CheckTx)
 			mem.logger.Debug(
 				"added good transaction",
 				"tx", types.Tx(tx).Hash(),
@@ -512,7 +617,7 @@ func (mem *CListMempool) resCbRecheck(req *abci.Request, res *abci.Response) {
 			// Tx became invalidated due to newly committed block.
 			mem.logger.Debug("tx is no longer valid", "tx", types.Tx(tx).Hash(), "res", r, "err", postCheckErr)
 			// NOTE: we remove tx from the cache because it might be good later
-			mem.removeTx(tx, mem.recheckCursor, !mem.config.KeepInvalidTxsInCache)
+			mem.removeTx(tx, mem.recheckCursor, !mem.config.KeepInvalidTxsInCache, types.MempoolTxRecheckFailed)
 		}
 		if mem.recheckCursor == mem.recheckEnd {
 			mem.recheckCursor = nil
@@ -542,6 +647,9 @@ func (mem *CListMempool) notifyTxsAvailable() {
 	if mem.Size() == 0 {
 		panic("notified txs available but mempool is empty!")
 	}
+	if mem.Size() < mem.txsAvailableThreshold {
+		return
+	}
 	if mem.txsAvailable != nil && !mem.notifiedTxsAvailable {
 		// channel cap is 1, so this will send once
 		mem.notifiedTxsAvailable = true
@@ -554,6 +662,21 @@ func (mem *CListMempool) notifyTxsAvailable() {
 
 // Safe for concurrent use by multiple goroutines.
 func (mem *CListMempool) ReapMaxBytesMaxGas(maxBytes, maxGas int64) types.Txs {
+	return mem.reapMaxBytesMaxGas(maxBytes, maxGas, nil)
+}
+
+// ReapMaxBytesMaxGasWithExclusions implements mempool.ExclusionReaper. Once a
+// transaction does not fit, it and all the following ones are left out.
+//
+// Safe for concurrent use by multiple goroutines.
+func (mem *CListMempool) ReapMaxBytesMaxGasWithExclusions(maxBytes, maxGas int64) (types.Txs, mempool.Exclusions) {
+	exclusions := make(mempool.Exclusions)
+	return mem.reapMaxBytesMaxGas(maxBytes, maxGas, exclusions), exclusions
+}
+
+// reapMaxBytesMaxGas reaps the transactions, adding the ones left out to
+// exclusions if it is not nil.
+func (mem *CListMempool) reapMaxBytesMaxGas(maxBytes, maxGas int64, exclusions mempool.Exclusions) types.Txs {
 	mem.updateMtx.RLock()
 	defer mem.updateMtx.RUnlock()
 
@@ -562,6 +685,16 @@ func (mem *CListMempool) ReapMaxBytesMaxGas(maxBytes, maxGas int64) types.Txs {
 		runningSize int64
 	)
 
+	// excludeFrom adds the transactions from e onwards to the exclusions
+	excludeFrom := func(e *clist.CElement, reason string) {
+		if exclusions == nil {
+			return
+		}
+		for ; e != nil; e = e.Next() {
+			exclusions.Add(reason, e.Value.(*mempoolTx).tx)
+		}
+	}
+
 	// TODO: we will get a performance boost if we have a good estimate of avg
 	// size per tx, and set the initial capacity based off of that.
 	// txs := make([]types.Tx, 0, cmtmath.MinInt(mem.txs.Len(), max/mem.avgTxSize))
@@ -571,10 +704,11 @@ func (mem *CListMempool) ReapMaxBytesMaxGas(maxBytes, maxGas int64) types.Txs {
 
 		txs = append(txs, memTx.tx)
 
-		dataSize := types.ComputeProtoSizeForTxs([]types.Tx{memTx.tx})
+		dataSize := types.ComputeProtoSizeForTx(memTx.tx)
 
 		// Check total size requirement
 		if maxBytes > -1 && runningSize+dataSize > maxBytes {
+			excludeFrom(e, mempool.ExclusionOverBytes)
 			return txs[:len(txs)-1]
 		}
 
@@ -586,6 +720,7 @@ func (mem *CListMempool) ReapMaxBytesMaxGas(maxBytes, maxGas int64) types.Txs {
 		// must be non-negative, it follows that this won't overflow.
 		newTotalGas := totalGas + memTx.gasWanted
 		if maxGas > -1 && newTotalGas > maxGas {
+			excludeFrom(e, mempool.ExclusionOverGas)
 			return txs[:len(txs)-1]
 		}
 		totalGas = newTotalGas
@@ -650,7 +785,7 @@ func (mem *CListMempool) Update(
 		//   100
 		// https://github.com/tendermint/tendermint/issues/3322.
 		if e, ok := mem.txsMap.Load(tx.Key()); ok {
-			mem.removeTx(tx, e.(*clist.CElement), false)
+			mem.removeTx(tx, e.(*clist.CElement), false, types.MempoolTxCommitted)
 		}
 	}
 
@@ -700,9 +835,10 @@ func (mem *CListMempool) recheckTxs() {
 
 // mempoolTx is a transaction that successfully ran
 type mempoolTx struct {
-	height    int64    // height that this tx had been validated in
-	gasWanted int64    // amount of gas this tx states it will require
-	tx        types.Tx //
+	height    int64         // height that this tx had been validated in
+	gasWanted int64         // amount of gas this tx states it will require
+	tx        types.Tx      //
+	guard     types.TxGuard // checks that the tx, shared by reference when reaped, is never mutated
 
 	// ids of peers who've sent us this tx (as a map for quick lookups).
 	// senders: PeerID -> bool
@@ -2,12 +2,14 @@ package v0
 
 import (
 	"bytes"
+	"context"
 	"crypto/rand"
 	"encoding/binary"
 	"fmt"
 	mrand "math/rand"
 	"os"
 	"strconv"
+	"sync"
 	"testing"
 	"time"
 
@@ -171,6 +173,38 @@ func TestReapMaxBytesMaxGas(t *testing.T) {
 	}
 }
 
+func TestReapMaxBytesMaxGasWithExclusions(t *testing.T) {
+	app := kvstore.NewApplication()
+	cc := proxy.NewLocalClientCreator(app)
+	mp, cleanup := newMempoolWithApp(cc)
+	defer cleanup()
+
+	// each tx has 20 bytes and requests 1 gas unit, and the txs after the
+	// first one that does not fit are left out
+	tests := []struct {
+		maxBytes          int64
+		maxGas            int64
+		expectedNumTxs    int
+		expectedOverBytes int
+		expectedOverGas   int
+	}{
+		{-1, -1, 20, 0, 0},
+		{-1, 5, 5, 0, 15},
+		{240, -1, 10, 10, 0},
+		{240, 5, 5, 0, 15},
+		{20000, 30, 20, 0, 0},
+	}
+	for tcIndex, tt := range tests {
+		checkTxs(t, mp, 20, mempool.UnknownPeerID)
+		got, exclusions := mp.ReapMaxBytesMaxGasWithExclusions(tt.maxBytes, tt.maxGas)
+		assert.Len(t, got, tt.expectedNumTxs, "tc #%d", tcIndex)
+		assert.Equal(t, tt.expectedOverBytes, exclusions.Count(mempool.ExclusionOverBytes), "tc #%d", tcIndex)
+		assert.Equal(t, tt.expectedOverGas, exclusions.Count(mempool.ExclusionOverGas), "tc #%d", tcIndex)
+		assert.Equal(t, mp.ReapMaxBytesMaxGas(tt.maxBytes, tt.maxGas), got, "tc #%d", tcIndex)
+		mp.Flush()
+	}
+}
+
 func TestMempoolFilters(t *testing.T) {
 	app := kvstore.NewApplication()
 	cc := proxy.NewLocalClientCreator(app)
@@ -394,6 +428,35 @@ func TestTxsAvailable(t *testing.T) {
 	ensureNoFire(t, mp.TxsAvailable(), timeoutMS)
 }
 
+func TestTxsAvailableThreshold(t *testing.T) {
+	app := kvstore.NewApplication()
+	cc := proxy.NewLocalClientCreator(app)
+	mp, cleanup := newMempoolWithApp(cc)
+	defer cleanup()
+	WithTxsAvailableThreshold(10)(mp)
+	mp.EnableTxsAvailable()
+
+	timeoutMS := 500
+
+	// below the threshold, it shouldnt fire
+	txs := checkTxs(t, mp, 9, mempool.UnknownPeerID)
+	ensureNoFire(t, mp.TxsAvailable(), timeoutMS)
+
+	// it fires once the threshold is reached
+	txs = append(txs, checkTxs(t, mp, 1, mempool.UnknownPeerID)...)
+	ensureFire(t, mp.TxsAvailable(), timeoutMS)
+
+	// the txs left after the update are below the threshold
+	if err := mp.Update(1, txs[:5], abciResponses(5, abci.CodeTypeOK), nil, nil); err != nil {
+		t.Error(err)
+	}
+	ensureNoFire(t, mp.TxsAvailable(), timeoutMS)
+
+	checkTxs(t, mp, 5, mempool.UnknownPeerID)
+	ensureFire(t, mp.TxsAvailable(), timeoutMS)
+	ensureNoFire(t, mp.TxsAvailable(), timeoutMS)
+}
+
 func TestSerialReap(t *testing.T) {
 	app := kvstore.NewApplication()
 	cc := proxy.NewLocalClientCreator(app)
@@ -576,6 +639,140 @@ func TestGetTxByKey(t *testing.T) {
 	require.Nil(t, randomTx)
 }
 
+func TestMempoolTxEvents(t *testing.T) {
+	app := kvstore.NewApplication()
+	cc := proxy.NewLocalClientCreator(app)
+	mp, cleanup := newMempoolWithApp(cc)
+	defer cleanup()
+
+	eventBus := types.NewEventBus()
+	require.NoError(t, eventBus.Start())
+	t.Cleanup(func() {
+		if err := eventBus.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+	mp.eventBus = eventBus
+	sub, err := eventBus.Subscribe(context.Background(), "test", types.EventQueryMempoolTx, 100)
+	require.NoError(t, err)
+
+	nextEvent := func() types.EventDataMempoolTx {
+		select {
+		case msg := <-sub.Out():
+			return msg.Data().(types.EventDataMempoolTx)
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for a mempool tx event")
+		}
+		return types.EventDataMempoolTx{}
+	}
+
+	txs := checkTxs(t, mp, 2, mempool.UnknownPeerID)
+	for i, tx := range txs {
+		ev := nextEvent()
+		assert.Equal(t, types.MempoolTxAdded, ev.Action)
+		assert.EqualValues(t, i+1, ev.Sequence)
+		assert.EqualValues(t, tx.Hash(), ev.Hash)
+		assert.Equal(t, len(tx), ev.Size)
+	}
+
+	require.NoError(t, mp.Update(1, txs[:1], abciResponses(1, abci.CodeTypeOK), nil, nil))
+	ev := nextEvent()
+	assert.Equal(t, types.MempoolTxRemoved, ev.Action)
+	assert.EqualValues(t, 3, ev.Sequence)
+	assert.EqualValues(t, txs[0].Hash(), ev.Hash)
+	assert.Equal(t, types.MempoolTxCommitted, ev.Reason)
+
+	require.NoError(t, mp.RemoveTxByKey(txs[1].Key()))
+	ev = nextEvent()
+	assert.EqualValues(t, 4, ev.Sequence)
+	assert.Equal(t, types.MempoolTxEvicted, ev.Reason)
+
+	snapshot := mp.Snapshot()
+	assert.EqualValues(t, 4, snapshot.Sequence)
+	assert.Empty(t, snapshot.Hashes)
+}
+
+// TestMempoolTxEventsMatchSnapshots checks that applying the events published
+// under churn to a snapshot yields the snapshot taken once the churn is over.
+func TestMempoolTxEventsMatchSnapshots(t *testing.T) {
+	app := kvstore.NewApplication()
+	cc := proxy.NewLocalClientCreator(app)
+	mp, cleanup := newMempoolWithApp(cc)
+	defer cleanup()
+
+	eventBus := types.NewEventBus()
+	require.NoError(t, eventBus.Start())
+	t.Cleanup(func() {
+		if err := eventBus.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+	mp.eventBus = eventBus
+	sub, err := eventBus.Subscribe(context.Background(), "test", types.EventQueryMempoolTx, 100000)
+	require.NoError(t, err)
+
+	const rounds = 50
+	var (
+		wg    sync.WaitGroup
+		start mempool.Snapshot
+	)
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < rounds; i++ {
+			checkTxs(t, mp, 20, mempool.UnknownPeerID)
+			if i == rounds/2 {
+				start = mp.Snapshot()
+			}
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for height := int64(1); height <= rounds; height++ {
+			txs := mp.ReapMaxTxs(10)
+			mp.Lock()
+			err := mp.Update(height, txs, abciResponses(len(txs), abci.CodeTypeOK), nil, nil)
+			mp.Unlock()
+			require.NoError(t, err)
+			for _, tx := range mp.ReapMaxTxs(2) {
+				_ = mp.RemoveTxByKey(tx.Key())
+			}
+		}
+	}()
+	wg.Wait()
+	end := mp.Snapshot()
+
+	txs := make(map[string]struct{}, len(start.Hashes))
+	for _, hash := range start.Hashes {
+		txs[hash.String()] = struct{}{}
+	}
+	for seq := uint64(1); seq <= end.Sequence; seq++ {
+		var ev types.EventDataMempoolTx
+		select {
+		case msg := <-sub.Out():
+			ev = msg.Data().(types.EventDataMempoolTx)
+		case <-time.After(time.Second):
+			t.Fatalf("timed out waiting for the mempool tx event %d", seq)
+		}
+		require.Equal(t, seq, ev.Sequence, "gap in the sequence of the events")
+		if seq <= start.Sequence {
+			continue
+		}
+		switch ev.Action {
+		case types.MempoolTxAdded:
+			txs[ev.Hash.String()] = struct{}{}
+		case types.MempoolTxRemoved:
+			require.Contains(t, txs, ev.Hash.String())
+			delete(txs, ev.Hash.String())
+		}
+	}
+
+	require.Len(t, txs, len(end.Hashes))
+	for _, hash := range end.Hashes {
+		assert.Contains(t, txs, hash.String())
+	}
+}
+
 func TestMempoolTxsBytes(t *testing.T) {
 	app := kvstore.NewApplication()
 	cc := proxy.NewLocalClientCreator(app)
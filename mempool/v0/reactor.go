@@ -172,8 +172,13 @@ func (memR *Reactor) ReceiveEnvelope(e p2p.Envelope) {
 		}
 
 		var err error
-		for _, tx := range protoTxs {
+		for i, tx := range protoTxs {
 			ntx := types.Tx(tx)
+			if originHeight := mempool.OriginHeight(msg, i); memR.isStale(originHeight) {
+				memR.Logger.Debug("Dropping stale tx", "tx", ntx.String(), "origin_height", originHeight)
+				memR.mempool.metrics.DroppedStaleTxs.Add(1)
+				continue
+			}
 			err = memR.mempool.CheckTx(ntx, nil, txInfo)
 			if errors.Is(err, mempool.ErrTxInCache) {
 				memR.Logger.Debug("Tx already exists in cache", "tx", ntx.String())
@@ -261,13 +266,18 @@ func (memR *Reactor) broadcastTxRoutine(peer p2p.Peer) {
 		// https://github.com/tendermint/tendermint/issues/5796
 
 		if _, ok := memTx.senders.Load(peerID); !ok {
-			success := p2p.SendEnvelopeShim(peer, p2p.Envelope{ //nolint: staticcheck
-				ChannelID: mempool.MempoolChannel,
-				Message:   &protomem.Txs{Txs: [][]byte{memTx.tx}},
-			}, memR.Logger)
-			if !success {
-				time.Sleep(mempool.PeerCatchupSleepIntervalMS * time.Millisecond)
-				continue
+			if memR.isStale(memTx.Height()) {
+				// the tx is held for too long to be forwarded
+				memR.mempool.metrics.UnsentStaleTxs.Add(1)
+			} else {
+				success := p2p.SendEnvelopeShim(peer, p2p.Envelope{ //nolint: staticcheck
+					ChannelID: mempool.MempoolChannel,
+					Message:   mempool.NewTxsMessage(memTx.tx, memTx.Height(), memR.config.MaxTxBytes),
+				}, memR.Logger)
+				if !success {
+					time.Sleep(mempool.PeerCatchupSleepIntervalMS * time.Millisecond)
+					continue
+				}
 			}
 		}
 
@@ -283,6 +293,13 @@ func (memR *Reactor) broadcastTxRoutine(peer p2p.Peer) {
 	}
 }
 
+// isStale returns true if a tx first seen at originHeight is too old to be
+// gossiped, as of the height of the mempool.
+func (memR *Reactor) isStale(originHeight int64) bool {
+	maxAge := memR.config.MaxGossipTxAge
+	return maxAge > 0 && mempool.IsStaleTx(originHeight, memR.mempool.Height(), maxAge)
+}
+
 // TxsMessage is a Message containing transactions.
 type TxsMessage struct {
 	Txs []types.Tx
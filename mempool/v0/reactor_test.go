@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/fortytw2/leaktest"
+	"github.com/go-kit/kit/metrics/generic"
 	"github.com/go-kit/log/term"
 	"github.com/gogo/protobuf/proto"
 	"github.com/stretchr/testify/assert"
@@ -186,6 +187,116 @@ func TestReactor_MaxTxBytes(t *testing.T) {
 	require.Error(t, err)
 }
 
+// A relayer holding txs while the chain advances does not forward them once it
+// held them for more than max-gossip-tx-age blocks.
+func TestReactorMaxGossipTxAge(t *testing.T) {
+	const maxAge = 5
+	config := cfg.TestConfig()
+	config.Mempool.MaxGossipTxAge = maxAge
+
+	// the origin, the relayer and the receiver, in a line
+	const N = 3
+	reactors := make([]*Reactor, N)
+	for i := range reactors {
+		mp, cleanup := newMempoolWithApp(proxy.NewLocalClientCreator(kvstore.NewApplication()))
+		defer cleanup()
+		mp.metrics = mempool.NopMetrics()
+		mp.metrics.UnsentStaleTxs = generic.NewCounter("unsent_stale_txs")
+		reactors[i] = NewReactor(config.Mempool, mp)
+		reactors[i].SetLogger(mempoolLogger().With("validator", i))
+	}
+	p2p.MakeConnectedSwitches(config.P2P, N, func(i int, s *p2p.Switch) *p2p.Switch {
+		s.AddReactor("MEMPOOL", reactors[i])
+		return s
+	}, func(sws []*p2p.Switch, i, j int) {
+		if j == i+1 {
+			p2p.Connect2Switches(sws, i, j)
+		}
+	})
+	defer func() {
+		for _, r := range reactors {
+			if err := r.Stop(); err != nil {
+				assert.NoError(t, err)
+			}
+		}
+	}()
+	origin, relayer, receiver := reactors[0], reactors[1], reactors[2]
+	advance := func(height int64) {
+		for _, r := range reactors {
+			r.mempool.Lock()
+			err := r.mempool.Update(height, []types.Tx{}, make([]*abci.ResponseDeliverTx, 0), nil, nil)
+			r.mempool.Unlock()
+			require.NoError(t, err)
+		}
+	}
+	// the relayer is delayed: it does not gossip to the receiver until the
+	// receiver has a peer state
+	for _, r := range []*Reactor{origin, relayer, receiver} {
+		for _, peer := range r.Switch.Peers().List() {
+			if r != relayer || peer.ID() != receiver.Switch.NetAddress().ID {
+				peer.Set(types.PeerStateKey, peerState{1})
+			}
+		}
+	}
+
+	advance(1)
+	staleTxs := checkTxs(t, origin.mempool, 5, mempool.UnknownPeerID)
+	waitForTxsOnReactor(t, staleTxs, relayer, 1)
+
+	// the relayer held the txs for too long to forward them
+	advance(1 + maxAge + 1)
+	for _, r := range reactors {
+		for _, peer := range r.Switch.Peers().List() {
+			peer.Set(types.PeerStateKey, peerState{1 + maxAge + 1})
+		}
+	}
+	require.Eventually(t, func() bool {
+		return relayer.mempool.metrics.UnsentStaleTxs.(*generic.Counter).Value() == float64(len(staleTxs))
+	}, timeout, 10*time.Millisecond)
+
+	// new txs are still relayed
+	txs := checkTxs(t, origin.mempool, 5, mempool.UnknownPeerID)
+	waitForTxsOnReactor(t, txs, receiver, 2)
+	assert.Equal(t, len(txs), receiver.mempool.Size())
+}
+
+func TestReactorDropsStaleTxs(t *testing.T) {
+	config := cfg.TestConfig()
+	config.Mempool.MaxGossipTxAge = 5
+	reactors := makeAndConnectReactors(config, 1)
+	reactor, peer := reactors[0], mock.NewPeer(nil)
+	defer func() {
+		assert.NoError(t, reactor.Stop())
+	}()
+	reactor.mempool.metrics.DroppedStaleTxs = generic.NewCounter("dropped_stale_txs")
+	reactor.InitPeer(peer)
+	reactor.mempool.Lock()
+	require.NoError(t, reactor.mempool.Update(10, []types.Tx{}, make([]*abci.ResponseDeliverTx, 0), nil, nil))
+	reactor.mempool.Unlock()
+
+	// the peer first saw the first tx more than 5 blocks ago, and the second
+	// one within 5 blocks
+	txs := types.Txs{cmtrand.Bytes(20), cmtrand.Bytes(20)}
+	reactor.ReceiveEnvelope(p2p.Envelope{
+		Src:       peer,
+		ChannelID: mempool.MempoolChannel,
+		Message: &memproto.Txs{
+			Txs:      [][]byte{txs[0], txs[1]},
+			Metadata: []*memproto.TxMetadata{{OriginHeight: 4}, {OriginHeight: 5}},
+		},
+	})
+	assert.Equal(t, 1.0, reactor.mempool.metrics.DroppedStaleTxs.(*generic.Counter).Value())
+	assert.Equal(t, types.Txs{txs[1]}, reactor.mempool.ReapMaxTxs(-1))
+
+	// a peer not reporting when it first saw the tx
+	reactor.ReceiveEnvelope(p2p.Envelope{
+		Src:       peer,
+		ChannelID: mempool.MempoolChannel,
+		Message:   &memproto.Txs{Txs: [][]byte{txs[0]}},
+	})
+	assert.Equal(t, types.Txs{txs[1], txs[0]}, reactor.mempool.ReapMaxTxs(-1))
+}
+
 func TestBroadcastTxForPeerStopsWhenPeerStops(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping test in short mode.")
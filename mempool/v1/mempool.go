@@ -12,6 +12,7 @@ import (
 
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/config"
+	cmtbytes "github.com/tendermint/tendermint/libs/bytes"
 	"github.com/tendermint/tendermint/libs/clist"
 	"github.com/tendermint/tendermint/libs/log"
 	"github.com/tendermint/tendermint/mempool"
@@ -20,7 +21,10 @@ import (
 	"github.com/tendermint/tendermint/types"
 )
 
-var _ mempool.Mempool = (*TxMempool)(nil)
+var (
+	_ mempool.Mempool     = (*TxMempool)(nil)
+	_ mempool.Snapshotter = (*TxMempool)(nil)
+)
 
 // TxMempoolOption sets an optional parameter on the TxMempool.
 type TxMempoolOption func(*TxMempool)
@@ -42,6 +46,10 @@ type TxMempool struct {
 	metrics      *mempool.Metrics
 	cache        mempool.TxCache // seen transactions
 
+	// minimum number of transactions for the mempool to notify that
+	// transactions are available
+	txsAvailableThreshold int
+
 	// Atomically-updated fields
 	txsBytes int64 // atomic: the total size of all transactions in the mempool, in bytes
 
@@ -59,6 +67,11 @@ type TxMempool struct {
 	txBySender map[string]*clist.CElement // for sender != ""
 	evictedTxs mempool.TxCache            // for tracking evicted transactions
 
+	// eventBus is notified of every transaction added or removed, and
+	// eventSeq numbers these events.
+	eventBus types.MempoolEventPublisher
+	eventSeq uint64
+
 	traceClient trace.Tracer
 }
 
@@ -111,11 +124,22 @@ func WithPostCheck(f mempool.PostCheckFunc) TxMempoolOption {
 	return func(txmp *TxMempool) { txmp.postCheck = f }
 }
 
+// WithTxsAvailableThreshold sets the number of transactions the mempool must
+// hold before TxsAvailable fires. Values below 1 mean a single transaction.
+func WithTxsAvailableThreshold(n int) TxMempoolOption {
+	return func(txmp *TxMempool) { txmp.txsAvailableThreshold = n }
+}
+
 // WithMetrics sets the mempool's metrics collector.
 func WithMetrics(metrics *mempool.Metrics) TxMempoolOption {
 	return func(txmp *TxMempool) { txmp.metrics = metrics }
 }
 
+// WithEventBus sets the publisher of the MempoolTx events.
+func WithEventBus(eventBus types.MempoolEventPublisher) TxMempoolOption {
+	return func(txmp *TxMempool) { txmp.eventBus = eventBus }
+}
+
 func WithTraceClient(tc trace.Tracer) TxMempoolOption {
 	return func(txmp *TxMempool) {
 		txmp.traceClient = tc
@@ -129,6 +153,14 @@ func (txmp *TxMempool) Lock() { txmp.mtx.Lock() }
 // Unlock releases a write-lock on the mempool.
 func (txmp *TxMempool) Unlock() { txmp.mtx.Unlock() }
 
+// Height returns the height of the last block the mempool was updated to. It
+// is thread-safe.
+func (txmp *TxMempool) Height() int64 {
+	txmp.mtx.RLock()
+	defer txmp.mtx.RUnlock()
+	return txmp.height
+}
+
 // Size returns the number of valid transactions in the mempool. It is
 // thread-safe.
 func (txmp *TxMempool) Size() int { return txmp.txs.Len() }
@@ -240,6 +272,7 @@ func (txmp *TxMempool) CheckTx(tx types.Tx, cb func(*abci.Response), txInfo memp
 	}
 	wtx := &WrappedTx{
 		tx:        tx,
+		guard:     types.GuardTx(tx),
 		hash:      tx.Key(),
 		timestamp: time.Now().UTC(),
 		height:    height,
@@ -258,7 +291,7 @@ func (txmp *TxMempool) CheckTx(tx types.Tx, cb func(*abci.Response), txInfo memp
 func (txmp *TxMempool) RemoveTxByKey(txKey types.TxKey) error {
 	txmp.mtx.Lock()
 	defer txmp.mtx.Unlock()
-	return txmp.removeTxByKey(txKey)
+	return txmp.removeTxByKey(txKey, types.MempoolTxEvicted)
 }
 
 // GetTxByKey retrieves a transaction based on the key. It returns a bool
@@ -279,32 +312,59 @@ func (txmp *TxMempool) WasRecentlyEvicted(txKey types.TxKey) bool {
 	return txmp.evictedTxs.HasKey(txKey)
 }
 
-// removeTxByKey removes the specified transaction key from the mempool.
-// The caller must hold txmp.mtx excluxively.
-func (txmp *TxMempool) removeTxByKey(key types.TxKey) error {
+// removeTxByKey removes the specified transaction key from the mempool for
+// the reason given. The caller must hold txmp.mtx excluxively.
+func (txmp *TxMempool) removeTxByKey(key types.TxKey, reason string) error {
 	if elt, ok := txmp.txByKey[key]; ok {
-		w := elt.Value.(*WrappedTx)
-		delete(txmp.txByKey, key)
-		delete(txmp.txBySender, w.sender)
-		txmp.txs.Remove(elt)
-		elt.DetachPrev()
-		elt.DetachNext()
-		atomic.AddInt64(&txmp.txsBytes, -w.Size())
+		txmp.removeTxByElement(elt, reason)
 		return nil
 	}
 	return fmt.Errorf("transaction %x not found", key)
 }
 
-// removeTxByElement removes the specified transaction element from the mempool.
-// The caller must hold txmp.mtx exclusively.
-func (txmp *TxMempool) removeTxByElement(elt *clist.CElement) {
+// removeTxByElement removes the specified transaction element from the mempool
+// for the reason given. The caller must hold txmp.mtx exclusively.
+func (txmp *TxMempool) removeTxByElement(elt *clist.CElement, reason string) {
 	w := elt.Value.(*WrappedTx)
+	w.guard.Check(w.tx)
 	delete(txmp.txByKey, w.tx.Key())
 	delete(txmp.txBySender, w.sender)
 	txmp.txs.Remove(elt)
 	elt.DetachPrev()
 	elt.DetachNext()
 	atomic.AddInt64(&txmp.txsBytes, -w.Size())
+
+	txmp.eventSeq++
+	txmp.publishEvent(types.EventDataMempoolTx{
+		Action:   types.MempoolTxRemoved,
+		Sequence: txmp.eventSeq,
+		Hash:     w.tx.Hash(),
+		Reason:   reason,
+	})
+}
+
+func (txmp *TxMempool) publishEvent(data types.EventDataMempoolTx) {
+	if txmp.eventBus == nil {
+		return
+	}
+	if err := txmp.eventBus.PublishEventMempoolTx(data); err != nil {
+		txmp.logger.Error("failed publishing mempool tx event", "err", err)
+	}
+}
+
+// Snapshot implements mempool.Snapshotter.
+func (txmp *TxMempool) Snapshot() mempool.Snapshot {
+	txmp.mtx.RLock()
+	defer txmp.mtx.RUnlock()
+
+	hashes := make([]cmtbytes.HexBytes, 0, txmp.txs.Len())
+	for e := txmp.txs.Front(); e != nil; e = e.Next() {
+		hashes = append(hashes, e.Value.(*WrappedTx).tx.Hash())
+	}
+	return mempool.Snapshot{
+		Sequence: txmp.eventSeq,
+		Hashes:   hashes,
+	}
 }
 
 // Flush purges the contents of the mempool and the cache, leaving both empty.
@@ -318,7 +378,7 @@ func (txmp *TxMempool) Flush() {
 	cur := txmp.txs.Front()
 	for cur != nil {
 		next := cur.Next()
-		txmp.removeTxByElement(cur)
+		txmp.removeTxByElement(cur, types.MempoolTxEvicted)
 		cur = next
 	}
 	txmp.cache.Reset()
@@ -355,15 +415,35 @@ func (txmp *TxMempool) allEntriesSorted() []*WrappedTx {
 // If the mempool is empty or has no transactions fitting within the given
 // constraints, the result will also be empty.
 func (txmp *TxMempool) ReapMaxBytesMaxGas(maxBytes, maxGas int64) types.Txs {
+	return txmp.reapMaxBytesMaxGas(maxBytes, maxGas, nil)
+}
+
+// ReapMaxBytesMaxGasWithExclusions implements mempool.ExclusionReaper. A
+// transaction that does not fit is left out, but the following ones can still
+// be reaped.
+func (txmp *TxMempool) ReapMaxBytesMaxGasWithExclusions(maxBytes, maxGas int64) (types.Txs, mempool.Exclusions) {
+	exclusions := make(mempool.Exclusions)
+	return txmp.reapMaxBytesMaxGas(maxBytes, maxGas, exclusions), exclusions
+}
+
+// reapMaxBytesMaxGas reaps the transactions, adding the ones left out to
+// exclusions if it is not nil.
+func (txmp *TxMempool) reapMaxBytesMaxGas(maxBytes, maxGas int64, exclusions mempool.Exclusions) types.Txs {
 	var totalGas, totalBytes int64
 
-	var keep []types.Tx //nolint:prealloc
-	for _, w := range txmp.allEntriesSorted() {
+	entries := txmp.allEntriesSorted()
+	keep := make([]types.Tx, 0, len(entries))
+	for _, w := range entries {
 		// N.B. When computing byte size, we need to include the overhead for
 		// encoding as protobuf to send to the application. This actually overestimates it
 		// as we add the proto overhead to each transaction
-		txBytes := types.ComputeProtoSizeForTxs([]types.Tx{w.tx})
-		if (maxGas >= 0 && totalGas+w.gasWanted > maxGas) || (maxBytes >= 0 && totalBytes+txBytes > maxBytes) {
+		txBytes := types.ComputeProtoSizeForTx(w.tx)
+		if maxGas >= 0 && totalGas+w.gasWanted > maxGas {
+			exclusions.Add(mempool.ExclusionOverGas, w.tx)
+			continue
+		}
+		if maxBytes >= 0 && totalBytes+txBytes > maxBytes {
+			exclusions.Add(mempool.ExclusionOverBytes, w.tx)
 			continue
 		}
 		totalBytes += txBytes
@@ -447,7 +527,7 @@ func (txmp *TxMempool) Update(
 		}
 
 		// Regardless of success, remove the transaction from the mempool.
-		_ = txmp.removeTxByKey(tx.Key())
+		_ = txmp.removeTxByKey(tx.Key(), types.MempoolTxCommitted)
 	}
 
 	txmp.purgeExpiredTxs(blockHeight)
@@ -600,7 +680,7 @@ func (txmp *TxMempool) addNewTransaction(wtx *WrappedTx, checkTxRes *abci.Respon
 				"old_tx", fmt.Sprintf("%X", w.tx.Hash()),
 				"old_priority", w.priority,
 			)
-			txmp.removeTxByElement(vic)
+			txmp.removeTxByElement(vic, types.MempoolTxEvicted)
 			txmp.cache.Remove(w.tx)
 			txmp.metrics.EvictedTxs.Add(1)
 			// Add it to evicted transactions cache
@@ -640,6 +720,17 @@ func (txmp *TxMempool) insertTx(wtx *WrappedTx) {
 	}
 
 	atomic.AddInt64(&txmp.txsBytes, wtx.Size())
+
+	txmp.eventSeq++
+	txmp.publishEvent(types.EventDataMempoolTx{
+		Action:    types.MempoolTxAdded,
+		Sequence:  txmp.eventSeq,
+		Hash:      wtx.tx.Hash(),
+		Size:      int(wtx.Size()),
+		GasWanted: wtx.GasWanted(),
+		Priority:  wtx.Priority(),
+		Sender:    wtx.Sender(),
+	})
 }
 
 // handleRecheckResult handles the responses from ABCI CheckTx calls issued
@@ -680,7 +771,7 @@ func (txmp *TxMempool) handleRecheckResult(tx types.Tx, checkTxRes *abci.Respons
 		"err", err,
 		"code", checkTxRes.Code,
 	)
-	txmp.removeTxByElement(elt)
+	txmp.removeTxByElement(elt, types.MempoolTxRecheckFailed)
 	txmp.metrics.FailedTxs.Add(1)
 	if !txmp.config.KeepInvalidTxsInCache {
 		txmp.cache.Remove(wtx.tx)
@@ -793,7 +884,7 @@ func (txmp *TxMempool) purgeExpiredTxs(blockHeight int64) {
 		w := cur.Value.(*WrappedTx)
 		if txmp.config.TTLNumBlocks > 0 && (blockHeight-w.height) > txmp.config.TTLNumBlocks ||
 			txmp.config.TTLDuration > 0 && now.Sub(w.timestamp) > txmp.config.TTLDuration {
-			txmp.removeTxByElement(cur)
+			txmp.removeTxByElement(cur, types.MempoolTxEvicted)
 			txmp.cache.Remove(w.tx)
 			txmp.evictedTxs.Push(w.tx)
 			txmp.metrics.ExpiredTxs.Add(1)
@@ -805,7 +896,7 @@ func (txmp *TxMempool) purgeExpiredTxs(blockHeight int64) {
 }
 
 func (txmp *TxMempool) notifyTxsAvailable() {
-	if txmp.Size() == 0 {
+	if txmp.Size() == 0 || txmp.Size() < txmp.txsAvailableThreshold {
 		return // nothing to do
 	}
 
@@ -30,3 +30,21 @@ func BenchmarkTxMempool_CheckTx(b *testing.B) {
 		require.NoError(b, txmp.CheckTx(tx, nil, mempool.TxInfo{}))
 	}
 }
+
+func BenchmarkTxMempool_ReapMaxBytesMaxGas(b *testing.B) {
+	txmp := setup(b, 10000)
+	txmp.config.Size = 10000
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+	for i := 0; i < txmp.config.Size; i++ {
+		tx := []byte(fmt.Sprintf("sender%d=%d=%d", i, i, rng.Intn(9999-1000)+1000))
+		require.NoError(b, txmp.CheckTx(tx, nil, mempool.TxInfo{}))
+	}
+	require.Equal(b, txmp.config.Size, txmp.Size())
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for n := 0; n < b.N; n++ {
+		txmp.ReapMaxBytesMaxGas(-1, -1)
+	}
+}
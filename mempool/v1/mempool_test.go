@@ -2,6 +2,7 @@ package v1
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"math/rand"
@@ -187,6 +188,54 @@ func TestTxMempool_TxsAvailable(t *testing.T) {
 	ensureNoTxFire()
 }
 
+func TestTxMempool_TxsAvailableThreshold(t *testing.T) {
+	txmp := setup(t, 0, WithTxsAvailableThreshold(10))
+	txmp.EnableTxsAvailable()
+
+	ensureNoTxFire := func() {
+		timer := time.NewTimer(500 * time.Millisecond)
+		select {
+		case <-txmp.TxsAvailable():
+			require.Fail(t, "unexpected transactions event")
+		case <-timer.C:
+		}
+	}
+
+	ensureTxFire := func() {
+		timer := time.NewTimer(500 * time.Millisecond)
+		select {
+		case <-txmp.TxsAvailable():
+		case <-timer.C:
+			require.Fail(t, "expected transactions event")
+		}
+	}
+
+	// no event below the threshold
+	txs := checkTxs(t, txmp, 9, 0)
+	ensureNoTxFire()
+
+	// a single event once the threshold is reached
+	txs = append(txs, checkTxs(t, txmp, 1, 1)...)
+	ensureTxFire()
+	ensureNoTxFire()
+
+	rawTxs := make([]types.Tx, 5)
+	responses := make([]*abci.ResponseDeliverTx, 5)
+	for i := range rawTxs {
+		rawTxs[i] = txs[i].tx
+		responses[i] = &abci.ResponseDeliverTx{Code: abci.CodeTypeOK}
+	}
+
+	// the transactions left after the update are below the threshold
+	txmp.Lock()
+	require.NoError(t, txmp.Update(1, rawTxs, responses, nil, nil))
+	txmp.Unlock()
+	ensureNoTxFire()
+
+	_ = checkTxs(t, txmp, 5, 2)
+	ensureTxFire()
+}
+
 func TestTxMempool_Size(t *testing.T) {
 	txmp := setup(t, 0)
 	txs := checkTxs(t, txmp, 100, 0)
@@ -369,6 +418,36 @@ func TestTxMempool_ReapMaxBytesMaxGas(t *testing.T) {
 	require.Len(t, reapedTxs, 25)
 }
 
+func TestTxMempool_ReapMaxBytesMaxGasWithExclusions(t *testing.T) {
+	txmp := setup(t, 0)
+	tTxs := checkTxs(t, txmp, 100, 0) // all txs request 1 gas unit
+	require.Equal(t, len(tTxs), txmp.Size())
+
+	// the txs left out are counted by reason, along with a sample of them
+	reapedTxs, exclusions := txmp.ReapMaxBytesMaxGasWithExclusions(-1, 50)
+	require.Len(t, reapedTxs, 50)
+	require.Equal(t, 50, exclusions.Count(mempool.ExclusionOverGas))
+	require.Zero(t, exclusions.Count(mempool.ExclusionOverBytes))
+	require.Len(t, exclusions[mempool.ExclusionOverGas].SampleHashes, mempool.MaxExclusionSampleHashes)
+	require.Equal(t, reapedTxs, txmp.ReapMaxBytesMaxGas(-1, 50))
+
+	reapedTxs, exclusions = txmp.ReapMaxBytesMaxGasWithExclusions(1000, -1)
+	require.NotEmpty(t, reapedTxs)
+	require.Equal(t, len(tTxs)-len(reapedTxs), exclusions.Count(mempool.ExclusionOverBytes))
+	require.Zero(t, exclusions.Count(mempool.ExclusionOverGas))
+
+	// the gas limit is checked first
+	reapedTxs, exclusions = txmp.ReapMaxBytesMaxGasWithExclusions(2000, 25)
+	require.Len(t, reapedTxs, 25)
+	require.Equal(t, 75, exclusions.Count(mempool.ExclusionOverGas))
+	require.Zero(t, exclusions.Count(mempool.ExclusionOverBytes))
+
+	// nothing is left out when everything fits
+	reapedTxs, exclusions = txmp.ReapMaxBytesMaxGasWithExclusions(-1, -1)
+	require.Len(t, reapedTxs, len(tTxs))
+	require.Empty(t, exclusions)
+}
+
 func TestTxMempoolTxLargerThanMaxBytes(t *testing.T) {
 	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
 	txmp := setup(t, 0)
@@ -740,6 +819,61 @@ func TestRemoveBlobTx(t *testing.T) {
 	assert.EqualValues(t, 0, txmp.SizeBytes())
 }
 
+func TestTxMempool_Events(t *testing.T) {
+	eventBus := types.NewEventBus()
+	require.NoError(t, eventBus.Start())
+	t.Cleanup(func() {
+		if err := eventBus.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+	sub, err := eventBus.Subscribe(context.Background(), "test", types.EventQueryMempoolTx, 100)
+	require.NoError(t, err)
+
+	txmp := setup(t, 100, WithEventBus(eventBus))
+	txs := checkTxs(t, txmp, 10, 0)
+	rawTxs := make([]types.Tx, len(txs))
+	for i, tx := range txs {
+		rawTxs[i] = tx.tx
+	}
+
+	txmp.Lock()
+	require.NoError(t, txmp.Update(1, rawTxs[:4], abciResponses(4, abci.CodeTypeOK), nil, nil))
+	txmp.Unlock()
+	require.NoError(t, txmp.RemoveTxByKey(rawTxs[4].Key()))
+
+	snapshot := txmp.Snapshot()
+	require.EqualValues(t, 15, snapshot.Sequence)
+
+	// applying the events to an empty mempool yields the snapshot
+	hashes := make(map[string]struct{})
+	reasons := make(map[string]int)
+	for seq := uint64(1); seq <= snapshot.Sequence; seq++ {
+		var ev types.EventDataMempoolTx
+		select {
+		case msg := <-sub.Out():
+			ev = msg.Data().(types.EventDataMempoolTx)
+		case <-time.After(time.Second):
+			t.Fatalf("timed out waiting for the mempool tx event %d", seq)
+		}
+		require.Equal(t, seq, ev.Sequence)
+		switch ev.Action {
+		case types.MempoolTxAdded:
+			hashes[ev.Hash.String()] = struct{}{}
+			require.NotEmpty(t, ev.Sender)
+			require.Equal(t, txs[seq-1].priority, ev.Priority)
+		case types.MempoolTxRemoved:
+			delete(hashes, ev.Hash.String())
+			reasons[ev.Reason]++
+		}
+	}
+	require.Equal(t, map[string]int{types.MempoolTxCommitted: 4, types.MempoolTxEvicted: 1}, reasons)
+	require.Len(t, snapshot.Hashes, len(hashes))
+	for _, hash := range snapshot.Hashes {
+		require.Contains(t, hashes, hash.String())
+	}
+}
+
 func abciResponses(n int, code uint32) []*abci.ResponseDeliverTx {
 	responses := make([]*abci.ResponseDeliverTx, 0, n)
 	for i := 0; i < n; i++ {
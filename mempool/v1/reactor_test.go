@@ -7,12 +7,15 @@ import (
 	"testing"
 	"time"
 
+	"github.com/go-kit/kit/metrics/generic"
 	"github.com/go-kit/log/term"
 	"github.com/gogo/protobuf/proto"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/tendermint/tendermint/abci/example/kvstore"
+	abci "github.com/tendermint/tendermint/abci/types"
+	cmtrand "github.com/tendermint/tendermint/libs/rand"
 	"github.com/tendermint/tendermint/p2p/mock"
 	"github.com/tendermint/tendermint/pkg/trace"
 
@@ -126,6 +129,121 @@ func TestReactorEventuallyRemovesExpiredTransaction(t *testing.T) {
 	require.False(t, has)
 }
 
+// A relayer holding txs while the chain advances does not forward them once it
+// held them for more than max-gossip-tx-age blocks.
+func TestReactorMaxGossipTxAge(t *testing.T) {
+	const maxAge = 5
+	config := cfg.TestConfig()
+	config.Mempool.MaxGossipTxAge = maxAge
+
+	// the origin, the relayer and the receiver, in a line
+	const N = 3
+	reactors := make([]*Reactor, N)
+	for i := range reactors {
+		mp, cleanup := newMempoolWithAppAndConfig(proxy.NewLocalClientCreator(kvstore.NewApplication()), config)
+		defer cleanup()
+		mp.metrics = mempool.NopMetrics()
+		mp.metrics.UnsentStaleTxs = generic.NewCounter("unsent_stale_txs")
+		reactors[i] = NewReactor(config.Mempool, mp, trace.NoOpTracer())
+		reactors[i].SetLogger(mempoolLogger().With("validator", i))
+	}
+	p2p.MakeConnectedSwitches(config.P2P, N, func(i int, s *p2p.Switch) *p2p.Switch {
+		s.AddReactor("MEMPOOL", reactors[i])
+		return s
+	}, func(sws []*p2p.Switch, i, j int) {
+		if j == i+1 {
+			p2p.Connect2Switches(sws, i, j)
+		}
+	})
+	defer func() {
+		for _, r := range reactors {
+			if err := r.Stop(); err != nil {
+				assert.NoError(t, err)
+			}
+		}
+	}()
+	origin, relayer, receiver := reactors[0], reactors[1], reactors[2]
+	advance := func(height int64) {
+		for _, r := range reactors {
+			r.mempool.Lock()
+			err := r.mempool.Update(height, []types.Tx{}, make([]*abci.ResponseDeliverTx, 0), nil, nil)
+			r.mempool.Unlock()
+			require.NoError(t, err)
+			for _, peer := range r.Switch.Peers().List() {
+				// the relayer is delayed: it does not gossip to the receiver
+				// until the receiver has a peer state
+				if height > 1 || r != relayer || peer.ID() != receiver.Switch.NetAddress().ID {
+					peer.Set(types.PeerStateKey, peerState{height})
+				}
+			}
+		}
+	}
+	hasTxs := func(r *Reactor, txs []testTx) bool {
+		for _, tx := range txs {
+			if _, ok := r.mempool.GetTxByKey(tx.tx.Key()); !ok {
+				return false
+			}
+		}
+		return true
+	}
+
+	advance(1)
+	staleTxs := checkTxs(t, origin.mempool, 5, mempool.UnknownPeerID)
+	require.Eventually(t, func() bool { return hasTxs(relayer, staleTxs) }, timeout, 10*time.Millisecond)
+
+	// the relayer held the txs for too long to forward them
+	advance(1 + maxAge + 1)
+	require.Eventually(t, func() bool {
+		return relayer.mempool.metrics.UnsentStaleTxs.(*generic.Counter).Value() == float64(len(staleTxs))
+	}, timeout, 10*time.Millisecond)
+
+	// new txs are still relayed
+	txs := checkTxs(t, origin.mempool, 5, mempool.UnknownPeerID)
+	require.Eventually(t, func() bool { return hasTxs(receiver, txs) }, timeout, 10*time.Millisecond)
+	assert.Equal(t, len(txs), receiver.mempool.Size())
+}
+
+func TestReactorDropsStaleTxs(t *testing.T) {
+	config := cfg.TestConfig()
+	config.Mempool.MaxGossipTxAge = 5
+	reactors := makeAndConnectReactors(config, 1)
+	reactor, peer := reactors[0], mock.NewPeer(nil)
+	defer func() {
+		assert.NoError(t, reactor.Stop())
+	}()
+	reactor.mempool.metrics.DroppedStaleTxs = generic.NewCounter("dropped_stale_txs")
+	reactor.InitPeer(peer)
+	reactor.mempool.Lock()
+	require.NoError(t, reactor.mempool.Update(10, []types.Tx{}, make([]*abci.ResponseDeliverTx, 0), nil, nil))
+	reactor.mempool.Unlock()
+
+	// the peer first saw the first tx more than 5 blocks ago, and the second
+	// one within 5 blocks
+	txs := types.Txs{cmtrand.Bytes(20), cmtrand.Bytes(20)}
+	reactor.ReceiveEnvelope(p2p.Envelope{
+		Src:       peer,
+		ChannelID: mempool.MempoolChannel,
+		Message: &memproto.Txs{
+			Txs:      [][]byte{txs[0], txs[1]},
+			Metadata: []*memproto.TxMetadata{{OriginHeight: 4}, {OriginHeight: 5}},
+		},
+	})
+	assert.Equal(t, 1.0, reactor.mempool.metrics.DroppedStaleTxs.(*generic.Counter).Value())
+	_, ok := reactor.mempool.GetTxByKey(txs[0].Key())
+	assert.False(t, ok)
+	_, ok = reactor.mempool.GetTxByKey(txs[1].Key())
+	assert.True(t, ok)
+
+	// a peer not reporting when it first saw the tx
+	reactor.ReceiveEnvelope(p2p.Envelope{
+		Src:       peer,
+		ChannelID: mempool.MempoolChannel,
+		Message:   &memproto.Txs{Txs: [][]byte{txs[0]}},
+	})
+	_, ok = reactor.mempool.GetTxByKey(txs[0].Key())
+	assert.True(t, ok)
+}
+
 func TestLegacyReactorReceiveBasic(t *testing.T) {
 	config := cfg.TestConfig()
 	// if there were more than two reactors, the order of transactions could not be
@@ -0,0 +1,202 @@
+package node
+
+import (
+	"errors"
+	"fmt"
+	"net"
+
+	dbm "github.com/cometbft/cometbft-db"
+
+	abcicli "github.com/tendermint/tendermint/abci/client"
+	cfg "github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/libs/log"
+	cmtos "github.com/tendermint/tendermint/libs/os"
+	"github.com/tendermint/tendermint/libs/service"
+	"github.com/tendermint/tendermint/p2p"
+	"github.com/tendermint/tendermint/proxy"
+	rpccore "github.com/tendermint/tendermint/rpc/core"
+	sm "github.com/tendermint/tendermint/state"
+	"github.com/tendermint/tendermint/state/txindex/null"
+	"github.com/tendermint/tendermint/store"
+	"github.com/tendermint/tendermint/types"
+	"github.com/tendermint/tendermint/version"
+)
+
+// ArchiveServer serves the RPC of a node from its block store, state store and
+// indexers, e.g. copied from another node, without running p2p, consensus or
+// the mempool. It serves the read-only routes that do not need them, e.g.
+// /block, /tx and /block_results, while the others return an error as not
+// available in archive mode.
+type ArchiveServer struct {
+	service.BaseService
+
+	config        *cfg.Config
+	clientCreator proxy.ClientCreator
+	genDoc        *types.GenesisDoc
+
+	blockStore *store.BlockStore
+	stateStore sm.Store
+	indexerDB  dbm.DB // nil unless the kv indexer is used
+	env        *rpccore.Environment
+
+	appClient   abcicli.Client
+	listeners   []net.Listener
+	dataDirLock *cmtos.FileLock
+}
+
+// NewArchiveServer returns an archive server for the databases of the node in
+// config. The application, which builds the proofs and answers the ABCI
+// queries, is only queried if clientCreator is not nil.
+func NewArchiveServer(
+	config *cfg.Config,
+	dbProvider DBProvider,
+	genesisDocProvider GenesisDocProvider,
+	clientCreator proxy.ClientCreator,
+	logger log.Logger,
+) (*ArchiveServer, error) {
+	dataDirLock, err := lockDataDir(config, logger)
+	if err != nil {
+		return nil, err
+	}
+	constructed := false
+	defer func() {
+		if !constructed {
+			releaseDataDirLock(dataDirLock, logger)
+		}
+	}()
+
+	blockStore, stateDB, err := initDBs(config, dbProvider)
+	if err != nil {
+		return nil, err
+	}
+	stateStore := sm.NewStore(stateDB, sm.StoreOptions{
+		DiscardABCIResponses: config.Storage.DiscardABCIResponses,
+	})
+
+	// the genesis doc saved by the node, not to save one to its databases
+	genDoc, err := loadGenesisDoc(stateDB)
+	if err != nil {
+		if genDoc, err = genesisDocProvider(); err != nil {
+			return nil, err
+		}
+	}
+	state, err := stateStore.Load()
+	if err != nil {
+		return nil, fmt.Errorf("loading state: %w", err)
+	}
+	if state.IsEmpty() {
+		return nil, errors.New("no state found: an archive server serves the databases of an existing node")
+	}
+	if state.ChainID != genDoc.ChainID {
+		return nil, fmt.Errorf("genesis doc chain ID %q does not match the state chain ID %q",
+			genDoc.ChainID, state.ChainID)
+	}
+
+	txIndexer, blockIndexer, indexerDB, err := createIndexers(config, genDoc.ChainID, dbProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	txIndexerStatus := "on"
+	if _, ok := txIndexer.(*null.TxIndex); ok {
+		txIndexerStatus = "off"
+	}
+	nodeInfo := p2p.DefaultNodeInfo{
+		ProtocolVersion: p2p.NewProtocolVersion(
+			version.P2PProtocol,
+			state.Version.Consensus.Block,
+			state.Version.Consensus.App,
+		),
+		Network: genDoc.ChainID,
+		Version: version.TMCoreSemVer,
+		Moniker: config.Moniker,
+		Other: p2p.DefaultNodeInfoOther{
+			TxIndex:    txIndexerStatus,
+			RPCAddress: config.RPC.ListenAddress,
+		},
+	}
+
+	env := rpccore.NewArchiveEnvironment(*config.RPC, nodeInfo, genDoc, stateStore, blockStore,
+		txIndexer, blockIndexer, logger.With("module", "rpc"))
+
+	s := &ArchiveServer{
+		config:        config,
+		clientCreator: clientCreator,
+		genDoc:        genDoc,
+		blockStore:    blockStore,
+		stateStore:    stateStore,
+		indexerDB:     indexerDB,
+		env:           env,
+		dataDirLock:   dataDirLock,
+	}
+	s.BaseService = *service.NewBaseService(logger, "ArchiveServer", s)
+	constructed = true
+	return s, nil
+}
+
+// OnStart implements service.Service.
+func (s *ArchiveServer) OnStart() error {
+	if s.clientCreator != nil {
+		client, err := s.clientCreator.NewABCIClient()
+		if err != nil {
+			return fmt.Errorf("error creating ABCI client: %w", err)
+		}
+		client.SetLogger(s.Logger.With("module", "proxy"))
+		if err := client.Start(); err != nil {
+			return fmt.Errorf("error starting ABCI client: %w", err)
+		}
+		s.appClient = client
+		s.env.ProxyAppQuery = proxy.NewAppConnQuery(client)
+	}
+
+	rpccore.SetEnvironment(s.env)
+	if err := rpccore.InitGenesisChunks(); err != nil {
+		return err
+	}
+
+	listeners, err := serveRPC(s.config.RPC, rpccore.ArchiveRoutes(rpccore.Routes), rpccore.NopMetrics(), s.Logger, nil)
+	if err != nil {
+		return err
+	}
+	s.listeners = listeners
+
+	s.Logger.Info("Serving archived blocks",
+		"base", s.blockStore.Base(), "height", s.blockStore.Height(), "chain_id", s.genDoc.ChainID)
+	return nil
+}
+
+// OnStop implements service.Service.
+func (s *ArchiveServer) OnStop() {
+	for _, l := range s.listeners {
+		s.Logger.Info("Closing rpc listener", "listener", l)
+		if err := l.Close(); err != nil {
+			s.Logger.Error("Error closing listener", "listener", l, "err", err)
+		}
+	}
+	if s.appClient != nil {
+		if err := s.appClient.Stop(); err != nil {
+			s.Logger.Error("Error stopping ABCI client", "err", err)
+		}
+	}
+	if err := s.blockStore.Close(); err != nil {
+		s.Logger.Error("Error closing blockstore", "err", err)
+	}
+	if err := s.stateStore.Close(); err != nil {
+		s.Logger.Error("Error closing statestore", "err", err)
+	}
+	if s.indexerDB != nil {
+		if err := s.indexerDB.Close(); err != nil {
+			s.Logger.Error("Error closing tx index", "err", err)
+		}
+	}
+	releaseDataDirLock(s.dataDirLock, s.Logger)
+}
+
+// Listeners returns the addresses the RPC is served on.
+func (s *ArchiveServer) Listeners() []string {
+	addrs := make([]string, len(s.listeners))
+	for i, l := range s.listeners {
+		addrs[i] = l.Addr().String()
+	}
+	return addrs
+}
@@ -0,0 +1,125 @@
+package node
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	dbm "github.com/cometbft/cometbft-db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cfg "github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/libs/log"
+	mempl "github.com/tendermint/tendermint/mempool"
+	"github.com/tendermint/tendermint/p2p"
+	"github.com/tendermint/tendermint/privval"
+	"github.com/tendermint/tendermint/proxy"
+	rpchttp "github.com/tendermint/tendermint/rpc/client/http"
+	rpccore "github.com/tendermint/tendermint/rpc/core"
+	"github.com/tendermint/tendermint/types"
+)
+
+// makeArchiveTestData runs a node with the kvstore app on goleveldb databases
+// until it commits tx, then stops it, leaving its data directory in config.
+// It returns the height of the block with tx.
+func makeArchiveTestData(t *testing.T, config *cfg.Config, tx types.Tx) int64 {
+	config.DBBackend = "goleveldb"
+
+	// the node does not close the tx index
+	var dbs []dbm.DB
+	dbProvider := func(ctx *DBContext) (dbm.DB, error) {
+		db, err := DefaultDBProvider(ctx)
+		if err == nil && ctx.ID == "tx_index" {
+			dbs = append(dbs, db)
+		}
+		return db, err
+	}
+	nodeKey, err := p2p.LoadOrGenNodeKey(config.NodeKeyFile())
+	require.NoError(t, err)
+	n, err := NewNode(config,
+		privval.LoadOrGenFilePV(config.PrivValidatorKeyFile(), config.PrivValidatorStateFile()),
+		nodeKey,
+		proxy.DefaultClientCreator(config.ProxyApp, config.ABCI, config.DBDir()),
+		DefaultGenesisDocProviderFunc(config),
+		dbProvider,
+		DefaultMetricsProvider(config.Instrumentation),
+		log.TestingLogger(),
+	)
+	require.NoError(t, err)
+	require.NoError(t, n.Start())
+
+	txSub, err := n.EventBus().Subscribe(context.Background(), "archive_test",
+		types.EventQueryTxFor(tx))
+	require.NoError(t, err)
+	require.NoError(t, n.Mempool().CheckTx(tx, nil, mempl.TxInfo{}))
+
+	var height int64
+	select {
+	case msg := <-txSub.Out():
+		height = msg.Data().(types.EventDataTx).Height
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for the tx to be committed")
+	}
+	// let the indexer catch up
+	require.Eventually(t, func() bool {
+		res, err := n.txIndexer.Get(tx.Hash())
+		return err == nil && res != nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	require.NoError(t, n.Stop())
+	for _, db := range dbs {
+		require.NoError(t, db.Close())
+	}
+	return height
+}
+
+func TestArchiveServer(t *testing.T) {
+	config := cfg.ResetTestRoot("node_archive_test")
+	defer os.RemoveAll(config.RootDir)
+
+	tx := types.Tx("archived=true")
+	height := makeArchiveTestData(t, config, tx)
+
+	config.RPC.ListenAddress = "tcp://" + testFreeAddr(t)
+	s, err := NewArchiveServer(config, DefaultDBProvider, DefaultGenesisDocProviderFunc(config), nil,
+		log.TestingLogger())
+	require.NoError(t, err)
+	require.NoError(t, s.Start())
+	defer s.Stop() //nolint:errcheck // ignore for tests
+
+	c, err := rpchttp.New(config.RPC.ListenAddress, "/websocket")
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	status, err := c.Status(ctx)
+	require.NoError(t, err)
+	assert.True(t, status.ArchiveMode)
+	assert.False(t, status.SyncInfo.CatchingUp)
+	assert.GreaterOrEqual(t, status.SyncInfo.LatestBlockHeight, height)
+	assert.Equal(t, config.ChainID(), status.NodeInfo.Network)
+
+	block, err := c.Block(ctx, &height)
+	require.NoError(t, err)
+	assert.Contains(t, block.Block.Txs, tx)
+
+	res, err := c.Tx(ctx, tx.Hash(), false)
+	require.NoError(t, err)
+	assert.Equal(t, height, res.Height)
+
+	results, err := c.BlockResults(ctx, &height)
+	require.NoError(t, err)
+	assert.Len(t, results.TxsResults, len(block.Block.Txs))
+
+	// the routes needing a running node are not served
+	_, err = c.NetInfo(ctx)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), rpccore.ErrArchiveMode.Error())
+	_, err = c.BroadcastTxSync(ctx, types.Tx("archived=false"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), rpccore.ErrArchiveMode.Error())
+	// and neither are the proofs, without the app
+	_, err = c.Tx(ctx, tx.Hash(), true)
+	assert.Error(t, err)
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"net"
 	"net/http"
 	"strings"
@@ -16,6 +17,8 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/rs/cors"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 
 	abci "github.com/tendermint/tendermint/abci/types"
 	bcv0 "github.com/tendermint/tendermint/blockchain/v0"
@@ -27,10 +30,13 @@ import (
 	"github.com/tendermint/tendermint/evidence"
 	"github.com/tendermint/tendermint/pkg/trace"
 
+	cmtflags "github.com/tendermint/tendermint/libs/cli/flags"
 	cmtjson "github.com/tendermint/tendermint/libs/json"
 	"github.com/tendermint/tendermint/libs/log"
+	cmtos "github.com/tendermint/tendermint/libs/os"
 	cmtpubsub "github.com/tendermint/tendermint/libs/pubsub"
 	"github.com/tendermint/tendermint/libs/service"
+	cmtsync "github.com/tendermint/tendermint/libs/sync"
 	"github.com/tendermint/tendermint/light"
 	mempl "github.com/tendermint/tendermint/mempool"
 	mempoolv2 "github.com/tendermint/tendermint/mempool/cat"
@@ -38,6 +44,7 @@ import (
 	mempoolv1 "github.com/tendermint/tendermint/mempool/v1"
 	"github.com/tendermint/tendermint/p2p"
 	"github.com/tendermint/tendermint/p2p/pex"
+	"github.com/tendermint/tendermint/p2p/pkcs11"
 	"github.com/tendermint/tendermint/privval"
 	"github.com/tendermint/tendermint/proxy"
 	rpccore "github.com/tendermint/tendermint/rpc/core"
@@ -75,11 +82,11 @@ type DBProvider func(*DBContext) (dbm.DB, error)
 
 const readHeaderTimeout = 10 * time.Second
 
-// DefaultDBProvider returns a database using the DBBackend and DBDir
-// specified in the ctx.Config.
+// DefaultDBProvider returns a database using the DBBackend and the directory
+// of the database, see StoreDir, specified in the ctx.Config.
 func DefaultDBProvider(ctx *DBContext) (dbm.DB, error) {
 	dbType := dbm.BackendType(ctx.Config.DBBackend)
-	return dbm.NewDB(ctx.ID, dbType, ctx.Config.DBDir())
+	return dbm.NewDB(ctx.ID, dbType, ctx.Config.StoreDir(ctx.ID))
 }
 
 // GenesisDocProvider returns a GenesisDoc.
@@ -102,12 +109,12 @@ type Provider func(*cfg.Config, log.Logger) (*Node, error)
 // PrivValidator, ClientCreator, GenesisDoc, and DBProvider.
 // It implements NodeProvider.
 func DefaultNewNode(config *cfg.Config, logger log.Logger) (*Node, error) {
-	nodeKey, err := p2p.LoadOrGenNodeKey(config.NodeKeyFile())
+	nodeKey, err := loadNodeKey(config)
 	if err != nil {
-		return nil, fmt.Errorf("failed to load or gen node key %s: %w", config.NodeKeyFile(), err)
+		return nil, err
 	}
 
-	return NewNode(config,
+	n, err := NewNode(config,
 		privval.LoadOrGenFilePV(config.PrivValidatorKeyFile(), config.PrivValidatorStateFile()),
 		nodeKey,
 		proxy.DefaultClientCreator(config.ProxyApp, config.ABCI, config.DBDir()),
@@ -116,6 +123,63 @@ func DefaultNewNode(config *cfg.Config, logger log.Logger) (*Node, error) {
 		DefaultMetricsProvider(config.Instrumentation),
 		logger,
 	)
+	if err != nil {
+		closeNodeKey(nodeKey, logger)
+	}
+	return n, err
+}
+
+// loadNodeKey returns the node key of config: the key in the PKCS#11 token of
+// p2p.node_key_pkcs11 if set, else the key of node_key_file, generated if
+// missing.
+func loadNodeKey(config *cfg.Config) (*p2p.NodeKey, error) {
+	if config.P2P.NodeKeyPKCS11.Enabled() {
+		signer, err := pkcs11.NewSigner(config.P2P.NodeKeyPKCS11)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open node key %q in PKCS#11 module %s: %w",
+				config.P2P.NodeKeyPKCS11.KeyLabel, config.P2P.NodeKeyPKCS11.Module, err)
+		}
+		return p2p.NewSignerNodeKey(signer), nil
+	}
+
+	nodeKey, err := p2p.LoadOrGenNodeKey(config.NodeKeyFile())
+	if err != nil {
+		return nil, fmt.Errorf("failed to load or gen node key %s: %w", config.NodeKeyFile(), err)
+	}
+	return nodeKey, nil
+}
+
+// closeNodeKey closes the signer of the node key if it needs to, e.g. the
+// session of its PKCS#11 token.
+func closeNodeKey(nodeKey *p2p.NodeKey, logger log.Logger) {
+	if closer, ok := nodeKey.Signer().(io.Closer); ok {
+		if err := closer.Close(); err != nil {
+			logger.Error("Error closing node key", "err", err)
+		}
+	}
+}
+
+// lockDataDir acquires the lock of the data directory, so that no other
+// process opens its databases while the node runs. The directories of the
+// databases are created first.
+func lockDataDir(config *cfg.Config, logger log.Logger) (*cmtos.FileLock, error) {
+	if err := cfg.EnsureStoreDirs(config.BaseConfig); err != nil {
+		return nil, err
+	}
+	lock, err := cmtos.LockFile(config.DBLockFile())
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock the data directory: %w", err)
+	}
+	if lock.StalePID != 0 {
+		logger.Info("The previous process using the data directory did not release its lock", "pid", lock.StalePID)
+	}
+	return lock, nil
+}
+
+func releaseDataDirLock(lock *cmtos.FileLock, logger log.Logger) {
+	if err := lock.Release(); err != nil {
+		logger.Error("Error releasing the data directory lock", "err", err)
+	}
 }
 
 // MetricsProvider returns a consensus, p2p and mempool Metrics.
@@ -226,17 +290,26 @@ type Node struct {
 	stateSyncGenesis  sm.State                // provides the genesis state for state sync
 	consensusState    *cs.State               // latest consensus state
 	consensusReactor  *cs.Reactor             // for participating in the consensus
+	appUpgrader       *cs.AppUpgrader         // for upgrading the application while running
 	pexReactor        *pex.Reactor            // for exchanging peer addresses
 	evidencePool      *evidence.Pool          // tracking evidence
+	reapDecisions     *sm.ReapDecisions       // optional, see consensus.record_reap_decisions
+	appRequirements   *mempl.AppRequirements  // mempool requirements of the application
+	logRing           *log.RingWriter         // optional, see log_ring_buffer_size
+	rpcMetrics        *rpccore.Metrics        // websocket subscription metrics
 	proxyApp          proxy.AppConns          // connection to the application
 	rpcListeners      []net.Listener          // rpc servers
+	grpcServer        *grpccore.Server        // optional, see rpc.grpc_laddr
 	txIndexer         txindex.TxIndexer
 	blockIndexer      indexer.BlockIndexer
 	indexerService    *txindex.IndexerService
+	rowProofRecorder  *sm.RowProofRecorder // optional, see storage.persist_row_proofs
+	snapshotManager   *statesync.SnapshotManager
 	prometheusSrv     *http.Server
 	tracer            trace.Tracer
 	pyroscopeProfiler *pyroscope.Profiler
 	pyroscopeTracer   *sdktrace.TracerProvider
+	dataDirLock       *cmtos.FileLock // held from construction until stopped
 }
 
 func initDBs(config *cfg.Config, dbProvider DBProvider) (blockStore *store.BlockStore, stateDB dbm.DB, err error) {
@@ -255,8 +328,78 @@ func initDBs(config *cfg.Config, dbProvider DBProvider) (blockStore *store.Block
 	return
 }
 
-func createAndStartProxyAppConns(clientCreator proxy.ClientCreator, logger log.Logger) (proxy.AppConns, error) {
-	proxyApp := proxy.NewAppConns(clientCreator)
+// instrumentedDBProvider wraps the databases of a DBProvider in
+// store.InstrumentedDBs. Their metrics are labeled by the chain ID, so they
+// are only set once it is known, by setMetrics.
+type instrumentedDBProvider struct {
+	dbProvider DBProvider
+
+	mtx     cmtsync.Mutex
+	dbs     []*store.InstrumentedDB
+	metrics *store.Metrics
+}
+
+func (p *instrumentedDBProvider) provide(ctx *DBContext) (dbm.DB, error) {
+	db, err := p.dbProvider(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var keyKind store.KeyKindFunc
+	switch ctx.ID {
+	case "blockstore":
+		keyKind = store.BlockStoreKeyKind
+	case "state":
+		keyKind = sm.StoreKeyKind
+	}
+	idb := store.NewInstrumentedDB(db, ctx.ID, keyKind)
+
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	if p.metrics != nil {
+		idb.SetMetrics(p.metrics)
+	}
+	p.dbs = append(p.dbs, idb)
+	return idb, nil
+}
+
+func (p *instrumentedDBProvider) setMetrics(metrics *store.Metrics) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	p.metrics = metrics
+	for _, db := range p.dbs {
+		db.SetMetrics(metrics)
+	}
+}
+
+// proxyAppConnOptions returns the options serving the ABCI connections with a
+// configured address by distinct apps.
+func proxyAppConnOptions(config *cfg.Config) []proxy.MultiAppConnOption {
+	var options []proxy.MultiAppConnOption
+	if addr := config.ProxyAppConsensus; addr != "" {
+		options = append(options, proxy.WithConsensusClientCreator(
+			proxy.DefaultClientCreator(addr, config.ABCI, config.DBDir())))
+	}
+	if addr := config.ProxyAppMempool; addr != "" {
+		options = append(options, proxy.WithMempoolClientCreator(
+			proxy.DefaultClientCreator(addr, config.ABCI, config.DBDir())))
+	}
+	if addr := config.ProxyAppQuery; addr != "" {
+		options = append(options, proxy.WithQueryClientCreator(
+			proxy.DefaultClientCreator(addr, config.ABCI, config.DBDir())))
+	}
+	if addr := config.ProxyAppSnapshot; addr != "" {
+		options = append(options, proxy.WithSnapshotClientCreator(
+			proxy.DefaultClientCreator(addr, config.ABCI, config.DBDir())))
+	}
+	return options
+}
+
+func createAndStartProxyAppConns(
+	clientCreator proxy.ClientCreator,
+	logger log.Logger,
+	options ...proxy.MultiAppConnOption,
+) (proxy.AppConns, error) {
+	proxyApp := proxy.NewAppConns(clientCreator, options...)
 	proxyApp.SetLogger(logger.With("module", "proxy"))
 	if err := proxyApp.Start(); err != nil {
 		return nil, fmt.Errorf("error starting proxy app connections: %v", err)
@@ -264,6 +407,17 @@ func createAndStartProxyAppConns(clientCreator proxy.ClientCreator, logger log.L
 	return proxyApp, nil
 }
 
+// createLogRing tees the logs of the node, at the configured log level, into
+// a ring buffer keeping the most recent lines for the /debug_bundle endpoint.
+func createLogRing(config *cfg.Config, logger log.Logger) (*log.RingWriter, log.Logger, error) {
+	ring := log.NewRingWriter(config.LogRingBufferSize)
+	ringLogger, err := cmtflags.ParseLogLevel(config.LogLevel, log.NewTMLogger(ring), cfg.DefaultLogLevel)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse log level: %w", err)
+	}
+	return ring, log.NewTeeLogger(logger, ringLogger), nil
+}
+
 func createAndStartEventBus(logger log.Logger) (*types.EventBus, error) {
 	eventBus := types.NewEventBus()
 	eventBus.SetLogger(logger.With("module", "events"))
@@ -273,26 +427,28 @@ func createAndStartEventBus(logger log.Logger) (*types.EventBus, error) {
 	return eventBus, nil
 }
 
-func createAndStartIndexerService(
+// createIndexers creates the tx and block indexers of the configured kind.
+// heightDB is the database of the kv indexers, nil for the others.
+func createIndexers(
 	config *cfg.Config,
 	chainID string,
 	dbProvider DBProvider,
-	eventBus *types.EventBus,
-	logger log.Logger,
-) (*txindex.IndexerService, txindex.TxIndexer, indexer.BlockIndexer, error) {
-	var (
-		txIndexer    txindex.TxIndexer
-		blockIndexer indexer.BlockIndexer
-	)
-
+) (txIndexer txindex.TxIndexer, blockIndexer indexer.BlockIndexer, heightDB dbm.DB, err error) {
 	switch config.TxIndex.Indexer {
 	case "kv":
 		store, err := dbProvider(&DBContext{"tx_index", config})
 		if err != nil {
 			return nil, nil, nil, err
 		}
+		heightDB = store
 
-		txIndexer = kv.NewTxIndex(store)
+		kvIndexer := kv.NewTxIndex(store)
+		kvIndexer.SetQueryLimits(kv.QueryLimits{
+			MaxHeightSpan: config.TxIndex.QueryMaxHeightSpan,
+			MaxRows:       config.TxIndex.QueryMaxRows,
+			Timeout:       config.TxIndex.QueryTimeout,
+		})
+		txIndexer = kvIndexer
 		blockIndexer = blockidxkv.New(dbm.NewPrefixDB(store, []byte("block_events")))
 
 	case "psql":
@@ -311,8 +467,38 @@ func createAndStartIndexerService(
 		blockIndexer = &blockidxnull.BlockerIndexer{}
 	}
 
+	return txIndexer, blockIndexer, heightDB, nil
+}
+
+func createAndStartIndexerService(
+	config *cfg.Config,
+	chainID string,
+	dbProvider DBProvider,
+	eventBus *types.EventBus,
+	stateStore sm.Store,
+	blockStore sm.BlockStore,
+	lastHeight int64,
+	logger log.Logger,
+) (*txindex.IndexerService, txindex.TxIndexer, indexer.BlockIndexer, error) {
+	txIndexer, blockIndexer, heightDB, err := createIndexers(config, chainID, dbProvider)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
 	indexerService := txindex.NewIndexerService(txIndexer, blockIndexer, eventBus, false)
+	indexerService.SetTxUnwrapper(txindex.DefaultTxUnwrapper)
 	indexerService.SetLogger(logger.With("module", "txindex"))
+	if config.Instrumentation.Prometheus {
+		metrics := txindex.PrometheusMetrics(config.Instrumentation.Namespace, "chain_id", chainID)
+		indexerService.SetMetrics(metrics)
+		if kvIndexer, ok := txIndexer.(*kv.TxIndex); ok {
+			kvIndexer.SetMetrics(metrics)
+		}
+	}
+	// the psql indexer has no local database to persist the indexed height in
+	if config.TxIndex.Async && heightDB != nil {
+		indexerService.EnableAsync(heightDB, lastHeight, blockStore, stateStore)
+	}
 
 	if err := indexerService.Start(); err != nil {
 		return nil, nil, nil, err
@@ -377,6 +563,8 @@ func createMempoolAndMempoolReactor(
 	proxyApp proxy.AppConns,
 	state sm.State,
 	memplMetrics *mempl.Metrics,
+	appRequirements *mempl.AppRequirements,
+	eventBus *types.EventBus,
 	logger log.Logger,
 	traceClient trace.Tracer,
 ) (mempl.Mempool, p2p.Reactor) {
@@ -388,8 +576,10 @@ func createMempoolAndMempoolReactor(
 			proxyApp.Mempool(),
 			state.LastBlockHeight,
 			mempoolv2.WithMetrics(memplMetrics),
-			mempoolv2.WithPreCheck(sm.TxPreCheck(state)),
+			mempoolv2.WithPreCheck(appRequirements.PreCheck(sm.TxPreCheck(state))),
 			mempoolv2.WithPostCheck(sm.TxPostCheck(state)),
+			mempoolv2.WithTxsAvailableThreshold(config.Consensus.MinTxsInBlock),
+			mempoolv2.WithEventBus(eventBus),
 		)
 
 		reactor, err := mempoolv2.NewReactor(
@@ -418,9 +608,11 @@ func createMempoolAndMempoolReactor(
 			proxyApp.Mempool(),
 			state.LastBlockHeight,
 			mempoolv1.WithMetrics(memplMetrics),
-			mempoolv1.WithPreCheck(sm.TxPreCheck(state)),
+			mempoolv1.WithPreCheck(appRequirements.PreCheck(sm.TxPreCheck(state))),
 			mempoolv1.WithPostCheck(sm.TxPostCheck(state)),
+			mempoolv1.WithTxsAvailableThreshold(config.Consensus.MinTxsInBlock),
 			mempoolv1.WithTraceClient(traceClient),
+			mempoolv1.WithEventBus(eventBus),
 		)
 
 		reactor := mempoolv1.NewReactor(
@@ -441,8 +633,10 @@ func createMempoolAndMempoolReactor(
 			proxyApp.Mempool(),
 			state.LastBlockHeight,
 			mempoolv0.WithMetrics(memplMetrics),
-			mempoolv0.WithPreCheck(sm.TxPreCheck(state)),
+			mempoolv0.WithPreCheck(appRequirements.PreCheck(sm.TxPreCheck(state))),
 			mempoolv0.WithPostCheck(sm.TxPostCheck(state)),
+			mempoolv0.WithTxsAvailableThreshold(config.Consensus.MinTxsInBlock),
+			mempoolv0.WithEventBus(eventBus),
 		)
 
 		mp.SetLogger(logger)
@@ -464,7 +658,7 @@ func createMempoolAndMempoolReactor(
 }
 
 func createEvidenceReactor(config *cfg.Config, dbProvider DBProvider,
-	stateDB dbm.DB, blockStore *store.BlockStore, logger log.Logger,
+	stateDB dbm.DB, blockStore *store.BlockStore, proxyApp proxy.AppConns, logger log.Logger,
 ) (*evidence.Reactor, *evidence.Pool, error) {
 	evidenceDB, err := dbProvider(&DBContext{"evidence", config})
 	if err != nil {
@@ -477,6 +671,28 @@ func createEvidenceReactor(config *cfg.Config, dbProvider DBProvider,
 	if err != nil {
 		return nil, nil, err
 	}
+	state := evidencePool.State()
+	if config.Instrumentation.Prometheus {
+		evidencePool.SetMetrics(evidence.PrometheusMetrics(config.Instrumentation.Namespace, "chain_id", state.ChainID))
+	}
+	info, err := proxyApp.Query().InfoSync(proxy.RequestInfo)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error during info call: %w", err)
+	}
+	if info.HasCapability(abci.CapabilityVerifyEvidence) {
+		evidencePool.SetAppConn(proxyApp.Query())
+	}
+	// blocks pruned before the node started can't be restored, so only warn
+	// that evidence at the pruned heights can't be verified
+	evidenceParams := state.ConsensusParams.Evidence
+	if base := blockStore.Base(); base > state.InitialHeight && base > state.LastBlockHeight-evidenceParams.MaxAgeNumBlocks {
+		baseMeta := blockStore.LoadBlockMeta(base)
+		if baseMeta != nil && !baseMeta.Header.Time.Before(state.LastBlockTime.Add(-evidenceParams.MaxAgeDuration)) {
+			evidenceLogger.Error("blocks within the max evidence age have been pruned, evidence at those heights can't be verified",
+				"base", base, "max_age_num_blocks", evidenceParams.MaxAgeNumBlocks,
+				"max_age_duration", evidenceParams.MaxAgeDuration)
+		}
+	}
 	evidenceReactor := evidence.NewReactor(evidencePool)
 	evidenceReactor.SetLogger(evidenceLogger)
 	return evidenceReactor, evidencePool, nil
@@ -607,6 +823,15 @@ func createTransport(
 
 	p2p.MultiplexTransportConnFilters(connFilters...)(transport)
 
+	if config.P2P.MaxSubnetConnRate > 0 {
+		p2p.MultiplexTransportSubnetLimiter(p2p.NewSubnetLimiter(
+			config.P2P.MaxSubnetConnRate,
+			config.P2P.SubnetConnRateWindow,
+			p2p.SubnetLimiterIPv4PrefixLen(config.P2P.SubnetIPv4PrefixLen),
+			p2p.SubnetLimiterIPv6PrefixLen(config.P2P.SubnetIPv6PrefixLen),
+		))(transport)
+	}
+
 	// Limit the number of incoming connections.
 	max := config.P2P.MaxNumInboundPeers + len(splitAndTrimEmpty(config.P2P.UnconditionalPeerIDs, ",", " "))
 	p2p.MultiplexTransportMaxIncomingConnections(max)(transport)
@@ -614,9 +839,70 @@ func createTransport(
 	return transport, peerFilters
 }
 
+// createPeerMetricsStore returns the store persisting the connection
+// statistics of every peer, or nil if peer connection metrics are disabled.
+func createPeerMetricsStore(
+	config *cfg.Config,
+	dbProvider DBProvider,
+	p2pMetrics *p2p.Metrics,
+	p2pLogger log.Logger,
+) (*p2p.PeerMetricsStore, error) {
+	if !config.Instrumentation.PeerConnectionMetrics {
+		return nil, nil
+	}
+	db, err := dbProvider(&DBContext{"peerstore", config})
+	if err != nil {
+		return nil, err
+	}
+	store := p2p.NewPeerMetricsStore(db, p2pMetrics)
+	store.SetLogger(p2pLogger)
+	return store, nil
+}
+
+// createPeerDiversity returns the caps on the peers within a single IP subnet
+// or autonomous system, loading the ASN database if one is configured.
+func createPeerDiversity(config *cfg.Config, p2pLogger log.Logger) (*p2p.PeerDiversity, error) {
+	options := []p2p.PeerDiversityOption{
+		p2p.PeerDiversityIPv4PrefixLen(config.P2P.SubnetIPv4PrefixLen),
+		p2p.PeerDiversityIPv6PrefixLen(config.P2P.SubnetIPv6PrefixLen),
+	}
+	if config.P2P.ASNDBFile != "" {
+		table, err := p2p.LoadASNTable(config.P2P.ASNDBFilePath())
+		if err != nil {
+			return nil, err
+		}
+		p2pLogger.Info("Loaded ASN database", "file", config.P2P.ASNDBFilePath(), "ranges", table.Size())
+		options = append(options, p2p.PeerDiversityASN(table, config.P2P.MaxPeersPerASN))
+	}
+	return p2p.NewPeerDiversity(config.P2P.MaxPeersPerSubnet, options...), nil
+}
+
+// createPeerTargeter returns the dynamic peer targets of the node, given its
+// role, or nil if the peer targets are static.
+func createPeerTargeter(config *cfg.Config, state sm.State, pubKey crypto.PubKey) *p2p.PeerTargeter {
+	if !config.P2P.DynamicPeerTargets {
+		return nil
+	}
+	role := p2p.NodeRoleFull
+	switch {
+	case config.P2P.SeedMode:
+		role = p2p.NodeRoleSeed
+	case pubKey != nil && state.Validators.HasAddress(pubKey.Address()):
+		role = p2p.NodeRoleValidator
+	}
+	minOutbound := config.P2P.MinNumOutboundPeers
+	if minOutbound == 0 {
+		minOutbound = p2p.DefaultMinOutboundPeers(role, config.P2P.MaxNumOutboundPeers)
+	}
+	return p2p.NewPeerTargeter(role, config.P2P.MaxNumInboundPeers, minOutbound, config.P2P.MaxNumOutboundPeers)
+}
+
 func createSwitch(config *cfg.Config,
 	transport p2p.Transport,
 	p2pMetrics *p2p.Metrics,
+	peerMetricsStore *p2p.PeerMetricsStore,
+	peerDiversity *p2p.PeerDiversity,
+	peerTargeter *p2p.PeerTargeter,
 	peerFilters []p2p.PeerFilterFunc,
 	mempoolReactor p2p.Reactor,
 	bcReactor p2p.Reactor,
@@ -628,13 +914,19 @@ func createSwitch(config *cfg.Config,
 	p2pLogger log.Logger,
 	tracer trace.Tracer,
 ) *p2p.Switch {
-	sw := p2p.NewSwitch(
-		config.P2P,
-		transport,
+	options := []p2p.SwitchOption{
 		p2p.WithMetrics(p2pMetrics),
 		p2p.SwitchPeerFilters(peerFilters...),
 		p2p.WithTracer(tracer),
-	)
+		p2p.SwitchPeerDiversity(peerDiversity),
+	}
+	if peerMetricsStore != nil {
+		options = append(options, p2p.WithPeerMetricsStore(peerMetricsStore))
+	}
+	if peerTargeter != nil {
+		options = append(options, p2p.SwitchPeerTargeter(peerTargeter))
+	}
+	sw := p2p.NewSwitch(config.P2P, transport, options...)
 	sw.SetLogger(p2pLogger)
 	sw.AddReactor("MEMPOOL", mempoolReactor)
 	sw.AddReactor("BLOCKCHAIN", bcReactor)
@@ -645,15 +937,22 @@ func createSwitch(config *cfg.Config,
 	sw.SetNodeInfo(nodeInfo)
 	sw.SetNodeKey(nodeKey)
 
-	p2pLogger.Info("P2P Node ID", "ID", nodeKey.ID(), "file", config.NodeKeyFile())
+	if config.P2P.NodeKeyPKCS11.Enabled() {
+		p2pLogger.Info("P2P Node ID", "ID", nodeKey.ID(), "pkcs11_module", config.P2P.NodeKeyPKCS11.Module,
+			"key_label", config.P2P.NodeKeyPKCS11.KeyLabel)
+	} else {
+		p2pLogger.Info("P2P Node ID", "ID", nodeKey.ID(), "file", config.NodeKeyFile())
+	}
 	return sw
 }
 
 func createAddrBookAndSetOnSwitch(config *cfg.Config, sw *p2p.Switch,
-	p2pLogger log.Logger, nodeKey *p2p.NodeKey,
+	p2pLogger log.Logger, nodeKey *p2p.NodeKey, network string,
 ) (pex.AddrBook, error) {
 	addrBook := pex.NewAddrBook(config.P2P.AddrBookFile(), config.P2P.AddrBookStrict)
 	addrBook.SetLogger(p2pLogger.With("book", config.P2P.AddrBookFile()))
+	// Skip the addresses of the peers on other networks
+	addrBook.SetNetwork(network)
 
 	// Add ourselves to addrbook to prevent dialing ourselves
 	if config.P2P.ExternalAddress != "" {
@@ -698,9 +997,13 @@ func createPEXReactorAndAddToSwitch(addrBook pex.AddrBook, config *cfg.Config,
 }
 
 // startStateSync starts an asynchronous state sync process, then switches to fast sync mode.
+// If state sync fails, the node falls back to fast sync from the configured fallback height,
+// or else from the state returned by handshake, which initializes the app like on a node
+// starting without state sync.
 func startStateSync(ssR *statesync.Reactor, bcR fastSyncReactor, conR *cs.Reactor,
 	stateProvider statesync.StateProvider, config *cfg.StateSyncConfig, fastSync bool,
 	stateStore sm.Store, blockStore *store.BlockStore, state sm.State,
+	handshake func() (sm.State, error),
 ) error {
 	ssR.Logger.Info("Starting state sync")
 
@@ -721,20 +1024,50 @@ func startStateSync(ssR *statesync.Reactor, bcR fastSyncReactor, conR *cs.Reacto
 		}
 	}
 
-	go func() {
-		state, commit, err := ssR.Sync(stateProvider, config.DiscoveryTime)
-		if err != nil {
-			ssR.Logger.Error("State sync failed", "err", err)
-			return
+	bootstrap := func(state sm.State, commit *types.Commit) error {
+		if err := stateStore.Bootstrap(state); err != nil {
+			return fmt.Errorf("failed to bootstrap node with new state: %w", err)
+		}
+		if err := blockStore.SaveSeenCommit(state.LastBlockHeight, commit); err != nil {
+			return fmt.Errorf("failed to store last seen commit: %w", err)
 		}
-		err = stateStore.Bootstrap(state)
+		return nil
+	}
+
+	go func() {
+		state, commit, err := ssR.Sync(context.Background(), stateProvider, config.DiscoveryTime)
 		if err != nil {
-			ssR.Logger.Error("Failed to bootstrap node with new state", "err", err)
+			reason := statesync.FailureReason(err)
+			if reason == statesync.FailureReasonCanceled {
+				ssR.Logger.Info("State sync cancelled", "err", err)
+				return
+			}
+			ssR.Logger.Error("State sync failed, falling back to fast sync", "reason", reason,
+				"attempts", ssR.Status().Attempts, "err", err)
+			if config.FallbackHeight > 0 {
+				state, commit, err = ssR.StateAt(context.Background(), stateProvider, uint64(config.FallbackHeight))
+				if err == nil {
+					err = bootstrap(state, commit)
+				}
+				if err != nil {
+					haltStateSync(ssR, fmt.Errorf("failed to fall back to fast sync at height %d: %w",
+						config.FallbackHeight, err))
+					return
+				}
+				fallbackToFastSync(ssR, bcR, conR, state)
+				return
+			}
+			state, err = handshake()
+			if err != nil {
+				haltStateSync(ssR, fmt.Errorf("failed to initialize the app for fast sync, "+
+					"it may hold a partially restored snapshot: %w", err))
+				return
+			}
+			fallbackToFastSync(ssR, bcR, conR, state)
 			return
 		}
-		err = blockStore.SaveSeenCommit(state.LastBlockHeight, commit)
-		if err != nil {
-			ssR.Logger.Error("Failed to store last seen commit", "err", err)
+		if err := bootstrap(state, commit); err != nil {
+			haltStateSync(ssR, fmt.Errorf("failed to bootstrap node after state sync: %w", err))
 			return
 		}
 
@@ -744,7 +1077,7 @@ func startStateSync(ssR *statesync.Reactor, bcR fastSyncReactor, conR *cs.Reacto
 			conR.Metrics.FastSyncing.Set(1)
 			err = bcR.SwitchToFastSync(state)
 			if err != nil {
-				ssR.Logger.Error("Failed to switch to fast sync", "err", err)
+				haltStateSync(ssR, fmt.Errorf("failed to switch to fast sync: %w", err))
 				return
 			}
 		} else {
@@ -754,6 +1087,26 @@ func startStateSync(ssR *statesync.Reactor, bcR fastSyncReactor, conR *cs.Reacto
 	return nil
 }
 
+// fallbackToFastSync starts fast syncing from state, after a failed state sync.
+func fallbackToFastSync(ssR *statesync.Reactor, bcR fastSyncReactor, conR *cs.Reactor, state sm.State) {
+	conR.Metrics.StateSyncing.Set(0)
+	conR.Metrics.FastSyncing.Set(1)
+	if err := bcR.SwitchToFastSync(state); err != nil {
+		haltStateSync(ssR, fmt.Errorf("failed to switch to fast sync: %w", err))
+		return
+	}
+	ssR.MarkFallback()
+	ssR.Logger.Info("Fell back to fast sync", "height", state.LastBlockHeight+1)
+}
+
+// haltStateSync records that the node could not get past state sync because of
+// err, so that it reports it in /status and fails its health checks, instead of
+// idling: neither syncing nor running consensus, it must be reset and restarted.
+func haltStateSync(ssR *statesync.Reactor, err error) {
+	ssR.MarkHalted(err)
+	ssR.Logger.Error("Node halted after state sync, reset the app and the node and restart it", "err", err)
+}
+
 // NewNode returns a new, ready to go, CometBFT Node.
 func NewNode(config *cfg.Config,
 	privValidator types.PrivValidator,
@@ -769,6 +1122,31 @@ func NewNode(config *cfg.Config,
 		return nil, fmt.Errorf("invalid config: %w", err)
 	}
 
+	dataDirLock, err := lockDataDir(config, logger)
+	if err != nil {
+		return nil, err
+	}
+	constructed := false
+	defer func() {
+		if !constructed {
+			releaseDataDirLock(dataDirLock, logger)
+		}
+	}()
+
+	var logRing *log.RingWriter
+	if config.LogRingBufferSize > 0 {
+		logRing, logger, err = createLogRing(config, logger)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var dbMetrics *instrumentedDBProvider
+	if config.Instrumentation.Prometheus && config.Instrumentation.DBMetrics {
+		dbMetrics = &instrumentedDBProvider{dbProvider: dbProvider}
+		dbProvider = dbMetrics.provide
+	}
+
 	blockStore, stateDB, err := initDBs(config, dbProvider)
 	if err != nil {
 		return nil, err
@@ -782,9 +1160,13 @@ func NewNode(config *cfg.Config,
 	if err != nil {
 		return nil, err
 	}
+	if dbMetrics != nil {
+		dbMetrics.setMetrics(store.PrometheusMetrics(config.Instrumentation.Namespace, "chain_id", genDoc.ChainID))
+	}
 
 	// Create the proxyApp and establish connections to the ABCI app (consensus, mempool, query).
-	proxyApp, err := createAndStartProxyAppConns(clientCreator, logger)
+	// The connections with an address of their own are served by distinct apps.
+	proxyApp, err := createAndStartProxyAppConns(clientCreator, logger, proxyAppConnOptions(config)...)
 	if err != nil {
 		return nil, err
 	}
@@ -799,11 +1181,23 @@ func NewNode(config *cfg.Config,
 	}
 
 	indexerService, txIndexer, blockIndexer, err := createAndStartIndexerService(config,
-		genDoc.ChainID, dbProvider, eventBus, logger)
+		genDoc.ChainID, dbProvider, eventBus, stateStore, blockStore, state.LastBlockHeight, logger)
 	if err != nil {
 		return nil, err
 	}
 
+	// started before the handshake too, to record the row proofs of the
+	// replayed blocks
+	var rowProofRecorder *sm.RowProofRecorder
+	if config.Storage.PersistRowProofs {
+		rowProofRecorder = sm.NewRowProofRecorder(proxyApp.Query(), blockStore, eventBus,
+			config.Storage.MaxRowProofSquareSize)
+		rowProofRecorder.SetLogger(logger.With("module", "rowproofs"))
+		if err := rowProofRecorder.Start(); err != nil {
+			return nil, err
+		}
+	}
+
 	// If an address is provided, listen on the socket for a connection from an
 	// external signing process.
 	if config.PrivValidatorListenAddr != "" {
@@ -870,24 +1264,40 @@ func NewNode(config *cfg.Config,
 		return nil, err
 	}
 
+	// Fetch the mempool requirements of the application, refreshed after each
+	// block by the block executor
+	appRequirements := mempl.NewAppRequirements(proxyApp.Query(), config.Mempool.EnforceAppRequirements)
+	if err := appRequirements.Refresh(); err != nil {
+		return nil, err
+	}
+
 	// Make MempoolReactor
-	mempool, mempoolReactor := createMempoolAndMempoolReactor(config, proxyApp, state, memplMetrics, logger, tracer)
+	mempool, mempoolReactor := createMempoolAndMempoolReactor(config, proxyApp, state, memplMetrics, appRequirements, eventBus, logger, tracer)
 
 	// Make Evidence Reactor
-	evidenceReactor, evidencePool, err := createEvidenceReactor(config, dbProvider, stateDB, blockStore, logger)
+	evidenceReactor, evidencePool, err := createEvidenceReactor(config, dbProvider, stateDB, blockStore, proxyApp, logger)
 	if err != nil {
 		return nil, err
 	}
 
 	// make block executor for consensus and blockchain reactors to execute blocks
+	blockExecOptions := []sm.BlockExecutorOption{
+		sm.BlockExecutorWithMetrics(smMetrics),
+		sm.WithBlockStore(blockStore),
+		sm.BlockExecutorWithAppRequirements(appRequirements),
+	}
+	var reapDecisions *sm.ReapDecisions
+	if config.Consensus.RecordReapDecisions {
+		reapDecisions = sm.NewReapDecisions(sm.DefaultReapDecisionsSize)
+		blockExecOptions = append(blockExecOptions, sm.BlockExecutorWithReapDecisions(reapDecisions))
+	}
 	blockExec := sm.NewBlockExecutor(
 		stateStore,
 		logger.With("module", "state"),
 		proxyApp.Consensus(),
 		mempool,
 		evidencePool,
-		sm.BlockExecutorWithMetrics(smMetrics),
-		sm.WithBlockStore(blockStore),
+		blockExecOptions...,
 	)
 
 	// Make BlockchainReactor. Don't start fast sync if we're doing a state sync first.
@@ -908,6 +1318,10 @@ func NewNode(config *cfg.Config,
 		privValidator, csMetrics, stateSync || fastSync, eventBus, consensusLogger, tracer,
 	)
 
+	appUpgrader := cs.NewAppUpgrader(consensusState, proxyApp, stateStore, blockStore, genDoc, eventBus,
+		config.Consensus.AppUpgradeTimeout)
+	appUpgrader.SetLogger(consensusLogger)
+
 	// Set up state sync reactor, and schedule a sync if requested.
 	// FIXME The way we do phased startups (e.g. replay -> fast sync -> consensus) is very messy,
 	// we should clean this whole thing up. See:
@@ -920,6 +1334,16 @@ func NewNode(config *cfg.Config,
 	)
 	stateSyncReactor.SetLogger(logger.With("module", "statesync"))
 
+	snapshotManager := statesync.NewSnapshotManager(
+		proxyApp.Snapshot(),
+		proxyApp.Query(),
+		eventBus,
+		config.StateSync.SnapshotInterval,
+		config.StateSync.SnapshotKeepRecent,
+		consensusReactor.WaitSync,
+	)
+	snapshotManager.SetLogger(logger.With("module", "statesync"))
+
 	nodeInfo, err := makeNodeInfo(config, nodeKey, txIndexer, genDoc, state, softwareVersion)
 	if err != nil {
 		return nil, err
@@ -930,9 +1354,18 @@ func NewNode(config *cfg.Config,
 
 	// Setup Switch.
 	p2pLogger := logger.With("module", "p2p")
+	peerMetricsStore, err := createPeerMetricsStore(config, dbProvider, p2pMetrics, p2pLogger)
+	if err != nil {
+		return nil, err
+	}
+	peerDiversity, err := createPeerDiversity(config, p2pLogger)
+	if err != nil {
+		return nil, err
+	}
+	peerTargeter := createPeerTargeter(config, state, pubKey)
 	sw := createSwitch(
-		config, transport, p2pMetrics, peerFilters, mempoolReactor, bcReactor,
-		stateSyncReactor, consensusReactor, evidenceReactor, nodeInfo, nodeKey, p2pLogger, tracer,
+		config, transport, p2pMetrics, peerMetricsStore, peerDiversity, peerTargeter, peerFilters, mempoolReactor,
+		bcReactor, stateSyncReactor, consensusReactor, evidenceReactor, nodeInfo, nodeKey, p2pLogger, tracer,
 	)
 
 	err = sw.AddPersistentPeers(splitAndTrimEmpty(config.P2P.PersistentPeers, ",", " "))
@@ -945,7 +1378,7 @@ func NewNode(config *cfg.Config,
 		return nil, fmt.Errorf("could not add peer ids from unconditional_peer_ids field: %w", err)
 	}
 
-	addrBook, err := createAddrBookAndSetOnSwitch(config, sw, p2pLogger, nodeKey)
+	addrBook, err := createAddrBookAndSetOnSwitch(config, sw, p2pLogger, nodeKey, nodeInfo.Network)
 	if err != nil {
 		return nil, fmt.Errorf("could not create addrbook: %w", err)
 	}
@@ -975,6 +1408,11 @@ func NewNode(config *cfg.Config,
 		}()
 	}
 
+	rpcMetrics := rpccore.NopMetrics()
+	if config.Instrumentation.Prometheus {
+		rpcMetrics = rpccore.PrometheusMetrics(config.Instrumentation.Namespace, "chain_id", genDoc.ChainID)
+	}
+
 	node := &Node{
 		config:        config,
 		genesisDoc:    genDoc,
@@ -993,17 +1431,25 @@ func NewNode(config *cfg.Config,
 		mempool:          mempool,
 		consensusState:   consensusState,
 		consensusReactor: consensusReactor,
+		appUpgrader:      appUpgrader,
 		stateSyncReactor: stateSyncReactor,
 		stateSync:        stateSync,
 		stateSyncGenesis: state, // Shouldn't be necessary, but need a way to pass the genesis state
 		pexReactor:       pexReactor,
 		evidencePool:     evidencePool,
+		reapDecisions:    reapDecisions,
+		appRequirements:  appRequirements,
+		logRing:          logRing,
+		rpcMetrics:       rpcMetrics,
 		proxyApp:         proxyApp,
 		txIndexer:        txIndexer,
 		indexerService:   indexerService,
+		rowProofRecorder: rowProofRecorder,
+		snapshotManager:  snapshotManager,
 		blockIndexer:     blockIndexer,
 		eventBus:         eventBus,
 		tracer:           tracer,
+		dataDirLock:      dataDirLock,
 	}
 	node.BaseService = *service.NewBaseService(logger, "Node", node)
 
@@ -1011,6 +1457,7 @@ func NewNode(config *cfg.Config,
 		option(node)
 	}
 
+	constructed = true
 	return node, nil
 }
 
@@ -1064,6 +1511,18 @@ func (n *Node) OnStart() error {
 
 	n.isListening = true
 
+	// peers in strict address book mode ignore addresses they can't route to
+	if ni, ok := n.nodeInfo.(p2p.DefaultNodeInfo); ok && n.config.P2P.AddrBookStrict {
+		if _, err := ni.AdvertisedAddress(); err != nil {
+			n.Logger.Error("Advertised address is not routable, peers will not be able to dial this node", "err", err)
+		}
+	}
+
+	// Start driving the snapshots of the application, if it supports it.
+	if err := n.snapshotManager.Start(); err != nil {
+		return fmt.Errorf("failed to start snapshot manager: %w", err)
+	}
+
 	// Start the switch (the P2P server).
 	err = n.sw.Start()
 	if err != nil {
@@ -1082,13 +1541,30 @@ func (n *Node) OnStart() error {
 		if !ok {
 			return fmt.Errorf("this blockchain reactor does not support switching from state sync")
 		}
+		handshake := func() (sm.State, error) {
+			_, err := doHandshake(context.TODO(), n.stateStore, n.stateSyncGenesis, n.blockStore, n.genesisDoc,
+				n.eventBus, n.proxyApp, n.Logger.With("module", "consensus"))
+			if err != nil {
+				return sm.State{}, err
+			}
+			state, err := n.stateStore.Load()
+			if err != nil || state.IsEmpty() {
+				return n.stateSyncGenesis, err
+			}
+			return state, nil
+		}
 		err := startStateSync(n.stateSyncReactor, bcR, n.consensusReactor, n.stateSyncProvider,
-			n.config.StateSync, n.config.FastSyncMode, n.stateStore, n.blockStore, n.stateSyncGenesis)
+			n.config.StateSync, n.config.FastSyncMode, n.stateStore, n.blockStore, n.stateSyncGenesis,
+			handshake)
 		if err != nil {
 			return fmt.Errorf("failed to start state sync: %w", err)
 		}
 	}
 
+	if n.grpcServer != nil {
+		n.grpcServer.SetServing(true)
+	}
+
 	return nil
 }
 
@@ -1098,6 +1574,11 @@ func (n *Node) OnStop() {
 
 	n.Logger.Info("Stopping Node")
 
+	// report the gRPC server as not serving while the node shuts down
+	if n.grpcServer != nil {
+		n.grpcServer.Drain()
+	}
+
 	// first stop the non-reactor services
 	if err := n.eventBus.Stop(); err != nil {
 		n.Logger.Error("Error closing eventBus", "err", err)
@@ -1105,6 +1586,14 @@ func (n *Node) OnStop() {
 	if err := n.indexerService.Stop(); err != nil {
 		n.Logger.Error("Error closing indexerService", "err", err)
 	}
+	if n.rowProofRecorder != nil {
+		if err := n.rowProofRecorder.Stop(); err != nil {
+			n.Logger.Error("Error closing rowProofRecorder", "err", err)
+		}
+	}
+	if err := n.snapshotManager.Stop(); err != nil {
+		n.Logger.Error("Error closing snapshotManager", "err", err)
+	}
 
 	// now stop the reactors
 	if err := n.sw.Stop(); err != nil {
@@ -1124,12 +1613,17 @@ func (n *Node) OnStop() {
 			n.Logger.Error("Error closing listener", "listener", l, "err", err)
 		}
 	}
+	if n.grpcServer != nil {
+		n.Logger.Info("Stopping gRPC server")
+		n.grpcServer.Stop()
+	}
 
 	if pvsc, ok := n.privValidator.(service.Service); ok {
 		if err := pvsc.Stop(); err != nil {
 			n.Logger.Error("Error closing private validator", "err", err)
 		}
 	}
+	closeNodeKey(n.nodeKey, n.Logger)
 
 	if n.prometheusSrv != nil {
 		if err := n.prometheusSrv.Shutdown(context.Background()); err != nil {
@@ -1174,6 +1668,9 @@ func (n *Node) OnStop() {
 			n.Logger.Error("problem closing evidencestore", "err", err)
 		}
 	}
+
+	// the databases are closed: another process may open them
+	releaseDataDirLock(n.dataDirLock, n.Logger)
 }
 
 // ConfigureRPC makes sure RPC has all the objects it needs to operate.
@@ -1182,7 +1679,7 @@ func (n *Node) ConfigureRPC() error {
 	if err != nil {
 		return fmt.Errorf("can't get pubkey: %w", err)
 	}
-	rpccore.SetEnvironment(&rpccore.Environment{
+	env := &rpccore.Environment{
 		ProxyAppQuery:   n.proxyApp.Query(),
 		ProxyAppMempool: n.proxyApp.Mempool(),
 
@@ -1197,14 +1694,31 @@ func (n *Node) ConfigureRPC() error {
 		GenDoc:           n.genesisDoc,
 		TxIndexer:        n.txIndexer,
 		BlockIndexer:     n.blockIndexer,
+		IndexerService:   n.indexerService,
+		SnapshotManager:  n.snapshotManager,
 		ConsensusReactor: n.consensusReactor,
 		EventBus:         n.eventBus,
 		Mempool:          n.mempool,
+		ReapDecisions:    n.reapDecisions,
+		AppRequirements:  n.appRequirements,
+		AppUpgrader:      n.appUpgrader,
+		BlockSyncReactor: n.bcReactor,
+		StateSyncReactor: n.stateSyncReactor,
+		LogRing:          n.logRing,
+		Metrics:          n.rpcMetrics,
 
 		Logger: n.Logger.With("module", "rpc"),
 
 		Config: *n.config.RPC,
-	})
+	}
+	if n.config.RPC.MaxPendingBroadcastTxs > 0 {
+		env.TxAdmission = rpccore.NewTxAdmission(n.config.RPC.MaxPendingBroadcastTxs,
+			n.config.RPC.BroadcastTxAdmissionWait, n.rpcMetrics)
+	}
+	if n.config.RPC.ReadOnly {
+		env.SetReadOnly()
+	}
+	rpccore.SetEnvironment(env)
 
 	return rpccore.InitGenesisChunks()
 }
@@ -1215,42 +1729,119 @@ func (n *Node) startRPC() ([]net.Listener, error) {
 		return nil, err
 	}
 
-	listenAddrs := splitAndTrimEmpty(n.config.RPC.ListenAddress, ",", " ")
-
 	if n.config.RPC.Unsafe {
 		rpccore.AddUnsafeRoutes()
 	}
+	routes := rpccore.Routes
+	if n.config.RPC.ReadOnly {
+		routes = rpcserver.ReadOnlyFuncs(routes)
+	}
+
+	wmLogger := n.Logger.With("module", "rpc-server", "protocol", "websocket")
+	listeners, err := serveRPC(n.config.RPC, routes, n.rpcMetrics, n.Logger, func(remoteAddr string) {
+		err := n.eventBus.UnsubscribeAll(context.Background(), remoteAddr)
+		if err != nil && err != cmtpubsub.ErrSubscriptionNotFound {
+			wmLogger.Error("Failed to unsubscribe addr from events", "addr", remoteAddr, "err", err)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// we expose a simplified api over grpc for convenience to app devs
+	grpcListenAddr := n.config.RPC.GRPCListenAddress
+	if grpcListenAddr != "" {
+		config := rpcserver.DefaultConfig()
+		config.MaxBodyBytes = n.config.RPC.MaxBodyBytes
+		config.MaxHeaderBytes = n.config.RPC.MaxHeaderBytes
+		// NOTE: GRPCMaxOpenConnections is used, not MaxOpenConnections
+		config.MaxOpenConnections = n.config.RPC.GRPCMaxOpenConnections
+		// If necessary adjust global WriteTimeout to ensure it's greater than
+		// TimeoutBroadcastTxCommit.
+		// See https://github.com/tendermint/tendermint/issues/3435
+		if config.WriteTimeout <= n.config.RPC.TimeoutBroadcastTxCommit {
+			config.WriteTimeout = n.config.RPC.TimeoutBroadcastTxCommit + 1*time.Second
+		}
+		listener, err := rpcserver.Listen(grpcListenAddr, config)
+		if err != nil {
+			return nil, err
+		}
+		var opts []grpc.ServerOption
+		if n.config.RPC.IsTLSEnabled() {
+			creds, err := credentials.NewServerTLSFromFile(n.config.RPC.CertFile(), n.config.RPC.KeyFile())
+			if err != nil {
+				return nil, fmt.Errorf("failed to load the TLS credentials of the gRPC server: %w", err)
+			}
+			opts = append(opts, grpc.Creds(creds))
+		}
+		// the gRPC server closes its listener when stopped
+		n.grpcServer = grpccore.NewServer(opts...)
+		go func() {
+			if err := n.grpcServer.Serve(listener); err != nil {
+				n.Logger.Error("Error starting gRPC server", "err", err)
+			}
+		}()
+	}
+
+	return listeners, nil
+}
+
+// serveRPC serves the routes over HTTP and websockets on the listen addresses
+// of the RPC config, authorizing their calls by the API keys if configured.
+// onDisconnect is called when a websocket client disconnects.
+func serveRPC(
+	rpcConfig *cfg.RPCConfig,
+	routes map[string]*rpcserver.RPCFunc,
+	metrics *rpccore.Metrics,
+	logger log.Logger,
+	onDisconnect func(remoteAddr string),
+) ([]net.Listener, error) {
+	listenAddrs := splitAndTrimEmpty(rpcConfig.ListenAddress, ",", " ")
+
+	var apiKeys *rpcserver.APIKeys
+	if rpcConfig.AuthKeysFile != "" {
+		publicClasses := make([]rpcserver.MethodClass, len(rpcConfig.AuthPublicClasses))
+		for i, class := range rpcConfig.AuthPublicClasses {
+			publicClasses[i] = rpcserver.MethodClass(class)
+		}
+		var err error
+		apiKeys, err = rpcserver.NewAPIKeys(rpcConfig.AuthKeysFilePath(), publicClasses)
+		if err != nil {
+			return nil, err
+		}
+		apiKeys.SetLogger(logger.With("module", "rpc-server"))
+		apiKeys.SetMetrics(metrics.APIKeyCalls, metrics.UnauthorizedCalls)
+	}
 
 	config := rpcserver.DefaultConfig()
-	config.MaxBodyBytes = n.config.RPC.MaxBodyBytes
-	config.MaxHeaderBytes = n.config.RPC.MaxHeaderBytes
-	config.MaxOpenConnections = n.config.RPC.MaxOpenConnections
+	config.MaxBodyBytes = rpcConfig.MaxBodyBytes
+	config.MaxParamBytes = rpcConfig.MaxParamBytes
+	config.MaxHeaderBytes = rpcConfig.MaxHeaderBytes
+	config.MaxOpenConnections = rpcConfig.MaxOpenConnections
 	// If necessary adjust global WriteTimeout to ensure it's greater than
 	// TimeoutBroadcastTxCommit.
 	// See https://github.com/tendermint/tendermint/issues/3435
-	if config.WriteTimeout <= n.config.RPC.TimeoutBroadcastTxCommit {
-		config.WriteTimeout = n.config.RPC.TimeoutBroadcastTxCommit + 1*time.Second
+	if config.WriteTimeout <= rpcConfig.TimeoutBroadcastTxCommit {
+		config.WriteTimeout = rpcConfig.TimeoutBroadcastTxCommit + 1*time.Second
 	}
 
 	// we may expose the rpc over both a unix and tcp socket
 	listeners := make([]net.Listener, len(listenAddrs))
 	for i, listenAddr := range listenAddrs {
 		mux := http.NewServeMux()
-		rpcLogger := n.Logger.With("module", "rpc-server")
+		rpcLogger := logger.With("module", "rpc-server")
 		wmLogger := rpcLogger.With("protocol", "websocket")
-		wm := rpcserver.NewWebsocketManager(rpccore.Routes,
-			rpcserver.OnDisconnect(func(remoteAddr string) {
-				err := n.eventBus.UnsubscribeAll(context.Background(), remoteAddr)
-				if err != nil && err != cmtpubsub.ErrSubscriptionNotFound {
-					wmLogger.Error("Failed to unsubscribe addr from events", "addr", remoteAddr, "err", err)
-				}
-			}),
-			rpcserver.ReadLimit(config.MaxBodyBytes),
-			rpcserver.WriteChanCapacity(n.config.RPC.WebSocketWriteBufferSize),
+		wm := rpcserver.NewWebsocketManager(routes,
+			rpcserver.OnDisconnect(onDisconnect),
+			rpcserver.ReadLimit(rpcConfig.MaxWebSocketMessageBytes),
+			rpcserver.MaxParamBytes(config.MaxParamBytes),
+			rpcserver.WriteChanCapacity(rpcConfig.WebSocketWriteBufferSize),
+			rpcserver.PingPeriod(rpcConfig.WebSocketPingInterval),
+			rpcserver.ReadWait(rpcConfig.WebSocketReadWait()),
 		)
 		wm.SetLogger(wmLogger)
 		mux.HandleFunc("/websocket", wm.WebsocketHandler)
-		rpcserver.RegisterRPCFuncs(mux, rpccore.Routes, rpcLogger)
+		rpcserver.RegisterRPCFuncs(mux, routes, rpcLogger)
 		listener, err := rpcserver.Listen(
 			listenAddr,
 			config,
@@ -1260,25 +1851,28 @@ func (n *Node) startRPC() ([]net.Listener, error) {
 		}
 
 		var rootHandler http.Handler = mux
-		if n.config.RPC.IsCorsEnabled() {
+		if apiKeys != nil {
+			rootHandler = rpcserver.AuthHandler(apiKeys, rootHandler)
+		}
+		if rpcConfig.IsCorsEnabled() {
 			corsMiddleware := cors.New(cors.Options{
-				AllowedOrigins: n.config.RPC.CORSAllowedOrigins,
-				AllowedMethods: n.config.RPC.CORSAllowedMethods,
-				AllowedHeaders: n.config.RPC.CORSAllowedHeaders,
+				AllowedOrigins: rpcConfig.CORSAllowedOrigins,
+				AllowedMethods: rpcConfig.CORSAllowedMethods,
+				AllowedHeaders: rpcConfig.CORSAllowedHeaders,
 			})
-			rootHandler = corsMiddleware.Handler(mux)
+			rootHandler = corsMiddleware.Handler(rootHandler)
 		}
-		if n.config.RPC.IsTLSEnabled() {
+		if rpcConfig.IsTLSEnabled() {
 			go func() {
 				if err := rpcserver.ServeTLS(
 					listener,
 					rootHandler,
-					n.config.RPC.CertFile(),
-					n.config.RPC.KeyFile(),
+					rpcConfig.CertFile(),
+					rpcConfig.KeyFile(),
 					rpcLogger,
 					config,
 				); err != nil {
-					n.Logger.Error("Error serving server with TLS", "err", err)
+					logger.Error("Error serving server with TLS", "err", err)
 				}
 			}()
 		} else {
@@ -1289,7 +1883,7 @@ func (n *Node) startRPC() ([]net.Listener, error) {
 					rpcLogger,
 					config,
 				); err != nil {
-					n.Logger.Error("Error serving server", "err", err)
+					logger.Error("Error serving server", "err", err)
 				}
 			}()
 		}
@@ -1297,33 +1891,6 @@ func (n *Node) startRPC() ([]net.Listener, error) {
 		listeners[i] = listener
 	}
 
-	// we expose a simplified api over grpc for convenience to app devs
-	grpcListenAddr := n.config.RPC.GRPCListenAddress
-	if grpcListenAddr != "" {
-		config := rpcserver.DefaultConfig()
-		config.MaxBodyBytes = n.config.RPC.MaxBodyBytes
-		config.MaxHeaderBytes = n.config.RPC.MaxHeaderBytes
-		// NOTE: GRPCMaxOpenConnections is used, not MaxOpenConnections
-		config.MaxOpenConnections = n.config.RPC.GRPCMaxOpenConnections
-		// If necessary adjust global WriteTimeout to ensure it's greater than
-		// TimeoutBroadcastTxCommit.
-		// See https://github.com/tendermint/tendermint/issues/3435
-		if config.WriteTimeout <= n.config.RPC.TimeoutBroadcastTxCommit {
-			config.WriteTimeout = n.config.RPC.TimeoutBroadcastTxCommit + 1*time.Second
-		}
-		listener, err := rpcserver.Listen(grpcListenAddr, config)
-		if err != nil {
-			return nil, err
-		}
-		go func() {
-			if err := grpccore.StartGRPCServer(listener); err != nil {
-				n.Logger.Error("Error starting gRPC server", "err", err)
-			}
-		}()
-		listeners = append(listeners, listener)
-
-	}
-
 	return listeners, nil
 }
 
@@ -1488,13 +2055,13 @@ func makeNodeInfo(
 		nodeInfo.Channels = append(nodeInfo.Channels, mempoolv2.MempoolStateChannel)
 	}
 
-	lAddr := config.P2P.ExternalAddress
-
-	if lAddr == "" {
-		lAddr = config.P2P.ListenAddress
+	if config.Consensus.ValidatorAnnouncements {
+		nodeInfo.Channels = append(nodeInfo.Channels, cs.ValidatorAnnouncementChannel)
 	}
 
-	nodeInfo.ListenAddr = lAddr
+	// a node behind a NAT advertises its external address to peers
+	nodeInfo.ListenAddr = config.P2P.ListenAddress
+	nodeInfo.ExternalAddr = config.P2P.ExternalAddress
 
 	err := nodeInfo.Validate()
 	return nodeInfo, err
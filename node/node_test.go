@@ -2,14 +2,17 @@ package node
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
 	"os"
+	"path/filepath"
 	"syscall"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	dbm "github.com/cometbft/cometbft-db"
@@ -19,6 +22,7 @@ import (
 	"github.com/tendermint/tendermint/crypto/ed25519"
 	"github.com/tendermint/tendermint/evidence"
 	"github.com/tendermint/tendermint/libs/log"
+	cmtos "github.com/tendermint/tendermint/libs/os"
 	cmtrand "github.com/tendermint/tendermint/libs/rand"
 	mempl "github.com/tendermint/tendermint/mempool"
 	mempoolv2 "github.com/tendermint/tendermint/mempool/cat"
@@ -29,7 +33,11 @@ import (
 	p2pmock "github.com/tendermint/tendermint/p2p/mock"
 	"github.com/tendermint/tendermint/privval"
 	"github.com/tendermint/tendermint/proxy"
+	rpccore "github.com/tendermint/tendermint/rpc/core"
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
 	sm "github.com/tendermint/tendermint/state"
+	"github.com/tendermint/tendermint/statesync"
+	ssmocks "github.com/tendermint/tendermint/statesync/mocks"
 	"github.com/tendermint/tendermint/store"
 	"github.com/tendermint/tendermint/types"
 	cmttime "github.com/tendermint/tendermint/types/time"
@@ -78,6 +86,109 @@ func TestNodeStartStop(t *testing.T) {
 	}
 }
 
+func TestNodeStateSyncFallback(t *testing.T) {
+	config := cfg.ResetTestRoot("node_state_sync_fallback_test")
+	defer os.RemoveAll(config.RootDir)
+
+	// state sync is skipped when the node is the only validator
+	genDoc, err := types.GenesisDocFromFile(config.GenesisFile())
+	require.NoError(t, err)
+	genDoc.Validators = append(genDoc.Validators, types.GenesisValidator{
+		PubKey: ed25519.GenPrivKey().PubKey(),
+		Power:  10,
+	})
+	require.NoError(t, genDoc.SaveAs(config.GenesisFile()))
+
+	// without peers, no snapshot is discovered
+	config.StateSync.Enable = true
+	config.StateSync.RPCServers = []string{"127.0.0.1:1", "127.0.0.1:2"}
+	config.StateSync.TrustHeight = 1
+	config.StateSync.TrustHash = "00"
+	config.StateSync.DiscoveryTime = 0
+
+	nodeKey, err := p2p.LoadOrGenNodeKey(config.NodeKeyFile())
+	require.NoError(t, err)
+	n, err := NewNode(config,
+		privval.LoadOrGenFilePV(config.PrivValidatorKeyFile(), config.PrivValidatorStateFile()),
+		nodeKey,
+		proxy.DefaultClientCreator(config.ProxyApp, config.ABCI, config.DBDir()),
+		DefaultGenesisDocProviderFunc(config),
+		DefaultDBProvider,
+		DefaultMetricsProvider(config.Instrumentation),
+		log.TestingLogger(),
+		StateProvider(&ssmocks.StateProvider{}),
+	)
+	require.NoError(t, err)
+	require.NoError(t, n.Start())
+	defer n.Stop() //nolint:errcheck // ignore for tests
+
+	require.Eventually(t, func() bool {
+		return n.stateSyncReactor.Status().State == statesync.SyncStateFallback
+	}, 10*time.Second, 50*time.Millisecond)
+	assert.Equal(t, statesync.FailureReasonNoSnapshots, n.stateSyncReactor.Status().FailureReason)
+
+	// the app was initialized by the handshake, and the node fast syncs from
+	// genesis
+	state, err := n.stateStore.Load()
+	require.NoError(t, err)
+	assert.Equal(t, kvstore.ProtocolVersion, state.Version.Consensus.App)
+	assert.Equal(t, int64(0), state.LastBlockHeight)
+	assert.True(t, n.consensusReactor.WaitSync())
+}
+
+func TestNodeStateSyncFallbackFailure(t *testing.T) {
+	config := cfg.ResetTestRoot("node_state_sync_fallback_failure_test")
+	defer os.RemoveAll(config.RootDir)
+
+	// state sync is skipped when the node is the only validator
+	genDoc, err := types.GenesisDocFromFile(config.GenesisFile())
+	require.NoError(t, err)
+	genDoc.Validators = append(genDoc.Validators, types.GenesisValidator{
+		PubKey: ed25519.GenPrivKey().PubKey(),
+		Power:  10,
+	})
+	require.NoError(t, genDoc.SaveAs(config.GenesisFile()))
+
+	// without peers, no snapshot is discovered, and the state at the fallback
+	// height cannot be verified
+	config.StateSync.Enable = true
+	config.StateSync.RPCServers = []string{"127.0.0.1:1", "127.0.0.1:2"}
+	config.StateSync.TrustHeight = 1
+	config.StateSync.TrustHash = "00"
+	config.StateSync.DiscoveryTime = 0
+	config.StateSync.FallbackHeight = 5
+	stateProvider := &ssmocks.StateProvider{}
+	stateProvider.On("AppHash", mock.Anything, uint64(5)).Return(nil, errors.New("no witnesses"))
+
+	nodeKey, err := p2p.LoadOrGenNodeKey(config.NodeKeyFile())
+	require.NoError(t, err)
+	n, err := NewNode(config,
+		privval.LoadOrGenFilePV(config.PrivValidatorKeyFile(), config.PrivValidatorStateFile()),
+		nodeKey,
+		proxy.DefaultClientCreator(config.ProxyApp, config.ABCI, config.DBDir()),
+		DefaultGenesisDocProviderFunc(config),
+		DefaultDBProvider,
+		DefaultMetricsProvider(config.Instrumentation),
+		log.TestingLogger(),
+		StateProvider(stateProvider),
+	)
+	require.NoError(t, err)
+	require.NoError(t, n.Start())
+	defer n.Stop() //nolint:errcheck // ignore for tests
+
+	// the node reports it is halted, instead of idling
+	require.Eventually(t, func() bool {
+		return n.stateSyncReactor.Status().State == statesync.SyncStateHalted
+	}, 10*time.Second, 50*time.Millisecond)
+	assert.Contains(t, n.stateSyncReactor.Status().Error, "no witnesses")
+	require.NoError(t, n.ConfigureRPC())
+	status, err := rpccore.Status(&rpctypes.Context{})
+	require.NoError(t, err)
+	require.NotNil(t, status.SyncInfo.StateSync)
+	assert.Equal(t, string(statesync.SyncStateHalted), status.SyncInfo.StateSync.State)
+	assert.Error(t, rpccore.CheckHealth())
+}
+
 func TestSplitAndTrimEmpty(t *testing.T) {
 	testCases := []struct {
 		s        string
@@ -115,6 +226,83 @@ func TestNodeDelayedStart(t *testing.T) {
 	assert.Equal(t, true, startTime.After(n.GenesisDoc().GenesisTime))
 }
 
+func TestNodeLocksDataDir(t *testing.T) {
+	config := cfg.ResetTestRoot("node_locks_data_dir_test")
+	defer os.RemoveAll(config.RootDir)
+
+	n, err := DefaultNewNode(config, log.TestingLogger())
+	require.NoError(t, err)
+
+	// the data directory is locked from the construction of the node
+	_, err = DefaultNewNode(config, log.TestingLogger())
+	var lerr *cmtos.LockedError
+	require.ErrorAs(t, err, &lerr)
+	assert.Equal(t, os.Getpid(), lerr.PID)
+	_, err = NewArchiveServer(config, DefaultDBProvider, DefaultGenesisDocProviderFunc(config), nil,
+		log.TestingLogger())
+	require.ErrorAs(t, err, &lerr)
+
+	// until it is stopped
+	require.NoError(t, n.Start())
+	require.NoError(t, n.Stop())
+	n, err = DefaultNewNode(config, log.TestingLogger())
+	require.NoError(t, err)
+	require.NoError(t, n.Start())
+	require.NoError(t, n.Stop())
+}
+
+func TestNodeSplitStoreDirs(t *testing.T) {
+	config := cfg.ResetTestRoot("node_split_store_dirs_test")
+	defer os.RemoveAll(config.RootDir)
+	config.DBBackend = "goleveldb"
+	config.BlockStorePath = "cold/blocks"
+	config.StatePath = "fast/state"
+	config.TxIndexPath = "fast/state"
+	config.EvidencePath = t.TempDir()
+	config.Consensus.SetWalFile(filepath.Join(config.RootDir, "fast", "cs.wal", "wal"))
+
+	n, err := DefaultNewNode(config, log.TestingLogger())
+	require.NoError(t, err)
+	blocksSub, err := n.EventBus().Subscribe(context.Background(), "node_test", types.EventQueryNewBlock)
+	require.NoError(t, err)
+	require.NoError(t, n.Start())
+	var height int64
+	for i := 0; i < 2; i++ {
+		select {
+		case msg := <-blocksSub.Out():
+			height = msg.Data().(types.EventDataNewBlock).Block.Height
+		case <-time.After(10 * time.Second):
+			t.Fatal("timed out waiting for the node to produce a block")
+		}
+	}
+	require.NoError(t, n.Stop())
+
+	for _, id := range cfg.StoreIDs {
+		assert.DirExists(t, config.StorePath(id))
+		assert.NoDirExists(t, filepath.Join(config.DBDir(), id+".db"))
+	}
+	assert.DirExists(t, filepath.Join(config.RootDir, "cold", "blocks", "blockstore.db"))
+	assert.DirExists(t, filepath.Join(config.RootDir, "fast", "state", "tx_index.db"))
+	assert.FileExists(t, config.Consensus.WalFile())
+
+	// the blocks and the state are in their own directories
+	blockStoreDB, err := dbm.NewDB("blockstore", dbm.GoLevelDBBackend, config.StoreDir("blockstore"))
+	require.NoError(t, err)
+	defer blockStoreDB.Close()
+	assert.GreaterOrEqual(t, store.NewBlockStore(blockStoreDB).Height(), height)
+	stateDB, err := dbm.NewDB("state", dbm.GoLevelDBBackend, config.StoreDir("state"))
+	require.NoError(t, err)
+	defer stateDB.Close()
+	state, err := sm.NewStore(stateDB, sm.StoreOptions{}).Load()
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, state.LastBlockHeight, height)
+
+	// a store can't be in the database of another one
+	config.StatePath = filepath.Join("cold", "blocks", "blockstore.db")
+	_, err = DefaultNewNode(config, log.TestingLogger())
+	assert.ErrorContains(t, err, "state_dir")
+}
+
 func TestNodeSetAppVersion(t *testing.T) {
 	config := cfg.ResetTestRoot("node_app_version_test")
 	defer os.RemoveAll(config.RootDir)
@@ -73,6 +73,12 @@ request times out.  The message `msg` is serialized using Protobuf.
 `TrySend(chID, msgBytes)` is a nonblocking call that returns false if the
 channel's queue is full.
 
+`SendTagged` and `TrySendTagged` additionally tag the message with the
+(height, round) it is relevant for. When the queue of a channel with the
+`SendQueueEvictStale` policy is full, the messages tagged older than the latest
+tag seen on the channel are evicted to make room, instead of the new message
+being dropped.
+
 Inbound message bytes are handled with an onReceive callback function.
 */
 type MConnection struct {
@@ -349,9 +355,22 @@ func (c *MConnection) stopForError(r interface{}) {
 
 // Queues a message to be sent to channel.
 func (c *MConnection) Send(chID byte, msgBytes []byte) bool {
+	return c.queueMsg(chID, queuedMsg{bytes: msgBytes})
+}
+
+// SendTagged queues a message to be sent to channel, tagged with the
+// (height, round) it is relevant for. On a channel with the
+// SendQueueEvictStale policy the message may be evicted from the queue once
+// messages with a later tag are queued; on other channels the tag is ignored.
+func (c *MConnection) SendTagged(chID byte, msgBytes []byte, tag RelevanceTag) bool {
+	return c.queueMsg(chID, queuedMsg{bytes: msgBytes, tag: tag, tagged: true})
+}
+
+func (c *MConnection) queueMsg(chID byte, msg queuedMsg) bool {
 	if !c.IsRunning() {
 		return false
 	}
+	msgBytes := msg.bytes
 
 	c.Logger.Debug("Send", "channel", chID, "conn", c, "msgBytes", log.NewLazySprintf("%X", msgBytes))
 
@@ -362,7 +381,7 @@ func (c *MConnection) Send(chID byte, msgBytes []byte) bool {
 		return false
 	}
 
-	success := channel.sendBytes(msgBytes)
+	success := channel.sendBytes(msg)
 	if success {
 		// Wake up sendRoutine if necessary
 		select {
@@ -378,9 +397,19 @@ func (c *MConnection) Send(chID byte, msgBytes []byte) bool {
 // Queues a message to be sent to channel.
 // Nonblocking, returns true if successful.
 func (c *MConnection) TrySend(chID byte, msgBytes []byte) bool {
+	return c.tryQueueMsg(chID, queuedMsg{bytes: msgBytes})
+}
+
+// TrySendTagged is the nonblocking counterpart of SendTagged.
+func (c *MConnection) TrySendTagged(chID byte, msgBytes []byte, tag RelevanceTag) bool {
+	return c.tryQueueMsg(chID, queuedMsg{bytes: msgBytes, tag: tag, tagged: true})
+}
+
+func (c *MConnection) tryQueueMsg(chID byte, msg queuedMsg) bool {
 	if !c.IsRunning() {
 		return false
 	}
+	msgBytes := msg.bytes
 
 	c.Logger.Debug("TrySend", "channel", chID, "conn", c, "msgBytes", log.NewLazySprintf("%X", msgBytes))
 
@@ -391,7 +420,7 @@ func (c *MConnection) TrySend(chID byte, msgBytes []byte) bool {
 		return false
 	}
 
-	ok = channel.trySendBytes(msgBytes)
+	ok = channel.trySendBytes(msg)
 	if ok {
 		// Wake up sendRoutine if necessary
 		select {
@@ -707,7 +736,7 @@ func (c *MConnection) Status() ConnectionStatus {
 	for i, channel := range c.channels {
 		status.Channels[i] = ChannelStatus{
 			ID:                channel.desc.ID,
-			SendQueueCapacity: cap(channel.sendQueue),
+			SendQueueCapacity: channel.desc.SendQueueCapacity,
 			SendQueueSize:     int(atomic.LoadInt32(&channel.sendQueueSize)),
 			Priority:          channel.desc.Priority,
 			RecentlySent:      atomic.LoadInt64(&channel.recentlySent),
@@ -725,6 +754,10 @@ type ChannelDescriptor struct {
 	RecvBufferCapacity  int
 	RecvMessageCapacity int
 	MessageType         proto.Message
+
+	// SendQueuePolicy decides what happens to messages sent while the send
+	// queue is full. Defaults to SendQueueTailDrop.
+	SendQueuePolicy SendQueuePolicy
 }
 
 func (chDesc ChannelDescriptor) FillDefaults() (filled ChannelDescriptor) {
@@ -747,7 +780,8 @@ type Channel struct {
 	conn          *MConnection
 	desc          ChannelDescriptor
 	sendQueue     chan []byte
-	sendQueueSize int32 // atomic.
+	staleQueue    *relevanceQueue // replaces sendQueue for SendQueueEvictStale
+	sendQueueSize int32           // atomic.
 	recving       []byte
 	sending       []byte
 	recentlySent  int64 // exponential moving average
@@ -762,13 +796,19 @@ func newChannel(conn *MConnection, desc ChannelDescriptor) *Channel {
 	if desc.Priority <= 0 {
 		panic("Channel default priority must be a positive integer")
 	}
-	return &Channel{
+	ch := &Channel{
 		conn:                    conn,
 		desc:                    desc,
-		sendQueue:               make(chan []byte, desc.SendQueueCapacity),
 		recving:                 make([]byte, 0, desc.RecvBufferCapacity),
 		maxPacketMsgPayloadSize: conn.config.MaxPacketMsgPayloadSize,
 	}
+	switch desc.SendQueuePolicy {
+	case SendQueueEvictStale:
+		ch.staleQueue = newRelevanceQueue(desc.SendQueueCapacity)
+	default:
+		ch.sendQueue = make(chan []byte, desc.SendQueueCapacity)
+	}
+	return ch
 }
 
 func (ch *Channel) SetLogger(l log.Logger) {
@@ -778,9 +818,21 @@ func (ch *Channel) SetLogger(l log.Logger) {
 // Queues message to send to this channel.
 // Goroutine-safe
 // Times out (and returns false) after defaultSendTimeout
-func (ch *Channel) sendBytes(bytes []byte) bool {
+func (ch *Channel) sendBytes(msg queuedMsg) bool {
+	if ch.staleQueue != nil {
+		timeout := time.NewTimer(defaultSendTimeout)
+		defer timeout.Stop()
+		for !ch.pushStale(msg) {
+			select {
+			case <-ch.staleQueue.popped:
+			case <-timeout.C:
+				return false
+			}
+		}
+		return true
+	}
 	select {
-	case ch.sendQueue <- bytes:
+	case ch.sendQueue <- msg.bytes:
 		atomic.AddInt32(&ch.sendQueueSize, 1)
 		return true
 	case <-time.After(defaultSendTimeout):
@@ -791,9 +843,12 @@ func (ch *Channel) sendBytes(bytes []byte) bool {
 // Queues message to send to this channel.
 // Nonblocking, returns true if successful.
 // Goroutine-safe
-func (ch *Channel) trySendBytes(bytes []byte) bool {
+func (ch *Channel) trySendBytes(msg queuedMsg) bool {
+	if ch.staleQueue != nil {
+		return ch.pushStale(msg)
+	}
 	select {
-	case ch.sendQueue <- bytes:
+	case ch.sendQueue <- msg.bytes:
 		atomic.AddInt32(&ch.sendQueueSize, 1)
 		return true
 	default:
@@ -801,6 +856,19 @@ func (ch *Channel) trySendBytes(bytes []byte) bool {
 	}
 }
 
+// Goroutine-safe
+func (ch *Channel) pushStale(msg queuedMsg) bool {
+	ok, evicted := ch.staleQueue.push(msg)
+	if evicted > 0 {
+		atomic.AddInt32(&ch.sendQueueSize, -int32(evicted))
+		ch.conn.Logger.Debug("Evicted stale messages", "channel", ch.desc.ID, "conn", ch.conn, "count", evicted)
+	}
+	if ok {
+		atomic.AddInt32(&ch.sendQueueSize, 1)
+	}
+	return ok
+}
+
 // Goroutine-safe
 func (ch *Channel) loadSendQueueSize() (size int) {
 	return int(atomic.LoadInt32(&ch.sendQueueSize))
@@ -817,6 +885,14 @@ func (ch *Channel) canSend() bool {
 // Goroutine-safe
 func (ch *Channel) isSendPending() bool {
 	if len(ch.sending) == 0 {
+		if ch.staleQueue != nil {
+			bytes, ok := ch.staleQueue.pop()
+			if !ok {
+				return false
+			}
+			ch.sending = bytes
+			return true
+		}
 		if len(ch.sendQueue) == 0 {
 			return false
 		}
@@ -3,6 +3,7 @@ package conn
 import (
 	"encoding/hex"
 	"net"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -631,3 +632,58 @@ func stopAll(t *testing.T, stoppers ...stopper) func() {
 		}
 	}
 }
+
+func TestChannelSendQueueEvictsStaleMessages(t *testing.T) {
+	server, client := NetPipe()
+	defer server.Close()
+	defer client.Close()
+
+	mconn := createTestMConnection(client)
+	newTestChannel := func(policy SendQueuePolicy) *Channel {
+		ch := newChannel(mconn, ChannelDescriptor{ID: 0x01, Priority: 1, SendQueueCapacity: 3, SendQueuePolicy: policy})
+		ch.SetLogger(log.TestingLogger())
+		return ch
+	}
+	tagged := func(msg string, height int64, round int32) queuedMsg {
+		return queuedMsg{bytes: []byte(msg), tag: RelevanceTag{Height: height, Round: round}, tagged: true}
+	}
+	drain := func(ch *Channel) (sent []string) {
+		for ch.isSendPending() {
+			sent = append(sent, string(ch.sending))
+			ch.sending = nil
+			atomic.AddInt32(&ch.sendQueueSize, -1)
+		}
+		return sent
+	}
+
+	ch := newTestChannel(SendQueueEvictStale)
+	require.True(t, ch.trySendBytes(queuedMsg{bytes: []byte("untagged")}))
+	require.True(t, ch.trySendBytes(tagged("1/0 a", 1, 0)))
+	require.True(t, ch.trySendBytes(tagged("1/0 b", 1, 0)))
+	// full and nothing is stale yet
+	assert.False(t, ch.trySendBytes(tagged("1/0 c", 1, 0)))
+	// the round 0 messages are evicted to make room for round 1
+	assert.True(t, ch.trySendBytes(tagged("1/1 a", 1, 1)))
+	assert.Equal(t, 2, ch.loadSendQueueSize())
+	assert.True(t, ch.trySendBytes(tagged("2/0 a", 2, 0)))
+	// full again: round 1 is stale by now, and a stale message never takes
+	// the place of another one
+	assert.False(t, ch.trySendBytes(tagged("1/1 b", 1, 1)))
+	assert.Equal(t, 2, ch.loadSendQueueSize())
+	assert.Equal(t, []string{"untagged", "2/0 a"}, drain(ch))
+	assert.Zero(t, ch.loadSendQueueSize())
+
+	// the untagged messages are never evicted
+	for i := 0; i < 3; i++ {
+		require.True(t, ch.trySendBytes(queuedMsg{bytes: []byte("untagged")}))
+	}
+	assert.False(t, ch.trySendBytes(tagged("3/0 a", 3, 0)))
+
+	// tail drop channels ignore the tags
+	ch = newTestChannel(SendQueueTailDrop)
+	for i := 0; i < 3; i++ {
+		require.True(t, ch.trySendBytes(tagged("1/0", 1, 0)))
+	}
+	assert.False(t, ch.trySendBytes(tagged("1/1", 1, 1)))
+	assert.Equal(t, []string{"1/0", "1/0", "1/0"}, drain(ch))
+}
@@ -84,14 +84,25 @@ type SecretConnection struct {
 	sendNonce *[aeadNonceSize]byte
 }
 
+// Signer authenticates the local end of a SecretConnection by signing the
+// handshake challenge. Any crypto.PrivKey is a Signer, while other
+// implementations keep the private key out of the process, e.g. in an HSM.
+// Sign is called from the goroutine making the connection.
+type Signer interface {
+	// Sign signs msg with the private key of PubKey.
+	Sign(msg []byte) ([]byte, error)
+	// PubKey returns the public key, whose address is the ID of the node.
+	PubKey() crypto.PubKey
+}
+
 // MakeSecretConnection performs handshake and returns a new authenticated
 // SecretConnection.
 // Returns nil if there is an error in handshake.
 // Caller should call conn.Close()
 // See docs/sts-final.pdf for more information.
-func MakeSecretConnection(conn io.ReadWriteCloser, locPrivKey crypto.PrivKey) (*SecretConnection, error) {
+func MakeSecretConnection(conn io.ReadWriteCloser, locSigner Signer) (*SecretConnection, error) {
 	var (
-		locPubKey = locPrivKey.PubKey()
+		locPubKey = locSigner.PubKey()
 	)
 
 	// Generate ephemeral keys for perfect forward secrecy.
@@ -155,7 +166,7 @@ func MakeSecretConnection(conn io.ReadWriteCloser, locPrivKey crypto.PrivKey) (*
 	}
 
 	// Sign the challenge bytes for authentication.
-	locSignature, err := signChallenge(&challenge, locPrivKey)
+	locSignature, err := signChallenge(&challenge, locSigner)
 	if err != nil {
 		return nil, err
 	}
@@ -388,8 +399,8 @@ func sort32(foo, bar *[32]byte) (lo, hi *[32]byte) {
 	return
 }
 
-func signChallenge(challenge *[32]byte, locPrivKey crypto.PrivKey) ([]byte, error) {
-	signature, err := locPrivKey.Sign(challenge[:])
+func signChallenge(challenge *[32]byte, locSigner Signer) ([]byte, error) {
+	signature, err := locSigner.Sign(challenge[:])
 	if err != nil {
 		return nil, err
 	}
@@ -422,6 +433,9 @@ func shareAuthSignature(sc io.ReadWriter, pubKey crypto.PubKey, signature []byte
 			if err != nil {
 				return nil, true, err // abort
 			}
+			if err := pba.ValidateLimits(); err != nil {
+				return nil, true, err // abort
+			}
 
 			pk, err := cryptoenc.PubKeyFromProto(pba.PubKey)
 			if err != nil {
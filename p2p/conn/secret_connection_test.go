@@ -3,6 +3,7 @@ package conn
 import (
 	"bufio"
 	"encoding/hex"
+	"errors"
 	"flag"
 	"fmt"
 	"io"
@@ -272,6 +273,50 @@ func TestNilPubkey(t *testing.T) {
 	assert.Equal(t, "toproto: key type <nil> is not supported", err.Error())
 }
 
+// remoteSigner is a Signer which is not a crypto.PrivKey, e.g. an HSM.
+type remoteSigner struct {
+	privKey crypto.PrivKey
+	err     error
+}
+
+func (s remoteSigner) Sign(msg []byte) ([]byte, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.privKey.Sign(msg)
+}
+func (s remoteSigner) PubKey() crypto.PubKey { return s.privKey.PubKey() }
+
+func TestSignerHandshake(t *testing.T) {
+	var fooConn, barConn = makeKVStoreConnPair()
+	defer fooConn.Close()
+	defer barConn.Close()
+	var fooPrvKey = ed25519.GenPrivKey()
+	var barSigner = remoteSigner{privKey: ed25519.GenPrivKey()}
+
+	fooSecConnc := make(chan *SecretConnection, 1)
+	go func() {
+		sc, _ := MakeSecretConnection(fooConn, fooPrvKey)
+		fooSecConnc <- sc
+	}()
+
+	barSecConn, err := MakeSecretConnection(barConn, barSigner)
+	require.NoError(t, err)
+	assert.Equal(t, fooPrvKey.PubKey(), barSecConn.RemotePubKey())
+	fooSecConn := <-fooSecConnc
+	require.NotNil(t, fooSecConn)
+	assert.Equal(t, barSigner.PubKey(), fooSecConn.RemotePubKey())
+
+	// the handshake fails with the signing
+	fooConn, barConn = makeKVStoreConnPair()
+	defer fooConn.Close()
+	defer barConn.Close()
+	go MakeSecretConnection(fooConn, fooPrvKey) //nolint:errcheck // ignore for tests
+	barSigner.err = errors.New("token removed")
+	_, err = MakeSecretConnection(barConn, barSigner)
+	assert.ErrorIs(t, err, barSigner.err)
+}
+
 func TestNonEd25519Pubkey(t *testing.T) {
 	var fooConn, barConn = makeKVStoreConnPair()
 	defer fooConn.Close()
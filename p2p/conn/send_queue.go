@@ -0,0 +1,129 @@
+package conn
+
+import (
+	cmtsync "github.com/tendermint/tendermint/libs/sync"
+)
+
+// SendQueuePolicy decides what a channel does with a message when its send
+// queue is full.
+type SendQueuePolicy uint8
+
+const (
+	// SendQueueTailDrop rejects (or, for Send, delays) the newest message when
+	// the queue is full. This is the default.
+	SendQueueTailDrop SendQueuePolicy = iota
+	// SendQueueEvictStale first evicts the queued messages whose relevance tag
+	// is older than the latest tag observed on the channel, and only rejects the
+	// new message if that did not free any room. Messages queued without a tag
+	// are never evicted.
+	SendQueueEvictStale
+)
+
+// RelevanceTag marks the (height, round) a queued message is relevant for.
+// Once a message with a later tag has been queued on a channel, the messages
+// with earlier tags are considered stale.
+type RelevanceTag struct {
+	Height int64
+	Round  int32
+}
+
+// Before returns true if t is older than other.
+func (t RelevanceTag) Before(other RelevanceTag) bool {
+	if t.Height != other.Height {
+		return t.Height < other.Height
+	}
+	return t.Round < other.Round
+}
+
+type queuedMsg struct {
+	bytes  []byte
+	tag    RelevanceTag
+	tagged bool
+}
+
+// stale returns true if the message may be evicted in favour of messages
+// tagged with latest.
+func (m queuedMsg) stale(latest RelevanceTag) bool {
+	return m.tagged && m.tag.Before(latest)
+}
+
+// relevanceQueue is the bounded FIFO send queue of a channel with the
+// SendQueueEvictStale policy.
+// Goroutine-safe
+type relevanceQueue struct {
+	mtx      cmtsync.Mutex
+	msgs     []queuedMsg
+	capacity int
+	latest   RelevanceTag
+
+	// popped is signalled whenever a message leaves the queue, so that blocked
+	// senders can try again.
+	popped chan struct{}
+}
+
+func newRelevanceQueue(capacity int) *relevanceQueue {
+	return &relevanceQueue{
+		msgs:     make([]queuedMsg, 0, capacity),
+		capacity: capacity,
+		popped:   make(chan struct{}, 1),
+	}
+}
+
+// push queues msg, evicting the stale messages first if the queue is full. It
+// returns whether msg was queued and how many messages were evicted. A stale
+// msg is never queued in place of another message.
+func (q *relevanceQueue) push(msg queuedMsg) (ok bool, evicted int) {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+
+	if msg.tagged && q.latest.Before(msg.tag) {
+		q.latest = msg.tag
+	}
+	if len(q.msgs) < q.capacity {
+		q.msgs = append(q.msgs, msg)
+		return true, 0
+	}
+
+	kept := q.msgs[:0]
+	for _, m := range q.msgs {
+		if !m.stale(q.latest) {
+			kept = append(kept, m)
+		}
+	}
+	evicted = len(q.msgs) - len(kept)
+	for i := len(kept); i < len(q.msgs); i++ {
+		q.msgs[i] = queuedMsg{} // release the evicted bytes
+	}
+	q.msgs = kept
+
+	if len(q.msgs) == q.capacity || msg.stale(q.latest) {
+		return false, evicted
+	}
+	q.msgs = append(q.msgs, msg)
+	return true, evicted
+}
+
+// pop removes the oldest queued message and returns its bytes.
+func (q *relevanceQueue) pop() ([]byte, bool) {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+
+	if len(q.msgs) == 0 {
+		return nil, false
+	}
+	bytes := q.msgs[0].bytes
+	q.msgs[0] = queuedMsg{}
+	q.msgs = q.msgs[1:]
+
+	select {
+	case q.popped <- struct{}{}:
+	default:
+	}
+	return bytes, true
+}
+
+func (q *relevanceQueue) len() int {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+	return len(q.msgs)
+}
@@ -25,6 +25,8 @@ type ErrRejected struct {
 	isIncompatible    bool
 	isNodeInfoInvalid bool
 	isSelf            bool
+	isWrongNetwork    bool
+	network           string
 }
 
 // Addr returns the NetAddress for the rejected Peer.
@@ -90,12 +92,47 @@ func (e ErrRejected) IsFiltered() bool { return e.isFiltered }
 // IsIncompatible when Peer NodeInfo is not compatible with our own.
 func (e ErrRejected) IsIncompatible() bool { return e.isIncompatible }
 
+// IsWrongNetwork when Peer is on a different network than ours. It implies
+// IsIncompatible.
+func (e ErrRejected) IsWrongNetwork() bool { return e.isWrongNetwork }
+
+// Network returns the network reported by the rejected Peer in the handshake,
+// if it was rejected as incompatible.
+func (e ErrRejected) Network() string { return e.network }
+
 // IsNodeInfoInvalid when the sent NodeInfo is not valid.
 func (e ErrRejected) IsNodeInfoInvalid() bool { return e.isNodeInfoInvalid }
 
 // IsSelf when Peer is our own node.
 func (e ErrRejected) IsSelf() bool { return e.isSelf }
 
+// ErrPersistentPeerWrongNetwork is returned when dialing a persistent peer
+// whose handshakes revealed a different network too many times in a row.
+type ErrPersistentPeerWrongNetwork struct {
+	Addr    *NetAddress
+	Network string
+	Dials   int
+}
+
+func (e ErrPersistentPeerWrongNetwork) Error() string {
+	return fmt.Sprintf("persistent peer %v is on network %q (%d dials)", e.Addr, e.Network, e.Dials)
+}
+
+// ErrPeerMessageLimits is the error a peer is stopped for once it sent too many
+// messages exceeding the limits of the p2p wire messages.
+type ErrPeerMessageLimits struct {
+	Violations uint32
+	Err        error // the latest violation
+}
+
+func (e ErrPeerMessageLimits) Error() string {
+	return fmt.Sprintf("peer sent %d messages exceeding the limits, the latest: %v", e.Violations, e.Err)
+}
+
+func (e ErrPeerMessageLimits) Unwrap() error {
+	return e.Err
+}
+
 // ErrSwitchDuplicatePeerID to be raised when a peer is connecting with a known
 // ID.
 type ErrSwitchDuplicatePeerID struct {
@@ -189,3 +226,16 @@ type ErrCurrentlyDialingOrExistingAddress struct {
 func (e ErrCurrentlyDialingOrExistingAddress) Error() string {
 	return fmt.Sprintf("connection with %s has been established or dialed", e.Addr)
 }
+
+// ErrPeerDiversity indicates that connecting to a peer would exceed the peers
+// allowed within its IP subnet or autonomous system.
+type ErrPeerDiversity struct {
+	IP    net.IP
+	Group string // the subnet or autonomous system
+	Peers int
+	Max   int
+}
+
+func (e ErrPeerDiversity) Error() string {
+	return fmt.Sprintf("too many peers in %s of %v: have %d, at most %d are allowed", e.Group, e.IP, e.Peers, e.Max)
+}
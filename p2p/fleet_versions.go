@@ -0,0 +1,85 @@
+package p2p
+
+import "sort"
+
+// VersionNodes holds the nodes of a fleet at a version.
+type VersionNodes struct {
+	Version uint64 `json:"version"`
+	NodeIDs []ID   `json:"node_ids"`
+}
+
+// ComponentVersions is the distribution of the values of a ProtocolVersion
+// component across a fleet.
+type ComponentVersions struct {
+	// Versions holds the values, from the most to the least common one. Ties
+	// go to the highest value.
+	Versions []VersionNodes `json:"versions"`
+	// Majority is the most common value, ties going to the highest one.
+	Majority uint64 `json:"majority"`
+	// Outliers holds the IDs of the nodes whose value is not Majority.
+	Outliers []ID `json:"outliers"`
+}
+
+// Skewed returns true if the nodes do not all agree on the value.
+func (cv ComponentVersions) Skewed() bool {
+	return len(cv.Versions) > 1
+}
+
+// FleetVersionReport is the distribution of the protocol versions across a
+// fleet of nodes, per component.
+type FleetVersionReport struct {
+	Nodes int               `json:"nodes"`
+	P2P   ComponentVersions `json:"p2p"`
+	Block ComponentVersions `json:"block"`
+	App   ComponentVersions `json:"app"`
+}
+
+// Skewed returns true if the nodes do not all agree on a component of the
+// protocol version.
+func (r FleetVersionReport) Skewed() bool {
+	return r.P2P.Skewed() || r.Block.Skewed() || r.App.Skewed()
+}
+
+// CompareFleetVersions reports the distribution of the protocol versions of
+// the given nodes, e.g. collected from their /status, to detect version skew
+// when coordinating upgrades. The node IDs are listed in the order of infos.
+func CompareFleetVersions(infos []DefaultNodeInfo) FleetVersionReport {
+	return FleetVersionReport{
+		Nodes: len(infos),
+		P2P:   compareComponent(infos, func(pv ProtocolVersion) uint64 { return pv.P2P }),
+		Block: compareComponent(infos, func(pv ProtocolVersion) uint64 { return pv.Block }),
+		App:   compareComponent(infos, func(pv ProtocolVersion) uint64 { return pv.App }),
+	}
+}
+
+func compareComponent(infos []DefaultNodeInfo, component func(ProtocolVersion) uint64) ComponentVersions {
+	var (
+		cv      ComponentVersions
+		indexes = make(map[uint64]int)
+	)
+	for _, info := range infos {
+		v := component(info.ProtocolVersion)
+		i, ok := indexes[v]
+		if !ok {
+			i = len(cv.Versions)
+			indexes[v] = i
+			cv.Versions = append(cv.Versions, VersionNodes{Version: v})
+		}
+		cv.Versions[i].NodeIDs = append(cv.Versions[i].NodeIDs, info.DefaultNodeID)
+	}
+	if len(cv.Versions) == 0 {
+		return cv
+	}
+
+	sort.Slice(cv.Versions, func(i, j int) bool {
+		if len(cv.Versions[i].NodeIDs) != len(cv.Versions[j].NodeIDs) {
+			return len(cv.Versions[i].NodeIDs) > len(cv.Versions[j].NodeIDs)
+		}
+		return cv.Versions[i].Version > cv.Versions[j].Version
+	})
+	cv.Majority = cv.Versions[0].Version
+	for _, vn := range cv.Versions[1:] {
+		cv.Outliers = append(cv.Outliers, vn.NodeIDs...)
+	}
+	return cv
+}
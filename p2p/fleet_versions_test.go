@@ -0,0 +1,54 @@
+package p2p
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompareFleetVersions(t *testing.T) {
+	node := func(id ID, p2p, block, app uint64) DefaultNodeInfo {
+		return DefaultNodeInfo{DefaultNodeID: id, ProtocolVersion: NewProtocolVersion(p2p, block, app)}
+	}
+
+	report := CompareFleetVersions([]DefaultNodeInfo{
+		node("a", 8, 11, 1),
+		node("b", 8, 11, 2),
+		node("c", 8, 11, 1),
+		node("d", 8, 11, 2),
+		node("e", 7, 11, 3),
+	})
+	assert.Equal(t, 5, report.Nodes)
+	assert.True(t, report.Skewed())
+
+	assert.Equal(t, ComponentVersions{
+		Versions: []VersionNodes{
+			{Version: 8, NodeIDs: []ID{"a", "b", "c", "d"}},
+			{Version: 7, NodeIDs: []ID{"e"}},
+		},
+		Majority: 8,
+		Outliers: []ID{"e"},
+	}, report.P2P)
+	assert.True(t, report.P2P.Skewed())
+
+	assert.Equal(t, ComponentVersions{
+		Versions: []VersionNodes{{Version: 11, NodeIDs: []ID{"a", "b", "c", "d", "e"}}},
+		Majority: 11,
+	}, report.Block)
+	assert.False(t, report.Block.Skewed())
+
+	// ties go to the highest version
+	assert.Equal(t, ComponentVersions{
+		Versions: []VersionNodes{
+			{Version: 2, NodeIDs: []ID{"b", "d"}},
+			{Version: 1, NodeIDs: []ID{"a", "c"}},
+			{Version: 3, NodeIDs: []ID{"e"}},
+		},
+		Majority: 2,
+		Outliers: []ID{"a", "c", "e"},
+	}, report.App)
+
+	report = CompareFleetVersions(nil)
+	assert.Zero(t, report.Nodes)
+	assert.False(t, report.Skewed())
+}
@@ -3,6 +3,7 @@ package p2p
 import (
 	"bytes"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"os"
 
@@ -10,6 +11,7 @@ import (
 	"github.com/tendermint/tendermint/crypto/ed25519"
 	cmtjson "github.com/tendermint/tendermint/libs/json"
 	cmtos "github.com/tendermint/tendermint/libs/os"
+	"github.com/tendermint/tendermint/p2p/conn"
 )
 
 // ID is a hex-encoded crypto.Address
@@ -27,6 +29,15 @@ const IDByteLength = crypto.AddressSize
 // It contains the nodes private key for authentication.
 type NodeKey struct {
 	PrivKey crypto.PrivKey `json:"priv_key"` // our priv key
+
+	signer conn.Signer // signs in place of PrivKey if set
+}
+
+// NewSignerNodeKey returns a NodeKey authenticating with signer, which keeps
+// the private key out of the process, e.g. in an HSM. Its PrivKey is nil, so
+// it cannot be saved.
+func NewSignerNodeKey(signer conn.Signer) *NodeKey {
+	return &NodeKey{signer: signer}
 }
 
 // ID returns the peer's canonical ID - the hash of its public key.
@@ -36,7 +47,16 @@ func (nodeKey *NodeKey) ID() ID {
 
 // PubKey returns the peer's PubKey
 func (nodeKey *NodeKey) PubKey() crypto.PubKey {
-	return nodeKey.PrivKey.PubKey()
+	return nodeKey.Signer().PubKey()
+}
+
+// Signer returns the signer authenticating the node in the secret connection
+// handshakes: the one of NewSignerNodeKey, else PrivKey.
+func (nodeKey *NodeKey) Signer() conn.Signer {
+	if nodeKey.signer != nil {
+		return nodeKey.signer
+	}
+	return nodeKey.PrivKey
 }
 
 // PubKeyToID returns the ID corresponding to the given PubKey.
@@ -84,6 +104,9 @@ func LoadNodeKey(filePath string) (*NodeKey, error) {
 
 // SaveAs persists the NodeKey to filePath.
 func (nodeKey *NodeKey) SaveAs(filePath string) error {
+	if nodeKey.PrivKey == nil {
+		return errors.New("cannot save a node key without its private key")
+	}
 	jsonBytes, err := cmtjson.Marshal(nodeKey)
 	if err != nil {
 		return err
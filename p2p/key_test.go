@@ -53,6 +53,20 @@ func TestNodeKeySaveAs(t *testing.T) {
 	assert.FileExists(t, filePath)
 }
 
+func TestSignerNodeKey(t *testing.T) {
+	privKey := ed25519.GenPrivKey()
+	nodeKey := &NodeKey{PrivKey: privKey}
+	assert.Equal(t, privKey, nodeKey.Signer())
+
+	// a key held outside of the process
+	signer := ed25519.GenPrivKey()
+	nodeKey = NewSignerNodeKey(signer)
+	assert.Equal(t, signer, nodeKey.Signer())
+	assert.Equal(t, signer.PubKey(), nodeKey.PubKey())
+	assert.Equal(t, PubKeyToID(signer.PubKey()), nodeKey.ID())
+	assert.Error(t, nodeKey.SaveAs(filepath.Join(t.TempDir(), "node_key.json")))
+}
+
 //----------------------------------------------------------
 
 func padBytes(bz []byte, targetBytes int) []byte {
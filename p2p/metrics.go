@@ -41,6 +41,25 @@ type Metrics struct {
 	MessageReceiveBytesTotal metrics.Counter
 	// Number of bytes of each message type sent.
 	MessageSendBytesTotal metrics.Counter
+	// Lifetime of the connections with peers in seconds.
+	PeerConnectionLifetime metrics.Histogram
+	// Number of connections established with a given peer, including before
+	// the latest restart. Only reported if peer connection metrics are enabled.
+	PeerConnectionsEstablished metrics.Counter
+	// Number of connections closed with a given peer, including before the
+	// latest restart. Only reported if peer connection metrics are enabled.
+	PeerConnectionsClosed metrics.Counter
+	// Total time in seconds a given peer has been connected, including before
+	// the latest restart. Only reported if peer connection metrics are enabled.
+	PeerConnectedSeconds metrics.Counter
+	// Reason of the latest disconnect of a given peer, set to 1 for the latest
+	// reason. Only reported if peer connection metrics are enabled.
+	PeerLastDisconnectReason metrics.Gauge
+	// Number of messages received from a given peer that exceeded the limits
+	// of the p2p wire messages, and were dropped.
+	MessageLimitViolations metrics.Counter
+	// Number of peers within a given IP subnet.
+	SubnetPeers metrics.Gauge
 }
 
 // PrometheusMetrics returns Metrics build using Prometheus client library.
@@ -94,18 +113,68 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 			Name:      "message_send_bytes_total",
 			Help:      "Number of bytes of each message type sent.",
 		}, append(labels, "message_type", "chID", "peer_id")).With(labelsAndValues...),
+		PeerConnectionLifetime: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "peer_connection_lifetime",
+			Help:      "Lifetime of the connections with peers in seconds.",
+			Buckets:   stdprometheus.ExponentialBuckets(1, 4, 10),
+		}, labels).With(labelsAndValues...),
+		PeerConnectionsEstablished: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "peer_connections_established",
+			Help:      "Number of connections established with a given peer, including before the latest restart.",
+		}, append(labels, "peer_id")).With(labelsAndValues...),
+		PeerConnectionsClosed: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "peer_connections_closed",
+			Help:      "Number of connections closed with a given peer, including before the latest restart.",
+		}, append(labels, "peer_id")).With(labelsAndValues...),
+		PeerConnectedSeconds: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "peer_connected_seconds",
+			Help:      "Total time in seconds a given peer has been connected, including before the latest restart.",
+		}, append(labels, "peer_id")).With(labelsAndValues...),
+		PeerLastDisconnectReason: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "peer_last_disconnect_reason",
+			Help:      "Reason of the latest disconnect of a given peer, set to 1 for the latest reason.",
+		}, append(labels, "peer_id", "reason")).With(labelsAndValues...),
+		MessageLimitViolations: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "message_limit_violations",
+			Help:      "Number of messages received from a given peer that exceeded the limits, and were dropped.",
+		}, append(labels, "peer_id", "message_type")).With(labelsAndValues...),
+		SubnetPeers: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "subnet_peers",
+			Help:      "Number of peers within a given IP subnet.",
+		}, append(labels, "subnet")).With(labelsAndValues...),
 	}
 }
 
 func NopMetrics() *Metrics {
 	return &Metrics{
-		Peers:                    discard.NewGauge(),
-		PeerReceiveBytesTotal:    discard.NewCounter(),
-		PeerSendBytesTotal:       discard.NewCounter(),
-		PeerPendingSendBytes:     discard.NewGauge(),
-		NumTxs:                   discard.NewGauge(),
-		MessageReceiveBytesTotal: discard.NewCounter(),
-		MessageSendBytesTotal:    discard.NewCounter(),
+		Peers:                      discard.NewGauge(),
+		PeerReceiveBytesTotal:      discard.NewCounter(),
+		PeerSendBytesTotal:         discard.NewCounter(),
+		PeerPendingSendBytes:       discard.NewGauge(),
+		NumTxs:                     discard.NewGauge(),
+		MessageReceiveBytesTotal:   discard.NewCounter(),
+		MessageSendBytesTotal:      discard.NewCounter(),
+		PeerConnectionLifetime:     discard.NewHistogram(),
+		PeerConnectionsEstablished: discard.NewCounter(),
+		PeerConnectionsClosed:      discard.NewCounter(),
+		PeerConnectedSeconds:       discard.NewCounter(),
+		PeerLastDisconnectReason:   discard.NewGauge(),
+		MessageLimitViolations:     discard.NewCounter(),
+		SubnetPeers:                discard.NewGauge(),
 	}
 }
 
@@ -13,8 +13,8 @@ import (
 )
 
 const (
-	maxNodeInfoSize = 10240 // 10KB
-	maxNumChannels  = 16    // plenty of room for upgrades, for now
+	maxNodeInfoSize = 10240                     // 10KB
+	maxNumChannels  = tmp2p.MaxNodeInfoChannels // plenty of room for upgrades, for now
 )
 
 // Max size of the NodeInfo struct
@@ -43,6 +43,15 @@ type nodeInfoTransport interface {
 	CompatibleWith(other NodeInfo) error
 }
 
+// nodeInfoNetwork returns the network of the NodeInfo, or an empty string if
+// it is not a DefaultNodeInfo.
+func nodeInfoNetwork(ni NodeInfo) string {
+	if dni, ok := ni.(DefaultNodeInfo); ok {
+		return dni.Network
+	}
+	return ""
+}
+
 //-------------------------------------------------------------
 
 // ProtocolVersion contains the protocol versions for the software.
@@ -81,8 +90,9 @@ type DefaultNodeInfo struct {
 
 	// Authenticate
 	// TODO: replace with NetAddress
-	DefaultNodeID ID     `json:"id"`          // authenticated identifier
-	ListenAddr    string `json:"listen_addr"` // accepting incoming
+	DefaultNodeID ID     `json:"id"`            // authenticated identifier
+	ListenAddr    string `json:"listen_addr"`   // accepting incoming
+	ExternalAddr  string `json:"external_addr"` // advertised to peers, if it differs from ListenAddr
 
 	// Check compatibility.
 	// Channels are HexBytes so easier to read as JSON
@@ -109,7 +119,7 @@ func (info DefaultNodeInfo) ID() ID {
 // Validate checks the self-reported DefaultNodeInfo is safe.
 // It returns an error if there
 // are too many Channels, if there are any duplicate Channels,
-// if the ListenAddr is malformed, or if the ListenAddr is a host name
+// if the ListenAddr or ExternalAddr is malformed, or if it is a host name
 // that can not be resolved to some IP.
 // TODO: constraints for Moniker/Other? Or is that for the UI ?
 // JAE: It needs to be done on the client, but to prevent ambiguous
@@ -129,6 +139,14 @@ func (info DefaultNodeInfo) Validate() error {
 		return err
 	}
 
+	// Validate ExternalAddr.
+	if info.ExternalAddr != "" {
+		_, err := NewNetAddressString(IDAddressString(info.ID(), info.ExternalAddr))
+		if err != nil {
+			return fmt.Errorf("invalid external address: %w", err)
+		}
+	}
+
 	// Network is validated in CompatibleWith.
 
 	// Validate Version
@@ -216,11 +234,29 @@ OUTER_LOOP:
 
 // NetAddress returns a NetAddress derived from the DefaultNodeInfo -
 // it includes the authenticated peer ID and the self-reported
-// ListenAddr. Note that the ListenAddr is not authenticated and
-// may not match that address actually dialed if its an outbound peer.
+// ExternalAddr, or the ListenAddr if the node has no external address.
+// Note that the address is not authenticated and may not match that
+// address actually dialed if its an outbound peer.
 func (info DefaultNodeInfo) NetAddress() (*NetAddress, error) {
-	idAddr := IDAddressString(info.ID(), info.ListenAddr)
-	return NewNetAddressString(idAddr)
+	addr := info.ExternalAddr
+	if addr == "" {
+		addr = info.ListenAddr
+	}
+	return NewNetAddressString(IDAddressString(info.ID(), addr))
+}
+
+// AdvertisedAddress returns the address peers should dial to reach the node:
+// its ExternalAddr, for a node behind a NAT, or else its ListenAddr. It
+// returns an error if the address is not routable.
+func (info DefaultNodeInfo) AdvertisedAddress() (*NetAddress, error) {
+	addr, err := info.NetAddress()
+	if err != nil {
+		return nil, err
+	}
+	if !addr.Routable() {
+		return nil, fmt.Errorf("advertised address %v is not routable", addr)
+	}
+	return addr, nil
 }
 
 func (info DefaultNodeInfo) HasChannel(chID byte) bool {
@@ -238,6 +274,7 @@ func (info DefaultNodeInfo) ToProto() *tmp2p.DefaultNodeInfo {
 
 	dni.DefaultNodeID = string(info.DefaultNodeID)
 	dni.ListenAddr = info.ListenAddr
+	dni.ExternalAddr = info.ExternalAddr
 	dni.Network = info.Network
 	dni.Version = info.Version
 	dni.Channels = info.Channels
@@ -262,6 +299,7 @@ func DefaultNodeInfoFromToProto(pb *tmp2p.DefaultNodeInfo) (DefaultNodeInfo, err
 		},
 		DefaultNodeID: ID(pb.DefaultNodeID),
 		ListenAddr:    pb.ListenAddr,
+		ExternalAddr:  pb.ExternalAddr,
 		Network:       pb.Network,
 		Version:       pb.Version,
 		Channels:      pb.Channels,
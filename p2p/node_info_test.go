@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/tendermint/tendermint/crypto/ed25519"
 )
@@ -41,6 +42,8 @@ func TestNodeInfoValidate(t *testing.T) {
 
 		{"Invalid NetAddress", func(ni *DefaultNodeInfo) { ni.ListenAddr = "not-an-address" }, true},
 		{"Good NetAddress", func(ni *DefaultNodeInfo) { ni.ListenAddr = "0.0.0.0:26656" }, false},
+		{"Invalid ExternalAddr", func(ni *DefaultNodeInfo) { ni.ExternalAddr = "not-an-address" }, true},
+		{"Good ExternalAddr", func(ni *DefaultNodeInfo) { ni.ExternalAddr = "159.89.10.97:26656" }, false},
 
 		{"Non-ASCII Version", func(ni *DefaultNodeInfo) { ni.Version = nonASCII }, true},
 		{"Empty tab Version", func(ni *DefaultNodeInfo) { ni.Version = emptyTab }, true},
@@ -88,6 +91,42 @@ func TestNodeInfoValidate(t *testing.T) {
 
 }
 
+func TestNodeInfoAdvertisedAddress(t *testing.T) {
+	nodeKey := NodeKey{PrivKey: ed25519.GenPrivKey()}
+	ni := testNodeInfo(nodeKey.ID(), "testing").(DefaultNodeInfo)
+
+	// the listen address is not routable
+	ni.ListenAddr = "0.0.0.0:26656"
+	_, err := ni.AdvertisedAddress()
+	assert.Error(t, err)
+	ni.ListenAddr = "10.0.0.1:26656"
+	_, err = ni.AdvertisedAddress()
+	assert.Error(t, err)
+
+	// a node behind a NAT advertises its external address
+	ni.ExternalAddr = "159.89.10.97:26657"
+	addr, err := ni.AdvertisedAddress()
+	require.NoError(t, err)
+	assert.Equal(t, nodeKey.ID(), addr.ID)
+	assert.Equal(t, "159.89.10.97", addr.IP.String())
+	assert.EqualValues(t, 26657, addr.Port)
+	netAddr, err := ni.NetAddress()
+	require.NoError(t, err)
+	assert.Equal(t, addr, netAddr)
+
+	// the external address survives the round trip through protobuf
+	pbni, err := DefaultNodeInfoFromToProto(ni.ToProto())
+	require.NoError(t, err)
+	assert.Equal(t, ni, pbni)
+
+	// without one, the listen address is advertised
+	ni.ExternalAddr = ""
+	ni.ListenAddr = "159.89.10.97:26656"
+	addr, err = ni.AdvertisedAddress()
+	require.NoError(t, err)
+	assert.EqualValues(t, 26656, addr.Port)
+}
+
 func TestNodeInfoCompatible(t *testing.T) {
 
 	nodeKey1 := NodeKey{PrivKey: ed25519.GenPrivKey()}
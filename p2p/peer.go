@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net"
 	"reflect"
+	"sync/atomic"
 	"time"
 
 	"github.com/gogo/protobuf/proto"
@@ -18,7 +19,14 @@ import (
 )
 
 //go:generate ../scripts/mockery_generate.sh Peer
-const metricsTickerDuration = 10 * time.Second
+const (
+	metricsTickerDuration = 10 * time.Second
+
+	// maxMessageLimitViolations is the number of messages exceeding the limits
+	// of the p2p wire messages after which a peer is stopped, and banned by the
+	// switch. The messages are dropped until then.
+	maxMessageLimitViolations = 3
+)
 
 // Peer is an interface representing a peer connected on a reactor.
 type Peer interface {
@@ -102,6 +110,34 @@ func TrySendEnvelopeShim(p Peer, e Envelope, lg log.Logger) bool {
 	return p.TrySend(e.ChannelID, msgBytes)
 }
 
+// TaggedEnvelopeSender is implemented by the peers able to tag the messages
+// they queue with the (height, round) the messages are relevant for, so that
+// the stale ones are shed first under backpressure.
+type TaggedEnvelopeSender interface {
+	SendEnvelopeTagged(Envelope, RelevanceTag) bool
+	TrySendEnvelopeTagged(Envelope, RelevanceTag) bool
+}
+
+// SendEnvelopeTaggedShim sends the envelope tagged with its relevance if the
+// peer implements TaggedEnvelopeSender, and falls back to SendEnvelopeShim
+// otherwise.
+func SendEnvelopeTaggedShim(p Peer, e Envelope, tag RelevanceTag, lg log.Logger) bool {
+	if ts, ok := p.(TaggedEnvelopeSender); ok {
+		return ts.SendEnvelopeTagged(e, tag)
+	}
+	return SendEnvelopeShim(p, e, lg) //nolint: staticcheck
+}
+
+// TrySendEnvelopeTaggedShim tries to send the envelope tagged with its
+// relevance if the peer implements TaggedEnvelopeSender, and falls back to
+// TrySendEnvelopeShim otherwise.
+func TrySendEnvelopeTaggedShim(p Peer, e Envelope, tag RelevanceTag, lg log.Logger) bool {
+	if ts, ok := p.(TaggedEnvelopeSender); ok {
+		return ts.TrySendEnvelopeTagged(e, tag)
+	}
+	return TrySendEnvelopeShim(p, e, lg) //nolint: staticcheck
+}
+
 //----------------------------------------------------------
 
 // peerConn contains the raw connection and its config.
@@ -183,6 +219,10 @@ type peer struct {
 
 	// When removal of a peer fails, we set this flag
 	removalAttemptFailed bool
+
+	// number of messages dropped for exceeding the limits, see
+	// maxMessageLimitViolations
+	limitViolations uint32
 }
 
 type PeerOption func(*peer)
@@ -324,31 +364,7 @@ func (p *peer) Status() cmtconn.ConnectionStatus {
 // Using SendEnvelope allows for tracking the message bytes sent and received by message type
 // as a metric which Send cannot support.
 func (p *peer) SendEnvelope(e Envelope) bool {
-	if !p.IsRunning() {
-		return false
-	} else if !p.hasChannel(e.ChannelID) {
-		return false
-	}
-	msg := e.Message
-	metricLabelValue := p.mlc.ValueToMetricLabel(msg)
-	if w, ok := msg.(Wrapper); ok {
-		msg = w.Wrap()
-	}
-	msgBytes, err := proto.Marshal(msg)
-	if err != nil {
-		p.Logger.Error("marshaling message to send", "error", err)
-		return false
-	}
-	res := p.Send(e.ChannelID, msgBytes)
-	if res {
-		labels := []string{
-			"message_type", metricLabelValue,
-			"chID", fmt.Sprintf("%#x", e.ChannelID),
-			"peer_id", string(p.ID()),
-		}
-		p.metrics.MessageSendBytesTotal.With(labels...).Add(float64(len(msgBytes)))
-	}
-	return res
+	return p.sendEnvelope(e, p.mconn.Send)
 }
 
 // Send msg bytes to the channel identified by chID byte. Returns false if the
@@ -376,6 +392,28 @@ func (p *peer) Send(chID byte, msgBytes []byte) bool {
 // Using TrySendEnvelope allows for tracking the message bytes sent and received by message type
 // as a metric which TrySend cannot support.
 func (p *peer) TrySendEnvelope(e Envelope) bool {
+	return p.sendEnvelope(e, p.mconn.TrySend)
+}
+
+// SendEnvelopeTagged is SendEnvelope with the message tagged with the
+// (height, round) it is relevant for, see cmtconn.SendQueueEvictStale.
+func (p *peer) SendEnvelopeTagged(e Envelope, tag RelevanceTag) bool {
+	return p.sendEnvelope(e, func(chID byte, msgBytes []byte) bool {
+		return p.mconn.SendTagged(chID, msgBytes, tag)
+	})
+}
+
+// TrySendEnvelopeTagged is TrySendEnvelope with the message tagged with the
+// (height, round) it is relevant for, see cmtconn.SendQueueEvictStale.
+func (p *peer) TrySendEnvelopeTagged(e Envelope, tag RelevanceTag) bool {
+	return p.sendEnvelope(e, func(chID byte, msgBytes []byte) bool {
+		return p.mconn.TrySendTagged(chID, msgBytes, tag)
+	})
+}
+
+// sendEnvelope marshals the message in the envelope, places it on the
+// connection's queue with queueMsg and records the sent bytes.
+func (p *peer) sendEnvelope(e Envelope, queueMsg func(chID byte, msgBytes []byte) bool) bool {
 	if !p.IsRunning() {
 		// see Switch#Broadcast, where we fetch the list of peers and loop over
 		// them - while we're looping, one peer may be removed and stopped.
@@ -393,8 +431,12 @@ func (p *peer) TrySendEnvelope(e Envelope) bool {
 		p.Logger.Error("marshaling message to send", "error", err)
 		return false
 	}
-	res := p.TrySend(e.ChannelID, msgBytes)
+	res := queueMsg(e.ChannelID, msgBytes)
 	if res {
+		p.metrics.PeerSendBytesTotal.With(
+			"peer_id", string(p.ID()),
+			"chID", fmt.Sprintf("%#x", e.ChannelID),
+		).Add(float64(len(msgBytes)))
 		labels := []string{
 			"message_type", metricLabelValue,
 			"chID", fmt.Sprintf("%#x", e.ChannelID),
@@ -552,6 +594,19 @@ func createMConnection(
 			}
 		}
 
+		if v, ok := msg.(LimitValidator); ok {
+			if err := v.ValidateLimits(); err != nil {
+				p.metrics.MessageLimitViolations.With(
+					"peer_id", string(p.ID()),
+					"message_type", p.mlc.ValueToMetricLabel(msg),
+				).Add(1)
+				if violations := atomic.AddUint32(&p.limitViolations, 1); violations >= maxMessageLimitViolations {
+					onPeerError(p, ErrPeerMessageLimits{Violations: violations, Err: err})
+				}
+				return
+			}
+		}
+
 		labels := []string{
 			"peer_id", string(p.ID()),
 			"chID", fmt.Sprintf("%#x", chID),
@@ -565,6 +620,7 @@ func createMConnection(
 				ChannelID: chID,
 				Src:       p,
 				Message:   msg,
+				Bytes:     msgBytes,
 			})
 		} else {
 			reactor.Receive(chID, p, msgBytes)
@@ -0,0 +1,219 @@
+package p2p
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// PeerDiversity caps the number of connected peers within a single IP subnet
+// and, given an ASN table, within a single autonomous system, so that a party
+// controlling a few subnets or a hosting provider cannot eclipse the node by
+// taking most of its peer slots.
+type PeerDiversity struct {
+	maxPerSubnet  int
+	maxPerASN     int
+	asns          *ASNTable
+	ipv4PrefixLen int
+	ipv6PrefixLen int
+}
+
+// PeerDiversityOption sets an optional parameter on the PeerDiversity.
+type PeerDiversityOption func(*PeerDiversity)
+
+// PeerDiversityASN caps the number of peers within an autonomous system, as
+// found in the table, to maxPeers. 0 disables the cap.
+func PeerDiversityASN(table *ASNTable, maxPeers int) PeerDiversityOption {
+	return func(pd *PeerDiversity) { pd.asns, pd.maxPerASN = table, maxPeers }
+}
+
+// PeerDiversityIPv4PrefixLen sets the length of the prefix grouping IPv4
+// addresses into subnets.
+func PeerDiversityIPv4PrefixLen(n int) PeerDiversityOption {
+	return func(pd *PeerDiversity) { pd.ipv4PrefixLen = n }
+}
+
+// PeerDiversityIPv6PrefixLen sets the length of the prefix grouping IPv6
+// addresses into subnets.
+func PeerDiversityIPv6PrefixLen(n int) PeerDiversityOption {
+	return func(pd *PeerDiversity) { pd.ipv6PrefixLen = n }
+}
+
+// NewPeerDiversity returns a PeerDiversity allowing at most maxPerSubnet peers
+// per subnet, or any number if 0. Subnets are /24 for IPv4 and /48 for IPv6
+// addresses unless set otherwise with the options.
+func NewPeerDiversity(maxPerSubnet int, options ...PeerDiversityOption) *PeerDiversity {
+	pd := &PeerDiversity{
+		maxPerSubnet:  maxPerSubnet,
+		ipv4PrefixLen: DefaultSubnetIPv4PrefixLen,
+		ipv6PrefixLen: DefaultSubnetIPv6PrefixLen,
+	}
+	for _, option := range options {
+		option(pd)
+	}
+	return pd
+}
+
+// Allow returns an ErrPeerDiversity if connecting to a peer at the IP would
+// exceed the peers allowed in its subnet or autonomous system, given the IPs
+// of the peers connected or being dialed.
+func (pd *PeerDiversity) Allow(ip net.IP, peerIPs []net.IP) error {
+	if pd.maxPerSubnet > 0 {
+		subnet := pd.Subnet(ip)
+		n := pd.SubnetPeers(subnet, peerIPs)
+		if n >= pd.maxPerSubnet {
+			return ErrPeerDiversity{IP: ip, Group: "subnet " + subnet, Peers: n, Max: pd.maxPerSubnet}
+		}
+	}
+	if pd.maxPerASN > 0 && pd.asns != nil {
+		asn := pd.asns.Lookup(ip)
+		if asn == 0 {
+			return nil
+		}
+		n := countIPs(peerIPs, func(peerIP net.IP) bool { return pd.asns.Lookup(peerIP) == asn })
+		if n >= pd.maxPerASN {
+			return ErrPeerDiversity{IP: ip, Group: fmt.Sprintf("AS%d", asn), Peers: n, Max: pd.maxPerASN}
+		}
+	}
+	return nil
+}
+
+// Subnet returns the subnet of the IP, in CIDR notation.
+func (pd *PeerDiversity) Subnet(ip net.IP) string {
+	return IPSubnet(ip, pd.ipv4PrefixLen, pd.ipv6PrefixLen)
+}
+
+// SubnetPeers returns the number of peer IPs within the subnet.
+func (pd *PeerDiversity) SubnetPeers(subnet string, peerIPs []net.IP) int {
+	return countIPs(peerIPs, func(peerIP net.IP) bool { return pd.Subnet(peerIP) == subnet })
+}
+
+func countIPs(ips []net.IP, match func(net.IP) bool) int {
+	n := 0
+	for _, ip := range ips {
+		if ip != nil && match(ip) {
+			n++
+		}
+	}
+	return n
+}
+
+//-----------------------------------------------------------------------------
+
+// ASNTable maps IP addresses to the autonomous systems announcing them.
+type ASNTable struct {
+	ranges []asnRange // sorted by start, not overlapping
+}
+
+type asnRange struct {
+	start, end net.IP // 16-byte form, inclusive
+	asn        uint32
+}
+
+// LoadASNTable reads an ASN table from the file at path, in the
+// tab-separated format of the ip2asn databases, optionally gzipped:
+//
+//	range_start	range_end	AS_number	country_code	AS_description
+//
+// Ranges not announced by any autonomous system, with AS number 0, are
+// skipped.
+func LoadASNTable(path string) (*ASNTable, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var r io.Reader = f
+	if strings.HasSuffix(path, ".gz") {
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			return nil, fmt.Errorf("reading ASN table %s: %w", path, err)
+		}
+		defer gz.Close()
+		r = gz
+	}
+	table, err := ParseASNTable(r)
+	if err != nil {
+		return nil, fmt.Errorf("reading ASN table %s: %w", path, err)
+	}
+	return table, nil
+}
+
+// ParseASNTable reads an ASN table in the format described in LoadASNTable.
+func ParseASNTable(r io.Reader) (*ASNTable, error) {
+	table := &ASNTable{}
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		fields := strings.Split(text, "\t")
+		if len(fields) < 3 {
+			return nil, fmt.Errorf("line %d: expected at least 3 tab-separated fields, got %d", line, len(fields))
+		}
+		start, end := net.ParseIP(fields[0]), net.ParseIP(fields[1])
+		if start == nil || end == nil {
+			return nil, fmt.Errorf("line %d: invalid range %s - %s", line, fields[0], fields[1])
+		}
+		start, end = start.To16(), end.To16()
+		if bytes.Compare(start, end) > 0 {
+			return nil, fmt.Errorf("line %d: range start %s is after its end %s", line, fields[0], fields[1])
+		}
+		asn, err := strconv.ParseUint(fields[2], 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid AS number %q: %w", line, fields[2], err)
+		}
+		if asn == 0 {
+			continue
+		}
+		table.ranges = append(table.ranges, asnRange{start: start, end: end, asn: uint32(asn)})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(table.ranges, func(i, j int) bool {
+		return bytes.Compare(table.ranges[i].start, table.ranges[j].start) < 0
+	})
+	for i := 1; i < len(table.ranges); i++ {
+		if prev := table.ranges[i-1]; bytes.Compare(prev.end, table.ranges[i].start) >= 0 {
+			return nil, fmt.Errorf("range %v - %v overlaps range %v - %v",
+				prev.start, prev.end, table.ranges[i].start, table.ranges[i].end)
+		}
+	}
+	return table, nil
+}
+
+// Lookup returns the number of the autonomous system announcing the IP, or 0
+// if none is known.
+func (t *ASNTable) Lookup(ip net.IP) uint32 {
+	ip = ip.To16()
+	if ip == nil {
+		return 0
+	}
+	// the first range starting after the IP is right after the only candidate
+	i := sort.Search(len(t.ranges), func(i int) bool {
+		return bytes.Compare(t.ranges[i].start, ip) > 0
+	})
+	if i == 0 {
+		return 0
+	}
+	if r := t.ranges[i-1]; bytes.Compare(ip, r.end) <= 0 {
+		return r.asn
+	}
+	return 0
+}
+
+// Size returns the number of ranges in the table.
+func (t *ASNTable) Size() int {
+	return len(t.ranges)
+}
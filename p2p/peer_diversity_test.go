@@ -0,0 +1,117 @@
+package p2p
+
+import (
+	"compress/gzip"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testASNTable = `# synthetic ip2asn table
+10.1.0.0	10.1.255.255	64500	US	HOSTING-A
+10.2.0.0	10.2.255.255	64500	US	HOSTING-A
+10.3.0.0	10.3.255.255	64501	DE	HOSTING-B
+10.4.0.0	10.4.255.255	0	None	Not routed
+2001:db8::	2001:db8:ffff:ffff:ffff:ffff:ffff:ffff	64502	FR	HOSTING-C
+`
+
+func parseIPs(ips ...string) []net.IP {
+	parsed := make([]net.IP, len(ips))
+	for i, ip := range ips {
+		parsed[i] = net.ParseIP(ip)
+	}
+	return parsed
+}
+
+func TestParseASNTable(t *testing.T) {
+	table, err := ParseASNTable(strings.NewReader(testASNTable))
+	require.NoError(t, err)
+	assert.Equal(t, 4, table.Size())
+
+	testCases := []struct {
+		ip  string
+		asn uint32
+	}{
+		{"10.1.0.0", 64500},
+		{"10.1.42.1", 64500},
+		{"::ffff:10.2.255.255", 64500},
+		{"10.3.0.1", 64501},
+		{"10.4.0.1", 0},
+		{"10.0.255.255", 0},
+		{"192.168.0.1", 0},
+		{"2001:db8:1::1", 64502},
+		{"2001:db9::1", 0},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.asn, table.Lookup(net.ParseIP(tc.ip)), tc.ip)
+	}
+
+	for _, invalid := range []string{
+		"10.1.0.0\t10.1.255.255",
+		"10.1.0.0\tnot an ip\t64500",
+		"10.1.255.255\t10.1.0.0\t64500",
+		"10.1.0.0\t10.1.255.255\tAS64500",
+		"10.1.0.0\t10.1.255.255\t64500\n10.1.128.0\t10.2.0.0\t64501",
+	} {
+		_, err := ParseASNTable(strings.NewReader(invalid))
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestLoadASNTableGzipped(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ip2asn-v4.tsv.gz")
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	gz := gzip.NewWriter(f)
+	_, err = gz.Write([]byte(testASNTable))
+	require.NoError(t, err)
+	require.NoError(t, gz.Close())
+	require.NoError(t, f.Close())
+
+	table, err := LoadASNTable(path)
+	require.NoError(t, err)
+	assert.EqualValues(t, 64501, table.Lookup(net.ParseIP("10.3.1.1")))
+}
+
+func TestPeerDiversityAllowSubnet(t *testing.T) {
+	pd := NewPeerDiversity(2)
+	peers := parseIPs("10.0.0.1", "10.0.0.2", "10.0.1.1", "2001:db8:abcd:1::1")
+
+	err := pd.Allow(net.ParseIP("10.0.0.3"), peers)
+	require.Error(t, err)
+	assert.IsType(t, ErrPeerDiversity{}, err)
+	assert.NoError(t, pd.Allow(net.ParseIP("10.0.1.2"), peers))
+	assert.NoError(t, pd.Allow(net.ParseIP("2001:db8:abcd:2::1"), peers))
+	assert.Error(t, pd.Allow(net.ParseIP("2001:db8:abcd:2::1"), append(peers, net.ParseIP("2001:db8:abcd::1"))))
+
+	// wider subnets
+	pd = NewPeerDiversity(2, PeerDiversityIPv4PrefixLen(16))
+	assert.Error(t, pd.Allow(net.ParseIP("10.0.2.1"), peers))
+
+	// no limit
+	pd = NewPeerDiversity(0)
+	assert.NoError(t, pd.Allow(net.ParseIP("10.0.0.3"), peers))
+	assert.Equal(t, 2, pd.SubnetPeers("10.0.0.0/24", peers))
+}
+
+func TestPeerDiversityAllowASN(t *testing.T) {
+	table, err := ParseASNTable(strings.NewReader(testASNTable))
+	require.NoError(t, err)
+	pd := NewPeerDiversity(0, PeerDiversityASN(table, 2))
+
+	// a skewed set of peers, mostly hosted by a single provider across subnets
+	peers := parseIPs("10.1.0.1", "10.2.0.1", "10.3.0.1", "10.4.0.1", "10.4.0.2", "10.4.0.3")
+
+	err = pd.Allow(net.ParseIP("10.1.7.7"), peers)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "AS64500")
+	assert.NoError(t, pd.Allow(net.ParseIP("10.3.7.7"), peers))
+	// addresses of unknown autonomous systems are not capped
+	assert.NoError(t, pd.Allow(net.ParseIP("10.4.0.4"), peers))
+	assert.NoError(t, pd.Allow(net.ParseIP("192.168.0.1"), peers))
+}
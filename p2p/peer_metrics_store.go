@@ -0,0 +1,225 @@
+package p2p
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	dbm "github.com/cometbft/cometbft-db"
+
+	"github.com/tendermint/tendermint/libs/service"
+	cmtsync "github.com/tendermint/tendermint/libs/sync"
+)
+
+const defaultPeerMetricsSaveInterval = 1 * time.Minute
+
+var peerConnectionStatsKey = []byte("peerConnectionStats")
+
+// PeerConnectionStats holds the cumulative connection statistics of a peer.
+type PeerConnectionStats struct {
+	// Number of connections established with the peer.
+	Established uint64 `json:"established"`
+	// Number of connections with the peer that were closed.
+	Closed uint64 `json:"closed"`
+	// Total time the peer has been connected, in seconds.
+	ConnectedSeconds float64 `json:"connected_seconds"`
+	// Reason of the latest disconnect, see disconnectReasonLabel.
+	LastDisconnectReason string `json:"last_disconnect_reason,omitempty"`
+
+	// the time up to which the connected time of a connected peer has been
+	// accounted for, zero if the peer is not connected
+	accountedUntil time.Time
+}
+
+// PeerMetricsStore reports the connection statistics of every peer, labeled by
+// peer ID, and persists them to a DB so that they are not reset when the node
+// restarts. Since every peer that ever connected adds series to the metrics, it
+// should only be used if the number of distinct peers is reasonably small.
+type PeerMetricsStore struct {
+	service.BaseService
+
+	// Mutex that protects the map and the DB
+	mtx   cmtsync.Mutex
+	peers map[ID]*PeerConnectionStats
+
+	db           dbm.DB
+	metrics      *Metrics
+	saveInterval time.Duration
+}
+
+// NewPeerMetricsStore returns a store that saves the peer connection
+// statistics to db and reports them to metrics. Use Start to load the
+// statistics saved before.
+func NewPeerMetricsStore(db dbm.DB, metrics *Metrics) *PeerMetricsStore {
+	pms := &PeerMetricsStore{
+		peers:        make(map[ID]*PeerConnectionStats),
+		db:           db,
+		metrics:      metrics,
+		saveInterval: defaultPeerMetricsSaveInterval,
+	}
+	pms.BaseService = *service.NewBaseService(nil, "PeerMetricsStore", pms)
+	return pms
+}
+
+// OnStart implements Service by loading the saved statistics and starting the
+// routine periodically saving them.
+func (pms *PeerMetricsStore) OnStart() error {
+	pms.mtx.Lock()
+	defer pms.mtx.Unlock()
+
+	if err := pms.loadFromDB(); err != nil {
+		return err
+	}
+	go pms.saveRoutine()
+	return nil
+}
+
+// OnStop implements Service by saving the statistics a last time.
+func (pms *PeerMetricsStore) OnStop() {
+	pms.mtx.Lock()
+	defer pms.mtx.Unlock()
+
+	pms.saveToDB()
+}
+
+// PeerConnected records that a connection with the peer was established.
+func (pms *PeerMetricsStore) PeerConnected(id ID) {
+	pms.mtx.Lock()
+	defer pms.mtx.Unlock()
+
+	stats := pms.peerStats(id)
+	stats.Established++
+	stats.accountedUntil = time.Now()
+	pms.metrics.PeerConnectionsEstablished.With("peer_id", string(id)).Add(1)
+}
+
+// PeerDisconnected records that the connection with the peer was closed for
+// the given reason, which is nil if the peer was stopped gracefully.
+func (pms *PeerMetricsStore) PeerDisconnected(id ID, reason interface{}) {
+	pms.mtx.Lock()
+	defer pms.mtx.Unlock()
+
+	stats := pms.peerStats(id)
+	pms.accountConnectedTime(id, stats, time.Now())
+	stats.accountedUntil = time.Time{}
+	stats.Closed++
+	pms.metrics.PeerConnectionsClosed.With("peer_id", string(id)).Add(1)
+
+	if stats.LastDisconnectReason != "" {
+		pms.metrics.PeerLastDisconnectReason.With("peer_id", string(id), "reason", stats.LastDisconnectReason).Set(0)
+	}
+	stats.LastDisconnectReason = disconnectReasonLabel(reason)
+	pms.metrics.PeerLastDisconnectReason.With("peer_id", string(id), "reason", stats.LastDisconnectReason).Set(1)
+}
+
+// PeerStats returns a copy of the connection statistics of the peer and
+// whether any connection with the peer was recorded.
+func (pms *PeerMetricsStore) PeerStats(id ID) (PeerConnectionStats, bool) {
+	pms.mtx.Lock()
+	defer pms.mtx.Unlock()
+
+	stats, ok := pms.peers[id]
+	if !ok {
+		return PeerConnectionStats{}, false
+	}
+	return *stats, true
+}
+
+// SaveToDB saves the statistics of all peers to the DB.
+func (pms *PeerMetricsStore) SaveToDB() {
+	pms.mtx.Lock()
+	defer pms.mtx.Unlock()
+
+	pms.saveToDB()
+}
+
+/* Private methods, all assume the mutex has been acquired */
+
+func (pms *PeerMetricsStore) peerStats(id ID) *PeerConnectionStats {
+	stats, ok := pms.peers[id]
+	if !ok {
+		stats = &PeerConnectionStats{}
+		pms.peers[id] = stats
+	}
+	return stats
+}
+
+// accountConnectedTime adds the time the peer has been connected since it was
+// last accounted for to its connected time.
+func (pms *PeerMetricsStore) accountConnectedTime(id ID, stats *PeerConnectionStats, now time.Time) {
+	if stats.accountedUntil.IsZero() {
+		return
+	}
+	seconds := now.Sub(stats.accountedUntil).Seconds()
+	stats.ConnectedSeconds += seconds
+	stats.accountedUntil = now
+	pms.metrics.PeerConnectedSeconds.With("peer_id", string(id)).Add(seconds)
+}
+
+// loadFromDB loads the saved statistics and adds them to the metrics.
+func (pms *PeerMetricsStore) loadFromDB() error {
+	bz, err := pms.db.Get(peerConnectionStatsKey)
+	if err != nil {
+		return err
+	}
+	if bz == nil {
+		return nil
+	}
+
+	peers := make(map[ID]*PeerConnectionStats)
+	if err := json.Unmarshal(bz, &peers); err != nil {
+		return fmt.Errorf("could not unmarshal peer connection stats: %w", err)
+	}
+	for id, stats := range peers {
+		pms.peers[id] = stats
+		pms.metrics.PeerConnectionsEstablished.With("peer_id", string(id)).Add(float64(stats.Established))
+		pms.metrics.PeerConnectionsClosed.With("peer_id", string(id)).Add(float64(stats.Closed))
+		pms.metrics.PeerConnectedSeconds.With("peer_id", string(id)).Add(stats.ConnectedSeconds)
+		if stats.LastDisconnectReason != "" {
+			pms.metrics.PeerLastDisconnectReason.With("peer_id", string(id), "reason", stats.LastDisconnectReason).Set(1)
+		}
+	}
+	return nil
+}
+
+// saveToDB accounts for the connected time of the connected peers and saves
+// the statistics of all peers to the DB.
+func (pms *PeerMetricsStore) saveToDB() {
+	now := time.Now()
+	for id, stats := range pms.peers {
+		pms.accountConnectedTime(id, stats, now)
+	}
+
+	bz, err := json.Marshal(pms.peers)
+	if err != nil {
+		pms.Logger.Error("failed to encode peer connection stats", "err", err)
+		return
+	}
+	if err := pms.db.SetSync(peerConnectionStatsKey, bz); err != nil {
+		pms.Logger.Error("failed to save peer connection stats", "err", err)
+	}
+}
+
+// saveRoutine periodically saves the statistics to the DB.
+func (pms *PeerMetricsStore) saveRoutine() {
+	t := time.NewTicker(pms.saveInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-t.C:
+			pms.SaveToDB()
+		case <-pms.Quit():
+			return
+		}
+	}
+}
+
+// disconnectReasonLabel returns a low cardinality label for the reason a peer
+// was disconnected: "none" if it was stopped gracefully, the type of the
+// reason otherwise.
+func disconnectReasonLabel(reason interface{}) string {
+	if reason == nil {
+		return "none"
+	}
+	return fmt.Sprintf("%T", reason)
+}
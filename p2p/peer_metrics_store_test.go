@@ -0,0 +1,114 @@
+package p2p
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	dbm "github.com/cometbft/cometbft-db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+func TestPeerMetricsStoreBouncingPeer(t *testing.T) {
+	db := dbm.NewMemDB()
+	store := NewPeerMetricsStore(db, NopMetrics())
+	store.SetLogger(log.TestingLogger())
+
+	sw := MakeSwitch(cfg, 1, "testing", "123.123.123", initSwitchFunc, WithPeerMetricsStore(store))
+	require.NoError(t, sw.Start())
+	require.True(t, store.IsRunning())
+
+	rp := &remotePeer{PrivKey: ed25519.GenPrivKey(), Config: cfg}
+	rp.Start()
+	defer rp.Stop()
+
+	// connect and disconnect the peer repeatedly. The remote peer does not
+	// respond, so the connection may fail before it is stopped.
+	const bounces = 3
+	for i := 0; i < bounces; i++ {
+		p, err := sw.transport.Dial(*rp.Addr(), peerConfig{
+			chDescs:      sw.chDescs,
+			onPeerError:  sw.StopPeerForError,
+			isPersistent: sw.IsPeerPersistent,
+			reactorsByCh: sw.reactorsByCh,
+		})
+		require.NoError(t, err)
+		require.NoError(t, sw.addPeer(p))
+		require.NotNil(t, sw.Peers().Get(rp.ID()))
+
+		time.Sleep(10 * time.Millisecond)
+		sw.StopPeerForError(p, errors.New("bounce"))
+		require.Nil(t, sw.Peers().Get(rp.ID()))
+	}
+
+	stats, ok := store.PeerStats(rp.ID())
+	require.True(t, ok)
+	assert.EqualValues(t, bounces, stats.Established)
+	assert.EqualValues(t, bounces, stats.Closed)
+	assert.Greater(t, stats.ConnectedSeconds, 0.0)
+	assert.NotEmpty(t, stats.LastDisconnectReason)
+
+	// stopping the switch stops the store, which saves the stats
+	require.NoError(t, sw.Stop())
+	require.False(t, store.IsRunning())
+
+	// simulate a restart
+	restarted := NewPeerMetricsStore(db, NopMetrics())
+	restarted.SetLogger(log.TestingLogger())
+	require.NoError(t, restarted.Start())
+	t.Cleanup(func() {
+		if err := restarted.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	loaded, ok := restarted.PeerStats(rp.ID())
+	require.True(t, ok)
+	assert.Equal(t, stats, loaded)
+
+	// the counters keep increasing from the loaded values
+	restarted.PeerConnected(rp.ID())
+	restarted.PeerDisconnected(rp.ID(), errors.New("bounce"))
+	stats, _ = restarted.PeerStats(rp.ID())
+	assert.EqualValues(t, bounces+1, stats.Established)
+	assert.EqualValues(t, bounces+1, stats.Closed)
+	assert.Equal(t, "*errors.errorString", stats.LastDisconnectReason)
+
+	restarted.PeerConnected(rp.ID())
+	restarted.PeerDisconnected(rp.ID(), nil)
+	stats, _ = restarted.PeerStats(rp.ID())
+	assert.Equal(t, "none", stats.LastDisconnectReason)
+}
+
+func TestPeerMetricsStoreSavesConnectedTime(t *testing.T) {
+	db := dbm.NewMemDB()
+	store := NewPeerMetricsStore(db, NopMetrics())
+	store.SetLogger(log.TestingLogger())
+	require.NoError(t, store.Start())
+
+	// the connected time of a peer that is still connected is saved as well,
+	// so that it is not lost if the node crashes
+	id := ID("peer")
+	store.PeerConnected(id)
+	time.Sleep(10 * time.Millisecond)
+	store.SaveToDB()
+
+	restarted := NewPeerMetricsStore(db, NopMetrics())
+	require.NoError(t, restarted.Start())
+	loaded, ok := restarted.PeerStats(id)
+	require.True(t, ok)
+	assert.EqualValues(t, 1, loaded.Established)
+	assert.Zero(t, loaded.Closed)
+	assert.GreaterOrEqual(t, loaded.ConnectedSeconds, 0.01)
+
+	require.NoError(t, store.Stop())
+	require.NoError(t, restarted.Stop())
+
+	// corrupted stats fail the start
+	require.NoError(t, db.Set(peerConnectionStatsKey, []byte("{")))
+	assert.Error(t, NewPeerMetricsStore(db, NopMetrics()).Start())
+}
@@ -0,0 +1,145 @@
+package p2p
+
+import (
+	"sort"
+
+	cmtsync "github.com/tendermint/tendermint/libs/sync"
+)
+
+// NodeRole is the role of a node in the network. It sets the default lower
+// bound of the outbound peer target and how the target follows the size of the
+// network, see PeerTargeter.
+type NodeRole string
+
+const (
+	// NodeRoleFull is a full node, which connects to as many peers as the
+	// network allows, within its bounds.
+	NodeRoleFull NodeRole = "full"
+	// NodeRoleValidator is a validator, which prefers few stable peers.
+	NodeRoleValidator NodeRole = "validator"
+	// NodeRoleSeed is a seed, which crawls the network and favors many
+	// ephemeral connections.
+	NodeRoleSeed NodeRole = "seed"
+)
+
+// the lower bound of the outbound peer target of a validator, unless set
+const defaultValidatorMinOutboundPeers = 3
+
+// DefaultMinOutboundPeers returns the default lower bound of the outbound peer
+// target of a node of the given role, given the upper bound maxOutbound.
+func DefaultMinOutboundPeers(role NodeRole, maxOutbound int) int {
+	switch role {
+	case NodeRoleSeed:
+		return maxOutbound
+	case NodeRoleValidator:
+		if maxOutbound < defaultValidatorMinOutboundPeers {
+			return maxOutbound
+		}
+		return defaultValidatorMinOutboundPeers
+	default:
+		return (maxOutbound + 1) / 2
+	}
+}
+
+// PeerTargets are the numbers of peers the switch aims for.
+type PeerTargets struct {
+	Role NodeRole `json:"role"`
+	// Dynamic is true if the outbound target follows the size of the network.
+	Dynamic     bool `json:"dynamic"`
+	MaxInbound  int  `json:"max_inbound"`
+	MinOutbound int  `json:"min_outbound"`
+	MaxOutbound int  `json:"max_outbound"`
+	// Outbound is the current outbound target, within MinOutbound and
+	// MaxOutbound.
+	Outbound int `json:"outbound"`
+	// NetworkSize is the latest number of other nodes observed in the network,
+	// or 0 if none has been observed yet.
+	NetworkSize int `json:"network_size"`
+}
+
+// PeerTargeter adjusts the outbound peer target of the switch between its
+// bounds, as the observed size of the network changes: a network with fewer
+// nodes than the upper bound is fully connected, while a validator does not go
+// above the middle of its bounds, to keep few stable peers. The target is the
+// upper bound until the size of the network is observed.
+type PeerTargeter struct {
+	mtx     cmtsync.Mutex
+	targets PeerTargets
+}
+
+// NewPeerTargeter returns a PeerTargeter for a node of the given role, whose
+// outbound target is within [minOutbound, maxOutbound]. minOutbound is capped
+// to maxOutbound.
+func NewPeerTargeter(role NodeRole, maxInbound, minOutbound, maxOutbound int) *PeerTargeter {
+	if minOutbound > maxOutbound {
+		minOutbound = maxOutbound
+	}
+	return &PeerTargeter{
+		targets: PeerTargets{
+			Role:        role,
+			Dynamic:     true,
+			MaxInbound:  maxInbound,
+			MinOutbound: minOutbound,
+			MaxOutbound: maxOutbound,
+			Outbound:    maxOutbound,
+		},
+	}
+}
+
+// ObserveNetworkSize updates the outbound target given the number of other
+// nodes observed in the network, and returns the new target.
+func (pt *PeerTargeter) ObserveNetworkSize(n int) int {
+	pt.mtx.Lock()
+	defer pt.mtx.Unlock()
+	if n <= 0 {
+		return pt.targets.Outbound
+	}
+	pt.targets.NetworkSize = n
+
+	upper := pt.targets.MaxOutbound
+	if pt.targets.Role == NodeRoleValidator {
+		upper = (pt.targets.MinOutbound + pt.targets.MaxOutbound + 1) / 2
+	}
+	target := n
+	if target > upper {
+		target = upper
+	}
+	if target < pt.targets.MinOutbound {
+		target = pt.targets.MinOutbound
+	}
+	pt.targets.Outbound = target
+	return target
+}
+
+// Outbound returns the current outbound target.
+func (pt *PeerTargeter) Outbound() int {
+	pt.mtx.Lock()
+	defer pt.mtx.Unlock()
+	return pt.targets.Outbound
+}
+
+// Targets returns the current targets.
+func (pt *PeerTargeter) Targets() PeerTargets {
+	pt.mtx.Lock()
+	defer pt.mtx.Unlock()
+	return pt.targets
+}
+
+// surplusPeers returns the peers to evict to get down to target, the ones with
+// the lowest reputation first: the peers that were never marked as good, then
+// the most recently connected ones.
+func surplusPeers(peers []Peer, target int, isGood func(Peer) bool) []Peer {
+	if len(peers) <= target {
+		return nil
+	}
+	sorted := make([]Peer, len(peers))
+	copy(sorted, peers)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		gi, gj := isGood(sorted[i]), isGood(sorted[j])
+		if gi != gj {
+			return !gi
+		}
+		return sorted[i].Status().Duration < sorted[j].Status().Duration
+	})
+	return sorted[:len(peers)-target]
+}
@@ -0,0 +1,134 @@
+package p2p
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/libs/service"
+)
+
+// outboundMockPeer is an outbound mockPeer connected for a given duration
+type outboundMockPeer struct {
+	*mockPeer
+	connected time.Duration
+}
+
+func (mp *outboundMockPeer) IsOutbound() bool   { return true }
+func (mp *outboundMockPeer) IsPersistent() bool { return false }
+func (mp *outboundMockPeer) Status() ConnectionStatus {
+	return ConnectionStatus{Duration: mp.connected}
+}
+
+func newOutboundMockPeer(t *testing.T, i int) *outboundMockPeer {
+	mp := newMockPeer(net.IP{127, 0, 1, byte(i)})
+	mp.BaseService = *service.NewBaseService(nil, "MockPeer", mp)
+	require.NoError(t, mp.Start())
+	return &outboundMockPeer{mockPeer: mp, connected: time.Duration(i) * time.Minute}
+}
+
+func TestDefaultMinOutboundPeers(t *testing.T) {
+	assert.Equal(t, 10, DefaultMinOutboundPeers(NodeRoleSeed, 10))
+	assert.Equal(t, 3, DefaultMinOutboundPeers(NodeRoleValidator, 10))
+	assert.Equal(t, 2, DefaultMinOutboundPeers(NodeRoleValidator, 2))
+	assert.Equal(t, 5, DefaultMinOutboundPeers(NodeRoleFull, 10))
+	assert.Equal(t, 6, DefaultMinOutboundPeers(NodeRoleFull, 11))
+}
+
+func TestPeerTargeterObserveNetworkSize(t *testing.T) {
+	testCases := []struct {
+		role    NodeRole
+		sizes   []int
+		targets []int
+	}{
+		// a growing then shrinking network is fully connected within bounds
+		{NodeRoleFull, []int{1, 4, 8, 20, 100, 8, 2}, []int{4, 4, 8, 12, 12, 8, 4}},
+		// a validator does not go above the middle of its bounds
+		{NodeRoleValidator, []int{1, 6, 8, 100, 5}, []int{4, 6, 8, 8, 5}},
+		{NodeRoleSeed, []int{2, 100}, []int{4, 12}},
+	}
+	for _, tc := range testCases {
+		pt := NewPeerTargeter(tc.role, 40, 4, 12)
+		assert.Equal(t, 12, pt.Outbound(), "the target is the upper bound until the network is observed")
+		for i, size := range tc.sizes {
+			assert.Equal(t, tc.targets[i], pt.ObserveNetworkSize(size), "%s, network of %d", tc.role, size)
+		}
+		// an unknown size leaves the target as is
+		assert.Equal(t, tc.targets[len(tc.targets)-1], pt.ObserveNetworkSize(0))
+		targets := pt.Targets()
+		assert.True(t, targets.Dynamic)
+		assert.Equal(t, tc.sizes[len(tc.sizes)-1], targets.NetworkSize)
+	}
+
+	// the lower bound is capped to the upper bound
+	pt := NewPeerTargeter(NodeRoleFull, 40, 20, 10)
+	assert.Equal(t, 10, pt.ObserveNetworkSize(3))
+}
+
+func TestSwitchEvictsSurplusPeersGradually(t *testing.T) {
+	targeter := NewPeerTargeter(NodeRoleFull, 40, 2, 8)
+	sw := MakeSwitch(config.TestP2PConfig(), 1, "testing", "123.123.123", initSwitchFunc,
+		SwitchPeerTargeter(targeter))
+	require.NoError(t, sw.Start())
+	t.Cleanup(func() {
+		if err := sw.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	// the network grows to 8 other nodes, all connected
+	sw.ReportNetworkSize(8)
+	require.Equal(t, 8, sw.OutboundPeerTarget())
+	peers := make([]*outboundMockPeer, 8)
+	for i := range peers {
+		peers[i] = newOutboundMockPeer(t, i+1)
+		require.NoError(t, sw.peers.Add(peers[i]))
+	}
+	// the oldest and the youngest peers contributed to consensus
+	sw.MarkPeerAsGood(peers[7])
+	sw.MarkPeerAsGood(peers[0])
+
+	sw.evictSurplusPeer()
+	assert.Equal(t, 8, sw.Peers().Size(), "no surplus to evict")
+
+	// the network shrinks to 5 other nodes
+	sw.ReportNetworkSize(5)
+	assert.Equal(t, 5, sw.PeerTargets().Outbound)
+
+	// the surplus peers are evicted one at a time, the youngest of the peers
+	// that are not good first
+	for _, i := range []int{1, 2, 3} {
+		sw.evictSurplusPeer()
+		assert.False(t, sw.Peers().Has(peers[i].ID()), "peer %d", i)
+	}
+	assert.Equal(t, 5, sw.Peers().Size())
+	sw.evictSurplusPeer()
+	assert.Equal(t, 5, sw.Peers().Size())
+
+	// down to the lower bound, good peers are evicted last
+	sw.ReportNetworkSize(1)
+	for i := 0; i < 3; i++ {
+		sw.evictSurplusPeer()
+	}
+	assert.Equal(t, 2, sw.Peers().Size())
+	assert.True(t, sw.Peers().Has(peers[0].ID()))
+	assert.True(t, sw.Peers().Has(peers[7].ID()))
+}
+
+func TestSwitchStaticPeerTargets(t *testing.T) {
+	cfg := config.TestP2PConfig()
+	sw := MakeSwitch(cfg, 1, "testing", "123.123.123", initSwitchFunc)
+
+	sw.ReportNetworkSize(3)
+	assert.Equal(t, cfg.MaxNumOutboundPeers, sw.OutboundPeerTarget())
+	assert.Equal(t, PeerTargets{
+		MaxInbound:  cfg.MaxNumInboundPeers,
+		MinOutbound: cfg.MaxNumOutboundPeers,
+		MaxOutbound: cfg.MaxNumOutboundPeers,
+		Outbound:    cfg.MaxNumOutboundPeers,
+	}, sw.PeerTargets())
+}
@@ -155,6 +155,7 @@ type remotePeer struct {
 	channels   bytes.HexBytes
 	listenAddr string
 	listener   net.Listener
+	network    string
 }
 
 func (rp *remotePeer) Addr() *NetAddress {
@@ -230,11 +231,15 @@ func (rp *remotePeer) accept() {
 }
 
 func (rp *remotePeer) nodeInfo() NodeInfo {
+	network := rp.network
+	if network == "" {
+		network = "testing"
+	}
 	return DefaultNodeInfo{
 		ProtocolVersion: defaultProtocolVersion,
 		DefaultNodeID:   rp.Addr().ID,
 		ListenAddr:      rp.listener.Addr().String(),
-		Network:         "testing",
+		Network:         network,
 		Version:         "1.2.3-rc0-deadbeef",
 		Channels:        rp.channels,
 		Moniker:         "remote_peer",
@@ -44,6 +44,9 @@ type AddrBook interface {
 
 	AddPrivateIDs([]string)
 
+	// Set our network, the addresses on other networks are not picked
+	SetNetwork(network string)
+
 	// Add and remove an address
 	AddAddress(addr *p2p.NetAddress, src *p2p.NetAddress) error
 	RemoveAddress(*p2p.NetAddress)
@@ -64,6 +67,7 @@ type AddrBook interface {
 	MarkGood(p2p.ID)
 	MarkAttempt(*p2p.NetAddress)
 	MarkBad(*p2p.NetAddress, time.Duration) // Move peer to bad peers list
+	MarkNetwork(*p2p.NetAddress, string)    // Record the network reported in a handshake
 	// Add bad peers back to addrBook
 	ReinstateBadPeers()
 
@@ -94,11 +98,13 @@ type addrBook struct {
 	ourAddrs   map[string]struct{}
 	privateIDs map[p2p.ID]struct{}
 	addrLookup map[p2p.ID]*knownAddress // new & old
+	subnets    map[string]int           // number of addresses in addrLookup per subnet
 	badPeers   map[p2p.ID]*knownAddress // blacklisted peers
 	bucketsOld []map[string]*knownAddress
 	bucketsNew []map[string]*knownAddress
 	nOld       int
 	nNew       int
+	network    string // ours, see SetNetwork
 
 	// immutable after creation
 	filePath          string
@@ -123,6 +129,7 @@ func NewAddrBook(filePath string, routabilityStrict bool) AddrBook {
 		ourAddrs:          make(map[string]struct{}),
 		privateIDs:        make(map[p2p.ID]struct{}),
 		addrLookup:        make(map[p2p.ID]*knownAddress),
+		subnets:           make(map[string]int),
 		badPeers:          make(map[p2p.ID]*knownAddress),
 		filePath:          filePath,
 		routabilityStrict: routabilityStrict,
@@ -206,6 +213,17 @@ func (a *addrBook) AddPrivateIDs(ids []string) {
 	}
 }
 
+// SetNetwork implements AddrBook - it sets our network. The addresses whose
+// peer reported another network in the last handshake are never picked nor
+// shared, and are evicted when loading the address book. It must be called
+// before the address book is started to evict the addresses on load.
+func (a *addrBook) SetNetwork(network string) {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	a.network = network
+}
+
 // AddAddress implements AddrBook
 // Add address to a "new" bucket. If it's already in one, only add it probabilistically.
 // Returns error if the addr is non-routable. Does not add self.
@@ -269,6 +287,11 @@ func (a *addrBook) Empty() bool {
 // and determines how biased we are to pick an address from a new bucket.
 // PickAddress returns nil if the AddrBook is empty or if we try to pick
 // from an empty bucket.
+//
+// To bias the selection towards under-represented subnets, a few addresses
+// are drawn and the one whose subnet has the fewest addresses in the book is
+// returned, so that an attacker filling the book with addresses of a few
+// subnets is less likely to be dialed.
 func (a *addrBook) PickAddress(biasTowardsNewAddrs int) *p2p.NetAddress {
 	a.mtx.Lock()
 	defer a.mtx.Unlock()
@@ -287,6 +310,19 @@ func (a *addrBook) PickAddress(biasTowardsNewAddrs int) *p2p.NetAddress {
 		biasTowardsNewAddrs = 0
 	}
 
+	var picked *p2p.NetAddress
+	for i := 0; i < pickAddressCandidates; i++ {
+		addr := a.pickAddress(biasTowardsNewAddrs)
+		if addr != nil && (picked == nil || a.subnets[addrSubnet(addr)] < a.subnets[addrSubnet(picked)]) {
+			picked = addr
+		}
+	}
+	return picked
+}
+
+// pickAddress picks a random address from an old or new bucket, see
+// PickAddress. The caller must hold mtx and check the book is not empty.
+func (a *addrBook) pickAddress(biasTowardsNewAddrs int) *p2p.NetAddress {
 	// Bias between new and old addresses.
 	oldCorrelation := math.Sqrt(float64(a.nOld)) * (100.0 - float64(biasTowardsNewAddrs))
 	newCorrelation := math.Sqrt(float64(a.nNew)) * float64(biasTowardsNewAddrs)
@@ -310,6 +346,9 @@ func (a *addrBook) PickAddress(biasTowardsNewAddrs int) *p2p.NetAddress {
 	randIndex := a.rand.Intn(len(bucket))
 	for _, ka := range bucket {
 		if randIndex == 0 {
+			if ka.isOnOtherNetwork(a.network) {
+				return nil
+			}
 			return ka.Addr
 		}
 		randIndex--
@@ -347,6 +386,30 @@ func (a *addrBook) MarkAttempt(addr *p2p.NetAddress) {
 	ka.markAttempt()
 }
 
+// MarkNetwork implements AddrBook - it records the network reported by the
+// peer in a handshake. The address is evicted once its peer reports a network
+// other than ours in maxNetworkMismatches consecutive handshakes.
+func (a *addrBook) MarkNetwork(addr *p2p.NetAddress, network string) {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	ka := a.addrLookup[addr.ID]
+	if ka == nil {
+		return
+	}
+	ka.Network = network
+	if !ka.isOnOtherNetwork(a.network) {
+		ka.NetworkMismatches = 0
+		return
+	}
+	ka.NetworkMismatches++
+	if ka.NetworkMismatches >= maxNetworkMismatches {
+		a.Logger.Info("Evicting address on another network",
+			"addr", addr, "network", network, "ourNetwork", a.network)
+		a.removeAddress(addr)
+	}
+}
+
 // MarkBad implements AddrBook. Kicks address out from book, places
 // the address in the badPeers pool.
 func (a *addrBook) MarkBad(addr *p2p.NetAddress, banTime time.Duration) {
@@ -407,12 +470,14 @@ func (a *addrBook) GetSelection() []*p2p.NetAddress {
 
 	// XXX: instead of making a list of all addresses, shuffling, and slicing a random chunk,
 	// could we just select a random numAddresses of indexes?
-	allAddr := make([]*p2p.NetAddress, bookSize)
-	i := 0
+	allAddr := make([]*p2p.NetAddress, 0, bookSize)
 	for _, ka := range a.addrLookup {
-		allAddr[i] = ka.Addr
-		i++
+		if ka.isOnOtherNetwork(a.network) {
+			continue
+		}
+		allAddr = append(allAddr, ka.Addr)
 	}
+	numAddresses = cmtmath.MinInt(numAddresses, len(allAddr))
 
 	// Fisher-Yates shuffle the array. We only need to do the first
 	// `numAddresses' since we are throwing the rest.
@@ -551,7 +616,7 @@ func (a *addrBook) addToNewBucket(ka *knownAddress, bucketIdx int) error {
 	}
 
 	// Add it to addrLookup
-	a.addrLookup[ka.ID()] = ka
+	a.addToLookup(ka)
 	return nil
 }
 
@@ -587,7 +652,7 @@ func (a *addrBook) addToOldBucket(ka *knownAddress, bucketIdx int) bool {
 	}
 
 	// Ensure in addrLookup
-	a.addrLookup[ka.ID()] = ka
+	a.addToLookup(ka)
 
 	return true
 }
@@ -605,7 +670,7 @@ func (a *addrBook) removeFromBucket(ka *knownAddress, bucketType byte, bucketIdx
 		} else {
 			a.nOld--
 		}
-		delete(a.addrLookup, ka.ID())
+		a.removeFromLookup(ka)
 	}
 }
 
@@ -620,7 +685,38 @@ func (a *addrBook) removeFromAllBuckets(ka *knownAddress) {
 	} else {
 		a.nOld--
 	}
-	delete(a.addrLookup, ka.ID())
+	a.removeFromLookup(ka)
+}
+
+// addToLookup adds ka to addrLookup, replacing any address with the same ID,
+// and keeps the number of addresses per subnet up to date.
+func (a *addrBook) addToLookup(ka *knownAddress) {
+	if prev, ok := a.addrLookup[ka.ID()]; ok {
+		a.uncountSubnet(prev.Addr)
+	}
+	a.addrLookup[ka.ID()] = ka
+	a.subnets[addrSubnet(ka.Addr)]++
+}
+
+func (a *addrBook) removeFromLookup(ka *knownAddress) {
+	if prev, ok := a.addrLookup[ka.ID()]; ok {
+		a.uncountSubnet(prev.Addr)
+		delete(a.addrLookup, ka.ID())
+	}
+}
+
+func (a *addrBook) uncountSubnet(addr *p2p.NetAddress) {
+	subnet := addrSubnet(addr)
+	if a.subnets[subnet] <= 1 {
+		delete(a.subnets, subnet)
+	} else {
+		a.subnets[subnet]--
+	}
+}
+
+// addrSubnet returns the subnet of the address, /24 for IPv4 and /48 for IPv6.
+func addrSubnet(addr *p2p.NetAddress) string {
+	return p2p.IPSubnet(addr.IP, p2p.DefaultSubnetIPv4PrefixLen, p2p.DefaultSubnetIPv6PrefixLen)
 }
 
 //----------------------------------------------------------
@@ -713,12 +809,15 @@ func (a *addrBook) randomPickAddresses(bucketType byte, num int) []*p2p.NetAddre
 	addresses := make([]*knownAddress, 0, total)
 	for _, bucket := range buckets {
 		for _, ka := range bucket {
+			if ka.isOnOtherNetwork(a.network) {
+				continue
+			}
 			addresses = append(addresses, ka)
 		}
 	}
 	selection := make([]*p2p.NetAddress, 0, num)
 	chosenSet := make(map[string]bool, num)
-	rand.Shuffle(total, func(i, j int) {
+	rand.Shuffle(len(addresses), func(i, j int) {
 		addresses[i], addresses[j] = addresses[j], addresses[i]
 	})
 	for _, addr := range addresses {
@@ -57,6 +57,50 @@ func TestAddrBookPickAddress(t *testing.T) {
 	assert.Nil(t, addr, "did not expected an address")
 }
 
+func TestAddrBookPickAddressSkewedSubnets(t *testing.T) {
+	fname := createTempFileName("addrbook_test")
+	defer deleteTempFile(fname)
+
+	book := NewAddrBook(fname, true).(*addrBook)
+	book.SetLogger(log.TestingLogger())
+
+	// 90% of the addresses are in a single subnet, e.g. filled in by an attacker
+	addAddr := func(ip string) *p2p.NetAddress {
+		addr := p2p.NewNetAddressIPPort(net.ParseIP(ip), 26656)
+		addr.ID = p2p.ID(hex.EncodeToString(cmtrand.Bytes(p2p.IDByteLength)))
+		require.NoError(t, book.AddAddress(addr, randIPv4Address(t)))
+		return addr
+	}
+	for i := 1; i <= 90; i++ {
+		addAddr(fmt.Sprintf("45.1.2.%d", i))
+	}
+	var diverse []*p2p.NetAddress
+	for i := 1; i <= 10; i++ {
+		diverse = append(diverse, addAddr(fmt.Sprintf("45.%d.1.1", 10+i)))
+	}
+	require.Equal(t, 100, book.Size())
+	assert.Equal(t, 90, book.subnets["45.1.2.0/24"])
+	assert.Len(t, book.subnets, 11)
+
+	skewed := 0
+	const picks = 1000
+	for i := 0; i < picks; i++ {
+		addr := book.PickAddress(100)
+		require.NotNil(t, addr)
+		if addrSubnet(addr) == "45.1.2.0/24" {
+			skewed++
+		}
+	}
+	// uniform picks would land in the subnet 90% of the time
+	assert.Less(t, float64(skewed)/picks, 0.75)
+
+	// the subnets are counted as addresses are removed
+	book.RemoveAddress(diverse[0])
+	assert.Len(t, book.subnets, 10)
+	book.MarkGood(diverse[1].ID)
+	assert.Equal(t, 1, book.subnets[addrSubnet(diverse[1])])
+}
+
 func TestAddrBookSaveLoad(t *testing.T) {
 	fname := createTempFileName("addrbook_test")
 	defer deleteTempFile(fname)
@@ -92,6 +136,76 @@ func TestAddrBookSaveLoad(t *testing.T) {
 	assert.Equal(t, 100, book.Size())
 }
 
+func TestAddrBookNetworkSegregation(t *testing.T) {
+	fname := createTempFileName("addrbook_test")
+	defer deleteTempFile(fname)
+
+	book := NewAddrBook(fname, true)
+	book.SetLogger(log.TestingLogger())
+	book.SetNetwork("mainnet")
+
+	// addresses of peers on our network, on another network, and not
+	// handshaked yet
+	randAddrs := randNetAddressPairs(t, 3)
+	for _, addrSrc := range randAddrs {
+		require.NoError(t, book.AddAddress(addrSrc.addr, addrSrc.src))
+	}
+	ours, other, unknown := randAddrs[0].addr, randAddrs[1].addr, randAddrs[2].addr
+	book.MarkNetwork(ours, "mainnet")
+	book.MarkNetwork(other, "testnet")
+
+	// the address on another network is never picked nor shared
+	for i := 0; i < 100; i++ {
+		assert.NotEqual(t, other, book.PickAddress(50))
+	}
+	assert.ElementsMatch(t, []*p2p.NetAddress{ours, unknown}, book.GetSelection())
+	assert.ElementsMatch(t, []*p2p.NetAddress{ours, unknown}, book.GetSelectionWithBias(30))
+	assert.Equal(t, 3, book.Size())
+
+	// the network is saved, and the address on another network evicted when
+	// loading
+	book.Save()
+	loaded := NewAddrBook(fname, true)
+	loaded.SetLogger(log.TestingLogger())
+	loaded.SetNetwork("mainnet")
+	require.NoError(t, loaded.Start())
+	t.Cleanup(func() { _ = loaded.Stop() })
+	assert.Equal(t, 2, loaded.Size())
+	assert.False(t, loaded.HasAddress(other))
+	assert.Equal(t, "mainnet", loaded.(*addrBook).addrLookup[ours.ID].Network)
+
+	// the address on another network is evicted after repeated handshakes,
+	// unless the peer moves back to our network in between
+	book.MarkNetwork(other, "testnet")
+	book.MarkNetwork(other, "mainnet")
+	book.MarkNetwork(other, "testnet")
+	book.MarkNetwork(other, "testnet")
+	assert.True(t, book.HasAddress(other))
+	book.MarkNetwork(other, "testnet")
+	assert.False(t, book.HasAddress(other))
+	assert.Equal(t, 2, book.Size())
+}
+
+func TestAddrBookLoadWithoutNetwork(t *testing.T) {
+	fname := createTempFileName("addrbook_test")
+	defer deleteTempFile(fname)
+
+	// an address book saved before the networks were recorded
+	addr := randIPv4Address(t)
+	content := fmt.Sprintf(`{"key": "0123456789abcdef01234567", "addrs": [{"addr": {"id": %q, "ip": %q, "port": %d},
+		"src": {"id": %q, "ip": %q, "port": %d}, "buckets": [0], "attempts": 0, "bucket_type": 1}]}`,
+		addr.ID, addr.IP, addr.Port, addr.ID, addr.IP, addr.Port)
+	require.NoError(t, os.WriteFile(fname, []byte(content), 0o600))
+
+	book := NewAddrBook(fname, true)
+	book.SetLogger(log.TestingLogger())
+	book.SetNetwork("mainnet")
+	require.NoError(t, book.Start())
+	t.Cleanup(func() { _ = book.Stop() })
+	assert.True(t, book.HasAddress(addr))
+	assert.Equal(t, addr, book.PickAddress(100))
+}
+
 func TestAddrBookLookup(t *testing.T) {
 	fname := createTempFileName("addrbook_test")
 	defer deleteTempFile(fname)
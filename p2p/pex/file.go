@@ -68,11 +68,16 @@ func (a *addrBook) loadFromFile(filePath string) bool {
 	a.key = aJSON.Key
 	// Restore .bucketsNew & .bucketsOld
 	for _, ka := range aJSON.Addrs {
+		if ka.isOnOtherNetwork(a.network) {
+			a.Logger.Info("Evicting address on another network",
+				"addr", ka.Addr, "network", ka.Network, "ourNetwork", a.network)
+			continue
+		}
 		for _, bucketIndex := range ka.Buckets {
 			bucket := a.getBucket(ka.BucketType, bucketIndex)
 			bucket[ka.Addr.String()] = ka
 		}
-		a.addrLookup[ka.ID()] = ka
+		a.addToLookup(ka)
 		if ka.BucketType == bucketTypeNew {
 			a.nNew++
 		} else {
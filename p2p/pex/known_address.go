@@ -17,6 +17,12 @@ type knownAddress struct {
 	LastAttempt time.Time       `json:"last_attempt"`
 	LastSuccess time.Time       `json:"last_success"`
 	LastBanTime time.Time       `json:"last_ban_time"`
+	// Network is the network reported by the peer in the last handshake, or
+	// empty if unknown.
+	Network string `json:"network,omitempty"`
+	// NetworkMismatches counts the consecutive handshakes in which the peer
+	// reported a different network than ours.
+	NetworkMismatches int32 `json:"network_mismatches,omitempty"`
 }
 
 func newKnownAddress(addr *p2p.NetAddress, src *p2p.NetAddress) *knownAddress {
@@ -55,6 +61,12 @@ func (ka *knownAddress) markGood() {
 	ka.LastSuccess = now
 }
 
+// isOnOtherNetwork returns true if the peer reported a network other than the
+// given one in the last handshake.
+func (ka *knownAddress) isOnOtherNetwork(network string) bool {
+	return ka.Network != "" && network != "" && ka.Network != network
+}
+
 func (ka *knownAddress) ban(banTime time.Duration) {
 	if ka.LastBanTime.Before(time.Now().Add(banTime)) {
 		ka.LastBanTime = time.Now().Add(banTime)
@@ -1,6 +1,10 @@
 package pex
 
-import "time"
+import (
+	"time"
+
+	tmp2p "github.com/tendermint/tendermint/proto/tendermint/p2p"
+)
 
 const (
 	// addresses under which the address manager will claim to need more addresses.
@@ -37,6 +41,13 @@ const (
 	// tries without a single success before we assume an address is bad.
 	numRetries = 3
 
+	// handshakes revealing a different network before we evict an address.
+	maxNetworkMismatches = 3
+
+	// addresses drawn by PickAddress, which returns the one in the subnet with
+	// the fewest addresses.
+	pickAddressCandidates = 5
+
 	// max failures we will accept without a success before considering an address bad.
 	maxFailures = 10 // ?
 
@@ -51,5 +62,5 @@ const (
 
 	// max addresses returned by GetSelection
 	// NOTE: this must match "maxMsgSize"
-	maxGetSelection = 250
+	maxGetSelection = tmp2p.MaxPexAddrs
 )
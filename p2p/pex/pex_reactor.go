@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/gogo/protobuf/proto"
@@ -96,6 +97,9 @@ type Reactor struct {
 
 	attemptsToDial sync.Map // address (string) -> {number of attempts (int), last time dialed (time.Time)}
 
+	// number of addresses in the latest PEX response, see networkSize
+	lastReceivedAddrs atomic.Int64
+
 	// seed/crawled mode fields
 	crawlPeerInfos map[p2p.ID]crawlPeerInfo
 }
@@ -375,6 +379,7 @@ func (r *Reactor) ReceiveAddrs(addrs []*p2p.NetAddress, src Peer) error {
 		return ErrUnsolicitedList
 	}
 	r.requestsSent.Delete(id)
+	r.lastReceivedAddrs.Store(int64(len(addrs)))
 
 	srcAddr, err := src.NodeInfo().NetAddress()
 	if err != nil {
@@ -464,15 +469,28 @@ func (r *Reactor) ensurePeersRoutine() {
 	}
 }
 
+// networkSize estimates the number of other nodes in the network from the
+// addresses we know of and the number of addresses the latest peer asked
+// reported. Both are lower bounds, so the larger is used.
+func (r *Reactor) networkSize() int {
+	n := r.book.Size()
+	if reported := int(r.lastReceivedAddrs.Load()); reported > n {
+		n = reported
+	}
+	return n
+}
+
 // ensurePeers ensures that sufficient peers are connected. (once)
 //
 // heuristic that we haven't perfected yet, or, perhaps is manually edited by
 // the node operator. It should not be used to compute what addresses are
 // already connected or not.
 func (r *Reactor) ensurePeers() {
+	r.Switch.ReportNetworkSize(r.networkSize())
+
 	var (
 		out, in, dial = r.Switch.NumPeers()
-		numToDial     = r.Switch.MaxNumOutboundPeers() - (out + dial)
+		numToDial     = r.Switch.OutboundPeerTarget() - (out + dial)
 	)
 	r.Logger.Info(
 		"Ensure peers",
@@ -506,6 +524,11 @@ func (r *Reactor) ensurePeers() {
 		if r.Switch.IsDialingOrExistingAddress(try) {
 			continue
 		}
+		// skip the addresses in subnets or autonomous systems we already have
+		// enough peers in, leaving room for more diverse ones
+		if r.Switch.CheckPeerDiversity(try) != nil {
+			continue
+		}
 		// TODO: consider moving some checks from toDial into here
 		// so we don't even consider dialing peers that we want to wait
 		// before dialling again, or have dialed too many times already
@@ -582,7 +605,8 @@ func (r *Reactor) dialPeer(addr *p2p.NetAddress) error {
 
 	err := r.Switch.DialPeerWithAddress(addr)
 	if err != nil {
-		if _, ok := err.(p2p.ErrCurrentlyDialingOrExistingAddress); ok {
+		switch err.(type) {
+		case p2p.ErrCurrentlyDialingOrExistingAddress, p2p.ErrPeerDiversity:
 			return err
 		}
 
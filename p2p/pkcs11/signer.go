@@ -0,0 +1,144 @@
+// Package pkcs11 implements a node key held in a PKCS#11 token, e.g. an HSM,
+// authenticating the node in the secret connection handshakes without its
+// private key ever being in the process.
+//
+// The tokens are accessed through their PKCS#11 module, loaded with cgo, so
+// only binaries built with the pkcs11 build tag, i.e. with
+// COMETBFT_BUILD_OPTIONS=pkcs11, support them. Only ed25519 keys, signing with
+// the CKM_EDDSA mechanism, are supported, as required by the handshake.
+package pkcs11
+
+import (
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	cmtsync "github.com/tendermint/tendermint/libs/sync"
+	"github.com/tendermint/tendermint/p2p/conn"
+)
+
+// ErrNotSupported is returned by NewSigner in the binaries built without the
+// pkcs11 build tag or without cgo.
+var ErrNotSupported = errors.New("PKCS#11 is not supported by this binary: " +
+	"build it with COMETBFT_BUILD_OPTIONS=pkcs11, which requires cgo")
+
+// probeMsg is signed when opening a key, to check that its private and public
+// key objects match.
+var probeMsg = []byte("cometbft PKCS#11 node key probe")
+
+var _ conn.Signer = (*Signer)(nil)
+
+// Signer signs with an ed25519 key in a PKCS#11 token. It is safe for
+// concurrent use, the signatures being serialized over a single session of
+// the token: a slow token delays the handshakes, not the accept loop of the
+// transport, which signs in the goroutines upgrading the connections.
+type Signer struct {
+	mtx    cmtsync.Mutex
+	token  *token // nil once closed
+	pubKey ed25519.PubKey
+}
+
+// NewSigner opens a session of the token of cfg, logging in with the PIN of
+// its environment variable, and returns a signer with its key. The signer must
+// be closed to close the session.
+func NewSigner(cfg config.PKCS11Config) (*Signer, error) {
+	if !cfg.Enabled() {
+		return nil, errors.New("no PKCS#11 module configured")
+	}
+	if err := cfg.ValidateBasic(); err != nil {
+		return nil, err
+	}
+	var pin string
+	if cfg.PINEnv != "" {
+		var ok bool
+		if pin, ok = os.LookupEnv(cfg.PINEnv); !ok {
+			return nil, fmt.Errorf("environment variable %s of the PKCS#11 PIN is not set", cfg.PINEnv)
+		}
+	}
+
+	t, err := openToken(cfg.Module, cfg.Slot, cfg.KeyLabel, pin)
+	if err != nil {
+		return nil, err
+	}
+	point, err := t.publicKey()
+	if err != nil {
+		_ = t.close()
+		return nil, err
+	}
+	pubKey, err := parseEdwardsPoint(point)
+	if err != nil {
+		_ = t.close()
+		return nil, fmt.Errorf("public key %q: %w", cfg.KeyLabel, err)
+	}
+
+	s := &Signer{token: t, pubKey: pubKey}
+	sig, err := s.Sign(probeMsg)
+	if err != nil {
+		_ = t.close()
+		return nil, fmt.Errorf("private key %q: %w", cfg.KeyLabel, err)
+	}
+	if !pubKey.VerifySignature(probeMsg, sig) {
+		_ = t.close()
+		return nil, fmt.Errorf("private key %q does not match its public key", cfg.KeyLabel)
+	}
+	return s, nil
+}
+
+// Sign implements conn.Signer.
+func (s *Signer) Sign(msg []byte) ([]byte, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if s.token == nil {
+		return nil, errors.New("PKCS#11 signer is closed")
+	}
+	sig, err := s.token.sign(msg)
+	if err != nil {
+		return nil, err
+	}
+	if len(sig) != ed25519.SignatureSize {
+		return nil, fmt.Errorf("expected a %d byte ed25519 signature, got %d bytes", ed25519.SignatureSize, len(sig))
+	}
+	return sig, nil
+}
+
+// PubKey implements conn.Signer.
+func (s *Signer) PubKey() crypto.PubKey {
+	return s.pubKey
+}
+
+// Close closes the session of the token. The signer cannot be used after.
+func (s *Signer) Close() error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if s.token == nil {
+		return nil
+	}
+	err := s.token.close()
+	s.token = nil
+	return err
+}
+
+// parseEdwardsPoint returns the ed25519 public key of the CKA_EC_POINT of a
+// public key object: a DER encoded octet string as mandated by PKCS#11 v3.0,
+// or the raw point as returned by some tokens.
+func parseEdwardsPoint(point []byte) (ed25519.PubKey, error) {
+	if len(point) == ed25519.PubKeySize {
+		return ed25519.PubKey(point), nil
+	}
+	var raw []byte
+	rest, err := asn1.Unmarshal(point, &raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid EC point: %w", err)
+	}
+	if len(rest) > 0 {
+		return nil, errors.New("invalid EC point: trailing data")
+	}
+	if len(raw) != ed25519.PubKeySize {
+		return nil, fmt.Errorf("expected a %d byte ed25519 public key, got %d bytes", ed25519.PubKeySize, len(raw))
+	}
+	return ed25519.PubKey(raw), nil
+}
@@ -0,0 +1,66 @@
+package pkcs11
+
+import (
+	"bytes"
+	"encoding/asn1"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+)
+
+func TestParseEdwardsPoint(t *testing.T) {
+	pubKey := ed25519.GenPrivKey().PubKey().(ed25519.PubKey)
+	der, err := asn1.Marshal([]byte(pubKey))
+	require.NoError(t, err)
+
+	testCases := map[string]struct {
+		point  []byte
+		expErr bool
+	}{
+		"der":            {der, false},
+		"raw":            {pubKey, false},
+		"empty":          {nil, true},
+		"short der":      {der[:len(der)-1], true},
+		"trailing data":  {append(der, 0), true},
+		"short key":      {mustMarshal(t, pubKey[:31]), true},
+		"not an octet":   {mustMarshal(t, 5), true},
+		"long raw point": {append(bytes.Repeat([]byte{1}, 40), pubKey...), true},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			parsed, err := parseEdwardsPoint(tc.point)
+			if tc.expErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, pubKey, parsed)
+		})
+	}
+}
+
+func mustMarshal(t *testing.T, v interface{}) []byte {
+	bz, err := asn1.Marshal(v)
+	require.NoError(t, err)
+	return bz
+}
+
+func TestNewSignerConfig(t *testing.T) {
+	_, err := NewSigner(config.PKCS11Config{})
+	assert.Error(t, err)
+	_, err = NewSigner(config.PKCS11Config{Module: "libsofthsm2.so"})
+	assert.Error(t, err)
+
+	// the PIN is never read from the config file
+	_, err = NewSigner(config.PKCS11Config{
+		Module:   "libsofthsm2.so",
+		KeyLabel: "node_key",
+		PINEnv:   "COMETBFT_TEST_UNSET_PKCS11_PIN",
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "COMETBFT_TEST_UNSET_PKCS11_PIN")
+}
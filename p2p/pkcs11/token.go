@@ -0,0 +1,424 @@
+//go:build pkcs11 && cgo
+
+package pkcs11
+
+/*
+#cgo linux LDFLAGS: -ldl
+
+#include <dlfcn.h>
+#include <stdlib.h>
+#include <string.h>
+
+// The subset of the PKCS#11 v2.40 types and constants used, not to depend on
+// the headers being installed. On unix, the structures are not packed.
+typedef unsigned long CK_ULONG;
+typedef CK_ULONG CK_RV;
+
+typedef struct CK_ATTRIBUTE {
+	CK_ULONG type;
+	void *pValue;
+	CK_ULONG ulValueLen;
+} CK_ATTRIBUTE;
+
+typedef struct CK_MECHANISM {
+	CK_ULONG mechanism;
+	void *pParameter;
+	CK_ULONG ulParameterLen;
+} CK_MECHANISM;
+
+#define CKR_OK 0x0UL
+#define CKF_SERIAL_SESSION 0x4UL
+#define CKU_USER 1UL
+#define CKA_CLASS 0x0UL
+#define CKA_LABEL 0x3UL
+#define CKA_EC_POINT 0x181UL
+#define CKM_EDDSA 0x1057UL
+
+typedef CK_RV (*C_Initialize_t)(void *);
+typedef CK_RV (*C_Finalize_t)(void *);
+typedef CK_RV (*C_OpenSession_t)(CK_ULONG, CK_ULONG, void *, void *, CK_ULONG *);
+typedef CK_RV (*C_CloseSession_t)(CK_ULONG);
+typedef CK_RV (*C_Login_t)(CK_ULONG, CK_ULONG, unsigned char *, CK_ULONG);
+typedef CK_RV (*C_FindObjectsInit_t)(CK_ULONG, CK_ATTRIBUTE *, CK_ULONG);
+typedef CK_RV (*C_FindObjects_t)(CK_ULONG, CK_ULONG *, CK_ULONG, CK_ULONG *);
+typedef CK_RV (*C_FindObjectsFinal_t)(CK_ULONG);
+typedef CK_RV (*C_GetAttributeValue_t)(CK_ULONG, CK_ULONG, CK_ATTRIBUTE *, CK_ULONG);
+typedef CK_RV (*C_SignInit_t)(CK_ULONG, CK_MECHANISM *, CK_ULONG);
+typedef CK_RV (*C_Sign_t)(CK_ULONG, unsigned char *, CK_ULONG, unsigned char *, CK_ULONG *);
+
+// ck_module holds the functions of a loaded PKCS#11 module.
+typedef struct ck_module {
+	void *handle;
+	C_Initialize_t C_Initialize;
+	C_Finalize_t C_Finalize;
+	C_OpenSession_t C_OpenSession;
+	C_CloseSession_t C_CloseSession;
+	C_Login_t C_Login;
+	C_FindObjectsInit_t C_FindObjectsInit;
+	C_FindObjects_t C_FindObjects;
+	C_FindObjectsFinal_t C_FindObjectsFinal;
+	C_GetAttributeValue_t C_GetAttributeValue;
+	C_SignInit_t C_SignInit;
+	C_Sign_t C_Sign;
+} ck_module;
+
+#define CK_LOAD(name) \
+	m->name = (name##_t)dlsym(m->handle, #name); \
+	if (m->name == NULL) { \
+		strncpy(err, "missing function " #name, err_len - 1); \
+		dlclose(m->handle); \
+		return 0; \
+	}
+
+// ck_load loads the module at path, or copies the reason it cannot into err.
+static int ck_load(ck_module *m, const char *path, char *err, size_t err_len) {
+	m->handle = dlopen(path, RTLD_NOW | RTLD_LOCAL);
+	if (m->handle == NULL) {
+		strncpy(err, dlerror(), err_len - 1);
+		return 0;
+	}
+	CK_LOAD(C_Initialize)
+	CK_LOAD(C_Finalize)
+	CK_LOAD(C_OpenSession)
+	CK_LOAD(C_CloseSession)
+	CK_LOAD(C_Login)
+	CK_LOAD(C_FindObjectsInit)
+	CK_LOAD(C_FindObjects)
+	CK_LOAD(C_FindObjectsFinal)
+	CK_LOAD(C_GetAttributeValue)
+	CK_LOAD(C_SignInit)
+	CK_LOAD(C_Sign)
+	return 1;
+}
+
+static void ck_unload(ck_module *m) {
+	dlclose(m->handle);
+}
+
+static CK_RV ck_initialize(ck_module *m) {
+	return m->C_Initialize(NULL);
+}
+
+static CK_RV ck_finalize(ck_module *m) {
+	return m->C_Finalize(NULL);
+}
+
+static CK_RV ck_open_session(ck_module *m, CK_ULONG slot, CK_ULONG *session) {
+	return m->C_OpenSession(slot, CKF_SERIAL_SESSION, NULL, NULL, session);
+}
+
+static CK_RV ck_close_session(ck_module *m, CK_ULONG session) {
+	return m->C_CloseSession(session);
+}
+
+static CK_RV ck_login(ck_module *m, CK_ULONG session, unsigned char *pin, CK_ULONG pin_len) {
+	return m->C_Login(session, CKU_USER, pin, pin_len);
+}
+
+// ck_find_key finds the keys of the class with the label, setting count to
+// their number, up to 2, and key to the first.
+static CK_RV ck_find_key(ck_module *m, CK_ULONG session, CK_ULONG class,
+		unsigned char *label, CK_ULONG label_len, CK_ULONG *key, CK_ULONG *count) {
+	CK_ATTRIBUTE template[2] = {
+		{CKA_CLASS, &class, sizeof(class)},
+		{CKA_LABEL, label, label_len},
+	};
+	CK_ULONG found[2];
+	CK_RV rv, final_rv;
+
+	rv = m->C_FindObjectsInit(session, template, 2);
+	if (rv != CKR_OK) {
+		return rv;
+	}
+	rv = m->C_FindObjects(session, found, 2, count);
+	final_rv = m->C_FindObjectsFinal(session);
+	if (rv != CKR_OK) {
+		return rv;
+	}
+	if (*count > 0) {
+		*key = found[0];
+	}
+	return final_rv;
+}
+
+// ck_get_attribute reads an attribute of the object into value, setting
+// value_len to its length.
+static CK_RV ck_get_attribute(ck_module *m, CK_ULONG session, CK_ULONG object, CK_ULONG type,
+		unsigned char *value, CK_ULONG *value_len) {
+	CK_ATTRIBUTE attr = {type, value, *value_len};
+	CK_RV rv = m->C_GetAttributeValue(session, object, &attr, 1);
+	*value_len = attr.ulValueLen;
+	return rv;
+}
+
+// ck_sign signs msg with the ed25519 key into sig, setting sig_len to its
+// length.
+static CK_RV ck_sign(ck_module *m, CK_ULONG session, CK_ULONG key,
+		unsigned char *msg, CK_ULONG msg_len, unsigned char *sig, CK_ULONG *sig_len) {
+	CK_MECHANISM mechanism = {CKM_EDDSA, NULL, 0};
+	CK_RV rv = m->C_SignInit(session, &mechanism, key);
+	if (rv != CKR_OK) {
+		return rv;
+	}
+	return m->C_Sign(session, msg, msg_len, sig, sig_len);
+}
+*/
+import "C"
+
+import (
+	"errors"
+	"fmt"
+	"unsafe"
+
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	cmtsync "github.com/tendermint/tendermint/libs/sync"
+)
+
+const (
+	ckoPublicKey  = 2
+	ckoPrivateKey = 3
+
+	ckrCryptokiAlreadyInitialized = 0x191
+	ckrUserAlreadyLoggedIn        = 0x100
+)
+
+// ckrNames are the names of the PKCS#11 return values most likely to be met.
+var ckrNames = map[C.CK_RV]string{
+	0x003: "CKR_SLOT_ID_INVALID",
+	0x005: "CKR_GENERAL_ERROR",
+	0x006: "CKR_FUNCTION_FAILED",
+	0x007: "CKR_ARGUMENTS_BAD",
+	0x012: "CKR_ATTRIBUTE_TYPE_INVALID",
+	0x030: "CKR_DEVICE_ERROR",
+	0x031: "CKR_DEVICE_MEMORY",
+	0x032: "CKR_DEVICE_REMOVED",
+	0x060: "CKR_KEY_HANDLE_INVALID",
+	0x063: "CKR_KEY_TYPE_INCONSISTENT",
+	0x068: "CKR_KEY_FUNCTION_NOT_PERMITTED",
+	0x070: "CKR_MECHANISM_INVALID",
+	0x0A0: "CKR_PIN_INCORRECT",
+	0x0A4: "CKR_PIN_LOCKED",
+	0x0B0: "CKR_SESSION_CLOSED",
+	0x0B3: "CKR_SESSION_HANDLE_INVALID",
+	0x0E0: "CKR_TOKEN_NOT_PRESENT",
+	0x0E1: "CKR_TOKEN_NOT_RECOGNIZED",
+	0x101: "CKR_USER_NOT_LOGGED_IN",
+	0x102: "CKR_USER_PIN_NOT_INITIALIZED",
+	0x150: "CKR_BUFFER_TOO_SMALL",
+	0x190: "CKR_CRYPTOKI_NOT_INITIALIZED",
+}
+
+// ckError is a PKCS#11 function failing.
+type ckError struct {
+	function string
+	rv       C.CK_RV
+}
+
+func (e ckError) Error() string {
+	if name, ok := ckrNames[e.rv]; ok {
+		return fmt.Sprintf("PKCS#11 %s failed: %s", e.function, name)
+	}
+	return fmt.Sprintf("PKCS#11 %s failed: 0x%X", e.function, uint64(e.rv))
+}
+
+// lostSession returns true if the session of the error must be reopened.
+func (e ckError) lostSession() bool {
+	switch e.rv {
+	case 0x032, 0x0B0, 0x0B3, 0x0E0, 0x101:
+		return true
+	}
+	return false
+}
+
+func ckCheck(function string, rv C.CK_RV) error {
+	if rv != C.CKR_OK {
+		return ckError{function, rv}
+	}
+	return nil
+}
+
+// module is a loaded PKCS#11 module, shared by the tokens using it as it is
+// initialized once per process.
+type module struct {
+	path string
+	ck   *C.ck_module
+	refs int
+}
+
+var (
+	modulesMtx cmtsync.Mutex
+	modules    = make(map[string]*module)
+)
+
+// loadModule loads and initializes the module at path, or returns the already
+// loaded one. It must be released.
+func loadModule(path string) (*module, error) {
+	modulesMtx.Lock()
+	defer modulesMtx.Unlock()
+	if m, ok := modules[path]; ok {
+		m.refs++
+		return m, nil
+	}
+
+	ck := (*C.ck_module)(C.calloc(1, C.sizeof_ck_module))
+	cpath := C.CString(path)
+	defer C.free(unsafe.Pointer(cpath))
+	var cerr [256]C.char
+	if C.ck_load(ck, cpath, &cerr[0], C.size_t(len(cerr))) == 0 {
+		C.free(unsafe.Pointer(ck))
+		return nil, fmt.Errorf("loading PKCS#11 module %s: %s", path, C.GoString(&cerr[0]))
+	}
+	// initialized by another library of the process, e.g. a crypto provider
+	if rv := C.ck_initialize(ck); rv != C.CKR_OK && rv != ckrCryptokiAlreadyInitialized {
+		C.ck_unload(ck)
+		C.free(unsafe.Pointer(ck))
+		return nil, ckError{"C_Initialize", rv}
+	}
+
+	m := &module{path: path, ck: ck, refs: 1}
+	modules[path] = m
+	return m, nil
+}
+
+// release finalizes and unloads the module once it is no longer used.
+func (m *module) release() error {
+	modulesMtx.Lock()
+	defer modulesMtx.Unlock()
+	m.refs--
+	if m.refs > 0 {
+		return nil
+	}
+	delete(modules, m.path)
+	err := ckCheck("C_Finalize", C.ck_finalize(m.ck))
+	C.ck_unload(m.ck)
+	C.free(unsafe.Pointer(m.ck))
+	return err
+}
+
+// token is a session of a PKCS#11 token, with the handles of the objects of
+// its key. It is not safe for concurrent use.
+type token struct {
+	module *module
+	slot   uint
+	label  string
+	pin    string
+
+	session C.CK_ULONG
+	privKey C.CK_ULONG
+	pubKey  C.CK_ULONG
+}
+
+// openToken opens a session of the token in the slot, logged in with pin if it
+// is not empty, and finds the objects of the key with the label.
+func openToken(path string, slot uint, label, pin string) (*token, error) {
+	m, err := loadModule(path)
+	if err != nil {
+		return nil, err
+	}
+	t := &token{module: m, slot: slot, label: label, pin: pin}
+	if err := t.open(); err != nil {
+		_ = m.release()
+		return nil, err
+	}
+	return t, nil
+}
+
+func (t *token) open() error {
+	ck := t.module.ck
+	if err := ckCheck("C_OpenSession", C.ck_open_session(ck, C.CK_ULONG(t.slot), &t.session)); err != nil {
+		return fmt.Errorf("slot %d: %w", t.slot, err)
+	}
+	err := t.findKey()
+	if err != nil {
+		_ = C.ck_close_session(ck, t.session)
+	}
+	return err
+}
+
+func (t *token) findKey() error {
+	ck := t.module.ck
+	if t.pin != "" {
+		pin := []byte(t.pin)
+		rv := C.ck_login(ck, t.session, (*C.uchar)(unsafe.Pointer(&pin[0])), C.CK_ULONG(len(pin)))
+		// the login is shared by the sessions of the process
+		if rv != C.CKR_OK && rv != ckrUserAlreadyLoggedIn {
+			return ckError{"C_Login", rv}
+		}
+	}
+
+	label := []byte(t.label)
+	find := func(class C.CK_ULONG) (C.CK_ULONG, error) {
+		var key, count C.CK_ULONG
+		rv := C.ck_find_key(ck, t.session, class, (*C.uchar)(unsafe.Pointer(&label[0])), C.CK_ULONG(len(label)),
+			&key, &count)
+		switch {
+		case rv != C.CKR_OK:
+			return 0, ckError{"C_FindObjects", rv}
+		case count == 0:
+			return 0, errors.New("not found")
+		case count > 1:
+			return 0, errors.New("not unique")
+		}
+		return key, nil
+	}
+	var err error
+	if t.privKey, err = find(ckoPrivateKey); err != nil {
+		return fmt.Errorf("private key %q: %w", t.label, err)
+	}
+	if t.pubKey, err = find(ckoPublicKey); err != nil {
+		return fmt.Errorf("public key %q: %w", t.label, err)
+	}
+	return nil
+}
+
+// publicKey returns the CKA_EC_POINT of the public key.
+func (t *token) publicKey() ([]byte, error) {
+	// a DER encoded octet string of the point, a few bytes longer than it
+	value := make([]byte, 2*ed25519.PubKeySize)
+	size := C.CK_ULONG(len(value))
+	rv := C.ck_get_attribute(t.module.ck, t.session, t.pubKey, C.CKA_EC_POINT,
+		(*C.uchar)(unsafe.Pointer(&value[0])), &size)
+	if err := ckCheck("C_GetAttributeValue", rv); err != nil {
+		return nil, fmt.Errorf("public key %q: %w", t.label, err)
+	}
+	return value[:size], nil
+}
+
+// sign signs msg with the private key, reopening the session once if the
+// token lost it, e.g. as it was reset.
+func (t *token) sign(msg []byte) ([]byte, error) {
+	sig, err := t.signOnce(msg)
+	var ckErr ckError
+	if errors.As(err, &ckErr) && ckErr.lostSession() {
+		_ = C.ck_close_session(t.module.ck, t.session)
+		if err := t.open(); err != nil {
+			return nil, fmt.Errorf("reopening the session: %w", err)
+		}
+		sig, err = t.signOnce(msg)
+	}
+	return sig, err
+}
+
+func (t *token) signOnce(msg []byte) ([]byte, error) {
+	sig := make([]byte, ed25519.SignatureSize)
+	size := C.CK_ULONG(len(sig))
+	var msgPtr *C.uchar
+	if len(msg) > 0 {
+		msgPtr = (*C.uchar)(unsafe.Pointer(&msg[0]))
+	}
+	rv := C.ck_sign(t.module.ck, t.session, t.privKey, msgPtr, C.CK_ULONG(len(msg)),
+		(*C.uchar)(unsafe.Pointer(&sig[0])), &size)
+	if err := ckCheck("C_Sign", rv); err != nil {
+		return nil, err
+	}
+	return sig[:size], nil
+}
+
+// close closes the session and releases the module.
+func (t *token) close() error {
+	err := ckCheck("C_CloseSession", C.ck_close_session(t.module.ck, t.session))
+	if rerr := t.module.release(); err == nil {
+		err = rerr
+	}
+	return err
+}
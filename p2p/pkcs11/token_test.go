@@ -0,0 +1,115 @@
+//go:build pkcs11 && cgo
+
+package pkcs11
+
+import (
+	"net"
+	"os"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/p2p/conn"
+)
+
+// testConfig returns the config of the ed25519 key of a PKCS#11 token, e.g. of
+// SoftHSM, set up by the environment:
+//
+//	PKCS11_TEST_MODULE     path to the module, the tests are skipped if unset
+//	PKCS11_TEST_SLOT       slot of the token
+//	PKCS11_TEST_KEY_LABEL  label of the key
+//	PKCS11_TEST_PIN        user PIN of the token
+func testConfig(t *testing.T) config.PKCS11Config {
+	module := os.Getenv("PKCS11_TEST_MODULE")
+	if module == "" {
+		t.Skip("PKCS11_TEST_MODULE is not set")
+	}
+	slot, err := strconv.ParseUint(os.Getenv("PKCS11_TEST_SLOT"), 10, 64)
+	require.NoError(t, err, "PKCS11_TEST_SLOT")
+	return config.PKCS11Config{
+		Module:   module,
+		Slot:     uint(slot),
+		KeyLabel: os.Getenv("PKCS11_TEST_KEY_LABEL"),
+		PINEnv:   "PKCS11_TEST_PIN",
+	}
+}
+
+func TestSigner(t *testing.T) {
+	cfg := testConfig(t)
+	s, err := NewSigner(cfg)
+	require.NoError(t, err)
+
+	// the signatures are serialized over the session
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			msg := []byte(strconv.Itoa(i))
+			sig, err := s.Sign(msg)
+			if assert.NoError(t, err) {
+				assert.True(t, s.PubKey().VerifySignature(msg, sig))
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	// the module is shared with another signer
+	s2, err := NewSigner(cfg)
+	require.NoError(t, err)
+	assert.Equal(t, s.PubKey(), s2.PubKey())
+	require.NoError(t, s.Close())
+	_, err = s.Sign([]byte("closed"))
+	assert.Error(t, err)
+	_, err = s2.Sign([]byte("open"))
+	assert.NoError(t, err)
+	require.NoError(t, s2.Close())
+
+	// and reloaded once both are closed
+	s, err = NewSigner(cfg)
+	require.NoError(t, err)
+	require.NoError(t, s.Close())
+}
+
+func TestSignerErrors(t *testing.T) {
+	cfg := testConfig(t)
+
+	missing := cfg
+	missing.KeyLabel = "cometbft-missing-key"
+	_, err := NewSigner(missing)
+	assert.Error(t, err)
+
+	t.Setenv("PKCS11_TEST_WRONG_PIN", "not the pin")
+	wrongPIN := cfg
+	wrongPIN.PINEnv = "PKCS11_TEST_WRONG_PIN"
+	_, err = NewSigner(wrongPIN)
+	assert.Error(t, err)
+}
+
+func TestSignerSecretConnection(t *testing.T) {
+	s, err := NewSigner(testConfig(t))
+	require.NoError(t, err)
+	defer s.Close()
+
+	fooConn, barConn := net.Pipe()
+	defer fooConn.Close()
+	defer barConn.Close()
+	fooPrivKey := ed25519.GenPrivKey()
+	fooSecConnc := make(chan *conn.SecretConnection, 1)
+	go func() {
+		sc, _ := conn.MakeSecretConnection(fooConn, fooPrivKey)
+		fooSecConnc <- sc
+	}()
+
+	barSecConn, err := conn.MakeSecretConnection(barConn, s)
+	require.NoError(t, err)
+	assert.Equal(t, fooPrivKey.PubKey(), barSecConn.RemotePubKey())
+	fooSecConn := <-fooSecConnc
+	require.NotNil(t, fooSecConn)
+	assert.Equal(t, s.PubKey(), fooSecConn.RemotePubKey())
+}
@@ -0,0 +1,16 @@
+//go:build !pkcs11 || !cgo
+
+package pkcs11
+
+// token is a session of a PKCS#11 token, not supported by this binary.
+type token struct{}
+
+func openToken(module string, slot uint, label, pin string) (*token, error) {
+	return nil, ErrNotSupported
+}
+
+func (t *token) publicKey() ([]byte, error) { return nil, ErrNotSupported }
+
+func (t *token) sign(msg []byte) ([]byte, error) { return nil, ErrNotSupported }
+
+func (t *token) close() error { return nil }
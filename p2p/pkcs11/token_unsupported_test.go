@@ -0,0 +1,16 @@
+//go:build !pkcs11 || !cgo
+
+package pkcs11
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tendermint/tendermint/config"
+)
+
+func TestNewSignerNotSupported(t *testing.T) {
+	_, err := NewSigner(config.PKCS11Config{Module: "libsofthsm2.so", KeyLabel: "node_key"})
+	assert.ErrorIs(t, err, ErrNotSupported)
+}
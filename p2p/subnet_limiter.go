@@ -0,0 +1,134 @@
+package p2p
+
+import (
+	"fmt"
+	"net"
+	"time"
+
+	cmtsync "github.com/tendermint/tendermint/libs/sync"
+)
+
+const (
+	// DefaultSubnetIPv4PrefixLen is the default length of the prefix grouping
+	// IPv4 addresses into subnets, i.e. a /24.
+	DefaultSubnetIPv4PrefixLen = 24
+	// DefaultSubnetIPv6PrefixLen is the default length of the prefix grouping
+	// IPv6 addresses into subnets, i.e. a /48.
+	DefaultSubnetIPv6PrefixLen = 48
+)
+
+// SubnetLimiter limits the rate of incoming connections from a single IP
+// subnet, to make it harder for an attacker controlling a subnet to fill the
+// inbound peer slots of a node with Sybil peers. At most maxConns connections
+// are allowed per subnet within any window of time.
+type SubnetLimiter struct {
+	maxConns      int
+	window        time.Duration
+	ipv4PrefixLen int
+	ipv6PrefixLen int
+
+	mtx cmtsync.Mutex
+	// times at which connections from a subnet were allowed within the window,
+	// oldest first
+	subnets   map[string][]time.Time
+	lastPrune time.Time
+}
+
+// SubnetLimiterOption sets an optional parameter on the SubnetLimiter.
+type SubnetLimiterOption func(*SubnetLimiter)
+
+// SubnetLimiterIPv4PrefixLen sets the length of the prefix grouping IPv4
+// addresses into subnets.
+func SubnetLimiterIPv4PrefixLen(n int) SubnetLimiterOption {
+	return func(sl *SubnetLimiter) { sl.ipv4PrefixLen = n }
+}
+
+// SubnetLimiterIPv6PrefixLen sets the length of the prefix grouping IPv6
+// addresses into subnets.
+func SubnetLimiterIPv6PrefixLen(n int) SubnetLimiterOption {
+	return func(sl *SubnetLimiter) { sl.ipv6PrefixLen = n }
+}
+
+// NewSubnetLimiter returns a SubnetLimiter allowing at most maxConns
+// connections per subnet within window. Subnets are /24 for IPv4 and /48 for
+// IPv6 addresses unless set otherwise with the options.
+func NewSubnetLimiter(maxConns int, window time.Duration, options ...SubnetLimiterOption) *SubnetLimiter {
+	sl := &SubnetLimiter{
+		maxConns:      maxConns,
+		window:        window,
+		ipv4PrefixLen: DefaultSubnetIPv4PrefixLen,
+		ipv6PrefixLen: DefaultSubnetIPv6PrefixLen,
+		subnets:       make(map[string][]time.Time),
+	}
+	for _, option := range options {
+		option(sl)
+	}
+	return sl
+}
+
+// Allow records a connection from the address and returns an error if the
+// limit of connections from its subnet has been reached, in which case the
+// connection is not recorded.
+func (sl *SubnetLimiter) Allow(addr *NetAddress) error {
+	return sl.allow(addr, time.Now())
+}
+
+func (sl *SubnetLimiter) allow(addr *NetAddress, now time.Time) error {
+	subnet := sl.Subnet(addr)
+
+	sl.mtx.Lock()
+	defer sl.mtx.Unlock()
+
+	sl.prune(now)
+
+	times := dropUntil(sl.subnets[subnet], now.Add(-sl.window))
+	if len(times) >= sl.maxConns {
+		sl.subnets[subnet] = times
+		return fmt.Errorf("too many connections from subnet %v, at most %d are allowed every %v",
+			subnet, sl.maxConns, sl.window)
+	}
+	sl.subnets[subnet] = append(times, now)
+	return nil
+}
+
+// Subnet returns the subnet of the address, in CIDR notation.
+func (sl *SubnetLimiter) Subnet(addr *NetAddress) string {
+	return IPSubnet(addr.IP, sl.ipv4PrefixLen, sl.ipv6PrefixLen)
+}
+
+// IPSubnet returns the subnet of the IP, in CIDR notation, given the lengths
+// of the prefixes grouping IPv4 and IPv6 addresses into subnets.
+func IPSubnet(ip net.IP, ipv4PrefixLen, ipv6PrefixLen int) string {
+	prefixLen, bits := ipv6PrefixLen, net.IPv6len*8
+	if ip4 := ip.To4(); ip4 != nil {
+		ip, prefixLen, bits = ip4, ipv4PrefixLen, net.IPv4len*8
+	}
+	subnet := net.IPNet{IP: ip.Mask(net.CIDRMask(prefixLen, bits)), Mask: net.CIDRMask(prefixLen, bits)}
+	return subnet.String()
+}
+
+// prune removes the subnets without connections within the window, so that
+// the memory used does not grow with the number of subnets ever seen. It runs
+// at most once per window.
+func (sl *SubnetLimiter) prune(now time.Time) {
+	if now.Sub(sl.lastPrune) < sl.window {
+		return
+	}
+	sl.lastPrune = now
+	for subnet, times := range sl.subnets {
+		if times = dropUntil(times, now.Add(-sl.window)); len(times) == 0 {
+			delete(sl.subnets, subnet)
+		} else {
+			sl.subnets[subnet] = times
+		}
+	}
+}
+
+// dropUntil returns the times, sorted in ascending order, that are after t.
+func dropUntil(times []time.Time, t time.Time) []time.Time {
+	i := 0
+	for i < len(times) && !times[i].After(t) {
+		i++
+	}
+	return times[i:]
+}
@@ -0,0 +1,49 @@
+package p2p
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubnetLimiterSubnet(t *testing.T) {
+	testCases := []struct {
+		ip       string
+		options  []SubnetLimiterOption
+		expected string
+	}{
+		{"192.168.10.42", nil, "192.168.10.0/24"},
+		{"::ffff:192.168.10.42", nil, "192.168.10.0/24"},
+		{"2001:db8:abcd:12::1", nil, "2001:db8:abcd::/48"},
+		{"192.168.10.42", []SubnetLimiterOption{SubnetLimiterIPv4PrefixLen(16)}, "192.168.0.0/16"},
+		{"2001:db8:abcd:12::1", []SubnetLimiterOption{SubnetLimiterIPv6PrefixLen(64)}, "2001:db8:abcd:12::/64"},
+	}
+	for _, tc := range testCases {
+		sl := NewSubnetLimiter(1, time.Minute, tc.options...)
+		assert.Equal(t, tc.expected, sl.Subnet(NewNetAddressIPPort(net.ParseIP(tc.ip), 26656)), tc.ip)
+	}
+}
+
+func TestSubnetLimiterAllow(t *testing.T) {
+	sl := NewSubnetLimiter(2, time.Minute)
+	now := time.Now()
+	addr := func(ip string) *NetAddress { return NewNetAddressIPPort(net.ParseIP(ip), 26656) }
+
+	require.NoError(t, sl.allow(addr("10.0.0.1"), now))
+	require.NoError(t, sl.allow(addr("10.0.0.2"), now.Add(time.Second)))
+	// the subnet reached its limit, other subnets are not affected
+	assert.Error(t, sl.allow(addr("10.0.0.3"), now.Add(2*time.Second)))
+	assert.NoError(t, sl.allow(addr("10.0.1.1"), now.Add(2*time.Second)))
+
+	// connections are allowed again as the earlier ones leave the window
+	require.NoError(t, sl.allow(addr("10.0.0.3"), now.Add(time.Minute)))
+	assert.Error(t, sl.allow(addr("10.0.0.4"), now.Add(time.Minute)))
+	require.NoError(t, sl.allow(addr("10.0.0.4"), now.Add(time.Minute+time.Second)))
+
+	// subnets without recent connections are forgotten
+	require.NoError(t, sl.allow(addr("10.0.2.1"), now.Add(10*time.Minute)))
+	assert.Len(t, sl.subnets, 1)
+}
@@ -3,6 +3,7 @@ package p2p
 import (
 	"fmt"
 	"math"
+	"net"
 	"sync"
 	"time"
 
@@ -11,6 +12,7 @@ import (
 	"github.com/tendermint/tendermint/libs/cmap"
 	"github.com/tendermint/tendermint/libs/rand"
 	"github.com/tendermint/tendermint/libs/service"
+	cmtsync "github.com/tendermint/tendermint/libs/sync"
 	"github.com/tendermint/tendermint/p2p/conn"
 	"github.com/tendermint/tendermint/pkg/trace"
 	"github.com/tendermint/tendermint/pkg/trace/schema"
@@ -30,6 +32,18 @@ const (
 	// ie. 3**10 = 16hrs
 	reconnectBackOffAttempts    = 10
 	reconnectBackOffBaseSeconds = 3
+
+	// handshakes revealing a different network before we give up on dialing
+	// a persistent peer
+	maxWrongNetworkDials = 3
+
+	// time for which a peer stopped for sending messages exceeding the limits
+	// is banned from the address book
+	messageLimitsBanTime = 24 * time.Hour
+
+	// interval at which a surplus outbound peer is evicted when the outbound
+	// target is dynamic
+	evictSurplusPeerInterval = 30 * time.Second
 )
 
 // MConnConfig returns an MConnConfig with fields updated
@@ -53,6 +67,7 @@ type AddrBook interface {
 	AddOurAddress(*NetAddress)
 	OurAddress(*NetAddress) bool
 	MarkGood(ID)
+	MarkNetwork(*NetAddress, string)
 	RemoveAddress(*NetAddress)
 	HasAddress(*NetAddress) bool
 	Save()
@@ -85,17 +100,26 @@ type Switch struct {
 	// peers addresses with whom we'll maintain constant connection
 	persistentPeersAddrs []*NetAddress
 	unconditionalPeerIDs map[ID]struct{}
+	// persistent peers found on another network, see markWrongNetworkDial
+	wrongNetwork *cmap.CMap
 
 	transport Transport
 
 	filterTimeout time.Duration
 	peerFilters   []PeerFilterFunc
+	diversity     *PeerDiversity // optional
+	// serializes the diversity checks of the addresses dialed
+	diversityMtx cmtsync.Mutex
+	targeter     *PeerTargeter // optional
+	// IDs of the connected peers marked as good, see MarkPeerAsGood
+	goodPeers *cmap.CMap
 
 	rng *rand.Rand // seed for randomizing dial times and orders
 
-	metrics     *Metrics
-	mlc         *metricsLabelCache
-	traceClient trace.Tracer
+	metrics          *Metrics
+	peerMetricsStore *PeerMetricsStore // optional
+	mlc              *metricsLabelCache
+	traceClient      trace.Tracer
 }
 
 // NetAddress returns the address the switch is listening on.
@@ -123,6 +147,8 @@ func NewSwitch(
 		peers:                NewPeerSet(),
 		dialing:              cmap.NewCMap(),
 		reconnecting:         cmap.NewCMap(),
+		wrongNetwork:         cmap.NewCMap(),
+		goodPeers:            cmap.NewCMap(),
 		metrics:              NopMetrics(),
 		transport:            transport,
 		filterTimeout:        defaultFilterTimeout,
@@ -154,11 +180,32 @@ func SwitchPeerFilters(filters ...PeerFilterFunc) SwitchOption {
 	return func(sw *Switch) { sw.peerFilters = filters }
 }
 
+// SwitchPeerDiversity sets the caps on the peers within a single IP subnet or
+// autonomous system. They apply to the addresses dialed and, once half of the
+// inbound peer slots are taken, to the inbound peers. Persistent and
+// unconditional peers are exempt.
+func SwitchPeerDiversity(pd *PeerDiversity) SwitchOption {
+	return func(sw *Switch) { sw.diversity = pd }
+}
+
+// SwitchPeerTargeter makes the outbound peer target dynamic: it follows the
+// size of the network reported with ReportNetworkSize, and the surplus
+// outbound peers are evicted one at a time, see PeerTargeter.
+func SwitchPeerTargeter(pt *PeerTargeter) SwitchOption {
+	return func(sw *Switch) { sw.targeter = pt }
+}
+
 // WithMetrics sets the metrics.
 func WithMetrics(metrics *Metrics) SwitchOption {
 	return func(sw *Switch) { sw.metrics = metrics }
 }
 
+// WithPeerMetricsStore sets the store recording the connection statistics of
+// every peer. The switch starts and stops the store.
+func WithPeerMetricsStore(store *PeerMetricsStore) SwitchOption {
+	return func(sw *Switch) { sw.peerMetricsStore = store }
+}
+
 func WithTracer(tracer trace.Tracer) SwitchOption {
 	return func(sw *Switch) { sw.traceClient = tracer }
 }
@@ -237,6 +284,12 @@ func (sw *Switch) SetNodeKey(nodeKey *NodeKey) {
 
 // OnStart implements BaseService. It starts all the reactors and peers.
 func (sw *Switch) OnStart() error {
+	if sw.peerMetricsStore != nil {
+		if err := sw.peerMetricsStore.Start(); err != nil {
+			return fmt.Errorf("failed to start peer metrics store: %w", err)
+		}
+	}
+
 	// Start reactors
 	for _, reactor := range sw.reactors {
 		err := reactor.Start()
@@ -248,6 +301,10 @@ func (sw *Switch) OnStart() error {
 	// Start accepting Peers.
 	go sw.acceptRoutine()
 
+	if sw.targeter != nil {
+		go sw.evictSurplusPeersRoutine()
+	}
+
 	return nil
 }
 
@@ -265,6 +322,12 @@ func (sw *Switch) OnStop() {
 			sw.Logger.Error("error while stopped reactor", "reactor", reactor, "error", err)
 		}
 	}
+
+	if sw.peerMetricsStore != nil {
+		if err := sw.peerMetricsStore.Stop(); err != nil {
+			sw.Logger.Error("error while stopping peer metrics store", "error", err)
+		}
+	}
 }
 
 //---------------------------------------------------------------------
@@ -364,6 +427,74 @@ func (sw *Switch) MaxNumOutboundPeers() int {
 	return sw.config.MaxNumOutboundPeers
 }
 
+// OutboundPeerTarget returns the number of outbound peers to connect to: the
+// current dynamic target if the switch has a PeerTargeter, MaxNumOutboundPeers
+// otherwise.
+func (sw *Switch) OutboundPeerTarget() int {
+	if sw.targeter != nil {
+		return sw.targeter.Outbound()
+	}
+	return sw.MaxNumOutboundPeers()
+}
+
+// ReportNetworkSize updates the dynamic outbound target, if any, given the
+// number of other nodes observed in the network.
+func (sw *Switch) ReportNetworkSize(n int) {
+	if sw.targeter == nil {
+		return
+	}
+	if before, after := sw.targeter.Outbound(), sw.targeter.ObserveNetworkSize(n); before != after {
+		sw.Logger.Info("Updated outbound peer target", "networkSize", n, "target", after, "previous", before)
+	}
+}
+
+// PeerTargets returns the numbers of peers the switch aims for.
+func (sw *Switch) PeerTargets() PeerTargets {
+	if sw.targeter != nil {
+		return sw.targeter.Targets()
+	}
+	return PeerTargets{
+		MaxInbound:  sw.config.MaxNumInboundPeers,
+		MinOutbound: sw.config.MaxNumOutboundPeers,
+		MaxOutbound: sw.config.MaxNumOutboundPeers,
+		Outbound:    sw.config.MaxNumOutboundPeers,
+	}
+}
+
+func (sw *Switch) evictSurplusPeersRoutine() {
+	ticker := time.NewTicker(evictSurplusPeerInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			sw.evictSurplusPeer()
+		case <-sw.Quit():
+			return
+		}
+	}
+}
+
+// evictSurplusPeer stops the outbound peer with the lowest reputation if there
+// are more outbound peers than the target. It evicts at most one peer per call,
+// so that the peers are shed gradually when the target goes down. Persistent
+// and unconditional peers are neither evicted nor counted.
+func (sw *Switch) evictSurplusPeer() {
+	var candidates []Peer
+	for _, peer := range sw.peers.List() {
+		if peer.IsOutbound() && !peer.IsPersistent() && !sw.IsPeerUnconditional(peer.ID()) {
+			candidates = append(candidates, peer)
+		}
+	}
+	surplus := surplusPeers(candidates, sw.OutboundPeerTarget(), func(p Peer) bool {
+		return sw.goodPeers.Has(string(p.ID()))
+	})
+	if len(surplus) == 0 {
+		return
+	}
+	sw.Logger.Info("Evicting surplus outbound peer", "peer", surplus[0], "surplus", len(surplus))
+	sw.StopPeerGracefully(surplus[0])
+}
+
 // Peers returns the set of peers that are connected to the switch.
 func (sw *Switch) Peers() IPeerSet {
 	return sw.peers
@@ -380,6 +511,11 @@ func (sw *Switch) StopPeerForError(peer Peer, reason interface{}) {
 	sw.Logger.Error("Stopping peer for error", "peer", peer, "err", reason)
 	sw.stopAndRemovePeer(peer, reason)
 
+	// persistent peers are trusted by the operator, so they are not banned
+	if _, ok := reason.(ErrPeerMessageLimits); ok && !peer.IsPersistent() {
+		sw.markPeerBad(peer, messageLimitsBanTime)
+	}
+
 	if peer.IsPersistent() {
 		var addr *NetAddress
 		if peer.IsOutbound() { // socket address for outbound peers
@@ -397,6 +533,18 @@ func (sw *Switch) StopPeerForError(peer Peer, reason interface{}) {
 	}
 }
 
+// markPeerBad marks the address of the peer as bad in the address book, if it
+// supports it, so that the peer is neither dialed nor gossiped for banTime.
+func (sw *Switch) markPeerBad(peer Peer, banTime time.Duration) {
+	book, ok := sw.addrBook.(interface {
+		MarkBad(*NetAddress, time.Duration)
+	})
+	if !ok || peer.SocketAddr() == nil {
+		return
+	}
+	book.MarkBad(peer.SocketAddr(), banTime)
+}
+
 // StopPeerGracefully disconnects from a peer gracefully.
 // TODO: handle graceful disconnects.
 func (sw *Switch) StopPeerGracefully(peer Peer) {
@@ -420,7 +568,13 @@ func (sw *Switch) stopAndRemovePeer(peer Peer, reason interface{}) {
 	// RemovePeer is finished.
 	// https://github.com/tendermint/tendermint/issues/3338
 	if sw.peers.Remove(peer) {
+		sw.goodPeers.Delete(string(peer.ID()))
 		sw.metrics.Peers.Add(float64(-1))
+		sw.updateSubnetPeersMetric(peer)
+		sw.metrics.PeerConnectionLifetime.Observe(peer.Status().Duration.Seconds())
+		if sw.peerMetricsStore != nil {
+			sw.peerMetricsStore.PeerDisconnected(peer.ID(), reason)
+		}
 	} else {
 		// Removal of the peer has failed. The function above sets a flag within the peer to mark this.
 		// We keep this message here as information to the developer.
@@ -432,7 +586,8 @@ func (sw *Switch) stopAndRemovePeer(peer Peer, reason interface{}) {
 // with a fixed interval, then with exponential backoff.
 // If no success after all that, it stops trying, and leaves it
 // to the PEX/Addrbook to find the peer with the addr again
-// NOTE: this will keep trying even if the handshake or auth fails.
+// NOTE: this will keep trying even if the handshake or auth fails, unless the
+// handshakes reveal that the peer is on a different network.
 // TODO: be more explicit with error types so we only retry on certain failures
 //   - ie. if we're getting ErrDuplicatePeer we can stop
 //     because the addrbook got us the peer back already
@@ -455,6 +610,8 @@ func (sw *Switch) reconnectToPeer(addr *NetAddress) {
 			return // success
 		} else if _, ok := err.(ErrCurrentlyDialingOrExistingAddress); ok {
 			return
+		} else if _, ok := err.(ErrPersistentPeerWrongNetwork); ok {
+			return
 		}
 
 		sw.Logger.Info("Error reconnecting to peer. Trying again", "tries", i, "err", err, "addr", addr)
@@ -479,6 +636,8 @@ func (sw *Switch) reconnectToPeer(addr *NetAddress) {
 			return // success
 		} else if _, ok := err.(ErrCurrentlyDialingOrExistingAddress); ok {
 			return
+		} else if _, ok := err.(ErrPersistentPeerWrongNetwork); ok {
+			return
 		}
 		sw.Logger.Info("Error reconnecting to peer. Trying again", "tries", i, "err", err, "addr", addr)
 	}
@@ -496,6 +655,9 @@ func (sw *Switch) MarkPeerAsGood(peer Peer) {
 	if sw.addrBook != nil {
 		sw.addrBook.MarkGood(peer.ID())
 	}
+	if sw.peers.Has(peer.ID()) {
+		sw.goodPeers.Set(string(peer.ID()), struct{}{})
+	}
 }
 
 //---------------------------------------------------------------------
@@ -593,13 +755,62 @@ func (sw *Switch) DialPeerWithAddress(addr *NetAddress) error {
 	if sw.IsDialingOrExistingAddress(addr) {
 		return ErrCurrentlyDialingOrExistingAddress{addr.String()}
 	}
+	if wn, ok := sw.wrongNetwork.Get(string(addr.ID)).(*wrongNetworkDials); ok && wn.dials >= maxWrongNetworkDials {
+		return ErrPersistentPeerWrongNetwork{Addr: addr, Network: wn.network, Dials: wn.dials}
+	}
 
-	sw.dialing.Set(string(addr.ID), addr)
+	// check and mark the address as dialing at once, so that concurrent dials
+	// do not exceed the peers allowed within a subnet together
+	sw.diversityMtx.Lock()
+	err := sw.CheckPeerDiversity(addr)
+	if err == nil {
+		sw.dialing.Set(string(addr.ID), addr)
+	}
+	sw.diversityMtx.Unlock()
+	if err != nil {
+		return err
+	}
 	defer sw.dialing.Delete(string(addr.ID))
 
 	return sw.addOutboundPeerWithConfig(addr, sw.config)
 }
 
+// CheckPeerDiversity returns an ErrPeerDiversity if connecting to the peer at
+// addr would exceed the peers allowed within its IP subnet or autonomous
+// system. Persistent and unconditional peers are always allowed.
+func (sw *Switch) CheckPeerDiversity(addr *NetAddress) error {
+	if sw.diversity == nil || sw.IsPeerPersistent(addr) || sw.IsPeerUnconditional(addr.ID) {
+		return nil
+	}
+	return sw.diversity.Allow(addr.IP, sw.peerIPs())
+}
+
+// peerIPs returns the IPs of the peers connected or being dialed, counting
+// each peer once.
+func (sw *Switch) peerIPs() []net.IP {
+	peers, dialing := sw.peers.List(), sw.dialing.Values()
+	ips := make([]net.IP, 0, len(peers)+len(dialing))
+	for _, p := range peers {
+		ips = append(ips, p.RemoteIP())
+	}
+	for _, v := range dialing {
+		if addr := v.(*NetAddress); !sw.peers.Has(addr.ID) {
+			ips = append(ips, addr.IP)
+		}
+	}
+	return ips
+}
+
+// updateSubnetPeersMetric reports the number of peers within the subnet of
+// the peer, after it was added or removed.
+func (sw *Switch) updateSubnetPeersMetric(p Peer) {
+	if sw.diversity == nil {
+		return
+	}
+	subnet := sw.diversity.Subnet(p.RemoteIP())
+	sw.metrics.SubnetPeers.With("subnet", subnet).Set(float64(sw.diversity.SubnetPeers(subnet, sw.peerIPs())))
+}
+
 // sleep for interval plus some random amount of ms on [0, dialRandomizerIntervalMilliseconds]
 func (sw *Switch) randomSleep(interval time.Duration) {
 	r := time.Duration(sw.rng.Int63n(dialRandomizerIntervalMilliseconds)) * time.Millisecond
@@ -745,6 +956,20 @@ func (sw *Switch) acceptRoutine() {
 				continue
 			}
 
+			// Past the soft limit, keep the remaining slots for diverse peers.
+			if sw.diversity != nil && in >= sw.config.MaxNumInboundPeers/2 && !p.IsPersistent() {
+				if err := sw.diversity.Allow(p.RemoteIP(), sw.peerIPs()); err != nil {
+					sw.Logger.Info(
+						"Ignoring inbound connection: not diverse enough",
+						"address", p.SocketAddr(),
+						"err", err,
+					)
+
+					sw.transport.Cleanup(p)
+
+					continue
+				}
+			}
 		}
 
 		if err := sw.addPeer(p); err != nil {
@@ -797,6 +1022,14 @@ func (sw *Switch) addOutboundPeerWithConfig(
 
 				return err
 			}
+			if e.IsWrongNetwork() {
+				if sw.addrBook != nil {
+					sw.addrBook.MarkNetwork(addr, e.Network())
+				}
+				if sw.markWrongNetworkDial(addr, e.Network()) {
+					return ErrPersistentPeerWrongNetwork{Addr: addr, Network: e.Network(), Dials: maxWrongNetworkDials}
+				}
+			}
 		}
 
 		// retry persistent peers after
@@ -808,6 +1041,11 @@ func (sw *Switch) addOutboundPeerWithConfig(
 		return err
 	}
 
+	sw.wrongNetwork.Delete(string(addr.ID))
+	if sw.addrBook != nil {
+		sw.addrBook.MarkNetwork(addr, nodeInfoNetwork(p.NodeInfo()))
+	}
+
 	if err := sw.addPeer(p); err != nil {
 		sw.transport.Cleanup(p)
 		if p.IsRunning() {
@@ -819,6 +1057,36 @@ func (sw *Switch) addOutboundPeerWithConfig(
 	return nil
 }
 
+// wrongNetworkDials counts the consecutive dials of a persistent peer whose
+// handshake revealed a different network.
+type wrongNetworkDials struct {
+	dials   int
+	network string
+}
+
+// markWrongNetworkDial records that the handshake with the peer at addr
+// revealed it is on the given network, different from ours. It returns true,
+// and logs prominently, if the peer is persistent and it happened
+// maxWrongNetworkDials times in a row, after which the peer is not dialed
+// anymore.
+func (sw *Switch) markWrongNetworkDial(addr *NetAddress, network string) bool {
+	if !sw.IsPeerPersistent(addr) {
+		return false
+	}
+	wn := &wrongNetworkDials{dials: 1, network: network}
+	if prev, ok := sw.wrongNetwork.Get(string(addr.ID)).(*wrongNetworkDials); ok && prev.network == network {
+		wn.dials = prev.dials + 1
+	}
+	sw.wrongNetwork.Set(string(addr.ID), wn)
+	if wn.dials < maxWrongNetworkDials {
+		return false
+	}
+	sw.Logger.Error("Persistent peer is on a different network, not dialing it anymore. "+
+		"Check the persistent_peers in the config",
+		"addr", addr, "network", network, "ourNetwork", nodeInfoNetwork(sw.nodeInfo), "dials", wn.dials)
+	return true
+}
+
 func (sw *Switch) filterPeer(p Peer) error {
 	// Avoid duplicate
 	if sw.peers.Has(p.ID()) {
@@ -892,6 +1160,10 @@ func (sw *Switch) addPeer(p Peer) error {
 		return err
 	}
 	sw.metrics.Peers.Add(float64(1))
+	sw.updateSubnetPeersMetric(p)
+	if sw.peerMetricsStore != nil {
+		sw.peerMetricsStore.PeerConnected(p.ID())
+	}
 	schema.WritePeerUpdate(sw.traceClient, string(p.ID()), schema.PeerJoin, "")
 
 	// Start all the reactor protocols on the peer.
@@ -204,11 +204,11 @@ func assertMsgReceivedWithTimeout(
 		select {
 		case <-ticker.C:
 			msgs := reactor.getMsgs(channel)
-			expectedBytes, err := proto.Marshal(msgs[0].Contents)
-			require.NoError(t, err)
-			gotBytes, err := proto.Marshal(msg)
-			require.NoError(t, err)
 			if len(msgs) > 0 {
+				expectedBytes, err := proto.Marshal(msgs[0].Contents)
+				require.NoError(t, err)
+				gotBytes, err := proto.Marshal(msg)
+				require.NoError(t, err)
 				if !bytes.Equal(expectedBytes, gotBytes) {
 					t.Fatalf("Unexpected message bytes. Wanted: %X, Got: %X", msg, msgs[0].Counter)
 				}
@@ -430,6 +430,88 @@ func TestSwitchStopsNonPersistentPeerOnError(t *testing.T) {
 	assert.False(p.IsRunning())
 }
 
+// banningAddrBook records the addresses marked bad.
+type banningAddrBook struct {
+	*AddrBookMock
+	mtx    cmtsync.Mutex
+	banned []*NetAddress
+}
+
+func (book *banningAddrBook) MarkBad(addr *NetAddress, _ time.Duration) {
+	book.mtx.Lock()
+	defer book.mtx.Unlock()
+	book.banned = append(book.banned, addr)
+}
+
+func TestSwitchStopsPeerExceedingMessageLimits(t *testing.T) {
+	sw1, sw2 := MakeSwitchPair(t, initSwitchFunc)
+	t.Cleanup(func() {
+		if err := sw1.Stop(); err != nil {
+			t.Error(err)
+		}
+		if err := sw2.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	p := sw1.Peers().List()[0]
+	oversized := &p2pproto.PexAddrs{Addrs: make([]p2pproto.NetAddress, p2pproto.MaxPexAddrs+1)}
+	for i := 0; i < maxMessageLimitViolations-1; i++ {
+		require.True(t, SendEnvelopeShim(p, Envelope{ChannelID: 0x00, Message: oversized}, sw1.Logger))
+	}
+	// the messages within the limits are still received
+	withinLimits := &p2pproto.PexAddrs{Addrs: make([]p2pproto.NetAddress, p2pproto.MaxPexAddrs)}
+	require.True(t, SendEnvelopeShim(p, Envelope{ChannelID: 0x01, Message: withinLimits}, sw1.Logger))
+	assertMsgReceivedWithTimeout(t, withinLimits, 0x01, sw2.Reactor("foo").(*TestReactor),
+		10*time.Millisecond, 5*time.Second)
+	assert.Equal(t, 1, sw2.Peers().Size())
+
+	// until the peer exceeds them once too many
+	require.True(t, SendEnvelopeShim(p, Envelope{ChannelID: 0x00, Message: oversized}, sw1.Logger))
+	assertNoPeersAfterTimeout(t, sw2, time.Second)
+	assert.Empty(t, sw2.Reactor("foo").(*TestReactor).getMsgs(0x00))
+}
+
+func TestSwitchBansPeerExceedingMessageLimits(t *testing.T) {
+	sw := MakeSwitch(cfg, 1, "testing", "123.123.123", initSwitchFunc)
+	book := &banningAddrBook{AddrBookMock: &AddrBookMock{
+		Addrs:    make(map[string]struct{}),
+		OurAddrs: make(map[string]struct{}),
+	}}
+	sw.SetAddrBook(book)
+	require.NoError(t, sw.Start())
+	t.Cleanup(func() {
+		if err := sw.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	// simulate remote peer
+	rp := &remotePeer{PrivKey: ed25519.GenPrivKey(), Config: cfg}
+	rp.Start()
+	defer rp.Stop()
+
+	p, err := sw.transport.Dial(*rp.Addr(), peerConfig{
+		chDescs:      sw.chDescs,
+		onPeerError:  sw.StopPeerForError,
+		isPersistent: sw.IsPeerPersistent,
+		reactorsByCh: sw.reactorsByCh,
+	})
+	require.NoError(t, err)
+	require.NoError(t, sw.addPeer(p))
+
+	sw.StopPeerForError(p, ErrPeerMessageLimits{
+		Violations: maxMessageLimitViolations,
+		Err:        p2pproto.ErrLimitExceeded,
+	})
+	assertNoPeersAfterTimeout(t, sw, 100*time.Millisecond)
+
+	book.mtx.Lock()
+	defer book.mtx.Unlock()
+	require.Len(t, book.banned, 1)
+	assert.Equal(t, rp.Addr(), book.banned[0])
+}
+
 func TestSwitchStopPeerForError(t *testing.T) {
 	s := httptest.NewServer(promhttp.Handler())
 	defer s.Close()
@@ -537,6 +619,56 @@ func TestSwitchReconnectsToOutboundPersistentPeer(t *testing.T) {
 	assert.Equal(t, 2, sw.Peers().Size())
 }
 
+func TestSwitchGivesUpOnPersistentPeerOnOtherNetwork(t *testing.T) {
+	sw := MakeSwitch(cfg, 1, "testing", "123.123.123", initSwitchFunc)
+	err := sw.Start()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := sw.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	// persistent peers on our network and on another one
+	rp := &remotePeer{PrivKey: ed25519.GenPrivKey(), Config: cfg}
+	rp.Start()
+	defer rp.Stop()
+	otherRp := &remotePeer{
+		PrivKey: ed25519.GenPrivKey(),
+		Config:  cfg,
+		// Use different interface to prevent duplicate IP filter
+		listenAddr: "127.0.0.2:0",
+		network:    "other",
+	}
+	otherRp.Start()
+	defer otherRp.Stop()
+	err = sw.AddPersistentPeers([]string{rp.Addr().String(), otherRp.Addr().String()})
+	require.NoError(t, err)
+
+	require.NoError(t, sw.DialPeerWithAddress(rp.Addr()))
+	assert.NotNil(t, sw.Peers().Get(rp.ID()))
+
+	err = sw.DialPeerWithAddress(otherRp.Addr())
+	require.Error(t, err)
+	rejected, ok := err.(ErrRejected)
+	require.True(t, ok, err)
+	assert.True(t, rejected.IsWrongNetwork())
+	assert.Equal(t, "other", rejected.Network())
+
+	// the switch keeps reconnecting until enough handshakes reveal the other
+	// network, and then refuses to dial the peer
+	require.Eventually(t, func() bool {
+		return !sw.reconnecting.Has(string(otherRp.ID()))
+	}, 3*(reconnectInterval+dialRandomizerIntervalMilliseconds*time.Millisecond), 100*time.Millisecond)
+	err = sw.DialPeerWithAddress(otherRp.Addr())
+	wrongNetworkErr, ok := err.(ErrPersistentPeerWrongNetwork)
+	require.True(t, ok, err)
+	assert.Equal(t, "other", wrongNetworkErr.Network)
+	assert.Equal(t, maxWrongNetworkDials, wrongNetworkErr.Dials)
+	assert.Nil(t, sw.Peers().Get(otherRp.ID()))
+	assert.Equal(t, 1, sw.Peers().Size())
+}
+
 func TestSwitchReconnectsToInboundPersistentPeer(t *testing.T) {
 	sw := MakeSwitch(cfg, 1, "testing", "123.123.123", initSwitchFunc)
 	err := sw.Start()
@@ -620,6 +752,31 @@ func TestSwitchFullConnectivity(t *testing.T) {
 	}
 }
 
+func TestSwitchCheckPeerDiversity(t *testing.T) {
+	sw := MakeSwitch(cfg, 1, "testing", "123.123.123", initSwitchFunc, SwitchPeerDiversity(NewPeerDiversity(1)))
+	addr := func(ip string) *NetAddress {
+		id := ed25519.GenPrivKey().PubKey().Address()
+		na, err := NewNetAddressString(fmt.Sprintf("%x@%s:26656", id, ip))
+		require.NoError(t, err)
+		return na
+	}
+
+	dialing := addr("45.1.2.1")
+	sw.dialing.Set(string(dialing.ID), dialing)
+
+	sameSubnet := addr("45.1.2.2")
+	assert.IsType(t, ErrPeerDiversity{}, sw.CheckPeerDiversity(sameSubnet))
+	assert.IsType(t, ErrPeerDiversity{}, sw.DialPeerWithAddress(sameSubnet))
+	assert.NoError(t, sw.CheckPeerDiversity(addr("45.1.3.1")))
+
+	// persistent and unconditional peers are exempt
+	require.NoError(t, sw.AddPersistentPeers([]string{sameSubnet.String()}))
+	assert.NoError(t, sw.CheckPeerDiversity(sameSubnet))
+	unconditional := addr("45.1.2.3")
+	require.NoError(t, sw.AddUnconditionalPeerIDs([]string{string(unconditional.ID)}))
+	assert.NoError(t, sw.CheckPeerDiversity(unconditional))
+}
+
 func TestSwitchAcceptRoutine(t *testing.T) {
 	cfg.MaxNumInboundPeers = 5
 
@@ -5,7 +5,6 @@ import (
 	"net"
 	"time"
 
-	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/crypto/ed25519"
 	"github.com/tendermint/tendermint/libs/log"
 	cmtnet "github.com/tendermint/tendermint/libs/net"
@@ -134,7 +133,7 @@ func Connect2Switches(switches []*Switch, i, j int) {
 }
 
 func (sw *Switch) addPeerWithConnection(conn net.Conn) error {
-	pc, err := testInboundPeerConn(conn, sw.config, sw.nodeKey.PrivKey)
+	pc, err := testInboundPeerConn(conn, sw.config, sw.nodeKey.Signer())
 	if err != nil {
 		if err := conn.Close(); err != nil {
 			sw.Logger.Error("Error closing connection", "err", err)
@@ -228,16 +227,16 @@ func MakeSwitch(
 func testInboundPeerConn(
 	conn net.Conn,
 	config *config.P2PConfig,
-	ourNodePrivKey crypto.PrivKey,
+	ourNodeSigner conn.Signer,
 ) (peerConn, error) {
-	return testPeerConn(conn, config, false, false, ourNodePrivKey, nil)
+	return testPeerConn(conn, config, false, false, ourNodeSigner, nil)
 }
 
 func testPeerConn(
 	rawConn net.Conn,
 	cfg *config.P2PConfig,
 	outbound, persistent bool,
-	ourNodePrivKey crypto.PrivKey,
+	ourNodeSigner conn.Signer,
 	socketAddr *NetAddress,
 ) (pc peerConn, err error) {
 	conn := rawConn
@@ -249,7 +248,7 @@ func testPeerConn(
 	}
 
 	// Encrypt connection
-	conn, err = upgradeSecretConn(conn, cfg.HandshakeTimeout, ourNodePrivKey)
+	conn, err = upgradeSecretConn(conn, cfg.HandshakeTimeout, ourNodeSigner)
 	if err != nil {
 		return pc, fmt.Errorf("error creating peer: %w", err)
 	}
@@ -306,7 +305,8 @@ func (book *AddrBookMock) OurAddress(addr *NetAddress) bool {
 	_, ok := book.OurAddrs[addr.String()]
 	return ok
 }
-func (book *AddrBookMock) MarkGood(ID) {}
+func (book *AddrBookMock) MarkGood(ID)                     {}
+func (book *AddrBookMock) MarkNetwork(*NetAddress, string) {}
 func (book *AddrBookMock) HasAddress(addr *NetAddress) bool {
 	_, ok := book.Addrs[addr.String()]
 	return ok
@@ -9,7 +9,6 @@ import (
 	"golang.org/x/net/netutil"
 
 	"github.com/gogo/protobuf/proto"
-	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/libs/protoio"
 	"github.com/tendermint/tendermint/p2p/conn"
 	"github.com/tendermint/tendermint/pkg/trace"
@@ -128,6 +127,12 @@ func MultiplexTransportResolver(resolver IPResolver) MultiplexTransportOption {
 	return func(mt *MultiplexTransport) { mt.resolver = resolver }
 }
 
+// MultiplexTransportSubnetLimiter sets the limiter for the rate of incoming
+// connections from a single IP subnet. Dialed connections are not limited.
+func MultiplexTransportSubnetLimiter(sl *SubnetLimiter) MultiplexTransportOption {
+	return func(mt *MultiplexTransport) { mt.subnetLimiter = sl }
+}
+
 // MultiplexTransportMaxIncomingConnections sets the maximum number of
 // simultaneous connections (incoming). Default: 0 (unlimited)
 func MultiplexTransportMaxIncomingConnections(n int) MultiplexTransportOption {
@@ -145,8 +150,9 @@ type MultiplexTransport struct {
 	closec  chan struct{}
 
 	// Lookup table for duplicate ip and id checks.
-	conns       ConnSet
-	connFilters []ConnFilterFunc
+	conns         ConnSet
+	connFilters   []ConnFilterFunc
+	subnetLimiter *SubnetLimiter
 
 	dialTimeout      time.Duration
 	filterTimeout    time.Duration
@@ -302,7 +308,8 @@ func (mt *MultiplexTransport) acceptPeers() {
 		}
 
 		// Connection upgrade and filtering should be asynchronous to avoid
-		// Head-of-line blocking[0].
+		// Head-of-line blocking[0], e.g. behind the signing of the handshake
+		// by a slow HSM.
 		// Reference:  https://github.com/tendermint/tendermint/issues/2047
 		//
 		// [0] https://en.wikipedia.org/wiki/Head-of-line_blocking
@@ -330,7 +337,10 @@ func (mt *MultiplexTransport) acceptPeers() {
 				netAddr    *NetAddress
 			)
 
-			err := mt.filterConn(c)
+			err := mt.limitSubnet(c)
+			if err == nil {
+				err = mt.filterConn(c)
+			}
 			if err == nil {
 				secretConn, nodeInfo, err = mt.upgrade(c, nil)
 				if err == nil {
@@ -365,6 +375,23 @@ func (mt *MultiplexTransport) cleanup(c net.Conn) error {
 	return c.Close()
 }
 
+// limitSubnet closes and rejects the incoming connection if the limit of
+// connections from its subnet has been reached.
+func (mt *MultiplexTransport) limitSubnet(c net.Conn) error {
+	if mt.subnetLimiter == nil {
+		return nil
+	}
+	tcpAddr, ok := c.RemoteAddr().(*net.TCPAddr)
+	if !ok {
+		return nil
+	}
+	if err := mt.subnetLimiter.Allow(NewNetAddressIPPort(tcpAddr.IP, uint16(tcpAddr.Port))); err != nil {
+		_ = c.Close()
+		return ErrRejected{conn: c, err: err, isFiltered: true}
+	}
+	return nil
+}
+
 func (mt *MultiplexTransport) filterConn(c net.Conn) (err error) {
 	defer func() {
 		if err != nil {
@@ -418,7 +445,7 @@ func (mt *MultiplexTransport) upgrade(
 		}
 	}()
 
-	secretConn, err = upgradeSecretConn(c, mt.handshakeTimeout, mt.nodeKey.PrivKey)
+	secretConn, err = upgradeSecretConn(c, mt.handshakeTimeout, mt.nodeKey.Signer())
 	if err != nil {
 		return nil, nil, ErrRejected{
 			conn:          c,
@@ -486,11 +513,14 @@ func (mt *MultiplexTransport) upgrade(
 	}
 
 	if err := mt.nodeInfo.CompatibleWith(nodeInfo); err != nil {
+		network := nodeInfoNetwork(nodeInfo)
 		return nil, nil, ErrRejected{
 			conn:           c,
 			err:            err,
 			id:             nodeInfo.ID(),
 			isIncompatible: true,
+			isWrongNetwork: network != nodeInfoNetwork(mt.nodeInfo),
+			network:        network,
 		}
 	}
 
@@ -573,6 +603,9 @@ func handshake(
 		}
 	}
 
+	if err := pbpeerNodeInfo.ValidateLimits(); err != nil {
+		return nil, err
+	}
 	peerNodeInfo, err := DefaultNodeInfoFromToProto(&pbpeerNodeInfo)
 	if err != nil {
 		return nil, err
@@ -584,13 +617,13 @@ func handshake(
 func upgradeSecretConn(
 	c net.Conn,
 	timeout time.Duration,
-	privKey crypto.PrivKey,
+	signer conn.Signer,
 ) (*conn.SecretConnection, error) {
 	if err := c.SetDeadline(time.Now().Add(timeout)); err != nil {
 		return nil, err
 	}
 
-	sc, err := conn.MakeSecretConnection(c, privKey)
+	sc, err := conn.MakeSecretConnection(c, signer)
 	if err != nil {
 		return nil, err
 	}
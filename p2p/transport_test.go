@@ -7,9 +7,13 @@ import (
 	"reflect"
 	"runtime"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/crypto/ed25519"
 	"github.com/tendermint/tendermint/libs/protoio"
 	"github.com/tendermint/tendermint/p2p/conn"
@@ -89,6 +93,57 @@ func TestTransportMultiplexConnFilter(t *testing.T) {
 	}
 }
 
+func TestTransportMultiplexSubnetLimiter(t *testing.T) {
+	pv := ed25519.GenPrivKey()
+	id := PubKeyToID(pv.PubKey())
+	mt := newMultiplexTransport(
+		testNodeInfo(id, "transport"),
+		NodeKey{
+			PrivKey: pv,
+		},
+	)
+
+	MultiplexTransportSubnetLimiter(NewSubnetLimiter(1, time.Minute))(mt)
+
+	addr, err := NewNetAddressString(IDAddressString(id, "127.0.0.1:0"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := mt.Listen(*addr); err != nil {
+		t.Fatal(err)
+	}
+	laddr := NewNetAddress(id, mt.listener.Addr())
+
+	// the first connection from the subnet is accepted
+	errc := make(chan error)
+	go testDialer(*laddr, errc)
+	if err := <-errc; err != nil {
+		t.Fatalf("dialer connection failed: %v", err)
+	}
+	if _, err := mt.Accept(peerConfig{}); err != nil {
+		t.Fatalf("connection failed: %v", err)
+	}
+
+	// the second one is refused
+	go func() {
+		_, err := laddr.Dial()
+		errc <- err
+	}()
+	if err := <-errc; err != nil {
+		t.Fatalf("connection failed: %v", err)
+	}
+
+	_, err = mt.Accept(peerConfig{})
+	if e, ok := err.(ErrRejected); ok {
+		if !e.IsFiltered() {
+			t.Errorf("expected peer to be filtered, got %v", err)
+		}
+	} else {
+		t.Errorf("expected ErrRejected, got %v", err)
+	}
+}
+
 func TestTransportMultiplexConnFilterTimeout(t *testing.T) {
 	mt := newMultiplexTransport(
 		emptyNodeInfo(),
@@ -355,6 +410,66 @@ func TestTransportMultiplexAcceptNonBlocking(t *testing.T) {
 	}
 }
 
+// slowSigner is a Signer whose first signature waits for release, like a slow
+// HSM.
+type slowSigner struct {
+	crypto.PrivKey
+	signed   atomic.Bool
+	signing  chan struct{}
+	released chan struct{}
+}
+
+func (s *slowSigner) Sign(msg []byte) ([]byte, error) {
+	if !s.signed.Swap(true) {
+		close(s.signing)
+		<-s.released
+	}
+	return s.PrivKey.Sign(msg)
+}
+
+func TestTransportMultiplexAcceptSlowSigner(t *testing.T) {
+	signer := &slowSigner{
+		PrivKey:  ed25519.GenPrivKey(),
+		signing:  make(chan struct{}),
+		released: make(chan struct{}),
+	}
+	nodeKey := NewSignerNodeKey(signer)
+	mt := newMultiplexTransport(testNodeInfo(nodeKey.ID(), "transport"), *nodeKey)
+	addr, err := NewNetAddressString(IDAddressString(nodeKey.ID(), "127.0.0.1:0"))
+	require.NoError(t, err)
+	require.NoError(t, mt.Listen(*addr))
+	defer mt.Close()
+	laddr := NewNetAddress(nodeKey.ID(), mt.listener.Addr())
+
+	// the handshake of the first peer waits for the signer
+	slowErrc := make(chan error, 1)
+	go testDialer(*laddr, slowErrc)
+	select {
+	case <-signer.signing:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the handshake of the first peer did not sign")
+	}
+
+	// while the second peer is accepted
+	fastErrc := make(chan error, 1)
+	go testDialer(*laddr, fastErrc)
+	select {
+	case err := <-fastErrc:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the second peer was not accepted while the signer was slow")
+	}
+	p, err := mt.Accept(peerConfig{})
+	require.NoError(t, err)
+	require.NoError(t, p.CloseConn())
+
+	close(signer.released)
+	require.NoError(t, <-slowErrc)
+	p, err = mt.Accept(peerConfig{})
+	require.NoError(t, err)
+	require.NoError(t, p.CloseConn())
+}
+
 func TestTransportMultiplexValidateNodeInfo(t *testing.T) {
 	mt := testSetupMultiplexTransport(t)
 
@@ -495,6 +610,9 @@ func TestTransportMultiplexRejectIncompatible(t *testing.T) {
 		if !e.IsIncompatible() {
 			t.Errorf("expected to reject incompatible, got %v", err)
 		}
+		if !e.IsWrongNetwork() || e.Network() != "incompatible-network" {
+			t.Errorf("expected to reject wrong network, got %v", err)
+		}
 	} else {
 		t.Errorf("expected ErrRejected, got %v", err)
 	}
@@ -8,12 +8,19 @@ import (
 
 type ChannelDescriptor = conn.ChannelDescriptor
 type ConnectionStatus = conn.ConnectionStatus
+type RelevanceTag = conn.RelevanceTag
 
 // Envelope contains a message with sender routing info.
 type Envelope struct {
 	Src       Peer          // sender (empty if outbound)
 	Message   proto.Message // message payload
 	ChannelID byte
+
+	// Bytes holds, on inbound envelopes, the encoding of the message as
+	// received, before unwrapping, for the reactors which check it. The p2p
+	// layer reuses its buffer once ReceiveEnvelope returns, so it must not be
+	// retained.
+	Bytes []byte
 }
 
 // Unwrapper is a Protobuf message that can contain a variety of inner messages
@@ -26,6 +33,17 @@ type Unwrapper interface {
 	Unwrap() (proto.Message, error)
 }
 
+// LimitValidator is a Protobuf message received from peers whose size is
+// bounded by limits, see the limits of the proto p2p package. If an inbound
+// message implements LimitValidator, the p2p layer drops it when it exceeds the
+// limits, before the reactors get to use it.
+type LimitValidator interface {
+	proto.Message
+
+	// ValidateLimits returns an error if the message exceeds the limits.
+	ValidateLimits() error
+}
+
 // Wrapper is a companion type to Unwrapper. It is a Protobuf message that can contain a variety of inner messages. The p2p layer will automatically wrap outbound messages so that the reactors do not have to do it themselves.
 type Wrapper interface {
 	proto.Message
@@ -37,4 +55,7 @@ type Wrapper interface {
 var (
 	_ Wrapper = &tmp2p.PexRequest{}
 	_ Wrapper = &tmp2p.PexAddrs{}
+
+	_ LimitValidator = &tmp2p.PexRequest{}
+	_ LimitValidator = &tmp2p.PexAddrs{}
 )
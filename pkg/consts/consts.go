@@ -30,6 +30,9 @@ const (
 
 	// NamespaceSize is the size of a namespace in bytes.
 	NamespaceSize = NamespaceIDSize + NamespaceVersionSize
+
+	// ShareSize is the size of a share in bytes.
+	ShareSize = 512
 )
 
 var (
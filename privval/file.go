@@ -266,6 +266,19 @@ func (pv *FilePV) SignProposal(chainID string, proposal *cmtproto.Proposal) erro
 	return nil
 }
 
+// SignValidatorAnnouncement signs the announcement of the node run by the
+// validator. Announcements take no part in consensus, so unlike votes and
+// proposals they are not recorded in the last sign state.
+// Implements types.ValidatorAnnouncementSigner.
+func (pv *FilePV) SignValidatorAnnouncement(chainID string, announcement *cmtproto.ValidatorAnnouncement) error {
+	sig, err := pv.Key.PrivKey.Sign(types.ValidatorAnnouncementSignBytes(chainID, announcement))
+	if err != nil {
+		return fmt.Errorf("error signing validator announcement: %v", err)
+	}
+	announcement.Signature = sig
+	return nil
+}
+
 // Save persists the FilePV to disk.
 func (pv *FilePV) Save() {
 	pv.Key.Save()
@@ -266,6 +266,30 @@ func TestSignProposal(t *testing.T) {
 	assert.Equal(sig, proposal.Signature)
 }
 
+func TestSignValidatorAnnouncement(t *testing.T) {
+	tempKeyFile, err := os.CreateTemp("", "priv_validator_key_")
+	require.Nil(t, err)
+	tempStateFile, err := os.CreateTemp("", "priv_validator_state_")
+	require.Nil(t, err)
+
+	privVal := GenFilePV(tempKeyFile.Name(), tempStateFile.Name())
+	pubKey, err := privVal.GetPubKey()
+	require.NoError(t, err)
+	vals := types.NewValidatorSet([]*types.Validator{types.NewValidator(pubKey, 10)})
+
+	announcement := &cmtproto.ValidatorAnnouncement{
+		ValidatorAddress: pubKey.Address(),
+		NodeId:           "0123456789abcdef0123456789abcdef01234567",
+		ChainID:          "mychainid",
+	}
+	require.NoError(t, privVal.SignValidatorAnnouncement("mychainid", announcement))
+	assert.NoError(t, types.VerifyValidatorAnnouncement("mychainid", vals, announcement))
+
+	// the announcement does not touch the last sign state
+	assert.Zero(t, privVal.LastSignState.Height)
+	assert.Nil(t, privVal.LastSignState.SignBytes)
+}
+
 func TestDifferByTimestamp(t *testing.T) {
 	tempKeyFile, err := os.CreateTemp("", "priv_validator_key_")
 	require.Nil(t, err)
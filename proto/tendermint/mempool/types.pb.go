@@ -24,6 +24,9 @@ const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 
 type Txs struct {
 	Txs [][]byte `protobuf:"bytes,1,rep,name=txs,proto3" json:"txs,omitempty"`
+	// Optional metadata of the txs, in the same order. Absent when the sender
+	// does not support it.
+	Metadata []*TxMetadata `protobuf:"bytes,2,rep,name=metadata,proto3" json:"metadata,omitempty"`
 }
 
 func (m *Txs) Reset()         { *m = Txs{} }
@@ -66,6 +69,59 @@ func (m *Txs) GetTxs() [][]byte {
 	return nil
 }
 
+func (m *Txs) GetMetadata() []*TxMetadata {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+// TxMetadata is the metadata gossiped along with a tx.
+type TxMetadata struct {
+	// The height of the chain when the sender first saw the tx, 0 if unknown.
+	OriginHeight int64 `protobuf:"varint,1,opt,name=origin_height,json=originHeight,proto3" json:"origin_height,omitempty"`
+}
+
+func (m *TxMetadata) Reset()         { *m = TxMetadata{} }
+func (m *TxMetadata) String() string { return proto.CompactTextString(m) }
+func (*TxMetadata) ProtoMessage()    {}
+func (*TxMetadata) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2af51926fdbcbc05, []int{1}
+}
+func (m *TxMetadata) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TxMetadata) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TxMetadata.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *TxMetadata) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TxMetadata.Merge(m, src)
+}
+func (m *TxMetadata) XXX_Size() int {
+	return m.Size()
+}
+func (m *TxMetadata) XXX_DiscardUnknown() {
+	xxx_messageInfo_TxMetadata.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TxMetadata proto.InternalMessageInfo
+
+func (m *TxMetadata) GetOriginHeight() int64 {
+	if m != nil {
+		return m.OriginHeight
+	}
+	return 0
+}
+
 type SeenTx struct {
 	TxKey []byte `protobuf:"bytes,1,opt,name=tx_key,json=txKey,proto3" json:"tx_key,omitempty"`
 }
@@ -74,7 +130,7 @@ func (m *SeenTx) Reset()         { *m = SeenTx{} }
 func (m *SeenTx) String() string { return proto.CompactTextString(m) }
 func (*SeenTx) ProtoMessage()    {}
 func (*SeenTx) Descriptor() ([]byte, []int) {
-	return fileDescriptor_2af51926fdbcbc05, []int{1}
+	return fileDescriptor_2af51926fdbcbc05, []int{2}
 }
 func (m *SeenTx) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -118,7 +174,7 @@ func (m *WantTx) Reset()         { *m = WantTx{} }
 func (m *WantTx) String() string { return proto.CompactTextString(m) }
 func (*WantTx) ProtoMessage()    {}
 func (*WantTx) Descriptor() ([]byte, []int) {
-	return fileDescriptor_2af51926fdbcbc05, []int{2}
+	return fileDescriptor_2af51926fdbcbc05, []int{3}
 }
 func (m *WantTx) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -156,7 +212,6 @@ func (m *WantTx) GetTxKey() []byte {
 
 type Message struct {
 	// Types that are valid to be assigned to Sum:
-	//
 	//	*Message_Txs
 	//	*Message_SeenTx
 	//	*Message_WantTx
@@ -167,7 +222,7 @@ func (m *Message) Reset()         { *m = Message{} }
 func (m *Message) String() string { return proto.CompactTextString(m) }
 func (*Message) ProtoMessage()    {}
 func (*Message) Descriptor() ([]byte, []int) {
-	return fileDescriptor_2af51926fdbcbc05, []int{3}
+	return fileDescriptor_2af51926fdbcbc05, []int{4}
 }
 func (m *Message) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -255,6 +310,7 @@ func (*Message) XXX_OneofWrappers() []interface{} {
 
 func init() {
 	proto.RegisterType((*Txs)(nil), "tendermint.mempool.Txs")
+	proto.RegisterType((*TxMetadata)(nil), "tendermint.mempool.TxMetadata")
 	proto.RegisterType((*SeenTx)(nil), "tendermint.mempool.SeenTx")
 	proto.RegisterType((*WantTx)(nil), "tendermint.mempool.WantTx")
 	proto.RegisterType((*Message)(nil), "tendermint.mempool.Message")
@@ -263,24 +319,27 @@ func init() {
 func init() { proto.RegisterFile("tendermint/mempool/types.proto", fileDescriptor_2af51926fdbcbc05) }
 
 var fileDescriptor_2af51926fdbcbc05 = []byte{
-	// 268 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x92, 0x2b, 0x49, 0xcd, 0x4b,
-	0x49, 0x2d, 0xca, 0xcd, 0xcc, 0x2b, 0xd1, 0xcf, 0x4d, 0xcd, 0x2d, 0xc8, 0xcf, 0xcf, 0xd1, 0x2f,
-	0xa9, 0x2c, 0x48, 0x2d, 0xd6, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x12, 0x42, 0xc8, 0xeb, 0x41,
-	0xe5, 0x95, 0xc4, 0xb9, 0x98, 0x43, 0x2a, 0x8a, 0x85, 0x04, 0xb8, 0x98, 0x4b, 0x2a, 0x8a, 0x25,
-	0x18, 0x15, 0x98, 0x35, 0x78, 0x82, 0x40, 0x4c, 0x25, 0x79, 0x2e, 0xb6, 0xe0, 0xd4, 0xd4, 0xbc,
-	0x90, 0x0a, 0x21, 0x51, 0x2e, 0xb6, 0x92, 0x8a, 0xf8, 0xec, 0xd4, 0x4a, 0x09, 0x46, 0x05, 0x46,
-	0x0d, 0x9e, 0x20, 0xd6, 0x92, 0x0a, 0xef, 0xd4, 0x4a, 0x90, 0x82, 0xf0, 0xc4, 0xbc, 0x12, 0xdc,
-	0x0a, 0x56, 0x33, 0x72, 0xb1, 0xfb, 0xa6, 0x16, 0x17, 0x27, 0xa6, 0xa7, 0x0a, 0x69, 0xc3, 0xcc,
-	0x67, 0xd4, 0xe0, 0x36, 0x12, 0xd7, 0xc3, 0x74, 0x88, 0x5e, 0x48, 0x45, 0xb1, 0x07, 0x03, 0xd8,
-	0x6a, 0x21, 0x53, 0x2e, 0xf6, 0xe2, 0xd4, 0xd4, 0xbc, 0xf8, 0x92, 0x0a, 0x09, 0x26, 0xb0, 0x06,
-	0x29, 0x6c, 0x1a, 0x20, 0xae, 0xf3, 0x60, 0x08, 0x62, 0x2b, 0x86, 0xb8, 0xd3, 0x94, 0x8b, 0xbd,
-	0x3c, 0x31, 0xaf, 0x04, 0xa4, 0x8d, 0x19, 0xb7, 0x36, 0x88, 0x9b, 0x41, 0xda, 0xca, 0xc1, 0x2c,
-	0x27, 0x56, 0x2e, 0xe6, 0xe2, 0xd2, 0x5c, 0xa7, 0xe0, 0x13, 0x8f, 0xe4, 0x18, 0x2f, 0x3c, 0x92,
-	0x63, 0x7c, 0xf0, 0x48, 0x8e, 0x71, 0xc2, 0x63, 0x39, 0x86, 0x0b, 0x8f, 0xe5, 0x18, 0x6e, 0x3c,
-	0x96, 0x63, 0x88, 0xb2, 0x4c, 0xcf, 0x2c, 0xc9, 0x28, 0x4d, 0xd2, 0x4b, 0xce, 0xcf, 0xd5, 0x47,
-	0x0a, 0x61, 0x24, 0x26, 0x38, 0x78, 0xf5, 0x31, 0x43, 0x3f, 0x89, 0x0d, 0x2c, 0x63, 0x0c, 0x08,
-	0x00, 0x00, 0xff, 0xff, 0x3b, 0xd2, 0x5d, 0x18, 0x9a, 0x01, 0x00, 0x00,
+	// 319 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x91, 0xc1, 0x6a, 0xf2, 0x40,
+	0x14, 0x85, 0x33, 0x0e, 0xc6, 0x9f, 0xab, 0x3f, 0x94, 0x81, 0xd2, 0xd0, 0xc5, 0x54, 0xec, 0x26,
+	0x50, 0x48, 0xa8, 0xc5, 0x45, 0xbb, 0x74, 0x25, 0x14, 0x37, 0x51, 0x28, 0x74, 0x23, 0xb1, 0x5e,
+	0x62, 0x68, 0x33, 0x11, 0xe7, 0x8a, 0xe3, 0x5b, 0xf4, 0x3d, 0xfa, 0x22, 0x5d, 0xba, 0xec, 0xb2,
+	0x98, 0x17, 0x29, 0x49, 0x6c, 0x15, 0xd4, 0xdd, 0xe5, 0x9c, 0xf3, 0xc1, 0xb9, 0x1c, 0x90, 0x84,
+	0x6a, 0x82, 0xf3, 0x24, 0x56, 0xe4, 0x27, 0x98, 0xcc, 0xd2, 0xf4, 0xcd, 0xa7, 0xd5, 0x0c, 0xb5,
+	0x37, 0x9b, 0xa7, 0x94, 0x0a, 0xb1, 0xf3, 0xbd, 0xad, 0xdf, 0x1a, 0x00, 0x1f, 0x1a, 0x2d, 0xce,
+	0x80, 0x93, 0xd1, 0x0e, 0x6b, 0x72, 0xb7, 0x11, 0xe4, 0xa7, 0x78, 0x80, 0x7f, 0x09, 0x52, 0x38,
+	0x09, 0x29, 0x74, 0x2a, 0x4d, 0xee, 0xd6, 0xdb, 0xd2, 0x3b, 0xe4, 0xbd, 0xa1, 0xe9, 0x6f, 0x53,
+	0xc1, 0x5f, 0xbe, 0x75, 0x0b, 0xb0, 0xd3, 0xc5, 0x35, 0xfc, 0x4f, 0xe7, 0x71, 0x14, 0xab, 0xd1,
+	0x14, 0xe3, 0x68, 0x4a, 0x0e, 0x6b, 0x32, 0x97, 0x07, 0x8d, 0x52, 0xec, 0x15, 0x5a, 0xeb, 0x0a,
+	0xec, 0x01, 0xa2, 0x1a, 0x1a, 0x71, 0x0e, 0x36, 0x99, 0xd1, 0x2b, 0xae, 0x8a, 0x5c, 0x23, 0xa8,
+	0x92, 0x79, 0xc4, 0x55, 0x1e, 0x78, 0x0a, 0x15, 0x9d, 0x0e, 0x7c, 0x30, 0xa8, 0xf5, 0x51, 0xeb,
+	0x30, 0x42, 0x71, 0xf3, 0xfb, 0x0e, 0x73, 0xeb, 0xed, 0x8b, 0xe3, 0xbd, 0x75, 0xcf, 0x2a, 0x3f,
+	0xed, 0x40, 0x4d, 0x23, 0xaa, 0x11, 0x19, 0xa7, 0x52, 0x00, 0x97, 0xc7, 0x80, 0xb2, 0x5d, 0xcf,
+	0x0a, 0x6c, 0x5d, 0xf6, 0xec, 0x40, 0x6d, 0x19, 0x2a, 0xca, 0x31, 0x7e, 0x1a, 0x2b, 0x3b, 0xe7,
+	0xd8, 0xb2, 0xb8, 0xba, 0x55, 0xe0, 0x7a, 0x91, 0x74, 0x07, 0x9f, 0x1b, 0xc9, 0xd6, 0x1b, 0xc9,
+	0xbe, 0x37, 0x92, 0xbd, 0x67, 0xd2, 0x5a, 0x67, 0xd2, 0xfa, 0xca, 0xa4, 0xf5, 0x7c, 0x1f, 0xc5,
+	0x34, 0x5d, 0x8c, 0xbd, 0x97, 0x34, 0xf1, 0xf7, 0x06, 0xdd, 0x3b, 0x8b, 0x35, 0xfd, 0xc3, 0xb1,
+	0xc7, 0x76, 0xe1, 0xdc, 0xfd, 0x0c, 0x00, 0x54, 0x8a, 0xcb, 0x45, 0x09, 0x02, 0x00, 0x00,
 }
 
 func (m *Txs) Marshal() (dAtA []byte, err error) {
@@ -303,6 +362,20 @@ func (m *Txs) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.Metadata) > 0 {
+		for iNdEx := len(m.Metadata) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Metadata[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintTypes(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
 	if len(m.Txs) > 0 {
 		for iNdEx := len(m.Txs) - 1; iNdEx >= 0; iNdEx-- {
 			i -= len(m.Txs[iNdEx])
@@ -315,6 +388,34 @@ func (m *Txs) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *TxMetadata) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TxMetadata) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TxMetadata) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.OriginHeight != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.OriginHeight))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *SeenTx) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -493,6 +594,24 @@ func (m *Txs) Size() (n int) {
 			n += 1 + l + sovTypes(uint64(l))
 		}
 	}
+	if len(m.Metadata) > 0 {
+		for _, e := range m.Metadata {
+			l = e.Size()
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *TxMetadata) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.OriginHeight != 0 {
+		n += 1 + sovTypes(uint64(m.OriginHeight))
+	}
 	return n
 }
 
@@ -638,6 +757,109 @@ func (m *Txs) Unmarshal(dAtA []byte) error {
 			m.Txs = append(m.Txs, make([]byte, postIndex-iNdEx))
 			copy(m.Txs[len(m.Txs)-1], dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Metadata", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Metadata = append(m.Metadata, &TxMetadata{})
+			if err := m.Metadata[len(m.Metadata)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TxMetadata) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TxMetadata: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TxMetadata: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OriginHeight", wireType)
+			}
+			m.OriginHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.OriginHeight |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -0,0 +1,118 @@
+package p2p
+
+import (
+	"errors"
+	"fmt"
+)
+
+// The limits of the p2p wire messages received from peers, checked with
+// ValidateLimits once a message is unmarshalled and before it is used. The
+// unmarshalling allocates every element of a repeated field as long as it
+// parses, so without them a hostile peer could make a node allocate and process
+// far more than honest peers ever send. They are all kept here so that they can
+// be audited together.
+//
+// The packets of the connections are not listed: their size is bounded by the
+// configured maximum packet size, which the connections check when reading.
+const (
+	// MaxPexAddrs is the maximum number of addresses of a PexAddrs message. The
+	// PEX reactor never sends more at once.
+	MaxPexAddrs = 250
+
+	// MaxNodeIDLength is the maximum length of a node ID, the hex encoding of a
+	// 20 byte address.
+	MaxNodeIDLength = 40
+	// MaxIPLength is the maximum length of the IP of a NetAddress, enough for
+	// the textual form of any IPv6 address.
+	MaxIPLength = 45
+	// MaxPort is the maximum port of a NetAddress.
+	MaxPort = 1<<16 - 1
+
+	// MaxNodeInfoChannels is the maximum number of channels a node info lists.
+	MaxNodeInfoChannels = 16
+	// MaxNodeInfoStringLength is the maximum length of the strings of a node
+	// info, e.g. its moniker or listen address.
+	MaxNodeInfoStringLength = 1024
+
+	// MaxAuthSigKeyLength and MaxAuthSigLength are the maximum lengths of the
+	// public key and the signature of an AuthSigMessage.
+	MaxAuthSigKeyLength = 128
+	MaxAuthSigLength    = 128
+)
+
+// ErrLimitExceeded is returned by ValidateLimits when a message exceeds one of
+// the limits.
+var ErrLimitExceeded = errors.New("p2p message exceeds limit")
+
+func limitError(field string, size, limit int) error {
+	return fmt.Errorf("%w: %s of %d exceeds the maximum of %d", ErrLimitExceeded, field, size, limit)
+}
+
+// ValidateLimits checks that the address does not exceed the limits.
+func (m *NetAddress) ValidateLimits() error {
+	if len(m.ID) > MaxNodeIDLength {
+		return limitError("node ID length", len(m.ID), MaxNodeIDLength)
+	}
+	if len(m.IP) > MaxIPLength {
+		return limitError("IP length", len(m.IP), MaxIPLength)
+	}
+	if m.Port > MaxPort {
+		return limitError("port", int(m.Port), MaxPort)
+	}
+	return nil
+}
+
+// ValidateLimits implements the limits check of the p2p wire messages.
+func (m *PexRequest) ValidateLimits() error {
+	return nil
+}
+
+// ValidateLimits checks that the message holds at most MaxPexAddrs addresses,
+// each within the limits.
+func (m *PexAddrs) ValidateLimits() error {
+	if len(m.Addrs) > MaxPexAddrs {
+		return limitError("number of addresses", len(m.Addrs), MaxPexAddrs)
+	}
+	for i := range m.Addrs {
+		if err := m.Addrs[i].ValidateLimits(); err != nil {
+			return fmt.Errorf("address %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// ValidateLimits checks that the node info does not exceed the limits.
+func (m *DefaultNodeInfo) ValidateLimits() error {
+	if len(m.Channels) > MaxNodeInfoChannels {
+		return limitError("number of channels", len(m.Channels), MaxNodeInfoChannels)
+	}
+	for _, f := range []struct {
+		name  string
+		value string
+	}{
+		{"node ID length", m.DefaultNodeID},
+		{"listen address length", m.ListenAddr},
+		{"network length", m.Network},
+		{"version length", m.Version},
+		{"moniker length", m.Moniker},
+		{"tx index length", m.Other.TxIndex},
+		{"RPC address length", m.Other.RPCAddress},
+	} {
+		if len(f.value) > MaxNodeInfoStringLength {
+			return limitError(f.name, len(f.value), MaxNodeInfoStringLength)
+		}
+	}
+	return nil
+}
+
+// ValidateLimits checks that the public key and the signature do not exceed
+// the limits.
+func (m *AuthSigMessage) ValidateLimits() error {
+	if size := m.PubKey.Size(); size > MaxAuthSigKeyLength {
+		return limitError("public key length", size, MaxAuthSigKeyLength)
+	}
+	if len(m.Sig) > MaxAuthSigLength {
+		return limitError("signature length", len(m.Sig), MaxAuthSigLength)
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+package p2p
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/proto/tendermint/crypto"
+)
+
+func pexAddrs(n int) *PexAddrs {
+	addrs := make([]NetAddress, n)
+	for i := range addrs {
+		addrs[i] = NetAddress{ID: strings.Repeat("a", MaxNodeIDLength), IP: "127.0.0.1", Port: 26656}
+	}
+	return &PexAddrs{Addrs: addrs}
+}
+
+func TestValidateLimits(t *testing.T) {
+	tests := []struct {
+		name     string
+		msg      interface{ ValidateLimits() error }
+		exceeded bool
+	}{
+		{"pex request", &PexRequest{}, false},
+		{"no addresses", pexAddrs(0), false},
+		{"max addresses", pexAddrs(MaxPexAddrs), false},
+		{"too many addresses", pexAddrs(MaxPexAddrs + 1), true},
+		{"max IP", &PexAddrs{Addrs: []NetAddress{{IP: strings.Repeat("f", MaxIPLength)}}}, false},
+		{"long IP", &PexAddrs{Addrs: []NetAddress{{IP: strings.Repeat("f", MaxIPLength+1)}}}, true},
+		{"long node ID", &NetAddress{ID: strings.Repeat("a", MaxNodeIDLength+1)}, true},
+		{"max port", &NetAddress{Port: MaxPort}, false},
+		{"large port", &NetAddress{Port: MaxPort + 1}, true},
+		{"max node info", &DefaultNodeInfo{
+			Channels: make([]byte, MaxNodeInfoChannels),
+			Moniker:  strings.Repeat("m", MaxNodeInfoStringLength),
+			Other:    DefaultNodeInfoOther{RPCAddress: strings.Repeat("r", MaxNodeInfoStringLength)},
+		}, false},
+		{"too many channels", &DefaultNodeInfo{Channels: make([]byte, MaxNodeInfoChannels+1)}, true},
+		{"long moniker", &DefaultNodeInfo{Moniker: strings.Repeat("m", MaxNodeInfoStringLength+1)}, true},
+		{"long tx index", &DefaultNodeInfo{
+			Other: DefaultNodeInfoOther{TxIndex: strings.Repeat("t", MaxNodeInfoStringLength+1)},
+		}, true},
+		{"auth sig", &AuthSigMessage{
+			PubKey: crypto.PublicKey{Sum: &crypto.PublicKey_Ed25519{Ed25519: make([]byte, 32)}},
+			Sig:    make([]byte, MaxAuthSigLength),
+		}, false},
+		{"long signature", &AuthSigMessage{Sig: make([]byte, MaxAuthSigLength+1)}, true},
+		{"long public key", &AuthSigMessage{
+			PubKey: crypto.PublicKey{Sum: &crypto.PublicKey_Ed25519{Ed25519: make([]byte, MaxAuthSigKeyLength)}},
+		}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.msg.ValidateLimits()
+			if tt.exceeded {
+				assert.ErrorIs(t, err, ErrLimitExceeded)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+// FuzzPexMessageLimits checks that the PEX messages accepted by ValidateLimits
+// are within the limits, whatever a peer sends.
+func FuzzPexMessageLimits(f *testing.F) {
+	for _, n := range []int{0, 1, MaxPexAddrs, MaxPexAddrs + 1, 4 * MaxPexAddrs} {
+		bz, err := pexAddrs(n).Wrap().(*Message).Marshal()
+		require.NoError(f, err)
+		f.Add(bz)
+	}
+	bz, err := (&PexAddrs{Addrs: []NetAddress{{IP: strings.Repeat("1", MaxIPLength+1), Port: MaxPort + 1}}}).Wrap().(*Message).Marshal()
+	require.NoError(f, err)
+	f.Add(bz)
+	// addresses are repeated fields of 2 bytes each when empty
+	f.Add([]byte(strings.Repeat("\x0a\x00", MaxPexAddrs+1)))
+
+	f.Fuzz(func(t *testing.T, bz []byte) {
+		msg := new(Message)
+		if err := msg.Unmarshal(bz); err != nil {
+			return
+		}
+		um, err := msg.Unwrap()
+		if err != nil {
+			return
+		}
+		v, ok := um.(interface{ ValidateLimits() error })
+		require.True(t, ok, "%T does not implement ValidateLimits", um)
+		if err := v.ValidateLimits(); err != nil {
+			require.ErrorIs(t, err, ErrLimitExceeded)
+			return
+		}
+		if addrs, ok := um.(*PexAddrs); ok {
+			require.LessOrEqual(t, len(addrs.Addrs), MaxPexAddrs)
+			for _, addr := range addrs.Addrs {
+				require.LessOrEqual(t, len(addr.ID), MaxNodeIDLength)
+				require.LessOrEqual(t, len(addr.IP), MaxIPLength)
+				require.LessOrEqual(t, addr.Port, uint32(MaxPort))
+			}
+		}
+	})
+}
+
+// FuzzNodeInfoLimits checks that the node infos accepted by ValidateLimits are
+// within the limits, whatever a peer sends during the handshake.
+func FuzzNodeInfoLimits(f *testing.F) {
+	for _, info := range []*DefaultNodeInfo{
+		{},
+		{Channels: make([]byte, MaxNodeInfoChannels), Moniker: strings.Repeat("m", MaxNodeInfoStringLength)},
+		{Channels: make([]byte, MaxNodeInfoChannels+1)},
+		{Network: strings.Repeat("n", MaxNodeInfoStringLength+1)},
+	} {
+		bz, err := info.Marshal()
+		require.NoError(f, err)
+		f.Add(bz)
+	}
+
+	f.Fuzz(func(t *testing.T, bz []byte) {
+		info := new(DefaultNodeInfo)
+		if err := info.Unmarshal(bz); err != nil {
+			return
+		}
+		if err := info.ValidateLimits(); err != nil {
+			require.ErrorIs(t, err, ErrLimitExceeded)
+			return
+		}
+		require.LessOrEqual(t, len(info.Channels), MaxNodeInfoChannels)
+		for _, s := range []string{
+			info.DefaultNodeID, info.ListenAddr, info.Network, info.Version, info.Moniker,
+			info.Other.TxIndex, info.Other.RPCAddress,
+		} {
+			require.LessOrEqual(t, len(s), MaxNodeInfoStringLength)
+		}
+	})
+}
@@ -152,6 +152,7 @@ type DefaultNodeInfo struct {
 	Channels        []byte               `protobuf:"bytes,6,opt,name=channels,proto3" json:"channels,omitempty"`
 	Moniker         string               `protobuf:"bytes,7,opt,name=moniker,proto3" json:"moniker,omitempty"`
 	Other           DefaultNodeInfoOther `protobuf:"bytes,8,opt,name=other,proto3" json:"other"`
+	ExternalAddr    string               `protobuf:"bytes,9,opt,name=external_addr,json=externalAddr,proto3" json:"external_addr,omitempty"`
 }
 
 func (m *DefaultNodeInfo) Reset()         { *m = DefaultNodeInfo{} }
@@ -243,6 +244,13 @@ func (m *DefaultNodeInfo) GetOther() DefaultNodeInfoOther {
 	return DefaultNodeInfoOther{}
 }
 
+func (m *DefaultNodeInfo) GetExternalAddr() string {
+	if m != nil {
+		return m.ExternalAddr
+	}
+	return ""
+}
+
 type DefaultNodeInfoOther struct {
 	TxIndex    string `protobuf:"bytes,1,opt,name=tx_index,json=txIndex,proto3" json:"tx_index,omitempty"`
 	RPCAddress string `protobuf:"bytes,2,opt,name=rpc_address,json=rpcAddress,proto3" json:"rpc_address,omitempty"`
@@ -305,37 +313,39 @@ func init() {
 func init() { proto.RegisterFile("tendermint/p2p/types.proto", fileDescriptor_c8a29e659aeca578) }
 
 var fileDescriptor_c8a29e659aeca578 = []byte{
-	// 479 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x52, 0x3d, 0x8f, 0xda, 0x40,
-	0x10, 0xc5, 0xc6, 0x7c, 0xdc, 0x10, 0x8e, 0xcb, 0x0a, 0x45, 0x3e, 0x0a, 0x1b, 0xa1, 0x14, 0x54,
-	0x20, 0x39, 0x4a, 0x91, 0x2e, 0x21, 0x34, 0x34, 0x77, 0xd6, 0x2a, 0x4a, 0x91, 0xc6, 0x02, 0xef,
-	0x1e, 0xac, 0x30, 0xbb, 0xab, 0xf5, 0x5e, 0x42, 0xfe, 0x45, 0x7e, 0xd6, 0x95, 0x57, 0xa6, 0xb2,
-	0x22, 0x53, 0xe6, 0x4f, 0x44, 0x5e, 0xfb, 0x12, 0x1f, 0x4a, 0x37, 0x6f, 0xbe, 0xde, 0xcc, 0xd3,
-	0x83, 0x91, 0xa6, 0x9c, 0x50, 0x75, 0x60, 0x5c, 0xcf, 0x65, 0x20, 0xe7, 0xfa, 0xbb, 0xa4, 0xe9,
-	0x4c, 0x2a, 0xa1, 0x05, 0xba, 0xfc, 0x57, 0x9b, 0xc9, 0x40, 0x8e, 0x86, 0x5b, 0xb1, 0x15, 0xa6,
-	0x34, 0x2f, 0xa2, 0xb2, 0x6b, 0x12, 0x02, 0xdc, 0x50, 0xfd, 0x81, 0x10, 0x45, 0xd3, 0x14, 0xbd,
-	0x02, 0x9b, 0x11, 0xd7, 0x1a, 0x5b, 0xd3, 0x8b, 0x45, 0x3b, 0xcf, 0x7c, 0x7b, 0xb5, 0xc4, 0x36,
-	0x23, 0x26, 0x2f, 0x5d, 0xbb, 0x96, 0x0f, 0xb1, 0xcd, 0x24, 0x42, 0xe0, 0x48, 0xa1, 0xb4, 0xdb,
-	0x1c, 0x5b, 0xd3, 0x3e, 0x36, 0xf1, 0xe4, 0x13, 0x0c, 0xc2, 0x62, 0x75, 0x2c, 0x92, 0xcf, 0x54,
-	0xa5, 0x4c, 0x70, 0x74, 0x0d, 0x4d, 0x19, 0x48, 0xb3, 0xd7, 0x59, 0x74, 0xf2, 0xcc, 0x6f, 0x86,
-	0x41, 0x88, 0x8b, 0x1c, 0x1a, 0x42, 0x6b, 0x93, 0x88, 0x78, 0x6f, 0x96, 0x3b, 0xb8, 0x04, 0xe8,
-	0x0a, 0x9a, 0x6b, 0x29, 0xcd, 0x5a, 0x07, 0x17, 0xe1, 0xe4, 0xb7, 0x0d, 0x83, 0x25, 0xbd, 0x5b,
-	0xdf, 0x27, 0xfa, 0x46, 0x10, 0xba, 0xe2, 0x77, 0x02, 0x85, 0x70, 0x25, 0x2b, 0xa6, 0xe8, 0x6b,
-	0x49, 0x65, 0x38, 0x7a, 0x81, 0x3f, 0x7b, 0xfe, 0xfc, 0xec, 0xec, 0xa2, 0x85, 0xf3, 0x90, 0xf9,
-	0x0d, 0x3c, 0x90, 0x67, 0x87, 0xbe, 0x83, 0x01, 0x29, 0x49, 0x22, 0x2e, 0x08, 0x8d, 0x18, 0xa9,
-	0x9e, 0x7e, 0x99, 0x67, 0x7e, 0xbf, 0xce, 0xbf, 0xc4, 0x7d, 0x52, 0x83, 0x04, 0xf9, 0xd0, 0x4b,
-	0x58, 0xaa, 0x29, 0x8f, 0xd6, 0x84, 0x28, 0x73, 0xfa, 0x05, 0x86, 0x32, 0x55, 0xc8, 0x8b, 0x5c,
-	0xe8, 0x70, 0xaa, 0xbf, 0x09, 0xb5, 0x77, 0x1d, 0x53, 0x7c, 0x82, 0x45, 0xe5, 0xe9, 0xfc, 0x56,
-	0x59, 0xa9, 0x20, 0x1a, 0x41, 0x37, 0xde, 0xad, 0x39, 0xa7, 0x49, 0xea, 0xb6, 0xc7, 0xd6, 0xf4,
-	0x05, 0xfe, 0x8b, 0x8b, 0xa9, 0x83, 0xe0, 0x6c, 0x4f, 0x95, 0xdb, 0x29, 0xa7, 0x2a, 0x88, 0xde,
-	0x43, 0x4b, 0xe8, 0x1d, 0x55, 0x6e, 0xd7, 0x88, 0xf1, 0xfa, 0x5c, 0x8c, 0x33, 0x1d, 0x6f, 0x8b,
-	0xde, 0x4a, 0x91, 0x72, 0x70, 0xb2, 0x81, 0xe1, 0xff, 0x9a, 0xd0, 0x35, 0x74, 0xf5, 0x31, 0x62,
-	0x9c, 0xd0, 0x63, 0xe9, 0x12, 0xdc, 0xd1, 0xc7, 0x55, 0x01, 0xd1, 0x1c, 0x7a, 0x4a, 0xc6, 0xe6,
-	0x79, 0x9a, 0xa6, 0x95, 0x6c, 0x97, 0x79, 0xe6, 0x03, 0x0e, 0x3f, 0x56, 0xfe, 0xc2, 0xa0, 0x64,
-	0x5c, 0xc5, 0x8b, 0xdb, 0x87, 0xdc, 0xb3, 0x1e, 0x73, 0xcf, 0xfa, 0x95, 0x7b, 0xd6, 0x8f, 0x93,
-	0xd7, 0x78, 0x3c, 0x79, 0x8d, 0x9f, 0x27, 0xaf, 0xf1, 0xe5, 0xed, 0x96, 0xe9, 0xdd, 0xfd, 0x66,
-	0x16, 0x8b, 0xc3, 0xbc, 0x66, 0xf0, 0xba, 0xd7, 0x8d, 0x8d, 0x9f, 0x9b, 0x7f, 0xd3, 0x36, 0xd9,
-	0x37, 0x7f, 0x02, 0x00, 0x00, 0xff, 0xff, 0x6c, 0xb3, 0x68, 0x97, 0x15, 0x03, 0x00, 0x00,
+	// 497 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x53, 0x3d, 0x8f, 0x1a, 0x31,
+	0x10, 0x65, 0x61, 0x8f, 0x8f, 0xe1, 0x38, 0x2e, 0x16, 0x8a, 0xf6, 0x28, 0x76, 0x11, 0x49, 0x41,
+	0x05, 0x12, 0x51, 0x8a, 0x74, 0x09, 0xa1, 0xa1, 0xb9, 0x5b, 0x59, 0x51, 0x8a, 0x34, 0x08, 0xd6,
+	0x3e, 0xb0, 0x58, 0x6c, 0xcb, 0xeb, 0x4b, 0xc8, 0xbf, 0xb8, 0x9f, 0x75, 0xe5, 0x95, 0xa9, 0x50,
+	0xb4, 0xfc, 0x91, 0xc8, 0xf6, 0x92, 0x70, 0x28, 0xdd, 0xbc, 0x37, 0xf6, 0x7b, 0x33, 0x4f, 0x36,
+	0x74, 0x35, 0xe5, 0x84, 0xaa, 0x2d, 0xe3, 0x7a, 0x24, 0xc7, 0x72, 0xa4, 0x7f, 0x4a, 0x9a, 0x0d,
+	0xa5, 0x12, 0x5a, 0xa0, 0xab, 0x7f, 0xbd, 0xa1, 0x1c, 0xcb, 0x6e, 0x67, 0x25, 0x56, 0xc2, 0xb6,
+	0x46, 0xa6, 0x72, 0xa7, 0xfa, 0x31, 0xc0, 0x2d, 0xd5, 0x9f, 0x08, 0x51, 0x34, 0xcb, 0xd0, 0x6b,
+	0x28, 0x33, 0x12, 0x78, 0x3d, 0x6f, 0xd0, 0x98, 0x54, 0xf3, 0x7d, 0x54, 0x9e, 0x4d, 0x71, 0x99,
+	0x11, 0xcb, 0xcb, 0xa0, 0x7c, 0xc2, 0xc7, 0xb8, 0xcc, 0x24, 0x42, 0xe0, 0x4b, 0xa1, 0x74, 0x50,
+	0xe9, 0x79, 0x83, 0x16, 0xb6, 0x75, 0xff, 0x0b, 0xb4, 0x63, 0x23, 0x9d, 0x88, 0xf4, 0x2b, 0x55,
+	0x19, 0x13, 0x1c, 0xdd, 0x40, 0x45, 0x8e, 0xa5, 0xd5, 0xf5, 0x27, 0xb5, 0x7c, 0x1f, 0x55, 0xe2,
+	0x71, 0x8c, 0x0d, 0x87, 0x3a, 0x70, 0xb1, 0x4c, 0x45, 0xb2, 0xb1, 0xe2, 0x3e, 0x76, 0x00, 0x5d,
+	0x43, 0x65, 0x21, 0xa5, 0x95, 0xf5, 0xb1, 0x29, 0xfb, 0x8f, 0x15, 0x68, 0x4f, 0xe9, 0xfd, 0xe2,
+	0x21, 0xd5, 0xb7, 0x82, 0xd0, 0x19, 0xbf, 0x17, 0x28, 0x86, 0x6b, 0x59, 0x38, 0xcd, 0xbf, 0x3b,
+	0x2b, 0xeb, 0xd1, 0x1c, 0x47, 0xc3, 0x97, 0xcb, 0x0f, 0xcf, 0x26, 0x9a, 0xf8, 0x4f, 0xfb, 0xa8,
+	0x84, 0xdb, 0xf2, 0x6c, 0xd0, 0x0f, 0xd0, 0x26, 0xce, 0x64, 0xce, 0x05, 0xa1, 0x73, 0x46, 0x8a,
+	0xa5, 0x5f, 0xe5, 0xfb, 0xa8, 0x75, 0xea, 0x3f, 0xc5, 0x2d, 0x72, 0x02, 0x09, 0x8a, 0xa0, 0x99,
+	0xb2, 0x4c, 0x53, 0x3e, 0x5f, 0x10, 0xa2, 0xec, 0xe8, 0x0d, 0x0c, 0x8e, 0x32, 0xf1, 0xa2, 0x00,
+	0x6a, 0x9c, 0xea, 0x1f, 0x42, 0x6d, 0x02, 0xdf, 0x36, 0x8f, 0xd0, 0x74, 0x8e, 0xe3, 0x5f, 0xb8,
+	0x4e, 0x01, 0x51, 0x17, 0xea, 0xc9, 0x7a, 0xc1, 0x39, 0x4d, 0xb3, 0xa0, 0xda, 0xf3, 0x06, 0x97,
+	0xf8, 0x2f, 0x36, 0xb7, 0xb6, 0x82, 0xb3, 0x0d, 0x55, 0x41, 0xcd, 0xdd, 0x2a, 0x20, 0xfa, 0x08,
+	0x17, 0x42, 0xaf, 0xa9, 0x0a, 0xea, 0x36, 0x8c, 0xb7, 0xe7, 0x61, 0x9c, 0xe5, 0x78, 0x67, 0xce,
+	0x16, 0x89, 0xb8, 0x8b, 0xe8, 0x0d, 0xb4, 0xe8, 0x4e, 0x53, 0xc5, 0x17, 0xa9, 0x5b, 0xa7, 0x61,
+	0x1d, 0x2e, 0x8f, 0xa4, 0x59, 0xa8, 0xbf, 0x84, 0xce, 0xff, 0x94, 0xd0, 0x0d, 0xd4, 0xf5, 0x6e,
+	0xce, 0x38, 0xa1, 0x3b, 0xf7, 0x94, 0x70, 0x4d, 0xef, 0x66, 0x06, 0xa2, 0x11, 0x34, 0x95, 0x4c,
+	0xac, 0x24, 0xcd, 0xb2, 0x22, 0xdb, 0xab, 0x7c, 0x1f, 0x01, 0x8e, 0x3f, 0x17, 0x8f, 0x10, 0x83,
+	0x92, 0x49, 0x51, 0x4f, 0xee, 0x9e, 0xf2, 0xd0, 0x7b, 0xce, 0x43, 0xef, 0x77, 0x1e, 0x7a, 0x8f,
+	0x87, 0xb0, 0xf4, 0x7c, 0x08, 0x4b, 0xbf, 0x0e, 0x61, 0xe9, 0xdb, 0xfb, 0x15, 0xd3, 0xeb, 0x87,
+	0xe5, 0x30, 0x11, 0xdb, 0xd1, 0xc9, 0x2f, 0x38, 0xfd, 0x10, 0xf6, 0xad, 0xbf, 0xfc, 0x21, 0xcb,
+	0xaa, 0x65, 0xdf, 0xfd, 0x09, 0x00, 0x00, 0xff, 0xff, 0x39, 0x9b, 0x41, 0xdb, 0x3a, 0x03, 0x00,
+	0x00,
 }
 
 func (m *NetAddress) Marshal() (dAtA []byte, err error) {
@@ -438,6 +448,13 @@ func (m *DefaultNodeInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.ExternalAddr) > 0 {
+		i -= len(m.ExternalAddr)
+		copy(dAtA[i:], m.ExternalAddr)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.ExternalAddr)))
+		i--
+		dAtA[i] = 0x4a
+	}
 	{
 		size, err := m.Other.MarshalToSizedBuffer(dAtA[:i])
 		if err != nil {
@@ -623,6 +640,10 @@ func (m *DefaultNodeInfo) Size() (n int) {
 	}
 	l = m.Other.Size()
 	n += 1 + l + sovTypes(uint64(l))
+	l = len(m.ExternalAddr)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
 	return n
 }
 
@@ -1178,6 +1199,38 @@ func (m *DefaultNodeInfo) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExternalAddr", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ExternalAddr = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -251,6 +251,114 @@ func (m *ABCIResponsesInfo) GetHeight() int64 {
 	return 0
 }
 
+// RoundProposer records the proposer of a round of a height and whether the
+// node observed a valid proposal from it.
+type RoundProposer struct {
+	Round            int32  `protobuf:"varint,1,opt,name=round,proto3" json:"round,omitempty"`
+	ProposerAddress  []byte `protobuf:"bytes,2,opt,name=proposer_address,json=proposerAddress,proto3" json:"proposer_address,omitempty"`
+	ProposalObserved bool   `protobuf:"varint,3,opt,name=proposal_observed,json=proposalObserved,proto3" json:"proposal_observed,omitempty"`
+}
+
+func (m *RoundProposer) Reset()         { *m = RoundProposer{} }
+func (m *RoundProposer) String() string { return proto.CompactTextString(m) }
+func (*RoundProposer) ProtoMessage()    {}
+func (*RoundProposer) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ccfacf933f22bf93, []int{4}
+}
+func (m *RoundProposer) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RoundProposer) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RoundProposer.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RoundProposer) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RoundProposer.Merge(m, src)
+}
+func (m *RoundProposer) XXX_Size() int {
+	return m.Size()
+}
+func (m *RoundProposer) XXX_DiscardUnknown() {
+	xxx_messageInfo_RoundProposer.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RoundProposer proto.InternalMessageInfo
+
+func (m *RoundProposer) GetRound() int32 {
+	if m != nil {
+		return m.Round
+	}
+	return 0
+}
+
+func (m *RoundProposer) GetProposerAddress() []byte {
+	if m != nil {
+		return m.ProposerAddress
+	}
+	return nil
+}
+
+func (m *RoundProposer) GetProposalObserved() bool {
+	if m != nil {
+		return m.ProposalObserved
+	}
+	return false
+}
+
+// ProposerRecord records the proposers of the rounds of a committed height
+// that the node took part in.
+type ProposerRecord struct {
+	Rounds []RoundProposer `protobuf:"bytes,1,rep,name=rounds,proto3" json:"rounds"`
+}
+
+func (m *ProposerRecord) Reset()         { *m = ProposerRecord{} }
+func (m *ProposerRecord) String() string { return proto.CompactTextString(m) }
+func (*ProposerRecord) ProtoMessage()    {}
+func (*ProposerRecord) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ccfacf933f22bf93, []int{5}
+}
+func (m *ProposerRecord) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ProposerRecord) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ProposerRecord.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ProposerRecord) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ProposerRecord.Merge(m, src)
+}
+func (m *ProposerRecord) XXX_Size() int {
+	return m.Size()
+}
+func (m *ProposerRecord) XXX_DiscardUnknown() {
+	xxx_messageInfo_ProposerRecord.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ProposerRecord proto.InternalMessageInfo
+
+func (m *ProposerRecord) GetRounds() []RoundProposer {
+	if m != nil {
+		return m.Rounds
+	}
+	return nil
+}
+
 type Version struct {
 	Consensus version.Consensus `protobuf:"bytes,1,opt,name=consensus,proto3" json:"consensus"`
 	Software  string            `protobuf:"bytes,2,opt,name=software,proto3" json:"software,omitempty"`
@@ -260,7 +368,7 @@ func (m *Version) Reset()         { *m = Version{} }
 func (m *Version) String() string { return proto.CompactTextString(m) }
 func (*Version) ProtoMessage()    {}
 func (*Version) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ccfacf933f22bf93, []int{4}
+	return fileDescriptor_ccfacf933f22bf93, []int{6}
 }
 func (m *Version) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -330,13 +438,16 @@ type State struct {
 	LastResultsHash []byte `protobuf:"bytes,12,opt,name=last_results_hash,json=lastResultsHash,proto3" json:"last_results_hash,omitempty"`
 	// the latest AppHash we've received from calling abci.Commit()
 	AppHash []byte `protobuf:"bytes,13,opt,name=app_hash,json=appHash,proto3" json:"app_hash,omitempty"`
+	// The evidence constraints declared by the application, which the evidence
+	// params updates are checked against, nil if it declared none.
+	EvidenceConstraints *types.EvidenceConstraints `protobuf:"bytes,15,opt,name=evidence_constraints,json=evidenceConstraints,proto3" json:"evidence_constraints,omitempty"`
 }
 
 func (m *State) Reset()         { *m = State{} }
 func (m *State) String() string { return proto.CompactTextString(m) }
 func (*State) ProtoMessage()    {}
 func (*State) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ccfacf933f22bf93, []int{5}
+	return fileDescriptor_ccfacf933f22bf93, []int{7}
 }
 func (m *State) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -463,11 +574,20 @@ func (m *State) GetAppHash() []byte {
 	return nil
 }
 
+func (m *State) GetEvidenceConstraints() *types.EvidenceConstraints {
+	if m != nil {
+		return m.EvidenceConstraints
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*ABCIResponses)(nil), "tendermint.state.ABCIResponses")
 	proto.RegisterType((*ValidatorsInfo)(nil), "tendermint.state.ValidatorsInfo")
 	proto.RegisterType((*ConsensusParamsInfo)(nil), "tendermint.state.ConsensusParamsInfo")
 	proto.RegisterType((*ABCIResponsesInfo)(nil), "tendermint.state.ABCIResponsesInfo")
+	proto.RegisterType((*RoundProposer)(nil), "tendermint.state.RoundProposer")
+	proto.RegisterType((*ProposerRecord)(nil), "tendermint.state.ProposerRecord")
 	proto.RegisterType((*Version)(nil), "tendermint.state.Version")
 	proto.RegisterType((*State)(nil), "tendermint.state.State")
 }
@@ -475,58 +595,66 @@ func init() {
 func init() { proto.RegisterFile("tendermint/state/types.proto", fileDescriptor_ccfacf933f22bf93) }
 
 var fileDescriptor_ccfacf933f22bf93 = []byte{
-	// 805 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x55, 0xcd, 0x8e, 0xe3, 0x44,
-	0x10, 0x8e, 0xc9, 0x6e, 0x7e, 0xca, 0x93, 0x64, 0xb7, 0x07, 0x21, 0x6f, 0x96, 0x75, 0xb2, 0xe1,
-	0x47, 0x23, 0x0e, 0x8e, 0xb4, 0x1c, 0x10, 0x97, 0x95, 0xd6, 0x09, 0xb0, 0x91, 0x56, 0x08, 0x3c,
-	0xa3, 0x39, 0x70, 0xb1, 0x3a, 0x71, 0x8f, 0x6d, 0x91, 0xd8, 0x96, 0xbb, 0x13, 0x86, 0x07, 0xe0,
-	0x3e, 0x57, 0xde, 0x68, 0x8e, 0x73, 0x44, 0x1c, 0x06, 0xc8, 0xbc, 0x08, 0xea, 0x1f, 0xdb, 0x9d,
-	0x84, 0x91, 0x06, 0xed, 0xad, 0x5d, 0xf5, 0xd5, 0x57, 0x5f, 0x55, 0x57, 0xb5, 0xe1, 0x63, 0x46,
-	0x92, 0x80, 0xe4, 0xab, 0x38, 0x61, 0x63, 0xca, 0x30, 0x23, 0x63, 0xf6, 0x6b, 0x46, 0xa8, 0x93,
-	0xe5, 0x29, 0x4b, 0xd1, 0x93, 0xca, 0xeb, 0x08, 0x6f, 0xff, 0xc3, 0x30, 0x0d, 0x53, 0xe1, 0x1c,
-	0xf3, 0x93, 0xc4, 0xf5, 0x9f, 0x6b, 0x2c, 0x78, 0xbe, 0x88, 0x75, 0x92, 0xbe, 0x9e, 0x42, 0xd8,
-	0x77, 0xbc, 0xc3, 0x03, 0xef, 0x06, 0x2f, 0xe3, 0x00, 0xb3, 0x34, 0x57, 0x88, 0x17, 0x07, 0x88,
-	0x0c, 0xe7, 0x78, 0x55, 0x10, 0xd8, 0x9a, 0x7b, 0x43, 0x72, 0x1a, 0xa7, 0xc9, 0x4e, 0x82, 0x41,
-	0x98, 0xa6, 0xe1, 0x92, 0x8c, 0xc5, 0xd7, 0x7c, 0x7d, 0x31, 0x66, 0xf1, 0x8a, 0x50, 0x86, 0x57,
-	0x99, 0x04, 0x8c, 0xfe, 0x34, 0xa0, 0xf3, 0xc6, 0x9d, 0xcc, 0x3c, 0x42, 0xb3, 0x34, 0xa1, 0x84,
-	0xa2, 0x09, 0x98, 0x01, 0x59, 0xc6, 0x1b, 0x92, 0xfb, 0xec, 0x92, 0x5a, 0xc6, 0xb0, 0x7e, 0x62,
-	0xbe, 0x1a, 0x39, 0x5a, 0x33, 0x78, 0x91, 0x4e, 0x11, 0x30, 0x95, 0xd8, 0xb3, 0x4b, 0x0f, 0x82,
-	0xe2, 0x48, 0xd1, 0x6b, 0x68, 0x93, 0x24, 0xf0, 0xe7, 0xcb, 0x74, 0xf1, 0xb3, 0xf5, 0xc1, 0xd0,
-	0x38, 0x31, 0x5f, 0xbd, 0xbc, 0x97, 0xe2, 0x9b, 0x24, 0x70, 0x39, 0xd0, 0x6b, 0x11, 0x75, 0x42,
-	0x53, 0x30, 0xe7, 0x24, 0x8c, 0x13, 0xc5, 0x50, 0x17, 0x0c, 0x9f, 0xdc, 0xcb, 0xe0, 0x72, 0xac,
-	0xe4, 0x80, 0x79, 0x79, 0x1e, 0xfd, 0x66, 0x40, 0xf7, 0xbc, 0x68, 0x28, 0x9d, 0x25, 0x17, 0x29,
-	0x9a, 0x40, 0xa7, 0x6c, 0xb1, 0x4f, 0x09, 0xb3, 0x0c, 0x41, 0x6d, 0xeb, 0xd4, 0xb2, 0x81, 0x65,
-	0xe0, 0x29, 0x61, 0xde, 0xd1, 0x46, 0xfb, 0x42, 0x0e, 0x1c, 0x2f, 0x31, 0x65, 0x7e, 0x44, 0xe2,
-	0x30, 0x62, 0xfe, 0x22, 0xc2, 0x49, 0x48, 0x02, 0x51, 0x67, 0xdd, 0x7b, 0xca, 0x5d, 0x6f, 0x85,
-	0x67, 0x22, 0x1d, 0xa3, 0xdf, 0x0d, 0x38, 0x9e, 0x70, 0x9d, 0x09, 0x5d, 0xd3, 0x1f, 0xc4, 0xfd,
-	0x09, 0x31, 0x1e, 0x3c, 0x59, 0x14, 0x66, 0x5f, 0xde, 0xab, 0xd2, 0xf3, 0xf2, 0x50, 0xcf, 0x1e,
-	0x81, 0xfb, 0xe8, 0xfa, 0x76, 0x50, 0xf3, 0x7a, 0x8b, 0x5d, 0xf3, 0xff, 0xd6, 0x46, 0xe1, 0xe9,
-	0xce, 0xfd, 0x0b, 0x61, 0xdf, 0x42, 0x97, 0xf7, 0xd7, 0xcf, 0x0b, 0xab, 0x92, 0x35, 0x70, 0xf6,
-	0x77, 0xc2, 0xd9, 0x09, 0xf6, 0x3a, 0x3c, 0xac, 0x9a, 0xa5, 0x8f, 0xa0, 0x21, 0x75, 0xa8, 0xfc,
-	0xea, 0x6b, 0x14, 0x41, 0xf3, 0x5c, 0x4e, 0x2b, 0x7a, 0x03, 0xed, 0xb2, 0x04, 0x95, 0xe5, 0x85,
-	0x9e, 0x45, 0x4d, 0x75, 0x55, 0xbe, 0x2a, 0xbc, 0x8a, 0x42, 0x7d, 0x68, 0xd1, 0xf4, 0x82, 0xfd,
-	0x82, 0x73, 0x22, 0xf2, 0xb4, 0xbd, 0xf2, 0x7b, 0xf4, 0x4f, 0x03, 0x1e, 0x9f, 0x72, 0xa1, 0xe8,
-	0x6b, 0x68, 0x2a, 0x2e, 0x95, 0xe6, 0xd9, 0x61, 0x31, 0x4a, 0x94, 0x4a, 0x51, 0xe0, 0xd1, 0xe7,
-	0xd0, 0x5a, 0x44, 0x38, 0x4e, 0xfc, 0x58, 0x36, 0xb2, 0xed, 0x9a, 0xdb, 0xdb, 0x41, 0x73, 0xc2,
-	0x6d, 0xb3, 0xa9, 0xd7, 0x14, 0xce, 0x59, 0x80, 0x3e, 0x83, 0x6e, 0x9c, 0xc4, 0x2c, 0xc6, 0x4b,
-	0xd5, 0x7e, 0xab, 0x2b, 0xca, 0xee, 0x28, 0xab, 0xec, 0x3c, 0xfa, 0x02, 0xc4, 0x3d, 0xc8, 0xd9,
-	0x2e, 0x90, 0x75, 0x81, 0xec, 0x71, 0x87, 0x18, 0x5e, 0x85, 0xf5, 0xa0, 0xa3, 0x61, 0xe3, 0xc0,
-	0x7a, 0x74, 0xa8, 0x5d, 0xce, 0x87, 0x88, 0x9a, 0x4d, 0xdd, 0x63, 0xae, 0x7d, 0x7b, 0x3b, 0x30,
-	0xdf, 0x15, 0x54, 0xb3, 0xa9, 0x67, 0x96, 0xbc, 0xb3, 0x00, 0xbd, 0x83, 0x9e, 0xc6, 0xc9, 0x5f,
-	0x04, 0xeb, 0xb1, 0x60, 0xed, 0x3b, 0xf2, 0xb9, 0x70, 0x8a, 0xe7, 0xc2, 0x39, 0x2b, 0x9e, 0x0b,
-	0xb7, 0xc5, 0x69, 0xaf, 0xfe, 0x1a, 0x18, 0x5e, 0xa7, 0xe4, 0xe2, 0x5e, 0xf4, 0x1d, 0xf4, 0x12,
-	0x72, 0xc9, 0xfc, 0x72, 0x43, 0xa8, 0xd5, 0x78, 0xd0, 0x4e, 0x75, 0x79, 0x58, 0xb5, 0x9e, 0xe8,
-	0x35, 0x80, 0xc6, 0xd1, 0x7c, 0x10, 0x87, 0x16, 0xc1, 0x85, 0x88, 0xb2, 0x34, 0x92, 0xd6, 0xc3,
-	0x84, 0xf0, 0x30, 0x4d, 0xc8, 0x04, 0x6c, 0x7d, 0x85, 0x2a, 0xbe, 0x72, 0x9b, 0xda, 0xe2, 0xb2,
-	0x9e, 0x57, 0xdb, 0x54, 0x45, 0xab, 0xbd, 0xfa, 0xcf, 0xdd, 0x86, 0xf7, 0xdc, 0xed, 0xef, 0xe1,
-	0xd3, 0x9d, 0xdd, 0xde, 0xe3, 0x2f, 0xe5, 0x99, 0x42, 0xde, 0x50, 0x5b, 0xf6, 0x5d, 0xa2, 0x42,
-	0x63, 0x31, 0x88, 0x39, 0xa1, 0xeb, 0x25, 0xa3, 0x7e, 0x84, 0x69, 0x64, 0x1d, 0x0d, 0x8d, 0x93,
-	0x23, 0x39, 0x88, 0x9e, 0xb4, 0xbf, 0xc5, 0x34, 0x42, 0xcf, 0xa0, 0x85, 0xb3, 0x4c, 0x42, 0x3a,
-	0x02, 0xd2, 0xc4, 0x59, 0xc6, 0x5d, 0xee, 0x8f, 0xd7, 0x5b, 0xdb, 0xb8, 0xd9, 0xda, 0xc6, 0xdf,
-	0x5b, 0xdb, 0xb8, 0xba, 0xb3, 0x6b, 0x37, 0x77, 0x76, 0xed, 0x8f, 0x3b, 0xbb, 0xf6, 0xd3, 0x57,
-	0x61, 0xcc, 0xa2, 0xf5, 0xdc, 0x59, 0xa4, 0xab, 0xb1, 0xfe, 0x23, 0xab, 0x8e, 0xf2, 0x6f, 0xba,
-	0xff, 0x1f, 0x9e, 0x37, 0x84, 0xfd, 0xcb, 0x7f, 0x03, 0x00, 0x00, 0xff, 0xff, 0x2b, 0x1a, 0xb9,
-	0x2e, 0xa2, 0x07, 0x00, 0x00,
+	// 929 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x56, 0x4f, 0x6f, 0x1b, 0x45,
+	0x14, 0xcf, 0x92, 0x26, 0xb6, 0x9f, 0x63, 0x3b, 0x99, 0x44, 0x68, 0xeb, 0x52, 0x3b, 0x98, 0x82,
+	0x0a, 0x48, 0x6b, 0xa9, 0x1c, 0x10, 0x07, 0x90, 0x62, 0xbb, 0x50, 0x4b, 0x15, 0x2d, 0xd3, 0xaa,
+	0x48, 0x5c, 0x56, 0xe3, 0xdd, 0x89, 0x77, 0x85, 0xbd, 0xb3, 0xda, 0x19, 0x9b, 0x70, 0xe0, 0xc8,
+	0xbd, 0x57, 0xbe, 0x0a, 0x9f, 0xa0, 0xc7, 0x1e, 0x11, 0x87, 0x80, 0x9c, 0x2f, 0x82, 0xe6, 0xdf,
+	0x7a, 0x1c, 0xb7, 0x52, 0x50, 0x6f, 0x33, 0xef, 0xfd, 0xde, 0xef, 0xfd, 0xe6, 0xcd, 0x9b, 0xb7,
+	0x0b, 0x1f, 0x08, 0x9a, 0xc5, 0xb4, 0x98, 0xa7, 0x99, 0xe8, 0x73, 0x41, 0x04, 0xed, 0x8b, 0x5f,
+	0x73, 0xca, 0x83, 0xbc, 0x60, 0x82, 0xa1, 0xc3, 0xb5, 0x37, 0x50, 0xde, 0xf6, 0xc9, 0x94, 0x4d,
+	0x99, 0x72, 0xf6, 0xe5, 0x4a, 0xe3, 0xda, 0x77, 0x1c, 0x16, 0x32, 0x89, 0x52, 0x97, 0xa4, 0xed,
+	0xa6, 0x50, 0xf6, 0x0d, 0xef, 0xe9, 0x96, 0x77, 0x49, 0x66, 0x69, 0x4c, 0x04, 0x2b, 0x0c, 0xe2,
+	0xee, 0x16, 0x22, 0x27, 0x05, 0x99, 0x5b, 0x82, 0x8e, 0xe3, 0x5e, 0xd2, 0x82, 0xa7, 0x2c, 0xdb,
+	0x48, 0xd0, 0x9d, 0x32, 0x36, 0x9d, 0xd1, 0xbe, 0xda, 0x4d, 0x16, 0xe7, 0x7d, 0x91, 0xce, 0x29,
+	0x17, 0x64, 0x9e, 0x6b, 0x40, 0xef, 0x6f, 0x0f, 0x1a, 0x67, 0x83, 0xe1, 0x18, 0x53, 0x9e, 0xb3,
+	0x8c, 0x53, 0x8e, 0x86, 0x50, 0x8f, 0xe9, 0x2c, 0x5d, 0xd2, 0x22, 0x14, 0x17, 0xdc, 0xf7, 0x4e,
+	0x77, 0xef, 0xd7, 0x1f, 0xf4, 0x02, 0xa7, 0x18, 0xf2, 0x90, 0x81, 0x0d, 0x18, 0x69, 0xec, 0xf3,
+	0x0b, 0x0c, 0xb1, 0x5d, 0x72, 0xf4, 0x0d, 0xd4, 0x68, 0x16, 0x87, 0x93, 0x19, 0x8b, 0x7e, 0xf6,
+	0xdf, 0x3b, 0xf5, 0xee, 0xd7, 0x1f, 0x7c, 0xf8, 0x56, 0x8a, 0x87, 0x59, 0x3c, 0x90, 0x40, 0x5c,
+	0xa5, 0x66, 0x85, 0x46, 0x50, 0x9f, 0xd0, 0x69, 0x9a, 0x19, 0x86, 0x5d, 0xc5, 0xf0, 0xd1, 0x5b,
+	0x19, 0x06, 0x12, 0xab, 0x39, 0x60, 0x52, 0xae, 0x7b, 0xbf, 0x7b, 0xd0, 0x7c, 0x61, 0x0b, 0xca,
+	0xc7, 0xd9, 0x39, 0x43, 0x43, 0x68, 0x94, 0x25, 0x0e, 0x39, 0x15, 0xbe, 0xa7, 0xa8, 0x3b, 0x2e,
+	0xb5, 0x2e, 0x60, 0x19, 0xf8, 0x8c, 0x0a, 0x7c, 0xb0, 0x74, 0x76, 0x28, 0x80, 0xe3, 0x19, 0xe1,
+	0x22, 0x4c, 0x68, 0x3a, 0x4d, 0x44, 0x18, 0x25, 0x24, 0x9b, 0xd2, 0x58, 0x9d, 0x73, 0x17, 0x1f,
+	0x49, 0xd7, 0x23, 0xe5, 0x19, 0x6a, 0x47, 0xef, 0x0f, 0x0f, 0x8e, 0x87, 0x52, 0x67, 0xc6, 0x17,
+	0xfc, 0xa9, 0xba, 0x3f, 0x25, 0x06, 0xc3, 0x61, 0x64, 0xcd, 0xa1, 0xbe, 0x57, 0xdf, 0xdb, 0x2e,
+	0x96, 0xd6, 0x73, 0x8d, 0x60, 0x70, 0xeb, 0xd5, 0x65, 0x77, 0x07, 0xb7, 0xa2, 0x4d, 0xf3, 0xff,
+	0xd6, 0xc6, 0xe1, 0x68, 0xe3, 0xfe, 0x95, 0xb0, 0x6f, 0xa1, 0x29, 0xeb, 0x1b, 0x16, 0xd6, 0x6a,
+	0x64, 0x75, 0x83, 0xeb, 0x6f, 0x22, 0xd8, 0x08, 0xc6, 0x0d, 0x19, 0x56, 0x6e, 0xd1, 0xfb, 0xb0,
+	0xaf, 0x75, 0x98, 0xfc, 0x66, 0xd7, 0xfb, 0x0d, 0x1a, 0x98, 0x2d, 0xb2, 0xf8, 0x69, 0xc1, 0x72,
+	0xc6, 0x69, 0x81, 0x4e, 0x60, 0xaf, 0x90, 0x06, 0x95, 0x67, 0x0f, 0xeb, 0x0d, 0xfa, 0x14, 0x0e,
+	0x73, 0x83, 0x08, 0x49, 0x1c, 0x17, 0x94, 0x73, 0x45, 0x74, 0x80, 0x5b, 0xd6, 0x7e, 0xa6, 0xcd,
+	0xe8, 0x73, 0x38, 0xd2, 0x26, 0x32, 0x0b, 0xd9, 0x84, 0xd3, 0x62, 0x49, 0x63, 0xd5, 0x36, 0x55,
+	0x7c, 0x68, 0x1d, 0x4f, 0x8c, 0xbd, 0xf7, 0x04, 0x9a, 0x36, 0x33, 0xa6, 0x11, 0x2b, 0x62, 0xf4,
+	0x35, 0xec, 0xab, 0x94, 0xb6, 0xdf, 0xdf, 0x70, 0xd0, 0x0d, 0xc1, 0xa6, 0xfa, 0x26, 0xa8, 0x97,
+	0x40, 0xe5, 0x85, 0x7e, 0x7d, 0xe8, 0x0c, 0x6a, 0xe5, 0x95, 0x98, 0xaa, 0xdd, 0x75, 0xc9, 0xcc,
+	0x2b, 0x5d, 0x5f, 0xa7, 0xa1, 0x5a, 0x47, 0xa1, 0x36, 0x54, 0x39, 0x3b, 0x17, 0xbf, 0x90, 0x82,
+	0xaa, 0xe3, 0xd6, 0x70, 0xb9, 0xef, 0xfd, 0x59, 0x81, 0xbd, 0x67, 0x52, 0x0f, 0xfa, 0x0a, 0x2a,
+	0x86, 0xcb, 0xa4, 0xb9, 0xbd, 0xad, 0xd9, 0x88, 0x32, 0x29, 0x2c, 0x1e, 0x7d, 0x02, 0xd5, 0x28,
+	0x21, 0x69, 0x16, 0xa6, 0xba, 0x31, 0x6a, 0x83, 0xfa, 0xea, 0xb2, 0x5b, 0x19, 0x4a, 0xdb, 0x78,
+	0x84, 0x2b, 0xca, 0x39, 0x8e, 0xd1, 0xc7, 0xd0, 0x4c, 0xb3, 0x54, 0xa4, 0x64, 0x66, 0xda, 0xc9,
+	0x6f, 0xaa, 0x6b, 0x6c, 0x18, 0xab, 0xee, 0x24, 0xf4, 0x19, 0xa8, 0xbe, 0xd2, 0x6f, 0xd5, 0x22,
+	0x77, 0x15, 0xb2, 0x25, 0x1d, 0xea, 0x31, 0x1a, 0x2c, 0x86, 0x86, 0x83, 0x4d, 0x63, 0xff, 0xd6,
+	0xb6, 0x76, 0xdd, 0xef, 0x2a, 0x6a, 0x3c, 0x1a, 0x1c, 0x4b, 0xed, 0xab, 0xcb, 0x6e, 0xfd, 0xb1,
+	0xa5, 0x1a, 0x8f, 0x70, 0xbd, 0xe4, 0x1d, 0xc7, 0xe8, 0x31, 0xb4, 0x1c, 0x4e, 0x39, 0xe1, 0xfc,
+	0x3d, 0xc5, 0xda, 0x0e, 0xf4, 0xf8, 0x0b, 0xec, 0xf8, 0x0b, 0x9e, 0xdb, 0xf1, 0x37, 0xa8, 0x4a,
+	0xda, 0x97, 0xff, 0x74, 0x3d, 0xdc, 0x28, 0xb9, 0xa4, 0x17, 0x7d, 0x07, 0xad, 0x8c, 0x5e, 0x88,
+	0xb0, 0x7c, 0xf1, 0xdc, 0xdf, 0xbf, 0xd1, 0x8c, 0x68, 0xca, 0xb0, 0xd2, 0x22, 0x67, 0x20, 0x38,
+	0x1c, 0x95, 0x1b, 0x71, 0x38, 0x11, 0x52, 0x88, 0x3a, 0x96, 0x43, 0x52, 0xbd, 0x99, 0x10, 0x19,
+	0xe6, 0x08, 0x19, 0x42, 0xc7, 0x1d, 0x09, 0x6b, 0xbe, 0x72, 0x3a, 0xd4, 0xd4, 0x65, 0xdd, 0x59,
+	0x4f, 0x87, 0x75, 0xb4, 0x99, 0x13, 0x6f, 0x9c, 0x55, 0xf0, 0x8e, 0xb3, 0xea, 0x7b, 0xb8, 0xb7,
+	0x31, 0xab, 0xae, 0xf1, 0x97, 0xf2, 0xea, 0x4a, 0xde, 0xa9, 0x33, 0xbc, 0x36, 0x89, 0xac, 0x46,
+	0xdb, 0x88, 0x05, 0xe5, 0x8b, 0x99, 0xe0, 0x61, 0x42, 0x78, 0xe2, 0x1f, 0xe8, 0x81, 0x21, 0x1d,
+	0x58, 0xdb, 0x1f, 0x11, 0x9e, 0xa0, 0xdb, 0x50, 0x25, 0x79, 0xae, 0x21, 0x0d, 0x05, 0xa9, 0x90,
+	0x3c, 0x57, 0xae, 0x1f, 0xe1, 0x84, 0x2e, 0xd3, 0x98, 0x66, 0x11, 0x55, 0x9a, 0x44, 0x41, 0xd2,
+	0x4c, 0x70, 0xbf, 0xa5, 0x8e, 0x7b, 0x6f, 0xeb, 0x2b, 0xf4, 0xd0, 0x80, 0x87, 0x6b, 0x2c, 0x3e,
+	0xa6, 0xdb, 0xc6, 0xc1, 0x0f, 0xaf, 0x56, 0x1d, 0xef, 0xf5, 0xaa, 0xe3, 0xfd, 0xbb, 0xea, 0x78,
+	0x2f, 0xaf, 0x3a, 0x3b, 0xaf, 0xaf, 0x3a, 0x3b, 0x7f, 0x5d, 0x75, 0x76, 0x7e, 0xfa, 0x72, 0x9a,
+	0x8a, 0x64, 0x31, 0x09, 0x22, 0x36, 0xef, 0xbb, 0x5f, 0xfc, 0xf5, 0x52, 0xff, 0x76, 0x5c, 0xff,
+	0x61, 0x99, 0xec, 0x2b, 0xfb, 0x17, 0xff, 0x0d, 0x00, 0xad, 0x0f, 0x04, 0x8b, 0xcb, 0x08, 0x00,
+	0x00,
 }
 
 func (m *ABCIResponses) Marshal() (dAtA []byte, err error) {
@@ -708,6 +836,88 @@ func (m *ABCIResponsesInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *RoundProposer) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RoundProposer) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RoundProposer) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.ProposalObserved {
+		i--
+		if m.ProposalObserved {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.ProposerAddress) > 0 {
+		i -= len(m.ProposerAddress)
+		copy(dAtA[i:], m.ProposerAddress)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.ProposerAddress)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Round != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Round))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ProposerRecord) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ProposerRecord) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ProposerRecord) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Rounds) > 0 {
+		for iNdEx := len(m.Rounds) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Rounds[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintTypes(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *Version) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -768,6 +978,18 @@ func (m *State) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.EvidenceConstraints != nil {
+		{
+			size, err := m.EvidenceConstraints.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x7a
+	}
 	if m.InitialHeight != 0 {
 		i = encodeVarintTypes(dAtA, i, uint64(m.InitialHeight))
 		i--
@@ -843,12 +1065,12 @@ func (m *State) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i--
 		dAtA[i] = 0x32
 	}
-	n11, err11 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.LastBlockTime, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.LastBlockTime):])
-	if err11 != nil {
-		return 0, err11
+	n12, err12 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.LastBlockTime, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.LastBlockTime):])
+	if err12 != nil {
+		return 0, err12
 	}
-	i -= n11
-	i = encodeVarintTypes(dAtA, i, uint64(n11))
+	i -= n12
+	i = encodeVarintTypes(dAtA, i, uint64(n12))
 	i--
 	dAtA[i] = 0x2a
 	{
@@ -966,6 +1188,40 @@ func (m *ABCIResponsesInfo) Size() (n int) {
 	return n
 }
 
+func (m *RoundProposer) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Round != 0 {
+		n += 1 + sovTypes(uint64(m.Round))
+	}
+	l = len(m.ProposerAddress)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.ProposalObserved {
+		n += 2
+	}
+	return n
+}
+
+func (m *ProposerRecord) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Rounds) > 0 {
+		for _, e := range m.Rounds {
+			l = e.Size()
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	return n
+}
+
 func (m *Version) Size() (n int) {
 	if m == nil {
 		return 0
@@ -1031,6 +1287,10 @@ func (m *State) Size() (n int) {
 	if m.InitialHeight != 0 {
 		n += 1 + sovTypes(uint64(m.InitialHeight))
 	}
+	if m.EvidenceConstraints != nil {
+		l = m.EvidenceConstraints.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
 	return n
 }
 
@@ -1508,6 +1768,213 @@ func (m *ABCIResponsesInfo) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *RoundProposer) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RoundProposer: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RoundProposer: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Round", wireType)
+			}
+			m.Round = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Round |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProposerAddress", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ProposerAddress = append(m.ProposerAddress[:0], dAtA[iNdEx:postIndex]...)
+			if m.ProposerAddress == nil {
+				m.ProposerAddress = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProposalObserved", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.ProposalObserved = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ProposerRecord) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ProposerRecord: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ProposerRecord: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Rounds", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Rounds = append(m.Rounds, RoundProposer{})
+			if err := m.Rounds[len(m.Rounds)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *Version) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
@@ -2068,6 +2535,42 @@ func (m *State) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 15:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EvidenceConstraints", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.EvidenceConstraints == nil {
+				m.EvidenceConstraints = &types.EvidenceConstraints{}
+			}
+			if err := m.EvidenceConstraints.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -6,6 +6,7 @@ package store
 import (
 	fmt "fmt"
 	proto "github.com/gogo/protobuf/proto"
+	types "github.com/tendermint/tendermint/proto/tendermint/types"
 	io "io"
 	math "math"
 	math_bits "math/bits"
@@ -25,6 +26,9 @@ const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 type BlockStoreState struct {
 	Base   int64 `protobuf:"varint,1,opt,name=base,proto3" json:"base,omitempty"`
 	Height int64 `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	// The height below which the application last asked for the blocks to be
+	// pruned, 0 if it never did.
+	RetainHeight int64 `protobuf:"varint,3,opt,name=retain_height,json=retainHeight,proto3" json:"retain_height,omitempty"`
 }
 
 func (m *BlockStoreState) Reset()         { *m = BlockStoreState{} }
@@ -74,6 +78,13 @@ func (m *BlockStoreState) GetHeight() int64 {
 	return 0
 }
 
+func (m *BlockStoreState) GetRetainHeight() int64 {
+	if m != nil {
+		return m.RetainHeight
+	}
+	return 0
+}
+
 // TxInfo describes the location of a tx inside a committed block
 // as well as the result of executing the transaction.
 type TxInfo struct {
@@ -138,28 +149,100 @@ func (m *TxInfo) GetCode() uint32 {
 	return 0
 }
 
+// BlockWithCommit is a block along with the commit for it, as written to block
+// archives.
+type BlockWithCommit struct {
+	Block  *types.Block  `protobuf:"bytes,1,opt,name=block,proto3" json:"block,omitempty"`
+	Commit *types.Commit `protobuf:"bytes,2,opt,name=commit,proto3" json:"commit,omitempty"`
+	// The hash of the validator set that signed the commit.
+	ValidatorsHash []byte `protobuf:"bytes,3,opt,name=validators_hash,json=validatorsHash,proto3" json:"validators_hash,omitempty"`
+}
+
+func (m *BlockWithCommit) Reset()         { *m = BlockWithCommit{} }
+func (m *BlockWithCommit) String() string { return proto.CompactTextString(m) }
+func (*BlockWithCommit) ProtoMessage()    {}
+func (*BlockWithCommit) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ff9e53a0a74267f7, []int{2}
+}
+func (m *BlockWithCommit) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *BlockWithCommit) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_BlockWithCommit.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *BlockWithCommit) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockWithCommit.Merge(m, src)
+}
+func (m *BlockWithCommit) XXX_Size() int {
+	return m.Size()
+}
+func (m *BlockWithCommit) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockWithCommit.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlockWithCommit proto.InternalMessageInfo
+
+func (m *BlockWithCommit) GetBlock() *types.Block {
+	if m != nil {
+		return m.Block
+	}
+	return nil
+}
+
+func (m *BlockWithCommit) GetCommit() *types.Commit {
+	if m != nil {
+		return m.Commit
+	}
+	return nil
+}
+
+func (m *BlockWithCommit) GetValidatorsHash() []byte {
+	if m != nil {
+		return m.ValidatorsHash
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*BlockStoreState)(nil), "tendermint.store.BlockStoreState")
 	proto.RegisterType((*TxInfo)(nil), "tendermint.store.TxInfo")
+	proto.RegisterType((*BlockWithCommit)(nil), "tendermint.store.BlockWithCommit")
 }
 
 func init() { proto.RegisterFile("tendermint/store/types.proto", fileDescriptor_ff9e53a0a74267f7) }
 
 var fileDescriptor_ff9e53a0a74267f7 = []byte{
-	// 207 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x92, 0x29, 0x49, 0xcd, 0x4b,
-	0x49, 0x2d, 0xca, 0xcd, 0xcc, 0x2b, 0xd1, 0x2f, 0x2e, 0xc9, 0x2f, 0x4a, 0xd5, 0x2f, 0xa9, 0x2c,
-	0x48, 0x2d, 0xd6, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x12, 0x40, 0xc8, 0xea, 0x81, 0x65, 0x95,
-	0x6c, 0xb9, 0xf8, 0x9d, 0x72, 0xf2, 0x93, 0xb3, 0x83, 0x41, 0xbc, 0xe0, 0x92, 0xc4, 0x92, 0x54,
-	0x21, 0x21, 0x2e, 0x96, 0xa4, 0xc4, 0xe2, 0x54, 0x09, 0x46, 0x05, 0x46, 0x0d, 0xe6, 0x20, 0x30,
-	0x5b, 0x48, 0x8c, 0x8b, 0x2d, 0x23, 0x35, 0x33, 0x3d, 0xa3, 0x44, 0x82, 0x09, 0x2c, 0x0a, 0xe5,
-	0x29, 0x79, 0x71, 0xb1, 0x85, 0x54, 0x78, 0xe6, 0xa5, 0xe5, 0x23, 0xa9, 0x60, 0x44, 0x56, 0x21,
-	0x24, 0xc2, 0xc5, 0x9a, 0x99, 0x97, 0x92, 0x5a, 0x01, 0xd6, 0xc8, 0x1b, 0x04, 0xe1, 0x80, 0xec,
-	0x48, 0xce, 0x4f, 0x49, 0x95, 0x60, 0x06, 0x0b, 0x82, 0xd9, 0x4e, 0x81, 0x27, 0x1e, 0xc9, 0x31,
-	0x5e, 0x78, 0x24, 0xc7, 0xf8, 0xe0, 0x91, 0x1c, 0xe3, 0x84, 0xc7, 0x72, 0x0c, 0x17, 0x1e, 0xcb,
-	0x31, 0xdc, 0x78, 0x2c, 0xc7, 0x10, 0x65, 0x9e, 0x9e, 0x59, 0x92, 0x51, 0x9a, 0xa4, 0x97, 0x9c,
-	0x9f, 0xab, 0x8f, 0xe4, 0x3f, 0x24, 0x26, 0xd8, 0x7b, 0xfa, 0xe8, 0x7e, 0x4f, 0x62, 0x03, 0x8b,
-	0x1b, 0x03, 0x02, 0x00, 0x00, 0xff, 0xff, 0x87, 0xbd, 0xb8, 0x53, 0x16, 0x01, 0x00, 0x00,
+	// 325 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x91, 0x3f, 0x4f, 0xf3, 0x30,
+	0x10, 0xc6, 0xeb, 0xb7, 0x6f, 0x33, 0xb8, 0x2d, 0x45, 0x16, 0x82, 0x0a, 0x21, 0x0b, 0x95, 0x01,
+	0x16, 0x12, 0x54, 0x06, 0xf6, 0xb2, 0x14, 0x36, 0x5c, 0x24, 0x24, 0x96, 0xca, 0x49, 0x4c, 0x6d,
+	0xd1, 0xc4, 0x55, 0x7c, 0xa0, 0xf2, 0x2d, 0xd8, 0xf9, 0x42, 0x8c, 0x1d, 0x19, 0x51, 0xfb, 0x45,
+	0x50, 0xcf, 0x91, 0x1a, 0xfe, 0x6c, 0xe7, 0xe7, 0x79, 0xee, 0x97, 0xbb, 0x1c, 0x3d, 0x00, 0x95,
+	0xa7, 0xaa, 0xc8, 0x4c, 0x0e, 0x91, 0x03, 0x5b, 0xa8, 0x08, 0x5e, 0x66, 0xca, 0x85, 0xb3, 0xc2,
+	0x82, 0x65, 0xdb, 0x1b, 0x37, 0x44, 0x77, 0xbf, 0x9a, 0xc7, 0x64, 0x14, 0x4f, 0x6d, 0xf2, 0xe8,
+	0xf3, 0x7f, 0xb8, 0x15, 0x5a, 0x2f, 0xa6, 0x9d, 0xc1, 0x3a, 0x3c, 0x5a, 0x93, 0x46, 0x20, 0x41,
+	0x31, 0x46, 0xff, 0xc7, 0xd2, 0xa9, 0x2e, 0x39, 0x24, 0x27, 0x75, 0x81, 0x35, 0xdb, 0xa5, 0x81,
+	0x56, 0x66, 0xa2, 0xa1, 0xfb, 0x0f, 0xd5, 0xf2, 0xc5, 0x8e, 0x68, 0xbb, 0x50, 0x20, 0x4d, 0x3e,
+	0x2e, 0xed, 0x3a, 0xda, 0x2d, 0x2f, 0x0e, 0x51, 0xeb, 0x5d, 0xd3, 0xe0, 0x76, 0x7e, 0x95, 0x3f,
+	0xd8, 0x0a, 0x86, 0x7c, 0xc3, 0xec, 0xd0, 0x86, 0xc9, 0x53, 0x35, 0x47, 0x7a, 0x5b, 0xf8, 0xc7,
+	0x7a, 0x90, 0xc4, 0xa6, 0x0a, 0x99, 0x6d, 0x81, 0x75, 0xef, 0x8d, 0x94, 0x03, 0xdf, 0x19, 0xd0,
+	0x97, 0x36, 0xcb, 0x0c, 0xb0, 0x53, 0xda, 0xc0, 0x85, 0x11, 0xda, 0xec, 0xef, 0x85, 0x95, 0x3f,
+	0xe4, 0x77, 0xc5, 0x0e, 0xe1, 0x53, 0xec, 0x8c, 0x06, 0x09, 0x36, 0xe2, 0xd7, 0x9a, 0xfd, 0xee,
+	0xef, 0xbc, 0x07, 0x8b, 0x32, 0xc7, 0x8e, 0x69, 0xe7, 0x59, 0x4e, 0x4d, 0x2a, 0xc1, 0x16, 0x6e,
+	0xac, 0xa5, 0xd3, 0x38, 0x53, 0x4b, 0x6c, 0x6d, 0xe4, 0xa1, 0x74, 0x7a, 0x70, 0xf3, 0xbe, 0xe4,
+	0x64, 0xb1, 0xe4, 0xe4, 0x73, 0xc9, 0xc9, 0xeb, 0x8a, 0xd7, 0x16, 0x2b, 0x5e, 0xfb, 0x58, 0xf1,
+	0xda, 0xfd, 0xc5, 0xc4, 0x80, 0x7e, 0x8a, 0xc3, 0xc4, 0x66, 0x51, 0xf5, 0x20, 0x9b, 0x12, 0xef,
+	0x11, 0xfd, 0x3c, 0x7d, 0x1c, 0xa0, 0x7e, 0xfe, 0x15, 0x00, 0x00, 0xff, 0xff, 0xd2, 0xc9, 0xff,
+	0x13, 0x15, 0x02, 0x00, 0x00,
 }
 
 func (m *BlockStoreState) Marshal() (dAtA []byte, err error) {
@@ -182,6 +265,11 @@ func (m *BlockStoreState) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.RetainHeight != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.RetainHeight))
+		i--
+		dAtA[i] = 0x18
+	}
 	if m.Height != 0 {
 		i = encodeVarintTypes(dAtA, i, uint64(m.Height))
 		i--
@@ -233,6 +321,60 @@ func (m *TxInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *BlockWithCommit) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *BlockWithCommit) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *BlockWithCommit) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.ValidatorsHash) > 0 {
+		i -= len(m.ValidatorsHash)
+		copy(dAtA[i:], m.ValidatorsHash)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.ValidatorsHash)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Commit != nil {
+		{
+			size, err := m.Commit.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Block != nil {
+		{
+			size, err := m.Block.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func encodeVarintTypes(dAtA []byte, offset int, v uint64) int {
 	offset -= sovTypes(v)
 	base := offset
@@ -256,6 +398,9 @@ func (m *BlockStoreState) Size() (n int) {
 	if m.Height != 0 {
 		n += 1 + sovTypes(uint64(m.Height))
 	}
+	if m.RetainHeight != 0 {
+		n += 1 + sovTypes(uint64(m.RetainHeight))
+	}
 	return n
 }
 
@@ -277,6 +422,27 @@ func (m *TxInfo) Size() (n int) {
 	return n
 }
 
+func (m *BlockWithCommit) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Block != nil {
+		l = m.Block.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.Commit != nil {
+		l = m.Commit.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.ValidatorsHash)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+
 func sovTypes(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
 }
@@ -350,6 +516,25 @@ func (m *BlockStoreState) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RetainHeight", wireType)
+			}
+			m.RetainHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RetainHeight |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -478,6 +663,162 @@ func (m *TxInfo) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *BlockWithCommit) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: BlockWithCommit: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: BlockWithCommit: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Block", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Block == nil {
+				m.Block = &types.Block{}
+			}
+			if err := m.Block.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Commit", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Commit == nil {
+				m.Commit = &types.Commit{}
+			}
+			if err := m.Commit.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ValidatorsHash", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ValidatorsHash = append(m.ValidatorsHash[:0], dAtA[iNdEx:postIndex]...)
+			if m.ValidatorsHash == nil {
+				m.ValidatorsHash = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipTypes(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
@@ -110,6 +110,11 @@ type BlockParams struct {
 	//
 	// Not exposed to the application.
 	TimeIotaMs int64 `protobuf:"varint,3,opt,name=time_iota_ms,json=timeIotaMs,proto3" json:"time_iota_ms,omitempty"`
+	// Reject the blocks, votes and proposals holding fields unknown to this
+	// version of the protocol, at any nesting level.
+	//
+	// Not included in the hash of the consensus params of the header.
+	StrictDecoding bool `protobuf:"varint,4,opt,name=strict_decoding,json=strictDecoding,proto3" json:"strict_decoding,omitempty"`
 }
 
 func (m *BlockParams) Reset()         { *m = BlockParams{} }
@@ -166,6 +171,13 @@ func (m *BlockParams) GetTimeIotaMs() int64 {
 	return 0
 }
 
+func (m *BlockParams) GetStrictDecoding() bool {
+	if m != nil {
+		return m.StrictDecoding
+	}
+	return false
+}
+
 // EvidenceParams determine how we handle evidence of malfeasance.
 type EvidenceParams struct {
 	// Max age of evidence, in blocks.
@@ -397,41 +409,43 @@ func init() {
 func init() { proto.RegisterFile("tendermint/types/params.proto", fileDescriptor_e12598271a686f57) }
 
 var fileDescriptor_e12598271a686f57 = []byte{
-	// 537 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x53, 0x31, 0x6f, 0xd3, 0x40,
-	0x18, 0xcd, 0xd5, 0xa5, 0x4d, 0xbe, 0x34, 0x4d, 0x75, 0x42, 0x22, 0x14, 0xd5, 0x0e, 0x1e, 0x50,
-	0x25, 0x24, 0x5b, 0x82, 0x01, 0xd1, 0xa5, 0xc2, 0x50, 0x15, 0x84, 0x82, 0x90, 0x05, 0x0c, 0x5d,
-	0xac, 0x73, 0x72, 0xb8, 0x56, 0x73, 0x3e, 0xcb, 0x77, 0x8e, 0x92, 0x7f, 0xc1, 0xd8, 0xb1, 0x23,
-	0xfc, 0x03, 0x7e, 0x42, 0xc7, 0x8e, 0x4c, 0x80, 0x92, 0x85, 0x9f, 0x81, 0x7c, 0xce, 0xe1, 0x38,
-	0x65, 0xf3, 0x7d, 0xdf, 0x7b, 0xef, 0xfc, 0xde, 0xd3, 0xc1, 0x81, 0xa4, 0xc9, 0x88, 0x66, 0x2c,
-	0x4e, 0xa4, 0x2b, 0x67, 0x29, 0x15, 0x6e, 0x4a, 0x32, 0xc2, 0x84, 0x93, 0x66, 0x5c, 0x72, 0xbc,
-	0x57, 0xad, 0x1d, 0xb5, 0xde, 0xbf, 0x1b, 0xf1, 0x88, 0xab, 0xa5, 0x5b, 0x7c, 0x95, 0xb8, 0x7d,
-	0x33, 0xe2, 0x3c, 0x1a, 0x53, 0x57, 0x9d, 0xc2, 0xfc, 0xb3, 0x3b, 0xca, 0x33, 0x22, 0x63, 0x9e,
-	0x94, 0x7b, 0xfb, 0x72, 0x03, 0xba, 0x2f, 0x79, 0x22, 0x68, 0x22, 0x72, 0xf1, 0x5e, 0xdd, 0x80,
-	0x9f, 0xc3, 0x9d, 0x70, 0xcc, 0x87, 0x17, 0x3d, 0xd4, 0x47, 0x87, 0xed, 0x27, 0x07, 0xce, 0xfa,
-	0x5d, 0x8e, 0x57, 0xac, 0x4b, 0xb4, 0xb7, 0x79, 0xfd, 0xd3, 0x6a, 0xf8, 0x25, 0x03, 0x7b, 0xd0,
-	0xa4, 0x93, 0x78, 0x44, 0x93, 0x21, 0xed, 0x6d, 0x28, 0x76, 0xff, 0x36, 0xfb, 0x64, 0x89, 0xa8,
-	0x09, 0xfc, 0xe3, 0xe1, 0x13, 0x68, 0x4d, 0xc8, 0x38, 0x1e, 0x11, 0xc9, 0xb3, 0x9e, 0xa1, 0x44,
-	0x1e, 0xde, 0x16, 0xf9, 0xa4, 0x21, 0x35, 0x95, 0x8a, 0x89, 0x8f, 0x61, 0x7b, 0x42, 0x33, 0x11,
-	0xf3, 0xa4, 0xb7, 0xa9, 0x44, 0xac, 0xff, 0x88, 0x94, 0x80, 0x9a, 0x84, 0x66, 0xd9, 0x14, 0xda,
-	0x2b, 0x3e, 0xf1, 0x03, 0x68, 0x31, 0x32, 0x0d, 0xc2, 0x99, 0xa4, 0x42, 0x25, 0x63, 0xf8, 0x4d,
-	0x46, 0xa6, 0x5e, 0x71, 0xc6, 0xf7, 0x60, 0xbb, 0x58, 0x46, 0x44, 0x28, 0xdb, 0x86, 0xbf, 0xc5,
-	0xc8, 0xf4, 0x94, 0x08, 0xdc, 0x87, 0x1d, 0x19, 0x33, 0x1a, 0xc4, 0x5c, 0x92, 0x80, 0x09, 0xe5,
-	0xc7, 0xf0, 0xa1, 0x98, 0xbd, 0xe1, 0x92, 0x0c, 0x84, 0xfd, 0x0d, 0xc1, 0x6e, 0x3d, 0x11, 0xfc,
-	0x18, 0x70, 0xa1, 0x46, 0x22, 0x1a, 0x24, 0x39, 0x0b, 0x54, 0xb4, 0xfa, 0xce, 0x2e, 0x23, 0xd3,
-	0x17, 0x11, 0x7d, 0x97, 0x33, 0xf5, 0x73, 0x02, 0x0f, 0x60, 0x4f, 0x83, 0x75, 0xb7, 0xcb, 0xe8,
-	0xef, 0x3b, 0x65, 0xf9, 0x8e, 0x2e, 0xdf, 0x79, 0xb5, 0x04, 0x78, 0xcd, 0xc2, 0xea, 0xe5, 0x2f,
-	0x0b, 0xf9, 0xbb, 0xa5, 0x9e, 0xde, 0xd4, 0x6d, 0x1a, 0x75, 0x9b, 0xf6, 0x31, 0x74, 0xd7, 0x72,
-	0xc7, 0x36, 0x74, 0xd2, 0x3c, 0x0c, 0x2e, 0xe8, 0x2c, 0x50, 0x99, 0xf6, 0x50, 0xdf, 0x38, 0x6c,
-	0xf9, 0xed, 0x34, 0x0f, 0xdf, 0xd2, 0xd9, 0x87, 0x62, 0x74, 0xd4, 0xfc, 0x7e, 0x65, 0xa1, 0x3f,
-	0x57, 0x16, 0xb2, 0x8f, 0xa0, 0x53, 0xcb, 0x1c, 0x5b, 0xd0, 0x26, 0x69, 0x1a, 0xe8, 0xa6, 0x0a,
-	0x8f, 0x9b, 0x3e, 0x90, 0x34, 0x5d, 0xc2, 0x56, 0xb8, 0x67, 0xb0, 0xf3, 0x9a, 0x88, 0x73, 0x3a,
-	0x5a, 0x52, 0x1f, 0x41, 0x57, 0x25, 0x13, 0xac, 0xd7, 0xd2, 0x51, 0xe3, 0x81, 0xee, 0xc6, 0x86,
-	0x4e, 0x85, 0xab, 0x1a, 0x6a, 0x6b, 0xd4, 0x29, 0x11, 0xde, 0xc7, 0xaf, 0x73, 0x13, 0x5d, 0xcf,
-	0x4d, 0x74, 0x33, 0x37, 0xd1, 0xef, 0xb9, 0x89, 0xbe, 0x2c, 0xcc, 0xc6, 0xcd, 0xc2, 0x6c, 0xfc,
-	0x58, 0x98, 0x8d, 0xb3, 0x67, 0x51, 0x2c, 0xcf, 0xf3, 0xd0, 0x19, 0x72, 0xe6, 0xae, 0x3e, 0xcb,
-	0xea, 0xb3, 0x7c, 0x77, 0xeb, 0x4f, 0x36, 0xdc, 0x52, 0xf3, 0xa7, 0x7f, 0x03, 0x00, 0x00, 0xff,
-	0xff, 0xfe, 0xe0, 0x3d, 0x9c, 0xcd, 0x03, 0x00, 0x00,
+	// 562 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x53, 0x41, 0x6f, 0xd3, 0x3c,
+	0x18, 0xae, 0x97, 0x7d, 0x5b, 0xfb, 0x76, 0x6d, 0x27, 0xeb, 0x93, 0x28, 0x43, 0x4b, 0x4a, 0x0e,
+	0x30, 0x09, 0x29, 0x91, 0xe0, 0x80, 0xd8, 0x65, 0x22, 0x6c, 0x1a, 0x08, 0x15, 0xa1, 0x08, 0x38,
+	0xec, 0x12, 0x39, 0x8d, 0xc9, 0xa2, 0xd5, 0x71, 0x14, 0x3b, 0x55, 0xfb, 0x27, 0x10, 0xc7, 0x1d,
+	0x77, 0x84, 0x7f, 0xc0, 0x4f, 0xd8, 0x71, 0x47, 0x4e, 0x80, 0xda, 0x0b, 0x3f, 0x03, 0xc5, 0x49,
+	0x68, 0xd3, 0x71, 0xb3, 0xdf, 0xf7, 0x79, 0x1e, 0xfb, 0x7d, 0x1e, 0x1b, 0xf6, 0x25, 0x8d, 0x03,
+	0x9a, 0xb2, 0x28, 0x96, 0xb6, 0x9c, 0x25, 0x54, 0xd8, 0x09, 0x49, 0x09, 0x13, 0x56, 0x92, 0x72,
+	0xc9, 0xf1, 0xee, 0xb2, 0x6d, 0xa9, 0xf6, 0xde, 0xff, 0x21, 0x0f, 0xb9, 0x6a, 0xda, 0xf9, 0xaa,
+	0xc0, 0xed, 0xe9, 0x21, 0xe7, 0xe1, 0x98, 0xda, 0x6a, 0xe7, 0x67, 0x1f, 0xed, 0x20, 0x4b, 0x89,
+	0x8c, 0x78, 0x5c, 0xf4, 0xcd, 0xcb, 0x0d, 0xe8, 0xbd, 0xe0, 0xb1, 0xa0, 0xb1, 0xc8, 0xc4, 0x5b,
+	0x75, 0x02, 0x7e, 0x06, 0xff, 0xf9, 0x63, 0x3e, 0xba, 0xe8, 0xa3, 0x01, 0x3a, 0x68, 0x3f, 0xde,
+	0xb7, 0xd6, 0xcf, 0xb2, 0x9c, 0xbc, 0x5d, 0xa0, 0x9d, 0xcd, 0xeb, 0x1f, 0x46, 0xc3, 0x2d, 0x18,
+	0xd8, 0x81, 0x26, 0x9d, 0x44, 0x01, 0x8d, 0x47, 0xb4, 0xbf, 0xa1, 0xd8, 0x83, 0xdb, 0xec, 0x93,
+	0x12, 0x51, 0x13, 0xf8, 0xcb, 0xc3, 0x27, 0xd0, 0x9a, 0x90, 0x71, 0x14, 0x10, 0xc9, 0xd3, 0xbe,
+	0xa6, 0x44, 0xee, 0xdf, 0x16, 0xf9, 0x50, 0x41, 0x6a, 0x2a, 0x4b, 0x26, 0x3e, 0x82, 0xed, 0x09,
+	0x4d, 0x45, 0xc4, 0xe3, 0xfe, 0xa6, 0x12, 0x31, 0xfe, 0x21, 0x52, 0x00, 0x6a, 0x12, 0x15, 0xcb,
+	0xfc, 0x84, 0xa0, 0xbd, 0x32, 0x28, 0xbe, 0x07, 0x2d, 0x46, 0xa6, 0x9e, 0x3f, 0x93, 0x54, 0x28,
+	0x6b, 0x34, 0xb7, 0xc9, 0xc8, 0xd4, 0xc9, 0xf7, 0xf8, 0x0e, 0x6c, 0xe7, 0xcd, 0x90, 0x08, 0x35,
+	0xb7, 0xe6, 0x6e, 0x31, 0x32, 0x3d, 0x25, 0x02, 0x0f, 0x60, 0x47, 0x46, 0x8c, 0x7a, 0x11, 0x97,
+	0xc4, 0x63, 0x42, 0x0d, 0xa4, 0xb9, 0x90, 0xd7, 0x5e, 0x71, 0x49, 0x86, 0x02, 0x3f, 0x84, 0x9e,
+	0x90, 0x69, 0x34, 0x92, 0x5e, 0x40, 0x47, 0x3c, 0x88, 0xe2, 0x50, 0x5d, 0xb8, 0xe9, 0x76, 0x8b,
+	0xf2, 0x71, 0x59, 0x35, 0xbf, 0x22, 0xe8, 0xd6, 0xbd, 0xc3, 0x8f, 0x00, 0xe7, 0xc7, 0x92, 0x90,
+	0x7a, 0x71, 0xc6, 0x3c, 0x15, 0x42, 0x75, 0xb9, 0x1e, 0x23, 0xd3, 0xe7, 0x21, 0x7d, 0x93, 0x31,
+	0x35, 0x85, 0xc0, 0x43, 0xd8, 0xad, 0xc0, 0xd5, 0x2b, 0x28, 0x43, 0xba, 0x6b, 0x15, 0xcf, 0xc4,
+	0xaa, 0x9e, 0x89, 0x75, 0x5c, 0x02, 0x9c, 0x66, 0x6e, 0xca, 0xe5, 0x4f, 0x03, 0xb9, 0xdd, 0x42,
+	0xaf, 0xea, 0xd4, 0xfd, 0xd0, 0xea, 0x7e, 0x98, 0x47, 0xd0, 0x5b, 0x4b, 0x08, 0x9b, 0xd0, 0x49,
+	0x32, 0xdf, 0xbb, 0xa0, 0x33, 0x4f, 0xb9, 0xdf, 0x47, 0x03, 0xed, 0xa0, 0xe5, 0xb6, 0x93, 0xcc,
+	0x7f, 0x4d, 0x67, 0xef, 0xf2, 0xd2, 0x61, 0xf3, 0xdb, 0x95, 0x81, 0x7e, 0x5f, 0x19, 0xc8, 0x3c,
+	0x84, 0x4e, 0x2d, 0x1d, 0x6c, 0x40, 0x9b, 0x24, 0x89, 0x57, 0x65, 0x9a, 0xcf, 0xb8, 0xe9, 0x02,
+	0x49, 0x92, 0x12, 0xb6, 0xc2, 0x3d, 0x83, 0x9d, 0x97, 0x44, 0x9c, 0xd3, 0xa0, 0xa4, 0x3e, 0x80,
+	0x9e, 0x72, 0xc6, 0x5b, 0xcf, 0xaf, 0xa3, 0xca, 0xc3, 0x2a, 0x44, 0x13, 0x3a, 0x4b, 0xdc, 0x32,
+	0xca, 0x76, 0x85, 0x3a, 0x25, 0xc2, 0x79, 0xff, 0x65, 0xae, 0xa3, 0xeb, 0xb9, 0x8e, 0x6e, 0xe6,
+	0x3a, 0xfa, 0x35, 0xd7, 0xd1, 0xe7, 0x85, 0xde, 0xb8, 0x59, 0xe8, 0x8d, 0xef, 0x0b, 0xbd, 0x71,
+	0xf6, 0x34, 0x8c, 0xe4, 0x79, 0xe6, 0x5b, 0x23, 0xce, 0xec, 0xd5, 0x0f, 0xbc, 0x5c, 0x16, 0x3f,
+	0x74, 0xfd, 0x73, 0xfb, 0x5b, 0xaa, 0xfe, 0xe4, 0xcf, 0x00, 0x42, 0x42, 0x13, 0xc5, 0xf7, 0x03,
+	0x00, 0x00,
 }
 
 func (this *ConsensusParams) Equal(that interface{}) bool {
@@ -495,6 +509,9 @@ func (this *BlockParams) Equal(that interface{}) bool {
 	if this.TimeIotaMs != that1.TimeIotaMs {
 		return false
 	}
+	if this.StrictDecoding != that1.StrictDecoding {
+		return false
+	}
 	return true
 }
 func (this *EvidenceParams) Equal(that interface{}) bool {
@@ -690,6 +707,16 @@ func (m *BlockParams) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.StrictDecoding {
+		i--
+		if m.StrictDecoding {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x20
+	}
 	if m.TimeIotaMs != 0 {
 		i = encodeVarintParams(dAtA, i, uint64(m.TimeIotaMs))
 		i--
@@ -977,6 +1004,9 @@ func (m *BlockParams) Size() (n int) {
 	if m.TimeIotaMs != 0 {
 		n += 1 + sovParams(uint64(m.TimeIotaMs))
 	}
+	if m.StrictDecoding {
+		n += 2
+	}
 	return n
 }
 
@@ -1313,6 +1343,26 @@ func (m *BlockParams) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StrictDecoding", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.StrictDecoding = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipParams(dAtA[iNdEx:])
@@ -997,6 +997,8 @@ type BlockMeta struct {
 	BlockSize int64   `protobuf:"varint,2,opt,name=block_size,json=blockSize,proto3" json:"block_size,omitempty"`
 	Header    Header  `protobuf:"bytes,3,opt,name=header,proto3" json:"header"`
 	NumTxs    int64   `protobuf:"varint,4,opt,name=num_txs,json=numTxs,proto3" json:"num_txs,omitempty"`
+	// square is unset for the blocks saved before it was recorded.
+	Square *DataSquareInfo `protobuf:"bytes,5,opt,name=square,proto3" json:"square,omitempty"`
 }
 
 func (m *BlockMeta) Reset()         { *m = BlockMeta{} }
@@ -1060,6 +1062,76 @@ func (m *BlockMeta) GetNumTxs() int64 {
 	return 0
 }
 
+func (m *BlockMeta) GetSquare() *DataSquareInfo {
+	if m != nil {
+		return m.Square
+	}
+	return nil
+}
+
+// DataSquareInfo describes the use of the data square of a block.
+type DataSquareInfo struct {
+	SquareSize uint64 `protobuf:"varint,1,opt,name=square_size,json=squareSize,proto3" json:"square_size,omitempty"`
+	// shares_used is the number of shares holding transactions and blobs, i.e.
+	// not padding.
+	SharesUsed int64 `protobuf:"varint,2,opt,name=shares_used,json=sharesUsed,proto3" json:"shares_used,omitempty"`
+	BlobBytes  int64 `protobuf:"varint,3,opt,name=blob_bytes,json=blobBytes,proto3" json:"blob_bytes,omitempty"`
+}
+
+func (m *DataSquareInfo) Reset()         { *m = DataSquareInfo{} }
+func (m *DataSquareInfo) String() string { return proto.CompactTextString(m) }
+func (*DataSquareInfo) ProtoMessage()    {}
+func (*DataSquareInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d3a6e55e2345de56, []int{13}
+}
+func (m *DataSquareInfo) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DataSquareInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DataSquareInfo.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *DataSquareInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DataSquareInfo.Merge(m, src)
+}
+func (m *DataSquareInfo) XXX_Size() int {
+	return m.Size()
+}
+func (m *DataSquareInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_DataSquareInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DataSquareInfo proto.InternalMessageInfo
+
+func (m *DataSquareInfo) GetSquareSize() uint64 {
+	if m != nil {
+		return m.SquareSize
+	}
+	return 0
+}
+
+func (m *DataSquareInfo) GetSharesUsed() int64 {
+	if m != nil {
+		return m.SharesUsed
+	}
+	return 0
+}
+
+func (m *DataSquareInfo) GetBlobBytes() int64 {
+	if m != nil {
+		return m.BlobBytes
+	}
+	return 0
+}
+
 // TxProof represents a Merkle proof of the presence of a transaction in the
 // Merkle tree.
 //
@@ -1081,7 +1153,7 @@ func (m *TxProof) Reset()         { *m = TxProof{} }
 func (m *TxProof) String() string { return proto.CompactTextString(m) }
 func (*TxProof) ProtoMessage()    {}
 func (*TxProof) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d3a6e55e2345de56, []int{13}
+	return fileDescriptor_d3a6e55e2345de56, []int{14}
 }
 func (m *TxProof) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1143,7 +1215,7 @@ func (m *IndexWrapper) Reset()         { *m = IndexWrapper{} }
 func (m *IndexWrapper) String() string { return proto.CompactTextString(m) }
 func (*IndexWrapper) ProtoMessage()    {}
 func (*IndexWrapper) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d3a6e55e2345de56, []int{14}
+	return fileDescriptor_d3a6e55e2345de56, []int{15}
 }
 func (m *IndexWrapper) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1206,7 +1278,7 @@ func (m *BlobTx) Reset()         { *m = BlobTx{} }
 func (m *BlobTx) String() string { return proto.CompactTextString(m) }
 func (*BlobTx) ProtoMessage()    {}
 func (*BlobTx) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d3a6e55e2345de56, []int{15}
+	return fileDescriptor_d3a6e55e2345de56, []int{16}
 }
 func (m *BlobTx) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1264,13 +1336,16 @@ type ShareProof struct {
 	NamespaceId      []byte      `protobuf:"bytes,3,opt,name=namespace_id,json=namespaceId,proto3" json:"namespace_id,omitempty"`
 	RowProof         *RowProof   `protobuf:"bytes,4,opt,name=row_proof,json=rowProof,proto3" json:"row_proof,omitempty"`
 	NamespaceVersion uint32      `protobuf:"varint,5,opt,name=namespace_version,json=namespaceVersion,proto3" json:"namespace_version,omitempty"`
+	// data_root is the data root the proof claims to prove the shares against.
+	// It is optional.
+	DataRoot []byte `protobuf:"bytes,6,opt,name=data_root,json=dataRoot,proto3" json:"data_root,omitempty"`
 }
 
 func (m *ShareProof) Reset()         { *m = ShareProof{} }
 func (m *ShareProof) String() string { return proto.CompactTextString(m) }
 func (*ShareProof) ProtoMessage()    {}
 func (*ShareProof) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d3a6e55e2345de56, []int{16}
+	return fileDescriptor_d3a6e55e2345de56, []int{17}
 }
 func (m *ShareProof) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1334,6 +1409,13 @@ func (m *ShareProof) GetNamespaceVersion() uint32 {
 	return 0
 }
 
+func (m *ShareProof) GetDataRoot() []byte {
+	if m != nil {
+		return m.DataRoot
+	}
+	return nil
+}
+
 // RowProof is a Merkle proof that a set of rows exist in a Merkle tree with a
 // given data root.
 type RowProof struct {
@@ -1342,13 +1424,17 @@ type RowProof struct {
 	Root     []byte          `protobuf:"bytes,3,opt,name=root,proto3" json:"root,omitempty"`
 	StartRow uint32          `protobuf:"varint,4,opt,name=start_row,json=startRow,proto3" json:"start_row,omitempty"`
 	EndRow   uint32          `protobuf:"varint,5,opt,name=end_row,json=endRow,proto3" json:"end_row,omitempty"`
+	// root_indices are the indices of the data roots of the rows, for a proof
+	// spanning several data roots. All the rows are of a single data root if
+	// empty.
+	RootIndices []uint32 `protobuf:"varint,6,rep,packed,name=root_indices,json=rootIndices,proto3" json:"root_indices,omitempty"`
 }
 
 func (m *RowProof) Reset()         { *m = RowProof{} }
 func (m *RowProof) String() string { return proto.CompactTextString(m) }
 func (*RowProof) ProtoMessage()    {}
 func (*RowProof) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d3a6e55e2345de56, []int{17}
+	return fileDescriptor_d3a6e55e2345de56, []int{18}
 }
 func (m *RowProof) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1412,6 +1498,13 @@ func (m *RowProof) GetEndRow() uint32 {
 	return 0
 }
 
+func (m *RowProof) GetRootIndices() []uint32 {
+	if m != nil {
+		return m.RootIndices
+	}
+	return nil
+}
+
 // NMTProof is a proof of a namespace.ID in an NMT.
 // In case this proof proves the absence of a namespace.ID
 // in a tree it also contains the leaf hashes of the range
@@ -1438,7 +1531,7 @@ func (m *NMTProof) Reset()         { *m = NMTProof{} }
 func (m *NMTProof) String() string { return proto.CompactTextString(m) }
 func (*NMTProof) ProtoMessage()    {}
 func (*NMTProof) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d3a6e55e2345de56, []int{18}
+	return fileDescriptor_d3a6e55e2345de56, []int{19}
 }
 func (m *NMTProof) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1495,6 +1588,197 @@ func (m *NMTProof) GetLeafHash() []byte {
 	return nil
 }
 
+// CompressedShareProof is a ShareProof whose proof nodes and row proof aunts
+// are deduplicated, see ShareProof.Compress in the types package.
+type CompressedShareProof struct {
+	// proof is the share proof without its proof nodes and row proof aunts.
+	Proof *ShareProof `protobuf:"bytes,1,opt,name=proof,proto3" json:"proof,omitempty"`
+	// nodes are the distinct proof nodes and row proof aunts of the proof.
+	Nodes [][]byte `protobuf:"bytes,2,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	// share_proof_nodes are the indices in nodes of the nodes of each share
+	// proof.
+	ShareProofNodes []NodeIndices `protobuf:"bytes,3,rep,name=share_proof_nodes,json=shareProofNodes,proto3" json:"share_proof_nodes"`
+	// row_proof_aunts are the indices in nodes of the aunts of each row proof.
+	RowProofAunts []NodeIndices `protobuf:"bytes,4,rep,name=row_proof_aunts,json=rowProofAunts,proto3" json:"row_proof_aunts"`
+}
+
+func (m *CompressedShareProof) Reset()         { *m = CompressedShareProof{} }
+func (m *CompressedShareProof) String() string { return proto.CompactTextString(m) }
+func (*CompressedShareProof) ProtoMessage()    {}
+func (*CompressedShareProof) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d3a6e55e2345de56, []int{20}
+}
+func (m *CompressedShareProof) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CompressedShareProof) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CompressedShareProof.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *CompressedShareProof) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CompressedShareProof.Merge(m, src)
+}
+func (m *CompressedShareProof) XXX_Size() int {
+	return m.Size()
+}
+func (m *CompressedShareProof) XXX_DiscardUnknown() {
+	xxx_messageInfo_CompressedShareProof.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CompressedShareProof proto.InternalMessageInfo
+
+func (m *CompressedShareProof) GetProof() *ShareProof {
+	if m != nil {
+		return m.Proof
+	}
+	return nil
+}
+
+func (m *CompressedShareProof) GetNodes() [][]byte {
+	if m != nil {
+		return m.Nodes
+	}
+	return nil
+}
+
+func (m *CompressedShareProof) GetShareProofNodes() []NodeIndices {
+	if m != nil {
+		return m.ShareProofNodes
+	}
+	return nil
+}
+
+func (m *CompressedShareProof) GetRowProofAunts() []NodeIndices {
+	if m != nil {
+		return m.RowProofAunts
+	}
+	return nil
+}
+
+// NodeIndices are indices in the nodes of a CompressedShareProof.
+type NodeIndices struct {
+	Indices []uint32 `protobuf:"varint,1,rep,packed,name=indices,proto3" json:"indices,omitempty"`
+}
+
+func (m *NodeIndices) Reset()         { *m = NodeIndices{} }
+func (m *NodeIndices) String() string { return proto.CompactTextString(m) }
+func (*NodeIndices) ProtoMessage()    {}
+func (*NodeIndices) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d3a6e55e2345de56, []int{21}
+}
+func (m *NodeIndices) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *NodeIndices) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_NodeIndices.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *NodeIndices) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NodeIndices.Merge(m, src)
+}
+func (m *NodeIndices) XXX_Size() int {
+	return m.Size()
+}
+func (m *NodeIndices) XXX_DiscardUnknown() {
+	xxx_messageInfo_NodeIndices.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NodeIndices proto.InternalMessageInfo
+
+func (m *NodeIndices) GetIndices() []uint32 {
+	if m != nil {
+		return m.Indices
+	}
+	return nil
+}
+
+// ValidatorAnnouncement is signed by a validator to announce the ID of the node
+// it runs to the peers of that node, so that they gossip to it first the votes
+// it misses.
+type ValidatorAnnouncement struct {
+	ValidatorAddress []byte `protobuf:"bytes,1,opt,name=validator_address,json=validatorAddress,proto3" json:"validator_address,omitempty"`
+	NodeId           string `protobuf:"bytes,2,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	ChainID          string `protobuf:"bytes,3,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	Signature        []byte `protobuf:"bytes,4,opt,name=signature,proto3" json:"signature,omitempty"`
+}
+
+func (m *ValidatorAnnouncement) Reset()         { *m = ValidatorAnnouncement{} }
+func (m *ValidatorAnnouncement) String() string { return proto.CompactTextString(m) }
+func (*ValidatorAnnouncement) ProtoMessage()    {}
+func (*ValidatorAnnouncement) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d3a6e55e2345de56, []int{22}
+}
+func (m *ValidatorAnnouncement) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ValidatorAnnouncement) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ValidatorAnnouncement.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ValidatorAnnouncement) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ValidatorAnnouncement.Merge(m, src)
+}
+func (m *ValidatorAnnouncement) XXX_Size() int {
+	return m.Size()
+}
+func (m *ValidatorAnnouncement) XXX_DiscardUnknown() {
+	xxx_messageInfo_ValidatorAnnouncement.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ValidatorAnnouncement proto.InternalMessageInfo
+
+func (m *ValidatorAnnouncement) GetValidatorAddress() []byte {
+	if m != nil {
+		return m.ValidatorAddress
+	}
+	return nil
+}
+
+func (m *ValidatorAnnouncement) GetNodeId() string {
+	if m != nil {
+		return m.NodeId
+	}
+	return ""
+}
+
+func (m *ValidatorAnnouncement) GetChainID() string {
+	if m != nil {
+		return m.ChainID
+	}
+	return ""
+}
+
+func (m *ValidatorAnnouncement) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterEnum("tendermint.types.BlockIDFlag", BlockIDFlag_name, BlockIDFlag_value)
 	proto.RegisterEnum("tendermint.types.SignedMsgType", SignedMsgType_name, SignedMsgType_value)
@@ -1511,122 +1795,140 @@ func init() {
 	proto.RegisterType((*SignedHeader)(nil), "tendermint.types.SignedHeader")
 	proto.RegisterType((*LightBlock)(nil), "tendermint.types.LightBlock")
 	proto.RegisterType((*BlockMeta)(nil), "tendermint.types.BlockMeta")
+	proto.RegisterType((*DataSquareInfo)(nil), "tendermint.types.DataSquareInfo")
 	proto.RegisterType((*TxProof)(nil), "tendermint.types.TxProof")
 	proto.RegisterType((*IndexWrapper)(nil), "tendermint.types.IndexWrapper")
 	proto.RegisterType((*BlobTx)(nil), "tendermint.types.BlobTx")
 	proto.RegisterType((*ShareProof)(nil), "tendermint.types.ShareProof")
 	proto.RegisterType((*RowProof)(nil), "tendermint.types.RowProof")
 	proto.RegisterType((*NMTProof)(nil), "tendermint.types.NMTProof")
+	proto.RegisterType((*CompressedShareProof)(nil), "tendermint.types.CompressedShareProof")
+	proto.RegisterType((*NodeIndices)(nil), "tendermint.types.NodeIndices")
+	proto.RegisterType((*ValidatorAnnouncement)(nil), "tendermint.types.ValidatorAnnouncement")
 }
 
 func init() { proto.RegisterFile("tendermint/types/types.proto", fileDescriptor_d3a6e55e2345de56) }
 
 var fileDescriptor_d3a6e55e2345de56 = []byte{
-	// 1661 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x58, 0x4f, 0x6f, 0xe3, 0xc6,
-	0x15, 0x37, 0x25, 0x4a, 0xa2, 0x9e, 0x24, 0x9b, 0x26, 0x9c, 0x8d, 0x56, 0x9b, 0x95, 0x55, 0x15,
-	0x6d, 0x9d, 0x34, 0x90, 0xb7, 0x4e, 0xd1, 0xb4, 0x87, 0x1c, 0x2c, 0xdb, 0xd9, 0x68, 0xe3, 0x7f,
-	0xa5, 0x94, 0x0d, 0x5a, 0x14, 0x20, 0x28, 0x73, 0x56, 0x62, 0x43, 0x71, 0x58, 0xce, 0xc8, 0xf6,
-	0xe6, 0x13, 0x14, 0xbe, 0x34, 0xa7, 0xde, 0x7c, 0x4a, 0x0f, 0xbd, 0xf7, 0x0b, 0x14, 0x3d, 0xe5,
-	0x98, 0x5b, 0x7b, 0x69, 0x5a, 0xec, 0x02, 0x45, 0x3e, 0x46, 0x31, 0x6f, 0x86, 0x14, 0x65, 0x49,
-	0x6d, 0xb0, 0x08, 0x72, 0x31, 0x38, 0xef, 0xfd, 0xde, 0x9b, 0xf7, 0x77, 0xde, 0x93, 0xe1, 0x0d,
-	0x4e, 0x42, 0x8f, 0xc4, 0x13, 0x3f, 0xe4, 0xbb, 0xfc, 0x79, 0x44, 0x98, 0xfc, 0xdb, 0x89, 0x62,
-	0xca, 0xa9, 0x65, 0xce, 0xb8, 0x1d, 0xa4, 0x37, 0xb6, 0x46, 0x74, 0x44, 0x91, 0xb9, 0x2b, 0xbe,
-	0x24, 0xae, 0xb1, 0x3d, 0xa2, 0x74, 0x14, 0x90, 0x5d, 0x3c, 0x0d, 0xa7, 0xcf, 0x76, 0xb9, 0x3f,
-	0x21, 0x8c, 0xbb, 0x93, 0x48, 0x01, 0x1e, 0x66, 0xae, 0xb9, 0x88, 0x9f, 0x47, 0x9c, 0x0a, 0x2c,
-	0x7d, 0xa6, 0xd8, 0xcd, 0x0c, 0xfb, 0x92, 0xc4, 0xcc, 0xa7, 0x61, 0xd6, 0x8e, 0x46, 0x6b, 0xc1,
-	0xca, 0x4b, 0x37, 0xf0, 0x3d, 0x97, 0xd3, 0x58, 0x22, 0xda, 0xbf, 0x80, 0xda, 0xb9, 0x1b, 0xf3,
-	0x3e, 0xe1, 0x1f, 0x10, 0xd7, 0x23, 0xb1, 0xb5, 0x05, 0x05, 0x4e, 0xb9, 0x1b, 0xd4, 0xb5, 0x96,
-	0xb6, 0x53, 0xb3, 0xe5, 0xc1, 0xb2, 0x40, 0x1f, 0xbb, 0x6c, 0x5c, 0xcf, 0xb5, 0xb4, 0x9d, 0xaa,
-	0x8d, 0xdf, 0xed, 0x31, 0xe8, 0x42, 0x54, 0x48, 0xf8, 0xa1, 0x47, 0xae, 0x13, 0x09, 0x3c, 0x08,
-	0xea, 0xf0, 0x39, 0x27, 0x4c, 0x89, 0xc8, 0x83, 0xf5, 0x53, 0x28, 0xa0, 0xfd, 0xf5, 0x7c, 0x4b,
-	0xdb, 0xa9, 0xec, 0xd5, 0x3b, 0x99, 0x40, 0x49, 0xff, 0x3a, 0xe7, 0x82, 0xdf, 0xd5, 0xbf, 0xf8,
-	0x6a, 0x7b, 0xcd, 0x96, 0xe0, 0x76, 0x00, 0xa5, 0x6e, 0x40, 0x2f, 0x3e, 0xe9, 0x1d, 0xa6, 0x86,
-	0x68, 0x33, 0x43, 0xac, 0x13, 0xd8, 0x88, 0xdc, 0x98, 0x3b, 0x8c, 0x70, 0x67, 0x8c, 0x5e, 0xe0,
-	0xa5, 0x95, 0xbd, 0xed, 0xce, 0xdd, 0x3c, 0x74, 0xe6, 0x9c, 0x55, 0xb7, 0xd4, 0xa2, 0x2c, 0xb1,
-	0xfd, 0x1f, 0x1d, 0x8a, 0x2a, 0x18, 0xef, 0x41, 0x49, 0x85, 0x15, 0x2f, 0xac, 0xec, 0x3d, 0xcc,
-	0x6a, 0x54, 0xac, 0xce, 0x01, 0x0d, 0x19, 0x09, 0xd9, 0x94, 0x29, 0x7d, 0x89, 0x8c, 0xf5, 0x43,
-	0x30, 0x2e, 0xc6, 0xae, 0x1f, 0x3a, 0xbe, 0x87, 0x16, 0x95, 0xbb, 0x95, 0x17, 0x5f, 0x6d, 0x97,
-	0x0e, 0x04, 0xad, 0x77, 0x68, 0x97, 0x90, 0xd9, 0xf3, 0xac, 0x7b, 0x50, 0x1c, 0x13, 0x7f, 0x34,
-	0xe6, 0x18, 0x96, 0xbc, 0xad, 0x4e, 0xd6, 0xcf, 0x41, 0x17, 0x05, 0x51, 0xd7, 0xf1, 0xee, 0x46,
-	0x47, 0x56, 0x4b, 0x27, 0xa9, 0x96, 0xce, 0x20, 0xa9, 0x96, 0xae, 0x21, 0x2e, 0xfe, 0xec, 0x5f,
-	0xdb, 0x9a, 0x8d, 0x12, 0xd6, 0x01, 0xd4, 0x02, 0x97, 0x71, 0x67, 0x28, 0xc2, 0x26, 0xae, 0x2f,
-	0xa0, 0x8a, 0xfb, 0x8b, 0x01, 0x51, 0x81, 0x55, 0xa6, 0x57, 0x84, 0x94, 0x24, 0x79, 0xd6, 0x0e,
-	0x98, 0xa8, 0xe4, 0x82, 0x4e, 0x26, 0x3e, 0x77, 0x30, 0xee, 0x45, 0x8c, 0xfb, 0xba, 0xa0, 0x1f,
-	0x20, 0xf9, 0x03, 0x91, 0x81, 0x07, 0x50, 0xf6, 0x5c, 0xee, 0x4a, 0x48, 0x09, 0x21, 0x86, 0x20,
-	0x20, 0xf3, 0x47, 0xb0, 0x91, 0x56, 0x1d, 0x93, 0x10, 0x43, 0x6a, 0x99, 0x91, 0x11, 0xf8, 0x08,
-	0xb6, 0x42, 0x72, 0xcd, 0x9d, 0xbb, 0xe8, 0x32, 0xa2, 0x2d, 0xc1, 0x7b, 0x3a, 0x2f, 0xf1, 0x03,
-	0x58, 0xbf, 0x48, 0x82, 0x2f, 0xb1, 0x80, 0xd8, 0x5a, 0x4a, 0x45, 0xd8, 0x7d, 0x30, 0xdc, 0x28,
-	0x92, 0x80, 0x0a, 0x02, 0x4a, 0x6e, 0x14, 0x21, 0xeb, 0x2d, 0xd8, 0x44, 0x1f, 0x63, 0xc2, 0xa6,
-	0x01, 0x57, 0x4a, 0xaa, 0x88, 0xd9, 0x10, 0x0c, 0x5b, 0xd2, 0x11, 0xfb, 0x7d, 0xa8, 0x91, 0x4b,
-	0xdf, 0x23, 0xe1, 0x05, 0x91, 0xb8, 0x1a, 0xe2, 0xaa, 0x09, 0x11, 0x41, 0x6f, 0x82, 0x19, 0xc5,
-	0x34, 0xa2, 0x8c, 0xc4, 0x8e, 0xeb, 0x79, 0x31, 0x61, 0xac, 0xbe, 0x2e, 0xf5, 0x25, 0xf4, 0x7d,
-	0x49, 0x6e, 0x3b, 0xa0, 0x1f, 0xba, 0xdc, 0xb5, 0x4c, 0xc8, 0xf3, 0x6b, 0x56, 0xd7, 0x5a, 0xf9,
-	0x9d, 0xaa, 0x2d, 0x3e, 0xad, 0x6d, 0xa8, 0xb0, 0xdf, 0x4d, 0xdd, 0x98, 0x38, 0xcc, 0xff, 0x94,
-	0x60, 0xf2, 0x74, 0x1b, 0x24, 0xa9, 0xef, 0x7f, 0x4a, 0xd2, 0x36, 0x28, 0xce, 0xda, 0xe0, 0x89,
-	0x6e, 0xe4, 0xcc, 0xfc, 0x13, 0xdd, 0xc8, 0x9b, 0xfa, 0x13, 0xdd, 0xd0, 0xcd, 0x42, 0xfb, 0x0f,
-	0x1a, 0xe8, 0xdd, 0x80, 0x0e, 0xad, 0xef, 0x41, 0x35, 0x74, 0x27, 0x84, 0x45, 0xee, 0x05, 0x11,
-	0xd5, 0x20, 0xbb, 0xa7, 0x92, 0xd2, 0x7a, 0x9e, 0xd0, 0x28, 0x32, 0x96, 0x74, 0xb8, 0xf8, 0x16,
-	0x0e, 0xb3, 0xb1, 0xb0, 0x22, 0x69, 0x82, 0x3c, 0x76, 0x78, 0x15, 0x89, 0x4f, 0x55, 0x91, 0xff,
-	0x18, 0x36, 0x67, 0xba, 0x13, 0xa0, 0x8e, 0x40, 0x33, 0x65, 0x28, 0x70, 0xfb, 0xeb, 0x1c, 0xe8,
-	0x4f, 0x29, 0x27, 0xd6, 0x3b, 0xa0, 0x8b, 0xfa, 0x43, 0x4b, 0xd6, 0x97, 0x35, 0x6a, 0xdf, 0x1f,
-	0x85, 0xc4, 0x3b, 0x61, 0xa3, 0xc1, 0xf3, 0x88, 0xd8, 0x08, 0xce, 0xf4, 0x49, 0x6e, 0xae, 0x4f,
-	0xb6, 0xa0, 0x10, 0xd3, 0x69, 0xe8, 0xa1, 0x7d, 0x05, 0x5b, 0x1e, 0xac, 0x23, 0x30, 0xd2, 0xf2,
-	0xd7, 0xff, 0x5f, 0xf9, 0x6f, 0x88, 0xf2, 0x17, 0xcd, 0xa9, 0x08, 0x76, 0x69, 0xa8, 0xba, 0xa0,
-	0x0b, 0xe5, 0xf4, 0x55, 0x56, 0x6d, 0xf4, 0xcd, 0x3a, 0x71, 0x26, 0x26, 0x62, 0x94, 0x16, 0x75,
-	0x5a, 0x15, 0x32, 0x77, 0x66, 0xca, 0x50, 0x65, 0x31, 0xd7, 0x2f, 0x8e, 0x7c, 0x59, 0x4b, 0xe8,
-	0xd7, 0xac, 0x5f, 0x7a, 0xf8, 0xc4, 0xbe, 0x01, 0x65, 0xe6, 0x8f, 0x42, 0x97, 0x4f, 0x63, 0xa2,
-	0x5a, 0x6a, 0x46, 0x68, 0xff, 0x55, 0x83, 0xa2, 0x6c, 0xd1, 0x4c, 0xdc, 0xb4, 0xe5, 0x71, 0xcb,
-	0xad, 0x8a, 0x5b, 0xfe, 0xd5, 0xe3, 0xb6, 0x0f, 0x90, 0x1a, 0xc3, 0xea, 0x7a, 0x2b, 0xbf, 0x53,
-	0xd9, 0x7b, 0xb0, 0xa8, 0x48, 0x9a, 0xd8, 0xf7, 0x47, 0xea, 0x05, 0xca, 0x08, 0xb5, 0xff, 0xa9,
-	0x41, 0x39, 0xe5, 0x5b, 0xfb, 0x50, 0x4b, 0xec, 0x72, 0x9e, 0x05, 0xee, 0x48, 0xd5, 0xce, 0xc3,
-	0x95, 0xc6, 0xbd, 0x1f, 0xb8, 0x23, 0xbb, 0xa2, 0xec, 0x11, 0x87, 0xe5, 0x79, 0xc8, 0xad, 0xc8,
-	0xc3, 0x5c, 0xe2, 0xf3, 0xaf, 0x96, 0xf8, 0xb9, 0x14, 0xe9, 0x77, 0x53, 0xf4, 0x97, 0x1c, 0x18,
-	0xe7, 0xf8, 0x28, 0xb8, 0xc1, 0x77, 0xd1, 0x11, 0x0f, 0xa0, 0x1c, 0xd1, 0xc0, 0x91, 0x1c, 0x1d,
-	0x39, 0x46, 0x44, 0x03, 0x7b, 0x21, 0xed, 0x85, 0x6f, 0xa9, 0x5d, 0x8a, 0xdf, 0x42, 0xd4, 0x4a,
-	0x77, 0xa3, 0x16, 0x43, 0x55, 0x86, 0x42, 0x0d, 0xe9, 0x47, 0x22, 0x06, 0x38, 0xf5, 0xb5, 0xc5,
-	0xa5, 0x42, 0x9a, 0x2d, 0x91, 0xb6, 0xc2, 0x09, 0x09, 0x39, 0xd3, 0xd4, 0x9e, 0x50, 0x5f, 0x55,
-	0x96, 0xb6, 0xc2, 0xb5, 0xff, 0xa8, 0x01, 0x1c, 0x8b, 0xc8, 0xa2, 0xbf, 0x62, 0xbc, 0x32, 0x34,
-	0xc1, 0x99, 0xbb, 0xb9, 0xb9, 0x2a, 0x69, 0xea, 0xfe, 0x2a, 0xcb, 0xda, 0x7d, 0x00, 0xb5, 0x59,
-	0x31, 0x32, 0x92, 0x18, 0xb3, 0x44, 0x49, 0x3a, 0xf5, 0xfa, 0x84, 0xdb, 0xd5, 0xcb, 0xcc, 0xa9,
-	0xfd, 0x37, 0x0d, 0xca, 0x68, 0xd3, 0x09, 0xe1, 0xee, 0x5c, 0x0e, 0xb5, 0x57, 0xcf, 0xe1, 0x43,
-	0x00, 0xa9, 0x06, 0xa7, 0x8f, 0xac, 0xac, 0x32, 0x52, 0x70, 0xf8, 0xfc, 0x2c, 0x0d, 0x78, 0xfe,
-	0x7f, 0x07, 0x5c, 0xb5, 0x74, 0x12, 0xf6, 0xd7, 0xa1, 0x14, 0x4e, 0x27, 0x8e, 0x98, 0x75, 0xba,
-	0xac, 0xd6, 0x70, 0x3a, 0x19, 0x5c, 0xb3, 0xf6, 0x6f, 0xa1, 0x34, 0xb8, 0xc6, 0xbd, 0x4f, 0x94,
-	0x68, 0x4c, 0xa9, 0x5a, 0x36, 0xe4, 0x98, 0x32, 0x04, 0x01, 0x67, 0xeb, 0xb2, 0x19, 0xd5, 0xf9,
-	0x86, 0x1b, 0x65, 0xb2, 0x4b, 0xfe, 0x06, 0xaa, 0xf8, 0x7a, 0x7e, 0x1c, 0xbb, 0x51, 0x44, 0x62,
-	0x6b, 0x1d, 0x72, 0xfc, 0x5a, 0xdd, 0x94, 0xe3, 0xd7, 0xb3, 0x99, 0x87, 0x2f, 0x2f, 0xee, 0xaf,
-	0xf9, 0x74, 0xe6, 0xf5, 0x24, 0x4d, 0x78, 0x22, 0xfc, 0x4c, 0x5e, 0xc8, 0xb2, 0x5d, 0x14, 0xc7,
-	0x9e, 0xd7, 0x76, 0xa0, 0x28, 0x06, 0xee, 0xe0, 0x7a, 0x41, 0xef, 0xdb, 0x50, 0x18, 0x06, 0x74,
-	0x28, 0xf5, 0x55, 0xf6, 0xee, 0x2d, 0xcd, 0xcb, 0xd0, 0x96, 0xa0, 0xd5, 0x17, 0x7c, 0xad, 0x01,
-	0xf4, 0x85, 0x29, 0x32, 0x5c, 0x49, 0x44, 0xe4, 0xee, 0x20, 0x23, 0xf2, 0x1e, 0x48, 0x63, 0x1d,
-	0x74, 0x38, 0xb9, 0xb0, 0xb1, 0x78, 0xe1, 0xe9, 0xc9, 0x40, 0x86, 0xa6, 0xc2, 0x52, 0x8d, 0x6c,
-	0x61, 0x57, 0xc8, 0x2f, 0xee, 0x0a, 0xef, 0x8a, 0x24, 0x5d, 0x49, 0xfd, 0xe9, 0x72, 0xba, 0xa0,
-	0xde, 0xa6, 0x57, 0x52, 0xbd, 0x11, 0xab, 0xaf, 0xe5, 0xbb, 0x42, 0x61, 0xc5, 0xae, 0xf0, 0xb9,
-	0x06, 0x46, 0xa2, 0x43, 0xd6, 0xc5, 0x95, 0x23, 0x4a, 0x21, 0xd9, 0x94, 0x84, 0x5a, 0x5b, 0x9c,
-	0x45, 0x3f, 0xcf, 0xf9, 0xba, 0xba, 0x08, 0x14, 0x4e, 0xc4, 0x4d, 0xa8, 0x52, 0xce, 0xe1, 0xb7,
-	0xb8, 0x82, 0x71, 0xf1, 0x3b, 0x22, 0xa6, 0x57, 0x6a, 0x81, 0x31, 0x90, 0x60, 0xd3, 0x2b, 0x91,
-	0x10, 0x12, 0x7a, 0xc8, 0x92, 0xf6, 0x16, 0x49, 0xe8, 0xd9, 0xf4, 0xaa, 0x4d, 0xc0, 0x48, 0xe2,
-	0x28, 0x5e, 0x5d, 0x14, 0xc0, 0xb4, 0x17, 0x6c, 0x79, 0x10, 0xeb, 0x1d, 0x49, 0x67, 0xac, 0xf8,
-	0x14, 0xb8, 0x90, 0x7a, 0x84, 0xd5, 0xf3, 0xe8, 0x88, 0x3c, 0x88, 0xfb, 0x03, 0xe2, 0x3e, 0x93,
-	0xa5, 0x2f, 0x67, 0x85, 0x21, 0x08, 0xa2, 0xf4, 0xdf, 0xfa, 0xbb, 0x06, 0x95, 0xcc, 0x58, 0xb3,
-	0x7e, 0x02, 0xaf, 0x75, 0x8f, 0xcf, 0x0e, 0x3e, 0x74, 0x7a, 0x87, 0xce, 0xfb, 0xc7, 0xfb, 0x8f,
-	0x9d, 0x8f, 0x4e, 0x3f, 0x3c, 0x3d, 0xfb, 0xf8, 0xd4, 0x5c, 0x6b, 0xdc, 0xbb, 0xb9, 0x6d, 0x59,
-	0x19, 0xec, 0x47, 0xe1, 0x27, 0x21, 0xbd, 0x0a, 0xad, 0x5d, 0xd8, 0x9a, 0x17, 0xd9, 0xef, 0xf6,
-	0x8f, 0x4e, 0x07, 0xa6, 0xd6, 0x78, 0xed, 0xe6, 0xb6, 0xb5, 0x99, 0x91, 0xd8, 0x1f, 0x32, 0x12,
-	0xf2, 0x45, 0x81, 0x83, 0xb3, 0x93, 0x93, 0xde, 0xc0, 0xcc, 0x2d, 0x08, 0xa8, 0x3d, 0xe3, 0x4d,
-	0xd8, 0x9c, 0x17, 0x38, 0xed, 0x1d, 0x9b, 0xf9, 0x86, 0x75, 0x73, 0xdb, 0x5a, 0xcf, 0xa0, 0x4f,
-	0xfd, 0xa0, 0x61, 0xfc, 0xfe, 0xf3, 0xe6, 0xda, 0x9f, 0xff, 0xd4, 0xd4, 0x84, 0x67, 0xb5, 0xb9,
-	0xd1, 0x66, 0xbd, 0x0d, 0xaf, 0xf7, 0x7b, 0x8f, 0x4f, 0x8f, 0x0e, 0x9d, 0x93, 0xfe, 0x63, 0x67,
-	0xf0, 0xab, 0xf3, 0xa3, 0x8c, 0x77, 0x1b, 0x37, 0xb7, 0xad, 0x8a, 0x72, 0x69, 0x15, 0xfa, 0xdc,
-	0x3e, 0x7a, 0x7a, 0x36, 0x38, 0x32, 0x35, 0x89, 0x3e, 0x8f, 0xc9, 0x25, 0xe5, 0x04, 0xd1, 0x8f,
-	0xe0, 0xfe, 0x12, 0x74, 0xea, 0xd8, 0xe6, 0xcd, 0x6d, 0xab, 0x76, 0x1e, 0x13, 0xf9, 0xec, 0xa3,
-	0x44, 0x07, 0xea, 0x8b, 0x12, 0x67, 0xe7, 0x67, 0xfd, 0xfd, 0x63, 0xb3, 0xd5, 0x30, 0x6f, 0x6e,
-	0x5b, 0xd5, 0x64, 0x86, 0x0b, 0xfc, 0xcc, 0xb3, 0xee, 0x2f, 0xbf, 0x78, 0xd1, 0xd4, 0xbe, 0x7c,
-	0xd1, 0xd4, 0xfe, 0xfd, 0xa2, 0xa9, 0x7d, 0xf6, 0xb2, 0xb9, 0xf6, 0xe5, 0xcb, 0xe6, 0xda, 0x3f,
-	0x5e, 0x36, 0xd7, 0x7e, 0xfd, 0xee, 0xc8, 0xe7, 0xe3, 0xe9, 0xb0, 0x73, 0x41, 0x27, 0xbb, 0xd9,
-	0x9f, 0xe8, 0xb3, 0x4f, 0xf9, 0xaf, 0x82, 0xbb, 0x3f, 0xdf, 0x87, 0x45, 0xa4, 0xbf, 0xf3, 0xdf,
-	0x00, 0x00, 0x00, 0xff, 0xff, 0xea, 0x3f, 0x8f, 0x46, 0x7f, 0x10, 0x00, 0x00,
+	// 1884 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x58, 0x4f, 0x6f, 0x1b, 0xd7,
+	0x11, 0xd7, 0x92, 0xcb, 0x7f, 0x43, 0x52, 0xa2, 0x16, 0xb2, 0x43, 0xd3, 0x36, 0xc5, 0x6c, 0xd1,
+	0x46, 0x49, 0x03, 0xca, 0x55, 0x8a, 0x26, 0x3d, 0xe4, 0x20, 0x4a, 0x8a, 0x43, 0x5b, 0xa2, 0xd4,
+	0x25, 0xed, 0xa0, 0x45, 0x81, 0xc5, 0x92, 0xfb, 0x44, 0x6e, 0x43, 0xee, 0xdb, 0xec, 0x2e, 0x25,
+	0x39, 0xf7, 0x02, 0x85, 0x2e, 0xcd, 0xa9, 0x37, 0x9d, 0xd2, 0x43, 0xef, 0xfd, 0x02, 0x3d, 0x15,
+	0x39, 0xe6, 0xd6, 0x5e, 0x9a, 0x16, 0x36, 0x50, 0xf4, 0x43, 0x14, 0x45, 0x31, 0xf3, 0xde, 0x2e,
+	0x97, 0x22, 0xe5, 0xa6, 0x86, 0x91, 0x8b, 0xf0, 0xde, 0xbc, 0xdf, 0xfc, 0x9f, 0xd9, 0x19, 0x0a,
+	0xee, 0x85, 0xcc, 0xb5, 0x99, 0x3f, 0x71, 0xdc, 0x70, 0x3b, 0x7c, 0xe6, 0xb1, 0x40, 0xfc, 0x6d,
+	0x7a, 0x3e, 0x0f, 0xb9, 0x56, 0x99, 0xbd, 0x36, 0x89, 0x5e, 0xdb, 0x18, 0xf2, 0x21, 0xa7, 0xc7,
+	0x6d, 0x3c, 0x09, 0x5c, 0x6d, 0x73, 0xc8, 0xf9, 0x70, 0xcc, 0xb6, 0xe9, 0xd6, 0x9f, 0x9e, 0x6e,
+	0x87, 0xce, 0x84, 0x05, 0xa1, 0x35, 0xf1, 0x24, 0xe0, 0x7e, 0x42, 0xcd, 0xc0, 0x7f, 0xe6, 0x85,
+	0x1c, 0xb1, 0xfc, 0x54, 0x3e, 0xd7, 0x13, 0xcf, 0x67, 0xcc, 0x0f, 0x1c, 0xee, 0x26, 0xed, 0xa8,
+	0x35, 0x16, 0xac, 0x3c, 0xb3, 0xc6, 0x8e, 0x6d, 0x85, 0xdc, 0x17, 0x08, 0xfd, 0xa7, 0x50, 0x3e,
+	0xb1, 0xfc, 0xb0, 0xcb, 0xc2, 0x8f, 0x99, 0x65, 0x33, 0x5f, 0xdb, 0x80, 0x4c, 0xc8, 0x43, 0x6b,
+	0x5c, 0x55, 0x1a, 0xca, 0x56, 0xd9, 0x10, 0x17, 0x4d, 0x03, 0x75, 0x64, 0x05, 0xa3, 0x6a, 0xaa,
+	0xa1, 0x6c, 0x95, 0x0c, 0x3a, 0xeb, 0x23, 0x50, 0x91, 0x15, 0x39, 0x1c, 0xd7, 0x66, 0x17, 0x11,
+	0x07, 0x5d, 0x90, 0xda, 0x7f, 0x16, 0xb2, 0x40, 0xb2, 0x88, 0x8b, 0xf6, 0x63, 0xc8, 0x90, 0xfd,
+	0xd5, 0x74, 0x43, 0xd9, 0x2a, 0xee, 0x54, 0x9b, 0x89, 0x40, 0x09, 0xff, 0x9a, 0x27, 0xf8, 0xde,
+	0x52, 0xbf, 0xfa, 0x66, 0x73, 0xc5, 0x10, 0x60, 0x7d, 0x0c, 0xb9, 0xd6, 0x98, 0x0f, 0x3e, 0x6d,
+	0xef, 0xc7, 0x86, 0x28, 0x33, 0x43, 0xb4, 0x23, 0x58, 0xf3, 0x2c, 0x3f, 0x34, 0x03, 0x16, 0x9a,
+	0x23, 0xf2, 0x82, 0x94, 0x16, 0x77, 0x36, 0x9b, 0xd7, 0xf3, 0xd0, 0x9c, 0x73, 0x56, 0x6a, 0x29,
+	0x7b, 0x49, 0xa2, 0xfe, 0x4f, 0x15, 0xb2, 0xe2, 0xa8, 0x7d, 0x08, 0x39, 0x19, 0x56, 0x52, 0x58,
+	0xdc, 0xb9, 0x9f, 0x94, 0x28, 0x9f, 0x9a, 0x7b, 0xdc, 0x0d, 0x98, 0x1b, 0x4c, 0x03, 0x29, 0x2f,
+	0xe2, 0xd1, 0x7e, 0x00, 0xf9, 0xc1, 0xc8, 0x72, 0x5c, 0xd3, 0xb1, 0xc9, 0xa2, 0x42, 0xab, 0xf8,
+	0xfc, 0x9b, 0xcd, 0xdc, 0x1e, 0xd2, 0xda, 0xfb, 0x46, 0x8e, 0x1e, 0xdb, 0xb6, 0x76, 0x1b, 0xb2,
+	0x23, 0xe6, 0x0c, 0x47, 0x21, 0x85, 0x25, 0x6d, 0xc8, 0x9b, 0xf6, 0x01, 0xa8, 0x58, 0x10, 0x55,
+	0x95, 0x74, 0xd7, 0x9a, 0xa2, 0x5a, 0x9a, 0x51, 0xb5, 0x34, 0x7b, 0x51, 0xb5, 0xb4, 0xf2, 0xa8,
+	0xf8, 0x8b, 0xbf, 0x6f, 0x2a, 0x06, 0x71, 0x68, 0x7b, 0x50, 0x1e, 0x5b, 0x41, 0x68, 0xf6, 0x31,
+	0x6c, 0xa8, 0x3e, 0x43, 0x22, 0xee, 0x2c, 0x06, 0x44, 0x06, 0x56, 0x9a, 0x5e, 0x44, 0x2e, 0x41,
+	0xb2, 0xb5, 0x2d, 0xa8, 0x90, 0x90, 0x01, 0x9f, 0x4c, 0x9c, 0xd0, 0xa4, 0xb8, 0x67, 0x29, 0xee,
+	0xab, 0x48, 0xdf, 0x23, 0xf2, 0xc7, 0x98, 0x81, 0xbb, 0x50, 0xb0, 0xad, 0xd0, 0x12, 0x90, 0x1c,
+	0x41, 0xf2, 0x48, 0xa0, 0xc7, 0xb7, 0x60, 0x2d, 0xae, 0xba, 0x40, 0x40, 0xf2, 0x42, 0xca, 0x8c,
+	0x4c, 0xc0, 0x07, 0xb0, 0xe1, 0xb2, 0x8b, 0xd0, 0xbc, 0x8e, 0x2e, 0x10, 0x5a, 0xc3, 0xb7, 0xa7,
+	0xf3, 0x1c, 0xdf, 0x87, 0xd5, 0x41, 0x14, 0x7c, 0x81, 0x05, 0xc2, 0x96, 0x63, 0x2a, 0xc1, 0xee,
+	0x40, 0xde, 0xf2, 0x3c, 0x01, 0x28, 0x12, 0x20, 0x67, 0x79, 0x1e, 0x3d, 0xbd, 0x03, 0xeb, 0xe4,
+	0xa3, 0xcf, 0x82, 0xe9, 0x38, 0x94, 0x42, 0x4a, 0x84, 0x59, 0xc3, 0x07, 0x43, 0xd0, 0x09, 0xfb,
+	0x3d, 0x28, 0xb3, 0x33, 0xc7, 0x66, 0xee, 0x80, 0x09, 0x5c, 0x99, 0x70, 0xa5, 0x88, 0x48, 0xa0,
+	0xb7, 0xa1, 0xe2, 0xf9, 0xdc, 0xe3, 0x01, 0xf3, 0x4d, 0xcb, 0xb6, 0x7d, 0x16, 0x04, 0xd5, 0x55,
+	0x21, 0x2f, 0xa2, 0xef, 0x0a, 0xb2, 0x6e, 0x82, 0xba, 0x6f, 0x85, 0x96, 0x56, 0x81, 0x74, 0x78,
+	0x11, 0x54, 0x95, 0x46, 0x7a, 0xab, 0x64, 0xe0, 0x51, 0xdb, 0x84, 0x62, 0xf0, 0xd9, 0xd4, 0xf2,
+	0x99, 0x19, 0x38, 0x9f, 0x33, 0x4a, 0x9e, 0x6a, 0x80, 0x20, 0x75, 0x9d, 0xcf, 0x59, 0xdc, 0x06,
+	0xd9, 0x59, 0x1b, 0x3c, 0x52, 0xf3, 0xa9, 0x4a, 0xfa, 0x91, 0x9a, 0x4f, 0x57, 0xd4, 0x47, 0x6a,
+	0x5e, 0xad, 0x64, 0xf4, 0xdf, 0x2a, 0xa0, 0xb6, 0xc6, 0xbc, 0xaf, 0xbd, 0x09, 0x25, 0xd7, 0x9a,
+	0xb0, 0xc0, 0xb3, 0x06, 0x0c, 0xab, 0x41, 0x74, 0x4f, 0x31, 0xa6, 0xb5, 0x6d, 0x94, 0x88, 0x19,
+	0x8b, 0x3a, 0x1c, 0xcf, 0xe8, 0x70, 0x30, 0x42, 0x2b, 0xa2, 0x26, 0x48, 0x53, 0x87, 0x97, 0x88,
+	0xf8, 0x54, 0xd0, 0xb4, 0x1f, 0xc2, 0xfa, 0x4c, 0x76, 0x04, 0x54, 0x09, 0x58, 0x89, 0x1f, 0x24,
+	0x58, 0xff, 0x57, 0x0a, 0xd4, 0xa7, 0x3c, 0x64, 0xda, 0x7b, 0xa0, 0x62, 0xfd, 0x91, 0x25, 0xab,
+	0xcb, 0x1a, 0xb5, 0xeb, 0x0c, 0x5d, 0x66, 0x1f, 0x05, 0xc3, 0xde, 0x33, 0x8f, 0x19, 0x04, 0x4e,
+	0xf4, 0x49, 0x6a, 0xae, 0x4f, 0x36, 0x20, 0xe3, 0xf3, 0xa9, 0x6b, 0x93, 0x7d, 0x19, 0x43, 0x5c,
+	0xb4, 0x03, 0xc8, 0xc7, 0xe5, 0xaf, 0xfe, 0xaf, 0xf2, 0x5f, 0xc3, 0xf2, 0xc7, 0xe6, 0x94, 0x04,
+	0x23, 0xd7, 0x97, 0x5d, 0xd0, 0x82, 0x42, 0xfc, 0x55, 0xae, 0x66, 0xfe, 0x8f, 0x4e, 0x9c, 0xb1,
+	0x61, 0x8c, 0xe2, 0xa2, 0x8e, 0xab, 0x42, 0xe4, 0xae, 0x12, 0x3f, 0xc8, 0xb2, 0x98, 0xeb, 0x17,
+	0x53, 0x7c, 0x59, 0x73, 0xe4, 0xd7, 0xac, 0x5f, 0xda, 0x48, 0xd5, 0xee, 0x41, 0x21, 0x70, 0x86,
+	0xae, 0x15, 0x4e, 0x7d, 0x26, 0x5b, 0x6a, 0x46, 0xd0, 0xff, 0xa4, 0x40, 0x56, 0xb4, 0x68, 0x22,
+	0x6e, 0xca, 0xf2, 0xb8, 0xa5, 0x6e, 0x8a, 0x5b, 0xfa, 0xd5, 0xe3, 0xb6, 0x0b, 0x10, 0x1b, 0x13,
+	0x54, 0xd5, 0x46, 0x7a, 0xab, 0xb8, 0x73, 0x77, 0x51, 0x90, 0x30, 0xb1, 0xeb, 0x0c, 0xe5, 0x17,
+	0x28, 0xc1, 0xa4, 0xff, 0x4d, 0x81, 0x42, 0xfc, 0xae, 0xed, 0x42, 0x39, 0xb2, 0xcb, 0x3c, 0x1d,
+	0x5b, 0x43, 0x59, 0x3b, 0xf7, 0x6f, 0x34, 0xee, 0xa3, 0xb1, 0x35, 0x34, 0x8a, 0xd2, 0x1e, 0xbc,
+	0x2c, 0xcf, 0x43, 0xea, 0x86, 0x3c, 0xcc, 0x25, 0x3e, 0xfd, 0x6a, 0x89, 0x9f, 0x4b, 0x91, 0x7a,
+	0x3d, 0x45, 0x7f, 0x4c, 0x41, 0xfe, 0x84, 0x3e, 0x0a, 0xd6, 0xf8, 0xbb, 0xe8, 0x88, 0xbb, 0x50,
+	0xf0, 0xf8, 0xd8, 0x14, 0x2f, 0x2a, 0xbd, 0xe4, 0x3d, 0x3e, 0x36, 0x16, 0xd2, 0x9e, 0x79, 0x4d,
+	0xed, 0x92, 0x7d, 0x0d, 0x51, 0xcb, 0x5d, 0x8f, 0x9a, 0x0f, 0x25, 0x11, 0x0a, 0x39, 0xa4, 0x1f,
+	0x60, 0x0c, 0xf0, 0x54, 0x55, 0x16, 0x97, 0x0a, 0x61, 0xb6, 0x40, 0x1a, 0xd9, 0x51, 0xcc, 0x21,
+	0x66, 0x5a, 0x35, 0x75, 0x13, 0x87, 0x28, 0x3b, 0x43, 0xe2, 0xf4, 0xdf, 0x29, 0x00, 0x87, 0x18,
+	0x59, 0xf2, 0x17, 0xc7, 0x6b, 0x40, 0x26, 0x98, 0x73, 0x9a, 0xeb, 0x37, 0x25, 0x4d, 0xea, 0x2f,
+	0x05, 0x49, 0xbb, 0xf7, 0xa0, 0x3c, 0x2b, 0xc6, 0x80, 0x45, 0xc6, 0x2c, 0x11, 0x12, 0x4f, 0xbd,
+	0x2e, 0x0b, 0x8d, 0xd2, 0x59, 0xe2, 0xa6, 0xff, 0x5b, 0x81, 0x02, 0xd9, 0x74, 0xc4, 0x42, 0x6b,
+	0x2e, 0x87, 0xca, 0xab, 0xe7, 0xf0, 0x3e, 0x80, 0x10, 0x43, 0xd3, 0x47, 0x54, 0x56, 0x81, 0x28,
+	0x34, 0x7c, 0x7e, 0x12, 0x07, 0x3c, 0xfd, 0xf2, 0x80, 0xcb, 0x96, 0x8e, 0xc2, 0xfe, 0x06, 0xe4,
+	0xdc, 0xe9, 0xc4, 0xc4, 0x59, 0xa7, 0x8a, 0x6a, 0x75, 0xa7, 0x93, 0xde, 0x45, 0xa0, 0x7d, 0x00,
+	0x59, 0x31, 0xdb, 0x64, 0xe1, 0x35, 0x16, 0x05, 0xe2, 0xa0, 0xec, 0x12, 0xa6, 0xed, 0x9e, 0x72,
+	0x43, 0xe2, 0xf5, 0xcf, 0x60, 0x75, 0xfe, 0xe5, 0xfa, 0xe8, 0x54, 0x16, 0x46, 0x27, 0x02, 0x70,
+	0x7e, 0x05, 0xe6, 0x34, 0x60, 0xb6, 0xf4, 0x0e, 0x04, 0xe9, 0x49, 0xc0, 0x22, 0xef, 0xfb, 0xa6,
+	0x58, 0x5f, 0xd3, 0xb1, 0xf7, 0xfd, 0x16, 0x12, 0xf4, 0x5f, 0x41, 0xae, 0x77, 0x41, 0x4b, 0x2a,
+	0xf6, 0x93, 0xcf, 0xb9, 0xdc, 0x8c, 0xc4, 0x4c, 0xcd, 0x23, 0x81, 0x16, 0x81, 0x65, 0x03, 0xb5,
+	0xf9, 0x2d, 0xd7, 0xdf, 0x68, 0xf1, 0xfd, 0x25, 0x94, 0xe8, 0x53, 0xff, 0x89, 0x6f, 0x79, 0x1e,
+	0xf3, 0xb5, 0x55, 0x48, 0x85, 0x17, 0x52, 0x53, 0x2a, 0xbc, 0x98, 0x0d, 0x68, 0x1a, 0x13, 0xb4,
+	0x6c, 0xa7, 0xe3, 0x01, 0xdd, 0x16, 0x34, 0x0c, 0x3b, 0xc6, 0x30, 0xfa, 0x9c, 0x17, 0x8c, 0x2c,
+	0x5e, 0xdb, 0xb6, 0x6e, 0x42, 0x16, 0xb7, 0x83, 0xde, 0xc5, 0x82, 0xdc, 0x77, 0x21, 0x83, 0x0e,
+	0x0b, 0x79, 0xc5, 0x9d, 0xdb, 0x4b, 0x8b, 0xa8, 0x6f, 0x08, 0xd0, 0xcd, 0x0a, 0x7e, 0x9d, 0x02,
+	0xe8, 0xa2, 0x29, 0x22, 0x5c, 0x51, 0x44, 0xc4, 0xa2, 0x43, 0x67, 0xed, 0x43, 0x10, 0xc6, 0x9a,
+	0xe4, 0x70, 0xa4, 0xb0, 0xb6, 0xa8, 0xb0, 0x73, 0xd4, 0x13, 0xa1, 0x11, 0xd9, 0xa3, 0x73, 0xb0,
+	0xb0, 0xd8, 0xa4, 0x17, 0x17, 0x9b, 0xf7, 0x31, 0x49, 0xe7, 0x42, 0x7e, 0xbc, 0x49, 0x2f, 0x88,
+	0x37, 0xf8, 0xb9, 0x10, 0x9f, 0xf7, 0xe5, 0x69, 0xf9, 0x62, 0x93, 0x59, 0xbe, 0xd8, 0xc4, 0x1b,
+	0x30, 0xa6, 0xbf, 0x9a, 0x9d, 0x6d, 0xc0, 0x06, 0xe7, 0xa1, 0xfe, 0x67, 0x05, 0xf2, 0x91, 0x02,
+	0x51, 0x34, 0xe7, 0x04, 0x8c, 0x76, 0x3e, 0xd4, 0x89, 0xc0, 0x00, 0xbf, 0x4c, 0x73, 0x81, 0xb8,
+	0xb9, 0x42, 0x24, 0x0e, 0x83, 0x4a, 0x3a, 0x85, 0xe7, 0x74, 0x46, 0x15, 0x41, 0x88, 0xbf, 0x88,
+	0x7c, 0x7e, 0x2e, 0x57, 0xb1, 0x3c, 0x11, 0x0c, 0x7e, 0x8e, 0xd9, 0x62, 0xae, 0x4d, 0x4f, 0xc2,
+	0x99, 0x2c, 0x73, 0x6d, 0x7c, 0x78, 0x13, 0x4a, 0x54, 0xcd, 0x8e, 0x6b, 0x3b, 0x03, 0x86, 0xfb,
+	0x09, 0xd6, 0x52, 0x11, 0x69, 0x6d, 0x41, 0xd2, 0x19, 0xe4, 0xa3, 0x3c, 0xe0, 0x88, 0x21, 0x99,
+	0x54, 0x36, 0x19, 0x43, 0x5c, 0x70, 0x97, 0x65, 0xf1, 0x42, 0x81, 0x47, 0xc4, 0xb9, 0xdc, 0xa6,
+	0x4e, 0x42, 0x5f, 0xc5, 0x05, 0x4d, 0x1c, 0x33, 0xeb, 0x54, 0xb4, 0x8e, 0x18, 0x8c, 0x79, 0x24,
+	0x60, 0xeb, 0xe8, 0xff, 0x51, 0x60, 0x63, 0x8f, 0x4f, 0x3c, 0x1c, 0xc3, 0xcc, 0x4e, 0x54, 0xd0,
+	0x4e, 0xd4, 0x3f, 0xe2, 0xe3, 0x76, 0x6f, 0xc9, 0xf7, 0x36, 0x06, 0xcb, 0x1e, 0x9a, 0xe9, 0x4f,
+	0x25, 0xf5, 0x1f, 0xc3, 0x7a, 0xa2, 0xee, 0xcc, 0x99, 0x85, 0xc5, 0x65, 0x0b, 0x45, 0x87, 0xdb,
+	0x4c, 0xc6, 0x40, 0x7e, 0xd3, 0xd6, 0x66, 0x55, 0xd8, 0x21, 0x81, 0x8f, 0x61, 0x2d, 0x2e, 0x33,
+	0xd3, 0x9a, 0xba, 0x61, 0xb4, 0xf3, 0x7c, 0x2b, 0x71, 0xe5, 0xa8, 0xea, 0x76, 0x91, 0x53, 0x7f,
+	0x0b, 0x8a, 0x09, 0x8c, 0x56, 0x85, 0x5c, 0x94, 0x14, 0x85, 0x92, 0x12, 0x5d, 0xf5, 0x2f, 0x15,
+	0xb8, 0x15, 0x4f, 0x87, 0x5d, 0xd7, 0xe5, 0x53, 0x77, 0xc0, 0x26, 0xcc, 0x0d, 0x97, 0xaf, 0x3a,
+	0xca, 0x0d, 0xab, 0x0e, 0x7e, 0x99, 0xb9, 0xcd, 0xe2, 0xdf, 0xa9, 0x46, 0x16, 0xaf, 0x6d, 0x7b,
+	0xee, 0x17, 0x6c, 0xfa, 0x25, 0xbf, 0x60, 0x5f, 0xba, 0xe7, 0xbc, 0xf3, 0x17, 0x05, 0x8a, 0x89,
+	0x9d, 0x4c, 0xfb, 0x11, 0xdc, 0x6a, 0x1d, 0x1e, 0xef, 0x3d, 0x36, 0xdb, 0xfb, 0xe6, 0x47, 0x87,
+	0xbb, 0x0f, 0xcd, 0x27, 0x9d, 0xc7, 0x9d, 0xe3, 0x4f, 0x3a, 0x95, 0x95, 0xda, 0xed, 0xcb, 0xab,
+	0x86, 0x96, 0xc0, 0x3e, 0x71, 0x3f, 0x75, 0xf9, 0xb9, 0xab, 0x6d, 0xc3, 0xc6, 0x3c, 0xcb, 0x6e,
+	0xab, 0x7b, 0xd0, 0xe9, 0x55, 0x94, 0xda, 0xad, 0xcb, 0xab, 0xc6, 0x7a, 0x82, 0x63, 0xb7, 0x1f,
+	0xa0, 0xff, 0x0b, 0x0c, 0x7b, 0xc7, 0x47, 0x47, 0xed, 0x5e, 0x25, 0xb5, 0xc0, 0x20, 0x97, 0xe4,
+	0xb7, 0x61, 0x7d, 0x9e, 0xa1, 0xd3, 0x3e, 0xac, 0xa4, 0x6b, 0xda, 0xe5, 0x55, 0x63, 0x35, 0x81,
+	0xee, 0x38, 0xe3, 0x5a, 0xfe, 0x37, 0x5f, 0xd6, 0x57, 0xfe, 0xf0, 0xfb, 0xba, 0x82, 0x9e, 0x95,
+	0xe7, 0xf6, 0x32, 0xed, 0x5d, 0x78, 0xa3, 0xdb, 0x7e, 0xd8, 0x39, 0xd8, 0x37, 0x8f, 0xba, 0x0f,
+	0xcd, 0xde, 0xcf, 0x4f, 0x0e, 0x12, 0xde, 0xad, 0x5d, 0x5e, 0x35, 0x8a, 0xd2, 0xa5, 0x9b, 0xd0,
+	0x27, 0xc6, 0xc1, 0xd3, 0xe3, 0xde, 0x41, 0x45, 0x11, 0xe8, 0x13, 0x9f, 0x9d, 0xf1, 0x90, 0x11,
+	0xfa, 0x01, 0xdc, 0x59, 0x82, 0x8e, 0x1d, 0x5b, 0xbf, 0xbc, 0x6a, 0x94, 0x4f, 0x7c, 0x26, 0x76,
+	0x16, 0xe2, 0x68, 0x42, 0x75, 0x91, 0xe3, 0xf8, 0xe4, 0xb8, 0xbb, 0x7b, 0x58, 0x69, 0xd4, 0x2a,
+	0x97, 0x57, 0x8d, 0x52, 0xb4, 0x80, 0x22, 0x7e, 0xe6, 0x59, 0xeb, 0x67, 0x5f, 0x3d, 0xaf, 0x2b,
+	0x5f, 0x3f, 0xaf, 0x2b, 0xff, 0x78, 0x5e, 0x57, 0xbe, 0x78, 0x51, 0x5f, 0xf9, 0xfa, 0x45, 0x7d,
+	0xe5, 0xaf, 0x2f, 0xea, 0x2b, 0xbf, 0x78, 0x7f, 0xe8, 0x84, 0xa3, 0x69, 0xbf, 0x39, 0xe0, 0x93,
+	0xed, 0xe4, 0xff, 0x97, 0x66, 0x47, 0xf1, 0x7f, 0xae, 0xeb, 0xff, 0x7b, 0xea, 0x67, 0x89, 0xfe,
+	0xde, 0x7f, 0x07, 0x00, 0x9d, 0x4c, 0xbd, 0x02, 0x3c, 0x13, 0x00, 0x00,
 }
 
 func (m *PartSetHeader) Marshal() (dAtA []byte, err error) {
@@ -2330,6 +2632,18 @@ func (m *BlockMeta) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.Square != nil {
+		{
+			size, err := m.Square.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x2a
+	}
 	if m.NumTxs != 0 {
 		i = encodeVarintTypes(dAtA, i, uint64(m.NumTxs))
 		i--
@@ -2363,6 +2677,44 @@ func (m *BlockMeta) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *DataSquareInfo) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DataSquareInfo) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DataSquareInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.BlobBytes != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.BlobBytes))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.SharesUsed != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.SharesUsed))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.SquareSize != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.SquareSize))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *TxProof) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -2440,20 +2792,20 @@ func (m *IndexWrapper) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		dAtA[i] = 0x1a
 	}
 	if len(m.ShareIndexes) > 0 {
-		dAtA20 := make([]byte, len(m.ShareIndexes)*10)
-		var j19 int
+		dAtA21 := make([]byte, len(m.ShareIndexes)*10)
+		var j20 int
 		for _, num := range m.ShareIndexes {
 			for num >= 1<<7 {
-				dAtA20[j19] = uint8(uint64(num)&0x7f | 0x80)
+				dAtA21[j20] = uint8(uint64(num)&0x7f | 0x80)
 				num >>= 7
-				j19++
+				j20++
 			}
-			dAtA20[j19] = uint8(num)
-			j19++
+			dAtA21[j20] = uint8(num)
+			j20++
 		}
-		i -= j19
-		copy(dAtA[i:], dAtA20[:j19])
-		i = encodeVarintTypes(dAtA, i, uint64(j19))
+		i -= j20
+		copy(dAtA[i:], dAtA21[:j20])
+		i = encodeVarintTypes(dAtA, i, uint64(j20))
 		i--
 		dAtA[i] = 0x12
 	}
@@ -2538,6 +2890,13 @@ func (m *ShareProof) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.DataRoot) > 0 {
+		i -= len(m.DataRoot)
+		copy(dAtA[i:], m.DataRoot)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.DataRoot)))
+		i--
+		dAtA[i] = 0x32
+	}
 	if m.NamespaceVersion != 0 {
 		i = encodeVarintTypes(dAtA, i, uint64(m.NamespaceVersion))
 		i--
@@ -2608,6 +2967,24 @@ func (m *RowProof) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.RootIndices) > 0 {
+		dAtA24 := make([]byte, len(m.RootIndices)*10)
+		var j23 int
+		for _, num := range m.RootIndices {
+			for num >= 1<<7 {
+				dAtA24[j23] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j23++
+			}
+			dAtA24[j23] = uint8(num)
+			j23++
+		}
+		i -= j23
+		copy(dAtA[i:], dAtA24[:j23])
+		i = encodeVarintTypes(dAtA, i, uint64(j23))
+		i--
+		dAtA[i] = 0x32
+	}
 	if m.EndRow != 0 {
 		i = encodeVarintTypes(dAtA, i, uint64(m.EndRow))
 		i--
@@ -2700,26 +3077,190 @@ func (m *NMTProof) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func encodeVarintTypes(dAtA []byte, offset int, v uint64) int {
-	offset -= sovTypes(v)
-	base := offset
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+func (m *CompressedShareProof) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	dAtA[offset] = uint8(v)
-	return base
+	return dAtA[:n], nil
 }
-func (m *PartSetHeader) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+
+func (m *CompressedShareProof) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CompressedShareProof) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.Total != 0 {
-		n += 1 + sovTypes(uint64(m.Total))
-	}
+	if len(m.RowProofAunts) > 0 {
+		for iNdEx := len(m.RowProofAunts) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.RowProofAunts[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintTypes(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if len(m.ShareProofNodes) > 0 {
+		for iNdEx := len(m.ShareProofNodes) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.ShareProofNodes[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintTypes(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if len(m.Nodes) > 0 {
+		for iNdEx := len(m.Nodes) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Nodes[iNdEx])
+			copy(dAtA[i:], m.Nodes[iNdEx])
+			i = encodeVarintTypes(dAtA, i, uint64(len(m.Nodes[iNdEx])))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if m.Proof != nil {
+		{
+			size, err := m.Proof.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *NodeIndices) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *NodeIndices) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *NodeIndices) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Indices) > 0 {
+		dAtA27 := make([]byte, len(m.Indices)*10)
+		var j26 int
+		for _, num := range m.Indices {
+			for num >= 1<<7 {
+				dAtA27[j26] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j26++
+			}
+			dAtA27[j26] = uint8(num)
+			j26++
+		}
+		i -= j26
+		copy(dAtA[i:], dAtA27[:j26])
+		i = encodeVarintTypes(dAtA, i, uint64(j26))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ValidatorAnnouncement) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ValidatorAnnouncement) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ValidatorAnnouncement) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Signature) > 0 {
+		i -= len(m.Signature)
+		copy(dAtA[i:], m.Signature)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Signature)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.ChainID) > 0 {
+		i -= len(m.ChainID)
+		copy(dAtA[i:], m.ChainID)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.ChainID)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.NodeId) > 0 {
+		i -= len(m.NodeId)
+		copy(dAtA[i:], m.NodeId)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.NodeId)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.ValidatorAddress) > 0 {
+		i -= len(m.ValidatorAddress)
+		copy(dAtA[i:], m.ValidatorAddress)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.ValidatorAddress)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintTypes(dAtA []byte, offset int, v uint64) int {
+	offset -= sovTypes(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *PartSetHeader) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Total != 0 {
+		n += 1 + sovTypes(uint64(m.Total))
+	}
 	l = len(m.Hash)
 	if l > 0 {
 		n += 1 + l + sovTypes(uint64(l))
@@ -3020,6 +3561,28 @@ func (m *BlockMeta) Size() (n int) {
 	if m.NumTxs != 0 {
 		n += 1 + sovTypes(uint64(m.NumTxs))
 	}
+	if m.Square != nil {
+		l = m.Square.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+
+func (m *DataSquareInfo) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.SquareSize != 0 {
+		n += 1 + sovTypes(uint64(m.SquareSize))
+	}
+	if m.SharesUsed != 0 {
+		n += 1 + sovTypes(uint64(m.SharesUsed))
+	}
+	if m.BlobBytes != 0 {
+		n += 1 + sovTypes(uint64(m.BlobBytes))
+	}
 	return n
 }
 
@@ -3120,6 +3683,10 @@ func (m *ShareProof) Size() (n int) {
 	if m.NamespaceVersion != 0 {
 		n += 1 + sovTypes(uint64(m.NamespaceVersion))
 	}
+	l = len(m.DataRoot)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
 	return n
 }
 
@@ -3151,6 +3718,13 @@ func (m *RowProof) Size() (n int) {
 	if m.EndRow != 0 {
 		n += 1 + sovTypes(uint64(m.EndRow))
 	}
+	if len(m.RootIndices) > 0 {
+		l = 0
+		for _, e := range m.RootIndices {
+			l += sovTypes(uint64(e))
+		}
+		n += 1 + sovTypes(uint64(l)) + l
+	}
 	return n
 }
 
@@ -3179,6 +3753,78 @@ func (m *NMTProof) Size() (n int) {
 	return n
 }
 
+func (m *CompressedShareProof) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Proof != nil {
+		l = m.Proof.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if len(m.Nodes) > 0 {
+		for _, b := range m.Nodes {
+			l = len(b)
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	if len(m.ShareProofNodes) > 0 {
+		for _, e := range m.ShareProofNodes {
+			l = e.Size()
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	if len(m.RowProofAunts) > 0 {
+		for _, e := range m.RowProofAunts {
+			l = e.Size()
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *NodeIndices) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Indices) > 0 {
+		l = 0
+		for _, e := range m.Indices {
+			l += sovTypes(uint64(e))
+		}
+		n += 1 + sovTypes(uint64(l)) + l
+	}
+	return n
+}
+
+func (m *ValidatorAnnouncement) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ValidatorAddress)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.NodeId)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.ChainID)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.Signature)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+
 func sovTypes(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
 }
@@ -5526,6 +6172,42 @@ func (m *BlockMeta) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Square", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Square == nil {
+				m.Square = &DataSquareInfo{}
+			}
+			if err := m.Square.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -5547,7 +6229,7 @@ func (m *BlockMeta) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *TxProof) Unmarshal(dAtA []byte) error {
+func (m *DataSquareInfo) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -5570,7 +6252,114 @@ func (m *TxProof) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: TxProof: wiretype end group for non-group")
+			return fmt.Errorf("proto: DataSquareInfo: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DataSquareInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SquareSize", wireType)
+			}
+			m.SquareSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SquareSize |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SharesUsed", wireType)
+			}
+			m.SharesUsed = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SharesUsed |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BlobBytes", wireType)
+			}
+			m.BlobBytes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BlobBytes |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TxProof) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TxProof: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
 			return fmt.Errorf("proto: TxProof: illegal tag %d (wire type %d)", fieldNum, wire)
@@ -6227,6 +7016,40 @@ func (m *ShareProof) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DataRoot", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DataRoot = append(m.DataRoot[:0], dAtA[iNdEx:postIndex]...)
+			if m.DataRoot == nil {
+				m.DataRoot = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -6415,6 +7238,82 @@ func (m *RowProof) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 6:
+			if wireType == 0 {
+				var v uint32
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowTypes
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= uint32(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.RootIndices = append(m.RootIndices, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowTypes
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthTypes
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthTypes
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.RootIndices) == 0 {
+					m.RootIndices = make([]uint32, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v uint32
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowTypes
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= uint32(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.RootIndices = append(m.RootIndices, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field RootIndices", wireType)
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -6590,6 +7489,500 @@ func (m *NMTProof) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *CompressedShareProof) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CompressedShareProof: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CompressedShareProof: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Proof", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Proof == nil {
+				m.Proof = &ShareProof{}
+			}
+			if err := m.Proof.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Nodes", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Nodes = append(m.Nodes, make([]byte, postIndex-iNdEx))
+			copy(m.Nodes[len(m.Nodes)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ShareProofNodes", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ShareProofNodes = append(m.ShareProofNodes, NodeIndices{})
+			if err := m.ShareProofNodes[len(m.ShareProofNodes)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RowProofAunts", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RowProofAunts = append(m.RowProofAunts, NodeIndices{})
+			if err := m.RowProofAunts[len(m.RowProofAunts)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *NodeIndices) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: NodeIndices: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: NodeIndices: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType == 0 {
+				var v uint32
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowTypes
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= uint32(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.Indices = append(m.Indices, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowTypes
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthTypes
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthTypes
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.Indices) == 0 {
+					m.Indices = make([]uint32, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v uint32
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowTypes
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= uint32(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.Indices = append(m.Indices, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field Indices", wireType)
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ValidatorAnnouncement) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ValidatorAnnouncement: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ValidatorAnnouncement: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ValidatorAddress", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ValidatorAddress = append(m.ValidatorAddress[:0], dAtA[iNdEx:postIndex]...)
+			if m.ValidatorAddress == nil {
+				m.ValidatorAddress = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NodeId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.NodeId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChainID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ChainID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Signature", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Signature = append(m.Signature[:0], dAtA[iNdEx:postIndex]...)
+			if m.Signature == nil {
+				m.Signature = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipTypes(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
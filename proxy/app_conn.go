@@ -3,6 +3,7 @@ package proxy
 import (
 	abcicli "github.com/tendermint/tendermint/abci/client"
 	"github.com/tendermint/tendermint/abci/types"
+	cmtsync "github.com/tendermint/tendermint/libs/sync"
 )
 
 //go:generate ../scripts/mockery_generate.sh AppConnConsensus|AppConnMempool|AppConnQuery|AppConnSnapshot
@@ -14,6 +15,9 @@ type AppConnConsensus interface {
 	SetResponseCallback(abcicli.Callback)
 	Error() error
 
+	// InfoSync is used by the handshake, which must ask the app executing the
+	// blocks even if the other connections are served by distinct apps.
+	InfoSync(types.RequestInfo) (*types.ResponseInfo, error)
 	InitChainSync(types.RequestInitChain) (*types.ResponseInitChain, error)
 
 	BeginBlockSync(types.RequestBeginBlock) (*types.ResponseBeginBlock, error)
@@ -42,6 +46,7 @@ type AppConnQuery interface {
 	EchoSync(string) (*types.ResponseEcho, error)
 	InfoSync(types.RequestInfo) (*types.ResponseInfo, error)
 	QuerySync(types.RequestQuery) (*types.ResponseQuery, error)
+	VerifyEvidenceSync(types.RequestVerifyEvidence) (*types.ResponseVerifyEvidence, error)
 
 	//	SetOptionSync(key string, value string) (res types.Result)
 }
@@ -53,13 +58,17 @@ type AppConnSnapshot interface {
 	OfferSnapshotSync(types.RequestOfferSnapshot) (*types.ResponseOfferSnapshot, error)
 	LoadSnapshotChunkSync(types.RequestLoadSnapshotChunk) (*types.ResponseLoadSnapshotChunk, error)
 	ApplySnapshotChunkSync(types.RequestApplySnapshotChunk) (*types.ResponseApplySnapshotChunk, error)
+	CreateSnapshotSync(types.RequestCreateSnapshot) (*types.ResponseCreateSnapshot, error)
+	PruneSnapshotsSync(types.RequestPruneSnapshots) (*types.ResponsePruneSnapshots, error)
 }
 
 //-----------------------------------------------------------------------------------------
 // Implements AppConnConsensus (subset of abcicli.Client)
 
 type appConnConsensus struct {
+	mtx     cmtsync.RWMutex
 	appConn abcicli.Client
+	cb      abcicli.Callback
 }
 
 func NewAppConnConsensus(appConn abcicli.Client) AppConnConsensus {
@@ -68,51 +77,77 @@ func NewAppConnConsensus(appConn abcicli.Client) AppConnConsensus {
 	}
 }
 
+func (app *appConnConsensus) client() abcicli.Client {
+	app.mtx.RLock()
+	defer app.mtx.RUnlock()
+	return app.appConn
+}
+
+// setClient replaces the client after reconnecting to the app restarted for
+// an upgrade, keeping the response callback.
+func (app *appConnConsensus) setClient(appConn abcicli.Client) {
+	app.mtx.Lock()
+	defer app.mtx.Unlock()
+	if app.cb != nil {
+		appConn.SetResponseCallback(app.cb)
+	}
+	app.appConn = appConn
+}
+
 func (app *appConnConsensus) SetResponseCallback(cb abcicli.Callback) {
+	app.mtx.Lock()
+	defer app.mtx.Unlock()
+	app.cb = cb
 	app.appConn.SetResponseCallback(cb)
 }
 
 func (app *appConnConsensus) Error() error {
-	return app.appConn.Error()
+	return app.client().Error()
+}
+
+func (app *appConnConsensus) InfoSync(req types.RequestInfo) (*types.ResponseInfo, error) {
+	return app.client().InfoSync(req)
 }
 
 func (app *appConnConsensus) InitChainSync(req types.RequestInitChain) (*types.ResponseInitChain, error) {
-	return app.appConn.InitChainSync(req)
+	return app.client().InitChainSync(req)
 }
 
 func (app *appConnConsensus) BeginBlockSync(req types.RequestBeginBlock) (*types.ResponseBeginBlock, error) {
-	return app.appConn.BeginBlockSync(req)
+	return app.client().BeginBlockSync(req)
 }
 
 func (app *appConnConsensus) DeliverTxAsync(req types.RequestDeliverTx) *abcicli.ReqRes {
-	return app.appConn.DeliverTxAsync(req)
+	return app.client().DeliverTxAsync(req)
 }
 
 func (app *appConnConsensus) EndBlockSync(req types.RequestEndBlock) (*types.ResponseEndBlock, error) {
-	return app.appConn.EndBlockSync(req)
+	return app.client().EndBlockSync(req)
 }
 
 func (app *appConnConsensus) CommitSync() (*types.ResponseCommit, error) {
-	return app.appConn.CommitSync()
+	return app.client().CommitSync()
 }
 
 func (app *appConnConsensus) PrepareProposalSync(
 	req types.RequestPrepareProposal,
 ) (*types.ResponsePrepareProposal, error) {
-	return app.appConn.PrepareProposalSync(req)
+	return app.client().PrepareProposalSync(req)
 }
 
 func (app *appConnConsensus) ProcessProposalSync(
 	req types.RequestProcessProposal,
 ) (*types.ResponseProcessProposal, error) {
-	return app.appConn.ProcessProposalSync(req)
+	return app.client().ProcessProposalSync(req)
 }
 
 //------------------------------------------------
 // Implements AppConnMempool (subset of abcicli.Client)
 
 type appConnMempool struct {
+	mtx     cmtsync.RWMutex
 	appConn abcicli.Client
+	cb      abcicli.Callback
 }
 
 func NewAppConnMempool(appConn abcicli.Client) AppConnMempool {
@@ -121,34 +156,55 @@ func NewAppConnMempool(appConn abcicli.Client) AppConnMempool {
 	}
 }
 
+func (app *appConnMempool) client() abcicli.Client {
+	app.mtx.RLock()
+	defer app.mtx.RUnlock()
+	return app.appConn
+}
+
+// setClient replaces the client after reconnecting to the app, keeping the
+// response callback.
+func (app *appConnMempool) setClient(appConn abcicli.Client) {
+	app.mtx.Lock()
+	defer app.mtx.Unlock()
+	if app.cb != nil {
+		appConn.SetResponseCallback(app.cb)
+	}
+	app.appConn = appConn
+}
+
 func (app *appConnMempool) SetResponseCallback(cb abcicli.Callback) {
+	app.mtx.Lock()
+	defer app.mtx.Unlock()
+	app.cb = cb
 	app.appConn.SetResponseCallback(cb)
 }
 
 func (app *appConnMempool) Error() error {
-	return app.appConn.Error()
+	return app.client().Error()
 }
 
 func (app *appConnMempool) FlushAsync() *abcicli.ReqRes {
-	return app.appConn.FlushAsync()
+	return app.client().FlushAsync()
 }
 
 func (app *appConnMempool) FlushSync() error {
-	return app.appConn.FlushSync()
+	return app.client().FlushSync()
 }
 
 func (app *appConnMempool) CheckTxAsync(req types.RequestCheckTx) *abcicli.ReqRes {
-	return app.appConn.CheckTxAsync(req)
+	return app.client().CheckTxAsync(req)
 }
 
 func (app *appConnMempool) CheckTxSync(req types.RequestCheckTx) (*types.ResponseCheckTx, error) {
-	return app.appConn.CheckTxSync(req)
+	return app.client().CheckTxSync(req)
 }
 
 //------------------------------------------------
 // Implements AppConnQuery (subset of abcicli.Client)
 
 type appConnQuery struct {
+	mtx     cmtsync.RWMutex
 	appConn abcicli.Client
 }
 
@@ -158,26 +214,44 @@ func NewAppConnQuery(appConn abcicli.Client) AppConnQuery {
 	}
 }
 
+func (app *appConnQuery) client() abcicli.Client {
+	app.mtx.RLock()
+	defer app.mtx.RUnlock()
+	return app.appConn
+}
+
+// setClient replaces the client after reconnecting to the app.
+func (app *appConnQuery) setClient(appConn abcicli.Client) {
+	app.mtx.Lock()
+	defer app.mtx.Unlock()
+	app.appConn = appConn
+}
+
 func (app *appConnQuery) Error() error {
-	return app.appConn.Error()
+	return app.client().Error()
 }
 
 func (app *appConnQuery) EchoSync(msg string) (*types.ResponseEcho, error) {
-	return app.appConn.EchoSync(msg)
+	return app.client().EchoSync(msg)
 }
 
 func (app *appConnQuery) InfoSync(req types.RequestInfo) (*types.ResponseInfo, error) {
-	return app.appConn.InfoSync(req)
+	return app.client().InfoSync(req)
 }
 
 func (app *appConnQuery) QuerySync(reqQuery types.RequestQuery) (*types.ResponseQuery, error) {
-	return app.appConn.QuerySync(reqQuery)
+	return app.client().QuerySync(reqQuery)
+}
+
+func (app *appConnQuery) VerifyEvidenceSync(req types.RequestVerifyEvidence) (*types.ResponseVerifyEvidence, error) {
+	return app.client().VerifyEvidenceSync(req)
 }
 
 //------------------------------------------------
 // Implements AppConnSnapshot (subset of abcicli.Client)
 
 type appConnSnapshot struct {
+	mtx     cmtsync.RWMutex
 	appConn abcicli.Client
 }
 
@@ -187,24 +261,47 @@ func NewAppConnSnapshot(appConn abcicli.Client) AppConnSnapshot {
 	}
 }
 
+func (app *appConnSnapshot) client() abcicli.Client {
+	app.mtx.RLock()
+	defer app.mtx.RUnlock()
+	return app.appConn
+}
+
+// setClient replaces the client after reconnecting to the app.
+func (app *appConnSnapshot) setClient(appConn abcicli.Client) {
+	app.mtx.Lock()
+	defer app.mtx.Unlock()
+	app.appConn = appConn
+}
+
 func (app *appConnSnapshot) Error() error {
-	return app.appConn.Error()
+	return app.client().Error()
 }
 
 func (app *appConnSnapshot) ListSnapshotsSync(req types.RequestListSnapshots) (*types.ResponseListSnapshots, error) {
-	return app.appConn.ListSnapshotsSync(req)
+	return app.client().ListSnapshotsSync(req)
 }
 
 func (app *appConnSnapshot) OfferSnapshotSync(req types.RequestOfferSnapshot) (*types.ResponseOfferSnapshot, error) {
-	return app.appConn.OfferSnapshotSync(req)
+	return app.client().OfferSnapshotSync(req)
 }
 
 func (app *appConnSnapshot) LoadSnapshotChunkSync(
 	req types.RequestLoadSnapshotChunk) (*types.ResponseLoadSnapshotChunk, error) {
-	return app.appConn.LoadSnapshotChunkSync(req)
+	return app.client().LoadSnapshotChunkSync(req)
 }
 
 func (app *appConnSnapshot) ApplySnapshotChunkSync(
 	req types.RequestApplySnapshotChunk) (*types.ResponseApplySnapshotChunk, error) {
-	return app.appConn.ApplySnapshotChunkSync(req)
+	return app.client().ApplySnapshotChunkSync(req)
+}
+
+func (app *appConnSnapshot) CreateSnapshotSync(
+	req types.RequestCreateSnapshot) (*types.ResponseCreateSnapshot, error) {
+	return app.client().CreateSnapshotSync(req)
+}
+
+func (app *appConnSnapshot) PruneSnapshotsSync(
+	req types.RequestPruneSnapshots) (*types.ResponsePruneSnapshots, error) {
+	return app.client().PruneSnapshotsSync(req)
 }
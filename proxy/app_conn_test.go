@@ -4,6 +4,10 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	abcicli "github.com/tendermint/tendermint/abci/client"
 	"github.com/tendermint/tendermint/abci/example/kvstore"
@@ -11,6 +15,7 @@ import (
 	"github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/libs/log"
 	cmtrand "github.com/tendermint/tendermint/libs/rand"
+	"github.com/tendermint/tendermint/libs/service"
 )
 
 //----------------------------------------
@@ -163,3 +168,78 @@ func TestInfo(t *testing.T) {
 		t.Error("Expected ResponseInfo with one element '{\"size\":0}' but got something else")
 	}
 }
+
+func TestSplitApps(t *testing.T) {
+	startServer := func(sockPath string, app types.Application) service.Service {
+		s := server.NewSocketServer(sockPath, app)
+		s.SetLogger(log.TestingLogger().With("module", "abci-server"))
+		if err := s.Start(); err != nil {
+			t.Fatalf("Error starting socket server: %v", err.Error())
+		}
+		return s
+	}
+
+	consensusSockPath := fmt.Sprintf("unix:///tmp/consensus_%v.sock", cmtrand.Str(6))
+	consensusServer := startServer(consensusSockPath, kvstore.NewApplication())
+	t.Cleanup(func() {
+		if err := consensusServer.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	mempoolSockPath := fmt.Sprintf("unix:///tmp/mempool_%v.sock", cmtrand.Str(6))
+	mempoolApp := kvstore.NewApplication()
+	mempoolServer := startServer(mempoolSockPath, mempoolApp)
+
+	appConns := NewAppConns(
+		NewRemoteClientCreator(consensusSockPath, SOCKET, true),
+		WithMempoolClientCreator(NewRemoteClientCreator(mempoolSockPath, SOCKET, true)),
+	)
+	appConns.SetLogger(log.TestingLogger())
+	if err := appConns.Start(); err != nil {
+		t.Fatalf("Error starting app connections: %v", err.Error())
+	}
+	t.Cleanup(func() {
+		if err := appConns.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	// commit a block on the consensus app only
+	_, err := appConns.Consensus().BeginBlockSync(types.RequestBeginBlock{})
+	require.NoError(t, err)
+	appConns.Consensus().DeliverTxAsync(types.RequestDeliverTx{Tx: []byte("key=value")})
+	_, err = appConns.Consensus().EndBlockSync(types.RequestEndBlock{Height: 1})
+	require.NoError(t, err)
+	_, err = appConns.Consensus().CommitSync()
+	require.NoError(t, err)
+
+	// the query connection is served by the consensus app
+	info, err := appConns.Query().InfoSync(RequestInfo)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, info.LastBlockHeight)
+
+	// the mempool connection is served by the mempool app
+	res, err := appConns.Mempool().CheckTxSync(types.RequestCheckTx{Tx: []byte("key=value")})
+	require.NoError(t, err)
+	assert.True(t, res.IsOK())
+	assert.EqualValues(t, 0, mempoolApp.Info(RequestInfo).LastBlockHeight)
+
+	// restart the mempool app, the connection is recreated
+	require.NoError(t, mempoolServer.Stop())
+	mempoolServer = startServer(mempoolSockPath, kvstore.NewApplication())
+	t.Cleanup(func() {
+		if err := mempoolServer.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+	require.Eventually(t, func() bool {
+		res, err := appConns.Mempool().CheckTxSync(types.RequestCheckTx{Tx: []byte("key=value")})
+		return err == nil && res.IsOK()
+	}, 10*time.Second, 50*time.Millisecond)
+
+	// the consensus app is still in use
+	info, err = appConns.Query().InfoSync(RequestInfo)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, info.LastBlockHeight)
+}
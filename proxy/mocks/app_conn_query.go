@@ -96,6 +96,29 @@ func (_m *AppConnQuery) QuerySync(_a0 types.RequestQuery) (*types.ResponseQuery,
 	return r0, r1
 }
 
+// VerifyEvidenceSync provides a mock function with given fields: _a0
+func (_m *AppConnQuery) VerifyEvidenceSync(_a0 types.RequestVerifyEvidence) (*types.ResponseVerifyEvidence, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *types.ResponseVerifyEvidence
+	if rf, ok := ret.Get(0).(func(types.RequestVerifyEvidence) *types.ResponseVerifyEvidence); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.ResponseVerifyEvidence)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(types.RequestVerifyEvidence) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type mockConstructorTestingTNewAppConnQuery interface {
 	mock.TestingT
 	Cleanup(func())
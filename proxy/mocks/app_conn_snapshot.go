@@ -36,6 +36,29 @@ func (_m *AppConnSnapshot) ApplySnapshotChunkSync(_a0 types.RequestApplySnapshot
 	return r0, r1
 }
 
+// CreateSnapshotSync provides a mock function with given fields: _a0
+func (_m *AppConnSnapshot) CreateSnapshotSync(_a0 types.RequestCreateSnapshot) (*types.ResponseCreateSnapshot, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *types.ResponseCreateSnapshot
+	if rf, ok := ret.Get(0).(func(types.RequestCreateSnapshot) *types.ResponseCreateSnapshot); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.ResponseCreateSnapshot)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(types.RequestCreateSnapshot) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Error provides a mock function with given fields:
 func (_m *AppConnSnapshot) Error() error {
 	ret := _m.Called()
@@ -119,6 +142,29 @@ func (_m *AppConnSnapshot) OfferSnapshotSync(_a0 types.RequestOfferSnapshot) (*t
 	return r0, r1
 }
 
+// PruneSnapshotsSync provides a mock function with given fields: _a0
+func (_m *AppConnSnapshot) PruneSnapshotsSync(_a0 types.RequestPruneSnapshots) (*types.ResponsePruneSnapshots, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *types.ResponsePruneSnapshots
+	if rf, ok := ret.Get(0).(func(types.RequestPruneSnapshots) *types.ResponsePruneSnapshots); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.ResponsePruneSnapshots)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(types.RequestPruneSnapshots) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type mockConstructorTestingTNewAppConnSnapshot interface {
 	mock.TestingT
 	Cleanup(func())
@@ -1,12 +1,16 @@
 package proxy
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"time"
 
 	abcicli "github.com/tendermint/tendermint/abci/client"
 	cmtlog "github.com/tendermint/tendermint/libs/log"
 	cmtos "github.com/tendermint/tendermint/libs/os"
 	"github.com/tendermint/tendermint/libs/service"
+	cmtsync "github.com/tendermint/tendermint/libs/sync"
 )
 
 const (
@@ -14,8 +18,17 @@ const (
 	connMempool   = "mempool"
 	connQuery     = "query"
 	connSnapshot  = "snapshot"
+
+	// reconnectInterval is the time to wait before retrying to create the
+	// client of a connection served by an app distinct from the consensus app,
+	// or by the consensus app restarting.
+	reconnectInterval = 1 * time.Second
 )
 
+// errAppConnsStopped is returned when the multiAppConn stops while
+// reconnecting to the consensus app.
+var errAppConnsStopped = errors.New("app connections stopped")
+
 // AppConns is the CometBFT's interface to the application that consists of
 // multiple connections.
 type AppConns interface {
@@ -31,16 +44,40 @@ type AppConns interface {
 	Snapshot() AppConnSnapshot
 }
 
+// AppRestarter is implemented by the AppConns which can reconnect to the
+// consensus app after it is restarted, e.g. to upgrade it, instead of killing
+// CometBFT.
+type AppRestarter interface {
+	// ExpectAppRestart makes the termination of the connections to the
+	// consensus app expected: instead of killing CometBFT, they are recreated
+	// once the app accepts connections again. The returned channel is closed
+	// once they are, after which their termination is fatal again. It fails if
+	// the consensus app runs in process.
+	ExpectAppRestart() (<-chan struct{}, error)
+	// CancelAppRestart makes the termination of the connections to the
+	// consensus app fatal again. If they terminated and were not recreated
+	// yet, CometBFT is killed.
+	CancelAppRestart()
+}
+
+// clientSetter is implemented by the connections whose client can be
+// replaced after reconnecting to the app.
+type clientSetter interface {
+	setClient(abcicli.Client)
+}
+
 // NewAppConns calls NewMultiAppConn.
-func NewAppConns(clientCreator ClientCreator) AppConns {
-	return NewMultiAppConn(clientCreator)
+func NewAppConns(clientCreator ClientCreator, options ...MultiAppConnOption) AppConns {
+	return NewMultiAppConn(clientCreator, options...)
 }
 
 // multiAppConn implements AppConns.
 //
 // A multiAppConn is made of a few appConns and manages their underlying abci
 // clients.
-// TODO: on app restart, clients must reboot together
+// The connections served by a distinct app are recreated if it fails. Those
+// served by the consensus app are recreated together if it is restarted after
+// a call to ExpectAppRestart, otherwise CometBFT is killed.
 type multiAppConn struct {
 	service.BaseService
 
@@ -49,20 +86,67 @@ type multiAppConn struct {
 	queryConn     AppConnQuery
 	snapshotConn  AppConnSnapshot
 
+	// Mutex that protects the clients, which are replaced when reconnecting
+	// to the app
+	mtx                 cmtsync.Mutex
 	consensusConnClient abcicli.Client
 	mempoolConnClient   abcicli.Client
 	queryConnClient     abcicli.Client
 	snapshotConnClient  abcicli.Client
+	// closed once the connections to the consensus app are recreated after an
+	// expected restart, nil if no restart is expected
+	restarted chan struct{}
 
 	clientCreator ClientCreator
+	// ClientCreators replacing clientCreator, by connection
+	connClientCreators map[string]ClientCreator
+}
+
+// MultiAppConnOption sets an optional parameter on the multiAppConn.
+type MultiAppConnOption func(*multiAppConn)
+
+// WithConsensusClientCreator makes the consensus connection use the given
+// ClientCreator instead of the default one.
+func WithConsensusClientCreator(clientCreator ClientCreator) MultiAppConnOption {
+	return func(app *multiAppConn) { app.connClientCreators[connConsensus] = clientCreator }
+}
+
+// WithMempoolClientCreator makes the mempool connection use the given
+// ClientCreator instead of the default one, e.g. to serve CheckTx by a
+// stateless verifier. If the mempool app is distinct from the consensus app
+// and fails, the connection is recreated instead of killing CometBFT.
+func WithMempoolClientCreator(clientCreator ClientCreator) MultiAppConnOption {
+	return func(app *multiAppConn) { app.connClientCreators[connMempool] = clientCreator }
+}
+
+// WithQueryClientCreator makes the query connection use the given
+// ClientCreator instead of the default one. If the query app is distinct from
+// the consensus app and fails, the connection is recreated instead of killing
+// CometBFT.
+func WithQueryClientCreator(clientCreator ClientCreator) MultiAppConnOption {
+	return func(app *multiAppConn) { app.connClientCreators[connQuery] = clientCreator }
+}
+
+// WithSnapshotClientCreator makes the snapshot connection use the given
+// ClientCreator instead of the default one. If the snapshot app is distinct
+// from the consensus app and fails, the connection is recreated instead of
+// killing CometBFT.
+func WithSnapshotClientCreator(clientCreator ClientCreator) MultiAppConnOption {
+	return func(app *multiAppConn) { app.connClientCreators[connSnapshot] = clientCreator }
 }
 
 // NewMultiAppConn makes all necessary abci connections to the application.
-func NewMultiAppConn(clientCreator ClientCreator) AppConns {
+// By default, all the connections are made with clientCreator, see the
+// MultiAppConnOptions to serve some of them by distinct apps.
+func NewMultiAppConn(clientCreator ClientCreator, options ...MultiAppConnOption) AppConns {
 	multiAppConn := &multiAppConn{
-		clientCreator: clientCreator,
+		clientCreator:      clientCreator,
+		connClientCreators: make(map[string]ClientCreator),
 	}
 	multiAppConn.BaseService = *service.NewBaseService(nil, "multiAppConn", multiAppConn)
+	for _, option := range options {
+		option(multiAppConn)
+	}
 	return multiAppConn
 }
 
@@ -87,24 +171,27 @@ func (app *multiAppConn) OnStart() error {
 	if err != nil {
 		return err
 	}
+	queryConn := &appConnQuery{appConn: c}
 	app.queryConnClient = c
-	app.queryConn = NewAppConnQuery(c)
+	app.queryConn = queryConn
 
 	c, err = app.abciClientFor(connSnapshot)
 	if err != nil {
 		app.stopAllClients()
 		return err
 	}
+	snapshotConn := &appConnSnapshot{appConn: c}
 	app.snapshotConnClient = c
-	app.snapshotConn = NewAppConnSnapshot(c)
+	app.snapshotConn = snapshotConn
 
 	c, err = app.abciClientFor(connMempool)
 	if err != nil {
 		app.stopAllClients()
 		return err
 	}
+	mempoolConn := &appConnMempool{appConn: c}
 	app.mempoolConnClient = c
-	app.mempoolConn = NewAppConnMempool(c)
+	app.mempoolConn = mempoolConn
 
 	c, err = app.abciClientFor(connConsensus)
 	if err != nil {
@@ -114,9 +201,23 @@ func (app *multiAppConn) OnStart() error {
 	app.consensusConnClient = c
 	app.consensusConn = NewAppConnConsensus(c)
 
-	// Kill CometBFT if the ABCI application crashes.
+	app.checkSplitApps()
+
+	// Kill CometBFT if the ABCI application crashes, unless it restarts as
+	// expected.
 	go app.killTMOnClientError()
 
+	// Reconnect to the apps distinct from the consensus app if they crash.
+	if app.isSplit(connMempool) {
+		go app.reconnectRoutine(connMempool, app.mempoolConnClient, mempoolConn.setClient)
+	}
+	if app.isSplit(connQuery) {
+		go app.reconnectRoutine(connQuery, app.queryConnClient, queryConn.setClient)
+	}
+	if app.isSplit(connSnapshot) {
+		go app.reconnectRoutine(connSnapshot, app.snapshotConnClient, snapshotConn.setClient)
+	}
+
 	return nil
 }
 
@@ -124,6 +225,32 @@ func (app *multiAppConn) OnStop() {
 	app.stopAllClients()
 }
 
+// ExpectAppRestart implements AppRestarter.
+func (app *multiAppConn) ExpectAppRestart() (<-chan struct{}, error) {
+	if _, ok := app.clientCreatorFor(connConsensus).(*localClientCreator); ok {
+		return nil, errors.New("the consensus app runs in process")
+	}
+	app.mtx.Lock()
+	defer app.mtx.Unlock()
+	if app.restarted == nil {
+		app.restarted = make(chan struct{})
+	}
+	return app.restarted, nil
+}
+
+// CancelAppRestart implements AppRestarter.
+func (app *multiAppConn) CancelAppRestart() {
+	app.mtx.Lock()
+	defer app.mtx.Unlock()
+	app.restarted = nil
+}
+
+func (app *multiAppConn) appRestartExpected() bool {
+	app.mtx.Lock()
+	defer app.mtx.Unlock()
+	return app.restarted != nil
+}
+
 func (app *multiAppConn) killTMOnClientError() {
 	killFn := func(conn string, err error, logger cmtlog.Logger) {
 		logger.Error(
@@ -135,27 +262,268 @@ func (app *multiAppConn) killTMOnClientError() {
 		}
 	}
 
+	for {
+		conn, c := app.waitConsensusAppClientQuit()
+		if c == nil {
+			return
+		}
+		err := c.Error()
+		if err == nil {
+			// stopped by OnStop
+			return
+		}
+		if !app.appRestartExpected() {
+			killFn(conn, err, app.Logger)
+			return
+		}
+
+		app.Logger.Info("Connection to the consensus app terminated, waiting for it to restart",
+			"connection", conn, "err", err)
+		if err := app.reconnectConsensusApp(); err != nil {
+			if !errors.Is(err, errAppConnsStopped) {
+				killFn(conn, err, app.Logger)
+			}
+			return
+		}
+		app.Logger.Info("Reconnected to the restarted consensus app")
+	}
+}
+
+// waitConsensusAppClientQuit waits for the client of a connection served by
+// the consensus app to quit, and returns it. It returns a nil client if the
+// multiAppConn stops first.
+func (app *multiAppConn) waitConsensusAppClientQuit() (string, abcicli.Client) {
+	// the connections reconnecting to their app are left out by receiving
+	// from a nil channel, which blocks forever
+	quit := func(conn string) <-chan struct{} {
+		if app.isSplit(conn) {
+			return nil
+		}
+		return app.clientFor(conn).Quit()
+	}
+
+	var conn string
 	select {
-	case <-app.consensusConnClient.Quit():
-		if err := app.consensusConnClient.Error(); err != nil {
-			killFn(connConsensus, err, app.Logger)
+	case <-quit(connConsensus):
+		conn = connConsensus
+	case <-quit(connMempool):
+		conn = connMempool
+	case <-quit(connQuery):
+		conn = connQuery
+	case <-quit(connSnapshot):
+		conn = connSnapshot
+	case <-app.Quit():
+		return "", nil
+	}
+	return conn, app.clientFor(conn)
+}
+
+// reconnectConsensusApp recreates the clients of all the connections served
+// by the consensus app once it accepts connections again after a restart. It
+// fails if the restart is cancelled first.
+func (app *multiAppConn) reconnectConsensusApp() error {
+	conns := []string{connConsensus}
+	for _, conn := range []string{connMempool, connQuery, connSnapshot} {
+		if !app.isSplit(conn) {
+			conns = append(conns, conn)
 		}
-	case <-app.mempoolConnClient.Quit():
-		if err := app.mempoolConnClient.Error(); err != nil {
-			killFn(connMempool, err, app.Logger)
+	}
+
+	// the other clients terminated too, or are about to
+	for _, conn := range conns {
+		if c := app.clientFor(conn); c.IsRunning() {
+			if err := c.Stop(); err != nil {
+				app.Logger.Error("error while stopping client", "connection", conn, "error", err)
+			}
 		}
-	case <-app.queryConnClient.Quit():
-		if err := app.queryConnClient.Error(); err != nil {
-			killFn(connQuery, err, app.Logger)
+	}
+
+	clients := make(map[string]abcicli.Client, len(conns))
+	stopClients := func() {
+		for conn, c := range clients {
+			if err := c.Stop(); err != nil {
+				app.Logger.Error("error while stopping client", "connection", conn, "error", err)
+			}
 		}
-	case <-app.snapshotConnClient.Quit():
-		if err := app.snapshotConnClient.Error(); err != nil {
-			killFn(connSnapshot, err, app.Logger)
+	}
+	for _, conn := range conns {
+		for {
+			c, err := app.abciClientFor(conn)
+			if err == nil {
+				clients[conn] = c
+				break
+			}
+			app.Logger.Debug("Consensus app not restarted yet", "connection", conn, "err", err)
+			select {
+			case <-time.After(reconnectInterval):
+			case <-app.Quit():
+				stopClients()
+				return errAppConnsStopped
+			}
+			if !app.appRestartExpected() {
+				stopClients()
+				return errors.New("the restart of the consensus app was cancelled before it came back")
+			}
 		}
 	}
+
+	app.mtx.Lock()
+	defer app.mtx.Unlock()
+	if !app.IsRunning() {
+		stopClients()
+		return errAppConnsStopped
+	}
+	for conn, c := range clients {
+		switch conn {
+		case connConsensus:
+			app.consensusConnClient = c
+			app.consensusConn.(clientSetter).setClient(c)
+		case connMempool:
+			app.mempoolConnClient = c
+			app.mempoolConn.(clientSetter).setClient(c)
+		case connQuery:
+			app.queryConnClient = c
+			app.queryConn.(clientSetter).setClient(c)
+		case connSnapshot:
+			app.snapshotConnClient = c
+			app.snapshotConn.(clientSetter).setClient(c)
+		}
+	}
+	if app.restarted != nil {
+		close(app.restarted)
+		app.restarted = nil
+	}
+	return nil
+}
+
+// reconnectRoutine recreates the client of a connection served by an app
+// distinct from the consensus app whenever it fails, passing the new client
+// to setClient. Unlike the consensus app, such an app does not hold the state
+// CometBFT relies on, so it can be restarted without restarting CometBFT.
+func (app *multiAppConn) reconnectRoutine(conn string, c abcicli.Client, setClient func(abcicli.Client)) {
+	for {
+		select {
+		case <-c.Quit():
+		case <-app.Quit():
+			return
+		}
+		err := c.Error()
+		if err == nil {
+			// stopped by OnStop
+			return
+		}
+		app.Logger.Error("Connection to the app terminated, reconnecting", "connection", conn, "err", err)
+
+		for {
+			c, err = app.abciClientFor(conn)
+			if err == nil {
+				break
+			}
+			app.Logger.Error("Failed to reconnect to the app", "connection", conn, "err", err)
+			select {
+			case <-time.After(reconnectInterval):
+			case <-app.Quit():
+				return
+			}
+		}
+
+		app.mtx.Lock()
+		if !app.IsRunning() {
+			app.mtx.Unlock()
+			if err := c.Stop(); err != nil {
+				app.Logger.Error("error while stopping client", "connection", conn, "error", err)
+			}
+			return
+		}
+		switch conn {
+		case connMempool:
+			app.mempoolConnClient = c
+		case connQuery:
+			app.queryConnClient = c
+		case connSnapshot:
+			app.snapshotConnClient = c
+		}
+		setClient(c)
+		app.mtx.Unlock()
+		app.Logger.Info("Reconnected to the app", "connection", conn)
+	}
+}
+
+// checkSplitApps logs an error for every connection served by an app distinct
+// from the consensus app which does not agree with it on the app version, the
+// height or the app hash. A mismatch does not prevent starting, as a mempool
+// app may for instance verify transactions without any state.
+func (app *multiAppConn) checkSplitApps() {
+	if !app.isSplit(connMempool) && !app.isSplit(connQuery) && !app.isSplit(connSnapshot) {
+		return
+	}
+	consensusInfo, err := app.consensusConnClient.InfoSync(RequestInfo)
+	if err != nil {
+		app.Logger.Error("Failed to get info from the consensus app", "err", err)
+		return
+	}
+	for conn, c := range map[string]abcicli.Client{
+		connMempool:  app.mempoolConnClient,
+		connQuery:    app.queryConnClient,
+		connSnapshot: app.snapshotConnClient,
+	} {
+		if !app.isSplit(conn) {
+			continue
+		}
+		info, err := c.InfoSync(RequestInfo)
+		if err != nil {
+			app.Logger.Error("Failed to get info from the app", "connection", conn, "err", err)
+			continue
+		}
+		switch {
+		case info.AppVersion != consensusInfo.AppVersion:
+			app.Logger.Error("App version differs from the consensus app", "connection", conn,
+				"appVersion", info.AppVersion, "consensusAppVersion", consensusInfo.AppVersion)
+		case info.LastBlockHeight != consensusInfo.LastBlockHeight:
+			app.Logger.Error("App height differs from the consensus app", "connection", conn,
+				"height", info.LastBlockHeight, "consensusHeight", consensusInfo.LastBlockHeight)
+		case !bytes.Equal(info.LastBlockAppHash, consensusInfo.LastBlockAppHash):
+			app.Logger.Error("App hash differs from the consensus app at the same height", "connection", conn,
+				"height", info.LastBlockHeight, "appHash", info.LastBlockAppHash,
+				"consensusAppHash", consensusInfo.LastBlockAppHash)
+		}
+	}
+}
+
+// isSplit returns true if the connection is not the consensus connection and
+// is served by an app distinct from the consensus app.
+func (app *multiAppConn) isSplit(conn string) bool {
+	return conn != connConsensus && app.clientCreatorFor(conn) != app.clientCreatorFor(connConsensus)
+}
+
+// clientFor returns the current client of the connection.
+func (app *multiAppConn) clientFor(conn string) abcicli.Client {
+	app.mtx.Lock()
+	defer app.mtx.Unlock()
+
+	switch conn {
+	case connConsensus:
+		return app.consensusConnClient
+	case connMempool:
+		return app.mempoolConnClient
+	case connQuery:
+		return app.queryConnClient
+	default:
+		return app.snapshotConnClient
+	}
+}
+
+func (app *multiAppConn) clientCreatorFor(conn string) ClientCreator {
+	if clientCreator, ok := app.connClientCreators[conn]; ok {
+		return clientCreator
+	}
+	return app.clientCreator
 }
 
 func (app *multiAppConn) stopAllClients() {
+	app.mtx.Lock()
+	defer app.mtx.Unlock()
+
 	if app.consensusConnClient != nil {
 		if err := app.consensusConnClient.Stop(); err != nil {
 			app.Logger.Error("error while stopping consensus client", "error", err)
@@ -179,7 +547,7 @@ func (app *multiAppConn) stopAllClients() {
 }
 
 func (app *multiAppConn) abciClientFor(conn string) (abcicli.Client, error) {
-	c, err := app.clientCreator.NewABCIClient()
+	c, err := app.clientCreatorFor(conn).NewABCIClient()
 	if err != nil {
 		return nil, fmt.Errorf("error creating ABCI client (%s connection): %w", conn, err)
 	}
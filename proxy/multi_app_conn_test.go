@@ -12,6 +12,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	abcimocks "github.com/tendermint/tendermint/abci/client/mocks"
+	"github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/proxy/mocks"
 )
 
@@ -88,3 +89,118 @@ func TestAppConns_Failure(t *testing.T) {
 		t.Fatal("expected process to receive SIGTERM signal")
 	}
 }
+
+// The connections served by an app distinct from the consensus app are
+// recreated upon failure, instead of killing CometBFT.
+func TestAppConns_SplitFailureReconnects(t *testing.T) {
+	quitCh := make(<-chan struct{})
+
+	clientMock := &abcimocks.Client{}
+	clientMock.On("SetLogger", mock.Anything).Return()
+	clientMock.On("Start").Return(nil)
+	clientMock.On("Stop").Return(nil)
+	clientMock.On("Quit").Return(quitCh)
+	clientMock.On("InfoSync", mock.Anything).Return(&types.ResponseInfo{}, nil)
+
+	clientCreatorMock := &mocks.ClientCreator{}
+	clientCreatorMock.On("NewABCIClient").Return(clientMock, nil)
+
+	failingQuitCh := make(chan struct{})
+	var recvFailingQuitCh <-chan struct{} //nolint:gosimple
+	recvFailingQuitCh = failingQuitCh
+
+	failingClientMock := &abcimocks.Client{}
+	failingClientMock.On("SetLogger", mock.Anything).Return()
+	failingClientMock.On("Start").Return(nil)
+	failingClientMock.On("Quit").Return(recvFailingQuitCh)
+	failingClientMock.On("Error").Return(errors.New("EOF"))
+	failingClientMock.On("CheckTxSync", mock.Anything).Return(nil, errors.New("EOF"))
+	failingClientMock.On("InfoSync", mock.Anything).Return(&types.ResponseInfo{}, nil)
+
+	newClientMock := &abcimocks.Client{}
+	newClientMock.On("SetLogger", mock.Anything).Return()
+	newClientMock.On("Start").Return(nil)
+	newClientMock.On("Stop").Return(nil)
+	newClientMock.On("Quit").Return(quitCh)
+	newClientMock.On("CheckTxSync", mock.Anything).Return(&types.ResponseCheckTx{}, nil)
+
+	mempoolClientCreatorMock := &mocks.ClientCreator{}
+	mempoolClientCreatorMock.On("NewABCIClient").Return(failingClientMock, nil).Once()
+	mempoolClientCreatorMock.On("NewABCIClient").Return(nil, errors.New("connection refused")).Once()
+	mempoolClientCreatorMock.On("NewABCIClient").Return(newClientMock, nil).Once()
+
+	appConns := NewAppConns(clientCreatorMock, WithMempoolClientCreator(mempoolClientCreatorMock))
+	require.NoError(t, appConns.Start())
+	t.Cleanup(func() {
+		if err := appConns.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	// simulate failure
+	close(failingQuitCh)
+
+	// the mempool connection switches to the new client
+	require.Eventually(t, func() bool {
+		_, err := appConns.Mempool().CheckTxSync(types.RequestCheckTx{Tx: []byte("tx")})
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	mempoolClientCreatorMock.AssertExpectations(t)
+	newClientMock.AssertCalled(t, "CheckTxSync", mock.Anything)
+}
+
+func TestAppConns_ExpectedRestartReconnects(t *testing.T) {
+	failingQuitCh := make(chan struct{})
+	var recvFailingQuitCh <-chan struct{} //nolint:gosimple
+	recvFailingQuitCh = failingQuitCh
+
+	failingClientMock := &abcimocks.Client{}
+	failingClientMock.On("SetLogger", mock.Anything).Return()
+	failingClientMock.On("Start").Return(nil)
+	failingClientMock.On("IsRunning").Return(false)
+	failingClientMock.On("Quit").Return(recvFailingQuitCh)
+	failingClientMock.On("Error").Return(errors.New("EOF"))
+	failingClientMock.On("InfoSync", mock.Anything).Return(nil, errors.New("EOF"))
+
+	quitCh := make(<-chan struct{})
+	newClientMock := &abcimocks.Client{}
+	newClientMock.On("SetLogger", mock.Anything).Return()
+	newClientMock.On("Start").Return(nil)
+	newClientMock.On("Stop").Return(nil)
+	newClientMock.On("Quit").Return(quitCh)
+	newClientMock.On("InfoSync", mock.Anything).Return(&types.ResponseInfo{AppVersion: 2}, nil)
+
+	clientCreatorMock := &mocks.ClientCreator{}
+	clientCreatorMock.On("NewABCIClient").Return(failingClientMock, nil).Times(4)
+	clientCreatorMock.On("NewABCIClient").Return(nil, errors.New("connection refused")).Once()
+	clientCreatorMock.On("NewABCIClient").Return(newClientMock, nil).Times(4)
+
+	appConns := NewAppConns(clientCreatorMock)
+	require.NoError(t, appConns.Start())
+	t.Cleanup(func() {
+		if err := appConns.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	restarted, err := appConns.(AppRestarter).ExpectAppRestart()
+	require.NoError(t, err)
+
+	// simulate the restart of the app
+	close(failingQuitCh)
+
+	select {
+	case <-restarted:
+	case <-time.After(5 * time.Second):
+		t.Fatal("connections to the app were not recreated")
+	}
+
+	// all the connections switched to the new clients
+	res, err := appConns.Consensus().InfoSync(types.RequestInfo{})
+	require.NoError(t, err)
+	require.EqualValues(t, 2, res.AppVersion)
+	_, err = appConns.Query().InfoSync(types.RequestInfo{})
+	require.NoError(t, err)
+	clientCreatorMock.AssertExpectations(t)
+}
@@ -11,9 +11,12 @@ import (
 	"github.com/stretchr/testify/require"
 
 	abci "github.com/tendermint/tendermint/abci/types"
+	cmtjson "github.com/tendermint/tendermint/libs/json"
 	cmtrand "github.com/tendermint/tendermint/libs/rand"
 	"github.com/tendermint/tendermint/rpc/client"
 	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	jsonrpcclient "github.com/tendermint/tendermint/rpc/jsonrpc/client"
+	rpctest "github.com/tendermint/tendermint/rpc/test"
 	"github.com/tendermint/tendermint/types"
 )
 
@@ -72,7 +75,9 @@ func TestBlockEvents(t *testing.T) {
 
 			const subscriber = "TestBlockEvents"
 
-			eventCh, err := c.Subscribe(context.Background(), subscriber, types.QueryForEvent(types.EventNewBlock).String())
+			// the blocks are committed faster than this test may read them
+			eventCh, err := c.Subscribe(context.Background(), subscriber,
+				types.QueryForEvent(types.EventNewBlock).String(), 100)
 			require.NoError(t, err)
 			t.Cleanup(func() {
 				if err := c.UnsubscribeAll(context.Background(), subscriber); err != nil {
@@ -121,6 +126,19 @@ func testTxEventsSent(t *testing.T, broadcastMethod string) {
 			// make the tx
 			_, _, tx := MakeTxKV()
 
+			// subscribe to it before sending it, so that its event is not
+			// missed
+			const subscriber = "TestTxEventsSent"
+			ctx, cancel := context.WithTimeout(context.Background(), waitForEventTimeout)
+			defer cancel()
+			eventCh, err := c.Subscribe(ctx, subscriber, types.EventQueryTxFor(tx).String())
+			require.NoError(t, err)
+			t.Cleanup(func() {
+				if err := c.UnsubscribeAll(context.Background(), subscriber); err != nil {
+					t.Error(err)
+				}
+			})
+
 			// send
 			go func() {
 				var (
@@ -142,11 +160,15 @@ func testTxEventsSent(t *testing.T, broadcastMethod string) {
 			}()
 
 			// and wait for confirmation
-			evt, err := client.WaitForOneEvent(c, types.EventTx, waitForEventTimeout)
-			require.Nil(t, err)
+			var evt ctypes.ResultEvent
+			select {
+			case evt = <-eventCh:
+			case <-ctx.Done():
+				t.Fatal("timed out waiting for event")
+			}
 
 			// and make sure it has the proper info
-			txe, ok := evt.(types.EventDataTx)
+			txe, ok := evt.Data.(types.EventDataTx)
 			require.True(t, ok)
 
 			// make sure this is the proper tx
@@ -179,3 +201,93 @@ func TestHTTPReturnsErrorIfClientIsNotRunning(t *testing.T) {
 	err = c.UnsubscribeAll(context.Background(), "TestHeaderEvents")
 	assert.Error(t, err)
 }
+
+func TestNewBlockEventsHeaderPayload(t *testing.T) {
+	// subscribe to NewBlock events over a raw websocket to measure the bytes
+	// delivered to the subscriber
+	dial := func() *jsonrpcclient.WSClient {
+		ws, err := jsonrpcclient.NewWS(rpctest.GetConfig().RPC.ListenAddress, "/websocket")
+		require.NoError(t, err)
+		require.NoError(t, ws.Start())
+		t.Cleanup(func() {
+			if err := ws.Stop(); err != nil {
+				t.Error(err)
+			}
+		})
+		return ws
+	}
+	subscribe := func(payload string) *jsonrpcclient.WSClient {
+		ws := dial()
+		params := map[string]interface{}{"query": types.QueryForEvent(types.EventNewBlock).String()}
+		if payload != "" {
+			params["payload"] = payload
+		}
+		require.NoError(t, ws.Call(context.Background(), "subscribe", params))
+		// wait for the subscription to be registered, as blocks are produced
+		// quickly and the block of the tx could be committed before that
+		for resp := range ws.ResponsesCh {
+			require.Nil(t, resp.Error)
+			result := new(ctypes.ResultEvent)
+			require.NoError(t, cmtjson.Unmarshal(resp.Result, result))
+			if result.Data == nil {
+				break
+			}
+		}
+		return ws
+	}
+	full := subscribe("")
+	header := subscribe(ctypes.EventPayloadHeader)
+
+	// a large tx makes the block much larger than its header
+	k := []byte(cmtrand.Str(8))
+	tx := append(append(k, '='), []byte(cmtrand.Str(10000))...)
+	res, err := getLocalClient().BroadcastTxCommit(context.Background(), tx)
+	require.NoError(t, err)
+	require.True(t, res.DeliverTx.IsOK())
+
+	// waitForHeight returns the event delivered for the height and its size
+	waitForHeight := func(ws *jsonrpcclient.WSClient, height int64) (types.TMEventData, int) {
+		timeout := time.After(waitForEventTimeout)
+		for {
+			select {
+			case resp := <-ws.ResponsesCh:
+				require.Nil(t, resp.Error)
+				result := new(ctypes.ResultEvent)
+				require.NoError(t, cmtjson.Unmarshal(resp.Result, result))
+				var eventHeight int64
+				switch data := result.Data.(type) {
+				case types.EventDataNewBlock:
+					eventHeight = data.Block.Height
+				case types.EventDataNewBlockHeader:
+					eventHeight = data.Header.Height
+				}
+				if eventHeight == height {
+					return result.Data, len(resp.Result)
+				}
+			case <-timeout:
+				t.Fatalf("timed out waiting for the event of height %d", height)
+			}
+		}
+	}
+
+	fullData, fullSize := waitForHeight(full, res.Height)
+	headerData, headerSize := waitForHeight(header, res.Height)
+
+	newBlock, ok := fullData.(types.EventDataNewBlock)
+	require.True(t, ok, "%T", fullData)
+	newBlockHeader, ok := headerData.(types.EventDataNewBlockHeader)
+	require.True(t, ok, "%T", headerData)
+	assert.Equal(t, newBlock.ToHeader(), newBlockHeader)
+	assert.EqualValues(t, 1, newBlockHeader.NumTxs)
+	assert.Less(t, headerSize+len(tx), fullSize)
+
+	// unknown payloads are refused
+	ws := dial()
+	require.NoError(t, ws.Call(context.Background(), "subscribe", map[string]interface{}{
+		"query":   types.QueryForEvent(types.EventNewBlock).String(),
+		"payload": "body",
+	}))
+	resp := <-ws.ResponsesCh
+	require.NotNil(t, resp.Error)
+	assert.Contains(t, resp.Error.Data, "unknown payload")
+}
@@ -13,9 +13,11 @@ import (
 	cmtpubsub "github.com/tendermint/tendermint/libs/pubsub"
 	"github.com/tendermint/tendermint/libs/service"
 	cmtsync "github.com/tendermint/tendermint/libs/sync"
+	cmtproto "github.com/tendermint/tendermint/proto/tendermint/types"
 	rpcclient "github.com/tendermint/tendermint/rpc/client"
 	ctypes "github.com/tendermint/tendermint/rpc/core/types"
 	jsonrpcclient "github.com/tendermint/tendermint/rpc/jsonrpc/client"
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
 	"github.com/tendermint/tendermint/types"
 )
 
@@ -89,6 +91,7 @@ type rpcClient interface {
 	rpcclient.HistoryClient
 	rpcclient.NetworkClient
 	rpcclient.SignClient
+	rpcclient.ProofClient
 	rpcclient.StatusClient
 }
 
@@ -311,6 +314,24 @@ func (c *baseRPCClient) NumUnconfirmedTxs(ctx context.Context) (*ctypes.ResultUn
 	return result, nil
 }
 
+func (c *baseRPCClient) MempoolSnapshot(ctx context.Context) (*ctypes.ResultMempoolSnapshot, error) {
+	result := new(ctypes.ResultMempoolSnapshot)
+	_, err := c.caller.Call(ctx, "mempool_snapshot", map[string]interface{}{}, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *baseRPCClient) MempoolRequirements(ctx context.Context) (*ctypes.ResultMempoolRequirements, error) {
+	result := new(ctypes.ResultMempoolRequirements)
+	_, err := c.caller.Call(ctx, "mempool_requirements", map[string]interface{}{}, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 func (c *baseRPCClient) CheckTx(ctx context.Context, tx types.Tx) (*ctypes.ResultCheckTx, error) {
 	result := new(ctypes.ResultCheckTx)
 	_, err := c.caller.Call(ctx, "check_tx", map[string]interface{}{"tx": tx}, result)
@@ -347,6 +368,24 @@ func (c *baseRPCClient) ConsensusState(ctx context.Context) (*ctypes.ResultConse
 	return result, nil
 }
 
+func (c *baseRPCClient) DebugBundle(ctx context.Context) (*ctypes.ResultDebugBundle, error) {
+	result := new(ctypes.ResultDebugBundle)
+	_, err := c.caller.Call(ctx, "debug_bundle", map[string]interface{}{}, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *baseRPCClient) Snapshots(ctx context.Context) (*ctypes.ResultSnapshots, error) {
+	result := new(ctypes.ResultSnapshots)
+	_, err := c.caller.Call(ctx, "snapshots", map[string]interface{}{}, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 func (c *baseRPCClient) ConsensusParams(
 	ctx context.Context,
 	height *int64,
@@ -363,6 +402,24 @@ func (c *baseRPCClient) ConsensusParams(
 	return result, nil
 }
 
+func (c *baseRPCClient) ProposerMisses(
+	ctx context.Context,
+	address []byte,
+	from,
+	to int64,
+) (*ctypes.ResultProposerMisses, error) {
+	result := new(ctypes.ResultProposerMisses)
+	params := map[string]interface{}{"from": from, "to": to}
+	if len(address) > 0 {
+		params["address"] = address
+	}
+	_, err := c.caller.Call(ctx, "proposer_misses", params, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 func (c *baseRPCClient) Health(ctx context.Context) (*ctypes.ResultHealth, error) {
 	result := new(ctypes.ResultHealth)
 	_, err := c.caller.Call(ctx, "health", map[string]interface{}{}, result)
@@ -387,6 +444,19 @@ func (c *baseRPCClient) BlockchainInfo(
 	return result, nil
 }
 
+func (c *baseRPCClient) BlockMeta(ctx context.Context, height *int64) (*ctypes.ResultBlockMeta, error) {
+	result := new(ctypes.ResultBlockMeta)
+	params := make(map[string]interface{})
+	if height != nil {
+		params["height"] = height
+	}
+	_, err := c.caller.Call(ctx, "block_meta", params, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 func (c *baseRPCClient) Genesis(ctx context.Context) (*ctypes.ResultGenesis, error) {
 	result := new(ctypes.ResultGenesis)
 	_, err := c.caller.Call(ctx, "genesis", map[string]interface{}{}, result)
@@ -533,6 +603,15 @@ func (c *baseRPCClient) TxStatus(
 	return result, nil
 }
 
+func (c *baseRPCClient) TxIndexStatus(ctx context.Context) (*ctypes.ResultTxIndexStatus, error) {
+	result := new(ctypes.ResultTxIndexStatus)
+	_, err := c.caller.Call(ctx, "tx_index_status", map[string]interface{}{}, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 func (c *baseRPCClient) DataRootInclusionProof(
 	ctx context.Context,
 	height uint64,
@@ -607,6 +686,150 @@ func (c *baseRPCClient) ProveSharesV2(
 	return result, nil
 }
 
+func (c *baseRPCClient) ProveSharesBatch(
+	ctx context.Context,
+	height int64,
+	ranges []ctypes.ShareRange,
+) (*ctypes.ResultShareProofBatch, error) {
+	result := new(ctypes.ResultShareProofBatch)
+	params := map[string]interface{}{
+		"height": height,
+		"ranges": ranges,
+	}
+	_, err := c.caller.Call(ctx, "prove_shares_batch", params, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *baseRPCClient) ProveSharesPaged(
+	ctx context.Context,
+	height int64,
+	startShare uint64,
+	endShare uint64,
+	page,
+	perPage *int,
+) (*ctypes.ResultShareProofPage, error) {
+	result := new(ctypes.ResultShareProofPage)
+	params := map[string]interface{}{
+		"height":     height,
+		"startShare": startShare,
+		"endShare":   endShare,
+	}
+	if page != nil {
+		params["page"] = page
+	}
+	if perPage != nil {
+		params["per_page"] = perPage
+	}
+	_, err := c.caller.Call(ctx, "prove_shares_paged", params, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *baseRPCClient) RowNamespaceRanges(
+	ctx context.Context,
+	height *int64,
+) (*ctypes.ResultRowNamespaceRanges, error) {
+	result := new(ctypes.ResultRowNamespaceRanges)
+	params := make(map[string]interface{})
+	if height != nil {
+		params["height"] = height
+	}
+	_, err := c.caller.Call(ctx, "row_namespace_ranges", params, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *baseRPCClient) ProveTxAbsence(
+	ctx context.Context,
+	height int64,
+	hash []byte,
+) (*ctypes.ResultTxAbsenceProof, error) {
+	result := new(ctypes.ResultTxAbsenceProof)
+	params := map[string]interface{}{
+		"height": height,
+		"hash":   hash,
+	}
+	_, err := c.caller.Call(ctx, "prove_tx_absence", params, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *baseRPCClient) ProveTxShares(
+	ctx context.Context,
+	height int64,
+	hash []byte,
+	includeBlob bool,
+) (*ctypes.ResultTxSharesProof, error) {
+	result := new(ctypes.ResultTxSharesProof)
+	params := map[string]interface{}{
+		"height":       height,
+		"hash":         hash,
+		"include_blob": includeBlob,
+	}
+	_, err := c.caller.Call(ctx, "prove_tx_shares", params, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *baseRPCClient) TxBundle(ctx context.Context, hash []byte) (*ctypes.ResultTxBundle, error) {
+	result := new(ctypes.ResultTxBundle)
+	params := map[string]interface{}{
+		"hash": hash,
+	}
+	_, err := c.caller.Call(ctx, "tx_bundle", params, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *baseRPCClient) EstimateNamespaceProofSize(
+	ctx context.Context,
+	height int64,
+	namespace []byte,
+) (*ctypes.ResultProofSizeEstimate, error) {
+	result := new(ctypes.ResultProofSizeEstimate)
+	params := map[string]interface{}{
+		"height":    height,
+		"namespace": namespace,
+	}
+	_, err := c.caller.Call(ctx, "namespace_proof_size", params, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *baseRPCClient) VerifyNamespaceCompleteness(
+	ctx context.Context,
+	height int64,
+	namespaceID []byte,
+	proofs []cmtproto.ShareProof,
+) (*ctypes.ResultNamespaceCompleteness, error) {
+	result := new(ctypes.ResultNamespaceCompleteness)
+	params := map[string]interface{}{
+		"height":       height,
+		"namespace_id": namespaceID,
+		"proofs":       proofs,
+	}
+	_, err := c.caller.Call(ctx, "namespace_completeness", params, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 func (c *baseRPCClient) TxSearch(
 	ctx context.Context,
 	query string,
@@ -716,6 +939,9 @@ type WSEvents struct {
 
 	mtx           cmtsync.RWMutex
 	subscriptions map[string]chan ctypes.ResultEvent // query -> chan
+	// the subscribe requests waiting for the server to confirm them, by
+	// request ID, see Subscribe
+	pending map[rpctypes.JSONRPCIntID]chan error
 }
 
 func newWSEvents(remote, endpoint string) (*WSEvents, error) {
@@ -723,6 +949,7 @@ func newWSEvents(remote, endpoint string) (*WSEvents, error) {
 		endpoint:      endpoint,
 		remote:        remote,
 		subscriptions: make(map[string]chan ctypes.ResultEvent),
+		pending:       make(map[rpctypes.JSONRPCIntID]chan error),
 	}
 	w.BaseService = *service.NewBaseService(nil, "WSEvents", w)
 
@@ -739,6 +966,8 @@ func newWSEvents(remote, endpoint string) (*WSEvents, error) {
 	return w, nil
 }
 
+var _ rpcclient.EventsClient = (*WSEvents)(nil)
+
 // OnStart implements service.Service by starting WSClient and event loop.
 func (w *WSEvents) OnStart() error {
 	if err := w.ws.Start(); err != nil {
@@ -759,7 +988,8 @@ func (w *WSEvents) OnStop() {
 
 // Subscribe implements EventsClient by using WSClient to subscribe given
 // subscriber to query. By default, returns a channel with cap=1. Error is
-// returned if it fails to subscribe.
+// returned if it fails to subscribe. It returns once the server confirmed the
+// subscription, so that the events published after it returns are not missed.
 //
 // Channel is never closed to prevent clients from seeing an erroneous event.
 //
@@ -771,25 +1001,58 @@ func (w *WSEvents) Subscribe(ctx context.Context, subscriber, query string,
 		return nil, errNotRunning
 	}
 
-	if err := w.ws.Subscribe(ctx, query); err != nil {
-		return nil, err
-	}
-
 	outCap := 1
 	if len(outCapacity) > 0 {
 		outCap = outCapacity[0]
 	}
 
+	// the events may be delivered before the confirmation, so the
+	// subscription is registered first
 	outc := make(chan ctypes.ResultEvent, outCap)
+	confirmed := make(chan error, 1)
 	w.mtx.Lock()
 	// subscriber param is ignored because CometBFT will override it with
 	// remote IP anyway.
 	w.subscriptions[query] = outc
+	id := w.ws.NextRequestID()
+	w.pending[id] = confirmed
 	w.mtx.Unlock()
 
+	err = w.subscribe(ctx, id, query, confirmed)
+	w.mtx.Lock()
+	delete(w.pending, id)
+	if err != nil && w.subscriptions[query] == outc {
+		delete(w.subscriptions, query)
+	}
+	w.mtx.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
 	return outc, nil
 }
 
+// subscribe sends the subscribe request id for query, and waits for the server
+// to confirm it on confirmed.
+func (w *WSEvents) subscribe(ctx context.Context, id rpctypes.JSONRPCIntID, query string,
+	confirmed <-chan error) error {
+	request, err := rpctypes.MapToRequest(id, "subscribe", map[string]interface{}{"query": query})
+	if err != nil {
+		return err
+	}
+	if err := w.ws.Send(ctx, request); err != nil {
+		return err
+	}
+	select {
+	case err := <-confirmed:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-w.Quit():
+		return errNotRunning
+	}
+}
+
 // Unsubscribe implements EventsClient by using WSClient to unsubscribe given
 // subscriber from query.
 //
@@ -848,6 +1111,38 @@ func (w *WSEvents) redoSubscriptionsAfter(d time.Duration) {
 	}
 }
 
+// confirm passes the response to a pending subscribe request, see Subscribe,
+// and returns true, if resp is one. The events delivered for the subscription
+// before it is confirmed have its request ID too, and are not passed.
+func (w *WSEvents) confirm(resp rpctypes.RPCResponse) bool {
+	id, ok := resp.ID.(rpctypes.JSONRPCIntID)
+	if !ok {
+		return false
+	}
+	w.mtx.RLock()
+	confirmed, ok := w.pending[id]
+	w.mtx.RUnlock()
+	if !ok {
+		return false
+	}
+
+	if resp.Error == nil {
+		result := new(ctypes.ResultEvent)
+		if cmtjson.Unmarshal(resp.Result, result) == nil && result.Query != "" {
+			return false // an event
+		}
+	}
+	var err error
+	if resp.Error != nil && !isErrAlreadySubscribed(resp.Error) {
+		err = resp.Error
+	}
+	select {
+	case confirmed <- err:
+	default:
+	}
+	return true
+}
+
 func isErrAlreadySubscribed(err error) bool {
 	return strings.Contains(err.Error(), cmtpubsub.ErrAlreadySubscribed.Error())
 }
@@ -860,6 +1155,10 @@ func (w *WSEvents) eventListener() {
 				return
 			}
 
+			if w.confirm(resp) {
+				continue
+			}
+
 			if resp.Error != nil {
 				w.Logger.Error("WS error", "err", resp.Error.Error())
 				// Error can be ErrAlreadySubscribed or max client (subscriptions per
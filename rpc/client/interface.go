@@ -12,7 +12,12 @@ compiling the abci app in the same process), you can use the client.Local
 implementation.
 
 For mocking out server responses during testing to see behavior for
-arbitrary return values, use the mock package.
+arbitrary return values, use the mock package, or the mocks package, which
+holds a mock of Client and of each of the interfaces it is made of.
+
+Client is the union of small interfaces split by capability, e.g. StatusClient
+or ProofClient. Code only needing some of the capabilities of a node should
+accept the narrowest of them, which is easier to implement and to mock.
 
 In addition to the Client interface, which should be used externally
 for maximum flexibility and testability, and two implementations,
@@ -25,10 +30,13 @@ import (
 
 	"github.com/tendermint/tendermint/libs/bytes"
 	"github.com/tendermint/tendermint/libs/service"
+	cmtproto "github.com/tendermint/tendermint/proto/tendermint/types"
 	ctypes "github.com/tendermint/tendermint/rpc/core/types"
 	"github.com/tendermint/tendermint/types"
 )
 
+//go:generate ../../scripts/mockery_generate.sh Client
+
 // Client wraps most important rpc calls a client would make if you want to
 // listen for events, test if it also implements events.EventSwitch.
 type Client interface {
@@ -38,11 +46,14 @@ type Client interface {
 	HistoryClient
 	NetworkClient
 	SignClient
+	ProofClient
 	StatusClient
 	EvidenceClient
 	MempoolClient
 }
 
+//go:generate ../../scripts/mockery_generate.sh ABCIClient
+
 // ABCIClient groups together the functionality that principally affects the
 // ABCI app.
 //
@@ -61,6 +72,8 @@ type ABCIClient interface {
 	BroadcastTxSync(context.Context, types.Tx) (*ctypes.ResultBroadcastTx, error)
 }
 
+//go:generate ../../scripts/mockery_generate.sh SignClient
+
 // SignClient groups together the functionality needed to get valid signatures
 // and prove anything about the chain.
 type SignClient interface {
@@ -83,11 +96,6 @@ type SignClient interface {
 	Validators(ctx context.Context, height *int64, page, perPage *int) (*ctypes.ResultValidators, error)
 	Tx(ctx context.Context, hash []byte, prove bool) (*ctypes.ResultTx, error)
 
-	// ProveShares
-	// Deprecated: Use ProveSharesV2 instead.
-	ProveShares(_ context.Context, height uint64, startShare uint64, endShare uint64) (types.ShareProof, error)
-	ProveSharesV2(_ context.Context, height uint64, startShare uint64, endShare uint64) (*ctypes.ResultShareProof, error)
-
 	// TxSearch defines a method to search for a paginated set of transactions by
 	// DeliverTx event search criteria.
 	TxSearch(
@@ -109,20 +117,78 @@ type SignClient interface {
 
 	// TxStatus returns the transaction status for a given transaction hash.
 	TxStatus(ctx context.Context, hash []byte) (*ctypes.ResultTxStatus, error)
+	// TxIndexStatus returns the heights covered by the transaction indexer,
+	// and whether indexing is enabled.
+	TxIndexStatus(ctx context.Context) (*ctypes.ResultTxIndexStatus, error)
 }
 
+//go:generate ../../scripts/mockery_generate.sh ProofClient
+
+// ProofClient groups together the proofs of the shares of the blocks, of the
+// transactions and of the namespaces in them.
+type ProofClient interface {
+	// ProveShares
+	// Deprecated: Use ProveSharesV2 instead.
+	ProveShares(_ context.Context, height uint64, startShare uint64, endShare uint64) (types.ShareProof, error)
+	ProveSharesV2(_ context.Context, height uint64, startShare uint64, endShare uint64) (*ctypes.ResultShareProof, error)
+	// ProveSharesBatch returns proofs for multiple end exclusive share ranges
+	// of the same block, in the order of the ranges.
+	ProveSharesBatch(ctx context.Context, height int64, ranges []ctypes.ShareRange) (*ctypes.ResultShareProofBatch, error)
+	// ProveSharesPaged returns the proofs of a page of the chunks of an end
+	// exclusive share range, one chunk per row of the square.
+	ProveSharesPaged(
+		ctx context.Context,
+		height int64,
+		startShare, endShare uint64,
+		page, perPage *int,
+	) (*ctypes.ResultShareProofPage, error)
+	// RowNamespaceRanges returns the minimum and maximum namespace of every
+	// row of the original data square of the block at the given height.
+	RowNamespaceRanges(ctx context.Context, height *int64) (*ctypes.ResultRowNamespaceRanges, error)
+	// ProveTxAbsence returns a proof that the transaction with the given hash
+	// is not in the block at the given height.
+	ProveTxAbsence(ctx context.Context, height int64, hash []byte) (*ctypes.ResultTxAbsenceProof, error)
+	// ProveTxShares returns a proof of the shares of the transaction with the
+	// given hash in the block at the given height and, if includeBlob is true
+	// for a blob transaction, proofs of the shares of its blobs.
+	ProveTxShares(ctx context.Context, height int64, hash []byte, includeBlob bool) (*ctypes.ResultTxSharesProof, error)
+	// TxBundle returns the committed transaction with the given hash, the
+	// proof of its shares and the light block committing to them, which can
+	// be verified offline, see ResultTxBundle.Verify.
+	TxBundle(ctx context.Context, hash []byte) (*ctypes.ResultTxBundle, error)
+	// EstimateNamespaceProofSize returns an estimate of the size of the proof
+	// of the shares of the namespace in the block at the given height.
+	EstimateNamespaceProofSize(ctx context.Context, height int64, namespace []byte) (*ctypes.ResultProofSizeEstimate, error)
+	// VerifyNamespaceCompleteness checks that the share proofs together cover
+	// all the shares of the namespace in the block at the given height, and
+	// returns the gaps of the coverage.
+	VerifyNamespaceCompleteness(
+		ctx context.Context,
+		height int64,
+		namespaceID []byte,
+		proofs []cmtproto.ShareProof,
+	) (*ctypes.ResultNamespaceCompleteness, error)
+}
+
+//go:generate ../../scripts/mockery_generate.sh HistoryClient
+
 // HistoryClient provides access to data from genesis to now in large chunks.
 type HistoryClient interface {
 	Genesis(context.Context) (*ctypes.ResultGenesis, error)
 	GenesisChunked(context.Context, uint) (*ctypes.ResultGenesisChunk, error)
 	BlockchainInfo(ctx context.Context, minHeight, maxHeight int64) (*ctypes.ResultBlockchainInfo, error)
+	BlockMeta(ctx context.Context, height *int64) (*ctypes.ResultBlockMeta, error)
 }
 
+//go:generate ../../scripts/mockery_generate.sh StatusClient
+
 // StatusClient provides access to general chain info.
 type StatusClient interface {
 	Status(context.Context) (*ctypes.ResultStatus, error)
 }
 
+//go:generate ../../scripts/mockery_generate.sh NetworkClient
+
 // NetworkClient is general info about the network state. May not be needed
 // usually.
 type NetworkClient interface {
@@ -130,9 +196,21 @@ type NetworkClient interface {
 	DumpConsensusState(context.Context) (*ctypes.ResultDumpConsensusState, error)
 	ConsensusState(context.Context) (*ctypes.ResultConsensusState, error)
 	ConsensusParams(ctx context.Context, height *int64) (*ctypes.ResultConsensusParams, error)
+	// ProposerMisses returns, per validator, the rounds of the heights in
+	// [from, to] it was the proposer of in which the node observed no
+	// proposal from it. If address is set, only this validator is reported.
+	ProposerMisses(ctx context.Context, address []byte, from, to int64) (*ctypes.ResultProposerMisses, error)
 	Health(context.Context) (*ctypes.ResultHealth, error)
+	// DebugBundle returns the status, network info, consensus state, mempool
+	// summary and recent logs of the node, captured at the same height.
+	DebugBundle(context.Context) (*ctypes.ResultDebugBundle, error)
+	// Snapshots returns the state machine snapshots the node asked the
+	// application to create and currently keeps.
+	Snapshots(context.Context) (*ctypes.ResultSnapshots, error)
 }
 
+//go:generate ../../scripts/mockery_generate.sh EventsClient
+
 // EventsClient is reactive, you can subscribe to any message, given the proper
 // string. see cometbft/types/events.go
 type EventsClient interface {
@@ -150,13 +228,23 @@ type EventsClient interface {
 	UnsubscribeAll(ctx context.Context, subscriber string) error
 }
 
+//go:generate ../../scripts/mockery_generate.sh MempoolClient
+
 // MempoolClient shows us data about current mempool state.
 type MempoolClient interface {
 	UnconfirmedTxs(ctx context.Context, limit *int) (*ctypes.ResultUnconfirmedTxs, error)
 	NumUnconfirmedTxs(context.Context) (*ctypes.ResultUnconfirmedTxs, error)
+	// MempoolSnapshot returns the hashes of the unconfirmed txs, with the
+	// sequence of the last MempoolTx event.
+	MempoolSnapshot(context.Context) (*ctypes.ResultMempoolSnapshot, error)
+	// MempoolRequirements returns the requirements of the application to
+	// admit a transaction in its mempool, e.g. the minimum gas price.
+	MempoolRequirements(context.Context) (*ctypes.ResultMempoolRequirements, error)
 	CheckTx(context.Context, types.Tx) (*ctypes.ResultCheckTx, error)
 }
 
+//go:generate ../../scripts/mockery_generate.sh EvidenceClient
+
 // EvidenceClient is used for submitting an evidence of the malicious
 // behaviour.
 type EvidenceClient interface {
@@ -10,6 +10,7 @@ import (
 	cmtpubsub "github.com/tendermint/tendermint/libs/pubsub"
 	cmtquery "github.com/tendermint/tendermint/libs/pubsub/query"
 	nm "github.com/tendermint/tendermint/node"
+	cmtproto "github.com/tendermint/tendermint/proto/tendermint/types"
 	rpcclient "github.com/tendermint/tendermint/rpc/client"
 	"github.com/tendermint/tendermint/rpc/core"
 	ctypes "github.com/tendermint/tendermint/rpc/core/types"
@@ -107,6 +108,14 @@ func (c *Local) NumUnconfirmedTxs(ctx context.Context) (*ctypes.ResultUnconfirme
 	return core.NumUnconfirmedTxs(c.ctx)
 }
 
+func (c *Local) MempoolSnapshot(ctx context.Context) (*ctypes.ResultMempoolSnapshot, error) {
+	return core.MempoolSnapshot(c.ctx)
+}
+
+func (c *Local) MempoolRequirements(ctx context.Context) (*ctypes.ResultMempoolRequirements, error) {
+	return core.MempoolRequirements(c.ctx)
+}
+
 func (c *Local) CheckTx(ctx context.Context, tx types.Tx) (*ctypes.ResultCheckTx, error) {
 	return core.CheckTx(c.ctx, tx)
 }
@@ -123,10 +132,22 @@ func (c *Local) ConsensusState(ctx context.Context) (*ctypes.ResultConsensusStat
 	return core.ConsensusState(c.ctx)
 }
 
+func (c *Local) DebugBundle(ctx context.Context) (*ctypes.ResultDebugBundle, error) {
+	return core.DebugBundle(c.ctx)
+}
+
+func (c *Local) Snapshots(ctx context.Context) (*ctypes.ResultSnapshots, error) {
+	return core.Snapshots(c.ctx)
+}
+
 func (c *Local) ConsensusParams(ctx context.Context, height *int64) (*ctypes.ResultConsensusParams, error) {
 	return core.ConsensusParams(c.ctx, height)
 }
 
+func (c *Local) ProposerMisses(ctx context.Context, address []byte, from, to int64) (*ctypes.ResultProposerMisses, error) {
+	return core.ProposerMisses(c.ctx, address, from, to)
+}
+
 func (c *Local) Health(ctx context.Context) (*ctypes.ResultHealth, error) {
 	return core.Health(c.ctx)
 }
@@ -149,6 +170,10 @@ func (c *Local) BlockchainInfo(ctx context.Context, minHeight, maxHeight int64)
 	return core.BlockchainInfo(c.ctx, minHeight, maxHeight)
 }
 
+func (c *Local) BlockMeta(ctx context.Context, height *int64) (*ctypes.ResultBlockMeta, error) {
+	return core.BlockMeta(c.ctx, height)
+}
+
 func (c *Local) Genesis(ctx context.Context) (*ctypes.ResultGenesis, error) {
 	return core.Genesis(c.ctx)
 }
@@ -230,6 +255,70 @@ func (c *Local) ProveSharesV2(
 	return core.ProveSharesV2(c.ctx, int64(height), startShare, endShare)
 }
 
+func (c *Local) ProveSharesBatch(
+	ctx context.Context,
+	height int64,
+	ranges []ctypes.ShareRange,
+) (*ctypes.ResultShareProofBatch, error) {
+	return core.ProveSharesBatch(c.ctx, height, ranges)
+}
+
+func (c *Local) ProveSharesPaged(
+	ctx context.Context,
+	height int64,
+	startShare uint64,
+	endShare uint64,
+	page,
+	perPage *int,
+) (*ctypes.ResultShareProofPage, error) {
+	return core.ProveSharesPaged(c.ctx, height, startShare, endShare, page, perPage)
+}
+
+func (c *Local) RowNamespaceRanges(
+	ctx context.Context,
+	height *int64,
+) (*ctypes.ResultRowNamespaceRanges, error) {
+	return core.RowNamespaceRanges(c.ctx, height)
+}
+
+func (c *Local) ProveTxAbsence(
+	ctx context.Context,
+	height int64,
+	hash []byte,
+) (*ctypes.ResultTxAbsenceProof, error) {
+	return core.ProveTxAbsence(c.ctx, height, hash)
+}
+
+func (c *Local) ProveTxShares(
+	ctx context.Context,
+	height int64,
+	hash []byte,
+	includeBlob bool,
+) (*ctypes.ResultTxSharesProof, error) {
+	return core.ProveTxShares(c.ctx, height, hash, includeBlob)
+}
+
+func (c *Local) TxBundle(ctx context.Context, hash []byte) (*ctypes.ResultTxBundle, error) {
+	return core.TxBundle(c.ctx, hash)
+}
+
+func (c *Local) EstimateNamespaceProofSize(
+	ctx context.Context,
+	height int64,
+	namespace []byte,
+) (*ctypes.ResultProofSizeEstimate, error) {
+	return core.EstimateNamespaceProofSize(c.ctx, height, namespace)
+}
+
+func (c *Local) VerifyNamespaceCompleteness(
+	ctx context.Context,
+	height int64,
+	namespaceID []byte,
+	proofs []cmtproto.ShareProof,
+) (*ctypes.ResultNamespaceCompleteness, error) {
+	return core.VerifyNamespaceCompleteness(c.ctx, height, namespaceID, proofs)
+}
+
 func (c *Local) TxSearch(
 	_ context.Context,
 	query string,
@@ -254,6 +343,10 @@ func (c *Local) TxStatus(ctx context.Context, hash []byte) (*ctypes.ResultTxStat
 	return core.TxStatus(c.ctx, hash)
 }
 
+func (c *Local) TxIndexStatus(ctx context.Context) (*ctypes.ResultTxIndexStatus, error) {
+	return core.TxIndexStatus(c.ctx)
+}
+
 func (c *Local) BroadcastEvidence(ctx context.Context, ev types.Evidence) (*ctypes.ResultBroadcastEvidence, error) {
 	return core.BroadcastEvidence(c.ctx, ev)
 }
@@ -35,6 +35,7 @@ import (
 type Client struct {
 	client.ABCIClient
 	client.SignClient
+	client.ProofClient
 	client.HistoryClient
 	client.StatusClient
 	client.EventsClient
@@ -127,10 +128,22 @@ func (c Client) DumpConsensusState(ctx context.Context) (*ctypes.ResultDumpConse
 	return core.DumpConsensusState(&rpctypes.Context{})
 }
 
+func (c Client) DebugBundle(ctx context.Context) (*ctypes.ResultDebugBundle, error) {
+	return core.DebugBundle(&rpctypes.Context{})
+}
+
+func (c Client) Snapshots(ctx context.Context) (*ctypes.ResultSnapshots, error) {
+	return core.Snapshots(&rpctypes.Context{})
+}
+
 func (c Client) ConsensusParams(ctx context.Context, height *int64) (*ctypes.ResultConsensusParams, error) {
 	return core.ConsensusParams(&rpctypes.Context{}, height)
 }
 
+func (c Client) ProposerMisses(ctx context.Context, address []byte, from, to int64) (*ctypes.ResultProposerMisses, error) {
+	return core.ProposerMisses(&rpctypes.Context{}, address, from, to)
+}
+
 func (c Client) Health(ctx context.Context) (*ctypes.ResultHealth, error) {
 	return core.Health(&rpctypes.Context{})
 }
@@ -153,6 +166,10 @@ func (c Client) BlockchainInfo(ctx context.Context, minHeight, maxHeight int64)
 	return core.BlockchainInfo(&rpctypes.Context{}, minHeight, maxHeight)
 }
 
+func (c Client) BlockMeta(ctx context.Context, height *int64) (*ctypes.ResultBlockMeta, error) {
+	return core.BlockMeta(&rpctypes.Context{}, height)
+}
+
 func (c Client) Genesis(ctx context.Context) (*ctypes.ResultGenesis, error) {
 	return core.Genesis(&rpctypes.Context{})
 }
@@ -0,0 +1,161 @@
+package mocks_test
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/tendermint/tendermint/rpc/client"
+	"github.com/tendermint/tendermint/rpc/client/mocks"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+// Code only needing some of the capabilities of a node accepts the narrowest
+// interface, whose mock only has the methods it uses.
+
+func ExampleABCIClient() {
+	broadcast := func(c client.ABCIClient, tx types.Tx) (uint32, error) {
+		res, err := c.BroadcastTxSync(context.Background(), tx)
+		if err != nil {
+			return 0, err
+		}
+		return res.Code, nil
+	}
+
+	c := new(mocks.ABCIClient)
+	c.On("BroadcastTxSync", mock.Anything, types.Tx("tx")).Return(&ctypes.ResultBroadcastTx{Code: 1}, nil)
+	fmt.Println(broadcast(c, types.Tx("tx")))
+	// Output: 1 <nil>
+}
+
+func ExampleSignClient() {
+	lastCommitHeight := func(c client.SignClient) (int64, error) {
+		res, err := c.Commit(context.Background(), nil)
+		if err != nil {
+			return 0, err
+		}
+		return res.Height, nil
+	}
+
+	c := new(mocks.SignClient)
+	c.On("Commit", mock.Anything, (*int64)(nil)).Return(&ctypes.ResultCommit{
+		SignedHeader: types.SignedHeader{Header: &types.Header{Height: 7}},
+	}, nil)
+	fmt.Println(lastCommitHeight(c))
+	// Output: 7 <nil>
+}
+
+func ExampleProofClient() {
+	provenShares := func(c client.ProofClient, height, start, end uint64) (int, error) {
+		res, err := c.ProveSharesV2(context.Background(), height, start, end)
+		if err != nil {
+			return 0, err
+		}
+		return len(res.ShareProof.Data), nil
+	}
+
+	c := new(mocks.ProofClient)
+	c.On("ProveSharesV2", mock.Anything, uint64(3), uint64(0), uint64(2)).Return(&ctypes.ResultShareProof{
+		ShareProof: types.ShareProof{Data: [][]byte{{1}, {2}}},
+	}, nil)
+	fmt.Println(provenShares(c, 3, 0, 2))
+	// Output: 2 <nil>
+}
+
+func ExampleHistoryClient() {
+	chainID := func(c client.HistoryClient) (string, error) {
+		res, err := c.Genesis(context.Background())
+		if err != nil {
+			return "", err
+		}
+		return res.Genesis.ChainID, nil
+	}
+
+	c := new(mocks.HistoryClient)
+	c.On("Genesis", mock.Anything).Return(&ctypes.ResultGenesis{
+		Genesis: &types.GenesisDoc{ChainID: "test-chain"},
+	}, nil)
+	fmt.Println(chainID(c))
+	// Output: test-chain <nil>
+}
+
+func ExampleStatusClient() {
+	latestHeight := func(c client.StatusClient) (int64, error) {
+		res, err := c.Status(context.Background())
+		if err != nil {
+			return 0, err
+		}
+		return res.SyncInfo.LatestBlockHeight, nil
+	}
+
+	c := new(mocks.StatusClient)
+	c.On("Status", mock.Anything).Return(&ctypes.ResultStatus{
+		SyncInfo: ctypes.SyncInfo{LatestBlockHeight: 10},
+	}, nil)
+	fmt.Println(latestHeight(c))
+	// Output: 10 <nil>
+}
+
+func ExampleNetworkClient() {
+	numPeers := func(c client.NetworkClient) (int, error) {
+		res, err := c.NetInfo(context.Background())
+		if err != nil {
+			return 0, err
+		}
+		return res.NPeers, nil
+	}
+
+	c := new(mocks.NetworkClient)
+	c.On("NetInfo", mock.Anything).Return(&ctypes.ResultNetInfo{NPeers: 4}, nil)
+	fmt.Println(numPeers(c))
+	// Output: 4 <nil>
+}
+
+func ExampleEventsClient() {
+	nextEvent := func(c client.EventsClient, query string) (string, error) {
+		out, err := c.Subscribe(context.Background(), "example", query)
+		if err != nil {
+			return "", err
+		}
+		defer c.UnsubscribeAll(context.Background(), "example") //nolint:errcheck
+		return (<-out).Query, nil
+	}
+
+	events := make(chan ctypes.ResultEvent, 1)
+	events <- ctypes.ResultEvent{Query: "tm.event='NewBlock'"}
+	c := new(mocks.EventsClient)
+	c.On("Subscribe", mock.Anything, "example", "tm.event='NewBlock'").
+		Return((<-chan ctypes.ResultEvent)(events), nil)
+	c.On("UnsubscribeAll", mock.Anything, "example").Return(nil)
+	fmt.Println(nextEvent(c, "tm.event='NewBlock'"))
+	// Output: tm.event='NewBlock' <nil>
+}
+
+func ExampleMempoolClient() {
+	pendingTxs := func(c client.MempoolClient) (int, error) {
+		res, err := c.NumUnconfirmedTxs(context.Background())
+		if err != nil {
+			return 0, err
+		}
+		return res.Total, nil
+	}
+
+	c := new(mocks.MempoolClient)
+	c.On("NumUnconfirmedTxs", mock.Anything).Return(&ctypes.ResultUnconfirmedTxs{Total: 42}, nil)
+	fmt.Println(pendingTxs(c))
+	// Output: 42 <nil>
+}
+
+func ExampleEvidenceClient() {
+	submit := func(c client.EvidenceClient, ev types.Evidence) error {
+		_, err := c.BroadcastEvidence(context.Background(), ev)
+		return err
+	}
+
+	c := new(mocks.EvidenceClient)
+	c.On("BroadcastEvidence", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("evidence already committed"))
+	fmt.Println(submit(c, &types.DuplicateVoteEvidence{}))
+	// Output: evidence already committed
+}
@@ -0,0 +1,18 @@
+// Package mocks holds the mocks of client.Client and of each of the
+// interfaces split by capability it is made of, generated by mockery.
+package mocks
+
+import "github.com/tendermint/tendermint/rpc/client"
+
+var (
+	_ client.Client         = (*Client)(nil)
+	_ client.ABCIClient     = (*ABCIClient)(nil)
+	_ client.SignClient     = (*SignClient)(nil)
+	_ client.ProofClient    = (*ProofClient)(nil)
+	_ client.HistoryClient  = (*HistoryClient)(nil)
+	_ client.StatusClient   = (*StatusClient)(nil)
+	_ client.NetworkClient  = (*NetworkClient)(nil)
+	_ client.EventsClient   = (*EventsClient)(nil)
+	_ client.MempoolClient  = (*MempoolClient)(nil)
+	_ client.EvidenceClient = (*EvidenceClient)(nil)
+)
@@ -0,0 +1,292 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	coretypes "github.com/tendermint/tendermint/rpc/core/types"
+
+	tenderminttypes "github.com/tendermint/tendermint/proto/tendermint/types"
+
+	types "github.com/tendermint/tendermint/types"
+)
+
+// ProofClient is an autogenerated mock type for the ProofClient type
+type ProofClient struct {
+	mock.Mock
+}
+
+// EstimateNamespaceProofSize provides a mock function with given fields: ctx, height, namespace
+func (_m *ProofClient) EstimateNamespaceProofSize(ctx context.Context, height int64, namespace []byte) (*coretypes.ResultProofSizeEstimate, error) {
+	ret := _m.Called(ctx, height, namespace)
+
+	var r0 *coretypes.ResultProofSizeEstimate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, []byte) (*coretypes.ResultProofSizeEstimate, error)); ok {
+		return rf(ctx, height, namespace)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, []byte) *coretypes.ResultProofSizeEstimate); ok {
+		r0 = rf(ctx, height, namespace)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultProofSizeEstimate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, []byte) error); ok {
+		r1 = rf(ctx, height, namespace)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ProveShares provides a mock function with given fields: _a0, height, startShare, endShare
+func (_m *ProofClient) ProveShares(_a0 context.Context, height uint64, startShare uint64, endShare uint64) (types.ShareProof, error) {
+	ret := _m.Called(_a0, height, startShare, endShare)
+
+	var r0 types.ShareProof
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64, uint64) (types.ShareProof, error)); ok {
+		return rf(_a0, height, startShare, endShare)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64, uint64) types.ShareProof); ok {
+		r0 = rf(_a0, height, startShare, endShare)
+	} else {
+		r0 = ret.Get(0).(types.ShareProof)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, uint64, uint64) error); ok {
+		r1 = rf(_a0, height, startShare, endShare)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ProveSharesBatch provides a mock function with given fields: ctx, height, ranges
+func (_m *ProofClient) ProveSharesBatch(ctx context.Context, height int64, ranges []coretypes.ShareRange) (*coretypes.ResultShareProofBatch, error) {
+	ret := _m.Called(ctx, height, ranges)
+
+	var r0 *coretypes.ResultShareProofBatch
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, []coretypes.ShareRange) (*coretypes.ResultShareProofBatch, error)); ok {
+		return rf(ctx, height, ranges)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, []coretypes.ShareRange) *coretypes.ResultShareProofBatch); ok {
+		r0 = rf(ctx, height, ranges)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultShareProofBatch)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, []coretypes.ShareRange) error); ok {
+		r1 = rf(ctx, height, ranges)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ProveSharesPaged provides a mock function with given fields: ctx, height, startShare, endShare, page, perPage
+func (_m *ProofClient) ProveSharesPaged(ctx context.Context, height int64, startShare uint64, endShare uint64, page *int, perPage *int) (*coretypes.ResultShareProofPage, error) {
+	ret := _m.Called(ctx, height, startShare, endShare, page, perPage)
+
+	var r0 *coretypes.ResultShareProofPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, uint64, uint64, *int, *int) (*coretypes.ResultShareProofPage, error)); ok {
+		return rf(ctx, height, startShare, endShare, page, perPage)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, uint64, uint64, *int, *int) *coretypes.ResultShareProofPage); ok {
+		r0 = rf(ctx, height, startShare, endShare, page, perPage)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultShareProofPage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, uint64, uint64, *int, *int) error); ok {
+		r1 = rf(ctx, height, startShare, endShare, page, perPage)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ProveSharesV2 provides a mock function with given fields: _a0, height, startShare, endShare
+func (_m *ProofClient) ProveSharesV2(_a0 context.Context, height uint64, startShare uint64, endShare uint64) (*coretypes.ResultShareProof, error) {
+	ret := _m.Called(_a0, height, startShare, endShare)
+
+	var r0 *coretypes.ResultShareProof
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64, uint64) (*coretypes.ResultShareProof, error)); ok {
+		return rf(_a0, height, startShare, endShare)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64, uint64) *coretypes.ResultShareProof); ok {
+		r0 = rf(_a0, height, startShare, endShare)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultShareProof)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, uint64, uint64) error); ok {
+		r1 = rf(_a0, height, startShare, endShare)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ProveTxAbsence provides a mock function with given fields: ctx, height, hash
+func (_m *ProofClient) ProveTxAbsence(ctx context.Context, height int64, hash []byte) (*coretypes.ResultTxAbsenceProof, error) {
+	ret := _m.Called(ctx, height, hash)
+
+	var r0 *coretypes.ResultTxAbsenceProof
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, []byte) (*coretypes.ResultTxAbsenceProof, error)); ok {
+		return rf(ctx, height, hash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, []byte) *coretypes.ResultTxAbsenceProof); ok {
+		r0 = rf(ctx, height, hash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultTxAbsenceProof)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, []byte) error); ok {
+		r1 = rf(ctx, height, hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ProveTxShares provides a mock function with given fields: ctx, height, hash, includeBlob
+func (_m *ProofClient) ProveTxShares(ctx context.Context, height int64, hash []byte, includeBlob bool) (*coretypes.ResultTxSharesProof, error) {
+	ret := _m.Called(ctx, height, hash, includeBlob)
+
+	var r0 *coretypes.ResultTxSharesProof
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, []byte, bool) (*coretypes.ResultTxSharesProof, error)); ok {
+		return rf(ctx, height, hash, includeBlob)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, []byte, bool) *coretypes.ResultTxSharesProof); ok {
+		r0 = rf(ctx, height, hash, includeBlob)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultTxSharesProof)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, []byte, bool) error); ok {
+		r1 = rf(ctx, height, hash, includeBlob)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RowNamespaceRanges provides a mock function with given fields: ctx, height
+func (_m *ProofClient) RowNamespaceRanges(ctx context.Context, height *int64) (*coretypes.ResultRowNamespaceRanges, error) {
+	ret := _m.Called(ctx, height)
+
+	var r0 *coretypes.ResultRowNamespaceRanges
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *int64) (*coretypes.ResultRowNamespaceRanges, error)); ok {
+		return rf(ctx, height)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *int64) *coretypes.ResultRowNamespaceRanges); ok {
+		r0 = rf(ctx, height)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultRowNamespaceRanges)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *int64) error); ok {
+		r1 = rf(ctx, height)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TxBundle provides a mock function with given fields: ctx, hash
+func (_m *ProofClient) TxBundle(ctx context.Context, hash []byte) (*coretypes.ResultTxBundle, error) {
+	ret := _m.Called(ctx, hash)
+
+	var r0 *coretypes.ResultTxBundle
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []byte) (*coretypes.ResultTxBundle, error)); ok {
+		return rf(ctx, hash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []byte) *coretypes.ResultTxBundle); ok {
+		r0 = rf(ctx, hash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultTxBundle)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []byte) error); ok {
+		r1 = rf(ctx, hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VerifyNamespaceCompleteness provides a mock function with given fields: ctx, height, namespaceID, proofs
+func (_m *ProofClient) VerifyNamespaceCompleteness(ctx context.Context, height int64, namespaceID []byte, proofs []tenderminttypes.ShareProof) (*coretypes.ResultNamespaceCompleteness, error) {
+	ret := _m.Called(ctx, height, namespaceID, proofs)
+
+	var r0 *coretypes.ResultNamespaceCompleteness
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, []byte, []tenderminttypes.ShareProof) (*coretypes.ResultNamespaceCompleteness, error)); ok {
+		return rf(ctx, height, namespaceID, proofs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, []byte, []tenderminttypes.ShareProof) *coretypes.ResultNamespaceCompleteness); ok {
+		r0 = rf(ctx, height, namespaceID, proofs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultNamespaceCompleteness)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, []byte, []tenderminttypes.ShareProof) error); ok {
+		r1 = rf(ctx, height, namespaceID, proofs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewProofClient interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewProofClient creates a new instance of ProofClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewProofClient(t mockConstructorTestingTNewProofClient) *ProofClient {
+	mock := &ProofClient{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -26,6 +26,7 @@ import (
 	rpcclient "github.com/tendermint/tendermint/rpc/jsonrpc/client"
 	rpctest "github.com/tendermint/tendermint/rpc/test"
 	"github.com/tendermint/tendermint/types"
+	"github.com/tendermint/tendermint/version"
 )
 
 var (
@@ -120,6 +121,35 @@ func TestInfo(t *testing.T) {
 	}
 }
 
+func TestDebugBundle(t *testing.T) {
+	for i, c := range GetClients() {
+		nc, ok := c.(client.NetworkClient)
+		require.True(t, ok, "%d", i)
+		bundle, err := nc.DebugBundle(context.Background())
+		require.NoError(t, err, "%d", i)
+
+		var roundState struct {
+			HeightRoundStep string `json:"height/round/step"`
+		}
+		require.NoError(t, cmtjson.Unmarshal(bundle.ConsensusState, &roundState), "%d", i)
+		// the test node commits blocks back to back, yet all the sections are
+		// from the same height
+		assert.True(t, bundle.Consistent, "%d", i)
+		assert.Equal(t, bundle.Height, bundle.Status.SyncInfo.LatestBlockHeight, "%d", i)
+		assert.True(t, strings.HasPrefix(roundState.HeightRoundStep, fmt.Sprintf("%d/", bundle.Height+1)),
+			"%d: consensus at %s, blocks at %d", i, roundState.HeightRoundStep, bundle.Height)
+
+		assert.Equal(t, rpctest.GetConfig().Moniker, bundle.Status.NodeInfo.Moniker, "%d", i)
+		assert.True(t, bundle.NetInfo.Listening, "%d", i)
+		require.NotNil(t, bundle.Mempool, "%d", i)
+		require.NotNil(t, bundle.BlockSync, "%d", i)
+		assert.False(t, bundle.BlockSync.Syncing, "%d", i)
+		assert.Equal(t, version.TMCoreSemVer, bundle.Version.Version, "%d", i)
+		assert.NotEmpty(t, bundle.RecentLogs, "%d", i)
+		assert.False(t, bundle.Truncated, "%d", i)
+	}
+}
+
 func TestNetInfo(t *testing.T) {
 	for i, c := range GetClients() {
 		nc, ok := c.(client.NetworkClient)
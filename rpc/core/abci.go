@@ -1,6 +1,8 @@
 package core
 
 import (
+	"errors"
+
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/libs/bytes"
 	"github.com/tendermint/tendermint/proxy"
@@ -40,3 +42,15 @@ func ABCIInfo(ctx *rpctypes.Context) (*ctypes.ResultABCIInfo, error) {
 
 	return &ctypes.ResultABCIInfo{Response: *resInfo}, nil
 }
+
+// Snapshots returns the state machine snapshots the node asked the application
+// to create and currently keeps. It requires statesync.snapshot_interval and an
+// application supporting snapshot orchestration.
+// More: https://docs.cometbft.com/v0.34/rpc/#/ABCI/snapshots
+func Snapshots(ctx *rpctypes.Context) (*ctypes.ResultSnapshots, error) {
+	manager := GetEnvironment().SnapshotManager
+	if manager == nil || !manager.Enabled() {
+		return nil, errors.New("snapshots are not orchestrated by the node, see statesync.snapshot_interval")
+	}
+	return &ctypes.ResultSnapshots{Snapshots: manager.Snapshots()}, nil
+}
@@ -0,0 +1,198 @@
+package core
+
+import (
+	"errors"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	cfg "github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/libs/log"
+	mempl "github.com/tendermint/tendermint/mempool"
+	"github.com/tendermint/tendermint/p2p"
+	"github.com/tendermint/tendermint/proxy"
+	rpc "github.com/tendermint/tendermint/rpc/jsonrpc/server"
+	sm "github.com/tendermint/tendermint/state"
+	"github.com/tendermint/tendermint/state/indexer"
+	"github.com/tendermint/tendermint/state/txindex"
+	"github.com/tendermint/tendermint/types"
+)
+
+// ErrArchiveMode is returned when calling, through the environment of an
+// archive server, a component it does not run, e.g. the application when the
+// server does not query one.
+var ErrArchiveMode = errors.New("not available in archive mode")
+
+// archiveUnavailableRoutes are the read-only routes an archive server does not
+// serve, as they need p2p, consensus, the mempool or the event bus. The other
+// routes not declared as read-only are not served either.
+var archiveUnavailableRoutes = []string{
+	"subscribe",
+	"unsubscribe",
+	"unsubscribe_all",
+	"net_info",
+	"dump_consensus_state",
+	"consensus_state",
+	"unconfirmed_txs",
+	"num_unconfirmed_txs",
+	"mempool_requirements",
+	"debug_bundle",
+	"snapshots",
+}
+
+// ArchiveRoutes returns a copy of routes for an archive server, in which the
+// calls of the routes it does not serve are rejected as not available in
+// archive mode.
+func ArchiveRoutes(routes map[string]*rpc.RPCFunc) map[string]*rpc.RPCFunc {
+	unavailable := append([]string{}, archiveUnavailableRoutes...)
+	for name, f := range routes {
+		if f.Access() != rpc.AccessReadOnly {
+			unavailable = append(unavailable, name)
+		}
+	}
+	return rpc.UnavailableFuncs(routes, ErrArchiveMode.Error(), unavailable...)
+}
+
+// NewArchiveEnvironment returns the environment of an archive server, serving
+// the blocks, results, transactions and proofs held by a block store, a state
+// store and the indexers, e.g. copied from another node, without running p2p
+// or consensus. The components it does not run are stubbed and return
+// ErrArchiveMode, or nothing, e.g. no unconfirmed transactions.
+//
+// The proofs are built by the application, which is not queried unless
+// ProxyAppQuery is set on the environment.
+func NewArchiveEnvironment(
+	config cfg.RPCConfig,
+	nodeInfo p2p.NodeInfo,
+	genDoc *types.GenesisDoc,
+	stateStore sm.Store,
+	blockStore sm.BlockStore,
+	txIndexer txindex.TxIndexer,
+	blockIndexer indexer.BlockIndexer,
+	logger log.Logger,
+) *Environment {
+	return &Environment{
+		ProxyAppQuery:   archiveAppConnQuery{},
+		ProxyAppMempool: archiveAppConnMempool{},
+
+		StateStore:     stateStore,
+		BlockStore:     blockStore,
+		EvidencePool:   archiveEvidencePool{},
+		ConsensusState: archiveConsensus{stateStore: stateStore, blockStore: blockStore},
+		P2PPeers:       archivePeers{},
+		P2PTransport:   archiveTransport{nodeInfo: nodeInfo},
+
+		GenDoc:       genDoc,
+		TxIndexer:    txIndexer,
+		BlockIndexer: blockIndexer,
+		Mempool:      archiveMempool{},
+		ArchiveMode:  true,
+
+		Logger: logger,
+
+		Config: config,
+	}
+}
+
+type archiveAppConnQuery struct{}
+
+func (archiveAppConnQuery) Error() error { return nil }
+
+func (archiveAppConnQuery) EchoSync(string) (*abci.ResponseEcho, error) {
+	return nil, ErrArchiveMode
+}
+
+func (archiveAppConnQuery) InfoSync(abci.RequestInfo) (*abci.ResponseInfo, error) {
+	return nil, ErrArchiveMode
+}
+
+func (archiveAppConnQuery) QuerySync(abci.RequestQuery) (*abci.ResponseQuery, error) {
+	return nil, ErrArchiveMode
+}
+
+func (archiveAppConnQuery) VerifyEvidenceSync(abci.RequestVerifyEvidence) (*abci.ResponseVerifyEvidence, error) {
+	return nil, ErrArchiveMode
+}
+
+type archiveAppConnMempool struct {
+	proxy.AppConnMempool
+}
+
+func (archiveAppConnMempool) CheckTxSync(abci.RequestCheckTx) (*abci.ResponseCheckTx, error) {
+	return nil, ErrArchiveMode
+}
+
+type archiveEvidencePool struct {
+	sm.EvidencePool
+}
+
+func (archiveEvidencePool) AddEvidence(types.Evidence) error {
+	return ErrArchiveMode
+}
+
+// archiveMempool is an always empty mempool.
+type archiveMempool struct {
+	mempl.Mempool
+}
+
+func (archiveMempool) CheckTx(types.Tx, func(*abci.Response), mempl.TxInfo) error {
+	return ErrArchiveMode
+}
+
+func (archiveMempool) ReapMaxTxs(int) types.Txs                { return nil }
+func (archiveMempool) Size() int                               { return 0 }
+func (archiveMempool) SizeBytes() int64                        { return 0 }
+func (archiveMempool) Flush()                                  {}
+func (archiveMempool) GetTxByKey(types.TxKey) (types.Tx, bool) { return nil, false }
+func (archiveMempool) WasRecentlyEvicted(types.TxKey) bool     { return false }
+
+// archiveConsensus reports the last state saved in the state store.
+type archiveConsensus struct {
+	stateStore sm.Store
+	blockStore sm.BlockStore
+}
+
+func (c archiveConsensus) GetState() sm.State {
+	state, _ := c.stateStore.Load()
+	return state
+}
+
+func (c archiveConsensus) GetValidators() (int64, []*types.Validator) {
+	state := c.GetState()
+	if state.Validators == nil {
+		return state.LastBlockHeight, nil
+	}
+	return state.LastBlockHeight, state.Validators.Copy().Validators
+}
+
+func (c archiveConsensus) GetLastHeight() int64 {
+	return c.blockStore.Height()
+}
+
+func (archiveConsensus) GetRoundStateJSON() ([]byte, error) {
+	return nil, ErrArchiveMode
+}
+
+func (archiveConsensus) GetRoundStateSimpleJSON() ([]byte, error) {
+	return nil, ErrArchiveMode
+}
+
+func (archiveConsensus) WithRoundStateSimpleJSON(func([]byte) error) error {
+	return ErrArchiveMode
+}
+
+// archivePeers is a switch with no peers, refusing to dial any.
+type archivePeers struct{}
+
+func (archivePeers) AddPersistentPeers([]string) error      { return ErrArchiveMode }
+func (archivePeers) AddUnconditionalPeerIDs([]string) error { return ErrArchiveMode }
+func (archivePeers) AddPrivatePeerIDs([]string) error       { return ErrArchiveMode }
+func (archivePeers) DialPeersAsync([]string) error          { return ErrArchiveMode }
+func (archivePeers) Peers() p2p.IPeerSet                    { return p2p.NewPeerSet() }
+
+// archiveTransport is a transport listening for no peers.
+type archiveTransport struct {
+	nodeInfo p2p.NodeInfo
+}
+
+func (archiveTransport) Listeners() []string      { return nil }
+func (archiveTransport) IsListening() bool        { return false }
+func (t archiveTransport) NodeInfo() p2p.NodeInfo { return t.nodeInfo }
@@ -46,12 +46,18 @@ func BlockchainInfo(ctx *rpctypes.Context, minHeight, maxHeight int64) (*ctypes.
 	blockMetas := []*types.BlockMeta{}
 	for height := maxHeight; height >= minHeight; height-- {
 		blockMeta := env.BlockStore.LoadBlockMeta(height)
+		if blockMeta != nil && !env.BlockStore.HasBlockData(height) {
+			blockMeta.DataPruned = true
+		}
 		blockMetas = append(blockMetas, blockMeta)
 	}
 
 	return &ctypes.ResultBlockchainInfo{
-		LastHeight: env.BlockStore.Height(),
-		BlockMetas: blockMetas}, nil
+		LastHeight:   env.BlockStore.Height(),
+		BlockMetas:   blockMetas,
+		BaseHeight:   env.BlockStore.Base(),
+		RetainHeight: env.BlockStore.RetainHeight(),
+	}, nil
 }
 
 // error if either min or max are negative or min > max
@@ -119,6 +125,26 @@ func HeaderByHash(ctx *rpctypes.Context, hash bytes.HexBytes) (*ctypes.ResultHea
 	return &ctypes.ResultHeader{Header: &blockMeta.Header}, nil
 }
 
+// BlockMeta gets the meta of the block at a given height, including the use of
+// its data square. If no height is provided, it will fetch the latest block
+// meta.
+func BlockMeta(ctx *rpctypes.Context, heightPtr *int64) (*ctypes.ResultBlockMeta, error) {
+	env := GetEnvironment()
+	height, err := getHeight(env.BlockStore.Height(), heightPtr)
+	if err != nil {
+		return nil, err
+	}
+
+	blockMeta := env.BlockStore.LoadBlockMeta(height)
+	if blockMeta == nil {
+		return nil, fmt.Errorf("block meta not found for height %d", height)
+	}
+	if !env.BlockStore.HasBlockData(height) {
+		blockMeta.DataPruned = true
+	}
+	return &ctypes.ResultBlockMeta{BlockMeta: blockMeta}, nil
+}
+
 // Block gets block at a given height.
 // If no height is provided, it will fetch the latest block.
 // More: https://docs.cometbft.com/v0.34/rpc/#/Info/block
@@ -133,6 +159,11 @@ func Block(ctx *rpctypes.Context, heightPtr *int64) (*ctypes.ResultBlock, error)
 	if blockMeta == nil {
 		return &ctypes.ResultBlock{BlockID: types.BlockID{}, Block: block}, nil
 	}
+	if block == nil {
+		// only the data of the block was pruned
+		blockMeta.DataPruned = true
+		return &ctypes.ResultBlock{BlockID: blockMeta.BlockID, BlockMeta: blockMeta}, nil
+	}
 	return &ctypes.ResultBlock{BlockID: blockMeta.BlockID, Block: block}, nil
 }
 
@@ -13,6 +13,7 @@ import (
 
 	dbm "github.com/cometbft/cometbft-db"
 	"github.com/tendermint/tendermint/crypto/merkle"
+	"github.com/tendermint/tendermint/libs/log"
 	"github.com/tendermint/tendermint/libs/pubsub/query"
 	cmtrand "github.com/tendermint/tendermint/libs/rand"
 	"github.com/tendermint/tendermint/types"
@@ -77,6 +78,78 @@ func TestBlockchainInfo(t *testing.T) {
 	}
 }
 
+func TestBlockchainInfoDataPruned(t *testing.T) {
+	height := int64(10)
+	blocks := randomBlocks(height)
+	env := &Environment{Logger: log.TestingLogger()}
+	env.BlockStore = mockBlockStore{
+		height:       height,
+		blocks:       blocks,
+		retainHeight: 8,
+		pruned:       map[int64]bool{4: true, 5: true},
+	}
+	SetEnvironment(env)
+
+	res, err := BlockchainInfo(&rpctypes.Context{}, 2, 7)
+	require.NoError(t, err)
+	assert.Equal(t, height, res.LastHeight)
+	assert.EqualValues(t, 1, res.BaseHeight)
+	assert.EqualValues(t, 8, res.RetainHeight)
+	require.Len(t, res.BlockMetas, 6)
+	for _, meta := range res.BlockMetas {
+		h := meta.Header.Height
+		assert.Equal(t, h == 4 || h == 5, meta.DataPruned, "height %d", h)
+	}
+
+	// the meta of a block whose data is pruned
+	h := int64(4)
+	block, err := Block(&rpctypes.Context{}, &h)
+	require.NoError(t, err)
+	assert.Nil(t, block.Block)
+	require.NotNil(t, block.BlockMeta)
+	assert.True(t, block.BlockMeta.DataPruned)
+	assert.Equal(t, blocks[h].Hash(), block.BlockID.Hash)
+
+	// an available block
+	h = 6
+	block, err = Block(&rpctypes.Context{}, &h)
+	require.NoError(t, err)
+	assert.Equal(t, blocks[h], block.Block)
+	assert.Nil(t, block.BlockMeta)
+	assert.Equal(t, blocks[h].Hash(), block.BlockID.Hash)
+}
+
+func TestBlockMeta(t *testing.T) {
+	height := int64(10)
+	blocks := randomBlocks(height)
+	env := &Environment{Logger: log.TestingLogger()}
+	env.BlockStore = mockBlockStore{
+		height: height,
+		blocks: blocks,
+		pruned: map[int64]bool{4: true},
+	}
+	SetEnvironment(env)
+
+	for h := int64(1); h <= height; h++ {
+		h := h
+		res, err := BlockMeta(&rpctypes.Context{}, &h)
+		require.NoError(t, err)
+		assert.Equal(t, blocks[h].Hash(), res.BlockMeta.BlockID.Hash)
+		assert.Equal(t, types.NewDataSquareInfo(&blocks[h].Data), res.BlockMeta.Square)
+		assert.Positive(t, res.BlockMeta.Square.SharesUsed)
+		assert.Equal(t, h == 4, res.BlockMeta.DataPruned, "height %d", h)
+	}
+
+	// the latest block meta
+	res, err := BlockMeta(&rpctypes.Context{}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, height, res.BlockMeta.Header.Height)
+
+	h := height + 1
+	_, err = BlockMeta(&rpctypes.Context{}, &h)
+	assert.Error(t, err)
+}
+
 func TestBlockResults(t *testing.T) {
 	results := &cmtstate.ABCIResponses{
 		DeliverTxs: []*abci.ResponseDeliverTx{
@@ -285,8 +358,11 @@ func TestDataRootInclusionProofResults(t *testing.T) {
 }
 
 type mockBlockStore struct {
-	height int64
-	blocks []*types.Block
+	height       int64
+	blocks       []*types.Block
+	retainHeight int64
+	// the heights whose meta is kept but not the data
+	pruned map[int64]bool
 }
 
 func (mockBlockStore) Base() int64                                       { return 1 }
@@ -299,6 +375,11 @@ func (mockBlockStore) LoadBlockMetaByHash(hash []byte) *types.BlockMeta  { retur
 func (mockBlockStore) LoadBlockCommit(height int64) *types.Commit        { return nil }
 func (mockBlockStore) LoadSeenCommit(height int64) *types.Commit         { return nil }
 func (mockBlockStore) PruneBlocks(height int64) (uint64, error)          { return 0, nil }
+func (store mockBlockStore) RetainHeight() int64                         { return store.retainHeight }
+func (mockBlockStore) SaveRetainHeight(height int64)                     {}
+func (store mockBlockStore) HasBlockData(height int64) bool {
+	return height <= store.height && !store.pruned[height]
+}
 func (mockBlockStore) SaveBlock(block *types.Block, blockParts *types.PartSet, seenCommit *types.Commit) {
 }
 func (mockBlockStore) SaveTxInfo(block *types.Block, txResponseCode []uint32) error {
@@ -313,11 +394,12 @@ func (store mockBlockStore) LoadBlockMeta(height int64) *types.BlockMeta {
 	return &types.BlockMeta{
 		BlockID: types.BlockID{Hash: block.Hash(), PartSetHeader: block.MakePartSet(types.BlockPartSizeBytes).Header()},
 		Header:  block.Header,
+		Square:  types.NewDataSquareInfo(&block.Data),
 	}
 }
 
 func (store mockBlockStore) LoadBlock(height int64) *types.Block {
-	if height > store.height {
+	if height > store.height || store.pruned[height] {
 		return nil
 	}
 	return store.blocks[height]
@@ -339,6 +421,9 @@ func (store mockBlockStore) LoadTxInfo(hash []byte) *cmtstore.TxInfo {
 	return nil
 }
 
+func (mockBlockStore) SaveRowProof(height int64, rowProof types.RowProof) error { return nil }
+func (mockBlockStore) LoadRowProof(height int64) (*types.RowProof, error)       { return nil, nil }
+
 // mockBlockIndexer used to mock the set of indexed blocks and return a predefined one.
 type mockBlockIndexer struct {
 	height          int64
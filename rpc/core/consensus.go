@@ -1,6 +1,9 @@
 package core
 
 import (
+	"bytes"
+	"sort"
+
 	cm "github.com/tendermint/tendermint/consensus"
 	cmtmath "github.com/tendermint/tendermint/libs/math"
 	ctypes "github.com/tendermint/tendermint/rpc/core/types"
@@ -106,3 +109,69 @@ func ConsensusParams(ctx *rpctypes.Context, heightPtr *int64) (*ctypes.ResultCon
 		BlockHeight:     height,
 		ConsensusParams: consensusParams}, nil
 }
+
+// maxProposerMissesHeights is the maximum number of heights aggregated by
+// ProposerMisses.
+const maxProposerMissesHeights = 1000
+
+// ProposerMisses aggregates, per validator, the rounds of the heights in
+// [from, to] in which the validator was the proposer and whether the node
+// observed a proposal from it, as recorded by consensus. If address is set,
+// only this validator is reported. At most 1000 heights, the latest of the
+// range, are aggregated. The heights the node did not take part in, e.g.
+// synced ones, are not recorded. This is best effort: a proposal not observed
+// by the node is not proof of a misbehavior of the proposer.
+func ProposerMisses(ctx *rpctypes.Context, address []byte, from, to int64) (*ctypes.ResultProposerMisses, error) {
+	env := GetEnvironment()
+	from, to, err := filterMinMax(
+		env.BlockStore.Base(),
+		env.BlockStore.Height(),
+		from,
+		to,
+		maxProposerMissesHeights)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &ctypes.ResultProposerMisses{FromHeight: from, ToHeight: to}
+	byAddress := make(map[string]*ctypes.ValidatorProposerMisses)
+	for height := from; height <= to; height++ {
+		record, err := env.StateStore.LoadProposerRecord(height)
+		if err != nil {
+			return nil, err
+		}
+		if record == nil {
+			continue
+		}
+		res.RecordedHeights++
+		for _, round := range record.Rounds {
+			if len(address) > 0 && !bytes.Equal(address, round.ProposerAddress) {
+				continue
+			}
+			v, ok := byAddress[string(round.ProposerAddress)]
+			if !ok {
+				v = &ctypes.ValidatorProposerMisses{Address: round.ProposerAddress}
+				byAddress[string(round.ProposerAddress)] = v
+			}
+			v.ScheduledRounds++
+			if !round.ProposalObserved {
+				v.NotObservedRounds++
+				v.NotObserved = append(v.NotObserved, ctypes.ProposerRound{Height: height, Round: round.Round})
+			}
+		}
+	}
+
+	res.Validators = make([]ctypes.ValidatorProposerMisses, 0, len(byAddress))
+	for _, v := range byAddress {
+		res.Validators = append(res.Validators, *v)
+	}
+	// the validators with the most rounds not observed first
+	sort.Slice(res.Validators, func(i, j int) bool {
+		vi, vj := res.Validators[i], res.Validators[j]
+		if vi.NotObservedRounds != vj.NotObservedRounds {
+			return vi.NotObservedRounds > vj.NotObservedRounds
+		}
+		return bytes.Compare(vi.Address, vj.Address) < 0
+	})
+	return res, nil
+}
@@ -0,0 +1,156 @@
+package core
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dbm "github.com/cometbft/cometbft-db"
+
+	cm "github.com/tendermint/tendermint/consensus"
+	cmtstate "github.com/tendermint/tendermint/proto/tendermint/state"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+	sm "github.com/tendermint/tendermint/state"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestProposerMisses(t *testing.T) {
+	addrA, addrB := []byte{0x0a}, []byte{0x0b}
+	env := &Environment{}
+	env.StateStore = sm.NewStore(dbm.NewMemDB(), sm.StoreOptions{})
+	env.BlockStore = mockBlockStore{height: 10}
+	SetEnvironment(env)
+
+	// A misses round 0 of heights 2 and 3, B proposes in round 1; height 4
+	// was not recorded
+	records := map[int64][]cmtstate.RoundProposer{
+		1: {{Round: 0, ProposerAddress: addrB, ProposalObserved: true}},
+		2: {
+			{Round: 0, ProposerAddress: addrA},
+			{Round: 1, ProposerAddress: addrB, ProposalObserved: true},
+		},
+		3: {
+			{Round: 0, ProposerAddress: addrA},
+			{Round: 1, ProposerAddress: addrB, ProposalObserved: true},
+		},
+		5: {{Round: 0, ProposerAddress: addrA, ProposalObserved: true}},
+	}
+	for h, rounds := range records {
+		err := env.StateStore.SaveProposerRecord(h, &cmtstate.ProposerRecord{Rounds: rounds})
+		require.NoError(t, err)
+	}
+
+	res, err := ProposerMisses(&rpctypes.Context{}, nil, 1, 5)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, res.FromHeight)
+	assert.EqualValues(t, 5, res.ToHeight)
+	assert.EqualValues(t, 4, res.RecordedHeights)
+	require.Len(t, res.Validators, 2)
+	a, b := res.Validators[0], res.Validators[1]
+	assert.EqualValues(t, addrA, a.Address)
+	assert.EqualValues(t, 3, a.ScheduledRounds)
+	assert.EqualValues(t, 2, a.NotObservedRounds)
+	assert.Equal(t, []ctypes.ProposerRound{{Height: 2, Round: 0}, {Height: 3, Round: 0}}, a.NotObserved)
+	assert.EqualValues(t, addrB, b.Address)
+	assert.EqualValues(t, 3, b.ScheduledRounds)
+	assert.Zero(t, b.NotObservedRounds)
+
+	res, err = ProposerMisses(&rpctypes.Context{}, addrB, 2, 5)
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, res.RecordedHeights)
+	require.Len(t, res.Validators, 1)
+	assert.EqualValues(t, addrB, res.Validators[0].Address)
+	assert.EqualValues(t, 2, res.Validators[0].ScheduledRounds)
+
+	_, err = ProposerMisses(&rpctypes.Context{}, nil, 11, 12)
+	assert.Error(t, err)
+}
+
+// TestValidatorsWhileCommitting reads /validators while blocks commit, changing
+// the validator set and its priorities, and checks that every read returns the
+// validator set of the height as it was committed, run with -race to detect
+// validator sets shared with the committing goroutine.
+func TestValidatorsWhileCommitting(t *testing.T) {
+	const heights = 200
+	env := &Environment{}
+	env.StateStore = sm.NewStore(dbm.NewMemDB(), sm.StoreOptions{})
+	env.BlockStore = mockBlockStore{height: heights + 2}
+	env.ConsensusReactor = &cm.Reactor{}
+	SetEnvironment(env)
+
+	vals, _ := types.RandValidatorSet(4, 10)
+	genDoc := &types.GenesisDoc{ChainID: "test"}
+	for _, val := range vals.Validators {
+		genDoc.Validators = append(genDoc.Validators, types.GenesisValidator{PubKey: val.PubKey, Power: val.VotingPower})
+	}
+	state, err := sm.MakeGenesisState(genDoc)
+	require.NoError(t, err)
+	require.NoError(t, env.StateStore.Save(state))
+
+	var (
+		mtx       sync.Mutex
+		committed = map[int64]*types.ValidatorSet{1: state.Validators.DeepCopy(), 2: state.NextValidators.DeepCopy()}
+		latest    atomic.Int64
+		done      = make(chan struct{})
+	)
+	latest.Store(2)
+	go func() {
+		defer close(done)
+		for h := int64(1); h <= heights; h++ {
+			// like the block executor, update a copy of the next validators
+			nextVals := state.NextValidators.Copy()
+			if h%10 == 0 {
+				update := nextVals.Validators[int(h/10)%nextVals.Size()].Copy()
+				update.VotingPower++
+				if !assert.NoError(t, nextVals.UpdateWithChangeSet([]*types.Validator{update})) {
+					return
+				}
+				state.LastHeightValidatorsChanged = h + 2
+			}
+			nextVals.IncrementProposerPriority(1)
+			state.LastBlockHeight = h
+			state.LastValidators, state.Validators, state.NextValidators = state.Validators, state.NextValidators, nextVals
+			if !assert.NoError(t, env.StateStore.Save(state)) {
+				return
+			}
+
+			mtx.Lock()
+			committed[h+2] = nextVals.DeepCopy()
+			mtx.Unlock()
+			latest.Store(h + 2)
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				h := latest.Load()
+				res, err := Validators(&rpctypes.Context{}, &h, nil, nil)
+				if !assert.NoError(t, err) {
+					return
+				}
+				mtx.Lock()
+				want := committed[h]
+				mtx.Unlock()
+				got := &types.ValidatorSet{Validators: res.Validators, Proposer: want.Proposer}
+				if !assert.True(t, want.Equal(got), "validators of height %d", h) {
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	<-done
+}
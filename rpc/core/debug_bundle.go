@@ -0,0 +1,149 @@
+package core
+
+import (
+	"regexp"
+	"runtime"
+	"time"
+
+	cmtjson "github.com/tendermint/tendermint/libs/json"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+	"github.com/tendermint/tendermint/version"
+)
+
+// maxDebugBundleSize is the size cap of the JSON encoded debug bundle.
+const maxDebugBundleSize = 4 * 1024 * 1024
+
+var (
+	// secretLogValue matches the key=value pairs of the log lines whose key
+	// looks like it names a secret.
+	secretLogValue = regexp.MustCompile(
+		`(?i)\b([\w.-]*(?:secret|passw(?:or)?d|token|priv_?key|mnemonic|auth)[\w.-]*)=("(?:[^"\\]|\\.)*"|\S+)`)
+	// urlUserinfo matches the credentials of the URLs in the log lines.
+	urlUserinfo = regexp.MustCompile(`://[^/\s@:]+:[^/\s@]+@`)
+)
+
+// blockSyncStatus is implemented by the blockchain reactors reporting the
+// progress of their block pool.
+type blockSyncStatus interface {
+	PoolStatus() (height int64, numPending int32, numRequesters int, maxPeerHeight int64)
+}
+
+// DebugBundle returns the status, network info, consensus round state,
+// mempool summary and block sync progress of the node, along with its version
+// and most recent logs, in a single document to attach to bug reports.
+//
+// The sections are captured while holding the consensus lock, so that the node
+// commits no block meanwhile and they all share the same block height. Log
+// lines, then peers, are left out to keep the bundle under 4MB.
+func DebugBundle(ctx *rpctypes.Context) (*ctypes.ResultDebugBundle, error) {
+	env := GetEnvironment()
+
+	var bundle *ctypes.ResultDebugBundle
+	err := env.ConsensusState.WithRoundStateSimpleJSON(func(roundState []byte) error {
+		height := env.BlockStore.Height()
+
+		var err error
+		bundle, err = captureDebugBundle(ctx, env, roundState)
+		if err != nil {
+			return err
+		}
+		bundle.Height = height
+
+		// while syncing, the blocks are saved by the block sync reactor rather
+		// than committed by the consensus, and may keep coming meanwhile
+		bundle.Consistent = bundle.Status.SyncInfo.LatestBlockHeight == height &&
+			env.BlockStore.Height() == height
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	capDebugBundle(bundle, maxDebugBundleSize)
+	return bundle, nil
+}
+
+func captureDebugBundle(
+	ctx *rpctypes.Context,
+	env *Environment,
+	roundState []byte,
+) (*ctypes.ResultDebugBundle, error) {
+	status, err := Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+	netInfo, err := NetInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	mempool, err := NumUnconfirmedTxs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	bundle := &ctypes.ResultDebugBundle{
+		CapturedAt: time.Now(),
+		Version: ctypes.DebugVersionInfo{
+			Version:       version.TMCoreSemVer,
+			GitCommit:     version.TMGitCommitHash,
+			ABCIVersion:   version.ABCIVersion,
+			BlockProtocol: version.BlockProtocol,
+			P2PProtocol:   version.P2PProtocol,
+			GoVersion:     runtime.Version(),
+		},
+		Status:         status,
+		NetInfo:        netInfo,
+		ConsensusState: roundState,
+		Mempool:        mempool,
+	}
+	if bcR, ok := env.BlockSyncReactor.(blockSyncStatus); ok {
+		poolHeight, numPending, numRequesters, maxPeerHeight := bcR.PoolStatus()
+		bundle.BlockSync = &ctypes.BlockSyncInfo{
+			Syncing:       status.SyncInfo.CatchingUp,
+			PoolHeight:    poolHeight,
+			NumPending:    numPending,
+			NumRequesters: numRequesters,
+			MaxPeerHeight: maxPeerHeight,
+		}
+	}
+	if env.LogRing != nil {
+		lines := env.LogRing.Lines()
+		for i, line := range lines {
+			lines[i] = redactLogLine(line)
+		}
+		bundle.RecentLogs = lines
+	}
+	return bundle, nil
+}
+
+// redactLogLine hides the values of the keys looking like they name secrets,
+// and the credentials of the URLs, in a log line.
+func redactLogLine(line string) string {
+	line = secretLogValue.ReplaceAllString(line, "$1=[REDACTED]")
+	return urlUserinfo.ReplaceAllString(line, "://[REDACTED]@")
+}
+
+// capDebugBundle leaves out the oldest log lines, then the peers, until the
+// JSON encoded bundle fits in maxSize.
+func capDebugBundle(bundle *ctypes.ResultDebugBundle, maxSize int) {
+	for debugBundleSize(bundle) > maxSize {
+		switch {
+		case len(bundle.RecentLogs) > 0:
+			bundle.RecentLogs = bundle.RecentLogs[(len(bundle.RecentLogs)+1)/2:]
+		case bundle.NetInfo != nil && len(bundle.NetInfo.Peers) > 0:
+			bundle.NetInfo.Peers = bundle.NetInfo.Peers[:len(bundle.NetInfo.Peers)/2]
+		default:
+			return
+		}
+		bundle.Truncated = true
+	}
+}
+
+func debugBundleSize(bundle *ctypes.ResultDebugBundle) int {
+	bz, err := cmtjson.Marshal(bundle)
+	if err != nil {
+		return 0
+	}
+	return len(bz)
+}
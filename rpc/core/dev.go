@@ -1,6 +1,9 @@
 package core
 
 import (
+	"errors"
+	"fmt"
+
 	ctypes "github.com/tendermint/tendermint/rpc/core/types"
 	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
 )
@@ -10,3 +13,43 @@ func UnsafeFlushMempool(ctx *rpctypes.Context) (*ctypes.ResultUnsafeFlushMempool
 	GetEnvironment().Mempool.Flush()
 	return &ctypes.ResultUnsafeFlushMempool{}, nil
 }
+
+// UnsafeProposalDebug returns why the transactions of the mempool were left
+// out of the block proposed by the node at the given height. It requires
+// consensus.record_reap_decisions, and only the last heights are kept.
+func UnsafeProposalDebug(ctx *rpctypes.Context, height int64) (*ctypes.ResultProposalDebug, error) {
+	reapDecisions := GetEnvironment().ReapDecisions
+	if reapDecisions == nil {
+		return nil, errors.New("reap decisions are not recorded, see consensus.record_reap_decisions")
+	}
+	decision, ok := reapDecisions.Get(height)
+	if !ok {
+		return nil, fmt.Errorf("no block proposed by the node was recorded at height %d", height)
+	}
+	return &ctypes.ResultProposalDebug{
+		Height:      decision.Height,
+		MempoolSize: decision.MempoolSize,
+		Reaped:      decision.Reaped,
+		Proposed:    decision.Proposed,
+		Excluded:    decision.Excluded,
+	}, nil
+}
+
+// UnsafePauseAppUpgrade finishes the current height, then holds the
+// participation of the node in consensus until the application is restarted
+// with a higher app version, so that it can be upgraded without restarting
+// the node. The node resumes once it has handshaked with the upgraded
+// application, or after consensus.app_upgrade_timeout. The progress is
+// published as AppUpgrade events. It requires a socket connection to the
+// application.
+func UnsafePauseAppUpgrade(ctx *rpctypes.Context) (*ctypes.ResultPauseAppUpgrade, error) {
+	appUpgrader := GetEnvironment().AppUpgrader
+	if appUpgrader == nil {
+		return nil, errors.New("upgrading the application requires a socket connection to it")
+	}
+	height, appVersion, err := appUpgrader.Pause(ctx.Context())
+	if err != nil {
+		return nil, err
+	}
+	return &ctypes.ResultPauseAppUpgrade{Height: height, AppVersion: appVersion}, nil
+}
@@ -0,0 +1,36 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	mempl "github.com/tendermint/tendermint/mempool"
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+	sm "github.com/tendermint/tendermint/state"
+)
+
+func TestUnsafeProposalDebug(t *testing.T) {
+	SetEnvironment(&Environment{})
+	_, err := UnsafeProposalDebug(&rpctypes.Context{}, 1)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "record_reap_decisions")
+
+	excluded := make(mempl.Exclusions)
+	excluded.Add(mempl.ExclusionOverBytes, []byte("tx"))
+	reapDecisions := sm.NewReapDecisions(sm.DefaultReapDecisionsSize)
+	reapDecisions.Record(&sm.ReapDecision{Height: 2, MempoolSize: 3, Reaped: 2, Proposed: 2, Excluded: excluded})
+	SetEnvironment(&Environment{ReapDecisions: reapDecisions})
+
+	_, err = UnsafeProposalDebug(&rpctypes.Context{}, 1)
+	require.Error(t, err)
+
+	res, err := UnsafeProposalDebug(&rpctypes.Context{}, 2)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, res.Height)
+	assert.Equal(t, 3, res.MempoolSize)
+	assert.Equal(t, 2, res.Reaped)
+	assert.Equal(t, 2, res.Proposed)
+	assert.Equal(t, 1, res.Excluded[mempl.ExclusionOverBytes].Count)
+}
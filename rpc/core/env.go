@@ -1,11 +1,13 @@
 package core
 
 import (
+	"context"
 	"encoding/base64"
 	"fmt"
 	"sync"
 	"time"
 
+	abci "github.com/tendermint/tendermint/abci/types"
 	cfg "github.com/tendermint/tendermint/config"
 	"github.com/tendermint/tendermint/consensus"
 	"github.com/tendermint/tendermint/crypto"
@@ -17,6 +19,7 @@ import (
 	sm "github.com/tendermint/tendermint/state"
 	"github.com/tendermint/tendermint/state/indexer"
 	"github.com/tendermint/tendermint/state/txindex"
+	"github.com/tendermint/tendermint/statesync"
 	"github.com/tendermint/tendermint/types"
 )
 
@@ -25,6 +28,14 @@ const (
 	defaultPerPage = 30
 	maxPerPage     = 100
 
+	// maxShareRangesPerBatch is the maximum number of share ranges that can
+	// be proven in a single ProveSharesBatch request.
+	maxShareRangesPerBatch = 256
+
+	// maxNamespaceCompletenessProofs is the maximum number of share proofs
+	// that can be checked in a single VerifyNamespaceCompleteness request.
+	maxNamespaceCompletenessProofs = 256
+
 	// SubscribeTimeout is the maximum time we wait to subscribe for an event.
 	// must be less than the server's write timeout (see rpcserver.DefaultConfig)
 	SubscribeTimeout = 5 * time.Second
@@ -64,6 +75,7 @@ type Consensus interface {
 	GetLastHeight() int64
 	GetRoundStateJSON() ([]byte, error)
 	GetRoundStateSimpleJSON() ([]byte, error)
+	WithRoundStateSimpleJSON(fn func(roundState []byte) error) error
 }
 
 type transport interface {
@@ -72,6 +84,19 @@ type transport interface {
 	NodeInfo() p2p.NodeInfo
 }
 
+type snapshotManager interface {
+	Enabled() bool
+	Snapshots() []*abci.Snapshot
+}
+
+type stateSyncer interface {
+	Status() statesync.Status
+}
+
+type appUpgrader interface {
+	Pause(ctx context.Context) (height int64, appVersion uint64, err error)
+}
+
 type peers interface {
 	AddPersistentPeers([]string) error
 	AddUnconditionalPeerIDs([]string) error
@@ -101,9 +126,23 @@ type Environment struct {
 	GenDoc           *types.GenesisDoc // cache the genesis structure
 	TxIndexer        txindex.TxIndexer
 	BlockIndexer     indexer.BlockIndexer
+	IndexerService   *txindex.IndexerService // optional, see waitForIndexing
+	SnapshotManager  snapshotManager         // optional, see Snapshots
 	ConsensusReactor *consensus.Reactor
 	EventBus         *types.EventBus // thread safe
 	Mempool          mempl.Mempool
+	ReapDecisions    *sm.ReapDecisions      // optional, see UnsafeProposalDebug
+	AppRequirements  *mempl.AppRequirements // optional, see MempoolRequirements
+	AppUpgrader      appUpgrader            // optional, see UnsafePauseAppUpgrade
+	BlockSyncReactor p2p.Reactor            // optional, see DebugBundle
+	StateSyncReactor stateSyncer            // optional, see Status
+	LogRing          *log.RingWriter        // optional, see DebugBundle
+	Metrics          *Metrics               // optional, see Subscribe
+	TxAdmission      *TxAdmission           // optional, see BroadcastTxAsync
+
+	// ArchiveMode is whether the environment is that of an archive server, see
+	// NewArchiveEnvironment. It has no consensus reactor nor public key.
+	ArchiveMode bool
 
 	Logger log.Logger
 
@@ -212,8 +251,8 @@ func getHeight(latestHeight int64, heightPtr *int64) (int64, error) {
 
 func latestUncommittedHeight() int64 {
 	env := GetEnvironment()
-	nodeIsSyncing := env.ConsensusReactor.WaitSync()
-	if nodeIsSyncing {
+	// an archive server is always behind the network, like a syncing node
+	if env.ArchiveMode || env.ConsensusReactor.WaitSync() {
 		return env.BlockStore.Height()
 	}
 	return env.BlockStore.Height() + 1
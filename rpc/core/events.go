@@ -8,8 +8,10 @@ import (
 
 	cmtpubsub "github.com/tendermint/tendermint/libs/pubsub"
 	cmtquery "github.com/tendermint/tendermint/libs/pubsub/query"
+	cmtsync "github.com/tendermint/tendermint/libs/sync"
 	ctypes "github.com/tendermint/tendermint/rpc/core/types"
 	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+	"github.com/tendermint/tendermint/types"
 )
 
 const (
@@ -18,26 +20,45 @@ const (
 	maxQueryLength = 512
 )
 
-// Subscribe for events via WebSocket.
+// Subscribe for events via WebSocket. The payload selects the data delivered
+// for the events, see ctypes.EventPayloadHeader for delivering only the header
+// of new blocks.
 // More: https://docs.cometbft.com/v0.34/rpc/#/Websocket/subscribe
-func Subscribe(ctx *rpctypes.Context, query string) (*ctypes.ResultSubscribe, error) {
+func Subscribe(ctx *rpctypes.Context, query string, payload string) (*ctypes.ResultSubscribe, error) {
 	addr := ctx.RemoteAddr()
 	env := GetEnvironment()
 
+	if len(query) > maxQueryLength {
+		return nil, errors.New("maximum query length exceeded")
+	} else if err := ctypes.ValidateEventPayload(payload); err != nil {
+		return nil, err
+	}
+	if _, err := cmtquery.New(query); err != nil {
+		return nil, fmt.Errorf("failed to parse query: %w", err)
+	}
+	// queries only differing by spaces are subscribed to once per client, so
+	// that their events are matched and delivered once
+	q, err := cmtquery.New(cmtquery.Normalize(query))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse query: %w", err)
+	}
+
+	if id, ok := subscriptionIDs.get(addr, q.String()); ok {
+		if env.Config.ReuseDuplicateSubscriptions {
+			return &ctypes.ResultSubscribe{SubscriptionID: id}, nil
+		}
+		return nil, fmt.Errorf("already subscribed to query %q with subscription id %s", query, id)
+	}
+
 	if env.EventBus.NumClients() >= env.Config.MaxSubscriptionClients {
 		return nil, fmt.Errorf("max_subscription_clients %d reached", env.Config.MaxSubscriptionClients)
 	} else if env.EventBus.NumClientSubscriptions(addr) >= env.Config.MaxSubscriptionsPerClient {
 		return nil, fmt.Errorf("max_subscriptions_per_client %d reached", env.Config.MaxSubscriptionsPerClient)
-	} else if len(query) > maxQueryLength {
-		return nil, errors.New("maximum query length exceeded")
+	} else if env.EventBus.NumSubscriptions() >= env.Config.MaxSubscriptions {
+		return nil, fmt.Errorf("max_subscriptions %d reached", env.Config.MaxSubscriptions)
 	}
 
-	env.Logger.Info("Subscribe to query", "remote", addr, "query", query)
-
-	q, err := cmtquery.New(query)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse query: %w", err)
-	}
+	env.Logger.Info("Subscribe to query", "remote", addr, "query", query, "payload", payload)
 
 	subCtx, cancel := context.WithTimeout(ctx.Context(), SubscribeTimeout)
 	defer cancel()
@@ -48,16 +69,33 @@ func Subscribe(ctx *rpctypes.Context, query string) (*ctypes.ResultSubscribe, er
 	}
 
 	closeIfSlow := env.Config.CloseOnSlowClient
+	metrics := env.metrics()
+	// the subscription ends with the connection, even if the client vanished
+	// without unsubscribing or the connection died while subscribing
+	connCtx := ctx.Context()
 
 	// Capture the current ID, since it can change in the future.
 	subscriptionID := ctx.JSONReq.ID
+	subscriptionIDs.add(addr, q.String(), fmt.Sprintf("%v", subscriptionID), sub)
+	metrics.Subscriptions.Set(float64(subscriptionIDs.len()))
 	go func() {
+		defer func() {
+			subscriptionIDs.remove(addr, q.String(), sub)
+			metrics.Subscriptions.Set(float64(subscriptionIDs.len()))
+			if connCtx.Err() != nil {
+				metrics.ReapedSubscriptions.Add(1)
+			}
+		}()
 		for sub != nil {
 			select {
 			case msg := <-sub.Out():
 				var (
-					resultEvent = &ctypes.ResultEvent{Query: query, Data: msg.Data(), Events: msg.Events()}
-					resp        = rpctypes.NewRPCSuccessResponse(subscriptionID, resultEvent)
+					resultEvent = &ctypes.ResultEvent{
+						Query:  query,
+						Data:   ctypes.EventPayloadData(payload, msg.Data()),
+						Events: msg.Events(),
+					}
+					resp = rpctypes.NewRPCSuccessResponse(subscriptionID, resultEvent)
 				)
 				writeCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 				defer cancel()
@@ -74,6 +112,7 @@ func Subscribe(ctx *rpctypes.Context, query string) (*ctypes.ResultSubscribe, er
 							env.Logger.Info("Can't write response (slow client)",
 								"to", addr, "subscriptionID", subscriptionID, "err", err)
 						}
+						unsubscribeOwned(env, addr, sub)
 						return
 					}
 				}
@@ -95,11 +134,16 @@ func Subscribe(ctx *rpctypes.Context, query string) (*ctypes.ResultSubscribe, er
 					}
 				}
 				return
+			case <-connCtx.Done():
+				env.Logger.Info("Removing the subscription of a closed connection",
+					"to", addr, "subscriptionID", subscriptionID)
+				unsubscribeOwned(env, addr, sub)
+				return
 			}
 		}
 	}()
 
-	return &ctypes.ResultSubscribe{}, nil
+	return &ctypes.ResultSubscribe{SubscriptionID: fmt.Sprintf("%v", subscriptionID)}, nil
 }
 
 // Unsubscribe from events via WebSocket.
@@ -108,7 +152,10 @@ func Unsubscribe(ctx *rpctypes.Context, query string) (*ctypes.ResultUnsubscribe
 	addr := ctx.RemoteAddr()
 	env := GetEnvironment()
 	env.Logger.Info("Unsubscribe from query", "remote", addr, "query", query)
-	q, err := cmtquery.New(query)
+	if _, err := cmtquery.New(query); err != nil {
+		return nil, fmt.Errorf("failed to parse query: %w", err)
+	}
+	q, err := cmtquery.New(cmtquery.Normalize(query))
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse query: %w", err)
 	}
@@ -116,6 +163,8 @@ func Unsubscribe(ctx *rpctypes.Context, query string) (*ctypes.ResultUnsubscribe
 	if err != nil {
 		return nil, err
 	}
+	subscriptionIDs.remove(addr, q.String(), nil)
+	env.metrics().Subscriptions.Set(float64(subscriptionIDs.len()))
 	return &ctypes.ResultUnsubscribe{}, nil
 }
 
@@ -129,5 +178,94 @@ func UnsubscribeAll(ctx *rpctypes.Context) (*ctypes.ResultUnsubscribe, error) {
 	if err != nil {
 		return nil, err
 	}
+	subscriptionIDs.removeClient(addr)
+	env.metrics().Subscriptions.Set(float64(subscriptionIDs.len()))
 	return &ctypes.ResultUnsubscribe{}, nil
 }
+
+// unsubscribeOwned removes the subscription of the client from the event bus,
+// unless the client already unsubscribed or subscribed again to the query.
+func unsubscribeOwned(env *Environment, addr string, sub types.Subscription) {
+	err := env.EventBus.UnsubscribeOwned(context.Background(), addr, sub)
+	if err != nil && !errors.Is(err, cmtpubsub.ErrSubscriptionNotFound) {
+		env.Logger.Error("Failed to unsubscribe", "addr", addr, "err", err)
+	}
+}
+
+// subscriptionIDs holds the ID of the active subscriptions, see
+// subscriptionRegistry.
+var subscriptionIDs = newSubscriptionRegistry()
+
+// subscriptionRegistry is a thread-safe registry of the JSON-RPC ID of the
+// subscribe request of every active subscription, by client and normalized
+// query, used to tell a client subscribing twice to the same query which
+// subscription delivers its events.
+type subscriptionRegistry struct {
+	mtx           cmtsync.Mutex
+	subscriptions map[string]map[string]registeredSubscription // client -> query -> subscription
+}
+
+type registeredSubscription struct {
+	id  string
+	sub types.Subscription
+}
+
+func newSubscriptionRegistry() *subscriptionRegistry {
+	return &subscriptionRegistry{
+		subscriptions: make(map[string]map[string]registeredSubscription),
+	}
+}
+
+func (r *subscriptionRegistry) get(client, query string) (string, bool) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	s, ok := r.subscriptions[client][query]
+	return s.id, ok
+}
+
+func (r *subscriptionRegistry) add(client, query, id string, sub types.Subscription) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	if _, ok := r.subscriptions[client]; !ok {
+		r.subscriptions[client] = make(map[string]registeredSubscription)
+	}
+	r.subscriptions[client][query] = registeredSubscription{id: id, sub: sub}
+}
+
+// remove removes the subscription of the client to the query. If sub is not
+// nil, the subscription is only removed if it is still sub, since the client
+// may have subscribed again to the query in the meantime.
+func (r *subscriptionRegistry) remove(client, query string, sub types.Subscription) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	s, ok := r.subscriptions[client][query]
+	if !ok || (sub != nil && s.sub != sub) {
+		return
+	}
+	delete(r.subscriptions[client], query)
+	if len(r.subscriptions[client]) == 0 {
+		delete(r.subscriptions, client)
+	}
+}
+
+// len returns the number of active subscriptions of all the clients.
+func (r *subscriptionRegistry) len() int {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	n := 0
+	for _, clientSubscriptions := range r.subscriptions {
+		n += len(clientSubscriptions)
+	}
+	return n
+}
+
+func (r *subscriptionRegistry) removeClient(client string) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	delete(r.subscriptions, client)
+}
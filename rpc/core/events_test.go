@@ -0,0 +1,211 @@
+package core
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-kit/kit/metrics"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cfg "github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/libs/log"
+	cmtsync "github.com/tendermint/tendermint/libs/sync"
+	rpcserver "github.com/tendermint/tendermint/rpc/jsonrpc/server"
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestSubscribeDuplicateQueries(t *testing.T) {
+	eventBus := types.NewEventBus()
+	require.NoError(t, eventBus.Start())
+	t.Cleanup(func() {
+		if err := eventBus.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	config := cfg.DefaultRPCConfig()
+	config.MaxSubscriptions = 3
+	SetEnvironment(&Environment{EventBus: eventBus, Config: *config, Logger: log.TestingLogger()})
+	subscriptionIDs = newSubscriptionRegistry()
+
+	conn1 := &wsConn{addr: "1.2.3.4:1"}
+	conn2 := &wsConn{addr: "1.2.3.4:2"}
+	subscribe := func(conn *wsConn, id int, query string) (string, error) {
+		ctx := &rpctypes.Context{
+			JSONReq: &rpctypes.RPCRequest{ID: rpctypes.JSONRPCIntID(id)},
+			WSConn:  conn,
+		}
+		res, err := Subscribe(ctx, query, "")
+		if err != nil {
+			return "", err
+		}
+		return res.SubscriptionID, nil
+	}
+
+	id, err := subscribe(conn1, 1, "tm.event='NewBlock'")
+	require.NoError(t, err)
+	assert.Equal(t, "1", id)
+
+	// identical and near-duplicate queries are rejected
+	for _, query := range []string{"tm.event='NewBlock'", "tm.event = 'NewBlock'", "tm.event  ='NewBlock'"} {
+		_, err = subscribe(conn1, 2, query)
+		require.Error(t, err, query)
+		assert.Contains(t, err.Error(), "subscription id 1")
+	}
+	// a different query, or the same query on another connection, is not a
+	// duplicate
+	_, err = subscribe(conn1, 3, "tm.event='NewBlock' AND block.height>1")
+	require.NoError(t, err)
+	_, err = subscribe(conn2, 1, "tm.event='NewBlock'")
+	require.NoError(t, err)
+	assert.Equal(t, 3, eventBus.NumSubscriptions())
+
+	// the duplicates are returned the existing subscription if configured
+	config.ReuseDuplicateSubscriptions = true
+	SetEnvironment(&Environment{EventBus: eventBus, Config: *config, Logger: log.TestingLogger()})
+	id, err = subscribe(conn1, 4, "tm.event = 'NewBlock'")
+	require.NoError(t, err)
+	assert.Equal(t, "1", id)
+	assert.Equal(t, 3, eventBus.NumSubscriptions())
+
+	// the subscriptions of all the clients are capped
+	_, err = subscribe(conn2, 2, "tm.event='Tx'")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "max_subscriptions 3 reached")
+
+	// a query can be subscribed to again once unsubscribed, with either form
+	ctx := &rpctypes.Context{JSONReq: &rpctypes.RPCRequest{ID: rpctypes.JSONRPCIntID(5)}, WSConn: conn1}
+	_, err = Unsubscribe(ctx, "tm.event =  'NewBlock'")
+	require.NoError(t, err)
+	id, err = subscribe(conn1, 6, "tm.event='NewBlock'")
+	require.NoError(t, err)
+	assert.Equal(t, "6", id)
+
+	_, err = UnsubscribeAll(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, eventBus.NumSubscriptions())
+	id, err = subscribe(conn1, 7, "tm.event='NewBlock'")
+	require.NoError(t, err)
+	assert.Equal(t, "7", id)
+}
+
+func TestSubscriptionsOfDeadConnectionsAreReaped(t *testing.T) {
+	eventBus := types.NewEventBus()
+	require.NoError(t, eventBus.Start())
+	t.Cleanup(func() {
+		if err := eventBus.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+	go func() {
+		for eventBus.IsRunning() {
+			_ = eventBus.PublishEventNewBlockHeader(types.EventDataNewBlockHeader{})
+			time.Sleep(time.Millisecond)
+		}
+	}()
+
+	config := cfg.DefaultRPCConfig()
+	config.MaxSubscriptionClients = 10000
+	subscriptions, reaped := &testGauge{}, &testCounter{}
+	metrics := &Metrics{Subscriptions: subscriptions, ReapedSubscriptions: reaped}
+	SetEnvironment(&Environment{EventBus: eventBus, Config: *config, Logger: log.NewNopLogger(), Metrics: metrics})
+
+	// the clients which stop answering pings are dropped after 200ms
+	wm := rpcserver.NewWebsocketManager(map[string]*rpcserver.RPCFunc{"subscribe": Routes["subscribe"]},
+		rpcserver.OnDisconnect(func(remoteAddr string) {
+			_ = eventBus.UnsubscribeAll(context.Background(), remoteAddr)
+		}),
+		rpcserver.PingPeriod(50*time.Millisecond),
+		rpcserver.ReadWait(200*time.Millisecond),
+	)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/websocket", wm.WebsocketHandler)
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	subscribe := func(t *testing.T) *websocket.Conn {
+		c, resp, err := websocket.DefaultDialer.Dial("ws://"+server.Listener.Addr().String()+"/websocket", nil)
+		require.NoError(t, err)
+		resp.Body.Close()
+		req, err := rpctypes.MapToRequest(rpctypes.JSONRPCIntID(1), "subscribe",
+			map[string]interface{}{"query": "tm.event='NewBlockHeader'"})
+		require.NoError(t, err)
+		require.NoError(t, c.WriteJSON(req))
+		var res rpctypes.RPCResponse
+		require.NoError(t, c.ReadJSON(&res))
+		require.Nil(t, res.Error)
+		return c
+	}
+	assertReaped := func(t *testing.T, n float64) {
+		require.Eventually(t, func() bool {
+			return eventBus.NumSubscriptions() == 0 && subscriptionIDs.len() == 0 && reaped.Value() == n
+		}, 5*time.Second, 10*time.Millisecond)
+		assert.Zero(t, eventBus.NumClients())
+		assert.Zero(t, subscriptions.Value())
+	}
+
+	// the connections are killed without a close frame
+	const cycles = 1000
+	for i := 0; i < cycles; i++ {
+		c := subscribe(t)
+		require.NoError(t, c.UnderlyingConn().Close())
+	}
+	assertReaped(t, cycles)
+
+	// the connection stays open but the client stops reading, so it does not
+	// answer the pings anymore
+	c := subscribe(t)
+	defer c.Close()
+	assertReaped(t, cycles+1)
+}
+
+// testMetric records the value of a gauge or counter.
+type testMetric struct {
+	mtx   cmtsync.Mutex
+	value float64
+}
+
+func (m *testMetric) Set(value float64) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	m.value = value
+}
+
+func (m *testMetric) Add(delta float64) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	m.value += delta
+}
+
+func (m *testMetric) Value() float64 {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	return m.value
+}
+
+type testGauge struct{ testMetric }
+
+func (g *testGauge) With(...string) metrics.Gauge { return g }
+
+type testCounter struct{ testMetric }
+
+func (c *testCounter) With(...string) metrics.Counter { return c }
+
+// wsConn is a websocket connection discarding the responses.
+type wsConn struct {
+	addr string
+}
+
+func (c *wsConn) GetRemoteAddr() string { return c.addr }
+
+func (c *wsConn) WriteRPCResponse(context.Context, rpctypes.RPCResponse) error { return nil }
+
+func (c *wsConn) TryWriteRPCResponse(rpctypes.RPCResponse) bool { return true }
+
+func (c *wsConn) Context() context.Context { return context.Background() }
@@ -1,13 +1,36 @@
 package core
 
 import (
+	"errors"
+	"fmt"
+
 	ctypes "github.com/tendermint/tendermint/rpc/core/types"
 	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+	"github.com/tendermint/tendermint/statesync"
 )
 
 // Health gets node health. Returns empty result (200 OK) on success, no
 // response - in case of an error.
 // More: https://docs.cometbft.com/v0.34/rpc/#/Info/health
 func Health(ctx *rpctypes.Context) (*ctypes.ResultHealth, error) {
+	if err := CheckHealth(); err != nil {
+		return nil, err
+	}
 	return &ctypes.ResultHealth{}, nil
 }
+
+// CheckHealth returns an error if a component of the node is unhealthy. It backs
+// both the /health endpoint and the health service of the gRPC server.
+func CheckHealth() error {
+	env := GetEnvironment()
+	if env == nil {
+		return errors.New("the node is not started")
+	}
+	if env.StateSyncReactor != nil {
+		if status := env.StateSyncReactor.Status(); status.State == statesync.SyncStateHalted {
+			return fmt.Errorf("state sync failed and the node could not fall back to fast sync, "+
+				"reset it and restart: %s", status.Error)
+		}
+	}
+	return nil
+}
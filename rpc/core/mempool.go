@@ -13,29 +13,51 @@ import (
 	"github.com/tendermint/tendermint/types"
 )
 
-var ErrTimedOutWaitingForTx = errors.New("timed out waiting for tx to be included in a block")
+var (
+	ErrTimedOutWaitingForTx = errors.New("timed out waiting for tx to be included in a block")
+	ErrNoCheckTxResponse    = errors.New("no CheckTx response, the request to the application failed")
+)
 
 //-----------------------------------------------------------------------------
 // NOTE: tx should be signed, but this is only checked at the app level (not by CometBFT!)
+//
+// The hash returned by the broadcast endpoints is the hash the transaction will
+// be indexed under once committed (see types.Tx.Hash), even if the transaction
+// is wrapped before being included in a block.
 
 // BroadcastTxAsync returns right away, with no response. Does not wait for
-// CheckTx nor DeliverTx results.
+// CheckTx nor DeliverTx results. It fails with a server overloaded error if
+// too many transactions are pending, see rpc.max_pending_broadcast_txs.
 // More: https://docs.cometbft.com/v0.34/rpc/#/Tx/broadcast_tx_async
 func BroadcastTxAsync(ctx *rpctypes.Context, tx types.Tx) (*ctypes.ResultBroadcastTx, error) {
-	err := GetEnvironment().Mempool.CheckTx(tx, nil, mempl.TxInfo{})
-
+	env := GetEnvironment()
+	release, err := env.admitTx(ctx.Context(), false)
 	if err != nil {
 		return nil, err
 	}
+	err = env.Mempool.CheckTx(tx, func(*abci.Response) { release() }, mempl.TxInfo{})
+	if err != nil {
+		release()
+		return nil, err
+	}
 	return &ctypes.ResultBroadcastTx{Hash: tx.Hash()}, nil
 }
 
 // BroadcastTxSync returns with the response from CheckTx. Does not wait for
-// DeliverTx result.
+// DeliverTx result. If too many transactions are pending, it waits up to
+// rpc.broadcast_tx_admission_wait before failing with a server overloaded
+// error.
 // More: https://docs.cometbft.com/v0.34/rpc/#/Tx/broadcast_tx_sync
 func BroadcastTxSync(ctx *rpctypes.Context, tx types.Tx) (*ctypes.ResultBroadcastTx, error) {
+	env := GetEnvironment()
+	release, err := env.admitTx(ctx.Context(), true)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
 	resCh := make(chan *abci.Response, 1)
-	err := GetEnvironment().Mempool.CheckTx(tx, func(res *abci.Response) {
+	err = env.Mempool.CheckTx(tx, func(res *abci.Response) {
+		release()
 		select {
 		case <-ctx.Context().Done():
 		case resCh <- res:
@@ -50,6 +72,9 @@ func BroadcastTxSync(ctx *rpctypes.Context, tx types.Tx) (*ctypes.ResultBroadcas
 	case <-ctx.Context().Done():
 		return nil, fmt.Errorf("broadcast confirmation not received: %w", ctx.Context().Err())
 	case res := <-resCh:
+		if res == nil {
+			return nil, ErrNoCheckTxResponse
+		}
 		r := res.GetCheckTx()
 		return &ctypes.ResultBroadcastTx{
 			Code:      r.Code,
@@ -72,6 +97,8 @@ func BroadcastTxCommit(ctx *rpctypes.Context, tx types.Tx) (*ctypes.ResultBroadc
 		return nil, fmt.Errorf("max_subscription_clients %d reached", env.Config.MaxSubscriptionClients)
 	} else if env.EventBus.NumClientSubscriptions(subscriber) >= env.Config.MaxSubscriptionsPerClient {
 		return nil, fmt.Errorf("max_subscriptions_per_client %d reached", env.Config.MaxSubscriptionsPerClient)
+	} else if env.EventBus.NumSubscriptions() >= env.Config.MaxSubscriptions {
+		return nil, fmt.Errorf("max_subscriptions %d reached", env.Config.MaxSubscriptions)
 	}
 
 	// Subscribe to tx being committed in block.
@@ -91,8 +118,14 @@ func BroadcastTxCommit(ctx *rpctypes.Context, tx types.Tx) (*ctypes.ResultBroadc
 	}()
 
 	// Broadcast tx and wait for CheckTx result
+	release, err := env.admitTx(ctx.Context(), true)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
 	checkTxResCh := make(chan *abci.Response, 1)
 	err = env.Mempool.CheckTx(tx, func(res *abci.Response) {
+		release()
 		select {
 		case <-ctx.Context().Done():
 		case checkTxResCh <- res:
@@ -106,6 +139,10 @@ func BroadcastTxCommit(ctx *rpctypes.Context, tx types.Tx) (*ctypes.ResultBroadc
 	case <-ctx.Context().Done():
 		return nil, fmt.Errorf("broadcast confirmation not received: %w", ctx.Context().Err())
 	case checkTxResMsg := <-checkTxResCh:
+		if checkTxResMsg == nil {
+			env.Logger.Error("Error on broadcastTxCommit", "err", ErrNoCheckTxResponse)
+			return nil, ErrNoCheckTxResponse
+		}
 		checkTxRes := checkTxResMsg.GetCheckTx()
 		if checkTxRes.Code != abci.CodeTypeOK {
 			return &ctypes.ResultBroadcastTxCommit{
@@ -177,6 +214,44 @@ func NumUnconfirmedTxs(ctx *rpctypes.Context) (*ctypes.ResultUnconfirmedTxs, err
 		TotalBytes: env.Mempool.SizeBytes()}, nil
 }
 
+// MempoolSnapshot returns the hashes of the unconfirmed transactions with the
+// sequence of the last MempoolTx event of the mempool. Clients subscribed to
+// tm.event='MempoolTx' apply the events with a greater sequence to the
+// snapshot to mirror the mempool, and detect missed events from gaps in the
+// sequence.
+func MempoolSnapshot(ctx *rpctypes.Context) (*ctypes.ResultMempoolSnapshot, error) {
+	snapshotter, ok := GetEnvironment().Mempool.(mempl.Snapshotter)
+	if !ok {
+		return nil, errors.New("the mempool does not support snapshots")
+	}
+	snapshot := snapshotter.Snapshot()
+	return &ctypes.ResultMempoolSnapshot{
+		Sequence: snapshot.Sequence,
+		Hashes:   snapshot.Hashes,
+	}, nil
+}
+
+// MempoolRequirements returns the requirements the application reported, after
+// the last block, to admit a transaction in its mempool, e.g. the minimum gas
+// price, and whether the mempool of the node enforces them before CheckTx.
+func MempoolRequirements(ctx *rpctypes.Context) (*ctypes.ResultMempoolRequirements, error) {
+	appRequirements := GetEnvironment().AppRequirements
+	if appRequirements == nil {
+		return nil, errors.New("the node does not track the mempool requirements of the application")
+	}
+	return mempoolRequirements(appRequirements), nil
+}
+
+func mempoolRequirements(appRequirements *mempl.AppRequirements) *ctypes.ResultMempoolRequirements {
+	requirements, height := appRequirements.Requirements()
+	return &ctypes.ResultMempoolRequirements{
+		Height:      height,
+		MinGasPrice: requirements.GetMinGasPrice(),
+		MaxTxBytes:  requirements.GetMaxTxBytes(),
+		Enforced:    appRequirements.Enforced(),
+	}
+}
+
 // CheckTx checks the transaction without executing it. The transaction won't
 // be added to the mempool either.
 // More: https://docs.cometbft.com/v0.34/rpc/#/Tx/check_tx
@@ -0,0 +1,53 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	mempl "github.com/tendermint/tendermint/mempool"
+	"github.com/tendermint/tendermint/proxy"
+	proxymocks "github.com/tendermint/tendermint/proxy/mocks"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+)
+
+func TestMempoolRequirements(t *testing.T) {
+	SetEnvironment(&Environment{})
+	_, err := MempoolRequirements(&rpctypes.Context{})
+	require.Error(t, err)
+
+	query := &proxymocks.AppConnQuery{}
+	query.On("InfoSync", proxy.RequestInfo).Return(&abci.ResponseInfo{
+		LastBlockHeight:     4,
+		MempoolRequirements: &abci.MempoolRequirements{MinGasPrice: "0.002utia", MaxTxBytes: 1024},
+	}, nil).Once()
+	query.On("InfoSync", proxy.RequestInfo).Return(&abci.ResponseInfo{
+		LastBlockHeight:     5,
+		MempoolRequirements: &abci.MempoolRequirements{MinGasPrice: "0.004utia"},
+	}, nil).Once()
+	appRequirements := mempl.NewAppRequirements(query, true)
+	SetEnvironment(&Environment{AppRequirements: appRequirements})
+
+	require.NoError(t, appRequirements.Refresh())
+	res, err := MempoolRequirements(&rpctypes.Context{})
+	require.NoError(t, err)
+	assert.Equal(t, &ctypes.ResultMempoolRequirements{
+		Height:      4,
+		MinGasPrice: "0.002utia",
+		MaxTxBytes:  1024,
+		Enforced:    true,
+	}, res)
+
+	// the app changes its requirements, served once refreshed after a block
+	require.NoError(t, appRequirements.Refresh())
+	res, err = MempoolRequirements(&rpctypes.Context{})
+	require.NoError(t, err)
+	assert.Equal(t, &ctypes.ResultMempoolRequirements{
+		Height:      5,
+		MinGasPrice: "0.004utia",
+		Enforced:    true,
+	}, res)
+}
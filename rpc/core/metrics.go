@@ -0,0 +1,103 @@
+package core
+
+import (
+	"github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/discard"
+	"github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// MetricsSubsystem is a subsystem shared by all metrics exposed by this
+	// package.
+	MetricsSubsystem = "rpc"
+)
+
+// Metrics contains metrics exposed by this package.
+type Metrics struct {
+	// Number of active event subscriptions of the WebSocket clients.
+	Subscriptions metrics.Gauge
+	// Number of event subscriptions removed because their WebSocket connection
+	// died.
+	ReapedSubscriptions metrics.Counter
+	// Number of transactions submitted by the broadcast routes whose CheckTx
+	// is pending.
+	PendingBroadcastTxs metrics.Gauge
+	// Number of transactions rejected by the broadcast routes because too many
+	// were pending.
+	OverloadedBroadcastTxs metrics.Counter
+	// Number of calls authorized by an API key, by key id and method class.
+	APIKeyCalls metrics.Counter
+	// Number of calls rejected because the caller presented no API key
+	// granting them, by method class.
+	UnauthorizedCalls metrics.Counter
+}
+
+// PrometheusMetrics returns Metrics build using Prometheus client library.
+// Optionally, labels can be provided along with their values ("foo",
+// "fooValue").
+func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
+	labels := []string{}
+	for i := 0; i < len(labelsAndValues); i += 2 {
+		labels = append(labels, labelsAndValues[i])
+	}
+	return &Metrics{
+		Subscriptions: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "subscriptions",
+			Help:      "Number of active event subscriptions of the WebSocket clients.",
+		}, labels).With(labelsAndValues...),
+		ReapedSubscriptions: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "reaped_subscriptions",
+			Help:      "Number of event subscriptions removed because their WebSocket connection died.",
+		}, labels).With(labelsAndValues...),
+		PendingBroadcastTxs: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "pending_broadcast_txs",
+			Help:      "Number of transactions submitted by the broadcast routes whose CheckTx is pending.",
+		}, labels).With(labelsAndValues...),
+		OverloadedBroadcastTxs: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "overloaded_broadcast_txs",
+			Help:      "Number of transactions rejected by the broadcast routes because too many were pending.",
+		}, labels).With(labelsAndValues...),
+		APIKeyCalls: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "api_key_calls",
+			Help:      "Number of calls authorized by an API key, by key id and method class.",
+		}, append(labels, "key_id", "class")).With(labelsAndValues...),
+		UnauthorizedCalls: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "unauthorized_calls",
+			Help:      "Number of calls rejected because the caller presented no API key granting them, by method class.",
+		}, append(labels, "class")).With(labelsAndValues...),
+	}
+}
+
+// NopMetrics returns no-op Metrics.
+func NopMetrics() *Metrics {
+	return &Metrics{
+		Subscriptions:          discard.NewGauge(),
+		ReapedSubscriptions:    discard.NewCounter(),
+		PendingBroadcastTxs:    discard.NewGauge(),
+		OverloadedBroadcastTxs: discard.NewCounter(),
+		APIKeyCalls:            discard.NewCounter(),
+		UnauthorizedCalls:      discard.NewCounter(),
+	}
+}
+
+// metrics returns the metrics of the environment, or no-op metrics if none
+// are set.
+func (env *Environment) metrics() *Metrics {
+	if env.Metrics == nil {
+		return NopMetrics()
+	}
+	return env.Metrics
+}
@@ -28,14 +28,20 @@ func NetInfo(ctx *rpctypes.Context) (*ctypes.ResultNetInfo, error) {
 			RemoteIP:         peer.RemoteIP().String(),
 		})
 	}
+	var targets *p2p.PeerTargets
+	if pt, ok := env.P2PPeers.(interface{ PeerTargets() p2p.PeerTargets }); ok {
+		t := pt.PeerTargets()
+		targets = &t
+	}
 	// TODO: Should we include PersistentPeers and Seeds in here?
 	// PRO: useful info
 	// CON: privacy
 	return &ctypes.ResultNetInfo{
-		Listening: env.P2PTransport.IsListening(),
-		Listeners: env.P2PTransport.Listeners(),
-		NPeers:    len(peers),
-		Peers:     peers,
+		Listening:   env.P2PTransport.IsListening(),
+		Listeners:   env.P2PTransport.Listeners(),
+		NPeers:      len(peers),
+		Peers:       peers,
+		PeerTargets: targets,
 	}, nil
 }
 
@@ -0,0 +1,50 @@
+package core
+
+import (
+	"errors"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	mempl "github.com/tendermint/tendermint/mempool"
+	"github.com/tendermint/tendermint/proxy"
+	sm "github.com/tendermint/tendermint/state"
+	"github.com/tendermint/tendermint/types"
+)
+
+// ErrReadOnly is returned when broadcasting through the environment of a
+// read-only node.
+var ErrReadOnly = errors.New("the node is read-only")
+
+// SetReadOnly makes the environment unable to broadcast transactions or
+// evidence, and to check transactions against the application, for a node
+// whose RPC server is read-only. The server already rejects the calls of the
+// functions doing so, see rpc.ReadOnlyFuncs, this guards against the functions
+// reaching them through another path.
+func (env *Environment) SetReadOnly() {
+	env.ProxyAppMempool = readOnlyAppConnMempool{env.ProxyAppMempool}
+	env.Mempool = readOnlyMempool{env.Mempool}
+	env.EvidencePool = readOnlyEvidencePool{env.EvidencePool}
+}
+
+type readOnlyAppConnMempool struct {
+	proxy.AppConnMempool
+}
+
+func (readOnlyAppConnMempool) CheckTxSync(abci.RequestCheckTx) (*abci.ResponseCheckTx, error) {
+	return nil, ErrReadOnly
+}
+
+type readOnlyMempool struct {
+	mempl.Mempool
+}
+
+func (readOnlyMempool) CheckTx(types.Tx, func(*abci.Response), mempl.TxInfo) error {
+	return ErrReadOnly
+}
+
+type readOnlyEvidencePool struct {
+	sm.EvidencePool
+}
+
+func (readOnlyEvidencePool) AddEvidence(types.Evidence) error {
+	return ErrReadOnly
+}
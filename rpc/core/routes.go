@@ -4,62 +4,79 @@ import (
 	rpc "github.com/tendermint/tendermint/rpc/jsonrpc/server"
 )
 
-// TODO: better system than "unsafe" prefix
-
-// Routes is a map of available routes.
+// Routes is a map of available routes. Each route declares its access, i.e.
+// whether it only reads the state of the node, mutates it or is unsafe, so that
+// a read-only node rejects all but the read-only routes.
 var Routes = map[string]*rpc.RPCFunc{
 	// subscribe/unsubscribe are reserved for websocket events.
-	"subscribe":       rpc.NewWSRPCFunc(Subscribe, "query"),
-	"unsubscribe":     rpc.NewWSRPCFunc(Unsubscribe, "query"),
-	"unsubscribe_all": rpc.NewWSRPCFunc(UnsubscribeAll, ""),
+	"subscribe":       rpc.NewWSRPCFunc(Subscribe, "query,payload", rpc.ReadOnly()),
+	"unsubscribe":     rpc.NewWSRPCFunc(Unsubscribe, "query", rpc.ReadOnly()),
+	"unsubscribe_all": rpc.NewWSRPCFunc(UnsubscribeAll, "", rpc.ReadOnly()),
 
 	// info API
-	"health":                    rpc.NewRPCFunc(Health, ""),
-	"status":                    rpc.NewRPCFunc(Status, ""),
-	"net_info":                  rpc.NewRPCFunc(NetInfo, ""),
-	"blockchain":                rpc.NewRPCFunc(BlockchainInfo, "minHeight,maxHeight", rpc.Cacheable()),
-	"genesis":                   rpc.NewRPCFunc(Genesis, "", rpc.Cacheable()),
-	"genesis_chunked":           rpc.NewRPCFunc(GenesisChunked, "chunk", rpc.Cacheable()),
-	"block":                     rpc.NewRPCFunc(Block, "height", rpc.Cacheable("height")),
-	"signed_block":              rpc.NewRPCFunc(SignedBlock, "height", rpc.Cacheable("height")),
-	"block_by_hash":             rpc.NewRPCFunc(BlockByHash, "hash", rpc.Cacheable()),
-	"block_results":             rpc.NewRPCFunc(BlockResults, "height", rpc.Cacheable("height")),
-	"commit":                    rpc.NewRPCFunc(Commit, "height", rpc.Cacheable("height")),
-	"header":                    rpc.NewRPCFunc(Header, "height", rpc.Cacheable("height")),
-	"header_by_hash":            rpc.NewRPCFunc(HeaderByHash, "hash"),
-	"data_commitment":           rpc.NewRPCFunc(DataCommitment, "start,end"),
-	"check_tx":                  rpc.NewRPCFunc(CheckTx, "tx"),
-	"tx":                        rpc.NewRPCFunc(Tx, "hash,prove", rpc.Cacheable()),
-	"prove_shares":              rpc.NewRPCFunc(ProveShares, "height,startShare,endShare"),
-	"prove_shares_v2":           rpc.NewRPCFunc(ProveSharesV2, "height,startShare,endShare"),
-	"data_root_inclusion_proof": rpc.NewRPCFunc(DataRootInclusionProof, "height,start,end"),
-	"tx_search":                 rpc.NewRPCFunc(TxSearchMatchEvents, "query,prove,page,per_page,order_by,match_events"),
-	"block_search":              rpc.NewRPCFunc(BlockSearchMatchEvents, "query,page,per_page,order_by,match_events"),
-	"validators":                rpc.NewRPCFunc(Validators, "height,page,per_page", rpc.Cacheable("height")),
-	"dump_consensus_state":      rpc.NewRPCFunc(DumpConsensusState, ""),
-	"consensus_state":           rpc.NewRPCFunc(ConsensusState, ""),
-	"consensus_params":          rpc.NewRPCFunc(ConsensusParams, "height", rpc.Cacheable("height")),
-	"unconfirmed_txs":           rpc.NewRPCFunc(UnconfirmedTxs, "limit"),
-	"num_unconfirmed_txs":       rpc.NewRPCFunc(NumUnconfirmedTxs, ""),
-	"tx_status":                 rpc.NewRPCFunc(TxStatus, "hash"),
+	"health":                    rpc.NewRPCFunc(Health, "", rpc.ReadOnly()),
+	"status":                    rpc.NewRPCFunc(Status, "", rpc.ReadOnly()),
+	"net_info":                  rpc.NewRPCFunc(NetInfo, "", rpc.ReadOnly()),
+	"blockchain":                rpc.NewRPCFunc(BlockchainInfo, "minHeight,maxHeight", rpc.Cacheable(), rpc.ReadOnly()),
+	"genesis":                   rpc.NewRPCFunc(Genesis, "", rpc.Cacheable(), rpc.ReadOnly()),
+	"genesis_chunked":           rpc.NewRPCFunc(GenesisChunked, "chunk", rpc.Cacheable(), rpc.ReadOnly()),
+	"block":                     rpc.NewRPCFunc(Block, "height", rpc.Cacheable("height"), rpc.ReadOnly()),
+	"block_meta":                rpc.NewRPCFunc(BlockMeta, "height", rpc.ReadOnly()),
+	"signed_block":              rpc.NewRPCFunc(SignedBlock, "height", rpc.Cacheable("height"), rpc.ReadOnly()),
+	"block_by_hash":             rpc.NewRPCFunc(BlockByHash, "hash", rpc.Cacheable(), rpc.ReadOnly()),
+	"block_results":             rpc.NewRPCFunc(BlockResults, "height", rpc.Cacheable("height"), rpc.ReadOnly()),
+	"commit":                    rpc.NewRPCFunc(Commit, "height", rpc.Cacheable("height"), rpc.ReadOnly()),
+	"header":                    rpc.NewRPCFunc(Header, "height", rpc.Cacheable("height"), rpc.ReadOnly()),
+	"header_by_hash":            rpc.NewRPCFunc(HeaderByHash, "hash", rpc.ReadOnly()),
+	"data_commitment":           rpc.NewRPCFunc(DataCommitment, "start,end", rpc.ReadOnly()),
+	"check_tx":                  rpc.NewRPCFunc(CheckTx, "tx", rpc.Mutating()),
+	"tx":                        rpc.NewRPCFunc(Tx, "hash,prove", rpc.Cacheable(), rpc.ReadOnly()),
+	"prove_shares":              rpc.NewRPCFunc(ProveShares, "height,startShare,endShare", rpc.ReadOnly()),
+	"prove_shares_v2":           rpc.NewRPCFunc(ProveSharesV2, "height,startShare,endShare", rpc.ReadOnly()),
+	"prove_shares_batch":        rpc.NewRPCFunc(ProveSharesBatch, "height,ranges", rpc.ReadOnly()),
+	"prove_shares_paged":        rpc.NewRPCFunc(ProveSharesPaged, "height,startShare,endShare,page,per_page", rpc.ReadOnly()),
+	"row_namespace_ranges":      rpc.NewRPCFunc(RowNamespaceRanges, "height", rpc.Cacheable("height"), rpc.ReadOnly()),
+	"prove_tx_shares":           rpc.NewRPCFunc(ProveTxShares, "height,hash,include_blob", rpc.Cacheable(), rpc.ReadOnly()),
+	"tx_bundle":                 rpc.NewRPCFunc(TxBundle, "hash", rpc.ReadOnly()),
+	"prove_tx_absence":          rpc.NewRPCFunc(ProveTxAbsence, "height,hash", rpc.Cacheable(), rpc.ReadOnly()),
+	"namespace_proof_size":      rpc.NewRPCFunc(EstimateNamespaceProofSize, "height,namespace", rpc.Cacheable(), rpc.ReadOnly()),
+	"namespace_completeness":    rpc.NewRPCFunc(VerifyNamespaceCompleteness, "height,namespace_id,proofs", rpc.ReadOnly()),
+	"data_root_inclusion_proof": rpc.NewRPCFunc(DataRootInclusionProof, "height,start,end", rpc.ReadOnly()),
+	"tx_search":                 rpc.NewRPCFunc(TxSearchMatchEvents, "query,prove,page,per_page,order_by,match_events,group_by_height", rpc.ReadOnly()),
+	"block_search":              rpc.NewRPCFunc(BlockSearchMatchEvents, "query,page,per_page,order_by,match_events", rpc.ReadOnly()),
+	"validators":                rpc.NewRPCFunc(Validators, "height,page,per_page", rpc.Cacheable("height"), rpc.ReadOnly()),
+	"dump_consensus_state":      rpc.NewRPCFunc(DumpConsensusState, "", rpc.ReadOnly()),
+	"consensus_state":           rpc.NewRPCFunc(ConsensusState, "", rpc.ReadOnly()),
+	"consensus_params":          rpc.NewRPCFunc(ConsensusParams, "height", rpc.Cacheable("height"), rpc.ReadOnly()),
+	"proposer_misses":           rpc.NewRPCFunc(ProposerMisses, "address,from,to", rpc.ReadOnly()),
+	"unconfirmed_txs":           rpc.NewRPCFunc(UnconfirmedTxs, "limit", rpc.ReadOnly()),
+	"num_unconfirmed_txs":       rpc.NewRPCFunc(NumUnconfirmedTxs, "", rpc.ReadOnly()),
+	"mempool_snapshot":          rpc.NewRPCFunc(MempoolSnapshot, "", rpc.ReadOnly()),
+	"mempool_requirements":      rpc.NewRPCFunc(MempoolRequirements, "", rpc.ReadOnly()),
+	"tx_status":                 rpc.NewRPCFunc(TxStatus, "hash", rpc.ReadOnly()),
+	"tx_index_status":           rpc.NewRPCFunc(TxIndexStatus, "", rpc.ReadOnly()),
+	"debug_bundle":              rpc.NewRPCFunc(DebugBundle, "", rpc.ReadOnly()),
 
 	// tx broadcast API
-	"broadcast_tx_commit": rpc.NewRPCFunc(BroadcastTxCommit, "tx"),
-	"broadcast_tx_sync":   rpc.NewRPCFunc(BroadcastTxSync, "tx"),
-	"broadcast_tx_async":  rpc.NewRPCFunc(BroadcastTxAsync, "tx"),
+	"broadcast_tx_commit": rpc.NewRPCFunc(BroadcastTxCommit, "tx", rpc.Mutating()),
+	"broadcast_tx_sync":   rpc.NewRPCFunc(BroadcastTxSync, "tx", rpc.Mutating()),
+	"broadcast_tx_async":  rpc.NewRPCFunc(BroadcastTxAsync, "tx", rpc.Mutating()),
 
 	// abci API
-	"abci_query": rpc.NewRPCFunc(ABCIQuery, "path,data,height,prove"),
-	"abci_info":  rpc.NewRPCFunc(ABCIInfo, "", rpc.Cacheable()),
+	"abci_query": rpc.NewRPCFunc(ABCIQuery, "path,data,height,prove", rpc.ReadOnly()),
+	"abci_info":  rpc.NewRPCFunc(ABCIInfo, "", rpc.Cacheable(), rpc.ReadOnly()),
+	"snapshots":  rpc.NewRPCFunc(Snapshots, "", rpc.ReadOnly()),
 
 	// evidence API
-	"broadcast_evidence": rpc.NewRPCFunc(BroadcastEvidence, "evidence"),
+	"broadcast_evidence": rpc.NewRPCFunc(BroadcastEvidence, "evidence", rpc.Mutating()),
 }
 
 // AddUnsafeRoutes adds unsafe routes.
 func AddUnsafeRoutes() {
 	// control API
-	Routes["dial_seeds"] = rpc.NewRPCFunc(UnsafeDialSeeds, "seeds")
-	Routes["dial_peers"] = rpc.NewRPCFunc(UnsafeDialPeers, "peers,persistent,unconditional,private")
-	Routes["unsafe_flush_mempool"] = rpc.NewRPCFunc(UnsafeFlushMempool, "")
+	Routes["dial_seeds"] = rpc.NewRPCFunc(UnsafeDialSeeds, "seeds", rpc.Unsafe())
+	Routes["dial_peers"] = rpc.NewRPCFunc(UnsafeDialPeers, "peers,persistent,unconditional,private", rpc.Unsafe())
+	Routes["unsafe_flush_mempool"] = rpc.NewRPCFunc(UnsafeFlushMempool, "", rpc.Unsafe())
+	Routes["unsafe_proposal_debug"] = rpc.NewRPCFunc(UnsafeProposalDebug, "height", rpc.Unsafe())
+	Routes["unsafe_pause_app_upgrade"] = rpc.NewRPCFunc(UnsafePauseAppUpgrade, "", rpc.Unsafe())
 }
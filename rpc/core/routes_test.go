@@ -0,0 +1,106 @@
+package core
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/libs/log"
+	mempl "github.com/tendermint/tendermint/mempool"
+	"github.com/tendermint/tendermint/mempool/mock"
+	rpc "github.com/tendermint/tendermint/rpc/jsonrpc/server"
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+	sm "github.com/tendermint/tendermint/state"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestRoutesDeclareAccess(t *testing.T) {
+	AddUnsafeRoutes()
+	for name, f := range Routes {
+		assert.NotEqual(t, rpc.AccessUnspecified, f.Access(), "%s does not declare its access", name)
+	}
+	for _, name := range []string{"dial_seeds", "dial_peers", "unsafe_flush_mempool", "unsafe_proposal_debug",
+		"unsafe_pause_app_upgrade"} {
+		assert.Equal(t, rpc.AccessUnsafe, Routes[name].Access(), name)
+	}
+	for _, name := range []string{"broadcast_tx_commit", "broadcast_tx_sync", "broadcast_tx_async", "broadcast_evidence"} {
+		assert.Equal(t, rpc.AccessMutating, Routes[name].Access(), name)
+	}
+}
+
+func TestReadOnlyRoutes(t *testing.T) {
+	AddUnsafeRoutes()
+	mux := http.NewServeMux()
+	rpc.RegisterRPCFuncs(mux, rpc.ReadOnlyFuncs(Routes), log.TestingLogger())
+	s := httptest.NewServer(mux)
+	defer s.Close()
+
+	readRPCError := func(t *testing.T, res *http.Response) *rpctypes.RPCError {
+		t.Helper()
+		defer res.Body.Close()
+		body, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		var rpcRes rpctypes.RPCResponse
+		require.NoError(t, json.Unmarshal(body, &rpcRes))
+		return rpcRes.Error
+	}
+
+	rejected := 0
+	for name, f := range Routes {
+		if f.Access() == rpc.AccessReadOnly {
+			continue
+		}
+		rejected++
+		t.Run(name, func(t *testing.T) {
+			// over JSON-RPC
+			res, err := http.Post(s.URL, "application/json",
+				strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"`+name+`","params":{}}`))
+			require.NoError(t, err)
+			rpcErr := readRPCError(t, res)
+			require.NotNil(t, rpcErr)
+			assert.Equal(t, rpctypes.CodeReadOnly, rpcErr.Code)
+
+			// and over URI
+			res, err = http.Get(s.URL + "/" + name)
+			require.NoError(t, err)
+			assert.Equal(t, http.StatusForbidden, res.StatusCode)
+			rpcErr = readRPCError(t, res)
+			require.NotNil(t, rpcErr)
+			assert.Equal(t, rpctypes.CodeReadOnly, rpcErr.Code)
+		})
+	}
+	assert.Equal(t, 10, rejected)
+
+	// the read-only routes are still served
+	res, err := http.Get(s.URL + "/health")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Nil(t, readRPCError(t, res))
+}
+
+func TestReadOnlyEnvironment(t *testing.T) {
+	env := &Environment{
+		Mempool:      &mock.Mempool{},
+		EvidencePool: sm.EmptyEvidencePool{},
+	}
+	env.SetReadOnly()
+	SetEnvironment(env)
+
+	_, err := BroadcastTxAsync(&rpctypes.Context{}, types.Tx("tx"))
+	assert.ErrorIs(t, err, ErrReadOnly)
+	_, err = BroadcastTxSync(&rpctypes.Context{}, types.Tx("tx"))
+	assert.ErrorIs(t, err, ErrReadOnly)
+	_, err = CheckTx(&rpctypes.Context{}, types.Tx("tx"))
+	assert.ErrorIs(t, err, ErrReadOnly)
+	assert.ErrorIs(t, env.EvidencePool.AddEvidence(nil), ErrReadOnly)
+	assert.ErrorIs(t, env.Mempool.CheckTx(types.Tx("tx"), nil, mempl.TxInfo{}), ErrReadOnly)
+
+	// reading the mempool is still possible
+	assert.Zero(t, env.Mempool.Size())
+}
@@ -0,0 +1,95 @@
+package core
+
+import (
+	"container/list"
+	"fmt"
+
+	cmtsync "github.com/tendermint/tendermint/libs/sync"
+	"github.com/tendermint/tendermint/types"
+)
+
+// rowProofCacheSize is the number of data squares whose row proofs are kept
+// in memory by the RPC endpoints.
+const rowProofCacheSize = 100
+
+// rowProofs caches the row proofs of the data squares computed by the
+// application, see rowProofCache.
+var rowProofs = newRowProofCache(rowProofCacheSize)
+
+// rowProofCache is a thread-safe LRU cache of the proofs of all the rows of
+// data squares, keyed by the data hash of their block. The row roots are not
+// stored with the blocks, so getting them requires the application to
+// recompute the extended data square. Since a row proof is only added after
+// being verified against its data hash, the cache can never serve row roots
+// that do not match the requested data hash.
+//
+// Pruned blocks do not need to be removed: their data hash can no longer be
+// looked up, and their row proofs are eventually evicted.
+type rowProofCache struct {
+	mtx      cmtsync.Mutex
+	size     int
+	cacheMap map[string]*list.Element
+	list     *list.List
+}
+
+type rowProofCacheEntry struct {
+	dataHash string
+	rowProof types.RowProof
+}
+
+func newRowProofCache(size int) *rowProofCache {
+	return &rowProofCache{
+		size:     size,
+		cacheMap: make(map[string]*list.Element, size),
+		list:     list.New(),
+	}
+}
+
+// Get returns the row proof of the data square with the given data hash, if
+// it is cached.
+func (c *rowProofCache) Get(dataHash []byte) (types.RowProof, bool) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	e, ok := c.cacheMap[string(dataHash)]
+	if !ok {
+		return types.RowProof{}, false
+	}
+	c.list.MoveToBack(e)
+	return e.Value.(*rowProofCacheEntry).rowProof, true
+}
+
+// Add verifies the row proof against the data hash and caches it, evicting
+// the least recently used row proof if the cache is full. It returns an error,
+// and does not cache anything, if the verification fails.
+func (c *rowProofCache) Add(dataHash []byte, rowProof types.RowProof) error {
+	if err := rowProof.Validate(dataHash); err != nil {
+		return fmt.Errorf("row proof does not match the data hash %X: %w", dataHash, err)
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	key := string(dataHash)
+	if e, ok := c.cacheMap[key]; ok {
+		c.list.MoveToBack(e)
+		return nil
+	}
+	if c.list.Len() >= c.size {
+		front := c.list.Front()
+		if front != nil {
+			delete(c.cacheMap, front.Value.(*rowProofCacheEntry).dataHash)
+			c.list.Remove(front)
+		}
+	}
+	c.cacheMap[key] = c.list.PushBack(&rowProofCacheEntry{dataHash: key, rowProof: rowProof})
+	return nil
+}
+
+// Len returns the number of cached row proofs.
+func (c *rowProofCache) Len() int {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	return c.list.Len()
+}
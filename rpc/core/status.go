@@ -7,6 +7,7 @@ import (
 	"github.com/tendermint/tendermint/p2p"
 	ctypes "github.com/tendermint/tendermint/rpc/core/types"
 	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+	"github.com/tendermint/tendermint/statesync"
 	"github.com/tendermint/tendermint/types"
 )
 
@@ -46,10 +47,13 @@ func Status(ctx *rpctypes.Context) (*ctypes.ResultStatus, error) {
 	}
 
 	// Return the very last voting power, not the voting power of this validator
-	// during the last block.
-	var votingPower int64
-	if val := validatorAtHeight(latestUncommittedHeight()); val != nil {
-		votingPower = val.VotingPower
+	// during the last block. An archive server is not a validator.
+	var validatorInfo ctypes.ValidatorInfo
+	if !env.ArchiveMode {
+		validatorInfo = ctypes.ValidatorInfo{Address: env.PubKey.Address(), PubKey: env.PubKey}
+		if val := validatorAtHeight(latestUncommittedHeight()); val != nil {
+			validatorInfo.VotingPower = val.VotingPower
+		}
 	}
 
 	result := &ctypes.ResultStatus{
@@ -63,13 +67,25 @@ func Status(ctx *rpctypes.Context) (*ctypes.ResultStatus, error) {
 			EarliestAppHash:     earliestAppHash,
 			EarliestBlockHeight: earliestBlockHeight,
 			EarliestBlockTime:   time.Unix(0, earliestBlockTimeNano),
-			CatchingUp:          env.ConsensusReactor.WaitSync(),
-		},
-		ValidatorInfo: ctypes.ValidatorInfo{
-			Address:     env.PubKey.Address(),
-			PubKey:      env.PubKey,
-			VotingPower: votingPower,
+			CatchingUp:          !env.ArchiveMode && env.ConsensusReactor.WaitSync(),
 		},
+		ValidatorInfo: validatorInfo,
+		ReadOnly:      env.Config.ReadOnly,
+		ArchiveMode:   env.ArchiveMode,
+	}
+	if env.AppRequirements != nil {
+		result.MempoolRequirements = mempoolRequirements(env.AppRequirements)
+	}
+	if env.StateSyncReactor != nil {
+		if status := env.StateSyncReactor.Status(); status.State != statesync.SyncStateInactive {
+			result.SyncInfo.StateSync = &ctypes.StateSyncInfo{
+				State:          string(status.State),
+				Attempts:       status.Attempts,
+				SnapshotHeight: status.SnapshotHeight,
+				FailureReason:  status.FailureReason,
+				Error:          status.Error,
+			}
+		}
 	}
 
 	return result, nil
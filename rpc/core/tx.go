@@ -1,11 +1,18 @@
 package core
 
 import (
+	"bytes"
+	"context"
 	"errors"
 	"fmt"
+	"math"
+	"math/bits"
 	"sort"
+	"time"
 
 	abcitypes "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	cmtbytes "github.com/tendermint/tendermint/libs/bytes"
 	cmtmath "github.com/tendermint/tendermint/libs/math"
 	cmtquery "github.com/tendermint/tendermint/libs/pubsub/query"
 	"github.com/tendermint/tendermint/pkg/consts"
@@ -22,11 +29,17 @@ const (
 	txStatusPending   string = "PENDING"
 	txStatusEvicted   string = "EVICTED"
 	txStatusCommitted string = "COMMITTED"
+
+	// indexWaitTimeout is the maximum time /tx and /tx_search wait for the
+	// latest height to be indexed when indexing asynchronously.
+	indexWaitTimeout = 2 * time.Second
 )
 
 // Tx allows you to query the transaction results. `nil` could mean the
 // transaction is in the mempool, invalidated, or was not sent in the first
-// place.
+// place. The hash may either be the hash of the original transaction or, if
+// the indexer maintains aliases, the hash of the wrapped transaction committed
+// in the block.
 // More: https://docs.cometbft.com/v0.34/rpc/#/Info/tx
 func Tx(ctx *rpctypes.Context, hash []byte, prove bool) (*ctypes.ResultTx, error) {
 	env := GetEnvironment()
@@ -35,6 +48,8 @@ func Tx(ctx *rpctypes.Context, hash []byte, prove bool) (*ctypes.ResultTx, error
 		return nil, fmt.Errorf("transaction indexing is disabled")
 	}
 
+	waitForIndexing(ctx.Context())
+
 	r, err := env.TxIndexer.Get(hash)
 	if err != nil {
 		return nil, err
@@ -56,17 +71,22 @@ func Tx(ctx *rpctypes.Context, hash []byte, prove bool) (*ctypes.ResultTx, error
 	}
 
 	return &ctypes.ResultTx{
-		Hash:     hash,
-		Height:   height,
-		Index:    index,
-		TxResult: r.Result,
-		Tx:       r.Tx,
-		Proof:    shareProof,
+		Hash:          types.Tx(r.Tx).Hash(),
+		Height:        height,
+		Index:         index,
+		TxResult:      r.Result,
+		Tx:            r.Tx,
+		Proof:         shareProof,
+		CommittedHash: committedHash(r.Tx),
+		OriginalHash:  originalHash(r.Tx),
 	}, nil
 }
 
 // TxSearch allows you to query for multiple transactions results. It returns a
 // list of transactions (maximum ?per_page entries) and the total count.
+// Depending on the tx_index configuration, queries that would scan the whole
+// index are rejected, and searches exceeding their budget return truncated
+// results.
 // More: https://docs.cometbft.com/v0.34/rpc/#/Info/tx_search
 func TxSearch(
 	ctx *rpctypes.Context,
@@ -89,7 +109,17 @@ func TxSearch(
 		return nil, err
 	}
 
-	results, err := env.TxIndexer.Search(ctx.Context(), q)
+	waitForIndexing(ctx.Context())
+
+	var (
+		results   []*abcitypes.TxResult
+		truncated bool
+	)
+	if searcher, ok := env.TxIndexer.(boundedTxSearcher); ok {
+		results, truncated, err = searcher.SearchBounded(ctx.Context(), q)
+	} else {
+		results, err = env.TxIndexer.Search(ctx.Context(), q)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -139,27 +169,86 @@ func TxSearch(
 		}
 
 		apiResults = append(apiResults, &ctypes.ResultTx{
-			Hash:     types.Tx(r.Tx).Hash(),
-			Height:   r.Height,
-			Index:    r.Index,
-			TxResult: r.Result,
-			Tx:       r.Tx,
-			Proof:    shareProof,
+			Hash:          types.Tx(r.Tx).Hash(),
+			Height:        r.Height,
+			Index:         r.Index,
+			TxResult:      r.Result,
+			Tx:            r.Tx,
+			Proof:         shareProof,
+			CommittedHash: committedHash(r.Tx),
+			OriginalHash:  originalHash(r.Tx),
 		})
 	}
 
-	return &ctypes.ResultTxSearch{Txs: apiResults, TotalCount: totalCount}, nil
+	return &ctypes.ResultTxSearch{Txs: apiResults, TotalCount: totalCount, Truncated: truncated}, nil
+}
+
+// boundedTxSearcher is implemented by the tx indexers able to report that the
+// results of a search were truncated, see TxSearch.
+type boundedTxSearcher interface {
+	SearchBounded(ctx context.Context, q *cmtquery.Query) ([]*abcitypes.TxResult, bool, error)
+}
+
+// committedHash returns the hash of the committed transaction bytes if it
+// differs from the hash the transaction is indexed under, nil otherwise.
+func committedHash(tx types.Tx) cmtbytes.HexBytes {
+	hash := tmhash.Sum(tx)
+	if bytes.Equal(hash, tx.Hash()) {
+		return nil
+	}
+	return hash
+}
+
+// originalHash returns the hash of the transaction unwrapped by the unwrapper
+// registered on the indexer, under which the indexer aliases it, if it differs
+// from the hash the transaction is indexed under, nil otherwise.
+func originalHash(tx types.Tx) cmtbytes.HexBytes {
+	env := GetEnvironment()
+	if env.IndexerService == nil {
+		return nil
+	}
+	unwrap := env.IndexerService.TxUnwrapper()
+	if unwrap == nil {
+		return nil
+	}
+	original, ok := unwrap(tx)
+	if !ok {
+		return nil
+	}
+	hash := tmhash.Sum(original)
+	if bytes.Equal(hash, tx.Hash()) {
+		return nil
+	}
+	return hash
 }
 
+// proveTx queries the application for a proof of the transaction at the given
+// index of the block at the given height. The proof is returned with the shares
+// holding the transaction, which are checked to contain it, so that the
+// transaction can be read from the proof and the proof verified offline.
 func proveTx(height int64, index uint32) (types.ShareProof, error) {
+	env := GetEnvironment()
+	rawBlock, err := loadRawBlock(env.BlockStore, height)
+	if err != nil {
+		return types.ShareProof{}, err
+	}
+	var pbb cmtproto.Block
+	if err := pbb.Unmarshal(rawBlock); err != nil {
+		return types.ShareProof{}, fmt.Errorf("error decoding block at height %d: %w", height, err)
+	}
+	return proveTxInBlock(env, rawBlock, &pbb, index)
+}
+
+// proveTxInBlock is proveTx for the given raw block and its decoding.
+func proveTxInBlock(env *Environment, rawBlock []byte, pbb *cmtproto.Block, index uint32) (types.ShareProof, error) {
 	var (
 		pShareProof cmtproto.ShareProof
 		shareProof  types.ShareProof
+		height      = pbb.Header.Height
 	)
-	env := GetEnvironment()
-	rawBlock, err := loadRawBlock(env.BlockStore, height)
-	if err != nil {
-		return shareProof, err
+	if int(index) >= len(pbb.Data.Txs) {
+		return shareProof, fmt.Errorf("tx index %d out of range for the %d txs of the block at height %d",
+			index, len(pbb.Data.Txs), height)
 	}
 	res, err := env.ProxyAppQuery.QuerySync(abcitypes.RequestQuery{
 		Data: rawBlock,
@@ -172,52 +261,222 @@ func proveTx(height int64, index uint32) (types.ShareProof, error) {
 	if err != nil {
 		return shareProof, err
 	}
+	if len(pShareProof.Data) == 0 && len(pShareProof.GetRowProof().GetRowRoots()) == 0 {
+		// the app does not prove transactions
+		return shareProof, nil
+	}
 	shareProof, err = types.ShareProofFromProto(pShareProof)
 	if err != nil {
 		return shareProof, err
 	}
+
+	if len(shareProof.Data) == 0 {
+		// the shares are proven separately if the app left them out
+		first, last, err := shareProof.ShareRange(int(pbb.Data.SquareSize))
+		if err != nil {
+			return shareProof, fmt.Errorf("error getting the shares of the tx proof: %w", err)
+		}
+		sharesProof, err := proveShares(env, rawBlock, uint64(first), uint64(last)+1)
+		if err != nil {
+			return shareProof, fmt.Errorf("error getting the shares of the tx proof: %w", err)
+		}
+		shareProof.Data = sharesProof.Data
+	}
+	if err := shareProof.ContainsTx(pbb.Data.Txs[index]); err != nil {
+		return shareProof, fmt.Errorf("invalid proof of tx %d at height %d: %w", index, height, err)
+	}
 	return shareProof, nil
 }
 
-// ProveShares creates an NMT proof for a set of shares to a set of rows. It is
-// end exclusive.
-// Deprecated: Use ProveSharesV2 instead.
-func ProveShares(
+// ProveTxShares proves the shares of the transaction with the given hash in
+// the block at the given height to the data root. For a blob transaction, only
+// the compact shares holding its MsgPayForBlobs transaction are proven, which
+// is all verifiers of the fee payment need. If includeBlob is true, the sparse
+// shares of its blobs are proven too, one proof per blob, as the blobs may be
+// in different namespaces.
+func ProveTxShares(
 	_ *rpctypes.Context,
 	height int64,
-	startShare uint64,
-	endShare uint64,
-) (types.ShareProof, error) {
-	var (
-		pShareProof cmtproto.ShareProof
-		shareProof  types.ShareProof
-	)
+	hash []byte,
+	includeBlob bool,
+) (*ctypes.ResultTxSharesProof, error) {
 	env := GetEnvironment()
 	rawBlock, err := loadRawBlock(env.BlockStore, height)
 	if err != nil {
-		return shareProof, err
+		return nil, err
 	}
-	res, err := env.ProxyAppQuery.QuerySync(abcitypes.RequestQuery{
-		Data: rawBlock,
-		Path: fmt.Sprintf(consts.ShareInclusionProofQueryPath, startShare, endShare),
-	})
+	var pbb cmtproto.Block
+	if err := pbb.Unmarshal(rawBlock); err != nil {
+		return nil, fmt.Errorf("error decoding block at height %d: %w", height, err)
+	}
+	txs := types.ToTxs(pbb.Data.Txs)
+	index := txs.IndexByHash(hash)
+	if index < 0 {
+		return nil, fmt.Errorf("tx (%X) not found in the block at height %d", hash, height)
+	}
+
+	txProof, err := proveTxInBlock(env, rawBlock, &pbb, uint32(index))
 	if err != nil {
-		return shareProof, err
+		return nil, err
 	}
-	if res.Value == nil && res.Log != "" {
-		// we can make the assumption that for custom queries, if the value is nil
-		// and some logs have been emitted, then an error happened.
-		return types.ShareProof{}, errors.New(res.Log)
+	res := &ctypes.ResultTxSharesProof{Height: height, Index: uint32(index), TxProof: txProof}
+	if !includeBlob {
+		return res, nil
 	}
-	err = pShareProof.Unmarshal(res.Value)
+
+	bTx, isBlob := types.UnmarshalBlobTx(txs[index])
+	if !isBlob {
+		return nil, fmt.Errorf("tx (%X) at height %d is not a blob tx", hash, height)
+	}
+	shareIndexes, err := blobShareIndexes(bTx, txProof)
 	if err != nil {
-		return shareProof, err
+		return nil, fmt.Errorf("error getting the shares of the blobs of tx (%X) at height %d: %w", hash, height, err)
 	}
-	shareProof, err = types.ShareProofFromProto(pShareProof)
+	for i, blob := range bTx.Blobs {
+		start := uint64(shareIndexes[i])
+		r := ctypes.ShareRange{Start: start, End: start + uint64(types.SharesForBlobOfVersion(len(blob.Data), uint8(blob.ShareVersion)))}
+		if err := validateShareRange(r, pbb.Data.SquareSize); err != nil {
+			return nil, fmt.Errorf("invalid shares of blob %d: %w", i, err)
+		}
+		blobProof, err := proveShares(env, rawBlock, r.Start, r.End)
+		if err != nil {
+			return nil, fmt.Errorf("error proving the shares of blob %d: %w", i, err)
+		}
+		res.BlobProofs = append(res.BlobProofs, ctypes.BlobShareProof{Range: r, ShareProof: blobProof})
+	}
+	return res, nil
+}
+
+// blobShareIndexes returns the index of the first share of each blob of the
+// blob transaction, read from the wrapper of its MsgPayForBlobs transaction in
+// the shares of its proof.
+func blobShareIndexes(bTx cmtproto.BlobTx, txProof types.ShareProof) ([]uint32, error) {
+	txs, err := txProof.CompactShareTxs()
 	if err != nil {
-		return shareProof, err
+		return nil, err
 	}
-	return shareProof, nil
+	for _, shareTx := range txs {
+		indexWrapper, ok := types.UnmarshalIndexWrapper(shareTx)
+		if !ok || !bytes.Equal(indexWrapper.Tx, bTx.Tx) {
+			continue
+		}
+		if len(indexWrapper.ShareIndexes) != len(bTx.Blobs) {
+			return nil, fmt.Errorf("the tx holds %d share indexes for %d blobs",
+				len(indexWrapper.ShareIndexes), len(bTx.Blobs))
+		}
+		return indexWrapper.ShareIndexes, nil
+	}
+	return nil, errors.New("the shares of the tx proof do not hold the share indexes of the blobs")
+}
+
+// TxBundle returns a self-contained receipt of the committed transaction with
+// the given hash: the transaction as committed in the block, the proof of its
+// shares to the data root, and the light block, i.e. the header committing to
+// the data root, its commit and validator set. The bundle is verified before
+// it is returned, and can be verified offline with ResultTxBundle.Verify.
+func TxBundle(ctx *rpctypes.Context, hash []byte) (*ctypes.ResultTxBundle, error) {
+	env := GetEnvironment()
+	if _, ok := env.TxIndexer.(*null.TxIndex); ok {
+		return nil, fmt.Errorf("transaction indexing is disabled")
+	}
+
+	waitForIndexing(ctx.Context())
+
+	r, err := env.TxIndexer.Get(hash)
+	if err != nil {
+		return nil, err
+	}
+	if r == nil {
+		return nil, fmt.Errorf("tx (%X) not found", hash)
+	}
+	height := r.Height
+
+	rawBlock, err := loadRawBlock(env.BlockStore, height)
+	if err != nil {
+		return nil, err
+	}
+	var pbb cmtproto.Block
+	if err := pbb.Unmarshal(rawBlock); err != nil {
+		return nil, fmt.Errorf("error decoding block at height %d: %w", height, err)
+	}
+	proof, err := proveTxInBlock(env, rawBlock, &pbb, r.Index)
+	if err != nil {
+		return nil, err
+	}
+	if len(proof.Data) == 0 {
+		return nil, errors.New("the application does not prove transactions")
+	}
+
+	lightBlock, canonical, err := loadLightBlock(env, height)
+	if err != nil {
+		return nil, err
+	}
+
+	tx := types.Tx(pbb.Data.Txs[r.Index])
+	bundle := &ctypes.ResultTxBundle{
+		Hash:       tx.Hash(),
+		Height:     height,
+		Index:      r.Index,
+		Tx:         tx,
+		Proof:      proof,
+		LightBlock: lightBlock,
+		Canonical:  canonical,
+	}
+	if err := bundle.Verify(env.GenDoc.ChainID); err != nil {
+		return nil, fmt.Errorf("invalid bundle of tx (%X) at height %d: %w", hash, height, err)
+	}
+	return bundle, nil
+}
+
+// loadLightBlock loads the header, commit and validator set of the given
+// height. The commit is the canonical one from the next block if it has been
+// committed, the commit seen by the node otherwise.
+func loadLightBlock(env *Environment, height int64) (*types.LightBlock, bool, error) {
+	blockMeta := env.BlockStore.LoadBlockMeta(height)
+	if blockMeta == nil {
+		return nil, false, fmt.Errorf("no header at height %d", height)
+	}
+	canonical := height < env.BlockStore.Height()
+	var commit *types.Commit
+	if canonical {
+		commit = env.BlockStore.LoadBlockCommit(height)
+	} else {
+		commit = env.BlockStore.LoadSeenCommit(height)
+	}
+	if commit == nil {
+		return nil, false, fmt.Errorf("no commit at height %d", height)
+	}
+	vals, err := env.StateStore.LoadValidators(height)
+	if err != nil {
+		return nil, false, fmt.Errorf("error loading the validators at height %d: %w", height, err)
+	}
+	return &types.LightBlock{
+		SignedHeader: &types.SignedHeader{Header: &blockMeta.Header, Commit: commit},
+		ValidatorSet: vals,
+	}, canonical, nil
+}
+
+// ProveShares creates an NMT proof for a set of shares to a set of rows. It is
+// end exclusive.
+// Deprecated: Use ProveSharesV2 instead.
+func ProveShares(
+	_ *rpctypes.Context,
+	height int64,
+	startShare uint64,
+	endShare uint64,
+) (types.ShareProof, error) {
+	env := GetEnvironment()
+	rawBlock, err := loadRawBlock(env.BlockStore, height)
+	if err != nil {
+		return types.ShareProof{}, err
+	}
+	return proveShares(env, rawBlock, startShare, endShare)
+}
+
+// proveShares queries the application for a proof of the share range of the
+// given raw block.
+func proveShares(env *Environment, rawBlock []byte, startShare, endShare uint64) (types.ShareProof, error) {
+	return state.ProveShares(env.ProxyAppQuery, rawBlock, startShare, endShare)
 }
 
 // TxStatus retrieves the status of a transaction given its hash. It returns a ResultTxStatus
@@ -269,6 +528,475 @@ func ProveSharesV2(
 	return &ctypes.ResultShareProof{ShareProof: shareProof}, nil
 }
 
+// ProveSharesBatch creates proofs for multiple share ranges of the same block
+// to the data root. Each range is end exclusive, and is validated and proven
+// independently: the proofs are returned in the order of the ranges, and a
+// range that cannot be proven has an error instead of a proof.
+//
+// The application only proves one range per query, and extends the square
+// again for every query, so the batch saves no work over separate ProveShares
+// calls beyond loading the block from the store once: it only saves round
+// trips to the node.
+func ProveSharesBatch(
+	_ *rpctypes.Context,
+	height int64,
+	ranges []ctypes.ShareRange,
+) (*ctypes.ResultShareProofBatch, error) {
+	if len(ranges) == 0 {
+		return nil, errors.New("no share ranges provided")
+	}
+	if len(ranges) > maxShareRangesPerBatch {
+		return nil, fmt.Errorf("too many share ranges %d, the maximum is %d", len(ranges), maxShareRangesPerBatch)
+	}
+
+	env := GetEnvironment()
+	rawBlock, err := loadRawBlock(env.BlockStore, height)
+	if err != nil {
+		return nil, err
+	}
+	var pbb cmtproto.Block
+	if err := pbb.Unmarshal(rawBlock); err != nil {
+		return nil, fmt.Errorf("error decoding block at height %d: %w", height, err)
+	}
+	squareSize := pbb.Data.SquareSize
+
+	proofs := make([]ctypes.ShareProofBatchItem, len(ranges))
+	for i, r := range ranges {
+		proofs[i].Range = r
+		if err := validateShareRange(r, squareSize); err != nil {
+			proofs[i].Error = err.Error()
+			continue
+		}
+		shareProof, err := proveShares(env, rawBlock, r.Start, r.End)
+		if err != nil {
+			proofs[i].Error = err.Error()
+			continue
+		}
+		proofs[i].ShareProof = &shareProof
+	}
+	return &ctypes.ResultShareProofBatch{Height: height, Proofs: proofs}, nil
+}
+
+// ProveSharesPaged creates proofs of the end exclusive share range of a block
+// to the data root, split in chunks so that the size of each response remains
+// bounded however large the range is. A chunk is the part of the range within
+// a row of the original data square: the chunks are contiguous, in order, and
+// together cover the whole range. Each chunk is proven independently, so that
+// its proof can be verified on its own.
+//
+// The chunks are paginated, perPage chunks at a time, and TotalCount holds the
+// number of chunks of the whole range.
+func ProveSharesPaged(
+	_ *rpctypes.Context,
+	height int64,
+	startShare uint64,
+	endShare uint64,
+	pagePtr, perPagePtr *int,
+) (*ctypes.ResultShareProofPage, error) {
+	env := GetEnvironment()
+	rawBlock, err := loadRawBlock(env.BlockStore, height)
+	if err != nil {
+		return nil, err
+	}
+	var pbb cmtproto.Block
+	if err := pbb.Unmarshal(rawBlock); err != nil {
+		return nil, fmt.Errorf("error decoding block at height %d: %w", height, err)
+	}
+	squareSize := pbb.Data.SquareSize
+	if squareSize == 0 {
+		return nil, fmt.Errorf("the square size of the block at height %d is unknown", height)
+	}
+	r := ctypes.ShareRange{Start: startShare, End: endShare}
+	if err := validateShareRange(r, squareSize); err != nil {
+		return nil, err
+	}
+
+	chunks := shareRangeChunks(r, squareSize)
+	perPage := validatePerPage(perPagePtr)
+	page, err := validatePage(pagePtr, perPage, len(chunks))
+	if err != nil {
+		return nil, err
+	}
+	skipCount := validateSkipCount(page, perPage)
+	pageSize := cmtmath.MinInt(perPage, len(chunks)-skipCount)
+
+	proofs := make([]ctypes.ShareProofChunk, pageSize)
+	for i := range proofs {
+		chunk := chunks[skipCount+i]
+		shareProof, err := proveShares(env, rawBlock, chunk.Start, chunk.End)
+		if err != nil {
+			return nil, fmt.Errorf("error proving shares %d to %d: %w", chunk.Start, chunk.End, err)
+		}
+		proofs[i] = ctypes.ShareProofChunk{Range: chunk, ShareProof: shareProof}
+	}
+	return &ctypes.ResultShareProofPage{Height: height, Chunks: proofs, TotalCount: len(chunks)}, nil
+}
+
+// shareRangeChunks splits the end exclusive share range along the rows of a
+// square of the given size.
+func shareRangeChunks(r ctypes.ShareRange, squareSize uint64) []ctypes.ShareRange {
+	var chunks []ctypes.ShareRange
+	for start := r.Start; start < r.End; {
+		row, _ := types.ShareIndexToRowCol(int(start), int(squareSize))
+		end := uint64(types.RowColToShareIndex(row+1, 0, int(squareSize)))
+		if end > r.End {
+			end = r.End
+		}
+		chunks = append(chunks, ctypes.ShareRange{Start: start, End: end})
+		start = end
+	}
+	return chunks
+}
+
+// validateShareRange checks that the end exclusive share range is not empty
+// and, if the square size is known, lies within the original data square.
+func validateShareRange(r ctypes.ShareRange, squareSize uint64) error {
+	if r.Start >= r.End {
+		return fmt.Errorf("start share %d must be less than end share %d", r.Start, r.End)
+	}
+	if squareSize != 0 && r.End > squareSize*squareSize {
+		return fmt.Errorf("end share %d exceeds the %d shares of the square", r.End, squareSize*squareSize)
+	}
+	return nil
+}
+
+// RowNamespaceRanges returns the minimum and maximum namespace committed to by
+// the root of every row of the original data square of the block at the given
+// height. If no height is provided, it returns the ranges of the latest block.
+// The row roots are not stored, so they are recomputed by the application by
+// proving all the shares of the square, and cached once verified against the
+// data hash of the block.
+func RowNamespaceRanges(_ *rpctypes.Context, heightPtr *int64) (*ctypes.ResultRowNamespaceRanges, error) {
+	env := GetEnvironment()
+	height, err := getHeight(env.BlockStore.Height(), heightPtr)
+	if err != nil {
+		return nil, err
+	}
+	blockMeta := env.BlockStore.LoadBlockMeta(height)
+	if blockMeta == nil {
+		return nil, fmt.Errorf("no block found for height %d", height)
+	}
+	rowProof, err := loadRowProof(env, height, blockMeta.Header.DataHash)
+	if err != nil {
+		return nil, err
+	}
+	rows := make([]ctypes.RowNamespaceRange, len(rowProof.RowRoots))
+	for i := range rows {
+		minNamespace, maxNamespace, err := rowProof.RowNamespaceRange(i)
+		if err != nil {
+			return nil, err
+		}
+		rows[i] = ctypes.RowNamespaceRange{
+			Row:          uint32(i),
+			MinNamespace: minNamespace,
+			MaxNamespace: maxNamespace,
+		}
+	}
+	return &ctypes.ResultRowNamespaceRanges{Height: height, Rows: rows}, nil
+}
+
+// EstimateNamespaceProofSize returns an estimate of the size of the proof of
+// the shares of the namespace in the block at the given height, as returned by
+// ProveShares, without building the proof. The namespace is the namespace
+// version followed by the namespace ID. The rows whose namespace range contains
+// the namespace are found from the row roots, see RowNamespaceRanges. The
+// estimate is an upper bound: every such row is assumed to be filled with the
+// shares of the namespace, except for a share of another namespace in the rows
+// where the namespace is not the only one, and the NMT proof of every row is
+// assumed to hold nodes on both sides of the shares.
+func EstimateNamespaceProofSize(
+	_ *rpctypes.Context,
+	height int64,
+	namespace []byte,
+) (*ctypes.ResultProofSizeEstimate, error) {
+	env := GetEnvironment()
+	height, err := getHeight(env.BlockStore.Height(), &height)
+	if err != nil {
+		return nil, err
+	}
+	if len(namespace) != consts.NamespaceSize {
+		return nil, fmt.Errorf("expected namespace size %d, got %d", consts.NamespaceSize, len(namespace))
+	}
+	blockMeta := env.BlockStore.LoadBlockMeta(height)
+	if blockMeta == nil {
+		return nil, fmt.Errorf("no block found for height %d", height)
+	}
+	rowProof, err := loadRowProof(env, height, blockMeta.Header.DataHash)
+	if err != nil {
+		return nil, err
+	}
+
+	squareSize := len(rowProof.RowRoots)
+	// the leaves of the NMT of a row are the shares of the extended row
+	nmtDepth := bits.Len(uint(2*squareSize - 1))
+	nmtNodeSize := 2*consts.NamespaceSize + tmhash.Size
+
+	res := &ctypes.ResultProofSizeEstimate{
+		Height:    height,
+		Namespace: namespace,
+		NMTDepth:  nmtDepth,
+	}
+	for row := range rowProof.RowRoots {
+		minNamespace, maxNamespace, err := rowProof.RowNamespaceRange(row)
+		if err != nil {
+			return nil, err
+		}
+		if bytes.Compare(namespace, minNamespace) < 0 || bytes.Compare(namespace, maxNamespace) > 0 {
+			continue
+		}
+		res.Rows++
+		shares := squareSize
+		if !bytes.Equal(minNamespace, maxNamespace) {
+			shares--
+		}
+		res.Shares += shares
+
+		// the shares, the NMT proof and the row root, and the Merkle proof of
+		// the row root to the data root
+		res.Size += int64(shares * consts.ShareSize)
+		res.Size += int64((2*nmtDepth + 1) * nmtNodeSize)
+		res.Size += int64((len(rowProof.Proofs[row].Aunts) + 1) * tmhash.Size)
+	}
+	return res, nil
+}
+
+// VerifyNamespaceCompleteness checks that the share proofs together cover all
+// the shares of the namespace in the block at the given height. Each proof
+// must be a valid proof of shares of the namespace against the data hash of
+// the block, otherwise an error is returned. The proofs may be in any order and
+// overlap.
+//
+// The namespace ID does not include the namespace version, which is that of
+// the proofs. Without any proof, the namespace is assumed to be of version 0.
+// If the coverage is incomplete, the share ranges of the namespace missing
+// from the proofs are returned as gaps.
+func VerifyNamespaceCompleteness(
+	_ *rpctypes.Context,
+	height int64,
+	namespaceID []byte,
+	proofs []cmtproto.ShareProof,
+) (*ctypes.ResultNamespaceCompleteness, error) {
+	env := GetEnvironment()
+	height, err := getHeight(env.BlockStore.Height(), &height)
+	if err != nil {
+		return nil, err
+	}
+	if len(namespaceID) != consts.NamespaceIDSize {
+		return nil, fmt.Errorf("expected namespace ID size %d, got %d", consts.NamespaceIDSize, len(namespaceID))
+	}
+	if len(proofs) > maxNamespaceCompletenessProofs {
+		return nil, fmt.Errorf("too many proofs %d, the maximum is %d", len(proofs), maxNamespaceCompletenessProofs)
+	}
+	blockMeta := env.BlockStore.LoadBlockMeta(height)
+	if blockMeta == nil {
+		return nil, fmt.Errorf("no block found for height %d", height)
+	}
+	dataHash := blockMeta.Header.DataHash
+	rowProof, err := loadRowProof(env, height, dataHash)
+	if err != nil {
+		return nil, err
+	}
+	squareSize := len(rowProof.RowRoots)
+
+	var version uint32
+	covered := make([]ctypes.ShareRange, len(proofs))
+	for i, pb := range proofs {
+		sp, err := types.ShareProofFromProto(pb)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proof %d: %w", i, err)
+		}
+		if !bytes.Equal(sp.NamespaceID, namespaceID) {
+			return nil, fmt.Errorf("proof %d is for namespace ID %X, not %X", i, sp.NamespaceID, namespaceID)
+		}
+		if i > 0 && sp.NamespaceVersion != version {
+			return nil, fmt.Errorf("proof %d is for namespace version %d, not %d", i, sp.NamespaceVersion, version)
+		}
+		version = sp.NamespaceVersion
+		if err := sp.Validate(dataHash); err != nil {
+			return nil, fmt.Errorf("proof %d failed to verify against the data hash of height %d: %w", i, height, err)
+		}
+		first, last, err := sp.ShareRange(squareSize)
+		if err != nil {
+			return nil, fmt.Errorf("invalid share range of proof %d: %w", i, err)
+		}
+		covered[i] = ctypes.ShareRange{Start: uint64(first), End: uint64(last) + 1}
+	}
+	if version > math.MaxUint8 {
+		return nil, fmt.Errorf("namespace version %d must be less than or equal to %d", version, math.MaxUint8)
+	}
+
+	namespace := append([]byte{uint8(version)}, namespaceID...)
+	shares, err := namespaceShareRange(env, height, rowProof, namespace)
+	if err != nil {
+		return nil, err
+	}
+	gaps := shareRangeGaps(shares, covered)
+	return &ctypes.ResultNamespaceCompleteness{
+		Height:           height,
+		NamespaceID:      namespaceID,
+		NamespaceVersion: version,
+		Shares:           shares,
+		Complete:         len(gaps) == 0,
+		Gaps:             gaps,
+	}, nil
+}
+
+// namespaceShareRange returns the end exclusive range of the shares of the
+// namespace in the original data square of the block at the given height, or
+// an empty range if the block holds no share of the namespace. The rows which
+// may hold the namespace are found from the row roots, and their shares are
+// then requested from the application.
+func namespaceShareRange(
+	env *Environment,
+	height int64,
+	rowProof types.RowProof,
+	namespace []byte,
+) (ctypes.ShareRange, error) {
+	firstRow, lastRow := -1, -1
+	for row := range rowProof.RowRoots {
+		minNamespace, maxNamespace, err := rowProof.RowNamespaceRange(row)
+		if err != nil {
+			return ctypes.ShareRange{}, err
+		}
+		if bytes.Compare(namespace, minNamespace) < 0 || bytes.Compare(namespace, maxNamespace) > 0 {
+			continue
+		}
+		if firstRow < 0 {
+			firstRow = row
+		}
+		lastRow = row
+	}
+	if firstRow < 0 {
+		return ctypes.ShareRange{}, nil
+	}
+
+	rawBlock, err := loadRawBlock(env.BlockStore, height)
+	if err != nil {
+		return ctypes.ShareRange{}, err
+	}
+	squareSize := len(rowProof.RowRoots)
+	start := uint64(types.RowColToShareIndex(firstRow, 0, squareSize))
+	end := uint64(types.RowColToShareIndex(lastRow+1, 0, squareSize))
+	rowsProof, err := proveShares(env, rawBlock, start, end)
+	if err != nil {
+		return ctypes.ShareRange{}, err
+	}
+	if uint64(len(rowsProof.Data)) != end-start {
+		return ctypes.ShareRange{}, fmt.Errorf("the application returned %d shares for the %d shares of rows %d to %d",
+			len(rowsProof.Data), end-start, firstRow, lastRow)
+	}
+
+	// the shares are sorted by namespace, so those of the namespace are
+	// contiguous
+	var r ctypes.ShareRange
+	for i, share := range rowsProof.Data {
+		if !bytes.HasPrefix(share, namespace) {
+			continue
+		}
+		if r.End == 0 {
+			r.Start = start + uint64(i)
+		}
+		r.End = start + uint64(i) + 1
+	}
+	return r, nil
+}
+
+// shareRangeGaps returns the end exclusive parts of the range which none of
+// the covered ranges overlap, in order.
+func shareRangeGaps(r ctypes.ShareRange, covered []ctypes.ShareRange) []ctypes.ShareRange {
+	sorted := append([]ctypes.ShareRange{}, covered...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Start < sorted[j].Start })
+
+	var gaps []ctypes.ShareRange
+	cursor := r.Start
+	for _, c := range sorted {
+		if cursor >= r.End {
+			break
+		}
+		if c.Start > cursor {
+			end := c.Start
+			if end > r.End {
+				end = r.End
+			}
+			gaps = append(gaps, ctypes.ShareRange{Start: cursor, End: end})
+		}
+		if c.End > cursor {
+			cursor = c.End
+		}
+	}
+	if cursor < r.End {
+		gaps = append(gaps, ctypes.ShareRange{Start: cursor, End: r.End})
+	}
+	return gaps
+}
+
+// loadRowProof returns the proof of all the rows of the original data square
+// of the block at the given height to its data hash. The proof is loaded from
+// the block store if it was saved there, and computed by the application and
+// saved otherwise, e.g. for the blocks saved before the row proofs were. An
+// error is returned if the saved proof is corrupted.
+func loadRowProof(env *Environment, height int64, dataHash []byte) (types.RowProof, error) {
+	if rowProof, ok := rowProofs.Get(dataHash); ok {
+		return rowProof, nil
+	}
+
+	saved, err := env.BlockStore.LoadRowProof(height)
+	if err != nil {
+		return types.RowProof{}, err
+	}
+	if saved != nil {
+		if err := rowProofs.Add(dataHash, *saved); err != nil {
+			return types.RowProof{}, fmt.Errorf("invalid proof of the square at height %d: %w", height, err)
+		}
+		return *saved, nil
+	}
+
+	rawBlock, err := loadRawBlock(env.BlockStore, height)
+	if err != nil {
+		return types.RowProof{}, err
+	}
+	rowProof, err := state.ProveSquareRows(env.ProxyAppQuery, rawBlock)
+	if err != nil {
+		return types.RowProof{}, err
+	}
+	if err := rowProofs.Add(dataHash, rowProof); err != nil {
+		return types.RowProof{}, fmt.Errorf("invalid proof of the square at height %d: %w", height, err)
+	}
+	if err := env.BlockStore.SaveRowProof(height, rowProof); err != nil {
+		env.Logger.Error("failed to save the proof of the rows of the data square", "height", height, "err", err)
+	}
+	return rowProof, nil
+}
+
+// ProveTxAbsence returns a proof that the transaction with the given hash is
+// not in the block at the given height. The proof commits to the hashes of all
+// the transactions of the block, so it can only be created if the data hash of
+// the block is the Merkle root of the transaction hashes, not if it is a data
+// root defined by the application.
+func ProveTxAbsence(_ *rpctypes.Context, height int64, hash []byte) (*ctypes.ResultTxAbsenceProof, error) {
+	env := GetEnvironment()
+	height, err := getHeight(env.BlockStore.Height(), &height)
+	if err != nil {
+		return nil, err
+	}
+	if len(hash) != tmhash.Size {
+		return nil, fmt.Errorf("expected tx hash size %d, got %d", tmhash.Size, len(hash))
+	}
+	block := env.BlockStore.LoadBlock(height)
+	if block == nil {
+		return nil, fmt.Errorf("no block found for height %d", height)
+	}
+
+	proof, err := block.Txs.AbsenceProof(hash)
+	if err != nil {
+		return nil, fmt.Errorf("cannot prove absence at height %d: %w", height, err)
+	}
+	if !bytes.Equal(proof.RootHash, block.DataHash) {
+		return nil, fmt.Errorf("the data hash of the block at height %d does not commit to the tx hashes", height)
+	}
+	return &ctypes.ResultTxAbsenceProof{Height: height, Proof: proof}, nil
+}
+
 func loadRawBlock(bs state.BlockStore, height int64) ([]byte, error) {
 	var blockMeta = bs.LoadBlockMeta(height)
 	if blockMeta == nil {
@@ -291,6 +1019,8 @@ func loadRawBlock(bs state.BlockStore, height int64) ([]byte, error) {
 // TxSearchMatchEvents allows you to query for multiple transactions results and match the
 // query attributes to a common event. It returns a
 // list of transactions (maximum ?per_page entries) and the total count.
+// If groupByHeight is set, the transactions of the page are returned grouped
+// by height, in the requested order, instead of as a flat list.
 // More: https://docs.cometbft.com/v0.34/rpc/#/Info/tx_search
 func TxSearchMatchEvents(
 	ctx *rpctypes.Context,
@@ -299,6 +1029,7 @@ func TxSearchMatchEvents(
 	pagePtr, perPagePtr *int,
 	orderBy string,
 	matchEvents bool,
+	groupByHeight bool,
 ) (*ctypes.ResultTxSearch, error) {
 
 	if matchEvents {
@@ -306,6 +1037,70 @@ func TxSearchMatchEvents(
 	} else {
 		query = "match.events = 0 AND " + query
 	}
-	return TxSearch(ctx, query, prove, pagePtr, perPagePtr, orderBy)
+	res, err := TxSearch(ctx, query, prove, pagePtr, perPagePtr, orderBy)
+	if err != nil {
+		return nil, err
+	}
+	if groupByHeight {
+		res.Groups = ctypes.GroupTxsByHeight(res.Txs)
+		res.Txs = []*ctypes.ResultTx{}
+	}
+	return res, nil
+}
+
+// indexedHeightsReporter is implemented by the block indexers able to report
+// the heights they indexed, see TxIndexStatus.
+type indexedHeightsReporter interface {
+	IndexedHeights() (lowest, highest int64, err error)
+}
+
+// TxIndexStatus returns the heights covered by the indexer, compared to the
+// heights of the block store, so that clients can tell whether TxSearch may
+// miss transactions: the indexer may be behind the block store, or may not
+// cover its earliest heights, e.g. after a partial reindex. If indexing is
+// disabled, only the heights of the block store are returned.
+func TxIndexStatus(ctx *rpctypes.Context) (*ctypes.ResultTxIndexStatus, error) {
+	env := GetEnvironment()
+	res := &ctypes.ResultTxIndexStatus{
+		BlockStoreBase:   env.BlockStore.Base(),
+		BlockStoreHeight: env.BlockStore.Height(),
+	}
+	if _, ok := env.TxIndexer.(*null.TxIndex); ok {
+		return res, nil
+	}
+	res.Enabled = true
 
+	// transactions and block events are indexed together, and every height
+	// has a block entry, even without transactions
+	reporter, ok := env.BlockIndexer.(indexedHeightsReporter)
+	if !ok {
+		return nil, fmt.Errorf("indexer %T does not report the heights it indexed", env.BlockIndexer)
+	}
+	lowest, highest, err := reporter.IndexedHeights()
+	if err != nil {
+		return nil, err
+	}
+	res.LowestIndexedHeight, res.HighestIndexedHeight = lowest, highest
+	if behind := res.BlockStoreHeight - highest; behind > 0 {
+		res.HeightsBehind = behind
+	}
+	return res, nil
+}
+
+// waitForIndexing blocks until the events of the latest committed height have
+// been indexed, so that a transaction can be found right after its block was
+// committed even when indexing asynchronously. It gives up after
+// indexWaitTimeout, in which case the results may not include the latest
+// height.
+func waitForIndexing(ctx context.Context) {
+	env := GetEnvironment()
+	if env.IndexerService == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, indexWaitTimeout)
+	defer cancel()
+	height := env.BlockStore.Height()
+	if err := env.IndexerService.WaitForHeight(ctx, height); err != nil {
+		env.Logger.Debug("searching indexer before the latest height was indexed", "height", height, "err", err)
+	}
 }
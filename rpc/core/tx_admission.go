@@ -0,0 +1,92 @@
+package core
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+)
+
+// TxAdmission bounds the number of transactions submitted by the broadcast
+// routes whose CheckTx is pending, so that a storm of submissions is pushed
+// back to the clients instead of piling up in the node.
+//
+// A transaction holds its slot from its admission until the mempool calls back
+// with its CheckTx response, or without if the request to the application
+// failed, or rejects it right away. The routes waiting for the response also
+// release the slot when they return, e.g. on a timeout. The mempools call back
+// once CheckTx returns for the ones running it synchronously, and once the
+// application answers for the v0 mempool, whose requests to the application
+// are thus bounded too: the socket client queues at most 256 of them and
+// blocks beyond, while the local client runs them one at a time.
+type TxAdmission struct {
+	slots   chan struct{}
+	wait    time.Duration
+	metrics *Metrics
+}
+
+// NewTxAdmission returns a TxAdmission letting up to depth transactions be
+// pending, the callers willing to wait doing so up to wait for a slot.
+func NewTxAdmission(depth int, wait time.Duration, metrics *Metrics) *TxAdmission {
+	if metrics == nil {
+		metrics = NopMetrics()
+	}
+	return &TxAdmission{
+		slots:   make(chan struct{}, depth),
+		wait:    wait,
+		metrics: metrics,
+	}
+}
+
+// Pending returns the number of pending transactions.
+func (a *TxAdmission) Pending() int {
+	return len(a.slots)
+}
+
+// admit takes a slot for a transaction, waiting for one up to the configured
+// wait if block is set, and returns the function releasing it, which may be
+// called more than once. It returns an error wrapping
+// rpctypes.ErrOverloaded if no slot is available in time.
+func (a *TxAdmission) admit(ctx context.Context, block bool) (release func(), err error) {
+	select {
+	case a.slots <- struct{}{}:
+	default:
+		if !block || a.wait == 0 {
+			return nil, a.reject()
+		}
+		timer := time.NewTimer(a.wait)
+		defer timer.Stop()
+		select {
+		case a.slots <- struct{}{}:
+		case <-timer.C:
+			return nil, a.reject()
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	a.metrics.PendingBroadcastTxs.Set(float64(len(a.slots)))
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			<-a.slots
+			a.metrics.PendingBroadcastTxs.Set(float64(len(a.slots)))
+		})
+	}, nil
+}
+
+func (a *TxAdmission) reject() error {
+	a.metrics.OverloadedBroadcastTxs.Add(1)
+	return rpctypes.ErrOverloaded
+}
+
+// admitTx admits a transaction submitted by a broadcast route, see
+// TxAdmission. Without a TxAdmission in the environment, all transactions are
+// admitted.
+func (env *Environment) admitTx(ctx context.Context, block bool) (release func(), err error) {
+	if env.TxAdmission == nil {
+		return func() {}, nil
+	}
+	return env.TxAdmission.admit(ctx, block)
+}
@@ -0,0 +1,246 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abcicli "github.com/tendermint/tendermint/abci/client"
+	abciserver "github.com/tendermint/tendermint/abci/server"
+	abci "github.com/tendermint/tendermint/abci/types"
+	cfg "github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/libs/log"
+	mempl "github.com/tendermint/tendermint/mempool"
+	"github.com/tendermint/tendermint/mempool/mock"
+	mempoolv0 "github.com/tendermint/tendermint/mempool/v0"
+	"github.com/tendermint/tendermint/proxy"
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+// slowMempool checks the txs asynchronously, one every interval, buffering
+// the txs waiting to be checked like a slow application would.
+type slowMempool struct {
+	mock.Mempool
+
+	mtx       sync.Mutex
+	queue     []func(*abci.Response)
+	maxQueued int
+	checked   int
+}
+
+func (mem *slowMempool) CheckTx(_ types.Tx, cb func(*abci.Response), _ mempl.TxInfo) error {
+	mem.mtx.Lock()
+	defer mem.mtx.Unlock()
+	mem.queue = append(mem.queue, cb)
+	if len(mem.queue) > mem.maxQueued {
+		mem.maxQueued = len(mem.queue)
+	}
+	return nil
+}
+
+// checkNext checks the oldest tx in the queue, if any.
+func (mem *slowMempool) checkNext() {
+	mem.mtx.Lock()
+	if len(mem.queue) == 0 {
+		mem.mtx.Unlock()
+		return
+	}
+	cb := mem.queue[0]
+	mem.queue = mem.queue[1:]
+	mem.checked++
+	mem.mtx.Unlock()
+	cb(&abci.Response{Value: &abci.Response_CheckTx{CheckTx: &abci.ResponseCheckTx{}}})
+}
+
+func (mem *slowMempool) run(interval time.Duration, quit <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			mem.checkNext()
+		case <-quit:
+			return
+		}
+	}
+}
+
+func TestBroadcastTxAsyncBoundsPendingTxs(t *testing.T) {
+	const (
+		depth    = 20
+		interval = time.Millisecond
+	)
+	mem := &slowMempool{}
+	SetEnvironment(&Environment{
+		Mempool:     mem,
+		TxAdmission: NewTxAdmission(depth, 0, nil),
+		Logger:      log.TestingLogger(),
+	})
+	quit := make(chan struct{})
+	defer close(quit)
+	go mem.run(interval, quit)
+
+	// 4 clients submitting together 10 times as many txs as are checked
+	var (
+		wg                 sync.WaitGroup
+		mtx                sync.Mutex
+		accepted, rejected int
+	)
+	for c := 0; c < 4; c++ {
+		wg.Add(1)
+		go func(c int) {
+			defer wg.Done()
+			for i := 0; i < 250; i++ {
+				_, err := BroadcastTxAsync(&rpctypes.Context{}, types.Tx{byte(c), byte(i)})
+				mtx.Lock()
+				if err != nil {
+					assert.ErrorIs(t, err, rpctypes.ErrOverloaded)
+					rejected++
+				} else {
+					accepted++
+				}
+				mtx.Unlock()
+				time.Sleep(4 * interval / 10)
+			}
+		}(c)
+	}
+	wg.Wait()
+
+	mem.mtx.Lock()
+	defer mem.mtx.Unlock()
+	assert.LessOrEqual(t, mem.maxQueued, depth, "the txs waiting to be checked are bounded")
+	assert.LessOrEqual(t, accepted, mem.checked+depth)
+	assert.Equal(t, 1000, accepted+rejected)
+	assert.Greater(t, rejected, accepted, "most txs are pushed back to the clients")
+}
+
+func TestBroadcastTxSyncWaitsForAdmission(t *testing.T) {
+	mem := &slowMempool{}
+	admission := NewTxAdmission(1, 50*time.Millisecond, nil)
+	SetEnvironment(&Environment{
+		Mempool:     mem,
+		TxAdmission: admission,
+		Logger:      log.TestingLogger(),
+	})
+
+	_, err := BroadcastTxAsync(&rpctypes.Context{}, types.Tx("a"))
+	require.NoError(t, err)
+	assert.Equal(t, 1, admission.Pending())
+
+	// async does not wait
+	_, err = BroadcastTxAsync(&rpctypes.Context{}, types.Tx("b"))
+	require.ErrorIs(t, err, rpctypes.ErrOverloaded)
+
+	// sync waits, up to the admission wait
+	start := time.Now()
+	_, err = BroadcastTxSync(&rpctypes.Context{}, types.Tx("c"))
+	require.ErrorIs(t, err, rpctypes.ErrOverloaded)
+	assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+
+	// and is admitted once the pending tx is checked
+	time.AfterFunc(10*time.Millisecond, mem.checkNext)
+	done := make(chan error, 1)
+	go func() {
+		_, err := BroadcastTxSync(&rpctypes.Context{}, types.Tx("d"))
+		done <- err
+	}()
+	require.Eventually(t, func() bool {
+		mem.mtx.Lock()
+		defer mem.mtx.Unlock()
+		return len(mem.queue) == 1 && mem.checked == 1
+	}, time.Second, time.Millisecond)
+	mem.checkNext()
+	require.NoError(t, <-done)
+	assert.Zero(t, admission.Pending())
+
+	// a tx rejected by the mempool releases its slot
+	SetEnvironment(&Environment{
+		Mempool:     failingMempool{},
+		TxAdmission: admission,
+		Logger:      log.TestingLogger(),
+	})
+	_, err = BroadcastTxAsync(&rpctypes.Context{}, types.Tx("e"))
+	require.Error(t, err)
+	assert.Zero(t, admission.Pending())
+}
+
+type failingMempool struct {
+	mock.Mempool
+}
+
+func (failingMempool) CheckTx(types.Tx, func(*abci.Response), mempl.TxInfo) error {
+	return errors.New("mempool is full")
+}
+
+// blockingApp blocks in CheckTx until unblocked, signaling each tx it gets.
+type blockingApp struct {
+	abci.BaseApplication
+
+	checking chan struct{}
+	unblock  chan struct{}
+}
+
+func (app *blockingApp) CheckTx(abci.RequestCheckTx) abci.ResponseCheckTx {
+	app.checking <- struct{}{}
+	<-app.unblock
+	return abci.ResponseCheckTx{}
+}
+
+func TestBroadcastTxReleasesAdmissionOnAppDisconnect(t *testing.T) {
+	app := &blockingApp{
+		checking: make(chan struct{}, 2),
+		unblock:  make(chan struct{}),
+	}
+	defer close(app.unblock)
+
+	addr := fmt.Sprintf("unix://%s/app.sock", t.TempDir())
+	server := abciserver.NewSocketServer(addr, app)
+	server.SetLogger(log.TestingLogger())
+	require.NoError(t, server.Start())
+	client := abcicli.NewSocketClient(addr, true)
+	client.SetLogger(log.TestingLogger())
+	require.NoError(t, client.Start())
+	t.Cleanup(func() {
+		_ = client.Stop()
+	})
+
+	mem := mempoolv0.NewCListMempool(cfg.TestMempoolConfig(), proxy.NewAppConnMempool(client), 0)
+	admission := NewTxAdmission(2, 0, nil)
+	SetEnvironment(&Environment{
+		Mempool:     mem,
+		TxAdmission: admission,
+		Logger:      log.TestingLogger(),
+	})
+
+	// the sync tx is in flight, the async one queued behind it
+	done := make(chan error, 1)
+	go func() {
+		_, err := BroadcastTxSync(&rpctypes.Context{}, types.Tx("a"))
+		done <- err
+	}()
+	<-app.checking
+	_, err := BroadcastTxAsync(&rpctypes.Context{}, types.Tx("b"))
+	require.NoError(t, err)
+	assert.Equal(t, 2, admission.Pending())
+
+	// disconnecting the app ends both requests without response
+	require.NoError(t, server.Stop())
+	select {
+	case err := <-done:
+		require.ErrorIs(t, err, ErrNoCheckTxResponse)
+	case <-time.After(5 * time.Second):
+		t.Fatal("broadcast_tx_sync did not return")
+	}
+	require.Eventually(t, func() bool {
+		return admission.Pending() == 0
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// and the txs may be submitted again, once the app is back
+	assert.Zero(t, mem.Size())
+}
@@ -0,0 +1,1179 @@
+package core
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/celestiaorg/nmt"
+	dbm "github.com/cometbft/cometbft-db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/merkle"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	cmtbytes "github.com/tendermint/tendermint/libs/bytes"
+	"github.com/tendermint/tendermint/libs/log"
+	mempl "github.com/tendermint/tendermint/mempool"
+	"github.com/tendermint/tendermint/mempool/mock"
+	"github.com/tendermint/tendermint/pkg/consts"
+	cmtcrypto "github.com/tendermint/tendermint/proto/tendermint/crypto"
+	cmtproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/proxy"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+	sm "github.com/tendermint/tendermint/state"
+	blockidxkv "github.com/tendermint/tendermint/state/indexer/block/kv"
+	blockidxnull "github.com/tendermint/tendermint/state/indexer/block/null"
+	"github.com/tendermint/tendermint/state/txindex"
+	"github.com/tendermint/tendermint/state/txindex/kv"
+	"github.com/tendermint/tendermint/state/txindex/null"
+	"github.com/tendermint/tendermint/store"
+	"github.com/tendermint/tendermint/types"
+	cmttime "github.com/tendermint/tendermint/types/time"
+)
+
+func TestProveSharesBatch(t *testing.T) {
+	const height = 1
+	blockStore := store.NewBlockStore(dbm.NewMemDB())
+	block := types.MakeBlock(height, types.Data{SquareSize: 2}, new(types.Commit), nil)
+	block.ProposerAddress = make([]byte, crypto.AddressSize)
+	partSet := block.MakePartSet(types.BlockPartSizeBytes)
+	blockStore.SaveBlock(block, partSet, &types.Commit{Height: height})
+
+	app := &shareProofApp{}
+	proxyApp := proxy.NewAppConns(proxy.NewLocalClientCreator(app))
+	require.NoError(t, proxyApp.Start())
+	t.Cleanup(func() {
+		if err := proxyApp.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	SetEnvironment(&Environment{BlockStore: blockStore, ProxyAppQuery: proxyApp.Query()})
+
+	ranges := []ctypes.ShareRange{
+		{Start: 0, End: 2},
+		{Start: 3, End: 3}, // empty
+		{Start: 1, End: 4},
+		{Start: 2, End: 5}, // beyond the square
+		{Start: 3, End: 4}, // rejected by the app
+	}
+	app.failStart = 3
+	res, err := ProveSharesBatch(&rpctypes.Context{}, height, ranges)
+	require.NoError(t, err)
+	require.Equal(t, int64(height), res.Height)
+	require.Len(t, res.Proofs, len(ranges))
+
+	for i, r := range ranges {
+		assert.Equal(t, r, res.Proofs[i].Range)
+	}
+	for _, i := range []int{0, 2} {
+		require.Empty(t, res.Proofs[i].Error)
+		require.NotNil(t, res.Proofs[i].ShareProof)
+		assert.Len(t, res.Proofs[i].ShareProof.Data, int(ranges[i].End-ranges[i].Start))
+	}
+	for _, i := range []int{1, 3, 4} {
+		assert.NotEmpty(t, res.Proofs[i].Error)
+		assert.Nil(t, res.Proofs[i].ShareProof)
+	}
+	assert.Equal(t, "invalid range", res.Proofs[4].Error)
+	// invalid ranges are not sent to the app
+	assert.Equal(t, 3, app.queries)
+
+	_, err = ProveSharesBatch(&rpctypes.Context{}, height, nil)
+	assert.Error(t, err)
+	_, err = ProveSharesBatch(&rpctypes.Context{}, height, make([]ctypes.ShareRange, maxShareRangesPerBatch+1))
+	assert.Error(t, err)
+	_, err = ProveSharesBatch(&rpctypes.Context{}, height+1, ranges)
+	assert.Error(t, err)
+}
+
+func TestProveSharesPaged(t *testing.T) {
+	const height = 1
+	blockStore := store.NewBlockStore(dbm.NewMemDB())
+	block := types.MakeBlock(height, types.Data{SquareSize: 4}, new(types.Commit), nil)
+	block.ProposerAddress = make([]byte, crypto.AddressSize)
+	partSet := block.MakePartSet(types.BlockPartSizeBytes)
+	blockStore.SaveBlock(block, partSet, &types.Commit{Height: height})
+
+	app := &shareProofApp{failStart: 16}
+	proxyApp := proxy.NewAppConns(proxy.NewLocalClientCreator(app))
+	require.NoError(t, proxyApp.Start())
+	t.Cleanup(func() {
+		if err := proxyApp.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	SetEnvironment(&Environment{BlockStore: blockStore, ProxyAppQuery: proxyApp.Query()})
+
+	// the range spans the 4 rows of the square, partially for the first and
+	// last ones
+	const startShare, endShare = 2, 14
+	var chunks []ctypes.ShareProofChunk
+	perPage := 3
+	for page := 1; page <= 2; page++ {
+		page := page
+		res, err := ProveSharesPaged(&rpctypes.Context{}, height, startShare, endShare, &page, &perPage)
+		require.NoError(t, err)
+		assert.Equal(t, int64(height), res.Height)
+		assert.Equal(t, 4, res.TotalCount)
+		chunks = append(chunks, res.Chunks...)
+	}
+	assert.Equal(t, []ctypes.ShareRange{{Start: 2, End: 4}, {Start: 4, End: 8}, {Start: 8, End: 12}, {Start: 12, End: 14}},
+		[]ctypes.ShareRange{chunks[0].Range, chunks[1].Range, chunks[2].Range, chunks[3].Range})
+	// the chunks stitch together to cover the range with no gaps
+	next := uint64(startShare)
+	for _, chunk := range chunks {
+		assert.Equal(t, next, chunk.Range.Start)
+		require.Len(t, chunk.ShareProof.Data, int(chunk.Range.End-chunk.Range.Start))
+		for i, share := range chunk.ShareProof.Data {
+			assert.Equal(t, []byte{byte(chunk.Range.Start) + byte(i)}, share)
+		}
+		next = chunk.Range.End
+	}
+	assert.EqualValues(t, endShare, next)
+	assert.Equal(t, 4, app.queries)
+
+	// a range within a single row is a single chunk
+	res, err := ProveSharesPaged(&rpctypes.Context{}, height, 5, 7, nil, nil)
+	require.NoError(t, err)
+	require.Len(t, res.Chunks, 1)
+	assert.Equal(t, ctypes.ShareRange{Start: 5, End: 7}, res.Chunks[0].Range)
+
+	page := 3
+	_, err = ProveSharesPaged(&rpctypes.Context{}, height, startShare, endShare, &page, &perPage)
+	assert.Error(t, err)
+	_, err = ProveSharesPaged(&rpctypes.Context{}, height, 3, 3, nil, nil)
+	assert.Error(t, err)
+	_, err = ProveSharesPaged(&rpctypes.Context{}, height, 0, 17, nil, nil)
+	assert.Error(t, err)
+	_, err = ProveSharesPaged(&rpctypes.Context{}, height+1, startShare, endShare, nil, nil)
+	assert.Error(t, err)
+	app.failStart = 8
+	_, err = ProveSharesPaged(&rpctypes.Context{}, height, startShare, endShare, nil, nil)
+	assert.Error(t, err)
+}
+
+func TestRowNamespaceRanges(t *testing.T) {
+	ns := func(b byte) []byte { return bytes.Repeat([]byte{b}, consts.NamespaceSize) }
+	rowRoot := func(minNs, maxNs byte) []byte {
+		return append(append(ns(minNs), ns(maxNs)...), bytes.Repeat([]byte{0xaa}, 32)...)
+	}
+	// the first row holds namespaces 1 and 2, the second one 2 to 4
+	rowRoots := [][]byte{rowRoot(1, 2), rowRoot(2, 4)}
+	dataHash, proofs := merkle.ProofsFromByteSlices(rowRoots)
+
+	const height = 1
+	newBlockStore := func() *store.BlockStore {
+		blockStore := store.NewBlockStore(dbm.NewMemDB())
+		block := types.MakeBlock(height, types.Data{SquareSize: 2}, new(types.Commit), nil)
+		block.ProposerAddress = make([]byte, crypto.AddressSize)
+		block.DataHash = dataHash
+		partSet := block.MakePartSet(types.BlockPartSizeBytes)
+		blockStore.SaveBlock(block, partSet, &types.Commit{Height: height})
+		return blockStore
+	}
+	blockStore := newBlockStore()
+
+	app := &shareProofApp{
+		rowRoots:  rowRoots,
+		failStart: 4, // beyond the square, never requested
+	}
+	for _, proof := range proofs {
+		app.rowRootProofs = append(app.rowRootProofs, proof.ToProto())
+	}
+	proxyApp := proxy.NewAppConns(proxy.NewLocalClientCreator(app))
+	require.NoError(t, proxyApp.Start())
+	t.Cleanup(func() {
+		if err := proxyApp.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	SetEnvironment(&Environment{BlockStore: blockStore, ProxyAppQuery: proxyApp.Query(), Logger: log.TestingLogger()})
+	rowProofs = newRowProofCache(rowProofCacheSize)
+
+	// the block was saved without its row proof, which is computed by the app
+	// and saved
+	rowProof, err := blockStore.LoadRowProof(height)
+	require.NoError(t, err)
+	require.Nil(t, rowProof)
+	res, err := RowNamespaceRanges(&rpctypes.Context{}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, int64(height), res.Height)
+	assert.Equal(t, []ctypes.RowNamespaceRange{
+		{Row: 0, MinNamespace: ns(1), MaxNamespace: ns(2)},
+		{Row: 1, MinNamespace: ns(2), MaxNamespace: ns(4)},
+	}, res.Rows)
+	assert.Equal(t, 1, app.queries)
+
+	// the square is not recomputed by the app once cached
+	res2, err := RowNamespaceRanges(&rpctypes.Context{}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, res, res2)
+	assert.Equal(t, 1, app.queries)
+
+	h := int64(height + 1)
+	_, err = RowNamespaceRanges(&rpctypes.Context{}, &h)
+	assert.Error(t, err)
+
+	// nor once evicted, as it is loaded from the block store
+	rowProof, err = blockStore.LoadRowProof(height)
+	require.NoError(t, err)
+	require.NotNil(t, rowProof)
+	require.Len(t, rowProof.RowRoots, 2)
+	assert.EqualValues(t, rowRoots[1], rowProof.RowRoots[1])
+	rowProofs = newRowProofCache(rowProofCacheSize)
+	res2, err = RowNamespaceRanges(&rpctypes.Context{}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, res, res2)
+	assert.Equal(t, 1, app.queries)
+
+	blockStore = newBlockStore()
+	SetEnvironment(&Environment{BlockStore: blockStore, ProxyAppQuery: proxyApp.Query(), Logger: log.TestingLogger()})
+	rowProofs = newRowProofCache(rowProofCacheSize)
+
+	// the app proves fewer rows than the square has
+	app.rowRoots = rowRoots[:1]
+	_, err = RowNamespaceRanges(&rpctypes.Context{}, nil)
+	assert.Error(t, err)
+
+	// the app proves row roots which do not match the data hash
+	app.rowRoots = [][]byte{rowRoot(1, 1), rowRoot(2, 4)}
+	_, err = RowNamespaceRanges(&rpctypes.Context{}, nil)
+	assert.Error(t, err)
+	assert.Zero(t, rowProofs.Len())
+	rowProof, err = blockStore.LoadRowProof(height)
+	require.NoError(t, err)
+	assert.Nil(t, rowProof)
+}
+
+func TestEstimateNamespaceProofSize(t *testing.T) {
+	ns := func(b byte) []byte { return bytes.Repeat([]byte{b}, consts.NamespaceSize) }
+	rowRoot := func(minNs, maxNs byte) []byte {
+		return append(append(ns(minNs), ns(maxNs)...), bytes.Repeat([]byte{0xaa}, 32)...)
+	}
+	// the first row only holds namespace 2, the second one 2 to 4
+	rowRoots := [][]byte{rowRoot(2, 2), rowRoot(2, 4)}
+	dataHash, proofs := merkle.ProofsFromByteSlices(rowRoots)
+
+	const height = 1
+	blockStore := store.NewBlockStore(dbm.NewMemDB())
+	block := types.MakeBlock(height, types.Data{SquareSize: 2}, new(types.Commit), nil)
+	block.ProposerAddress = make([]byte, crypto.AddressSize)
+	block.DataHash = dataHash
+	partSet := block.MakePartSet(types.BlockPartSizeBytes)
+	blockStore.SaveBlock(block, partSet, &types.Commit{Height: height})
+
+	app := &shareProofApp{
+		rowRoots:  rowRoots,
+		failStart: 4, // beyond the square, never requested
+	}
+	for _, proof := range proofs {
+		app.rowRootProofs = append(app.rowRootProofs, proof.ToProto())
+	}
+	proxyApp := proxy.NewAppConns(proxy.NewLocalClientCreator(app))
+	require.NoError(t, proxyApp.Start())
+	t.Cleanup(func() {
+		if err := proxyApp.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	SetEnvironment(&Environment{BlockStore: blockStore, ProxyAppQuery: proxyApp.Query()})
+	rowProofs = newRowProofCache(rowProofCacheSize)
+
+	// an NMT proof node is 90 bytes, and the NMT of the extended rows of 4
+	// shares has depth 2, so each row adds 5 nodes, the row root and its Merkle
+	// proof with a single aunt
+	const rowSize = 5*90 + 2*32
+	tests := []struct {
+		namespace byte
+		rows      int
+		shares    int
+	}{
+		{1, 0, 0},
+		{2, 2, 3}, // fills the first row
+		{3, 1, 1},
+		{5, 0, 0},
+	}
+	for _, tt := range tests {
+		res, err := EstimateNamespaceProofSize(&rpctypes.Context{}, height, ns(tt.namespace))
+		require.NoError(t, err)
+		assert.Equal(t, &ctypes.ResultProofSizeEstimate{
+			Height:    height,
+			Namespace: ns(tt.namespace),
+			Rows:      tt.rows,
+			NMTDepth:  2,
+			Shares:    tt.shares,
+			Size:      int64(tt.shares*consts.ShareSize + tt.rows*rowSize),
+		}, res, tt.namespace)
+	}
+	assert.Equal(t, 1, app.queries)
+
+	_, err := EstimateNamespaceProofSize(&rpctypes.Context{}, height, ns(2)[1:])
+	assert.Error(t, err)
+	_, err = EstimateNamespaceProofSize(&rpctypes.Context{}, height+1, ns(2))
+	assert.Error(t, err)
+}
+
+func TestVerifyNamespaceCompleteness(t *testing.T) {
+	const height = 1
+	ns := func(b byte) []byte { return append(make([]byte, consts.NamespaceSize-1), b) }
+	share := func(b byte) []byte { return append(ns(b), make([]byte, consts.ShareSize-consts.NamespaceSize)...) }
+	// namespace 2 spans shares 2 to 9, over the first three rows
+	var shares [][]byte
+	for i, b := range []byte{1, 1, 2, 2, 2, 2, 2, 2, 2, 2, 3, 3, 3, 3, 3, 3} {
+		shares = append(shares, share(b))
+		shares[i][consts.NamespaceSize] = byte(i) // make the shares distinct
+	}
+	square := newTestSquare(t, 4, shares)
+
+	blockStore := store.NewBlockStore(dbm.NewMemDB())
+	block := types.MakeBlock(height, types.Data{SquareSize: 4}, new(types.Commit), nil)
+	block.DataHash = square.dataRoot
+	block.ProposerAddress = make([]byte, crypto.AddressSize)
+	partSet := block.MakePartSet(types.BlockPartSizeBytes)
+	blockStore.SaveBlock(block, partSet, &types.Commit{Height: height})
+
+	proxyApp := proxy.NewAppConns(proxy.NewLocalClientCreator(&squareApp{square: square}))
+	require.NoError(t, proxyApp.Start())
+	t.Cleanup(func() {
+		if err := proxyApp.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+	SetEnvironment(&Environment{BlockStore: blockStore, ProxyAppQuery: proxyApp.Query()})
+	rowProofs = newRowProofCache(rowProofCacheSize)
+
+	prove := func(ranges ...ctypes.ShareRange) []cmtproto.ShareProof {
+		var proofs []cmtproto.ShareProof
+		for _, r := range ranges {
+			proofs = append(proofs, square.prove(r.Start, r.End))
+		}
+		return proofs
+	}
+	tests := []struct {
+		name      string
+		namespace byte
+		proofs    []ctypes.ShareRange
+		shares    ctypes.ShareRange
+		gaps      []ctypes.ShareRange
+	}{
+		{"complete", 2, []ctypes.ShareRange{{Start: 2, End: 6}, {Start: 6, End: 10}}, ctypes.ShareRange{Start: 2, End: 10}, nil},
+		{"unordered and overlapping", 2, []ctypes.ShareRange{{Start: 5, End: 10}, {Start: 2, End: 7}}, ctypes.ShareRange{Start: 2, End: 10}, nil},
+		{"gap in the middle", 2, []ctypes.ShareRange{{Start: 2, End: 4}, {Start: 8, End: 10}}, ctypes.ShareRange{Start: 2, End: 10},
+			[]ctypes.ShareRange{{Start: 4, End: 8}}},
+		{"gaps at both ends", 2, []ctypes.ShareRange{{Start: 3, End: 5}}, ctypes.ShareRange{Start: 2, End: 10},
+			[]ctypes.ShareRange{{Start: 2, End: 3}, {Start: 5, End: 10}}},
+		{"no proof", 3, nil, ctypes.ShareRange{Start: 10, End: 16}, []ctypes.ShareRange{{Start: 10, End: 16}}},
+		{"absent namespace", 5, nil, ctypes.ShareRange{}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := VerifyNamespaceCompleteness(&rpctypes.Context{}, height, ns(tt.namespace)[1:], prove(tt.proofs...))
+			require.NoError(t, err)
+			assert.EqualValues(t, height, res.Height)
+			assert.EqualValues(t, ns(tt.namespace)[1:], res.NamespaceID)
+			assert.Equal(t, tt.shares, res.Shares)
+			assert.Equal(t, tt.gaps, res.Gaps)
+			assert.Equal(t, len(tt.gaps) == 0, res.Complete)
+		})
+	}
+
+	// the proofs must be valid proofs of the namespace
+	_, err := VerifyNamespaceCompleteness(&rpctypes.Context{}, height, ns(2)[1:], prove(ctypes.ShareRange{Start: 10, End: 12}))
+	assert.Error(t, err)
+	tampered := prove(ctypes.ShareRange{Start: 2, End: 4})
+	tampered[0].Data = [][]byte{shares[4], shares[5]}
+	_, err = VerifyNamespaceCompleteness(&rpctypes.Context{}, height, ns(2)[1:], tampered)
+	assert.Error(t, err)
+
+	_, err = VerifyNamespaceCompleteness(&rpctypes.Context{}, height, ns(2), nil)
+	assert.Error(t, err)
+	_, err = VerifyNamespaceCompleteness(&rpctypes.Context{}, height+1, ns(2)[1:], nil)
+	assert.Error(t, err)
+}
+
+func TestRowProofCache(t *testing.T) {
+	rowProof := func(rowRoots ...[]byte) ([]byte, types.RowProof) {
+		root, proofs := merkle.ProofsFromByteSlices(rowRoots)
+		rp := types.RowProof{Proofs: proofs, EndRow: uint32(len(rowRoots) - 1)}
+		for _, rowRoot := range rowRoots {
+			rp.RowRoots = append(rp.RowRoots, rowRoot)
+		}
+		return root, rp
+	}
+	hash1, proof1 := rowProof([]byte("a"), []byte("b"))
+	hash2, proof2 := rowProof([]byte("c"))
+	hash3, proof3 := rowProof([]byte("d"), []byte("e"))
+
+	cache := newRowProofCache(2)
+	require.NoError(t, cache.Add(hash1, proof1))
+	require.NoError(t, cache.Add(hash2, proof2))
+	// a proof is only added for its own data hash
+	require.Error(t, cache.Add(hash3, proof1))
+	assert.Equal(t, 2, cache.Len())
+
+	// the least recently used proof is evicted
+	_, ok := cache.Get(hash1)
+	require.True(t, ok)
+	require.NoError(t, cache.Add(hash3, proof3))
+	_, ok = cache.Get(hash2)
+	assert.False(t, ok)
+	got, ok := cache.Get(hash1)
+	require.True(t, ok)
+	assert.Equal(t, proof1, got)
+	got, ok = cache.Get(hash3)
+	require.True(t, ok)
+	assert.Equal(t, proof3, got)
+}
+
+func TestProveTxIncludesShares(t *testing.T) {
+	const height = 1
+	txs := types.Txs{types.Tx("a=1"), types.Tx("b=2")}
+	blockStore := store.NewBlockStore(dbm.NewMemDB())
+	block := types.MakeBlock(height, types.Data{Txs: txs, SquareSize: 1}, new(types.Commit), nil)
+	block.ProposerAddress = make([]byte, crypto.AddressSize)
+	partSet := block.MakePartSet(types.BlockPartSizeBytes)
+	blockStore.SaveBlock(block, partSet, &types.Commit{Height: height})
+
+	// a compact share holding the length delimited txs
+	share := make([]byte, consts.NamespaceSize)
+	share = append(share, 1)                // info byte of the first share of a sequence
+	share = append(share, 0, 0, 0, 8)       // sequence length
+	share = append(share, 0, 0, 0, 38)      // index of the first tx
+	share = append(share, 3, 'a', '=', '1') // first tx
+	share = append(share, 3, 'b', '=', '2') // second tx
+	share = append(share, make([]byte, 512-len(share))...)
+
+	app := &shareProofApp{
+		failStart: 1, // beyond the square, never requested
+		txProof: cmtproto.ShareProof{
+			ShareProofs: []*cmtproto.NMTProof{{Start: 0, End: 1}},
+			RowProof: &cmtproto.RowProof{
+				RowRoots: [][]byte{make([]byte, 2*consts.NamespaceSize+32)},
+				Proofs:   []*cmtcrypto.Proof{{Total: 4}},
+			},
+		},
+	}
+	proxyApp := proxy.NewAppConns(proxy.NewLocalClientCreator(app))
+	require.NoError(t, proxyApp.Start())
+	t.Cleanup(func() {
+		if err := proxyApp.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+	SetEnvironment(&Environment{BlockStore: blockStore, ProxyAppQuery: proxyApp.Query()})
+
+	// the app leaves the shares out, they are proven separately, and checked
+	_, err := proveTx(height, 1)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "share 0 is too short")
+	assert.Equal(t, 2, app.queries)
+
+	// the shares returned by the app are checked to contain the tx
+	app.txProof.Data = [][]byte{share}
+	proof, err := proveTx(height, 1)
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{share}, proof.Data)
+	proofTxs, err := proof.CompactShareTxs()
+	require.NoError(t, err)
+	assert.Equal(t, []types.Tx(txs), proofTxs)
+
+	app.txProof.Data = [][]byte{append(share[:consts.NamespaceSize+9:consts.NamespaceSize+9], make([]byte, 512-consts.NamespaceSize-9)...)}
+	_, err = proveTx(height, 1)
+	assert.Error(t, err)
+
+	_, err = proveTx(height, 2)
+	assert.Error(t, err)
+
+	// the empty proofs of an app not proving txs are returned as is
+	app.txProof = cmtproto.ShareProof{}
+	proof, err = proveTx(height, 1)
+	require.NoError(t, err)
+	assert.Empty(t, proof.Data)
+}
+
+func TestProveTxShares(t *testing.T) {
+	const height = 1
+	var (
+		txNamespace   = append(make([]byte, consts.NamespaceSize-1), 1)
+		blobNamespace = append(make([]byte, consts.NamespaceSize-1), 2)
+		// the PFB spans the two compact shares, and the blob the two sparse
+		// shares following them
+		pfb  = bytes.Repeat([]byte{1}, 600)
+		blob = &cmtproto.Blob{NamespaceId: blobNamespace[1:], Data: bytes.Repeat([]byte{2}, 600)}
+		tx   = types.Tx("a=1")
+	)
+	blobTx, err := types.MarshalBlobTx(pfb, blob)
+	require.NoError(t, err)
+	wrappedPFB, err := types.MarshalIndexWrapper(pfb, 2)
+	require.NoError(t, err)
+	shares := append(testCompactShares(txNamespace, wrappedPFB, tx), testSparseShares(blobNamespace, 0, nil, blob.Data)...)
+	require.Len(t, shares, 4)
+	square := newTestSquare(t, 2, shares)
+
+	blockStore := store.NewBlockStore(dbm.NewMemDB())
+	block := types.MakeBlock(height, types.Data{Txs: types.Txs{blobTx, tx}, SquareSize: 2}, new(types.Commit), nil)
+	block.DataHash = square.dataRoot
+	block.ProposerAddress = make([]byte, crypto.AddressSize)
+	partSet := block.MakePartSet(types.BlockPartSizeBytes)
+	blockStore.SaveBlock(block, partSet, &types.Commit{Height: height})
+
+	app := &squareApp{square: square, txShares: []ctypes.ShareRange{{Start: 0, End: 2}, {Start: 1, End: 2}}}
+	proxyApp := proxy.NewAppConns(proxy.NewLocalClientCreator(app))
+	require.NoError(t, proxyApp.Start())
+	t.Cleanup(func() {
+		if err := proxyApp.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+	SetEnvironment(&Environment{BlockStore: blockStore, ProxyAppQuery: proxyApp.Query()})
+
+	// only the shares of the PFB are proven
+	res, err := ProveTxShares(&rpctypes.Context{}, height, types.Tx(blobTx).Hash(), false)
+	require.NoError(t, err)
+	assert.EqualValues(t, height, res.Height)
+	assert.EqualValues(t, 0, res.Index)
+	assert.Empty(t, res.BlobProofs)
+	assert.Equal(t, shares[:2], res.TxProof.Data)
+	require.NoError(t, res.TxProof.Validate(block.DataHash))
+
+	// the shares of the blob are proven separately, each proof against the
+	// data hash on its own
+	res, err = ProveTxShares(&rpctypes.Context{}, height, types.Tx(blobTx).Hash(), true)
+	require.NoError(t, err)
+	require.NoError(t, res.TxProof.Validate(block.DataHash))
+	require.Len(t, res.BlobProofs, 1)
+	blobProof := res.BlobProofs[0]
+	assert.Equal(t, ctypes.ShareRange{Start: 2, End: 4}, blobProof.Range)
+	assert.Equal(t, shares[2:], blobProof.ShareProof.Data)
+	assert.True(t, blobProof.ShareProof.DataNamespacesConsistent())
+	require.NoError(t, blobProof.ShareProof.Validate(block.DataHash))
+	assert.Error(t, blobProof.ShareProof.Validate(tmhash.Sum([]byte("other root"))))
+
+	// a tx that is not a blob tx has no blob
+	res, err = ProveTxShares(&rpctypes.Context{}, height, tx.Hash(), false)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, res.Index)
+	require.NoError(t, res.TxProof.Validate(block.DataHash))
+	_, err = ProveTxShares(&rpctypes.Context{}, height, tx.Hash(), true)
+	assert.Error(t, err)
+
+	_, err = ProveTxShares(&rpctypes.Context{}, height, types.Tx("b=2").Hash(), false)
+	assert.Error(t, err)
+	_, err = ProveTxShares(&rpctypes.Context{}, height+1, tx.Hash(), false)
+	assert.Error(t, err)
+}
+
+func TestProveTxSharesSignedBlob(t *testing.T) {
+	const height = 1
+	var (
+		txNamespace   = append(make([]byte, consts.NamespaceSize-1), 1)
+		blobNamespace = append(make([]byte, consts.NamespaceSize-1), 2)
+		signer        = bytes.Repeat([]byte{3}, 20)
+		// the data of the blob would fit in the first share of a blob of
+		// share version 0, but with the signer it spans two shares
+		pfb  = bytes.Repeat([]byte{1}, 600)
+		blob = &cmtproto.Blob{NamespaceId: blobNamespace[1:], Data: bytes.Repeat([]byte{2}, 470), ShareVersion: 1}
+	)
+	blobTx, err := types.MarshalBlobTx(pfb, blob)
+	require.NoError(t, err)
+	wrappedPFB, err := types.MarshalIndexWrapper(pfb, 2)
+	require.NoError(t, err)
+	shares := append(testCompactShares(txNamespace, wrappedPFB), testSparseShares(blobNamespace, 1, signer, blob.Data)...)
+	require.Len(t, shares, 4)
+	square := newTestSquare(t, 2, shares)
+
+	blockStore := store.NewBlockStore(dbm.NewMemDB())
+	block := types.MakeBlock(height, types.Data{Txs: types.Txs{blobTx}, SquareSize: 2}, new(types.Commit), nil)
+	block.DataHash = square.dataRoot
+	block.ProposerAddress = make([]byte, crypto.AddressSize)
+	partSet := block.MakePartSet(types.BlockPartSizeBytes)
+	blockStore.SaveBlock(block, partSet, &types.Commit{Height: height})
+
+	app := &squareApp{square: square, txShares: []ctypes.ShareRange{{Start: 0, End: 2}}}
+	proxyApp := proxy.NewAppConns(proxy.NewLocalClientCreator(app))
+	require.NoError(t, proxyApp.Start())
+	t.Cleanup(func() {
+		if err := proxyApp.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+	SetEnvironment(&Environment{BlockStore: blockStore, ProxyAppQuery: proxyApp.Query()})
+
+	res, err := ProveTxShares(&rpctypes.Context{}, height, types.Tx(blobTx).Hash(), true)
+	require.NoError(t, err)
+	require.Len(t, res.BlobProofs, 1)
+	blobProof := res.BlobProofs[0]
+	assert.Equal(t, ctypes.ShareRange{Start: 2, End: 4}, blobProof.Range)
+	assert.Equal(t, shares[2:], blobProof.ShareProof.Data)
+	require.NoError(t, blobProof.ShareProof.Validate(block.DataHash))
+}
+
+func TestTxBundle(t *testing.T) {
+	const (
+		height  = 1
+		chainID = "test-chain"
+	)
+	namespace := append(make([]byte, consts.NamespaceSize-1), 1)
+	txs := types.Txs{types.Tx("a=1"), types.Tx("b=2")}
+	shares := testCompactShares(namespace, txs...)
+	require.Len(t, shares, 1)
+	square := newTestSquare(t, 1, shares)
+
+	vals, privVals := types.RandValidatorSet(2, 10)
+	stateStore := sm.NewStore(dbm.NewMemDB(), sm.StoreOptions{})
+	require.NoError(t, stateStore.Save(sm.State{
+		ChainID:         chainID,
+		InitialHeight:   height,
+		Validators:      vals,
+		NextValidators:  vals.CopyIncrementProposerPriority(1),
+		LastValidators:  types.NewValidatorSet(nil),
+		ConsensusParams: *types.DefaultConsensusParams(),
+	}))
+
+	makeBlock := func(height int64, data types.Data, lastCommit *types.Commit) *types.Block {
+		block := types.MakeBlock(height, data, lastCommit, nil)
+		block.ChainID = chainID
+		block.ValidatorsHash = vals.Hash()
+		block.NextValidatorsHash = vals.Hash()
+		block.ProposerAddress = vals.Proposer.Address
+		return block
+	}
+	makeCommit := func(block *types.Block) (*types.PartSet, *types.Commit) {
+		partSet := block.MakePartSet(types.BlockPartSizeBytes)
+		blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: partSet.Header()}
+		voteSet := types.NewVoteSet(chainID, block.Height, 0, cmtproto.PrecommitType, vals)
+		commit, err := types.MakeCommit(blockID, block.Height, 0, voteSet, privVals, cmttime.Now())
+		require.NoError(t, err)
+		return partSet, commit
+	}
+
+	blockStore := store.NewBlockStore(dbm.NewMemDB())
+	block := makeBlock(height, types.Data{Txs: txs, SquareSize: 1}, new(types.Commit))
+	block.DataHash = square.dataRoot
+	partSet, commit := makeCommit(block)
+	blockStore.SaveBlock(block, partSet, commit)
+
+	txIndexer := kv.NewTxIndex(dbm.NewMemDB())
+	batch := txindex.NewBatch(0)
+	batch.Ops = append(batch.Ops, &abci.TxResult{Height: height, Index: 1, Tx: txs[1]})
+	require.NoError(t, txIndexer.AddBatch(batch))
+
+	app := &squareApp{square: square, txShares: []ctypes.ShareRange{{Start: 0, End: 1}, {Start: 0, End: 1}}}
+	proxyApp := proxy.NewAppConns(proxy.NewLocalClientCreator(app))
+	require.NoError(t, proxyApp.Start())
+	t.Cleanup(func() {
+		if err := proxyApp.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+	SetEnvironment(&Environment{
+		BlockStore:    blockStore,
+		StateStore:    stateStore,
+		TxIndexer:     txIndexer,
+		ProxyAppQuery: proxyApp.Query(),
+		GenDoc:        &types.GenesisDoc{ChainID: chainID},
+	})
+
+	// the next block is not committed yet, the seen commit is used
+	res, err := TxBundle(&rpctypes.Context{}, txs[1].Hash())
+	require.NoError(t, err)
+	assert.EqualValues(t, height, res.Height)
+	assert.EqualValues(t, 1, res.Index)
+	assert.Equal(t, txs[1], res.Tx)
+	assert.False(t, res.Canonical)
+	assert.Equal(t, block.Hash(), res.LightBlock.Hash())
+	require.NoError(t, res.Verify(chainID))
+	assert.Error(t, res.Verify("other-chain"))
+
+	// the canonical commit is used once the next block is committed
+	next := makeBlock(height+1, types.Data{}, commit)
+	nextPartSet, nextCommit := makeCommit(next)
+	blockStore.SaveBlock(next, nextPartSet, nextCommit)
+	res, err = TxBundle(&rpctypes.Context{}, txs[1].Hash())
+	require.NoError(t, err)
+	assert.True(t, res.Canonical)
+	require.NoError(t, res.Verify(chainID))
+
+	// tampering with any part of the bundle is detected
+	tampered := *res
+	tampered.Tx = txs[0]
+	assert.Error(t, tampered.Verify(chainID))
+	tampered.Hash = txs[0].Hash()
+	require.NoError(t, tampered.Verify(chainID), "the shares hold both txs")
+	tampered.Tx, tampered.Hash = types.Tx("c=3"), types.Tx("c=3").Hash()
+	assert.Error(t, tampered.Verify(chainID))
+
+	tampered = *res
+	tampered.Height = height + 1
+	assert.Error(t, tampered.Verify(chainID))
+
+	tampered = *res
+	header := *res.LightBlock.Header
+	header.DataHash = tmhash.Sum([]byte("other root"))
+	tampered.LightBlock = &types.LightBlock{
+		SignedHeader: &types.SignedHeader{Header: &header, Commit: res.LightBlock.Commit},
+		ValidatorSet: res.LightBlock.ValidatorSet,
+	}
+	assert.Error(t, tampered.Verify(chainID))
+
+	otherVals, _ := types.RandValidatorSet(2, 10)
+	tampered.LightBlock = &types.LightBlock{SignedHeader: res.LightBlock.SignedHeader, ValidatorSet: otherVals}
+	assert.Error(t, tampered.Verify(chainID))
+
+	_, err = TxBundle(&rpctypes.Context{}, types.Tx("c=3").Hash())
+	assert.Error(t, err)
+}
+
+func TestProveTxAbsence(t *testing.T) {
+	blockStore := store.NewBlockStore(dbm.NewMemDB())
+	txs := types.Txs{types.Tx("a=1"), types.Tx("b=2")}
+	saveBlock := func(height int64, dataHash []byte) {
+		block := types.MakeBlock(height, types.Data{Txs: txs}, new(types.Commit), nil)
+		if dataHash != nil {
+			block.DataHash = dataHash
+		}
+		block.ProposerAddress = make([]byte, crypto.AddressSize)
+		partSet := block.MakePartSet(types.BlockPartSizeBytes)
+		blockStore.SaveBlock(block, partSet, &types.Commit{Height: height})
+	}
+	saveBlock(1, nil)
+	// the data hash of the second block is a data root set by the app
+	saveBlock(2, bytes.Repeat([]byte{1}, tmhash.Size))
+
+	SetEnvironment(&Environment{BlockStore: blockStore})
+
+	absent := types.Tx("c=3").Hash()
+	res, err := ProveTxAbsence(&rpctypes.Context{}, 1, absent)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, res.Height)
+	assert.NoError(t, res.Proof.Validate(blockStore.LoadBlockMeta(1).Header.DataHash))
+
+	_, err = ProveTxAbsence(&rpctypes.Context{}, 1, txs[1].Hash())
+	assert.Error(t, err)
+	_, err = ProveTxAbsence(&rpctypes.Context{}, 1, []byte{1})
+	assert.Error(t, err)
+	_, err = ProveTxAbsence(&rpctypes.Context{}, 2, absent)
+	assert.Error(t, err)
+	_, err = ProveTxAbsence(&rpctypes.Context{}, 3, absent)
+	assert.Error(t, err)
+}
+
+func TestTxSearchGroupByHeight(t *testing.T) {
+	txIndexer := kv.NewTxIndex(dbm.NewMemDB())
+	batch := txindex.NewBatch(0)
+	for _, r := range []struct {
+		height int64
+		index  uint32
+	}{{1, 0}, {1, 1}, {2, 0}, {4, 0}, {4, 1}, {4, 2}} {
+		batch.Ops = append(batch.Ops, &abci.TxResult{
+			Height: r.height,
+			Index:  r.index,
+			Tx:     types.Tx(fmt.Sprintf("tx-%d-%d", r.height, r.index)),
+		})
+	}
+	require.NoError(t, txIndexer.AddBatch(batch))
+	SetEnvironment(&Environment{TxIndexer: txIndexer})
+
+	heights := func(txs []*ctypes.ResultTx) []int64 {
+		hs := make([]int64, len(txs))
+		for i, tx := range txs {
+			hs[i] = tx.Height
+		}
+		return hs
+	}
+
+	// flat by default
+	res, err := TxSearchMatchEvents(&rpctypes.Context{}, "tx.height > 0", false, nil, nil, "asc", false, false)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{1, 1, 2, 4, 4, 4}, heights(res.Txs))
+	assert.Nil(t, res.Groups)
+
+	// only the transactions of the page are grouped, in the requested order
+	page, perPage := 1, 5
+	res, err = TxSearchMatchEvents(&rpctypes.Context{}, "tx.height > 0", false, &page, &perPage, "desc", false, true)
+	require.NoError(t, err)
+	assert.Equal(t, 6, res.TotalCount)
+	assert.Empty(t, res.Txs)
+	require.Len(t, res.Groups, 3)
+	assert.Equal(t, int64(4), res.Groups[0].Height)
+	assert.Equal(t, []int64{4, 4, 4}, heights(res.Groups[0].Txs))
+	assert.Equal(t, int64(2), res.Groups[1].Height)
+	assert.Equal(t, int64(1), res.Groups[2].Height)
+	assert.Equal(t, []int64{1}, heights(res.Groups[2].Txs))
+}
+
+func TestTxSearchLimits(t *testing.T) {
+	txIndexer := kv.NewTxIndex(dbm.NewMemDB())
+	batch := txindex.NewBatch(0)
+	for height := int64(1); height <= 10; height++ {
+		batch.Ops = append(batch.Ops, &abci.TxResult{
+			Height: height,
+			Tx:     types.Tx(fmt.Sprintf("tx-%d", height)),
+		})
+	}
+	require.NoError(t, txIndexer.AddBatch(batch))
+	SetEnvironment(&Environment{TxIndexer: txIndexer})
+
+	res, err := TxSearch(&rpctypes.Context{}, "tx.height > 0", false, nil, nil, "asc")
+	require.NoError(t, err)
+	assert.Equal(t, 10, res.TotalCount)
+	assert.False(t, res.Truncated)
+
+	txIndexer.SetQueryLimits(kv.QueryLimits{MaxHeightSpan: 5})
+	_, err = TxSearch(&rpctypes.Context{}, "tx.height > 0", false, nil, nil, "asc")
+	require.ErrorIs(t, err, kv.ErrQueryTooBroad)
+	res, err = TxSearch(&rpctypes.Context{}, "tx.height > 2 AND tx.height <= 7", false, nil, nil, "asc")
+	require.NoError(t, err)
+	assert.Equal(t, 5, res.TotalCount)
+	assert.False(t, res.Truncated)
+
+	txIndexer.SetQueryLimits(kv.QueryLimits{MaxRows: 4})
+	res, err = TxSearch(&rpctypes.Context{}, "tx.height > 0", false, nil, nil, "asc")
+	require.NoError(t, err)
+	assert.True(t, res.Truncated)
+	assert.LessOrEqual(t, res.TotalCount, 4)
+}
+
+// acceptingMempool accepts every tx and keeps them in the order received.
+type acceptingMempool struct {
+	mock.Mempool
+
+	txs []types.Tx
+}
+
+func (mem *acceptingMempool) CheckTx(tx types.Tx, cb func(*abci.Response), _ mempl.TxInfo) error {
+	mem.txs = append(mem.txs, tx)
+	cb(&abci.Response{Value: &abci.Response_CheckTx{CheckTx: &abci.ResponseCheckTx{}}})
+	return nil
+}
+
+func TestTxByOriginalHash(t *testing.T) {
+	eventBus := types.NewEventBus()
+	require.NoError(t, eventBus.Start())
+	t.Cleanup(func() { require.NoError(t, eventBus.Stop()) })
+
+	// the app wraps the txs with a prefix that types.Tx.Hash does not know
+	// about, so they are only found by their original hash through the
+	// unwrapper registered on the indexer
+	prefix := []byte("wrapped:")
+	store := dbm.NewMemDB()
+	txIndexer := kv.NewTxIndex(store)
+	indexerService := txindex.NewIndexerService(txIndexer, blockidxkv.New(dbm.NewPrefixDB(store, []byte("block_events"))), eventBus, false)
+	indexerService.SetTxUnwrapper(func(tx types.Tx) (types.Tx, bool) {
+		if !bytes.HasPrefix(tx, prefix) {
+			return nil, false
+		}
+		return tx[len(prefix):], true
+	})
+	indexerService.SetLogger(log.TestingLogger())
+	require.NoError(t, indexerService.Start())
+	t.Cleanup(func() { require.NoError(t, indexerService.Stop()) })
+
+	mempool := &acceptingMempool{}
+	SetEnvironment(&Environment{
+		BlockStore:     mockBlockStore{height: 1},
+		TxIndexer:      txIndexer,
+		IndexerService: indexerService,
+		EventBus:       eventBus,
+		Mempool:        mempool,
+		Logger:         log.TestingLogger(),
+	})
+
+	original := types.Tx("foo=bar")
+	res, err := BroadcastTxSync(&rpctypes.Context{}, original)
+	require.NoError(t, err)
+	require.Equal(t, []types.Tx{original}, mempool.txs)
+	assert.Equal(t, cmtbytes.HexBytes(tmhash.Sum(original)), res.Hash)
+
+	// the tx is committed wrapped
+	wrapped := types.Tx(append(append([]byte{}, prefix...), original...))
+	require.NoError(t, eventBus.PublishEventNewBlockHeader(types.EventDataNewBlockHeader{
+		Header: types.Header{Height: 1},
+		NumTxs: 1,
+	}))
+	require.NoError(t, eventBus.PublishEventTx(types.EventDataTx{TxResult: abci.TxResult{Height: 1, Tx: wrapped}}))
+
+	var tx *ctypes.ResultTx
+	require.Eventually(t, func() bool {
+		tx, err = Tx(&rpctypes.Context{}, res.Hash, false)
+		return err == nil
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, wrapped, tx.Tx)
+	assert.Equal(t, cmtbytes.HexBytes(tmhash.Sum(wrapped)), tx.Hash)
+	assert.Equal(t, res.Hash, tx.OriginalHash)
+	assert.Nil(t, tx.CommittedHash)
+
+	// and is found by its original hash by tx_search too
+	search, err := TxSearch(&rpctypes.Context{}, fmt.Sprintf("tx.hash='%X'", res.Hash), false, nil, nil, "asc")
+	require.NoError(t, err)
+	require.Len(t, search.Txs, 1)
+	assert.Equal(t, res.Hash, search.Txs[0].OriginalHash)
+}
+
+func TestTxIndexStatus(t *testing.T) {
+	blockStore := store.NewBlockStore(dbm.NewMemDB())
+	for height := int64(1); height <= 5; height++ {
+		block := types.MakeBlock(height, types.Data{}, new(types.Commit), nil)
+		block.ProposerAddress = make([]byte, crypto.AddressSize)
+		blockStore.SaveBlock(block, block.MakePartSet(types.BlockPartSizeBytes), &types.Commit{Height: height})
+	}
+
+	// disabled
+	SetEnvironment(&Environment{BlockStore: blockStore, TxIndexer: &null.TxIndex{}, BlockIndexer: &blockidxnull.BlockerIndexer{}})
+	res, err := TxIndexStatus(&rpctypes.Context{})
+	require.NoError(t, err)
+	assert.Equal(t, &ctypes.ResultTxIndexStatus{BlockStoreBase: 1, BlockStoreHeight: 5}, res)
+
+	// heights 2 and 3 indexed, e.g. by a partial reindex
+	blockIndexer := blockidxkv.New(dbm.NewMemDB())
+	for height := int64(2); height <= 3; height++ {
+		require.NoError(t, blockIndexer.Index(types.EventDataNewBlockHeader{Header: types.Header{Height: height}}))
+	}
+	SetEnvironment(&Environment{BlockStore: blockStore, TxIndexer: kv.NewTxIndex(dbm.NewMemDB()), BlockIndexer: blockIndexer})
+	res, err = TxIndexStatus(&rpctypes.Context{})
+	require.NoError(t, err)
+	assert.Equal(t, &ctypes.ResultTxIndexStatus{
+		Enabled:              true,
+		LowestIndexedHeight:  2,
+		HighestIndexedHeight: 3,
+		BlockStoreBase:       1,
+		BlockStoreHeight:     5,
+		HeightsBehind:        2,
+	}, res)
+}
+
+// shareProofApp answers share inclusion proof queries with a proof containing
+// one share per index of the requested range and, if set, the row roots of
+// all rows with their proofs. Tx inclusion proof queries are answered with
+// txProof.
+type shareProofApp struct {
+	abci.BaseApplication
+	queries       int
+	failStart     uint64
+	rowRoots      [][]byte
+	rowRootProofs []*cmtcrypto.Proof
+	txProof       cmtproto.ShareProof
+}
+
+func (app *shareProofApp) Query(req abci.RequestQuery) abci.ResponseQuery {
+	app.queries++
+	var index uint32
+	if _, err := fmt.Sscanf(req.Path, consts.TxInclusionProofQueryPath, &index); err == nil {
+		bz, err := app.txProof.Marshal()
+		if err != nil {
+			return abci.ResponseQuery{Log: err.Error()}
+		}
+		return abci.ResponseQuery{Value: bz}
+	}
+	var start, end uint64
+	if _, err := fmt.Sscanf(req.Path, consts.ShareInclusionProofQueryPath, &start, &end); err != nil {
+		return abci.ResponseQuery{Log: err.Error()}
+	}
+	if start == app.failStart {
+		return abci.ResponseQuery{Log: "invalid range"}
+	}
+	rowRoots := app.rowRoots
+	if len(rowRoots) == 0 {
+		// proofs hold at least a row root
+		rowRoots = [][]byte{tmhash.Sum(nil)}
+	}
+	proof := cmtproto.ShareProof{RowProof: &cmtproto.RowProof{
+		RowRoots: rowRoots,
+		EndRow:   uint32(len(rowRoots) - 1),
+	}}
+	for i := range rowRoots {
+		rowRootProof := &cmtcrypto.Proof{}
+		if i < len(app.rowRootProofs) {
+			rowRootProof = app.rowRootProofs[i]
+		}
+		proof.RowProof.Proofs = append(proof.RowProof.Proofs, rowRootProof)
+	}
+	for i := start; i < end; i++ {
+		proof.Data = append(proof.Data, []byte{byte(i)})
+	}
+	bz, err := proof.Marshal()
+	if err != nil {
+		return abci.ResponseQuery{Log: err.Error()}
+	}
+	return abci.ResponseQuery{Value: bz}
+}
+
+// squareApp answers share inclusion proof queries with proofs of the shares
+// of square, and tx inclusion proof queries with proofs of the shares in
+// txShares at the index of the tx.
+type squareApp struct {
+	abci.BaseApplication
+	square   *testSquare
+	txShares []ctypes.ShareRange
+}
+
+func (app *squareApp) Query(req abci.RequestQuery) abci.ResponseQuery {
+	var (
+		index      uint32
+		start, end uint64
+	)
+	if _, err := fmt.Sscanf(req.Path, consts.TxInclusionProofQueryPath, &index); err == nil {
+		if int(index) >= len(app.txShares) {
+			return abci.ResponseQuery{Log: "tx index out of range"}
+		}
+		start, end = app.txShares[index].Start, app.txShares[index].End
+	} else if _, err := fmt.Sscanf(req.Path, consts.ShareInclusionProofQueryPath, &start, &end); err != nil {
+		return abci.ResponseQuery{Log: err.Error()}
+	}
+	proof := app.square.prove(start, end)
+	bz, err := proof.Marshal()
+	if err != nil {
+		return abci.ResponseQuery{Log: err.Error()}
+	}
+	return abci.ResponseQuery{Value: bz}
+}
+
+// testSquare is an original data square whose rows are committed to by NMTs
+// and whose data root commits to the row roots. The roots of the extended rows
+// and of the columns are made up, as proofs of shares do not use them.
+type testSquare struct {
+	size      int
+	shares    [][]byte
+	rows      []*nmt.NamespacedMerkleTree
+	rowRoots  [][]byte
+	rowProofs []*merkle.Proof
+	dataRoot  []byte
+}
+
+func newTestSquare(t *testing.T, size int, shares [][]byte) *testSquare {
+	require.Len(t, shares, size*size)
+	parityShare := bytes.Repeat([]byte{0xff}, consts.ShareSize)
+	sq := &testSquare{size: size, shares: shares}
+	var roots [][]byte
+	for row := 0; row < size; row++ {
+		tree := nmt.New(consts.NewBaseHashFunc(), nmt.NamespaceIDSize(consts.NamespaceSize), nmt.IgnoreMaxNamespace(true))
+		for col := 0; col < 2*size; col++ {
+			share := parityShare
+			if col < size {
+				share = shares[row*size+col]
+			}
+			require.NoError(t, tree.Push(append(append([]byte{}, share[:consts.NamespaceSize]...), share...)))
+		}
+		root, err := tree.Root()
+		require.NoError(t, err)
+		sq.rows = append(sq.rows, tree)
+		roots = append(roots, root)
+	}
+	for i := len(roots); i < 4*size; i++ {
+		roots = append(roots, tmhash.Sum([]byte{byte(i)}))
+	}
+	sq.dataRoot, sq.rowProofs = merkle.ProofsFromByteSlices(roots)
+	sq.rowRoots = roots
+	return sq
+}
+
+// prove returns a proof of the end exclusive range of shares.
+func (sq *testSquare) prove(start, end uint64) cmtproto.ShareProof {
+	startRow, endRow := int(start)/sq.size, int(end-1)/sq.size
+	first := sq.shares[start]
+	proof := cmtproto.ShareProof{
+		Data:             sq.shares[start:end],
+		NamespaceId:      first[1:consts.NamespaceSize],
+		NamespaceVersion: uint32(first[0]),
+		RowProof: &cmtproto.RowProof{
+			StartRow: uint32(startRow),
+			EndRow:   uint32(endRow),
+		},
+	}
+	for row := startRow; row <= endRow; row++ {
+		colStart, colEnd := 0, sq.size
+		if row == startRow {
+			colStart = int(start) % sq.size
+		}
+		if row == endRow {
+			colEnd = int(end-1)%sq.size + 1
+		}
+		nmtProof, err := sq.rows[row].ProveRange(colStart, colEnd)
+		if err != nil {
+			panic(err)
+		}
+		proof.ShareProofs = append(proof.ShareProofs, &cmtproto.NMTProof{
+			Start: int32(nmtProof.Start()),
+			End:   int32(nmtProof.End()),
+			Nodes: nmtProof.Nodes(),
+		})
+		proof.RowProof.RowRoots = append(proof.RowProof.RowRoots, sq.rowRoots[row])
+		proof.RowProof.Proofs = append(proof.RowProof.Proofs, sq.rowProofs[row].ToProto())
+	}
+	return proof
+}
+
+// testCompactShares splits the length delimited txs into the compact shares
+// of a sequence of the namespace.
+func testCompactShares(namespace []byte, txs ...types.Tx) [][]byte {
+	var (
+		units      []byte
+		unitStarts []int
+	)
+	for _, tx := range txs {
+		unitStarts = append(unitStarts, len(units))
+		units = binary.AppendUvarint(units, uint64(len(tx)))
+		units = append(units, tx...)
+	}
+	var shares [][]byte
+	for cursor := 0; cursor < len(units); {
+		share := append([]byte{}, namespace...)
+		if cursor == 0 {
+			share = append(share, 1) // info byte of the first share of a sequence
+			share = binary.BigEndian.AppendUint32(share, uint32(len(units)))
+		} else {
+			share = append(share, 0)
+		}
+		dataStart := len(share) + 4
+		end := cursor + consts.ShareSize - dataStart
+		if end > len(units) {
+			end = len(units)
+		}
+		reserved := 0
+		for _, start := range unitStarts {
+			if start >= cursor && start < end {
+				reserved = dataStart + start - cursor
+				break
+			}
+		}
+		share = binary.BigEndian.AppendUint32(share, uint32(reserved))
+		share = append(share, units[cursor:end]...)
+		shares = append(shares, append(share, make([]byte, consts.ShareSize-len(share))...))
+		cursor = end
+	}
+	return shares
+}
+
+// testSparseShares splits the blob data into the sparse shares of the
+// namespace, of share version 0.
+func testSparseShares(namespace []byte, shareVersion uint8, signer, data []byte) [][]byte {
+	var shares [][]byte
+	for cursor := 0; cursor < len(data); {
+		share := append([]byte{}, namespace...)
+		if cursor == 0 {
+			share = append(share, shareVersion<<1|1) // info byte of the first share of a sequence
+			share = binary.BigEndian.AppendUint32(share, uint32(len(data)))
+			share = append(share, signer...)
+		} else {
+			share = append(share, shareVersion<<1)
+		}
+		end := cursor + consts.ShareSize - len(share)
+		if end > len(data) {
+			end = len(data)
+		}
+		share = append(share, data[cursor:end]...)
+		shares = append(shares, append(share, make([]byte, consts.ShareSize-len(share))...))
+		cursor = end
+	}
+	return shares
+}
@@ -1,7 +1,10 @@
 package coretypes
 
 import (
+	stdbytes "bytes"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/tendermint/tendermint/crypto/merkle"
@@ -9,6 +12,7 @@ import (
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/libs/bytes"
+	mempl "github.com/tendermint/tendermint/mempool"
 	"github.com/tendermint/tendermint/p2p"
 	cmtproto "github.com/tendermint/tendermint/proto/tendermint/types"
 	"github.com/tendermint/tendermint/types"
@@ -18,6 +22,12 @@ import (
 type ResultBlockchainInfo struct {
 	LastHeight int64              `json:"last_height"`
 	BlockMetas []*types.BlockMeta `json:"block_metas"`
+	// The lowest height of the block store, below which the blocks are pruned.
+	BaseHeight int64 `json:"base_height"`
+	// The height the application asked to retain the blocks from, 0 if it
+	// never did. The blocks below it are pruned, or will be once the evidence
+	// for them has expired.
+	RetainHeight int64 `json:"retain_height"`
 }
 
 // Genesis file
@@ -35,10 +45,17 @@ type ResultGenesisChunk struct {
 	Data        string `json:"data"`
 }
 
+// Meta of a single block
+type ResultBlockMeta struct {
+	BlockMeta *types.BlockMeta `json:"block_meta"`
+}
+
 // Single block (with meta)
 type ResultBlock struct {
 	BlockID types.BlockID `json:"block_id"`
 	Block   *types.Block  `json:"block"`
+	// The meta of the block, with DataPruned set, if only its data was pruned.
+	BlockMeta *types.BlockMeta `json:"block_meta,omitempty"`
 }
 
 // Single block with all data for validation
@@ -69,6 +86,20 @@ type ResultTxStatus struct {
 	Status        string `json:"status"`
 }
 
+// ResultTxIndexStatus reports the heights covered by the transaction indexer.
+// The heights between the lowest and highest indexed heights may not all be
+// indexed, e.g. after a partial reindex.
+type ResultTxIndexStatus struct {
+	// Whether transactions are indexed. If not, the indexed heights are 0.
+	Enabled              bool  `json:"enabled"`
+	LowestIndexedHeight  int64 `json:"lowest_indexed_height"`
+	HighestIndexedHeight int64 `json:"highest_indexed_height"`
+	BlockStoreBase       int64 `json:"block_store_base"`
+	BlockStoreHeight     int64 `json:"block_store_height"`
+	// Number of committed heights not indexed yet, 0 if indexing is disabled.
+	HeightsBehind int64 `json:"heights_behind"`
+}
+
 // ABCI results from a block
 type ResultBlockResults struct {
 	Height                int64                     `json:"height"`
@@ -114,6 +145,26 @@ type SyncInfo struct {
 	EarliestBlockTime   time.Time      `json:"earliest_block_time"`
 
 	CatchingUp bool `json:"catching_up"`
+
+	// StateSync is the state sync of the node on startup, if it ran one.
+	StateSync *StateSyncInfo `json:"state_sync,omitempty"`
+}
+
+// StateSyncInfo is the progress of the state sync of a node.
+type StateSyncInfo struct {
+	// State is the stage of the state sync: discovering, restoring, done,
+	// failed, fast_sync_fallback or halted.
+	State string `json:"state"`
+	// Attempts is the number of attempted snapshot restorations.
+	Attempts uint32 `json:"attempts"`
+	// SnapshotHeight is the height of the snapshot being, or last, restored.
+	SnapshotHeight uint64 `json:"snapshot_height"`
+	// FailureReason is the reason why the node gave up on state sync, if it
+	// did.
+	FailureReason string `json:"failure_reason,omitempty"`
+	// Error is the error which halted the node, if it could not fall back to
+	// fast sync either.
+	Error string `json:"error,omitempty"`
 }
 
 // Info about the node's validator
@@ -128,6 +179,15 @@ type ResultStatus struct {
 	NodeInfo      p2p.DefaultNodeInfo `json:"node_info"`
 	SyncInfo      SyncInfo            `json:"sync_info"`
 	ValidatorInfo ValidatorInfo       `json:"validator_info"`
+	// ReadOnly is whether the RPC server of the node is read-only, i.e.
+	// rejects the endpoints mutating its state, like broadcast_tx_sync.
+	ReadOnly bool `json:"read_only"`
+	// ArchiveMode is whether the node is an archive server, serving the blocks
+	// of its stores without running p2p or consensus.
+	ArchiveMode bool `json:"archive_mode"`
+	// MempoolRequirements are the requirements of the application to admit a
+	// transaction in its mempool, if the node tracks them.
+	MempoolRequirements *ResultMempoolRequirements `json:"mempool_requirements,omitempty"`
 }
 
 // Is TxIndexing enabled
@@ -144,6 +204,8 @@ type ResultNetInfo struct {
 	Listeners []string `json:"listeners"`
 	NPeers    int      `json:"n_peers"`
 	Peers     []Peer   `json:"peers"`
+	// PeerTargets are the numbers of peers the node aims for, if known.
+	PeerTargets *p2p.PeerTargets `json:"peer_targets,omitempty"`
 }
 
 // Log from dialing seeds
@@ -180,6 +242,35 @@ type ResultConsensusParams struct {
 	ConsensusParams cmtproto.ConsensusParams `json:"consensus_params"`
 }
 
+// ResultProposerMisses reports, for the heights in [FromHeight, ToHeight]
+// the node took part in, the rounds each validator was the proposer of and
+// those in which the node observed no proposal from it.
+type ResultProposerMisses struct {
+	FromHeight int64 `json:"from_height"`
+	ToHeight   int64 `json:"to_height"`
+	// RecordedHeights is the number of heights of the range the node recorded
+	// the proposers of, i.e. not those it synced or pruned.
+	RecordedHeights int64                     `json:"recorded_heights"`
+	Validators      []ValidatorProposerMisses `json:"validators"`
+}
+
+// ValidatorProposerMisses are the rounds a validator was the proposer of. A
+// proposal not observed by the node is not necessarily a misbehavior of the
+// validator, e.g. it may not have been gossiped to the node in time.
+type ValidatorProposerMisses struct {
+	Address           types.Address `json:"address"`
+	ScheduledRounds   int           `json:"scheduled_rounds"`
+	NotObservedRounds int           `json:"not_observed_rounds"`
+	// NotObserved are the rounds in which no proposal was observed.
+	NotObserved []ProposerRound `json:"not_observed"`
+}
+
+// ProposerRound identifies a round of a height.
+type ProposerRound struct {
+	Height int64 `json:"height"`
+	Round  int32 `json:"round"`
+}
+
 // Info about the consensus state.
 // UNSTABLE
 type ResultDumpConsensusState struct {
@@ -229,12 +320,49 @@ type ResultTx struct {
 	TxResult abci.ResponseDeliverTx `json:"tx_result"`
 	Tx       types.Tx               `json:"tx"`
 	Proof    types.ShareProof       `json:"proof,omitempty"`
+	// CommittedHash is the hash of the transaction bytes as committed in the
+	// block. It is only set if it differs from Hash, i.e. if the committed
+	// transaction is wrapped.
+	CommittedHash bytes.HexBytes `json:"committed_hash,omitempty"`
+	// OriginalHash is the hash of the transaction as broadcast, unwrapped by
+	// the unwrapper registered on the indexer. It is only set if it differs
+	// from Hash, i.e. if the unwrapper knows a wrapping types.Tx.Hash does not.
+	OriginalHash bytes.HexBytes `json:"original_hash,omitempty"`
 }
 
 // Result of searching for txs
 type ResultTxSearch struct {
 	Txs        []*ResultTx `json:"txs"`
 	TotalCount int         `json:"total_count"`
+	// Truncated is set if the search exhausted the row or time budget of the
+	// indexer, in which case other transactions matching the query may be
+	// missing and the query should be narrowed.
+	Truncated bool `json:"truncated,omitempty"`
+	// Groups holds the transactions grouped by height instead of Txs if the
+	// search was requested with group_by_height.
+	Groups []TxHeightGroup `json:"groups,omitempty"`
+}
+
+// TxHeightGroup holds the transactions of a search result committed at the
+// same height.
+type TxHeightGroup struct {
+	Height int64       `json:"height"`
+	Txs    []*ResultTx `json:"txs"`
+}
+
+// GroupTxsByHeight groups consecutive transactions committed at the same
+// height, keeping the order of txs. Since search results are sorted by
+// height, every height has a single group.
+func GroupTxsByHeight(txs []*ResultTx) []TxHeightGroup {
+	groups := []TxHeightGroup{}
+	for _, tx := range txs {
+		if n := len(groups); n > 0 && groups[n-1].Height == tx.Height {
+			groups[n-1].Txs = append(groups[n-1].Txs, tx)
+			continue
+		}
+		groups = append(groups, TxHeightGroup{Height: tx.Height, Txs: []*ResultTx{tx}})
+	}
+	return groups
 }
 
 // ResultBlockSearch defines the RPC response type for a block search by events.
@@ -251,6 +379,29 @@ type ResultUnconfirmedTxs struct {
 	Txs        []types.Tx `json:"txs"`
 }
 
+// ResultMempoolSnapshot holds the hashes of the unconfirmed txs at a sequence
+// of the MempoolTx events.
+type ResultMempoolSnapshot struct {
+	Sequence uint64           `json:"sequence"`
+	Hashes   []bytes.HexBytes `json:"hashes"`
+}
+
+// ResultMempoolRequirements holds the requirements the application reported
+// to admit a transaction in its mempool. Unset requirements are empty.
+type ResultMempoolRequirements struct {
+	// Height is the height of the last block the application had committed
+	// when reporting the requirements.
+	Height int64 `json:"height"`
+	// MinGasPrice is the minimum gas price, as a decimal, e.g. "0.002utia".
+	MinGasPrice string `json:"min_gas_price"`
+	// MaxTxBytes is the maximum size of a transaction, 0 if unbounded.
+	MaxTxBytes int64 `json:"max_tx_bytes"`
+	// Enforced is whether the mempool of the node rejects, before CheckTx,
+	// the transactions not meeting the requirements it can check, i.e. their
+	// size.
+	Enforced bool `json:"enforced"`
+}
+
 // Info abci msg
 type ResultABCIInfo struct {
 	Response abci.ResponseInfo `json:"response"`
@@ -261,20 +412,91 @@ type ResultABCIQuery struct {
 	Response abci.ResponseQuery `json:"response"`
 }
 
+// Snapshots created by the application at the request of the node
+type ResultSnapshots struct {
+	Snapshots []*abci.Snapshot `json:"snapshots"`
+}
+
 // Result of broadcasting evidence
 type ResultBroadcastEvidence struct {
 	Hash []byte `json:"hash"`
 }
 
+// ResultProposalDebug holds why the transactions of the mempool were left out
+// of the block proposed by the node at a height.
+type ResultProposalDebug struct {
+	Height      int64 `json:"height"`
+	MempoolSize int   `json:"mempool_size"`
+	Reaped      int   `json:"reaped"`
+	Proposed    int   `json:"proposed"`
+	// Excluded holds the number of transactions left out, and the hashes of
+	// the first of them, by reason
+	Excluded map[string]*mempl.TxExclusions `json:"excluded"`
+}
+
+// ResultPauseAppUpgrade holds the last height committed before pausing for an
+// upgrade of the application, and the app version of the running application.
+type ResultPauseAppUpgrade struct {
+	Height     int64  `json:"height"`
+	AppVersion uint64 `json:"app_version"`
+}
+
+// ResultDebugBundle is a snapshot of the state of the node to attach to bug
+// reports, with all its sections captured at the same block height.
+type ResultDebugBundle struct {
+	// Height is the latest block height of the node in every section.
+	Height int64 `json:"height"`
+	// Consistent is false if the node kept syncing blocks while the bundle was
+	// captured, in which case the sections may be from different heights.
+	Consistent     bool                  `json:"consistent"`
+	CapturedAt     time.Time             `json:"captured_at"`
+	Version        DebugVersionInfo      `json:"version"`
+	Status         *ResultStatus         `json:"status"`
+	NetInfo        *ResultNetInfo        `json:"net_info"`
+	ConsensusState json.RawMessage       `json:"consensus_state"`
+	Mempool        *ResultUnconfirmedTxs `json:"mempool"`
+	BlockSync      *BlockSyncInfo        `json:"block_sync,omitempty"`
+	// RecentLogs holds the most recent log lines of the node, with the values
+	// of the secret looking keys redacted, if log_ring_buffer_size is set.
+	RecentLogs []string `json:"recent_logs,omitempty"`
+	// Truncated is true if log lines or peers were left out of the bundle to
+	// keep it under the size cap.
+	Truncated bool `json:"truncated"`
+}
+
+// DebugVersionInfo is the version and build info of the node.
+type DebugVersionInfo struct {
+	Version       string `json:"version"`
+	GitCommit     string `json:"git_commit"`
+	ABCIVersion   string `json:"abci_version"`
+	BlockProtocol uint64 `json:"block_protocol"`
+	P2PProtocol   uint64 `json:"p2p_protocol"`
+	GoVersion     string `json:"go_version"`
+}
+
+// BlockSyncInfo is the progress of the block pool of the node.
+type BlockSyncInfo struct {
+	Syncing       bool  `json:"syncing"`
+	PoolHeight    int64 `json:"pool_height"`
+	NumPending    int32 `json:"num_pending"`
+	NumRequesters int   `json:"num_requesters"`
+	MaxPeerHeight int64 `json:"max_peer_height"`
+}
+
 // empty results
 type (
 	ResultUnsafeFlushMempool struct{}
 	ResultUnsafeProfile      struct{}
-	ResultSubscribe          struct{}
 	ResultUnsubscribe        struct{}
 	ResultHealth             struct{}
 )
 
+// Result of subscribing to events. The events are delivered as responses to
+// the subscribe request, whose JSON-RPC ID is the subscription ID.
+type ResultSubscribe struct {
+	SubscriptionID string `json:"subscription_id,omitempty"`
+}
+
 // Event data from a subscription
 type ResultEvent struct {
 	Query  string              `json:"query"`
@@ -282,7 +504,196 @@ type ResultEvent struct {
 	Events map[string][]string `json:"events"`
 }
 
+// Payloads of the events delivered to a subscription.
+const (
+	// EventPayloadFull delivers the data of every event as published. It is
+	// the default.
+	EventPayloadFull = "full"
+	// EventPayloadHeader delivers the data of NewBlock events as
+	// EventDataNewBlockHeader, without the transactions, evidence and last
+	// commit of the block. The data of other events is delivered as published.
+	EventPayloadHeader = "header"
+)
+
+// ValidateEventPayload returns an error if payload is not a known event
+// payload. An empty payload is the full payload.
+func ValidateEventPayload(payload string) error {
+	switch payload {
+	case "", EventPayloadFull, EventPayloadHeader:
+		return nil
+	default:
+		return fmt.Errorf("unknown payload %q, must be %q or %q", payload, EventPayloadFull, EventPayloadHeader)
+	}
+}
+
+// EventPayloadData returns the data of an event to deliver to a subscription
+// with the given payload.
+func EventPayloadData(payload string, data types.TMEventData) types.TMEventData {
+	if payload != EventPayloadHeader {
+		return data
+	}
+	switch data := data.(type) {
+	case types.EventDataNewBlock:
+		return data.ToHeader()
+	default:
+		return data
+	}
+}
+
 // ResultShareProof is an API response that contains a ShareProof.
 type ResultShareProof struct {
 	ShareProof types.ShareProof `json:"share_proof"`
 }
+
+// ResultProofSizeEstimate is an API response that contains an estimate of the
+// size of the proof of the shares of a namespace in a block.
+type ResultProofSizeEstimate struct {
+	Height    int64          `json:"height"`
+	Namespace bytes.HexBytes `json:"namespace"`
+	// Rows is the number of rows of the original data square whose namespace
+	// range contains the namespace.
+	Rows int `json:"rows"`
+	// NMTDepth is the depth of the NMT of a row.
+	NMTDepth int `json:"nmt_depth"`
+	// Shares is the maximum number of shares of the namespace.
+	Shares int `json:"shares"`
+	// Size is the estimated size of the proof in bytes, including the shares.
+	Size int64 `json:"size"`
+}
+
+// ResultTxAbsenceProof is an API response that contains a proof that a
+// transaction is not in the block at the given height.
+type ResultTxAbsenceProof struct {
+	Height int64                `json:"height"`
+	Proof  types.TxAbsenceProof `json:"proof"`
+}
+
+// RowNamespaceRange is the range of namespaces committed to by the root of a
+// row of the original data square.
+type RowNamespaceRange struct {
+	Row          uint32         `json:"row"`
+	MinNamespace bytes.HexBytes `json:"min_namespace"`
+	MaxNamespace bytes.HexBytes `json:"max_namespace"`
+}
+
+// ResultRowNamespaceRanges is an API response that contains the namespace
+// range of every row of the original data square of a block.
+type ResultRowNamespaceRanges struct {
+	Height int64               `json:"height"`
+	Rows   []RowNamespaceRange `json:"rows"`
+}
+
+// ShareRange is an end exclusive range of shares in the original data square.
+type ShareRange struct {
+	Start uint64 `json:"start"`
+	End   uint64 `json:"end"`
+}
+
+// ShareProofBatchItem is the result of proving a single share range of a
+// batch. Either ShareProof or Error is set.
+type ShareProofBatchItem struct {
+	Range      ShareRange        `json:"range"`
+	ShareProof *types.ShareProof `json:"share_proof,omitempty"`
+	Error      string            `json:"error,omitempty"`
+}
+
+// ShareProofChunk is the proof of a chunk of a share range, see
+// ResultShareProofPage.
+type ShareProofChunk struct {
+	Range      ShareRange       `json:"range"`
+	ShareProof types.ShareProof `json:"share_proof"`
+}
+
+// BlobShareProof is the proof of the shares of a blob, see
+// ResultTxSharesProof.
+type BlobShareProof struct {
+	Range      ShareRange       `json:"range"`
+	ShareProof types.ShareProof `json:"share_proof"`
+}
+
+// ResultTxSharesProof is an API response that contains the proof of the
+// shares of a transaction and, if requested for a blob transaction, the proofs
+// of the shares of its blobs, in order.
+type ResultTxSharesProof struct {
+	Height     int64            `json:"height"`
+	Index      uint32           `json:"index"`
+	TxProof    types.ShareProof `json:"tx_proof"`
+	BlobProofs []BlobShareProof `json:"blob_proofs,omitempty"`
+}
+
+// ResultTxBundle is a self-contained receipt of a committed transaction: the
+// transaction as committed in the block, the proof of its shares to the data
+// root, and the light block whose header commits to the data root. Canonical
+// is false if the commit is the one seen by the node, the next block not being
+// committed yet.
+type ResultTxBundle struct {
+	Hash       bytes.HexBytes    `json:"hash"`
+	Height     int64             `json:"height"`
+	Index      uint32            `json:"index"`
+	Tx         types.Tx          `json:"tx"`
+	Proof      types.ShareProof  `json:"proof"`
+	LightBlock *types.LightBlock `json:"light_block"`
+	Canonical  bool              `json:"canonical"`
+}
+
+// Verify checks that the bundle is consistent, without any other data: the
+// light block is valid and its commit is signed by more than 2/3 of its
+// validator set, the proof proves the shares to the data root of its header,
+// and the shares hold the transaction. The caller still has to check that it
+// trusts the validator set, e.g. against the validators hash of a trusted
+// header.
+func (b *ResultTxBundle) Verify(chainID string) error {
+	lb := b.LightBlock
+	if lb == nil {
+		return errors.New("missing light block")
+	}
+	if err := lb.ValidateBasic(chainID); err != nil {
+		return fmt.Errorf("invalid light block: %w", err)
+	}
+	if lb.Height != b.Height {
+		return fmt.Errorf("light block height %d does not match tx height %d", lb.Height, b.Height)
+	}
+	err := lb.ValidatorSet.VerifyCommitLight(chainID, lb.Commit.BlockID, lb.Height, lb.Commit)
+	if err != nil {
+		return fmt.Errorf("invalid commit: %w", err)
+	}
+	if hash := b.Tx.Hash(); !stdbytes.Equal(b.Hash, hash) {
+		return fmt.Errorf("hash %X does not match the hash of the tx %X", b.Hash, hash)
+	}
+	if err := b.Proof.Validate(lb.DataHash); err != nil {
+		return fmt.Errorf("invalid proof against the data hash: %w", err)
+	}
+	if err := b.Proof.ContainsTx(b.Tx); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ResultNamespaceCompleteness is an API response that tells whether a set of
+// share proofs covers all the shares of a namespace in a block. Shares is the
+// end exclusive range of the shares of the namespace, empty if the block holds
+// none, and Gaps are the parts of it which no proof covers, in order.
+type ResultNamespaceCompleteness struct {
+	Height           int64          `json:"height"`
+	NamespaceID      bytes.HexBytes `json:"namespace_id"`
+	NamespaceVersion uint32         `json:"namespace_version"`
+	Shares           ShareRange     `json:"shares"`
+	Complete         bool           `json:"complete"`
+	Gaps             []ShareRange   `json:"gaps,omitempty"`
+}
+
+// ResultShareProofPage is an API response that contains the proofs of a page of
+// the chunks of a share range, one per row of the square. TotalCount is the
+// number of chunks of the whole range.
+type ResultShareProofPage struct {
+	Height     int64             `json:"height"`
+	Chunks     []ShareProofChunk `json:"chunks"`
+	TotalCount int               `json:"total_count"`
+}
+
+// ResultShareProofBatch is an API response that contains the proofs of a
+// batch of share ranges, in the order the ranges were requested.
+type ResultShareProofBatch struct {
+	Height int64                 `json:"height"`
+	Proofs []ShareProofBatchItem `json:"proofs"`
+}
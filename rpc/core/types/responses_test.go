@@ -8,6 +8,17 @@ import (
 	"github.com/tendermint/tendermint/p2p"
 )
 
+func TestGroupTxsByHeight(t *testing.T) {
+	assert.Empty(t, GroupTxsByHeight(nil))
+
+	txs := []*ResultTx{{Height: 3, Index: 1}, {Height: 3, Index: 0}, {Height: 2}, {Height: 1, Index: 4}}
+	assert.Equal(t, []TxHeightGroup{
+		{Height: 3, Txs: txs[0:2]},
+		{Height: 2, Txs: txs[2:3]},
+		{Height: 1, Txs: txs[3:4]},
+	}, GroupTxsByHeight(txs))
+}
+
 func TestStatusIndexer(t *testing.T) {
 	var status *ResultStatus
 	assert.False(t, status.TxIndexEnabled())
@@ -16,12 +16,12 @@ type Config struct {
 }
 
 // StartGRPCServer starts a new gRPC BroadcastAPIServer using the given
-// net.Listener.
+// net.Listener, whose health service reports it as serving.
 // NOTE: This function blocks - you may want to call it in a go-routine.
 func StartGRPCServer(ln net.Listener) error {
-	grpcServer := grpc.NewServer()
-	RegisterBroadcastAPIServer(grpcServer, &broadcastAPI{})
-	return grpcServer.Serve(ln)
+	s := NewServer()
+	s.SetServing(true)
+	return s.Serve(ln)
 }
 
 // StartGRPCClient dials the gRPC server using protoAddr and returns a new
@@ -0,0 +1,194 @@
+package coregrpc
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"time"
+
+	gogoproto "github.com/gogo/protobuf/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+	reflectionv1 "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	reflectionv1alpha "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	core "github.com/tendermint/tendermint/rpc/core"
+)
+
+const (
+	// broadcastAPIProtoFile is the proto file defining the BroadcastAPI service.
+	broadcastAPIProtoFile = "tendermint/rpc/grpc/types.proto"
+
+	// stopTimeout bounds the wait for the pending requests when stopping, as
+	// the streams of the health watchers are never complete.
+	stopTimeout = 5 * time.Second
+)
+
+// Server is a gRPC server of the BroadcastAPI, which also exposes the standard
+// gRPC health and server reflection services.
+//
+// The health service reports NOT_SERVING until SetServing is called, i.e. until
+// the node finishes starting, and once the server starts shutting down. While
+// serving, it also reports NOT_SERVING if the node fails the health checks of
+// the /health endpoint.
+type Server struct {
+	grpcServer *grpc.Server
+	health     *health.Server
+}
+
+// NewServer returns a new Server, configured with opts, e.g. the TLS credentials
+// of the listener.
+func NewServer(opts ...grpc.ServerOption) *Server {
+	s := &Server{
+		grpcServer: grpc.NewServer(opts...),
+		health:     health.NewServer(),
+	}
+	RegisterBroadcastAPIServer(s.grpcServer, &broadcastAPI{})
+	healthpb.RegisterHealthServer(s.grpcServer, &healthServer{Server: s.health})
+	s.SetServing(false)
+
+	// the BroadcastAPI is registered with gogoproto, which the reflection
+	// service does not know of, so its descriptors are resolved separately
+	reflectionOpts := reflection.ServerOptions{
+		Services:           s.grpcServer,
+		DescriptorResolver: descriptorResolvers{gogoFileDescriptors(broadcastAPIProtoFile), protoregistry.GlobalFiles},
+	}
+	reflectionv1.RegisterServerReflectionServer(s.grpcServer, reflection.NewServerV1(reflectionOpts))
+	reflectionv1alpha.RegisterServerReflectionServer(s.grpcServer, reflection.NewServer(reflectionOpts))
+	return s
+}
+
+// Serve accepts the connections on ln until the server is stopped.
+// NOTE: This function blocks - you may want to call it in a go-routine.
+func (s *Server) Serve(ln net.Listener) error {
+	return s.grpcServer.Serve(ln)
+}
+
+// SetServing sets the status reported by the health service, for the server as
+// a whole and for the BroadcastAPI.
+func (s *Server) SetServing(serving bool) {
+	status := healthpb.HealthCheckResponse_NOT_SERVING
+	if serving {
+		status = healthpb.HealthCheckResponse_SERVING
+	}
+	s.health.SetServingStatus("", status)
+	s.health.SetServingStatus(_BroadcastAPI_serviceDesc.ServiceName, status)
+}
+
+// Drain makes the health service report NOT_SERVING from now on, so that load
+// balancers stop routing to the server, while it still serves the requests.
+func (s *Server) Drain() {
+	s.health.Shutdown()
+}
+
+// Stop drains the server, then stops it once the pending requests complete, or
+// after stopTimeout.
+func (s *Server) Stop() {
+	s.Drain()
+	stopped := make(chan struct{})
+	go func() {
+		s.grpcServer.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(stopTimeout):
+		s.grpcServer.Stop()
+		<-stopped
+	}
+}
+
+// healthServer reports the node as NOT_SERVING when it fails the health
+// checks, on top of the status set on the server.
+type healthServer struct {
+	*health.Server
+}
+
+func (hs *healthServer) Check(
+	ctx context.Context,
+	req *healthpb.HealthCheckRequest,
+) (*healthpb.HealthCheckResponse, error) {
+	res, err := hs.Server.Check(ctx, req)
+	if err != nil || res.Status != healthpb.HealthCheckResponse_SERVING {
+		return res, err
+	}
+	if err := core.CheckHealth(); err != nil {
+		return &healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_NOT_SERVING}, nil
+	}
+	return res, nil
+}
+
+// descriptorResolvers resolves descriptors from the first resolver knowing of
+// them.
+type descriptorResolvers []protodesc.Resolver
+
+func (rs descriptorResolvers) FindFileByPath(path string) (protoreflect.FileDescriptor, error) {
+	for _, r := range rs {
+		if fd, err := r.FindFileByPath(path); err == nil {
+			return fd, nil
+		}
+	}
+	return nil, protoregistry.NotFound
+}
+
+func (rs descriptorResolvers) FindDescriptorByName(name protoreflect.FullName) (protoreflect.Descriptor, error) {
+	for _, r := range rs {
+		if d, err := r.FindDescriptorByName(name); err == nil {
+			return d, nil
+		}
+	}
+	return nil, protoregistry.NotFound
+}
+
+// gogoFileDescriptors returns the descriptors of the proto files registered with
+// gogoproto, along with their imports. The imports that are not registered, like
+// the gogoproto options, are left unresolved.
+func gogoFileDescriptors(paths ...string) *protoregistry.Files {
+	files := new(protoregistry.Files)
+	var register func(path string) error
+	register = func(path string) error {
+		if _, err := files.FindFileByPath(path); err == nil {
+			return nil
+		}
+		gz := gogoproto.FileDescriptor(path)
+		if gz == nil {
+			return fmt.Errorf("proto file %s is not registered", path)
+		}
+		zr, err := gzip.NewReader(bytes.NewReader(gz))
+		if err != nil {
+			return err
+		}
+		b, err := io.ReadAll(zr)
+		if err != nil {
+			return err
+		}
+		fdp := new(descriptorpb.FileDescriptorProto)
+		if err := proto.Unmarshal(b, fdp); err != nil {
+			return err
+		}
+		for _, dep := range fdp.GetDependency() {
+			// an unregistered import is left unresolved
+			_ = register(dep)
+		}
+		fd, err := protodesc.FileOptions{AllowUnresolvable: true}.New(fdp, files)
+		if err != nil {
+			return err
+		}
+		return files.RegisterFile(fd)
+	}
+	for _, path := range paths {
+		// the reflection service falls back to the other resolvers
+		_ = register(path)
+	}
+	return files
+}
@@ -0,0 +1,118 @@
+package coregrpc_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+
+	cmtnet "github.com/tendermint/tendermint/libs/net"
+	core_grpc "github.com/tendermint/tendermint/rpc/grpc"
+	rpctest "github.com/tendermint/tendermint/rpc/test"
+)
+
+const broadcastAPIService = "tendermint.rpc.grpc.BroadcastAPI"
+
+func dial(t *testing.T, addr string) *grpc.ClientConn {
+	conn, err := grpc.Dial(addr,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithContextDialer(func(_ context.Context, addr string) (net.Conn, error) {
+			return cmtnet.Connect(addr)
+		}),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func checkHealth(t *testing.T, client healthpb.HealthClient, service string) healthpb.HealthCheckResponse_ServingStatus {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	res, err := client.Check(ctx, &healthpb.HealthCheckRequest{Service: service})
+	require.NoError(t, err)
+	return res.Status
+}
+
+func TestServerHealth(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := core_grpc.NewServer()
+	served := make(chan error, 1)
+	go func() { served <- server.Serve(ln) }()
+
+	conn := dial(t, "tcp://"+ln.Addr().String())
+	health := healthpb.NewHealthClient(conn)
+	api := core_grpc.NewBroadcastAPIClient(conn)
+
+	// not serving until the node finishes starting
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, checkHealth(t, health, ""))
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, checkHealth(t, health, broadcastAPIService))
+
+	server.SetServing(true)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, checkHealth(t, health, ""))
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, checkHealth(t, health, broadcastAPIService))
+	_, err = health.Check(context.Background(), &healthpb.HealthCheckRequest{Service: "unknown"})
+	assert.Error(t, err)
+
+	// not serving while draining, though the requests are still served
+	server.Drain()
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, checkHealth(t, health, ""))
+	server.SetServing(true)
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, checkHealth(t, health, broadcastAPIService))
+	_, err = api.Ping(context.Background(), &core_grpc.RequestPing{})
+	assert.NoError(t, err)
+
+	server.Stop()
+	select {
+	case err := <-served:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("server not stopped")
+	}
+	_, err = health.Check(context.Background(), &healthpb.HealthCheckRequest{})
+	assert.Error(t, err)
+}
+
+func TestNodeHealth(t *testing.T) {
+	conn := dial(t, rpctest.GetConfig().RPC.GRPCListenAddress)
+	health := healthpb.NewHealthClient(conn)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, checkHealth(t, health, ""))
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, checkHealth(t, health, broadcastAPIService))
+}
+
+func TestServerReflection(t *testing.T) {
+	conn := dial(t, rpctest.GetConfig().RPC.GRPCListenAddress)
+	stream, err := reflectionpb.NewServerReflectionClient(conn).ServerReflectionInfo(context.Background())
+	require.NoError(t, err)
+	defer stream.CloseSend() //nolint:errcheck
+
+	require.NoError(t, stream.Send(&reflectionpb.ServerReflectionRequest{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_ListServices{},
+	}))
+	res, err := stream.Recv()
+	require.NoError(t, err)
+	var services []string
+	for _, service := range res.GetListServicesResponse().GetService() {
+		services = append(services, service.Name)
+	}
+	assert.Contains(t, services, broadcastAPIService)
+	assert.Contains(t, services, "grpc.health.v1.Health")
+
+	// the descriptors of the BroadcastAPI, registered with gogoproto, are
+	// resolved too
+	require.NoError(t, stream.Send(&reflectionpb.ServerReflectionRequest{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_FileContainingSymbol{
+			FileContainingSymbol: broadcastAPIService,
+		},
+	}))
+	res, err = stream.Recv()
+	require.NoError(t, err)
+	assert.NotEmpty(t, res.GetFileDescriptorResponse().GetFileDescriptorProto())
+}
@@ -24,6 +24,9 @@ func TestArgToJSON(t *testing.T) {
 	}{
 		{[]byte("1234"), "0x31323334"},
 		{Tx("654"), "0x363534"},
+		{&Tx{0xab}, "0xAB"},
+		{(*Tx)(nil), "null"},
+		{nil, "null"},
 		{Foo{7, "hello"}, `{"Bar":"7","Baz":"hello"}`},
 	}
 
@@ -26,13 +26,13 @@ func argsToURLValues(args map[string]interface{}) (url.Values, error) {
 	return values, nil
 }
 
+// argsToJSON encodes the args as URI parameters. Byte slices, and non-nil
+// pointers to byte slices, are encoded as 0x-hex, which the server never
+// confuses with another encoding. Everything else is encoded as JSON.
 func argsToJSON(args map[string]interface{}) error {
 	for k, v := range args {
-		rt := reflect.TypeOf(v)
-		isByteSlice := rt.Kind() == reflect.Slice && rt.Elem().Kind() == reflect.Uint8
-		if isByteSlice {
-			bytes := reflect.ValueOf(v).Bytes()
-			args[k] = fmt.Sprintf("0x%X", bytes)
+		if bz, ok := byteSliceArg(v); ok {
+			args[k] = fmt.Sprintf("0x%X", bz)
 			continue
 		}
 
@@ -44,3 +44,19 @@ func argsToJSON(args map[string]interface{}) error {
 	}
 	return nil
 }
+
+// byteSliceArg returns the bytes of v if it is a byte slice or a non-nil
+// pointer to one, of any named type such as types.Tx or bytes.HexBytes.
+func byteSliceArg(v interface{}) ([]byte, bool) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return nil, false
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Slice || rv.Type().Elem().Kind() != reflect.Uint8 {
+		return nil, false
+	}
+	return rv.Bytes(), true
+}
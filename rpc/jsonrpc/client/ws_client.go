@@ -251,6 +251,12 @@ func (c *WSClient) CallWithArrayParams(ctx context.Context, method string, param
 	return c.Send(ctx, request)
 }
 
+// NextRequestID returns the ID of a new request, for the requests built by
+// the caller and sent with Send, to tell their responses apart.
+func (c *WSClient) NextRequestID() types.JSONRPCIntID {
+	return c.nextRequestID()
+}
+
 // Private methods
 
 func (c *WSClient) nextRequestID() types.JSONRPCIntID {
@@ -0,0 +1,340 @@
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/discard"
+
+	"github.com/tendermint/tendermint/libs/log"
+	cmtsync "github.com/tendermint/tendermint/libs/sync"
+)
+
+// MethodClass groups the RPC functions by their effect on the node, for API
+// keys to grant the calls of.
+type MethodClass string
+
+const (
+	// ClassRead is the class of the functions which only read the state of
+	// the node.
+	ClassRead MethodClass = "read"
+	// ClassBroadcast is the class of the functions which change the state of
+	// the node or of the network, e.g. by broadcasting a transaction.
+	ClassBroadcast MethodClass = "broadcast"
+	// ClassSubscribe is the class of the websocket functions managing the
+	// event subscriptions.
+	ClassSubscribe MethodClass = "subscribe"
+	// ClassUnsafe is the class of the unsafe functions controlling the node.
+	ClassUnsafe MethodClass = "unsafe"
+)
+
+// ParseMethodClass returns the method class named s.
+func ParseMethodClass(s string) (MethodClass, error) {
+	switch c := MethodClass(s); c {
+	case ClassRead, ClassBroadcast, ClassSubscribe, ClassUnsafe:
+		return c, nil
+	default:
+		return "", fmt.Errorf("unknown method class %q, expected one of %q, %q, %q or %q",
+			s, ClassRead, ClassBroadcast, ClassSubscribe, ClassUnsafe)
+	}
+}
+
+// Class returns the method class of the function, derived from its access.
+func (f *RPCFunc) Class() MethodClass {
+	switch {
+	case f.access == AccessUnsafe:
+		return ClassUnsafe
+	case f.ws:
+		return ClassSubscribe
+	case f.access == AccessReadOnly:
+		return ClassRead
+	default:
+		return ClassBroadcast
+	}
+}
+
+// ErrUnauthorized is returned for the calls an API key is required for but
+// the caller did not present one granting them. It is turned into an error of
+// code CodeUnauthorized.
+var ErrUnauthorized = errors.New("unauthorized")
+
+// authMethod is the method of the first websocket message authenticating the
+// connection, as browsers can't set the Authorization header of a websocket.
+const authMethod = "auth"
+
+// KeyID returns the identifier of an API key in the metrics: the first bytes
+// of its SHA-256 hash, hex encoded, which does not reveal the key.
+func KeyID(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(hash[:8])
+}
+
+// apiKeysFile is the JSON file the API keys are loaded from.
+type apiKeysFile struct {
+	Keys []struct {
+		Key     string   `json:"key"`
+		Classes []string `json:"classes"`
+	} `json:"keys"`
+}
+
+type apiKey struct {
+	id      string
+	classes map[MethodClass]bool
+}
+
+// APIKeys authorizes the calls of the RPC functions by method class. The calls
+// of the public classes are open to all, while the others need an API key
+// granting their class, presented as a bearer token in the Authorization
+// header, or in the first message of a websocket connection:
+//
+//	{"jsonrpc": "2.0", "id": 0, "method": "auth", "params": {"key": "<key>"}}
+//
+// The keys are loaded from a JSON file, which is reloaded once modified:
+//
+//	{"keys": [{"key": "<key>", "classes": ["read", "broadcast"]}]}
+//
+// Keys are only referred to by their KeyID, they never appear in the logs.
+type APIKeys struct {
+	path   string
+	public map[MethodClass]bool
+	logger log.Logger
+
+	// the file is checked for changes at most once per reloadInterval
+	reloadInterval time.Duration
+
+	// Number of calls authorized by an API key, by key id and class.
+	calls metrics.Counter
+	// Number of calls rejected as unauthorized, by class.
+	unauthorized metrics.Counter
+
+	mtx     cmtsync.Mutex
+	keys    map[[sha256.Size]byte]apiKey
+	modTime time.Time
+	size    int64
+	checked time.Time
+}
+
+// NewAPIKeys loads the API keys from the file at path. The calls of the public
+// classes need no key.
+func NewAPIKeys(path string, public []MethodClass) (*APIKeys, error) {
+	ks := &APIKeys{
+		path:           path,
+		public:         make(map[MethodClass]bool, len(public)),
+		logger:         log.NewNopLogger(),
+		reloadInterval: time.Second,
+		calls:          discard.NewCounter(),
+		unauthorized:   discard.NewCounter(),
+	}
+	for _, class := range public {
+		ks.public[class] = true
+	}
+	if err := ks.reload(); err != nil {
+		return nil, err
+	}
+	return ks, nil
+}
+
+// SetLogger sets the logger.
+func (ks *APIKeys) SetLogger(l log.Logger) {
+	ks.logger = l
+}
+
+// SetMetrics sets the counters of the calls authorized by an API key, labeled
+// by "key_id" and "class", and of the calls rejected as unauthorized, labeled
+// by "class".
+func (ks *APIKeys) SetMetrics(calls, unauthorized metrics.Counter) {
+	ks.calls = calls
+	ks.unauthorized = unauthorized
+}
+
+// reload loads the keys from the file if it changed since the last load.
+func (ks *APIKeys) reload() error {
+	fi, err := os.Stat(ks.path)
+	if err != nil {
+		return fmt.Errorf("failed to read the API keys: %w", err)
+	}
+	if ks.keys != nil && fi.ModTime().Equal(ks.modTime) && fi.Size() == ks.size {
+		return nil
+	}
+	keys, err := loadAPIKeys(ks.path)
+	if err != nil {
+		return err
+	}
+	ks.keys = keys
+	ks.modTime = fi.ModTime()
+	ks.size = fi.Size()
+	return nil
+}
+
+// maybeReload reloads the keys if the file changed and was not checked for
+// reloadInterval. If the file is invalid, the keys loaded last are kept.
+func (ks *APIKeys) maybeReload() {
+	if time.Since(ks.checked) < ks.reloadInterval {
+		return
+	}
+	ks.checked = time.Now()
+	modTime := ks.modTime
+	if err := ks.reload(); err != nil {
+		ks.logger.Error("Failed to reload the API keys, keeping the previous ones", "path", ks.path, "err", err)
+		return
+	}
+	if !ks.modTime.Equal(modTime) {
+		ks.logger.Info("Reloaded the API keys", "path", ks.path, "keys", len(ks.keys))
+	}
+}
+
+// loadAPIKeys reads and validates the API keys file at path. Its errors refer
+// to the keys by their index, as they are logged.
+func loadAPIKeys(path string) (map[[sha256.Size]byte]apiKey, error) {
+	bz, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the API keys: %w", err)
+	}
+	var file apiKeysFile
+	if err := json.Unmarshal(bz, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse the API keys file %s: %w", path, err)
+	}
+	keys := make(map[[sha256.Size]byte]apiKey, len(file.Keys))
+	for i, k := range file.Keys {
+		if k.Key == "" {
+			return nil, fmt.Errorf("API key #%d is empty", i)
+		}
+		hash := sha256.Sum256([]byte(k.Key))
+		if _, ok := keys[hash]; ok {
+			return nil, fmt.Errorf("API key #%d is a duplicate", i)
+		}
+		key := apiKey{id: KeyID(k.Key), classes: make(map[MethodClass]bool, len(k.Classes))}
+		for _, s := range k.Classes {
+			class, err := ParseMethodClass(s)
+			if err != nil {
+				return nil, fmt.Errorf("API key #%d: %w", i, err)
+			}
+			key.classes[class] = true
+		}
+		keys[hash] = key
+	}
+	return keys, nil
+}
+
+// lookup returns the key of the given hash, if it is known.
+func (ks *APIKeys) lookup(hash [sha256.Size]byte) (apiKey, bool) {
+	ks.mtx.Lock()
+	defer ks.mtx.Unlock()
+	ks.maybeReload()
+	key, ok := ks.keys[hash]
+	return key, ok
+}
+
+// authorize returns an error wrapping ErrUnauthorized if the caller may not
+// call the functions of class.
+func (ks *APIKeys) authorize(c *apiCaller, class MethodClass) error {
+	if c.invalid {
+		ks.unauthorized.With("class", string(class)).Add(1)
+		return fmt.Errorf("%w: malformed Authorization header, expected \"Bearer <key>\"", ErrUnauthorized)
+	}
+	if !c.hasKey {
+		if ks.public[class] {
+			return nil
+		}
+		ks.unauthorized.With("class", string(class)).Add(1)
+		return fmt.Errorf("%w: an API key granting the %s class is required", ErrUnauthorized, class)
+	}
+	key, ok := ks.lookup(c.hash)
+	if !ok {
+		ks.unauthorized.With("class", string(class)).Add(1)
+		return fmt.Errorf("%w: unknown API key", ErrUnauthorized)
+	}
+	if !key.classes[class] && !ks.public[class] {
+		ks.unauthorized.With("class", string(class)).Add(1)
+		return fmt.Errorf("%w: the API key does not grant the %s class", ErrUnauthorized, class)
+	}
+	ks.calls.With("key_id", key.id, "class", string(class)).Add(1)
+	return nil
+}
+
+// apiCaller is the caller of the requests of an HTTP request or websocket
+// connection. Only the hash of its key is kept, and it is looked up on every
+// call, so that revoking a key applies to the open websocket connections.
+type apiCaller struct {
+	keys    *APIKeys
+	hasKey  bool
+	hash    [sha256.Size]byte
+	invalid bool
+}
+
+// authorize returns an error wrapping ErrUnauthorized if the caller may not
+// call the functions of class. A nil caller, of a server without API keys, may
+// call all the functions.
+func (c *apiCaller) authorize(class MethodClass) error {
+	if c == nil {
+		return nil
+	}
+	return c.keys.authorize(c, class)
+}
+
+func (c *apiCaller) setKey(key string) {
+	c.hasKey = true
+	c.hash = sha256.Sum256([]byte(key))
+}
+
+type apiCallerKey struct{}
+
+// callerFromContext returns the caller of a request set by AuthHandler, or nil
+// if the server has no API keys.
+func callerFromContext(ctx context.Context) *apiCaller {
+	c, _ := ctx.Value(apiCallerKey{}).(*apiCaller)
+	return c
+}
+
+// AuthHandler wraps an HTTP handler serving the RPC functions, so that their
+// calls are authorized by the API keys.
+func AuthHandler(keys *APIKeys, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c := &apiCaller{keys: keys}
+		if header := r.Header.Get("Authorization"); header != "" {
+			scheme, key, ok := strings.Cut(header, " ")
+			if !ok || !strings.EqualFold(scheme, "Bearer") || key == "" {
+				c.invalid = true
+			} else {
+				c.setKey(key)
+			}
+			// the key must not reach the handlers, which may log the request
+			r.Header.Del("Authorization")
+		}
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), apiCallerKey{}, c)))
+	})
+}
+
+// authResult is the result of the websocket authentication message.
+type authResult struct {
+	KeyID string `json:"key_id"`
+}
+
+// authenticate authenticates the caller with the key of the params of the
+// websocket authentication message, and returns the id of the key.
+func (c *apiCaller) authenticate(params json.RawMessage) (string, error) {
+	var p struct {
+		Key string `json:"key"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil || p.Key == "" {
+		return "", fmt.Errorf("%w: expected the params {\"key\": \"<key>\"}", ErrUnauthorized)
+	}
+	hash := sha256.Sum256([]byte(p.Key))
+	key, ok := c.keys.lookup(hash)
+	if !ok {
+		return "", fmt.Errorf("%w: unknown API key", ErrUnauthorized)
+	}
+	c.hasKey = true
+	c.hash = hash
+	c.invalid = false
+	return key.id, nil
+}
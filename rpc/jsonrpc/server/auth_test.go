@@ -0,0 +1,235 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/libs/log"
+	types "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+)
+
+const testAPIKeys = `{"keys": [
+	{"key": "broadcast-key", "classes": ["broadcast"]},
+	{"key": "subscribe-key", "classes": ["subscribe"]},
+	{"key": "admin-key", "classes": ["read", "broadcast", "subscribe", "unsafe"]}
+]}`
+
+func writeAPIKeys(t *testing.T, path, keys string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(path, []byte(keys), 0o600))
+}
+
+// newAuthServer serves a function of each method class, authorized by the API
+// keys of the file at path, the read class being public.
+func newAuthServer(t *testing.T, path string) (*httptest.Server, *APIKeys) {
+	t.Helper()
+	result := func(ctx *types.Context) (string, error) { return "ok", nil }
+	funcMap := map[string]*RPCFunc{
+		"read":      NewRPCFunc(result, "", ReadOnly()),
+		"broadcast": NewRPCFunc(result, "", Mutating()),
+		"unsafe":    NewRPCFunc(result, "", Unsafe()),
+		"subscribe": NewWSRPCFunc(result, "", ReadOnly()),
+	}
+	keys, err := NewAPIKeys(path, []MethodClass{ClassRead})
+	require.NoError(t, err)
+	keys.reloadInterval = 0
+
+	mux := http.NewServeMux()
+	wm := NewWebsocketManager(funcMap)
+	wm.SetLogger(log.TestingLogger())
+	mux.HandleFunc("/websocket", wm.WebsocketHandler)
+	RegisterRPCFuncs(mux, funcMap, log.TestingLogger())
+	s := httptest.NewServer(AuthHandler(keys, mux))
+	t.Cleanup(s.Close)
+	return s, keys
+}
+
+// callHTTP calls method over JSON-RPC and over URI with the given
+// Authorization header, and returns the error codes of both calls, 0 for
+// success.
+func callHTTP(t *testing.T, s *httptest.Server, authorization, method string) (int, int) {
+	t.Helper()
+	codes := make([]int, 2)
+	for i, req := range []*http.Request{
+		httptest.NewRequest(http.MethodPost, s.URL,
+			strings.NewReader(fmt.Sprintf(`{"jsonrpc": "2.0", "id": 0, "method": %q}`, method))),
+		httptest.NewRequest(http.MethodGet, s.URL+"/"+method, nil),
+	} {
+		req.RequestURI = ""
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+		res, err := s.Client().Do(req)
+		require.NoError(t, err)
+		body, err := io.ReadAll(res.Body)
+		res.Body.Close()
+		require.NoError(t, err)
+		var resp types.RPCResponse
+		require.NoError(t, json.Unmarshal(body, &resp))
+		if resp.Error != nil {
+			codes[i] = resp.Error.Code
+			if i == 1 && resp.Error.Code == types.CodeUnauthorized {
+				assert.Equal(t, http.StatusUnauthorized, res.StatusCode)
+			}
+		}
+	}
+	return codes[0], codes[1]
+}
+
+func TestAuthHTTPClasses(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "api_keys.json")
+	writeAPIKeys(t, path, testAPIKeys)
+	s, _ := newAuthServer(t, path)
+
+	testCases := []struct {
+		authorization string
+		allowed       map[string]bool
+	}{
+		{"", map[string]bool{"read": true}},
+		{"Bearer broadcast-key", map[string]bool{"read": true, "broadcast": true}},
+		{"Bearer subscribe-key", map[string]bool{"read": true}},
+		{"bearer admin-key", map[string]bool{"read": true, "broadcast": true, "unsafe": true}},
+		// unknown or malformed keys are rejected, even for the public classes
+		{"Bearer unknown-key", map[string]bool{}},
+		{"Basic YWRtaW4tay", map[string]bool{}},
+		{"admin-key", map[string]bool{}},
+	}
+	for _, tc := range testCases {
+		for _, method := range []string{"read", "broadcast", "unsafe"} {
+			jsonCode, uriCode := callHTTP(t, s, tc.authorization, method)
+			if tc.allowed[method] {
+				assert.Zero(t, jsonCode, "%q calling %s", tc.authorization, method)
+				assert.Zero(t, uriCode, "%q calling %s", tc.authorization, method)
+			} else {
+				assert.Equal(t, types.CodeUnauthorized, jsonCode, "%q calling %s", tc.authorization, method)
+				assert.Equal(t, types.CodeUnauthorized, uriCode, "%q calling %s", tc.authorization, method)
+			}
+		}
+	}
+}
+
+// callWS calls method on the websocket connection and returns the error code,
+// 0 for success.
+func callWS(t *testing.T, c *websocket.Conn, method string, params map[string]interface{}) int {
+	t.Helper()
+	req, err := types.MapToRequest(types.JSONRPCStringID(method), method, params)
+	require.NoError(t, err)
+	require.NoError(t, c.WriteJSON(req))
+	var resp types.RPCResponse
+	require.NoError(t, c.ReadJSON(&resp))
+	if resp.Error != nil {
+		return resp.Error.Code
+	}
+	return 0
+}
+
+func dialWS(t *testing.T, s *httptest.Server, authorization string) *websocket.Conn {
+	t.Helper()
+	header := http.Header{}
+	if authorization != "" {
+		header.Set("Authorization", authorization)
+	}
+	c, dialResp, err := websocket.DefaultDialer.Dial("ws://"+s.Listener.Addr().String()+"/websocket", header)
+	require.NoError(t, err)
+	dialResp.Body.Close()
+	t.Cleanup(func() { c.Close() })
+	return c
+}
+
+func TestAuthWebsocketClasses(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "api_keys.json")
+	writeAPIKeys(t, path, testAPIKeys)
+	s, _ := newAuthServer(t, path)
+
+	// anonymous connections only call the public classes, and can't
+	// authenticate after their first message
+	c := dialWS(t, s, "")
+	assert.Zero(t, callWS(t, c, "read", nil))
+	assert.Equal(t, types.CodeUnauthorized, callWS(t, c, "subscribe", nil))
+	assert.Equal(t, types.CodeUnauthorized, callWS(t, c, "broadcast", nil))
+	assert.Equal(t, -32601, callWS(t, c, authMethod, map[string]interface{}{"key": "admin-key"}))
+
+	// the key of the upgrade request authenticates the connection
+	c = dialWS(t, s, "Bearer subscribe-key")
+	assert.Zero(t, callWS(t, c, "subscribe", nil))
+	assert.Equal(t, types.CodeUnauthorized, callWS(t, c, "broadcast", nil))
+
+	// as does the first message
+	c = dialWS(t, s, "")
+	assert.Equal(t, types.CodeUnauthorized, callWS(t, c, authMethod, map[string]interface{}{"key": "unknown-key"}))
+	assert.Equal(t, types.CodeUnauthorized, callWS(t, c, "subscribe", nil))
+
+	c = dialWS(t, s, "")
+	req, err := types.MapToRequest(types.JSONRPCStringID("auth"), authMethod, map[string]interface{}{"key": "admin-key"})
+	require.NoError(t, err)
+	require.NoError(t, c.WriteJSON(req))
+	var resp types.RPCResponse
+	require.NoError(t, c.ReadJSON(&resp))
+	require.Nil(t, resp.Error)
+	assert.Contains(t, string(resp.Result), KeyID("admin-key"))
+	for _, method := range []string{"read", "broadcast", "subscribe", "unsafe"} {
+		assert.Zero(t, callWS(t, c, method, nil), method)
+	}
+}
+
+func TestAuthReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "api_keys.json")
+	writeAPIKeys(t, path, testAPIKeys)
+	s, _ := newAuthServer(t, path)
+	c := dialWS(t, s, "Bearer broadcast-key")
+
+	jsonCode, _ := callHTTP(t, s, "Bearer broadcast-key", "broadcast")
+	require.Zero(t, jsonCode)
+	jsonCode, _ = callHTTP(t, s, "Bearer new-key", "broadcast")
+	require.Equal(t, types.CodeUnauthorized, jsonCode)
+
+	// rotate the broadcast key, which applies to the open connections too
+	writeAPIKeys(t, path, `{"keys": [{"key": "new-key", "classes": ["broadcast"]}]}`)
+	modTime := time.Now().Add(time.Minute)
+	require.NoError(t, os.Chtimes(path, modTime, modTime))
+
+	jsonCode, _ = callHTTP(t, s, "Bearer broadcast-key", "broadcast")
+	assert.Equal(t, types.CodeUnauthorized, jsonCode)
+	jsonCode, _ = callHTTP(t, s, "Bearer new-key", "broadcast")
+	assert.Zero(t, jsonCode)
+	assert.Equal(t, types.CodeUnauthorized, callWS(t, c, "broadcast", nil))
+
+	// an invalid file keeps the keys loaded last
+	writeAPIKeys(t, path, `{"keys": [{"key": "new-key", "classes": ["write"]}]}`)
+	modTime = modTime.Add(time.Minute)
+	require.NoError(t, os.Chtimes(path, modTime, modTime))
+
+	jsonCode, _ = callHTTP(t, s, "Bearer new-key", "broadcast")
+	assert.Zero(t, jsonCode)
+}
+
+func TestNewAPIKeysInvalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "api_keys.json")
+	_, err := NewAPIKeys(path, nil)
+	require.Error(t, err)
+
+	for _, keys := range []string{
+		`{"keys": [`,
+		`{"keys": [{"key": "", "classes": ["read"]}]}`,
+		`{"keys": [{"key": "secret-key", "classes": ["write"]}]}`,
+		`{"keys": [{"key": "secret-key", "classes": ["read"]}, {"key": "secret-key", "classes": ["unsafe"]}]}`,
+	} {
+		writeAPIKeys(t, path, keys)
+		_, err := NewAPIKeys(path, nil)
+		require.Error(t, err, keys)
+		// the errors are logged, so must not reveal the keys
+		assert.NotContains(t, err.Error(), "secret-key")
+	}
+}
@@ -0,0 +1,92 @@
+package server
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"strings"
+
+	cmtbytes "github.com/tendermint/tendermint/libs/bytes"
+)
+
+// Encodings accepted for byte slice parameters.
+const (
+	encodingPrefixedHex = "0x-hex"
+	encodingHex         = "hex"
+	encodingBase64      = "base64"
+)
+
+var hexBytesType = reflect.TypeOf(cmtbytes.HexBytes{})
+
+// isBytesParam returns true if rt is a byte slice or a pointer to one.
+func isBytesParam(rt reflect.Type) bool {
+	if rt.Kind() == reflect.Ptr {
+		rt = rt.Elem()
+	}
+	return rt.Kind() == reflect.Slice && rt.Elem().Kind() == reflect.Uint8
+}
+
+// decodeBytesParam decodes a byte slice parameter given as 0x-hex, bare hex or
+// base64. The encoding is chosen deterministically:
+//
+//  1. a value starting with "0x" or "0X" is 0x-hex;
+//  2. a value that is valid in only one of bare hex and base64 uses that
+//     encoding;
+//  3. a value that is valid in both uses hex if preferHex is true, base64
+//     otherwise.
+//
+// If the value cannot be decoded, the error names the encodings that were
+// tried, in the order of preference.
+func decodeBytesParam(s string, preferHex bool) ([]byte, error) {
+	if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+		bz, err := hex.DecodeString(s[2:])
+		if err != nil {
+			return nil, fmt.Errorf("decoding as %s: %w", encodingPrefixedHex, err)
+		}
+		return bz, nil
+	}
+
+	first, second := encodingBase64, encodingHex
+	if preferHex {
+		first, second = second, first
+	}
+	bz, err1 := decodeBytesAs(first, s)
+	if err1 == nil {
+		return bz, nil
+	}
+	bz, err2 := decodeBytesAs(second, s)
+	if err2 == nil {
+		return bz, nil
+	}
+	return nil, fmt.Errorf("decoding as %s: %v; decoding as %s: %v", first, err1, second, err2)
+}
+
+func decodeBytesAs(encoding, s string) ([]byte, error) {
+	if encoding == encodingHex {
+		return hex.DecodeString(s)
+	}
+	return base64.StdEncoding.DecodeString(s)
+}
+
+// bytesParamToArg decodes s, see decodeBytesParam, into a value of type rt,
+// which must be a byte slice or a pointer to one. Hex is preferred for
+// ambiguous values if uri is true, since URI requests have always encoded
+// bytes as hex, or if the parameter is a HexBytes, whose JSON encoding is hex.
+func bytesParamToArg(rt reflect.Type, s string, uri bool) (reflect.Value, error) {
+	if rt.Kind() == reflect.Ptr {
+		v, err := bytesParamToArg(rt.Elem(), s, uri)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		rv := reflect.New(rt.Elem())
+		rv.Elem().Set(v)
+		return rv, nil
+	}
+
+	bz, err := decodeBytesParam(s, uri || rt == hexBytesType)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	return reflect.ValueOf(bz).Convert(rt), nil
+}
@@ -3,6 +3,7 @@ package server
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -21,6 +22,13 @@ func makeJSONRPCHandler(funcMap map[string]*RPCFunc, logger log.Logger) http.Han
 	return func(w http.ResponseWriter, r *http.Request) {
 		b, err := io.ReadAll(r.Body)
 		if err != nil {
+			if isRequestTooLarge(err) {
+				res := types.RPCRequestTooLargeError(nil, fmt.Errorf("error reading request body: %w", err))
+				if wErr := WriteRPCResponseHTTPError(w, http.StatusRequestEntityTooLarge, res); wErr != nil {
+					logger.Error("failed to write response", "err", wErr)
+				}
+				return
+			}
 			res := types.RPCInvalidRequestError(nil,
 				fmt.Errorf("error reading request body: %w", err),
 			)
@@ -86,15 +94,32 @@ func makeJSONRPCHandler(funcMap map[string]*RPCFunc, logger log.Logger) http.Han
 				cache = false
 				continue
 			}
+			if rpcFunc.rejected {
+				responses = append(responses, types.RPCReadOnlyError(request.ID, request.Method))
+				cache = false
+				continue
+			}
+			if rpcFunc.unavailable != "" {
+				responses = append(responses, types.RPCUnavailableError(request.ID, request.Method, rpcFunc.unavailable))
+				cache = false
+				continue
+			}
+			if err := callerFromContext(r.Context()).authorize(rpcFunc.Class()); err != nil {
+				responses = append(responses, types.RPCUnauthorizedError(request.ID, request.Method, err))
+				cache = false
+				continue
+			}
 			ctx := &types.Context{JSONReq: &request, HTTPReq: r}
 			args := []reflect.Value{reflect.ValueOf(ctx)}
 			if len(request.Params) > 0 {
-				fnArgs, err := jsonParamsToArgs(rpcFunc, request.Params)
+				fnArgs, err := jsonParamsToArgs(rpcFunc, request.Params, maxParamBytes(r))
 				if err != nil {
-					responses = append(
-						responses,
-						types.RPCInvalidParamsError(request.ID, fmt.Errorf("error converting json params to arguments: %w", err)),
-					)
+					err = fmt.Errorf("error converting json params to arguments: %w", err)
+					if isRequestTooLarge(err) {
+						responses = append(responses, types.RPCRequestTooLargeError(request.ID, err))
+					} else {
+						responses = append(responses, types.RPCInvalidParamsError(request.ID, err))
+					}
 					cache = false
 					continue
 				}
@@ -108,7 +133,7 @@ func makeJSONRPCHandler(funcMap map[string]*RPCFunc, logger log.Logger) http.Han
 			returns := rpcFunc.f.Call(args)
 			result, err := unreflectResult(returns)
 			if err != nil {
-				responses = append(responses, types.RPCInternalError(request.ID, err))
+				responses = append(responses, types.RPCFuncError(request.ID, err))
 				continue
 			}
 			responses = append(responses, types.NewRPCSuccessResponse(request.ID, result))
@@ -141,22 +166,45 @@ func handleInvalidJSONRPCPaths(next http.HandlerFunc) http.HandlerFunc {
 	}
 }
 
+// ErrParamTooLarge is returned if the encoded size of a byte-carrying request
+// parameter exceeds the configured maximum parameter size.
+var ErrParamTooLarge = errors.New("parameter too large")
+
+// checkParamSize returns ErrParamTooLarge if the parameter is byte-carrying,
+// i.e. a byte slice or an interface (such as evidence), and its encoded size
+// exceeds limit. A limit of 0 disables the check.
+func checkParamSize(name string, rt reflect.Type, size int, limit int64) error {
+	if limit <= 0 {
+		return nil
+	}
+	if !isBytesParam(rt) && rt.Kind() != reflect.Interface {
+		return nil
+	}
+	if int64(size) > limit {
+		return fmt.Errorf("%w: %s is %d bytes, max %d", ErrParamTooLarge, name, size, limit)
+	}
+	return nil
+}
+
 func mapParamsToArgs(
 	rpcFunc *RPCFunc,
 	params map[string]json.RawMessage,
 	argsOffset int,
+	maxParamBytes int64,
 ) ([]reflect.Value, error) {
 	values := make([]reflect.Value, len(rpcFunc.argNames))
 	for i, argName := range rpcFunc.argNames {
 		argType := rpcFunc.args[i+argsOffset]
 
 		if p, ok := params[argName]; ok && p != nil && len(p) > 0 {
-			val := reflect.New(argType)
-			err := cmtjson.Unmarshal(p, val.Interface())
+			if err := checkParamSize(argName, argType, len(p), maxParamBytes); err != nil {
+				return nil, err
+			}
+			val, err := jsonParamToArg(argName, argType, p)
 			if err != nil {
 				return nil, err
 			}
-			values[i] = val.Elem()
+			values[i] = val
 		} else { // use default for that type
 			values[i] = reflect.Zero(argType)
 		}
@@ -169,25 +217,51 @@ func arrayParamsToArgs(
 	rpcFunc *RPCFunc,
 	params []json.RawMessage,
 	argsOffset int,
+	maxParamBytes int64,
 ) ([]reflect.Value, error) {
 	if len(rpcFunc.argNames) != len(params) {
-		return nil, fmt.Errorf("expected %v parameters (%v), got %v (%v)",
-			len(rpcFunc.argNames), rpcFunc.argNames, len(params), params)
+		return nil, fmt.Errorf("expected %v parameters (%v), got %v",
+			len(rpcFunc.argNames), rpcFunc.argNames, len(params))
 	}
 
 	values := make([]reflect.Value, len(params))
 	for i, p := range params {
 		argType := rpcFunc.args[i+argsOffset]
-		val := reflect.New(argType)
-		err := cmtjson.Unmarshal(p, val.Interface())
+		if err := checkParamSize(rpcFunc.argNames[i], argType, len(p), maxParamBytes); err != nil {
+			return nil, err
+		}
+		val, err := jsonParamToArg(rpcFunc.argNames[i], argType, p)
 		if err != nil {
 			return nil, err
 		}
-		values[i] = val.Elem()
+		values[i] = val
 	}
 	return values, nil
 }
 
+// jsonParamToArg decodes the JSON encoded parameter into a value of type rt.
+// Byte slices given as JSON strings accept 0x-hex, bare hex and base64, see
+// decodeBytesParam.
+func jsonParamToArg(name string, rt reflect.Type, p json.RawMessage) (reflect.Value, error) {
+	if isBytesParam(rt) && len(p) > 0 && p[0] == '"' {
+		var s string
+		if err := json.Unmarshal(p, &s); err != nil {
+			return reflect.Value{}, err
+		}
+		val, err := bytesParamToArg(rt, s, false)
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("invalid %s: %w", name, err)
+		}
+		return val, nil
+	}
+
+	val := reflect.New(rt)
+	if err := cmtjson.Unmarshal(p, val.Interface()); err != nil {
+		return reflect.Value{}, err
+	}
+	return val.Elem(), nil
+}
+
 // raw is unparsed json (from json.RawMessage) encoding either a map or an
 // array.
 //
@@ -195,7 +269,10 @@ func arrayParamsToArgs(
 //
 //	rpcFunc.args = [rpctypes.Context string]
 //	rpcFunc.argNames = ["arg"]
-func jsonParamsToArgs(rpcFunc *RPCFunc, raw []byte) ([]reflect.Value, error) {
+//
+// Byte-carrying parameters larger than maxParamBytes are rejected with
+// ErrParamTooLarge before being decoded.
+func jsonParamsToArgs(rpcFunc *RPCFunc, raw []byte, maxParamBytes int64) ([]reflect.Value, error) {
 	const argsOffset = 1
 
 	// TODO: Make more efficient, perhaps by checking the first character for '{' or '['?
@@ -203,14 +280,14 @@ func jsonParamsToArgs(rpcFunc *RPCFunc, raw []byte) ([]reflect.Value, error) {
 	var m map[string]json.RawMessage
 	err := json.Unmarshal(raw, &m)
 	if err == nil {
-		return mapParamsToArgs(rpcFunc, m, argsOffset)
+		return mapParamsToArgs(rpcFunc, m, argsOffset, maxParamBytes)
 	}
 
 	// Otherwise, try an array.
 	var a []json.RawMessage
 	err = json.Unmarshal(raw, &a)
 	if err == nil {
-		return arrayParamsToArgs(rpcFunc, a, argsOffset)
+		return arrayParamsToArgs(rpcFunc, a, argsOffset, maxParamBytes)
 	}
 
 	// Otherwise, bad format, we cannot parse
@@ -277,3 +277,39 @@ func TestRPCResponseCache(t *testing.T) {
 	res.Body.Close()
 	require.Nil(t, err, "reading from the body should not give back an error")
 }
+
+func TestUnavailableFuncs(t *testing.T) {
+	funcMap := map[string]*RPCFunc{
+		"c":     NewRPCFunc(func(ctx *types.Context, s string, i int) (string, error) { return "foo", nil }, "s,i"),
+		"block": NewRPCFunc(func(ctx *types.Context, h int) (string, error) { return "block", nil }, "height"),
+	}
+	mux := http.NewServeMux()
+	RegisterRPCFuncs(mux, UnavailableFuncs(funcMap, "not available here", "block", "unknown"), log.TestingLogger())
+
+	// JSON-RPC
+	body := strings.NewReader(`[{"jsonrpc": "2.0","method":"block","id": 0, "params": ["1"]},` +
+		`{"jsonrpc": "2.0","method":"c","id": 1, "params": ["a", "1"]}]`)
+	req, _ := http.NewRequest("POST", "http://localhost/", body)
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	res := rec.Result()
+	var responses []types.RPCResponse
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&responses))
+	res.Body.Close()
+	require.Len(t, responses, 2)
+	require.NotNil(t, responses[0].Error)
+	assert.Equal(t, types.CodeUnavailable, responses[0].Error.Code)
+	assert.Equal(t, "block is not available here", responses[0].Error.Data)
+	assert.Nil(t, responses[1].Error)
+
+	// URI
+	req, _ = http.NewRequest("GET", "http://localhost/block?height=1", nil)
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	res = rec.Result()
+	assert.Equal(t, http.StatusNotImplemented, res.StatusCode)
+	res.Body.Close()
+
+	// the original map is left untouched
+	assert.Empty(t, funcMap["block"].unavailable)
+}
@@ -3,6 +3,7 @@ package server
 
 import (
 	"bufio"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -30,6 +31,9 @@ type Config struct {
 	// MaxBodyBytes controls the maximum number of bytes the
 	// server will read parsing the request body.
 	MaxBodyBytes int64
+	// MaxParamBytes controls the maximum encoded size of a single byte-carrying
+	// parameter (e.g. a tx or evidence). 0 means no limit.
+	MaxParamBytes int64
 	// mirrors http.Server#MaxHeaderBytes
 	MaxHeaderBytes int
 }
@@ -41,19 +45,21 @@ func DefaultConfig() *Config {
 		ReadTimeout:        10 * time.Second,
 		WriteTimeout:       10 * time.Second,
 		MaxBodyBytes:       int64(1000000), // 1MB
+		MaxParamBytes:      int64(1000000), // 1MB
 		MaxHeaderBytes:     1 << 20,        // same as the net/http default
 	}
 }
 
 // Serve creates a http.Server and calls Serve with the given listener. It
 // wraps handler with RecoverAndLogHandler and a handler, which limits the max
-// body size to config.MaxBodyBytes.
+// body size to config.MaxBodyBytes and the max parameter size to
+// config.MaxParamBytes.
 //
 // NOTE: This function blocks - you may want to call it in a go-routine.
 func Serve(listener net.Listener, handler http.Handler, logger log.Logger, config *Config) error {
 	logger.Info("serve", "msg", log.NewLazySprintf("Starting RPC HTTP server on %s", listener.Addr()))
 	s := &http.Server{
-		Handler:           RecoverAndLogHandler(maxBytesHandler{h: handler, n: config.MaxBodyBytes}, logger),
+		Handler:           RecoverAndLogHandler(maxBytesHandler{h: handler, n: config.MaxBodyBytes, p: config.MaxParamBytes}, logger),
 		ReadTimeout:       config.ReadTimeout,
 		ReadHeaderTimeout: config.ReadTimeout,
 		WriteTimeout:      config.WriteTimeout,
@@ -66,7 +72,8 @@ func Serve(listener net.Listener, handler http.Handler, logger log.Logger, confi
 
 // Serve creates a http.Server and calls ServeTLS with the given listener,
 // certFile and keyFile. It wraps handler with RecoverAndLogHandler and a
-// handler, which limits the max body size to config.MaxBodyBytes.
+// handler, which limits the max body size to config.MaxBodyBytes and the max
+// parameter size to config.MaxParamBytes.
 //
 // NOTE: This function blocks - you may want to call it in a go-routine.
 func ServeTLS(
@@ -79,7 +86,7 @@ func ServeTLS(
 	logger.Info("serve tls", "msg", log.NewLazySprintf("Starting RPC HTTPS server on %s (cert: %q, key: %q)",
 		listener.Addr(), certFile, keyFile))
 	s := &http.Server{
-		Handler:           RecoverAndLogHandler(maxBytesHandler{h: handler, n: config.MaxBodyBytes}, logger),
+		Handler:           RecoverAndLogHandler(maxBytesHandler{h: handler, n: config.MaxBodyBytes, p: config.MaxParamBytes}, logger),
 		ReadTimeout:       config.ReadTimeout,
 		ReadHeaderTimeout: config.ReadTimeout,
 		WriteTimeout:      config.WriteTimeout,
@@ -249,13 +256,34 @@ func (w *responseWriterWrapper) Hijack() (net.Conn, *bufio.ReadWriter, error) {
 type maxBytesHandler struct {
 	h http.Handler
 	n int64
+	p int64
 }
 
 func (h maxBytesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	r.Body = http.MaxBytesReader(w, r.Body, h.n)
+	if h.p > 0 {
+		r = r.WithContext(context.WithValue(r.Context(), maxParamBytesKey{}, h.p))
+	}
 	h.h.ServeHTTP(w, r)
 }
 
+type maxParamBytesKey struct{}
+
+// maxParamBytes returns the maximum parameter size set for the request by
+// maxBytesHandler or 0 if there is no limit.
+func maxParamBytes(r *http.Request) int64 {
+	n, _ := r.Context().Value(maxParamBytesKey{}).(int64)
+	return n
+}
+
+// isRequestTooLarge returns true if err was caused by reading more than the
+// maximum number of bytes allowed for the request body or by a parameter
+// exceeding the maximum parameter size.
+func isRequestTooLarge(err error) bool {
+	var maxBytesErr *http.MaxBytesError
+	return errors.As(err, &maxBytesErr) || errors.Is(err, ErrParamTooLarge)
+}
+
 // Listen starts a new net.Listener on the given address.
 // It returns an error if the address is invalid or the call to Listen() fails.
 func Listen(addr string, config *Config) (listener net.Listener, err error) {
@@ -2,12 +2,15 @@ package server
 
 import (
 	"crypto/tls"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"net"
 	"net/http"
 	"net/http/httptest"
+	"runtime"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -154,3 +157,115 @@ func TestWriteRPCResponseHTTPError(t *testing.T) {
 	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
 	assert.Equal(t, `{"jsonrpc":"2.0","id":-1,"error":{"code":-32603,"message":"Internal error","data":"foo"}}`, string(body))
 }
+
+// repeatReader endlessly returns the same byte.
+type repeatReader byte
+
+func (r repeatReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = byte(r)
+	}
+	return len(p), nil
+}
+
+func TestOversizedRequests(t *testing.T) {
+	const (
+		maxBodyBytes  = 1000000
+		maxParamBytes = 1000
+		// requests are generated lazily so that the body is never held in
+		// memory by the test itself.
+		oversizedBodyBytes = 64 * maxBodyBytes
+		// the server may allocate a few multiples of maxBodyBytes while
+		// reading and decoding, but never anything near the full body.
+		maxAllocBytes = 16 * maxBodyBytes
+	)
+
+	funcMap := map[string]*RPCFunc{
+		"tx": NewRPCFunc(func(ctx *types.Context, tx []byte) (string, error) { return "ok", nil }, "tx"),
+	}
+	mux := http.NewServeMux()
+	RegisterRPCFuncs(mux, funcMap, log.NewNopLogger())
+	handler := maxBytesHandler{h: mux, n: maxBodyBytes, p: maxParamBytes}
+
+	largeTx := strings.Repeat("ab", maxParamBytes)
+	nested := strings.Repeat("[", maxBodyBytes/2) + strings.Repeat("]", maxBodyBytes/2)
+
+	tests := []struct {
+		name       string
+		method     string
+		url        string
+		body       io.Reader
+		wantStatus int
+		wantError  string
+	}{
+		{
+			"oversized body",
+			http.MethodPost, "http://localhost/",
+			io.MultiReader(
+				strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"tx","params":{"tx":"`),
+				io.LimitReader(repeatReader('a'), oversizedBodyBytes),
+			),
+			http.StatusRequestEntityTooLarge, "Request too large",
+		},
+		{
+			"oversized URI form body",
+			http.MethodPost, "http://localhost/tx",
+			io.MultiReader(strings.NewReader("tx=0x"), io.LimitReader(repeatReader('a'), oversizedBodyBytes)),
+			http.StatusRequestEntityTooLarge, "Request too large",
+		},
+		{
+			"deeply nested body",
+			http.MethodPost, "http://localhost/",
+			strings.NewReader(nested),
+			http.StatusInternalServerError, "Parse error. Invalid JSON",
+		},
+		{
+			"oversized JSON param",
+			http.MethodPost, "http://localhost/",
+			strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"tx","params":{"tx":"` + largeTx + `"}}`),
+			http.StatusOK, "Request too large",
+		},
+		{
+			"oversized URI param",
+			http.MethodGet, "http://localhost/tx?tx=0x" + largeTx,
+			http.NoBody,
+			http.StatusRequestEntityTooLarge, "Request too large",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.url, tt.body)
+			if tt.method == http.MethodPost && tt.url != "http://localhost/" {
+				req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			}
+			rec := httptest.NewRecorder()
+
+			var before, after runtime.MemStats
+			runtime.GC()
+			runtime.ReadMemStats(&before)
+			handler.ServeHTTP(rec, req)
+			runtime.ReadMemStats(&after)
+
+			assert.Less(t, after.TotalAlloc-before.TotalAlloc, uint64(maxAllocBytes))
+
+			res := rec.Result()
+			defer res.Body.Close()
+			assert.Equal(t, tt.wantStatus, res.StatusCode)
+
+			blob, err := io.ReadAll(res.Body)
+			require.NoError(t, err)
+			var resp types.RPCResponse
+			if err := json.Unmarshal(blob, &resp); err != nil {
+				// batch responses are returned as arrays
+				var resps []types.RPCResponse
+				require.NoError(t, json.Unmarshal(blob, &resps))
+				require.Len(t, resps, 1)
+				resp = resps[0]
+			}
+			require.NotNil(t, resp.Error)
+			assert.Equal(t, tt.wantError, resp.Error.Message)
+		})
+	}
+}
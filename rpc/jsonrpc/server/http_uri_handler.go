@@ -2,6 +2,7 @@ package server
 
 import (
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"net/http"
 	"reflect"
@@ -18,7 +19,7 @@ import (
 var reInt = regexp.MustCompile(`^-?[0-9]+$`)
 
 // convert from a function name to the http handler
-func makeHTTPHandler(rpcFunc *RPCFunc, logger log.Logger) func(http.ResponseWriter, *http.Request) {
+func makeHTTPHandler(name string, rpcFunc *RPCFunc, logger log.Logger) func(http.ResponseWriter, *http.Request) {
 	// Always return -1 as there's no ID here.
 	dummyID := types.JSONRPCIntID(-1) // URIClientRequestID
 
@@ -32,18 +33,52 @@ func makeHTTPHandler(rpcFunc *RPCFunc, logger log.Logger) func(http.ResponseWrit
 		}
 	}
 
+	// Exception for the endpoints rejected in read-only mode
+	if rpcFunc.rejected {
+		return func(w http.ResponseWriter, r *http.Request) {
+			res := types.RPCReadOnlyError(dummyID, name)
+			if wErr := WriteRPCResponseHTTPError(w, http.StatusForbidden, res); wErr != nil {
+				logger.Error("failed to write response", "err", wErr)
+			}
+		}
+	}
+
+	// Exception for the endpoints the server does not serve
+	if rpcFunc.unavailable != "" {
+		return func(w http.ResponseWriter, r *http.Request) {
+			res := types.RPCUnavailableError(dummyID, name, rpcFunc.unavailable)
+			if wErr := WriteRPCResponseHTTPError(w, http.StatusNotImplemented, res); wErr != nil {
+				logger.Error("failed to write response", "err", wErr)
+			}
+		}
+	}
+
 	// All other endpoints
 	return func(w http.ResponseWriter, r *http.Request) {
 		logger.Debug("HTTP HANDLER", "req", r)
 
+		if err := callerFromContext(r.Context()).authorize(rpcFunc.Class()); err != nil {
+			res := types.RPCUnauthorizedError(dummyID, name, err)
+			if wErr := WriteRPCResponseHTTPError(w, http.StatusUnauthorized, res); wErr != nil {
+				logger.Error("failed to write response", "err", wErr)
+			}
+			return
+		}
+
 		ctx := &types.Context{HTTPReq: r}
 		args := []reflect.Value{reflect.ValueOf(ctx)}
 
 		fnArgs, err := httpParamsToArgs(rpcFunc, r)
 		if err != nil {
-			res := types.RPCInvalidParamsError(dummyID,
-				fmt.Errorf("error converting http params to arguments: %w", err),
-			)
+			err = fmt.Errorf("error converting http params to arguments: %w", err)
+			if isRequestTooLarge(err) {
+				res := types.RPCRequestTooLargeError(dummyID, err)
+				if wErr := WriteRPCResponseHTTPError(w, http.StatusRequestEntityTooLarge, res); wErr != nil {
+					logger.Error("failed to write response", "err", wErr)
+				}
+				return
+			}
+			res := types.RPCInvalidParamsError(dummyID, err)
 			if wErr := WriteRPCResponseHTTPError(w, http.StatusInternalServerError, res); wErr != nil {
 				logger.Error("failed to write response", "err", wErr)
 			}
@@ -56,8 +91,11 @@ func makeHTTPHandler(rpcFunc *RPCFunc, logger log.Logger) func(http.ResponseWrit
 		logger.Debug("HTTPRestRPC", "method", r.URL.Path, "args", args, "returns", returns)
 		result, err := unreflectResult(returns)
 		if err != nil {
-			if err := WriteRPCResponseHTTPError(w, http.StatusInternalServerError,
-				types.RPCInternalError(dummyID, err)); err != nil {
+			status := http.StatusInternalServerError
+			if errors.Is(err, types.ErrOverloaded) {
+				status = http.StatusServiceUnavailable
+			}
+			if err := WriteRPCResponseHTTPError(w, status, types.RPCFuncError(dummyID, err)); err != nil {
 				logger.Error("failed to write response", "err", err)
 				return
 			}
@@ -83,6 +121,12 @@ func httpParamsToArgs(rpcFunc *RPCFunc, r *http.Request) ([]reflect.Value, error
 	// skip types.Context
 	const argsOffset = 1
 
+	// parse the form explicitly, as r.FormValue swallows errors such as the
+	// body exceeding the maximum size.
+	if err := r.ParseForm(); err != nil && isRequestTooLarge(err) {
+		return nil, err
+	}
+
 	values := make([]reflect.Value, len(rpcFunc.argNames))
 
 	for i, name := range rpcFunc.argNames {
@@ -97,6 +141,21 @@ func httpParamsToArgs(rpcFunc *RPCFunc, r *http.Request) ([]reflect.Value, error
 			continue
 		}
 
+		if err := checkParamSize(name, argType, len(arg), maxParamBytes(r)); err != nil {
+			return nil, err
+		}
+
+		// unquoted byte slices accept 0x-hex, bare hex and base64, quoted ones
+		// are taken as raw strings
+		if isBytesParam(argType) && !strings.HasPrefix(arg, `"`) {
+			v, err := bytesParamToArg(argType, arg, true)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s: %w", name, err)
+			}
+			values[i] = v
+			continue
+		}
+
 		v, ok, err := nonJSONStringToArg(argType, arg)
 		if err != nil {
 			return nil, err
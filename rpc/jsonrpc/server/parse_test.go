@@ -4,13 +4,18 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/tendermint/tendermint/libs/bytes"
+	cmtjson "github.com/tendermint/tendermint/libs/json"
 	types "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+	cmttypes "github.com/tendermint/tendermint/types"
 )
 
 func TestParseJSONMap(t *testing.T) {
@@ -156,7 +161,7 @@ func TestParseJSONRPC(t *testing.T) {
 	for idx, tc := range cases {
 		i := strconv.Itoa(idx)
 		data := []byte(tc.raw)
-		vals, err := jsonParamsToArgs(call, data)
+		vals, err := jsonParamsToArgs(call, data, 0)
 		if tc.fail {
 			assert.NotNil(t, err, i)
 		} else {
@@ -211,3 +216,104 @@ func TestParseURI(t *testing.T) {
 
 	}
 }
+
+func TestParseBytesParams(t *testing.T) {
+	// functions with the byte-carrying parameters of the RPC endpoints
+	funcs := map[string]*RPCFunc{
+		"tx":       NewRPCFunc(func(ctx *types.Context, tx cmttypes.Tx) {}, "tx"),
+		"hash":     NewRPCFunc(func(ctx *types.Context, hash []byte) {}, "hash"),
+		"data":     NewRPCFunc(func(ctx *types.Context, data bytes.HexBytes) {}, "data"),
+		"evidence": NewRPCFunc(func(ctx *types.Context, evidence cmttypes.Evidence) {}, "evidence"),
+	}
+
+	ev := cmttypes.NewMockDuplicateVoteEvidence(1, time.Now(), "test-chain")
+	evJSON, err := cmtjson.Marshal(ev)
+	require.NoError(t, err)
+
+	hello := []byte("hello")
+	// "abcd" is valid in both hex and base64
+	ambiguousHex, ambiguousBase64 := []byte{0xab, 0xcd}, []byte{0x69, 0xb7, 0x1d}
+
+	cases := []struct {
+		name  string
+		param string
+		value string
+		// expected values for URI and JSON-RPC requests, nil if they fail
+		uri  interface{}
+		json interface{}
+	}{
+		{"0x-hex", "tx", "0x68656C6C6F", cmttypes.Tx(hello), cmttypes.Tx(hello)},
+		{"bare hex", "tx", "68656c6c6f", cmttypes.Tx(hello), cmttypes.Tx(hello)},
+		{"base64", "tx", "aGVsbG8=", cmttypes.Tx(hello), cmttypes.Tx(hello)},
+		{"ambiguous", "tx", "abcd", cmttypes.Tx(ambiguousHex), cmttypes.Tx(ambiguousBase64)},
+		{"invalid", "tx", "hello!", nil, nil},
+		{"invalid 0x-hex", "tx", "0xaGVsbG8=", nil, nil},
+
+		{"0x-hex", "hash", "0x68656c6c6f", hello, hello},
+		{"bare hex", "hash", "68656C6C6F", hello, hello},
+		{"base64", "hash", "aGVsbG8=", hello, hello},
+		{"ambiguous", "hash", "abcd", ambiguousHex, ambiguousBase64},
+		{"invalid", "hash", "hello!", nil, nil},
+
+		{"0x-hex", "data", "0x68656c6c6f", bytes.HexBytes(hello), bytes.HexBytes(hello)},
+		{"bare hex", "data", "68656c6c6f", bytes.HexBytes(hello), bytes.HexBytes(hello)},
+		{"base64", "data", "aGVsbG8=", bytes.HexBytes(hello), bytes.HexBytes(hello)},
+		{"ambiguous", "data", "abcd", bytes.HexBytes(ambiguousHex), bytes.HexBytes(ambiguousHex)},
+		{"invalid", "data", "hello!", nil, nil},
+
+		// evidence is only accepted as JSON
+		{"0x-hex", "evidence", "0x68656c6c6f", nil, nil},
+		{"bare hex", "evidence", "68656c6c6f", nil, nil},
+		{"base64", "evidence", "aGVsbG8=", nil, nil},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.param+" "+tc.name, func(t *testing.T) {
+			call := funcs[tc.param]
+
+			req, err := http.NewRequest("GET", "test.com/method?"+url.Values{tc.param: {tc.value}}.Encode(), nil)
+			require.NoError(t, err)
+			vals, err := httpParamsToArgs(call, req)
+			if tc.uri == nil {
+				assert.Error(t, err)
+			} else if assert.NoError(t, err) {
+				assert.Equal(t, tc.uri, vals[0].Interface())
+			}
+
+			raw, err := json.Marshal(map[string]string{tc.param: tc.value})
+			require.NoError(t, err)
+			vals, err = jsonParamsToArgs(call, raw, 0)
+			if tc.json == nil {
+				assert.Error(t, err)
+			} else if assert.NoError(t, err) {
+				assert.Equal(t, tc.json, vals[0].Interface())
+			}
+		})
+	}
+
+	// the error names the parameter and the encodings that were tried
+	_, err = jsonParamsToArgs(funcs["tx"], []byte(`{"tx": "hello!"}`), 0)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid tx: decoding as base64")
+	assert.Contains(t, err.Error(), "decoding as hex")
+	_, err = jsonParamsToArgs(funcs["data"], []byte(`["0xzz"]`), 0)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid data: decoding as 0x-hex")
+
+	// quoted URI strings are raw bytes
+	req, err := http.NewRequest("GET", "test.com/method?tx=%22hello%22", nil)
+	require.NoError(t, err)
+	vals, err := httpParamsToArgs(funcs["tx"], req)
+	require.NoError(t, err)
+	assert.EqualValues(t, hello, vals[0].Bytes())
+
+	// evidence is accepted as JSON in both forms
+	req, err = http.NewRequest("GET", "test.com/method?"+url.Values{"evidence": {string(evJSON)}}.Encode(), nil)
+	require.NoError(t, err)
+	vals, err = httpParamsToArgs(funcs["evidence"], req)
+	require.NoError(t, err)
+	assert.Equal(t, ev.Hash(), vals[0].Interface().(cmttypes.Evidence).Hash())
+	vals, err = jsonParamsToArgs(funcs["evidence"], []byte(`{"evidence": `+string(evJSON)+`}`), 0)
+	require.NoError(t, err)
+	assert.Equal(t, ev.Hash(), vals[0].Interface().(cmttypes.Evidence).Hash())
+}
@@ -16,7 +16,7 @@ import (
 func RegisterRPCFuncs(mux *http.ServeMux, funcMap map[string]*RPCFunc, logger log.Logger) {
 	// HTTP endpoints
 	for funcName, rpcFunc := range funcMap {
-		mux.HandleFunc("/"+funcName, makeHTTPHandler(rpcFunc, logger))
+		mux.HandleFunc("/"+funcName, makeHTTPHandler(funcName, rpcFunc, logger))
 	}
 
 	// JSONRPC endpoints
@@ -48,6 +48,45 @@ func Ws() Option {
 	}
 }
 
+// Access classifies RPC functions by their effect on the node.
+type Access int
+
+const (
+	// AccessUnspecified is the access of the functions which do not declare
+	// one. They are treated as mutating.
+	AccessUnspecified Access = iota
+	// AccessReadOnly functions only read the state of the node.
+	AccessReadOnly
+	// AccessMutating functions change the state of the node or of the network,
+	// e.g. by broadcasting a transaction.
+	AccessMutating
+	// AccessUnsafe functions control the node and are only exposed when the
+	// operator allows unsafe functions.
+	AccessUnsafe
+)
+
+// ReadOnly declares the function as only reading the state of the node.
+func ReadOnly() Option {
+	return func(r *RPCFunc) {
+		r.access = AccessReadOnly
+	}
+}
+
+// Mutating declares the function as changing the state of the node or of the
+// network.
+func Mutating() Option {
+	return func(r *RPCFunc) {
+		r.access = AccessMutating
+	}
+}
+
+// Unsafe declares the function as controlling the node.
+func Unsafe() Option {
+	return func(r *RPCFunc) {
+		r.access = AccessUnsafe
+	}
+}
+
 // RPCFunc contains the introspected type information for a function
 type RPCFunc struct {
 	f              reflect.Value          // underlying rpc function
@@ -57,6 +96,48 @@ type RPCFunc struct {
 	cacheable      bool                   // enable cache control
 	ws             bool                   // enable websocket communication
 	noCacheDefArgs map[string]interface{} // a lookup table of args that, if not supplied or are set to default values, cause us to not cache
+	access         Access                 // effect of the function on the node
+	rejected       bool                   // reject the calls in read-only mode
+	unavailable    string                 // if set, reject the calls for this reason
+}
+
+// Access returns the access declared by the function.
+func (f *RPCFunc) Access() Access {
+	return f.access
+}
+
+// ReadOnlyFuncs returns a copy of funcMap for a read-only server: the calls of
+// the functions which are not declared as read-only are rejected with
+// RPCReadOnlyError, without calling them.
+func ReadOnlyFuncs(funcMap map[string]*RPCFunc) map[string]*RPCFunc {
+	readOnly := make(map[string]*RPCFunc, len(funcMap))
+	for name, f := range funcMap {
+		if f.access != AccessReadOnly {
+			rejected := *f
+			rejected.rejected = true
+			f = &rejected
+		}
+		readOnly[name] = f
+	}
+	return readOnly
+}
+
+// UnavailableFuncs returns a copy of funcMap in which the calls of the named
+// functions are rejected with RPCUnavailableError, for the given reason,
+// without calling them.
+func UnavailableFuncs(funcMap map[string]*RPCFunc, reason string, names ...string) map[string]*RPCFunc {
+	funcs := make(map[string]*RPCFunc, len(funcMap))
+	for name, f := range funcMap {
+		funcs[name] = f
+	}
+	for _, name := range names {
+		if f, ok := funcs[name]; ok {
+			unavailable := *f
+			unavailable.unavailable = reason
+			funcs[name] = &unavailable
+		}
+	}
+	return funcs
 }
 
 // NewRPCFunc wraps a function for introspection.
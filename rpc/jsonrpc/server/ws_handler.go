@@ -86,6 +86,7 @@ func (wm *WebsocketManager) WebsocketHandler(w http.ResponseWriter, r *http.Requ
 
 	// register connection
 	con := newWSConnection(wsConn, wm.funcMap, wm.wsConnOptions...)
+	con.caller = callerFromContext(r.Context())
 	con.SetLogger(wm.logger.With("remote", wsConn.RemoteAddr()))
 	wm.logger.Info("New websocket connection", "remote", con.remoteAddr)
 	err = con.Start() // BLOCKING
@@ -118,13 +119,20 @@ type wsConnection struct {
 
 	funcMap map[string]*RPCFunc
 
+	// caller of the connection, nil if the server has no API keys
+	caller *apiCaller
+	// whether a message was read, as only the first one may authenticate
+	received bool
+
 	// write channel capacity
 	writeChanCapacity int
 
 	// each write times out after this.
 	writeWait time.Duration
 
-	// Connection times out if we haven't received *anything* in this long, not even pings.
+	// Connection times out if we haven't received *anything* in this long, not
+	// even pongs. With pings sent every pingPeriod, this is the number of pings
+	// a client may leave unanswered before being considered dead.
 	readWait time.Duration
 
 	// Send pings to server with this period. Must be less than readWait, but greater than zero.
@@ -133,9 +141,14 @@ type wsConnection struct {
 	// Maximum message size.
 	readLimit int64
 
+	// Maximum encoded size of a byte-carrying parameter.
+	maxParamBytes int64
+
 	// callback which is called upon disconnect
 	onDisconnect func(remoteAddr string)
 
+	// ctx is canceled as soon as reading from the connection fails, or the
+	// connection is stopped, so that the subscriptions tied to it end.
 	ctx    context.Context
 	cancel context.CancelFunc
 }
@@ -161,6 +174,7 @@ func newWSConnection(
 		pingPeriod:        defaultWSPingPeriod,
 		readRoutineQuit:   make(chan struct{}),
 	}
+	wsc.ctx, wsc.cancel = context.WithCancel(context.Background())
 	for _, option := range options {
 		option(wsc)
 	}
@@ -217,6 +231,14 @@ func ReadLimit(readLimit int64) func(*wsConnection) {
 	}
 }
 
+// MaxParamBytes sets the maximum encoded size of a byte-carrying parameter.
+// It should only be used in the constructor - not Goroutine-safe.
+func MaxParamBytes(maxParamBytes int64) func(*wsConnection) {
+	return func(wsc *wsConnection) {
+		wsc.maxParamBytes = maxParamBytes
+	}
+}
+
 // OnStart implements service.Service by starting the read and write routines. It
 // blocks until there's some error.
 func (wsc *wsConnection) OnStart() error {
@@ -237,9 +259,7 @@ func (wsc *wsConnection) OnStop() {
 		wsc.onDisconnect(wsc.remoteAddr)
 	}
 
-	if wsc.ctx != nil {
-		wsc.cancel()
-	}
+	wsc.cancel()
 }
 
 // GetRemoteAddr returns the remote address of the underlying connection.
@@ -277,12 +297,9 @@ func (wsc *wsConnection) TryWriteRPCResponse(resp types.RPCResponse) bool {
 }
 
 // Context returns the connection's context.
-// The context is canceled when the client's connection closes.
+// The context is canceled when the client's connection closes, or as soon as
+// the client is found unresponsive. It is Goroutine-safe.
 func (wsc *wsConnection) Context() context.Context {
-	if wsc.ctx != nil {
-		return wsc.ctx
-	}
-	wsc.ctx, wsc.cancel = context.WithCancel(context.Background())
 	return wsc.ctx
 }
 
@@ -321,6 +338,9 @@ func (wsc *wsConnection) readRoutine() {
 
 			_, r, err := wsc.baseConn.NextReader()
 			if err != nil {
+				// the client is gone, possibly without a close frame: end its
+				// subscriptions right away
+				wsc.cancel()
 				if websocket.IsCloseError(err, websocket.CloseNormalClosure) {
 					wsc.Logger.Info("Client closed the connection")
 				} else {
@@ -337,13 +357,25 @@ func (wsc *wsConnection) readRoutine() {
 			var request types.RPCRequest
 			err = dec.Decode(&request)
 			if err != nil {
-				if err := wsc.WriteRPCResponse(writeCtx,
-					types.RPCParseError(fmt.Errorf("error unmarshaling request: %w", err))); err != nil {
+				res := types.RPCParseError(fmt.Errorf("error unmarshaling request: %w", err))
+				if errors.Is(err, websocket.ErrReadLimit) {
+					res = types.RPCRequestTooLargeError(nil, err)
+				}
+				if err := wsc.WriteRPCResponse(writeCtx, res); err != nil {
 					wsc.Logger.Error("Error writing RPC response", "err", err)
 				}
 				continue
 			}
 
+			// The first message may authenticate the connection. It is handled
+			// before logging the notifications, which would log the key.
+			first := !wsc.received
+			wsc.received = true
+			if first && wsc.caller != nil && request.Method == authMethod {
+				wsc.authenticate(writeCtx, request)
+				continue
+			}
+
 			// A Notification is a Request object without an "id" member.
 			// The Server MUST NOT reply to a Notification, including those that are within a batch request.
 			if request.ID == nil {
@@ -362,15 +394,38 @@ func (wsc *wsConnection) readRoutine() {
 				}
 				continue
 			}
+			if rpcFunc.rejected {
+				if err := wsc.WriteRPCResponse(writeCtx, types.RPCReadOnlyError(request.ID, request.Method)); err != nil {
+					wsc.Logger.Error("Error writing RPC response", "err", err)
+				}
+				continue
+			}
+			if rpcFunc.unavailable != "" {
+				res := types.RPCUnavailableError(request.ID, request.Method, rpcFunc.unavailable)
+				if err := wsc.WriteRPCResponse(writeCtx, res); err != nil {
+					wsc.Logger.Error("Error writing RPC response", "err", err)
+				}
+				continue
+			}
+			if err := wsc.caller.authorize(rpcFunc.Class()); err != nil {
+				res := types.RPCUnauthorizedError(request.ID, request.Method, err)
+				if err := wsc.WriteRPCResponse(writeCtx, res); err != nil {
+					wsc.Logger.Error("Error writing RPC response", "err", err)
+				}
+				continue
+			}
 
 			ctx := &types.Context{JSONReq: &request, WSConn: wsc}
 			args := []reflect.Value{reflect.ValueOf(ctx)}
 			if len(request.Params) > 0 {
-				fnArgs, err := jsonParamsToArgs(rpcFunc, request.Params)
+				fnArgs, err := jsonParamsToArgs(rpcFunc, request.Params, wsc.maxParamBytes)
 				if err != nil {
-					if err := wsc.WriteRPCResponse(writeCtx,
-						types.RPCInternalError(request.ID, fmt.Errorf("error converting json params to arguments: %w", err)),
-					); err != nil {
+					err = fmt.Errorf("error converting json params to arguments: %w", err)
+					res := types.RPCInternalError(request.ID, err)
+					if isRequestTooLarge(err) {
+						res = types.RPCRequestTooLargeError(request.ID, err)
+					}
+					if err := wsc.WriteRPCResponse(writeCtx, res); err != nil {
 						wsc.Logger.Error("Error writing RPC response", "err", err)
 					}
 					continue
@@ -385,7 +440,7 @@ func (wsc *wsConnection) readRoutine() {
 
 			result, err := unreflectResult(returns)
 			if err != nil {
-				if err := wsc.WriteRPCResponse(writeCtx, types.RPCInternalError(request.ID, err)); err != nil {
+				if err := wsc.WriteRPCResponse(writeCtx, types.RPCFuncError(request.ID, err)); err != nil {
 					wsc.Logger.Error("Error writing RPC response", "err", err)
 				}
 				continue
@@ -398,6 +453,28 @@ func (wsc *wsConnection) readRoutine() {
 	}
 }
 
+// authenticate authenticates the connection with the key of the request, the
+// first message of the connection, and replies unless it is a notification.
+// The connection is left unauthenticated if the key is unknown.
+func (wsc *wsConnection) authenticate(ctx context.Context, request types.RPCRequest) {
+	keyID, err := wsc.caller.authenticate(request.Params)
+	if err != nil {
+		wsc.Logger.Info("Failed to authenticate the connection", "err", err)
+	} else {
+		wsc.Logger.Info("Authenticated the connection", "key_id", keyID)
+	}
+	if request.ID == nil {
+		return
+	}
+	res := types.NewRPCSuccessResponse(request.ID, authResult{KeyID: keyID})
+	if err != nil {
+		res = types.RPCUnauthorizedError(request.ID, request.Method, err)
+	}
+	if err := wsc.WriteRPCResponse(ctx, res); err != nil {
+		wsc.Logger.Error("Error writing RPC response", "err", err)
+	}
+}
+
 // receives on a write channel and writes out on the socket
 func (wsc *wsConnection) writeRoutine() {
 	pingTicker := time.NewTicker(wsc.pingPeriod)
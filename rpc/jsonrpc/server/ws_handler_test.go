@@ -54,3 +54,37 @@ func newWSServer() *httptest.Server {
 
 	return httptest.NewServer(mux)
 }
+
+func TestWebsocketManagerReadOnly(t *testing.T) {
+	funcMap := map[string]*RPCFunc{
+		"read":  NewWSRPCFunc(func(ctx *types.Context) (string, error) { return "foo", nil }, "", ReadOnly()),
+		"write": NewWSRPCFunc(func(ctx *types.Context) (string, error) { return "foo", nil }, "", Mutating()),
+		"other": NewWSRPCFunc(func(ctx *types.Context) (string, error) { return "foo", nil }, ""),
+	}
+	wm := NewWebsocketManager(ReadOnlyFuncs(funcMap))
+	wm.SetLogger(log.TestingLogger())
+	mux := http.NewServeMux()
+	mux.HandleFunc("/websocket", wm.WebsocketHandler)
+	s := httptest.NewServer(mux)
+	defer s.Close()
+
+	c, dialResp, err := websocket.DefaultDialer.Dial("ws://"+s.Listener.Addr().String()+"/websocket", nil)
+	require.NoError(t, err)
+	defer dialResp.Body.Close()
+	defer c.Close()
+
+	for method, rejected := range map[string]bool{"read": false, "write": true, "other": true} {
+		req, err := types.MapToRequest(types.JSONRPCStringID(method), method, nil)
+		require.NoError(t, err)
+		require.NoError(t, c.WriteJSON(req))
+
+		var resp types.RPCResponse
+		require.NoError(t, c.ReadJSON(&resp))
+		if rejected {
+			require.NotNil(t, resp.Error, method)
+			require.Equal(t, types.CodeReadOnly, resp.Error.Code)
+		} else {
+			require.Nil(t, resp.Error, method)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package types
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"reflect"
@@ -230,6 +231,12 @@ func RPCInvalidRequestError(id jsonrpcid, err error) RPCResponse {
 	return NewRPCErrorResponse(id, -32600, "Invalid Request", err.Error())
 }
 
+// RPCRequestTooLargeError is returned if the request body, a websocket message
+// or one of the request parameters exceeds the size limits of the server.
+func RPCRequestTooLargeError(id jsonrpcid, err error) RPCResponse {
+	return NewRPCErrorResponse(id, -32600, "Request too large", err.Error())
+}
+
 func RPCMethodNotFoundError(id jsonrpcid) RPCResponse {
 	return NewRPCErrorResponse(id, -32601, "Method not found", "")
 }
@@ -242,6 +249,59 @@ func RPCInternalError(id jsonrpcid, err error) RPCResponse {
 	return NewRPCErrorResponse(id, -32603, "Internal error", err.Error())
 }
 
+// CodeReadOnly is the code of the error returned by a read-only server for the
+// functions changing the state of the node.
+const CodeReadOnly = -32001
+
+// RPCReadOnlyError is returned by a read-only server for the calls of the
+// functions which change the state of the node, or are unsafe.
+func RPCReadOnlyError(id jsonrpcid, method string) RPCResponse {
+	return NewRPCErrorResponse(id, CodeReadOnly, "Read-only mode",
+		fmt.Sprintf("%s is not allowed on a read-only node", method))
+}
+
+// CodeUnavailable is the code of the error returned by a server for the
+// functions it does not serve, e.g. those needing consensus on an archive
+// server.
+const CodeUnavailable = -32002
+
+// RPCUnavailableError is returned by a server for the calls of the functions
+// it does not serve, for the given reason.
+func RPCUnavailableError(id jsonrpcid, method, reason string) RPCResponse {
+	return NewRPCErrorResponse(id, CodeUnavailable, "Unavailable",
+		fmt.Sprintf("%s is %s", method, reason))
+}
+
+// CodeOverloaded is the code of the error returned by a server too busy to
+// serve a call, which should be retried later.
+const CodeOverloaded = -32003
+
+// ErrOverloaded is returned by the functions of a server too busy to serve the
+// call, e.g. when too many transactions are pending. It is turned into an error
+// of code CodeOverloaded.
+var ErrOverloaded = errors.New("server overloaded, retry later")
+
+// CodeUnauthorized is the code of the error returned by a server with API keys
+// for the calls the caller did not present a key granting.
+const CodeUnauthorized = -32004
+
+// RPCUnauthorizedError is returned by a server with API keys for the calls of
+// a function the caller is not authorized to call, for the reason err.
+func RPCUnauthorizedError(id jsonrpcid, method string, err error) RPCResponse {
+	return NewRPCErrorResponse(id, CodeUnauthorized, "Unauthorized",
+		fmt.Sprintf("%s: %v", method, err))
+}
+
+// RPCFuncError returns the error of the call of a function failing with err:
+// an overloaded error if err wraps ErrOverloaded, an internal error
+// otherwise.
+func RPCFuncError(id jsonrpcid, err error) RPCResponse {
+	if errors.Is(err, ErrOverloaded) {
+		return NewRPCErrorResponse(id, CodeOverloaded, "Server overloaded", err.Error())
+	}
+	return RPCInternalError(id, err)
+}
+
 func RPCServerError(id jsonrpcid, err error) RPCResponse {
 	return NewRPCErrorResponse(id, -32000, "Server error", err.Error())
 }
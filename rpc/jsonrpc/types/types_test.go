@@ -81,3 +81,14 @@ func TestRPCError(t *testing.T) {
 			Message: "Badness",
 		}))
 }
+
+func TestRPCFuncError(t *testing.T) {
+	id := JSONRPCIntID(1)
+
+	resp := RPCFuncError(id, fmt.Errorf("mempool: %w", ErrOverloaded))
+	assert.Equal(t, CodeOverloaded, resp.Error.Code)
+	assert.Equal(t, "Server overloaded", resp.Error.Message)
+
+	resp = RPCFuncError(id, errors.New("boom"))
+	assert.Equal(t, -32603, resp.Error.Code)
+}
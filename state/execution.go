@@ -43,6 +43,13 @@ type BlockExecutor struct {
 	logger log.Logger
 
 	metrics *Metrics
+
+	// reapDecisions is optional and records why txs were left out of the
+	// proposed blocks
+	reapDecisions *ReapDecisions
+
+	// appRequirements is optional and refreshed after each block
+	appRequirements *mempl.AppRequirements
 }
 
 type BlockExecutorOption func(executor *BlockExecutor)
@@ -60,6 +67,23 @@ func WithBlockStore(blockStore BlockStore) BlockExecutorOption {
 	}
 }
 
+// BlockExecutorWithReapDecisions records in reapDecisions why the txs of the
+// mempool were left out of the blocks proposed by the node.
+func BlockExecutorWithReapDecisions(reapDecisions *ReapDecisions) BlockExecutorOption {
+	return func(blockExec *BlockExecutor) {
+		blockExec.reapDecisions = reapDecisions
+	}
+}
+
+// BlockExecutorWithAppRequirements refreshes appRequirements after each block,
+// before updating the mempool, whose PreCheck then enforces them if
+// appRequirements does.
+func BlockExecutorWithAppRequirements(appRequirements *mempl.AppRequirements) BlockExecutorOption {
+	return func(blockExec *BlockExecutor) {
+		blockExec.appRequirements = appRequirements
+	}
+}
+
 // NewBlockExecutor returns a new BlockExecutor with a NopEventBus.
 // Call SetEventBus to provide one.
 func NewBlockExecutor(
@@ -112,11 +136,27 @@ func (blockExec *BlockExecutor) CreateProposalBlock(
 	maxBytes := state.ConsensusParams.Block.MaxBytes
 	maxGas := state.ConsensusParams.Block.MaxGas
 
-	evidence, evSize := blockExec.evpool.PendingEvidence(state.ConsensusParams.Evidence.MaxBytes)
+	// The space of the pending evidence is reserved first, up to the space left
+	// by the header and the last commit, and the txs are given the rest.
+	// Evidence that does not fit is left pending rather than preventing the
+	// block from being proposed.
+	_, evSize := blockExec.evpool.PendingEvidence(maxEvidenceBytes(state, 0))
 
 	maxDataBytes := types.MaxDataBytes(maxBytes, evSize, state.Validators.Size())
 
-	txs := blockExec.mempool.ReapMaxBytesMaxGas(maxDataBytes, maxGas)
+	var (
+		txs        types.Txs
+		exclusions mempl.Exclusions
+	)
+	mempoolSize := blockExec.mempool.Size()
+	if reaper, ok := blockExec.mempool.(mempl.ExclusionReaper); ok && blockExec.reapDecisions != nil {
+		txs, exclusions = reaper.ReapMaxBytesMaxGasWithExclusions(maxDataBytes, maxGas)
+	} else {
+		txs = blockExec.mempool.ReapMaxBytesMaxGas(maxDataBytes, maxGas)
+	}
+	// The reaped txs share the bytes of the mempool, up to the block: check
+	// nothing mutates them, in the builds with the txdebug tag.
+	guards := types.GuardTxs(txs)
 
 	var timestamp time.Time
 	if height == state.InitialHeight {
@@ -147,6 +187,10 @@ func (blockExec *BlockExecutor) CreateProposalBlock(
 	}
 	rawNewData := preparedProposal.GetBlockData()
 
+	if exclusions != nil {
+		blockExec.reapDecisions.Record(newReapDecision(height, mempoolSize, txs, rawNewData.Txs, exclusions))
+	}
+
 	rejectedTxs := len(rawNewData.Txs) - len(txs)
 	if rejectedTxs > 0 {
 		blockExec.metrics.RejectedTransactions.Add(float64(rejectedTxs))
@@ -167,13 +211,20 @@ func (blockExec *BlockExecutor) CreateProposalBlock(
 		panic(err)
 	}
 
-	return state.MakeBlock(
+	// The evidence is selected in the space the prepared txs leave, bounded as
+	// in ValidateBlock. It is at least the reserved space, more if the app
+	// prepared fewer txs than it could.
+	evidence, _ := blockExec.evpool.PendingEvidence(maxEvidenceBytes(state, newData.Size()))
+
+	block, partSet := state.MakeBlock(
 		height,
 		newData,
 		commit,
 		evidence,
 		proposerAddr,
 	)
+	types.CheckTxGuards(txs, guards)
+	return block, partSet
 }
 
 func (blockExec *BlockExecutor) ProcessProposal(
@@ -270,6 +321,14 @@ func (blockExec *BlockExecutor) ApplyBlock(
 		blockExec.logger.Info("updates to validators", "updates", types.ValidatorListString(validatorUpdates))
 	}
 
+	// validate the evidence params updates against the evidence constraints of the app
+	paramUpdates := abciResponses.EndBlock.ConsensusParamUpdates
+	if paramUpdates != nil && paramUpdates.Evidence != nil {
+		if err := types.ValidateEvidenceConstraints(*paramUpdates.Evidence, state.EvidenceConstraints); err != nil {
+			return state, 0, fmt.Errorf("error updating consensus params: %v", err)
+		}
+	}
+
 	// Update the state with the block and responses.
 	state, err = updateState(state, blockID, &block.Header, abciResponses, validatorUpdates)
 	if err != nil {
@@ -287,9 +346,16 @@ func (blockExec *BlockExecutor) ApplyBlock(
 
 	fail.Fail() // XXX
 
-	// Update the app hash and save the state.
+	// Update the app hash and save the state, along with the height of the
+	// Finalized event if it is to be published.
 	state.AppHash = appHash
-	if err := blockExec.store.Save(state); err != nil {
+	finalize := blockExec.finalizing(block.Height)
+	if finalize {
+		err = blockExec.store.SaveFinalized(state)
+	} else {
+		err = blockExec.store.Save(state)
+	}
+	if err != nil {
 		return state, 0, err
 	}
 
@@ -299,9 +365,47 @@ func (blockExec *BlockExecutor) ApplyBlock(
 	// NOTE: if we crash between Commit and Save, events wont be fired during replay
 	fireEvents(blockExec.logger, blockExec.eventBus, block, abciResponses, validatorUpdates, state.LastValidators, commit)
 
+	// the block, its results and the state are durably persisted
+	if finalize {
+		blockExec.publishFinalized(block, state.AppHash)
+	}
+
 	return state, retainHeight, nil
 }
 
+// finalizing returns whether the Finalized event of the block at height is to
+// be published, i.e. it was not before, e.g. before a restart which processes
+// the height again. The height is saved along with the state, before the event
+// is published, so that the event is published at most once per height: a
+// crash in between loses it. Without event bus, nothing is published.
+func (blockExec *BlockExecutor) finalizing(height int64) bool {
+	if _, ok := blockExec.eventBus.(types.NopEventBus); ok {
+		return false
+	}
+	finalized, err := blockExec.store.LoadFinalizedHeight()
+	if err != nil {
+		blockExec.logger.Error("failed to load the finalized height", "err", err)
+		return false
+	}
+	if height <= finalized {
+		blockExec.logger.Info("not publishing the finalized event again", "height", height)
+		return false
+	}
+	return true
+}
+
+// publishFinalized publishes the Finalized event of the block.
+func (blockExec *BlockExecutor) publishFinalized(block *types.Block, appHash []byte) {
+	if err := blockExec.eventBus.PublishEventFinalized(types.EventDataFinalized{
+		Height:    block.Height,
+		BlockHash: block.Hash(),
+		DataHash:  block.DataHash,
+		AppHash:   appHash,
+	}); err != nil {
+		blockExec.logger.Error("failed publishing finalized event", "height", block.Height, "err", err)
+	}
+}
+
 // Commit locks the mempool, runs the ABCI Commit message, and updates the
 // mempool.
 // It returns the result of calling abci.Commit (the AppHash) and the height to retain (if any).
@@ -339,12 +443,22 @@ func (blockExec *BlockExecutor) Commit(
 		"app_hash", fmt.Sprintf("%X", res.Data),
 	)
 
+	// The app may have changed its requirements to admit txs in the block,
+	// they apply to the txs rechecked below.
+	preCheck := TxPreCheck(state)
+	if blockExec.appRequirements != nil {
+		if err := blockExec.appRequirements.Refresh(); err != nil {
+			blockExec.logger.Error("failed to refresh the mempool requirements of the app", "err", err)
+		}
+		preCheck = blockExec.appRequirements.PreCheck(preCheck)
+	}
+
 	// Update mempool.
 	err = blockExec.mempool.Update(
 		block.Height,
 		block.Txs,
 		deliverTxResponses,
-		TxPreCheck(state),
+		preCheck,
 		TxPostCheck(state),
 	)
 
@@ -567,6 +681,7 @@ func updateState(
 		LastHeightConsensusParamsChanged: lastHeightParamsChanged,
 		LastResultsHash:                  ABCIResponsesResultsHash(abciResponses),
 		AppHash:                          nil,
+		EvidenceConstraints:              state.EvidenceConstraints,
 	}, nil
 }
 
@@ -582,11 +697,12 @@ func fireEvents(
 	currentValidatorSet *types.ValidatorSet,
 	seenCommit *types.Commit,
 ) {
-	if err := eventBus.PublishEventNewBlock(types.EventDataNewBlock{
+	newBlock := types.EventDataNewBlock{
 		Block:            block,
 		ResultBeginBlock: *abciResponses.BeginBlock,
 		ResultEndBlock:   *abciResponses.EndBlock,
-	}); err != nil {
+	}
+	if err := eventBus.PublishEventNewBlock(newBlock); err != nil {
 		logger.Error("failed publishing new block", "err", err)
 	}
 
@@ -602,12 +718,7 @@ func fireEvents(
 		}
 	}
 
-	if err := eventBus.PublishEventNewBlockHeader(types.EventDataNewBlockHeader{
-		Header:           block.Header,
-		NumTxs:           int64(len(block.Txs)),
-		ResultBeginBlock: *abciResponses.BeginBlock,
-		ResultEndBlock:   *abciResponses.EndBlock,
-	}); err != nil {
+	if err := eventBus.PublishEventNewBlockHeader(newBlock.ToHeader()); err != nil {
 		logger.Error("failed publishing new block header", "err", err)
 	}
 
@@ -19,12 +19,17 @@ import (
 
 	db "github.com/cometbft/cometbft-db"
 	abci "github.com/tendermint/tendermint/abci/types"
+	cfg "github.com/tendermint/tendermint/config"
 	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/crypto/ed25519"
 	cryptoenc "github.com/tendermint/tendermint/crypto/encoding"
 	"github.com/tendermint/tendermint/crypto/tmhash"
 	"github.com/tendermint/tendermint/libs/log"
+	cmtquery "github.com/tendermint/tendermint/libs/pubsub/query"
+	cmtrand "github.com/tendermint/tendermint/libs/rand"
+	mempl "github.com/tendermint/tendermint/mempool"
 	mmock "github.com/tendermint/tendermint/mempool/mock"
+	mempoolv1 "github.com/tendermint/tendermint/mempool/v1"
 	cmtproto "github.com/tendermint/tendermint/proto/tendermint/types"
 	cmtversion "github.com/tendermint/tendermint/proto/tendermint/version"
 	"github.com/tendermint/tendermint/proxy"
@@ -625,6 +630,91 @@ func TestEndBlockValidatorUpdatesResultingInEmptySet(t *testing.T) {
 	assert.NotEmpty(t, state.NextValidators.Validators)
 }
 
+func TestEndBlockEvidenceParamUpdatesViolatingEvidenceConstraints(t *testing.T) {
+	app := &testApp{}
+	cc := proxy.NewLocalClientCreator(app)
+	proxyApp := proxy.NewAppConns(cc)
+	err := proxyApp.Start()
+	require.Nil(t, err)
+	defer proxyApp.Stop() //nolint:errcheck // ignore for tests
+
+	state, stateDB, _ := makeState(1, 1)
+	stateStore := sm.NewStore(stateDB, sm.StoreOptions{
+		DiscardABCIResponses: false,
+	})
+	state.EvidenceConstraints = &abci.EvidenceConstraints{
+		MinMaxAgeNumBlocks: 1000,
+		MinMaxAgeDuration:  time.Hour,
+	}
+	blockExec := sm.NewBlockExecutor(
+		stateStore,
+		log.TestingLogger(),
+		proxyApp.Consensus(),
+		mmock.Mempool{},
+		sm.EmptyEvidencePool{},
+	)
+
+	block := makeBlock(state, 1)
+	blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: block.MakePartSet(testPartSize).Header()}
+
+	// evidence would expire before the minimum age required by the app
+	app.EvidenceParams = &cmtproto.EvidenceParams{
+		MaxAgeNumBlocks: 999,
+		MaxAgeDuration:  time.Hour,
+		MaxBytes:        1024,
+	}
+	_, _, err = blockExec.ApplyBlock(state, blockID, block, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "evidence.MaxAgeNumBlocks must be at least 1000")
+
+	app.EvidenceParams.MaxAgeNumBlocks = 1000
+	state, _, err = blockExec.ApplyBlock(state, blockID, block, nil)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1000, state.ConsensusParams.Evidence.MaxAgeNumBlocks)
+	// the next heights are checked against the same constraints
+	assert.Equal(t, int64(1000), state.EvidenceConstraints.MinMaxAgeNumBlocks)
+}
+
+// A node joining by state sync does not run InitChain: the evidence params
+// updates are checked against the constraints the app reported in Info, saved
+// with the state it bootstraps from.
+func TestEndBlockEvidenceParamUpdatesViolatingEvidenceConstraintsAfterStateSync(t *testing.T) {
+	app := &testApp{}
+	cc := proxy.NewLocalClientCreator(app)
+	proxyApp := proxy.NewAppConns(cc)
+	err := proxyApp.Start()
+	require.Nil(t, err)
+	defer proxyApp.Stop() //nolint:errcheck // ignore for tests
+
+	syncedState, _, _ := makeState(1, 1)
+	syncedState.EvidenceConstraints = &abci.EvidenceConstraints{MinMaxAgeNumBlocks: 1000}
+	stateStore := sm.NewStore(db.NewMemDB(), sm.StoreOptions{
+		DiscardABCIResponses: false,
+	})
+	require.NoError(t, stateStore.Bootstrap(syncedState))
+	state, err := stateStore.Load()
+	require.NoError(t, err)
+	blockExec := sm.NewBlockExecutor(
+		stateStore,
+		log.TestingLogger(),
+		proxyApp.Consensus(),
+		mmock.Mempool{},
+		sm.EmptyEvidencePool{},
+	)
+
+	block := makeBlock(state, 1)
+	blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: block.MakePartSet(testPartSize).Header()}
+
+	app.EvidenceParams = &cmtproto.EvidenceParams{
+		MaxAgeNumBlocks: 999,
+		MaxAgeDuration:  time.Hour,
+		MaxBytes:        1024,
+	}
+	_, _, err = blockExec.ApplyBlock(state, blockID, block, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "evidence.MaxAgeNumBlocks must be at least 1000")
+}
+
 func TestFireEventSignedBlockEvent(t *testing.T) {
 	app := &testApp{}
 	cc := proxy.NewLocalClientCreator(app)
@@ -692,6 +782,110 @@ func TestFireEventSignedBlockEvent(t *testing.T) {
 	}
 }
 
+func TestApplyBlockPublishesFinalizedOnce(t *testing.T) {
+	proxyApp := proxy.NewAppConns(proxy.NewLocalClientCreator(&testApp{}))
+	require.NoError(t, proxyApp.Start())
+	defer proxyApp.Stop() //nolint:errcheck // ignore for tests
+
+	state, stateDB, privVals := makeState(1, 1)
+	stateStore := sm.NewStore(stateDB, sm.StoreOptions{})
+	eventBus := types.NewEventBus()
+	require.NoError(t, eventBus.Start())
+	defer eventBus.Stop() //nolint:errcheck
+	sub, err := eventBus.Subscribe(context.Background(), "test-client", cmtquery.MustParse("tm.event EXISTS"), 100)
+	require.NoError(t, err)
+
+	// apply applies the next block with the block executor of a node
+	// (re)started with the stores, publishing the events if publish is set
+	commits := map[int64]*types.Commit{}
+	apply := func(state sm.State, publish bool) (sm.State, *types.Block) {
+		blockExec := sm.NewBlockExecutor(stateStore, log.TestingLogger(), proxyApp.Consensus(),
+			mmock.Mempool{}, sm.EmptyEvidencePool{})
+		if publish {
+			blockExec.SetEventBus(eventBus)
+		}
+		height := state.LastBlockHeight + 1
+		lastCommit := commits[height-1]
+		if lastCommit == nil {
+			lastCommit = new(types.Commit)
+		}
+		block, _ := state.MakeBlock(height, factory.MakeData(makeTxs(height)), lastCommit, nil,
+			state.Validators.GetProposer().Address)
+		blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: block.MakePartSet(testPartSize).Header()}
+		state, _, err := blockExec.ApplyBlock(state, blockID, block, nil)
+		require.NoError(t, err)
+		commits[height], err = makeValidCommit(height, blockID, state.LastValidators, privVals)
+		require.NoError(t, err)
+		return state, block
+	}
+	// events returns the types of the events published since the last call,
+	// and the data of the Finalized event if any. The events are delivered
+	// asynchronously, hence collected until none is for a while.
+	events := func() ([]string, *types.EventDataFinalized) {
+		var (
+			events    []string
+			finalized *types.EventDataFinalized
+		)
+		for {
+			select {
+			case msg := <-sub.Out():
+				events = append(events, msg.Events()[types.EventTypeKey][0])
+				if data, ok := msg.Data().(types.EventDataFinalized); ok {
+					finalized = &data
+				}
+			case <-time.After(100 * time.Millisecond):
+				return events, finalized
+			}
+		}
+	}
+
+	state1, block1 := apply(state, true)
+	// NewBlock is published first, Finalized last, once the block results,
+	// served by /block_results, are saved
+	published, finalized := events()
+	require.Equal(t, types.EventNewBlock, published[0])
+	require.Equal(t, types.EventFinalized, published[len(published)-1])
+	assert.NotContains(t, published[:len(published)-1], types.EventFinalized)
+	assert.Equal(t, &types.EventDataFinalized{
+		Height:    1,
+		BlockHash: block1.Hash(),
+		DataHash:  block1.DataHash,
+		AppHash:   state1.AppHash,
+	}, finalized)
+	_, err = stateStore.LoadABCIResponses(1)
+	require.NoError(t, err)
+
+	// after a restart, the height is processed again: NewBlock is published
+	// again, but Finalized is not
+	apply(state, true)
+	published, finalized = events()
+	assert.Contains(t, published, types.EventNewBlock)
+	assert.Nil(t, finalized)
+
+	// a crash after the state was saved along with the finalized height, but
+	// before the event was published, loses the event rather than publishing
+	// it twice
+	state2, _ := apply(state1, false)
+	require.NoError(t, stateStore.SaveFinalized(state2))
+	apply(state1, true)
+	_, finalized = events()
+	assert.Nil(t, finalized)
+
+	// a crash before the finalized height was saved, here without event bus,
+	// publishes it once the height is processed again
+	apply(state2, false)
+	published, _ = events()
+	assert.Empty(t, published)
+	_, block3 := apply(state2, true)
+	_, finalized = events()
+	require.NotNil(t, finalized)
+	assert.EqualValues(t, 3, finalized.Height)
+	assert.EqualValues(t, block3.Hash(), finalized.BlockHash)
+	height, err := stateStore.LoadFinalizedHeight()
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, height)
+}
+
 func makeBlockID(hash []byte, partSetSize uint32, partSetHash []byte) types.BlockID {
 	var (
 		h   = make([]byte, tmhash.Size)
@@ -720,3 +914,324 @@ func makeRejectedBlock(state sm.State, height int) (block *types.Block) {
 	block.Txs[0] = types.Tx{}
 	return block
 }
+
+func TestCreateProposalBlockRecordsReapDecisions(t *testing.T) {
+	app := &dropFirstTxApp{}
+	cc := proxy.NewLocalClientCreator(app)
+	proxyApp := proxy.NewAppConns(cc)
+	require.NoError(t, proxyApp.Start())
+	defer proxyApp.Stop() //nolint:errcheck // ignore for tests
+
+	state, stateDB, _ := makeState(1, 1)
+	stateStore := sm.NewStore(stateDB, sm.StoreOptions{
+		DiscardABCIResponses: false,
+	})
+	logger := log.TestingLogger()
+	mempool := mempoolv1.NewTxMempool(logger, cfg.TestMempoolConfig(), proxyApp.Mempool(), state.LastBlockHeight)
+
+	// fill the mempool with more txs than can fit in a block
+	const numTxs = 30
+	for i := 0; i < numTxs; i++ {
+		require.NoError(t, mempool.CheckTx(cmtrand.Bytes(100), nil, mempl.TxInfo{}))
+	}
+	require.Equal(t, numTxs, mempool.Size())
+
+	reapDecisions := sm.NewReapDecisions(sm.DefaultReapDecisionsSize)
+	blockExec := sm.NewBlockExecutor(stateStore, logger, proxyApp.Consensus(),
+		mempool, sm.EmptyEvidencePool{}, sm.BlockExecutorWithReapDecisions(reapDecisions))
+	proposerAddr, _ := state.Validators.GetByIndex(0)
+	commit := types.NewCommit(0, 0, types.BlockID{}, nil)
+
+	// over the max gas, and the first reaped tx removed by the app
+	state.ConsensusParams.Block.MaxGas = 20
+	block, _ := blockExec.CreateProposalBlock(1, state, commit, proposerAddr)
+	require.Len(t, block.Txs, 19)
+	decision, ok := reapDecisions.Get(1)
+	require.True(t, ok)
+	assert.Equal(t, numTxs, decision.MempoolSize)
+	assert.Equal(t, 20, decision.Reaped)
+	assert.Equal(t, 19, decision.Proposed)
+	assert.Equal(t, numTxs-20, decision.Excluded.Count(mempl.ExclusionOverGas))
+	assert.Zero(t, decision.Excluded.Count(mempl.ExclusionOverBytes))
+	require.Equal(t, 1, decision.Excluded.Count(mempl.ExclusionPrepareProposal))
+	assert.Equal(t, app.dropped.Hash(), []byte(decision.Excluded[mempl.ExclusionPrepareProposal].SampleHashes[0]))
+
+	// over the max bytes
+	state.ConsensusParams.Block.MaxGas = -1
+	state.ConsensusParams.Block.MaxBytes = 2000
+	_, _ = blockExec.CreateProposalBlock(2, state, commit, proposerAddr)
+	decision, ok = reapDecisions.Get(2)
+	require.True(t, ok)
+	assert.Less(t, decision.Reaped, numTxs)
+	assert.Equal(t, numTxs-decision.Reaped, decision.Excluded.Count(mempl.ExclusionOverBytes))
+	assert.Zero(t, decision.Excluded.Count(mempl.ExclusionOverGas))
+
+	_, ok = reapDecisions.Get(3)
+	assert.False(t, ok)
+}
+
+func TestReapDecisions(t *testing.T) {
+	reapDecisions := sm.NewReapDecisions(3)
+	for height := int64(1); height <= 5; height++ {
+		reapDecisions.Record(&sm.ReapDecision{Height: height})
+	}
+	// only the last heights are kept
+	for height := int64(1); height <= 5; height++ {
+		_, ok := reapDecisions.Get(height)
+		assert.Equal(t, height > 2, ok, height)
+	}
+
+	// proposing again at a height replaces its decision
+	reapDecisions.Record(&sm.ReapDecision{Height: 4, Reaped: 1})
+	decision, ok := reapDecisions.Get(4)
+	require.True(t, ok)
+	assert.Equal(t, 1, decision.Reaped)
+	_, ok = reapDecisions.Get(3)
+	assert.True(t, ok)
+}
+
+// dropFirstTxApp requests 1 gas unit per tx and removes the first tx of the
+// proposals.
+type dropFirstTxApp struct {
+	testApp
+
+	dropped types.Tx
+}
+
+func (app *dropFirstTxApp) CheckTx(req abci.RequestCheckTx) abci.ResponseCheckTx {
+	return abci.ResponseCheckTx{GasWanted: 1}
+}
+
+func (app *dropFirstTxApp) PrepareProposal(req abci.RequestPrepareProposal) abci.ResponsePrepareProposal {
+	txs := req.BlockData.Txs
+	if len(txs) > 0 {
+		app.dropped = txs[0]
+		txs = txs[1:]
+	}
+	return abci.ResponsePrepareProposal{BlockData: &cmtproto.Data{Txs: txs}}
+}
+
+func TestCreateProposalBlockEvidenceBytes(t *testing.T) {
+	proxyApp := newTestApp()
+	require.NoError(t, proxyApp.Start())
+	defer proxyApp.Stop() //nolint:errcheck // ignore for tests
+
+	state, stateDB, _ := makeState(1, 1)
+	stateStore := sm.NewStore(stateDB, sm.StoreOptions{
+		DiscardABCIResponses: false,
+	})
+	proposerAddr, _ := state.Validators.GetByIndex(0)
+	commit := types.NewCommit(0, 0, types.BlockID{}, nil)
+	reserved := types.MaxOverheadForBlock + types.MaxHeaderBytes + types.MaxCommitBytes(state.Validators.Size())
+
+	testCases := []struct {
+		name             string
+		maxBytes         int64
+		evidenceMaxBytes int64
+		budget           int64
+	}{
+		{"evidence limit", reserved + 1000, 100, 100},
+		{"exactly the evidence limit", reserved + 100, 100, 100},
+		{"block space under the evidence limit", reserved + 99, 100, 99},
+		{"no block space left", reserved, 100, 0},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// the evidence pool is asked for the evidence fitting the budget
+			evpool := &mocks.EvidencePool{}
+			evpool.On("PendingEvidence", tc.budget).Return([]types.Evidence{}, int64(0))
+			blockExec := sm.NewBlockExecutor(stateStore, log.TestingLogger(), proxyApp.Consensus(),
+				mmock.Mempool{}, evpool)
+
+			state.ConsensusParams.Block.MaxBytes = tc.maxBytes
+			state.ConsensusParams.Evidence.MaxBytes = tc.evidenceMaxBytes
+			block, _ := blockExec.CreateProposalBlock(1, state, commit, proposerAddr)
+			require.NotNil(t, block)
+			evpool.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCreateProposalBlockEvidenceBlockSpace(t *testing.T) {
+	proxyApp := newTestApp()
+	require.NoError(t, proxyApp.Start())
+	defer proxyApp.Stop() //nolint:errcheck // ignore for tests
+
+	state, stateDB, privVals := makeState(1, 1)
+	stateStore := sm.NewStore(stateDB, sm.StoreOptions{
+		DiscardABCIResponses: false,
+	})
+	proposerAddr := state.Validators.GetProposer().Address
+	commit := types.NewCommit(0, 0, types.BlockID{}, nil)
+	reserved := types.MaxOverheadForBlock + types.MaxHeaderBytes + types.MaxCommitBytes(state.Validators.Size())
+
+	ev := types.NewMockDuplicateVoteEvidenceWithValidator(1, time.Now(), privVals[proposerAddr.String()], chainID)
+	evSize := (&types.EvidenceData{Evidence: types.EvidenceList{ev}}).ByteSize()
+	// the evidence pool holds the evidence, proposed if it fits
+	evpool := &mocks.EvidencePool{}
+	evpool.On("PendingEvidence", mock.Anything).Return(
+		func(maxBytes int64) []types.Evidence {
+			if evSize > maxBytes {
+				return nil
+			}
+			return []types.Evidence{ev}
+		},
+		func(maxBytes int64) int64 {
+			if evSize > maxBytes {
+				return 0
+			}
+			return evSize
+		})
+	tx := types.Tx(cmtrand.Bytes(100))
+	blockExec := sm.NewBlockExecutor(stateStore, log.TestingLogger(), proxyApp.Consensus(),
+		&oneTxMempool{tx: tx}, evpool)
+
+	testCases := []struct {
+		name             string
+		maxBytes         int64
+		evidenceMaxBytes int64
+		evidence         bool
+		txs              bool
+	}{
+		{"exactly the block space", reserved + evSize + 100, evSize, true, true},
+		// the evidence is given the space first
+		{"a byte under the block space", reserved + evSize + 99, evSize, true, false},
+		// the evidence never prevents the block from being proposed
+		{"a byte over the evidence limit", reserved + evSize + 100, evSize - 1, false, true},
+		{"a byte over the block space", reserved + evSize - 1, evSize, false, true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			state.ConsensusParams.Block.MaxBytes = tc.maxBytes
+			state.ConsensusParams.Evidence.MaxBytes = tc.evidenceMaxBytes
+			block, _ := blockExec.CreateProposalBlock(1, state, commit, proposerAddr)
+			require.NotNil(t, block)
+			assert.Equal(t, tc.evidence, len(block.Evidence.Evidence) == 1)
+			assert.Equal(t, tc.txs, len(block.Data.Txs) == 1)
+
+			// the peers accept the proposed block
+			validator := sm.NewBlockExecutor(stateStore, log.TestingLogger(), proxyApp.Consensus(),
+				mmock.Mempool{}, sm.EmptyEvidencePool{})
+			require.NoError(t, validator.ValidateBlock(state, block))
+		})
+	}
+}
+
+// oneTxMempool holds a single tx, reaped if it fits.
+type oneTxMempool struct {
+	mmock.Mempool
+	tx types.Tx
+}
+
+func (mem *oneTxMempool) ReapMaxBytesMaxGas(maxBytes, maxGas int64) types.Txs {
+	if int64(len(mem.tx)) > maxBytes {
+		return types.Txs{}
+	}
+	return types.Txs{mem.tx}
+}
+
+func BenchmarkCreateProposalBlock(b *testing.B) {
+	app := &testApp{}
+	cc := proxy.NewLocalClientCreator(app)
+	proxyApp := proxy.NewAppConns(cc)
+	require.NoError(b, proxyApp.Start())
+	defer proxyApp.Stop() //nolint:errcheck // ignore for tests
+
+	state, stateDB, _ := makeState(1, 1)
+	stateStore := sm.NewStore(stateDB, sm.StoreOptions{
+		DiscardABCIResponses: false,
+	})
+	logger := log.NewNopLogger()
+	memplCfg := cfg.TestMempoolConfig()
+	memplCfg.Size = 10000
+	mempool := mempoolv1.NewTxMempool(logger, memplCfg, proxyApp.Mempool(), state.LastBlockHeight)
+	for i := 0; i < memplCfg.Size; i++ {
+		require.NoError(b, mempool.CheckTx(cmtrand.Bytes(100), nil, mempl.TxInfo{}))
+	}
+	require.Equal(b, memplCfg.Size, mempool.Size())
+
+	blockExec := sm.NewBlockExecutor(stateStore, logger, proxyApp.Consensus(), mempool, sm.EmptyEvidencePool{})
+	proposerAddr, _ := state.Validators.GetByIndex(0)
+	commit := types.NewCommit(0, 0, types.BlockID{}, nil)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for n := 0; n < b.N; n++ {
+		block, _ := blockExec.CreateProposalBlock(1, state, commit, proposerAddr)
+		require.Len(b, block.Txs, memplCfg.Size)
+	}
+}
+
+// requirementsApp lowers the max tx size it requires after each block.
+type requirementsApp struct {
+	testApp
+
+	height     int64
+	maxTxBytes int64
+}
+
+func (app *requirementsApp) Info(req abci.RequestInfo) abci.ResponseInfo {
+	return abci.ResponseInfo{
+		LastBlockHeight: app.height,
+		MempoolRequirements: &abci.MempoolRequirements{
+			MinGasPrice: "0.002utia",
+			MaxTxBytes:  app.maxTxBytes,
+		},
+	}
+}
+
+func (app *requirementsApp) Commit() abci.ResponseCommit {
+	app.height++
+	app.maxTxBytes -= 10
+	return abci.ResponseCommit{}
+}
+
+// preCheckMempool keeps the PreCheckFunc of the last update.
+type preCheckMempool struct {
+	mmock.Mempool
+
+	preCheck mempl.PreCheckFunc
+}
+
+func (mp *preCheckMempool) Update(
+	_ int64,
+	_ types.Txs,
+	_ []*abci.ResponseDeliverTx,
+	preCheck mempl.PreCheckFunc,
+	_ mempl.PostCheckFunc,
+) error {
+	mp.preCheck = preCheck
+	return nil
+}
+
+func TestApplyBlockRefreshesAppRequirements(t *testing.T) {
+	app := &requirementsApp{maxTxBytes: 100}
+	proxyApp := proxy.NewAppConns(proxy.NewLocalClientCreator(app))
+	require.NoError(t, proxyApp.Start())
+	defer proxyApp.Stop() //nolint:errcheck // ignore for tests
+
+	state, stateDB, _ := makeState(1, 1)
+	stateStore := sm.NewStore(stateDB, sm.StoreOptions{
+		DiscardABCIResponses: false,
+	})
+	appRequirements := mempl.NewAppRequirements(proxyApp.Query(), true)
+	require.NoError(t, appRequirements.Refresh())
+	mp := &preCheckMempool{}
+	blockExec := sm.NewBlockExecutor(stateStore, log.TestingLogger(), proxyApp.Consensus(),
+		mp, sm.EmptyEvidencePool{}, sm.BlockExecutorWithAppRequirements(appRequirements))
+
+	block := makeBlock(state, 1)
+	blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: block.MakePartSet(testPartSize).Header()}
+	_, _, err := blockExec.ApplyBlock(state, blockID, block, nil)
+	require.NoError(t, err)
+
+	// the requirements the app reports after the block apply to the txs
+	// rechecked against it
+	requirements, height := appRequirements.Requirements()
+	assert.EqualValues(t, 1, height)
+	assert.EqualValues(t, 90, requirements.MaxTxBytes)
+	require.NotNil(t, mp.preCheck)
+	assert.NoError(t, mp.preCheck(make(types.Tx, 90)))
+	assert.Error(t, mp.preCheck(make(types.Tx, 91)))
+}
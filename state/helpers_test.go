@@ -245,6 +245,7 @@ type testApp struct {
 	CommitVotes         []abci.VoteInfo
 	ByzantineValidators []abci.Evidence
 	ValidatorUpdates    []abci.ValidatorUpdate
+	EvidenceParams      *cmtproto.EvidenceParams
 }
 
 var _ abci.Application = (*testApp)(nil)
@@ -263,6 +264,7 @@ func (app *testApp) EndBlock(req abci.RequestEndBlock) abci.ResponseEndBlock {
 	return abci.ResponseEndBlock{
 		ValidatorUpdates: app.ValidatorUpdates,
 		ConsensusParamUpdates: &abci.ConsensusParams{
+			Evidence: app.EvidenceParams,
 			Version: &cmtproto.VersionParams{
 				AppVersion: 1}}}
 }
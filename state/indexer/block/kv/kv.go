@@ -49,6 +49,44 @@ func (idx *BlockerIndexer) Has(height int64) (bool, error) {
 	return idx.store.Has(key)
 }
 
+// IndexedHeights returns the lowest and highest indexed heights, or 0 and 0 if
+// no height is indexed. Heights in between may be missing, e.g. if only some
+// of them were reindexed.
+func (idx *BlockerIndexer) IndexedHeights() (lowest, highest int64, err error) {
+	start, err := orderedcode.Append(nil, types.BlockHeightKey)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to create block height index key: %w", err)
+	}
+	end := prefixEnd(start)
+
+	it, err := idx.store.Iterator(start, end)
+	if err != nil {
+		return 0, 0, err
+	}
+	if lowest, err = firstHeight(it); err != nil || lowest == 0 {
+		return 0, 0, err
+	}
+	if it, err = idx.store.ReverseIterator(start, end); err != nil {
+		return 0, 0, err
+	}
+	highest, err = firstHeight(it)
+	return lowest, highest, err
+}
+
+// firstHeight returns the height of the first primary key of the iterator, or
+// 0 if there is none, and closes the iterator.
+func firstHeight(it dbm.Iterator) (int64, error) {
+	defer it.Close()
+	if !it.Valid() {
+		return 0, it.Error()
+	}
+	height, err := parseValueFromPrimaryKey(it.Key())
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(height, 10, 64)
+}
+
 // Index indexes BeginBlock and EndBlock events for a given block by its height.
 // The following is indexed:
 //
@@ -209,7 +247,8 @@ func (idx *BlockerIndexer) Search(ctx context.Context, q *query.Query) ([]int64,
 			continue
 		}
 
-		startKey, err := orderedcode.Append(nil, c.CompositeKey, fmt.Sprintf("%v", c.Operand))
+		// a denominated number is stored with its denomination, e.g. "5utia"
+		startKey, err := orderedcode.Append(nil, c.CompositeKey, fmt.Sprintf("%v%s", c.Operand, c.Denom))
 
 		if err != nil {
 			return nil, err
@@ -313,8 +352,7 @@ LOOP:
 		}
 
 		if _, ok := qr.AnyBound().(*big.Int); ok {
-			v := new(big.Int)
-			v, ok := v.SetString(eventValue, 10)
+			v, ok := indexer.ParseNumericValue(eventValue, qr.Denom)
 			if !ok { // If the number was not int it might be a float but this behavior is kept the same as before the patch
 				continue LOOP
 			}
@@ -487,3 +487,78 @@ func TestBigInt(t *testing.T) {
 		})
 	}
 }
+
+func TestDenominatedAmounts(t *testing.T) {
+	store := db.NewPrefixDB(db.NewMemDB(), []byte("block_events"))
+	indexer := blockidxkv.New(store)
+
+	for height, amount := range map[int64]string{1: "1000utia", 2: "1000stake", 3: "1000", 4: "2000utia"} {
+		require.NoError(t, indexer.Index(types.EventDataNewBlockHeader{
+			Header: types.Header{Height: height},
+			ResultEndBlock: abci.ResponseEndBlock{
+				Events: []abci.Event{{
+					Type:       "reward",
+					Attributes: []abci.EventAttribute{{Key: []byte("amount"), Value: []byte(amount), Index: true}},
+				}},
+			},
+		}))
+	}
+
+	testCases := map[string]struct {
+		q       *query.Query
+		results []int64
+	}{
+		"no denomination": {
+			q:       query.MustParse("reward.amount >= 1000"),
+			results: []int64{3},
+		},
+		"range of a denomination": {
+			q:       query.MustParse("reward.amount[utia] >= 1000"),
+			results: []int64{1, 4},
+		},
+		"bounded range of a denomination": {
+			q:       query.MustParse("reward.amount[utia] > 1000 AND reward.amount[utia] <= 2000"),
+			results: []int64{4},
+		},
+		"equality with a denomination": {
+			q:       query.MustParse("reward.amount[stake] = 1000"),
+			results: []int64{2},
+		},
+	}
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			results, err := indexer.Search(context.Background(), tc.q)
+			require.NoError(t, err)
+			require.Equal(t, tc.results, results)
+		})
+	}
+}
+
+func TestBlockIndexerIndexedHeights(t *testing.T) {
+	store := db.NewPrefixDB(db.NewMemDB(), []byte("block_events"))
+	indexer := blockidxkv.New(store)
+
+	lowest, highest, err := indexer.IndexedHeights()
+	require.NoError(t, err)
+	require.Zero(t, lowest)
+	require.Zero(t, highest)
+
+	// heights indexed out of order and with gaps, as by a partial reindex
+	for _, height := range []int64{300, 7, 42, 256} {
+		require.NoError(t, indexer.Index(types.EventDataNewBlockHeader{
+			Header: types.Header{Height: height},
+			ResultEndBlock: abci.ResponseEndBlock{
+				Events: []abci.Event{{
+					Type:       "end_event",
+					Attributes: []abci.EventAttribute{{Key: []byte("foo"), Value: []byte("bar"), Index: true}},
+				}},
+			},
+		}))
+	}
+
+	lowest, highest, err = indexer.IndexedHeights()
+	require.NoError(t, err)
+	require.EqualValues(t, 7, lowest)
+	require.EqualValues(t, 300, highest)
+}
@@ -8,6 +8,20 @@ import (
 	"github.com/tendermint/tendermint/types"
 )
 
+// ParseNumericValue parses an event attribute value that looks numeric into a
+// big.Int so that it can be compared numerically rather than lexicographically,
+// if it has the denomination denom, e.g. "1000000utia" for "utia" or "1000000"
+// for none, like the pubsub queries compare the values (see
+// query.ParseNumericValue). Values of arbitrary size are supported. Floats are
+// not parsed.
+func ParseNumericValue(value, denom string) (*big.Int, bool) {
+	number, valueDenom, ok := query.ParseNumericValue(value)
+	if !ok || valueDenom != denom {
+		return nil, false
+	}
+	return new(big.Int).SetString(number, 10)
+}
+
 // QueryRanges defines a mapping between a composite event key and a QueryRange.
 //
 // e.g.account.number => queryRange{lowerBound: 1, upperBound: 5}
@@ -20,6 +34,9 @@ type QueryRange struct {
 	Key               string
 	IncludeLowerBound bool
 	IncludeUpperBound bool
+	// Denom is the denomination of the values compared to the bounds, see
+	// query.Condition.
+	Denom string
 }
 
 // AnyBound returns either the lower bound if non-nil, otherwise the upper bound.
@@ -92,7 +109,7 @@ func LookForRangesWithHeight(conditions []query.Condition) (ranges QueryRanges,
 		if IsRangeOperation(c.Op) {
 			r, ok := ranges[c.CompositeKey]
 			if !ok {
-				r = QueryRange{Key: c.CompositeKey}
+				r = QueryRange{Key: c.CompositeKey, Denom: c.Denom}
 				if c.CompositeKey == types.BlockHeightKey || c.CompositeKey == types.TxHeightKey {
 					heightRange = QueryRange{Key: c.CompositeKey}
 					heightKey = true
@@ -147,7 +164,7 @@ func LookForRanges(conditions []query.Condition) (ranges QueryRanges, indexes []
 		if IsRangeOperation(c.Op) {
 			r, ok := ranges[c.CompositeKey]
 			if !ok {
-				r = QueryRange{Key: c.CompositeKey}
+				r = QueryRange{Key: c.CompositeKey, Denom: c.Denom}
 			}
 
 			switch c.Op {
@@ -5,7 +5,8 @@ package mocks
 import (
 	mock "github.com/stretchr/testify/mock"
 
-	cmtstore "github.com/tendermint/tendermint/proto/tendermint/store"
+	store "github.com/tendermint/tendermint/proto/tendermint/store"
+
 	types "github.com/tendermint/tendermint/types"
 )
 
@@ -28,6 +29,20 @@ func (_m *BlockStore) Base() int64 {
 	return r0
 }
 
+// HasBlockData provides a mock function with given fields: height
+func (_m *BlockStore) HasBlockData(height int64) bool {
+	ret := _m.Called(height)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(int64) bool); ok {
+		r0 = rf(height)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
 // Height provides a mock function with given fields:
 func (_m *BlockStore) Height() int64 {
 	ret := _m.Called()
@@ -74,22 +89,6 @@ func (_m *BlockStore) LoadBlock(height int64) *types.Block {
 	return r0
 }
 
-// LoadTxInfo provides a mock function with given fields: hash
-func (_m *BlockStore) LoadTxInfo(hash []byte) *cmtstore.TxInfo {
-	ret := _m.Called(hash)
-
-	var r0 *cmtstore.TxInfo
-	if rf, ok := ret.Get(0).(func([]byte) *cmtstore.TxInfo); ok {
-		r0 = rf(hash)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*cmtstore.TxInfo)
-		}
-	}
-
-	return r0
-}
-
 // LoadBlockByHash provides a mock function with given fields: hash
 func (_m *BlockStore) LoadBlockByHash(hash []byte) *types.Block {
 	ret := _m.Called(hash)
@@ -170,6 +169,32 @@ func (_m *BlockStore) LoadBlockPart(height int64, index int) *types.Part {
 	return r0
 }
 
+// LoadRowProof provides a mock function with given fields: height
+func (_m *BlockStore) LoadRowProof(height int64) (*types.RowProof, error) {
+	ret := _m.Called(height)
+
+	var r0 *types.RowProof
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64) (*types.RowProof, error)); ok {
+		return rf(height)
+	}
+	if rf, ok := ret.Get(0).(func(int64) *types.RowProof); ok {
+		r0 = rf(height)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.RowProof)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(height)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // LoadSeenCommit provides a mock function with given fields: height
 func (_m *BlockStore) LoadSeenCommit(height int64) *types.Commit {
 	ret := _m.Called(height)
@@ -186,18 +211,37 @@ func (_m *BlockStore) LoadSeenCommit(height int64) *types.Commit {
 	return r0
 }
 
+// LoadTxInfo provides a mock function with given fields: hash
+func (_m *BlockStore) LoadTxInfo(hash []byte) *store.TxInfo {
+	ret := _m.Called(hash)
+
+	var r0 *store.TxInfo
+	if rf, ok := ret.Get(0).(func([]byte) *store.TxInfo); ok {
+		r0 = rf(hash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*store.TxInfo)
+		}
+	}
+
+	return r0
+}
+
 // PruneBlocks provides a mock function with given fields: height
 func (_m *BlockStore) PruneBlocks(height int64) (uint64, error) {
 	ret := _m.Called(height)
 
 	var r0 uint64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64) (uint64, error)); ok {
+		return rf(height)
+	}
 	if rf, ok := ret.Get(0).(func(int64) uint64); ok {
 		r0 = rf(height)
 	} else {
 		r0 = ret.Get(0).(uint64)
 	}
 
-	var r1 error
 	if rf, ok := ret.Get(1).(func(int64) error); ok {
 		r1 = rf(height)
 	} else {
@@ -207,6 +251,44 @@ func (_m *BlockStore) PruneBlocks(height int64) (uint64, error) {
 	return r0, r1
 }
 
+// RetainHeight provides a mock function with given fields:
+func (_m *BlockStore) RetainHeight() int64 {
+	ret := _m.Called()
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func() int64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	return r0
+}
+
+// SaveBlock provides a mock function with given fields: block, blockParts, seenCommit
+func (_m *BlockStore) SaveBlock(block *types.Block, blockParts *types.PartSet, seenCommit *types.Commit) {
+	_m.Called(block, blockParts, seenCommit)
+}
+
+// SaveRetainHeight provides a mock function with given fields: height
+func (_m *BlockStore) SaveRetainHeight(height int64) {
+	_m.Called(height)
+}
+
+// SaveRowProof provides a mock function with given fields: height, rowProof
+func (_m *BlockStore) SaveRowProof(height int64, rowProof types.RowProof) error {
+	ret := _m.Called(height, rowProof)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64, types.RowProof) error); ok {
+		r0 = rf(height, rowProof)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SaveTxInfo provides a mock function with given fields: block, txResponseCode
 func (_m *BlockStore) SaveTxInfo(block *types.Block, txResponseCode []uint32) error {
 	ret := _m.Called(block, txResponseCode)
@@ -221,11 +303,6 @@ func (_m *BlockStore) SaveTxInfo(block *types.Block, txResponseCode []uint32) er
 	return r0
 }
 
-// SaveBlock provides a mock function with given fields: block, blockParts, seenCommit
-func (_m *BlockStore) SaveBlock(block *types.Block, blockParts *types.PartSet, seenCommit *types.Commit) {
-	_m.Called(block, blockParts, seenCommit)
-}
-
 // Size provides a mock function with given fields:
 func (_m *BlockStore) Size() int64 {
 	ret := _m.Called()
@@ -0,0 +1,39 @@
+package state
+
+// EvidenceRetainHeight returns the retain height to prune blocks and states up
+// to so that no data needed to verify evidence that has not expired yet is
+// pruned. Evidence expires once it is both older than MaxAgeNumBlocks blocks
+// and older than MaxAgeDuration compared to the last block of the given state,
+// so every pruned block must satisfy both conditions. The returned height is
+// never greater than retainHeight nor, if blocks would be pruned, lower than the
+// base of the block store.
+func EvidenceRetainHeight(state State, blockStore BlockStore, retainHeight int64) int64 {
+	base := blockStore.Base()
+	if retainHeight <= base {
+		return retainHeight
+	}
+
+	evidenceParams := state.ConsensusParams.Evidence
+	// the last pruned block is retainHeight-1
+	if maxHeight := state.LastBlockHeight - evidenceParams.MaxAgeNumBlocks; retainHeight > maxHeight {
+		retainHeight = maxHeight
+	}
+	if retainHeight <= base {
+		return base
+	}
+
+	// block times are monotonic, so search for the highest retain height whose
+	// preceding block is older than the max age duration
+	cutoff := state.LastBlockTime.Add(-evidenceParams.MaxAgeDuration)
+	lo, hi := base, retainHeight
+	for lo < hi {
+		mid := lo + (hi-lo+1)/2
+		meta := blockStore.LoadBlockMeta(mid - 1)
+		if meta != nil && meta.Header.Time.Before(cutoff) {
+			lo = mid
+		} else {
+			hi = mid - 1
+		}
+	}
+	return lo
+}
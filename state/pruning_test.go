@@ -0,0 +1,62 @@
+package state_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/tendermint/tendermint/state"
+	"github.com/tendermint/tendermint/state/mocks"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestEvidenceRetainHeight(t *testing.T) {
+	var (
+		height    int64 = 100
+		base      int64 = 10
+		startTime       = time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	)
+	// one block per minute
+	blockStore := &mocks.BlockStore{}
+	blockStore.On("Base").Return(base)
+	blockStore.On("LoadBlockMeta", mock.AnythingOfType("int64")).Return(func(h int64) *types.BlockMeta {
+		if h < base || h > height {
+			return nil
+		}
+		return &types.BlockMeta{Header: types.Header{Height: h, Time: startTime.Add(time.Duration(h) * time.Minute)}}
+	})
+
+	st := state.State{
+		LastBlockHeight: height,
+		LastBlockTime:   startTime.Add(time.Duration(height) * time.Minute),
+		ConsensusParams: *types.DefaultConsensusParams(),
+	}
+
+	testCases := []struct {
+		name            string
+		maxAgeNumBlocks int64
+		maxAgeDuration  time.Duration
+		retainHeight    int64
+		expected        int64
+	}{
+		{"evidence already expired", 10, 10 * time.Minute, 50, 50},
+		{"nothing to prune", 10, 10 * time.Minute, base, base},
+		{"clamped by max age num blocks", 60, 10 * time.Minute, 90, 40},
+		{"clamped by max age duration", 10, 30 * time.Minute, 90, 70},
+		{"clamped by both", 60, 70 * time.Minute, 90, 30},
+		{"everything within max age", 200, 10 * time.Minute, 90, base},
+		{"everything within max age duration", 10, 200 * time.Minute, 90, base},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			st := st
+			st.ConsensusParams.Evidence.MaxAgeNumBlocks = tc.maxAgeNumBlocks
+			st.ConsensusParams.Evidence.MaxAgeDuration = tc.maxAgeDuration
+			assert.Equal(t, tc.expected, state.EvidenceRetainHeight(st, blockStore, tc.retainHeight))
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package state
+
+import (
+	cmtsync "github.com/tendermint/tendermint/libs/sync"
+	mempl "github.com/tendermint/tendermint/mempool"
+	"github.com/tendermint/tendermint/types"
+)
+
+// DefaultReapDecisionsSize is the number of heights whose reap decisions are
+// kept by default.
+const DefaultReapDecisionsSize = 100
+
+// ReapDecision records why the transactions of the mempool were left out of a
+// block proposed by this node.
+type ReapDecision struct {
+	Height int64 `json:"height"`
+	// MempoolSize is the number of transactions in the mempool when reaping.
+	MempoolSize int `json:"mempool_size"`
+	// Reaped is the number of transactions reaped from the mempool.
+	Reaped int `json:"reaped"`
+	// Proposed is the number of transactions in the proposed block, after
+	// PrepareProposal.
+	Proposed int `json:"proposed"`
+	// Excluded holds the transactions left out, by reason.
+	Excluded mempl.Exclusions `json:"excluded"`
+}
+
+// ReapDecisions is a thread-safe ring buffer holding the reap decisions of
+// the last heights proposed by this node.
+type ReapDecisions struct {
+	mtx       cmtsync.Mutex
+	decisions []*ReapDecision
+	next      int
+}
+
+// NewReapDecisions returns a ReapDecisions keeping the decisions of the last
+// size heights. It panics if size is not positive.
+func NewReapDecisions(size int) *ReapDecisions {
+	if size <= 0 {
+		panic("reap decisions size must be positive")
+	}
+	return &ReapDecisions{decisions: make([]*ReapDecision, size)}
+}
+
+// Record stores the decision, overwriting the oldest one if the buffer is
+// full. Proposing again at the same height replaces the previous decision.
+func (r *ReapDecisions) Record(decision *ReapDecision) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	for i, d := range r.decisions {
+		if d != nil && d.Height == decision.Height {
+			r.decisions[i] = decision
+			return
+		}
+	}
+	r.decisions[r.next] = decision
+	r.next = (r.next + 1) % len(r.decisions)
+}
+
+// Get returns the decision recorded for the height, if any.
+func (r *ReapDecisions) Get(height int64) (*ReapDecision, bool) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	for _, d := range r.decisions {
+		if d != nil && d.Height == height {
+			return d, true
+		}
+	}
+	return nil, false
+}
+
+// newReapDecision returns the decision of proposing the prepared txs out of
+// the txs reaped from a mempool of size transactions. The reaped txs missing
+// from the prepared ones are counted as removed by PrepareProposal. Since the
+// application replaces the blob transactions by index wrappers, both are
+// compared by the transaction they wrap.
+func newReapDecision(
+	height int64,
+	size int,
+	reaped types.Txs,
+	prepared [][]byte,
+	excluded mempl.Exclusions,
+) *ReapDecision {
+	proposed := make(map[types.TxKey]struct{}, len(prepared))
+	for _, tx := range prepared {
+		if indexWrapper, isIndexWrapper := types.UnmarshalIndexWrapper(tx); isIndexWrapper {
+			tx = indexWrapper.Tx
+		}
+		proposed[types.Tx(tx).Key()] = struct{}{}
+	}
+	for _, tx := range reaped {
+		key := tx.Key()
+		if bTx, isBlob := types.UnmarshalBlobTx(tx); isBlob {
+			key = types.Tx(bTx.Tx).Key()
+		}
+		if _, ok := proposed[key]; !ok {
+			excluded.Add(mempl.ExclusionPrepareProposal, tx)
+		}
+	}
+	return &ReapDecision{
+		Height:      height,
+		MempoolSize: size,
+		Reaped:      len(reaped),
+		Proposed:    len(prepared),
+		Excluded:    excluded,
+	}
+}
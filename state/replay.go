@@ -0,0 +1,238 @@
+package state
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/gogo/protobuf/proto"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+	cmtstate "github.com/tendermint/tendermint/proto/tendermint/state"
+	"github.com/tendermint/tendermint/proxy"
+	"github.com/tendermint/tendermint/types"
+)
+
+// ReplayDivergence describes the first difference found between re-executing
+// a stored block against an application and the results recorded by the node.
+type ReplayDivergence struct {
+	// Height is the height of the block whose execution diverged.
+	Height int64
+	// Field names the differing value, e.g. "app_hash" or "deliver_tx[2].code".
+	Field    string
+	Expected string
+	Got      string
+}
+
+func (d ReplayDivergence) String() string {
+	return fmt.Sprintf("height %d: %s differs: expected %s, got %s", d.Height, d.Field, d.Expected, d.Got)
+}
+
+// ReplayBlocks re-executes the stored blocks from..to (inclusive) against the
+// application and compares the resulting app hashes and tx results with the
+// stored headers and ABCI responses. Blocks are neither validated nor is any
+// state persisted.
+//
+// If genDoc is not nil, the application is expected to be at genesis and is
+// initialized with InitChain before replaying, in which case from must be the
+// initial height. Otherwise the application must have committed height from-1.
+//
+// It returns the first divergence found or nil if the re-executed blocks match
+// the stored chain.
+func ReplayBlocks(
+	ctx context.Context,
+	proxyApp proxy.AppConns,
+	blockStore BlockStore,
+	stateStore Store,
+	genDoc *types.GenesisDoc,
+	from, to int64,
+	logger log.Logger,
+) (*ReplayDivergence, error) {
+	if from < blockStore.Base() || to > blockStore.Height() || from > to {
+		return nil, fmt.Errorf("invalid height range [%d, %d], block store contains [%d, %d]",
+			from, to, blockStore.Base(), blockStore.Height())
+	}
+
+	state, err := stateStore.Load()
+	if err != nil {
+		return nil, err
+	}
+	if state.IsEmpty() {
+		return nil, errors.New("no state found")
+	}
+
+	res, err := proxyApp.Consensus().InfoSync(proxy.RequestInfo)
+	if err != nil {
+		return nil, fmt.Errorf("error calling Info: %w", err)
+	}
+
+	if genDoc != nil {
+		if res.LastBlockHeight != 0 {
+			return nil, fmt.Errorf("app is at height %d, expected a fresh app to start from genesis", res.LastBlockHeight)
+		}
+		if from != state.InitialHeight {
+			return nil, fmt.Errorf("replaying from genesis must start at the initial height %d, got %d",
+				state.InitialHeight, from)
+		}
+		divergence, err := replayInitChain(proxyApp.Consensus(), blockStore, genDoc, from)
+		if divergence != nil || err != nil {
+			return divergence, err
+		}
+	} else if res.LastBlockHeight != from-1 {
+		return nil, fmt.Errorf("app is at height %d, expected it to be at height %d", res.LastBlockHeight, from-1)
+	}
+
+	for height := from; height <= to; height++ {
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("replay terminated at height %d: %w", height, ctx.Err())
+		default:
+		}
+
+		block := blockStore.LoadBlock(height)
+		if block == nil {
+			return nil, fmt.Errorf("not able to load block at height %d from the blockstore", height)
+		}
+
+		abciResponses, err := execBlockOnProxyApp(logger, proxyApp.Consensus(), block, stateStore, state.InitialHeight)
+		if err != nil {
+			return nil, fmt.Errorf("error executing block at height %d: %w", height, err)
+		}
+		commitRes, err := proxyApp.Consensus().CommitSync()
+		if err != nil {
+			return nil, fmt.Errorf("error committing block at height %d: %w", height, err)
+		}
+
+		// The results of executing a block are recorded in the next header. The
+		// latest block has no next header, so we fall back to the latest state.
+		var nextHeader *types.Header
+		if meta := blockStore.LoadBlockMeta(height + 1); meta != nil {
+			nextHeader = &meta.Header
+		} else if state.LastBlockHeight == height {
+			nextHeader = &types.Header{AppHash: state.AppHash, LastResultsHash: state.LastResultsHash}
+		}
+
+		if divergence := diffReplayedBlock(stateStore, height, abciResponses, commitRes.Data, nextHeader); divergence != nil {
+			return divergence, nil
+		}
+		logger.Info("replayed block", "height", height, "app_hash", fmt.Sprintf("%X", commitRes.Data))
+	}
+
+	return nil, nil
+}
+
+func replayInitChain(
+	appConn proxy.AppConnConsensus,
+	blockStore BlockStore,
+	genDoc *types.GenesisDoc,
+	initialHeight int64,
+) (*ReplayDivergence, error) {
+	validators := make([]*types.Validator, len(genDoc.Validators))
+	for i, val := range genDoc.Validators {
+		validators[i] = types.NewValidator(val.PubKey, val.Power)
+	}
+	validatorSet := types.NewValidatorSet(validators)
+	res, err := appConn.InitChainSync(abci.RequestInitChain{
+		Time:            genDoc.GenesisTime,
+		ChainId:         genDoc.ChainID,
+		InitialHeight:   genDoc.InitialHeight,
+		ConsensusParams: types.TM2PB.ConsensusParams(genDoc.ConsensusParams),
+		Validators:      types.TM2PB.ValidatorUpdates(validatorSet),
+		AppStateBytes:   genDoc.AppState,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error calling InitChain: %w", err)
+	}
+
+	// If the app returned no app hash, the one from the genesis doc is used in
+	// the first block.
+	appHash := res.AppHash
+	if len(appHash) == 0 {
+		appHash = genDoc.AppHash
+	}
+	meta := blockStore.LoadBlockMeta(initialHeight)
+	if meta != nil && !bytes.Equal(meta.Header.AppHash, appHash) {
+		return &ReplayDivergence{
+			Height:   initialHeight,
+			Field:    "init_chain.app_hash",
+			Expected: fmt.Sprintf("%X", meta.Header.AppHash),
+			Got:      fmt.Sprintf("%X", appHash),
+		}, nil
+	}
+	return nil, nil
+}
+
+// diffReplayedBlock compares the results of re-executing the block at height
+// with the stored ABCI responses and, if available, the next header. Only the
+// deterministic parts of the responses, i.e. those that are committed to in
+// the header, are compared.
+func diffReplayedBlock(
+	stateStore Store,
+	height int64,
+	got *cmtstate.ABCIResponses,
+	appHash []byte,
+	nextHeader *types.Header,
+) *ReplayDivergence {
+	diverged := func(field string, expected, got interface{}) *ReplayDivergence {
+		return &ReplayDivergence{
+			Height:   height,
+			Field:    field,
+			Expected: fmt.Sprintf("%v", expected),
+			Got:      fmt.Sprintf("%v", got),
+		}
+	}
+
+	expected, err := stateStore.LoadABCIResponses(height)
+	if err == nil {
+		if len(expected.DeliverTxs) != len(got.DeliverTxs) {
+			return diverged("len(deliver_txs)", len(expected.DeliverTxs), len(got.DeliverTxs))
+		}
+		for i := range expected.DeliverTxs {
+			e, g := expected.DeliverTxs[i], got.DeliverTxs[i]
+			switch {
+			case e.Code != g.Code:
+				return diverged(fmt.Sprintf("deliver_tx[%d].code", i), e.Code, g.Code)
+			case !bytes.Equal(e.Data, g.Data):
+				return diverged(fmt.Sprintf("deliver_tx[%d].data", i), fmt.Sprintf("%X", e.Data), fmt.Sprintf("%X", g.Data))
+			case e.GasWanted != g.GasWanted:
+				return diverged(fmt.Sprintf("deliver_tx[%d].gas_wanted", i), e.GasWanted, g.GasWanted)
+			case e.GasUsed != g.GasUsed:
+				return diverged(fmt.Sprintf("deliver_tx[%d].gas_used", i), e.GasUsed, g.GasUsed)
+			}
+		}
+		if expected.EndBlock != nil && got.EndBlock != nil {
+			if len(expected.EndBlock.ValidatorUpdates) != len(got.EndBlock.ValidatorUpdates) {
+				return diverged("end_block.validator_updates",
+					expected.EndBlock.ValidatorUpdates, got.EndBlock.ValidatorUpdates)
+			}
+			for i := range expected.EndBlock.ValidatorUpdates {
+				if !proto.Equal(&expected.EndBlock.ValidatorUpdates[i], &got.EndBlock.ValidatorUpdates[i]) {
+					return diverged(fmt.Sprintf("end_block.validator_updates[%d]", i),
+						expected.EndBlock.ValidatorUpdates[i], got.EndBlock.ValidatorUpdates[i])
+				}
+			}
+			if !proto.Equal(expected.EndBlock.ConsensusParamUpdates, got.EndBlock.ConsensusParamUpdates) {
+				return diverged("end_block.consensus_param_updates",
+					expected.EndBlock.ConsensusParamUpdates, got.EndBlock.ConsensusParamUpdates)
+			}
+		}
+	} else if !errors.Is(err, ErrABCIResponsesNotPersisted) {
+		var notFound ErrNoABCIResponsesForHeight
+		if !errors.As(err, &notFound) {
+			return diverged("abci_responses", "stored responses", err)
+		}
+	}
+
+	if nextHeader == nil {
+		return nil
+	}
+	if resultsHash := types.NewResults(got.DeliverTxs).Hash(); !bytes.Equal(nextHeader.LastResultsHash, resultsHash) {
+		return diverged("last_results_hash", fmt.Sprintf("%X", nextHeader.LastResultsHash), fmt.Sprintf("%X", resultsHash))
+	}
+	if !bytes.Equal(nextHeader.AppHash, appHash) {
+		return diverged("app_hash", fmt.Sprintf("%X", nextHeader.AppHash), fmt.Sprintf("%X", appHash))
+	}
+	return nil
+}
@@ -0,0 +1,184 @@
+package state_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	dbm "github.com/cometbft/cometbft-db"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/abci/example/kvstore"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+	mmock "github.com/tendermint/tendermint/mempool/mock"
+	"github.com/tendermint/tendermint/proxy"
+	sm "github.com/tendermint/tendermint/state"
+	"github.com/tendermint/tendermint/store"
+	"github.com/tendermint/tendermint/test/factory"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestReplayBlocks(t *testing.T) {
+	const chainHeight = 5
+	stateStore, blockStore := makeKVStoreChain(t, chainHeight)
+
+	testCases := []struct {
+		name       string
+		app        abci.Application
+		from, to   int64
+		divergence *sm.ReplayDivergence
+		expErr     bool
+	}{
+		{
+			name: "identical app",
+			app:  kvstore.NewApplication(),
+			from: 1,
+			to:   chainHeight,
+		},
+		{
+			name: "diverging tx result",
+			app:  &divergentApp{Application: kvstore.NewApplication(), divergeAt: 3, code: 1},
+			from: 1,
+			to:   chainHeight,
+			divergence: &sm.ReplayDivergence{
+				Height: 3, Field: "deliver_tx[0].code", Expected: "0", Got: "1",
+			},
+		},
+		{
+			name: "diverging app hash",
+			app:  &divergentApp{Application: kvstore.NewApplication(), divergeAt: 2, skipTx: true},
+			from: 1,
+			to:   chainHeight,
+			divergence: &sm.ReplayDivergence{
+				Height: 2, Field: "app_hash",
+			},
+		},
+		{
+			name: "divergence at the latest height",
+			app:  &divergentApp{Application: kvstore.NewApplication(), divergeAt: chainHeight, skipTx: true},
+			from: 1,
+			to:   chainHeight,
+			divergence: &sm.ReplayDivergence{
+				Height: chainHeight, Field: "app_hash",
+			},
+		},
+		{
+			name: "divergence outside of the range",
+			app:  &divergentApp{Application: kvstore.NewApplication(), divergeAt: 4, code: 1},
+			from: 1,
+			to:   3,
+		},
+		{
+			name:   "app not at the start height",
+			app:    kvstore.NewApplication(),
+			from:   2,
+			to:     chainHeight,
+			expErr: true,
+		},
+		{
+			name:   "range beyond the block store",
+			app:    kvstore.NewApplication(),
+			from:   1,
+			to:     chainHeight + 1,
+			expErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			proxyApp := proxy.NewAppConns(proxy.NewLocalClientCreator(tc.app))
+			require.NoError(t, proxyApp.Start())
+			t.Cleanup(func() {
+				if err := proxyApp.Stop(); err != nil {
+					t.Error(err)
+				}
+			})
+
+			divergence, err := sm.ReplayBlocks(context.Background(), proxyApp, blockStore, stateStore,
+				nil, tc.from, tc.to, log.TestingLogger())
+			if tc.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			if tc.divergence == nil {
+				require.Nil(t, divergence)
+				return
+			}
+			require.NotNil(t, divergence)
+			require.Equal(t, tc.divergence.Height, divergence.Height)
+			require.Equal(t, tc.divergence.Field, divergence.Field)
+			if tc.divergence.Expected != "" {
+				require.Equal(t, tc.divergence.Expected, divergence.Expected)
+				require.Equal(t, tc.divergence.Got, divergence.Got)
+			}
+		})
+	}
+}
+
+// makeKVStoreChain executes and stores a chain of the given height against the
+// kvstore app.
+func makeKVStoreChain(t *testing.T, height int64) (sm.Store, *store.BlockStore) {
+	app := kvstore.NewApplication()
+	proxyApp := proxy.NewAppConns(proxy.NewLocalClientCreator(app))
+	require.NoError(t, proxyApp.Start())
+	defer proxyApp.Stop() //nolint:errcheck // ignore for tests
+
+	state, stateDB, privVals := makeState(1, 1)
+	stateStore := sm.NewStore(stateDB, sm.StoreOptions{
+		DiscardABCIResponses: false,
+	})
+	blockStore := store.NewBlockStore(dbm.NewMemDB())
+	blockExec := sm.NewBlockExecutor(stateStore, log.TestingLogger(), proxyApp.Consensus(),
+		mmock.Mempool{}, sm.EmptyEvidencePool{})
+
+	lastCommit := new(types.Commit)
+	for h := int64(1); h <= height; h++ {
+		txs := make([]types.Tx, 3)
+		for i := range txs {
+			txs[i] = types.Tx(fmt.Sprintf("key%d-%d=value", h, i))
+		}
+		block, _ := state.MakeBlock(h, factory.MakeData(txs), lastCommit, nil, state.Validators.GetProposer().Address)
+		partSet := block.MakePartSet(testPartSize)
+		blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: partSet.Header()}
+
+		validators := state.Validators
+		var err error
+		state, _, err = blockExec.ApplyBlock(state, blockID, block, lastCommit)
+		require.NoError(t, err)
+
+		lastCommit, err = makeValidCommit(h, blockID, validators, privVals)
+		require.NoError(t, err)
+		blockStore.SaveBlock(block, partSet, lastCommit)
+	}
+	return stateStore, blockStore
+}
+
+// divergentApp behaves like the kvstore app except at a single height where it
+// either returns a different code for every tx or skips applying the txs.
+type divergentApp struct {
+	*kvstore.Application
+	height    int64
+	divergeAt int64
+	code      uint32
+	skipTx    bool
+}
+
+func (app *divergentApp) BeginBlock(req abci.RequestBeginBlock) abci.ResponseBeginBlock {
+	app.height = req.Header.Height
+	return app.Application.BeginBlock(req)
+}
+
+func (app *divergentApp) DeliverTx(req abci.RequestDeliverTx) abci.ResponseDeliverTx {
+	if app.height != app.divergeAt {
+		return app.Application.DeliverTx(req)
+	}
+	if app.skipTx {
+		return abci.ResponseDeliverTx{}
+	}
+	res := app.Application.DeliverTx(req)
+	res.Code = app.code
+	return res
+}
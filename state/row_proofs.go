@@ -0,0 +1,180 @@
+package state
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/service"
+	"github.com/tendermint/tendermint/pkg/consts"
+	cmtproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/proxy"
+	"github.com/tendermint/tendermint/types"
+)
+
+const (
+	rowProofRecorderSubscriber = "RowProofRecorder"
+
+	// rowProofRecorderCapacity is the number of new blocks the recorder can lag
+	// behind before its subscription is cancelled.
+	rowProofRecorderCapacity = 100
+)
+
+// ProveShares queries the application for a proof of the share range
+// [startShare, endShare) of the given raw block to its data hash.
+func ProveShares(query proxy.AppConnQuery, rawBlock []byte, startShare, endShare uint64) (types.ShareProof, error) {
+	var pShareProof cmtproto.ShareProof
+	res, err := query.QuerySync(abci.RequestQuery{
+		Data: rawBlock,
+		Path: fmt.Sprintf(consts.ShareInclusionProofQueryPath, startShare, endShare),
+	})
+	if err != nil {
+		return types.ShareProof{}, err
+	}
+	if res.Value == nil && res.Log != "" {
+		// we can make the assumption that for custom queries, if the value is nil
+		// and some logs have been emitted, then an error happened.
+		return types.ShareProof{}, errors.New(res.Log)
+	}
+	err = pShareProof.Unmarshal(res.Value)
+	if err != nil {
+		return types.ShareProof{}, err
+	}
+	return types.ShareProofFromProto(pShareProof)
+}
+
+// ProveSquareRows queries the application for the proof of all the rows of
+// the original data square of the given raw block, and checks it against the
+// data hash of the block. It returns an error if the block has no data square.
+func ProveSquareRows(query proxy.AppConnQuery, rawBlock []byte) (types.RowProof, error) {
+	var pbb cmtproto.Block
+	if err := pbb.Unmarshal(rawBlock); err != nil {
+		return types.RowProof{}, fmt.Errorf("error decoding block: %w", err)
+	}
+	height, squareSize := pbb.Header.Height, pbb.Data.SquareSize
+	if squareSize == 0 {
+		return types.RowProof{}, fmt.Errorf("block at height %d has no data square", height)
+	}
+
+	shareProof, err := ProveShares(query, rawBlock, 0, squareSize*squareSize)
+	if err != nil {
+		return types.RowProof{}, err
+	}
+	rowProof := shareProof.RowProof
+	if rowProof.StartRow != 0 || uint64(rowProof.EndRow)+1 != squareSize {
+		return types.RowProof{}, fmt.Errorf("proof of the square at height %d covers rows [%d, %d] instead of %d rows",
+			height, rowProof.StartRow, rowProof.EndRow, squareSize)
+	}
+	if err := rowProof.Validate(pbb.Header.DataHash); err != nil {
+		return types.RowProof{}, fmt.Errorf("invalid proof of the square at height %d: %w", height, err)
+	}
+	return rowProof, nil
+}
+
+// RowProofRecorder saves the proof of the row roots of the data square of
+// every new block, computed by the application, in the block store, so that
+// the RPC endpoints proving shares do not need the application to recompute
+// the extended data square on every request. The blocks come from the event
+// bus, which both consensus and block sync publish them on once executed,
+// hence once saved.
+//
+// Recording is best effort: the proofs that could not be saved, those of the
+// squares larger than maxSquareSize, which would hold the application too long,
+// and those of the blocks saved before the recorder was running, are computed
+// when first requested and saved then.
+type RowProofRecorder struct {
+	service.BaseService
+
+	query         proxy.AppConnQuery
+	blockStore    BlockStore
+	eventBus      *types.EventBus
+	maxSquareSize uint64
+}
+
+// NewRowProofRecorder returns a new recorder saving the row proofs of the
+// squares up to maxSquareSize, computed through query, in blockStore.
+func NewRowProofRecorder(
+	query proxy.AppConnQuery,
+	blockStore BlockStore,
+	eventBus *types.EventBus,
+	maxSquareSize uint64,
+) *RowProofRecorder {
+	r := &RowProofRecorder{
+		query:         query,
+		blockStore:    blockStore,
+		eventBus:      eventBus,
+		maxSquareSize: maxSquareSize,
+	}
+	r.BaseService = *service.NewBaseService(nil, "RowProofRecorder", r)
+	return r
+}
+
+// OnStart implements service.Service by subscribing to the new blocks.
+func (r *RowProofRecorder) OnStart() error {
+	sub, err := r.eventBus.Subscribe(context.Background(), rowProofRecorderSubscriber,
+		types.EventQueryNewBlock, rowProofRecorderCapacity)
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		for {
+			select {
+			case msg := <-sub.Out():
+				r.record(msg.Data().(types.EventDataNewBlock).Block)
+			case <-sub.Cancelled():
+				if sub.Err() != nil {
+					r.Logger.Error("stopped recording the row proofs, they are computed on request from now on",
+						"err", sub.Err())
+				}
+				return
+			case <-r.Quit():
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// OnStop implements service.Service by unsubscribing from the new blocks.
+func (r *RowProofRecorder) OnStop() {
+	if r.eventBus.IsRunning() {
+		_ = r.eventBus.UnsubscribeAll(context.Background(), rowProofRecorderSubscriber)
+	}
+}
+
+func (r *RowProofRecorder) record(block *types.Block) {
+	if block == nil || block.Data.SquareSize == 0 {
+		return
+	}
+	height := block.Height
+	if block.Data.SquareSize > r.maxSquareSize {
+		r.Logger.Debug("not proving the rows of the data square, left to the first request",
+			"height", height, "square_size", block.Data.SquareSize, "max", r.maxSquareSize)
+		return
+	}
+	pbb, err := block.ToProto()
+	if err != nil {
+		r.Logger.Error("failed to encode block", "height", height, "err", err)
+		return
+	}
+	rawBlock, err := pbb.Marshal()
+	if err != nil {
+		r.Logger.Error("failed to encode block", "height", height, "err", err)
+		return
+	}
+
+	// the application may not support proving shares, so failing to get the
+	// proof is not an error
+	rowProof, err := ProveSquareRows(r.query, rawBlock)
+	if err != nil {
+		r.Logger.Debug("failed to prove the rows of the data square", "height", height, "err", err)
+		return
+	}
+	if err := r.blockStore.SaveRowProof(height, rowProof); err != nil {
+		r.Logger.Error("failed to save the proof of the rows of the data square", "height", height, "err", err)
+		return
+	}
+	r.Logger.Debug("saved the proof of the rows of the data square", "height", height)
+}
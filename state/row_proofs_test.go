@@ -0,0 +1,77 @@
+package state_test
+
+import (
+	"testing"
+	"time"
+
+	dbm "github.com/cometbft/cometbft-db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/merkle"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/proxy/mocks"
+	sm "github.com/tendermint/tendermint/state"
+	"github.com/tendermint/tendermint/store"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestRowProofRecorder(t *testing.T) {
+	rowRoots := [][]byte{[]byte("row 0"), []byte("row 1")}
+	dataHash, proofs := merkle.ProofsFromByteSlices(rowRoots)
+	rowProof := types.RowProof{Proofs: proofs, EndRow: 1}
+	for _, rowRoot := range rowRoots {
+		rowProof.RowRoots = append(rowProof.RowRoots, rowRoot)
+	}
+	pbsp := types.ShareProof{RowProof: rowProof}.ToProto()
+	bz, err := pbsp.Marshal()
+	require.NoError(t, err)
+	query := &mocks.AppConnQuery{}
+	query.On("QuerySync", mock.Anything).Return(&abci.ResponseQuery{Value: bz}, nil)
+
+	eventBus := types.NewEventBus()
+	require.NoError(t, eventBus.Start())
+	t.Cleanup(func() {
+		if err := eventBus.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+	blockStore := store.NewBlockStore(dbm.NewMemDB())
+	recorder := sm.NewRowProofRecorder(query, blockStore, eventBus, 2)
+	recorder.SetLogger(log.TestingLogger())
+	require.NoError(t, recorder.Start())
+	t.Cleanup(func() {
+		if err := recorder.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	// the blocks are saved before their new block event is published, the
+	// first one without data square, the last one with a square too large
+	for h, squareSize := range []uint64{0, 2, 4} {
+		block := types.MakeBlock(int64(h+1), types.Data{SquareSize: squareSize}, new(types.Commit), nil)
+		block.ProposerAddress = make([]byte, 20)
+		block.DataHash = dataHash
+		blockStore.SaveBlock(block, block.MakePartSet(types.BlockPartSizeBytes), &types.Commit{Height: block.Height})
+		require.NoError(t, eventBus.PublishEventNewBlock(types.EventDataNewBlock{Block: block}))
+	}
+
+	require.Eventually(t, func() bool {
+		saved, err := blockStore.LoadRowProof(2)
+		return err == nil && saved != nil
+	}, time.Second, 10*time.Millisecond)
+	saved, err := blockStore.LoadRowProof(2)
+	require.NoError(t, err)
+	assert.Equal(t, rowProof, *saved)
+
+	// the blocks without data square, or with a square too large, are not
+	// proven
+	for _, height := range []int64{1, 3} {
+		saved, err = blockStore.LoadRowProof(height)
+		require.NoError(t, err)
+		assert.Nil(t, saved)
+	}
+	query.AssertNumberOfCalls(t, "QuerySync", 1)
+}
@@ -24,11 +24,14 @@ type BlockStore interface {
 	LoadBaseMeta() *types.BlockMeta
 	LoadBlockMeta(height int64) *types.BlockMeta
 	LoadBlock(height int64) *types.Block
+	HasBlockData(height int64) bool
 
 	SaveBlock(block *types.Block, blockParts *types.PartSet, seenCommit *types.Commit)
 	SaveTxInfo(block *types.Block, txResponseCode []uint32) error
 
 	PruneBlocks(height int64) (uint64, error)
+	RetainHeight() int64
+	SaveRetainHeight(height int64)
 
 	LoadBlockByHash(hash []byte) *types.Block
 	LoadBlockMetaByHash(hash []byte) *types.BlockMeta
@@ -38,6 +41,12 @@ type BlockStore interface {
 	LoadSeenCommit(height int64) *types.Commit
 
 	LoadTxInfo(hash []byte) *cmtstore.TxInfo
+
+	// SaveRowProof persists the proof of the row roots of the data square of
+	// the block at the given height to its data hash, and LoadRowProof loads
+	// it, or returns nil if it was not saved.
+	SaveRowProof(height int64, rowProof types.RowProof) error
+	LoadRowProof(height int64) (*types.RowProof, error)
 }
 
 //-----------------------------------------------------------------------------
@@ -9,6 +9,7 @@ import (
 
 	"github.com/gogo/protobuf/proto"
 
+	abci "github.com/tendermint/tendermint/abci/types"
 	cmtstate "github.com/tendermint/tendermint/proto/tendermint/state"
 	cmtproto "github.com/tendermint/tendermint/proto/tendermint/types"
 	cmtversion "github.com/tendermint/tendermint/proto/tendermint/version"
@@ -77,6 +78,11 @@ type State struct {
 
 	// the latest AppHash we've received from calling abci.Commit()
 	AppHash []byte
+
+	// Evidence constraints declared by the app in InitChain, or reported in
+	// Info to the nodes joining by state sync. The evidence params updates must
+	// meet them. Nil if the app declared none.
+	EvidenceConstraints *abci.EvidenceConstraints
 }
 
 // Copy makes a copy of the State for mutating.
@@ -101,6 +107,8 @@ func (state State) Copy() State {
 		AppHash: state.AppHash,
 
 		LastResultsHash: state.LastResultsHash,
+
+		EvidenceConstraints: state.EvidenceConstraints,
 	}
 }
 
@@ -169,6 +177,7 @@ func (state *State) ToProto() (*cmtstate.State, error) {
 	sm.LastHeightConsensusParamsChanged = state.LastHeightConsensusParamsChanged
 	sm.LastResultsHash = state.LastResultsHash
 	sm.AppHash = state.AppHash
+	sm.EvidenceConstraints = state.EvidenceConstraints
 
 	return sm, nil
 }
@@ -220,6 +229,7 @@ func FromProto(pb *cmtstate.State) (*State, error) { //nolint:golint
 	state.LastHeightConsensusParamsChanged = pb.LastHeightConsensusParamsChanged
 	state.LastResultsHash = pb.LastResultsHash
 	state.AppHash = pb.AppHash
+	state.EvidenceConstraints = pb.EvidenceConstraints
 
 	return state, nil
 }
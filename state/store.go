@@ -1,8 +1,10 @@
 package state
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
+	"strconv"
 
 	dbm "github.com/cometbft/cometbft-db"
 	"github.com/gogo/protobuf/proto"
@@ -37,12 +39,39 @@ func calcABCIResponsesKey(height int64) []byte {
 	return []byte(fmt.Sprintf("abciResponsesKey:%v", height))
 }
 
+func calcProposerRecordKey(height int64) []byte {
+	return []byte(fmt.Sprintf("proposerRecordKey:%v", height))
+}
+
 //----------------------
 
 var (
 	lastABCIResponseKey = []byte("lastABCIResponseKey")
+	finalizedHeightKey  = []byte("finalizedHeightKey")
 )
 
+// StoreKeyKind returns the kind of a key of the state store, labeling the
+// metrics of the store.InstrumentedDB wrapping its database.
+func StoreKeyKind(key []byte) string {
+	switch {
+	case bytes.HasPrefix(key, []byte("validatorsKey:")):
+		return "validators"
+	case bytes.HasPrefix(key, []byte("consensusParamsKey:")):
+		return "consensus_params"
+	case bytes.HasPrefix(key, []byte("abciResponsesKey:")):
+		return "abci_responses"
+	case bytes.HasPrefix(key, []byte("proposerRecordKey:")):
+		return "proposer_records"
+	case bytes.Equal(key, lastABCIResponseKey):
+		return "last_abci_responses"
+	case bytes.Equal(key, stateKey):
+		return "state"
+	case bytes.Equal(key, finalizedHeightKey):
+		return "finalized_height"
+	}
+	return "other"
+}
+
 //go:generate ../scripts/mockery_generate.sh Store
 
 // Store defines the state store interface
@@ -58,7 +87,8 @@ type Store interface {
 	LoadFromDBOrGenesisDoc(*types.GenesisDoc) (State, error)
 	// Load loads the current state of the blockchain
 	Load() (State, error)
-	// LoadValidators loads the validator set at a given height
+	// LoadValidators loads the validator set at a given height. It returns a
+	// new set on every call, which the caller may mutate.
 	LoadValidators(int64) (*types.ValidatorSet, error)
 	// LoadABCIResponses loads the abciResponse for a given height
 	LoadABCIResponses(int64) (*cmtstate.ABCIResponses, error)
@@ -70,6 +100,18 @@ type Store interface {
 	Save(State) error
 	// SaveABCIResponses saves ABCIResponses for a given height
 	SaveABCIResponses(int64, *cmtstate.ABCIResponses) error
+	// LoadFinalizedHeight loads the height of the last Finalized event
+	// published, 0 if none was
+	LoadFinalizedHeight() (int64, error)
+	// SaveFinalized overwrites the previous state with the updated one, and
+	// saves its last block height as that of the Finalized event about to be
+	// published
+	SaveFinalized(State) error
+	// LoadProposerRecord loads the proposers of the rounds of a given height
+	// recorded by consensus, nil if none were
+	LoadProposerRecord(int64) (*cmtstate.ProposerRecord, error)
+	// SaveProposerRecord saves the proposers of the rounds of a given height
+	SaveProposerRecord(int64, *cmtstate.ProposerRecord) error
 	// Bootstrap is used for bootstrapping state when not starting from a initial height.
 	Bootstrap(State) error
 	// PruneStates takes the height from which to start prning and which height stop at
@@ -172,10 +214,17 @@ func (store dbStore) loadState(key []byte) (state State, err error) {
 // Save persists the State, the ValidatorsInfo, and the ConsensusParamsInfo to the database.
 // This flushes the writes (e.g. calls SetSync).
 func (store dbStore) Save(state State) error {
-	return store.save(state, stateKey)
+	return store.save(state, stateKey, false)
+}
+
+// SaveFinalized overwrites the previous state with the updated one and, in the
+// same batch, saves its last block height as that of the Finalized event about
+// to be published, so that it is not published again after a restart.
+func (store dbStore) SaveFinalized(state State) error {
+	return store.save(state, stateKey, true)
 }
 
-func (store dbStore) save(state State, key []byte) error {
+func (store dbStore) save(state State, key []byte, finalized bool) error {
 	nextHeight := state.LastBlockHeight + 1
 	// If first block, save validators for the block.
 	if nextHeight == 1 {
@@ -196,11 +245,19 @@ func (store dbStore) save(state State, key []byte) error {
 		state.LastHeightConsensusParamsChanged, state.ConsensusParams); err != nil {
 		return err
 	}
-	err := store.db.SetSync(key, state.Bytes())
-	if err != nil {
+	if !finalized {
+		return store.db.SetSync(key, state.Bytes())
+	}
+	batch := store.db.NewBatch()
+	defer batch.Close()
+	if err := batch.Set(key, state.Bytes()); err != nil {
 		return err
 	}
-	return nil
+	height := strconv.FormatInt(state.LastBlockHeight, 10)
+	if err := batch.Set(finalizedHeightKey, []byte(height)); err != nil {
+		return err
+	}
+	return batch.WriteSync()
 }
 
 // BootstrapState saves a new state, used e.g. by state sync when starting from non-zero height.
@@ -342,6 +399,11 @@ func (store dbStore) PruneStates(from int64, to int64) error {
 		if err != nil {
 			return err
 		}
+
+		err = batch.Delete(calcProposerRecordKey(h))
+		if err != nil {
+			return err
+		}
 		pruned++
 
 		// avoid batches growing too large by flushing to database regularly
@@ -658,6 +720,54 @@ func (store dbStore) saveConsensusParamsInfo(nextHeight, changeHeight int64, par
 	return nil
 }
 
+//-----------------------------------------------------------------------------
+
+// LoadFinalizedHeight loads the height of the last Finalized event published,
+// or 0 if none was, e.g. because the node predates the event.
+func (store dbStore) LoadFinalizedHeight() (int64, error) {
+	buf, err := store.db.Get(finalizedHeightKey)
+	if err != nil {
+		return 0, err
+	}
+	if len(buf) == 0 {
+		return 0, nil
+	}
+	height, err := strconv.ParseInt(string(buf), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid finalized height %q: %w", buf, err)
+	}
+	return height, nil
+}
+
+// LoadProposerRecord loads the proposers of the rounds of the given height
+// recorded by consensus, or nil if none were, e.g. because the height was
+// synced rather than taken part in.
+func (store dbStore) LoadProposerRecord(height int64) (*cmtstate.ProposerRecord, error) {
+	buf, err := store.db.Get(calcProposerRecordKey(height))
+	if err != nil {
+		return nil, err
+	}
+	if len(buf) == 0 {
+		return nil, nil
+	}
+
+	record := new(cmtstate.ProposerRecord)
+	if err := record.Unmarshal(buf); err != nil {
+		return nil, fmt.Errorf("unmarshaling proposer record: %w", err)
+	}
+	return record, nil
+}
+
+// SaveProposerRecord persists the proposers of the rounds of the given height
+// recorded by consensus.
+func (store dbStore) SaveProposerRecord(height int64, record *cmtstate.ProposerRecord) error {
+	bz, err := record.Marshal()
+	if err != nil {
+		return err
+	}
+	return store.db.Set(calcProposerRecordKey(height), bz)
+}
+
 func (store dbStore) Close() error {
 	return store.db.Close()
 }
@@ -206,6 +206,58 @@ func TestPruneStates(t *testing.T) {
 	}
 }
 
+func TestProposerRecord(t *testing.T) {
+	stateStore := sm.NewStore(dbm.NewMemDB(), sm.StoreOptions{})
+	pk := ed25519.GenPrivKey().PubKey()
+	validator := &types.Validator{Address: pk.Address(), VotingPower: 100, PubKey: pk}
+	validatorSet := &types.ValidatorSet{Validators: []*types.Validator{validator}, Proposer: validator}
+
+	record, err := stateStore.LoadProposerRecord(1)
+	require.NoError(t, err)
+	assert.Nil(t, record)
+
+	for h := int64(1); h <= 10; h++ {
+		err := stateStore.Save(sm.State{
+			InitialHeight:   1,
+			LastBlockHeight: h - 1,
+			Validators:      validatorSet,
+			NextValidators:  validatorSet,
+			LastValidators:  validatorSet,
+			ConsensusParams: cmtproto.ConsensusParams{
+				Block: cmtproto.BlockParams{MaxBytes: 10e6},
+			},
+			LastHeightValidatorsChanged:      1,
+			LastHeightConsensusParamsChanged: 1,
+		})
+		require.NoError(t, err)
+		err = stateStore.SaveProposerRecord(h, &cmtstate.ProposerRecord{Rounds: []cmtstate.RoundProposer{
+			{Round: 0, ProposerAddress: validator.Address},
+			{Round: 1, ProposerAddress: validator.Address, ProposalObserved: true},
+		}})
+		require.NoError(t, err)
+	}
+
+	record, err = stateStore.LoadProposerRecord(5)
+	require.NoError(t, err)
+	require.Len(t, record.Rounds, 2)
+	assert.False(t, record.Rounds[0].ProposalObserved)
+	assert.True(t, record.Rounds[1].ProposalObserved)
+	assert.EqualValues(t, 1, record.Rounds[1].Round)
+	assert.Equal(t, validator.Address.Bytes(), record.Rounds[1].ProposerAddress)
+
+	// the records are pruned with the states
+	require.NoError(t, stateStore.PruneStates(1, 8))
+	for h := int64(1); h <= 10; h++ {
+		record, err := stateStore.LoadProposerRecord(h)
+		require.NoError(t, err)
+		if h < 8 {
+			assert.Nil(t, record, "height %v", h)
+		} else {
+			assert.NotNil(t, record, "height %v", h)
+		}
+	}
+}
+
 func TestABCIResponsesResultsHash(t *testing.T) {
 	responses := &cmtstate.ABCIResponses{
 		BeginBlock: &abci.ResponseBeginBlock{},
@@ -1,11 +1,13 @@
 package txindex
 
 import (
+	"bytes"
 	"context"
 	"errors"
 
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/libs/pubsub/query"
+	"github.com/tendermint/tendermint/types"
 )
 
 // XXX/TODO: These types should be moved to the indexer package.
@@ -28,10 +30,30 @@ type TxIndexer interface {
 	Search(ctx context.Context, q *query.Query) ([]*abci.TxResult, error)
 }
 
+// TxUnwrapper extracts the original transaction, as signed and broadcast by the
+// user, from a transaction committed in a block. It returns false if tx is not
+// wrapped.
+type TxUnwrapper func(tx types.Tx) (types.Tx, bool)
+
+// DefaultTxUnwrapper unwraps IndexWrapper and BlobTx transactions.
+func DefaultTxUnwrapper(tx types.Tx) (types.Tx, bool) {
+	if indexWrapper, ok := types.UnmarshalIndexWrapper(tx); ok {
+		return indexWrapper.Tx, true
+	}
+	if blobTx, ok := types.UnmarshalBlobTx(tx); ok {
+		return blobTx.Tx, true
+	}
+	return nil, false
+}
+
 // Batch groups together multiple Index operations to be performed at the same time.
 // NOTE: Batch is NOT thread-safe and must not be modified after starting its execution.
 type Batch struct {
 	Ops []*abci.TxResult
+
+	// Aliases maps alternative hashes of a transaction (e.g. the hash of the
+	// unwrapped tx) to the hash the transaction is indexed under.
+	Aliases map[string][]byte
 }
 
 // NewBatch creates a new Batch.
@@ -47,6 +69,18 @@ func (b *Batch) Add(result *abci.TxResult) error {
 	return nil
 }
 
+// AddAlias records that the transaction indexed under hash can also be looked
+// up by alias. It is a no-op if alias and hash are equal.
+func (b *Batch) AddAlias(alias, hash []byte) {
+	if bytes.Equal(alias, hash) {
+		return
+	}
+	if b.Aliases == nil {
+		b.Aliases = make(map[string][]byte)
+	}
+	b.Aliases[string(alias)] = hash
+}
+
 // Size returns the total number of operations inside the batch.
 func (b *Batch) Size() int {
 	return len(b.Ops)
@@ -2,8 +2,17 @@ package txindex
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strconv"
 
+	dbm "github.com/cometbft/cometbft-db"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/tmhash"
 	"github.com/tendermint/tendermint/libs/service"
+	cmtsync "github.com/tendermint/tendermint/libs/sync"
+	cmtstate "github.com/tendermint/tendermint/proto/tendermint/state"
 	"github.com/tendermint/tendermint/state/indexer"
 	"github.com/tendermint/tendermint/types"
 )
@@ -12,8 +21,28 @@ import (
 
 const (
 	subscriber = "IndexerService"
+
+	// recoveryBatchHeights is the maximum number of heights written in a
+	// single batch when recovering heights that were not indexed.
+	recoveryBatchHeights = 64
 )
 
+// indexedHeightKey is the key under which the latest indexed height is
+// persisted when indexing asynchronously.
+var indexedHeightKey = []byte("indexerServiceIndexedHeight")
+
+// BlockStore is the part of the block store needed to recover heights that
+// were committed but not indexed.
+type BlockStore interface {
+	LoadBlock(height int64) *types.Block
+}
+
+// ABCIResponsesStore is the part of the state store needed to recover heights
+// that were committed but not indexed.
+type ABCIResponsesStore interface {
+	LoadABCIResponses(height int64) (*cmtstate.ABCIResponses, error)
+}
+
 // IndexerService connects event bus, transaction and block indexers together in
 // order to index transactions and blocks coming from the event bus.
 type IndexerService struct {
@@ -23,6 +52,28 @@ type IndexerService struct {
 	blockIdxr        indexer.BlockIndexer
 	eventBus         *types.EventBus
 	terminateOnError bool
+	txUnwrapper      TxUnwrapper
+	metrics          *Metrics
+
+	// set when indexing asynchronously, see EnableAsync
+	async         bool
+	heightDB      dbm.DB
+	lastHeight    int64
+	blockStore    BlockStore
+	abciResponses ABCIResponsesStore
+
+	mtx            cmtsync.Mutex
+	pending        []heightBatch // heights waiting to be indexed asynchronously
+	pendingCh      chan struct{}
+	receivedHeight int64
+	indexedHeight  int64
+	indexedCh      chan struct{} // closed and replaced when indexedHeight advances
+}
+
+// heightBatch holds the events of a single height.
+type heightBatch struct {
+	header types.EventDataNewBlockHeader
+	batch  *Batch
 }
 
 // NewIndexerService returns a new service instance.
@@ -33,11 +84,63 @@ func NewIndexerService(
 	terminateOnError bool,
 ) *IndexerService {
 
-	is := &IndexerService{txIdxr: txIdxr, blockIdxr: blockIdxr, eventBus: eventBus, terminateOnError: terminateOnError}
+	is := &IndexerService{
+		txIdxr:           txIdxr,
+		blockIdxr:        blockIdxr,
+		eventBus:         eventBus,
+		terminateOnError: terminateOnError,
+		metrics:          NopMetrics(),
+		pendingCh:        make(chan struct{}, 1),
+		indexedCh:        make(chan struct{}),
+	}
 	is.BaseService = *service.NewBaseService(nil, "IndexerService", is)
 	return is
 }
 
+// SetTxUnwrapper registers a function used to unwrap committed transactions
+// while indexing. When set, every wrapped transaction can additionally be
+// looked up by the hash of the original (unwrapped) transaction and by the hash
+// of the committed bytes. Must be called before the service is started.
+func (is *IndexerService) SetTxUnwrapper(u TxUnwrapper) {
+	is.txUnwrapper = u
+}
+
+// TxUnwrapper returns the function unwrapping committed transactions, nil if
+// none is registered.
+func (is *IndexerService) TxUnwrapper() TxUnwrapper {
+	return is.txUnwrapper
+}
+
+// SetMetrics sets the metrics reported by the service. Must be called before
+// the service is started.
+func (is *IndexerService) SetMetrics(metrics *Metrics) {
+	is.metrics = metrics
+}
+
+// EnableAsync makes the service index asynchronously: events received from the
+// event bus are queued and written in batches by a background routine, so that
+// indexing does not delay the processing of the next block. Use WaitForHeight
+// to wait for a height to be indexed.
+//
+// The latest indexed height is persisted in db. On start, heights up to
+// lastHeight, the height of the latest committed block, that were not indexed
+// before, e.g. because of a crash, are re-indexed from the block store and the
+// stored ABCI responses. If no height was persisted yet, all heights up to
+// lastHeight are assumed to have been indexed. Must be called before the
+// service is started.
+func (is *IndexerService) EnableAsync(
+	db dbm.DB,
+	lastHeight int64,
+	blockStore BlockStore,
+	abciResponses ABCIResponsesStore,
+) {
+	is.async = true
+	is.heightDB = db
+	is.lastHeight = lastHeight
+	is.blockStore = blockStore
+	is.abciResponses = abciResponses
+}
+
 // OnStart implements service.Service by subscribing for all transactions
 // and indexing them by events.
 func (is *IndexerService) OnStart() error {
@@ -57,6 +160,28 @@ func (is *IndexerService) OnStart() error {
 		return err
 	}
 
+	if is.async {
+		recoverFrom, err := is.loadIndexedHeight()
+		if err != nil {
+			return err
+		}
+		recoverTo := is.lastHeight
+		if recoverFrom == 0 {
+			// nothing was indexed asynchronously yet, so the committed heights
+			// have been indexed synchronously
+			recoverFrom = recoverTo
+			if err := is.saveIndexedHeight(recoverTo); err != nil {
+				return err
+			}
+		}
+		is.indexedHeight = recoverFrom
+		is.receivedHeight = recoverTo
+		is.metrics.IndexedHeight.Set(float64(recoverFrom))
+		is.metrics.IndexingLag.Set(float64(recoverTo - recoverFrom))
+
+		go is.indexRoutine(recoverFrom+1, recoverTo)
+	}
+
 	go func() {
 		for {
 			msg := <-blockHeadersSub.Out()
@@ -77,42 +202,295 @@ func (is *IndexerService) OnStart() error {
 					)
 
 					if is.terminateOnError {
-						if err := is.Stop(); err != nil {
-							is.Logger.Error("failed to stop", "err", err)
-						}
+						is.terminate()
 						return
 					}
 				}
+				is.addAliases(batch, types.Tx(txResult.Tx))
 			}
 
-			if err := is.blockIdxr.Index(eventDataHeader); err != nil {
-				is.Logger.Error("failed to index block", "height", height, "err", err)
-				if is.terminateOnError {
-					if err := is.Stop(); err != nil {
-						is.Logger.Error("failed to stop", "err", err)
-					}
-					return
-				}
-			} else {
-				is.Logger.Info("indexed block exents", "height", height)
+			hb := heightBatch{header: eventDataHeader, batch: batch}
+			if is.async {
+				is.enqueue(hb)
+				continue
 			}
 
-			if err = is.txIdxr.AddBatch(batch); err != nil {
-				is.Logger.Error("failed to index block txs", "height", height, "err", err)
-				if is.terminateOnError {
-					if err := is.Stop(); err != nil {
-						is.Logger.Error("failed to stop", "err", err)
-					}
-					return
-				}
-			} else {
-				is.Logger.Debug("indexed transactions", "height", height, "num_txs", eventDataHeader.NumTxs)
+			is.mtx.Lock()
+			is.receivedHeight = height
+			is.mtx.Unlock()
+			if err := is.index([]heightBatch{hb}); err != nil && is.terminateOnError {
+				is.terminate()
+				return
 			}
 		}
 	}()
 	return nil
 }
 
+// WaitForHeight blocks until the events of the given height have been indexed
+// or the context is done. Unless asynchronous indexing is enabled, it returns
+// immediately since the events of a height are indexed before the events of
+// the next height are received.
+func (is *IndexerService) WaitForHeight(ctx context.Context, height int64) error {
+	if !is.async {
+		return nil
+	}
+	for {
+		is.mtx.Lock()
+		indexedHeight, indexedCh := is.indexedHeight, is.indexedCh
+		is.mtx.Unlock()
+		if indexedHeight >= height {
+			return nil
+		}
+
+		select {
+		case <-indexedCh:
+		case <-ctx.Done():
+			return fmt.Errorf("waiting for height %d to be indexed: %w", height, ctx.Err())
+		case <-is.Quit():
+			return errors.New("indexer service stopped")
+		}
+	}
+}
+
+// enqueue queues the events of a height to be indexed by indexRoutine.
+func (is *IndexerService) enqueue(hb heightBatch) {
+	is.mtx.Lock()
+	is.pending = append(is.pending, hb)
+	is.receivedHeight = hb.header.Header.Height
+	lag := is.receivedHeight - is.indexedHeight
+	is.mtx.Unlock()
+	is.metrics.IndexingLag.Set(float64(lag))
+
+	select {
+	case is.pendingCh <- struct{}{}:
+	default:
+	}
+}
+
+// indexRoutine recovers the heights in [recoverFrom, recoverTo] and then
+// indexes the queued heights, writing all heights queued since the previous
+// write in a single batch.
+func (is *IndexerService) indexRoutine(recoverFrom, recoverTo int64) {
+	if err := is.recoverHeights(recoverFrom, recoverTo); err != nil && is.terminateOnError {
+		is.terminate()
+		return
+	}
+
+	for {
+		select {
+		case <-is.Quit():
+			return
+		case <-is.pendingCh:
+		}
+
+		is.mtx.Lock()
+		hbs := is.pending
+		is.pending = nil
+		is.mtx.Unlock()
+		if len(hbs) == 0 {
+			continue
+		}
+
+		if err := is.index(hbs); err != nil && is.terminateOnError {
+			is.terminate()
+			return
+		}
+	}
+}
+
+// recoverHeights indexes the heights in [from, to] from the block store and the
+// stored ABCI responses.
+func (is *IndexerService) recoverHeights(from, to int64) error {
+	if from > to {
+		return nil
+	}
+	is.Logger.Info("indexing heights missed before the last shutdown", "from", from, "to", to)
+
+	hbs := make([]heightBatch, 0, recoveryBatchHeights)
+	for height := from; height <= to; height++ {
+		select {
+		case <-is.Quit():
+			return nil
+		default:
+		}
+
+		hb, err := is.loadHeightBatch(height)
+		if err != nil {
+			// The height cannot be recovered, e.g. because ABCI responses are
+			// discarded. Skip the remaining heights so that the service does not
+			// wait for them, they can be indexed with the reindex-event command.
+			is.Logger.Error("failed to recover heights, use reindex-event to index them",
+				"from", height, "to", to, "err", err)
+			if len(hbs) > 0 {
+				if err := is.index(hbs); err != nil {
+					return err
+				}
+			}
+			is.setIndexedHeight(to)
+			return err
+		}
+
+		hbs = append(hbs, hb)
+		if len(hbs) == recoveryBatchHeights || height == to {
+			if err := is.index(hbs); err != nil {
+				return err
+			}
+			hbs = hbs[:0]
+		}
+	}
+	return nil
+}
+
+// loadHeightBatch rebuilds the events of a committed height from the block
+// store and the stored ABCI responses.
+func (is *IndexerService) loadHeightBatch(height int64) (heightBatch, error) {
+	block := is.blockStore.LoadBlock(height)
+	if block == nil {
+		return heightBatch{}, fmt.Errorf("not able to load block at height %d from the blockstore", height)
+	}
+	r, err := is.abciResponses.LoadABCIResponses(height)
+	if err != nil {
+		return heightBatch{}, fmt.Errorf("not able to load ABCI responses at height %d: %w", height, err)
+	}
+	if len(r.DeliverTxs) != len(block.Data.Txs) {
+		return heightBatch{}, fmt.Errorf("ABCI responses at height %d have %d results for %d txs",
+			height, len(r.DeliverTxs), len(block.Data.Txs))
+	}
+
+	header := types.EventDataNewBlockHeader{
+		Header: block.Header,
+		NumTxs: int64(len(block.Data.Txs)),
+	}
+	if r.BeginBlock != nil {
+		header.ResultBeginBlock = *r.BeginBlock
+	}
+	if r.EndBlock != nil {
+		header.ResultEndBlock = *r.EndBlock
+	}
+
+	batch := NewBatch(header.NumTxs)
+	for i, tx := range block.Data.Txs {
+		txResult := abci.TxResult{
+			Height: height,
+			Index:  uint32(i),
+			Tx:     tx,
+			Result: *r.DeliverTxs[i],
+		}
+		if err := batch.Add(&txResult); err != nil {
+			return heightBatch{}, err
+		}
+		is.addAliases(batch, tx)
+	}
+	return heightBatch{header: header, batch: batch}, nil
+}
+
+// index writes the block and tx events of the given heights, which must be in
+// increasing order, and advances the indexed height. The tx events of all
+// heights are written in a single batch.
+func (is *IndexerService) index(hbs []heightBatch) error {
+	numTxs := 0
+	for _, hb := range hbs {
+		numTxs += hb.batch.Size()
+	}
+	batch := &Batch{Ops: make([]*abci.TxResult, 0, numTxs)}
+
+	for _, hb := range hbs {
+		height := hb.header.Header.Height
+		if err := is.blockIdxr.Index(hb.header); err != nil {
+			is.Logger.Error("failed to index block", "height", height, "err", err)
+			if is.terminateOnError {
+				return err
+			}
+		} else {
+			is.Logger.Info("indexed block exents", "height", height)
+		}
+
+		batch.Ops = append(batch.Ops, hb.batch.Ops...)
+		for alias, hash := range hb.batch.Aliases {
+			batch.AddAlias([]byte(alias), hash)
+		}
+	}
+
+	first, last := hbs[0].header.Header.Height, hbs[len(hbs)-1].header.Header.Height
+	if err := is.txIdxr.AddBatch(batch); err != nil {
+		is.Logger.Error("failed to index block txs", "from", first, "to", last, "err", err)
+		if is.terminateOnError {
+			return err
+		}
+	} else {
+		is.Logger.Debug("indexed transactions", "from", first, "to", last, "num_txs", numTxs)
+	}
+
+	is.metrics.BatchHeights.Observe(float64(len(hbs)))
+	is.setIndexedHeight(last)
+	return nil
+}
+
+// setIndexedHeight advances the indexed height, persisting it when indexing
+// asynchronously, and wakes up routines waiting for it.
+func (is *IndexerService) setIndexedHeight(height int64) {
+	is.mtx.Lock()
+	defer is.mtx.Unlock()
+	if height <= is.indexedHeight {
+		return
+	}
+
+	if is.async {
+		if err := is.saveIndexedHeight(height); err != nil {
+			is.Logger.Error("failed to persist indexed height", "height", height, "err", err)
+		}
+	}
+	is.indexedHeight = height
+	close(is.indexedCh)
+	is.indexedCh = make(chan struct{})
+
+	is.metrics.IndexedHeight.Set(float64(height))
+	lag := is.receivedHeight - height
+	if lag < 0 {
+		lag = 0
+	}
+	is.metrics.IndexingLag.Set(float64(lag))
+}
+
+func (is *IndexerService) loadIndexedHeight() (int64, error) {
+	bz, err := is.heightDB.Get(indexedHeightKey)
+	if err != nil || len(bz) == 0 {
+		return 0, err
+	}
+	height, err := strconv.ParseInt(string(bz), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid indexed height %q: %w", bz, err)
+	}
+	return height, nil
+}
+
+func (is *IndexerService) saveIndexedHeight(height int64) error {
+	return is.heightDB.Set(indexedHeightKey, []byte(strconv.FormatInt(height, 10)))
+}
+
+// terminate stops the service after an indexing error.
+func (is *IndexerService) terminate() {
+	if err := is.Stop(); err != nil {
+		is.Logger.Error("failed to stop", "err", err)
+	}
+}
+
+// addAliases maps the hash of the original transaction and the hash of the
+// committed bytes to the hash tx is indexed under.
+func (is *IndexerService) addAliases(batch *Batch, tx types.Tx) {
+	if is.txUnwrapper == nil {
+		return
+	}
+	original, ok := is.txUnwrapper(tx)
+	if !ok {
+		return
+	}
+	hash := tx.Hash()
+	batch.AddAlias(tmhash.Sum(original), hash)
+	batch.AddAlias(tmhash.Sum(tx), hash)
+}
+
 // OnStop implements service.Service by unsubscribing from all transactions.
 func (is *IndexerService) OnStop() {
 	if is.eventBus.IsRunning() {
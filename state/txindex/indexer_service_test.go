@@ -1,6 +1,9 @@
 package txindex_test
 
 import (
+	"bytes"
+	"context"
+	"fmt"
 	"testing"
 	"time"
 
@@ -8,7 +11,9 @@ import (
 	"github.com/stretchr/testify/require"
 
 	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/tmhash"
 	"github.com/tendermint/tendermint/libs/log"
+	cmtstate "github.com/tendermint/tendermint/proto/tendermint/state"
 	blockidxkv "github.com/tendermint/tendermint/state/indexer/block/kv"
 	"github.com/tendermint/tendermint/state/txindex"
 	"github.com/tendermint/tendermint/state/txindex/kv"
@@ -79,3 +84,246 @@ func TestIndexerServiceIndexesBlocks(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, txResult2, res)
 }
+
+func TestIndexerServiceIndexesAliases(t *testing.T) {
+	eventBus := types.NewEventBus()
+	eventBus.SetLogger(log.TestingLogger())
+	err := eventBus.Start()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := eventBus.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	store := db.NewMemDB()
+	txIndexer := kv.NewTxIndex(store)
+	blockIndexer := blockidxkv.New(db.NewPrefixDB(store, []byte("block_events")))
+
+	// wrap the committed tx with a custom prefix that the default unwrapping
+	// of types.Tx.Hash does not know about.
+	prefix := []byte("wrapped:")
+	service := txindex.NewIndexerService(txIndexer, blockIndexer, eventBus, false)
+	service.SetTxUnwrapper(func(tx types.Tx) (types.Tx, bool) {
+		if !bytes.HasPrefix(tx, prefix) {
+			return nil, false
+		}
+		return tx[len(prefix):], true
+	})
+	service.SetLogger(log.TestingLogger())
+	err = service.Start()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := service.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	original := types.Tx("foo")
+	wrapped := types.Tx(append(prefix, original...))
+	indexWrapped, err := types.MarshalIndexWrapper(types.Tx("bar"), 1)
+	require.NoError(t, err)
+
+	err = eventBus.PublishEventNewBlockHeader(types.EventDataNewBlockHeader{
+		Header: types.Header{Height: 1},
+		NumTxs: int64(2),
+	})
+	require.NoError(t, err)
+	txResult1 := &abci.TxResult{
+		Height: 1,
+		Index:  uint32(0),
+		Tx:     wrapped,
+		Result: abci.ResponseDeliverTx{Code: 0},
+	}
+	err = eventBus.PublishEventTx(types.EventDataTx{TxResult: *txResult1})
+	require.NoError(t, err)
+	txResult2 := &abci.TxResult{
+		Height: 1,
+		Index:  uint32(1),
+		Tx:     indexWrapped,
+		Result: abci.ResponseDeliverTx{Code: 0},
+	}
+	err = eventBus.PublishEventTx(types.EventDataTx{TxResult: *txResult2})
+	require.NoError(t, err)
+
+	time.Sleep(100 * time.Millisecond)
+
+	// the wrapped tx can be looked up by its committed and original hash
+	res, err := txIndexer.Get(wrapped.Hash())
+	require.NoError(t, err)
+	require.Equal(t, txResult1, res)
+
+	res, err = txIndexer.Get(original.Hash())
+	require.NoError(t, err)
+	require.Equal(t, txResult1, res)
+
+	// index wrappers are always indexed by the unwrapped hash
+	res, err = txIndexer.Get(types.Tx("bar").Hash())
+	require.NoError(t, err)
+	require.Equal(t, txResult2, res)
+
+	// the custom unwrapper does not recognize index wrappers
+	res, err = txIndexer.Get(tmhash.Sum(indexWrapped))
+	require.NoError(t, err)
+	require.Nil(t, res)
+}
+
+func TestIndexerServiceAsyncWaitForHeight(t *testing.T) {
+	eventBus := types.NewEventBus()
+	eventBus.SetLogger(log.TestingLogger())
+	err := eventBus.Start()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := eventBus.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	store := db.NewMemDB()
+	txIndexer := kv.NewTxIndex(store)
+	blockIndexer := blockidxkv.New(db.NewPrefixDB(store, []byte("block_events")))
+
+	service := txindex.NewIndexerService(txIndexer, blockIndexer, eventBus, false)
+	service.SetLogger(log.TestingLogger())
+	service.EnableAsync(store, 0, newMockStores(), newMockStores())
+	err = service.Start()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := service.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	// height 1 has not been received yet
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	err = service.WaitForHeight(ctx, 1)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	txResult := &abci.TxResult{
+		Height: 1,
+		Index:  uint32(0),
+		Tx:     types.Tx("foo"),
+		Result: abci.ResponseDeliverTx{Code: 0},
+	}
+	err = eventBus.PublishEventNewBlockHeader(types.EventDataNewBlockHeader{
+		Header: types.Header{Height: 1},
+		NumTxs: int64(1),
+	})
+	require.NoError(t, err)
+	err = eventBus.PublishEventTx(types.EventDataTx{TxResult: *txResult})
+	require.NoError(t, err)
+
+	// once the barrier is passed, the tx of height 1 must be readable
+	err = service.WaitForHeight(context.Background(), 1)
+	require.NoError(t, err)
+
+	res, err := txIndexer.Get(types.Tx("foo").Hash())
+	require.NoError(t, err)
+	require.Equal(t, txResult, res)
+
+	ok, err := blockIndexer.Has(1)
+	require.NoError(t, err)
+	require.True(t, ok)
+}
+
+func TestIndexerServiceAsyncRecoversMissedHeights(t *testing.T) {
+	eventBus := types.NewEventBus()
+	eventBus.SetLogger(log.TestingLogger())
+	err := eventBus.Start()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := eventBus.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	store := db.NewMemDB()
+	txIndexer := kv.NewTxIndex(store)
+	blockIndexer := blockidxkv.New(db.NewPrefixDB(store, []byte("block_events")))
+	stores := newMockStores()
+
+	// the first run persists height 1 as the latest indexed height
+	service := txindex.NewIndexerService(txIndexer, blockIndexer, eventBus, false)
+	service.SetLogger(log.TestingLogger())
+	service.EnableAsync(store, 1, stores, stores)
+	require.NoError(t, service.Start())
+	require.NoError(t, service.WaitForHeight(context.Background(), 1))
+	require.NoError(t, service.Stop())
+
+	// heights 2 and 3 are committed while the node is down, e.g. because it
+	// crashed before indexing them
+	stores.commit(2, types.Tx("foo"), types.Tx("bar"))
+	stores.commit(3, types.Tx("baz"))
+
+	service = txindex.NewIndexerService(txIndexer, blockIndexer, eventBus, false)
+	service.SetLogger(log.TestingLogger())
+	service.EnableAsync(store, 3, stores, stores)
+	require.NoError(t, service.Start())
+	t.Cleanup(func() {
+		if err := service.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.NoError(t, service.WaitForHeight(ctx, 3))
+
+	for _, height := range []int64{2, 3} {
+		ok, err := blockIndexer.Has(height)
+		require.NoError(t, err)
+		require.True(t, ok, "block %d not indexed", height)
+	}
+
+	res, err := txIndexer.Get(types.Tx("bar").Hash())
+	require.NoError(t, err)
+	require.Equal(t, &abci.TxResult{
+		Height: 2,
+		Index:  uint32(1),
+		Tx:     types.Tx("bar"),
+		Result: abci.ResponseDeliverTx{Code: 0, Data: []byte("bar")},
+	}, res)
+
+	res, err = txIndexer.Get(types.Tx("baz").Hash())
+	require.NoError(t, err)
+	require.NotNil(t, res)
+	require.EqualValues(t, 3, res.Height)
+}
+
+// mockStores implements txindex.BlockStore and txindex.ABCIResponsesStore.
+type mockStores struct {
+	blocks    map[int64]*types.Block
+	responses map[int64]*cmtstate.ABCIResponses
+}
+
+func newMockStores() *mockStores {
+	return &mockStores{
+		blocks:    make(map[int64]*types.Block),
+		responses: make(map[int64]*cmtstate.ABCIResponses),
+	}
+}
+
+func (s *mockStores) commit(height int64, txs ...types.Tx) {
+	responses := &cmtstate.ABCIResponses{}
+	for _, tx := range txs {
+		responses.DeliverTxs = append(responses.DeliverTxs, &abci.ResponseDeliverTx{Code: 0, Data: tx})
+	}
+	s.blocks[height] = &types.Block{
+		Header: types.Header{Height: height},
+		Data:   types.Data{Txs: txs},
+	}
+	s.responses[height] = responses
+}
+
+func (s *mockStores) LoadBlock(height int64) *types.Block {
+	return s.blocks[height]
+}
+
+func (s *mockStores) LoadABCIResponses(height int64) (*cmtstate.ABCIResponses, error) {
+	r, ok := s.responses[height]
+	if !ok {
+		return nil, fmt.Errorf("no ABCI responses at height %d", height)
+	}
+	return r, nil
+}
@@ -22,6 +22,7 @@ import (
 const (
 	tagKeySeparator   = "/"
 	eventSeqSeparator = "$es$"
+	aliasKeyPrefix    = "txalias/"
 )
 
 var _ txindex.TxIndexer = (*TxIndex)(nil)
@@ -31,15 +32,29 @@ type TxIndex struct {
 	store dbm.DB
 	// Number the events in the event list
 	eventSeq int64
+
+	limits  QueryLimits
+	metrics *txindex.Metrics
 }
 
 // NewTxIndex creates new KV indexer.
 func NewTxIndex(store dbm.DB) *TxIndex {
 	return &TxIndex{
-		store: store,
+		store:   store,
+		metrics: txindex.NopMetrics(),
 	}
 }
 
+// SetQueryLimits sets the limits bounding the cost of the searches.
+func (txi *TxIndex) SetQueryLimits(limits QueryLimits) {
+	txi.limits = limits
+}
+
+// SetMetrics sets the metrics reported by the searches.
+func (txi *TxIndex) SetMetrics(metrics *txindex.Metrics) {
+	txi.metrics = metrics
+}
+
 // Get gets transaction from the TxIndex storage and returns it or nil if the
 // transaction is not found.
 func (txi *TxIndex) Get(hash []byte) (*abci.TxResult, error) {
@@ -52,7 +67,7 @@ func (txi *TxIndex) Get(hash []byte) (*abci.TxResult, error) {
 		panic(err)
 	}
 	if rawBytes == nil {
-		return nil, nil
+		return txi.getByAlias(hash)
 	}
 
 	txResult := new(abci.TxResult)
@@ -64,6 +79,19 @@ func (txi *TxIndex) Get(hash []byte) (*abci.TxResult, error) {
 	return txResult, nil
 }
 
+// getByAlias returns the transaction that was indexed with the given alias
+// hash or nil if there is none.
+func (txi *TxIndex) getByAlias(alias []byte) (*abci.TxResult, error) {
+	hash, err := txi.store.Get(keyForAlias(alias))
+	if err != nil {
+		panic(err)
+	}
+	if hash == nil || bytes.Equal(hash, alias) {
+		return nil, nil
+	}
+	return txi.Get(hash)
+}
+
 // AddBatch indexes a batch of transactions using the given list of events. Each
 // key that indexed from the tx's events is a composite of the event type and
 // the respective attribute's key delimited by a "." (eg. "account.number").
@@ -79,6 +107,13 @@ func (txi *TxIndex) AddBatch(b *txindex.Batch) error {
 		}
 	}
 
+	// index aliases of the transaction hashes
+	for alias, hash := range b.Aliases {
+		if err := storeBatch.Set(keyForAlias([]byte(alias)), hash); err != nil {
+			return err
+		}
+	}
+
 	return storeBatch.WriteSync()
 }
 
@@ -214,10 +249,22 @@ func (txi *TxIndex) indexResult(batch dbm.Batch, result *abci.TxResult) error {
 //
 // Search will exit early and return any result fetched so far,
 // when a message is received on the context chan.
+//
+// The query is rejected with ErrQueryTooBroad, or its results truncated,
+// according to the QueryLimits, see SearchBounded.
 func (txi *TxIndex) Search(ctx context.Context, q *query.Query) ([]*abci.TxResult, error) {
+	results, _, err := txi.SearchBounded(ctx, q)
+	return results, err
+}
+
+// SearchBounded is like Search, but also returns whether the results were
+// truncated because the search exhausted the row or time budget of the
+// QueryLimits. Truncated results match the query, but other transactions
+// matching it may be missing.
+func (txi *TxIndex) SearchBounded(ctx context.Context, q *query.Query) ([]*abci.TxResult, bool, error) {
 	select {
 	case <-ctx.Done():
-		return make([]*abci.TxResult, 0), nil
+		return make([]*abci.TxResult, 0), false, nil
 
 	default:
 	}
@@ -228,25 +275,31 @@ func (txi *TxIndex) Search(ctx context.Context, q *query.Query) ([]*abci.TxResul
 	// get a list of conditions (like "tx.height > 5")
 	conditions, err := q.Conditions()
 	if err != nil {
-		return nil, fmt.Errorf("error during parsing conditions from query: %w", err)
+		return nil, false, fmt.Errorf("error during parsing conditions from query: %w", err)
 	}
 
 	// if there is a hash condition, return the result immediately
 	hash, ok, err := lookForHash(conditions)
 	if err != nil {
-		return nil, fmt.Errorf("error during searching for a hash in the query: %w", err)
+		return nil, false, fmt.Errorf("error during searching for a hash in the query: %w", err)
 	} else if ok {
 		res, err := txi.Get(hash)
 		switch {
 		case err != nil:
-			return []*abci.TxResult{}, fmt.Errorf("error while retrieving the result: %w", err)
+			return []*abci.TxResult{}, false, fmt.Errorf("error while retrieving the result: %w", err)
 		case res == nil:
-			return []*abci.TxResult{}, nil
+			return []*abci.TxResult{}, false, nil
 		default:
-			return []*abci.TxResult{res}, nil
+			return []*abci.TxResult{res}, false, nil
 		}
 	}
 
+	if err := txi.limits.checkQuery(conditions); err != nil {
+		txi.metrics.RejectedQueries.Add(1)
+		return nil, false, err
+	}
+	budget := newSearchBudget(txi.limits)
+
 	var matchEvents bool
 	var matchEventIdx int
 
@@ -301,7 +354,7 @@ func (txi *TxIndex) Search(ctx context.Context, q *query.Query) ([]*abci.TxResul
 				continue
 			}
 			if !hashesInitialized {
-				filteredHashes = txi.matchRange(ctx, qr, startKey(qr.Key), filteredHashes, true, matchEvents, heightInfo)
+				filteredHashes = txi.matchRange(ctx, qr, txi.rangePrefixes(qr), filteredHashes, true, matchEvents, heightInfo, budget)
 				hashesInitialized = true
 
 				// Ignore any remaining conditions if the first condition resulted
@@ -310,7 +363,7 @@ func (txi *TxIndex) Search(ctx context.Context, q *query.Query) ([]*abci.TxResul
 					break
 				}
 			} else {
-				filteredHashes = txi.matchRange(ctx, qr, startKey(qr.Key), filteredHashes, false, matchEvents, heightInfo)
+				filteredHashes = txi.matchRange(ctx, qr, txi.rangePrefixes(qr), filteredHashes, false, matchEvents, heightInfo, budget)
 			}
 		}
 	}
@@ -322,7 +375,7 @@ func (txi *TxIndex) Search(ctx context.Context, q *query.Query) ([]*abci.TxResul
 		}
 
 		if !hashesInitialized {
-			filteredHashes = txi.match(ctx, c, startKeyForCondition(c, heightInfo.height), filteredHashes, true, matchEvents, heightInfo)
+			filteredHashes = txi.match(ctx, c, startKeyForCondition(c, heightInfo.height), filteredHashes, true, matchEvents, heightInfo, budget)
 			hashesInitialized = true
 
 			// Ignore any remaining conditions if the first condition resulted
@@ -331,16 +384,19 @@ func (txi *TxIndex) Search(ctx context.Context, q *query.Query) ([]*abci.TxResul
 				break
 			}
 		} else {
-			filteredHashes = txi.match(ctx, c, startKeyForCondition(c, heightInfo.height), filteredHashes, false, matchEvents, heightInfo)
+			filteredHashes = txi.match(ctx, c, startKeyForCondition(c, heightInfo.height), filteredHashes, false, matchEvents, heightInfo, budget)
 		}
 	}
 
 	results := make([]*abci.TxResult, 0, len(filteredHashes))
 	resultMap := make(map[string]struct{})
 	for _, h := range filteredHashes {
+		if !budget.read() {
+			break
+		}
 		res, err := txi.Get(h)
 		if err != nil {
-			return nil, fmt.Errorf("failed to get Tx{%X}: %w", h, err)
+			return nil, false, fmt.Errorf("failed to get Tx{%X}: %w", h, err)
 		}
 		hashString := string(h)
 		if _, ok := resultMap[hashString]; !ok {
@@ -355,7 +411,10 @@ func (txi *TxIndex) Search(ctx context.Context, q *query.Query) ([]*abci.TxResul
 		}
 	}
 
-	return results, nil
+	if budget.exhausted {
+		txi.metrics.TruncatedQueries.Add(1)
+	}
+	return results, budget.exhausted, nil
 }
 
 func lookForHash(conditions []query.Condition) (hash []byte, ok bool, err error) {
@@ -399,6 +458,7 @@ func (txi *TxIndex) match(
 	firstRun bool,
 	matchEvents bool,
 	heightInfo HeightInfo,
+	budget *searchBudget,
 ) map[string][]byte {
 	// A previous match was attempted but resulted in no matches, so we return
 	// no matches (assuming AND operand).
@@ -416,7 +476,7 @@ func (txi *TxIndex) match(
 		}
 		defer it.Close()
 
-		for ; it.Valid(); it.Next() {
+		for ; it.Valid() && budget.read(); it.Next() {
 
 			// If we have a height range in a query, we need only transactions
 			// for this height
@@ -450,7 +510,7 @@ func (txi *TxIndex) match(
 		}
 		defer it.Close()
 
-		for ; it.Valid(); it.Next() {
+		for ; it.Valid() && budget.read(); it.Next() {
 			if matchEvents {
 				keyHeight, err := extractHeightFromKey(it.Key())
 				if err != nil || !checkHeightConditions(heightInfo, keyHeight) {
@@ -481,7 +541,7 @@ func (txi *TxIndex) match(
 		}
 		defer it.Close()
 
-		for ; it.Valid(); it.Next() {
+		for ; it.Valid() && budget.read(); it.Next() {
 			if !isTagKey(it.Key()) {
 				continue
 			}
@@ -548,11 +608,12 @@ func (txi *TxIndex) match(
 func (txi *TxIndex) matchRange(
 	ctx context.Context,
 	qr indexer.QueryRange,
-	startKey []byte,
+	prefixes [][]byte,
 	filteredHashes map[string][]byte,
 	firstRun bool,
 	matchEvents bool,
 	heightInfo HeightInfo,
+	budget *searchBudget,
 ) map[string][]byte {
 	// A previous match was attempted but resulted in no matches, so we return
 	// no matches (assuming AND operand).
@@ -562,22 +623,66 @@ func (txi *TxIndex) matchRange(
 
 	tmpHashes := make(map[string][]byte)
 
-	it, err := dbm.IteratePrefix(txi.store, startKey)
+	for _, prefix := range prefixes {
+		txi.matchRangePrefix(ctx, qr, prefix, tmpHashes, matchEvents, heightInfo, budget)
+	}
+
+	if len(tmpHashes) == 0 || firstRun {
+		// Either:
+		//
+		// 1. Regardless if a previous match was attempted, which may have had
+		// results, but no match was found for the current condition, then we
+		// return no matches (assuming AND operand).
+		//
+		// 2. A previous match was not attempted, so we return all results.
+		return tmpHashes
+	}
+
+	// Remove/reduce matches in filteredHashes that were not found in this
+	// match (tmpHashes).
+	for k, v := range filteredHashes {
+		tmpHash := tmpHashes[k]
+		if tmpHash == nil || !bytes.Equal(tmpHashes[k], v) {
+			delete(filteredHashes, k)
+
+			// Potentially exit early.
+			select {
+			case <-ctx.Done():
+				break
+			default:
+			}
+		}
+	}
+
+	return filteredHashes
+}
+
+// matchRangePrefix adds to tmpHashes the txs matching a given queryRange among
+// the keys with the given prefix.
+func (txi *TxIndex) matchRangePrefix(
+	ctx context.Context,
+	qr indexer.QueryRange,
+	prefix []byte,
+	tmpHashes map[string][]byte,
+	matchEvents bool,
+	heightInfo HeightInfo,
+	budget *searchBudget,
+) {
+	it, err := dbm.IteratePrefix(txi.store, prefix)
 	if err != nil {
 		panic(err)
 	}
 	defer it.Close()
 
 LOOP:
-	for ; it.Valid(); it.Next() {
+	for ; it.Valid() && budget.read(); it.Next() {
 		if !isTagKey(it.Key()) {
 			continue
 		}
 
 		if _, ok := qr.AnyBound().(*big.Int); ok {
-			v := new(big.Int)
 			eventValue := extractValueFromKey(it.Key())
-			v, ok := v.SetString(eventValue, 10)
+			v, ok := indexer.ParseNumericValue(eventValue, qr.Denom)
 			if !ok {
 				continue LOOP
 			}
@@ -610,35 +715,25 @@ LOOP:
 	if err := it.Error(); err != nil {
 		panic(err)
 	}
+}
 
-	if len(tmpHashes) == 0 || firstRun {
-		// Either:
-		//
-		// 1. Regardless if a previous match was attempted, which may have had
-		// results, but no match was found for the current condition, then we
-		// return no matches (assuming AND operand).
-		//
-		// 2. A previous match was not attempted, so we return all results.
-		return tmpHashes
+// rangePrefixes returns the key prefixes to iterate to match a given
+// queryRange. A tx.height range within the MaxHeightSpan of the QueryLimits is
+// matched height by height, instead of scanning the keys of all heights.
+func (txi *TxIndex) rangePrefixes(qr indexer.QueryRange) [][]byte {
+	if qr.Key != types.TxHeightKey || txi.limits.MaxHeightSpan <= 0 {
+		return [][]byte{startKey(qr.Key)}
 	}
-
-	// Remove/reduce matches in filteredHashes that were not found in this
-	// match (tmpHashes).
-	for k, v := range filteredHashes {
-		tmpHash := tmpHashes[k]
-		if tmpHash == nil || !bytes.Equal(tmpHashes[k], v) {
-			delete(filteredHashes, k)
-
-			// Potentially exit early.
-			select {
-			case <-ctx.Done():
-				break
-			default:
-			}
-		}
+	span, ok := heightSpan(qr)
+	if !ok || span > txi.limits.MaxHeightSpan {
+		return [][]byte{startKey(qr.Key)}
 	}
-
-	return filteredHashes
+	lower := qr.LowerBoundValue().(*big.Int).Int64()
+	prefixes := make([][]byte, 0, span)
+	for h := lower; h < lower+span; h++ {
+		prefixes = append(prefixes, startKey(qr.Key, h))
+	}
+	return prefixes
 }
 
 // Keys
@@ -691,6 +786,10 @@ func keyForEvent(key string, value []byte, result *abci.TxResult, eventSeq int64
 	))
 }
 
+func keyForAlias(alias []byte) []byte {
+	return append([]byte(aliasKeyPrefix), alias...)
+}
+
 func keyForHeight(result *abci.TxResult) []byte {
 	return []byte(fmt.Sprintf("%s/%d/%d/%d%s",
 		types.TxHeightKey,
@@ -704,10 +803,12 @@ func keyForHeight(result *abci.TxResult) []byte {
 }
 
 func startKeyForCondition(c query.Condition, height int64) []byte {
+	// a denominated number is stored with its denomination, e.g. "5utia"
+	operand := fmt.Sprintf("%v%s", c.Operand, c.Denom)
 	if height > 0 {
-		return startKey(c.CompositeKey, c.Operand, height)
+		return startKey(c.CompositeKey, operand, height)
 	}
-	return startKey(c.CompositeKey, c.Operand)
+	return startKey(c.CompositeKey, operand)
 }
 
 func startKey(fields ...interface{}) []byte {
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/gogo/protobuf/proto"
 	"github.com/stretchr/testify/assert"
@@ -90,6 +91,64 @@ func TestBigInt(t *testing.T) {
 	}
 }
 
+func TestNumericAmounts(t *testing.T) {
+	indexer := NewTxIndex(db.NewMemDB())
+
+	amounts := []string{"999999utia", "1000000utia", "20000000000000000000000utia", "1000000stake", "5000000", "invalid"}
+	for i, amount := range amounts {
+		txResult := txResultWithEvents([]abci.Event{
+			{Type: "transfer", Attributes: []abci.EventAttribute{{Key: []byte("amount"), Value: []byte(amount), Index: true}}},
+		})
+		txResult.Tx = types.Tx(fmt.Sprintf("TX %d", i))
+		txResult.Index = uint32(i)
+		require.NoError(t, indexer.Index(txResult))
+	}
+
+	testCases := []struct {
+		q       string
+		amounts []string
+	}{
+		// amounts are only compared to amounts of the same denomination
+		{"transfer.amount >= 1000000", []string{"5000000"}},
+		{"transfer.amount[utia] >= 1000000", []string{"1000000utia", "20000000000000000000000utia"}},
+		{"transfer.amount[utia] > 1000000", []string{"20000000000000000000000utia"}},
+		{"transfer.amount[utia] < 1000000", []string{"999999utia"}},
+		{"transfer.amount[stake] >= 1000000", []string{"1000000stake"}},
+		{"transfer.amount[utia] >= 10000000000000000000000", []string{"20000000000000000000000utia"}},
+		{"transfer.amount[utia] >= 1000000 AND transfer.amount[utia] <= 5000000", []string{"1000000utia"}},
+		{"transfer.amount[utia] = 1000000", []string{"1000000utia"}},
+	}
+
+	ctx := context.Background()
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.q, func(t *testing.T) {
+			q := query.MustParse(tc.q)
+			results, err := indexer.Search(ctx, q)
+			assert.NoError(t, err)
+			found := make([]string, len(results))
+			for i, result := range results {
+				found[i] = amounts[result.Index]
+			}
+			assert.ElementsMatch(t, tc.amounts, found)
+
+			// the subscriptions match the same amounts
+			var matched []string
+			for _, amount := range amounts {
+				if ok, err := q.Matches(map[string][]string{"transfer.amount": {amount}}); err == nil && ok {
+					matched = append(matched, amount)
+				}
+			}
+			assert.ElementsMatch(t, tc.amounts, matched)
+		})
+	}
+
+	// a query comparing amounts of distinct denominations is rejected
+	_, err := query.New("transfer.amount[utia] >= 1000000 AND transfer.amount[stake] <= 5000000")
+	assert.Error(t, err)
+}
+
 func TestTxIndex(t *testing.T) {
 	indexer := NewTxIndex(db.NewMemDB())
 
@@ -696,6 +755,108 @@ func TestTxSearchMultipleTxs(t *testing.T) {
 	require.Len(t, results, 3)
 }
 
+// indexSyntheticTxs indexes a transaction at each height up to numHeights,
+// with a transfer event to one of 100 addresses.
+func indexSyntheticTxs(t *testing.T, indexer *TxIndex, numHeights int) {
+	batch := txindex.NewBatch(int64(numHeights))
+	for i := 0; i < numHeights; i++ {
+		txResult := txResultWithEvents([]abci.Event{
+			{Type: "transfer", Attributes: []abci.EventAttribute{
+				{Key: []byte("address"), Value: []byte(fmt.Sprintf("address_%d", i%100)), Index: true},
+				{Key: []byte("amount"), Value: []byte("50"), Index: true},
+			}},
+		})
+		txResult.Tx = types.Tx(fmt.Sprintf("tx_%d", i))
+		txResult.Height = int64(i + 1)
+		batch.Ops[i] = txResult
+	}
+	require.NoError(t, indexer.AddBatch(batch))
+}
+
+func TestTxSearchQueryPlanning(t *testing.T) {
+	indexer := NewTxIndex(db.NewMemDB())
+	indexSyntheticTxs(t, indexer, 2000)
+	indexer.SetQueryLimits(QueryLimits{MaxHeightSpan: 100})
+
+	testCases := []struct {
+		q          string
+		rejected   bool
+		numResults int
+	}{
+		{q: "tx.height > 1", rejected: true},
+		{q: "tx.height >= 1 AND tx.height <= 2000", rejected: true},
+		{q: "tx.height >= 100 AND tx.height <= 200", rejected: true},
+		{q: "transfer.amount > 10", rejected: true},
+		{q: "transfer.address EXISTS", rejected: true},
+		{q: "transfer.address CONTAINS 'address_4'", rejected: true},
+		{q: "match.events = 1 AND tx.height > 1", rejected: true},
+		{q: "tx.height >= 100 AND tx.height <= 199", numResults: 100},
+		{q: "tx.height > 100 AND tx.height < 201 AND transfer.amount > 10", numResults: 100},
+		{q: "tx.height = 5", numResults: 1},
+		{q: "transfer.address = 'address_43'", numResults: 20},
+		{q: "transfer.address = 'address_43' AND tx.height > 1000", numResults: 10},
+		{q: "match.events = 1 AND transfer.address = 'address_43'", numResults: 20},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.q, func(t *testing.T) {
+			results, err := indexer.Search(context.Background(), query.MustParse(tc.q))
+			if tc.rejected {
+				require.ErrorIs(t, err, ErrQueryTooBroad)
+				return
+			}
+			require.NoError(t, err)
+			assert.Len(t, results, tc.numResults)
+		})
+	}
+}
+
+func TestTxSearchBudget(t *testing.T) {
+	indexer := NewTxIndex(db.NewMemDB())
+	indexSyntheticTxs(t, indexer, 2000)
+	ctx := context.Background()
+
+	indexer.SetQueryLimits(QueryLimits{MaxRows: 250})
+
+	// a full scan of the index exhausts the budget
+	results, truncated, err := indexer.SearchBounded(ctx, query.MustParse("transfer.address EXISTS"))
+	require.NoError(t, err)
+	assert.True(t, truncated)
+	assert.LessOrEqual(t, len(results), 250)
+
+	// truncated results still match the query
+	results, truncated, err = indexer.SearchBounded(ctx, query.MustParse("tx.height > 1 AND transfer.address = 'address_43'"))
+	require.NoError(t, err)
+	assert.True(t, truncated)
+	for _, res := range results {
+		assert.Equal(t, "address_43", string(res.Result.Events[0].Attributes[0].Value))
+	}
+
+	results, truncated, err = indexer.SearchBounded(ctx, query.MustParse("transfer.address = 'address_43'"))
+	require.NoError(t, err)
+	assert.False(t, truncated)
+	assert.Len(t, results, 20)
+
+	// a bounded height range is read height by height rather than scanning
+	// the index when the query planning is enabled
+	results, truncated, err = indexer.SearchBounded(ctx, query.MustParse("tx.height >= 1000 AND tx.height < 1100"))
+	require.NoError(t, err)
+	assert.True(t, truncated)
+	assert.Empty(t, results)
+
+	indexer.SetQueryLimits(QueryLimits{MaxHeightSpan: 100, MaxRows: 250})
+	results, truncated, err = indexer.SearchBounded(ctx, query.MustParse("tx.height >= 1000 AND tx.height < 1100"))
+	require.NoError(t, err)
+	assert.False(t, truncated)
+	assert.Len(t, results, 100)
+
+	indexer.SetQueryLimits(QueryLimits{Timeout: time.Nanosecond})
+	_, truncated, err = indexer.SearchBounded(ctx, query.MustParse("transfer.address EXISTS"))
+	require.NoError(t, err)
+	assert.True(t, truncated)
+}
+
 func txResultWithEvents(events []abci.Event) *abci.TxResult {
 	tx := types.Tx("HELLO WORLD")
 	return &abci.TxResult{
@@ -756,3 +917,24 @@ func BenchmarkTxIndex500(b *testing.B)   { benchmarkTxIndex(500, b) }
 func BenchmarkTxIndex1000(b *testing.B)  { benchmarkTxIndex(1000, b) }
 func BenchmarkTxIndex2000(b *testing.B)  { benchmarkTxIndex(2000, b) }
 func BenchmarkTxIndex10000(b *testing.B) { benchmarkTxIndex(10000, b) }
+
+func TestTxIndexGetByAlias(t *testing.T) {
+	indexer := NewTxIndex(db.NewMemDB())
+
+	txResult := txResultWithEvents(nil)
+	hash := types.Tx(txResult.Tx).Hash()
+	alias := []byte("alias")
+
+	batch := txindex.NewBatch(1)
+	require.NoError(t, batch.Add(txResult))
+	batch.AddAlias(alias, hash)
+	require.NoError(t, indexer.AddBatch(batch))
+
+	res, err := indexer.Get(alias)
+	require.NoError(t, err)
+	require.True(t, proto.Equal(txResult, res))
+
+	res, err = indexer.Get([]byte("unknown"))
+	require.NoError(t, err)
+	require.Nil(t, res)
+}
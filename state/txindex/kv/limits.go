@@ -0,0 +1,111 @@
+package kv
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/tendermint/tendermint/libs/pubsub/query"
+	"github.com/tendermint/tendermint/state/indexer"
+	"github.com/tendermint/tendermint/types"
+)
+
+// ErrQueryTooBroad is returned by Search for the queries rejected by the
+// query planning, see QueryLimits.
+var ErrQueryTooBroad = errors.New("query too broad")
+
+// QueryLimits bound the cost of the searches of the indexer. The zero value
+// sets no limit.
+type QueryLimits struct {
+	// MaxHeightSpan enables the query planning if positive: queries must have
+	// an equality condition, e.g. on tx.hash, tx.height or an event
+	// attribute, or bound tx.height on both sides to a range of at most
+	// MaxHeightSpan heights. The other queries, e.g. "tx.height > 1", would
+	// scan the whole index and are rejected with ErrQueryTooBroad.
+	MaxHeightSpan int64
+	// MaxRows is the maximum number of index entries read by a search, after
+	// which the results found so far are returned as truncated.
+	MaxRows int
+	// Timeout is the maximum duration of a search, after which the results
+	// found so far are returned as truncated.
+	Timeout time.Duration
+}
+
+// checkQuery returns an error if the conditions of a query would scan the
+// whole index, according to the query planning of the limits.
+func (l QueryLimits) checkQuery(conditions []query.Condition) error {
+	if l.MaxHeightSpan <= 0 {
+		return nil
+	}
+	for _, c := range conditions {
+		if c.Op == query.OpEqual && c.CompositeKey != types.MatchEventKey {
+			return nil
+		}
+	}
+	ranges, _, _ := indexer.LookForRangesWithHeight(conditions)
+	if span, ok := heightSpan(ranges[types.TxHeightKey]); ok && span <= l.MaxHeightSpan {
+		return nil
+	}
+	return fmt.Errorf("%w: add an equality condition, e.g. on tx.hash, tx.height or an event attribute, "+
+		"or bound tx.height on both sides to at most %d heights, e.g. \"tx.height >= H AND tx.height <= H+%d\"",
+		ErrQueryTooBroad, l.MaxHeightSpan, l.MaxHeightSpan-1)
+}
+
+// heightSpan returns the number of heights within a height range, or false if
+// the range is not bounded on both sides.
+func heightSpan(qr indexer.QueryRange) (int64, bool) {
+	lower, ok := qr.LowerBoundValue().(*big.Int)
+	if !ok {
+		return 0, false
+	}
+	upper, ok := qr.UpperBoundValue().(*big.Int)
+	if !ok {
+		return 0, false
+	}
+	if !lower.IsInt64() || !upper.IsInt64() {
+		return 0, false
+	}
+	if upper.Cmp(lower) < 0 {
+		return 0, true
+	}
+	span := new(big.Int).Sub(upper, lower)
+	span.Add(span, big.NewInt(1))
+	if !span.IsInt64() {
+		return 0, false
+	}
+	return span.Int64(), true
+}
+
+// searchBudget counts the index entries read by a search against the
+// QueryLimits. Once exhausted, the search stops reading and returns the
+// results found so far as truncated.
+type searchBudget struct {
+	maxRows  int
+	deadline time.Time
+	rows     int
+
+	exhausted bool
+}
+
+func newSearchBudget(l QueryLimits) *searchBudget {
+	b := &searchBudget{maxRows: l.MaxRows}
+	if l.Timeout > 0 {
+		b.deadline = time.Now().Add(l.Timeout)
+	}
+	return b
+}
+
+// read records that an entry of the index was read, and returns false if the
+// budget is exhausted.
+func (b *searchBudget) read() bool {
+	if b.exhausted {
+		return false
+	}
+	b.rows++
+	if (b.maxRows > 0 && b.rows > b.maxRows) || (!b.deadline.IsZero() && time.Now().After(b.deadline)) {
+		b.exhausted = true
+		return false
+	}
+	return true
+}
@@ -0,0 +1,84 @@
+package txindex
+
+import (
+	"github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/discard"
+	"github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// MetricsSubsystem is a subsystem shared by all metrics exposed by this
+	// package.
+	MetricsSubsystem = "txindex"
+)
+
+// Metrics contains metrics exposed by this package.
+type Metrics struct {
+	// The latest height whose block and tx events have been indexed.
+	IndexedHeight metrics.Gauge
+	// Number of heights received from the event bus but not indexed yet.
+	IndexingLag metrics.Gauge
+	// Number of heights written together in a single indexing batch.
+	BatchHeights metrics.Histogram
+	// Number of tx searches rejected because the query would scan the whole
+	// index.
+	RejectedQueries metrics.Counter
+	// Number of tx searches whose results were truncated because they
+	// exhausted their row or time budget.
+	TruncatedQueries metrics.Counter
+}
+
+// PrometheusMetrics returns Metrics build using Prometheus client library.
+// Optionally, labels can be provided along with their values ("foo",
+// "fooValue").
+func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
+	labels := []string{}
+	for i := 0; i < len(labelsAndValues); i += 2 {
+		labels = append(labels, labelsAndValues[i])
+	}
+	return &Metrics{
+		IndexedHeight: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "indexed_height",
+			Help:      "The latest height whose block and tx events have been indexed.",
+		}, labels).With(labelsAndValues...),
+		IndexingLag: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "indexing_lag",
+			Help:      "Number of heights received from the event bus but not indexed yet.",
+		}, labels).With(labelsAndValues...),
+		BatchHeights: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "batch_heights",
+			Help:      "Number of heights written together in a single indexing batch.",
+			Buckets:   stdprometheus.ExponentialBuckets(1, 2, 8),
+		}, labels).With(labelsAndValues...),
+		RejectedQueries: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "rejected_queries",
+			Help:      "Number of tx searches rejected because the query would scan the whole index.",
+		}, labels).With(labelsAndValues...),
+		TruncatedQueries: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "truncated_queries",
+			Help:      "Number of tx searches whose results were truncated because they exhausted their row or time budget.",
+		}, labels).With(labelsAndValues...),
+	}
+}
+
+// NopMetrics returns no-op Metrics.
+func NopMetrics() *Metrics {
+	return &Metrics{
+		IndexedHeight:    discard.NewGauge(),
+		IndexingLag:      discard.NewGauge(),
+		BatchHeights:     discard.NewHistogram(),
+		RejectedQueries:  discard.NewCounter(),
+		TruncatedQueries: discard.NewCounter(),
+	}
+}
@@ -142,10 +142,20 @@ func validateBlock(state State, block *types.Block) error {
 			block.Height, state.InitialHeight)
 	}
 
-	// Check evidence doesn't exceed the limit amount of bytes.
-	if max, got := state.ConsensusParams.Evidence.MaxBytes, block.Evidence.ByteSize(); got > max {
+	// Check evidence doesn't exceed the limit amount of bytes, nor the space
+	// left in the block by the header, the last commit and the txs, like
+	// CreateProposalBlock.
+	if max, got := maxEvidenceBytes(state, block.Data.Size()), block.Evidence.ByteSize(); got > max {
 		return types.NewErrEvidenceOverflow(max, got)
 	}
 
 	return nil
 }
+
+// maxEvidenceBytes returns the maximum size of the evidence of a block of the
+// given state holding txBytes of txs: the smaller of Evidence.MaxBytes and the
+// block space left by the header, the last commit and the txs.
+func maxEvidenceBytes(state State, txBytes int64) int64 {
+	return types.MaxEvidenceBytes(state.ConsensusParams.Block.MaxBytes, state.ConsensusParams.Evidence.MaxBytes,
+		txBytes, state.Validators.Size())
+}
@@ -323,3 +323,49 @@ func TestValidateBlockEvidence(t *testing.T) {
 		require.NoError(t, err, "height %d", height)
 	}
 }
+
+func TestValidateBlockEvidenceBlockSpace(t *testing.T) {
+	state, _, privVals := makeState(1, 1)
+	proposerAddr := state.Validators.GetProposer().Address
+	ev := types.NewMockDuplicateVoteEvidenceWithValidator(1, time.Now(), privVals[proposerAddr.String()], chainID)
+	makeBlock := func() *types.Block {
+		block, _ := state.MakeBlock(
+			1,
+			factory.MakeData(factory.MakeTenTxs(1)),
+			types.NewCommit(0, 0, types.BlockID{}, nil),
+			[]types.Evidence{ev},
+			proposerAddr,
+		)
+		return block
+	}
+	block := makeBlock()
+	evSize := block.Evidence.ByteSize()
+	// the space of the block left to the evidence by the header, the last
+	// commit and the txs
+	used := types.MaxOverheadForBlock + types.MaxHeaderBytes +
+		types.MaxCommitBytes(state.Validators.Size()) + block.Data.Size()
+
+	blockExec := sm.NewBlockExecutor(nil, log.TestingLogger(), nil, memmock.Mempool{}, sm.EmptyEvidencePool{})
+	testCases := []struct {
+		name             string
+		maxBytes         int64
+		evidenceMaxBytes int64
+		overflow         bool
+	}{
+		{"exactly the block space", used + evSize, evSize, false},
+		{"a byte over the block space", used + evSize - 1, evSize, true},
+		{"a byte over the evidence limit", used + evSize, evSize - 1, true},
+	}
+	for _, tc := range testCases {
+		state.ConsensusParams.Block.MaxBytes = tc.maxBytes
+		state.ConsensusParams.Evidence.MaxBytes = tc.evidenceMaxBytes
+		// the block commits to the consensus params
+		err := blockExec.ValidateBlock(state, makeBlock())
+		if !tc.overflow {
+			assert.NoError(t, err, tc.name)
+			continue
+		}
+		var overflow *types.ErrEvidenceOverflow
+		assert.ErrorAs(t, err, &overflow, tc.name)
+	}
+}
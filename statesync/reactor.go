@@ -1,7 +1,9 @@
 package statesync
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"sort"
 	"time"
 
@@ -40,6 +42,10 @@ type Reactor struct {
 	// snapshots and chunks into the sync.
 	mtx    cmtsync.RWMutex
 	syncer *syncer
+	// cancel cancels the state sync in progress, if any.
+	cancel context.CancelFunc
+	// status is that of the last state sync, nil if none ran.
+	status *statusTracker
 }
 
 // NewReactor creates a new state sync reactor.
@@ -85,6 +91,77 @@ func (r *Reactor) OnStart() error {
 	return nil
 }
 
+// OnStop implements p2p.Reactor. It cancels the state sync in progress, if
+// any, which cleans up its temporary chunks.
+func (r *Reactor) OnStop() {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	if r.cancel != nil {
+		r.cancel()
+	}
+}
+
+// Status returns the status of the state sync of the node, whose State is
+// SyncStateInactive if it did not state sync.
+func (r *Reactor) Status() Status {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	if r.status == nil {
+		return Status{}
+	}
+	return r.status.get()
+}
+
+// MarkFallback records that the node gave up on state sync and fell back to
+// fast sync.
+func (r *Reactor) MarkFallback() {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	if r.status != nil {
+		r.status.setState(SyncStateFallback)
+	}
+}
+
+// MarkHalted records that the node could neither state sync nor fall back to
+// fast sync, because of err.
+func (r *Reactor) MarkHalted(err error) {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	if r.status != nil {
+		r.status.halted(err)
+	}
+}
+
+// StateAt returns the state and last commit at height, verified by the state
+// provider, for a node whose app already holds the state of that height to
+// fast sync from it, the way it would after restoring a snapshot of that
+// height. It returns an error if the app does not report the verified app hash
+// and app version at that height.
+func (r *Reactor) StateAt(
+	ctx context.Context,
+	stateProvider StateProvider,
+	height uint64,
+) (sm.State, *types.Commit, error) {
+	appHash, err := stateProvider.AppHash(ctx, height)
+	if err != nil {
+		return sm.State{}, nil, fmt.Errorf("failed to fetch and verify app hash at height %d: %w", height, err)
+	}
+	state, err := stateProvider.State(ctx, height)
+	if err != nil {
+		return sm.State{}, nil, fmt.Errorf("failed to fetch and verify state at height %d: %w", height, err)
+	}
+	commit, err := stateProvider.Commit(ctx, height)
+	if err != nil {
+		return sm.State{}, nil, fmt.Errorf("failed to fetch and verify commit at height %d: %w", height, err)
+	}
+	constraints, err := verifyAppState(r.connQuery, r.Logger, height, appHash, state.Version.Consensus.App)
+	if err != nil {
+		return sm.State{}, nil, fmt.Errorf("app does not hold the state of height %d: %w", height, err)
+	}
+	state.EvidenceConstraints = constraints
+	return state, commit, nil
+}
+
 // AddPeer implements p2p.Reactor.
 func (r *Reactor) AddPeer(peer p2p.Peer) {
 	r.mtx.RLock()
@@ -279,13 +356,36 @@ func (r *Reactor) recentSnapshots(n uint32) ([]*snapshot, error) {
 
 // Sync runs a state sync, returning the new state and last commit at the snapshot height.
 // The caller must store the state and commit in the state database and block store.
-func (r *Reactor) Sync(stateProvider StateProvider, discoveryTime time.Duration) (sm.State, *types.Commit, error) {
+// It gives up when ctx is done, the reactor stops, the configured timeout expires or the
+// configured number of snapshot restorations failed, returning an error whose reason is given
+// by FailureReason.
+func (r *Reactor) Sync(
+	ctx context.Context,
+	stateProvider StateProvider,
+	discoveryTime time.Duration,
+) (sm.State, *types.Commit, error) {
+	var timeoutCtx context.Context
+	if r.cfg.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.cfg.Timeout)
+		defer cancel()
+		timeoutCtx = ctx
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
 	r.mtx.Lock()
 	if r.syncer != nil {
 		r.mtx.Unlock()
 		return sm.State{}, nil, errors.New("a state sync is already in progress")
 	}
+	if !r.IsRunning() {
+		r.mtx.Unlock()
+		return sm.State{}, nil, errors.New("the state sync reactor is not running")
+	}
 	r.syncer = newSyncer(r.cfg, r.Logger, r.conn, r.connQuery, stateProvider, r.tempDir)
+	r.status = r.syncer.status
+	r.cancel = cancel
 	r.mtx.Unlock()
 
 	hook := func() {
@@ -300,10 +400,19 @@ func (r *Reactor) Sync(stateProvider StateProvider, discoveryTime time.Duration)
 
 	hook()
 
-	state, commit, err := r.syncer.SyncAny(discoveryTime, hook)
+	state, commit, err := r.syncer.SyncAny(ctx, discoveryTime, hook)
+	if err != nil && timeoutCtx != nil && errors.Is(timeoutCtx.Err(), context.DeadlineExceeded) {
+		err = fmt.Errorf("%w after %v: %v", errSyncTimeout, r.cfg.Timeout, err)
+	}
+	if err != nil {
+		r.status.failed(err)
+	} else {
+		r.status.setState(SyncStateDone)
+	}
 
 	r.mtx.Lock()
 	r.syncer = nil
+	r.cancel = nil
 	r.mtx.Unlock()
 	return state, commit, err
 }
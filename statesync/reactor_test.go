@@ -1,6 +1,8 @@
 package statesync
 
 import (
+	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -14,7 +16,11 @@ import (
 	"github.com/tendermint/tendermint/p2p"
 	p2pmocks "github.com/tendermint/tendermint/p2p/mocks"
 	ssproto "github.com/tendermint/tendermint/proto/tendermint/statesync"
+	"github.com/tendermint/tendermint/proxy"
 	proxymocks "github.com/tendermint/tendermint/proxy/mocks"
+	sm "github.com/tendermint/tendermint/state"
+	"github.com/tendermint/tendermint/statesync/mocks"
+	"github.com/tendermint/tendermint/types"
 )
 
 func TestReactor_Receive_ChunkRequest(t *testing.T) {
@@ -201,3 +207,114 @@ func TestLegacyReactorReceiveBasic(t *testing.T) {
 		reactor.Receive(ChunkChannel, peer, msg)
 	})
 }
+
+func TestReactor_Sync_Timeout(t *testing.T) {
+	cfg := config.DefaultStateSyncConfig()
+	cfg.Timeout = 100 * time.Millisecond
+	r := startSyncReactor(t, *cfg)
+	assert.Equal(t, SyncStateInactive, r.Status().State)
+
+	// no peer offers snapshots
+	start := time.Now()
+	_, _, err := r.Sync(context.Background(), &mocks.StateProvider{}, 5*time.Second)
+	require.Error(t, err)
+	assert.Less(t, time.Since(start), 5*time.Second)
+	assert.Equal(t, FailureReasonTimeout, FailureReason(err))
+	assert.Equal(t, Status{State: SyncStateFailed, FailureReason: FailureReasonTimeout}, r.Status())
+
+	r.MarkFallback()
+	assert.Equal(t, SyncStateFallback, r.Status().State)
+
+	r.MarkHalted(errors.New("boom"))
+	assert.Equal(t, Status{
+		State:         SyncStateHalted,
+		FailureReason: FailureReasonTimeout,
+		Error:         "boom",
+	}, r.Status())
+}
+
+func TestReactor_Sync_Stop(t *testing.T) {
+	cfg := config.DefaultStateSyncConfig()
+	r := startSyncReactor(t, *cfg)
+
+	errCh := make(chan error, 1)
+	go func() {
+		_, _, err := r.Sync(context.Background(), &mocks.StateProvider{}, 5*time.Second)
+		errCh <- err
+	}()
+	require.Eventually(t, func() bool {
+		return r.Status().State == SyncStateDiscovering
+	}, time.Second, 10*time.Millisecond)
+
+	// stopping the node cancels the state sync
+	require.NoError(t, r.Stop())
+	select {
+	case err := <-errCh:
+		assert.Equal(t, FailureReasonCanceled, FailureReason(err))
+	case <-time.After(time.Second):
+		t.Fatal("state sync was not cancelled")
+	}
+	assert.Equal(t, SyncStateFailed, r.Status().State)
+
+	_, _, err := r.Sync(context.Background(), &mocks.StateProvider{}, 0)
+	assert.Error(t, err)
+}
+
+func TestReactor_StateAt(t *testing.T) {
+	const height = 7
+	state := sm.State{ChainID: "chain", LastBlockHeight: height}
+	state.Version.Consensus.App = 9
+	commit := &types.Commit{BlockID: types.BlockID{Hash: []byte{1, 2, 3}}}
+	stateProvider := &mocks.StateProvider{}
+	stateProvider.On("AppHash", mock.Anything, uint64(height)).Return([]byte("app_hash"), nil)
+	stateProvider.On("State", mock.Anything, uint64(height)).Return(state, nil)
+	stateProvider.On("Commit", mock.Anything, uint64(height)).Return(commit, nil)
+
+	testcases := map[string]struct {
+		response  abci.ResponseInfo
+		expectErr bool
+	}{
+		"app at the height": {abci.ResponseInfo{LastBlockHeight: height, LastBlockAppHash: []byte("app_hash"), AppVersion: 9}, false},
+		"app with evidence constraints": {abci.ResponseInfo{
+			LastBlockHeight: height, LastBlockAppHash: []byte("app_hash"), AppVersion: 9,
+			EvidenceConstraints: &abci.EvidenceConstraints{MinMaxAgeNumBlocks: 100},
+		}, false},
+		"app at genesis":    {abci.ResponseInfo{AppVersion: 9}, true},
+		"other app hash":    {abci.ResponseInfo{LastBlockHeight: height, LastBlockAppHash: []byte("xxx"), AppVersion: 9}, true},
+	}
+	for name, tc := range testcases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			connQuery := &proxymocks.AppConnQuery{}
+			connQuery.On("InfoSync", proxy.RequestInfo).Return(&tc.response, nil)
+			r := NewReactor(*config.DefaultStateSyncConfig(), &proxymocks.AppConnSnapshot{}, connQuery, "")
+
+			gotState, gotCommit, err := r.StateAt(context.Background(), stateProvider, height)
+			if tc.expectErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			expectState := state
+			expectState.EvidenceConstraints = tc.response.EvidenceConstraints
+			assert.Equal(t, expectState, gotState)
+			assert.Equal(t, commit, gotCommit)
+		})
+	}
+}
+
+// startSyncReactor starts a state sync reactor without peers.
+func startSyncReactor(t *testing.T, cfg config.StateSyncConfig) *Reactor {
+	r := NewReactor(cfg, &proxymocks.AppConnSnapshot{}, &proxymocks.AppConnQuery{}, "")
+	p2p.MakeSwitch(config.DefaultP2PConfig(), 0, "127.0.0.1", "123.123.123", func(_ int, sw *p2p.Switch) *p2p.Switch {
+		sw.AddReactor("STATESYNC", r)
+		return sw
+	})
+	require.NoError(t, r.Start())
+	t.Cleanup(func() {
+		if r.IsRunning() {
+			require.NoError(t, r.Stop())
+		}
+	})
+	return r
+}
@@ -0,0 +1,259 @@
+package statesync
+
+import (
+	"context"
+	"sort"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/service"
+	cmtsync "github.com/tendermint/tendermint/libs/sync"
+	"github.com/tendermint/tendermint/proxy"
+	"github.com/tendermint/tendermint/types"
+)
+
+const snapshotManagerSubscriber = "SnapshotManager"
+
+// SnapshotManager drives the creation and pruning of state machine snapshots
+// by the application. Every snapshot_interval heights, once the block is
+// committed and unless the node is catching up, it asks the application to
+// create a snapshot via CreateSnapshot, and then asks it to prune all but the
+// snapshot_keep_recent most recent snapshots via PruneSnapshots.
+//
+// Applications that do not advertise abci.CapabilitySnapshotOrchestration in
+// their Info response are left alone.
+type SnapshotManager struct {
+	service.BaseService
+
+	conn       proxy.AppConnSnapshot
+	connQuery  proxy.AppConnQuery
+	eventBus   *types.EventBus
+	interval   uint64
+	keepRecent uint32
+	isSyncing  func() bool
+
+	mtx       cmtsync.RWMutex
+	enabled   bool
+	snapshots []*abci.Snapshot // sorted by height, then format
+
+	heightCh chan uint64
+	quit     chan struct{}
+}
+
+// NewSnapshotManager returns a new snapshot manager. isSyncing reports whether
+// the node is catching up (state or fast syncing), in which case no snapshots
+// are created.
+func NewSnapshotManager(
+	conn proxy.AppConnSnapshot,
+	connQuery proxy.AppConnQuery,
+	eventBus *types.EventBus,
+	interval uint64,
+	keepRecent uint32,
+	isSyncing func() bool,
+) *SnapshotManager {
+	m := &SnapshotManager{
+		conn:       conn,
+		connQuery:  connQuery,
+		eventBus:   eventBus,
+		interval:   interval,
+		keepRecent: keepRecent,
+		isSyncing:  isSyncing,
+		heightCh:   make(chan uint64, 1),
+		quit:       make(chan struct{}),
+	}
+	m.BaseService = *service.NewBaseService(nil, "SnapshotManager", m)
+	return m
+}
+
+// OnStart implements service.Service. It checks that the application supports
+// snapshot orchestration, loads its existing snapshots and starts listening
+// for committed blocks.
+func (m *SnapshotManager) OnStart() error {
+	if m.interval == 0 {
+		return nil
+	}
+
+	info, err := m.connQuery.InfoSync(proxy.RequestInfo)
+	if err != nil {
+		return err
+	}
+	if !info.HasCapability(abci.CapabilitySnapshotOrchestration) {
+		m.Logger.Info("Application does not support snapshot orchestration, not creating snapshots")
+		return nil
+	}
+
+	res, err := m.conn.ListSnapshotsSync(abci.RequestListSnapshots{})
+	if err != nil {
+		return err
+	}
+
+	// receiveRoutine never blocks, so an unbuffered subscription does not hold up
+	// the event bus and is never canceled for being too slow.
+	sub, err := m.eventBus.SubscribeUnbuffered(
+		context.Background(),
+		snapshotManagerSubscriber,
+		types.EventQueryNewBlockHeader)
+	if err != nil {
+		return err
+	}
+
+	m.mtx.Lock()
+	m.enabled = true
+	m.addSnapshots(res.Snapshots)
+	m.mtx.Unlock()
+
+	go m.receiveRoutine(sub)
+	go m.snapshotRoutine()
+	return nil
+}
+
+// OnStop implements service.Service.
+func (m *SnapshotManager) OnStop() {
+	close(m.quit)
+	if m.Enabled() && m.eventBus.IsRunning() {
+		if err := m.eventBus.UnsubscribeAll(context.Background(), snapshotManagerSubscriber); err != nil {
+			m.Logger.Error("Failed to unsubscribe from event bus", "err", err)
+		}
+	}
+}
+
+// Enabled returns whether the node drives the snapshots of the application.
+func (m *SnapshotManager) Enabled() bool {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+	return m.enabled
+}
+
+// Snapshots returns the snapshots currently kept by the application, sorted by
+// height.
+func (m *SnapshotManager) Snapshots() []*abci.Snapshot {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+	snapshots := make([]*abci.Snapshot, len(m.snapshots))
+	copy(snapshots, m.snapshots)
+	return snapshots
+}
+
+// receiveRoutine hands the eligible committed heights over to
+// snapshotRoutine. A height is skipped if a snapshot is still being created,
+// so that a slow application never holds up the event bus.
+func (m *SnapshotManager) receiveRoutine(sub types.Subscription) {
+	for {
+		select {
+		case msg := <-sub.Out():
+			header := msg.Data().(types.EventDataNewBlockHeader).Header
+			height := uint64(header.Height)
+			if height%m.interval != 0 {
+				continue
+			}
+			if m.isSyncing != nil && m.isSyncing() {
+				m.Logger.Debug("Node is catching up, not creating snapshot", "height", height)
+				continue
+			}
+			select {
+			case m.heightCh <- height:
+			default:
+				m.Logger.Info("Snapshot still in progress, skipping height", "height", height)
+			}
+		case <-sub.Cancelled():
+			if m.IsRunning() {
+				m.Logger.Error("Snapshot manager subscription was cancelled", "err", sub.Err())
+			}
+			return
+		case <-m.quit:
+			return
+		}
+	}
+}
+
+func (m *SnapshotManager) snapshotRoutine() {
+	for {
+		select {
+		case height := <-m.heightCh:
+			m.createSnapshot(height)
+		case <-m.quit:
+			return
+		}
+	}
+}
+
+// createSnapshot asks the application to create a snapshot at the given
+// height, then to prune the snapshots beyond keepRecent.
+func (m *SnapshotManager) createSnapshot(height uint64) {
+	res, err := m.conn.CreateSnapshotSync(abci.RequestCreateSnapshot{Height: height})
+	if err != nil {
+		m.Logger.Error("Failed to create snapshot", "height", height, "err", err)
+		return
+	}
+	m.Logger.Info("Created snapshot", "height", height, "snapshots", len(res.Snapshots))
+
+	m.mtx.Lock()
+	m.addSnapshots(res.Snapshots)
+	pruned := m.pruneHeights()
+	m.mtx.Unlock()
+
+	if len(pruned) == 0 {
+		return
+	}
+	if _, err := m.conn.PruneSnapshotsSync(abci.RequestPruneSnapshots{Heights: pruned}); err != nil {
+		m.Logger.Error("Failed to prune snapshots", "heights", pruned, "err", err)
+		return
+	}
+	m.Logger.Info("Pruned snapshots", "heights", pruned)
+}
+
+// addSnapshots records the given snapshots, replacing any with the same height
+// and format. The caller must hold mtx.
+func (m *SnapshotManager) addSnapshots(snapshots []*abci.Snapshot) {
+	for _, snapshot := range snapshots {
+		if snapshot == nil {
+			continue
+		}
+		replaced := false
+		for i, s := range m.snapshots {
+			if s.Height == snapshot.Height && s.Format == snapshot.Format {
+				m.snapshots[i] = snapshot
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			m.snapshots = append(m.snapshots, snapshot)
+		}
+	}
+	sort.Slice(m.snapshots, func(i, j int) bool {
+		a, b := m.snapshots[i], m.snapshots[j]
+		if a.Height != b.Height {
+			return a.Height < b.Height
+		}
+		return a.Format < b.Format
+	})
+}
+
+// pruneHeights removes the snapshots beyond the keepRecent most recent heights
+// and returns their heights in ascending order. The caller must hold mtx.
+func (m *SnapshotManager) pruneHeights() []uint64 {
+	if m.keepRecent == 0 {
+		return nil
+	}
+
+	var heights []uint64
+	for _, s := range m.snapshots {
+		if len(heights) == 0 || heights[len(heights)-1] != s.Height {
+			heights = append(heights, s.Height)
+		}
+	}
+	if len(heights) <= int(m.keepRecent) {
+		return nil
+	}
+	pruned := heights[:len(heights)-int(m.keepRecent)]
+	cutoff := pruned[len(pruned)-1]
+
+	kept := m.snapshots[:0]
+	for _, s := range m.snapshots {
+		if s.Height > cutoff {
+			kept = append(kept, s)
+		}
+	}
+	m.snapshots = kept
+	return pruned
+}
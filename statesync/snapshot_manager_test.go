@@ -0,0 +1,215 @@
+package statesync
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abcicli "github.com/tendermint/tendermint/abci/client"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+	cmtsync "github.com/tendermint/tendermint/libs/sync"
+	"github.com/tendermint/tendermint/proxy"
+	"github.com/tendermint/tendermint/types"
+)
+
+// orchestratedApp is an application recording the snapshot orchestration
+// calls made by the node.
+type orchestratedApp struct {
+	abci.BaseApplication
+
+	capabilities []string
+	existing     []*abci.Snapshot
+
+	mtx     sync.Mutex
+	listed  int
+	created []uint64
+	pruned  [][]uint64
+}
+
+func (app *orchestratedApp) Info(req abci.RequestInfo) abci.ResponseInfo {
+	return abci.ResponseInfo{Capabilities: app.capabilities}
+}
+
+func (app *orchestratedApp) ListSnapshots(req abci.RequestListSnapshots) abci.ResponseListSnapshots {
+	app.mtx.Lock()
+	defer app.mtx.Unlock()
+	app.listed++
+	return abci.ResponseListSnapshots{Snapshots: app.existing}
+}
+
+func (app *orchestratedApp) CreateSnapshot(req abci.RequestCreateSnapshot) abci.ResponseCreateSnapshot {
+	app.mtx.Lock()
+	defer app.mtx.Unlock()
+	app.created = append(app.created, req.Height)
+	return abci.ResponseCreateSnapshot{Snapshots: []*abci.Snapshot{
+		{Height: req.Height, Format: 1, Chunks: 1, Hash: []byte{byte(req.Height)}},
+	}}
+}
+
+func (app *orchestratedApp) PruneSnapshots(req abci.RequestPruneSnapshots) abci.ResponsePruneSnapshots {
+	app.mtx.Lock()
+	defer app.mtx.Unlock()
+	app.pruned = append(app.pruned, req.Heights)
+	return abci.ResponsePruneSnapshots{}
+}
+
+func (app *orchestratedApp) calls() (listed int, created []uint64, pruned [][]uint64) {
+	app.mtx.Lock()
+	defer app.mtx.Unlock()
+	return app.listed, append([]uint64(nil), app.created...), append([][]uint64(nil), app.pruned...)
+}
+
+func startSnapshotManager(
+	t *testing.T,
+	app abci.Application,
+	interval uint64,
+	keepRecent uint32,
+	isSyncing func() bool,
+) (*SnapshotManager, *types.EventBus) {
+	t.Helper()
+
+	eventBus := types.NewEventBus()
+	require.NoError(t, eventBus.Start())
+	t.Cleanup(func() { _ = eventBus.Stop() })
+
+	client := abcicli.NewLocalClient(new(cmtsync.Mutex), app)
+	m := NewSnapshotManager(
+		proxy.NewAppConnSnapshot(client),
+		proxy.NewAppConnQuery(client),
+		eventBus,
+		interval,
+		keepRecent,
+		isSyncing,
+	)
+	m.SetLogger(log.TestingLogger())
+	require.NoError(t, m.Start())
+	t.Cleanup(func() { _ = m.Stop() })
+	return m, eventBus
+}
+
+func publishHeight(t *testing.T, eventBus *types.EventBus, height int64) {
+	t.Helper()
+	err := eventBus.PublishEventNewBlockHeader(types.EventDataNewBlockHeader{
+		Header: types.Header{Height: height},
+	})
+	require.NoError(t, err)
+}
+
+func snapshotHeights(snapshots []*abci.Snapshot) []uint64 {
+	heights := make([]uint64, 0, len(snapshots))
+	for _, s := range snapshots {
+		heights = append(heights, s.Height)
+	}
+	return heights
+}
+
+func TestSnapshotManager_CreatesAndPrunes(t *testing.T) {
+	app := &orchestratedApp{
+		capabilities: []string{abci.CapabilitySnapshotOrchestration},
+		existing:     []*abci.Snapshot{{Height: 1, Format: 1, Chunks: 1}},
+	}
+	m, eventBus := startSnapshotManager(t, app, 2, 2, nil)
+	require.True(t, m.Enabled())
+	assert.Equal(t, []uint64{1}, snapshotHeights(m.Snapshots()))
+
+	for height := int64(2); height <= 8; height++ {
+		publishHeight(t, eventBus, height)
+		if height%2 != 0 {
+			continue
+		}
+		// wait for the snapshot to be created, so that no height is skipped
+		require.Eventually(t, func() bool {
+			_, created, _ := app.calls()
+			return len(created) > 0 && created[len(created)-1] == uint64(height)
+		}, time.Second, 10*time.Millisecond)
+	}
+
+	require.Eventually(t, func() bool {
+		_, _, pruned := app.calls()
+		return len(pruned) == 3
+	}, time.Second, 10*time.Millisecond)
+
+	listed, created, pruned := app.calls()
+	assert.Equal(t, 1, listed)
+	assert.Equal(t, []uint64{2, 4, 6, 8}, created)
+	assert.Equal(t, [][]uint64{{1}, {2}, {4}}, pruned)
+	assert.Equal(t, []uint64{6, 8}, snapshotHeights(m.Snapshots()))
+}
+
+func TestSnapshotManager_KeepAll(t *testing.T) {
+	app := &orchestratedApp{capabilities: []string{abci.CapabilitySnapshotOrchestration}}
+	m, eventBus := startSnapshotManager(t, app, 1, 0, nil)
+
+	for height := int64(1); height <= 3; height++ {
+		publishHeight(t, eventBus, height)
+		require.Eventually(t, func() bool {
+			return len(m.Snapshots()) == int(height)
+		}, time.Second, 10*time.Millisecond)
+	}
+
+	_, created, pruned := app.calls()
+	assert.Equal(t, []uint64{1, 2, 3}, created)
+	assert.Empty(t, pruned)
+}
+
+func TestSnapshotManager_SkipsWhileSyncing(t *testing.T) {
+	app := &orchestratedApp{capabilities: []string{abci.CapabilitySnapshotOrchestration}}
+	var (
+		syncing atomic.Bool
+		checked atomic.Int32
+	)
+	syncing.Store(true)
+	isSyncing := func() bool {
+		checked.Add(1)
+		return syncing.Load()
+	}
+	m, eventBus := startSnapshotManager(t, app, 1, 1, isSyncing)
+
+	publishHeight(t, eventBus, 1)
+	publishHeight(t, eventBus, 2)
+	require.Eventually(t, func() bool {
+		return checked.Load() == 2
+	}, time.Second, 10*time.Millisecond)
+	syncing.Store(false)
+	publishHeight(t, eventBus, 3)
+
+	require.Eventually(t, func() bool {
+		return len(m.Snapshots()) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	_, created, _ := app.calls()
+	assert.Equal(t, []uint64{3}, created)
+}
+
+func TestSnapshotManager_UnsupportedApplication(t *testing.T) {
+	app := &orchestratedApp{}
+	m, eventBus := startSnapshotManager(t, app, 1, 1, nil)
+	assert.False(t, m.Enabled())
+
+	publishHeight(t, eventBus, 1)
+	time.Sleep(50 * time.Millisecond)
+
+	listed, created, pruned := app.calls()
+	assert.Zero(t, listed)
+	assert.Empty(t, created)
+	assert.Empty(t, pruned)
+	assert.Empty(t, m.Snapshots())
+}
+
+func TestSnapshotManager_Disabled(t *testing.T) {
+	app := &orchestratedApp{capabilities: []string{abci.CapabilitySnapshotOrchestration}}
+	m, eventBus := startSnapshotManager(t, app, 0, 1, nil)
+	assert.False(t, m.Enabled())
+
+	publishHeight(t, eventBus, 1)
+	time.Sleep(50 * time.Millisecond)
+
+	listed, created, _ := app.calls()
+	assert.Zero(t, listed)
+	assert.Empty(t, created)
+}
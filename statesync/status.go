@@ -0,0 +1,129 @@
+package statesync
+
+import (
+	"context"
+	"errors"
+
+	cmtsync "github.com/tendermint/tendermint/libs/sync"
+	"github.com/tendermint/tendermint/light"
+)
+
+// SyncState is a stage of the state sync of the node on startup.
+type SyncState string
+
+const (
+	// SyncStateInactive is the state of a node which does not state sync.
+	SyncStateInactive SyncState = ""
+	// SyncStateDiscovering is the state while waiting for peers to offer
+	// snapshots.
+	SyncStateDiscovering SyncState = "discovering"
+	// SyncStateRestoring is the state while restoring a snapshot.
+	SyncStateRestoring SyncState = "restoring"
+	// SyncStateDone is the state once a snapshot is restored.
+	SyncStateDone SyncState = "done"
+	// SyncStateFailed is the state once state sync gave up, until the node
+	// falls back to fast sync.
+	SyncStateFailed SyncState = "failed"
+	// SyncStateFallback is the state of a node which gave up on state sync and
+	// fell back to fast sync.
+	SyncStateFallback SyncState = "fast_sync_fallback"
+	// SyncStateHalted is the state of a node which could neither state sync
+	// nor fall back to fast sync, and must be reset.
+	SyncStateHalted SyncState = "halted"
+)
+
+// The reasons for a state sync failure, see FailureReason.
+const (
+	FailureReasonTimeout     = "timeout"
+	FailureReasonMaxAttempts = "max_attempts"
+	FailureReasonNoSnapshots = "no_snapshots"
+	FailureReasonNoWitnesses = "no_witnesses"
+	FailureReasonAborted     = "aborted_by_app"
+	FailureReasonCanceled    = "canceled"
+	FailureReasonFailed      = "restore_failed"
+)
+
+var (
+	// errSyncTimeout is returned by Sync() when the state sync timeout expires.
+	errSyncTimeout = errors.New("state sync timed out")
+	// errMaxAttempts is returned by SyncAny() when the maximum number of
+	// snapshot restorations failed.
+	errMaxAttempts = errors.New("too many failed snapshot restorations")
+)
+
+// FailureReason returns a short, stable reason for the state sync failure err,
+// to be logged and reported.
+func FailureReason(err error) string {
+	switch {
+	case errors.Is(err, errSyncTimeout):
+		return FailureReasonTimeout
+	case errors.Is(err, errMaxAttempts):
+		return FailureReasonMaxAttempts
+	case errors.Is(err, errNoSnapshots):
+		return FailureReasonNoSnapshots
+	case errors.Is(err, light.ErrNoWitnesses):
+		return FailureReasonNoWitnesses
+	case errors.Is(err, errAbort):
+		return FailureReasonAborted
+	case errors.Is(err, context.Canceled):
+		return FailureReasonCanceled
+	default:
+		return FailureReasonFailed
+	}
+}
+
+// Status is the progress of the state sync of the node.
+type Status struct {
+	State SyncState
+	// Attempts is the number of snapshot restorations attempted.
+	Attempts uint32
+	// SnapshotHeight is the height of the snapshot being, or last, restored.
+	SnapshotHeight uint64
+	// FailureReason is the reason why state sync gave up, see FailureReason.
+	FailureReason string
+	// Error is the error which halted the node, if it is halted.
+	Error string
+}
+
+// statusTracker keeps the Status of a state sync, safe for concurrent use.
+type statusTracker struct {
+	mtx    cmtsync.RWMutex
+	status Status
+}
+
+func (t *statusTracker) get() Status {
+	t.mtx.RLock()
+	defer t.mtx.RUnlock()
+	return t.status
+}
+
+func (t *statusTracker) setState(state SyncState) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	t.status.State = state
+}
+
+// restoring records the attempt to restore the snapshot at height, and
+// returns the number of attempts so far.
+func (t *statusTracker) restoring(height uint64) uint32 {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	t.status.State = SyncStateRestoring
+	t.status.Attempts++
+	t.status.SnapshotHeight = height
+	return t.status.Attempts
+}
+
+func (t *statusTracker) halted(err error) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	t.status.State = SyncStateHalted
+	t.status.Error = err.Error()
+}
+
+func (t *statusTracker) failed(err error) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	t.status.State = SyncStateFailed
+	t.status.FailureReason = FailureReason(err)
+}
@@ -59,6 +59,8 @@ type syncer struct {
 	tempDir       string
 	chunkFetchers int32
 	retryTimeout  time.Duration
+	maxAttempts   uint32
+	status        *statusTracker
 
 	mtx    cmtsync.RWMutex
 	chunks *chunkQueue
@@ -83,6 +85,8 @@ func newSyncer(
 		tempDir:       tempDir,
 		chunkFetchers: cfg.ChunkFetchers,
 		retryTimeout:  cfg.ChunkRequestTimeout,
+		maxAttempts:   cfg.MaxAttempts,
+		status:        &statusTracker{},
 	}
 }
 
@@ -141,15 +145,23 @@ func (s *syncer) RemovePeer(peer p2p.Peer) {
 
 // SyncAny tries to sync any of the snapshots in the snapshot pool, waiting to discover further
 // snapshots if none were found and discoveryTime > 0. It returns the latest state and block commit
-// which the caller must use to bootstrap the node.
-func (s *syncer) SyncAny(discoveryTime time.Duration, retryHook func()) (sm.State, *types.Commit, error) {
+// which the caller must use to bootstrap the node. It gives up when ctx is done, or after
+// maxAttempts failed snapshot restorations if maxAttempts > 0, returning an error.
+func (s *syncer) SyncAny(
+	ctx context.Context,
+	discoveryTime time.Duration,
+	retryHook func(),
+) (sm.State, *types.Commit, error) {
 	if discoveryTime != 0 && discoveryTime < minimumDiscoveryTime {
 		discoveryTime = 5 * minimumDiscoveryTime
 	}
 
+	s.status.setState(SyncStateDiscovering)
 	if discoveryTime > 0 {
 		s.logger.Info("sync any", "msg", log.NewLazySprintf("Discovering snapshots for %v", discoveryTime))
-		time.Sleep(discoveryTime)
+		if err := sleepCtx(ctx, discoveryTime); err != nil {
+			return sm.State{}, nil, err
+		}
 	}
 
 	// The app may ask us to retry a snapshot restoration, in which case we need to reuse
@@ -171,7 +183,10 @@ func (s *syncer) SyncAny(discoveryTime time.Duration, retryHook func()) (sm.Stat
 			}
 			retryHook()
 			s.logger.Info("sync any", "msg", log.NewLazySprintf("Discovering snapshots for %v", discoveryTime))
-			time.Sleep(discoveryTime)
+			s.status.setState(SyncStateDiscovering)
+			if err := sleepCtx(ctx, discoveryTime); err != nil {
+				return sm.State{}, nil, err
+			}
 			continue
 		}
 		if chunks == nil {
@@ -182,14 +197,21 @@ func (s *syncer) SyncAny(discoveryTime time.Duration, retryHook func()) (sm.Stat
 			defer chunks.Close() // in case we forget to close it elsewhere
 		}
 
-		newState, commit, err := s.Sync(snapshot, chunks)
+		attempts := s.status.restoring(snapshot.Height)
+		newState, commit, err := s.Sync(ctx, snapshot, chunks)
 		switch {
 		case err == nil:
 			return newState, commit, nil
 
+		case ctx.Err() != nil:
+			return sm.State{}, nil, ctx.Err()
+
 		case errors.Is(err, errAbort):
 			return sm.State{}, nil, err
 
+		case s.maxAttempts > 0 && attempts >= s.maxAttempts:
+			return sm.State{}, nil, fmt.Errorf("%w: %d attempts, last error: %v", errMaxAttempts, attempts, err)
+
 		case errors.Is(err, errRetrySnapshot):
 			chunks.RetryAll()
 			s.logger.Info("Retrying snapshot", "height", snapshot.Height, "format", snapshot.Format,
@@ -237,8 +259,9 @@ func (s *syncer) SyncAny(discoveryTime time.Duration, retryHook func()) (sm.Stat
 }
 
 // Sync executes a sync for a specific snapshot, returning the latest state and block commit which
-// the caller must use to bootstrap the node.
-func (s *syncer) Sync(snapshot *snapshot, chunks *chunkQueue) (sm.State, *types.Commit, error) {
+// the caller must use to bootstrap the node. When ctx is done, it closes the chunk queue and
+// returns the error of ctx.
+func (s *syncer) Sync(ctx context.Context, snapshot *snapshot, chunks *chunkQueue) (sm.State, *types.Commit, error) {
 	s.mtx.Lock()
 	if s.chunks != nil {
 		s.mtx.Unlock()
@@ -252,7 +275,19 @@ func (s *syncer) Sync(snapshot *snapshot, chunks *chunkQueue) (sm.State, *types.
 		s.mtx.Unlock()
 	}()
 
-	hctx, cancel := context.WithTimeout(context.TODO(), 30*time.Second)
+	// Unblock the restoration if the sync is cancelled, the chunk queue can't be reused anyway.
+	syncCtx, syncCancel := context.WithCancel(ctx)
+	defer syncCancel()
+	go func() {
+		<-syncCtx.Done()
+		if ctx.Err() != nil {
+			if err := chunks.Close(); err != nil {
+				s.logger.Error("Failed to clean up chunk queue", "err", err)
+			}
+		}
+	}()
+
+	hctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
 	appHash, err := s.stateProvider.AppHash(hctx, snapshot.Height)
@@ -272,13 +307,13 @@ func (s *syncer) Sync(snapshot *snapshot, chunks *chunkQueue) (sm.State, *types.
 	}
 
 	// Spawn chunk fetchers. They will terminate when the chunk queue is closed or context cancelled.
-	fetchCtx, cancel := context.WithCancel(context.TODO())
+	fetchCtx, cancel := context.WithCancel(ctx)
 	defer cancel()
 	for i := int32(0); i < s.chunkFetchers; i++ {
 		go s.fetchChunks(fetchCtx, snapshot, chunks)
 	}
 
-	pctx, pcancel := context.WithTimeout(context.TODO(), 30*time.Second)
+	pctx, pcancel := context.WithTimeout(ctx, 30*time.Second)
 	defer pcancel()
 
 	// Optimistically build new state, so we don't discover any light client failures at the end.
@@ -300,15 +335,18 @@ func (s *syncer) Sync(snapshot *snapshot, chunks *chunkQueue) (sm.State, *types.
 	}
 
 	// Restore snapshot
-	err = s.applyChunks(chunks)
+	err = s.applyChunks(ctx, chunks)
 	if err != nil {
 		return sm.State{}, nil, err
 	}
 
 	// Verify app and app version
-	if err := s.verifyApp(snapshot, state.Version.Consensus.App); err != nil {
+	constraints, err := s.verifyApp(snapshot, state.Version.Consensus.App)
+	if err != nil {
 		return sm.State{}, nil, err
 	}
+	// the node did not run InitChain, the app reports its evidence constraints
+	state.EvidenceConstraints = constraints
 
 	// Done! 🎉
 	s.logger.Info("Snapshot restored", "height", snapshot.Height, "format", snapshot.Format,
@@ -355,9 +393,13 @@ func (s *syncer) offerSnapshot(snapshot *snapshot) error {
 
 // applyChunks applies chunks to the app. It returns various errors depending on the app's
 // response, or nil once the snapshot is fully restored.
-func (s *syncer) applyChunks(chunks *chunkQueue) error {
+func (s *syncer) applyChunks(ctx context.Context, chunks *chunkQueue) error {
 	for {
 		chunk, err := chunks.Next()
+		if ctx.Err() != nil {
+			// the queue was closed, not done
+			return ctx.Err()
+		}
 		if err == errDone {
 			return nil
 		} else if err != nil {
@@ -481,11 +523,21 @@ func (s *syncer) requestChunk(snapshot *snapshot, chunk uint32) {
 	}, s.logger)
 }
 
-// verifyApp verifies the sync, checking the app hash, last block height and app version
-func (s *syncer) verifyApp(snapshot *snapshot, appVersion uint64) error {
-	resp, err := s.connQuery.InfoSync(proxy.RequestInfo)
+// verifyApp verifies the sync, checking the app hash, last block height and app version.
+// It returns the evidence constraints reported by the app.
+func (s *syncer) verifyApp(snapshot *snapshot, appVersion uint64) (*abci.EvidenceConstraints, error) {
+	return verifyAppState(s.connQuery, s.logger, snapshot.Height, snapshot.trustedAppHash, appVersion)
+}
+
+// verifyAppState checks that the app reports the given app hash, app version
+// and last block height. It returns the evidence constraints the app reports,
+// nil if it declared none.
+func verifyAppState(connQuery proxy.AppConnQuery, logger log.Logger,
+	height uint64, trustedAppHash []byte, appVersion uint64,
+) (*abci.EvidenceConstraints, error) {
+	resp, err := connQuery.InfoSync(proxy.RequestInfo)
 	if err != nil {
-		return fmt.Errorf("failed to query ABCI app for appHash: %w", err)
+		return nil, fmt.Errorf("failed to query ABCI app for appHash: %w", err)
 	}
 
 	// sanity check that the app version in the block matches the application's own record
@@ -493,24 +545,36 @@ func (s *syncer) verifyApp(snapshot *snapshot, appVersion uint64) error {
 	if resp.AppVersion != appVersion {
 		// An error here most likely means that the app hasn't inplemented state sync
 		// or the Info call correctly
-		return fmt.Errorf("app version mismatch. Expected: %d, got: %d",
+		return nil, fmt.Errorf("app version mismatch. Expected: %d, got: %d",
 			appVersion, resp.AppVersion)
 	}
-	if !bytes.Equal(snapshot.trustedAppHash, resp.LastBlockAppHash) {
-		s.logger.Error("appHash verification failed",
-			"expected", snapshot.trustedAppHash,
+	if !bytes.Equal(trustedAppHash, resp.LastBlockAppHash) {
+		logger.Error("appHash verification failed",
+			"expected", trustedAppHash,
 			"actual", resp.LastBlockAppHash)
-		return errVerifyFailed
+		return nil, errVerifyFailed
 	}
-	if uint64(resp.LastBlockHeight) != snapshot.Height {
-		s.logger.Error(
+	if uint64(resp.LastBlockHeight) != height {
+		logger.Error(
 			"ABCI app reported unexpected last block height",
-			"expected", snapshot.Height,
+			"expected", height,
 			"actual", resp.LastBlockHeight,
 		)
-		return errVerifyFailed
+		return nil, errVerifyFailed
 	}
 
-	s.logger.Info("Verified ABCI app", "height", snapshot.Height, "appHash", snapshot.trustedAppHash)
-	return nil
+	logger.Info("Verified ABCI app", "height", height, "appHash", trustedAppHash)
+	return resp.EvidenceConstraints, nil
+}
+
+// sleepCtx sleeps for d, or until ctx is done, in which case it returns the error of ctx.
+func sleepCtx(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
 }
@@ -1,6 +1,7 @@
 package statesync
 
 import (
+	"context"
 	"errors"
 	"testing"
 	"time"
@@ -205,13 +206,15 @@ func TestSyncer_SyncAny(t *testing.T) {
 	connSnapshot.On("ApplySnapshotChunkSync", abci.RequestApplySnapshotChunk{
 		Index: 2, Chunk: []byte{1, 1, 2},
 	}).Once().Return(&abci.ResponseApplySnapshotChunk{Result: abci.ResponseApplySnapshotChunk_ACCEPT}, nil)
+	constraints := &abci.EvidenceConstraints{MinMaxAgeNumBlocks: 100}
 	connQuery.On("InfoSync", proxy.RequestInfo).Return(&abci.ResponseInfo{
-		AppVersion:       testAppVersion,
-		LastBlockHeight:  1,
-		LastBlockAppHash: []byte("app_hash"),
+		AppVersion:          testAppVersion,
+		LastBlockHeight:     1,
+		LastBlockAppHash:    []byte("app_hash"),
+		EvidenceConstraints: constraints,
 	}, nil)
 
-	newState, lastCommit, err := syncer.SyncAny(0, func() {})
+	newState, lastCommit, err := syncer.SyncAny(context.Background(), 0, func() {})
 	require.NoError(t, err)
 
 	time.Sleep(50 * time.Millisecond) // wait for peers to receive requests
@@ -221,6 +224,7 @@ func TestSyncer_SyncAny(t *testing.T) {
 	chunkRequestsMtx.Unlock()
 
 	expectState := state
+	expectState.EvidenceConstraints = constraints
 
 	assert.Equal(t, expectState, newState)
 	assert.Equal(t, commit, lastCommit)
@@ -233,10 +237,44 @@ func TestSyncer_SyncAny(t *testing.T) {
 
 func TestSyncer_SyncAny_noSnapshots(t *testing.T) {
 	syncer, _ := setupOfferSyncer(t)
-	_, _, err := syncer.SyncAny(0, func() {})
+	_, _, err := syncer.SyncAny(context.Background(), 0, func() {})
 	assert.Equal(t, errNoSnapshots, err)
 }
 
+func TestSyncer_SyncAny_maxAttempts(t *testing.T) {
+	syncer, connSnapshot := setupOfferSyncer(t)
+	syncer.maxAttempts = 2
+
+	// the snapshots are rejected one after the other, until the attempts run out
+	for height := uint64(1); height <= 3; height++ {
+		s := &snapshot{Height: height, Format: 1, Chunks: 3, Hash: []byte{1, 2, 3}}
+		_, err := syncer.AddSnapshot(simplePeer("id"), s)
+		require.NoError(t, err)
+		if height > 1 {
+			connSnapshot.On("OfferSnapshotSync", abci.RequestOfferSnapshot{
+				Snapshot: toABCI(s), AppHash: []byte("app_hash"),
+			}).Once().Return(&abci.ResponseOfferSnapshot{Result: abci.ResponseOfferSnapshot_REJECT}, nil)
+		}
+	}
+
+	_, _, err := syncer.SyncAny(context.Background(), 0, func() {})
+	assert.ErrorIs(t, err, errMaxAttempts)
+	assert.Equal(t, FailureReasonMaxAttempts, FailureReason(err))
+	assert.Equal(t, Status{State: SyncStateRestoring, Attempts: 2, SnapshotHeight: 2}, syncer.status.get())
+	connSnapshot.AssertExpectations(t)
+}
+
+func TestSyncer_SyncAny_canceled(t *testing.T) {
+	syncer, _ := setupOfferSyncer(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	start := time.Now()
+	_, _, err := syncer.SyncAny(ctx, time.Minute, func() {})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Less(t, time.Since(start), time.Minute)
+}
+
 func TestSyncer_SyncAny_abort(t *testing.T) {
 	syncer, connSnapshot := setupOfferSyncer(t)
 
@@ -247,7 +285,7 @@ func TestSyncer_SyncAny_abort(t *testing.T) {
 		Snapshot: toABCI(s), AppHash: []byte("app_hash"),
 	}).Once().Return(&abci.ResponseOfferSnapshot{Result: abci.ResponseOfferSnapshot_ABORT}, nil)
 
-	_, _, err = syncer.SyncAny(0, func() {})
+	_, _, err = syncer.SyncAny(context.Background(), 0, func() {})
 	assert.Equal(t, errAbort, err)
 	connSnapshot.AssertExpectations(t)
 }
@@ -278,7 +316,7 @@ func TestSyncer_SyncAny_reject(t *testing.T) {
 		Snapshot: toABCI(s11), AppHash: []byte("app_hash"),
 	}).Once().Return(&abci.ResponseOfferSnapshot{Result: abci.ResponseOfferSnapshot_REJECT}, nil)
 
-	_, _, err = syncer.SyncAny(0, func() {})
+	_, _, err = syncer.SyncAny(context.Background(), 0, func() {})
 	assert.Equal(t, errNoSnapshots, err)
 	connSnapshot.AssertExpectations(t)
 }
@@ -305,7 +343,7 @@ func TestSyncer_SyncAny_reject_format(t *testing.T) {
 		Snapshot: toABCI(s11), AppHash: []byte("app_hash"),
 	}).Once().Return(&abci.ResponseOfferSnapshot{Result: abci.ResponseOfferSnapshot_ABORT}, nil)
 
-	_, _, err = syncer.SyncAny(0, func() {})
+	_, _, err = syncer.SyncAny(context.Background(), 0, func() {})
 	assert.Equal(t, errAbort, err)
 	connSnapshot.AssertExpectations(t)
 }
@@ -343,7 +381,7 @@ func TestSyncer_SyncAny_reject_sender(t *testing.T) {
 		Snapshot: toABCI(sa), AppHash: []byte("app_hash"),
 	}).Once().Return(&abci.ResponseOfferSnapshot{Result: abci.ResponseOfferSnapshot_REJECT}, nil)
 
-	_, _, err = syncer.SyncAny(0, func() {})
+	_, _, err = syncer.SyncAny(context.Background(), 0, func() {})
 	assert.Equal(t, errNoSnapshots, err)
 	connSnapshot.AssertExpectations(t)
 }
@@ -359,7 +397,7 @@ func TestSyncer_SyncAny_abciError(t *testing.T) {
 		Snapshot: toABCI(s), AppHash: []byte("app_hash"),
 	}).Once().Return(nil, errBoom)
 
-	_, _, err = syncer.SyncAny(0, func() {})
+	_, _, err = syncer.SyncAny(context.Background(), 0, func() {})
 	assert.True(t, errors.Is(err, errBoom))
 	connSnapshot.AssertExpectations(t)
 }
@@ -450,7 +488,7 @@ func TestSyncer_applyChunks_Results(t *testing.T) {
 					Result: abci.ResponseApplySnapshotChunk_ACCEPT}, nil)
 			}
 
-			err = syncer.applyChunks(chunks)
+			err = syncer.applyChunks(context.Background(), chunks)
 			if tc.expectErr == unknownErr {
 				require.Error(t, err)
 			} else {
@@ -517,7 +555,7 @@ func TestSyncer_applyChunks_RefetchChunks(t *testing.T) {
 			// check the queue contents, and finally close the queue to end the goroutine.
 			// We don't really care about the result of applyChunks, since it has separate test.
 			go func() {
-				syncer.applyChunks(chunks) //nolint:errcheck // purposefully ignore error
+				syncer.applyChunks(context.Background(), chunks) //nolint:errcheck // purposefully ignore error
 			}()
 
 			time.Sleep(50 * time.Millisecond)
@@ -610,7 +648,7 @@ func TestSyncer_applyChunks_RejectSenders(t *testing.T) {
 			// However, it will block on e.g. retry result, so we spawn a goroutine that will
 			// be shut down when the chunk queue closes.
 			go func() {
-				syncer.applyChunks(chunks) //nolint:errcheck // purposefully ignore error
+				syncer.applyChunks(context.Background(), chunks) //nolint:errcheck // purposefully ignore error
 			}()
 
 			time.Sleep(50 * time.Millisecond)
@@ -647,6 +685,12 @@ func TestSyncer_verifyApp(t *testing.T) {
 			LastBlockAppHash: []byte("app_hash"),
 			AppVersion:       appVersion,
 		}, nil, nil},
+		"verified with evidence constraints": {&abci.ResponseInfo{
+			LastBlockHeight:     3,
+			LastBlockAppHash:    []byte("app_hash"),
+			AppVersion:          appVersion,
+			EvidenceConstraints: &abci.EvidenceConstraints{MinMaxAgeNumBlocks: 100},
+		}, nil, nil},
 		"invalid app version": {&abci.ResponseInfo{
 			LastBlockHeight:  3,
 			LastBlockAppHash: []byte("app_hash"),
@@ -675,12 +719,15 @@ func TestSyncer_verifyApp(t *testing.T) {
 			syncer := newSyncer(*cfg, log.NewNopLogger(), connSnapshot, connQuery, stateProvider, "")
 
 			connQuery.On("InfoSync", proxy.RequestInfo).Return(tc.response, tc.err)
-			err := syncer.verifyApp(s, appVersion)
+			constraints, err := syncer.verifyApp(s, appVersion)
 			unwrapped := errors.Unwrap(err)
 			if unwrapped != nil {
 				err = unwrapped
 			}
 			require.Equal(t, tc.expectErr, err)
+			if err == nil {
+				require.Equal(t, tc.response.EvidenceConstraints, constraints)
+			}
 		})
 	}
 }
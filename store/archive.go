@@ -0,0 +1,549 @@
+package store
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+
+	cmtstore "github.com/tendermint/tendermint/proto/tendermint/store"
+	"github.com/tendermint/tendermint/types"
+)
+
+/*
+A block archive holds a contiguous range of blocks, each with the commit for it,
+for archival outside of the node. Its layout is:
+
+	header  | magic "CMTBLKS1" | version (1 byte) | flags (1 byte)
+	records | length (4 bytes) | CRC-32C of the payload (4 bytes) | payload
+	index   | height (8 bytes) | offset of the record (8 bytes), per record
+	trailer | index offset (8 bytes) | index entries (8 bytes) | CRC-32C of the index (4 bytes) | magic "CMTBLKIX"
+
+where the payload of a record is a proto encoded BlockWithCommit, compressed
+with gzip if the flags say so, and integers are big endian. The index and the
+trailer are written once all the records are, so an archive without them is an
+interrupted export that can be resumed.
+*/
+
+const (
+	archiveMagic      = "CMTBLKS1"
+	archiveIndexMagic = "CMTBLKIX"
+	archiveVersion    = 1
+
+	// archiveFlagGzip is the flag of the archives whose record payloads are
+	// compressed with gzip.
+	archiveFlagGzip = 1 << 0
+
+	archiveHeaderSize       = len(archiveMagic) + 2
+	archiveRecordHeaderSize = 8
+	archiveIndexEntrySize   = 16
+	archiveTrailerSize      = 20 + len(archiveIndexMagic)
+
+	// maxArchiveRecordSize bounds the payload of a record, so that a corrupted
+	// length does not make the reader allocate an arbitrary amount of memory.
+	maxArchiveRecordSize = 2 * types.MaxBlockSizeBytes
+)
+
+var (
+	crc32c = crc32.MakeTable(crc32.Castagnoli)
+
+	// ErrCorruptedArchive is returned when reading an archive whose content
+	// does not match its checksums or its layout.
+	ErrCorruptedArchive = errors.New("corrupted block archive")
+)
+
+// ExportBlocks writes the blocks from height from to height to of the block
+// store, along with the commits for them, to the archive at path. If compress
+// is set, the records are compressed with gzip.
+//
+// If path holds an archive left incomplete by an interrupted export of the same
+// blocks, the export resumes after its last valid record.
+func ExportBlocks(bs *BlockStore, path string, from, to int64, compress bool) error {
+	if from <= 0 || from > to {
+		return fmt.Errorf("invalid height range [%d, %d]", from, to)
+	}
+	if base, height := bs.Base(), bs.Height(); from < base || to > height {
+		return fmt.Errorf("height range [%d, %d] is not within the stored blocks [%d, %d]", from, to, base, height)
+	}
+
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	flags := byte(0)
+	if compress {
+		flags |= archiveFlagGzip
+	}
+	index, end, err := resumeArchive(f, flags)
+	if err != nil {
+		return fmt.Errorf("failed to resume the export to %s: %w", path, err)
+	}
+	next := from
+	if len(index) > 0 {
+		first, last := index[0].height, index[len(index)-1].height
+		if first != from || last > to {
+			return fmt.Errorf("%s holds the blocks from height %d to %d, not part of [%d, %d]",
+				path, first, last, from, to)
+		}
+		next = last + 1
+	}
+
+	if err := f.Truncate(end); err != nil {
+		return err
+	}
+	if _, err := f.Seek(end, io.SeekStart); err != nil {
+		return err
+	}
+	w := bufio.NewWriter(f)
+	if end == 0 {
+		if _, err := w.Write(append([]byte(archiveMagic), archiveVersion, flags)); err != nil {
+			return err
+		}
+		end = int64(archiveHeaderSize)
+	}
+
+	for height := next; height <= to; height++ {
+		record, err := archiveRecord(bs, height)
+		if err != nil {
+			return err
+		}
+		n, err := writeArchiveRecord(w, record, compress)
+		if err != nil {
+			return fmt.Errorf("failed to write the block at height %d: %w", height, err)
+		}
+		index = append(index, archiveIndexEntry{height: height, offset: end})
+		end += n
+	}
+
+	if err := writeArchiveIndex(w, index, end); err != nil {
+		return err
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	return f.Sync()
+}
+
+// archiveRecord returns the record of the block at height. The commit for the
+// last block of the store is its seen commit, since no block commits to it yet.
+func archiveRecord(bs *BlockStore, height int64) (*cmtstore.BlockWithCommit, error) {
+	block := bs.LoadBlock(height)
+	if block == nil {
+		return nil, fmt.Errorf("no block found at height %d", height)
+	}
+	commit := bs.LoadBlockCommit(height)
+	if commit == nil {
+		commit = bs.LoadSeenCommit(height)
+	}
+	if commit == nil {
+		return nil, fmt.Errorf("no commit found for the block at height %d", height)
+	}
+	pbb, err := block.ToProto()
+	if err != nil {
+		return nil, err
+	}
+	return &cmtstore.BlockWithCommit{
+		Block:          pbb,
+		Commit:         commit.ToProto(),
+		ValidatorsHash: block.ValidatorsHash,
+	}, nil
+}
+
+// resumeArchive checks the archive in f, if any, and returns the index of its
+// valid records and the offset following them. An empty file, or one not
+// holding a full header, is started over.
+func resumeArchive(f *os.File, flags byte) ([]archiveIndexEntry, int64, error) {
+	info, err := f.Stat()
+	if err != nil {
+		return nil, 0, err
+	}
+	if info.Size() < int64(archiveHeaderSize) {
+		return nil, 0, nil
+	}
+	if _, _, err := readArchiveIndex(f, info.Size()); err == nil {
+		return nil, 0, errors.New("the archive is already complete")
+	}
+
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return nil, 0, err
+	}
+	r := bufio.NewReader(f)
+	fileFlags, err := readArchiveHeader(r)
+	if err != nil {
+		return nil, 0, err
+	}
+	if fileFlags != flags {
+		return nil, 0, fmt.Errorf("the archive flags %#x differ from the export flags %#x", fileFlags, flags)
+	}
+
+	var index []archiveIndexEntry
+	offset := int64(archiveHeaderSize)
+	for {
+		record, n, err := readArchiveRecord(r, flags&archiveFlagGzip != 0)
+		if err != nil {
+			// the records from the first invalid one on were left by the
+			// interruption, and are written again
+			return index, offset, nil
+		}
+		height := record.GetBlock().GetHeader().Height
+		if len(index) > 0 && height != index[len(index)-1].height+1 {
+			return index, offset, nil
+		}
+		index = append(index, archiveIndexEntry{height: height, offset: offset})
+		offset += n
+	}
+}
+
+// ImportBlocks saves the blocks of the archive at path to the block store, after
+// validating each of them against its commit and the previous block. The import
+// starts at the height following the last stored block, so an interrupted import
+// can be resumed. It returns the number of blocks saved.
+//
+// NOTE: the signatures of the commits are not verified, as the archive does not
+// hold the validator sets. The archive is only checked to form a chain of blocks
+// with matching commits, and must come from a trusted source.
+func ImportBlocks(bs *BlockStore, path string) (int64, error) {
+	archive, err := OpenArchive(path)
+	if err != nil {
+		return 0, err
+	}
+	defer archive.Close()
+
+	from := archive.Base()
+	var (
+		prevHeader  *types.Header
+		prevBlockID types.BlockID
+	)
+	if height := bs.Height(); height > 0 {
+		if height >= archive.Height() {
+			return 0, nil
+		}
+		if height+1 < from {
+			return 0, fmt.Errorf("the archive starts at height %d, after the next stored height %d", from, height+1)
+		}
+		meta := bs.LoadBlockMeta(height)
+		if meta == nil {
+			return 0, fmt.Errorf("no block meta found at height %d", height)
+		}
+		prevHeader, prevBlockID = &meta.Header, meta.BlockID
+		from = height + 1
+	}
+
+	var imported int64
+	for height := from; height <= archive.Height(); height++ {
+		block, commit, err := archive.LoadBlock(height)
+		if err != nil {
+			return imported, err
+		}
+		parts, err := validateArchivedBlock(block, commit, prevHeader, prevBlockID)
+		if err != nil {
+			return imported, fmt.Errorf("invalid block at height %d: %w", height, err)
+		}
+		bs.SaveBlock(block, parts, commit)
+		imported++
+		prevHeader, prevBlockID = &block.Header, commit.BlockID
+	}
+	return imported, nil
+}
+
+// validateArchivedBlock checks that the commit is for the block, and that the
+// block follows the previous one, if any. It returns the parts of the block.
+func validateArchivedBlock(
+	block *types.Block,
+	commit *types.Commit,
+	prevHeader *types.Header,
+	prevBlockID types.BlockID,
+) (*types.PartSet, error) {
+	if err := block.ValidateBasic(); err != nil {
+		return nil, err
+	}
+	if err := commit.ValidateBasic(); err != nil {
+		return nil, fmt.Errorf("invalid commit: %w", err)
+	}
+	if commit.Height != block.Height {
+		return nil, fmt.Errorf("commit is for height %d", commit.Height)
+	}
+	parts := block.MakePartSet(types.BlockPartSizeBytes)
+	if blockID := (types.BlockID{Hash: block.Hash(), PartSetHeader: parts.Header()}); !commit.BlockID.Equals(blockID) {
+		return nil, fmt.Errorf("commit is for block %v, not %v", commit.BlockID, blockID)
+	}
+
+	if prevHeader == nil {
+		return parts, nil
+	}
+	if block.ChainID != prevHeader.ChainID {
+		return nil, fmt.Errorf("chain ID %q differs from the previous %q", block.ChainID, prevHeader.ChainID)
+	}
+	if block.Height != prevHeader.Height+1 {
+		return nil, fmt.Errorf("does not follow the previous height %d", prevHeader.Height)
+	}
+	if !block.LastBlockID.Equals(prevBlockID) {
+		return nil, fmt.Errorf("last block ID %v differs from the previous block %v", block.LastBlockID, prevBlockID)
+	}
+	if !bytes.Equal(block.ValidatorsHash, prevHeader.NextValidatorsHash) {
+		return nil, fmt.Errorf("validators hash %X differs from the next validators hash %X of the previous block",
+			block.ValidatorsHash, prevHeader.NextValidatorsHash)
+	}
+	return parts, nil
+}
+
+// ArchiveReader reads the blocks of a complete archive, in any order.
+type ArchiveReader struct {
+	file       *os.File
+	compressed bool
+	index      []archiveIndexEntry
+}
+
+// OpenArchive opens the archive at path for reading. The archive must be
+// complete, i.e. hold its index.
+func OpenArchive(path string) (*ArchiveReader, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	ar, err := newArchiveReader(f)
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("failed to open archive %s: %w", path, err)
+	}
+	return ar, nil
+}
+
+func newArchiveReader(f *os.File) (*ArchiveReader, error) {
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	flags, err := readArchiveHeader(f)
+	if err != nil {
+		return nil, err
+	}
+	index, indexOffset, err := readArchiveIndex(f, info.Size())
+	if err != nil {
+		return nil, err
+	}
+	for i, entry := range index {
+		if entry.offset < int64(archiveHeaderSize) || entry.offset >= indexOffset ||
+			(i > 0 && (entry.height != index[i-1].height+1 || entry.offset <= index[i-1].offset)) {
+			return nil, fmt.Errorf("%w: invalid index entry %d", ErrCorruptedArchive, i)
+		}
+	}
+	return &ArchiveReader{
+		file:       f,
+		compressed: flags&archiveFlagGzip != 0,
+		index:      index,
+	}, nil
+}
+
+// Base returns the height of the first block of the archive, or 0 if it is
+// empty.
+func (ar *ArchiveReader) Base() int64 {
+	if len(ar.index) == 0 {
+		return 0
+	}
+	return ar.index[0].height
+}
+
+// Height returns the height of the last block of the archive, or 0 if it is
+// empty.
+func (ar *ArchiveReader) Height() int64 {
+	if len(ar.index) == 0 {
+		return 0
+	}
+	return ar.index[len(ar.index)-1].height
+}
+
+// LoadBlock returns the block at height and the commit for it.
+func (ar *ArchiveReader) LoadBlock(height int64) (*types.Block, *types.Commit, error) {
+	if height < ar.Base() || height > ar.Height() {
+		return nil, nil, fmt.Errorf("height %d is not within the archived blocks [%d, %d]",
+			height, ar.Base(), ar.Height())
+	}
+	entry := ar.index[height-ar.Base()]
+	r := io.NewSectionReader(ar.file, entry.offset, 1<<62)
+	record, _, err := readArchiveRecord(r, ar.compressed)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read the block at height %d: %w", height, err)
+	}
+
+	block, err := types.BlockFromProto(record.Block)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: invalid block at height %d: %v", ErrCorruptedArchive, height, err)
+	}
+	if block.Height != height {
+		return nil, nil, fmt.Errorf("%w: index points to the block at height %d for height %d",
+			ErrCorruptedArchive, block.Height, height)
+	}
+	commit, err := types.CommitFromProto(record.Commit)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: invalid commit at height %d: %v", ErrCorruptedArchive, height, err)
+	}
+	if !bytes.Equal(record.ValidatorsHash, block.ValidatorsHash) {
+		return nil, nil, fmt.Errorf("%w: validators hash %X of the record at height %d differs from the header's %X",
+			ErrCorruptedArchive, record.ValidatorsHash, height, block.ValidatorsHash)
+	}
+	return block, commit, nil
+}
+
+// Close closes the archive file.
+func (ar *ArchiveReader) Close() error {
+	return ar.file.Close()
+}
+
+// archiveIndexEntry is the offset in the archive of the record of a block.
+type archiveIndexEntry struct {
+	height int64
+	offset int64
+}
+
+func readArchiveHeader(r io.Reader) (byte, error) {
+	header := make([]byte, archiveHeaderSize)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return 0, fmt.Errorf("%w: failed to read the header: %v", ErrCorruptedArchive, err)
+	}
+	if string(header[:len(archiveMagic)]) != archiveMagic {
+		return 0, fmt.Errorf("%w: not a block archive", ErrCorruptedArchive)
+	}
+	if version := header[len(archiveMagic)]; version != archiveVersion {
+		return 0, fmt.Errorf("unsupported archive version %d", version)
+	}
+	return header[len(archiveMagic)+1], nil
+}
+
+// writeArchiveRecord writes the record to w and returns the number of bytes
+// written.
+func writeArchiveRecord(w io.Writer, record *cmtstore.BlockWithCommit, compress bool) (int64, error) {
+	payload, err := record.Marshal()
+	if err != nil {
+		return 0, err
+	}
+	if compress {
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		if _, err := zw.Write(payload); err != nil {
+			return 0, err
+		}
+		if err := zw.Close(); err != nil {
+			return 0, err
+		}
+		payload = buf.Bytes()
+	}
+	if len(payload) > maxArchiveRecordSize {
+		return 0, fmt.Errorf("record of %d bytes exceeds the maximum of %d", len(payload), maxArchiveRecordSize)
+	}
+
+	header := make([]byte, archiveRecordHeaderSize)
+	binary.BigEndian.PutUint32(header[:4], uint32(len(payload)))
+	binary.BigEndian.PutUint32(header[4:], crc32.Checksum(payload, crc32c))
+	if _, err := w.Write(header); err != nil {
+		return 0, err
+	}
+	if _, err := w.Write(payload); err != nil {
+		return 0, err
+	}
+	return int64(archiveRecordHeaderSize + len(payload)), nil
+}
+
+// readArchiveRecord reads the record at the position of r and returns it along
+// with its size in bytes.
+func readArchiveRecord(r io.Reader, compressed bool) (*cmtstore.BlockWithCommit, int64, error) {
+	header := make([]byte, archiveRecordHeaderSize)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, 0, fmt.Errorf("%w: failed to read the record header: %v", ErrCorruptedArchive, err)
+	}
+	size := binary.BigEndian.Uint32(header[:4])
+	if size > maxArchiveRecordSize {
+		return nil, 0, fmt.Errorf("%w: record of %d bytes exceeds the maximum of %d",
+			ErrCorruptedArchive, size, maxArchiveRecordSize)
+	}
+	payload := make([]byte, size)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		return nil, 0, fmt.Errorf("%w: failed to read the record: %v", ErrCorruptedArchive, err)
+	}
+	if crc32.Checksum(payload, crc32c) != binary.BigEndian.Uint32(header[4:]) {
+		return nil, 0, fmt.Errorf("%w: record checksum mismatch", ErrCorruptedArchive)
+	}
+
+	if compressed {
+		zr, err := gzip.NewReader(bytes.NewReader(payload))
+		if err != nil {
+			return nil, 0, fmt.Errorf("%w: %v", ErrCorruptedArchive, err)
+		}
+		if payload, err = io.ReadAll(zr); err != nil {
+			return nil, 0, fmt.Errorf("%w: %v", ErrCorruptedArchive, err)
+		}
+	}
+	record := new(cmtstore.BlockWithCommit)
+	if err := record.Unmarshal(payload); err != nil {
+		return nil, 0, fmt.Errorf("%w: %v", ErrCorruptedArchive, err)
+	}
+	if record.Block == nil || record.Commit == nil {
+		return nil, 0, fmt.Errorf("%w: record without a block or a commit", ErrCorruptedArchive)
+	}
+	return record, int64(archiveRecordHeaderSize) + int64(size), nil
+}
+
+// writeArchiveIndex writes the index of the records, followed by the trailer,
+// at indexOffset.
+func writeArchiveIndex(w io.Writer, index []archiveIndexEntry, indexOffset int64) error {
+	entries := make([]byte, len(index)*archiveIndexEntrySize)
+	for i, entry := range index {
+		binary.BigEndian.PutUint64(entries[i*archiveIndexEntrySize:], uint64(entry.height))
+		binary.BigEndian.PutUint64(entries[i*archiveIndexEntrySize+8:], uint64(entry.offset))
+	}
+	trailer := make([]byte, 0, archiveTrailerSize)
+	trailer = binary.BigEndian.AppendUint64(trailer, uint64(indexOffset))
+	trailer = binary.BigEndian.AppendUint64(trailer, uint64(len(index)))
+	trailer = binary.BigEndian.AppendUint32(trailer, crc32.Checksum(entries, crc32c))
+	trailer = append(trailer, archiveIndexMagic...)
+	if _, err := w.Write(entries); err != nil {
+		return err
+	}
+	_, err := w.Write(trailer)
+	return err
+}
+
+// readArchiveIndex reads the index from the trailer of the archive of size
+// bytes, and returns it along with its offset.
+func readArchiveIndex(r io.ReaderAt, size int64) ([]archiveIndexEntry, int64, error) {
+	if size < int64(archiveHeaderSize+archiveTrailerSize) {
+		return nil, 0, fmt.Errorf("%w: no index", ErrCorruptedArchive)
+	}
+	trailer := make([]byte, archiveTrailerSize)
+	if _, err := r.ReadAt(trailer, size-int64(archiveTrailerSize)); err != nil {
+		return nil, 0, err
+	}
+	if string(trailer[20:]) != archiveIndexMagic {
+		return nil, 0, fmt.Errorf("%w: no index", ErrCorruptedArchive)
+	}
+	indexOffset := int64(binary.BigEndian.Uint64(trailer[:8]))
+	count := binary.BigEndian.Uint64(trailer[8:16])
+	indexSize := size - int64(archiveTrailerSize) - indexOffset
+	if indexOffset < int64(archiveHeaderSize) || indexSize < 0 ||
+		count > uint64(indexSize)/archiveIndexEntrySize || int64(count)*archiveIndexEntrySize != indexSize {
+		return nil, 0, fmt.Errorf("%w: invalid index of %d entries at offset %d", ErrCorruptedArchive, count, indexOffset)
+	}
+
+	entries := make([]byte, indexSize)
+	if _, err := r.ReadAt(entries, indexOffset); err != nil {
+		return nil, 0, err
+	}
+	if crc32.Checksum(entries, crc32c) != binary.BigEndian.Uint32(trailer[16:20]) {
+		return nil, 0, fmt.Errorf("%w: index checksum mismatch", ErrCorruptedArchive)
+	}
+	index := make([]archiveIndexEntry, count)
+	for i := range index {
+		index[i] = archiveIndexEntry{
+			height: int64(binary.BigEndian.Uint64(entries[i*archiveIndexEntrySize:])),
+			offset: int64(binary.BigEndian.Uint64(entries[i*archiveIndexEntrySize+8:])),
+		}
+	}
+	return index, indexOffset, nil
+}
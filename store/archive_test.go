@@ -0,0 +1,234 @@
+package store
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	dbm "github.com/cometbft/cometbft-db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/libs/log"
+	cmtrand "github.com/tendermint/tendermint/libs/rand"
+	"github.com/tendermint/tendermint/types"
+	cmttime "github.com/tendermint/tendermint/types/time"
+)
+
+// makeChain saves a chain of height blocks, each committed by the next one, to
+// a new block store.
+func makeChain(t *testing.T, height int64) *BlockStore {
+	state, bs, cleanup := makeStateAndBlockStore(log.NewNopLogger())
+	t.Cleanup(cleanup)
+
+	lastCommit := new(types.Commit)
+	for h := int64(1); h <= height; h++ {
+		block := makeBlock(h, state, lastCommit)
+		parts := block.MakePartSet(types.BlockPartSizeBytes)
+		blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: parts.Header()}
+		commit := types.NewCommit(h, 0, blockID, []types.CommitSig{{
+			BlockIDFlag:      types.BlockIDFlagCommit,
+			ValidatorAddress: cmtrand.Bytes(crypto.AddressSize),
+			Timestamp:        cmttime.Now(),
+			Signature:        []byte("Signature"),
+		}})
+		bs.SaveBlock(block, parts, commit)
+
+		state.LastBlockID = blockID
+		state.LastBlockHeight = h
+		lastCommit = commit
+	}
+	return bs
+}
+
+func TestExportImportBlocks(t *testing.T) {
+	const height = 1000
+	bs := makeChain(t, height)
+
+	for _, compress := range []bool{false, true} {
+		path := filepath.Join(t.TempDir(), "blocks.dat")
+		require.NoError(t, ExportBlocks(bs, path, 1, height, compress))
+
+		imported := NewBlockStore(dbm.NewMemDB())
+		n, err := ImportBlocks(imported, path)
+		require.NoError(t, err)
+		assert.EqualValues(t, height, n)
+		assert.EqualValues(t, 1, imported.Base())
+		assert.EqualValues(t, height, imported.Height())
+		for h := int64(1); h <= height; h++ {
+			require.Equal(t, bs.LoadBlockMeta(h), imported.LoadBlockMeta(h), h)
+			require.Equal(t, bs.LoadBlockCommit(h), imported.LoadBlockCommit(h), h)
+		}
+		assert.Equal(t, bs.LoadSeenCommit(height), imported.LoadSeenCommit(height))
+
+		// the blocks can be read in any order
+		archive, err := OpenArchive(path)
+		require.NoError(t, err)
+		assert.EqualValues(t, 1, archive.Base())
+		assert.EqualValues(t, height, archive.Height())
+		for _, h := range []int64{height, 1, 500} {
+			block, commit, err := archive.LoadBlock(h)
+			require.NoError(t, err)
+			assert.Equal(t, bs.LoadBlock(h).Hash(), block.Hash())
+			assert.EqualValues(t, h, commit.Height)
+		}
+		_, _, err = archive.LoadBlock(height + 1)
+		assert.Error(t, err)
+		require.NoError(t, archive.Close())
+
+		// importing again saves nothing
+		n, err = ImportBlocks(imported, path)
+		require.NoError(t, err)
+		assert.Zero(t, n)
+	}
+
+	// the range must be stored
+	path := filepath.Join(t.TempDir(), "blocks.dat")
+	assert.Error(t, ExportBlocks(bs, path, 0, 10, false))
+	assert.Error(t, ExportBlocks(bs, path, 10, 9, false))
+	assert.Error(t, ExportBlocks(bs, path, 1, height+1, false))
+}
+
+func TestExportImportBlocksResume(t *testing.T) {
+	const height = 1000
+	bs := makeChain(t, height)
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "blocks.dat")
+	require.NoError(t, ExportBlocks(bs, path, 1, height, true))
+	complete, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.ErrorContains(t, ExportBlocks(bs, path, 1, height, true), "already complete")
+
+	archive, err := OpenArchive(path)
+	require.NoError(t, err)
+	interrupted := archive.index[599].offset + 10
+	require.NoError(t, archive.Close())
+
+	// an export interrupted in the middle of a record resumes from it
+	require.NoError(t, os.Truncate(path, interrupted))
+	require.NoError(t, ExportBlocks(bs, path, 1, height, true))
+	resumed, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, complete, resumed)
+
+	// the interrupted export must be resumed with the same range and flags
+	require.NoError(t, os.Truncate(path, interrupted))
+	assert.Error(t, ExportBlocks(bs, path, 1, height, false))
+	assert.Error(t, ExportBlocks(bs, path, 2, height, true))
+	assert.Error(t, ExportBlocks(bs, path, 1, 500, true))
+
+	// an import resumes after the last stored block
+	firstHalf := filepath.Join(dir, "first_half.dat")
+	require.NoError(t, ExportBlocks(bs, firstHalf, 1, height/2, false))
+	imported := NewBlockStore(dbm.NewMemDB())
+	n, err := ImportBlocks(imported, firstHalf)
+	require.NoError(t, err)
+	assert.EqualValues(t, height/2, n)
+
+	secondHalf := filepath.Join(dir, "second_half.dat")
+	require.NoError(t, ExportBlocks(bs, secondHalf, height/2+1, height, false))
+	n, err = ImportBlocks(imported, secondHalf)
+	require.NoError(t, err)
+	assert.EqualValues(t, height/2, n)
+	assert.EqualValues(t, height, imported.Height())
+	assert.Equal(t, bs.LoadBlockMeta(height), imported.LoadBlockMeta(height))
+
+	// the archive must follow the stored blocks
+	firstBlocks := filepath.Join(dir, "first_blocks.dat")
+	require.NoError(t, ExportBlocks(bs, firstBlocks, 1, 10, false))
+	imported = NewBlockStore(dbm.NewMemDB())
+	_, err = ImportBlocks(imported, firstBlocks)
+	require.NoError(t, err)
+	_, err = ImportBlocks(imported, secondHalf)
+	assert.Error(t, err)
+}
+
+func TestImportBlocksCorrupted(t *testing.T) {
+	const height = 100
+	bs := makeChain(t, height)
+	path := filepath.Join(t.TempDir(), "blocks.dat")
+	require.NoError(t, ExportBlocks(bs, path, 1, height, false))
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	archive, err := OpenArchive(path)
+	require.NoError(t, err)
+	recordOffset := archive.index[49].offset
+	lastRecord := archive.index[height-1].offset + 1
+	require.NoError(t, archive.Close())
+
+	corrupt := func(t *testing.T, offset int64) {
+		corrupted := bytes.Clone(data)
+		corrupted[offset] ^= 0xff
+		require.NoError(t, os.WriteFile(path, corrupted, 0o644))
+	}
+
+	t.Run("record", func(t *testing.T) {
+		corrupt(t, recordOffset+archiveRecordHeaderSize+100)
+		archive, err := OpenArchive(path)
+		require.NoError(t, err)
+		defer archive.Close()
+		_, _, err = archive.LoadBlock(50)
+		assert.ErrorIs(t, err, ErrCorruptedArchive)
+		_, _, err = archive.LoadBlock(51)
+		assert.NoError(t, err)
+
+		// the blocks before the corrupted record are imported
+		imported := NewBlockStore(dbm.NewMemDB())
+		n, err := ImportBlocks(imported, path)
+		assert.ErrorIs(t, err, ErrCorruptedArchive)
+		assert.EqualValues(t, 49, n)
+		assert.EqualValues(t, 49, imported.Height())
+	})
+
+	t.Run("index", func(t *testing.T) {
+		corrupt(t, int64(len(data)-archiveTrailerSize-1))
+		_, err := OpenArchive(path)
+		assert.ErrorIs(t, err, ErrCorruptedArchive)
+	})
+
+	t.Run("header", func(t *testing.T) {
+		corrupt(t, 0)
+		_, err := OpenArchive(path)
+		assert.ErrorIs(t, err, ErrCorruptedArchive)
+	})
+
+	t.Run("truncated", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(path, data[:lastRecord], 0o644))
+		_, err := OpenArchive(path)
+		assert.ErrorIs(t, err, ErrCorruptedArchive)
+	})
+}
+
+func TestValidateArchivedBlock(t *testing.T) {
+	bs := makeChain(t, 3)
+	prev := bs.LoadBlockMeta(1)
+	block := bs.LoadBlock(2)
+	commit := bs.LoadBlockCommit(2)
+
+	_, err := validateArchivedBlock(block, commit, &prev.Header, prev.BlockID)
+	require.NoError(t, err)
+	_, err = validateArchivedBlock(block, commit, nil, types.BlockID{})
+	require.NoError(t, err)
+
+	// the commit must be for the block
+	_, err = validateArchivedBlock(block, bs.LoadBlockCommit(1), &prev.Header, prev.BlockID)
+	assert.Error(t, err)
+
+	// the block must follow the previous one
+	_, err = validateArchivedBlock(block, commit, &block.Header, commit.BlockID)
+	assert.Error(t, err)
+	_, err = validateArchivedBlock(bs.LoadBlock(3), bs.LoadSeenCommit(3), &prev.Header, prev.BlockID)
+	assert.Error(t, err)
+	otherChain := prev.Header
+	otherChain.ChainID = "other"
+	_, err = validateArchivedBlock(block, commit, &otherChain, prev.BlockID)
+	assert.Error(t, err)
+	otherValidators := prev.Header
+	otherValidators.NextValidatorsHash = cmtrand.Bytes(32)
+	_, err = validateArchivedBlock(block, commit, &otherValidators, prev.BlockID)
+	assert.Error(t, err)
+}
@@ -0,0 +1,263 @@
+package store
+
+import (
+	"bytes"
+	"sync/atomic"
+	"time"
+
+	dbm "github.com/cometbft/cometbft-db"
+)
+
+// OtherKeyKind is the kind of the keys not known to the KeyKindFunc of an
+// InstrumentedDB, or of all keys if it has none.
+const OtherKeyKind = "other"
+
+// KeyKindFunc returns the kind of a key, e.g. "block_part" for the keys of the
+// block parts in the block store. The kinds label the metrics of an
+// InstrumentedDB, so there must be only a handful of them.
+type KeyKindFunc func(key []byte) string
+
+// InstrumentedDB wraps a dbm.DB to report the duration of its operations and
+// the bytes read and written, labeled by the name of the database, the
+// operation and the kind of key, so that every store using it is instrumented
+// without touching its call sites.
+//
+// The iterators are timed from their creation until they are closed, and the
+// batches when they are written, labeled by the kind of their first key.
+type InstrumentedDB struct {
+	dbm.DB
+
+	name    string
+	keyKind KeyKindFunc
+	metrics atomic.Pointer[Metrics]
+}
+
+var _ dbm.DB = (*InstrumentedDB)(nil)
+
+// NewInstrumentedDB returns db instrumented under the given name, with no-op
+// metrics until SetMetrics is called. keyKind may be nil.
+func NewInstrumentedDB(db dbm.DB, name string, keyKind KeyKindFunc) *InstrumentedDB {
+	idb := &InstrumentedDB{DB: db, name: name, keyKind: keyKind}
+	idb.metrics.Store(NopMetrics())
+	return idb
+}
+
+// SetMetrics sets the metrics, e.g. once the chain ID labeling them is known.
+// It is safe to call while the database is in use.
+func (db *InstrumentedDB) SetMetrics(metrics *Metrics) {
+	db.metrics.Store(metrics)
+}
+
+func (db *InstrumentedDB) kind(key []byte) string {
+	if db.keyKind == nil {
+		return OtherKeyKind
+	}
+	return db.keyKind(key)
+}
+
+func (db *InstrumentedDB) observe(op, kind string, start time.Time) {
+	db.metrics.Load().DBOperationDuration.With("db", db.name, "op", op, "kind", kind).
+		Observe(time.Since(start).Seconds())
+}
+
+func (db *InstrumentedDB) addRead(kind string, n int) {
+	if n > 0 {
+		db.metrics.Load().DBReadBytes.With("db", db.name, "kind", kind).Add(float64(n))
+	}
+}
+
+func (db *InstrumentedDB) addWritten(kind string, n int) {
+	if n > 0 {
+		db.metrics.Load().DBWrittenBytes.With("db", db.name, "kind", kind).Add(float64(n))
+	}
+}
+
+// Get implements dbm.DB.
+func (db *InstrumentedDB) Get(key []byte) ([]byte, error) {
+	start, kind := time.Now(), db.kind(key)
+	value, err := db.DB.Get(key)
+	db.observe("get", kind, start)
+	db.addRead(kind, len(value))
+	return value, err
+}
+
+// Has implements dbm.DB.
+func (db *InstrumentedDB) Has(key []byte) (bool, error) {
+	start := time.Now()
+	ok, err := db.DB.Has(key)
+	db.observe("has", db.kind(key), start)
+	return ok, err
+}
+
+// Set implements dbm.DB.
+func (db *InstrumentedDB) Set(key, value []byte) error {
+	return db.write("set", key, value, db.DB.Set)
+}
+
+// SetSync implements dbm.DB.
+func (db *InstrumentedDB) SetSync(key, value []byte) error {
+	return db.write("set_sync", key, value, db.DB.SetSync)
+}
+
+func (db *InstrumentedDB) write(op string, key, value []byte, set func(key, value []byte) error) error {
+	start, kind := time.Now(), db.kind(key)
+	err := set(key, value)
+	db.observe(op, kind, start)
+	if err == nil {
+		db.addWritten(kind, len(key)+len(value))
+	}
+	return err
+}
+
+// Delete implements dbm.DB.
+func (db *InstrumentedDB) Delete(key []byte) error {
+	start := time.Now()
+	err := db.DB.Delete(key)
+	db.observe("delete", db.kind(key), start)
+	return err
+}
+
+// DeleteSync implements dbm.DB.
+func (db *InstrumentedDB) DeleteSync(key []byte) error {
+	start := time.Now()
+	err := db.DB.DeleteSync(key)
+	db.observe("delete_sync", db.kind(key), start)
+	return err
+}
+
+// Iterator implements dbm.DB.
+func (db *InstrumentedDB) Iterator(start, end []byte) (dbm.Iterator, error) {
+	it, err := db.DB.Iterator(start, end)
+	return db.iterator("iterate", start, it, err)
+}
+
+// ReverseIterator implements dbm.DB.
+func (db *InstrumentedDB) ReverseIterator(start, end []byte) (dbm.Iterator, error) {
+	it, err := db.DB.ReverseIterator(start, end)
+	return db.iterator("reverse_iterate", start, it, err)
+}
+
+func (db *InstrumentedDB) iterator(op string, start []byte, it dbm.Iterator, err error) (dbm.Iterator, error) {
+	if err != nil {
+		return nil, err
+	}
+	return &instrumentedIterator{Iterator: it, db: db, op: op, kind: db.kind(start), start: time.Now()}, nil
+}
+
+// NewBatch implements dbm.DB.
+func (db *InstrumentedDB) NewBatch() dbm.Batch {
+	return &instrumentedBatch{Batch: db.DB.NewBatch(), db: db}
+}
+
+// instrumentedIterator reports the bytes of the values read, and the duration
+// of the iteration once closed.
+type instrumentedIterator struct {
+	dbm.Iterator
+
+	db     *InstrumentedDB
+	op     string
+	kind   string
+	start  time.Time
+	read   int
+	closed bool
+}
+
+func (it *instrumentedIterator) Value() []byte {
+	value := it.Iterator.Value()
+	it.read += len(value)
+	return value
+}
+
+func (it *instrumentedIterator) Close() error {
+	err := it.Iterator.Close()
+	if !it.closed {
+		it.closed = true
+		it.db.observe(it.op, it.kind, it.start)
+		it.db.addRead(it.kind, it.read)
+	}
+	return err
+}
+
+// instrumentedBatch reports the duration of the write of the batch, labeled by
+// the kind of its first key, and the bytes written by kind of key.
+type instrumentedBatch struct {
+	dbm.Batch
+
+	db      *InstrumentedDB
+	kind    string
+	written map[string]int
+}
+
+func (b *instrumentedBatch) add(key []byte, n int) {
+	kind := b.db.kind(key)
+	if b.written == nil {
+		b.kind = kind
+		b.written = make(map[string]int, 1)
+	}
+	b.written[kind] += n
+}
+
+func (b *instrumentedBatch) Set(key, value []byte) error {
+	if err := b.Batch.Set(key, value); err != nil {
+		return err
+	}
+	b.add(key, len(key)+len(value))
+	return nil
+}
+
+func (b *instrumentedBatch) Delete(key []byte) error {
+	if err := b.Batch.Delete(key); err != nil {
+		return err
+	}
+	b.add(key, 0)
+	return nil
+}
+
+func (b *instrumentedBatch) Write() error {
+	return b.write("write_batch", b.Batch.Write)
+}
+
+func (b *instrumentedBatch) WriteSync() error {
+	return b.write("write_batch_sync", b.Batch.WriteSync)
+}
+
+func (b *instrumentedBatch) write(op string, write func() error) error {
+	start := time.Now()
+	err := write()
+	if b.written == nil {
+		// nothing to report for an empty batch
+		return err
+	}
+	b.db.observe(op, b.kind, start)
+	if err == nil {
+		for kind, n := range b.written {
+			b.db.addWritten(kind, n)
+		}
+	}
+	return err
+}
+
+//-----------------------------------------------------------------------------
+
+var blockStoreKeyKinds = []struct {
+	prefix []byte
+	kind   string
+}{
+	{[]byte("H:"), "block_meta"},
+	{[]byte("P:"), "block_part"},
+	{[]byte("C:"), "commit"},
+	{[]byte("SC:"), "seen_commit"},
+	{[]byte("BH:"), "block_hash"},
+	{[]byte("TH:"), "tx_info"},
+	{blockStoreKey, "state"},
+}
+
+// BlockStoreKeyKind is the KeyKindFunc of the keys of the block store.
+func BlockStoreKeyKind(key []byte) string {
+	for _, k := range blockStoreKeyKinds {
+		if bytes.HasPrefix(key, k.prefix) {
+			return k.kind
+		}
+	}
+	return OtherKeyKind
+}
@@ -0,0 +1,259 @@
+package store
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	dbm "github.com/cometbft/cometbft-db"
+	"github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	cmtrand "github.com/tendermint/tendermint/libs/rand"
+	cmtstate "github.com/tendermint/tendermint/proto/tendermint/state"
+	sm "github.com/tendermint/tendermint/state"
+	"github.com/tendermint/tendermint/test/factory"
+	"github.com/tendermint/tendermint/types"
+	cmttime "github.com/tendermint/tendermint/types/time"
+)
+
+// recordedMetric records the sums of the values of a metric, by label values.
+type recordedMetric struct {
+	mtx  *sync.Mutex
+	sums map[string]float64
+	lvs  []string
+}
+
+func newRecordedMetric() *recordedMetric {
+	return &recordedMetric{mtx: new(sync.Mutex), sums: make(map[string]float64)}
+}
+
+func (m *recordedMetric) with(labelValues ...string) *recordedMetric {
+	lvs := append(append([]string{}, m.lvs...), labelValues...)
+	return &recordedMetric{mtx: m.mtx, sums: m.sums, lvs: lvs}
+}
+
+func (m *recordedMetric) record(value float64) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	var values []string
+	for i := 1; i < len(m.lvs); i += 2 {
+		values = append(values, m.lvs[i])
+	}
+	m.sums[strings.Join(values, "/")] += value
+}
+
+func (m *recordedMetric) get(labelValues ...string) (float64, bool) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	sum, ok := m.sums[strings.Join(labelValues, "/")]
+	return sum, ok
+}
+
+type recordedHistogram struct{ *recordedMetric }
+
+func (h recordedHistogram) With(labelValues ...string) metrics.Histogram {
+	return recordedHistogram{h.with(labelValues...)}
+}
+
+func (h recordedHistogram) Observe(value float64) { h.record(value) }
+
+type recordedCounter struct{ *recordedMetric }
+
+func (c recordedCounter) With(labelValues ...string) metrics.Counter {
+	return recordedCounter{c.with(labelValues...)}
+}
+
+func (c recordedCounter) Add(delta float64) { c.record(delta) }
+
+func TestInstrumentedDBMetrics(t *testing.T) {
+	durations, read, written := newRecordedMetric(), newRecordedMetric(), newRecordedMetric()
+	metrics := &Metrics{
+		DBOperationDuration: recordedHistogram{durations},
+		DBReadBytes:         recordedCounter{read},
+		DBWrittenBytes:      recordedCounter{written},
+	}
+
+	// block store
+	blockDB := NewInstrumentedDB(dbm.NewMemDB(), "blockstore", BlockStoreKeyKind)
+	blockDB.SetMetrics(metrics)
+	bs := NewBlockStore(blockDB)
+	bs.SaveBlock(block, partSet, seenCommit1)
+	require.NotNil(t, bs.LoadBlock(block.Height))
+	require.NotNil(t, bs.LoadBlockPart(block.Height, 1))
+	require.NotNil(t, bs.LoadSeenCommit(block.Height))
+	require.NotNil(t, bs.LoadBlockByHash(block.Hash()))
+	require.NoError(t, bs.SaveTxInfo(block, make([]uint32, len(block.Txs))))
+	require.NotNil(t, bs.LoadTxInfo(block.Txs[0].Hash()))
+
+	// state store
+	stateDB := NewInstrumentedDB(dbm.NewMemDB(), "state", sm.StoreKeyKind)
+	stateDB.SetMetrics(metrics)
+	stateStore := sm.NewStore(stateDB, sm.StoreOptions{})
+	require.NoError(t, stateStore.Save(state))
+	_, err := stateStore.LoadValidators(state.LastBlockHeight + 1)
+	require.NoError(t, err)
+	_, err = stateStore.LoadConsensusParams(state.LastBlockHeight + 1)
+	require.NoError(t, err)
+	require.NoError(t, stateStore.SaveABCIResponses(1, &cmtstate.ABCIResponses{
+		DeliverTxs: []*abci.ResponseDeliverTx{{Data: []byte("data")}},
+		EndBlock:   &abci.ResponseEndBlock{},
+		BeginBlock: &abci.ResponseBeginBlock{},
+	}))
+	_, err = stateStore.LoadABCIResponses(1)
+	require.NoError(t, err)
+	_, err = stateStore.LoadLastABCIResponse(1)
+	require.NoError(t, err)
+
+	// the remaining operations, on a database with no kinds of keys
+	db := NewInstrumentedDB(dbm.NewMemDB(), "tx_index", nil)
+	db.SetMetrics(metrics)
+	require.NoError(t, db.SetSync([]byte("a"), []byte("value")))
+	_, err = db.Has([]byte("a"))
+	require.NoError(t, err)
+	for _, newIterator := range []func(start, end []byte) (dbm.Iterator, error){db.Iterator, db.ReverseIterator} {
+		it, err := newIterator(nil, nil)
+		require.NoError(t, err)
+		for ; it.Valid(); it.Next() {
+			assert.Equal(t, []byte("value"), it.Value())
+		}
+		require.NoError(t, it.Close())
+	}
+	require.NoError(t, db.Delete([]byte("a")))
+	require.NoError(t, db.DeleteSync([]byte("b")))
+	batch := db.NewBatch()
+	require.NoError(t, batch.Set([]byte("b"), []byte("value")))
+	require.NoError(t, batch.Write())
+	require.NoError(t, batch.Close())
+
+	for _, op := range []struct{ db, op, kind string }{
+		{"blockstore", "set", "block_meta"},
+		{"blockstore", "set", "block_part"},
+		{"blockstore", "set", "block_hash"},
+		{"blockstore", "set", "commit"},
+		{"blockstore", "set", "seen_commit"},
+		{"blockstore", "set_sync", "state"},
+		{"blockstore", "get", "block_meta"},
+		{"blockstore", "get", "block_part"},
+		{"blockstore", "get", "seen_commit"},
+		{"blockstore", "get", "block_hash"},
+		{"blockstore", "get", "tx_info"},
+		{"blockstore", "write_batch_sync", "tx_info"},
+		{"state", "set", "validators"},
+		{"state", "set", "consensus_params"},
+		{"state", "set_sync", "state"},
+		{"state", "get", "validators"},
+		{"state", "get", "consensus_params"},
+		{"state", "set", "abci_responses"},
+		{"state", "get", "abci_responses"},
+		{"state", "set_sync", "last_abci_responses"},
+		{"state", "get", "last_abci_responses"},
+		{"tx_index", "set_sync", OtherKeyKind},
+		{"tx_index", "has", OtherKeyKind},
+		{"tx_index", "iterate", OtherKeyKind},
+		{"tx_index", "reverse_iterate", OtherKeyKind},
+		{"tx_index", "delete", OtherKeyKind},
+		{"tx_index", "delete_sync", OtherKeyKind},
+		{"tx_index", "write_batch", OtherKeyKind},
+	} {
+		_, ok := durations.get(op.db, op.op, op.kind)
+		assert.True(t, ok, "no duration for %v", op)
+	}
+
+	for _, kind := range []struct{ db, kind string }{
+		{"blockstore", "block_part"},
+		{"blockstore", "tx_info"},
+		{"state", "validators"},
+		{"state", "abci_responses"},
+		{"tx_index", OtherKeyKind},
+	} {
+		n, _ := read.get(kind.db, kind.kind)
+		assert.Positive(t, n, "no bytes read for %v", kind)
+		n, _ = written.get(kind.db, kind.kind)
+		assert.Positive(t, n, "no bytes written for %v", kind)
+	}
+	// the values read by the two iterators, then the batch
+	n, _ := read.get("tx_index", OtherKeyKind)
+	assert.EqualValues(t, 2*len("value"), n)
+	n, _ = written.get("tx_index", OtherKeyKind)
+	assert.EqualValues(t, 2*len("a"+"value"), n)
+}
+
+// unregisteredPrometheusMetrics returns Metrics like PrometheusMetrics, without
+// registering them, so that it can be called repeatedly.
+func unregisteredPrometheusMetrics() *Metrics {
+	return &Metrics{
+		DBOperationDuration: prometheus.NewHistogram(stdprometheus.NewHistogramVec(stdprometheus.HistogramOpts{
+			Name:    "db_operation_duration_seconds",
+			Buckets: stdprometheus.ExponentialBuckets(0.00001, 4, 10),
+		}, []string{"chain_id", "db", "op", "kind"})).With("chain_id", "test"),
+		DBReadBytes: prometheus.NewCounter(stdprometheus.NewCounterVec(stdprometheus.CounterOpts{
+			Name: "db_read_bytes",
+		}, []string{"chain_id", "db", "kind"})).With("chain_id", "test"),
+		DBWrittenBytes: prometheus.NewCounter(stdprometheus.NewCounterVec(stdprometheus.CounterOpts{
+			Name: "db_written_bytes",
+		}, []string{"chain_id", "db", "kind"})).With("chain_id", "test"),
+	}
+}
+
+// BenchmarkInstrumentedDB compares saving and loading blocks of 512 KiB in a
+// goleveldb block store, with and without instrumenting its database. The
+// instrumented store should be within 2% of the plain one.
+func BenchmarkInstrumentedDB(b *testing.B) {
+	const heights = 100
+	blocks := make([]*types.Block, heights)
+	partSets := make([]*types.PartSet, heights)
+	commits := make([]*types.Commit, heights)
+	for i := range blocks {
+		h := int64(i + 1)
+		txs := make([]types.Tx, 256)
+		for j := range txs {
+			txs[j] = cmtrand.Bytes(2048)
+		}
+		blocks[i], _ = state.MakeBlock(h, factory.MakeData(txs), new(types.Commit), nil,
+			state.Validators.GetProposer().Address)
+		partSets[i] = blocks[i].MakePartSet(types.BlockPartSizeBytes)
+		commits[i] = makeTestCommit(h, cmttime.Now())
+	}
+
+	for _, instrumented := range []bool{false, true} {
+		b.Run(fmt.Sprintf("instrumented=%v", instrumented), func(b *testing.B) {
+			var db dbm.DB
+			newBlockStore := func() *BlockStore {
+				var err error
+				db, err = dbm.NewGoLevelDB("blockstore", b.TempDir())
+				require.NoError(b, err)
+				if !instrumented {
+					return NewBlockStore(db)
+				}
+				idb := NewInstrumentedDB(db, "blockstore", BlockStoreKeyKind)
+				idb.SetMetrics(unregisteredPrometheusMetrics())
+				return NewBlockStore(idb)
+			}
+			bs := newBlockStore()
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				// start over with a new store once all the blocks are saved
+				j := i % heights
+				if j == 0 && i > 0 {
+					b.StopTimer()
+					require.NoError(b, db.Close())
+					bs = newBlockStore()
+					b.StartTimer()
+				}
+				bs.SaveBlock(blocks[j], partSets[j], commits[j])
+				if bs.LoadBlock(blocks[j].Height) == nil {
+					b.Fatal("block not found")
+				}
+			}
+			b.StopTimer()
+			require.NoError(b, db.Close())
+		})
+	}
+}
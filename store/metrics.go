@@ -0,0 +1,65 @@
+package store
+
+import (
+	"github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/discard"
+	"github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// MetricsSubsystem is a subsystem shared by all metrics exposed by this
+	// package.
+	MetricsSubsystem = "store"
+)
+
+// Metrics contains metrics exposed by this package.
+type Metrics struct {
+	// Duration of the database operations in seconds, by database, operation
+	// and kind of key.
+	DBOperationDuration metrics.Histogram
+	// Number of bytes read from the databases, by database and kind of key.
+	DBReadBytes metrics.Counter
+	// Number of bytes written to the databases, by database and kind of key.
+	DBWrittenBytes metrics.Counter
+}
+
+// PrometheusMetrics returns Metrics build using Prometheus client library.
+// Optionally, labels can be provided along with their values ("foo",
+// "fooValue").
+func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
+	labels := []string{}
+	for i := 0; i < len(labelsAndValues); i += 2 {
+		labels = append(labels, labelsAndValues[i])
+	}
+	return &Metrics{
+		DBOperationDuration: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "db_operation_duration_seconds",
+			Help:      "Duration of the database operations in seconds.",
+			Buckets:   stdprometheus.ExponentialBuckets(0.00001, 4, 10),
+		}, append(labels, "db", "op", "kind")).With(labelsAndValues...),
+		DBReadBytes: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "db_read_bytes",
+			Help:      "Number of bytes read from the databases.",
+		}, append(labels, "db", "kind")).With(labelsAndValues...),
+		DBWrittenBytes: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "db_written_bytes",
+			Help:      "Number of bytes written to the databases.",
+		}, append(labels, "db", "kind")).With(labelsAndValues...),
+	}
+}
+
+// NopMetrics returns no-op Metrics.
+func NopMetrics() *Metrics {
+	return &Metrics{
+		DBOperationDuration: discard.NewHistogram(),
+		DBReadBytes:         discard.NewCounter(),
+		DBWrittenBytes:      discard.NewCounter(),
+	}
+}
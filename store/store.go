@@ -1,6 +1,7 @@
 package store
 
 import (
+	"errors"
 	"fmt"
 	"strconv"
 
@@ -21,6 +22,9 @@ There are three types of information stored:
   - Block part:  Parts of each block, aggregated w/ PartSet
   - Commit:      The commit part of each block, for gossiping precommit votes
 
+The proof of the row roots of the data square of a block to its data hash can
+also be stored once computed by the application, see SaveRowProof.
+
 Currently the precommit signatures are duplicated in the Block parts as
 well as the Commit.  In the future this may change, perhaps by moving
 the Commit data outside the Block. (TODO)
@@ -38,9 +42,10 @@ type BlockStore struct {
 	// database contents. The only reason for keeping these fields in the struct is that the data
 	// can't efficiently be queried from the database since the key encoding we use is not
 	// lexicographically ordered.
-	mtx    cmtsync.RWMutex
-	base   int64
-	height int64
+	mtx          cmtsync.RWMutex
+	base         int64
+	height       int64
+	retainHeight int64
 }
 
 // NewBlockStore returns a new BlockStore with the given DB,
@@ -48,9 +53,10 @@ type BlockStore struct {
 func NewBlockStore(db dbm.DB) *BlockStore {
 	bs := LoadBlockStoreState(db)
 	return &BlockStore{
-		base:   bs.Base,
-		height: bs.Height,
-		db:     db,
+		base:         bs.Base,
+		height:       bs.Height,
+		retainHeight: bs.RetainHeight,
+		db:           db,
 	}
 }
 
@@ -68,6 +74,28 @@ func (bs *BlockStore) Height() int64 {
 	return bs.height
 }
 
+// RetainHeight returns the highest height the application asked to retain the
+// blocks from, see SaveRetainHeight, or 0 if it never did. The blocks below it
+// are pruned, or will be once the evidence for them has expired.
+func (bs *BlockStore) RetainHeight() int64 {
+	bs.mtx.RLock()
+	defer bs.mtx.RUnlock()
+	return bs.retainHeight
+}
+
+// SaveRetainHeight persists the height the application asked to retain the
+// blocks from, if higher than the one already persisted.
+func (bs *BlockStore) SaveRetainHeight(height int64) {
+	bs.mtx.Lock()
+	if height <= bs.retainHeight {
+		bs.mtx.Unlock()
+		return
+	}
+	bs.retainHeight = height
+	bs.mtx.Unlock()
+	bs.saveState()
+}
+
 // Size returns the number of blocks in the block store.
 func (bs *BlockStore) Size() int64 {
 	bs.mtx.RLock()
@@ -85,17 +113,23 @@ func (bs *BlockStore) LoadBaseMeta() *types.BlockMeta {
 	if bs.base == 0 {
 		return nil
 	}
-	return bs.LoadBlockMeta(bs.base)
+	// the base meta is not backfilled, which would need the write lock
+	return bs.loadBlockMeta(bs.base)
 }
 
 // LoadBlock returns the block with the given height.
 // If no block is found for that height, it returns nil.
 func (bs *BlockStore) LoadBlock(height int64) *types.Block {
-	var blockMeta = bs.LoadBlockMeta(height)
+	var blockMeta = bs.loadBlockMeta(height)
 	if blockMeta == nil {
 		return nil
 	}
+	return bs.loadBlock(height, blockMeta)
+}
 
+// loadBlock returns the block with the given height and meta, or nil if any of
+// its parts is missing.
+func (bs *BlockStore) loadBlock(height int64, blockMeta *types.BlockMeta) *types.Block {
 	pbb := new(cmtproto.Block)
 	buf := []byte{}
 	for i := 0; i < int(blockMeta.BlockID.PartSetHeader.Total); i++ {
@@ -122,6 +156,32 @@ func (bs *BlockStore) LoadBlock(height int64) *types.Block {
 	return block
 }
 
+// HasBlockData returns whether the block at the given height is in the store,
+// i.e. its meta and its parts, without loading the parts. The meta of a block
+// can outlive its parts, e.g. while the block is being pruned.
+func (bs *BlockStore) HasBlockData(height int64) bool {
+	blockMeta := bs.loadBlockMeta(height)
+	if blockMeta == nil {
+		return false
+	}
+	total := int(blockMeta.BlockID.PartSetHeader.Total)
+	if total == 0 {
+		return false
+	}
+	// the parts are saved and pruned together, so checking the first and the
+	// last is enough
+	for _, index := range []int{0, total - 1} {
+		has, err := bs.db.Has(calcBlockPartKey(height, index))
+		if err != nil {
+			panic(err)
+		}
+		if !has {
+			return false
+		}
+	}
+	return true
+}
+
 // LoadBlockByHash returns the block with the given hash.
 // If no block is found for that hash, it returns nil.
 // Panics if it fails to parse height associated with the given hash.
@@ -171,7 +231,44 @@ func (bs *BlockStore) LoadBlockPart(height int64, index int) *types.Part {
 
 // LoadBlockMeta returns the BlockMeta for the given height.
 // If no block is found for the given height, it returns nil.
+//
+// The data square info of the blocks saved before it was recorded is computed
+// from the block on first access and saved, unless the data of the block has
+// been pruned.
 func (bs *BlockStore) LoadBlockMeta(height int64) *types.BlockMeta {
+	blockMeta := bs.loadBlockMeta(height)
+	if blockMeta != nil && blockMeta.Square == nil {
+		bs.backfillSquareInfo(height, blockMeta)
+	}
+	return blockMeta
+}
+
+// backfillSquareInfo sets the data square info of blockMeta, which was saved
+// without it, and saves the meta.
+func (bs *BlockStore) backfillSquareInfo(height int64, blockMeta *types.BlockMeta) {
+	if blockMeta.BlockID.PartSetHeader.Total == 0 {
+		return
+	}
+	block := bs.loadBlock(height, blockMeta)
+	if block == nil {
+		return
+	}
+	blockMeta.Square = types.NewDataSquareInfo(&block.Data)
+
+	// the meta is only saved while the block is not being pruned, as pruning
+	// moves the base before deleting the blocks below it
+	bs.mtx.Lock()
+	defer bs.mtx.Unlock()
+	if height < bs.base {
+		return
+	}
+	if err := bs.db.Set(calcBlockMetaKey(height), mustEncode(blockMeta.ToProto())); err != nil {
+		panic(err)
+	}
+}
+
+// loadBlockMeta returns the BlockMeta for the given height, as saved.
+func (bs *BlockStore) loadBlockMeta(height int64) *types.BlockMeta {
 	var pbbm = new(cmtproto.BlockMeta)
 	bz, err := bs.db.Get(calcBlockMetaKey(height))
 
@@ -300,7 +397,7 @@ func (bs *BlockStore) PruneBlocks(height int64) (uint64, error) {
 	}
 
 	for h := base; h < height; h++ {
-		meta := bs.LoadBlockMeta(h)
+		meta := bs.loadBlockMeta(h)
 		if meta == nil { // assume already deleted
 			continue
 		}
@@ -322,6 +419,9 @@ func (bs *BlockStore) PruneBlocks(height int64) (uint64, error) {
 		if err := batch.Delete(calcSeenCommitKey(h)); err != nil {
 			return 0, err
 		}
+		if err := batch.Delete(calcRowProofKey(h)); err != nil {
+			return 0, err
+		}
 		for p := 0; p < int(meta.BlockID.PartSetHeader.Total); p++ {
 			if err := batch.Delete(calcBlockPartKey(h, p)); err != nil {
 				return 0, err
@@ -419,6 +519,62 @@ func (bs *BlockStore) SaveBlock(block *types.Block, blockParts *types.PartSet, s
 	bs.saveState()
 }
 
+// ErrCorruptedRowProof is returned by LoadRowProof when the stored row proof
+// of a block cannot be decoded or does not match the data hash of the block,
+// which means that the block store is corrupted.
+var ErrCorruptedRowProof = errors.New("corrupted row proof")
+
+// SaveRowProof persists the proof of all the row roots of the original data
+// square of the block at the given height to its data hash. It is only saved
+// if it matches the data hash, and if the block is still stored.
+func (bs *BlockStore) SaveRowProof(height int64, rowProof types.RowProof) error {
+	blockMeta := bs.loadBlockMeta(height)
+	if blockMeta == nil {
+		return fmt.Errorf("no block at height %d", height)
+	}
+	if err := rowProof.Validate(blockMeta.Header.DataHash); err != nil {
+		return fmt.Errorf("row proof does not match the data hash of the block at height %d: %w", height, err)
+	}
+
+	// as in backfillSquareInfo, nothing is saved below the base, which pruning
+	// moves before deleting the blocks below it
+	bs.mtx.Lock()
+	defer bs.mtx.Unlock()
+	if height < bs.base {
+		return fmt.Errorf("block at height %d is pruned", height)
+	}
+	return bs.db.Set(calcRowProofKey(height), mustEncode(rowProof.ToProto()))
+}
+
+// LoadRowProof returns the proof of the row roots of the data square of the
+// block at the given height saved by SaveRowProof, or nil if there is none,
+// e.g. for the blocks saved before the row proofs were. The proof is checked
+// against the data hash of the block, and ErrCorruptedRowProof is returned if
+// it does not match.
+func (bs *BlockStore) LoadRowProof(height int64) (*types.RowProof, error) {
+	bz, err := bs.db.Get(calcRowProofKey(height))
+	if err != nil {
+		return nil, err
+	}
+	if len(bz) == 0 {
+		return nil, nil
+	}
+	blockMeta := bs.loadBlockMeta(height)
+	if blockMeta == nil {
+		return nil, nil
+	}
+
+	pbrp := new(cmtproto.RowProof)
+	if err := proto.Unmarshal(bz, pbrp); err != nil {
+		return nil, fmt.Errorf("%w at height %d: %v", ErrCorruptedRowProof, height, err)
+	}
+	rowProof := types.RowProofFromProto(pbrp)
+	if err := rowProof.Validate(blockMeta.Header.DataHash); err != nil {
+		return nil, fmt.Errorf("%w at height %d: %v", ErrCorruptedRowProof, height, err)
+	}
+	return &rowProof, nil
+}
+
 func (bs *BlockStore) saveBlockPart(height int64, index int, part *types.Part) {
 	pbp, err := part.ToProto()
 	if err != nil {
@@ -433,8 +589,9 @@ func (bs *BlockStore) saveBlockPart(height int64, index int, part *types.Part) {
 func (bs *BlockStore) saveState() {
 	bs.mtx.RLock()
 	bss := cmtstore.BlockStoreState{
-		Base:   bs.base,
-		Height: bs.height,
+		Base:         bs.base,
+		Height:       bs.height,
+		RetainHeight: bs.retainHeight,
 	}
 	bs.mtx.RUnlock()
 	SaveBlockStoreState(&bss, bs.db)
@@ -505,6 +662,10 @@ func calcBlockHashKey(hash []byte) []byte {
 	return []byte(fmt.Sprintf("BH:%x", hash))
 }
 
+func calcRowProofKey(height int64) []byte {
+	return []byte(fmt.Sprintf("RP:%v", height))
+}
+
 func calcTxHashKey(hash []byte) []byte {
 	return []byte(fmt.Sprintf("TH:%x", hash))
 }
@@ -17,6 +17,8 @@ import (
 
 	cfg "github.com/tendermint/tendermint/config"
 	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/merkle"
+	"github.com/tendermint/tendermint/crypto/tmhash"
 	"github.com/tendermint/tendermint/libs/log"
 	cmtrand "github.com/tendermint/tendermint/libs/rand"
 	cmtstore "github.com/tendermint/tendermint/proto/tendermint/store"
@@ -94,6 +96,8 @@ func TestLoadBlockStoreState(t *testing.T) {
 			cmtstore.BlockStoreState{Base: 100, Height: 1000}},
 		{"empty", &cmtstore.BlockStoreState{}, cmtstore.BlockStoreState{}},
 		{"no base", &cmtstore.BlockStoreState{Height: 1000}, cmtstore.BlockStoreState{Base: 1, Height: 1000}},
+		{"retain height", &cmtstore.BlockStoreState{Base: 100, Height: 1000, RetainHeight: 200},
+			cmtstore.BlockStoreState{Base: 100, Height: 1000, RetainHeight: 200}},
 	}
 
 	for _, tc := range testCases {
@@ -678,6 +682,180 @@ func TestLoadBlockMeta(t *testing.T) {
 	}
 }
 
+func TestLoadBlockMetaBackfillsSquareInfo(t *testing.T) {
+	config := cfg.ResetTestRoot("blockchain_reactor_test")
+	defer os.RemoveAll(config.RootDir)
+	stateStore := sm.NewStore(dbm.NewMemDB(), sm.StoreOptions{
+		DiscardABCIResponses: false,
+	})
+	state, err := stateStore.LoadFromDBOrGenesisFile(config.GenesisFile())
+	require.NoError(t, err)
+	db := dbm.NewMemDB()
+	bs := NewBlockStore(db)
+
+	blocks := make([]*types.Block, 10)
+	for i := range blocks {
+		h := int64(i + 1)
+		blocks[i] = makeBlock(h, state, new(types.Commit))
+		blocks[i].Data.Txs = factory.MakeTxs(h, i*20)
+		blocks[i].Data.SquareSize = uint64(i + 1)
+		bs.SaveBlock(blocks[i], blocks[i].MakePartSet(2), makeTestCommit(h, cmttime.Now()))
+	}
+	// the square info is recorded as blocks are saved
+	for _, block := range blocks {
+		meta := bs.LoadBlockMeta(block.Height)
+		require.NotNil(t, meta.Square, "height %d", block.Height)
+		assert.Equal(t, types.NewDataSquareInfo(&block.Data), meta.Square)
+		assert.EqualValues(t, block.Height, meta.Square.SquareSize)
+	}
+	assert.Zero(t, bs.LoadBlockMeta(1).Square.SharesUsed)
+	assert.Positive(t, bs.LoadBlockMeta(10).Square.SharesUsed)
+
+	// the metas saved before the square info was recorded
+	for h := int64(1); h <= 10; h++ {
+		pbm := bs.LoadBlockMeta(h).ToProto()
+		pbm.Square = nil
+		require.NoError(t, db.Set(calcBlockMetaKey(h), mustEncode(pbm)))
+	}
+	// a block whose data is pruned can't be backfilled
+	meta := bs.loadBlockMeta(5)
+	require.NoError(t, db.Delete(calcBlockPartKey(5, 0)))
+	assert.Nil(t, bs.LoadBlockMeta(5).Square)
+
+	for _, block := range blocks {
+		if block.Height == 5 {
+			continue
+		}
+		require.Nil(t, bs.loadBlockMeta(block.Height).Square)
+		assert.Equal(t, types.NewDataSquareInfo(&block.Data), bs.LoadBlockMeta(block.Height).Square)
+		// and saved
+		assert.Equal(t, types.NewDataSquareInfo(&block.Data), bs.loadBlockMeta(block.Height).Square)
+	}
+	assert.Equal(t, meta, bs.loadBlockMeta(5))
+}
+
+// makeRowProof returns the proof of rows row roots of 90 bytes, the size of
+// the roots of the namespaced Merkle trees, and their root.
+func makeRowProof(rows int) ([]byte, types.RowProof) {
+	rowRoots := make([][]byte, rows)
+	for i := range rowRoots {
+		rowRoots[i] = cmtrand.Bytes(90)
+	}
+	root, proofs := merkle.ProofsFromByteSlices(rowRoots)
+	rp := types.RowProof{Proofs: proofs, EndRow: uint32(rows - 1)}
+	for _, rowRoot := range rowRoots {
+		rp.RowRoots = append(rp.RowRoots, rowRoot)
+	}
+	return root, rp
+}
+
+func TestSaveLoadRowProof(t *testing.T) {
+	bs, db := freshBlockStore()
+	dataHash, rowProof := makeRowProof(4)
+	_, otherProof := makeRowProof(4)
+	for h := int64(1); h <= 3; h++ {
+		block := newBlock(block.Header, &types.Commit{Height: h - 1})
+		block.Height = h
+		block.DataHash = dataHash
+		bs.SaveBlock(block, block.MakePartSet(2), &types.Commit{Height: h})
+	}
+
+	// the blocks saved before the row proofs were have none
+	loaded, err := bs.LoadRowProof(1)
+	require.NoError(t, err)
+	assert.Nil(t, loaded)
+
+	require.NoError(t, bs.SaveRowProof(1, rowProof))
+	require.NoError(t, bs.SaveRowProof(2, rowProof))
+	loaded, err = bs.LoadRowProof(1)
+	require.NoError(t, err)
+	assert.Equal(t, &rowProof, loaded)
+
+	// only the proofs of the data hash of stored blocks are saved
+	assert.Error(t, bs.SaveRowProof(3, otherProof))
+	assert.Error(t, bs.SaveRowProof(4, rowProof))
+	loaded, err = bs.LoadRowProof(3)
+	require.NoError(t, err)
+	assert.Nil(t, loaded)
+
+	// a stored proof which does not match the data hash, or does not decode,
+	// is corrupted
+	require.NoError(t, db.Set(calcRowProofKey(2), mustEncode(otherProof.ToProto())))
+	_, err = bs.LoadRowProof(2)
+	assert.ErrorIs(t, err, ErrCorruptedRowProof)
+	require.NoError(t, db.Set(calcRowProofKey(2), []byte("corrupted")))
+	_, err = bs.LoadRowProof(2)
+	assert.ErrorIs(t, err, ErrCorruptedRowProof)
+
+	// the proofs are pruned with their blocks
+	_, err = bs.PruneBlocks(2)
+	require.NoError(t, err)
+	loaded, err = bs.LoadRowProof(1)
+	require.NoError(t, err)
+	assert.Nil(t, loaded)
+	assert.Error(t, bs.SaveRowProof(1, rowProof))
+	has, err := db.Has(calcRowProofKey(1))
+	require.NoError(t, err)
+	assert.False(t, has)
+}
+
+func TestRowProofStorageOverhead(t *testing.T) {
+	// the square of the largest blocks has 128 rows, and the Merkle proof of
+	// each row root has 7 aunts and a leaf hash
+	const rows, depth = 128, 7
+	_, rowProof := makeRowProof(rows)
+	size := len(mustEncode(rowProof.ToProto()))
+	t.Logf("row proof of %d rows: %d bytes", rows, size)
+	// the row root, the hashes and the encoding of their lengths and of the
+	// index and total of the proof, about a byte per field, per row
+	assert.LessOrEqual(t, size, rows*(90+(depth+1)*tmhash.Size+32))
+}
+
+func TestHasBlockDataAndRetainHeight(t *testing.T) {
+	config := cfg.ResetTestRoot("blockchain_reactor_test")
+	defer os.RemoveAll(config.RootDir)
+	stateStore := sm.NewStore(dbm.NewMemDB(), sm.StoreOptions{
+		DiscardABCIResponses: false,
+	})
+	state, err := stateStore.LoadFromDBOrGenesisFile(config.GenesisFile())
+	require.NoError(t, err)
+	db := dbm.NewMemDB()
+	bs := NewBlockStore(db)
+	assert.False(t, bs.HasBlockData(1))
+	assert.Zero(t, bs.RetainHeight())
+
+	for h := int64(1); h <= 10; h++ {
+		block := makeBlock(h, state, new(types.Commit))
+		partSet := block.MakePartSet(2)
+		require.Greater(t, partSet.Total(), uint32(2))
+		bs.SaveBlock(block, partSet, makeTestCommit(h, cmttime.Now()))
+	}
+	for h := int64(1); h <= 10; h++ {
+		assert.True(t, bs.HasBlockData(h), "height %d", h)
+	}
+	assert.False(t, bs.HasBlockData(11))
+
+	// pruned blocks
+	bs.SaveRetainHeight(5)
+	_, err = bs.PruneBlocks(3)
+	require.NoError(t, err)
+	assert.False(t, bs.HasBlockData(2))
+	assert.True(t, bs.HasBlockData(3))
+
+	// the meta of a block outliving its last part
+	meta := bs.LoadBlockMeta(4)
+	require.NoError(t, db.Delete(calcBlockPartKey(4, int(meta.BlockID.PartSetHeader.Total)-1)))
+	assert.False(t, bs.HasBlockData(4))
+	assert.NotNil(t, bs.LoadBlockMeta(4))
+
+	// the retain height only increases, and is persisted
+	bs.SaveRetainHeight(4)
+	assert.EqualValues(t, 5, bs.RetainHeight())
+	bs = NewBlockStore(db)
+	assert.EqualValues(t, 5, bs.RetainHeight())
+	assert.EqualValues(t, 3, bs.Base())
+}
+
 func TestLoadBlockMetaByHash(t *testing.T) {
 	config := cfg.ResetTestRoot("blockchain_reactor_test")
 	defer os.RemoveAll(config.RootDir)
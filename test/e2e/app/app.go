@@ -15,8 +15,6 @@ import (
 	"github.com/tendermint/tendermint/version"
 )
 
-const appVersion = 1
-
 // Application is an ABCI application for use by end-to-end tests. It is a
 // simple key/value store for strings, storing data in memory and persisting
 // to disk as JSON, taking state sync snapshots if requested.
@@ -70,6 +68,10 @@ type Config struct {
 	//
 	// height <-> pubkey <-> voting power
 	ValidatorUpdates map[string]map[string]uint8 `toml:"validator_update"`
+
+	// AppVersion is the app version reported by Info. Defaults to 1, and is
+	// raised to simulate an upgrade of the application.
+	AppVersion uint64 `toml:"app_version"`
 }
 
 func DefaultConfig(dir string) *Config {
@@ -77,6 +79,7 @@ func DefaultConfig(dir string) *Config {
 		PersistInterval:  1,
 		SnapshotInterval: 100,
 		Dir:              dir,
+		AppVersion:       1,
 	}
 }
 
@@ -102,7 +105,7 @@ func NewApplication(cfg *Config) (*Application, error) {
 func (app *Application) Info(req abci.RequestInfo) abci.ResponseInfo {
 	return abci.ResponseInfo{
 		Version:          version.ABCIVersion,
-		AppVersion:       appVersion,
+		AppVersion:       app.cfg.AppVersion,
 		LastBlockHeight:  int64(app.state.Height),
 		LastBlockAppHash: app.state.Hash,
 	}
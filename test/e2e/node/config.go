@@ -25,6 +25,7 @@ type Config struct {
 	PrivValState     string                      `toml:"privval_state"`
 	Misbehaviors     map[string]string           `toml:"misbehaviors"`
 	KeyType          string                      `toml:"key_type"`
+	AppVersion       uint64                      `toml:"app_version"`
 }
 
 // App extracts out the application specific configuration parameters
@@ -36,6 +37,7 @@ func (cfg *Config) App() *app.Config {
 		KeyType:          cfg.KeyType,
 		ValidatorUpdates: cfg.ValidatorUpdates,
 		PersistInterval:  cfg.PersistInterval,
+		AppVersion:       cfg.AppVersion,
 	}
 }
 
@@ -45,6 +47,7 @@ func LoadConfig(file string) (*Config, error) {
 		Listen:          "unix:///var/run/app.sock",
 		Protocol:        "socket",
 		PersistInterval: 1,
+		AppVersion:      1,
 	}
 	_, err := toml.DecodeFile(file, &cfg)
 	if err != nil {
@@ -128,6 +128,12 @@ type ManifestNode struct {
 	// StartAt set to an appropriate height where a snapshot is available.
 	StateSync bool `toml:"state_sync"`
 
+	// StateSyncTimeout bounds the state sync of the node, e.g. "30s", after
+	// which it falls back to fast sync from genesis. Required for the state
+	// syncing nodes of a network where no node takes snapshots. Defaults to
+	// no timeout.
+	StateSyncTimeout string `toml:"state_sync_timeout"`
+
 	// PersistInterval specifies the height interval at which the application
 	// will persist state to disk. Defaults to 1 (every height), setting this to
 	// 0 disables state persistence.
@@ -149,6 +155,10 @@ type ManifestNode struct {
 	// kill:       kills the node with SIGKILL then restarts it
 	// pause:      temporarily pauses (freezes) the node
 	// restart:    restarts the node, shutting it down with SIGTERM
+	// app_upgrade: pauses the node via unsafe_pause_app_upgrade, then restarts
+	//             the application with app version 2 while CometBFT keeps
+	//             running. Requires the unix or tcp ABCI protocol and the file
+	//             privval protocol.
 	Perturb []string `toml:"perturb"`
 
 	// Misbehaviors sets how a validator behaves during consensus at a
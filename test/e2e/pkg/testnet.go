@@ -10,6 +10,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/tendermint/tendermint/config"
 	"github.com/tendermint/tendermint/crypto"
@@ -54,6 +55,7 @@ const (
 	PerturbationPause      Perturbation = "pause"
 	PerturbationRestart    Perturbation = "restart"
 	PerturbationUpgrade    Perturbation = "upgrade"
+	PerturbationAppUpgrade Perturbation = "app_upgrade"
 )
 
 // Testnet represents a single testnet.
@@ -92,6 +94,7 @@ type Node struct {
 	StartAt               int64
 	FastSync              string
 	StateSync             bool
+	StateSyncTimeout      time.Duration
 	Mempool               string
 	Database              string
 	ABCIProtocol          Protocol
@@ -234,6 +237,14 @@ func LoadTestnet(manifest Manifest, fname string, ifd InfrastructureData) (*Test
 		if nodeManifest.PersistInterval != nil {
 			node.PersistInterval = *nodeManifest.PersistInterval
 		}
+		if nodeManifest.StateSyncTimeout != "" {
+			timeout, err := time.ParseDuration(nodeManifest.StateSyncTimeout)
+			if err != nil {
+				return nil, fmt.Errorf("invalid state_sync_timeout %q for node %q: %w",
+					nodeManifest.StateSyncTimeout, name, err)
+			}
+			node.StateSyncTimeout = timeout
+		}
 		if node.Prometheus {
 			node.PrometheusProxyPort = prometheusProxyPortGen.Next()
 		}
@@ -412,6 +423,9 @@ func (n Node) Validate(testnet Testnet) error {
 	if n.StateSync && n.StartAt == 0 {
 		return errors.New("state synced nodes cannot start at the initial height")
 	}
+	if n.StateSyncFallsBack() && n.StateSyncTimeout <= 0 {
+		return errors.New("state synced nodes need a node taking snapshots or a state_sync_timeout")
+	}
 	if n.PersistInterval == 0 && n.RetainBlocks > 0 {
 		return errors.New("persist_interval=0 requires retain_blocks=0")
 	}
@@ -430,6 +444,14 @@ func (n Node) Validate(testnet Testnet) error {
 				return fmt.Errorf("'upgrade' perturbation can appear at most once per node")
 			}
 			upgradeFound = true
+		case PerturbationAppUpgrade:
+			if n.ABCIProtocol != ProtocolUNIX && n.ABCIProtocol != ProtocolTCP {
+				return fmt.Errorf("'app_upgrade' perturbation requires the unix or tcp ABCI protocol, not %q",
+					n.ABCIProtocol)
+			}
+			if n.PrivvalProtocol != ProtocolFile {
+				return errors.New("'app_upgrade' perturbation requires the file privval protocol")
+			}
 		case PerturbationDisconnect, PerturbationKill, PerturbationPause, PerturbationRestart:
 		default:
 			return fmt.Errorf("invalid perturbation %q", perturbation)
@@ -559,6 +581,31 @@ func (n Node) Stateless() bool {
 	return n.Mode == ModeLight || n.Mode == ModeSeed
 }
 
+// StateSyncFallsBack returns true if the node state syncs in a testnet where
+// no node takes snapshots, so it falls back to fast sync from genesis.
+func (n Node) StateSyncFallsBack() bool {
+	if !n.StateSync {
+		return false
+	}
+	for _, node := range n.Testnet.Nodes {
+		if node.SnapshotInterval > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// HasPerturbation returns whether the node is perturbed with the given
+// perturbation.
+func (n Node) HasPerturbation(perturbation Perturbation) bool {
+	for _, p := range n.Perturbations {
+		if p == perturbation {
+			return true
+		}
+	}
+	return false
+}
+
 // keyGenerator generates pseudorandom Ed25519 keys based on a seed.
 type keyGenerator struct {
 	random *rand.Rand
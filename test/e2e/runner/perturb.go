@@ -1,15 +1,25 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"path/filepath"
 	"time"
 
 	"github.com/tendermint/tendermint/libs/log"
 	rpctypes "github.com/tendermint/tendermint/rpc/core/types"
+	rpcclient "github.com/tendermint/tendermint/rpc/jsonrpc/client"
 	e2e "github.com/tendermint/tendermint/test/e2e/pkg"
 )
 
+// appUpgradeScript restarts the application of a node with the given app
+// version, leaving CometBFT running.
+const appUpgradeScript = `sed -i '1i app_version = %d' /cometbft/config/app.toml
+pkill -x app
+while pgrep -x app >/dev/null; do sleep 0.1; done
+rm -f /var/run/app.sock
+setsid nohup /usr/bin/app /cometbft/config/app.toml </dev/null >/proc/1/fd/1 2>&1 &`
+
 // Perturbs a running testnet.
 func Perturb(testnet *e2e.Testnet) error {
 	for _, node := range testnet.Nodes {
@@ -81,6 +91,25 @@ func PerturbNode(node *e2e.Node, perturbation e2e.Perturbation) (*rpctypes.Resul
 			return nil, err
 		}
 
+	case e2e.PerturbationAppUpgrade:
+		logger.Info("perturb node", "msg", log.NewLazySprintf("Upgrading the application of node %v...", node.Name))
+		paused, err := pauseAppUpgrade(node)
+		if err != nil {
+			return nil, err
+		}
+		appVersion := paused.AppVersion + 1
+		logger.Info("perturb node", "msg",
+			log.NewLazySprintf("Node %v paused at height %v, restarting its application with app version %v",
+				node.Name, paused.Height, appVersion))
+		if err := execCompose(testnet.Dir, "exec", "-T", name, "sh", "-c",
+			fmt.Sprintf(appUpgradeScript, appVersion)); err != nil {
+			return nil, err
+		}
+		// the node commits no block until it resumes with the upgraded application
+		if _, err := waitForNode(node, paused.Height+2, time.Minute); err != nil {
+			return nil, err
+		}
+
 	case e2e.PerturbationUpgrade:
 		oldV := node.Version
 		newV := node.Testnet.UpgradeVersion
@@ -119,3 +148,18 @@ func PerturbNode(node *e2e.Node, perturbation e2e.Perturbation) (*rpctypes.Resul
 		log.NewLazySprintf("Node %v recovered at height %v", node.Name, status.SyncInfo.LatestBlockHeight))
 	return status, nil
 }
+
+// pauseAppUpgrade pauses a node for an upgrade of its application.
+func pauseAppUpgrade(node *e2e.Node) (*rpctypes.ResultPauseAppUpgrade, error) {
+	client, err := rpcclient.New(fmt.Sprintf("http://127.0.0.1:%v", node.ProxyPort))
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	result := &rpctypes.ResultPauseAppUpgrade{}
+	if _, err := client.Call(ctx, "unsafe_pause_app_upgrade", map[string]interface{}{}, result); err != nil {
+		return nil, fmt.Errorf("failed to pause node %v for an upgrade of its application: %w", node.Name, err)
+	}
+	return result, nil
+}
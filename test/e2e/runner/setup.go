@@ -229,6 +229,7 @@ func MakeConfig(node *e2e.Node) (*config.Config, error) {
 
 	if node.StateSync {
 		cfg.StateSync.Enable = true
+		cfg.StateSync.Timeout = node.StateSyncTimeout
 		cfg.StateSync.RPCServers = []string{}
 		for _, peer := range node.Testnet.ArchiveNodes() {
 			if peer.Name == node.Name {
@@ -264,6 +265,10 @@ func MakeConfig(node *e2e.Node) (*config.Config, error) {
 	if node.Prometheus {
 		cfg.Instrumentation.Prometheus = true
 	}
+	if node.HasPerturbation(e2e.PerturbationAppUpgrade) {
+		// the application upgrade is orchestrated via unsafe_pause_app_upgrade
+		cfg.RPC.Unsafe = true
+	}
 
 	return cfg, nil
 }
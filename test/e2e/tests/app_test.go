@@ -54,6 +54,22 @@ func TestApp_Hash(t *testing.T) {
 	})
 }
 
+// Tests that the nodes whose application was upgraded run it with the higher
+// app version.
+func TestApp_Upgraded(t *testing.T) {
+	testNode(t, func(t *testing.T, node e2e.Node) {
+		if !node.HasPerturbation(e2e.PerturbationAppUpgrade) {
+			return
+		}
+
+		client, err := node.Client()
+		require.NoError(t, err)
+		info, err := client.ABCIInfo(ctx)
+		require.NoError(t, err)
+		assert.EqualValues(t, 2, info.Response.AppVersion)
+	})
+}
+
 // Tests that we can set a value and retrieve it.
 func TestApp_Tx(t *testing.T) {
 	testNode(t, func(t *testing.T, node e2e.Node) {
@@ -65,7 +65,7 @@ func TestBlock_Range(t *testing.T) {
 		last := status.SyncInfo.LatestBlockHeight
 
 		switch {
-		case node.StateSync:
+		case node.StateSync && !node.StateSyncFallsBack():
 			assert.Greater(t, first, node.Testnet.InitialHeight,
 				"state synced nodes should not contain network's initial height")
 
@@ -0,0 +1,33 @@
+package e2e_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/statesync"
+	e2e "github.com/tendermint/tendermint/test/e2e/pkg"
+)
+
+// Tests that the state syncing nodes which found no snapshots fell back to
+// fast sync, synced the whole chain and caught up with the network.
+func TestStateSync_Fallback(t *testing.T) {
+	testNode(t, func(t *testing.T, node e2e.Node) {
+		if !node.StateSyncFallsBack() {
+			return
+		}
+
+		client, err := node.Client()
+		require.NoError(t, err)
+		status, err := client.Status(ctx)
+		require.NoError(t, err)
+
+		require.NotNil(t, status.SyncInfo.StateSync, "the node did not report its state sync")
+		assert.Equal(t, string(statesync.SyncStateFallback), status.SyncInfo.StateSync.State)
+		assert.Equal(t, statesync.FailureReasonTimeout, status.SyncInfo.StateSync.FailureReason)
+		assert.False(t, status.SyncInfo.CatchingUp)
+		assert.Equal(t, node.Testnet.InitialHeight, status.SyncInfo.EarliestBlockHeight)
+		assert.GreaterOrEqual(t, status.SyncInfo.LatestBlockHeight, node.StartAt)
+	})
+}
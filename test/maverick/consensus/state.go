@@ -542,6 +542,19 @@ func (cs *State) GetRoundStateSimpleJSON() ([]byte, error) {
 	return cmtjson.Marshal(cs.RoundState.RoundStateSimple())
 }
 
+// WithRoundStateSimpleJSON calls fn with a json of RoundStateSimple, holding
+// the consensus lock until fn returns so that no block is committed meanwhile.
+// fn must not call back into the consensus state.
+func (cs *State) WithRoundStateSimpleJSON(fn func(roundState []byte) error) error {
+	cs.mtx.RLock()
+	defer cs.mtx.RUnlock()
+	bz, err := cmtjson.Marshal(cs.RoundState.RoundStateSimple())
+	if err != nil {
+		return err
+	}
+	return fn(bz)
+}
+
 // GetValidators returns a copy of the current validators.
 func (cs *State) GetValidators() (int64, []*types.Validator) {
 	cs.mtx.RLock()
@@ -309,6 +309,28 @@ func MaxDataBytesNoEvidence(maxBytes int64, valsCount int) int64 {
 	return maxDataBytes
 }
 
+// MaxEvidenceBytes returns the maximum size of block's evidence, given the
+// size of its data: the smaller of the evidence's own limit, evidenceMaxBytes,
+// and the block space left by the header, the last commit and the data. Unlike
+// MaxDataBytes, it does not panic, but returns 0 if there is no space left, so
+// that evidence never prevents a block from being proposed.
+func MaxEvidenceBytes(maxBytes, evidenceMaxBytes, dataBytes int64, valsCount int) int64 {
+	maxEvidenceBytes := maxBytes -
+		MaxOverheadForBlock -
+		MaxHeaderBytes -
+		MaxCommitBytes(valsCount) -
+		dataBytes
+
+	if maxEvidenceBytes > evidenceMaxBytes {
+		maxEvidenceBytes = evidenceMaxBytes
+	}
+	if maxEvidenceBytes < 0 {
+		return 0
+	}
+
+	return maxEvidenceBytes
+}
+
 // MakeBlock returns a new block with an empty header, except what can be
 // computed from itself.
 // It populates the same set of fields validated by ValidateBasic.
@@ -1040,6 +1062,16 @@ func (data *Data) Hash() cmtbytes.HexBytes {
 	return data.hash
 }
 
+// Size returns the number of bytes of the txs, as counted against
+// MaxDataBytes.
+func (data *Data) Size() int64 {
+	var size int64
+	for _, tx := range data.Txs {
+		size += int64(len(tx))
+	}
+	return size
+}
+
 type Blob struct {
 	// NamespaceVersion is the version of the namespace. Used in conjunction
 	// with NamespaceID to determine the namespace of this blob.
@@ -14,6 +14,12 @@ type BlockMeta struct {
 	BlockSize int     `json:"block_size"`
 	Header    Header  `json:"header"`
 	NumTxs    int     `json:"num_txs"`
+	// Square is nil for the blocks saved before it was recorded.
+	Square *DataSquareInfo `json:"square,omitempty"`
+
+	// Volatile: not persisted. Set by the RPC for the blocks whose meta is in
+	// the block store but not their data, e.g. while being pruned.
+	DataPruned bool `json:"data_pruned,omitempty"`
 }
 
 // NewBlockMeta returns a new BlockMeta.
@@ -23,6 +29,64 @@ func NewBlockMeta(block *Block, blockParts *PartSet) *BlockMeta {
 		BlockSize: block.Size(),
 		Header:    block.Header,
 		NumTxs:    len(block.Data.Txs),
+		Square:    NewDataSquareInfo(&block.Data),
+	}
+}
+
+// DataSquareInfo describes the use of the data square of a block.
+type DataSquareInfo struct {
+	SquareSize uint64 `json:"square_size"`
+	// SharesUsed is the number of shares holding transactions and blobs, i.e.
+	// not padding.
+	SharesUsed int `json:"shares_used"`
+	BlobBytes  int `json:"blob_bytes"`
+}
+
+// NewDataSquareInfo returns the use of the data square of data. The shares of
+// the transactions and blobs are counted as laid out by the application:
+// the transactions, the transactions paying for blobs, then the blobs, each in
+// their own shares.
+func NewDataSquareInfo(data *Data) *DataSquareInfo {
+	info := &DataSquareInfo{SquareSize: data.SquareSize}
+	var txsSize, pfbTxsSize int
+	for _, tx := range data.Txs {
+		blobTx, isBlobTx := UnmarshalBlobTx(tx)
+		if !isBlobTx {
+			txsSize += compactUnitSize(tx)
+			continue
+		}
+		pfbTxsSize += compactUnitSize(blobTx.Tx)
+		for _, blob := range blobTx.Blobs {
+			info.BlobBytes += len(blob.Data)
+			info.SharesUsed += SharesForBlobOfVersion(len(blob.Data), uint8(blob.ShareVersion))
+		}
+	}
+	info.SharesUsed += compactSharesForUnits(txsSize) + compactSharesForUnits(pfbTxsSize)
+	return info
+}
+
+// ToProto converts DataSquareInfo to protobuf.
+func (dsi *DataSquareInfo) ToProto() *cmtproto.DataSquareInfo {
+	if dsi == nil {
+		return nil
+	}
+	return &cmtproto.DataSquareInfo{
+		SquareSize: dsi.SquareSize,
+		SharesUsed: int64(dsi.SharesUsed),
+		BlobBytes:  int64(dsi.BlobBytes),
+	}
+}
+
+// DataSquareInfoFromProto converts a protobuf DataSquareInfo, which may be
+// nil, to DataSquareInfo.
+func DataSquareInfoFromProto(pb *cmtproto.DataSquareInfo) *DataSquareInfo {
+	if pb == nil {
+		return nil
+	}
+	return &DataSquareInfo{
+		SquareSize: pb.SquareSize,
+		SharesUsed: int(pb.SharesUsed),
+		BlobBytes:  int(pb.BlobBytes),
 	}
 }
 
@@ -36,6 +100,7 @@ func (bm *BlockMeta) ToProto() *cmtproto.BlockMeta {
 		BlockSize: int64(bm.BlockSize),
 		Header:    *bm.Header.ToProto(),
 		NumTxs:    int64(bm.NumTxs),
+		Square:    bm.Square.ToProto(),
 	}
 	return pb
 }
@@ -61,6 +126,7 @@ func BlockMetaFromProto(pb *cmtproto.BlockMeta) (*BlockMeta, error) {
 	bm.BlockSize = int(pb.BlockSize)
 	bm.Header = h
 	bm.NumTxs = int(pb.NumTxs)
+	bm.Square = DataSquareInfoFromProto(pb.Square)
 
 	return bm, bm.ValidateBasic()
 }
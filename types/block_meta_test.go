@@ -7,6 +7,8 @@ import (
 
 	"github.com/tendermint/tendermint/crypto/tmhash"
 	cmtrand "github.com/tendermint/tendermint/libs/rand"
+	"github.com/tendermint/tendermint/pkg/consts"
+	cmtproto "github.com/tendermint/tendermint/proto/tendermint/types"
 )
 
 func TestBlockMeta_ToProto(t *testing.T) {
@@ -26,6 +28,13 @@ func TestBlockMeta_ToProto(t *testing.T) {
 		expErr   bool
 	}{
 		{"success", bm, false},
+		{"success with square", &BlockMeta{
+			BlockID:   bi,
+			BlockSize: 200,
+			Header:    h,
+			NumTxs:    2,
+			Square:    &DataSquareInfo{SquareSize: 4, SharesUsed: 5, BlobBytes: 1000},
+		}, false},
 		{"failure nil", nil, true},
 	}
 
@@ -46,6 +55,40 @@ func TestBlockMeta_ToProto(t *testing.T) {
 	}
 }
 
+func TestNewDataSquareInfo(t *testing.T) {
+	blob := func(size int, shareVersion uint32) *cmtproto.Blob {
+		return &cmtproto.Blob{
+			NamespaceId:  make([]byte, consts.NamespaceIDSize),
+			Data:         make([]byte, size),
+			ShareVersion: shareVersion,
+		}
+	}
+	blobTx, err := MarshalBlobTx(make([]byte, 100), blob(1000, 0), blob(firstSparseShareContentSize, 1))
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name string
+		data Data
+		exp  DataSquareInfo
+	}{
+		{"empty", Data{SquareSize: 1}, DataSquareInfo{SquareSize: 1}},
+		{"txs sharing a share", Data{Txs: Txs{make(Tx, 100), make(Tx, 200)}, SquareSize: 1},
+			DataSquareInfo{SquareSize: 1, SharesUsed: 1}},
+		{"txs spanning shares", Data{Txs: Txs{make(Tx, 400), make(Tx, 400)}, SquareSize: 2},
+			DataSquareInfo{SquareSize: 2, SharesUsed: 2}},
+		// a share of txs, a share of PFB txs, 3 shares for the first blob and 2
+		// for the second, whose signer pushes its data past the first share
+		{"blobs", Data{Txs: Txs{make(Tx, 10), blobTx}, SquareSize: 4},
+			DataSquareInfo{SquareSize: 4, SharesUsed: 7, BlobBytes: 1000 + firstSparseShareContentSize}},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, &tc.exp, NewDataSquareInfo(&tc.data))
+		})
+	}
+}
+
 func TestBlockMeta_ValidateBasic(t *testing.T) {
 	h := makeRandHeader()
 	bi := BlockID{Hash: h.Hash(), PartSetHeader: PartSetHeader{Total: 123, Hash: cmtrand.Bytes(tmhash.Size)}}
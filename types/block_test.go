@@ -492,6 +492,35 @@ func TestBlockMaxDataBytesNoEvidence(t *testing.T) {
 	}
 }
 
+func TestBlockMaxEvidenceBytes(t *testing.T) {
+	testCases := []struct {
+		maxBytes         int64
+		evidenceMaxBytes int64
+		dataBytes        int64
+		valsCount        int
+		result           int64
+	}{
+		0:  {-10, 100, 0, 1, 0},
+		1:  {841, 100, 0, 1, 0},
+		2:  {842, 100, 0, 1, 0},
+		3:  {843, 100, 0, 1, 1},
+		4:  {942, 100, 0, 1, 100},
+		5:  {943, 100, 0, 1, 100},
+		6:  {942, 100, 1, 1, 99},
+		7:  {942, 100, 100, 1, 0},
+		8:  {942, 100, 101, 1, 0},
+		9:  {1053, 100, 0, 2, 100},
+		10: {1052, 100, 0, 2, 99},
+	}
+
+	for i, tc := range testCases {
+		assert.Equal(t,
+			tc.result,
+			MaxEvidenceBytes(tc.maxBytes, tc.evidenceMaxBytes, tc.dataBytes, tc.valsCount),
+			"#%v", i)
+	}
+}
+
 func TestCommitToVoteSet(t *testing.T) {
 	lastID := makeBlockIDRandom()
 	h := int64(3)
@@ -0,0 +1,149 @@
+package types
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/tendermint/tendermint/pkg/consts"
+)
+
+// The layout of the compact shares holding the transactions of a block:
+//
+//	namespace | info byte | sequence length (first share only) | reserved bytes | units
+//
+// where the units are the length delimited transactions of the sequence,
+// spanning shares, and the reserved bytes hold the index in the share of the
+// first unit starting in it, or 0 if none does.
+const (
+	compactShareInfoByteSize       = 1
+	compactShareSequenceLenSize    = 4
+	compactShareReservedBytesSize  = 4
+	compactShareSequenceStartFlag  = 1
+	compactShareMinSizeWithoutData = consts.NamespaceSize + compactShareInfoByteSize + compactShareReservedBytesSize
+
+	// the number of bytes of units held by the first share of a sequence, and
+	// by the following ones
+	firstCompactShareContentSize        = consts.ShareSize - compactShareMinSizeWithoutData - compactShareSequenceLenSize
+	continuationCompactShareContentSize = consts.ShareSize - compactShareMinSizeWithoutData
+)
+
+// SharesForTx returns the number of compact shares the length delimited tx
+// occupies when it starts a sequence, as a tx alone in the data square does.
+// Next to other txs, it can start in a share it shares with the previous tx,
+// and span one share more or less.
+func SharesForTx(tx Tx) int {
+	return compactSharesForUnits(compactUnitSize(tx))
+}
+
+// compactUnitSize returns the size of the length delimited encoding of tx.
+func compactUnitSize(tx []byte) int {
+	return uvarintSize(uint64(len(tx))) + len(tx)
+}
+
+// compactSharesForUnits returns the number of compact shares of a sequence of
+// units of unitsSize bytes in total.
+func compactSharesForUnits(unitsSize int) int {
+	if unitsSize <= 0 {
+		return 0
+	}
+	if unitsSize <= firstCompactShareContentSize {
+		return 1
+	}
+	rest := unitsSize - firstCompactShareContentSize
+	return 1 + (rest+continuationCompactShareContentSize-1)/continuationCompactShareContentSize
+}
+
+// uvarintSize returns the number of bytes of the uvarint encoding of x.
+func uvarintSize(x uint64) int {
+	var buf [binary.MaxVarintLen64]byte
+	return binary.PutUvarint(buf[:], x)
+}
+
+// CompactShareTxs returns the transactions whose length delimited encoding
+// starts in the compact shares in Data, in order. A transaction that starts in
+// the shares but continues past the last share is left out, and so is the end
+// of a transaction that starts before the first share. It does not verify the
+// proof.
+func (sp ShareProof) CompactShareTxs() ([]Tx, error) {
+	if len(sp.Data) == 0 {
+		return nil, errors.New("the proof contains no shares")
+	}
+
+	// concatenate the units of the shares, remembering where the first unit
+	// starting in the shares is
+	var (
+		data       []byte
+		firstUnit  = -1
+		infoOffset = consts.NamespaceSize
+	)
+	for i, share := range sp.Data {
+		if len(share) < compactShareMinSizeWithoutData {
+			return nil, fmt.Errorf("share %d is too short: %d bytes", i, len(share))
+		}
+		offset := infoOffset + compactShareInfoByteSize
+		if share[infoOffset]&compactShareSequenceStartFlag != 0 {
+			offset += compactShareSequenceLenSize
+		}
+		if len(share) < offset+compactShareReservedBytesSize {
+			return nil, fmt.Errorf("share %d is too short: %d bytes", i, len(share))
+		}
+		reserved := int(binary.BigEndian.Uint32(share[offset : offset+compactShareReservedBytesSize]))
+		offset += compactShareReservedBytesSize
+		if firstUnit < 0 && reserved != 0 {
+			if reserved < offset || reserved >= len(share) {
+				return nil, fmt.Errorf("share %d has an invalid first unit index %d", i, reserved)
+			}
+			firstUnit = len(data) + reserved - offset
+		}
+		data = append(data, share[offset:]...)
+	}
+	if firstUnit < 0 {
+		// no transaction starts in the shares
+		return nil, nil
+	}
+
+	var txs []Tx
+	for cursor := firstUnit; cursor < len(data); {
+		txLen, n := binary.Uvarint(data[cursor:])
+		if n <= 0 {
+			// the length is truncated by the end of the shares
+			break
+		}
+		if txLen == 0 {
+			// padding after the last transaction
+			break
+		}
+		cursor += n
+		if txLen > uint64(len(data)-cursor) {
+			break
+		}
+		txs = append(txs, Tx(data[cursor:cursor+int(txLen)]))
+		cursor += int(txLen)
+	}
+	return txs, nil
+}
+
+// ContainsTx returns nil if the transaction is one of the transactions held by
+// the compact shares in Data, see CompactShareTxs. The shares of a blob
+// transaction hold its MsgPayForBlobs transaction, wrapped with the indexes of
+// the blob shares. It does not verify the proof.
+func (sp ShareProof) ContainsTx(tx Tx) error {
+	txs, err := sp.CompactShareTxs()
+	if err != nil {
+		return err
+	}
+	bTx, isBlob := UnmarshalBlobTx(tx)
+	for _, shareTx := range txs {
+		if !isBlob && bytes.Equal(shareTx, tx) {
+			return nil
+		}
+		if isBlob {
+			if indexWrapper, ok := UnmarshalIndexWrapper(shareTx); ok && bytes.Equal(indexWrapper.Tx, bTx.Tx) {
+				return nil
+			}
+		}
+	}
+	return errors.New("the shares of the proof do not contain the tx")
+}
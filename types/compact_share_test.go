@@ -0,0 +1,125 @@
+package types
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/pkg/consts"
+)
+
+func TestShareProofCompactShareTxs(t *testing.T) {
+	txs := []Tx{
+		bytes.Repeat([]byte{1}, 100),
+		bytes.Repeat([]byte{2}, 600), // spans two shares
+		bytes.Repeat([]byte{3}, 10),
+		bytes.Repeat([]byte{4}, 300),
+	}
+	shares := compactShares(t, txs)
+	require.Len(t, shares, 3)
+
+	got, err := ShareProof{Data: shares}.CompactShareTxs()
+	require.NoError(t, err)
+	assert.Equal(t, txs, got)
+
+	// the second share starts in the middle of the second tx, whose end is
+	// left out like the last tx continuing past the last share
+	got, err = ShareProof{Data: shares[1:2]}.CompactShareTxs()
+	require.NoError(t, err)
+	assert.Equal(t, []Tx{txs[2]}, got)
+	got, err = ShareProof{Data: shares[1:]}.CompactShareTxs()
+	require.NoError(t, err)
+	assert.Equal(t, txs[2:], got)
+	got, err = ShareProof{Data: shares[:1]}.CompactShareTxs()
+	require.NoError(t, err)
+	assert.Equal(t, txs[:1], got)
+
+	_, err = ShareProof{}.CompactShareTxs()
+	assert.Error(t, err)
+	_, err = ShareProof{Data: [][]byte{shares[0][:10]}}.CompactShareTxs()
+	assert.Error(t, err)
+
+	// the reserved bytes point before the units
+	invalid := append([]byte(nil), shares[0]...)
+	binary.BigEndian.PutUint32(invalid[consts.NamespaceSize+5:], 3)
+	_, err = ShareProof{Data: [][]byte{invalid}}.CompactShareTxs()
+	assert.Error(t, err)
+}
+
+func TestSharesForTx(t *testing.T) {
+	// the first share holds 474 bytes of units, and the following ones 478
+	tests := []struct {
+		txSize int
+		want   int
+	}{
+		{0, 1},
+		{1, 1},
+		{127, 1}, // the largest tx with a 1 byte length delimiter
+		{128, 1},
+		{472, 1}, // exactly fills the first share
+		{473, 2},
+		{950, 2}, // exactly fills two shares
+		{951, 3},
+		{1428, 3},
+		{1429, 4},
+		{100_000, 210},
+	}
+	for _, tt := range tests {
+		tx := Tx(bytes.Repeat([]byte{1}, tt.txSize))
+		assert.Equal(t, tt.want, SharesForTx(tx), tt.txSize)
+		if tt.txSize > 0 {
+			assert.Len(t, compactShares(t, []Tx{tx}), tt.want, tt.txSize)
+		}
+	}
+}
+
+// compactShares splits the length delimited txs into 512 byte compact shares
+// of the transaction namespace.
+func compactShares(tb testing.TB, txs []Tx) [][]byte {
+	const shareSize = consts.ShareSize
+	namespace := make([]byte, consts.NamespaceSize)
+	namespace[len(namespace)-1] = 1
+
+	var (
+		units      []byte
+		unitStarts []int
+	)
+	for _, tx := range txs {
+		unitStarts = append(unitStarts, len(units))
+		units = binary.AppendUvarint(units, uint64(len(tx)))
+		units = append(units, tx...)
+	}
+
+	var shares [][]byte
+	for cursor, i := 0, 0; cursor < len(units); i++ {
+		share := append([]byte{}, namespace...)
+		if i == 0 {
+			share = append(share, compactShareSequenceStartFlag)
+			share = binary.BigEndian.AppendUint32(share, uint32(len(units)))
+		} else {
+			share = append(share, 0)
+		}
+		dataStart := len(share) + compactShareReservedBytesSize
+		end := cursor + shareSize - dataStart
+		if end > len(units) {
+			end = len(units)
+		}
+		reserved := 0
+		for _, start := range unitStarts {
+			if start >= cursor && start < end {
+				reserved = dataStart + start - cursor
+				break
+			}
+		}
+		share = binary.BigEndian.AppendUint32(share, uint32(reserved))
+		share = append(share, units[cursor:end]...)
+		share = append(share, make([]byte, shareSize-len(share))...)
+		require.Len(tb, share, shareSize)
+		shares = append(shares, share)
+		cursor = end
+	}
+	return shares
+}
@@ -39,3 +39,41 @@ func NewErrInvalidCommitSignatures(expected, actual int) ErrInvalidCommitSignatu
 func (e ErrInvalidCommitSignatures) Error() string {
 	return fmt.Sprintf("Invalid commit -- wrong set size: %v vs %v", e.Expected, e.Actual)
 }
+
+// ErrRowVerification is returned when the verification of a row of a
+// ShareProof or RowProof fails.
+type ErrRowVerification struct {
+	// Row is the index of the row in the data square.
+	Row int
+	// RootIndex is the index of the data root of the row, for a proof whose
+	// rows span several data roots, see RowProof.RootIndices.
+	RootIndex int
+	// Start and End are the range of the shares proven in the row by the NMT
+	// proof, End being exclusive. Both are 0 if unknown, e.g. when verifying a
+	// RowProof alone.
+	Start int32
+	End   int32
+	// RowProof is true if the proof of the row root against the data root
+	// failed, false if the NMT inclusion check of the shares in the row did.
+	RowProof bool
+	Reason   error
+}
+
+func (e *ErrRowVerification) Error() string {
+	check := "NMT inclusion check"
+	if e.RowProof {
+		check = "row proof"
+	}
+	row := fmt.Sprintf("row %d", e.Row)
+	if e.RootIndex > 0 {
+		row += fmt.Sprintf(" of data root %d", e.RootIndex)
+	}
+	if e.Start == 0 && e.End == 0 {
+		return fmt.Sprintf("%s of %s failed: %v", check, row, e.Reason)
+	}
+	return fmt.Sprintf("%s of %s (shares %d to %d) failed: %v", check, row, e.Start, e.End, e.Reason)
+}
+
+func (e *ErrRowVerification) Unwrap() error {
+	return e.Reason
+}
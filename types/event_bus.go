@@ -19,6 +19,7 @@ type EventBusSubscriber interface {
 
 	NumClients() int
 	NumClientSubscriptions(clientID string) int
+	NumSubscriptions() int
 }
 
 type Subscription interface {
@@ -72,6 +73,10 @@ func (b *EventBus) NumClientSubscriptions(clientID string) int {
 	return b.pubsub.NumClientSubscriptions(clientID)
 }
 
+func (b *EventBus) NumSubscriptions() int {
+	return b.pubsub.NumSubscriptions()
+}
+
 func (b *EventBus) Subscribe(
 	ctx context.Context,
 	subscriber string,
@@ -99,6 +104,17 @@ func (b *EventBus) UnsubscribeAll(ctx context.Context, subscriber string) error
 	return b.pubsub.UnsubscribeAll(ctx, subscriber)
 }
 
+// UnsubscribeOwned removes the subscription of the subscriber, provided it is
+// still the subscription of the subscriber to its query, see
+// pubsub.Server.UnsubscribeOwned.
+func (b *EventBus) UnsubscribeOwned(ctx context.Context, subscriber string, subscription Subscription) error {
+	sub, ok := subscription.(*cmtpubsub.Subscription)
+	if !ok {
+		return cmtpubsub.ErrSubscriptionNotFound
+	}
+	return b.pubsub.UnsubscribeOwned(ctx, subscriber, sub)
+}
+
 func (b *EventBus) Publish(eventType string, eventData TMEventData) error {
 	// no explicit deadline for publishing events
 	ctx := context.Background()
@@ -148,6 +164,11 @@ func (b *EventBus) PublishEventNewSignedBlock(data EventDataSignedBlock) error {
 	return b.Publish(EventSignedBlock, data)
 }
 
+// PublishEventNewBlockHeader publishes the header of a new block. The event
+// duplicates the NewBlock event without the block data and is only kept for
+// existing subscribers: RPC subscribers should subscribe to NewBlock events
+// with the header payload instead, which the RPC derives from the NewBlock
+// event.
 func (b *EventBus) PublishEventNewBlockHeader(data EventDataNewBlockHeader) error {
 	// no explicit deadline for publishing events
 	ctx := context.Background()
@@ -231,6 +252,22 @@ func (b *EventBus) PublishEventValidatorSetUpdates(data EventDataValidatorSetUpd
 	return b.Publish(EventValidatorSetUpdates, data)
 }
 
+func (b *EventBus) PublishEventFinalized(data EventDataFinalized) error {
+	return b.Publish(EventFinalized, data)
+}
+
+func (b *EventBus) PublishEventClockDrift(data EventDataClockDrift) error {
+	return b.Publish(EventClockDrift, data)
+}
+
+func (b *EventBus) PublishEventAppUpgrade(data EventDataAppUpgrade) error {
+	return b.Publish(EventAppUpgrade, data)
+}
+
+func (b *EventBus) PublishEventMempoolTx(data EventDataMempoolTx) error {
+	return b.Publish(EventMempoolTx, data)
+}
+
 // -----------------------------------------------------------------------------
 type NopEventBus struct{}
 
@@ -314,3 +351,19 @@ func (NopEventBus) PublishEventLock(data EventDataRoundState) error {
 func (NopEventBus) PublishEventValidatorSetUpdates(data EventDataValidatorSetUpdates) error {
 	return nil
 }
+
+func (NopEventBus) PublishEventFinalized(data EventDataFinalized) error {
+	return nil
+}
+
+func (NopEventBus) PublishEventClockDrift(data EventDataClockDrift) error {
+	return nil
+}
+
+func (NopEventBus) PublishEventAppUpgrade(data EventDataAppUpgrade) error {
+	return nil
+}
+
+func (NopEventBus) PublishEventMempoolTx(data EventDataMempoolTx) error {
+	return nil
+}
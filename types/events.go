@@ -2,8 +2,10 @@ package types
 
 import (
 	"fmt"
+	"time"
 
 	abci "github.com/tendermint/tendermint/abci/types"
+	cmtbytes "github.com/tendermint/tendermint/libs/bytes"
 	cmtjson "github.com/tendermint/tendermint/libs/json"
 	cmtpubsub "github.com/tendermint/tendermint/libs/pubsub"
 	cmtquery "github.com/tendermint/tendermint/libs/pubsub/query"
@@ -18,6 +20,7 @@ const (
 	// All of this data can be fetched through the rpc.
 	EventNewBlock            = "NewBlock"
 	EventNewBlockHeader      = "NewBlockHeader"
+	EventFinalized           = "Finalized"
 	EventSignedBlock         = "NewSignedBlock"
 	EventNewEvidence         = "NewEvidence"
 	EventTx                  = "Tx"
@@ -37,6 +40,12 @@ const (
 	EventUnlock           = "Unlock"
 	EventValidBlock       = "ValidBlock"
 	EventVote             = "Vote"
+
+	// Local events, describing the node itself. They are not part of
+	// consensus, and other nodes do not observe them.
+	EventClockDrift = "ClockDrift"
+	EventAppUpgrade = "AppUpgrade"
+	EventMempoolTx  = "MempoolTx"
 )
 
 // ENCODING / DECODING
@@ -50,6 +59,7 @@ func init() {
 	cmtjson.RegisterType(EventDataNewBlock{}, "tendermint/event/NewBlock")
 	cmtjson.RegisterType(EventDataSignedBlock{}, "tendermint/event/NewSignedBlock")
 	cmtjson.RegisterType(EventDataNewBlockHeader{}, "tendermint/event/NewBlockHeader")
+	cmtjson.RegisterType(EventDataFinalized{}, "tendermint/event/Finalized")
 	cmtjson.RegisterType(EventDataNewEvidence{}, "tendermint/event/NewEvidence")
 	cmtjson.RegisterType(EventDataTx{}, "tendermint/event/Tx")
 	cmtjson.RegisterType(EventDataRoundState{}, "tendermint/event/RoundState")
@@ -58,6 +68,9 @@ func init() {
 	cmtjson.RegisterType(EventDataVote{}, "tendermint/event/Vote")
 	cmtjson.RegisterType(EventDataValidatorSetUpdates{}, "tendermint/event/ValidatorSetUpdates")
 	cmtjson.RegisterType(EventDataString(""), "tendermint/event/ProposalString")
+	cmtjson.RegisterType(EventDataClockDrift{}, "tendermint/event/ClockDrift")
+	cmtjson.RegisterType(EventDataAppUpgrade{}, "tendermint/event/AppUpgrade")
+	cmtjson.RegisterType(EventDataMempoolTx{}, "tendermint/event/MempoolTx")
 }
 
 // Most event messages are basic types (a block, a transaction)
@@ -70,6 +83,16 @@ type EventDataNewBlock struct {
 	ResultEndBlock   abci.ResponseEndBlock   `json:"result_end_block"`
 }
 
+// ToHeader returns the data of the NewBlockHeader event of the block.
+func (data EventDataNewBlock) ToHeader() EventDataNewBlockHeader {
+	return EventDataNewBlockHeader{
+		Header:           data.Block.Header,
+		NumTxs:           int64(len(data.Block.Txs)),
+		ResultBeginBlock: data.ResultBeginBlock,
+		ResultEndBlock:   data.ResultEndBlock,
+	}
+}
+
 // EventDataSignedBlock contains all the information needed to verify
 // the data committed in a block.
 type EventDataSignedBlock struct {
@@ -87,6 +110,19 @@ type EventDataNewBlockHeader struct {
 	ResultEndBlock   abci.ResponseEndBlock   `json:"result_end_block"`
 }
 
+// EventDataFinalized is published once per height, after the NewBlock, Tx and
+// other block events of the height, when the block, its ABCI results and the
+// resulting state have been durably persisted. Unlike NewBlock, which may be
+// published again when a height is re-processed after a crash, it is published
+// at most once per height across restarts, so it may be missed, but never
+// repeated, if the node crashes while publishing it.
+type EventDataFinalized struct {
+	Height    int64             `json:"height"`
+	BlockHash cmtbytes.HexBytes `json:"block_hash"`
+	DataHash  cmtbytes.HexBytes `json:"data_hash"`
+	AppHash   cmtbytes.HexBytes `json:"app_hash"`
+}
+
 type EventDataNewEvidence struct {
 	Evidence Evidence `json:"evidence"`
 
@@ -136,6 +172,67 @@ type EventDataValidatorSetUpdates struct {
 	ValidatorUpdates []*Validator `json:"validator_updates"`
 }
 
+// EventDataClockDrift is published when the estimated drift of the local clock
+// exceeds the configured threshold. A positive drift means the local clock is
+// ahead of the clocks of the other validators.
+type EventDataClockDrift struct {
+	// Source is what the drift was estimated from: the timestamps of the votes
+	// of the peers, or the times of the recent blocks.
+	Source    string        `json:"source"`
+	Drift     time.Duration `json:"drift"`
+	Threshold time.Duration `json:"threshold"`
+}
+
+// The statuses of an upgrade of the application.
+const (
+	AppUpgradePaused    = "paused"
+	AppUpgradeCompleted = "completed"
+	AppUpgradeAborted   = "aborted"
+)
+
+// EventDataAppUpgrade is published when the node pauses its participation in
+// consensus for an upgrade of the application, and when it resumes it, either
+// with the upgraded application or after giving up.
+type EventDataAppUpgrade struct {
+	Status string `json:"status"`
+	// Height is the last height committed before the pause.
+	Height     int64  `json:"height"`
+	AppVersion uint64 `json:"app_version"`
+	// Reason is why the upgrade was aborted.
+	Reason string `json:"reason,omitempty"`
+}
+
+// The actions of a MempoolTx event.
+const (
+	MempoolTxAdded   = "add"
+	MempoolTxRemoved = "remove"
+)
+
+// The reasons for which a transaction leaves the mempool.
+const (
+	MempoolTxCommitted     = "committed"
+	MempoolTxEvicted       = "evicted"
+	MempoolTxRecheckFailed = "recheck-failed"
+)
+
+// EventDataMempoolTx is published when a transaction is added to or removed
+// from the mempool. Sequence increases by one with every event of the
+// mempool, so a subscriber can detect the events it missed, and matches the
+// sequence of a snapshot of the mempool taken after the event.
+type EventDataMempoolTx struct {
+	Action   string            `json:"action"`
+	Sequence uint64            `json:"sequence"`
+	Hash     cmtbytes.HexBytes `json:"hash"`
+	// Size, GasWanted, Priority and Sender are only set when the transaction
+	// is added.
+	Size      int    `json:"size,omitempty"`
+	GasWanted int64  `json:"gas_wanted,omitempty"`
+	Priority  int64  `json:"priority,omitempty"`
+	Sender    string `json:"sender,omitempty"`
+	// Reason is only set when the transaction is removed.
+	Reason string `json:"reason,omitempty"`
+}
+
 // PUBSUB
 
 const (
@@ -159,8 +256,12 @@ const (
 )
 
 var (
+	EventQueryAppUpgrade          = QueryForEvent(EventAppUpgrade)
+	EventQueryClockDrift          = QueryForEvent(EventClockDrift)
 	EventQueryCompleteProposal    = QueryForEvent(EventCompleteProposal)
+	EventQueryFinalized           = QueryForEvent(EventFinalized)
 	EventQueryLock                = QueryForEvent(EventLock)
+	EventQueryMempoolTx           = QueryForEvent(EventMempoolTx)
 	EventQueryNewBlock            = QueryForEvent(EventNewBlock)
 	EventQueryNewBlockHeader      = QueryForEvent(EventNewBlockHeader)
 	EventQueryNewEvidence         = QueryForEvent(EventNewEvidence)
@@ -191,6 +292,7 @@ type BlockEventPublisher interface {
 	PublishEventNewBlock(block EventDataNewBlock) error
 	PublishEventNewSignedBlock(event EventDataSignedBlock) error
 	PublishEventNewBlockHeader(header EventDataNewBlockHeader) error
+	PublishEventFinalized(EventDataFinalized) error
 	PublishEventNewEvidence(evidence EventDataNewEvidence) error
 	PublishEventTx(EventDataTx) error
 	PublishEventValidatorSetUpdates(EventDataValidatorSetUpdates) error
@@ -199,3 +301,9 @@ type BlockEventPublisher interface {
 type TxEventPublisher interface {
 	PublishEventTx(EventDataTx) error
 }
+
+// MempoolEventPublisher publishes the additions and removals of the
+// transactions of the mempool.
+type MempoolEventPublisher interface {
+	PublishEventMempoolTx(EventDataMempoolTx) error
+}
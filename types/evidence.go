@@ -229,17 +229,24 @@ func (l *LightClientAttackEvidence) Bytes() []byte {
 
 // GetByzantineValidators finds out what style of attack LightClientAttackEvidence was and then works out who
 // the malicious validators were and returns them. This is used both for forming the ByzantineValidators
-// field and for validating that it is correct. Validators are ordered based on validator power
+// field and for validating that it is correct. Validators are ordered based on validator power.
+//
+// commonVals is the validator set at the common height of the evidence. The malicious validators are
+// matched by address, not by index, as the validator set may have changed between the common height and
+// the height of the conflicting block, and are returned with their power in commonVals. Those who are not
+// in commonVals are left out.
 func (l *LightClientAttackEvidence) GetByzantineValidators(commonVals *ValidatorSet,
 	trusted *SignedHeader) []*Validator {
 	var validators []*Validator
 	// First check if the header is invalid. This means that it is a lunatic attack and therefore we take the
 	// validators who are in the commonVals and voted for the lunatic header
 	if l.ConflictingHeaderIsInvalid(trusted.Header) {
+		seen := make(map[string]bool)
 		for _, commitSig := range l.ConflictingBlock.Commit.Signatures {
-			if !commitSig.ForBlock() {
+			if !commitSig.ForBlock() || seen[string(commitSig.ValidatorAddress)] {
 				continue
 			}
+			seen[string(commitSig.ValidatorAddress)] = true
 
 			_, val := commonVals.GetByAddress(commitSig.ValidatorAddress)
 			if val == nil {
@@ -252,21 +259,24 @@ func (l *LightClientAttackEvidence) GetByzantineValidators(commonVals *Validator
 		return validators
 	} else if trusted.Commit.Round == l.ConflictingBlock.Commit.Round {
 		// This is an equivocation attack as both commits are in the same round. We then find the validators
-		// from the conflicting light block validator set that voted in both headers.
-		// Validator hashes are the same therefore the indexing order of validators are the same and thus we
-		// only need a single loop to find the validators that voted twice.
-		for i := 0; i < len(l.ConflictingBlock.Commit.Signatures); i++ {
-			sigA := l.ConflictingBlock.Commit.Signatures[i]
-			if sigA.Absent() {
+		// that signed both headers.
+		signedTrusted := make(map[string]bool, len(trusted.Commit.Signatures))
+		for _, sig := range trusted.Commit.Signatures {
+			if !sig.Absent() {
+				signedTrusted[string(sig.ValidatorAddress)] = true
+			}
+		}
+		for _, sig := range l.ConflictingBlock.Commit.Signatures {
+			if sig.Absent() || !signedTrusted[string(sig.ValidatorAddress)] {
 				continue
 			}
+			// only count a validator once
+			delete(signedTrusted, string(sig.ValidatorAddress))
 
-			sigB := trusted.Commit.Signatures[i]
-			if sigB.Absent() {
+			_, val := commonVals.GetByAddress(sig.ValidatorAddress)
+			if val == nil {
 				continue
 			}
-
-			_, val := l.ConflictingBlock.ValidatorSet.GetByAddress(sigA.ValidatorAddress)
 			validators = append(validators, val)
 		}
 		sort.Sort(ValidatorsByVotingPower(validators))
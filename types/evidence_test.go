@@ -148,6 +148,69 @@ func TestLightClientAttackEvidenceBasic(t *testing.T) {
 	}
 }
 
+func TestLightClientAttackEvidenceByzantineValidators(t *testing.T) {
+	const height, round = int64(5), int32(1)
+	voteSet, valSet, privVals := randVoteSet(height, round, cmtproto.PrecommitType, 4, 1)
+	header := makeHeaderRandom()
+	header.Height = height
+	header.ChainID = voteSet.ChainID()
+	blockID := makeBlockID(header.Hash(), math.MaxInt32, tmhash.Sum([]byte("partshash")))
+	commit, err := MakeCommit(blockID, height, round, voteSet, privVals, defaultVoteTime)
+	require.NoError(t, err)
+	ev := &LightClientAttackEvidence{
+		ConflictingBlock: &LightBlock{
+			SignedHeader: &SignedHeader{Header: header, Commit: commit},
+			ValidatorSet: valSet,
+		},
+	}
+
+	// the validator set at the common height holds two of the signers, with
+	// a different power, and a validator who did not sign
+	withPower := func(val *Validator, power int64) *Validator {
+		val = val.Copy()
+		val.VotingPower = power
+		return val
+	}
+	other, _ := RandValidator(false, 1)
+	commonVals := NewValidatorSet([]*Validator{
+		withPower(valSet.Validators[0], 5),
+		withPower(valSet.Validators[1], 3),
+		other,
+	})
+
+	// lunatic attack: only the signers in the common validator set are
+	// malicious, with their common power
+	trusted := &SignedHeader{Header: makeHeaderRandom(), Commit: commit}
+	require.True(t, ev.ConflictingHeaderIsInvalid(trusted.Header))
+	byzVals := ev.GetByzantineValidators(commonVals, trusted)
+	require.Len(t, byzVals, 2)
+	assert.Equal(t, valSet.Validators[0].Address, byzVals[0].Address)
+	assert.EqualValues(t, 5, byzVals[0].VotingPower)
+	assert.Equal(t, valSet.Validators[1].Address, byzVals[1].Address)
+	assert.EqualValues(t, 3, byzVals[1].VotingPower)
+
+	// equivocation: only those who signed both headers, and are in the common
+	// validator set, are malicious
+	trustedHeader := *header
+	trustedHeader.DataHash = tmhash.Sum([]byte("other data"))
+	trustedVoteSet := NewVoteSet(voteSet.ChainID(), height, round, cmtproto.PrecommitType, valSet)
+	trustedBlockID := makeBlockID(trustedHeader.Hash(), math.MaxInt32, tmhash.Sum([]byte("partshash")))
+	// the first validator did not sign the trusted header
+	trustedCommit, err := MakeCommit(trustedBlockID, height, round, trustedVoteSet, privVals, defaultVoteTime)
+	require.NoError(t, err)
+	trustedCommit.Signatures[0] = NewCommitSigAbsent()
+	trusted = &SignedHeader{Header: &trustedHeader, Commit: trustedCommit}
+	require.False(t, ev.ConflictingHeaderIsInvalid(trusted.Header))
+	byzVals = ev.GetByzantineValidators(commonVals, trusted)
+	require.Len(t, byzVals, 1)
+	assert.Equal(t, valSet.Validators[1].Address, byzVals[0].Address)
+	assert.EqualValues(t, 3, byzVals[0].VotingPower)
+
+	// none of the signers are in a disjoint common validator set
+	disjointVals, _ := RandValidatorSet(4, 1)
+	assert.Empty(t, ev.GetByzantineValidators(disjointVals, trusted))
+}
+
 func TestLightClientAttackEvidenceValidation(t *testing.T) {
 	height := int64(5)
 	commonHeight := height - 1
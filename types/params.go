@@ -130,10 +130,34 @@ func ValidateConsensusParams(params cmtproto.ConsensusParams) error {
 	return nil
 }
 
+// ValidateEvidenceConstraints returns an error if the evidence params allow
+// evidence to expire before the minimum age required by the application, e.g.
+// before the validators it would slash can unbond. Nil constraints, declared by
+// the applications predating them, always validate.
+func ValidateEvidenceConstraints(params cmtproto.EvidenceParams, constraints *abci.EvidenceConstraints) error {
+	if constraints == nil {
+		return nil
+	}
+
+	if params.MaxAgeNumBlocks < constraints.MinMaxAgeNumBlocks {
+		return fmt.Errorf("evidence.MaxAgeNumBlocks must be at least %d, as required by the application. Got %d",
+			constraints.MinMaxAgeNumBlocks, params.MaxAgeNumBlocks)
+	}
+
+	if params.MaxAgeDuration < constraints.MinMaxAgeDuration {
+		return fmt.Errorf("evidence.MaxAgeDuration must be at least %v, as required by the application. Got %v",
+			constraints.MinMaxAgeDuration, params.MaxAgeDuration)
+	}
+
+	return nil
+}
+
 // Hash returns a hash of a subset of the parameters to store in the block header.
 // Only the Block.MaxBytes and Block.MaxGas are included in the hash.
 // This allows the ConsensusParams to evolve more without breaking the block
 // protocol. No need for a Merkle tree here, just a small struct to hash.
+// Note that Block.StrictDecoding is not committed to by the header either:
+// nodes only agree on it by applying the same updates from the application.
 func HashConsensusParams(params cmtproto.ConsensusParams) []byte {
 	hasher := tmhash.New()
 
@@ -167,6 +191,7 @@ func UpdateConsensusParams(params cmtproto.ConsensusParams, params2 *abci.Consen
 	if params2.Block != nil {
 		res.Block.MaxBytes = params2.Block.MaxBytes
 		res.Block.MaxGas = params2.Block.MaxGas
+		res.Block.StrictDecoding = params2.Block.StrictDecoding
 	}
 	if params2.Evidence != nil {
 		res.Evidence.MaxAgeNumBlocks = params2.Evidence.MaxAgeNumBlocks
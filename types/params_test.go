@@ -149,3 +149,27 @@ func TestConsensusParamsUpdate_AppVersion(t *testing.T) {
 
 	assert.EqualValues(t, 1, updated.Version.AppVersion)
 }
+
+func TestEvidenceConstraintsValidation(t *testing.T) {
+	constraints := &abci.EvidenceConstraints{MinMaxAgeNumBlocks: 100, MinMaxAgeDuration: time.Hour}
+	testCases := []struct {
+		params      cmtproto.EvidenceParams
+		constraints *abci.EvidenceConstraints
+		valid       bool
+	}{
+		0: {cmtproto.EvidenceParams{MaxAgeNumBlocks: 1, MaxAgeDuration: 1}, nil, true},
+		1: {cmtproto.EvidenceParams{MaxAgeNumBlocks: 100, MaxAgeDuration: time.Hour}, constraints, true},
+		2: {cmtproto.EvidenceParams{MaxAgeNumBlocks: 1000, MaxAgeDuration: 2 * time.Hour}, constraints, true},
+		3: {cmtproto.EvidenceParams{MaxAgeNumBlocks: 99, MaxAgeDuration: time.Hour}, constraints, false},
+		4: {cmtproto.EvidenceParams{MaxAgeNumBlocks: 100, MaxAgeDuration: time.Minute}, constraints, false},
+		5: {cmtproto.EvidenceParams{MaxAgeNumBlocks: 1, MaxAgeDuration: 1}, &abci.EvidenceConstraints{}, true},
+	}
+	for i, tc := range testCases {
+		err := ValidateEvidenceConstraints(tc.params, tc.constraints)
+		if tc.valid {
+			assert.NoErrorf(t, err, "expected no error for valid params (#%d)", i)
+		} else {
+			assert.Errorf(t, err, "expected error for non valid params (#%d)", i)
+		}
+	}
+}
@@ -101,6 +101,16 @@ func (pv MockPV) SignProposal(chainID string, proposal *cmtproto.Proposal) error
 	return nil
 }
 
+// Implements ValidatorAnnouncementSigner.
+func (pv MockPV) SignValidatorAnnouncement(chainID string, announcement *cmtproto.ValidatorAnnouncement) error {
+	sig, err := pv.PrivKey.Sign(ValidatorAnnouncementSignBytes(chainID, announcement))
+	if err != nil {
+		return err
+	}
+	announcement.Signature = sig
+	return nil
+}
+
 func (pv MockPV) ExtractIntoValidator(votingPower int64) *Validator {
 	pubKey, _ := pv.GetPubKey()
 	return &Validator{
@@ -100,8 +100,9 @@ func (tm2pb) ValidatorUpdates(vals *ValidatorSet) []abci.ValidatorUpdate {
 func (tm2pb) ConsensusParams(params *cmtproto.ConsensusParams) *abci.ConsensusParams {
 	return &abci.ConsensusParams{
 		Block: &abci.BlockParams{
-			MaxBytes: params.Block.MaxBytes,
-			MaxGas:   params.Block.MaxGas,
+			MaxBytes:       params.Block.MaxBytes,
+			MaxGas:         params.Block.MaxGas,
+			StrictDecoding: params.Block.StrictDecoding,
 		},
 		Evidence:  &params.Evidence,
 		Validator: &params.Validator,
@@ -1,16 +1,20 @@
 package types
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 
 	"github.com/tendermint/tendermint/crypto/merkle"
 	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+	"github.com/tendermint/tendermint/pkg/consts"
+	"github.com/tendermint/tendermint/proto/tendermint/crypto"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 )
 
 // RowProof is a Merkle proof that a set of rows exist in a Merkle tree with a
-// given data root.
+// given data root. The rows may also span several data roots, e.g. a range of
+// rows crossing a block boundary, see RootIndices.
 type RowProof struct {
 	// RowRoots are the roots of the rows being proven.
 	RowRoots []tmbytes.HexBytes `json:"row_roots"`
@@ -19,48 +23,284 @@ type RowProof struct {
 	Proofs   []*merkle.Proof `json:"proofs"`
 	StartRow uint32          `json:"start_row"`
 	EndRow   uint32          `json:"end_row"`
+	// RootIndices are the indices, in the data roots passed to
+	// ValidateMultiRoot, of the data root of each row. They are
+	// non-decreasing, by steps of one: the rows of the first data root start
+	// at StartRow in its square, those of each following data root at row 0,
+	// and the last row is EndRow in the square of the last data root. All the
+	// rows are of a single data root, the first one, if empty.
+	RootIndices []uint32 `json:"root_indices,omitempty"`
+}
+
+// ProofOption configures how a RowProof or ShareProof is verified.
+type ProofOption func(*proofConfig)
+
+type proofConfig struct {
+	trustedRowRoots [][]byte
+	squareSize      int
+}
+
+// TrustRowRoots skips the Merkle verification of the row roots against the
+// data root. Instead, the row roots of the proof are only compared with
+// rowRoots, which must hold the row roots of the whole data square, indexed
+// by row, such that rowRoots[StartRow:EndRow+1] are the roots being proven.
+// Share inclusion in those rows is still fully verified.
+//
+// SECURITY: this removes the link between the proof and the data root passed
+// to Validate, which is ignored. It must only be used if rowRoots have already
+// been verified against a data root from a header that is trusted, e.g. one
+// that was signed by the validator set and checked by the caller. Passing row
+// roots taken from an untrusted source, such as the proof itself, allows
+// proving arbitrary data. This option is meant for trusted internal pipelines
+// that verify many proofs against the same square.
+func TrustRowRoots(rowRoots [][]byte) ProofOption {
+	return func(c *proofConfig) {
+		c.trustedRowRoots = rowRoots
+	}
+}
+
+// WithSquareSize checks that the share proofs of a ShareProof stay within the
+// rows of a data square of the given size, i.e. that none of them ends beyond
+// the squareSize shares of a row. It rejects such malformed proofs early, with
+// an error naming the row, instead of failing inside the NMT verification. It
+// has no effect on the validation of a RowProof.
+func WithSquareSize(squareSize int) ProofOption {
+	return func(c *proofConfig) {
+		c.squareSize = squareSize
+	}
 }
 
 // Validate performs checks on the fields of this RowProof. Returns an error if
 // the proof fails validation. If the proof passes validation, this function
 // attempts to verify the proof. It returns nil if the proof is valid.
-func (rp RowProof) Validate(root []byte) error {
+func (rp RowProof) Validate(root []byte, opts ...ProofOption) error {
+	return rp.validate([][]byte{root}, opts...)
+}
+
+// ValidateMultiRoot is like Validate for a proof whose rows span several data
+// roots, verifying each row against the data root of roots given by its root
+// index, see RootIndices.
+func (rp RowProof) ValidateMultiRoot(roots [][]byte) error {
+	return rp.validate(roots)
+}
+
+func (rp RowProof) validate(roots [][]byte, opts ...ProofOption) error {
+	if err := rp.validateRows(); err != nil {
+		return err
+	}
+
+	cfg := proofConfig{}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.trustedRowRoots != nil {
+		if rp.spansRoots() {
+			return errors.New("cannot trust the row roots of a single square for rows spanning several data roots")
+		}
+		return rp.verifyTrustedRowRoots(cfg.trustedRowRoots)
+	}
+
+	return rp.verifyProof(roots)
+}
+
+// validateRows checks that the rows of the proof are ordered, and that there
+// is a row root and a proof per row.
+func (rp RowProof) validateRows() error {
+	if len(rp.RootIndices) > 0 {
+		return rp.validateRootIndices()
+	}
 	if rp.EndRow < rp.StartRow {
 		return fmt.Errorf("end row %d cannot be less than start row %d", rp.EndRow, rp.StartRow)
 	}
 	if int(rp.EndRow-rp.StartRow+1) != len(rp.RowRoots) {
 		return fmt.Errorf("the number of rows %d must equal the number of row roots %d", int(rp.EndRow-rp.StartRow+1), len(rp.RowRoots))
 	}
+	return rp.validateProofCount()
+}
+
+// validateRootIndices checks that there is a root index per row, that the
+// root indices are non-decreasing by steps of one, and that the last row is
+// EndRow.
+func (rp RowProof) validateRootIndices() error {
+	if len(rp.RootIndices) != len(rp.RowRoots) {
+		return fmt.Errorf("the number of root indices %d must equal the number of row roots %d", len(rp.RootIndices), len(rp.RowRoots))
+	}
+	for i := 1; i < len(rp.RootIndices); i++ {
+		if rp.RootIndices[i] != rp.RootIndices[i-1] && rp.RootIndices[i] != rp.RootIndices[i-1]+1 {
+			return fmt.Errorf("root index %d of row root %d does not follow root index %d", rp.RootIndices[i], i, rp.RootIndices[i-1])
+		}
+	}
+	if last := rp.row(len(rp.RowRoots) - 1); last != int(rp.EndRow) {
+		return fmt.Errorf("the last row %d must equal the end row %d", last, rp.EndRow)
+	}
+	return rp.validateProofCount()
+}
+
+// spansRoots returns true if the rows of the proof are of several data roots.
+func (rp RowProof) spansRoots() bool {
+	return len(rp.RootIndices) > 0 && rp.RootIndices[0] != rp.RootIndices[len(rp.RootIndices)-1]
+}
+
+// rootIndex returns the root index of the row root i, 0 if the proof has no
+// root indices.
+func (rp RowProof) rootIndex(i int) int {
+	if i >= len(rp.RootIndices) {
+		return 0
+	}
+	return int(rp.RootIndices[i])
+}
+
+// firstOfRoot returns the first row root of the data root of the row root i.
+func (rp RowProof) firstOfRoot(i int) int {
+	first := i
+	for first > 0 && rp.rootIndex(first-1) == rp.rootIndex(i) {
+		first--
+	}
+	return first
+}
+
+// row returns the row of the row root i in the square of its data root.
+func (rp RowProof) row(i int) int {
+	first := rp.firstOfRoot(i)
+	if first == 0 {
+		return int(rp.StartRow) + i
+	}
+	return i - first
+}
+
+// validateProofCount checks that there is a proof per row root, as the
+// verification indexes both by row.
+func (rp RowProof) validateProofCount() error {
 	if len(rp.Proofs) != len(rp.RowRoots) {
 		return fmt.Errorf("the number of proofs %d must equal the number of row roots %d", len(rp.Proofs), len(rp.RowRoots))
 	}
-	if !rp.VerifyProof(root) {
-		return errors.New("row proof failed to verify")
-	}
+	return nil
+}
 
+// verifyTrustedRowRoots checks that the row roots of this RowProof match the
+// trusted row roots of the rows being proven.
+func (rp RowProof) verifyTrustedRowRoots(trustedRowRoots [][]byte) error {
+	if int(rp.EndRow) >= len(trustedRowRoots) {
+		return fmt.Errorf("end row %d exceeds the number of trusted row roots %d", rp.EndRow, len(trustedRowRoots))
+	}
+	for i, rowRoot := range rp.RowRoots {
+		if !bytes.Equal(rowRoot, trustedRowRoots[int(rp.StartRow)+i]) {
+			return fmt.Errorf("row root of row %d does not match the trusted row root", int(rp.StartRow)+i)
+		}
+	}
 	return nil
 }
 
 // VerifyProof verifies that all the row roots in this RowProof exist in a
-// Merkle tree with the given root. Returns true if all proofs are valid.
-func (rp RowProof) VerifyProof(root []byte) bool {
+// Merkle tree with the given root, at the positions of their rows. It returns
+// an error if the rows are not ordered, if there is not a row root and a proof
+// per row, or if the proofs are not of the same tree, with the same total
+// number of leaves holding all the rows, and of the leaves of the rows, the
+// index of each proof being its row. The failure of the proof of a row root is
+// reported as an *ErrRowVerification.
+func (rp RowProof) VerifyProof(root []byte) error {
+	if err := rp.validateRows(); err != nil {
+		return err
+	}
+	return rp.verifyProof([][]byte{root})
+}
+
+// verifyProof verifies the row roots against the data roots of their root
+// indices, the proofs of the rows of each data root being of the same tree.
+func (rp RowProof) verifyProof(roots [][]byte) error {
 	for i, proof := range rp.Proofs {
-		err := proof.Verify(root, rp.RowRoots[i])
-		if err != nil {
-			return false
+		row, rootIndex, first := rp.row(i), rp.rootIndex(i), rp.firstOfRoot(i)
+		if rootIndex >= len(roots) {
+			return &ErrRowVerification{Row: row, RootIndex: rootIndex, RowProof: true,
+				Reason: fmt.Errorf("root index %d is beyond the %d data roots", rootIndex, len(roots))}
+		}
+		if proof == nil {
+			return &ErrRowVerification{Row: row, RootIndex: rootIndex, RowProof: true, Reason: errors.New("nil proof")}
+		}
+		if proof.Total != rp.Proofs[first].Total {
+			return &ErrRowVerification{Row: row, RootIndex: rootIndex, RowProof: true,
+				Reason: fmt.Errorf("proof total %d differs from the total %d of the first row", proof.Total, rp.Proofs[first].Total)}
+		}
+		if i == first {
+			if last := rp.lastRow(i); int64(last) >= proof.Total {
+				return &ErrRowVerification{Row: row, RootIndex: rootIndex, RowProof: true,
+					Reason: fmt.Errorf("end row %d is beyond the %d leaves of the data root", last, proof.Total)}
+			}
+		}
+		if proof.Index != int64(row) {
+			return &ErrRowVerification{Row: row, RootIndex: rootIndex, RowProof: true,
+				Reason: fmt.Errorf("proof index %d does not match the row", proof.Index)}
+		}
+		if err := proof.Verify(roots[rootIndex], rp.RowRoots[i]); err != nil {
+			return &ErrRowVerification{Row: row, RootIndex: rootIndex, RowProof: true, Reason: err}
 		}
 	}
-	return true
+	return nil
+}
+
+// lastRow returns the last row of the data root of the row root i.
+func (rp RowProof) lastRow(i int) int {
+	last := i
+	for last+1 < len(rp.RowRoots) && rp.rootIndex(last+1) == rp.rootIndex(i) {
+		last++
+	}
+	return rp.row(last)
+}
+
+// RowNamespaceRange returns the minimum and maximum namespace committed to by
+// the root of the given row. The row is the index of the row in the data
+// square and must be within [StartRow, EndRow]. The namespaces are the prefix
+// of the namespaced row root.
+func (rp RowProof) RowNamespaceRange(row int) (minNamespace, maxNamespace []byte, err error) {
+	if row < int(rp.StartRow) || row > int(rp.EndRow) {
+		return nil, nil, fmt.Errorf("row %d out of range [%d, %d]", row, rp.StartRow, rp.EndRow)
+	}
+	i := row - int(rp.StartRow)
+	if i >= len(rp.RowRoots) {
+		return nil, nil, fmt.Errorf("missing row root of row %d", row)
+	}
+	rowRoot := rp.RowRoots[i]
+	if len(rowRoot) < 2*consts.NamespaceSize {
+		return nil, nil, fmt.Errorf("row root of row %d is %d bytes, too short for a namespaced hash", row, len(rowRoot))
+	}
+	return rowRoot[:consts.NamespaceSize], rowRoot[consts.NamespaceSize : 2*consts.NamespaceSize], nil
 }
 
+// ToProto converts the RowProof to its proto message.
+func (rp RowProof) ToProto() *tmproto.RowProof {
+	rowRoots := make([][]byte, len(rp.RowRoots))
+	for i := range rp.RowRoots {
+		rowRoots[i] = rp.RowRoots[i].Bytes()
+	}
+	proofs := make([]*crypto.Proof, len(rp.Proofs))
+	for i := range rp.Proofs {
+		proofs[i] = rp.Proofs[i].ToProto()
+	}
+	return &tmproto.RowProof{
+		RowRoots:    rowRoots,
+		Proofs:      proofs,
+		StartRow:    rp.StartRow,
+		EndRow:      rp.EndRow,
+		RootIndices: rp.RootIndices,
+	}
+}
+
+// RowProofFromProto creates a RowProof from a proto message. The row roots and
+// the proofs are converted as is, even if their numbers differ or some proofs
+// are nil, which Validate then reports.
 func RowProofFromProto(p *tmproto.RowProof) RowProof {
 	if p == nil {
 		return RowProof{}
 	}
 	rowRoots := make([]tmbytes.HexBytes, len(p.RowRoots))
+	for i := range p.RowRoots {
+		rowRoots[i] = p.RowRoots[i]
+	}
 	rowProofs := make([]*merkle.Proof, len(p.Proofs))
 	for i := range p.Proofs {
-		rowRoots[i] = p.RowRoots[i]
+		if p.Proofs[i] == nil {
+			continue
+		}
 		rowProofs[i] = &merkle.Proof{
 			Total:    p.Proofs[i].Total,
 			Index:    p.Proofs[i].Index,
@@ -70,9 +310,10 @@ func RowProofFromProto(p *tmproto.RowProof) RowProof {
 	}
 
 	return RowProof{
-		RowRoots: rowRoots,
-		Proofs:   rowProofs,
-		StartRow: p.StartRow,
-		EndRow:   p.EndRow,
+		RowRoots:    rowRoots,
+		Proofs:      rowProofs,
+		StartRow:    p.StartRow,
+		EndRow:      p.EndRow,
+		RootIndices: p.RootIndices,
 	}
 }
@@ -5,8 +5,11 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/tendermint/tendermint/crypto/merkle"
+	"github.com/tendermint/tendermint/crypto/tmhash"
 	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+	"github.com/tendermint/tendermint/pkg/consts"
 )
 
 func TestRowProofValidate(t *testing.T) {
@@ -72,6 +75,54 @@ func TestRowProofValidate(t *testing.T) {
 	}
 }
 
+func TestRowProofValidateTrustedRowRoots(t *testing.T) {
+	rowRoot := validRowProof().RowRoots[0].Bytes()
+	otherRowRoot := bytes.Repeat([]byte{1}, len(rowRoot))
+
+	testCases := []struct {
+		name            string
+		rp              RowProof
+		trustedRowRoots [][]byte
+		wantErr         bool
+	}{
+		{
+			name:            "matching trusted row root skips Merkle verification",
+			rp:              validRowProof(),
+			trustedRowRoots: [][]byte{rowRoot, otherRowRoot},
+			wantErr:         false,
+		},
+		{
+			name:            "mismatched trusted row root returns error",
+			rp:              validRowProof(),
+			trustedRowRoots: [][]byte{otherRowRoot},
+			wantErr:         true,
+		},
+		{
+			name:            "end row beyond the trusted row roots returns error",
+			rp:              validRowProof(),
+			trustedRowRoots: [][]byte{},
+			wantErr:         true,
+		},
+		{
+			name:            "invalid row proof still returns error",
+			rp:              mismatchedProofs(),
+			trustedRowRoots: [][]byte{rowRoot},
+			wantErr:         true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// the data root is ignored when the row roots are trusted
+			got := tc.rp.Validate(incorrectRoot, TrustRowRoots(tc.trustedRowRoots))
+			if tc.wantErr {
+				assert.Error(t, got)
+				return
+			}
+			assert.NoError(t, got)
+		})
+	}
+}
+
 // root is the root hash of the Merkle tree used in validRowProof
 var root = []byte{0x82, 0x37, 0x91, 0xd2, 0x5d, 0x77, 0x7, 0x67, 0x35, 0x3, 0x90, 0x12, 0x10, 0xc4, 0x43, 0x8a, 0x8b, 0x78, 0x4b, 0xbf, 0x5b, 0x8f, 0xa6, 0x40, 0xa9, 0x51, 0xa7, 0xa9, 0xbd, 0x52, 0xd5, 0xf6}
 
@@ -96,6 +147,126 @@ func validRowProof() RowProof {
 	}
 }
 
+func TestRowProofVerifyProof(t *testing.T) {
+	// the data root commits to 4 row roots followed by 4 column roots
+	leaves := make([][]byte, 8)
+	for i := range leaves {
+		leaves[i] = tmhash.Sum([]byte{byte(i)})
+	}
+	dataRoot, proofs := merkle.ProofsFromByteSlices(leaves)
+	_, otherProofs := merkle.ProofsFromByteSlices(leaves[:4])
+	rowProof := func(startRow uint32, proofs ...*merkle.Proof) RowProof {
+		rp := RowProof{StartRow: startRow, EndRow: startRow + uint32(len(proofs)) - 1, Proofs: proofs}
+		for i := range proofs {
+			rp.RowRoots = append(rp.RowRoots, leaves[int(startRow)+i])
+		}
+		return rp
+	}
+
+	testCases := []struct {
+		name    string
+		rp      RowProof
+		root    []byte
+		wantErr string
+	}{
+		{"valid", rowProof(1, proofs[1], proofs[2]), dataRoot, ""},
+		{"incorrect root", rowProof(1, proofs[1], proofs[2]), incorrectRoot, "row proof of row 1 failed"},
+		{"out of order proofs", rowProof(1, proofs[2], proofs[1]), dataRoot, "proof index 2 does not match the row"},
+		{"index not matching the claimed row", rowProof(2, proofs[1], proofs[2]), dataRoot,
+			"row proof of row 2 failed: proof index 1 does not match the row"},
+		{"proofs of different trees", rowProof(1, proofs[1], otherProofs[2]), dataRoot,
+			"proof total 4 differs from the total 8 of the first row"},
+		{"rows beyond the total", RowProof{
+			StartRow: 7, EndRow: 8,
+			RowRoots: []tmbytes.HexBytes{leaves[7], leaves[7]},
+			Proofs:   []*merkle.Proof{proofs[7], proofs[7]},
+		}, dataRoot, "end row 8 is beyond the 8 leaves"},
+		{"start row after end row", RowProof{StartRow: 2, EndRow: 1}, dataRoot, "cannot be less than start row"},
+		{"missing proof", RowProof{StartRow: 1, EndRow: 1, RowRoots: []tmbytes.HexBytes{leaves[1]}}, dataRoot, "number of proofs 0"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.rp.VerifyProof(tc.root)
+			if tc.wantErr == "" {
+				require.NoError(t, err)
+				require.NoError(t, tc.rp.Validate(tc.root))
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.wantErr)
+			assert.Error(t, tc.rp.Validate(tc.root))
+		})
+	}
+}
+
+// TestRowProofMismatchedProofs is a regression test for the index out of range
+// panic of the conversion and verification of row proofs holding more proofs
+// than row roots, or fewer.
+func TestRowProofMismatchedProofs(t *testing.T) {
+	valid := validRowProof()
+	extraProof := validRowProof()
+	extraProof.Proofs = append(extraProof.Proofs, valid.Proofs[0])
+	extraRowRoot := validRowProof()
+	extraRowRoot.RowRoots = append(extraRowRoot.RowRoots, valid.RowRoots[0])
+	extraRowRoot.EndRow = 1
+
+	for name, rp := range map[string]RowProof{"extra proof": extraProof, "extra row root": extraRowRoot} {
+		t.Run(name, func(t *testing.T) {
+			sp := ShareProof{RowProof: rp}
+			pb := sp.ToProto()
+			assert.Len(t, pb.RowProof.RowRoots, len(rp.RowRoots))
+			assert.Len(t, pb.RowProof.Proofs, len(rp.Proofs))
+
+			converted := RowProofFromProto(pb.RowProof)
+			assert.Equal(t, rp.RowRoots, converted.RowRoots)
+			assert.Len(t, converted.Proofs, len(rp.Proofs))
+			err := converted.Validate(root)
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), "number of proofs")
+			assert.Error(t, converted.VerifyProof(root))
+
+			_, err = ShareProofFromProto(pb)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestRowProofRowNamespaceRange(t *testing.T) {
+	ns := func(b byte) []byte { return bytes.Repeat([]byte{b}, consts.NamespaceSize) }
+	rowRoot := func(minNs, maxNs byte) tmbytes.HexBytes {
+		return append(append(ns(minNs), ns(maxNs)...), bytes.Repeat([]byte{0xff}, 32)...)
+	}
+	rp := RowProof{
+		RowRoots: []tmbytes.HexBytes{rowRoot(1, 2), rowRoot(2, 5), {1, 2, 3}},
+		StartRow: 3,
+		EndRow:   5,
+	}
+
+	minNs, maxNs, err := rp.RowNamespaceRange(3)
+	assert.NoError(t, err)
+	assert.Equal(t, ns(1), minNs)
+	assert.Equal(t, ns(2), maxNs)
+
+	minNs, maxNs, err = rp.RowNamespaceRange(4)
+	assert.NoError(t, err)
+	assert.Equal(t, ns(2), minNs)
+	assert.Equal(t, ns(5), maxNs)
+
+	// too short to be a namespaced hash
+	_, _, err = rp.RowNamespaceRange(5)
+	assert.Error(t, err)
+	// out of range
+	_, _, err = rp.RowNamespaceRange(2)
+	assert.Error(t, err)
+	_, _, err = rp.RowNamespaceRange(6)
+	assert.Error(t, err)
+	_, _, err = rp.RowNamespaceRange(-1)
+	assert.Error(t, err)
+	// fewer row roots than rows
+	_, _, err = RowProof{StartRow: 0, EndRow: 1}.RowNamespaceRange(1)
+	assert.Error(t, err)
+}
+
 func mismatchedRowRoots() RowProof {
 	rp := validRowProof()
 	rp.RowRoots = []tmbytes.HexBytes{}
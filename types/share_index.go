@@ -0,0 +1,16 @@
+package types
+
+// ShareIndexToRowCol converts the index of a share in the original data square
+// of the given size, counting the shares row by row, to the row of the share
+// and its index within the row. squareSize must be positive.
+func ShareIndexToRowCol(shareIndex, squareSize int) (row, col int) {
+	return shareIndex / squareSize, shareIndex % squareSize
+}
+
+// RowColToShareIndex converts the row of a share in the original data square
+// of the given size and its index within the row to the index of the share in
+// the square, counting the shares row by row. It is the inverse of
+// ShareIndexToRowCol.
+func RowColToShareIndex(row, col, squareSize int) int {
+	return row*squareSize + col
+}
@@ -0,0 +1,34 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShareIndexToRowCol(t *testing.T) {
+	for _, squareSize := range []int{1, 2, 4, 8} {
+		shareIndex := 0
+		for row := 0; row < squareSize; row++ {
+			for col := 0; col < squareSize; col++ {
+				gotRow, gotCol := ShareIndexToRowCol(shareIndex, squareSize)
+				require.Equal(t, row, gotRow, "row of share %d in a square of size %d", shareIndex, squareSize)
+				require.Equal(t, col, gotCol, "column of share %d in a square of size %d", shareIndex, squareSize)
+				require.Equal(t, shareIndex, RowColToShareIndex(row, col, squareSize))
+				shareIndex++
+			}
+		}
+	}
+}
+
+func TestRowColToShareIndexRowBoundaries(t *testing.T) {
+	const squareSize = 4
+	// the share after the last of a row is the first of the next row
+	for row := 0; row < squareSize-1; row++ {
+		last := RowColToShareIndex(row, squareSize-1, squareSize)
+		assert.Equal(t, RowColToShareIndex(row+1, 0, squareSize), last+1)
+	}
+	// the end of the square is the start of the row past the last one
+	assert.Equal(t, squareSize*squareSize, RowColToShareIndex(squareSize, 0, squareSize))
+}
@@ -1,13 +1,22 @@
 package types
 
 import (
+	"bytes"
+	"crypto/sha256"
 	"errors"
 	"fmt"
+	"hash"
 	"math"
+	"math/bits"
+	"sync"
+	"sync/atomic"
 
 	"github.com/celestiaorg/nmt"
+	"github.com/celestiaorg/nmt/namespace"
+	"github.com/tendermint/tendermint/crypto/merkle"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
 	"github.com/tendermint/tendermint/pkg/consts"
-	"github.com/tendermint/tendermint/proto/tendermint/crypto"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 )
 
@@ -17,7 +26,8 @@ type ShareProof struct {
 	// Data are the raw shares that are being proven.
 	Data [][]byte `json:"data"`
 	// ShareProofs are NMT proofs that the shares in Data exist in a set of
-	// rows. There will be one ShareProof per row that the shares occupy.
+	// rows. There will be one ShareProof per row that the shares occupy, or
+	// per row the namespace is proven absent from.
 	ShareProofs []*tmproto.NMTProof `json:"share_proofs"`
 	// NamespaceID is the namespace id of the shares being proven. This
 	// namespace id is used when verifying the proof. If the namespace id doesn't
@@ -25,60 +35,333 @@ type ShareProof struct {
 	NamespaceID      []byte   `json:"namespace_id"`
 	RowProof         RowProof `json:"row_proof"`
 	NamespaceVersion uint32   `json:"namespace_version"`
+	// DataRoot is the data root the proof claims to prove the shares against.
+	// It is optional and must not be trusted before checking it with
+	// MatchesRoot, see VerifySelfConsistent.
+	DataRoot []byte `json:"data_root,omitempty"`
 }
 
 func (sp ShareProof) ToProto() tmproto.ShareProof {
-	// TODO consider extracting a ToProto function for RowProof
-	rowRoots := make([][]byte, len(sp.RowProof.RowRoots))
-	rowProofs := make([]*crypto.Proof, len(sp.RowProof.Proofs))
-	for i := range sp.RowProof.RowRoots {
-		rowRoots[i] = sp.RowProof.RowRoots[i].Bytes()
-		rowProofs[i] = sp.RowProof.Proofs[i].ToProto()
-	}
 	pbtp := tmproto.ShareProof{
-		Data:        sp.Data,
-		ShareProofs: sp.ShareProofs,
-		NamespaceId: sp.NamespaceID,
-		RowProof: &tmproto.RowProof{
-			RowRoots: rowRoots,
-			Proofs:   rowProofs,
-			StartRow: sp.RowProof.StartRow,
-			EndRow:   sp.RowProof.EndRow,
-		},
+		Data:             sp.Data,
+		ShareProofs:      sp.ShareProofs,
+		NamespaceId:      sp.NamespaceID,
+		RowProof:         sp.RowProof.ToProto(),
 		NamespaceVersion: sp.NamespaceVersion,
+		DataRoot:         sp.DataRoot,
 	}
 
 	return pbtp
 }
 
-// ShareProofFromProto creates a ShareProof from a proto message.
-// Expects the proof to be pre-validated.
+// ShareProofFromProto creates a ShareProof from a proto message, which may
+// come from an untrusted peer. It returns an error if the proof lacks a row
+// proof or row roots, if the row proof does not hold a proof per row root, or
+// if a share proof or row proof is nil, as these would make the verification
+// panic. The proof must still be checked with Validate.
 func ShareProofFromProto(pb tmproto.ShareProof) (ShareProof, error) {
+	if pb.RowProof == nil {
+		return ShareProof{}, errors.New("the proof has no row proof")
+	}
+	if len(pb.RowProof.RowRoots) == 0 {
+		return ShareProof{}, errors.New("invalid row proof: no row roots")
+	}
+	for i, proof := range pb.RowProof.Proofs {
+		if proof == nil {
+			return ShareProof{}, fmt.Errorf("invalid row proof: proof %d is nil", i)
+		}
+	}
+	for i, proof := range pb.ShareProofs {
+		if proof == nil {
+			return ShareProof{}, fmt.Errorf("share proof %d is nil", i)
+		}
+	}
+	rowProof := RowProofFromProto(pb.RowProof)
+	if err := rowProof.validateProofCount(); err != nil {
+		return ShareProof{}, fmt.Errorf("invalid row proof: %w", err)
+	}
 	return ShareProof{
-		RowProof:         RowProofFromProto(pb.RowProof),
+		RowProof:         rowProof,
 		Data:             pb.Data,
 		ShareProofs:      pb.ShareProofs,
 		NamespaceID:      pb.NamespaceId,
 		NamespaceVersion: pb.NamespaceVersion,
+		DataRoot:         pb.DataRoot,
 	}, nil
 }
 
+// Canonicalize returns a copy of the proof in canonical form, so that
+// semantically identical proofs have identical encodings: the empty slices,
+// including the empty shares and proof nodes, are set to nil. The order of
+// every field of a proof is meaningful, so none is reordered. Nil share proofs
+// and row proofs are kept as is. The proof is not validated and the returned
+// proof shares no memory with sp.
+func (sp ShareProof) Canonicalize() ShareProof {
+	c := ShareProof{
+		Data:             canonicalBytesSlices(sp.Data),
+		NamespaceID:      canonicalBytes(sp.NamespaceID),
+		NamespaceVersion: sp.NamespaceVersion,
+		DataRoot:         canonicalBytes(sp.DataRoot),
+		RowProof: RowProof{
+			StartRow: sp.RowProof.StartRow,
+			EndRow:   sp.RowProof.EndRow,
+		},
+	}
+	if len(sp.RowProof.RootIndices) > 0 {
+		c.RowProof.RootIndices = append([]uint32(nil), sp.RowProof.RootIndices...)
+	}
+	if len(sp.ShareProofs) > 0 {
+		c.ShareProofs = make([]*tmproto.NMTProof, len(sp.ShareProofs))
+		for i, proof := range sp.ShareProofs {
+			if proof == nil {
+				continue
+			}
+			c.ShareProofs[i] = &tmproto.NMTProof{
+				Start:    proof.Start,
+				End:      proof.End,
+				Nodes:    canonicalBytesSlices(proof.Nodes),
+				LeafHash: canonicalBytes(proof.LeafHash),
+			}
+		}
+	}
+	if len(sp.RowProof.RowRoots) > 0 {
+		c.RowProof.RowRoots = make([]tmbytes.HexBytes, len(sp.RowProof.RowRoots))
+		for i, root := range sp.RowProof.RowRoots {
+			c.RowProof.RowRoots[i] = canonicalBytes(root)
+		}
+	}
+	if len(sp.RowProof.Proofs) > 0 {
+		c.RowProof.Proofs = make([]*merkle.Proof, len(sp.RowProof.Proofs))
+		for i, proof := range sp.RowProof.Proofs {
+			if proof == nil {
+				continue
+			}
+			c.RowProof.Proofs[i] = &merkle.Proof{
+				Total:    proof.Total,
+				Index:    proof.Index,
+				LeafHash: canonicalBytes(proof.LeafHash),
+				Aunts:    canonicalBytesSlices(proof.Aunts),
+			}
+		}
+	}
+	return c
+}
+
+// Hash returns the hash of the protobuf encoding of the canonical form of the
+// proof, see Canonicalize. It is a stable commitment to the proof, e.g. to sign
+// over.
+func (sp ShareProof) Hash() []byte {
+	pb := sp.Canonicalize().ToProto()
+	bz, err := pb.Marshal()
+	if err != nil {
+		panic(fmt.Sprintf("failed to marshal share proof: %v", err))
+	}
+	return tmhash.Sum(bz)
+}
+
+// canonicalBytes returns a copy of bz, or nil if bz is empty.
+func canonicalBytes(bz []byte) []byte {
+	if len(bz) == 0 {
+		return nil
+	}
+	return append([]byte(nil), bz...)
+}
+
+// canonicalBytesSlices returns a copy of s and of its elements, in canonical
+// form, or nil if s is empty.
+func canonicalBytesSlices(s [][]byte) [][]byte {
+	if len(s) == 0 {
+		return nil
+	}
+	c := make([][]byte, len(s))
+	for i, bz := range s {
+		c[i] = canonicalBytes(bz)
+	}
+	return c
+}
+
 // Validate runs basic validations on the proof then verifies if it is consistent.
 // It returns nil if the proof is valid. Otherwise, it returns a sensible error.
 // The `root` is the block data root that the shares to be proven belong to.
+// The options are passed on to the validation of the row proof, see
+// TrustRowRoots for skipping the verification of the row roots, and
+// WithSquareSize for checking the share ranges against the size of the square.
 // Note: these proofs are tested on the app side.
-func (sp ShareProof) Validate(root []byte) error {
-	numberOfSharesInProofs := int32(0)
+func (sp ShareProof) Validate(root []byte, opts ...ProofOption) error {
+	return sp.validate([][]byte{root}, opts...)
+}
+
+// ValidateMultiRoot is like Validate for a proof whose rows span several data
+// roots, e.g. a range of shares crossing a block boundary. Each row is
+// verified against the data root of roots given by its root index, see
+// RowProof.RootIndices. Validate is the case of a single data root.
+func (sp ShareProof) ValidateMultiRoot(roots [][]byte) error {
+	return sp.validate(roots)
+}
+
+func (sp ShareProof) validate(roots [][]byte, opts ...ProofOption) error {
+	cfg := proofConfig{}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.squareSize > 0 {
+		if err := sp.validateRowCapacity(cfg.squareSize); err != nil {
+			return err
+		}
+	}
+	if err := sp.validateSizes(); err != nil {
+		return err
+	}
+
+	if err := sp.validateShareRanges(); err != nil {
+		return err
+	}
+
+	if err := sp.validateNodeOrder(); err != nil {
+		return err
+	}
+
+	if err := sp.RowProof.validate(roots, opts...); err != nil {
+		// report the shares of the row whose row proof failed
+		var rowErr *ErrRowVerification
+		if errors.As(err, &rowErr) {
+			for i, proof := range sp.ShareProofs {
+				if sp.RowProof.row(i) == rowErr.Row && sp.RowProof.rootIndex(i) == rowErr.RootIndex {
+					rowErr.Start, rowErr.End = proof.Start, proof.End
+					break
+				}
+			}
+		}
+		return err
+	}
+
+	if err := sp.VerifyProofWithError(); err != nil {
+		return fmt.Errorf("share proof failed to verify: %w", err)
+	}
+
+	return nil
+}
+
+// IsAbsence returns true if the proof proves that the namespace is absent from
+// the rows of the proof: it holds no shares and each of its share proofs is an
+// absence proof, i.e. holds the leaf hash that follows the namespace in the row.
+func (sp ShareProof) IsAbsence() bool {
+	if len(sp.Data) != 0 || len(sp.ShareProofs) == 0 {
+		return false
+	}
 	for _, proof := range sp.ShareProofs {
-		// the range is not inclusive from the left.
-		numberOfSharesInProofs += proof.End - proof.Start
+		if proof == nil || !isAbsenceProof(proof) {
+			return false
+		}
+	}
+	return true
+}
+
+// ValidateStructure runs the checks of a proof of sparse shares that do not
+// involve hashing: the shares in Data must all start with the namespace of the
+// proof, and match the ranges of the share proofs. It is meant to cheaply
+// reject malformed proofs before verifying them with Validate.
+func (sp ShareProof) ValidateStructure() error {
+	if !sp.DataNamespacesConsistent() {
+		return errors.New("the shares do not all start with the namespace of the proof")
+	}
+	if err := sp.validateShareRanges(); err != nil {
+		return err
+	}
+	return sp.validateNodeOrder()
+}
+
+// DataNamespacesConsistent returns true if every share in Data starts with the
+// namespace of the proof, i.e. NamespaceVersion followed by NamespaceID.
+//
+// It only applies to sparse shares, which each hold the data of a single
+// namespace. It says nothing about the content of compact shares, whose units
+// may span shares outside of the proof.
+func (sp ShareProof) DataNamespacesConsistent() bool {
+	if sp.NamespaceVersion > math.MaxUint8 {
+		return false
+	}
+	ns := append([]byte{uint8(sp.NamespaceVersion)}, sp.NamespaceID...)
+	for _, share := range sp.Data {
+		if !bytes.HasPrefix(share, ns) {
+			return false
+		}
+	}
+	return true
+}
+
+// MinNamespace returns the smallest namespace of the shares in Data, i.e. of
+// their first consts.NamespaceSize bytes, e.g. to reject a proof of shares not
+// of the requested namespace before verifying it. The shares too short to hold
+// a namespace are ignored. If Data holds no such share, it returns NamespaceID.
+func (sp ShareProof) MinNamespace() []byte {
+	minNamespace, _ := sp.namespaceRange()
+	return minNamespace
+}
+
+// MaxNamespace returns the largest namespace of the shares in Data, see
+// MinNamespace.
+func (sp ShareProof) MaxNamespace() []byte {
+	_, maxNamespace := sp.namespaceRange()
+	return maxNamespace
+}
+
+// namespaceRange returns copies of the smallest and largest namespaces of the
+// shares in Data, see MinNamespace.
+func (sp ShareProof) namespaceRange() (minNamespace, maxNamespace []byte) {
+	for _, share := range sp.Data {
+		if len(share) < consts.NamespaceSize {
+			continue
+		}
+		ns := share[:consts.NamespaceSize]
+		if minNamespace == nil || bytes.Compare(ns, minNamespace) < 0 {
+			minNamespace = ns
+		}
+		if maxNamespace == nil || bytes.Compare(ns, maxNamespace) > 0 {
+			maxNamespace = ns
+		}
+	}
+	if minNamespace == nil {
+		return append([]byte(nil), sp.NamespaceID...), append([]byte(nil), sp.NamespaceID...)
+	}
+	return append([]byte(nil), minNamespace...), append([]byte(nil), maxNamespace...)
+}
+
+// validateSizes checks that the namespace ID and the shares in Data have the
+// sizes of the consts. The namespace ID has
+// either consts.NamespaceIDSize bytes, or the size of the reserved namespace
+// IDs, e.g. consts.TxNamespaceID, which are longer.
+func (sp ShareProof) validateSizes() error {
+	if len(sp.NamespaceID) != consts.NamespaceIDSize && len(sp.NamespaceID) != len(consts.TxNamespaceID) {
+		return fmt.Errorf("namespace ID has size %d, expected %d or %d",
+			len(sp.NamespaceID), consts.NamespaceIDSize, len(consts.TxNamespaceID))
+	}
+	for i, share := range sp.Data {
+		if len(share) != consts.ShareSize {
+			return fmt.Errorf("share %d has size %d, expected %d", i, len(share), consts.ShareSize)
+		}
+	}
+	return nil
+}
+
+// validateShareRanges checks that the share proofs cover the shares in Data,
+// one per row root.
+func (sp ShareProof) validateShareRanges() error {
+	for i, proof := range sp.ShareProofs {
+		if proof == nil {
+			return fmt.Errorf("share proof %d is nil", i)
+		}
+	}
+	numberOfSharesInProofs := 0
+	for _, proof := range sp.ShareProofs {
+		numberOfSharesInProofs += provenShares(proof)
 	}
 
 	if len(sp.ShareProofs) != len(sp.RowProof.RowRoots) {
 		return fmt.Errorf("the number of share proofs %d must equal the number of row roots %d", len(sp.ShareProofs), len(sp.RowProof.RowRoots))
 
 	}
-	if len(sp.Data) != int(numberOfSharesInProofs) {
+	if len(sp.Data) != numberOfSharesInProofs {
 		return fmt.Errorf("the number of shares %d must equal the number of shares in share proofs %d", len(sp.Data), numberOfSharesInProofs)
 	}
 
@@ -86,48 +369,552 @@ func (sp ShareProof) Validate(root []byte) error {
 		if proof.Start < 0 {
 			return errors.New("proof index cannot be negative")
 		}
+		if isAbsenceProof(proof) {
+			// the leaf following the absent namespace
+			if proof.End-proof.Start != 1 {
+				return errors.New("absence proof must cover a single leaf")
+			}
+			continue
+		}
 		if (proof.End - proof.Start) <= 0 {
 			return errors.New("proof total must be positive")
 		}
 	}
+	return nil
+}
 
-	if err := sp.RowProof.Validate(root); err != nil {
-		return err
+// validateRowCapacity checks that no share proof ends beyond the squareSize
+// shares of its row.
+func (sp ShareProof) validateRowCapacity(squareSize int) error {
+	for i, proof := range sp.ShareProofs {
+		if proof != nil && int(proof.End) > squareSize {
+			return fmt.Errorf("share proof of row %d ends at share %d, beyond the %d shares of a row",
+				sp.RowProof.row(i), proof.End, squareSize)
+		}
 	}
+	return nil
+}
 
-	if ok := sp.VerifyProof(); !ok {
-		return errors.New("share proof failed to verify")
+// validateNodeOrder checks, without hashing, that the nodes of the share
+// proofs are plausibly ordered, so that a proof with misplaced nodes is
+// rejected with a clear error rather than failing verification. The nodes of
+// an NMT proof are the roots of the subtrees left and right of the proven
+// shares, from left to right. As the leaves of an NMT are sorted by namespace,
+// the namespace ranges of the nodes must follow each other, the subtrees left
+// of the shares must not hold a namespace above theirs and those right of them
+// a namespace below. If the square size can be inferred from the row proofs,
+// the number of nodes must also match the number of subtrees left and right of
+// the proven shares.
+func (sp ShareProof) validateNodeOrder() error {
+	if sp.NamespaceVersion > math.MaxUint8 {
+		return fmt.Errorf("namespace version %d must be less than or equal to %d", sp.NamespaceVersion, math.MaxUint8)
 	}
+	ns := append([]byte{uint8(sp.NamespaceVersion)}, sp.NamespaceID...)
+	nodeSize := 2*len(ns) + consts.NewBaseHashFunc().Size()
+
+	for i, proof := range sp.ShareProofs {
+		// the width of the extended rows of the data root of the row, if it
+		// can be inferred
+		rowWidth := 0
+		if first := sp.RowProof.firstOfRoot(i); first < len(sp.RowProof.Proofs) && sp.RowProof.Proofs[first] != nil {
+			if total := sp.RowProof.Proofs[first].Total; total > 0 && total%4 == 0 {
+				rowWidth = int(total / 2)
+			}
+		}
 
+		leftNodes := -1
+		if rowWidth > 0 && int(proof.End) <= rowWidth && bits.OnesCount(uint(rowWidth)) == 1 {
+			leftNodes = bits.OnesCount(uint(proof.Start))
+			rightNodes := bits.OnesCount(uint(rowWidth - int(proof.End)))
+			if len(proof.Nodes) != leftNodes+rightNodes {
+				return fmt.Errorf("share proof %d has %d nodes, expected %d for shares %d to %d of a row of %d shares",
+					i, len(proof.Nodes), leftNodes+rightNodes, proof.Start, proof.End, rowWidth)
+			}
+		}
+
+		var prevMax []byte
+		for j, node := range proof.Nodes {
+			if len(node) != nodeSize {
+				return fmt.Errorf("node %d of share proof %d has size %d, expected %d", j, i, len(node), nodeSize)
+			}
+			minNs, maxNs := node[:len(ns)], node[len(ns):2*len(ns)]
+			if bytes.Compare(minNs, maxNs) > 0 {
+				return fmt.Errorf("node %d of share proof %d has a min namespace %X above its max namespace %X",
+					j, i, minNs, maxNs)
+			}
+			if prevMax != nil && bytes.Compare(prevMax, minNs) > 0 {
+				return fmt.Errorf("nodes %d and %d of share proof %d are out of order: namespace %X is before %X",
+					j-1, j, i, prevMax, minNs)
+			}
+			prevMax = maxNs
+			if leftNodes < 0 {
+				continue
+			}
+			if j < leftNodes && bytes.Compare(maxNs, ns) > 0 {
+				return fmt.Errorf("node %d of share proof %d is left of the shares of namespace %X but holds namespace %X",
+					j, i, ns, maxNs)
+			}
+			if j >= leftNodes && bytes.Compare(minNs, ns) < 0 {
+				return fmt.Errorf("node %d of share proof %d is right of the shares of namespace %X but holds namespace %X",
+					j, i, ns, minNs)
+			}
+		}
+	}
 	return nil
 }
 
+// VerifySelfConsistent validates the proof against its embedded DataRoot, see
+// Validate. It only checks that the proof is internally consistent: the
+// embedded root is claimed by whoever built the proof, so it must additionally
+// be checked against a trusted data root with MatchesRoot before trusting the
+// shares.
+func (sp ShareProof) VerifySelfConsistent() error {
+	if len(sp.DataRoot) == 0 {
+		return errors.New("the proof does not embed a data root")
+	}
+	return sp.Validate(sp.DataRoot)
+}
+
+// MatchesRoot returns true if the proof embeds a DataRoot equal to the trusted
+// data root. It does not verify the proof, see VerifySelfConsistent.
+func (sp ShareProof) MatchesRoot(trusted []byte) bool {
+	return len(sp.DataRoot) != 0 && bytes.Equal(sp.DataRoot, trusted)
+}
+
+// HeaderStore is a store of trusted headers keyed by height, such as the store
+// of the light client, which only holds verified light blocks.
+type HeaderStore interface {
+	// LightBlock returns the trusted light block at height, or an error if
+	// there is none.
+	LightBlock(height int64) (*LightBlock, error)
+}
+
+// VerifyAgainstStore validates the proof against the data root of the trusted
+// header at height in the store, see Validate. It returns an error if the
+// store holds no trusted header for the height.
+func (sp ShareProof) VerifyAgainstStore(store HeaderStore, height int64) error {
+	lb, err := store.LightBlock(height)
+	if err != nil {
+		return fmt.Errorf("no trusted header at height %d: %w", height, err)
+	}
+	if lb == nil || lb.SignedHeader == nil || lb.Header == nil {
+		return fmt.Errorf("no trusted header at height %d", height)
+	}
+	if lb.Height != height {
+		return fmt.Errorf("the store returned the header at height %d for height %d", lb.Height, height)
+	}
+	if err := sp.Validate(lb.DataHash); err != nil {
+		return fmt.Errorf("failed to verify the proof against the data root of height %d: %w", height, err)
+	}
+	return nil
+}
+
+// VerifyProof verifies that the shares in Data are included in the rows whose
+// roots are in RowProof.RowRoots, built with the maximum namespace ignored.
+// The share proofs holding a leaf hash are absence proofs, which prove no
+// shares but that the namespace is absent from their row, see IsAbsence.
+// See VerifyProofWithError for the reason of a failure.
 func (sp ShareProof) VerifyProof() bool {
-	cursor := int32(0)
+	return sp.VerifyProofWithError() == nil
+}
+
+// VerifyProofWithError is like VerifyProof, but returns why the verification
+// failed. The failure of the NMT inclusion check of a row is reported as an
+// *ErrRowVerification, holding the index of the row and the share range of
+// its proof.
+func (sp ShareProof) VerifyProofWithError() error {
+	return sp.verifyProof(true)
+}
+
+// VerifyProofWithOptions verifies that the shares in Data are included in the
+// rows whose roots are in RowProof.RowRoots. ignoreMaxNS must match the
+// IgnoreMaxNamespace option of the NMTs the proof was built from: it controls
+// whether the maximum namespace, used by parity shares, is left out of the
+// namespace ranges of the inner nodes. A proof verified with the wrong value
+// fails. Rows are verified one at a time and the leaf hashes of a row are
+// computed into a buffer that is reused for the next row, so memory use does
+// not grow with the number of rows.
+func (sp ShareProof) VerifyProofWithOptions(ignoreMaxNS bool) bool {
+	return sp.verifyProof(ignoreMaxNS) == nil
+}
+
+func (sp ShareProof) verifyProof(ignoreMaxNS bool) error {
+	if sp.NamespaceVersion > math.MaxUint8 {
+		return fmt.Errorf("namespace version %d must be less than or equal to %d", sp.NamespaceVersion, math.MaxUint8)
+	}
+	if len(sp.ShareProofs) > len(sp.RowProof.RowRoots) {
+		return fmt.Errorf("the number of share proofs %d exceeds the number of row roots %d",
+			len(sp.ShareProofs), len(sp.RowProof.RowRoots))
+	}
+	// Consider extracting celestia-app's namespace package. We can't use it
+	// here because that would introduce a circulcar import.
+	ns := append([]byte{uint8(sp.NamespaceVersion)}, sp.NamespaceID...)
+	if len(ns) > math.MaxUint8 {
+		return fmt.Errorf("namespace size %d must be less than or equal to %d", len(ns), math.MaxUint8)
+	}
+
+	pool := baseHasherPool.Load()
+	h := pool.Get().(hash.Hash)
+	defer pool.Put(h)
+	nth := nmt.NewNmtHasher(h, namespace.IDSize(len(ns)), ignoreMaxNS)
+
+	var buf leafHashBuffer
+	cursor := 0
 	for i, proof := range sp.ShareProofs {
-		nmtProof := nmt.NewInclusionProof(
-			int(proof.Start),
-			int(proof.End),
-			proof.Nodes,
-			true,
-		)
-		sharesUsed := proof.End - proof.Start
-		if sp.NamespaceVersion > math.MaxUint8 {
-			return false
+		sharesUsed := provenShares(proof)
+		if sharesUsed < 0 || cursor+sharesUsed > len(sp.Data) {
+			return &ErrRowVerification{
+				Row:       sp.RowProof.row(i),
+				RootIndex: sp.RowProof.rootIndex(i),
+				Start:     proof.Start,
+				End:       proof.End,
+				Reason:    fmt.Errorf("the share range does not fit the %d remaining shares", len(sp.Data)-cursor),
+			}
+		}
+		shares := sp.Data[cursor : cursor+sharesUsed]
+		err := verifyRowInclusion(nth, h, ns, proof, shares, sp.RowProof.RowRoots[i], ignoreMaxNS, &buf)
+		if err != nil {
+			return &ErrRowVerification{
+				Row:       sp.RowProof.row(i),
+				RootIndex: sp.RowProof.rootIndex(i),
+				Start:     proof.Start,
+				End:       proof.End,
+				Reason:    err,
+			}
+		}
+		cursor += sharesUsed
+	}
+	return nil
+}
+
+// VerifyRowRootsFromData recomputes the root of every row from the shares in
+// Data and the proof nodes, and returns an error identifying the first row
+// whose recomputed root differs from the corresponding root in
+// RowProof.RowRoots. It is stricter than VerifyProof: the leaves are built from
+// the namespace embedded in each share instead of the namespace claimed by the
+// proof, every row root must be covered by exactly one share proof and all of
+// Data must be consumed by the share proofs.
+func (sp ShareProof) VerifyRowRootsFromData() error {
+	if sp.NamespaceVersion > math.MaxUint8 {
+		return fmt.Errorf("namespace version %d must be less than or equal to %d", sp.NamespaceVersion, math.MaxUint8)
+	}
+	if len(sp.ShareProofs) != len(sp.RowProof.RowRoots) {
+		return fmt.Errorf("the number of share proofs %d must equal the number of row roots %d", len(sp.ShareProofs), len(sp.RowProof.RowRoots))
+	}
+	ns := append([]byte{uint8(sp.NamespaceVersion)}, sp.NamespaceID...)
+	if len(ns) > math.MaxUint8 {
+		return fmt.Errorf("namespace size %d must be less than or equal to %d", len(ns), math.MaxUint8)
+	}
+
+	pool := baseHasherPool.Load()
+	h := pool.Get().(hash.Hash)
+	defer pool.Put(h)
+	nth := nmt.NewNmtHasher(h, namespace.IDSize(len(ns)), true)
+
+	cursor := 0
+	for i, proof := range sp.ShareProofs {
+		if proof == nil {
+			return fmt.Errorf("share proof of row %d is nil", i)
+		}
+		if isAbsenceProof(proof) {
+			if err := verifyRowAbsence(nth, ns, proof, sp.RowProof.RowRoots[i], true); err != nil {
+				return fmt.Errorf("failed to verify the absence proof of row %d: %w", i, err)
+			}
+			continue
+		}
+		sharesUsed := int(proof.End) - int(proof.Start)
+		if sharesUsed <= 0 || cursor+sharesUsed > len(sp.Data) {
+			return fmt.Errorf("share proof of row %d covers an invalid range [%d, %d) for the %d remaining shares",
+				i, proof.Start, proof.End, len(sp.Data)-cursor)
+		}
+
+		leafHashes := make([][]byte, sharesUsed)
+		for j, share := range sp.Data[cursor : cursor+sharesUsed] {
+			if len(share) < len(ns) {
+				return fmt.Errorf("share %d is shorter than a namespace", cursor+j)
+			}
+			// the leaves of a row are namespaced with the namespace the share
+			// starts with
+			leafHash, err := nth.HashLeaf(append(share[:len(ns):len(ns)], share...))
+			if err != nil {
+				return fmt.Errorf("failed to hash share %d: %w", cursor+j, err)
+			}
+			leafHashes[j] = leafHash
+		}
+
+		nmtProof := nmt.NewInclusionProof(int(proof.Start), int(proof.End), proof.Nodes, true)
+		valid, err := nmtProof.VerifyLeafHashes(nth, false, ns, leafHashes, sp.RowProof.RowRoots[i])
+		if err != nil {
+			return fmt.Errorf("failed to recompute the root of row %d: %w", i, err)
 		}
-		// Consider extracting celestia-app's namespace package. We can't use it
-		// here because that would introduce a circulcar import.
-		namespace := append([]byte{uint8(sp.NamespaceVersion)}, sp.NamespaceID...)
-		valid := nmtProof.VerifyInclusion(
-			consts.NewBaseHashFunc(),
-			namespace,
-			sp.Data[cursor:sharesUsed+cursor],
-			sp.RowProof.RowRoots[i],
-		)
 		if !valid {
-			return false
+			return fmt.Errorf("the shares of row %d do not hash to row root %X", i, sp.RowProof.RowRoots[i])
 		}
 		cursor += sharesUsed
 	}
-	return true
+	if cursor != len(sp.Data) {
+		return fmt.Errorf("%d shares are not covered by the share proofs", len(sp.Data)-cursor)
+	}
+	return nil
+}
+
+// EstimateVerificationCost returns an estimate of the number of bytes hashed
+// when verifying the share inclusion of this proof with VerifyProof. It is
+// computed from the dimensions of the proof only, so callers can cheaply
+// reject proofs that would exceed a budget before verifying them. It does not
+// validate the proof.
+func (sp ShareProof) EstimateVerificationCost() int64 {
+	nsLen := int64(consts.NamespaceVersionSize + len(sp.NamespaceID))
+	nodeLen := 2*nsLen + int64(consts.NewBaseHashFunc().Size())
+
+	var cost int64
+	for _, share := range sp.Data {
+		// every leaf is hashed as LeafPrefix || namespace || share
+		cost += 1 + nsLen + int64(len(share))
+	}
+	for _, proof := range sp.ShareProofs {
+		if proof == nil {
+			continue
+		}
+		// recomputing the root of a row hashes roughly one inner node per leaf
+		// in the proven range and per proof node, each as NodePrefix || left || right
+		leaves := int64(proof.End) - int64(proof.Start)
+		if leaves < 0 {
+			leaves = 0
+		}
+		cost += (leaves + int64(len(proof.Nodes))) * (1 + 2*nodeLen)
+	}
+	return cost
+}
+
+// baseHasherPool pools the base hashers used to verify the NMT proofs of
+// shares. It is replaced by SetDefaultHasher.
+var baseHasherPool atomic.Pointer[sync.Pool]
+
+func init() {
+	baseHasherPool.Store(newHasherPool(consts.NewBaseHashFunc))
+}
+
+func newHasherPool(fn func() hash.Hash) *sync.Pool {
+	return &sync.Pool{
+		New: func() interface{} {
+			return fn()
+		},
+	}
+}
+
+// hasherCheckInput is hashed by SetDefaultHasher to make sure the supplied
+// hasher computes SHA-256. It spans several blocks and is written in two
+// parts, so that buffering and padding are exercised.
+var hasherCheckInput = bytes.Repeat([]byte("celestia share proof hasher check"), 17)
+
+// SetDefaultHasher sets the constructor of the SHA-256 hashers used to verify
+// share proofs, e.g. to supply a hardware accelerated implementation. It only
+// affects VerifyProof, VerifyProofWithOptions and VerifyRowRootsFromData: the
+// hashing of consensus data, including the computation of row roots and data
+// roots, always uses crypto/sha256. Passing nil restores the default hasher.
+//
+// SetDefaultHasher panics if fn does not produce SHA-256 digests. It is safe
+// to call concurrently with the verification of proofs.
+func SetDefaultHasher(fn func() hash.Hash) {
+	if fn == nil {
+		fn = consts.NewBaseHashFunc
+	}
+	if err := checkSHA256Hasher(fn); err != nil {
+		panic(fmt.Sprintf("SetDefaultHasher: %v", err))
+	}
+	baseHasherPool.Store(newHasherPool(fn))
+}
+
+func checkSHA256Hasher(fn func() hash.Hash) error {
+	h := fn()
+	if h == nil {
+		return errors.New("hasher constructor returned nil")
+	}
+	if h.Size() != sha256.Size {
+		return fmt.Errorf("hasher digest size %d, expected %d", h.Size(), sha256.Size)
+	}
+	want := sha256.Sum256(hasherCheckInput)
+	for i := 0; i < 2; i++ { // check that Reset works as well
+		h.Reset()
+		h.Write(hasherCheckInput[:sha256.BlockSize+3])
+		h.Write(hasherCheckInput[sha256.BlockSize+3:])
+		if got := h.Sum(nil); !bytes.Equal(got, want[:]) {
+			return fmt.Errorf("hasher digest %X does not match the SHA-256 digest %X", got, want)
+		}
+	}
+	return nil
+}
+
+var leafPrefix = []byte{nmt.LeafPrefix}
+
+// leafHashBuffer holds the leaf hashes of a single row. Its memory is reused
+// across rows.
+type leafHashBuffer struct {
+	data   []byte
+	hashes [][]byte
+}
+
+// verifyRowInclusion verifies that the shares are included in the row with the
+// given root. It is equivalent to verifying an nmt inclusion proof with
+// nmt.Proof.VerifyInclusion but hashes the leaves directly into buf instead of
+// copying each share. It returns an error explaining why the verification
+// failed.
+func verifyRowInclusion(
+	nth *nmt.NmtHasher,
+	h hash.Hash,
+	ns []byte,
+	proof *tmproto.NMTProof,
+	shares [][]byte,
+	rowRoot []byte,
+	ignoreMaxNS bool,
+	buf *leafHashBuffer,
+) error {
+	if isAbsenceProof(proof) {
+		if len(shares) != 0 {
+			return errors.New("shares for an absence proof")
+		}
+		return verifyRowAbsence(nth, ns, proof, rowRoot, ignoreMaxNS)
+	}
+	nmtProof := nmt.NewInclusionProof(int(proof.Start), int(proof.End), proof.Nodes, ignoreMaxNS)
+	if len(shares) == 0 {
+		// only an empty proof proves an empty set of shares
+		if !nmtProof.IsEmptyProof() {
+			return errors.New("no shares for a non-empty proof")
+		}
+		return nil
+	}
+
+	nodeLen := nth.Size()
+	if cap(buf.data) < len(shares)*nodeLen {
+		buf.data = make([]byte, len(shares)*nodeLen)
+		buf.hashes = make([][]byte, len(shares))
+	}
+	hashes := buf.hashes[:len(shares)]
+	for i, share := range shares {
+		// nID || nID || h(LeafPrefix || nID || share)
+		leafHash := buf.data[i*nodeLen : i*nodeLen : (i+1)*nodeLen]
+		leafHash = append(leafHash, ns...)
+		leafHash = append(leafHash, ns...)
+		h.Reset()
+		h.Write(leafPrefix)
+		h.Write(ns)
+		h.Write(share)
+		hashes[i] = h.Sum(leafHash)
+	}
+
+	valid, err := nmtProof.VerifyLeafHashes(nth, false, ns, hashes, rowRoot)
+	if err != nil {
+		return err
+	}
+	if !valid {
+		return errors.New("the shares do not hash to the row root")
+	}
+	return nil
+}
+
+// verifyRowAbsence verifies that the absence proof proves that the namespace ns
+// is absent from the row: its leaf hash must be in the row and be the first
+// leaf of a namespace above ns, the leaves left of it being below ns.
+func verifyRowAbsence(nth *nmt.NmtHasher, ns []byte, proof *tmproto.NMTProof, rowRoot []byte, ignoreMaxNS bool) error {
+	if err := nth.ValidateNodeFormat(proof.LeafHash); err != nil {
+		return fmt.Errorf("invalid leaf hash: %w", err)
+	}
+	if !namespace.ID(ns).Less(proof.LeafHash[:len(ns)]) {
+		return errors.New("the namespace of the leaf hash is not above the absent namespace")
+	}
+	nmtProof := nmt.NewAbsenceProof(int(proof.Start), int(proof.End), proof.Nodes, proof.LeafHash, ignoreMaxNS)
+	valid, err := nmtProof.VerifyLeafHashes(nth, true, ns, [][]byte{proof.LeafHash}, rowRoot)
+	if err != nil {
+		return err
+	}
+	if !valid {
+		return errors.New("the absence proof does not hash to the row root")
+	}
+	return nil
+}
+
+// isAbsenceProof returns true if the share proof proves that the namespace is
+// absent from its row, in which case it holds the hash of the leaf following
+// the namespace and proves no shares.
+func isAbsenceProof(proof *tmproto.NMTProof) bool {
+	return len(proof.LeafHash) > 0
+}
+
+// provenShares returns the number of shares of Data proven by the share proof.
+func provenShares(proof *tmproto.NMTProof) int {
+	if isAbsenceProof(proof) {
+		return 0
+	}
+	// the range is not inclusive from the left.
+	return int(proof.End) - int(proof.Start)
+}
+
+// ShareRange returns the global indices of the first and last share, both
+// inclusive, covered by this proof in the original data square. The square
+// size is the width of the original data square. If squareSize is 0, it is
+// inferred from the row proofs, which prove row roots against a data root
+// committing to 2*squareSize row roots and 2*squareSize column roots.
+func (sp ShareProof) ShareRange(squareSize int) (first, last int, err error) {
+	if squareSize < 0 {
+		return 0, 0, fmt.Errorf("square size %d cannot be negative", squareSize)
+	}
+	if squareSize == 0 {
+		if len(sp.RowProof.Proofs) == 0 || sp.RowProof.Proofs[0] == nil {
+			return 0, 0, errors.New("square size not provided and the proof lacks row proofs to infer it from")
+		}
+		total := sp.RowProof.Proofs[0].Total
+		if total <= 0 || total%4 != 0 {
+			return 0, 0, fmt.Errorf("cannot infer square size from row proof total %d", total)
+		}
+		squareSize = int(total / 4)
+	}
+	if len(sp.ShareProofs) == 0 {
+		return 0, 0, errors.New("proof contains no share proofs")
+	}
+	if len(sp.RowProof.RootIndices) > 0 {
+		return 0, 0, errors.New("the share range of a proof with root indices is not of a single square")
+	}
+	if sp.RowProof.EndRow < sp.RowProof.StartRow {
+		return 0, 0, fmt.Errorf("end row %d cannot be less than start row %d", sp.RowProof.EndRow, sp.RowProof.StartRow)
+	}
+	if int(sp.RowProof.EndRow-sp.RowProof.StartRow+1) != len(sp.ShareProofs) {
+		return 0, 0, fmt.Errorf("the number of rows %d must equal the number of share proofs %d",
+			sp.RowProof.EndRow-sp.RowProof.StartRow+1, len(sp.ShareProofs))
+	}
+	if int(sp.RowProof.EndRow) >= squareSize {
+		return 0, 0, fmt.Errorf("end row %d exceeds square size %d", sp.RowProof.EndRow, squareSize)
+	}
+
+	firstProof, lastProof := sp.ShareProofs[0], sp.ShareProofs[len(sp.ShareProofs)-1]
+	if firstProof.Start < 0 || int(firstProof.Start) >= squareSize {
+		return 0, 0, fmt.Errorf("start share %d out of range for square size %d", firstProof.Start, squareSize)
+	}
+	if lastProof.End <= 0 || int(lastProof.End) > squareSize {
+		return 0, 0, fmt.Errorf("end share %d out of range for square size %d", lastProof.End, squareSize)
+	}
+
+	first = RowColToShareIndex(int(sp.RowProof.StartRow), int(firstProof.Start), squareSize)
+	last = RowColToShareIndex(int(sp.RowProof.EndRow), int(lastProof.End)-1, squareSize)
+	if last < first {
+		return 0, 0, fmt.Errorf("last share %d cannot be less than first share %d", last, first)
+	}
+	return first, last, nil
+}
+
+// AdjacentTo returns true if other covers the shares immediately following the
+// shares covered by this proof, i.e. the first share of other directly follows
+// the last share of sp in the original data square of the given size. It
+// returns false if the share range of either proof cannot be determined, see
+// ShareRange. Proofs must be adjacent to be merged into a single proof.
+func (sp ShareProof) AdjacentTo(other ShareProof, squareSize int) bool {
+	_, last, err := sp.ShareRange(squareSize)
+	if err != nil {
+		return false
+	}
+	otherFirst, _, err := other.ShareRange(squareSize)
+	if err != nil {
+		return false
+	}
+	return otherFirst == last+1
 }
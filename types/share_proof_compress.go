@@ -0,0 +1,115 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/tendermint/tendermint/crypto/merkle"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+)
+
+// Compress returns a compact encoding of the proof, e.g. for light clients
+// fetching proofs spanning many rows. The proof nodes of the share proofs and
+// the aunts of the row proofs are stored once each, however many times they
+// are repeated across the rows, and referenced by index. The proofs of
+// adjacent rows share most of their aunts. DecompressShareProof reconstructs
+// the proof. Like the proto encoding, the round trip does not tell empty
+// slices from nil ones. It returns an error if a share proof or row proof is
+// nil.
+func (sp ShareProof) Compress() ([]byte, error) {
+	for i, proof := range sp.ShareProofs {
+		if proof == nil {
+			return nil, fmt.Errorf("share proof %d is nil", i)
+		}
+	}
+	for i, proof := range sp.RowProof.Proofs {
+		if proof == nil {
+			return nil, fmt.Errorf("row proof %d is nil", i)
+		}
+	}
+
+	var c tmproto.CompressedShareProof
+	indices := make(map[string]uint32)
+	index := func(nodes [][]byte) tmproto.NodeIndices {
+		ni := tmproto.NodeIndices{Indices: make([]uint32, len(nodes))}
+		for i, node := range nodes {
+			j, ok := indices[string(node)]
+			if !ok {
+				j = uint32(len(c.Nodes))
+				indices[string(node)] = j
+				c.Nodes = append(c.Nodes, node)
+			}
+			ni.Indices[i] = j
+		}
+		return ni
+	}
+
+	// strip the nodes and aunts from a copy of the proof
+	stripped := sp
+	stripped.ShareProofs = make([]*tmproto.NMTProof, len(sp.ShareProofs))
+	c.ShareProofNodes = make([]tmproto.NodeIndices, len(sp.ShareProofs))
+	for i, proof := range sp.ShareProofs {
+		c.ShareProofNodes[i] = index(proof.Nodes)
+		stripped.ShareProofs[i] = &tmproto.NMTProof{Start: proof.Start, End: proof.End, LeafHash: proof.LeafHash}
+	}
+	stripped.RowProof.Proofs = make([]*merkle.Proof, len(sp.RowProof.Proofs))
+	c.RowProofAunts = make([]tmproto.NodeIndices, len(sp.RowProof.Proofs))
+	for i, proof := range sp.RowProof.Proofs {
+		c.RowProofAunts[i] = index(proof.Aunts)
+		stripped.RowProof.Proofs[i] = &merkle.Proof{Total: proof.Total, Index: proof.Index, LeafHash: proof.LeafHash}
+	}
+	pb := stripped.ToProto()
+	c.Proof = &pb
+	return c.Marshal()
+}
+
+// DecompressShareProof reconstructs a proof compressed with Compress. The
+// nodes repeated across the rows share their memory. Like ShareProofFromProto,
+// it returns an error if the proof is malformed, e.g. if it references nodes
+// it does not hold, but the proof must still be checked with Validate.
+func DecompressShareProof(bz []byte) (ShareProof, error) {
+	var c tmproto.CompressedShareProof
+	if err := c.Unmarshal(bz); err != nil {
+		return ShareProof{}, fmt.Errorf("invalid compressed share proof: %w", err)
+	}
+	if c.Proof == nil {
+		return ShareProof{}, errors.New("the compressed proof has no share proof")
+	}
+	sp, err := ShareProofFromProto(*c.Proof)
+	if err != nil {
+		return ShareProof{}, err
+	}
+	if len(c.ShareProofNodes) != len(sp.ShareProofs) {
+		return ShareProof{}, fmt.Errorf("the number of share proof nodes %d must equal the number of share proofs %d",
+			len(c.ShareProofNodes), len(sp.ShareProofs))
+	}
+	if len(c.RowProofAunts) != len(sp.RowProof.Proofs) {
+		return ShareProof{}, fmt.Errorf("the number of row proof aunts %d must equal the number of row proofs %d",
+			len(c.RowProofAunts), len(sp.RowProof.Proofs))
+	}
+
+	nodes := func(ni tmproto.NodeIndices) ([][]byte, error) {
+		if len(ni.Indices) == 0 {
+			return nil, nil
+		}
+		nodes := make([][]byte, len(ni.Indices))
+		for i, j := range ni.Indices {
+			if int(j) >= len(c.Nodes) {
+				return nil, fmt.Errorf("node index %d is beyond the %d nodes", j, len(c.Nodes))
+			}
+			nodes[i] = c.Nodes[j]
+		}
+		return nodes, nil
+	}
+	for i, proof := range sp.ShareProofs {
+		if proof.Nodes, err = nodes(c.ShareProofNodes[i]); err != nil {
+			return ShareProof{}, fmt.Errorf("share proof %d: %w", i, err)
+		}
+	}
+	for i, proof := range sp.RowProof.Proofs {
+		if proof.Aunts, err = nodes(c.RowProofAunts[i]); err != nil {
+			return ShareProof{}, fmt.Errorf("row proof %d: %w", i, err)
+		}
+	}
+	return sp, nil
+}
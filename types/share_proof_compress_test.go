@@ -0,0 +1,105 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/crypto/merkle"
+	"github.com/tendermint/tendermint/proto/tendermint/types"
+)
+
+func TestShareProofCompress(t *testing.T) {
+	multiRow, multiRowRoot := withRowProofs(t, squareShareProof(t, 64, 16), 64)
+	absence, absenceRoot := absenceShareProof(t)
+	testCases := []struct {
+		name string
+		sp   ShareProof
+		root []byte
+	}{
+		{"single row", validShareProof(), root},
+		{"16 rows", multiRow, multiRowRoot},
+		{"absence", absence, absenceRoot},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			bz, err := tc.sp.Compress()
+			require.NoError(t, err)
+			decompressed, err := DecompressShareProof(bz)
+			require.NoError(t, err)
+			assert.Equal(t, tc.sp.Canonicalize(), decompressed.Canonicalize())
+			assert.True(t, decompressed.VerifyProof())
+			assert.NoError(t, decompressed.Validate(tc.root))
+
+			// the proof compressed is left untouched
+			assert.NoError(t, tc.sp.Validate(tc.root))
+		})
+	}
+
+	// the rows share most of their row proof aunts
+	pb := multiRow.ToProto()
+	uncompressed, err := pb.Marshal()
+	require.NoError(t, err)
+	compressed, err := multiRow.Compress()
+	require.NoError(t, err)
+	assert.Less(t, len(compressed), len(uncompressed))
+}
+
+func TestShareProofCompressMalformed(t *testing.T) {
+	nilShareProof := validShareProof()
+	nilShareProof.ShareProofs = []*types.NMTProof{nil}
+	_, err := nilShareProof.Compress()
+	assert.Error(t, err)
+
+	nilRowProof := validShareProof()
+	nilRowProof.RowProof.Proofs = []*merkle.Proof{nil}
+	_, err = nilRowProof.Compress()
+	assert.Error(t, err)
+
+	_, err = DecompressShareProof([]byte("not a proof"))
+	assert.Error(t, err)
+
+	compress := func(malform func(c *types.CompressedShareProof)) []byte {
+		bz, err := validShareProof().Compress()
+		require.NoError(t, err)
+		var c types.CompressedShareProof
+		require.NoError(t, c.Unmarshal(bz))
+		malform(&c)
+		bz, err = c.Marshal()
+		require.NoError(t, err)
+		return bz
+	}
+	for name, malform := range map[string]func(c *types.CompressedShareProof){
+		"no proof":            func(c *types.CompressedShareProof) { c.Proof = nil },
+		"node out of range":   func(c *types.CompressedShareProof) { c.Nodes = c.Nodes[:1] },
+		"missing share nodes": func(c *types.CompressedShareProof) { c.ShareProofNodes = nil },
+		"missing row aunts":   func(c *types.CompressedShareProof) { c.RowProofAunts = nil },
+	} {
+		_, err := DecompressShareProof(compress(malform))
+		assert.Error(t, err, name)
+	}
+}
+
+// BenchmarkShareProofCompress reports the size of a compressed proof of 16 rows
+// relative to its proto encoding.
+func BenchmarkShareProofCompress(b *testing.B) {
+	sp, _ := withRowProofs(b, squareShareProof(b, 64, 16), 64)
+	pb := sp.ToProto()
+	uncompressed, err := pb.Marshal()
+	require.NoError(b, err)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	var compressed []byte
+	for i := 0; i < b.N; i++ {
+		compressed, err = sp.Compress()
+		require.NoError(b, err)
+	}
+	b.StopTimer()
+
+	// the shares make up most of the proof, so the proof bytes are reported too
+	proofBytes := func(bz []byte) int { return len(bz) - len(sp.Data)*len(sp.Data[0]) }
+	b.ReportMetric(float64(len(compressed))/float64(len(uncompressed)), "size-ratio")
+	b.ReportMetric(float64(proofBytes(compressed))/float64(proofBytes(uncompressed)), "proof-size-ratio")
+}
@@ -0,0 +1,226 @@
+package types
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"hash"
+	"math"
+	"strings"
+
+	"github.com/celestiaorg/nmt"
+	"github.com/celestiaorg/nmt/namespace"
+
+	"github.com/tendermint/tendermint/crypto/merkle"
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+	"github.com/tendermint/tendermint/pkg/consts"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+)
+
+// ProofDiagnostics explains the verification of a ShareProof against a data
+// root, see ShareProof.Diagnose.
+type ProofDiagnostics struct {
+	// Valid is true if the proof verifies against ExpectedRoot, i.e. if
+	// Validate returns no error.
+	Valid bool `json:"valid"`
+	// ExpectedRoot is the data root the proof is verified against.
+	ExpectedRoot tmbytes.HexBytes `json:"expected_root"`
+	// ComputedRoot is the data root computed from the row proof of the first
+	// row, nil if it cannot be computed.
+	ComputedRoot tmbytes.HexBytes `json:"computed_root"`
+	// Rows are the diagnostics of the rows of the proof, one per share proof
+	// or row root, whichever there are more of.
+	Rows []RowDiagnostics `json:"rows"`
+	// Issues are the structural issues of the proof, e.g. mismatched numbers
+	// of share proofs and row roots, which fail the verification regardless of
+	// the hashes.
+	Issues []string `json:"issues"`
+}
+
+// RowDiagnostics explains the verification of a single row of a ShareProof.
+type RowDiagnostics struct {
+	// Row is the index of the row in the data square.
+	Row int `json:"row"`
+	// ShareStart and ShareEnd are the range of the proven shares in the row,
+	// ShareEnd being exclusive.
+	ShareStart int32 `json:"share_start"`
+	ShareEnd   int32 `json:"share_end"`
+	// RowRoot is the root of the row, nil if the proof holds none.
+	RowRoot tmbytes.HexBytes `json:"row_root"`
+	// MinNamespace and MaxNamespace are the namespace range committed to by
+	// the row root, nil if it is too short to hold one.
+	MinNamespace tmbytes.HexBytes `json:"min_namespace"`
+	MaxNamespace tmbytes.HexBytes `json:"max_namespace"`
+	// SharesVerified is true if the shares of the row verify against the row
+	// root.
+	SharesVerified bool `json:"shares_verified"`
+	// RowRootVerified is true if the row root verifies against the expected
+	// data root.
+	RowRootVerified bool `json:"row_root_verified"`
+	// Error explains why the row failed to verify, empty if it verified.
+	Error string `json:"error,omitempty"`
+}
+
+// Diagnose verifies the proof against the data root like Validate, but
+// instead of stopping at the first error it reports the verification of every
+// row and every structural issue found, e.g. to explain why a proof fails. It
+// never panics, whatever the proof: the checks that do are reported as issues.
+func (sp ShareProof) Diagnose(root []byte) ProofDiagnostics {
+	d := ProofDiagnostics{ExpectedRoot: root}
+	addIssue := func(err error) {
+		if err != nil {
+			d.Issues = append(d.Issues, err.Error())
+		}
+	}
+
+	if sp.NamespaceVersion > math.MaxUint8 {
+		addIssue(fmt.Errorf("namespace version %d must be less than or equal to %d", sp.NamespaceVersion, math.MaxUint8))
+	}
+	for i, proof := range sp.ShareProofs {
+		if proof == nil {
+			addIssue(fmt.Errorf("share proof %d is nil", i))
+		}
+	}
+	for i, proof := range sp.RowProof.Proofs {
+		if proof == nil {
+			addIssue(fmt.Errorf("row proof %d is nil", i))
+		}
+	}
+	if len(d.Issues) == 0 {
+		addIssue(diagnoseSafely(sp.validateShareRanges))
+		addIssue(diagnoseSafely(sp.validateNodeOrder))
+	}
+	if len(sp.RowProof.RootIndices) > 0 {
+		addIssue(errors.New("the rows have root indices, diagnose the rows of each data root separately"))
+	} else if sp.RowProof.EndRow < sp.RowProof.StartRow {
+		addIssue(fmt.Errorf("end row %d cannot be less than start row %d", sp.RowProof.EndRow, sp.RowProof.StartRow))
+	} else if rows := int(sp.RowProof.EndRow-sp.RowProof.StartRow) + 1; rows != len(sp.RowProof.RowRoots) {
+		addIssue(fmt.Errorf("the number of rows %d must equal the number of row roots %d", rows, len(sp.RowProof.RowRoots)))
+	}
+	addIssue(sp.RowProof.validateProofCount())
+	if root == nil {
+		addIssue(errors.New("the expected data root is nil"))
+	}
+
+	ns := append([]byte{uint8(sp.NamespaceVersion)}, sp.NamespaceID...)
+	nth, h, release := newDiagnosticsHasher(ns)
+	defer release()
+
+	numRows := len(sp.ShareProofs)
+	if len(sp.RowProof.RowRoots) > numRows {
+		numRows = len(sp.RowProof.RowRoots)
+	}
+	d.Rows = make([]RowDiagnostics, numRows)
+	cursor := 0
+	var buf leafHashBuffer
+	for i := range d.Rows {
+		row := &d.Rows[i]
+		row.Row = int(sp.RowProof.StartRow) + i
+
+		var proof *tmproto.NMTProof
+		if i < len(sp.ShareProofs) {
+			proof = sp.ShareProofs[i]
+		}
+		if proof != nil {
+			row.ShareStart, row.ShareEnd = proof.Start, proof.End
+		}
+		if i < len(sp.RowProof.RowRoots) {
+			row.RowRoot = sp.RowProof.RowRoots[i]
+			if len(row.RowRoot) >= 2*consts.NamespaceSize {
+				row.MinNamespace = row.RowRoot[:consts.NamespaceSize]
+				row.MaxNamespace = row.RowRoot[consts.NamespaceSize : 2*consts.NamespaceSize]
+			}
+		}
+
+		var rowErrs []string
+		// the shares in the row
+		switch {
+		case proof == nil:
+			rowErrs = append(rowErrs, "no share proof")
+		case row.RowRoot == nil:
+			rowErrs = append(rowErrs, "no row root")
+		case nth == nil:
+			rowErrs = append(rowErrs, "invalid namespace")
+		default:
+			sharesUsed := provenShares(proof)
+			if sharesUsed < 0 || cursor+sharesUsed > len(sp.Data) {
+				rowErrs = append(rowErrs, fmt.Sprintf("share range [%d, %d) does not fit the %d remaining shares",
+					proof.Start, proof.End, len(sp.Data)-cursor))
+				break
+			}
+			shares := sp.Data[cursor : cursor+sharesUsed]
+			cursor += sharesUsed
+			err := diagnoseSafely(func() error {
+				return verifyRowInclusion(nth, h, ns, proof, shares, row.RowRoot, true, &buf)
+			})
+			if err != nil {
+				rowErrs = append(rowErrs, err.Error())
+			} else {
+				row.SharesVerified = true
+			}
+		}
+
+		// the row root in the data root
+		var rowProof *merkle.Proof
+		if i < len(sp.RowProof.Proofs) {
+			rowProof = sp.RowProof.Proofs[i]
+		}
+		switch {
+		case rowProof == nil:
+			rowErrs = append(rowErrs, "no row proof")
+		case row.RowRoot == nil:
+		case root == nil:
+			rowErrs = append(rowErrs, "no expected data root")
+		default:
+			if err := diagnoseSafely(func() error { return rowProof.Verify(root, row.RowRoot) }); err != nil {
+				rowErrs = append(rowErrs, fmt.Sprintf("row root not in the data root: %v", err))
+			} else {
+				row.RowRootVerified = true
+			}
+		}
+		if rowProof != nil && d.ComputedRoot == nil {
+			_ = diagnoseSafely(func() error {
+				d.ComputedRoot = rowProof.ComputeRootHash()
+				return nil
+			})
+		}
+
+		if len(rowErrs) > 0 {
+			row.Error = strings.Join(rowErrs, "; ")
+		}
+	}
+
+	d.Valid = len(d.Issues) == 0 && len(d.Rows) > 0
+	for _, row := range d.Rows {
+		if !row.SharesVerified || !row.RowRootVerified {
+			d.Valid = false
+		}
+	}
+	if d.Valid && !bytes.Equal(d.ComputedRoot, root) {
+		d.Valid = false
+	}
+	return d
+}
+
+// newDiagnosticsHasher returns the NMT hasher for the namespace, or a nil
+// hasher if the namespace is too long, along with the base hasher, which the
+// returned function puts back in the pool.
+func newDiagnosticsHasher(ns []byte) (*nmt.NmtHasher, hash.Hash, func()) {
+	pool := baseHasherPool.Load()
+	h := pool.Get().(hash.Hash)
+	release := func() { pool.Put(h) }
+	if len(ns) > math.MaxUint8 {
+		return nil, h, release
+	}
+	return nmt.NewNmtHasher(h, namespace.IDSize(len(ns)), true), h, release
+}
+
+// diagnoseSafely returns the error of f, or an error describing its panic.
+func diagnoseSafely(f func() error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("malformed proof: %v", r)
+		}
+	}()
+	return f()
+}
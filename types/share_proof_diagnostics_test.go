@@ -0,0 +1,139 @@
+package types
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/crypto/merkle"
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+	"github.com/tendermint/tendermint/proto/tendermint/types"
+)
+
+// maxSquareShareProofWithRowProofs returns maxSquareShareProof along with row
+// proofs of its rows against a data root, which it returns.
+func maxSquareShareProofWithRowProofs(t *testing.T) (ShareProof, []byte) {
+	return withRowProofs(t, maxSquareShareProof(t), 128)
+}
+
+// withRowProofs returns the proof of rows of a squareSize x squareSize original
+// data square along with row proofs of its rows against a data root, which it
+// returns.
+func withRowProofs(tb testing.TB, sp ShareProof, squareSize int) (ShareProof, []byte) {
+	// the data root commits to the row roots and column roots of the extended
+	// square, only the first rows of which are in the proof
+	leaves := make([][]byte, 4*squareSize)
+	for i := range leaves {
+		if i < len(sp.RowProof.RowRoots) {
+			leaves[i] = sp.RowProof.RowRoots[i]
+		} else {
+			leaves[i] = bytes.Repeat([]byte{byte(i)}, len(sp.RowProof.RowRoots[0]))
+		}
+	}
+	dataRoot, proofs := merkle.ProofsFromByteSlices(leaves)
+	sp.RowProof.Proofs = proofs[:len(sp.RowProof.RowRoots)]
+	require.NoError(tb, sp.Validate(dataRoot))
+	return sp, dataRoot
+}
+
+func TestShareProofDiagnose(t *testing.T) {
+	d := validShareProof().Diagnose(root)
+	assert.True(t, d.Valid)
+	assert.Empty(t, d.Issues)
+	assert.Equal(t, tmbytes.HexBytes(root), d.ComputedRoot)
+	require.Len(t, d.Rows, 1)
+	row := d.Rows[0]
+	assert.True(t, row.SharesVerified)
+	assert.True(t, row.RowRootVerified)
+	assert.Empty(t, row.Error)
+	assert.Equal(t, int32(0), row.ShareStart)
+	assert.Equal(t, int32(1), row.ShareEnd)
+	assert.Len(t, row.MinNamespace, 29)
+	assert.Len(t, row.MaxNamespace, 29)
+
+	// the shares are in the rows, which are not in the expected root
+	d = validShareProof().Diagnose(incorrectRoot)
+	assert.False(t, d.Valid)
+	assert.Empty(t, d.Issues)
+	assert.Equal(t, tmbytes.HexBytes(root), d.ComputedRoot)
+	assert.Equal(t, tmbytes.HexBytes(incorrectRoot), d.ExpectedRoot)
+	assert.True(t, d.Rows[0].SharesVerified)
+	assert.False(t, d.Rows[0].RowRootVerified)
+	assert.Contains(t, d.Rows[0].Error, "row root not in the data root")
+
+	// a single tampered row out of many
+	sp, dataRoot := maxSquareShareProofWithRowProofs(t)
+	assert.True(t, sp.Diagnose(dataRoot).Valid)
+	share := append([]byte(nil), sp.Data[5*128]...)
+	share[len(share)-1] ^= 0xff
+	sp.Data[5*128] = share
+	d = sp.Diagnose(dataRoot)
+	assert.False(t, d.Valid)
+	assert.Empty(t, d.Issues)
+	require.Len(t, d.Rows, 128)
+	for _, row := range d.Rows {
+		assert.True(t, row.RowRootVerified, "row %d", row.Row)
+		assert.Equal(t, row.Row != 5, row.SharesVerified, "row %d", row.Row)
+	}
+	assert.Contains(t, d.Rows[5].Error, "do not hash to the row root")
+
+	// structural issues
+	sp = validShareProof()
+	sp.Data = append(sp.Data, sp.Data[0])
+	d = sp.Diagnose(root)
+	assert.False(t, d.Valid)
+	require.Len(t, d.Issues, 1)
+	assert.Contains(t, d.Issues[0], "number of shares")
+}
+
+func TestShareProofDiagnoseMalformed(t *testing.T) {
+	mutations := map[string]func(sp *ShareProof){
+		"nothing":             func(sp *ShareProof) {},
+		"empty":               func(sp *ShareProof) { *sp = ShareProof{} },
+		"nil share proof":     func(sp *ShareProof) { sp.ShareProofs[0] = nil },
+		"nil row proof":       func(sp *ShareProof) { sp.RowProof.Proofs[0] = nil },
+		"no row roots":        func(sp *ShareProof) { sp.RowProof.RowRoots = nil },
+		"no row proofs":       func(sp *ShareProof) { sp.RowProof.Proofs = nil },
+		"no data":             func(sp *ShareProof) { sp.Data = nil },
+		"nil share":           func(sp *ShareProof) { sp.Data[0] = nil },
+		"negative start":      func(sp *ShareProof) { sp.ShareProofs[0].Start = -5 },
+		"reversed range":      func(sp *ShareProof) { sp.ShareProofs[0].Start, sp.ShareProofs[0].End = 1, 0 },
+		"huge range":          func(sp *ShareProof) { sp.ShareProofs[0].End = 1 << 30 },
+		"short node":          func(sp *ShareProof) { sp.ShareProofs[0].Nodes[0] = []byte{1} },
+		"no nodes":            func(sp *ShareProof) { sp.ShareProofs[0].Nodes = nil },
+		"extra share proof":   func(sp *ShareProof) { sp.ShareProofs = append(sp.ShareProofs, &types.NMTProof{End: 1}) },
+		"short row root":      func(sp *ShareProof) { sp.RowProof.RowRoots[0] = []byte{1} },
+		"reversed rows":       func(sp *ShareProof) { sp.RowProof.StartRow, sp.RowProof.EndRow = 2, 1 },
+		"zero total":          func(sp *ShareProof) { sp.RowProof.Proofs[0].Total = 0 },
+		"negative index":      func(sp *ShareProof) { sp.RowProof.Proofs[0].Index = -1 },
+		"index beyond total":  func(sp *ShareProof) { sp.RowProof.Proofs[0].Index = 1 << 40 },
+		"no aunts":            func(sp *ShareProof) { sp.RowProof.Proofs[0].Aunts = nil },
+		"huge namespace":      func(sp *ShareProof) { sp.NamespaceID = make([]byte, 300) },
+		"huge version":        func(sp *ShareProof) { sp.NamespaceVersion = 1 << 20 },
+		"no namespace":        func(sp *ShareProof) { sp.NamespaceID = nil },
+		"row proof leaf hash": func(sp *ShareProof) { sp.RowProof.Proofs[0].LeafHash = nil },
+	}
+	for name, mutate := range mutations {
+		t.Run(name, func(t *testing.T) {
+			sp := validShareProof()
+			mutate(&sp)
+			for _, r := range [][]byte{root, incorrectRoot, nil} {
+				var d ProofDiagnostics
+				require.NotPanics(t, func() { d = sp.Diagnose(r) })
+				// the diagnostics agree with Validate, which panics on some of
+				// the malformed proofs
+				err := diagnoseSafely(func() error { return sp.Validate(r) })
+				assert.Equal(t, err == nil, d.Valid, "%v: %+v", err, d)
+				if !d.Valid {
+					failed := len(d.Issues) > 0
+					for _, row := range d.Rows {
+						failed = failed || row.Error != ""
+					}
+					assert.True(t, failed || !bytes.Equal(d.ComputedRoot, r), "no reason given for the failure")
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,290 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/tendermint/tendermint/crypto/merkle"
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+)
+
+// The JSON encoding of the proofs, e.g. as returned by the RPC, is meant to be
+// verified by clients in any language, so it is kept stable:
+//
+//	{
+//	  "data": [<base64 share>, ...],
+//	  "share_proofs": [{"start": <int>, "end": <int>, "nodes": [<base64 node>, ...], "leaf_hash": <base64>}, ...],
+//	  "namespace_id": <base64>,
+//	  "namespace_version": <int>,
+//	  "row_proof": {
+//	    "row_roots": [<hex root>, ...],
+//	    "proofs": [{"total": <int>, "index": <int>, "leaf_hash": <base64>, "aunts": [<base64 aunt>, ...]}, ...],
+//	    "start_row": <int>,
+//	    "end_row": <int>,
+//	    "root_indices": [<int>, ...]
+//	  },
+//	  "data_root": <hex root>
+//	}
+//
+// The shares, the namespace and the nodes of the proofs are base64, the roots
+// hex, and the integers numbers rather than strings. The leaf hash of a share
+// proof is only set for an absence proof, the root indices only for a proof
+// spanning several data roots, and the data root only if the proof holds one.
+
+type shareProofJSON struct {
+	Data             [][]byte         `json:"data"`
+	ShareProofs      []*nmtProofJSON  `json:"share_proofs"`
+	NamespaceID      []byte           `json:"namespace_id"`
+	NamespaceVersion uint32           `json:"namespace_version"`
+	RowProof         RowProof         `json:"row_proof"`
+	DataRoot         tmbytes.HexBytes `json:"data_root,omitempty"`
+}
+
+// nmtProofJSON and merkleProofJSON replace the encodings of the proto and
+// merkle proofs, which encode some integers as strings.
+type nmtProofJSON struct {
+	Start    int32    `json:"start"`
+	End      int32    `json:"end"`
+	Nodes    [][]byte `json:"nodes"`
+	LeafHash []byte   `json:"leaf_hash,omitempty"`
+}
+
+type rowProofJSON struct {
+	RowRoots    []tmbytes.HexBytes `json:"row_roots"`
+	Proofs      []*merkleProofJSON `json:"proofs"`
+	StartRow    uint32             `json:"start_row"`
+	EndRow      uint32             `json:"end_row"`
+	RootIndices []uint32           `json:"root_indices,omitempty"`
+}
+
+type merkleProofJSON struct {
+	Total    int64    `json:"total"`
+	Index    int64    `json:"index"`
+	LeafHash []byte   `json:"leaf_hash"`
+	Aunts    [][]byte `json:"aunts"`
+}
+
+// MarshalJSON encodes the proof as documented above.
+func (sp ShareProof) MarshalJSON() ([]byte, error) {
+	js := shareProofJSON{
+		Data:             sp.Data,
+		NamespaceID:      sp.NamespaceID,
+		NamespaceVersion: sp.NamespaceVersion,
+		RowProof:         sp.RowProof,
+		DataRoot:         sp.DataRoot,
+	}
+	if sp.ShareProofs != nil {
+		js.ShareProofs = make([]*nmtProofJSON, len(sp.ShareProofs))
+		for i, proof := range sp.ShareProofs {
+			if proof != nil {
+				js.ShareProofs[i] = &nmtProofJSON{
+					Start:    proof.Start,
+					End:      proof.End,
+					Nodes:    proof.Nodes,
+					LeafHash: proof.LeafHash,
+				}
+			}
+		}
+	}
+	return json.Marshal(js)
+}
+
+// UnmarshalJSON decodes a proof encoded with MarshalJSON. It returns an error
+// naming the field that fails to decode, e.g. "row_proof.proofs[1].aunts".
+// The proof must still be checked with Validate.
+func (sp *ShareProof) UnmarshalJSON(bz []byte) error {
+	fields, err := decodeJSONFields(bz)
+	if err != nil || fields == nil {
+		return err
+	}
+	var (
+		js          shareProofJSON
+		shareProofs []json.RawMessage
+	)
+	if err := fields.decode([]jsonField{
+		{"data", &js.Data},
+		{"share_proofs", &shareProofs},
+		{"namespace_id", &js.NamespaceID},
+		{"namespace_version", &js.NamespaceVersion},
+		{"row_proof", &js.RowProof},
+		{"data_root", &js.DataRoot},
+	}); err != nil {
+		return err
+	}
+	proofs, err := decodeJSONList(shareProofs, "share_proofs", func(fields jsonFields) (*tmproto.NMTProof, error) {
+		var proof tmproto.NMTProof
+		err := fields.decode([]jsonField{
+			{"start", &proof.Start},
+			{"end", &proof.End},
+			{"nodes", &proof.Nodes},
+			{"leaf_hash", &proof.LeafHash},
+		})
+		return &proof, err
+	})
+	if err != nil {
+		return err
+	}
+
+	*sp = ShareProof{
+		Data:             js.Data,
+		ShareProofs:      proofs,
+		NamespaceID:      js.NamespaceID,
+		NamespaceVersion: js.NamespaceVersion,
+		RowProof:         js.RowProof,
+	}
+	if len(js.DataRoot) > 0 {
+		sp.DataRoot = js.DataRoot
+	}
+	return nil
+}
+
+// MarshalJSON encodes the row proof as documented for ShareProof.
+func (rp RowProof) MarshalJSON() ([]byte, error) {
+	js := rowProofJSON{
+		RowRoots:    rp.RowRoots,
+		StartRow:    rp.StartRow,
+		EndRow:      rp.EndRow,
+		RootIndices: rp.RootIndices,
+	}
+	if rp.Proofs != nil {
+		js.Proofs = make([]*merkleProofJSON, len(rp.Proofs))
+		for i, proof := range rp.Proofs {
+			if proof != nil {
+				js.Proofs[i] = &merkleProofJSON{
+					Total:    proof.Total,
+					Index:    proof.Index,
+					LeafHash: proof.LeafHash,
+					Aunts:    proof.Aunts,
+				}
+			}
+		}
+	}
+	return json.Marshal(js)
+}
+
+// UnmarshalJSON decodes a row proof encoded with MarshalJSON. Like
+// ShareProof.UnmarshalJSON, it returns an error naming the field that fails
+// to decode.
+func (rp *RowProof) UnmarshalJSON(bz []byte) error {
+	fields, err := decodeJSONFields(bz)
+	if err != nil || fields == nil {
+		return err
+	}
+	var (
+		js     rowProofJSON
+		proofs []json.RawMessage
+	)
+	if err := fields.decode([]jsonField{
+		{"row_roots", &js.RowRoots},
+		{"proofs", &proofs},
+		{"start_row", &js.StartRow},
+		{"end_row", &js.EndRow},
+		{"root_indices", &js.RootIndices},
+	}); err != nil {
+		return err
+	}
+	merkleProofs, err := decodeJSONList(proofs, "proofs", func(fields jsonFields) (*merkle.Proof, error) {
+		var proof merkle.Proof
+		err := fields.decode([]jsonField{
+			{"total", &proof.Total},
+			{"index", &proof.Index},
+			{"leaf_hash", &proof.LeafHash},
+			{"aunts", &proof.Aunts},
+		})
+		return &proof, err
+	})
+	if err != nil {
+		return err
+	}
+
+	*rp = RowProof{
+		RowRoots:    js.RowRoots,
+		Proofs:      merkleProofs,
+		StartRow:    js.StartRow,
+		EndRow:      js.EndRow,
+		RootIndices: js.RootIndices,
+	}
+	return nil
+}
+
+// jsonFields are the raw fields of a JSON object, nil for null.
+type jsonFields map[string]json.RawMessage
+
+func decodeJSONFields(bz []byte) (jsonFields, error) {
+	var fields jsonFields
+	if err := json.Unmarshal(bz, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+// jsonField is a field of a JSON object and the value it decodes into.
+type jsonField struct {
+	name string
+	v    interface{}
+}
+
+// decode decodes the fields that are set, in order. The error names the
+// first field failing to decode.
+func (f jsonFields) decode(fields []jsonField) error {
+	for _, field := range fields {
+		raw, ok := f[field.name]
+		if !ok {
+			continue
+		}
+		if err := json.Unmarshal(raw, field.v); err != nil {
+			return jsonFieldErrorf(field.name, err)
+		}
+	}
+	return nil
+}
+
+// decodeJSONList decodes the objects of the list field name with decode,
+// keeping the null ones nil.
+func decodeJSONList[T any](list []json.RawMessage, name string, decode func(jsonFields) (*T, error)) ([]*T, error) {
+	if list == nil {
+		return nil, nil
+	}
+	decoded := make([]*T, len(list))
+	for i, raw := range list {
+		field := fmt.Sprintf("%s[%d]", name, i)
+		fields, err := decodeJSONFields(raw)
+		if err != nil {
+			return nil, jsonFieldErrorf(field, err)
+		}
+		if fields == nil {
+			continue
+		}
+		if decoded[i], err = decode(fields); err != nil {
+			return nil, jsonFieldErrorf(field, err)
+		}
+	}
+	return decoded, nil
+}
+
+// jsonFieldError is the error decoding a field of a proof from JSON. Field
+// is the path of the field, e.g. "row_proof.proofs[1].aunts".
+type jsonFieldError struct {
+	Field string
+	Err   error
+}
+
+// jsonFieldErrorf returns the error decoding field, prefixing the path of
+// the nested field failing to decode if any.
+func jsonFieldErrorf(field string, err error) error {
+	var fieldErr *jsonFieldError
+	if errors.As(err, &fieldErr) {
+		return &jsonFieldError{Field: field + "." + fieldErr.Field, Err: fieldErr.Err}
+	}
+	return &jsonFieldError{Field: field, Err: err}
+}
+
+func (e *jsonFieldError) Error() string {
+	return fmt.Sprintf("invalid %s: %v", e.Field, e.Err)
+}
+
+func (e *jsonFieldError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,123 @@
+package types
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+	cmtjson "github.com/tendermint/tendermint/libs/json"
+)
+
+func TestShareProofJSON(t *testing.T) {
+	multiRow, multiRowRoot := withRowProofs(t, squareShareProof(t, 64, 16), 64)
+	multiRow.DataRoot = multiRowRoot
+	absence, absenceRoot := absenceShareProof(t)
+	testCases := []struct {
+		name string
+		sp   ShareProof
+		root []byte
+	}{
+		{"empty", ShareProof{}, nil},
+		{"single row", validShareProof(), root},
+		{"16 rows", multiRow, multiRowRoot},
+		{"absence", absence, absenceRoot},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			bz, err := json.Marshal(tc.sp)
+			require.NoError(t, err)
+			var decoded ShareProof
+			require.NoError(t, json.Unmarshal(bz, &decoded))
+			assert.Equal(t, tc.sp.Canonicalize(), decoded.Canonicalize())
+			if tc.root != nil {
+				assert.NoError(t, decoded.Validate(tc.root))
+			}
+
+			// the RPC encodes the proof the same way
+			cmtBz, err := cmtjson.Marshal(tc.sp)
+			require.NoError(t, err)
+			assert.JSONEq(t, string(bz), string(cmtBz))
+			decoded = ShareProof{}
+			require.NoError(t, cmtjson.Unmarshal(cmtBz, &decoded))
+			assert.Equal(t, tc.sp.Canonicalize(), decoded.Canonicalize())
+		})
+	}
+}
+
+func TestShareProofJSONEncoding(t *testing.T) {
+	sp := validShareProof()
+	sp.DataRoot = root
+	bz, err := json.Marshal(sp)
+	require.NoError(t, err)
+
+	var fields map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(bz, &fields))
+	var rowProof map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(fields["row_proof"], &rowProof))
+
+	// the shares and the namespace are base64, the roots hex
+	var data []string
+	require.NoError(t, json.Unmarshal(fields["data"], &data))
+	assert.Equal(t, base64.StdEncoding.EncodeToString(sp.Data[0]), data[0])
+	assert.Equal(t, `"`+base64.StdEncoding.EncodeToString(sp.NamespaceID)+`"`, string(fields["namespace_id"]))
+	var rowRoots []string
+	require.NoError(t, json.Unmarshal(rowProof["row_roots"], &rowRoots))
+	assert.Equal(t, sp.RowProof.RowRoots[0].String(), rowRoots[0])
+	assert.Equal(t, `"`+tmbytes.HexBytes(root).String()+`"`, string(fields["data_root"]))
+
+	// the integers are numbers
+	var proofs []map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(rowProof["proofs"], &proofs))
+	assert.Equal(t, "128", string(proofs[0]["total"]))
+
+	// there is no data root unless the proof holds one
+	bz, err = json.Marshal(validShareProof())
+	require.NoError(t, err)
+	assert.NotContains(t, string(bz), "data_root")
+}
+
+func TestShareProofJSONInvalidField(t *testing.T) {
+	bz, err := json.Marshal(validShareProof())
+	require.NoError(t, err)
+
+	testCases := []struct {
+		field   string
+		malform func(sp map[string]interface{})
+	}{
+		{"data", func(sp map[string]interface{}) { sp["data"] = []interface{}{"not base64!"} }},
+		{"namespace_id", func(sp map[string]interface{}) { sp["namespace_id"] = 1 }},
+		{"namespace_version", func(sp map[string]interface{}) { sp["namespace_version"] = "0" }},
+		{"share_proofs[0].nodes", func(sp map[string]interface{}) {
+			sp["share_proofs"].([]interface{})[0].(map[string]interface{})["nodes"] = []interface{}{"not base64!"}
+		}},
+		{"share_proofs[0]", func(sp map[string]interface{}) { sp["share_proofs"] = []interface{}{"proof"} }},
+		{"row_proof.row_roots", func(sp map[string]interface{}) {
+			sp["row_proof"].(map[string]interface{})["row_roots"] = []interface{}{"not hex"}
+		}},
+		{"row_proof.proofs[0].aunts", func(sp map[string]interface{}) {
+			proof := sp["row_proof"].(map[string]interface{})["proofs"].([]interface{})[0]
+			proof.(map[string]interface{})["aunts"] = "aunts"
+		}},
+		{"row_proof.end_row", func(sp map[string]interface{}) { sp["row_proof"].(map[string]interface{})["end_row"] = -1 }},
+		{"data_root", func(sp map[string]interface{}) { sp["data_root"] = "not hex" }},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.field, func(t *testing.T) {
+			// copy the valid proof through its encoding
+			var sp map[string]interface{}
+			require.NoError(t, json.Unmarshal(bz, &sp))
+			tc.malform(sp)
+			malformed, err := json.Marshal(sp)
+			require.NoError(t, err)
+
+			var decoded ShareProof
+			err = json.Unmarshal(malformed, &decoded)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "invalid "+tc.field+":")
+		})
+	}
+}
@@ -1,9 +1,22 @@
 package types
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/json"
+	"errors"
+	"hash"
+	"sync/atomic"
 	"testing"
 
+	"github.com/celestiaorg/nmt"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/merkle"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+	cmtjson "github.com/tendermint/tendermint/libs/json"
 	"github.com/tendermint/tendermint/pkg/consts"
 	"github.com/tendermint/tendermint/proto/tendermint/types"
 )
@@ -61,6 +74,292 @@ func TestShareProofValidate(t *testing.T) {
 	}
 }
 
+func TestShareProofValidateNodeOrder(t *testing.T) {
+	require.NoError(t, validShareProof().Validate(root))
+	require.NoError(t, validShareProof().ValidateStructure())
+
+	testCases := []struct {
+		name     string
+		malleate func(nodes [][]byte) [][]byte
+		wantErr  string
+	}{
+		{
+			"reordered nodes",
+			func(nodes [][]byte) [][]byte {
+				nodes[4], nodes[5] = nodes[5], nodes[4]
+				return nodes
+			},
+			"nodes 4 and 5 of share proof 0 are out of order",
+		},
+		{
+			"missing node",
+			func(nodes [][]byte) [][]byte { return nodes[:5] },
+			"share proof 0 has 5 nodes, expected 6",
+		},
+		{
+			"truncated node",
+			func(nodes [][]byte) [][]byte {
+				nodes[2] = nodes[2][:40]
+				return nodes
+			},
+			"node 2 of share proof 0 has size 40",
+		},
+		{
+			"node right of the shares holding a lower namespace",
+			func(nodes [][]byte) [][]byte {
+				nsSize := (len(nodes[0]) - 32) / 2
+				nodes[0] = append(make([]byte, 2*nsSize), nodes[0][2*nsSize:]...)
+				return nodes
+			},
+			"node 0 of share proof 0 is right of the shares",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sp := validShareProof()
+			sp.ShareProofs[0].Nodes = tc.malleate(sp.ShareProofs[0].Nodes)
+			err := sp.Validate(root)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.wantErr)
+			assert.Error(t, sp.ValidateStructure())
+		})
+	}
+}
+
+func TestShareProofValidateSquareSize(t *testing.T) {
+	// the row proof commits to 4*32 roots
+	assert.NoError(t, validShareProof().Validate(root, WithSquareSize(32)))
+
+	sp := validShareProof()
+	sp.ShareProofs[0].End = 33
+	err := sp.Validate(root, WithSquareSize(32))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "share proof of row 0 ends at share 33, beyond the 32 shares of a row")
+
+	sp = ShareProof{
+		RowProof:    RowProof{StartRow: 2, EndRow: 4},
+		ShareProofs: []*types.NMTProof{{Start: 8, End: 16}, {Start: 0, End: 17}, {Start: 0, End: 5}},
+	}
+	err = sp.Validate(root, WithSquareSize(16))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "share proof of row 3 ends at share 17")
+}
+
+func TestShareProofValidateSizes(t *testing.T) {
+	sp := validShareProof()
+	sp.NamespaceID = sp.NamespaceID[:consts.NamespaceIDSize-1]
+	err := sp.Validate(root)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "namespace ID has size 27")
+
+	sp = validShareProof()
+	sp.Data = [][]byte{sp.Data[0][:consts.ShareSize-1]}
+	err = sp.Validate(root)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "share 0 has size 511")
+
+	sp = validShareProof()
+	sp.ShareProofs = []*types.NMTProof{nil}
+	err = sp.Validate(root)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "share proof 0 is nil")
+
+	sp = validShareProof()
+	sp.RowProof.Proofs = []*merkle.Proof{nil}
+	err = sp.Validate(root)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "nil proof")
+}
+
+func TestShareProofFromProtoMalformed(t *testing.T) {
+	_, err := ShareProofFromProto(validShareProof().ToProto())
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name    string
+		malform func(pb *types.ShareProof)
+		wantErr string
+	}{
+		{"nil row proof", func(pb *types.ShareProof) { pb.RowProof = nil }, "no row proof"},
+		{"no row roots", func(pb *types.ShareProof) { pb.RowProof.RowRoots = nil }, "no row roots"},
+		{"nil row proof entry", func(pb *types.ShareProof) { pb.RowProof.Proofs[0] = nil }, "proof 0 is nil"},
+		{"missing row proof entry", func(pb *types.ShareProof) { pb.RowProof.Proofs = nil }, "invalid row proof"},
+		{"nil share proof", func(pb *types.ShareProof) { pb.ShareProofs[0] = nil }, "share proof 0 is nil"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pb := validShareProof().ToProto()
+			tc.malform(&pb)
+			_, err := ShareProofFromProto(pb)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.wantErr)
+		})
+	}
+}
+
+// FuzzShareProofFromProto feeds truncated and random encodings of share proofs
+// to ShareProofFromProto, as received from a peer, and checks that neither
+// decoding nor verifying them panics.
+func FuzzShareProofFromProto(f *testing.F) {
+	pb := validShareProof().ToProto()
+	bz, err := pb.Marshal()
+	require.NoError(f, err)
+	for _, n := range []int{0, 1, len(bz) / 4, len(bz) / 2, len(bz) - 1, len(bz)} {
+		f.Add(bz[:n])
+	}
+	for _, malform := range []func(pb *types.ShareProof){
+		func(pb *types.ShareProof) { pb.RowProof = nil },
+		func(pb *types.ShareProof) { pb.RowProof.RowRoots = nil },
+		func(pb *types.ShareProof) { pb.RowProof.Proofs[0].Aunts = nil },
+		func(pb *types.ShareProof) { pb.ShareProofs[0].Nodes = nil },
+		func(pb *types.ShareProof) { pb.NamespaceId = pb.NamespaceId[:1] },
+		func(pb *types.ShareProof) { pb.Data[0] = pb.Data[0][:1] },
+	} {
+		pb := validShareProof().ToProto()
+		malform(&pb)
+		bz, err := pb.Marshal()
+		require.NoError(f, err)
+		f.Add(bz)
+	}
+
+	f.Fuzz(func(t *testing.T, bz []byte) {
+		var pb types.ShareProof
+		if err := pb.Unmarshal(bz); err != nil {
+			return
+		}
+		sp, err := ShareProofFromProto(pb)
+		if err != nil {
+			return
+		}
+		_ = sp.Validate(root)
+		_ = sp.ValidateStructure()
+		_ = sp.VerifyProof()
+	})
+}
+
+func TestShareProofValidateTrustedRowRoots(t *testing.T) {
+	trustedRowRoots := [][]byte{validRowProof().RowRoots[0].Bytes()}
+
+	// the data root is ignored when the row roots are trusted
+	assert.NoError(t, validShareProof().Validate(incorrectRoot, TrustRowRoots(trustedRowRoots)))
+
+	// share inclusion is still verified
+	sp := validShareProof()
+	sp.Data = [][]byte{append([]byte{}, sp.Data[0]...)}
+	sp.Data[0][len(sp.Data[0])-1]++
+	assert.Error(t, sp.Validate(incorrectRoot, TrustRowRoots(trustedRowRoots)))
+
+	assert.Error(t, validShareProof().Validate(root, TrustRowRoots([][]byte{incorrectRoot})))
+}
+
+func TestShareProofNamespaceRange(t *testing.T) {
+	sp := validShareProof()
+	namespace := sp.Data[0][:consts.NamespaceSize]
+	assert.Equal(t, namespace, sp.MinNamespace())
+	assert.Equal(t, namespace, sp.MaxNamespace())
+
+	share := func(b byte) []byte {
+		share := make([]byte, consts.ShareSize)
+		share[consts.NamespaceSize-1] = b
+		return share
+	}
+	sp.Data = [][]byte{share(3), share(1), {0}, share(7), share(2)}
+	assert.Equal(t, share(1)[:consts.NamespaceSize], sp.MinNamespace())
+	assert.Equal(t, share(7)[:consts.NamespaceSize], sp.MaxNamespace())
+
+	// the returned namespaces are copies
+	sp.MinNamespace()[0] = 0xff
+	assert.Equal(t, share(1), sp.Data[1])
+
+	sp.Data = nil
+	assert.Equal(t, consts.TxNamespaceID, sp.MinNamespace())
+	assert.Equal(t, consts.TxNamespaceID, sp.MaxNamespace())
+}
+
+func TestShareProofEmbeddedDataRoot(t *testing.T) {
+	sp := validShareProof()
+	assert.Error(t, sp.VerifySelfConsistent())
+	assert.False(t, sp.MatchesRoot(root))
+
+	sp.DataRoot = root
+	assert.NoError(t, sp.VerifySelfConsistent())
+	assert.True(t, sp.MatchesRoot(root))
+	assert.False(t, sp.MatchesRoot(incorrectRoot))
+
+	// the embedded root survives a proto round trip
+	pb := sp.ToProto()
+	got, err := ShareProofFromProto(pb)
+	require.NoError(t, err)
+	assert.Equal(t, root, got.DataRoot)
+	assert.NoError(t, got.VerifySelfConsistent())
+
+	// a root the rows are not proven against fails the verification
+	sp.DataRoot = incorrectRoot
+	assert.Error(t, sp.VerifySelfConsistent())
+	assert.False(t, sp.MatchesRoot(root))
+}
+
+func TestShareProofDataNamespacesConsistent(t *testing.T) {
+	sp := validShareProof()
+	assert.True(t, sp.DataNamespacesConsistent())
+	assert.NoError(t, sp.ValidateStructure())
+
+	// a share of another namespace
+	other := append([]byte{}, sp.Data[0]...)
+	other[len(sp.NamespaceID)]++
+	sp.Data = [][]byte{other}
+	assert.False(t, sp.DataNamespacesConsistent())
+	assert.Error(t, sp.ValidateStructure())
+
+	// a share shorter than the namespace
+	sp.Data = [][]byte{validShareProof().Data[0][:len(sp.NamespaceID)]}
+	assert.False(t, sp.DataNamespacesConsistent())
+
+	// the namespace version is part of the prefix
+	sp = validShareProof()
+	sp.NamespaceVersion = 1
+	assert.False(t, sp.DataNamespacesConsistent())
+
+	// the shares must still match the share proofs
+	assert.True(t, mismatchedShareProofs().DataNamespacesConsistent())
+	assert.Error(t, mismatchedShareProofs().ValidateStructure())
+}
+
+func TestShareProofVerifyAgainstStore(t *testing.T) {
+	store := headerStore{
+		1: {SignedHeader: &SignedHeader{Header: &Header{Height: 1, DataHash: root}}},
+		2: {SignedHeader: &SignedHeader{Header: &Header{Height: 2, DataHash: incorrectRoot}}},
+		3: {SignedHeader: &SignedHeader{Header: &Header{Height: 4, DataHash: root}}},
+		4: {},
+	}
+
+	assert.NoError(t, validShareProof().VerifyAgainstStore(store, 1))
+	assert.Error(t, mismatchedShares().VerifyAgainstStore(store, 1))
+
+	// the proof is verified against the trusted data root of the height
+	assert.Error(t, validShareProof().VerifyAgainstStore(store, 2))
+
+	for _, height := range []int64{3, 4, 5} {
+		assert.ErrorContains(t, validShareProof().VerifyAgainstStore(store, height), "height", height)
+	}
+	err := validShareProof().VerifyAgainstStore(store, 5)
+	assert.ErrorIs(t, err, errNoLightBlock)
+	assert.ErrorContains(t, err, "no trusted header at height 5")
+}
+
+var errNoLightBlock = errors.New("light block not found")
+
+// headerStore is a HeaderStore holding the light blocks by height.
+type headerStore map[int64]*LightBlock
+
+func (s headerStore) LightBlock(height int64) (*LightBlock, error) {
+	lb, ok := s[height]
+	if !ok {
+		return nil, errNoLightBlock
+	}
+	return lb, nil
+}
+
 func mismatchedShareProofs() ShareProof {
 	sp := validShareProof()
 	sp.ShareProofs = []*types.NMTProof{}
@@ -92,3 +391,581 @@ func validShareProof() ShareProof {
 		NamespaceVersion: uint32(0),
 	}
 }
+
+func TestShareProofShareRange(t *testing.T) {
+	multiRow := validShareProof()
+	multiRow.RowProof.StartRow = 2
+	multiRow.RowProof.EndRow = 4
+	multiRow.ShareProofs = []*types.NMTProof{{Start: 30, End: 32}, {Start: 0, End: 32}, {Start: 0, End: 5}}
+
+	noRowProofs := validShareProof()
+	noRowProofs.RowProof.Proofs = nil
+
+	testCases := []struct {
+		name       string
+		sp         ShareProof
+		squareSize int
+		wantFirst  int
+		wantLast   int
+		wantErr    bool
+	}{
+		{"single share with inferred square size", validShareProof(), 0, 0, 0, false},
+		{"single share with explicit square size", validShareProof(), 32, 0, 0, false},
+		{"multiple rows", multiRow, 32, 94, 132, false},
+		{"multiple rows with inferred square size", multiRow, 0, 94, 132, false},
+		{"no square size and no row proofs", noRowProofs, 0, 0, 0, true},
+		{"negative square size", validShareProof(), -1, 0, 0, true},
+		{"end row exceeds square size", multiRow, 4, 0, 0, true},
+		{"end share exceeds square size", multiRow, 16, 0, 0, true},
+		{"no share proofs", mismatchedShareProofs(), 32, 0, 0, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			first, last, err := tc.sp.ShareRange(tc.squareSize)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantFirst, first)
+			assert.Equal(t, tc.wantLast, last)
+		})
+	}
+}
+
+func TestShareProofAdjacentTo(t *testing.T) {
+	// shareProof returns a proof covering the shares [start, end) of a single
+	// row in a square of size 32
+	shareProof := func(row uint32, start, end int32) ShareProof {
+		sp := validShareProof()
+		sp.RowProof.StartRow = row
+		sp.RowProof.EndRow = row
+		sp.ShareProofs = []*types.NMTProof{{Start: start, End: end}}
+		return sp
+	}
+	// spanning the end of row 2 and the start of row 3
+	multiRow := validShareProof()
+	multiRow.RowProof.StartRow = 2
+	multiRow.RowProof.EndRow = 3
+	multiRow.ShareProofs = []*types.NMTProof{{Start: 30, End: 32}, {Start: 0, End: 4}}
+
+	testCases := []struct {
+		name  string
+		a, b  ShareProof
+		wantA bool // a.AdjacentTo(b)
+		wantB bool // b.AdjacentTo(a)
+	}{
+		{"adjacent in the same row", shareProof(1, 0, 4), shareProof(1, 4, 8), true, false},
+		{"adjacent across rows", shareProof(1, 28, 32), shareProof(2, 0, 3), true, false},
+		{"adjacent to a multi row proof", shareProof(2, 10, 30), multiRow, true, false},
+		{"multi row proof adjacent to the next", multiRow, shareProof(3, 4, 5), true, false},
+		{"overlapping", shareProof(1, 0, 5), shareProof(1, 4, 8), false, false},
+		{"identical", shareProof(1, 0, 4), shareProof(1, 0, 4), false, false},
+		{"gapped", shareProof(1, 0, 4), shareProof(1, 5, 8), false, false},
+		{"gapped across rows", shareProof(1, 28, 31), shareProof(2, 0, 3), false, false},
+		{"invalid proof", shareProof(1, 0, 4), mismatchedShareProofs(), false, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.wantA, tc.a.AdjacentTo(tc.b, 32))
+			assert.Equal(t, tc.wantB, tc.b.AdjacentTo(tc.a, 32))
+		})
+	}
+}
+
+func TestShareProofCanonicalize(t *testing.T) {
+	sp := validShareProof()
+
+	// an equal proof built with empty slices instead of nil ones, and copies
+	// of the byte slices
+	other := validShareProof()
+	other.DataRoot = []byte{}
+	other.ShareProofs[0].LeafHash = []byte{}
+	for _, proof := range other.RowProof.Proofs {
+		proof.Aunts = append([][]byte{}, proof.Aunts...)
+		proof.LeafHash = append([]byte{}, proof.LeafHash...)
+	}
+	other.NamespaceID = append([]byte{}, sp.NamespaceID...)
+	require.NotEqual(t, sp, other)
+
+	canonical := sp.Canonicalize()
+	assert.Equal(t, canonical, other.Canonicalize())
+	assert.Equal(t, canonical, canonical.Canonicalize())
+	assert.Equal(t, sp.Hash(), other.Hash())
+	assert.Len(t, sp.Hash(), tmhash.Size)
+
+	spJSON, err := cmtjson.Marshal(sp.Canonicalize())
+	require.NoError(t, err)
+	otherJSON, err := cmtjson.Marshal(other.Canonicalize())
+	require.NoError(t, err)
+	assert.Equal(t, spJSON, otherJSON)
+
+	// the canonical proof still verifies
+	assert.True(t, canonical.VerifyProof())
+
+	// the canonical proof shares no memory with the proof
+	canonical.Data[0][0] ^= 0xff
+	canonical.ShareProofs[0].Nodes[0][0] ^= 0xff
+	canonical.RowProof.RowRoots[0][0] ^= 0xff
+	assert.Equal(t, validShareProof(), sp)
+
+	// different proofs have different hashes
+	empty := ShareProof{Data: [][]byte{}, ShareProofs: []*types.NMTProof{}}
+	assert.Equal(t, ShareProof{}, empty.Canonicalize())
+	assert.Equal(t, ShareProof{}.Hash(), empty.Hash())
+	assert.NotEqual(t, sp.Hash(), empty.Hash())
+	assert.NotEqual(t, sp.Hash(), mismatchedShares().Hash())
+}
+
+func TestShareProofVerifyProofMaxSquare(t *testing.T) {
+	sp := maxSquareShareProof(t)
+	assert.True(t, sp.VerifyProof())
+
+	// a share in the last row is modified
+	tampered := maxSquareShareProof(t)
+	tampered.Data[len(tampered.Data)-1] = bytes.Repeat([]byte{0}, len(tampered.Data[0]))
+	assert.False(t, tampered.VerifyProof())
+
+	// more shares are claimed than the proof carries
+	truncated := maxSquareShareProof(t)
+	truncated.Data = truncated.Data[:len(truncated.Data)-1]
+	assert.False(t, truncated.VerifyProof())
+
+	// fewer row roots than share proofs
+	missingRows := maxSquareShareProof(t)
+	missingRows.RowProof.RowRoots = missingRows.RowProof.RowRoots[:1]
+	assert.False(t, missingRows.VerifyProof())
+}
+
+func TestShareProofVerifyProofWithError(t *testing.T) {
+	require.NoError(t, maxSquareShareProof(t).VerifyProofWithError())
+
+	// a share in the last row is modified
+	tampered := maxSquareShareProof(t)
+	tampered.Data[len(tampered.Data)-1] = bytes.Repeat([]byte{0}, len(tampered.Data[0]))
+	lastProof := tampered.ShareProofs[len(tampered.ShareProofs)-1]
+	err := tampered.VerifyProofWithError()
+	var rowErr *ErrRowVerification
+	require.ErrorAs(t, err, &rowErr)
+	assert.Equal(t, int(tampered.RowProof.EndRow), rowErr.Row)
+	assert.Equal(t, lastProof.Start, rowErr.Start)
+	assert.Equal(t, lastProof.End, rowErr.End)
+	assert.False(t, rowErr.RowProof)
+	assert.Contains(t, err.Error(), "NMT inclusion check of row")
+	assert.Contains(t, err.Error(), "do not hash to the row root")
+
+	// more shares are claimed than the proof carries
+	truncated := maxSquareShareProof(t)
+	truncated.Data = truncated.Data[:len(truncated.Data)-1]
+	err = truncated.VerifyProofWithError()
+	require.ErrorAs(t, err, &rowErr)
+	assert.Equal(t, int(truncated.RowProof.EndRow), rowErr.Row)
+	assert.Contains(t, err.Error(), "does not fit the")
+
+	// Validate reports the detailed error
+	sp := validShareProof()
+	sp.Data[0] = append([]byte{}, sp.Data[0]...)
+	sp.Data[0][len(sp.Data[0])-1]++
+	err = sp.Validate(root)
+	require.ErrorAs(t, err, &rowErr)
+	assert.False(t, rowErr.RowProof)
+	assert.Equal(t, int(sp.RowProof.StartRow), rowErr.Row)
+
+	// as well as the failing row proof, with the shares of the row
+	sp = validShareProof()
+	err = sp.Validate(incorrectRoot)
+	require.ErrorAs(t, err, &rowErr)
+	assert.True(t, rowErr.RowProof)
+	assert.Equal(t, int(sp.RowProof.StartRow), rowErr.Row)
+	assert.Equal(t, sp.ShareProofs[0].Start, rowErr.Start)
+	assert.Equal(t, sp.ShareProofs[0].End, rowErr.End)
+	assert.Contains(t, err.Error(), "row proof of row")
+}
+
+func TestShareProofVerifyProofWithOptions(t *testing.T) {
+	// a row of namespaced shares followed by parity shares, whose maximum
+	// namespace changes the root depending on the IgnoreMaxNamespace option
+	shareProof := func(ignoreMaxNS bool) ShareProof {
+		namespace := append([]byte{0}, consts.TxNamespaceID...)
+		parityNamespace := bytes.Repeat([]byte{0xff}, len(namespace))
+		tree := nmt.New(consts.NewBaseHashFunc(), nmt.NamespaceIDSize(len(namespace)), nmt.IgnoreMaxNamespace(ignoreMaxNS))
+		sp := ShareProof{NamespaceID: consts.TxNamespaceID}
+		for col := 0; col < 4; col++ {
+			ns := namespace
+			if col >= 2 {
+				ns = parityNamespace
+			}
+			share := append(append([]byte{}, ns...), byte(col))
+			require.NoError(t, tree.Push(append(append([]byte{}, ns...), share...)))
+			if col < 2 {
+				sp.Data = append(sp.Data, share)
+			}
+		}
+		rowRoot, err := tree.Root()
+		require.NoError(t, err)
+		proof, err := tree.ProveRange(0, 2)
+		require.NoError(t, err)
+		sp.RowProof.RowRoots = append(sp.RowProof.RowRoots, rowRoot)
+		sp.ShareProofs = append(sp.ShareProofs, &types.NMTProof{
+			Start: int32(proof.Start()),
+			End:   int32(proof.End()),
+			Nodes: proof.Nodes(),
+		})
+		return sp
+	}
+
+	ignoring := shareProof(true)
+	assert.True(t, ignoring.VerifyProof())
+	assert.True(t, ignoring.VerifyProofWithOptions(true))
+	assert.False(t, ignoring.VerifyProofWithOptions(false))
+
+	notIgnoring := shareProof(false)
+	assert.False(t, notIgnoring.VerifyProof())
+	assert.False(t, notIgnoring.VerifyProofWithOptions(true))
+	assert.True(t, notIgnoring.VerifyProofWithOptions(false))
+}
+
+// absenceShareProof returns a proof that a namespace falling between the two
+// namespaces of a row is absent from it, along with the data root of the row.
+func absenceShareProof(t *testing.T) (ShareProof, []byte) {
+	namespaceOf := func(b byte) []byte {
+		ns := make([]byte, consts.NamespaceVersionSize+consts.NamespaceIDSize)
+		ns[len(ns)-1] = b
+		return ns
+	}
+	tree := nmt.New(consts.NewBaseHashFunc(), nmt.NamespaceIDSize(len(namespaceOf(0))), nmt.IgnoreMaxNamespace(true))
+	for _, ns := range [][]byte{namespaceOf(1), namespaceOf(1), namespaceOf(3), bytes.Repeat([]byte{0xff}, len(namespaceOf(0)))} {
+		share := append(append([]byte{}, ns...), make([]byte, consts.ShareSize-len(ns))...)
+		require.NoError(t, tree.Push(append(append([]byte{}, ns...), share...)))
+	}
+	rowRoot, err := tree.Root()
+	require.NoError(t, err)
+	absent := namespaceOf(2)
+	proof, err := tree.ProveNamespace(absent)
+	require.NoError(t, err)
+	require.True(t, proof.IsOfAbsence())
+
+	dataRoot, rowProofs := merkle.ProofsFromByteSlices([][]byte{rowRoot, tmhash.Sum([]byte("column"))})
+	sp := ShareProof{
+		ShareProofs: []*types.NMTProof{{
+			Start:    int32(proof.Start()),
+			End:      int32(proof.End()),
+			Nodes:    proof.Nodes(),
+			LeafHash: proof.LeafHash(),
+		}},
+		NamespaceID: absent[consts.NamespaceVersionSize:],
+		RowProof: RowProof{
+			RowRoots: []tmbytes.HexBytes{rowRoot},
+			Proofs:   rowProofs[:1],
+		},
+	}
+	return sp, dataRoot
+}
+
+func TestShareProofAbsence(t *testing.T) {
+	sp, dataRoot := absenceShareProof(t)
+	assert.True(t, sp.IsAbsence())
+	require.NoError(t, sp.Validate(dataRoot))
+	require.NoError(t, sp.ValidateStructure())
+	require.NoError(t, sp.VerifyRowRootsFromData())
+	assert.True(t, sp.Diagnose(dataRoot).Valid)
+	assert.False(t, validShareProof().IsAbsence())
+
+	// the namespaces around the absent one are present
+	for _, b := range []byte{1, 3} {
+		present, _ := absenceShareProof(t)
+		present.NamespaceID = append([]byte{}, present.NamespaceID...)
+		present.NamespaceID[len(present.NamespaceID)-1] = b
+		assert.Error(t, present.Validate(dataRoot), "namespace %d", b)
+	}
+
+	// an absence proof proves no shares
+	withData, _ := absenceShareProof(t)
+	withData.Data = [][]byte{make([]byte, consts.ShareSize)}
+	assert.Error(t, withData.Validate(dataRoot))
+
+	tampered, _ := absenceShareProof(t)
+	tampered.ShareProofs[0].LeafHash = append([]byte{}, tampered.ShareProofs[0].LeafHash...)
+	tampered.ShareProofs[0].LeafHash[len(tampered.ShareProofs[0].LeafHash)-1] ^= 0xff
+	err := tampered.Validate(dataRoot)
+	require.Error(t, err)
+	var rowErr *ErrRowVerification
+	assert.ErrorAs(t, err, &rowErr)
+
+	wideRange, _ := absenceShareProof(t)
+	wideRange.ShareProofs[0].End++
+	err = wideRange.Validate(dataRoot)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "absence proof must cover a single leaf")
+}
+
+// multiRootShareProof returns a proof of the last two rows of a square of size
+// 4 and the first two rows of a square of size 8, e.g. of consecutive blocks,
+// and the data roots of both squares.
+func multiRootShareProof(t *testing.T) (ShareProof, [][]byte) {
+	first, firstRoot := withRowProofs(t, squareShareProof(t, 4, 4), 4)
+	second, secondRoot := withRowProofs(t, squareShareProof(t, 8, 2), 8)
+	sp := ShareProof{
+		Data:        append(first.Data[2*4:], second.Data...),
+		ShareProofs: append(first.ShareProofs[2:], second.ShareProofs...),
+		NamespaceID: first.NamespaceID,
+		RowProof: RowProof{
+			RowRoots:    append(first.RowProof.RowRoots[2:], second.RowProof.RowRoots...),
+			Proofs:      append(first.RowProof.Proofs[2:], second.RowProof.Proofs...),
+			StartRow:    2,
+			EndRow:      1,
+			RootIndices: []uint32{0, 0, 1, 1},
+		},
+	}
+	return sp, [][]byte{firstRoot, secondRoot}
+}
+
+func TestShareProofValidateMultiRoot(t *testing.T) {
+	sp, roots := multiRootShareProof(t)
+	require.NoError(t, sp.ValidateMultiRoot(roots))
+
+	// the rows are verified against the data roots of their root indices
+	var rowErr *ErrRowVerification
+	err := sp.ValidateMultiRoot([][]byte{roots[1], roots[0]})
+	require.ErrorAs(t, err, &rowErr)
+	assert.Equal(t, 2, rowErr.Row)
+	assert.Equal(t, 0, rowErr.RootIndex)
+	err = sp.ValidateMultiRoot(roots[:1])
+	require.ErrorAs(t, err, &rowErr)
+	assert.Equal(t, 0, rowErr.Row)
+	assert.Equal(t, 1, rowErr.RootIndex)
+	assert.Contains(t, err.Error(), "row 0 of data root 1 (shares 0 to 8)")
+	assert.Error(t, sp.Validate(roots[0]))
+
+	// the rows of a single data root are the rows of the proofs without
+	// root indices
+	single := sp
+	single.RowProof.RootIndices = []uint32{0, 0, 0, 0}
+	single.RowProof.EndRow = 5
+	assert.Error(t, single.ValidateMultiRoot(roots))
+
+	testCases := []struct {
+		name    string
+		malform func(rp *RowProof)
+	}{
+		{"missing root index", func(rp *RowProof) { rp.RootIndices = rp.RootIndices[:3] }},
+		{"skipped data root", func(rp *RowProof) { rp.RootIndices = []uint32{0, 0, 2, 2} }},
+		{"decreasing root index", func(rp *RowProof) { rp.RootIndices = []uint32{1, 1, 0, 0} }},
+		{"wrong end row", func(rp *RowProof) { rp.EndRow = 3 }},
+		{"rows of the next data root not starting at row 0", func(rp *RowProof) {
+			rp.RootIndices = []uint32{0, 0, 0, 1}
+			rp.EndRow = 0
+		}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			malformed, _ := multiRootShareProof(t)
+			tc.malform(&malformed.RowProof)
+			assert.Error(t, malformed.ValidateMultiRoot(roots))
+		})
+	}
+
+	// the root indices survive the encodings
+	decoded, err := ShareProofFromProto(sp.ToProto())
+	require.NoError(t, err)
+	assert.NoError(t, decoded.ValidateMultiRoot(roots))
+	bz, err := json.Marshal(sp)
+	require.NoError(t, err)
+	decoded = ShareProof{}
+	require.NoError(t, json.Unmarshal(bz, &decoded))
+	assert.NoError(t, decoded.ValidateMultiRoot(roots))
+	assert.Equal(t, sp.Canonicalize(), decoded.Canonicalize())
+
+	// the row roots of a single square cannot be trusted for several squares
+	trusted := make([][]byte, 8)
+	for i := range trusted {
+		trusted[i] = sp.RowProof.RowRoots[i%len(sp.RowProof.RowRoots)]
+	}
+	assert.Error(t, sp.RowProof.validate(roots, TrustRowRoots(trusted)))
+}
+
+func TestShareProofVerifyRowRootsFromData(t *testing.T) {
+	require.NoError(t, validShareProof().VerifyRowRootsFromData())
+	require.NoError(t, maxSquareShareProof(t).VerifyRowRootsFromData())
+
+	// the data of the last row is tampered but the share proofs are stale
+	tampered := maxSquareShareProof(t)
+	share := append([]byte(nil), tampered.Data[len(tampered.Data)-1]...)
+	share[len(share)-1] ^= 0xff
+	tampered.Data[len(tampered.Data)-1] = share
+	err := tampered.VerifyRowRootsFromData()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "row 127")
+
+	// a share that does not start with the namespace of the proof
+	wrongNamespace := validShareProof()
+	share = append([]byte(nil), wrongNamespace.Data[0]...)
+	share[0] = 1
+	wrongNamespace.Data[0] = share
+	assert.Error(t, wrongNamespace.VerifyRowRootsFromData())
+
+	// shares not covered by any share proof pass VerifyProof but not this check
+	trailing := validShareProof()
+	trailing.Data = append(trailing.Data, trailing.Data[0])
+	assert.True(t, trailing.VerifyProof())
+	assert.Error(t, trailing.VerifyRowRootsFromData())
+
+	// row roots not covered by any share proof
+	missingProofs := maxSquareShareProof(t)
+	missingProofs.ShareProofs = missingProofs.ShareProofs[:1]
+	missingProofs.Data = missingProofs.Data[:128]
+	assert.Error(t, missingProofs.VerifyRowRootsFromData())
+
+	tooShort := validShareProof()
+	tooShort.Data = [][]byte{{0}}
+	tooShort.ShareProofs[0].End = 1
+	assert.Error(t, tooShort.VerifyRowRootsFromData())
+}
+
+func TestShareProofEstimateVerificationCost(t *testing.T) {
+	assert.Equal(t, int64(0), ShareProof{}.EstimateVerificationCost())
+
+	// one 512 byte share with a 33 byte namespace and six proof nodes of 98 bytes
+	assert.Equal(t, int64(1+33+512+7*(1+2*98)), validShareProof().EstimateVerificationCost())
+
+	maxCost := maxSquareShareProof(t).EstimateVerificationCost()
+	assert.Greater(t, maxCost, int64(128*128*512))
+}
+
+// maxSquareShareProof returns a valid ShareProof covering every share of a
+// 128x128 original data square. The row roots are the roots of the NMTs of the
+// extended rows, which is all that is needed to verify share inclusion.
+func maxSquareShareProof(tb testing.TB) ShareProof {
+	return squareShareProof(tb, 128, 128)
+}
+
+// squareShareProof returns a valid ShareProof covering every share of the
+// first rows of a squareSize x squareSize original data square, see
+// maxSquareShareProof.
+func squareShareProof(tb testing.TB, squareSize, rows int) ShareProof {
+	const shareSize = 512
+	namespace := append([]byte{0}, consts.TxNamespaceID...)
+	parityNamespace := bytes.Repeat([]byte{0xff}, len(namespace))
+
+	sp := ShareProof{
+		NamespaceID: consts.TxNamespaceID,
+		RowProof: RowProof{
+			StartRow: 0,
+			EndRow:   uint32(rows - 1),
+		},
+	}
+	for row := 0; row < rows; row++ {
+		tree := nmt.New(consts.NewBaseHashFunc(), nmt.NamespaceIDSize(len(namespace)), nmt.IgnoreMaxNamespace(true))
+		for col := 0; col < 2*squareSize; col++ {
+			ns := namespace
+			if col >= squareSize {
+				ns = parityNamespace
+			}
+			// like real shares, every share starts with its namespace
+			share := append(append([]byte{}, ns...), bytes.Repeat([]byte{byte(row), byte(col)}, shareSize/2)[len(ns):]...)
+			require.NoError(tb, tree.Push(append(append([]byte{}, ns...), share...)))
+			if col < squareSize {
+				sp.Data = append(sp.Data, share)
+			}
+		}
+		rowRoot, err := tree.Root()
+		require.NoError(tb, err)
+		proof, err := tree.ProveRange(0, squareSize)
+		require.NoError(tb, err)
+
+		sp.RowProof.RowRoots = append(sp.RowProof.RowRoots, rowRoot)
+		sp.ShareProofs = append(sp.ShareProofs, &types.NMTProof{
+			Start: int32(proof.Start()),
+			End:   int32(proof.End()),
+			Nodes: proof.Nodes(),
+		})
+	}
+	return sp
+}
+
+func TestSetDefaultHasher(t *testing.T) {
+	sp := maxSquareShareProof(t)
+	t.Cleanup(func() { SetDefaultHasher(nil) })
+
+	var hasher countingHasher
+	SetDefaultHasher(hasher.new)
+	hasher.reset()
+	assert.True(t, sp.VerifyProof())
+	assert.NoError(t, sp.VerifyRowRootsFromData())
+	assert.Positive(t, hasher.count())
+
+	// the hashing of consensus data is left untouched
+	hasher.reset()
+	tree := nmt.New(consts.NewBaseHashFunc(), nmt.NamespaceIDSize(consts.NamespaceSize))
+	require.NoError(t, tree.Push(append(append([]byte{}, consts.TxNamespaceID...), sp.Data[0]...)))
+	_, err := tree.Root()
+	require.NoError(t, err)
+	assert.Zero(t, hasher.count())
+
+	// hashers not computing SHA-256 are rejected
+	assert.Panics(t, func() { SetDefaultHasher(sha512.New512_256) })
+	assert.Panics(t, func() { SetDefaultHasher(sha512.New) })
+	hasher.reset()
+	assert.True(t, sp.VerifyProof())
+	assert.Positive(t, hasher.count())
+
+	SetDefaultHasher(nil)
+	hasher.reset()
+	assert.True(t, sp.VerifyProof())
+	assert.Zero(t, hasher.count())
+}
+
+// countingHasher creates SHA-256 hashers counting the writes made to them.
+type countingHasher struct {
+	writes atomic.Int64
+}
+
+func (c *countingHasher) new() hash.Hash {
+	return countingHash{Hash: sha256.New(), writes: &c.writes}
+}
+
+func (c *countingHasher) count() int64 { return c.writes.Load() }
+func (c *countingHasher) reset()       { c.writes.Store(0) }
+
+type countingHash struct {
+	hash.Hash
+	writes *atomic.Int64
+}
+
+func (h countingHash) Write(p []byte) (int, error) {
+	h.writes.Add(1)
+	return h.Hash.Write(p)
+}
+
+// BenchmarkShareProofVerifyProof compares the verification of a share proof
+// with the default hasher and with the hashers supplied by SetDefaultHasher.
+// A hardware accelerated SHA-256 implementation is benchmarked by adding its
+// constructor to the hashers below.
+func BenchmarkShareProofVerifyProof(b *testing.B) {
+	sp := maxSquareShareProof(b)
+	require.True(b, sp.VerifyProof())
+
+	hashers := []struct {
+		name string
+		fn   func() hash.Hash
+	}{
+		{"default", nil},
+		{"sha256.New", sha256.New},
+	}
+	for _, hasher := range hashers {
+		b.Run(hasher.name, func(b *testing.B) {
+			SetDefaultHasher(hasher.fn)
+			defer SetDefaultHasher(nil)
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if !sp.VerifyProof() {
+					b.Fatal("share proof failed to verify")
+				}
+			}
+		})
+	}
+}
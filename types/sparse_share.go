@@ -0,0 +1,131 @@
+package types
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/tendermint/tendermint/pkg/consts"
+)
+
+// The layout of the sparse shares holding a blob, of share version 0:
+//
+//	namespace | info byte | sequence length (first share only) | data | padding (last share only)
+//
+// where the info byte holds the share version in its 7 upper bits and the
+// sequence start flag in its lowest bit. The first share of a blob of share
+// version 1 also holds the address of the signer of the blob, after the
+// sequence length. The data of the last share is padded with zeros.
+const (
+	sparseShareInfoByteSize       = 1
+	sparseShareSequenceLenSize    = 4
+	sparseShareSequenceStartFlag  = 1
+	sparseShareVersionSignerSize  = 20
+	sparseShareMinSizeWithoutData = consts.NamespaceSize + sparseShareInfoByteSize
+
+	// the number of bytes of blob data held by the first share of a blob, and
+	// by the following ones
+	firstSparseShareContentSize        = consts.ShareSize - consts.NamespaceSize - sparseShareInfoByteSize - sparseShareSequenceLenSize
+	continuationSparseShareContentSize = consts.ShareSize - consts.NamespaceSize - sparseShareInfoByteSize
+)
+
+// SharesForBlob returns the number of sparse shares, of share version 0, that
+// hold a blob of blobSize bytes.
+func SharesForBlob(blobSize int) int {
+	if blobSize <= 0 {
+		return 0
+	}
+	if blobSize <= firstSparseShareContentSize {
+		return 1
+	}
+	rest := blobSize - firstSparseShareContentSize
+	return 1 + (rest+continuationSparseShareContentSize-1)/continuationSparseShareContentSize
+}
+
+// SharesForBlobOfVersion returns the number of sparse shares that hold a blob
+// of blobSize bytes of the given share version, whose first share may hold
+// more than the data of the blob, like the signer of a blob of share version 1.
+// Unknown share versions are counted as share version 0.
+func SharesForBlobOfVersion(blobSize int, shareVersion uint8) int {
+	return SharesForBlob(blobSize + sparseShareVersions[shareVersion])
+}
+
+// ErrUnsupportedShareVersion is returned when reconstructing the data of
+// shares of a share version whose layout is unknown.
+var ErrUnsupportedShareVersion = errors.New("unsupported share version")
+
+// sparseShareVersions maps the supported share versions to the size of what
+// the first share of a blob holds between the sequence length and the data.
+var sparseShareVersions = map[uint8]int{
+	0: 0,
+	1: sparseShareVersionSignerSize,
+}
+
+// ReconstructData returns the data of the blob held by the sparse shares in
+// Data, which must be all the shares of the blob, in order. The layout of the
+// shares, and so the padding stripped from the last share, is that of the
+// share version found in their info byte. It returns an error wrapping
+// ErrUnsupportedShareVersion if the version is unknown. The signer of a blob
+// of share version 1 is not part of the data. It does not verify the proof.
+func (sp ShareProof) ReconstructData() ([]byte, error) {
+	if len(sp.Data) == 0 {
+		return nil, errors.New("the proof contains no shares")
+	}
+
+	var (
+		data      []byte
+		seqLen    int
+		version   uint8
+		lastStart int // the index in data of the data of the last share
+	)
+	for i, share := range sp.Data {
+		if len(share) < sparseShareMinSizeWithoutData {
+			return nil, fmt.Errorf("share %d is too short: %d bytes", i, len(share))
+		}
+		info := share[consts.NamespaceSize]
+		shareVersion, isStart := info>>1, info&sparseShareSequenceStartFlag != 0
+		offset := sparseShareMinSizeWithoutData
+
+		if i == 0 {
+			if !isStart {
+				return nil, errors.New("the first share does not start a blob")
+			}
+			prefixSize, ok := sparseShareVersions[shareVersion]
+			if !ok {
+				return nil, fmt.Errorf("%w: %d", ErrUnsupportedShareVersion, shareVersion)
+			}
+			if len(share) < offset+sparseShareSequenceLenSize+prefixSize {
+				return nil, fmt.Errorf("share %d is too short: %d bytes", i, len(share))
+			}
+			version = shareVersion
+			seqLen = int(binary.BigEndian.Uint32(share[offset : offset+sparseShareSequenceLenSize]))
+			offset += sparseShareSequenceLenSize + prefixSize
+		} else {
+			switch {
+			case isStart:
+				return nil, fmt.Errorf("share %d starts another blob", i)
+			case shareVersion != version:
+				return nil, fmt.Errorf("share %d is of share version %d, the first share of version %d",
+					i, shareVersion, version)
+			case !bytes.Equal(share[:consts.NamespaceSize], sp.Data[0][:consts.NamespaceSize]):
+				return nil, fmt.Errorf("share %d is of another namespace than the first share", i)
+			}
+		}
+		lastStart = len(data)
+		data = append(data, share[offset:]...)
+	}
+
+	switch {
+	case seqLen > len(data):
+		return nil, fmt.Errorf("the shares hold %d bytes of the blob of %d bytes", len(data), seqLen)
+	case len(sp.Data) > 1 && seqLen <= lastStart:
+		return nil, fmt.Errorf("share %d holds no data of the blob", len(sp.Data)-1)
+	}
+	for _, b := range data[seqLen:] {
+		if b != 0 {
+			return nil, errors.New("the padding of the last share is not zero")
+		}
+	}
+	return data[:seqLen], nil
+}
@@ -0,0 +1,137 @@
+package types
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/pkg/consts"
+)
+
+func TestSharesForBlob(t *testing.T) {
+	// the first share holds 478 bytes of blob data, and the following ones 482
+	tests := []struct {
+		blobSize int
+		want     int
+	}{
+		{0, 0},
+		{1, 1},
+		{478, 1}, // exactly fills the first share
+		{479, 2},
+		{960, 2}, // exactly fills two shares
+		{961, 3},
+		{1442, 3},
+		{1443, 4},
+		{100_000, 208},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, SharesForBlob(tt.blobSize), tt.blobSize)
+	}
+}
+
+func TestSharesForBlobOfVersion(t *testing.T) {
+	// the signer of a blob of share version 1 takes 20 bytes of its first share
+	assert.Equal(t, 1, SharesForBlobOfVersion(478, 0))
+	assert.Equal(t, 2, SharesForBlobOfVersion(478, 1))
+	assert.Equal(t, 1, SharesForBlobOfVersion(458, 1))
+	assert.Equal(t, 2, SharesForBlobOfVersion(459, 1))
+}
+
+func TestShareProofReconstructData(t *testing.T) {
+	signer := bytes.Repeat([]byte{0xaa}, sparseShareVersionSignerSize)
+	tests := []struct {
+		name       string
+		version    uint8
+		blobSize   int
+		wantShares int
+	}{
+		// the first share of version 0 holds 478 bytes, and of version 1 458
+		{"v0 one byte", 0, 1, 1},
+		{"v0 full first share", 0, 478, 1},
+		{"v0 two shares", 0, 479, 2},
+		{"v0 padded last share", 0, 1000, 3},
+		{"v1 one byte", 1, 1, 1},
+		{"v1 full first share", 1, 458, 1},
+		{"v1 two shares", 1, 459, 2},
+		{"v1 padded last share", 1, 1000, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			blob := bytes.Repeat([]byte{7}, tt.blobSize)
+			shares := sparseShares(t, tt.version, signer, blob)
+			require.Len(t, shares, tt.wantShares)
+
+			data, err := ShareProof{Data: shares}.ReconstructData()
+			require.NoError(t, err)
+			assert.Equal(t, blob, data)
+		})
+	}
+
+	// the layouts of the first share of each version, byte by byte
+	v0 := sparseShares(t, 0, nil, []byte("blob"))[0]
+	assert.Equal(t, []byte{1, 0, 0, 0, 4, 'b', 'l', 'o', 'b', 0}, v0[consts.NamespaceSize:consts.NamespaceSize+10])
+	v1 := sparseShares(t, 1, signer, []byte("blob"))[0]
+	assert.Equal(t, []byte{3, 0, 0, 0, 4}, v1[consts.NamespaceSize:consts.NamespaceSize+5])
+	assert.Equal(t, signer, v1[consts.NamespaceSize+5:consts.NamespaceSize+25])
+	assert.Equal(t, []byte{'b', 'l', 'o', 'b', 0}, v1[consts.NamespaceSize+25:consts.NamespaceSize+30])
+
+	shares := sparseShares(t, 0, nil, bytes.Repeat([]byte{7}, 1000))
+	_, err := ShareProof{Data: sparseShares(t, 2, nil, []byte("blob"))}.ReconstructData()
+	assert.True(t, errors.Is(err, ErrUnsupportedShareVersion), err)
+
+	invalid := map[string][][]byte{
+		"no shares":          nil,
+		"too short":          {shares[0][:consts.NamespaceSize]},
+		"no first share":     shares[1:],
+		"missing last share": shares[:2],
+		"extra share":        append(append([][]byte{}, shares...), shares[2]),
+		"other version": {shares[0], func() []byte {
+			share := append([]byte(nil), shares[1]...)
+			share[consts.NamespaceSize] = 1 << 1
+			return share
+		}(), shares[2]},
+		"non zero padding": {shares[0], shares[1], func() []byte {
+			share := append([]byte(nil), shares[2]...)
+			share[len(share)-1] = 1
+			return share
+		}()},
+	}
+	for name, shares := range invalid {
+		_, err := ShareProof{Data: shares}.ReconstructData()
+		assert.Error(t, err, name)
+	}
+}
+
+// sparseShares splits the blob into 512 byte sparse shares of the given share
+// version, with the signer in the first share of version 1 and above.
+func sparseShares(tb testing.TB, version uint8, signer, blob []byte) [][]byte {
+	namespace := bytes.Repeat([]byte{1}, consts.NamespaceSize)
+
+	var shares [][]byte
+	for cursor := 0; cursor < len(blob); {
+		share := append([]byte{}, namespace...)
+		if len(shares) == 0 {
+			share = append(share, version<<1|sparseShareSequenceStartFlag)
+			share = binary.BigEndian.AppendUint32(share, uint32(len(blob)))
+			if version > 0 {
+				share = append(share, signer...)
+			}
+		} else {
+			share = append(share, version<<1)
+		}
+		end := cursor + consts.ShareSize - len(share)
+		if end > len(blob) {
+			end = len(blob)
+		}
+		share = append(share, blob[cursor:end]...)
+		share = append(share, make([]byte, consts.ShareSize-len(share))...)
+		require.Len(tb, share, consts.ShareSize)
+		shares = append(shares, share)
+		cursor = end
+	}
+	return shares
+}
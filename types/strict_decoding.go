@@ -0,0 +1,49 @@
+package types
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/gogo/protobuf/proto"
+)
+
+// ErrNonCanonicalEncoding is returned by the strict decoding helpers when the
+// bytes of a consensus message differ from its canonical encoding, which is
+// the case when they hold fields unknown to this version of the protocol.
+type ErrNonCanonicalEncoding struct {
+	Type      string
+	Size      int
+	CanonSize int
+}
+
+func (e ErrNonCanonicalEncoding) Error() string {
+	return fmt.Sprintf("non-canonical encoding of %s: got %d bytes, expected %d (unknown fields?)",
+		e.Type, e.Size, e.CanonSize)
+}
+
+// CheckCanonicalEncoding returns an ErrNonCanonicalEncoding if re-marshalling
+// msg, decoded from bz, does not produce bz byte for byte. Since the generated
+// types drop the fields they do not know about, this catches unknown fields at
+// any nesting level without touching the generated code.
+func CheckCanonicalEncoding(msg proto.Message, bz []byte) error {
+	canon, err := proto.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(canon, bz) {
+		return ErrNonCanonicalEncoding{Type: proto.MessageName(msg), Size: len(bz), CanonSize: len(canon)}
+	}
+	return nil
+}
+
+// UnmarshalStrict decodes bz into msg like proto.Unmarshal, then rejects the
+// encodings CheckCanonicalEncoding rejects. It is meant for the consensus
+// critical types (Block, Header, Data, Commit, Vote, Proposal) when the
+// StrictDecoding consensus param is set; everything else keeps the tolerant
+// proto.Unmarshal.
+func UnmarshalStrict(bz []byte, msg proto.Message) error {
+	if err := proto.Unmarshal(bz, msg); err != nil {
+		return err
+	}
+	return CheckCanonicalEncoding(msg, bz)
+}
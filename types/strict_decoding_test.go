@@ -0,0 +1,100 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"github.com/tendermint/tendermint/crypto"
+	cmtrand "github.com/tendermint/tendermint/libs/rand"
+	cmtproto "github.com/tendermint/tendermint/proto/tendermint/types"
+)
+
+// withUnknownField returns a copy of bz with an unknown field appended to the
+// message found by following path, a list of length-delimited field numbers
+// starting from the top level message (the first occurrence is used).
+func withUnknownField(t *testing.T, bz []byte, path ...protowire.Number) []byte {
+	t.Helper()
+	if len(path) == 0 {
+		out := append([]byte{}, bz...)
+		out = protowire.AppendTag(out, 1000, protowire.VarintType)
+		return protowire.AppendVarint(out, 7)
+	}
+	var out []byte
+	found := false
+	for len(bz) > 0 {
+		num, typ, n := protowire.ConsumeTag(bz)
+		require.GreaterOrEqual(t, n, 0)
+		m := protowire.ConsumeFieldValue(num, typ, bz[n:])
+		require.GreaterOrEqual(t, m, 0)
+		if !found && num == path[0] && typ == protowire.BytesType {
+			inner, _ := protowire.ConsumeBytes(bz[n:])
+			out = protowire.AppendTag(out, num, typ)
+			out = protowire.AppendBytes(out, withUnknownField(t, inner, path[1:]...))
+			found = true
+		} else {
+			out = append(out, bz[:n+m]...)
+		}
+		bz = bz[n+m:]
+	}
+	require.True(t, found, "field %d not found", path[0])
+	return out
+}
+
+func TestUnmarshalStrict(t *testing.T) {
+	block := MakeBlock(3, makeData([]Tx{Tx("foo")}), randCommit(time.Now()), nil)
+	block.ProposerAddress = cmtrand.Bytes(crypto.AddressSize)
+	pbb, err := block.ToProto()
+	require.NoError(t, err)
+	blockBz, err := proto.Marshal(pbb)
+	require.NoError(t, err)
+
+	voteBz, err := proto.Marshal(examplePrevote().ToProto())
+	require.NoError(t, err)
+
+	proposal := NewProposal(3, 1, -1, makeBlockIDRandom())
+	proposal.Signature = []byte("signature")
+	proposalBz, err := proto.Marshal(proposal.ToProto())
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name string
+		msg  func() proto.Message
+		bz   []byte
+		path []protowire.Number
+	}{
+		{"block", func() proto.Message { return new(cmtproto.Block) }, blockBz, nil},
+		{"block header", func() proto.Message { return new(cmtproto.Block) }, blockBz, []protowire.Number{1}},
+		{"block header version", func() proto.Message { return new(cmtproto.Block) }, blockBz, []protowire.Number{1, 1}},
+		{"block header last block id parts", func() proto.Message { return new(cmtproto.Block) }, blockBz,
+			[]protowire.Number{1, 5, 2}},
+		{"block data", func() proto.Message { return new(cmtproto.Block) }, blockBz, []protowire.Number{2}},
+		{"block last commit", func() proto.Message { return new(cmtproto.Block) }, blockBz, []protowire.Number{4}},
+		{"block last commit sig", func() proto.Message { return new(cmtproto.Block) }, blockBz, []protowire.Number{4, 4}},
+		{"vote", func() proto.Message { return new(cmtproto.Vote) }, voteBz, nil},
+		{"vote block id", func() proto.Message { return new(cmtproto.Vote) }, voteBz, []protowire.Number{4}},
+		{"proposal", func() proto.Message { return new(cmtproto.Proposal) }, proposalBz, nil},
+		{"proposal block id parts", func() proto.Message { return new(cmtproto.Proposal) }, proposalBz,
+			[]protowire.Number{5, 2}},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			require.NoError(t, UnmarshalStrict(tc.bz, tc.msg()))
+
+			bz := withUnknownField(t, tc.bz, tc.path...)
+			// the tolerant decoding drops the unknown field...
+			require.NoError(t, proto.Unmarshal(bz, tc.msg()))
+			// ...while the strict one rejects it
+			err := UnmarshalStrict(bz, tc.msg())
+			var errNonCanon ErrNonCanonicalEncoding
+			require.ErrorAs(t, err, &errNonCanon)
+			assert.Equal(t, len(bz), errNonCanon.Size)
+			assert.Equal(t, len(tc.bz), errNonCanon.CanonSize)
+		})
+	}
+}
@@ -104,7 +104,8 @@ func (txs Txs) IndexByHash(hash []byte) int {
 	return -1
 }
 
-// ToSliceOfBytes converts a Txs to slice of byte slices.
+// ToSliceOfBytes converts a Txs to slice of byte slices. The byte slices share
+// the bytes of the txs, which must not be mutated.
 //
 // NOTE: This method should become obsolete once Txs is switched to [][]byte.
 // ref: #2603 https://github.com/tendermint/tendermint/issues/2603
@@ -116,7 +117,8 @@ func (txs Txs) ToSliceOfBytes() [][]byte {
 	return txBzs
 }
 
-// ToTxs converts a raw slice of byte slices into a Txs type.
+// ToTxs converts a raw slice of byte slices into a Txs type. The txs share the
+// bytes of the byte slices, which must not be mutated.
 func ToTxs(txs [][]byte) Txs {
 	txBzs := make(Txs, len(txs))
 	for i := 0; i < len(txs); i++ {
@@ -202,6 +204,60 @@ func TxProofFromProto(pb cmtproto.TxProof) (TxProof, error) {
 	return pbtp, nil
 }
 
+// AbsenceProof returns a proof that the transaction with the given hash, see
+// Tx.Hash, is not in txs. It returns an error if it is.
+func (txs Txs) AbsenceProof(hash []byte) (TxAbsenceProof, error) {
+	leaves := make([][]byte, len(txs))
+	txHashes := make([]cmtbytes.HexBytes, len(txs))
+	for i := range txs {
+		leaves[i] = txs[i].Hash()
+		if bytes.Equal(leaves[i], hash) {
+			return TxAbsenceProof{}, fmt.Errorf("tx %X is at index %d", hash, i)
+		}
+		txHashes[i] = leaves[i]
+	}
+	return TxAbsenceProof{
+		RootHash: merkle.HashFromByteSlices(leaves),
+		TxHash:   hash,
+		TxHashes: txHashes,
+	}, nil
+}
+
+// TxAbsenceProof proves that a transaction is not in a block by committing to
+// the hashes of all the transactions of the block, i.e. to all the leaves of
+// the Merkle tree whose root is the data hash. It can only be validated against
+// a data hash computed by Txs.Hash, not against an application defined data
+// root.
+type TxAbsenceProof struct {
+	RootHash cmtbytes.HexBytes   `json:"root_hash"`
+	TxHash   cmtbytes.HexBytes   `json:"tx_hash"`
+	TxHashes []cmtbytes.HexBytes `json:"tx_hashes"`
+}
+
+// Validate verifies the proof. It returns nil if the RootHash matches the
+// dataHash argument, if the transaction hashes are the leaves of the Merkle
+// tree with RootHash as root, and if TxHash is not one of them. Otherwise, it
+// returns a sensible error.
+func (tap TxAbsenceProof) Validate(dataHash []byte) error {
+	if !bytes.Equal(dataHash, tap.RootHash) {
+		return errors.New("proof matches different data hash")
+	}
+	if len(tap.TxHash) != tmhash.Size {
+		return fmt.Errorf("expected tx hash size %d, got %d", tmhash.Size, len(tap.TxHash))
+	}
+	leaves := make([][]byte, len(tap.TxHashes))
+	for i, txHash := range tap.TxHashes {
+		if bytes.Equal(txHash, tap.TxHash) {
+			return fmt.Errorf("tx %X is at index %d", tap.TxHash, i)
+		}
+		leaves[i] = txHash
+	}
+	if !bytes.Equal(merkle.HashFromByteSlices(leaves), tap.RootHash) {
+		return errors.New("proof is not internally consistent")
+	}
+	return nil
+}
+
 // ComputeProtoSizeForTxs wraps the transactions in cmtproto.Data{} and calculates the size.
 // https://developers.google.com/protocol-buffers/docs/encoding
 func ComputeProtoSizeForTxs(txs []Tx) int64 {
@@ -210,6 +266,13 @@ func ComputeProtoSizeForTxs(txs []Tx) int64 {
 	return int64(pdData.Size())
 }
 
+// ComputeProtoSizeForTx returns the size of the transaction in cmtproto.Data{},
+// i.e. ComputeProtoSizeForTxs([]Tx{tx}), without allocating.
+func ComputeProtoSizeForTx(tx Tx) int64 {
+	// the field tag of txs and the length prefix
+	return 1 + int64(proto.SizeVarint(uint64(len(tx)))) + int64(len(tx))
+}
+
 // UnmarshalIndexWrapper attempts to unmarshal the provided transaction into an
 // IndexWrapper transaction. It returns true if the provided transaction is an
 // IndexWrapper transaction. An IndexWrapper transaction is a transaction that contains
@@ -0,0 +1,57 @@
+package types
+
+import (
+	"fmt"
+	"hash/crc32"
+)
+
+// TxGuard is a checksum of the bytes of a transaction, to detect their
+// mutation. The transactions are shared by reference from the mempool to the
+// proposed block, so nothing may mutate them: the guards check it in the
+// builds with the txdebug build tag, and are no-ops otherwise.
+type TxGuard uint32
+
+// GuardTx returns the guard of the transaction, zero unless TxGuardsEnabled.
+func GuardTx(tx Tx) TxGuard {
+	if !TxGuardsEnabled {
+		return 0
+	}
+	return TxGuard(crc32.ChecksumIEEE(tx))
+}
+
+// Check panics if the transaction was mutated since its guard was taken.
+func (g TxGuard) Check(tx Tx) {
+	if !TxGuardsEnabled {
+		return
+	}
+	if sum := TxGuard(crc32.ChecksumIEEE(tx)); sum != g {
+		panic(fmt.Sprintf("tx %X was mutated while shared by reference: checksum %08x, expected %08x",
+			tx.Hash(), uint32(sum), uint32(g)))
+	}
+}
+
+// GuardTxs returns the guards of the transactions, nil unless TxGuardsEnabled.
+func GuardTxs(txs Txs) []TxGuard {
+	if !TxGuardsEnabled {
+		return nil
+	}
+	guards := make([]TxGuard, len(txs))
+	for i, tx := range txs {
+		guards[i] = GuardTx(tx)
+	}
+	return guards
+}
+
+// CheckTxGuards panics if any of the transactions was mutated since their
+// guards were taken by GuardTxs.
+func CheckTxGuards(txs Txs, guards []TxGuard) {
+	if !TxGuardsEnabled {
+		return
+	}
+	if len(txs) != len(guards) {
+		panic(fmt.Sprintf("%d txs guarded, got %d", len(guards), len(txs)))
+	}
+	for i, tx := range txs {
+		guards[i].Check(tx)
+	}
+}
@@ -0,0 +1,8 @@
+//go:build !txdebug
+// +build !txdebug
+
+package types
+
+// TxGuardsEnabled is true in the builds with the txdebug build tag, in which
+// the TxGuards check that the transactions are never mutated.
+const TxGuardsEnabled = false
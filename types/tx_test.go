@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	cmtbytes "github.com/tendermint/tendermint/libs/bytes"
 	cmtrand "github.com/tendermint/tendermint/libs/rand"
 	"github.com/tendermint/tendermint/pkg/consts"
 	cmtproto "github.com/tendermint/tendermint/proto/tendermint/types"
@@ -46,6 +47,40 @@ func TestTxIndexByHash(t *testing.T) {
 	}
 }
 
+func TestTxAbsenceProof(t *testing.T) {
+	txs := makeTxs(15, 60)
+	absent := Tx("absent").Hash()
+
+	proof, err := txs.AbsenceProof(absent)
+	require.NoError(t, err)
+	assert.NoError(t, proof.Validate(txs.Hash()))
+	assert.Error(t, proof.Validate(makeTxs(15, 60).Hash()))
+
+	// an empty block proves the absence of every tx
+	empty, err := Txs{}.AbsenceProof(absent)
+	require.NoError(t, err)
+	assert.NoError(t, empty.Validate(Txs{}.Hash()))
+
+	// the absence of a tx of the block cannot be proven
+	_, err = txs.AbsenceProof(txs[3].Hash())
+	assert.Error(t, err)
+
+	// omitting the tx from the hashes makes the proof inconsistent
+	omitted := proof
+	omitted.TxHash = txs[3].Hash()
+	omitted.TxHashes = append(append([]cmtbytes.HexBytes{}, proof.TxHashes[:3]...), proof.TxHashes[4:]...)
+	assert.Error(t, omitted.Validate(txs.Hash()))
+
+	// claiming the absence of a tx of the block is refused
+	present := proof
+	present.TxHash = txs[3].Hash()
+	assert.Error(t, present.Validate(txs.Hash()))
+
+	invalidHash := proof
+	invalidHash.TxHash = []byte{1}
+	assert.Error(t, invalidHash.Validate(txs.Hash()))
+}
+
 func TestUnmarshalIndexWrapper(t *testing.T) {
 	// perform a simple test for being unable to decode a non
 	// IndexWrapper transaction
@@ -120,3 +155,27 @@ func TestTxKeyFromBytes(t *testing.T) {
 	_, err = TxKeyFromBytes([]byte("foo"))
 	require.Error(t, err)
 }
+
+func TestComputeProtoSizeForTx(t *testing.T) {
+	for _, size := range []int{0, 1, 127, 128, 16383, 16384, 1 << 21} {
+		tx := Tx(make([]byte, size))
+		assert.Equal(t, ComputeProtoSizeForTxs([]Tx{tx}), ComputeProtoSizeForTx(tx), "size %d", size)
+	}
+	assert.Zero(t, testing.AllocsPerRun(10, func() { ComputeProtoSizeForTx(Tx("hello")) }))
+}
+
+func TestTxGuard(t *testing.T) {
+	txs := makeTxs(3, 10)
+	guards := GuardTxs(txs)
+	require.NotPanics(t, func() { CheckTxGuards(txs, guards) })
+
+	txs[1][0]++
+	if !TxGuardsEnabled {
+		require.Nil(t, guards)
+		require.NotPanics(t, func() { CheckTxGuards(txs, guards) })
+		return
+	}
+	require.Panics(t, func() { CheckTxGuards(txs, guards) })
+	require.Panics(t, func() { guards[1].Check(txs[1]) })
+	require.NotPanics(t, func() { guards[0].Check(txs[0]) })
+}
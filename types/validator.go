@@ -60,6 +60,35 @@ func (v *Validator) Copy() *Validator {
 	return &vCopy
 }
 
+// deepCopy is like Copy, but copies the address and the public key too.
+// Panics if the validator is nil or its public key of an unknown type.
+func (v *Validator) deepCopy() *Validator {
+	vCopy := *v
+	vCopy.Address = append(Address(nil), v.Address...)
+	if v.PubKey != nil {
+		pk, err := ce.PubKeyToProto(v.PubKey)
+		if err != nil {
+			panic(err)
+		}
+		vCopy.PubKey, err = ce.PubKeyFromProto(pk)
+		if err != nil {
+			panic(err)
+		}
+	}
+	return &vCopy
+}
+
+// equal returns true if both validators have the same address, voting power
+// and proposer priority, or are both nil.
+func (v *Validator) equal(other *Validator) bool {
+	if v == nil || other == nil {
+		return v == other
+	}
+	return bytes.Equal(v.Address, other.Address) &&
+		v.VotingPower == other.VotingPower &&
+		v.ProposerPriority == other.ProposerPriority
+}
+
 // Returns the one with higher ProposerPriority.
 func (v *Validator) CompareProposerPriority(other *Validator) *Validator {
 	if v == nil {
@@ -0,0 +1,63 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/tendermint/tendermint/libs/protoio"
+	cmtproto "github.com/tendermint/tendermint/proto/tendermint/types"
+)
+
+var (
+	ErrAnnouncementInvalidChainID   = errors.New("validator announcement for another chain")
+	ErrAnnouncementUnknownValidator = errors.New("validator announcement of an address not in the validator set")
+	ErrAnnouncementInvalidSignature = errors.New("invalid validator announcement signature")
+)
+
+// ValidatorAnnouncementSigner is implemented by the PrivValidators able to sign
+// the announcement of the node run by their validator. Announcing is optional,
+// the validators whose PrivValidator does not implement it do not announce.
+type ValidatorAnnouncementSigner interface {
+	SignValidatorAnnouncement(chainID string, announcement *cmtproto.ValidatorAnnouncement) error
+}
+
+// ValidatorAnnouncementSignBytes returns the bytes signed by a validator to
+// announce the node it runs on the chain. Unlike the sign bytes of votes and
+// proposals, they do not start with a message type, so that neither can be
+// mistaken for an announcement.
+func ValidatorAnnouncementSignBytes(chainID string, announcement *cmtproto.ValidatorAnnouncement) []byte {
+	pb := cmtproto.ValidatorAnnouncement{
+		ValidatorAddress: announcement.ValidatorAddress,
+		NodeId:           announcement.NodeId,
+		ChainID:          chainID,
+	}
+	bz, err := protoio.MarshalDelimited(&pb)
+	if err != nil {
+		panic(err)
+	}
+
+	return bz
+}
+
+// VerifyValidatorAnnouncement checks that the announcement is signed for the
+// chain by the validator of the set it names.
+func VerifyValidatorAnnouncement(
+	chainID string,
+	vals *ValidatorSet,
+	announcement *cmtproto.ValidatorAnnouncement,
+) error {
+	if announcement.ChainID != chainID {
+		return fmt.Errorf("%w: got %q, expected %q", ErrAnnouncementInvalidChainID, announcement.ChainID, chainID)
+	}
+	if len(announcement.Signature) > MaxSignatureSize {
+		return fmt.Errorf("signature is too big (max: %d)", MaxSignatureSize)
+	}
+	_, val := vals.GetByAddress(announcement.ValidatorAddress)
+	if val == nil {
+		return fmt.Errorf("%w: %X", ErrAnnouncementUnknownValidator, announcement.ValidatorAddress)
+	}
+	if !val.PubKey.VerifySignature(ValidatorAnnouncementSignBytes(chainID, announcement), announcement.Signature) {
+		return ErrAnnouncementInvalidSignature
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cmtproto "github.com/tendermint/tendermint/proto/tendermint/types"
+)
+
+func TestVerifyValidatorAnnouncement(t *testing.T) {
+	const chainID = "test_chain_id"
+	vals, privVals := RandValidatorSet(2, 10)
+	_, outsider := RandValidator(false, 10)
+
+	announce := func(t *testing.T, pv PrivValidator, chainID string) *cmtproto.ValidatorAnnouncement {
+		pubKey, err := pv.GetPubKey()
+		require.NoError(t, err)
+		a := &cmtproto.ValidatorAnnouncement{
+			ValidatorAddress: pubKey.Address(),
+			NodeId:           "0123456789abcdef0123456789abcdef01234567",
+			ChainID:          chainID,
+		}
+		require.NoError(t, pv.(ValidatorAnnouncementSigner).SignValidatorAnnouncement(chainID, a))
+		return a
+	}
+
+	assert.NoError(t, VerifyValidatorAnnouncement(chainID, vals, announce(t, privVals[0], chainID)))
+
+	a := announce(t, privVals[0], "other_chain_id")
+	assert.ErrorIs(t, VerifyValidatorAnnouncement(chainID, vals, a), ErrAnnouncementInvalidChainID)
+	a.ChainID = chainID
+	assert.ErrorIs(t, VerifyValidatorAnnouncement(chainID, vals, a), ErrAnnouncementInvalidSignature)
+
+	a = announce(t, outsider, chainID)
+	assert.ErrorIs(t, VerifyValidatorAnnouncement(chainID, vals, a), ErrAnnouncementUnknownValidator)
+
+	// the announcement can not be moved to another node
+	a = announce(t, privVals[1], chainID)
+	a.NodeId = "fedcba9876543210fedcba9876543210fedcba98"
+	assert.ErrorIs(t, VerifyValidatorAnnouncement(chainID, vals, a), ErrAnnouncementInvalidSignature)
+
+	// nor signed by another validator
+	a = announce(t, privVals[1], chainID)
+	a.ValidatorAddress = announce(t, privVals[0], chainID).ValidatorAddress
+	assert.ErrorIs(t, VerifyValidatorAnnouncement(chainID, vals, a), ErrAnnouncementInvalidSignature)
+}
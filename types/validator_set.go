@@ -245,15 +245,72 @@ func validatorListCopy(valsList []*Validator) []*Validator {
 	return valsCopy
 }
 
-// Copy each validator into a new ValidatorSet.
+// Copy each validator into a new ValidatorSet. The proposer is copied too, so
+// that mutating the priorities of either set, e.g. with
+// IncrementProposerPriority, is not observed by the other. The validators of
+// both sets share their addresses and public keys, which must not be mutated,
+// see DeepCopy.
 func (vals *ValidatorSet) Copy() *ValidatorSet {
+	valsCopy := validatorListCopy(vals.Validators)
 	return &ValidatorSet{
-		Validators:       validatorListCopy(vals.Validators),
-		Proposer:         vals.Proposer,
+		Validators:       valsCopy,
+		Proposer:         vals.copyProposer(valsCopy, (*Validator).Copy),
 		totalVotingPower: vals.totalVotingPower,
 	}
 }
 
+// DeepCopy is like Copy, but the returned set shares no memory with vals: the
+// addresses and public keys of the validators are copied too.
+func (vals *ValidatorSet) DeepCopy() *ValidatorSet {
+	var valsCopy []*Validator
+	if vals.Validators != nil {
+		valsCopy = make([]*Validator, len(vals.Validators))
+		for i, val := range vals.Validators {
+			valsCopy[i] = val.deepCopy()
+		}
+	}
+	return &ValidatorSet{
+		Validators:       valsCopy,
+		Proposer:         vals.copyProposer(valsCopy, (*Validator).deepCopy),
+		totalVotingPower: vals.totalVotingPower,
+	}
+}
+
+// copyProposer returns the proposer of the copy valsCopy of the validators of
+// vals: the validator of valsCopy at the index of the proposer if it is one of
+// the validators of vals, as it is after IncrementProposerPriority, or a copy
+// of the proposer made with copyVal otherwise.
+func (vals *ValidatorSet) copyProposer(valsCopy []*Validator, copyVal func(*Validator) *Validator) *Validator {
+	if vals.Proposer == nil {
+		return nil
+	}
+	for i, val := range vals.Validators {
+		if val == vals.Proposer {
+			return valsCopy[i]
+		}
+	}
+	return copyVal(vals.Proposer)
+}
+
+// Equal returns true if both sets hold the same validators, in the same order,
+// with the same addresses, voting powers and proposer priorities, and have the
+// same proposer. Unlike comparing their hashes, it compares the priorities,
+// which decide the next proposers.
+func (vals *ValidatorSet) Equal(other *ValidatorSet) bool {
+	if vals == nil || other == nil {
+		return vals == other
+	}
+	if len(vals.Validators) != len(other.Validators) {
+		return false
+	}
+	for i, val := range vals.Validators {
+		if !val.equal(other.Validators[i]) {
+			return false
+		}
+	}
+	return vals.Proposer.equal(other.Proposer)
+}
+
 // HasAddress returns true if address given is in the validator set, false -
 // otherwise.
 func (vals *ValidatorSet) HasAddress(address []byte) bool {
@@ -151,6 +151,77 @@ func TestCopy(t *testing.T) {
 	}
 }
 
+// TestCopyProposerAliasing is a regression test for the copies of a validator
+// set sharing its proposer, whose priority then changed when the original set
+// was incremented.
+func TestCopyProposerAliasing(t *testing.T) {
+	vset := randValidatorSet(10)
+	for _, copyFn := range []func(*ValidatorSet) *ValidatorSet{(*ValidatorSet).Copy, (*ValidatorSet).DeepCopy} {
+		vsetCopy := copyFn(vset)
+		snapshot := vset.DeepCopy()
+		require.True(t, vsetCopy.Equal(snapshot))
+
+		vset.IncrementProposerPriority(3)
+		assert.True(t, vsetCopy.Equal(snapshot))
+		assert.False(t, vsetCopy.Equal(vset))
+		// the proposer of the copy is still one of its validators
+		_, proposer := vsetCopy.GetByAddress(vsetCopy.Proposer.Address)
+		require.NotNil(t, proposer)
+		assert.Equal(t, proposer.ProposerPriority, vsetCopy.Proposer.ProposerPriority)
+		vsetCopy.IncrementProposerPriority(3)
+		assert.True(t, vsetCopy.Equal(vset))
+	}
+}
+
+func TestDeepCopy(t *testing.T) {
+	vset := randValidatorSet(10)
+	vsetCopy := vset.DeepCopy()
+	assert.True(t, vsetCopy.Equal(vset))
+	assert.Equal(t, vset.Hash(), vsetCopy.Hash())
+	assert.Equal(t, vset.TotalVotingPower(), vsetCopy.TotalVotingPower())
+
+	// the addresses and public keys are not shared either
+	for i, val := range vset.Validators {
+		val.Address[0] ^= 0xff
+		val.PubKey.Bytes()[0] ^= 0xff
+		assert.NotEqual(t, val.Address, vsetCopy.Validators[i].Address)
+		assert.False(t, val.PubKey.Equals(vsetCopy.Validators[i].PubKey))
+	}
+
+	empty := NewValidatorSet(nil)
+	assert.True(t, empty.DeepCopy().Equal(empty))
+}
+
+func TestValidatorSetEqual(t *testing.T) {
+	vset := randValidatorSet(5)
+	assert.True(t, vset.Equal(vset.Copy()))
+	assert.True(t, (*ValidatorSet)(nil).Equal(nil))
+	assert.False(t, vset.Equal(nil))
+	assert.False(t, (*ValidatorSet)(nil).Equal(vset))
+
+	for name, mutate := range map[string]func(vals *ValidatorSet){
+		"address":  func(vals *ValidatorSet) { vals.Validators[1].Address = randPubKey().Address() },
+		"power":    func(vals *ValidatorSet) { vals.Validators[1].VotingPower++ },
+		"priority": func(vals *ValidatorSet) { vals.Validators[1].ProposerPriority++ },
+		"order": func(vals *ValidatorSet) {
+			vals.Validators[0], vals.Validators[1] = vals.Validators[1], vals.Validators[0]
+		},
+		"size": func(vals *ValidatorSet) { vals.Validators = vals.Validators[1:] },
+		"proposer": func(vals *ValidatorSet) {
+			for _, val := range vals.Validators {
+				if !bytes.Equal(val.Address, vals.Proposer.Address) {
+					vals.Proposer = val
+					return
+				}
+			}
+		},
+	} {
+		vsetCopy := vset.DeepCopy()
+		mutate(vsetCopy)
+		assert.False(t, vset.Equal(vsetCopy), name)
+	}
+}
+
 // Test that IncrementProposerPriority requires positive times.
 func TestIncrementProposerPriorityPositiveTimes(t *testing.T) {
 	vset := NewValidatorSet([]*Validator{